@@ -1,32 +1,40 @@
 import { Card, Title } from '@tremor/react';
 import { AreaChart, Area, XAxis, YAxis, CartesianGrid, Tooltip, Legend, ResponsiveContainer } from 'recharts';
-import { PerformanceDataPoint } from '../../types/api';
+import { PerformanceSeries } from '../../types/api';
 
 interface PerformanceChartProps {
-  data?: PerformanceDataPoint[];
+  series?: PerformanceSeries[];
   loading?: boolean;
 }
 
 /**
  * PerformanceChart Component
- * Line/Area chart showing Sharpe ratio trends over time for optimizations
+ * Area chart showing Sharpe ratio trends over time, one area per optimization
  * Uses Recharts for detailed, interactive visualizations
  */
-export const PerformanceChart: React.FC<PerformanceChartProps> = ({ data = [], loading }) => {
-  // Transform data for Recharts format
-  const chartData = data.map((point) => ({
-    timestamp: new Date(point.timestamp).toLocaleTimeString('en-US', {
-      month: 'short',
-      day: 'numeric',
-      hour: '2-digit',
-      minute: '2-digit',
-    }),
-    sharpe: point.sharpe_ratio,
-    name: point.optimization_name,
-  }));
+export const PerformanceChart: React.FC<PerformanceChartProps> = ({ series = [], loading }) => {
+  // Transform series into Recharts rows: one row per bucket, one key per series
+  const rows = new Map<string, Record<string, string | number>>();
+  series.forEach((s, index) => {
+    s.points.forEach((point) => {
+      const row = rows.get(point.timestamp) ?? {
+        timestamp: new Date(point.timestamp).toLocaleTimeString('en-US', {
+          month: 'short',
+          day: 'numeric',
+          hour: '2-digit',
+          minute: '2-digit',
+        }),
+      };
+      row[`series${index}`] = point.value;
+      rows.set(point.timestamp, row);
+    });
+  });
+  const chartData = Array.from(rows.keys())
+    .sort()
+    .map((timestamp) => rows.get(timestamp)!);
 
-  // Group data by optimization for multi-line support
-  const optimizationIds = Array.from(new Set(data.map((d) => d.optimization_id)));
+  const points = series.flatMap((s) => s.points);
+  const iterations = points.reduce((acc, curr) => acc + curr.iterations, 0);
 
   // Generate colors for different optimizations
   const colors = ['#3b82f6', '#10b981', '#f59e0b', '#ef4444', '#8b5cf6', '#ec4899'];
@@ -42,7 +50,7 @@ export const PerformanceChart: React.FC<PerformanceChartProps> = ({ data = [], l
     );
   }
 
-  if (data.length === 0) {
+  if (points.length === 0) {
     return (
       <Card>
         <Title>Performance Overview (Last 24h)</Title>
@@ -108,8 +116,8 @@ export const PerformanceChart: React.FC<PerformanceChartProps> = ({ data = [], l
             margin={{ top: 10, right: 30, left: 0, bottom: 0 }}
           >
             <defs>
-              {optimizationIds.map((id, index) => (
-                <linearGradient key={id} id={`color${id}`} x1="0" y1="0" x2="0" y2="1">
+              {series.map((s, index) => (
+                <linearGradient key={index} id={`colorSeries${index}`} x1="0" y1="0" x2="0" y2="1">
                   <stop offset="5%" stopColor={colors[index % colors.length]} stopOpacity={0.8} />
                   <stop offset="95%" stopColor={colors[index % colors.length]} stopOpacity={0} />
                 </linearGradient>
@@ -130,15 +138,19 @@ export const PerformanceChart: React.FC<PerformanceChartProps> = ({ data = [], l
             />
             <Tooltip content={<CustomTooltip />} />
             <Legend wrapperStyle={{ fontSize: 12 }} />
-            <Area
-              type="monotone"
-              dataKey="sharpe"
-              stroke={colors[0]}
-              fillOpacity={1}
-              fill={`url(#color${optimizationIds[0] || 'default'})`}
-              name="Sharpe Ratio"
-              strokeWidth={2}
-            />
+            {series.map((s, index) => (
+              <Area
+                key={s.optimization_id ?? index}
+                type="monotone"
+                dataKey={`series${index}`}
+                stroke={colors[index % colors.length]}
+                fillOpacity={1}
+                fill={`url(#colorSeries${index})`}
+                name={s.optimization_name}
+                strokeWidth={2}
+                connectNulls
+              />
+            ))}
           </AreaChart>
         </ResponsiveContainer>
       </div>
@@ -154,18 +166,18 @@ export const PerformanceChart: React.FC<PerformanceChartProps> = ({ data = [], l
         <div>
           <p style={{ fontSize: 12, color: '#595959' }}>Avg Sharpe</p>
           <p style={{ fontSize: 18, fontWeight: 600, color: '#262626' }}>
-            {(data.reduce((acc, curr) => acc + curr.sharpe_ratio, 0) / data.length).toFixed(2)}
+            {(points.reduce((acc, curr) => acc + curr.value * curr.iterations, 0) / iterations).toFixed(2)}
           </p>
         </div>
         <div>
           <p style={{ fontSize: 12, color: '#595959' }}>Best Sharpe</p>
           <p style={{ fontSize: 18, fontWeight: 600, color: '#52c41a' }}>
-            {Math.max(...data.map((d) => d.sharpe_ratio)).toFixed(2)}
+            {Math.max(...points.map((p) => p.best)).toFixed(2)}
           </p>
         </div>
         <div>
-          <p style={{ fontSize: 12, color: '#595959' }}>Data Points</p>
-          <p style={{ fontSize: 18, fontWeight: 600, color: '#262626' }}>{data.length}</p>
+          <p style={{ fontSize: 12, color: '#595959' }}>Iterations</p>
+          <p style={{ fontSize: 18, fontWeight: 600, color: '#262626' }}>{iterations}</p>
         </div>
       </div>
     </Card>
@@ -8,7 +8,7 @@ import {
   QueueStats as QueueStatsType,
   OptimizationRun,
   Agent,
-  PerformanceDataPoint,
+  OptimizationPerformance,
 } from '../../types/api';
 
 const { Title } = Typography;
@@ -56,13 +56,15 @@ export const DashboardPage = () => {
     method: 'get',
   });
 
-  // Fetch performance data (last 24h)
-  const { data: performanceData, isLoading: performanceLoading } = useCustom<PerformanceDataPoint[]>({
+  // Fetch performance data (last 24h, hourly Sharpe per optimization)
+  const { data: performanceData, isLoading: performanceLoading } = useCustom<OptimizationPerformance>({
     url: '/optimizations/performance',
     method: 'get',
     config: {
       query: {
         period: '24h',
+        bucket: 'hourly',
+        metric: 'sharpe',
       },
     },
   });
@@ -182,7 +184,7 @@ export const DashboardPage = () => {
         </Row>
 
         {/* Performance Overview Chart */}
-        <PerformanceChart data={performanceData?.data} loading={performanceLoading} />
+        <PerformanceChart series={performanceData?.data?.series} loading={performanceLoading} />
       </Space>
     </div>
   );
@@ -502,16 +502,19 @@ export const getBacktestWithResult = async (
 };
 
 /**
- * Get strategy lineage
+ * Get strategy lineage: descendants (default), ancestors or both, optionally
+ * with each node's best metrics and their change from its parent
  */
 export const getStrategyLineage = async (
   strategyId: string,
-  depth: number = 10
+  depth: number = 10,
+  direction: "up" | "down" | "both" = "down",
+  includeMetrics: boolean = false
 ): Promise<any> => {
   const { data } = await dataProvider.custom!({
     url: `/strategies/${strategyId}/lineage`,
     method: "get",
-    query: { depth },
+    query: { depth, direction, include_metrics: includeMetrics },
   });
 
   return data;
@@ -30,11 +30,28 @@ export interface Agent {
   current_task?: string;
 }
 
-export interface PerformanceDataPoint {
-  timestamp: string;
-  sharpe_ratio: number;
-  optimization_id: string;
+export type PerformanceMetric = 'sharpe' | 'profit' | 'drawdown';
+
+export interface PerformancePoint {
+  timestamp: string; // Start of the bucket
+  value: number; // Mean over the bucket's iterations
+  best: number;
+  iterations: number;
+}
+
+export interface PerformanceSeries {
+  optimization_id?: string; // Absent when grouped across all runs
   optimization_name: string;
+  points: PerformancePoint[];
+}
+
+export interface OptimizationPerformance {
+  metric: PerformanceMetric;
+  bucket: 'none' | 'hourly' | 'daily';
+  start: string;
+  end: string;
+  timezone: string;
+  series: PerformanceSeries[];
 }
 
 export interface BacktestJob {
@@ -88,8 +105,10 @@ export interface ScoutSchedule {
   last_run_id?: string;
   last_run_at?: string;
   next_run_at?: string;
+  credential_secret_id?: string;
   created_at: string;
   updated_at: string;
+  timezone?: string;
 }
 
 export interface TriggerScoutPayload {
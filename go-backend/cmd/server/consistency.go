@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Modes of the -consistency flag.
+const (
+	consistencyModeCheck  = "check"
+	consistencyModeRepair = "repair"
+	consistencyModeDryRun = "dry-run"
+)
+
+// runConsistency checks or repairs cross-table invariants, writes the report
+// to stdout as JSON and returns the number of violations found.
+func runConsistency(ctx context.Context, cfg *config.Config, logger *zap.Logger, mode string) (int, error) {
+	if mode != consistencyModeCheck && mode != consistencyModeRepair && mode != consistencyModeDryRun {
+		return 0, fmt.Errorf("invalid consistency mode %q: must be check, repair or dry-run", mode)
+	}
+
+	pool, err := db.NewPool(ctx, &cfg.GoBackend.Database, logger)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	repo := repository.NewConsistencyRepository(pool)
+
+	var report *domain.ConsistencyReport
+	if mode == consistencyModeCheck {
+		report, err = repo.Check(ctx, domain.ConsistencyChecks, time.Now())
+	} else {
+		report, err = repo.Repair(ctx, domain.ConsistencyChecks, mode == consistencyModeDryRun, time.Now())
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return 0, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	logger.Info("Consistency check finished",
+		zap.String("mode", mode),
+		zap.Int("violations", report.Total()),
+	)
+	return report.Total(), nil
+}
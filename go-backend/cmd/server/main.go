@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
@@ -12,18 +13,29 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Zone database for images without /usr/share/zoneinfo
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/saltfish/freqsearch/go-backend/internal/api/grpc"
 	httpapi "github.com/saltfish/freqsearch/go-backend/internal/api/http"
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
 	"github.com/saltfish/freqsearch/go-backend/internal/config"
 	"github.com/saltfish/freqsearch/go-backend/internal/db"
 	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
 	"github.com/saltfish/freqsearch/go-backend/internal/docker"
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 	"github.com/saltfish/freqsearch/go-backend/internal/events"
+	"github.com/saltfish/freqsearch/go-backend/internal/insights"
+	"github.com/saltfish/freqsearch/go-backend/internal/marketdata"
+	"github.com/saltfish/freqsearch/go-backend/internal/parser"
+	"github.com/saltfish/freqsearch/go-backend/internal/pricing"
+	"github.com/saltfish/freqsearch/go-backend/internal/ranking"
+	"github.com/saltfish/freqsearch/go-backend/internal/redact"
 	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
 )
 
 // Build-time variables (set via ldflags)
@@ -35,6 +47,12 @@ var (
 func main() {
 	// Parse command line flags
 	configPath := flag.String("config", "", "Path to configuration file (YAML)")
+	consistencyMode := flag.String("consistency", "",
+		"Check cross-table invariants and exit instead of serving: check, repair or dry-run")
+	migrateMode := flag.String("migrate", "",
+		"Migrate the database schema and exit instead of serving: up, down, status or baseline")
+	migrateTo := flag.Int("migrate-to", -1,
+		"Version -migrate down rolls back to (default: the latest migration only) or baseline records up to")
 	flag.Parse()
 
 	fmt.Println("FreqSearch Backend", *configPath)
@@ -45,6 +63,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Use the server timezone for API output and cron schedules without CRON_TZ.
+	// Set before anything else runs so no goroutine reads time.Local meanwhile.
+	time.Local = cfg.GoBackend.Location()
+
+	// Page size limits apply to every list query, wherever it is built.
+	domain.SetPageLimits(pageLimits(&cfg.GoBackend.Pagination))
+
 	// Initialize logger
 	logger, err := initLogger(cfg)
 	if err != nil {
@@ -58,6 +83,7 @@ func main() {
 		zap.String("build_time", BuildTime),
 		zap.String("environment", cfg.Env),
 		zap.String("log_level", cfg.Logging.Level),
+		zap.String("timezone", time.Local.String()),
 	)
 
 	// Create root context
@@ -74,6 +100,26 @@ func main() {
 		cancel()
 	}()
 
+	if *consistencyMode != "" {
+		violations, err := runConsistency(ctx, cfg, logger, *consistencyMode)
+		if err != nil {
+			logger.Error("Consistency check failed", zap.Error(err))
+			os.Exit(1)
+		}
+		if violations > 0 && *consistencyMode == consistencyModeCheck {
+			os.Exit(2)
+		}
+		return
+	}
+
+	if *migrateMode != "" {
+		if err := runMigrate(ctx, cfg, logger, *migrateMode, *migrateTo); err != nil {
+			logger.Error("Migration failed", zap.Error(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize components
 	if err := run(ctx, cfg, logger); err != nil {
 		logger.Error("Application error", zap.Error(err))
@@ -94,12 +140,35 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 	defer pool.Close()
 	logger.Info("Connected to PostgreSQL")
 
+	if cfg.GoBackend.Database.AutoMigrate {
+		if err := autoMigrate(ctx, pool, logger); err != nil {
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
 	// 2. Initialize repositories
 	repos := repository.NewRepositories(pool)
 
+	// Secrets store; without a master key the secrets API is unavailable
+	secretCipher, err := secrets.LoadCipher(&cfg.GoBackend.Secrets)
+	if err != nil && !errors.Is(err, secrets.ErrNotConfigured) {
+		return fmt.Errorf("failed to load secrets master key: %w", err)
+	}
+	if secretCipher == nil {
+		logger.Warn("Secrets master key not configured, secrets API disabled")
+	}
+	secretStore := secrets.NewStore(repos.Secret, secretCipher)
+
 	// 3. Initialize Docker manager
-	logger.Info("Initializing Docker manager...")
-	dockerManager, err := docker.NewDockerManager(&cfg.GoBackend.Docker, logger)
+	logger.Info("Initializing Docker manager...",
+		zap.String("executor", cfg.GoBackend.Docker.Executor),
+	)
+	var dockerManager docker.Manager
+	if cfg.GoBackend.Docker.Executor == config.ExecutorFake {
+		dockerManager, err = docker.NewFakeManager(&cfg.GoBackend.Docker, clock.Real(), logger)
+	} else {
+		dockerManager, err = docker.NewDockerManager(&cfg.GoBackend.Docker, logger)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to initialize Docker manager: %w", err)
 	}
@@ -107,6 +176,7 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 
 	// 4. Initialize event publisher (RabbitMQ)
 	var eventPublisher events.Publisher
+	var rabbitPublisher *events.RabbitMQPublisher
 	if cfg.GoBackend.RabbitMQ.URL != "" {
 		logger.Info("Connecting to RabbitMQ...")
 		publisher, err := events.NewRabbitMQPublisher(&cfg.GoBackend.RabbitMQ, logger)
@@ -114,7 +184,11 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 			logger.Warn("Failed to connect to RabbitMQ, using no-op publisher", zap.Error(err))
 			eventPublisher = events.NewNoOpPublisher()
 		} else {
+			if cfg.Logging.Redaction.Enabled {
+				publisher.SetRedactor(redact.New(cfg.Logging.Redaction.EventFields, cfg.Logging.Redaction.MaxEventFieldBytes))
+			}
 			eventPublisher = publisher
+			rabbitPublisher = publisher
 			defer publisher.Close()
 			logger.Info("Connected to RabbitMQ")
 		}
@@ -126,11 +200,67 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 	// 5. Initialize Scout Scheduler
 	logger.Info("Initializing Scout scheduler...")
 	scoutSched := scheduler.NewScoutScheduler(repos, eventPublisher, logger)
+	scoutSched.SetSecrets(secretStore)
+	scoutSched.SetSourceLimits(cfg.GoBackend.Scout.SourceLimits())
 	if err := scoutSched.Start(); err != nil {
 		return fmt.Errorf("failed to start scout scheduler: %w", err)
 	}
 	logger.Info("Scout scheduler started")
 
+	// Initialize strategy archiver (optional)
+	var archiver *scheduler.StrategyArchiver
+	if cfg.GoBackend.Archival.Enabled {
+		archiver = scheduler.NewStrategyArchiver(&cfg.GoBackend.Archival, repos, eventPublisher, logger)
+		if err := archiver.Start(); err != nil {
+			return fmt.Errorf("failed to start strategy archiver: %w", err)
+		}
+	}
+
+	// Initialize description queue (optional)
+	var descriptionQueue *scheduler.DescriptionQueue
+	if cfg.GoBackend.Descriptions.Enabled {
+		descriptionQueue = scheduler.NewDescriptionQueue(&cfg.GoBackend.Descriptions, repos, eventPublisher, logger)
+		if err := descriptionQueue.Start(); err != nil {
+			return fmt.Errorf("failed to start description queue: %w", err)
+		}
+	}
+
+	// Initialize export worker (optional)
+	var exporter *scheduler.Exporter
+	if cfg.GoBackend.Export.Enabled {
+		exporter = scheduler.NewExporter(&cfg.GoBackend.Export, repos, logger)
+		if err := exporter.Start(); err != nil {
+			return fmt.Errorf("failed to start exporter: %w", err)
+		}
+	}
+
+	// Initialize hyperopt runner (optional)
+	var hyperoptRunner *scheduler.HyperoptRunner
+	if cfg.GoBackend.Hyperopt.Enabled {
+		hyperoptRunner = scheduler.NewHyperoptRunner(&cfg.GoBackend.Hyperopt, repos, dockerManager, logger)
+		if err := hyperoptRunner.Start(); err != nil {
+			return fmt.Errorf("failed to start hyperopt runner: %w", err)
+		}
+	}
+
+	// Initialize queue SLA monitor (optional)
+	var slaMonitor *scheduler.SLAMonitor
+	if cfg.GoBackend.SLA.Enabled {
+		slaMonitor = scheduler.NewSLAMonitor(&cfg.GoBackend.SLA, repos, eventPublisher, logger)
+		if err := slaMonitor.Start(); err != nil {
+			return fmt.Errorf("failed to start SLA monitor: %w", err)
+		}
+	}
+
+	// Initialize optimization progress reporter (optional)
+	var progressReporter *scheduler.ProgressReporter
+	if cfg.GoBackend.Progress.Enabled {
+		progressReporter = scheduler.NewProgressReporter(&cfg.GoBackend.Progress, repos, eventPublisher, logger)
+		if err := progressReporter.Start(); err != nil {
+			return fmt.Errorf("failed to start progress reporter: %w", err)
+		}
+	}
+
 	// 6. Initialize scheduler
 	logger.Info("Initializing scheduler...")
 	sched := scheduler.NewScheduler(
@@ -141,11 +271,98 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 		logger,
 	)
 
+	// Jobs hinting at cached market data prefer the data already mounted here
+	sched.SetDataCache(scheduler.NewDirDataCache(cfg.GoBackend.Docker.DataMount))
+
+	// Market data listing and downloads; the scheduler checks jobs' data
+	// before starting them (optional) and downloads what is missing (optional)
+	dataDefaults, err := marketdata.LoadDefaults(cfg.GoBackend.Docker.BaseConfigPath)
+	if err != nil {
+		logger.Warn("Failed to read market data defaults from base config", zap.Error(err))
+	}
+	dataInventory := marketdata.NewInventory(cfg.GoBackend.Docker.DataMount, dataDefaults)
+	dataDownloader := marketdata.NewDownloader(&cfg.GoBackend.MarketData, dockerManager, logger)
+	if cfg.GoBackend.MarketData.VerifyBeforeStart {
+		var autoDownloader scheduler.MarketDataDownloader
+		if cfg.GoBackend.MarketData.AutoDownload {
+			autoDownloader = dataDownloader
+		}
+		sched.SetMarketData(dataInventory, autoDownloader)
+		logger.Info("Market data check enabled",
+			zap.Bool("auto_download", cfg.GoBackend.MarketData.AutoDownload),
+		)
+	}
+
+	// Backtest output formats beyond the builtin one (optional)
+	if parserCfg := cfg.GoBackend.Scheduler.Parser; len(parserCfg.Formats) > 0 || parserCfg.DefaultFormat != "" {
+		registry, err := newParserRegistry(&parserCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create parser registry: %w", err)
+		}
+		sched.SetParserRegistry(registry)
+		logger.Info("Result parser formats configured",
+			zap.Int("formats", len(parserCfg.Formats)),
+			zap.String("default_format", parserCfg.DefaultFormat),
+		)
+	}
+
+	// Normalize result profits to a reference currency (optional)
+	if cfg.GoBackend.Currency.ReferenceCurrency != "" {
+		priceSource, err := pricing.NewSource(&cfg.GoBackend.Currency)
+		if err != nil {
+			return fmt.Errorf("failed to create price source: %w", err)
+		}
+		sched.SetNormalizer(pricing.NewNormalizer(&cfg.GoBackend.Currency, priceSource, logger))
+		logger.Info("Result profit normalization enabled",
+			zap.String("reference_currency", cfg.GoBackend.Currency.ReferenceCurrency),
+			zap.String("price_source", cfg.GoBackend.Currency.PriceSource),
+		)
+	}
+
 	if err := sched.Start(); err != nil {
 		return fmt.Errorf("failed to start scheduler: %w", err)
 	}
 	logger.Info("Scheduler started")
 
+	// Initialize strategy re-validation worker, validating through the
+	// scheduler's validation pool (optional)
+	var revalidator *scheduler.Revalidator
+	if cfg.GoBackend.Revalidation.Enabled {
+		revalidator = scheduler.NewRevalidator(&cfg.GoBackend.Revalidation, repos, sched, cfg.GoBackend.Docker.Image, logger)
+		if err := revalidator.Start(); err != nil {
+			return fmt.Errorf("failed to start revalidator: %w", err)
+		}
+	}
+
+	// Initialize dependency watchdog to auto-pause optimizations during outages (optional)
+	var watchdog *scheduler.DependencyWatchdog
+	if cfg.GoBackend.AutoPause.Enabled {
+		probes := []scheduler.DependencyProbe{{Name: "docker", Check: dockerManager.Ping}}
+		if rabbitPublisher != nil {
+			probes = append(probes, scheduler.DependencyProbe{Name: "rabbitmq", Check: rabbitPublisher.Ping})
+		}
+		watchdog = scheduler.NewDependencyWatchdog(&cfg.GoBackend.AutoPause, repos, eventPublisher, probes, logger)
+		if err := watchdog.Start(); err != nil {
+			return fmt.Errorf("failed to start dependency watchdog: %w", err)
+		}
+	}
+
+	// Initialize stall watchdog to flag optimizations that stopped making progress (optional)
+	var stallWatchdog *scheduler.StallWatchdog
+	if cfg.GoBackend.Stall.Enabled {
+		stallWatchdog = scheduler.NewStallWatchdog(&cfg.GoBackend.Stall, repos, eventPublisher, logger)
+		if err := stallWatchdog.Start(); err != nil {
+			return fmt.Errorf("failed to start stall watchdog: %w", err)
+		}
+	}
+
+	// Initialize maintenance mode watcher, which pauses the schedulers while
+	// maintenance mode is enabled on any instance
+	maintenance := scheduler.NewMaintenanceWatcher(&cfg.GoBackend.Maintenance, repos, eventPublisher, logger, sched, scoutSched)
+	if err := maintenance.Start(); err != nil {
+		return fmt.Errorf("failed to start maintenance mode watcher: %w", err)
+	}
+
 	// 7. Initialize event subscriber (RabbitMQ) for receiving events from Python agents
 	var eventSubscriber events.Subscriber
 	if cfg.GoBackend.RabbitMQ.URL != "" {
@@ -164,6 +381,39 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 		}
 	}
 
+	// Drain the dead-letter queue into the database, where dead letters can
+	// be listed and requeued (optional)
+	var deadLetters *events.DeadLetterCollector
+	if cfg.GoBackend.RabbitMQ.URL != "" && cfg.GoBackend.RabbitMQ.DeadLetterQueue != "" {
+		deadLetters = events.NewDeadLetterCollector(&cfg.GoBackend.RabbitMQ, repos.DeadLetter, logger)
+		deadLetters.Start()
+	}
+
+	// API keys authenticate both the REST and gRPC APIs
+	authenticator, err := auth.NewAuthenticator(&cfg.GoBackend.Auth, repos.APIKey, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize authentication: %w", err)
+	}
+
+	// The gRPC service is also served over gRPC-Web by the HTTP server
+	// Strategy code is checked the same way whichever API stores it
+	codeSafety := cfg.GoBackend.Scheduler.CodeSafety.Policy()
+
+	grpcServer := grpc.NewServer(repos, sched, eventPublisher, logger)
+	grpcServer.SetAgents(&cfg.GoBackend.Agents)
+	grpcServer.SetAuth(authenticator)
+	grpcServer.SetMaintenance(maintenance)
+	grpcServer.SetJobWatch(&cfg.GoBackend.JobWatch)
+	if cfg.GoBackend.AccessLog.Enabled {
+		grpcServer.SetAccessLog(&cfg.GoBackend.AccessLog)
+	}
+	grpcServer.SetSecrets(secretStore)
+
+	// Results are ranked against cached aggregates of their cohort
+	ranker := ranking.NewRanker(repos.Result, cfg.GoBackend.Ranking.TTL(), cfg.GoBackend.Ranking.MaxCohorts)
+	grpcServer.SetRanker(ranker)
+	grpcServer.SetCodeSafety(codeSafety)
+
 	// 8. Start HTTP server (health/metrics + REST API)
 	httpAddr := fmt.Sprintf(":%d", cfg.GoBackend.HTTPPort)
 	httpServer := httpapi.NewServer(httpAddr, pool, repos, sched, logger)
@@ -171,9 +421,45 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 	// Set event publisher, scout scheduler, and subscriber for HTTP handlers
 	httpServer.SetEventPublisher(eventPublisher)
 	httpServer.SetScoutScheduler(scoutSched)
+	httpServer.SetLoadShedding(&cfg.GoBackend.LoadShedding)
+	httpServer.SetAuth(authenticator)
+	httpServer.SetMaintenance(maintenance)
+	httpServer.SetCodeSafety(codeSafety)
+	httpServer.SetDefaultImage(cfg.GoBackend.Docker.Image)
+	httpServer.SetContainerLogs(dockerManager)
+	httpServer.SetMarketData(dataInventory, dataDownloader)
+	httpServer.SetWebSocket(&cfg.GoBackend.WebSocket)
+	if cfg.GoBackend.ResponseCache.Enabled {
+		httpServer.SetResponseCache(&cfg.GoBackend.ResponseCache)
+	}
+	if cfg.GoBackend.AccessLog.Enabled {
+		httpServer.SetAccessLog(&cfg.GoBackend.AccessLog)
+	}
+	httpServer.SetFeatures(&cfg.GoBackend.Features)
+	httpServer.SetAgents(&cfg.GoBackend.Agents)
+	httpServer.SetScout(&cfg.GoBackend.Scout)
+	httpServer.SetSecrets(secretStore)
+	httpServer.SetRanker(ranker)
+	httpServer.SetTimezone(cfg.GoBackend.Location())
+	if hyperoptRunner != nil {
+		httpServer.SetHyperopt(hyperoptRunner, cfg.GoBackend.Hyperopt.MaxEpochs)
+	}
+	if cfg.GoBackend.Insights.Enabled {
+		insightService, err := insights.NewService(&cfg.GoBackend.Insights, repos.Insight)
+		if err != nil {
+			return fmt.Errorf("failed to load insight templates: %w", err)
+		}
+		httpServer.SetInsights(insightService)
+	}
+	if slaMonitor != nil {
+		httpServer.SetSLAMonitor(slaMonitor)
+	}
 	if eventSubscriber != nil {
 		httpServer.SetSubscriber(eventSubscriber)
 	}
+	if cfg.GoBackend.GRPCWeb.Enabled {
+		httpServer.SetGRPCWeb(grpc.GRPCWebPathPrefix, grpcServer.GRPCWebHandler(&cfg.GoBackend.GRPCWeb))
+	}
 
 	go func() {
 		logger.Info("HTTP server starting", zap.String("address", httpAddr))
@@ -184,7 +470,6 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 
 	// 9. Start gRPC server
 	grpcAddr := fmt.Sprintf(":%d", cfg.GoBackend.GRPCPort)
-	grpcServer := grpc.NewServer(repos, sched, eventPublisher, logger)
 
 	go func() {
 		logger.Info("gRPC server starting", zap.String("address", grpcAddr))
@@ -220,12 +505,90 @@ func run(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
 	}
 	logger.Info("HTTP server stopped")
 
+	// Stop re-validation worker before the scheduler it validates through
+	if revalidator != nil {
+		if err := revalidator.Stop(); err != nil {
+			logger.Error("Error stopping revalidator", zap.Error(err))
+		}
+	}
+
 	// Stop scheduler (waits for active jobs)
 	if err := sched.Stop(); err != nil {
 		logger.Error("Error stopping scheduler", zap.Error(err))
 	}
 	logger.Info("Scheduler stopped")
 
+	// Stop dependency watchdog
+	if watchdog != nil {
+		if err := watchdog.Stop(); err != nil {
+			logger.Error("Error stopping dependency watchdog", zap.Error(err))
+		}
+	}
+
+	// Stop maintenance mode watcher
+	if err := maintenance.Stop(); err != nil {
+		logger.Error("Error stopping maintenance mode watcher", zap.Error(err))
+	}
+
+	// Stop stall watchdog
+	if stallWatchdog != nil {
+		if err := stallWatchdog.Stop(); err != nil {
+			logger.Error("Error stopping stall watchdog", zap.Error(err))
+		}
+	}
+
+	// Stop strategy archiver
+	if archiver != nil {
+		if err := archiver.Stop(); err != nil {
+			logger.Error("Error stopping strategy archiver", zap.Error(err))
+		}
+	}
+
+	// Stop description queue
+	if descriptionQueue != nil {
+		if err := descriptionQueue.Stop(); err != nil {
+			logger.Error("Error stopping description queue", zap.Error(err))
+		}
+	}
+
+	// Stop export worker
+	if exporter != nil {
+		if err := exporter.Stop(); err != nil {
+			logger.Error("Error stopping exporter", zap.Error(err))
+		}
+	}
+
+	// Stop dead letter collector
+	if deadLetters != nil {
+		if err := deadLetters.Stop(); err != nil {
+			logger.Error("Error stopping dead letter collector", zap.Error(err))
+		}
+	}
+
+	// Stop market data downloads, after the scheduler no longer waits on them
+	dataDownloader.Stop()
+
+	// Stop hyperopt runner
+	if hyperoptRunner != nil {
+		if err := hyperoptRunner.Stop(); err != nil {
+			logger.Error("Error stopping hyperopt runner", zap.Error(err))
+		}
+	}
+
+	// Stop SLA monitor
+	if slaMonitor != nil {
+		if err := slaMonitor.Stop(); err != nil {
+			logger.Error("Error stopping SLA monitor", zap.Error(err))
+		}
+	}
+
+	// Stop progress reporter
+	if progressReporter != nil {
+		if err := progressReporter.Stop(); err != nil {
+			logger.Error("Error stopping progress reporter", zap.Error(err))
+		}
+	}
+
 	// Stop Scout scheduler
 	logger.Info("Stopping Scout scheduler...")
 	if err := scoutSched.Stop(); err != nil {
@@ -270,6 +633,7 @@ func resumeRunningOptimizations(ctx context.Context, repos *repository.Repositor
 		event := map[string]interface{}{
 			"optimization_run_id": run.ID.String(),
 			"base_strategy_id":    run.BaseStrategyID.String(),
+			"seed_strategy_ids":   run.SeedStrategyIDs,
 			"max_iterations":      run.MaxIterations,
 			"config":              run.Config,
 		}
@@ -288,6 +652,40 @@ func resumeRunningOptimizations(ctx context.Context, repos *repository.Repositor
 	}
 }
 
+// newParserRegistry compiles the configured backtest output formats.
+// pageLimits converts the pagination config to domain page limits.
+func pageLimits(cfg *config.PaginationConfig) (domain.PageLimits, map[string]domain.PageLimits) {
+	limits := domain.PageLimits{DefaultPageSize: cfg.DefaultPageSize, MaxPageSize: cfg.MaxPageSize}
+	overrides := make(map[string]domain.PageLimits, len(cfg.Overrides))
+	for resource := range cfg.Overrides {
+		def, max := cfg.Limits(resource)
+		overrides[resource] = domain.PageLimits{DefaultPageSize: def, MaxPageSize: max}
+	}
+	return limits, overrides
+}
+
+func newParserRegistry(cfg *config.ParserConfig) (*parser.Registry, error) {
+	specs := make([]parser.FormatSpec, len(cfg.Formats))
+	for i, f := range cfg.Formats {
+		specs[i] = parser.FormatSpec{
+			Name:              f.Name,
+			Extends:           f.Extends,
+			FreqtradeVersions: f.FreqtradeVersions,
+			ImageTags:         f.ImageTags,
+			Patterns:          f.Patterns,
+			PairPattern:       f.PairPattern,
+			ErrorPatterns:     f.ErrorPatterns,
+			ExitReasonSection: f.ExitReasonSection,
+			ExitReasonPattern: f.ExitReasonPattern,
+			EntryTagSection:   f.EntryTagSection,
+			EntryTagPattern:   f.EntryTagPattern,
+			JSONStart:         f.JSONStart,
+			JSONPaths:         f.JSONPaths,
+		}
+	}
+	return parser.NewRegistry(specs, cfg.DefaultFormat)
+}
+
 // initLogger initializes the zap logger based on configuration.
 func initLogger(cfg *config.Config) (*zap.Logger, error) {
 	var zapCfg zap.Config
@@ -312,5 +710,13 @@ func initLogger(cfg *config.Config) (*zap.Logger, error) {
 		zapCfg.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
 	}
 
+	// Redact sensitive and oversized fields before they reach the log sink
+	if cfg.Logging.Redaction.Enabled {
+		redactor := redact.New(cfg.Logging.Redaction.LogFields, cfg.Logging.Redaction.MaxLogFieldBytes)
+		return zapCfg.Build(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return redact.NewCore(c, redactor)
+		}))
+	}
+
 	return zapCfg.Build()
 }
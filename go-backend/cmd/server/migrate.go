@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/migrations"
+)
+
+// Modes of the -migrate flag.
+const (
+	migrateModeUp       = "up"
+	migrateModeDown     = "down"
+	migrateModeStatus   = "status"
+	migrateModeBaseline = "baseline"
+)
+
+// runMigrate applies, rolls back or reports the schema migrations embedded
+// in the binary. Down rolls back to the -migrate-to version, or the latest
+// applied migration if it is negative; baseline records the migrations up to
+// it as applied without running them. Status is written to stdout as JSON.
+func runMigrate(ctx context.Context, cfg *config.Config, logger *zap.Logger, mode string, to int) error {
+	if mode != migrateModeUp && mode != migrateModeDown && mode != migrateModeStatus && mode != migrateModeBaseline {
+		return fmt.Errorf("invalid migrate mode %q: must be up, down, status or baseline", mode)
+	}
+	if mode == migrateModeBaseline && to < 0 {
+		return errors.New("baseline requires -migrate-to")
+	}
+
+	pool, err := db.NewPool(ctx, &cfg.GoBackend.Database, logger)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	migrator, err := db.NewMigrator(pool, migrations.FS, logger)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case migrateModeUp:
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Info("Migrations applied", zap.Int("applied", applied), zap.Int("version", migrator.Latest()))
+
+	case migrateModeDown:
+		if to < 0 {
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+			to = previousVersion(statuses)
+		}
+		rolledBack, err := migrator.Down(ctx, to)
+		if err != nil {
+			return err
+		}
+		logger.Info("Migrations rolled back", zap.Int("rolled_back", rolledBack), zap.Int("version", to))
+
+	case migrateModeBaseline:
+		if err := migrator.Baseline(ctx, to); err != nil {
+			return err
+		}
+		logger.Info("Migrations baselined", zap.Int("version", to))
+
+	case migrateModeStatus:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(statuses); err != nil {
+			return fmt.Errorf("failed to write status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// previousVersion returns the version below the latest applied migration,
+// the target that rolls back just that one.
+func previousVersion(statuses []db.MigrationStatus) int {
+	previous, latest := 0, 0
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			previous, latest = latest, status.Version
+		}
+	}
+	if latest == 0 {
+		return 0
+	}
+	return previous
+}
+
+// autoMigrate applies pending migrations at startup.
+func autoMigrate(ctx context.Context, pool *db.Pool, logger *zap.Logger) error {
+	migrator, err := db.NewMigrator(pool, migrations.FS, logger)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("Database schema up to date",
+		zap.Int("applied", applied),
+		zap.Int("version", migrator.Latest()),
+	)
+	return nil
+}
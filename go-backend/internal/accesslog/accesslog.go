@@ -0,0 +1,90 @@
+// Package accesslog logs one line per HTTP request or gRPC call, sampling the
+// successful ones to high-volume endpoints.
+package accesslog
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader carries the request ID of an HTTP request and response.
+// gRPC calls carry it in the lowercase metadata key.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the longest request ID accepted from a client.
+const maxRequestIDLength = 128
+
+// Logger writes access log lines. A nil Logger logs nothing.
+type Logger struct {
+	logger *zap.Logger
+	slow   time.Duration
+	rates  []*sampleRate // Longest prefix first
+}
+
+// sampleRate is the fraction of successful calls logged for a prefix.
+type sampleRate struct {
+	prefix string
+	rate   float64
+	count  atomic.Uint64
+}
+
+// New creates an access logger. Successful calls whose path or method starts
+// with a key of rates are logged at that rate (0-1); the longest matching
+// prefix applies. Failed calls and those taking at least slow are always
+// logged; a zero slow disables the latter.
+func New(logger *zap.Logger, rates map[string]float64, slow time.Duration) *Logger {
+	l := &Logger{
+		logger: logger,
+		slow:   slow,
+	}
+	for prefix, rate := range rates {
+		l.rates = append(l.rates, &sampleRate{prefix: prefix, rate: rate})
+	}
+	sort.Slice(l.rates, func(i, j int) bool {
+		return len(l.rates[i].prefix) > len(l.rates[j].prefix)
+	})
+	return l
+}
+
+// Log records a call to path, an HTTP path or gRPC method name, unless it
+// succeeded and is sampled out. The latency is added to fields.
+func (l *Logger) Log(path string, failed bool, latency time.Duration, fields ...zap.Field) {
+	if l == nil {
+		return
+	}
+	if !failed && (l.slow <= 0 || latency < l.slow) && !l.sample(path) {
+		return
+	}
+	l.logger.Info("Request", append(fields, zap.Duration("latency", latency))...)
+}
+
+// sample reports whether a successful call to path is logged. Calls are
+// counted per prefix, so exactly the configured fraction is logged.
+func (l *Logger) sample(path string) bool {
+	for _, r := range l.rates {
+		if strings.HasPrefix(path, r.prefix) {
+			n := r.count.Add(1)
+			return uint64(float64(n)*r.rate) != uint64(float64(n-1)*r.rate)
+		}
+	}
+	return true
+}
+
+// RequestID returns the request ID a client sent, or a new one if it sent
+// none or one that is too long or not printable ASCII.
+func RequestID(sent string) string {
+	if sent == "" || len(sent) > maxRequestIDLength {
+		return uuid.New().String()
+	}
+	for i := 0; i < len(sent); i++ {
+		if sent[i] <= ' ' || sent[i] > '~' {
+			return uuid.New().String()
+		}
+	}
+	return sent
+}
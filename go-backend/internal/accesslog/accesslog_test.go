@@ -0,0 +1,51 @@
+package accesslog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger_Sampling(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := New(zap.New(core), map[string]float64{
+		"/health":  0.25,
+		"/health/": 0,
+	}, time.Second)
+
+	for i := 0; i < 8; i++ {
+		l.Log("/health", false, time.Millisecond)
+	}
+	assert.Equal(t, 2, logs.Len())
+
+	// The longest prefix wins; failed and slow calls are always logged
+	l.Log("/health/live", false, time.Millisecond)
+	assert.Equal(t, 2, logs.Len())
+	l.Log("/health/live", true, time.Millisecond)
+	l.Log("/health/live", false, 2*time.Second)
+	assert.Equal(t, 4, logs.Len())
+
+	// Paths without a rate are all logged
+	l.Log("/api/v1/strategies", false, time.Millisecond, zap.Int("status", 201))
+	entry := logs.All()[4]
+	assert.Equal(t, int64(201), entry.ContextMap()["status"])
+	assert.Equal(t, time.Millisecond, entry.ContextMap()["latency"])
+
+	var nilLogger *Logger
+	nilLogger.Log("/api/v1/strategies", true, time.Millisecond)
+}
+
+func TestRequestID(t *testing.T) {
+	assert.Equal(t, "abc-123", RequestID("abc-123"))
+
+	for _, sent := range []string{"", "has space", "new\nline", strings.Repeat("x", maxRequestIDLength+1)} {
+		id := RequestID(sent)
+		assert.NotEqual(t, sent, id)
+		assert.Len(t, id, 36)
+	}
+}
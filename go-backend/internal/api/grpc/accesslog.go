@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/accesslog"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// requestIDMetadataKey carries the request ID of a call, as the X-Request-ID
+// HTTP header does.
+var requestIDMetadataKey = strings.ToLower(accesslog.RequestIDHeader)
+
+// SetAccessLog logs every call, including those refused by authentication.
+// It must be called before Start and GRPCWebHandler.
+func (s *Server) SetAccessLog(cfg *config.AccessLogConfig) {
+	s.accessLog = accesslog.New(s.logger.Named("access"), cfg.GRPCSampleRates, cfg.SlowThresholdDuration())
+}
+
+// accessLogCall is the access log entry of a call being served. The caller is
+// filled in once authenticated.
+type accessLogCall struct {
+	requestID string
+	caller    string
+}
+
+// accessLogCallKey is the context key of the accessLogCall.
+type accessLogCallKey struct{}
+
+// setAccessLogCaller records the authenticated caller of a call.
+func setAccessLogCaller(ctx context.Context, caller string) {
+	if call, ok := ctx.Value(accessLogCallKey{}).(*accessLogCall); ok {
+		call.caller = caller
+	}
+}
+
+// startAccessLogCall returns the context of a call carrying its access log
+// entry. The request ID is taken from incoming metadata or generated.
+func startAccessLogCall(ctx context.Context) (context.Context, *accessLogCall) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	sent := ""
+	if vs := md.Get(requestIDMetadataKey); len(vs) > 0 {
+		sent = vs[0]
+	}
+	call := &accessLogCall{requestID: accesslog.RequestID(sent)}
+	for _, v := range md.Get(principalMetadataKey) {
+		if v = strings.TrimSpace(v); v != "" {
+			call.caller = v
+			break
+		}
+	}
+	return context.WithValue(ctx, accessLogCallKey{}, call), call
+}
+
+// logCall writes the access log line of a finished call.
+func (s *Server) logCall(ctx context.Context, call *accessLogCall, fullMethod string, start time.Time, err error) {
+	code := status.Code(err)
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	s.accessLog.Log(fullMethod[strings.LastIndex(fullMethod, "/")+1:], err != nil, time.Since(start),
+		zap.String("method", fullMethod),
+		zap.String("code", code.String()),
+		zap.String("request_id", call.requestID),
+		zap.String("caller", call.caller),
+		zap.String("remote_addr", remoteAddr),
+	)
+}
+
+// accessLogUnaryInterceptor logs unary calls.
+func (s *Server) accessLogUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx, call := startAccessLogCall(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, call.requestID))
+
+	resp, err := handler(ctx, req)
+	s.logCall(ctx, call, info.FullMethod, start, err)
+	return resp, err
+}
+
+// accessLogStreamInterceptor logs streaming calls once they end.
+func (s *Server) accessLogStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, call := startAccessLogCall(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(requestIDMetadataKey, call.requestID))
+
+	err := handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	s.logCall(ctx, call, info.FullMethod, start, err)
+	return err
+}
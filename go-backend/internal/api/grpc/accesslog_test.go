@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestAccessLogUnaryInterceptor(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	server := NewServer(nil, nil, nil, zap.New(core))
+	server.SetAccessLog(&config.AccessLogConfig{GRPCSampleRates: map[string]float64{"HealthCheck": 0}})
+
+	a, err := auth.NewAuthenticator(&config.AuthConfig{
+		Enabled: true,
+		Keys:    []config.AuthKeyConfig{{Name: "agent", KeyHash: domain.HashAPIKey("agent-secret")}},
+	}, nil, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	server.SetAuth(a)
+
+	interceptor := server.unaryInterceptor()
+	require.NotNil(t, interceptor)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	accessLogs := func() []observer.LoggedEntry {
+		return logs.FilterMessage("Request").All()
+	}
+
+	// The caller is the authenticated principal, not the claimed user
+	info := &grpc.UnaryServerInfo{FullMethod: GRPCWebPathPrefix + "CreateStrategy"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		apiKeyMetadataKey, "agent-secret",
+		principalMetadataKey, "someone-else",
+		requestIDMetadataKey, "req-1",
+	))
+	_, err = interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.Len(t, accessLogs(), 1)
+	fields := accessLogs()[0].ContextMap()
+	assert.Equal(t, info.FullMethod, fields["method"])
+	assert.Equal(t, "OK", fields["code"])
+	assert.Equal(t, "req-1", fields["request_id"])
+	assert.Equal(t, "agent", fields["caller"])
+
+	// Refused calls are logged with the claimed user
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(principalMetadataKey, "someone-else"))
+	_, err = interceptor(ctx, nil, info, handler)
+	assert.Equal(t, grpccodes.Unauthenticated, status.Code(err))
+	require.Len(t, accessLogs(), 2)
+	fields = accessLogs()[1].ContextMap()
+	assert.Equal(t, "Unauthenticated", fields["code"])
+	assert.Equal(t, "someone-else", fields["caller"])
+	assert.NotEmpty(t, fields["request_id"])
+
+	// Successful health checks are sampled out
+	health := &grpc.UnaryServerInfo{FullMethod: GRPCWebPathPrefix + "HealthCheck"}
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiKeyMetadataKey, "agent-secret"))
+	_, err = interceptor(ctx, nil, health, handler)
+	require.NoError(t, err)
+	assert.Len(t, accessLogs(), 2)
+}
@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
+)
+
+// SetAgents sets how agent registrations are tracked. The TTL is validated
+// when the config is loaded.
+func (s *Server) SetAgents(cfg *config.AgentsConfig) {
+	s.agentTTL, _ = time.ParseDuration(cfg.RegistrationTTL)
+	s.enforceAgents = cfg.EnforceCapabilities
+}
+
+// RegisterAgent stores what an agent instance announces about itself.
+// Registering again with the same agent_id replaces the registration.
+func (s *Server) RegisterAgent(ctx context.Context, req *pb.RegisterAgentRequest) (*pb.RegisterAgentResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "FreqSearchService.RegisterAgent")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("agent_id", req.AgentId),
+		attribute.String("type", req.Type),
+	)
+
+	now := time.Now()
+	reg := &domain.AgentRegistration{
+		AgentID:             req.AgentId,
+		Type:                domain.AgentType(req.Type),
+		Version:             req.Version,
+		EventSchemaVersions: make([]int, len(req.EventSchemaVersions)),
+		Capabilities:        append([]string{}, req.Capabilities...),
+		RegisteredAt:        now,
+		LastSeenAt:          now,
+	}
+	for i, v := range req.EventSchemaVersions {
+		reg.EventSchemaVersions[i] = int(v)
+	}
+	if err := reg.Validate(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid registration")
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid registration: %v", err)
+	}
+
+	if err := s.repos.Agent.Register(ctx, reg); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to register agent")
+		s.logger.Error("Failed to register agent", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to register agent")
+	}
+
+	resp := &pb.RegisterAgentResponse{
+		Agent:              domainAgentRegistrationToProto(reg),
+		EventSchemaVersion: domain.EventSchemaVersion,
+	}
+	if !reg.SupportsSchema() {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("agent does not support event schema v%d published by this backend", domain.EventSchemaVersion))
+	}
+
+	s.logger.Info("Agent registered",
+		zap.String("agent_id", reg.AgentID),
+		zap.String("type", string(reg.Type)),
+		zap.String("version", reg.Version),
+		zap.Strings("capabilities", reg.Capabilities),
+	)
+
+	return resp, nil
+}
+
+// checkRunAgents returns a FailedPrecondition error naming the agents a run
+// needs that no live registered agent provides. Nothing is checked unless
+// capabilities are enforced.
+func (s *Server) checkRunAgents(ctx context.Context, run *domain.OptimizationRun) error {
+	if !s.enforceAgents {
+		return nil
+	}
+
+	var since *time.Time
+	if s.agentTTL > 0 {
+		cutoff := time.Now().Add(-s.agentTTL)
+		since = &cutoff
+	}
+	regs, err := s.repos.Agent.List(ctx, since)
+	if err != nil {
+		s.logger.Error("Failed to list agents", zap.Error(err))
+		return status.Errorf(grpccodes.Internal, "failed to check registered agents")
+	}
+
+	unmet := domain.UnmetAgentRequirements(run.RequiredAgents(), regs)
+	if len(unmet) == 0 {
+		return nil
+	}
+	parts := make([]string, len(unmet))
+	for i, req := range unmet {
+		parts[i] = req.String()
+	}
+	return status.Errorf(grpccodes.FailedPrecondition, "no registered agent can serve this run: requires a live %s", strings.Join(parts, "; "))
+}
@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// apiKeyMetadataKey carries an API key as an alternative to a bearer token,
+// mirroring the HTTP X-API-Key header.
+const apiKeyMetadataKey = "x-api-key"
+
+// viewerMethods are the read-only methods a viewer may call. Every other
+// method requires an operator.
+var viewerMethods = map[string]bool{
+	"GetStrategy":           true,
+	"SearchStrategies":      true,
+	"GetStrategyLineage":    true,
+	"GetStrategyStatistics": true,
+	"DiffStrategies":        true,
+	"GetBacktestJob":        true,
+	"WatchBacktestJob":      true,
+	"GetBacktestResult":     true,
+	"QueryBacktestResults":  true,
+	"GetQueueStats":         true,
+	"GetSchedulerStatus":    true,
+	"GetOptimizationRun":    true,
+	"ListOptimizationRuns":  true,
+	"ListScoutDiscoveries":  true,
+	"HealthCheck":           true,
+}
+
+// methodRole returns the role required to call a method.
+func methodRole(fullMethod string) domain.Role {
+	if viewerMethods[fullMethod[strings.LastIndex(fullMethod, "/")+1:]] {
+		return domain.RoleViewer
+	}
+	return domain.RoleOperator
+}
+
+// SetAuth requires an API key on every call if authentication is enabled.
+// It must be called before Start and GRPCWebHandler.
+func (s *Server) SetAuth(a *auth.Authenticator) {
+	s.auth = a
+}
+
+// serverOptions returns the options the gRPC server is created with. The
+// access log runs first, so it sees calls refused by authentication.
+func (s *Server) serverOptions() []grpc.ServerOption {
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if s.accessLog != nil {
+		unary = append(unary, s.accessLogUnaryInterceptor)
+		stream = append(stream, s.accessLogStreamInterceptor)
+	}
+	if s.auth.Enabled() {
+		unary = append(unary, s.authUnaryInterceptor)
+		stream = append(stream, s.authStreamInterceptor)
+	}
+	if len(unary) == 0 {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// unaryInterceptor returns the interceptor unary calls run through, for
+// callers dispatching to method handlers directly. It is nil without
+// authentication and access log.
+func (s *Server) unaryInterceptor() grpc.UnaryServerInterceptor {
+	switch {
+	case s.accessLog != nil && s.auth.Enabled():
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return s.accessLogUnaryInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return s.authUnaryInterceptor(ctx, req, info, handler)
+			})
+		}
+	case s.accessLog != nil:
+		return s.accessLogUnaryInterceptor
+	case s.auth.Enabled():
+		return s.authUnaryInterceptor
+	default:
+		return nil
+	}
+}
+
+// authUnaryInterceptor authenticates unary calls.
+func (s *Server) authUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStreamInterceptor authenticates streaming calls.
+func (s *Server) authStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate checks the API key of a call and the role it requires, and
+// returns a context carrying its principal. The key's name replaces any x-user-id metadata, so callers
+// can't act as another principal.
+func (s *Server) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+
+	key := ""
+	for _, v := range md.Get("authorization") {
+		if key = auth.BearerKey(v); key != "" {
+			break
+		}
+	}
+	if key == "" {
+		if vs := md.Get(apiKeyMetadataKey); len(vs) > 0 {
+			key = strings.TrimSpace(vs[0])
+		}
+	}
+
+	principal, err := s.auth.Authenticate(ctx, key)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			return nil, status.Error(grpccodes.Unauthenticated, err.Error())
+		}
+		s.logger.Error("Failed to authenticate call", zap.String("method", fullMethod), zap.Error(err))
+		return nil, status.Error(grpccodes.Internal, "failed to authenticate call")
+	}
+	if !principal.Role.Allows(methodRole(fullMethod)) {
+		return nil, status.Errorf(grpccodes.PermissionDenied, "%v: %s role required", auth.ErrForbidden, methodRole(fullMethod))
+	}
+
+	md.Set(principalMetadataKey, principal.Name)
+	setAccessLogCaller(ctx, principal.Name)
+	ctx = metadata.NewIncomingContext(ctx, md)
+	return auth.NewContext(ctx, principal), nil
+}
+
+// authServerStream overrides the context of a server stream.
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the authenticated context.
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	server := NewServer(nil, nil, nil, zaptest.NewLogger(t))
+	assert.Nil(t, server.unaryInterceptor())
+
+	a, err := auth.NewAuthenticator(&config.AuthConfig{
+		Enabled: true,
+		Keys: []config.AuthKeyConfig{
+			{Name: "agent", KeyHash: domain.HashAPIKey("agent-secret")},
+			{Name: "dashboard", KeyHash: domain.HashAPIKey("viewer-secret"), Role: "viewer"},
+		},
+	}, nil, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	server.SetAuth(a)
+
+	interceptor := server.unaryInterceptor()
+	require.NotNil(t, interceptor)
+
+	info := &grpc.UnaryServerInfo{FullMethod: GRPCWebPathPrefix + "HealthCheck"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return requestPrincipal(ctx), nil
+	}
+
+	_, err = interceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, grpccodes.Unauthenticated, status.Code(err))
+
+	// The key's name replaces the claimed principal
+	for _, md := range []metadata.MD{
+		metadata.Pairs("authorization", "Bearer agent-secret", principalMetadataKey, "someone-else"),
+		metadata.Pairs(apiKeyMetadataKey, "agent-secret"),
+	} {
+		principal, err := interceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "agent", principal)
+	}
+
+	// Viewers may only call read-only methods
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiKeyMetadataKey, "viewer-secret"))
+	principal, err := interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "dashboard", principal)
+
+	cancel := &grpc.UnaryServerInfo{FullMethod: GRPCWebPathPrefix + "CancelBacktest"}
+	_, err = interceptor(ctx, nil, cancel, handler)
+	assert.Equal(t, grpccodes.PermissionDenied, status.Code(err))
+
+	credential := &grpc.UnaryServerInfo{FullMethod: GRPCWebPathPrefix + "GetScoutCredential"}
+	_, err = interceptor(ctx, nil, credential, handler)
+	assert.Equal(t, grpccodes.PermissionDenied, status.Code(err), "viewers may not read credentials")
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiKeyMetadataKey, "agent-secret"))
+	_, err = interceptor(ctx, nil, cancel, handler)
+	assert.NoError(t, err)
+}
@@ -28,12 +28,18 @@ func domainStrategyToProto(s *domain.Strategy) *pb.Strategy {
 		Description: s.Description,
 		CreatedAt:   timestamppb.New(s.CreatedAt),
 		UpdatedAt:   timestamppb.New(s.UpdatedAt),
+
+		ValidationStatus: s.ValidationStatus.String(),
+		ValidationErrors: s.ValidationErrors,
 	}
 
 	if s.ParentID != nil {
 		parentID := s.ParentID.String()
 		proto.ParentId = &parentID
 	}
+	if s.ValidatedAt != nil {
+		proto.ValidatedAt = timestamppb.New(*s.ValidatedAt)
+	}
 
 	// Build metadata
 	metadata := &pb.StrategyMetadata{
@@ -89,6 +95,31 @@ func domainJobToProto(job *domain.BacktestJob) *pb.BacktestJob {
 		proto.ErrorMessage = job.ErrorMessage
 	}
 
+	if job.FailureCategory != nil {
+		category := job.FailureCategory.String()
+		proto.FailureCategory = &category
+	}
+
+	if job.ExternalRef != nil {
+		proto.ExternalRef = job.ExternalRef
+	}
+	if job.CampaignID != nil {
+		campaignID := job.CampaignID.String()
+		proto.CampaignId = &campaignID
+	}
+	if job.ResubmittedFrom != nil {
+		resubmittedFrom := job.ResubmittedFrom.String()
+		proto.ResubmittedFrom = &resubmittedFrom
+	}
+	if job.Hints != nil {
+		proto.Hints = &pb.JobHints{
+			PreferCachedData: job.Hints.PreferCachedData,
+			AntiAffinity:     job.Hints.AntiAffinity,
+		}
+	}
+	proto.CancelReason = job.CancelReason
+	proto.CancelledBy = job.CancelledBy
+
 	if job.StartedAt != nil {
 		proto.StartedAt = timestamppb.New(*job.StartedAt)
 	}
@@ -177,6 +208,24 @@ func domainResultToProto(result *domain.BacktestResult) *pb.BacktestResult {
 	if result.WorstTradePct != nil {
 		proto.WorstTradePct = *result.WorstTradePct
 	}
+	if result.SupersededBy != nil {
+		supersededBy := result.SupersededBy.String()
+		proto.SupersededBy = &supersededBy
+	}
+	proto.StakeCurrency = result.StakeCurrency
+	proto.ReferenceCurrency = result.ReferenceCurrency
+	proto.ReferenceRate = result.ReferenceRate
+	proto.ProfitTotalNormalized = result.ProfitTotalNormalized
+	if result.Environment != nil {
+		proto.Environment = &pb.ExecutionEnvironment{
+			FreqtradeVersion: result.Environment.FreqtradeVersion,
+			PythonVersion:    result.Environment.PythonVersion,
+			Image:            result.Environment.Image,
+			ImageDigest:      result.Environment.ImageDigest,
+			Host:             result.Environment.Host,
+			Packages:         result.Environment.Packages,
+		}
+	}
 
 	// Convert pair results
 	proto.PairResults = make([]*pb.PairResult, len(result.PairResults))
@@ -190,6 +239,13 @@ func domainResultToProto(result *domain.BacktestResult) *pb.BacktestResult {
 		}
 	}
 
+	// Convert trade reason breakdowns
+	proto.ExitReasons = domainTradeReasonsToProto(result.ExitReasons)
+	proto.EntryTags = domainTradeReasonsToProto(result.EntryTags)
+	proto.StoplossExitPct = result.StoplossExitPct
+	proto.TrailingStopExitPct = result.TrailingStopExitPct
+	proto.Percentiles = domainPercentilesToProto(result.Percentiles)
+
 	// Decompress raw log if present
 	if len(result.RawLog) > 0 {
 		if decompressed, err := decompressGzip(result.RawLog); err == nil {
@@ -200,6 +256,19 @@ func domainResultToProto(result *domain.BacktestResult) *pb.BacktestResult {
 	return proto
 }
 
+// domainTradeReasonsToProto converts []domain.TradeReasonStats to []*pb.TradeReasonStats.
+func domainTradeReasonsToProto(reasons []domain.TradeReasonStats) []*pb.TradeReasonStats {
+	result := make([]*pb.TradeReasonStats, len(reasons))
+	for i, r := range reasons {
+		result[i] = &pb.TradeReasonStats{
+			Reason:       r.Reason,
+			Trades:       int32(r.Trades),
+			AvgProfitPct: r.AvgProfitPct,
+		}
+	}
+	return result
+}
+
 // protoConfigToDomain converts a pb.BacktestConfig to a domain.BacktestConfig.
 func protoConfigToDomain(config *pb.BacktestConfig) domain.BacktestConfig {
 	if config == nil {
@@ -218,6 +287,19 @@ func protoConfigToDomain(config *pb.BacktestConfig) domain.BacktestConfig {
 	}
 }
 
+// protoHintsToDomain converts pb.JobHints to domain.JobHints, or nil if no
+// hint is set.
+func protoHintsToDomain(hints *pb.JobHints) *domain.JobHints {
+	h := &domain.JobHints{
+		PreferCachedData: hints.GetPreferCachedData(),
+		AntiAffinity:     hints.GetAntiAffinity(),
+	}
+	if h.IsZero() {
+		return nil
+	}
+	return h
+}
+
 // protoJobStatusToDomain converts a pb.JobStatus to a domain.JobStatus.
 func protoJobStatusToDomain(status pb.JobStatus) domain.JobStatus {
 	switch status {
@@ -297,6 +379,11 @@ func protoSearchQueryToDomain(req *pb.SearchStrategiesRequest) domain.StrategySe
 		minTrades := int(*req.MinTrades)
 		query.MinTrades = &minTrades
 	}
+	if req.ValidationStatus != nil {
+		if validationStatus := domain.ValidationStatus(*req.ValidationStatus); validationStatus.IsValid() {
+			query.ValidationStatus = &validationStatus
+		}
+	}
 
 	if req.Pagination != nil {
 		query.Page = int(req.Pagination.Page)
@@ -313,26 +400,34 @@ func domainStrategyWithMetricsToProto(swm domain.StrategyWithMetrics) *pb.Strate
 		Strategy: domainStrategyToProto(swm.Strategy),
 	}
 
-	if swm.BestResult != nil {
-		proto.BestResult = &pb.StrategyPerformanceMetrics{
-			ProfitPct:      swm.BestResult.ProfitPct,
-			MaxDrawdownPct: swm.BestResult.MaxDrawdownPct,
-			TotalTrades:    int32(swm.BestResult.TotalTrades),
-			WinRate:        swm.BestResult.WinRate,
-		}
+	proto.BestResult = domainPerformanceMetricsToProto(swm.BestResult)
+	proto.BacktestCount = int32(swm.BestResult.BacktestCount)
 
-		if swm.BestResult.SharpeRatio != nil {
-			proto.BestResult.SharpeRatio = *swm.BestResult.SharpeRatio
-		}
-		if swm.BestResult.SortinoRatio != nil {
-			proto.BestResult.SortinoRatio = *swm.BestResult.SortinoRatio
-		}
-		if swm.BestResult.SharpeRatio != nil {
-			proto.BestResult.ProfitFactor = *swm.BestResult.SharpeRatio
-		}
+	return proto
+}
+
+// domainPerformanceMetricsToProto converts *domain.StrategyPerformanceMetrics to *pb.StrategyPerformanceMetrics.
+func domainPerformanceMetricsToProto(metrics *domain.StrategyPerformanceMetrics) *pb.StrategyPerformanceMetrics {
+	if metrics == nil {
+		return nil
 	}
 
-	proto.BacktestCount = int32(swm.BestResult.BacktestCount)
+	proto := &pb.StrategyPerformanceMetrics{
+		ProfitPct:      metrics.ProfitPct,
+		MaxDrawdownPct: metrics.MaxDrawdownPct,
+		TotalTrades:    int32(metrics.TotalTrades),
+		WinRate:        metrics.WinRate,
+	}
+
+	if metrics.SharpeRatio != nil {
+		proto.SharpeRatio = *metrics.SharpeRatio
+	}
+	if metrics.SortinoRatio != nil {
+		proto.SortinoRatio = *metrics.SortinoRatio
+	}
+	if metrics.SharpeRatio != nil {
+		proto.ProfitFactor = *metrics.SharpeRatio
+	}
 
 	return proto
 }
@@ -349,6 +444,13 @@ func domainLineageNodeToProto(node *domain.StrategyLineageNode) *pb.StrategyLine
 			Name:       node.Name,
 			Generation: int32(node.Generation),
 		},
+		Metrics:       domainPerformanceMetricsToProto(node.Metrics),
+		BacktestCount: int32(node.BacktestCount),
+		BestSharpe:    node.BestSharpe,
+		ChildCount:    int32(node.ChildCount),
+		Level:         int32(node.Level),
+		SharpeDelta:   node.SharpeDelta,
+		ProfitDelta:   node.ProfitDelta,
 	}
 
 	if node.ParentID != nil {
@@ -366,11 +468,78 @@ func domainLineageNodeToProto(node *domain.StrategyLineageNode) *pb.StrategyLine
 	return proto
 }
 
+// protoLineageDirectionToDomain converts a pb.LineageDirection to a domain.LineageDirection.
+func protoLineageDirectionToDomain(direction pb.LineageDirection) domain.LineageDirection {
+	switch direction {
+	case pb.LineageDirection_LINEAGE_DIRECTION_UP:
+		return domain.LineageUp
+	case pb.LineageDirection_LINEAGE_DIRECTION_BOTH:
+		return domain.LineageBoth
+	default:
+		return domain.LineageDown
+	}
+}
+
+// protoWindowToDomain converts an optional pb.TimeRange to a domain.TimeRange.
+// Unset bounds are left zero.
+func protoWindowToDomain(window *pb.TimeRange) *domain.TimeRange {
+	if window == nil {
+		return nil
+	}
+
+	tr := &domain.TimeRange{}
+	if window.Start != nil {
+		tr.Start = window.Start.AsTime()
+	}
+	if window.End != nil {
+		tr.End = window.End.AsTime()
+	}
+	return tr
+}
+
+// domainStrategyStatisticsToProto converts domain.StrategyStatistics to a pb.GetStrategyStatisticsResponse.
+func domainStrategyStatisticsToProto(stats *domain.StrategyStatistics) *pb.GetStrategyStatisticsResponse {
+	resp := &pb.GetStrategyStatisticsResponse{
+		StrategyId:     stats.StrategyID.String(),
+		ResultCount:    int32(stats.ResultCount),
+		SharpeRatio:    domainMetricStatisticsToProto(stats.SharpeRatio),
+		ProfitPct:      domainMetricStatisticsToProto(stats.ProfitPct),
+		MaxDrawdownPct: domainMetricStatisticsToProto(stats.MaxDrawdownPct),
+	}
+
+	if stats.FirstResultAt != nil {
+		resp.FirstResultAt = timestamppb.New(*stats.FirstResultAt)
+	}
+	if stats.LastResultAt != nil {
+		resp.LastResultAt = timestamppb.New(*stats.LastResultAt)
+	}
+
+	return resp
+}
+
+// domainMetricStatisticsToProto converts domain.MetricStatistics to pb.MetricStatistics.
+func domainMetricStatisticsToProto(m domain.MetricStatistics) *pb.MetricStatistics {
+	return &pb.MetricStatistics{
+		Count:  int32(m.Count),
+		Mean:   m.Mean,
+		Median: m.Median,
+		Stddev: m.StdDev,
+		Min:    m.Min,
+		Max:    m.Max,
+	}
+}
+
 // protoBacktestQueryToDomain converts a pb.QueryBacktestResultsRequest to a domain.BacktestResultQuery.
 func protoBacktestQueryToDomain(req *pb.QueryBacktestResultsRequest) domain.BacktestResultQuery {
 	query := domain.BacktestResultQuery{
-		OrderBy:   req.OrderBy,
-		Ascending: req.Ascending,
+		OrderBy:                req.OrderBy,
+		Ascending:              req.Ascending,
+		IncludeSuperseded:      req.IncludeSuperseded,
+		FreqtradeVersion:       req.FreqtradeVersion,
+		ImageDigest:            req.ImageDigest,
+		Host:                   req.Host,
+		MaxStoplossExitPct:     req.MaxStoplossExitPct,
+		MaxTrailingStopExitPct: req.MaxTrailingStopExitPct,
 	}
 
 	if req.StrategyId != nil && *req.StrategyId != "" {
@@ -438,6 +607,54 @@ func domainResultSummaryToProto(r *domain.BacktestResult) *pb.BacktestResultSumm
 	return summary
 }
 
+// domainSubmissionPreviewToProto converts a domain.SubmissionPreview to a pb.SubmissionPreview.
+func domainSubmissionPreviewToProto(p *domain.SubmissionPreview) *pb.SubmissionPreview {
+	if p == nil {
+		return nil
+	}
+
+	preview := &pb.SubmissionPreview{
+		QueuePosition:            int32(p.QueuePosition),
+		RunningJobs:              int32(p.RunningJobs),
+		Workers:                  int32(p.Workers),
+		EstimatedRuntimeMs:       p.EstimatedRuntimeMs,
+		EstimatedWaitMs:          p.EstimatedWaitMs,
+		EstimatedCompletionMs:    p.EstimatedCompletionMs,
+		EstimatedCompletionP90Ms: p.EstimatedCompletionP90Ms,
+		NewPairs:                 p.NewCoverage.Pairs,
+		NewTimeframes:            p.NewCoverage.Timeframes,
+	}
+	for _, r := range p.NewCoverage.Timeranges {
+		preview.NewTimeranges = append(preview.NewTimeranges, &pb.DateRange{
+			Start: r.Start,
+			End:   r.End,
+			Days:  int32(r.Days),
+		})
+	}
+
+	return preview
+}
+
+// domainUnresolvedImportsToProto converts domain.UnresolvedImports to pb.UnresolvedImports.
+func domainUnresolvedImportsToProto(imports []domain.UnresolvedImport) []*pb.UnresolvedImport {
+	if len(imports) == 0 {
+		return nil
+	}
+
+	out := make([]*pb.UnresolvedImport, len(imports))
+	for i, u := range imports {
+		out[i] = &pb.UnresolvedImport{
+			Module:   u.Module,
+			Package:  u.Package,
+			Names:    u.Names,
+			Line:     int32(u.Line),
+			Optional: u.Optional,
+			Fix:      u.Fix,
+		}
+	}
+	return out
+}
+
 // protoOptConfigToDomain converts a pb.OptimizationConfig to a domain.OptimizationConfig.
 func protoOptConfigToDomain(cfg *pb.OptimizationConfig) domain.OptimizationConfig {
 	if cfg == nil {
@@ -448,6 +665,15 @@ func protoOptConfigToDomain(cfg *pb.OptimizationConfig) domain.OptimizationConfi
 		BacktestConfig: protoConfigToDomain(cfg.BacktestConfig),
 		MaxIterations:  int(cfg.MaxIterations),
 		Mode:           protoOptModeToDomain(cfg.Mode),
+		SnapshotCode:   cfg.SnapshotCode,
+	}
+
+	if cfg.Quota != nil {
+		config.Quota = &domain.OptimizationQuota{
+			MaxConcurrentJobs: int(cfg.Quota.MaxConcurrentJobs),
+			CPULimit:          cfg.Quota.CpuLimit,
+			MemoryLimitMB:     int(cfg.Quota.MemoryLimitMb),
+		}
 	}
 
 	if cfg.Criteria != nil {
@@ -487,16 +713,27 @@ func domainOptRunToProto(run *domain.OptimizationRun) *pb.OptimizationRun {
 		proto.BestStrategyId = &bestStrategyID
 	}
 
+	for _, id := range run.SeedStrategyIDs {
+		proto.SeedStrategyIds = append(proto.SeedStrategyIds, id.String())
+	}
+
 	if run.CompletedAt != nil {
 		proto.CompletedAt = timestamppb.New(*run.CompletedAt)
 	}
 
+	if run.ExternalRef != nil {
+		proto.ExternalRef = run.ExternalRef
+	}
+
+	proto.CancelReason = run.CancelReason
+	proto.CancelledBy = run.CancelledBy
+
 	return proto
 }
 
 // domainOptConfigToProto converts a domain.OptimizationConfig to a pb.OptimizationConfig.
 func domainOptConfigToProto(cfg domain.OptimizationConfig) *pb.OptimizationConfig {
-	return &pb.OptimizationConfig{
+	proto := &pb.OptimizationConfig{
 		BacktestConfig: domainConfigToProto(cfg.BacktestConfig),
 		MaxIterations:  int32(cfg.MaxIterations),
 		Criteria: &pb.OptimizationCriteria{
@@ -506,8 +743,19 @@ func domainOptConfigToProto(cfg domain.OptimizationConfig) *pb.OptimizationConfi
 			MinTrades:      int32(cfg.Criteria.MinTrades),
 			MinWinRate:     cfg.Criteria.MinWinRate,
 		},
-		Mode: domainOptModeToProto(cfg.Mode),
+		Mode:         domainOptModeToProto(cfg.Mode),
+		SnapshotCode: cfg.SnapshotCode,
+	}
+
+	if cfg.Quota != nil {
+		proto.Quota = &pb.OptimizationQuota{
+			MaxConcurrentJobs: int32(cfg.Quota.MaxConcurrentJobs),
+			CpuLimit:          cfg.Quota.CPULimit,
+			MemoryLimitMb:     int32(cfg.Quota.MemoryLimitMB),
+		}
 	}
+
+	return proto
 }
 
 // domainIterationToProto converts a domain.OptimizationIteration to a pb.OptimizationIteration.
@@ -524,6 +772,29 @@ func domainIterationToProto(iter *domain.OptimizationIteration) *pb.Optimization
 		AnalystFeedback: iter.AnalystFeedback,
 		Approval:        domainApprovalStatusToProto(iter.Approval),
 		Timestamp:       timestamppb.New(iter.CreatedAt),
+		CodeHash:        iter.CodeHash,
+		CodeSnapshot:    iter.CodeSnapshot,
+	}
+
+	return proto
+}
+
+// domainOptProgressToProto converts a domain.OptimizationProgress to a pb.OptimizationProgress.
+func domainOptProgressToProto(p *domain.OptimizationProgress) *pb.OptimizationProgress {
+	if p == nil {
+		return nil
+	}
+
+	proto := &pb.OptimizationProgress{
+		CompletedIterations: int32(p.CompletedIterations),
+		MaxIterations:       int32(p.MaxIterations),
+		PercentComplete:     p.PercentComplete,
+		ElapsedMs:           p.ElapsedMs,
+		AvgIterationMs:      p.AvgIterationMs,
+		RemainingMs:         p.RemainingMs,
+	}
+	if p.EstimatedCompletionAt != nil {
+		proto.EstimatedCompletionAt = timestamppb.New(*p.EstimatedCompletionAt)
 	}
 
 	return proto
@@ -544,6 +815,8 @@ func domainOptStatusToProto(status domain.OptimizationStatus) pb.OptimizationSta
 		return pb.OptimizationStatus_OPTIMIZATION_STATUS_FAILED
 	case domain.OptimizationStatusCancelled:
 		return pb.OptimizationStatus_OPTIMIZATION_STATUS_CANCELLED
+	case domain.OptimizationStatusStalled:
+		return pb.OptimizationStatus_OPTIMIZATION_STATUS_STALLED
 	default:
 		return pb.OptimizationStatus_OPTIMIZATION_STATUS_UNSPECIFIED
 	}
@@ -564,6 +837,8 @@ func protoOptStatusToDomain(status pb.OptimizationStatus) domain.OptimizationSta
 		return domain.OptimizationStatusFailed
 	case pb.OptimizationStatus_OPTIMIZATION_STATUS_CANCELLED:
 		return domain.OptimizationStatusCancelled
+	case pb.OptimizationStatus_OPTIMIZATION_STATUS_STALLED:
+		return domain.OptimizationStatusStalled
 	default:
 		return domain.OptimizationStatusPending
 	}
@@ -616,3 +891,141 @@ func domainApprovalStatusToProto(status domain.ApprovalStatus) pb.ApprovalStatus
 		return pb.ApprovalStatus_APPROVAL_STATUS_UNSPECIFIED
 	}
 }
+
+// domainOrchestratorActionToProto converts a domain.OrchestratorAction to a pb.ClaimNextOptimizationActionResponse.
+func domainOrchestratorActionToProto(action *domain.OrchestratorAction) *pb.ClaimNextOptimizationActionResponse {
+	resp := &pb.ClaimNextOptimizationActionResponse{
+		RunId:           action.RunID.String(),
+		Action:          domainNextActionTypeToProto(action.Type),
+		IterationNumber: int32(action.IterationNumber),
+		Reason:          action.Reason,
+		Feedback:        action.Feedback,
+		Iteration:       domainIterationToProto(action.Iteration),
+		ClaimedBy:       action.ClaimedBy,
+	}
+
+	if action.SourceStrategyID != nil {
+		resp.SourceStrategyId = stringPtr(action.SourceStrategyID.String())
+	}
+	if action.ResultID != nil {
+		resp.ResultId = stringPtr(action.ResultID.String())
+	}
+	if action.ClaimExpiresAt != nil {
+		resp.ClaimExpiresAt = timestamppb.New(*action.ClaimExpiresAt)
+	}
+
+	return resp
+}
+
+// domainNextActionTypeToProto converts a domain.OrchestratorActionType to a pb.NextActionType.
+func domainNextActionTypeToProto(t domain.OrchestratorActionType) pb.NextActionType {
+	switch t {
+	case domain.OrchestratorActionNone:
+		return pb.NextActionType_NEXT_ACTION_TYPE_NONE
+	case domain.OrchestratorActionGenerateCandidate:
+		return pb.NextActionType_NEXT_ACTION_TYPE_GENERATE_CANDIDATE
+	case domain.OrchestratorActionAwaitResults:
+		return pb.NextActionType_NEXT_ACTION_TYPE_AWAIT_RESULTS
+	case domain.OrchestratorActionEvaluateCriteria:
+		return pb.NextActionType_NEXT_ACTION_TYPE_EVALUATE_CRITERIA
+	case domain.OrchestratorActionFinalize:
+		return pb.NextActionType_NEXT_ACTION_TYPE_FINALIZE
+	default:
+		return pb.NextActionType_NEXT_ACTION_TYPE_UNSPECIFIED
+	}
+}
+
+// domainAgentRegistrationToProto converts a domain.AgentRegistration to a pb.AgentRegistration.
+func domainAgentRegistrationToProto(reg *domain.AgentRegistration) *pb.AgentRegistration {
+	versions := make([]int32, len(reg.EventSchemaVersions))
+	for i, v := range reg.EventSchemaVersions {
+		versions[i] = int32(v)
+	}
+
+	return &pb.AgentRegistration{
+		AgentId:             reg.AgentID,
+		Type:                string(reg.Type),
+		Version:             reg.Version,
+		EventSchemaVersions: versions,
+		Capabilities:        reg.Capabilities,
+		RegisteredAt:        timestamppb.New(reg.RegisteredAt),
+		LastSeenAt:          timestamppb.New(reg.LastSeenAt),
+	}
+}
+
+// domainStrategyDiffToProto converts a domain.StrategyDiff to a pb.DiffStrategiesResponse.
+func domainStrategyDiffToProto(diff *domain.StrategyDiff) *pb.DiffStrategiesResponse {
+	changes := make([]*pb.SettingChange, len(diff.Changes))
+	for i, c := range diff.Changes {
+		changes[i] = &pb.SettingChange{
+			Field:     c.Field,
+			FromValue: c.From,
+			ToValue:   c.To,
+		}
+	}
+
+	return &pb.DiffStrategiesResponse{
+		FromId:            diff.FromID.String(),
+		ToId:              diff.ToID.String(),
+		Identical:         diff.Identical,
+		UnifiedDiff:       diff.UnifiedDiff,
+		LinesAdded:        int32(diff.LinesAdded),
+		LinesRemoved:      int32(diff.LinesRemoved),
+		Changes:           changes,
+		IndicatorsAdded:   diff.IndicatorsAdded,
+		IndicatorsRemoved: diff.IndicatorsRemoved,
+	}
+}
+
+// domainSchedulerStatusToProto converts a domain.SchedulerStatus to a pb.SchedulerStatus.
+func domainSchedulerStatusToProto(st *domain.SchedulerStatus) *pb.SchedulerStatus {
+	mode := pb.SchedulerMode_SCHEDULER_MODE_UNSPECIFIED
+	switch st.Mode {
+	case domain.SchedulerModeRunning:
+		mode = pb.SchedulerMode_SCHEDULER_MODE_RUNNING
+	case domain.SchedulerModePaused:
+		mode = pb.SchedulerMode_SCHEDULER_MODE_PAUSED
+	case domain.SchedulerModeDraining:
+		mode = pb.SchedulerMode_SCHEDULER_MODE_DRAINING
+	}
+
+	return &pb.SchedulerStatus{
+		Mode:        mode,
+		Since:       timestamppb.New(st.Since),
+		ChangedBy:   st.ChangedBy,
+		ClaimedJobs: int32(st.ClaimedJobs),
+		ActiveJobs:  int32(st.ActiveJobs),
+		Drained:     st.Drained,
+	}
+}
+
+// domainScoutDiscoveryToProto converts a domain.ScoutDiscovery to a pb.ScoutDiscovery.
+func domainScoutDiscoveryToProto(d *domain.ScoutDiscovery) *pb.ScoutDiscovery {
+	proto := &pb.ScoutDiscovery{
+		Id:               d.ID.String(),
+		RunId:            d.RunID.String(),
+		Name:             d.Name,
+		Source:           d.Source,
+		SourceUrl:        d.SourceURL,
+		CodeHash:         d.CodeHash,
+		ValidationStatus: string(d.ValidationStatus),
+		ValidationErrors: d.ValidationErrors,
+		Outcome:          string(d.Outcome),
+		Imported:         d.Imported,
+		OutcomeDetail:    d.OutcomeDetail,
+		DiscoveredAt:     timestamppb.New(d.DiscoveredAt),
+	}
+	if d.StrategyID != nil {
+		proto.StrategyId = stringPtr(d.StrategyID.String())
+	}
+	return proto
+}
+
+// protoScoutDiscoveryToDomain converts a pb.ScoutDiscovery reported by the
+// Scout agent to a domain.ScoutDiscovery of the run. The outcome is left for
+// the backend to set when the strategy is submitted for import.
+func protoScoutDiscoveryToDomain(runID uuid.UUID, d *pb.ScoutDiscovery) *domain.ScoutDiscovery {
+	discovery := domain.NewScoutDiscovery(runID, d.Name, d.Source, d.SourceUrl, d.CodeHash, domain.ScoutValidationStatus(d.ValidationStatus))
+	discovery.ValidationErrors = d.ValidationErrors
+	return discovery
+}
@@ -0,0 +1,325 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
+)
+
+// GRPCWebPathPrefix is the URL path prefix of the service's gRPC-Web methods,
+// e.g. /freqsearch.v1.FreqSearchService/GetStrategy.
+var GRPCWebPathPrefix = "/" + pb.FreqSearchService_ServiceDesc.ServiceName + "/"
+
+// gRPC-Web content types. The text variants carry base64 encoded frames for
+// clients that can't read binary response bodies.
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+)
+
+// grpcWebExcludedMethods are not served over gRPC-Web, which browsers reach:
+// they are for agents only.
+var grpcWebExcludedMethods = map[string]bool{
+	"GetScoutCredential": true,
+}
+
+// gRPC-Web frame flags.
+const (
+	grpcWebFlagData       byte = 0x00
+	grpcWebFlagCompressed byte = 0x01
+	grpcWebFlagTrailer    byte = 0x80
+)
+
+// grpcWebHandler serves the service's unary methods over the gRPC-Web
+// protocol on a plain HTTP/1.1 server. Requests are dispatched in-process to
+// the same method handlers the gRPC server registers, so both transports
+// share one implementation.
+type grpcWebHandler struct {
+	server         *Server
+	methods        map[string]grpc.MethodDesc
+	maxMessageSize int
+	interceptor    grpc.UnaryServerInterceptor // Runs calls as the gRPC server does
+	logger         *zap.Logger
+}
+
+// GRPCWebHandler returns an http.Handler serving the service over gRPC-Web
+// (binary and text encodings), to be mounted at GRPCWebPathPrefix. Only unary
+// methods are supported; compressed request messages are rejected.
+func (s *Server) GRPCWebHandler(cfg *config.GRPCWebConfig) http.Handler {
+	methods := make(map[string]grpc.MethodDesc, len(pb.FreqSearchService_ServiceDesc.Methods))
+	for _, m := range pb.FreqSearchService_ServiceDesc.Methods {
+		if !grpcWebExcludedMethods[m.MethodName] {
+			methods[m.MethodName] = m
+		}
+	}
+
+	maxMessageSize := cfg.MaxMessageSize
+	if maxMessageSize <= 0 {
+		maxMessageSize = 4 * 1024 * 1024
+	}
+
+	return &grpcWebHandler{
+		server:         s,
+		methods:        methods,
+		maxMessageSize: maxMessageSize,
+		interceptor:    s.unaryInterceptor(),
+		logger:         s.logger,
+	}
+}
+
+// ServeHTTP handles a single gRPC-Web call.
+func (h *grpcWebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	text := strings.HasPrefix(contentType, grpcWebTextContentType)
+	if !text && !strings.HasPrefix(contentType, grpcWebContentType) {
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	methodName := strings.TrimPrefix(r.URL.Path, GRPCWebPathPrefix)
+	fullMethod := GRPCWebPathPrefix + methodName
+
+	w.Header().Set("Content-Type", contentType)
+	resp, header, trailer, err := h.invoke(r, methodName, fullMethod, text)
+	for k, vs := range header {
+		for _, v := range vs {
+			w.Header().Add(k, encodeGRPCWebHeaderValue(k, v))
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+
+	var body bytes.Buffer
+	if err == nil {
+		writeGRPCWebFrame(&body, grpcWebFlagData, resp)
+	}
+	writeGRPCWebFrame(&body, grpcWebFlagTrailer, grpcWebTrailer(status.Convert(err), trailer))
+
+	out := body.Bytes()
+	if text {
+		out = []byte(base64.StdEncoding.EncodeToString(out))
+	}
+	if _, err := w.Write(out); err != nil {
+		h.logger.Debug("Failed to write gRPC-Web response",
+			zap.String("method", fullMethod),
+			zap.Error(err),
+		)
+	}
+}
+
+// invoke decodes the request message and calls the method. It returns the
+// encoded response message and the header and trailer metadata set by the
+// method.
+func (h *grpcWebHandler) invoke(r *http.Request, methodName, fullMethod string, text bool) ([]byte, metadata.MD, metadata.MD, error) {
+	desc, ok := h.methods[methodName]
+	if !ok {
+		return nil, nil, nil, status.Errorf(grpccodes.Unimplemented, "unknown method %s", fullMethod)
+	}
+
+	var body io.Reader = io.LimitReader(r.Body, int64(h.maxMessageSize)*2+5)
+	if text {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	msg, err := readGRPCWebFrame(body, h.maxMessageSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), grpcWebMetadata(r.Header))
+	if timeout := r.Header.Get("Grpc-Timeout"); timeout != "" {
+		d, err := parseGRPCTimeout(timeout)
+		if err != nil {
+			return nil, nil, nil, status.Errorf(grpccodes.InvalidArgument, "invalid grpc-timeout: %v", err)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	stream := &grpcWebTransportStream{method: fullMethod}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	dec := func(v interface{}) error {
+		if err := proto.Unmarshal(msg, v.(proto.Message)); err != nil {
+			return status.Errorf(grpccodes.InvalidArgument, "failed to decode request: %v", err)
+		}
+		return nil
+	}
+
+	resp, err := desc.Handler(h.server, ctx, dec, h.interceptor)
+	if err != nil {
+		return nil, stream.header, stream.trailer, err
+	}
+
+	out, err := proto.Marshal(resp.(proto.Message))
+	if err != nil {
+		return nil, stream.header, stream.trailer, status.Errorf(grpccodes.Internal, "failed to encode response: %v", err)
+	}
+	return out, stream.header, stream.trailer, nil
+}
+
+// readGRPCWebFrame reads the single length-prefixed request message.
+func readGRPCWebFrame(r io.Reader, maxSize int) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "failed to read message frame: %v", err)
+	}
+	if prefix[0]&grpcWebFlagCompressed != 0 {
+		return nil, status.Error(grpccodes.Unimplemented, "compressed messages are not supported")
+	}
+
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if int64(size) > int64(maxSize) {
+		return nil, status.Errorf(grpccodes.ResourceExhausted, "message of %d bytes exceeds the limit of %d", size, maxSize)
+	}
+
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "failed to read message: %v", err)
+	}
+	return msg, nil
+}
+
+// writeGRPCWebFrame writes a length-prefixed frame.
+func writeGRPCWebFrame(w *bytes.Buffer, flag byte, payload []byte) {
+	var prefix [5]byte
+	prefix[0] = flag
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(payload)))
+	w.Write(prefix[:])
+	w.Write(payload)
+}
+
+// grpcWebTrailer returns the trailer frame payload: the call status and the
+// trailer metadata as HTTP/1 style header lines.
+func grpcWebTrailer(st *status.Status, trailer metadata.MD) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "grpc-status: %d\r\n", st.Code())
+	if msg := st.Message(); msg != "" {
+		fmt.Fprintf(&b, "grpc-message: %s\r\n", percentEncodeGRPCMessage(msg))
+	}
+	for k, vs := range trailer {
+		for _, v := range vs {
+			fmt.Fprintf(&b, "%s: %s\r\n", k, encodeGRPCWebHeaderValue(k, v))
+		}
+	}
+	return b.Bytes()
+}
+
+// grpcWebMetadata converts request headers to incoming gRPC metadata, so
+// methods see e.g. X-User-ID as they do over gRPC.
+func grpcWebMetadata(header http.Header) metadata.MD {
+	md := make(metadata.MD, len(header))
+	for k, vs := range header {
+		key := strings.ToLower(k)
+		switch key {
+		case "content-length", "connection", "host", "grpc-timeout":
+			continue
+		}
+		for _, v := range vs {
+			if strings.HasSuffix(key, "-bin") {
+				decoded, err := base64.StdEncoding.DecodeString(v)
+				if err != nil {
+					if decoded, err = base64.RawStdEncoding.DecodeString(v); err != nil {
+						continue
+					}
+				}
+				v = string(decoded)
+			}
+			md.Append(key, v)
+		}
+	}
+	return md
+}
+
+// encodeGRPCWebHeaderValue base64 encodes binary metadata values.
+func encodeGRPCWebHeaderValue(key, value string) string {
+	if strings.HasSuffix(key, "-bin") {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	return value
+}
+
+// percentEncodeGRPCMessage encodes a status message as the gRPC protocol
+// requires: bytes outside printable ASCII and '%' are percent-encoded.
+func percentEncodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// parseGRPCTimeout parses a grpc-timeout header value, e.g. "500m" for 500ms.
+func parseGRPCTimeout(v string) (time.Duration, error) {
+	if len(v) < 2 || len(v) > 9 {
+		return 0, fmt.Errorf("malformed timeout %q", v)
+	}
+	n, err := strconv.ParseInt(v[:len(v)-1], 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("malformed timeout %q", v)
+	}
+
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[v[len(v)-1]]
+	if !ok {
+		return 0, fmt.Errorf("unknown timeout unit in %q", v)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// grpcWebTransportStream collects the header and trailer metadata a method
+// sets with grpc.SetHeader and grpc.SetTrailer.
+type grpcWebTransportStream struct {
+	method  string
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *grpcWebTransportStream) Method() string { return s.method }
+
+func (s *grpcWebTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *grpcWebTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *grpcWebTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
+)
+
+// grpcWebCall posts a message to a gRPC-Web method and returns the response
+// frames by flag.
+func grpcWebCall(t *testing.T, handler http.Handler, method, contentType string, msg proto.Message) (*httptest.ResponseRecorder, map[byte][]byte) {
+	t.Helper()
+
+	payload, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	var body bytes.Buffer
+	writeGRPCWebFrame(&body, grpcWebFlagData, payload)
+
+	reqBody := body.Bytes()
+	text := strings.HasPrefix(contentType, grpcWebTextContentType)
+	if text {
+		reqBody = []byte(base64.StdEncoding.EncodeToString(reqBody))
+	}
+
+	req := httptest.NewRequest(http.MethodPost, GRPCWebPathPrefix+method, bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	out := rec.Body.Bytes()
+	if text {
+		out, err = base64.StdEncoding.DecodeString(string(out))
+		require.NoError(t, err)
+	}
+
+	frames := map[byte][]byte{}
+	r := bytes.NewReader(out)
+	for {
+		var prefix [5]byte
+		if _, err := io.ReadFull(r, prefix[:]); err == io.EOF {
+			break
+		} else {
+			require.NoError(t, err)
+		}
+		frame := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+		_, err := io.ReadFull(r, frame)
+		require.NoError(t, err)
+		frames[prefix[0]] = frame
+	}
+	return rec, frames
+}
+
+func TestGRPCWebHandler_UnaryCall(t *testing.T) {
+	server := NewServer(nil, nil, nil, zaptest.NewLogger(t))
+	handler := server.GRPCWebHandler(&config.GRPCWebConfig{Enabled: true})
+
+	rec, frames := grpcWebCall(t, handler, "HealthCheck", "application/grpc-web+proto", &pb.HealthCheckRequest{})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/grpc-web+proto", rec.Header().Get("Content-Type"))
+
+	var resp pb.HealthCheckResponse
+	require.NoError(t, proto.Unmarshal(frames[grpcWebFlagData], &resp))
+	assert.True(t, resp.Healthy)
+	assert.Contains(t, string(frames[grpcWebFlagTrailer]), "grpc-status: 0\r\n")
+}
+
+func TestGRPCWebHandler_Errors(t *testing.T) {
+	server := NewServer(nil, nil, nil, zaptest.NewLogger(t))
+	handler := server.GRPCWebHandler(&config.GRPCWebConfig{Enabled: true})
+
+	// Method errors are reported in the trailer, in text mode too
+	_, frames := grpcWebCall(t, handler, "GetStrategy", "application/grpc-web-text", &pb.GetStrategyRequest{Id: "not-a-uuid"})
+	assert.NotContains(t, frames, grpcWebFlagData)
+	trailer := string(frames[grpcWebFlagTrailer])
+	assert.Contains(t, trailer, "grpc-status: 3\r\n")
+	assert.Contains(t, trailer, "grpc-message: invalid id")
+
+	_, frames = grpcWebCall(t, handler, "NoSuchMethod", "application/grpc-web", &pb.HealthCheckRequest{})
+	assert.Contains(t, string(frames[grpcWebFlagTrailer]), "grpc-status: 12\r\n")
+
+	// Agent only methods are not served
+	_, frames = grpcWebCall(t, handler, "GetScoutCredential", "application/grpc-web", &pb.GetScoutCredentialRequest{})
+	assert.Contains(t, string(frames[grpcWebFlagTrailer]), "grpc-status: 12\r\n")
+
+	// Non gRPC-Web requests are rejected at the HTTP level
+	req := httptest.NewRequest(http.MethodPost, GRPCWebPathPrefix+"HealthCheck", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestParseGRPCTimeout(t *testing.T) {
+	d, err := parseGRPCTimeout("1500m")
+	require.NoError(t, err)
+	assert.Equal(t, "1.5s", d.String())
+
+	for _, v := range []string{"", "5", "10x", "-1S"} {
+		_, err := parseGRPCTimeout(v)
+		assert.Error(t, err, v)
+	}
+}
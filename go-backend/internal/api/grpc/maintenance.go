@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Maintenance reports the system-wide maintenance mode.
+type Maintenance interface {
+	Current() domain.MaintenanceMode
+}
+
+// SetMaintenance sets the maintenance mode, which rejects submissions while
+// it is enabled.
+func (s *Server) SetMaintenance(m Maintenance) {
+	s.maintenance = m
+}
+
+// checkMaintenance returns an Unavailable error carrying the maintenance
+// message if submissions are rejected for maintenance mode.
+func (s *Server) checkMaintenance() error {
+	if s.maintenance == nil {
+		return nil
+	}
+	if mode := s.maintenance.Current(); mode.Enabled {
+		return status.Error(codes.Unavailable, mode.RejectionMessage())
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/ranking"
+	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
+)
+
+// SetRanker sets the ranker behind the percentile ranks of backtest results.
+// Without it results are returned without percentile ranks.
+func (s *Server) SetRanker(r *ranking.Ranker) {
+	s.ranker = r
+}
+
+// rankResult sets the percentile ranks of a job's result within its cohort.
+// The result is returned without them if they cannot be computed.
+func (s *Server) rankResult(ctx context.Context, result *domain.BacktestResult) {
+	if s.ranker == nil {
+		return
+	}
+
+	job, err := s.repos.BacktestJob.GetByID(ctx, result.JobID)
+	if err == nil {
+		err = s.ranker.Rank(ctx, result, &job.Config)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to rank backtest result",
+			zap.Error(err),
+			zap.String("job_id", result.JobID.String()))
+	}
+}
+
+// domainPercentilesToProto converts *domain.PercentileRanks to *pb.PercentileRanks.
+func domainPercentilesToProto(ranks *domain.PercentileRanks) *pb.PercentileRanks {
+	if ranks == nil {
+		return nil
+	}
+	return &pb.PercentileRanks{
+		CohortPairs:          ranks.Cohort.Pairs,
+		CohortTimerangeStart: ranks.Cohort.TimerangeStart,
+		CohortTimerangeEnd:   ranks.Cohort.TimerangeEnd,
+		CohortSize:           int32(ranks.CohortSize),
+		Sharpe:               ranks.Sharpe,
+		Profit:               ranks.Profit,
+		Drawdown:             ranks.Drawdown,
+	}
+}
@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
+	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
+)
+
+// RecordScoutDiscoveries records the strategies a Scout run fetched, so those
+// it did not submit for import are accounted for too. Outcomes in the request
+// are ignored; the backend sets them when strategies are submitted.
+func (s *Server) RecordScoutDiscoveries(ctx context.Context, req *pb.RecordScoutDiscoveriesRequest) (*pb.RecordScoutDiscoveriesResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "FreqSearchService.RecordScoutDiscoveries")
+	defer span.End()
+
+	runID, err := uuid.Parse(req.RunId)
+	if err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid run_id: %v", err)
+	}
+	span.SetAttributes(
+		attribute.String("run_id", req.RunId),
+		attribute.Int("discoveries", len(req.Discoveries)),
+	)
+
+	discoveries := make([]*domain.ScoutDiscovery, len(req.Discoveries))
+	for i, d := range req.Discoveries {
+		discoveries[i] = protoScoutDiscoveryToDomain(runID, d)
+		if err := discoveries[i].Validate(); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "invalid discovery")
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid discovery %d: %v", i, err)
+		}
+	}
+
+	if err := s.repos.Scout.RecordDiscoveries(ctx, discoveries); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrNotFound) {
+			span.SetStatus(codes.Error, "scout run not found")
+			return nil, status.Errorf(grpccodes.NotFound, "scout run not found")
+		}
+		span.SetStatus(codes.Error, "failed to record discoveries")
+		s.logger.Error("Failed to record scout discoveries", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to record discoveries")
+	}
+
+	resp := &pb.RecordScoutDiscoveriesResponse{
+		Discoveries: make([]*pb.ScoutDiscovery, len(discoveries)),
+	}
+	for i, d := range discoveries {
+		resp.Discoveries[i] = domainScoutDiscoveryToProto(d)
+	}
+	return resp, nil
+}
+
+// ListScoutDiscoveries lists the strategies a Scout run discovered, oldest
+// first, optionally filtered by outcome and validation status.
+func (s *Server) ListScoutDiscoveries(ctx context.Context, req *pb.ListScoutDiscoveriesRequest) (*pb.ListScoutDiscoveriesResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "FreqSearchService.ListScoutDiscoveries")
+	defer span.End()
+
+	runID, err := uuid.Parse(req.RunId)
+	if err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid run_id: %v", err)
+	}
+	span.SetAttributes(attribute.String("run_id", req.RunId))
+
+	query := domain.ScoutDiscoveryQuery{RunID: runID}
+	if req.Outcome != nil {
+		outcome := domain.ScoutDiscoveryOutcome(*req.Outcome)
+		if !outcome.IsValid() {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "unknown outcome %q", *req.Outcome)
+		}
+		query.Outcome = &outcome
+	}
+	if req.ValidationStatus != nil {
+		validation := domain.ScoutValidationStatus(*req.ValidationStatus)
+		if !validation.IsValid() {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "unknown validation_status %q", *req.ValidationStatus)
+		}
+		query.ValidationStatus = &validation
+	}
+
+	if _, err := s.repos.Scout.GetRunByID(ctx, runID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Errorf(grpccodes.NotFound, "scout run not found")
+		}
+		span.RecordError(err)
+		return nil, status.Errorf(grpccodes.Internal, "failed to get scout run")
+	}
+
+	discoveries, err := s.repos.Scout.ListDiscoveries(ctx, query)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to list discoveries")
+		s.logger.Error("Failed to list scout discoveries", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to list discoveries")
+	}
+
+	resp := &pb.ListScoutDiscoveriesResponse{
+		Discoveries: make([]*pb.ScoutDiscovery, len(discoveries)),
+	}
+	for i, d := range discoveries {
+		resp.Discoveries[i] = domainScoutDiscoveryToProto(d)
+	}
+	return resp, nil
+}
+
+// SetSecrets sets the store GetScoutCredential decrypts source credentials
+// from.
+func (s *Server) SetSecrets(store *secrets.Store) {
+	s.secrets = store
+}
+
+// GetScoutCredential decrypts the source credential a scout.trigger event
+// references, so the Scout agent gets it without it being published.
+func (s *Server) GetScoutCredential(ctx context.Context, req *pb.GetScoutCredentialRequest) (*pb.GetScoutCredentialResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "FreqSearchService.GetScoutCredential")
+	defer span.End()
+
+	secretID, err := uuid.Parse(req.SecretId)
+	if err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid secret_id: %v", err)
+	}
+	span.SetAttributes(attribute.String("secret_id", req.SecretId))
+
+	credential, err := s.secrets.Reveal(ctx, secretID)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			span.SetStatus(codes.Error, "secret not found")
+			return nil, status.Errorf(grpccodes.NotFound, "secret not found")
+		case errors.Is(err, secrets.ErrNotConfigured):
+			span.SetStatus(codes.Error, "secrets store not configured")
+			return nil, status.Errorf(grpccodes.FailedPrecondition, "%v", err)
+		}
+		span.SetStatus(codes.Error, "failed to read credential")
+		s.logger.Error("Failed to read scout credential", zap.String("secret_id", req.SecretId), zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to read credential")
+	}
+	return &pb.GetScoutCredentialResponse{Credential: credential}, nil
+}
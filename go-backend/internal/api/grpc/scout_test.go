@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
+	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
+)
+
+// mockSecretRepository keeps secrets in memory.
+type mockSecretRepository struct {
+	repository.SecretRepository
+	secrets map[uuid.UUID]*domain.Secret
+}
+
+func (m *mockSecretRepository) Create(ctx context.Context, secret *domain.Secret) error {
+	m.secrets[secret.ID] = secret
+	return nil
+}
+
+func (m *mockSecretRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Secret, error) {
+	if secret, ok := m.secrets[id]; ok {
+		return secret, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func TestGetScoutCredential(t *testing.T) {
+	server := NewServer(nil, nil, nil, zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	_, err := server.GetScoutCredential(ctx, &pb.GetScoutCredentialRequest{SecretId: uuid.NewString()})
+	assert.Equal(t, grpccodes.FailedPrecondition, status.Code(err), "no secrets store")
+
+	cipher, err := secrets.NewCipher(bytes.Repeat([]byte{7}, secrets.KeySize))
+	require.NoError(t, err)
+	store := secrets.NewStore(&mockSecretRepository{secrets: map[uuid.UUID]*domain.Secret{}}, cipher)
+	secret, err := store.Create(ctx, "github-token", "", "ghp_example")
+	require.NoError(t, err)
+	server.SetSecrets(store)
+
+	resp, err := server.GetScoutCredential(ctx, &pb.GetScoutCredentialRequest{SecretId: secret.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_example", resp.Credential)
+
+	_, err = server.GetScoutCredential(ctx, &pb.GetScoutCredentialRequest{SecretId: uuid.NewString()})
+	assert.Equal(t, grpccodes.NotFound, status.Code(err))
+
+	_, err = server.GetScoutCredential(ctx, &pb.GetScoutCredentialRequest{SecretId: "not-a-uuid"})
+	assert.Equal(t, grpccodes.InvalidArgument, status.Code(err))
+}
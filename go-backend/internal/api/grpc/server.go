@@ -4,8 +4,11 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
@@ -15,13 +18,19 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/saltfish/freqsearch/go-backend/internal/accesslog"
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
 	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 	"github.com/saltfish/freqsearch/go-backend/internal/events"
+	"github.com/saltfish/freqsearch/go-backend/internal/ranking"
 	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
+	"github.com/saltfish/freqsearch/go-backend/internal/strategycode"
 	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
 )
 
@@ -35,7 +44,42 @@ type Server struct {
 	logger         *zap.Logger
 	tracer         trace.Tracer
 
+	agentTTL      time.Duration // Registrations not seen for longer are not live; 0 keeps them live
+	enforceAgents bool          // Refuse runs no live registered agent can serve
+
+	auth          *auth.Authenticator
+	accessLog     *accesslog.Logger // Nil disables the access log
+	maintenance   Maintenance
+	watchInterval time.Duration // How often WatchBacktestJob checks for changes
+	ranker        *ranking.Ranker
+	codeSafety    *domain.CodeSafetyPolicy // Nil allows any strategy code
+	secrets       *secrets.Store           // Nil makes scout credentials unavailable
+
 	grpcServer *grpc.Server
+	done       chan struct{} // Closed on Stop, ending open watch streams
+}
+
+// principalMetadataKey carries the calling principal, mirroring the HTTP X-User-ID header.
+const principalMetadataKey = "x-user-id"
+
+// requestPrincipal returns the calling principal from incoming metadata.
+func requestPrincipal(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get(principalMetadataKey) {
+			if v = strings.TrimSpace(v); v != "" {
+				return v
+			}
+		}
+	}
+	return domain.DefaultPreferenceOwner
+}
+
+// warnPageSizeClamped tells the client with a warning header that its
+// requested page size exceeded the maximum and was lowered to pageSize.
+func warnPageSizeClamped(ctx context.Context, pagination *pb.PaginationRequest, pageSize int) {
+	if requested := int(pagination.GetPageSize()); requested > pageSize {
+		grpc.SetHeader(ctx, metadata.Pairs("warning", fmt.Sprintf("page_size %d exceeds the maximum of %d; pages hold %d items", requested, pageSize, pageSize)))
+	}
 }
 
 // NewServer creates a new gRPC server.
@@ -51,9 +95,16 @@ func NewServer(
 		eventPublisher: eventPublisher,
 		logger:         logger,
 		tracer:         otel.Tracer("freqsearch.grpc"),
+		done:           make(chan struct{}),
 	}
 }
 
+// SetCodeSafety sets the static checks strategy code must pass before it is
+// stored.
+func (s *Server) SetCodeSafety(policy *domain.CodeSafetyPolicy) {
+	s.codeSafety = policy
+}
+
 // Start starts the gRPC server.
 func (s *Server) Start(address string) error {
 	lis, err := net.Listen("tcp", address)
@@ -61,15 +112,17 @@ func (s *Server) Start(address string) error {
 		return err
 	}
 
-	s.grpcServer = grpc.NewServer()
+	s.grpcServer = grpc.NewServer(s.serverOptions()...)
 	pb.RegisterFreqSearchServiceServer(s.grpcServer, s)
 
 	s.logger.Info("gRPC server starting", zap.String("address", address))
 	return s.grpcServer.Serve(lis)
 }
 
-// Stop gracefully stops the gRPC server.
+// Stop gracefully stops the gRPC server. Open watch streams are ended first,
+// since graceful stops wait for every call to finish.
 func (s *Server) Stop() {
+	close(s.done)
 	if s.grpcServer != nil {
 		s.grpcServer.GracefulStop()
 	}
@@ -77,6 +130,10 @@ func (s *Server) Stop() {
 
 // CreateStrategy creates a new strategy.
 func (s *Server) CreateStrategy(ctx context.Context, req *pb.CreateStrategyRequest) (*pb.CreateStrategyResponse, error) {
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
 	// Sanitize strategy name to ensure valid Python class name
 	sanitizedName := domain.SanitizeStrategyName(req.Name)
 
@@ -118,6 +175,21 @@ func (s *Server) CreateStrategy(ctx context.Context, req *pb.CreateStrategyReque
 		strategy.ParentID = &parentID
 	}
 
+	if req.ValidationStatus != nil {
+		validationStatus := domain.ValidationStatus(*req.ValidationStatus)
+		if !validationStatus.IsValid() {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid validation_status: %q", *req.ValidationStatus)
+		}
+		validatedAt := time.Now()
+		strategy.ValidationStatus = validationStatus
+		strategy.ValidationErrors = req.ValidationErrors
+		strategy.ValidatedAt = &validatedAt
+	}
+
+	if err := s.codeSafety.Verify(strategy.Code); err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "%v", err)
+	}
+
 	if err := s.repos.Strategy.Create(ctx, strategy); err != nil {
 		if errors.Is(err, domain.ErrDuplicate) {
 			return nil, status.Errorf(grpccodes.AlreadyExists, "strategy with same code already exists")
@@ -126,6 +198,10 @@ func (s *Server) CreateStrategy(ctx context.Context, req *pb.CreateStrategyReque
 		return nil, status.Errorf(grpccodes.Internal, "failed to create strategy")
 	}
 
+	if err := s.eventPublisher.PublishStrategyCreated(strategy); err != nil {
+		s.logger.Warn("Failed to publish strategy created event", zap.Error(err), zap.String("strategy_id", strategy.ID.String()))
+	}
+
 	return &pb.CreateStrategyResponse{
 		Strategy: domainStrategyToProto(strategy),
 	}, nil
@@ -158,6 +234,15 @@ func (s *Server) DeleteStrategy(ctx context.Context, req *pb.DeleteStrategyReque
 		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid id: %v", err)
 	}
 
+	// Load the strategy first so the deleted event can carry its code hash and parent
+	strategy, err := s.repos.Strategy.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Errorf(grpccodes.NotFound, "strategy not found")
+		}
+		return nil, status.Errorf(grpccodes.Internal, "failed to get strategy")
+	}
+
 	if err := s.repos.Strategy.Delete(ctx, id); err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, status.Errorf(grpccodes.NotFound, "strategy not found")
@@ -168,20 +253,145 @@ func (s *Server) DeleteStrategy(ctx context.Context, req *pb.DeleteStrategyReque
 		return nil, status.Errorf(grpccodes.Internal, "failed to delete strategy")
 	}
 
+	if err := s.eventPublisher.PublishStrategyDeleted(strategy); err != nil {
+		s.logger.Warn("Failed to publish strategy deleted event", zap.Error(err), zap.String("strategy_id", id.String()))
+	}
+
 	return &pb.DeleteStrategyResponse{}, nil
 }
 
+// SetStrategyDescription stores a description generated by the Analyst Agent
+// for a strategy lacking one.
+func (s *Server) SetStrategyDescription(ctx context.Context, req *pb.SetStrategyDescriptionRequest) (*pb.SetStrategyDescriptionResponse, error) {
+	id, err := uuid.Parse(req.StrategyId)
+	if err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid strategy_id: %v", err)
+	}
+
+	description, err := domain.ValidateGeneratedDescription(req.Description)
+	if err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "%v", err)
+	}
+
+	if err := s.repos.Strategy.SetGeneratedDescription(ctx, id, description); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Errorf(grpccodes.NotFound, "strategy not found")
+		}
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, status.Errorf(grpccodes.FailedPrecondition, "strategy already has a description")
+		}
+		s.logger.Error("Failed to set strategy description", zap.Error(err), zap.String("strategy_id", id.String()))
+		return nil, status.Errorf(grpccodes.Internal, "failed to set strategy description")
+	}
+
+	strategy, err := s.repos.Strategy.GetByID(ctx, id)
+	if err != nil {
+		return nil, status.Errorf(grpccodes.Internal, "failed to get strategy")
+	}
+
+	s.logger.Info("Strategy description generated",
+		zap.String("strategy_id", id.String()),
+		zap.String("principal", requestPrincipal(ctx)),
+	)
+	if err := s.eventPublisher.PublishStrategyUpdated(strategy); err != nil {
+		s.logger.Warn("Failed to publish strategy updated event", zap.Error(err), zap.String("strategy_id", id.String()))
+	}
+
+	return &pb.SetStrategyDescriptionResponse{
+		Strategy: domainStrategyToProto(strategy),
+	}, nil
+}
+
+// DiffStrategies compares a strategy's code and settings with another
+// strategy, by default its parent.
+func (s *Server) DiffStrategies(ctx context.Context, req *pb.DiffStrategiesRequest) (*pb.DiffStrategiesResponse, error) {
+	id, err := uuid.Parse(req.StrategyId)
+	if err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid strategy_id: %v", err)
+	}
+
+	contextLines := domain.DefaultDiffContextLines
+	if req.ContextLines != nil {
+		contextLines = int(*req.ContextLines)
+	}
+	if err := domain.ValidateDiffContextLines(contextLines); err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "%v", err)
+	}
+
+	strategy, err := s.repos.Strategy.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Errorf(grpccodes.NotFound, "strategy not found")
+		}
+		return nil, status.Errorf(grpccodes.Internal, "failed to get strategy")
+	}
+
+	var againstID uuid.UUID
+	switch {
+	case req.AgainstId != nil:
+		againstID, err = uuid.Parse(*req.AgainstId)
+		if err != nil {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid against_id: %v", err)
+		}
+	case strategy.ParentID != nil:
+		againstID = *strategy.ParentID
+	default:
+		return nil, status.Errorf(grpccodes.InvalidArgument, "strategy has no parent; against_id is required")
+	}
+
+	against, err := s.repos.Strategy.GetByID(ctx, againstID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Errorf(grpccodes.NotFound, "strategy to compare with not found")
+		}
+		return nil, status.Errorf(grpccodes.Internal, "failed to get strategy")
+	}
+
+	diff, err := strategycode.Diff(against, strategy, contextLines)
+	if err != nil {
+		s.logger.Error("Failed to diff strategies", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to diff strategies")
+	}
+
+	return domainStrategyDiffToProto(diff), nil
+}
+
 // ValidateStrategy validates strategy code using Docker container.
 func (s *Server) ValidateStrategy(ctx context.Context, req *pb.ValidateStrategyRequest) (*pb.ValidateStrategyResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "ValidateStrategy")
 	defer span.End()
 
-	if req.Code == "" {
+	// With a strategy_id the outcome is recorded on that strategy, and its
+	// stored code is validated unless code is given
+	var strategyID *uuid.UUID
+	code, name := req.Code, req.Name
+	if req.StrategyId != nil && *req.StrategyId != "" {
+		id, err := uuid.Parse(*req.StrategyId)
+		if err != nil {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid strategy_id: %v", err)
+		}
+		strategy, err := s.repos.Strategy.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, status.Errorf(grpccodes.NotFound, "strategy not found")
+			}
+			s.logger.Error("Failed to get strategy", zap.Error(err))
+			return nil, status.Errorf(grpccodes.Internal, "failed to get strategy")
+		}
+		if code == "" {
+			code = strategy.Code
+		}
+		if name == "" {
+			name = strategy.Name
+		}
+		strategyID = &id
+	}
+
+	if code == "" {
 		return nil, status.Errorf(grpccodes.InvalidArgument, "code is required")
 	}
 
 	// Get sanitized name
-	name := req.Name
 	if name == "" {
 		name = "ValidatedStrategy"
 	}
@@ -190,7 +400,7 @@ func (s *Server) ValidateStrategy(ctx context.Context, req *pb.ValidateStrategyR
 	span.SetAttributes(attribute.String("strategy.name", name))
 
 	// Validate using Docker
-	result, err := s.scheduler.ValidateStrategy(ctx, req.Code, name)
+	result, err := s.scheduler.ValidateStrategy(ctx, code, name)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -203,16 +413,33 @@ func (s *Server) ValidateStrategy(ctx context.Context, req *pb.ValidateStrategyR
 		zap.Strings("errors", result.Errors),
 	)
 
+	if strategyID != nil {
+		validationStatus := domain.ValidationStatusValidated
+		if !result.Valid {
+			validationStatus = domain.ValidationStatusFailed
+		}
+		if err := s.repos.Strategy.SetValidation(ctx, *strategyID, validationStatus, result.Errors); err != nil {
+			s.logger.Error("Failed to record strategy validation", zap.Error(err), zap.String("strategy_id", strategyID.String()))
+			return nil, status.Errorf(grpccodes.Internal, "failed to record validation")
+		}
+	}
+
 	return &pb.ValidateStrategyResponse{
-		Valid:     result.Valid,
-		Errors:    result.Errors,
-		Warnings:  result.Warnings,
-		ClassName: result.ClassName,
+		Valid:             result.Valid,
+		Errors:            result.Errors,
+		Warnings:          result.Warnings,
+		ClassName:         result.ClassName,
+		UnresolvedImports: domainUnresolvedImportsToProto(result.UnresolvedImports),
 	}, nil
 }
 
-// SubmitBacktest submits a backtest job.
+// SubmitBacktest submits a backtest job. A job identical to one pending or
+// running is rejected with AlreadyExists unless allow_duplicate is set.
 func (s *Server) SubmitBacktest(ctx context.Context, req *pb.SubmitBacktestRequest) (*pb.SubmitBacktestResponse, error) {
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
 	strategyID, err := uuid.Parse(req.StrategyId)
 	if err != nil {
 		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid strategy_id: %v", err)
@@ -228,30 +455,76 @@ func (s *Server) SubmitBacktest(ctx context.Context, req *pb.SubmitBacktestReque
 		optRunID = &parsed
 	}
 
+	campaignID, err := s.parseCampaignID(ctx, req.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	warning, err := s.checkSubmittable(ctx, strategyID, req.SkipValidationCheck)
+	if err != nil {
+		return nil, err
+	}
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	// Jobs of an optimization run are pinned to the run's data snapshot
 	config := protoConfigToDomain(req.Config)
+	var optRun *domain.OptimizationRun
+	if optRunID != nil {
+		optRun, err = s.getOptimizationRun(ctx, *optRunID)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, optRun.PinBacktestConfig(&config)...)
+	}
+
 	job := domain.NewBacktestJob(strategyID, config, int(req.Priority), optRunID)
+	job.CampaignID = campaignID
+
+	if req.ExternalRef != nil {
+		if err := domain.ValidateExternalRef(*req.ExternalRef); err != nil {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid external_ref: %v", err)
+		}
+		job.SetExternalRef(requestPrincipal(ctx), *req.ExternalRef)
+	}
+
+	if job.Hints, err = jobHints(req); err != nil {
+		return nil, err
+	}
+
+	dupErrs, err := s.duplicateJobErrors(ctx, []*domain.BacktestJob{job}, []bool{req.AllowDuplicate})
+	if err != nil {
+		return nil, err
+	}
+	if dupErrs[0] != nil {
+		return nil, dupErrs[0]
+	}
+
+	if req.DryRun {
+		return s.previewSubmission(ctx, job, warnings)
+	}
 
 	if err := s.repos.BacktestJob.Create(ctx, job); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			return nil, status.Errorf(grpccodes.AlreadyExists, "job with external_ref %q already exists", *req.ExternalRef)
+		}
 		s.logger.Error("Failed to create backtest job", zap.Error(err))
 		return nil, status.Errorf(grpccodes.Internal, "failed to create job")
 	}
 
 	// If this is part of an optimization run, create an iteration record
-	if optRunID != nil {
-		optRun, err := s.repos.Optimization.GetByID(ctx, *optRunID)
-		if err != nil {
-			s.logger.Warn("Failed to get optimization run for iteration", zap.Error(err), zap.String("run_id", optRunID.String()))
+	if optRun != nil {
+		// Create iteration record (iteration_number = current_iteration + 1)
+		iteration := domain.NewOptimizationIteration(*optRunID, optRun.CurrentIteration+1, strategyID, job.ID)
+		if err := s.repos.Optimization.AddIteration(ctx, iteration); err != nil {
+			s.logger.Warn("Failed to create optimization iteration", zap.Error(err), zap.String("run_id", optRunID.String()))
 		} else {
-			// Create iteration record (iteration_number = current_iteration + 1)
-			iteration := domain.NewOptimizationIteration(*optRunID, optRun.CurrentIteration+1, strategyID, job.ID)
-			if err := s.repos.Optimization.AddIteration(ctx, iteration); err != nil {
-				s.logger.Warn("Failed to create optimization iteration", zap.Error(err), zap.String("run_id", optRunID.String()))
-			} else {
-				s.logger.Info("Created optimization iteration",
-					zap.String("run_id", optRunID.String()),
-					zap.Int("iteration_number", iteration.IterationNumber),
-					zap.String("job_id", job.ID.String()))
-			}
+			s.logger.Info("Created optimization iteration",
+				zap.String("run_id", optRunID.String()),
+				zap.Int("iteration_number", iteration.IterationNumber),
+				zap.String("job_id", job.ID.String()))
 		}
 	}
 
@@ -261,18 +534,111 @@ func (s *Server) SubmitBacktest(ctx context.Context, req *pb.SubmitBacktestReque
 	}
 
 	return &pb.SubmitBacktestResponse{
-		Job: domainJobToProto(job),
+		Job:      domainJobToProto(job),
+		Warnings: warnings,
 	}, nil
 }
 
-// GetBacktestJob gets a backtest job by ID.
-func (s *Server) GetBacktestJob(ctx context.Context, req *pb.GetBacktestJobRequest) (*pb.GetBacktestJobResponse, error) {
-	id, err := uuid.Parse(req.JobId)
+// previewSubmission answers a dry-run submission of a checked job.
+func (s *Server) previewSubmission(ctx context.Context, job *domain.BacktestJob, warnings []string) (*pb.SubmitBacktestResponse, error) {
+	if job.ExternalRef != nil {
+		_, err := s.repos.BacktestJob.GetByExternalRef(ctx, *job.ExternalRefOwner, *job.ExternalRef)
+		if err == nil {
+			return nil, status.Errorf(grpccodes.AlreadyExists, "job with external_ref %q already exists", *job.ExternalRef)
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			s.logger.Error("Failed to get backtest job by external ref", zap.Error(err))
+			return nil, status.Errorf(grpccodes.Internal, "failed to check external_ref")
+		}
+	}
+
+	workers, agingPerHour := 0, 0.0
+	if s.scheduler != nil {
+		workers = s.scheduler.WorkerCount()
+		agingPerHour = s.scheduler.PriorityAgingPerHour()
+	}
+	preview, dataWarnings, err := scheduler.PreviewSubmission(ctx, s.repos, workers, agingPerHour, job, time.Now())
 	if err != nil {
-		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid job_id: %v", err)
+		s.logger.Error("Failed to preview backtest submission", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to preview submission")
 	}
 
-	job, err := s.repos.BacktestJob.GetByID(ctx, id)
+	return &pb.SubmitBacktestResponse{
+		Job:      domainJobToProto(job),
+		Warnings: append(warnings, dataWarnings...),
+		Preview:  domainSubmissionPreviewToProto(preview),
+	}, nil
+}
+
+// parseCampaignID parses an optional campaign ID and checks that the campaign exists.
+func (s *Server) parseCampaignID(ctx context.Context, raw *string) (*uuid.UUID, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(*raw)
+	if err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid campaign_id: %v", err)
+	}
+	if _, err := s.repos.Campaign.GetByID(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Errorf(grpccodes.NotFound, "campaign not found")
+		}
+		s.logger.Error("Failed to get campaign", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to get campaign")
+	}
+	return &id, nil
+}
+
+// getOptimizationRun loads the optimization run a backtest is submitted for.
+func (s *Server) getOptimizationRun(ctx context.Context, id uuid.UUID) (*domain.OptimizationRun, error) {
+	run, err := s.repos.Optimization.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, status.Errorf(grpccodes.NotFound, "optimization run not found")
+		}
+		s.logger.Error("Failed to get optimization run", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to get optimization run")
+	}
+	return run, nil
+}
+
+// checkSubmittable loads a strategy and checks that its validation status
+// allows submitting a backtest of it. It returns a warning to pass back to the
+// caller when the submission is allowed but may fail.
+func (s *Server) checkSubmittable(ctx context.Context, strategyID uuid.UUID, skipValidationCheck bool) (string, error) {
+	strategy, err := s.repos.Strategy.GetByID(ctx, strategyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", status.Errorf(grpccodes.NotFound, "strategy not found")
+		}
+		s.logger.Error("Failed to get strategy", zap.Error(err))
+		return "", status.Errorf(grpccodes.Internal, "failed to get strategy")
+	}
+
+	warning, err := strategy.CheckSubmittable(skipValidationCheck)
+	if err != nil {
+		return "", status.Errorf(grpccodes.FailedPrecondition,
+			"strategy %s failed validation; set skip_validation_check to submit anyway", strategyID)
+	}
+	return warning, nil
+}
+
+// GetBacktestJob gets a backtest job by ID.
+func (s *Server) GetBacktestJob(ctx context.Context, req *pb.GetBacktestJobRequest) (*pb.GetBacktestJobResponse, error) {
+	var (
+		job *domain.BacktestJob
+		err error
+	)
+	if req.JobId == "" && req.ExternalRef != "" {
+		job, err = s.repos.BacktestJob.GetByExternalRef(ctx, requestPrincipal(ctx), req.ExternalRef)
+	} else {
+		id, parseErr := uuid.Parse(req.JobId)
+		if parseErr != nil {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid job_id: %v", parseErr)
+		}
+		job, err = s.repos.BacktestJob.GetByID(ctx, id)
+	}
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, status.Errorf(grpccodes.NotFound, "job not found")
@@ -300,19 +666,38 @@ func (s *Server) GetBacktestResult(ctx context.Context, req *pb.GetBacktestResul
 		return nil, status.Errorf(grpccodes.Internal, "failed to get result")
 	}
 
+	// Raw logs are stored apart from results and loaded only here
+	rawLog, err := s.repos.Result.GetRawLog(ctx, result.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		s.logger.Warn("Failed to load result log",
+			zap.Error(err),
+			zap.String("result_id", result.ID.String()))
+	}
+	result.RawLog = rawLog
+
+	if req.IncludePercentiles {
+		s.rankResult(ctx, result)
+	}
+
 	return &pb.GetBacktestResultResponse{
 		Result: domainResultToProto(result),
 	}, nil
 }
 
-// CancelBacktest cancels a backtest job.
+// CancelBacktest cancels a backtest job, recording the optional reason and
+// the caller.
 func (s *Server) CancelBacktest(ctx context.Context, req *pb.CancelBacktestRequest) (*pb.CancelBacktestResponse, error) {
 	id, err := uuid.Parse(req.JobId)
 	if err != nil {
 		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid job_id: %v", err)
 	}
 
-	if err := s.repos.BacktestJob.Cancel(ctx, id); err != nil {
+	cancellation := domain.NewCancellation(req.GetReason(), requestPrincipal(ctx))
+	if err := cancellation.Validate(); err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "%v", err)
+	}
+
+	if err := s.repos.BacktestJob.Cancel(ctx, id, cancellation); err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, status.Errorf(grpccodes.NotFound, "job not found")
 		}
@@ -322,6 +707,11 @@ func (s *Server) CancelBacktest(ctx context.Context, req *pb.CancelBacktestReque
 		return nil, status.Errorf(grpccodes.Internal, "failed to cancel job")
 	}
 
+	s.logger.Info("Backtest job cancelled",
+		zap.String("job_id", id.String()),
+		zap.String("reason", cancellation.Reason),
+		zap.String("cancelled_by", cancellation.CancelledBy))
+
 	// Fetch the cancelled job and publish event
 	job, err := s.repos.BacktestJob.GetByID(ctx, id)
 	if err == nil {
@@ -348,12 +738,48 @@ func (s *Server) GetQueueStats(ctx context.Context, req *pb.GetQueueStatsRequest
 	}, nil
 }
 
+// GetSchedulerStatus reports whether the backtest scheduler dequeues jobs.
+func (s *Server) GetSchedulerStatus(ctx context.Context, req *pb.GetSchedulerStatusRequest) (*pb.GetSchedulerStatusResponse, error) {
+	if s.scheduler == nil {
+		return nil, status.Errorf(grpccodes.Unavailable, "scheduler not available")
+	}
+
+	return &pb.GetSchedulerStatusResponse{
+		Status: domainSchedulerStatusToProto(s.scheduler.Status()),
+	}, nil
+}
+
+// ControlScheduler pauses, drains or resumes the backtest scheduler.
+func (s *Server) ControlScheduler(ctx context.Context, req *pb.ControlSchedulerRequest) (*pb.ControlSchedulerResponse, error) {
+	if s.scheduler == nil {
+		return nil, status.Errorf(grpccodes.Unavailable, "scheduler not available")
+	}
+
+	by := requestPrincipal(ctx)
+	var sched *domain.SchedulerStatus
+	switch req.Action {
+	case pb.SchedulerAction_SCHEDULER_ACTION_PAUSE:
+		sched = s.scheduler.Pause(by)
+	case pb.SchedulerAction_SCHEDULER_ACTION_DRAIN:
+		sched = s.scheduler.Drain(by)
+	case pb.SchedulerAction_SCHEDULER_ACTION_RESUME:
+		sched = s.scheduler.Resume(by)
+	default:
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid action")
+	}
+
+	return &pb.ControlSchedulerResponse{
+		Status: domainSchedulerStatusToProto(sched),
+	}, nil
+}
+
 // SearchStrategies searches for strategies with filters.
 func (s *Server) SearchStrategies(ctx context.Context, req *pb.SearchStrategiesRequest) (*pb.SearchStrategiesResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "FreqSearchService.SearchStrategies")
 	defer span.End()
 
 	query := protoSearchQueryToDomain(req)
+	warnPageSizeClamped(ctx, req.Pagination, query.PageSize)
 	strategies, totalCount, err := s.repos.Strategy.Search(ctx, query)
 	if err != nil {
 		span.RecordError(err)
@@ -394,12 +820,19 @@ func (s *Server) GetStrategyLineage(ctx context.Context, req *pb.GetStrategyLine
 		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid strategy_id: %v", err)
 	}
 
+	query := domain.StrategyLineageQuery{
+		Depth:          int(req.Depth),
+		Direction:      protoLineageDirectionToDomain(req.Direction),
+		IncludeMetrics: req.IncludeMetrics,
+	}
+
 	span.SetAttributes(
 		attribute.String("strategy_id", strategyID.String()),
 		attribute.Int("depth", int(req.Depth)),
+		attribute.String("direction", string(query.Direction)),
 	)
 
-	lineageNode, err := s.repos.Strategy.GetLineage(ctx, strategyID, int(req.Depth))
+	lineageNode, err := s.repos.Strategy.GetLineage(ctx, strategyID, query)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			span.SetStatus(codes.Error, "strategy not found")
@@ -418,60 +851,347 @@ func (s *Server) GetStrategyLineage(ctx context.Context, req *pb.GetStrategyLine
 	}, nil
 }
 
-// SubmitBatchBacktest submits multiple backtest jobs.
+// GetStrategyStatistics returns aggregated metrics across a strategy's results
+// within a time window, so clients don't need to fetch raw results.
+func (s *Server) GetStrategyStatistics(ctx context.Context, req *pb.GetStrategyStatisticsRequest) (*pb.GetStrategyStatisticsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "FreqSearchService.GetStrategyStatistics")
+	defer span.End()
+
+	strategyID, err := uuid.Parse(req.StrategyId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid strategy_id")
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid strategy_id: %v", err)
+	}
+
+	span.SetAttributes(attribute.String("strategy_id", strategyID.String()))
+
+	window := protoWindowToDomain(req.Window)
+	if window != nil && !window.Start.IsZero() && !window.End.IsZero() && !window.Start.Before(window.End) {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "window start must be before end")
+	}
+
+	if _, err := s.repos.Strategy.GetByID(ctx, strategyID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			span.SetStatus(codes.Error, "strategy not found")
+			return nil, status.Errorf(grpccodes.NotFound, "strategy not found")
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get strategy")
+		return nil, status.Errorf(grpccodes.Internal, "failed to get strategy")
+	}
+
+	stats, err := s.repos.Result.GetStatistics(ctx, strategyID, window)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get statistics")
+		s.logger.Error("Failed to get strategy statistics", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to get statistics")
+	}
+
+	span.SetAttributes(attribute.Int("result_count", stats.ResultCount))
+
+	return domainStrategyStatisticsToProto(stats), nil
+}
+
+// SubmitBatchBacktest submits multiple backtest jobs. By default the batch is
+// all or nothing; in partial mode the valid backtests are created and the
+// others reported per item. Backtests identical to a pending or running job,
+// or to an earlier backtest of the batch, are rejected unless they set
+// allow_duplicate.
 func (s *Server) SubmitBatchBacktest(ctx context.Context, req *pb.SubmitBatchBacktestRequest) (*pb.SubmitBatchBacktestResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "FreqSearchService.SubmitBatchBacktest")
 	defer span.End()
 
-	span.SetAttributes(attribute.Int("batch_size", len(req.Backtests)))
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("batch_size", len(req.Backtests)),
+		attribute.Bool("partial", req.Partial),
+	)
 
-	jobs := make([]*domain.BacktestJob, 0, len(req.Backtests))
-	for _, btReq := range req.Backtests {
-		strategyID, err := uuid.Parse(btReq.StrategyId)
+	// Batches often repeat strategies, campaigns and runs; look each up once
+	checked := make(map[uuid.UUID]error)
+	campaigns := make(map[string]*uuid.UUID)
+	runs := make(map[uuid.UUID]*domain.OptimizationRun)
+
+	jobs := make([]*domain.BacktestJob, len(req.Backtests))
+	itemErrs := make([]error, len(req.Backtests))
+	var warnings []string
+	for i, btReq := range req.Backtests {
+		job, jobWarnings, err := s.newBatchJob(ctx, btReq, checked, campaigns, runs)
+		warnings = append(warnings, jobWarnings...)
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, "invalid strategy_id in batch")
-			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid strategy_id: %v", err)
+			if !req.Partial {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "invalid backtest in batch")
+				return nil, err
+			}
+			itemErrs[i] = err
+			continue
 		}
+		jobs[i] = job
+	}
 
-		var optRunID *uuid.UUID
-		if btReq.OptimizationRunId != nil && *btReq.OptimizationRunId != "" {
-			parsed, err := uuid.Parse(*btReq.OptimizationRunId)
-			if err != nil {
-				span.RecordError(err)
-				span.SetStatus(codes.Error, "invalid optimization_run_id in batch")
-				return nil, status.Errorf(grpccodes.InvalidArgument, "invalid optimization_run_id: %v", err)
+	allowed := make([]bool, len(req.Backtests))
+	for i, btReq := range req.Backtests {
+		allowed[i] = btReq.AllowDuplicate
+	}
+	dupErrs, err := s.duplicateJobErrors(ctx, jobs, allowed)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to check for duplicate jobs")
+		return nil, err
+	}
+	for i, dupErr := range dupErrs {
+		if dupErr == nil {
+			continue
+		}
+		if !req.Partial {
+			span.RecordError(dupErr)
+			span.SetStatus(codes.Error, "duplicate backtest in batch")
+			return nil, dupErr
+		}
+		itemErrs[i] = dupErr
+		jobs[i] = nil
+	}
+
+	if !req.Partial {
+		if err := s.repos.BacktestJob.CreateBatch(ctx, jobs); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to create batch")
+			return nil, s.batchInsertError(err)
+		}
+	} else {
+		var valid []*domain.BacktestJob
+		var validIdx []int
+		for i, job := range jobs {
+			if job != nil {
+				valid = append(valid, job)
+				validIdx = append(validIdx, i)
 			}
-			optRunID = &parsed
 		}
 
-		config := protoConfigToDomain(btReq.Config)
-		job := domain.NewBacktestJob(strategyID, config, int(btReq.Priority), optRunID)
-		jobs = append(jobs, job)
+		insertErrs, err := s.repos.BacktestJob.CreateBatchPartial(ctx, valid)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to create batch")
+			s.logger.Error("Failed to create partial batch backtest jobs", zap.Error(err))
+			return nil, status.Errorf(grpccodes.Internal, "failed to create batch jobs")
+		}
+		for j, insertErr := range insertErrs {
+			if insertErr != nil {
+				i := validIdx[j]
+				itemErrs[i] = s.batchInsertError(insertErr)
+				jobs[i] = nil
+			}
+		}
 	}
 
-	if err := s.repos.BacktestJob.CreateBatch(ctx, jobs); err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "failed to create batch")
-		s.logger.Error("Failed to create batch backtest jobs", zap.Error(err))
-		return nil, status.Errorf(grpccodes.Internal, "failed to create batch jobs")
+	var created []*domain.BacktestJob
+	for _, job := range jobs {
+		if job != nil {
+			created = append(created, job)
+		}
 	}
+	span.SetAttributes(attribute.Int("created", len(created)))
 
 	// Publish task created events for each job
-	for _, job := range jobs {
+	for _, job := range created {
 		if err := s.eventPublisher.PublishTaskCreated(job); err != nil {
 			s.logger.Warn("Failed to publish task created event", zap.Error(err), zap.String("job_id", job.ID.String()))
 		}
 	}
 
-	protoJobs := make([]*pb.BacktestJob, len(jobs))
-	for i, job := range jobs {
+	protoJobs := make([]*pb.BacktestJob, len(created))
+	for i, job := range created {
 		protoJobs[i] = domainJobToProto(job)
 	}
 
-	return &pb.SubmitBatchBacktestResponse{
-		Jobs: protoJobs,
-	}, nil
+	resp := &pb.SubmitBatchBacktestResponse{
+		Jobs:     protoJobs,
+		Warnings: warnings,
+	}
+	if req.Partial {
+		resp.Items = batchItemResultsToProto(jobs, itemErrs)
+	}
+	return resp, nil
+}
+
+// newBatchJob builds the job for one backtest of a batch. Strategy checks,
+// campaign and optimization run lookups are cached in checked, campaigns and
+// runs; a strategy's warning is only returned the first time it is checked.
+func (s *Server) newBatchJob(
+	ctx context.Context,
+	btReq *pb.SubmitBacktestRequest,
+	checked map[uuid.UUID]error,
+	campaigns map[string]*uuid.UUID,
+	runs map[uuid.UUID]*domain.OptimizationRun,
+) (*domain.BacktestJob, []string, error) {
+	if btReq.DryRun {
+		return nil, nil, status.Errorf(grpccodes.InvalidArgument, "dry_run is not supported in batch submissions")
+	}
+
+	strategyID, err := uuid.Parse(btReq.StrategyId)
+	if err != nil {
+		return nil, nil, status.Errorf(grpccodes.InvalidArgument, "invalid strategy_id: %v", err)
+	}
+
+	var warnings []string
+	checkErr, ok := checked[strategyID]
+	if !ok {
+		var warning string
+		warning, checkErr = s.checkSubmittable(ctx, strategyID, btReq.SkipValidationCheck)
+		checked[strategyID] = checkErr
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if checkErr != nil {
+		return nil, nil, checkErr
+	}
+
+	var optRunID *uuid.UUID
+	if btReq.OptimizationRunId != nil && *btReq.OptimizationRunId != "" {
+		parsed, err := uuid.Parse(*btReq.OptimizationRunId)
+		if err != nil {
+			return nil, warnings, status.Errorf(grpccodes.InvalidArgument, "invalid optimization_run_id: %v", err)
+		}
+		optRunID = &parsed
+	}
+
+	campaignID, ok := campaigns[btReq.GetCampaignId()]
+	if !ok {
+		campaignID, err = s.parseCampaignID(ctx, btReq.CampaignId)
+		if err != nil {
+			return nil, warnings, err
+		}
+		campaigns[btReq.GetCampaignId()] = campaignID
+	}
+
+	config := protoConfigToDomain(btReq.Config)
+	if optRunID != nil {
+		optRun, ok := runs[*optRunID]
+		if !ok {
+			optRun, err = s.getOptimizationRun(ctx, *optRunID)
+			if err != nil {
+				return nil, warnings, err
+			}
+			runs[*optRunID] = optRun
+		}
+		warnings = append(warnings, optRun.PinBacktestConfig(&config)...)
+	}
+
+	job := domain.NewBacktestJob(strategyID, config, int(btReq.Priority), optRunID)
+	job.CampaignID = campaignID
+	if btReq.ExternalRef != nil {
+		if err := domain.ValidateExternalRef(*btReq.ExternalRef); err != nil {
+			return nil, warnings, status.Errorf(grpccodes.InvalidArgument, "invalid external_ref: %v", err)
+		}
+		job.SetExternalRef(requestPrincipal(ctx), *btReq.ExternalRef)
+	}
+	if job.Hints, err = jobHints(btReq); err != nil {
+		return nil, warnings, err
+	}
+	return job, warnings, nil
+}
+
+// duplicateJobErrors returns an AlreadyExists error for each job identical to
+// a pending or running job, or to an earlier job of jobs, so that large
+// batches don't queue the same work twice. Jobs that are nil or allowed to be
+// duplicates are not rejected.
+func (s *Server) duplicateJobErrors(ctx context.Context, jobs []*domain.BacktestJob, allowed []bool) ([]error, error) {
+	errs := make([]error, len(jobs))
+
+	var checked []*domain.BacktestJob
+	for i, job := range jobs {
+		if job != nil && !allowed[i] {
+			checked = append(checked, job)
+		}
+	}
+	if len(checked) == 0 {
+		return errs, nil
+	}
+
+	duplicates, err := s.repos.BacktestJob.GetActiveDuplicates(ctx, checked)
+	if err != nil {
+		s.logger.Error("Failed to check for duplicate jobs", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to check for duplicate jobs")
+	}
+
+	earlier := make(map[string]int)
+	j := 0
+	for i, job := range jobs {
+		if job == nil {
+			continue
+		}
+		key := job.WorkKey()
+		if !allowed[i] {
+			existing := duplicates[j]
+			j++
+			if existing != nil {
+				errs[i] = status.Errorf(grpccodes.AlreadyExists, "%v; set allow_duplicate to submit anyway",
+					domain.DuplicateJobError{ExistingJobID: *existing})
+				continue
+			}
+			if k, ok := earlier[key]; ok {
+				errs[i] = status.Errorf(grpccodes.AlreadyExists,
+					"identical to backtest %d of the batch; set allow_duplicate to submit anyway", k)
+				continue
+			}
+		}
+		if _, ok := earlier[key]; !ok {
+			earlier[key] = i
+		}
+	}
+	return errs, nil
+}
+
+// jobHints converts and validates the placement hints of a submission.
+func jobHints(req *pb.SubmitBacktestRequest) (*domain.JobHints, error) {
+	hints := protoHintsToDomain(req.Hints)
+	if hints == nil {
+		return nil, nil
+	}
+	if err := hints.Validate(); err != nil {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid hints: %v", err)
+	}
+	return hints, nil
+}
+
+// batchInsertError converts an error inserting batch jobs to a gRPC status.
+func (s *Server) batchInsertError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrDuplicate):
+		return status.Errorf(grpccodes.AlreadyExists, "%v", err)
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Errorf(grpccodes.NotFound, "%v", err)
+	default:
+		s.logger.Error("Failed to create batch backtest jobs", zap.Error(err))
+		return status.Errorf(grpccodes.Internal, "failed to create batch jobs")
+	}
+}
+
+// batchItemResultsToProto converts the outcome of each backtest of a partial
+// batch to proto. jobs[i] is set for created jobs and errs[i] for rejected ones.
+func batchItemResultsToProto(jobs []*domain.BacktestJob, errs []error) []*pb.BatchItemResult {
+	items := make([]*pb.BatchItemResult, len(jobs))
+	for i, job := range jobs {
+		item := &pb.BatchItemResult{Index: int32(i)}
+		if job != nil {
+			jobID := job.ID.String()
+			item.JobId = &jobID
+		} else if errs[i] != nil {
+			st := status.Convert(errs[i])
+			message := st.Message()
+			item.Error = &message
+			item.ErrorCode = st.Code().String()
+		}
+		items[i] = item
+	}
+	return items
 }
 
 // QueryBacktestResults queries backtest results with filters.
@@ -480,6 +1200,7 @@ func (s *Server) QueryBacktestResults(ctx context.Context, req *pb.QueryBacktest
 	defer span.End()
 
 	query := protoBacktestQueryToDomain(req)
+	warnPageSizeClamped(ctx, req.Pagination, query.PageSize)
 	results, totalCount, err := s.repos.Result.Query(ctx, query)
 	if err != nil {
 		span.RecordError(err)
@@ -513,23 +1234,71 @@ func (s *Server) StartOptimization(ctx context.Context, req *pb.StartOptimizatio
 	ctx, span := s.tracer.Start(ctx, "FreqSearchService.StartOptimization")
 	defer span.End()
 
-	baseStrategyID, err := uuid.Parse(req.BaseStrategyId)
+	if err := s.checkMaintenance(); err != nil {
+		return nil, err
+	}
+
+	seeds, err := domain.ParseSeedStrategyIDs(req.BaseStrategyId, req.BaseStrategyIds)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "invalid base_strategy_id")
 		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid base_strategy_id: %v", err)
 	}
 
+	config := protoOptConfigToDomain(req.Config)
+	if config.Quota != nil {
+		if err := config.Quota.Validate(); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "invalid quota")
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid quota: %v", err)
+		}
+	}
+	run := domain.NewOptimizationRun(req.Name, uuid.Nil, config)
+	if err := run.SetSeeds(seeds); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid base_strategy_ids")
+		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid base_strategy_ids: %v", err)
+	}
+
 	span.SetAttributes(
 		attribute.String("name", req.Name),
-		attribute.String("base_strategy_id", baseStrategyID.String()),
+		attribute.String("base_strategy_id", run.BaseStrategyID.String()),
+		attribute.Int("seed_count", len(run.SeedStrategyIDs)),
 	)
 
-	config := protoOptConfigToDomain(req.Config)
-	run := domain.NewOptimizationRun(req.Name, baseStrategyID, config)
+	found, err := s.repos.Strategy.GetByIDs(ctx, run.SeedStrategyIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get seed strategies")
+		s.logger.Error("Failed to get seed strategies", zap.Error(err))
+		return nil, status.Errorf(grpccodes.Internal, "failed to get seed strategies")
+	}
+	if len(found) != len(run.SeedStrategyIDs) {
+		span.SetStatus(codes.Error, "seed strategy not found")
+		return nil, status.Errorf(grpccodes.InvalidArgument, "seed strategy not found")
+	}
+
+	if req.ExternalRef != nil {
+		if err := domain.ValidateExternalRef(*req.ExternalRef); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "invalid external_ref")
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid external_ref: %v", err)
+		}
+		run.SetExternalRef(requestPrincipal(ctx), *req.ExternalRef)
+	}
+
+	if err := s.checkRunAgents(ctx, run); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "no registered agent can serve the run")
+		return nil, err
+	}
 
 	if err := s.repos.Optimization.Create(ctx, run); err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrDuplicate) {
+			span.SetStatus(codes.Error, "duplicate external_ref")
+			return nil, status.Errorf(grpccodes.AlreadyExists, "optimization run with external_ref %q already exists", *req.ExternalRef)
+		}
 		span.SetStatus(codes.Error, "failed to create optimization run")
 		s.logger.Error("Failed to create optimization run", zap.Error(err))
 		return nil, status.Errorf(grpccodes.Internal, "failed to create optimization run")
@@ -550,16 +1319,23 @@ func (s *Server) GetOptimizationRun(ctx context.Context, req *pb.GetOptimization
 	ctx, span := s.tracer.Start(ctx, "FreqSearchService.GetOptimizationRun")
 	defer span.End()
 
-	runID, err := uuid.Parse(req.RunId)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "invalid run_id")
-		return nil, status.Errorf(grpccodes.InvalidArgument, "invalid run_id: %v", err)
+	var (
+		run *domain.OptimizationRun
+		err error
+	)
+	if req.RunId == "" && req.ExternalRef != "" {
+		span.SetAttributes(attribute.String("external_ref", req.ExternalRef))
+		run, err = s.repos.Optimization.GetByExternalRef(ctx, requestPrincipal(ctx), req.ExternalRef)
+	} else {
+		runID, parseErr := uuid.Parse(req.RunId)
+		if parseErr != nil {
+			span.RecordError(parseErr)
+			span.SetStatus(codes.Error, "invalid run_id")
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid run_id: %v", parseErr)
+		}
+		span.SetAttributes(attribute.String("run_id", runID.String()))
+		run, err = s.repos.Optimization.GetByID(ctx, runID)
 	}
-
-	span.SetAttributes(attribute.String("run_id", runID.String()))
-
-	run, err := s.repos.Optimization.GetByID(ctx, runID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			span.SetStatus(codes.Error, "optimization run not found")
@@ -571,7 +1347,7 @@ func (s *Server) GetOptimizationRun(ctx context.Context, req *pb.GetOptimization
 		return nil, status.Errorf(grpccodes.Internal, "failed to get optimization run")
 	}
 
-	iterations, err := s.repos.Optimization.GetIterations(ctx, runID)
+	iterations, err := s.repos.Optimization.GetIterations(ctx, run.ID)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to get iterations")
@@ -600,6 +1376,7 @@ func (s *Server) GetOptimizationRun(ctx context.Context, req *pb.GetOptimization
 	return &pb.GetOptimizationRunResponse{
 		Run:        domainOptRunToProto(run),
 		Iterations: protoIterations,
+		Progress:   domainOptProgressToProto(domain.NewOptimizationProgress(run, time.Now())),
 	}, nil
 }
 
@@ -662,16 +1439,25 @@ func (s *Server) ControlOptimization(ctx context.Context, req *pb.ControlOptimiz
 		}
 	case pb.OptimizationAction_OPTIMIZATION_ACTION_CANCEL:
 		newStatus = domain.OptimizationStatusCancelled
-		if err := s.repos.Optimization.UpdateStatus(ctx, runID, newStatus); err != nil {
+		cancellation := domain.NewCancellation(req.GetTerminationReason(), requestPrincipal(ctx))
+		if err := cancellation.Validate(); err != nil {
+			span.RecordError(err)
+			return nil, status.Errorf(grpccodes.InvalidArgument, "%v", err)
+		}
+		if _, err := s.repos.Optimization.Cancel(ctx, runID, cancellation); err != nil {
 			if errors.Is(err, domain.ErrNotFound) {
 				span.SetStatus(codes.Error, "optimization run not found")
 				return nil, status.Errorf(grpccodes.NotFound, "optimization run not found")
 			}
 			span.RecordError(err)
-			span.SetStatus(codes.Error, "failed to update status")
-			s.logger.Error("Failed to control optimization run", zap.Error(err))
+			span.SetStatus(codes.Error, "failed to cancel optimization")
+			s.logger.Error("Failed to cancel optimization run", zap.Error(err))
 			return nil, status.Errorf(grpccodes.Internal, "failed to control optimization")
 		}
+		s.logger.Info("Optimization run cancelled",
+			zap.String("run_id", runID.String()),
+			zap.String("reason", cancellation.Reason),
+			zap.String("cancelled_by", cancellation.CancelledBy))
 	case pb.OptimizationAction_OPTIMIZATION_ACTION_COMPLETE:
 		newStatus = domain.OptimizationStatusCompleted
 		// Parse optional best_strategy_id
@@ -753,8 +1539,7 @@ func (s *Server) ListOptimizationRuns(ctx context.Context, req *pb.ListOptimizat
 	defer span.End()
 
 	query := domain.OptimizationListQuery{
-		Page:     1,
-		PageSize: 20,
+		Page: 1,
 	}
 
 	if req.Pagination != nil {
@@ -762,6 +1547,7 @@ func (s *Server) ListOptimizationRuns(ctx context.Context, req *pb.ListOptimizat
 		query.PageSize = int(req.Pagination.PageSize)
 	}
 	query.SetDefaults()
+	warnPageSizeClamped(ctx, req.Pagination, query.PageSize)
 
 	if req.Status != nil {
 		status := protoOptStatusToDomain(*req.Status)
@@ -874,6 +1660,95 @@ func (s *Server) UpdateIterationFeedback(ctx context.Context, req *pb.UpdateIter
 	return &emptypb.Empty{}, nil
 }
 
+// Claim lease bounds for ClaimNextOptimizationAction.
+const (
+	defaultActionClaimLease = 5 * time.Minute
+	maxActionClaimLease     = time.Hour
+)
+
+// ClaimNextOptimizationAction atomically determines the next step of a run
+// and claims it for the calling orchestrator replica, so that replicas running
+// side by side never generate or evaluate the same iteration twice. Without a
+// run_id, the first running run with claimable work is returned.
+func (s *Server) ClaimNextOptimizationAction(ctx context.Context, req *pb.ClaimNextOptimizationActionRequest) (*pb.ClaimNextOptimizationActionResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "FreqSearchService.ClaimNextOptimizationAction")
+	defer span.End()
+
+	claimant := strings.TrimSpace(req.Claimant)
+	if claimant == "" {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "claimant is required")
+	}
+
+	lease := defaultActionClaimLease
+	if req.LeaseSeconds < 0 {
+		return nil, status.Errorf(grpccodes.InvalidArgument, "lease_seconds must not be negative")
+	}
+	if req.LeaseSeconds > 0 {
+		lease = time.Duration(req.LeaseSeconds) * time.Second
+		if lease > maxActionClaimLease {
+			lease = maxActionClaimLease
+		}
+	}
+
+	span.SetAttributes(attribute.String("claimant", claimant))
+
+	if req.RunId != nil && *req.RunId != "" {
+		runID, err := uuid.Parse(*req.RunId)
+		if err != nil {
+			return nil, status.Errorf(grpccodes.InvalidArgument, "invalid run_id: %v", err)
+		}
+
+		action, err := s.repos.Optimization.ClaimNextAction(ctx, runID, claimant, lease, time.Now())
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, status.Errorf(grpccodes.NotFound, "optimization run not found")
+			}
+			span.RecordError(err)
+			s.logger.Error("Failed to claim optimization action", zap.Error(err), zap.String("run_id", runID.String()))
+			return nil, status.Errorf(grpccodes.Internal, "failed to claim next action")
+		}
+		return domainOrchestratorActionToProto(action), nil
+	}
+
+	// Oldest runs first so a busy deployment doesn't starve them
+	running := domain.OptimizationStatusRunning
+	query := domain.OptimizationListQuery{Status: &running, OrderBy: "created_at", Ascending: true, PageSize: 100}
+	query.SetDefaults() // Pages are counted in the possibly capped page size
+	for page := 1; ; page++ {
+		query.Page = page
+		runs, total, err := s.repos.Optimization.List(ctx, query)
+		if err != nil {
+			span.RecordError(err)
+			s.logger.Error("Failed to list running optimization runs", zap.Error(err))
+			return nil, status.Errorf(grpccodes.Internal, "failed to list optimization runs")
+		}
+
+		for _, run := range runs {
+			action, err := s.repos.Optimization.ClaimNextAction(ctx, run.ID, claimant, lease, time.Now())
+			if err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					continue // Deleted since it was listed
+				}
+				span.RecordError(err)
+				s.logger.Error("Failed to claim optimization action", zap.Error(err), zap.String("run_id", run.ID.String()))
+				return nil, status.Errorf(grpccodes.Internal, "failed to claim next action")
+			}
+			if action.Type.IsClaimable() {
+				return domainOrchestratorActionToProto(action), nil
+			}
+		}
+
+		if len(runs) == 0 || page*query.PageSize >= total {
+			break
+		}
+	}
+
+	return &pb.ClaimNextOptimizationActionResponse{
+		Action: pb.NextActionType_NEXT_ACTION_TYPE_NONE,
+		Reason: "no running optimization run has claimable work",
+	}, nil
+}
+
 // HealthCheck performs a health check.
 func (s *Server) HealthCheck(ctx context.Context, req *pb.HealthCheckRequest) (*pb.HealthCheckResponse, error) {
 	// TODO: Add actual health checks for DB, RabbitMQ, Docker
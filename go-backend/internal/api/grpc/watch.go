@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
+)
+
+// defaultWatchInterval is how often watched jobs are checked when SetJobWatch
+// was not called.
+const defaultWatchInterval = time.Second
+
+// SetJobWatch sets how often WatchBacktestJob checks a job for changes. The
+// interval is validated when the config is loaded.
+func (s *Server) SetJobWatch(cfg *config.JobWatchConfig) {
+	s.watchInterval, _ = time.ParseDuration(cfg.PollInterval)
+}
+
+// WatchBacktestJob streams a backtest job's status transitions as they
+// happen. The job is sent on subscribing and whenever it changes; the stream
+// ends after the update for a finished job, which carries the result of a
+// completed one. Jobs are checked in the database, so updates made by any
+// instance are seen.
+func (s *Server) WatchBacktestJob(req *pb.WatchBacktestJobRequest, stream grpc.ServerStreamingServer[pb.WatchBacktestJobResponse]) error {
+	ctx := stream.Context()
+
+	id, err := uuid.Parse(req.JobId)
+	if err != nil {
+		return status.Errorf(grpccodes.InvalidArgument, "invalid job_id: %v", err)
+	}
+
+	interval := s.watchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *pb.BacktestJob
+	for {
+		job, err := s.repos.BacktestJob.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return status.Errorf(grpccodes.NotFound, "job not found")
+			}
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			s.logger.Error("Failed to get watched job", zap.Error(err), zap.String("job_id", id.String()))
+			return status.Errorf(grpccodes.Internal, "failed to get job")
+		}
+
+		current := domainJobToProto(job)
+		final := job.Status.IsTerminal()
+		if last == nil || final || !proto.Equal(last, current) {
+			update := &pb.WatchBacktestJobResponse{Job: current, Final: final}
+			if final && job.Status == domain.JobStatusCompleted {
+				result, err := s.repos.Result.GetByJobID(ctx, id)
+				if err != nil && !errors.Is(err, domain.ErrNotFound) {
+					s.logger.Warn("Failed to load watched job result",
+						zap.Error(err),
+						zap.String("job_id", id.String()))
+				}
+				if result != nil {
+					update.Result = domainResultToProto(result)
+				}
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			last = current
+		}
+		if final {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.done:
+			return status.Error(grpccodes.Unavailable, "server shutting down")
+		case <-ticker.C:
+		}
+	}
+}
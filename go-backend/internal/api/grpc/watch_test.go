@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	pb "github.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1"
+)
+
+// mockWatchJobRepository returns a job with the next status of a sequence on
+// each lookup, repeating the last one.
+type mockWatchJobRepository struct {
+	repository.BacktestJobRepository
+	mu       sync.Mutex
+	job      *domain.BacktestJob
+	statuses []domain.JobStatus
+}
+
+func (m *mockWatchJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.BacktestJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.job == nil || m.job.ID != id {
+		return nil, domain.NewNotFoundError("backtest_job", id.String())
+	}
+	job := *m.job
+	job.Status = m.statuses[0]
+	if len(m.statuses) > 1 {
+		m.statuses = m.statuses[1:]
+	}
+	return &job, nil
+}
+
+// mockWatchResultRepository serves one result.
+type mockWatchResultRepository struct {
+	repository.BacktestResultRepository
+	result *domain.BacktestResult
+}
+
+func (m *mockWatchResultRepository) GetByJobID(ctx context.Context, jobID uuid.UUID) (*domain.BacktestResult, error) {
+	if m.result == nil || m.result.JobID != jobID {
+		return nil, domain.NewNotFoundError("backtest_result", jobID.String())
+	}
+	return m.result, nil
+}
+
+// recordingStream collects the updates sent on a server stream.
+type recordingStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates []*pb.WatchBacktestJobResponse
+}
+
+func (s *recordingStream) Context() context.Context { return s.ctx }
+
+func (s *recordingStream) Send(m *pb.WatchBacktestJobResponse) error {
+	s.updates = append(s.updates, m)
+	return nil
+}
+
+func newWatchServer(t *testing.T, jobs *mockWatchJobRepository, results *mockWatchResultRepository) *Server {
+	server := NewServer(&repository.Repositories{BacktestJob: jobs, Result: results}, nil, nil, zaptest.NewLogger(t))
+	server.SetJobWatch(&config.JobWatchConfig{PollInterval: "1ms"})
+	return server
+}
+
+func TestWatchBacktestJob_StreamsTransitions(t *testing.T) {
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+	jobs := &mockWatchJobRepository{job: job, statuses: []domain.JobStatus{
+		domain.JobStatusPending, domain.JobStatusPending, domain.JobStatusRunning,
+		domain.JobStatusRunning, domain.JobStatusRunning, domain.JobStatusCompleted,
+	}}
+	result := domain.NewBacktestResult(job.ID, job.StrategyID)
+	server := newWatchServer(t, jobs, &mockWatchResultRepository{result: result})
+
+	stream := &recordingStream{ctx: context.Background()}
+	require.NoError(t, server.WatchBacktestJob(&pb.WatchBacktestJobRequest{JobId: job.ID.String()}, stream))
+
+	// Unchanged lookups send nothing
+	require.Len(t, stream.updates, 3)
+	assert.Equal(t, pb.JobStatus_JOB_STATUS_PENDING, stream.updates[0].Job.Status)
+	assert.Equal(t, pb.JobStatus_JOB_STATUS_RUNNING, stream.updates[1].Job.Status)
+	assert.False(t, stream.updates[1].Final)
+	assert.Nil(t, stream.updates[1].Result)
+
+	last := stream.updates[2]
+	assert.Equal(t, pb.JobStatus_JOB_STATUS_COMPLETED, last.Job.Status)
+	assert.True(t, last.Final)
+	require.NotNil(t, last.Result)
+	assert.Equal(t, result.ID.String(), last.Result.Id)
+}
+
+func TestWatchBacktestJob_FinishedJob(t *testing.T) {
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+	jobs := &mockWatchJobRepository{job: job, statuses: []domain.JobStatus{domain.JobStatusFailed}}
+	server := newWatchServer(t, jobs, &mockWatchResultRepository{})
+
+	stream := &recordingStream{ctx: context.Background()}
+	require.NoError(t, server.WatchBacktestJob(&pb.WatchBacktestJobRequest{JobId: job.ID.String()}, stream))
+	require.Len(t, stream.updates, 1)
+	assert.True(t, stream.updates[0].Final)
+	assert.Nil(t, stream.updates[0].Result)
+
+	err := server.WatchBacktestJob(&pb.WatchBacktestJobRequest{JobId: uuid.NewString()}, stream)
+	assert.Equal(t, grpccodes.NotFound, status.Code(err))
+}
+
+func TestWatchBacktestJob_Stop(t *testing.T) {
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+	jobs := &mockWatchJobRepository{job: job, statuses: []domain.JobStatus{domain.JobStatusRunning}}
+	server := newWatchServer(t, jobs, &mockWatchResultRepository{})
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.WatchBacktestJob(&pb.WatchBacktestJobRequest{JobId: job.ID.String()}, &recordingStream{ctx: context.Background()})
+	}()
+
+	// Stopping the server ends open watches instead of waiting for them
+	server.Stop()
+	assert.Equal(t, grpccodes.Unavailable, status.Code(<-errs))
+}
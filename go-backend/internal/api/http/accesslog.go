@@ -0,0 +1,101 @@
+package http
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/accesslog"
+)
+
+// accessLogMiddleware logs every request with its status, latency, request ID
+// and caller. The request ID is taken from the X-Request-ID header or
+// generated, and echoed in the response. The caller is the authenticated
+// principal, or the X-User-ID a client claims without authentication.
+func accessLogMiddleware(l *accesslog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		requestID := accesslog.RequestID(r.Header.Get(accesslog.RequestIDHeader))
+		r.Header.Set(accesslog.RequestIDHeader, requestID)
+		w.Header().Set(accesslog.RequestIDHeader, requestID)
+
+		rec := &accessRecorder{ResponseWriter: w}
+		defer func() {
+			// A handler panic, e.g. http.ErrAbortHandler, aborts the response;
+			// it is logged as failed and passed on to the server
+			aborted := recover()
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+				if aborted != nil {
+					status = http.StatusInternalServerError
+				}
+			}
+
+			// The auth middleware replaces X-User-ID with the principal
+			// in the shared header map, so it is read once served
+			l.Log(r.URL.Path, status >= 400 || aborted != nil, time.Since(start),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Int("status", status),
+				zap.Bool("aborted", aborted != nil),
+				zap.Int64("bytes", rec.bytes),
+				zap.String("request_id", requestID),
+				zap.String("caller", r.Header.Get(userIDHeader)),
+				zap.String("remote_addr", r.RemoteAddr),
+			)
+
+			if aborted != nil {
+				panic(aborted)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// accessRecorder records the status and size of a response. It passes
+// flushes and hijacks through, for streamed responses and WebSockets.
+type accessRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *accessRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *accessRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush sends buffered data to the client.
+func (r *accessRecorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Hijack takes over the connection, e.g. to upgrade it to a WebSocket.
+func (r *accessRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(r.ResponseWriter).Hijack()
+	if err == nil && r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped writer, for http.ResponseController.
+func (r *accessRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
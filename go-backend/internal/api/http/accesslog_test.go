@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/accesslog"
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	a, err := auth.NewAuthenticator(&config.AuthConfig{
+		Enabled: true,
+		Keys:    []config.AuthKeyConfig{{Name: "dashboard", KeyHash: domain.HashAPIKey("dash-secret")}},
+	}, nil, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := accesslog.New(zap.New(core), map[string]float64{"/health": 0}, time.Minute)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("created"))
+	})
+	handler := accessLogMiddleware(l, authMiddleware(a, zaptest.NewLogger(t), next))
+
+	serve := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		for k, vs := range header {
+			req.Header[k] = vs
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The caller is the authenticated principal, not the claimed user
+	rec := serve("/api/v1/strategies", http.Header{
+		"Authorization": {"Bearer dash-secret"},
+		"X-User-Id":     {"someone-else"},
+		"X-Request-Id":  {"req-1"},
+	})
+	assert.Equal(t, "req-1", rec.Header().Get(accesslog.RequestIDHeader))
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "POST", fields["method"])
+	assert.Equal(t, "/api/v1/strategies", fields["path"])
+	assert.Equal(t, int64(http.StatusCreated), fields["status"])
+	assert.Equal(t, int64(len("created")), fields["bytes"])
+	assert.Equal(t, "req-1", fields["request_id"])
+	assert.Equal(t, "dashboard", fields["caller"])
+
+	// Rejected requests are logged with a generated request ID
+	rec = serve("/api/v1/strategies", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, 2, logs.Len())
+	fields = logs.All()[1].ContextMap()
+	assert.Equal(t, int64(http.StatusUnauthorized), fields["status"])
+	assert.Equal(t, rec.Header().Get(accesslog.RequestIDHeader), fields["request_id"])
+	assert.NotEmpty(t, fields["request_id"])
+
+	// Successful health checks are sampled out
+	serve("/health", nil)
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestAccessLogMiddleware_Aborted(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	handler := accessLogMiddleware(accesslog.New(zap.New(core), nil, 0), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/backtests/export", nil))
+	})
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, int64(http.StatusOK), fields["status"])
+	assert.Equal(t, true, fields["aborted"])
+}
@@ -110,6 +110,7 @@ func (s *AgentStore) cleanup() {
 
 // AgentHeartbeatPayload represents the JSON payload of a heartbeat event.
 type AgentHeartbeatPayload struct {
+	AgentID     string `json:"agent_id,omitempty"` // Set by registered agents
 	AgentType   string `json:"agent_type"`
 	Status      string `json:"status"`
 	CurrentTask string `json:"current_task,omitempty"`
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// apiKeyHeader carries an API key as an alternative to a bearer token.
+const apiKeyHeader = "X-API-Key"
+
+// requestAPIKey returns the API key of a request, from a bearer token or the
+// X-API-Key header.
+func requestAPIKey(r *http.Request) string {
+	if key := auth.BearerKey(r.Header.Get("Authorization")); key != "" {
+		return key
+	}
+	return strings.TrimSpace(r.Header.Get(apiKeyHeader))
+}
+
+// adminPathPrefix prefixes admin operations, which require an admin for
+// reads and writes alike.
+const adminPathPrefix = "/api/v1/admin/"
+
+// writeRoleOverrides give the role required for writes to paths matching a
+// pattern (path.Match syntax). Other writes require an operator; reads only
+// a viewer. The first match wins.
+var writeRoleOverrides = []struct {
+	pattern string
+	role    domain.Role
+}{
+	{"/api/v1/preferences", domain.RoleViewer},
+	{"/api/v1/preferences/*", domain.RoleViewer},
+	{"/api/v1/strategies/*/star", domain.RoleViewer},
+	{"/api/v1/optimizations/*/star", domain.RoleViewer},
+	{"/api/v1/analytics/significance", domain.RoleViewer}, // Computes a test, changes nothing
+}
+
+// requiredRole returns the role a REST request requires.
+func requiredRole(r *http.Request) domain.Role {
+	if strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+		return domain.RoleAdmin
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return domain.RoleViewer
+	}
+	for _, o := range writeRoleOverrides {
+		if matched, _ := path.Match(o.pattern, r.URL.Path); matched {
+			return o.role
+		}
+	}
+	return domain.RoleOperator
+}
+
+// authMiddleware requires an API key on REST API requests other than the
+// configured public paths, with a role allowing the request. The key's name
+// replaces any X-User-ID header, so callers can't act as another principal.
+func authMiddleware(a *auth.Authenticator, logger *zap.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") || a.IsPublicPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := a.Authenticate(r.Context(), requestAPIKey(r))
+		if err != nil {
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="freqsearch"`)
+				writeError(w, http.StatusUnauthorized, err, "")
+				return
+			}
+			logger.Error("Failed to authenticate request", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to authenticate request")
+			return
+		}
+		if required := requiredRole(r); !principal.Role.Allows(required) {
+			writeError(w, http.StatusForbidden, auth.ErrForbidden, string(required)+" role required")
+			return
+		}
+
+		r.Header.Set(userIDHeader, principal.Name)
+		next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+	})
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	a, err := auth.NewAuthenticator(&config.AuthConfig{
+		Enabled:     true,
+		Keys:        []config.AuthKeyConfig{{Name: "dashboard", KeyHash: domain.HashAPIKey("dash-secret")}},
+		PublicPaths: []string{"/api/v1/strategies/*/badge.svg"},
+	}, nil, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	var gotUser string
+	var gotPrincipal *auth.Principal
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = r.Header.Get(userIDHeader)
+		gotPrincipal, _ = auth.FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := authMiddleware(a, zaptest.NewLogger(t), next)
+
+	serve := func(path string, header http.Header) int {
+		gotUser, gotPrincipal = "", nil
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, vs := range header {
+			req.Header[k] = vs
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/strategies", nil))
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/v1/strategies", http.Header{"Authorization": {"Bearer wrong"}}))
+
+	// Health checks, the frontend and public paths need no key
+	assert.Equal(t, http.StatusOK, serve("/health", nil))
+	assert.Equal(t, http.StatusOK, serve("/index.html", nil))
+	assert.Equal(t, http.StatusOK, serve("/api/v1/strategies/abc/badge.svg", nil))
+	assert.Nil(t, gotPrincipal)
+
+	// The key's name replaces the claimed user
+	assert.Equal(t, http.StatusOK, serve("/api/v1/strategies", http.Header{
+		"Authorization": {"Bearer dash-secret"},
+		"X-User-Id":     {"someone-else"},
+	}))
+	assert.Equal(t, "dashboard", gotUser)
+	require.NotNil(t, gotPrincipal)
+	assert.Equal(t, "dashboard", gotPrincipal.Name)
+
+	assert.Equal(t, http.StatusOK, serve("/api/v1/strategies", http.Header{"X-Api-Key": {"dash-secret"}}))
+	assert.Equal(t, "dashboard", gotUser)
+}
+
+func TestAuthMiddleware_Roles(t *testing.T) {
+	a, err := auth.NewAuthenticator(&config.AuthConfig{
+		Enabled: true,
+		Keys: []config.AuthKeyConfig{
+			{Name: "dashboard", KeyHash: domain.HashAPIKey("viewer-secret"), Role: "viewer"},
+			{Name: "ci", KeyHash: domain.HashAPIKey("operator-secret"), Role: "operator"},
+			{Name: "ops", KeyHash: domain.HashAPIKey("admin-secret"), Role: "admin"},
+		},
+	}, nil, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := authMiddleware(a, zaptest.NewLogger(t), next)
+
+	serve := func(method, path, key string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Viewers read, and manage their own preferences
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/strategies", "viewer-secret"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPut, "/api/v1/preferences/theme", "viewer-secret"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPut, "/api/v1/strategies/abc/star", "viewer-secret"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/analytics/significance", "viewer-secret"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/v1/backtests", "viewer-secret"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/api/v1/strategies/abc", "viewer-secret"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/v1/optimizations/abc/control", "viewer-secret"))
+
+	// Operators change things, but only admins use admin operations, reads
+	// included
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/backtests", "operator-secret"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodDelete, "/api/v1/strategies/abc", "operator-secret"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/api/v1/admin/consistency", "operator-secret"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/admin/consistency", "admin-secret"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/v1/admin/consistency/repair", "operator-secret"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/admin/consistency/repair", "admin-secret"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/v1/admin/dead-letters/abc/requeue", "operator-secret"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/admin/dead-letters/abc/requeue", "admin-secret"))
+}
+
+func TestHandleAPIKeys_RequiresAdmin(t *testing.T) {
+	a, err := auth.NewAuthenticator(&config.AuthConfig{Enabled: true}, nil, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	h := NewHandler(nil, nil, zaptest.NewLogger(t))
+	h.SetAuth(a)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/keys", nil)
+	req = req.WithContext(auth.NewContext(req.Context(), &auth.Principal{Name: "dashboard"}))
+	rec := httptest.NewRecorder()
+	h.HandleListAPIKeys(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// Without an authenticator, API keys are unavailable
+	rec = httptest.NewRecorder()
+	NewHandler(nil, nil, zaptest.NewLogger(t)).HandleListAPIKeys(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
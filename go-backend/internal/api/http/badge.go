@@ -0,0 +1,67 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"unicode/utf8"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+const (
+	// badgeMaxLabel is the most characters of a strategy name shown on a badge.
+	badgeMaxLabel = 32
+
+	// Badge colors by the best result's sharpe ratio
+	badgeColorGood    = "#4c1"    // Sharpe of at least 1
+	badgeColorFair    = "#dfb317" // Non-negative sharpe
+	badgeColorPoor    = "#e05d44" // Negative sharpe
+	badgeColorUnknown = "#9f9f9f" // No result with a sharpe ratio
+)
+
+// badgeText returns the value text and color of a badge for the strategy's
+// best result, which may be nil.
+func badgeText(best *domain.BacktestResult) (value, color string) {
+	if best == nil || best.SharpeRatio == nil {
+		return "no results", badgeColorUnknown
+	}
+	sharpe := *best.SharpeRatio
+	value = fmt.Sprintf("sharpe %.2f | %+.1f%%", sharpe, best.ProfitPct)
+	switch {
+	case sharpe >= 1:
+		color = badgeColorGood
+	case sharpe >= 0:
+		color = badgeColorFair
+	default:
+		color = badgeColorPoor
+	}
+	return value, color
+}
+
+// badgeWidth estimates the rendered width in pixels of text in the badge font.
+func badgeWidth(text string) int {
+	return utf8.RuneCountInString(text)*7 + 10
+}
+
+// renderBadge renders a flat two-part SVG badge with the label on the left
+// and the value on the right.
+func renderBadge(label, value, color string) []byte {
+	if utf8.RuneCountInString(label) > badgeMaxLabel {
+		label = string([]rune(label)[:badgeMaxLabel-1]) + "…"
+	}
+	lw, vw := badgeWidth(label), badgeWidth(value)
+	label, value = html.EscapeString(label), html.EscapeString(value)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, lw+vw, label, value)
+	fmt.Fprintf(&b, `<title>%s: %s</title>`, label, value)
+	fmt.Fprintf(&b, `<rect width="%d" height="20" rx="3" fill="#555"/>`, lw+vw)
+	fmt.Fprintf(&b, `<rect x="%d" width="%d" height="20" rx="3" fill="%s"/>`, lw, vw, color)
+	fmt.Fprintf(&b, `<rect x="%d" width="4" height="20" fill="%s"/>`, lw, color)
+	b.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	fmt.Fprintf(&b, `<text x="%d" y="14">%s</text>`, lw/2, label)
+	fmt.Fprintf(&b, `<text x="%d" y="14">%s</text>`, lw+vw/2, value)
+	b.WriteString(`</g></svg>`)
+	return b.Bytes()
+}
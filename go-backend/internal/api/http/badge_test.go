@@ -0,0 +1,38 @@
+package http
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestBadgeText(t *testing.T) {
+	value, color := badgeText(nil)
+	assert.Equal(t, "no results", value)
+	assert.Equal(t, badgeColorUnknown, color)
+
+	sharpe := 1.826
+	value, color = badgeText(&domain.BacktestResult{SharpeRatio: &sharpe, ProfitPct: 12.34})
+	assert.Equal(t, "sharpe 1.83 | +12.3%", value)
+	assert.Equal(t, badgeColorGood, color)
+
+	sharpe = -0.4
+	value, color = badgeText(&domain.BacktestResult{SharpeRatio: &sharpe, ProfitPct: -3})
+	assert.Equal(t, "sharpe -0.40 | -3.0%", value)
+	assert.Equal(t, badgeColorPoor, color)
+}
+
+func TestRenderBadge(t *testing.T) {
+	svg := renderBadge(`RSI<Cross> & "Friends"`+strings.Repeat("x", 40), "sharpe 1.20 | +4.0%", badgeColorGood)
+
+	// Names are escaped and truncated, and the result is well-formed XML
+	require.NoError(t, xml.Unmarshal(svg, new(struct{})))
+	assert.Contains(t, string(svg), "RSI&lt;Cross&gt; &amp; &#34;Friends&#34;")
+	assert.Contains(t, string(svg), "…")
+	assert.Contains(t, string(svg), `fill="#4c1"`)
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestRequestCancellation(t *testing.T) {
+	// Reason in the query, caller from the user header
+	r := httptest.NewRequest("DELETE", "/api/v1/backtests/x?reason=superseded", nil)
+	r.Header.Set(userIDHeader, "ops")
+	c, err := requestCancellation(r)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Cancellation{Reason: "superseded", CancelledBy: "ops"}, c)
+
+	// Reason in the body
+	r = httptest.NewRequest("DELETE", "/api/v1/backtests/x", strings.NewReader(`{"reason":"  wrong pairs "}`))
+	c, err = requestCancellation(r)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Cancellation{Reason: "wrong pairs", CancelledBy: domain.DefaultPreferenceOwner}, c)
+
+	// No reason
+	r = httptest.NewRequest("DELETE", "/api/v1/backtests/x", nil)
+	c, err = requestCancellation(r)
+	require.NoError(t, err)
+	assert.Empty(t, c.Reason)
+
+	r = httptest.NewRequest("DELETE", "/api/v1/backtests/x", strings.NewReader(`{`))
+	_, err = requestCancellation(r)
+	assert.Error(t, err)
+
+	r = httptest.NewRequest("DELETE", "/api/v1/backtests/x?reason="+strings.Repeat("x", domain.MaxCancelReasonLength+1), nil)
+	_, err = requestCancellation(r)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockQueueStatsJobRepository serves fixed queue statistics.
+type mockQueueStatsJobRepository struct {
+	repository.BacktestJobRepository
+	stats domain.QueueStats
+}
+
+func (m *mockQueueStatsJobRepository) GetQueueStats(ctx context.Context) (*domain.QueueStats, error) {
+	return &m.stats, nil
+}
+
+func TestHandleGetCapacity_Limits(t *testing.T) {
+	h := NewHandler(&repository.Repositories{
+		BacktestJob: &mockQueueStatsJobRepository{stats: domain.QueueStats{PendingJobs: 10}},
+	}, nil, zaptest.NewLogger(t))
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.HandleGetCapacity(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := serve("/api/v1/admin/capacity?workers=1,2,3,4,5,6,7,8&avg_runtime=15m&queue=100000")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp CapacityResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 100000, resp.QueueLength)
+	assert.Len(t, resp.Scenarios, 8)
+
+	assert.Equal(t, http.StatusBadRequest, serve("/api/v1/admin/capacity?avg_runtime=15m&queue=100001").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("/api/v1/admin/capacity?avg_runtime=15m&workers=1,2,3,4,5,6,7,8,9").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("/api/v1/admin/capacity?avg_runtime=15m&workers=2048").Code)
+}
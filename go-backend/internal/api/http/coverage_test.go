@@ -0,0 +1,81 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockStrategyRepository serves a fixed set of strategies by ID.
+type mockStrategyRepository struct {
+	repository.StrategyRepository
+	strategies map[uuid.UUID]*domain.Strategy
+}
+
+func (m *mockStrategyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Strategy, error) {
+	if s, ok := m.strategies[id]; ok {
+		return s, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+// mockCoverageJobRepository serves fixed coverage entries per strategy.
+type mockCoverageJobRepository struct {
+	repository.BacktestJobRepository
+	entries map[uuid.UUID][]domain.CoverageEntry
+}
+
+func (m *mockCoverageJobRepository) GetCoverageEntries(ctx context.Context, strategyID uuid.UUID) ([]domain.CoverageEntry, error) {
+	return m.entries[strategyID], nil
+}
+
+func TestHandleGetStrategyCoverage(t *testing.T) {
+	id := uuid.New()
+	h := NewHandler(&repository.Repositories{
+		Strategy: &mockStrategyRepository{strategies: map[uuid.UUID]*domain.Strategy{id: {ID: id}}},
+		BacktestJob: &mockCoverageJobRepository{entries: map[uuid.UUID][]domain.CoverageEntry{id: {
+			{
+				Config:    domain.BacktestConfig{Timeframe: "1h", Pairs: []string{"BTC/USDT"}, TimerangeStart: "2024-01-01", TimerangeEnd: "2024-01-31"},
+				Status:    domain.JobStatusCompleted,
+				HasResult: true,
+			},
+			{
+				Config: domain.BacktestConfig{Timeframe: "5m", Pairs: []string{"ETH/USDT"}, TimerangeStart: "2024-02-01", TimerangeEnd: "2024-02-29"},
+				Status: domain.JobStatusRunning,
+			},
+		}}},
+	}, nil, zaptest.NewLogger(t))
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.HandleGetStrategyCoverage(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := serve("/api/v1/strategies/" + id.String() + "/coverage?pairs=BTC/USDT,ETH/USDT&timeframes=1h,5m&start=2024-01-01&end=2024-03-31")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var coverage domain.StrategyCoverage
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&coverage))
+	assert.Equal(t, id, coverage.StrategyID)
+	assert.Equal(t, 2, coverage.TotalJobs)
+	assert.Equal(t, 1, coverage.CompletedJobs)
+	assert.Equal(t, []domain.DateRange{{Start: "2024-01-01", End: "2024-01-31", Days: 31}}, coverage.Timeranges)
+	assert.Equal(t, []string{"ETH/USDT"}, coverage.Gaps.Pairs)
+	assert.Equal(t, []string{"5m"}, coverage.Gaps.Timeframes)
+	assert.Equal(t, []domain.DateRange{{Start: "2024-02-01", End: "2024-03-31", Days: 60}}, coverage.Gaps.Timeranges)
+
+	assert.Equal(t, http.StatusBadRequest, serve("/api/v1/strategies/not-a-uuid/coverage").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("/api/v1/strategies/"+id.String()+"/coverage?start=2024-02-01&end=2024-01-01").Code)
+	assert.Equal(t, http.StatusNotFound, serve("/api/v1/strategies/"+uuid.NewString()+"/coverage").Code)
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockExternalRefJobRepository stores created jobs in memory. External
+// references are unique per owner, as with the Postgres unique index.
+type mockExternalRefJobRepository struct {
+	repository.BacktestJobRepository
+	jobs []*domain.BacktestJob
+}
+
+func (m *mockExternalRefJobRepository) Create(ctx context.Context, job *domain.BacktestJob) error {
+	if job.ExternalRef != nil {
+		if _, err := m.GetByExternalRef(ctx, *job.ExternalRefOwner, *job.ExternalRef); err == nil {
+			return domain.NewDuplicateError("backtest_job", "external_ref", *job.ExternalRef)
+		}
+	}
+	m.jobs = append(m.jobs, job)
+	return nil
+}
+
+func (m *mockExternalRefJobRepository) GetActiveDuplicates(ctx context.Context, jobs []*domain.BacktestJob) ([]*uuid.UUID, error) {
+	return make([]*uuid.UUID, len(jobs)), nil
+}
+
+func (m *mockExternalRefJobRepository) GetByExternalRef(ctx context.Context, owner, ref string) (*domain.BacktestJob, error) {
+	for _, job := range m.jobs {
+		if job.ExternalRef != nil && *job.ExternalRefOwner == owner && *job.ExternalRef == ref {
+			return job, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// mockExternalRefOptimizationRepository serves fixed runs by external reference.
+type mockExternalRefOptimizationRepository struct {
+	repository.OptimizationRepository
+	runs []*domain.OptimizationRun
+}
+
+func (m *mockExternalRefOptimizationRepository) GetByExternalRef(ctx context.Context, owner, ref string) (*domain.OptimizationRun, error) {
+	for _, run := range m.runs {
+		if run.ExternalRef != nil && *run.ExternalRefOwner == owner && *run.ExternalRef == ref {
+			return run, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockExternalRefOptimizationRepository) GetIterations(ctx context.Context, runID uuid.UUID) ([]*domain.OptimizationIteration, error) {
+	return nil, nil
+}
+
+// mockArtifactRepository has no artifacts.
+type mockArtifactRepository struct {
+	repository.ArtifactRepository
+}
+
+func (m *mockArtifactRepository) ListByOwners(ctx context.Context, ownerType domain.ArtifactOwnerType, ownerIDs []uuid.UUID) ([]*domain.Artifact, error) {
+	return nil, nil
+}
+
+func TestBacktestExternalRef(t *testing.T) {
+	strategy := &domain.Strategy{ID: uuid.New(), Name: "RefStrategy", ValidationStatus: domain.ValidationStatusValidated}
+	jobs := &mockExternalRefJobRepository{}
+	h := NewHandler(&repository.Repositories{
+		Strategy:    &mockStrategyRepository{strategies: map[uuid.UUID]*domain.Strategy{strategy.ID: strategy}},
+		BacktestJob: jobs,
+	}, nil, zaptest.NewLogger(t))
+
+	submit := func(user, ref string, dryRun bool) *httptest.ResponseRecorder {
+		body, err := json.Marshal(SubmitBacktestRequest{
+			StrategyID:  strategy.ID.String(),
+			Config:      domain.BacktestConfig{Timeframe: "1h", Pairs: []string{"BTC/USDT"}},
+			ExternalRef: &ref,
+			DryRun:      dryRun,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/backtests", strings.NewReader(string(body)))
+		req.Header.Set(userIDHeader, user)
+		rec := httptest.NewRecorder()
+		h.HandleSubmitBacktest(rec, req)
+		return rec
+	}
+	lookup := func(user, ref string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/backtests/by-ref/"+ref, nil)
+		req.Header.Set(userIDHeader, user)
+		rec := httptest.NewRecorder()
+		h.HandleGetBacktestJobByRef(rec, req)
+		return rec
+	}
+
+	rec := submit("alice", "ci:build-42", false)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var submitted SubmitBacktestResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&submitted))
+	require.NotNil(t, submitted.Job.ExternalRef)
+	assert.Equal(t, "ci:build-42", *submitted.Job.ExternalRef)
+
+	// The submitter looks the job up by its reference
+	rec = lookup("alice", "ci:build-42")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got GetBacktestJobResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, submitted.Job.ID, got.Job.ID)
+
+	// References are scoped to their owner
+	assert.Equal(t, http.StatusNotFound, lookup("bob", "ci:build-42").Code)
+	assert.Equal(t, http.StatusNotFound, lookup("alice", "ci:build-43").Code)
+
+	// A duplicate reference is rejected, in dry runs too
+	assert.Equal(t, http.StatusConflict, submit("alice", "ci:build-42", false).Code)
+	assert.Equal(t, http.StatusConflict, submit("alice", "ci:build-42", true).Code)
+	assert.Len(t, jobs.jobs, 1)
+
+	rec = submit("bob", "ci:build-42", false)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Len(t, jobs.jobs, 2)
+
+	// Malformed references are rejected
+	assert.Equal(t, http.StatusBadRequest, submit("alice", "has space", false).Code)
+	assert.Equal(t, http.StatusBadRequest, submit("alice", strings.Repeat("x", domain.MaxExternalRefLength+1), false).Code)
+	assert.Equal(t, http.StatusBadRequest, lookup("alice", "bad%20ref").Code)
+}
+
+func TestHandleGetOptimizationRunByRef(t *testing.T) {
+	run := domain.NewOptimizationRun("sweep", uuid.New(), domain.OptimizationConfig{MaxIterations: 5})
+	run.SetExternalRef("alice", "sweep-7")
+	h := NewHandler(&repository.Repositories{
+		Optimization: &mockExternalRefOptimizationRepository{runs: []*domain.OptimizationRun{run}},
+		Artifact:     &mockArtifactRepository{},
+	}, nil, zaptest.NewLogger(t))
+
+	lookup := func(user, ref string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/optimizations/by-ref/"+ref, nil)
+		req.Header.Set(userIDHeader, user)
+		rec := httptest.NewRecorder()
+		h.HandleGetOptimizationRunByRef(rec, req)
+		return rec
+	}
+
+	rec := lookup("alice", "sweep-7")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got GetOptimizationRunResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, run.ID, got.Run.ID)
+
+	assert.Equal(t, http.StatusNotFound, lookup("bob", "sweep-7").Code)
+	assert.Equal(t, http.StatusBadRequest, lookup("alice", "bad%20ref").Code)
+}
@@ -1,9 +1,12 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,9 +14,15 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
 	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 	"github.com/saltfish/freqsearch/go-backend/internal/events"
+	"github.com/saltfish/freqsearch/go-backend/internal/insights"
+	"github.com/saltfish/freqsearch/go-backend/internal/ranking"
+	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
 )
 
 // Handler provides REST API handlers.
@@ -22,6 +31,26 @@ type Handler struct {
 	agentStore     *AgentStore
 	eventPublisher events.Publisher
 	scoutScheduler ScoutSchedulerInterface
+	scheduler      SchedulerInterface
+	queryMonitor   QueryMonitor
+	slaMonitor     SLAMonitorInterface
+	features       map[string]bool
+	agentTTL       time.Duration // Registrations not seen for longer are not live; 0 keeps them live
+	enforceAgents  bool          // Refuse runs no live registered agent can serve
+	secrets        *secrets.Store
+	scoutLimits    map[string]domain.ScoutSourceLimits // Etiquette limits of Scout sources
+	insights       *insights.Service
+	ranker         *ranking.Ranker
+	auth           *auth.Authenticator
+	maintenance    MaintenanceInterface
+	defaultImage   string         // Image re-validation runs use when none is given
+	location       *time.Location // Server timezone reported with schedules and reports
+	maxEpochs      int            // Largest epochs a hyperopt job may ask for; 0 is unlimited
+	hyperopt       HyperoptRunnerInterface
+	containerLogs  ContainerLogStreamer
+	codeSafety     *domain.CodeSafetyPolicy // Nil allows any strategy code
+	marketData     MarketDataInventoryInterface
+	downloader     DataDownloaderInterface
 	logger         *zap.Logger
 }
 
@@ -30,6 +59,25 @@ type ScoutSchedulerInterface interface {
 	ReloadSchedules() error
 }
 
+// SchedulerInterface defines the interface for backtest scheduler operations.
+type SchedulerInterface interface {
+	WorkerCount() int
+	PriorityAgingPerHour() float64
+	MaxValidationBatch() int
+	ValidateStrategies(ctx context.Context, items []scheduler.StrategyValidationItem) *scheduler.BatchValidationResult
+	State() *scheduler.State
+	Status() *domain.SchedulerStatus
+	Pause(by string) *domain.SchedulerStatus
+	Drain(by string) *domain.SchedulerStatus
+	Resume(by string) *domain.SchedulerStatus
+}
+
+// QueryMonitor defines the interface for inspecting executing database queries.
+type QueryMonitor interface {
+	ActiveQueries() []db.ActiveQuery
+	QueryStats() db.QueryStats
+}
+
 // NewHandler creates a new Handler instance.
 func NewHandler(repos *repository.Repositories, agentStore *AgentStore, logger *zap.Logger) *Handler {
 	return &Handler{
@@ -49,6 +97,106 @@ func (h *Handler) SetScoutScheduler(scheduler ScoutSchedulerInterface) {
 	h.scoutScheduler = scheduler
 }
 
+// SetScheduler sets the backtest scheduler for the handler.
+func (h *Handler) SetScheduler(scheduler SchedulerInterface) {
+	h.scheduler = scheduler
+}
+
+// SetQueryMonitor sets the database query monitor for the handler.
+func (h *Handler) SetQueryMonitor(monitor QueryMonitor) {
+	h.queryMonitor = monitor
+}
+
+// SetSLAMonitor sets the queue SLA monitor for the handler.
+func (h *Handler) SetSLAMonitor(monitor SLAMonitorInterface) {
+	h.slaMonitor = monitor
+}
+
+// SetFeatures sets the configured feature flags for the handler.
+func (h *Handler) SetFeatures(flags map[string]bool) {
+	h.features = flags
+}
+
+// SetAgents sets how agent registrations are tracked for the handler.
+func (h *Handler) SetAgents(ttl time.Duration, enforce bool) {
+	h.agentTTL = ttl
+	h.enforceAgents = enforce
+}
+
+// SetScoutSourceLimits sets the etiquette limits of Scout sources, keyed by
+// source, that triggered runs must keep.
+func (h *Handler) SetScoutSourceLimits(limits map[string]domain.ScoutSourceLimits) {
+	h.scoutLimits = limits
+}
+
+// SetSecrets sets the secrets store for the handler.
+func (h *Handler) SetSecrets(store *secrets.Store) {
+	h.secrets = store
+}
+
+// SetAuth sets the authenticator behind the API key endpoints.
+func (h *Handler) SetAuth(a *auth.Authenticator) {
+	h.auth = a
+}
+
+// SetInsights sets the insights service for the handler.
+func (h *Handler) SetInsights(svc *insights.Service) {
+	h.insights = svc
+}
+
+// SetRanker sets the ranker behind the percentile ranks of backtest results.
+func (h *Handler) SetRanker(r *ranking.Ranker) {
+	h.ranker = r
+}
+
+// SetMaintenance sets the maintenance mode for the handler.
+func (h *Handler) SetMaintenance(m MaintenanceInterface) {
+	h.maintenance = m
+}
+
+// SetDefaultImage sets the image re-validation runs use when none is given.
+func (h *Handler) SetDefaultImage(image string) {
+	h.defaultImage = image
+}
+
+// SetHyperopt sets the hyperopt runner and the largest epochs a hyperopt
+// job may ask for.
+func (h *Handler) SetHyperopt(runner HyperoptRunnerInterface, maxEpochs int) {
+	h.hyperopt = runner
+	h.maxEpochs = maxEpochs
+}
+
+// SetContainerLogs sets where the output of running jobs' containers is read
+// from.
+func (h *Handler) SetContainerLogs(streamer ContainerLogStreamer) {
+	h.containerLogs = streamer
+}
+
+// SetCodeSafety sets the static checks strategy code must pass before it is
+// stored.
+func (h *Handler) SetCodeSafety(policy *domain.CodeSafetyPolicy) {
+	h.codeSafety = policy
+}
+
+// SetMarketData sets the market data listing and downloader.
+func (h *Handler) SetMarketData(inventory MarketDataInventoryInterface, downloader DataDownloaderInterface) {
+	h.marketData = inventory
+	h.downloader = downloader
+}
+
+// SetTimezone sets the server timezone for the handler.
+func (h *Handler) SetTimezone(loc *time.Location) {
+	h.location = loc
+}
+
+// timezone returns the server timezone, UTC unless one was set.
+func (h *Handler) timezone() *time.Location {
+	if h.location == nil {
+		return time.UTC
+	}
+	return h.location
+}
+
 // Error response structure
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -103,6 +251,10 @@ type CreateStrategyRequest struct {
 	Code        string  `json:"code"`
 	Description string  `json:"description"`
 	ParentID    *string `json:"parent_id,omitempty"`
+
+	// Outcome of validation done by the importer (e.g. Scout), if any
+	ValidationStatus *domain.ValidationStatus `json:"validation_status,omitempty"`
+	ValidationErrors []string                 `json:"validation_errors,omitempty"`
 }
 
 // CreateStrategyResponse represents the response for creating a strategy.
@@ -154,6 +306,21 @@ func (h *Handler) HandleCreateStrategy(w http.ResponseWriter, r *http.Request) {
 		strategy.ParentID = &parentID
 	}
 
+	if req.ValidationStatus != nil {
+		if !req.ValidationStatus.IsValid() {
+			writeError(w, http.StatusBadRequest, domain.ErrInvalidInput, "invalid validation_status")
+			return
+		}
+		validatedAt := time.Now()
+		strategy.ValidationStatus = *req.ValidationStatus
+		strategy.ValidationErrors = req.ValidationErrors
+		strategy.ValidatedAt = &validatedAt
+	}
+
+	if !h.checkCodeSafety(w, strategy.Code) {
+		return
+	}
+
 	if err := h.repos.Strategy.Create(r.Context(), strategy); err != nil {
 		if errors.Is(err, domain.ErrDuplicate) {
 			writeError(w, http.StatusConflict, err, "strategy with same code already exists")
@@ -164,9 +331,21 @@ func (h *Handler) HandleCreateStrategy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.publishStrategyCreated(strategy)
+
 	writeJSON(w, http.StatusCreated, CreateStrategyResponse{Strategy: strategy})
 }
 
+// publishStrategyCreated publishes a strategy created event, logging failures.
+func (h *Handler) publishStrategyCreated(strategy *domain.Strategy) {
+	if h.eventPublisher == nil {
+		return
+	}
+	if err := h.eventPublisher.PublishStrategyCreated(strategy); err != nil {
+		h.logger.Error("Failed to publish strategy created event", zap.Error(err))
+	}
+}
+
 // GetStrategyResponse represents the response for getting a strategy.
 type GetStrategyResponse struct {
 	Strategy *domain.Strategy `json:"strategy"`
@@ -208,7 +387,8 @@ type UpdateStrategyRequest struct {
 	ParentID    *string `json:"parent_id,omitempty"`
 }
 
-// HandleUpdateStrategy updates an existing strategy.
+// HandleUpdateStrategy updates an existing strategy, storing the result as
+// a new version.
 func (h *Handler) HandleUpdateStrategy(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
 		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
@@ -243,8 +423,11 @@ func (h *Handler) HandleUpdateStrategy(w http.ResponseWriter, r *http.Request) {
 	// Sanitize strategy name
 	sanitizedName := domain.SanitizeStrategyName(req.Name)
 
-	// Update code class name if needed
+	// Update code class name if needed; without code the current code is kept
 	code := req.Code
+	if code == "" {
+		code = strategy.Code
+	}
 	if sanitizedName != req.Name {
 		code = strings.Replace(code, "class "+req.Name+"(", "class "+sanitizedName+"(", 1)
 		h.logger.Info("Sanitized strategy name",
@@ -265,17 +448,40 @@ func (h *Handler) HandleUpdateStrategy(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusBadRequest, err, "invalid parent_id")
 			return
 		}
+		if parentID == id {
+			writeError(w, http.StatusBadRequest, errors.New("a strategy cannot be its own parent"), "invalid parent_id")
+			return
+		}
 		strategy.ParentID = &parentID
 	} else {
 		strategy.ParentID = nil
 	}
 
+	if req.Code != "" && !h.checkCodeSafety(w, strategy.Code) {
+		return
+	}
+
+	// Every update is stored as a new version that can be rolled back to
 	if err := h.repos.Strategy.Update(r.Context(), strategy); err != nil {
-		h.logger.Error("Failed to update strategy", zap.Error(err))
-		writeError(w, http.StatusInternalServerError, err, "failed to update strategy")
+		switch {
+		case errors.Is(err, domain.ErrDuplicate):
+			writeError(w, http.StatusConflict, err, "another strategy has the same code")
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, err, "strategy or parent not found")
+		default:
+			h.logger.Error("Failed to update strategy", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to update strategy")
+		}
 		return
 	}
 
+	if h.eventPublisher != nil {
+		if err := h.eventPublisher.PublishStrategyUpdated(strategy); err != nil {
+			h.logger.Error("Failed to publish strategy updated event", zap.Error(err))
+			// Don't fail the request, just log the error
+		}
+	}
+
 	writeJSON(w, http.StatusOK, GetStrategyResponse{Strategy: strategy})
 }
 
@@ -293,6 +499,18 @@ func (h *Handler) HandleDeleteStrategy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Load the strategy first so the deleted event can carry its code hash and parent
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
 	if err := h.repos.Strategy.Delete(r.Context(), id); err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			writeError(w, http.StatusNotFound, err, "strategy not found")
@@ -307,6 +525,13 @@ func (h *Handler) HandleDeleteStrategy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.eventPublisher != nil {
+		if err := h.eventPublisher.PublishStrategyDeleted(strategy); err != nil {
+			h.logger.Error("Failed to publish strategy deleted event", zap.Error(err))
+			// Don't fail the request, just log the error
+		}
+	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -324,8 +549,7 @@ func (h *Handler) HandleSearchStrategies(w http.ResponseWriter, r *http.Request)
 	}
 
 	query := domain.StrategySearchQuery{
-		Page:     1,
-		PageSize: 20,
+		Page: 1,
 	}
 
 	// Parse query parameters
@@ -353,6 +577,21 @@ func (h *Handler) HandleSearchStrategies(w http.ResponseWriter, r *http.Request)
 			query.MinTrades = &val
 		}
 	}
+	if starred := queryParams.Get("starred"); starred == "true" {
+		owner := requestOwner(r)
+		query.StarredBy = &owner
+	}
+	if includeArchived := queryParams.Get("include_archived"); includeArchived == "true" {
+		query.IncludeArchived = true
+	}
+	if validationStatus := queryParams.Get("validation_status"); validationStatus != "" {
+		status := domain.ValidationStatus(validationStatus)
+		if !status.IsValid() {
+			writeError(w, http.StatusBadRequest, domain.ErrInvalidInput, "invalid validation_status")
+			return
+		}
+		query.ValidationStatus = &status
+	}
 	if orderBy := queryParams.Get("order_by"); orderBy != "" {
 		query.OrderBy = orderBy
 	}
@@ -370,7 +609,10 @@ func (h *Handler) HandleSearchStrategies(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	query.SetDefaults()
+	requested := query.PageSize
+	if query.SetDefaults() {
+		warnPageSizeClamped(w, requested, query.PageSize)
+	}
 
 	strategies, totalCount, err := h.repos.Strategy.Search(r.Context(), query)
 	if err != nil {
@@ -392,7 +634,9 @@ type GetStrategyLineageResponse struct {
 	Lineage *domain.StrategyLineageNode `json:"lineage"`
 }
 
-// HandleGetStrategyLineage retrieves the strategy lineage tree.
+// HandleGetStrategyLineage retrieves the strategy lineage tree, walking
+// descendants, ancestors or both.
+// GET /api/v1/strategies/:id/lineage?depth=2&direction=both&include_metrics=true
 func (h *Handler) HandleGetStrategyLineage(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
@@ -410,15 +654,21 @@ func (h *Handler) HandleGetStrategyLineage(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Parse depth parameter
-	depth := 2 // default depth
+	query := domain.StrategyLineageQuery{
+		Direction:      domain.LineageDirection(r.URL.Query().Get("direction")),
+		IncludeMetrics: r.URL.Query().Get("include_metrics") == "true",
+	}
 	if depthStr := r.URL.Query().Get("depth"); depthStr != "" {
 		if val, err := strconv.Atoi(depthStr); err == nil && val > 0 {
-			depth = val
+			query.Depth = val
 		}
 	}
+	if query.Direction != "" && !query.Direction.IsValid() {
+		writeError(w, http.StatusBadRequest, domain.ErrInvalidInput, "direction must be up, down or both")
+		return
+	}
 
-	lineage, err := h.repos.Strategy.GetLineage(r.Context(), id, depth)
+	lineage, err := h.repos.Strategy.GetLineage(r.Context(), id, query)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			writeError(w, http.StatusNotFound, err, "strategy not found")
@@ -432,6 +682,159 @@ func (h *Handler) HandleGetStrategyLineage(w http.ResponseWriter, r *http.Reques
 	writeJSON(w, http.StatusOK, GetStrategyLineageResponse{Lineage: lineage})
 }
 
+// HandleGetStrategyCoverage reports which pairs, timeframes, and timeranges a strategy
+// has been backtested on, and the gaps relative to an optional target universe.
+// GET /api/v1/strategies/:id/coverage?pairs=BTC/USDT,ETH/USDT&timeframes=5m,1h&start=2024-01-01&end=2024-06-30
+func (h *Handler) HandleGetStrategyCoverage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID from path like /api/v1/strategies/:id/coverage
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	idStr := strings.TrimSuffix(path, "/coverage")
+
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+
+	q := r.URL.Query()
+	target := domain.CoverageTarget{
+		Pairs:          splitCSV(q.Get("pairs")),
+		Timeframes:     splitCSV(q.Get("timeframes")),
+		TimerangeStart: q.Get("start"),
+		TimerangeEnd:   q.Get("end"),
+	}
+	if err := target.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid coverage target")
+		return
+	}
+
+	// Ensure the strategy exists
+	if _, err := h.repos.Strategy.GetByID(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
+	entries, err := h.repos.BacktestJob.GetCoverageEntries(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get coverage entries", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get coverage")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.BuildStrategyCoverage(id, entries, target))
+}
+
+// ArchiveStrategyRequest represents the request body for archiving a strategy.
+type ArchiveStrategyRequest struct {
+	Reason string `json:"reason"`
+}
+
+// HandleArchiveStrategy archives a strategy or restores an archived one.
+// Archived strategies are hidden from search results unless include_archived=true.
+// POST   /api/v1/strategies/:id/archive
+// DELETE /api/v1/strategies/:id/archive
+func (h *Handler) HandleArchiveStrategy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID from path like /api/v1/strategies/:id/archive
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	idStr := strings.TrimSuffix(path, "/archive")
+
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := h.repos.Strategy.Unarchive(r.Context(), id); err != nil {
+			h.logger.Error("Failed to unarchive strategy", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to unarchive strategy")
+			return
+		}
+		strategy.ArchivedAt = nil
+		strategy.ArchiveReason = nil
+		writeJSON(w, http.StatusOK, GetStrategyResponse{Strategy: strategy})
+		return
+	}
+
+	var req ArchiveStrategyRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = "archived by " + requestOwner(r)
+	}
+
+	if err := h.repos.Strategy.Archive(r.Context(), id, reason); err != nil {
+		h.logger.Error("Failed to archive strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to archive strategy")
+		return
+	}
+
+	if !strategy.IsArchived() && h.eventPublisher != nil {
+		event := &events.StrategyArchivedEvent{
+			BaseEvent:    events.NewBaseEvent(events.EventTypeStrategyArchived),
+			StrategyID:   strategy.ID,
+			StrategyName: strategy.Name,
+			Reason:       reason,
+		}
+		if err := h.eventPublisher.Publish(r.Context(), events.RoutingKeyStrategyArchived, event); err != nil {
+			h.logger.Error("Failed to publish strategy archived event", zap.Error(err))
+			// Don't fail the request, just log the error
+		}
+	}
+
+	// Re-read to return the stored archive timestamp and reason
+	strategy, err = h.repos.Strategy.GetByID(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get strategy after archive", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, GetStrategyResponse{Strategy: strategy})
+}
+
+// splitCSV splits a comma-separated query value, dropping empty items.
+func splitCSV(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // ========================================
 // Backtest Handlers
 // ========================================
@@ -442,14 +845,46 @@ type SubmitBacktestRequest struct {
 	Config            domain.BacktestConfig `json:"config"`
 	Priority          int                   `json:"priority"`
 	OptimizationRunID *string               `json:"optimization_run_id,omitempty"`
+	ExternalRef       *string               `json:"external_ref,omitempty"`
+	CampaignID        *string               `json:"campaign_id,omitempty"`
+
+	// Hints are placement hints the scheduler honors best-effort.
+	Hints *domain.JobHints `json:"hints,omitempty"`
+
+	// SkipValidationCheck submits even if the strategy failed validation.
+	SkipValidationCheck bool `json:"skip_validation_check,omitempty"`
+
+	// AllowDuplicate submits even if an identical job is pending or running.
+	AllowDuplicate bool `json:"allow_duplicate,omitempty"`
+
+	// DryRun runs all the checks of the submission and previews it without
+	// creating the job.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // SubmitBacktestResponse represents the response for submitting a backtest.
 type SubmitBacktestResponse struct {
-	Job *domain.BacktestJob `json:"job"`
+	Job      *domain.BacktestJob `json:"job"`
+	Warnings []string            `json:"warnings,omitempty"`
+
+	// Set for dry runs, whose job is not persisted.
+	DryRun  bool                      `json:"dry_run,omitempty"`
+	Preview *domain.SubmissionPreview `json:"preview,omitempty"`
+}
+
+// DuplicateJobResponse is the error response for a submission identical to a
+// pending or running job.
+type DuplicateJobResponse struct {
+	Error         string `json:"error"`
+	Message       string `json:"message,omitempty"`
+	ExistingJobID string `json:"existing_job_id"`
 }
 
-// HandleSubmitBacktest submits a backtest job.
+// HandleSubmitBacktest submits a backtest job. With dry_run set, it responds
+// with what the submission would do instead of creating the job. A job
+// identical to one pending or running, with the same strategy and config, is
+// rejected with 409 and the existing job's ID unless allow_duplicate is set.
+// POST /api/v1/backtests
 func (h *Handler) HandleSubmitBacktest(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
@@ -478,15 +913,247 @@ func (h *Handler) HandleSubmitBacktest(w http.ResponseWriter, r *http.Request) {
 		optRunID = &id
 	}
 
+	var campaignID *uuid.UUID
+	if req.CampaignID != nil && *req.CampaignID != "" {
+		id, err := parseUUID(*req.CampaignID)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid campaign_id")
+			return
+		}
+		if _, err := h.repos.Campaign.GetByID(r.Context(), id); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				writeError(w, http.StatusNotFound, err, "campaign not found")
+				return
+			}
+			h.logger.Error("Failed to get campaign", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to get campaign")
+			return
+		}
+		campaignID = &id
+	}
+
+	// Save a container from crashing on a strategy that is known not to load
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), strategyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+	var warnings []string
+	warning, err := strategy.CheckSubmittable(req.SkipValidationCheck)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err, "strategy failed validation; set skip_validation_check to submit anyway")
+		return
+	}
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	// Jobs of an optimization run are pinned to the run's data snapshot
+	if optRunID != nil {
+		optRun, err := h.repos.Optimization.GetByID(r.Context(), *optRunID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				writeError(w, http.StatusNotFound, err, "optimization run not found")
+				return
+			}
+			h.logger.Error("Failed to get optimization run", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to get optimization run")
+			return
+		}
+		warnings = append(warnings, optRun.PinBacktestConfig(&req.Config)...)
+	}
+
 	job := domain.NewBacktestJob(strategyID, req.Config, req.Priority, optRunID)
+	job.CampaignID = campaignID
+
+	if req.ExternalRef != nil {
+		if err := domain.ValidateExternalRef(*req.ExternalRef); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid external_ref")
+			return
+		}
+		job.SetExternalRef(requestOwner(r), *req.ExternalRef)
+	}
+
+	if !req.Hints.IsZero() {
+		if err := req.Hints.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid hints")
+			return
+		}
+		job.Hints = req.Hints
+		if optRunID == nil && slices.Contains(req.Hints.AntiAffinity, domain.AntiAffinityOptimization) {
+			warnings = append(warnings, "the optimization anti-affinity hint has no effect on a job outside an optimization run")
+		}
+	}
+
+	if !req.AllowDuplicate {
+		duplicates, err := h.repos.BacktestJob.GetActiveDuplicates(r.Context(), []*domain.BacktestJob{job})
+		if err != nil {
+			h.logger.Error("Failed to check for duplicate jobs", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to check for duplicate jobs")
+			return
+		}
+		if duplicates[0] != nil {
+			writeJSON(w, http.StatusConflict, DuplicateJobResponse{
+				Error:         domain.DuplicateJobError{ExistingJobID: *duplicates[0]}.Error(),
+				Message:       "an identical job is already pending or running; set allow_duplicate to submit anyway",
+				ExistingJobID: duplicates[0].String(),
+			})
+			return
+		}
+	}
+
+	if req.DryRun {
+		h.previewSubmission(w, r, job, warnings)
+		return
+	}
+
+	if err := h.repos.BacktestJob.Create(r.Context(), job); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			writeError(w, http.StatusConflict, err, "job with same external_ref already exists")
+			return
+		}
+		h.logger.Error("Failed to create backtest job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to create job")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, SubmitBacktestResponse{Job: job, Warnings: warnings})
+}
+
+// previewSubmission responds to a dry-run submission of a checked job.
+func (h *Handler) previewSubmission(w http.ResponseWriter, r *http.Request, job *domain.BacktestJob, warnings []string) {
+	if job.ExternalRef != nil {
+		_, err := h.repos.BacktestJob.GetByExternalRef(r.Context(), *job.ExternalRefOwner, *job.ExternalRef)
+		if err == nil {
+			writeError(w, http.StatusConflict, domain.ErrDuplicate, "job with same external_ref already exists")
+			return
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			h.logger.Error("Failed to get backtest job by external ref", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to check external_ref")
+			return
+		}
+	}
+
+	workers, agingPerHour := 0, 0.0
+	if h.scheduler != nil {
+		workers = h.scheduler.WorkerCount()
+		agingPerHour = h.scheduler.PriorityAgingPerHour()
+	}
+	preview, dataWarnings, err := scheduler.PreviewSubmission(r.Context(), h.repos, workers, agingPerHour, job, time.Now())
+	if err != nil {
+		h.logger.Error("Failed to preview backtest submission", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to preview submission")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SubmitBacktestResponse{
+		Job:      job,
+		Warnings: append(warnings, dataWarnings...),
+		DryRun:   true,
+		Preview:  preview,
+	})
+}
+
+// ResubmitBacktestRequest represents the request body for resubmitting a backtest.
+type ResubmitBacktestRequest struct {
+	domain.ResubmitOverrides
+	ExternalRef *string `json:"external_ref,omitempty"`
+
+	// SkipValidationCheck submits even if the strategy failed validation.
+	SkipValidationCheck bool `json:"skip_validation_check,omitempty"`
+}
+
+// HandleResubmitBacktest repeats a finished backtest job as a new job with
+// the same config, optionally overriding its timerange, pairs and priority.
+// The new job records the original in resubmitted_from.
+// POST /api/v1/backtests/:id/resubmit
+func (h *Handler) HandleResubmitBacktest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	idStr := extractID(r.URL.Path, "/api/v1/backtests/")
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid job id")
+		return
+	}
+
+	var req ResubmitBacktestRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+	}
+
+	original, err := h.repos.BacktestJob.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "job not found")
+			return
+		}
+		h.logger.Error("Failed to get backtest job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job")
+		return
+	}
+	if !original.Status.IsTerminal() {
+		writeError(w, http.StatusConflict, errors.New("job has not finished"), "only completed, failed or cancelled jobs can be resubmitted")
+		return
+	}
+
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), original.StrategyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+	var warnings []string
+	warning, err := strategy.CheckSubmittable(req.SkipValidationCheck)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err, "strategy failed validation; set skip_validation_check to submit anyway")
+		return
+	}
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	job := original.Resubmit(req.ResubmitOverrides)
+	if req.ExternalRef != nil {
+		if err := domain.ValidateExternalRef(*req.ExternalRef); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid external_ref")
+			return
+		}
+		job.SetExternalRef(requestOwner(r), *req.ExternalRef)
+	}
 
 	if err := h.repos.BacktestJob.Create(r.Context(), job); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			writeError(w, http.StatusConflict, err, "job with same external_ref already exists")
+			return
+		}
 		h.logger.Error("Failed to create backtest job", zap.Error(err))
 		writeError(w, http.StatusInternalServerError, err, "failed to create job")
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, SubmitBacktestResponse{Job: job})
+	h.logger.Info("Resubmitted backtest job",
+		zap.String("job_id", job.ID.String()),
+		zap.String("resubmitted_from", original.ID.String()),
+	)
+
+	writeJSON(w, http.StatusCreated, SubmitBacktestResponse{Job: job, Warnings: warnings})
 }
 
 // GetBacktestJobResponse represents the response for getting a backtest job.
@@ -495,7 +1162,9 @@ type GetBacktestJobResponse struct {
 	Result *domain.BacktestResult `json:"result,omitempty"`
 }
 
-// HandleGetBacktestJob retrieves a backtest job by ID.
+// HandleGetBacktestJob retrieves a backtest job by ID. With percentiles=true
+// the result includes its percentile ranks within its cohort.
+// GET /api/v1/backtests/:id?percentiles=true
 func (h *Handler) HandleGetBacktestJob(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
@@ -509,6 +1178,12 @@ func (h *Handler) HandleGetBacktestJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	percentiles, err := requestPercentiles(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid percentiles")
+		return
+	}
+
 	job, err := h.repos.BacktestJob.GetByID(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
@@ -529,6 +1204,114 @@ func (h *Handler) HandleGetBacktestJob(w http.ResponseWriter, r *http.Request) {
 			h.logger.Warn("Failed to get backtest result for completed job", zap.Error(err), zap.String("job_id", id.String()))
 		}
 		if result != nil {
+			if percentiles {
+				h.rankResult(r.Context(), result, job)
+			}
+			response.Result = result
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// requestPercentiles reports whether a request asks for the percentile ranks
+// of a backtest result with the percentiles query parameter.
+func requestPercentiles(r *http.Request) (bool, error) {
+	if v := r.URL.Query().Get("percentiles"); v != "" {
+		return strconv.ParseBool(v)
+	}
+	return false, nil
+}
+
+// rankResult sets the percentile ranks of a job's result within its cohort.
+// The result is returned without them if they cannot be computed.
+func (h *Handler) rankResult(ctx context.Context, result *domain.BacktestResult, job *domain.BacktestJob) {
+	if h.ranker == nil {
+		return
+	}
+	if err := h.ranker.Rank(ctx, result, &job.Config); err != nil {
+		h.logger.Warn("Failed to rank backtest result", zap.Error(err), zap.String("job_id", job.ID.String()))
+	}
+}
+
+// HandleGetBacktestJobEvents returns a job's state transition timeline along
+// with the time spent queued, starting the container, and running.
+// GET /api/v1/backtests/:id/events
+func (h *Handler) HandleGetBacktestJobEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	idStr := extractID(r.URL.Path, "/api/v1/backtests/")
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid job id")
+		return
+	}
+
+	if _, err := h.repos.BacktestJob.GetByID(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "job not found")
+			return
+		}
+		h.logger.Error("Failed to get backtest job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job")
+		return
+	}
+
+	events, err := h.repos.BacktestJob.GetEvents(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get backtest job events", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job events")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.NewJobTimeline(id, events))
+}
+
+// HandleGetBacktestJobByRef retrieves a backtest job by the requester's external reference.
+// GET /api/v1/backtests/by-ref/:ref?percentiles=true
+func (h *Handler) HandleGetBacktestJobByRef(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	ref := extractID(r.URL.Path, "/api/v1/backtests/by-ref/")
+	if err := domain.ValidateExternalRef(ref); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid external_ref")
+		return
+	}
+
+	percentiles, err := requestPercentiles(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid percentiles")
+		return
+	}
+
+	job, err := h.repos.BacktestJob.GetByExternalRef(r.Context(), requestOwner(r), ref)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "job not found")
+			return
+		}
+		h.logger.Error("Failed to get backtest job by external ref", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job")
+		return
+	}
+
+	response := GetBacktestJobResponse{Job: job}
+
+	if job.Status == domain.JobStatusCompleted {
+		result, err := h.repos.Result.GetByJobID(r.Context(), job.ID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			h.logger.Warn("Failed to get backtest result for completed job", zap.Error(err), zap.String("job_id", job.ID.String()))
+		}
+		if result != nil {
+			if percentiles {
+				h.rankResult(r.Context(), result, job)
+			}
 			response.Result = result
 		}
 	}
@@ -536,7 +1319,27 @@ func (h *Handler) HandleGetBacktestJob(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, response)
 }
 
-// HandleCancelBacktest cancels a backtest job.
+// CancelRequest represents the optional request body for cancelling a job or run.
+type CancelRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// requestCancellation reads the cancellation reason of a request, given as
+// the reason query parameter or in an optional CancelRequest body. The caller
+// is recorded as the canceller.
+func requestCancellation(r *http.Request) (domain.Cancellation, error) {
+	req := CancelRequest{Reason: r.URL.Query().Get("reason")}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return domain.Cancellation{}, err
+		}
+	}
+	c := domain.NewCancellation(req.Reason, requestOwner(r))
+	return c, c.Validate()
+}
+
+// HandleCancelBacktest cancels a backtest job, with an optional reason.
+// DELETE /api/v1/backtests/:id?reason=...
 func (h *Handler) HandleCancelBacktest(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
@@ -550,7 +1353,13 @@ func (h *Handler) HandleCancelBacktest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.repos.BacktestJob.Cancel(r.Context(), id); err != nil {
+	cancellation, err := requestCancellation(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid cancellation")
+		return
+	}
+
+	if err := h.repos.BacktestJob.Cancel(r.Context(), id, cancellation); err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			writeError(w, http.StatusNotFound, err, "job not found")
 			return
@@ -559,12 +1368,178 @@ func (h *Handler) HandleCancelBacktest(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusConflict, err, "job cannot be cancelled")
 			return
 		}
-		h.logger.Error("Failed to cancel backtest job", zap.Error(err))
-		writeError(w, http.StatusInternalServerError, err, "failed to cancel job")
-		return
+		h.logger.Error("Failed to cancel backtest job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to cancel job")
+		return
+	}
+
+	h.logger.Info("Backtest job cancelled",
+		zap.String("job_id", id.String()),
+		zap.String("reason", cancellation.Reason),
+		zap.String("cancelled_by", cancellation.CancelledBy),
+	)
+
+	if h.eventPublisher != nil {
+		job, err := h.repos.BacktestJob.GetByID(r.Context(), id)
+		if err == nil {
+			err = h.eventPublisher.PublishTaskCancelled(job)
+		}
+		if err != nil {
+			h.logger.Error("Failed to publish task cancelled event", zap.Error(err))
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BulkCancelRequest represents the request body for cancelling backtest jobs
+// by filter. Status defaults to pending.
+type BulkCancelRequest struct {
+	domain.JobCancelFilter
+	Reason string `json:"reason,omitempty"`
+}
+
+// BulkCancelResponse represents the response for cancelling backtest jobs by filter.
+type BulkCancelResponse struct {
+	Cancelled []uuid.UUID `json:"cancelled"`
+	Count     int         `json:"count"`
+}
+
+// HandleBulkCancelBacktests cancels all backtest jobs matching the filters in
+// one go, e.g. the queued jobs of an optimization run gone off the rails.
+// Running jobs cancelled this way have their containers stopped by the
+// scheduler, as with a single cancellation.
+// POST /api/v1/backtests/cancel
+func (h *Handler) HandleBulkCancelBacktests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var req BulkCancelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+	if req.Status == "" {
+		req.Status = domain.JobStatusPending
+	}
+	if err := req.JobCancelFilter.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "")
+		return
+	}
+	cancellation := domain.NewCancellation(req.Reason, requestOwner(r))
+	if err := cancellation.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid cancellation")
+		return
+	}
+	if !h.checkSelectedRun(w, r, req.OptimizationRunID) {
+		return
+	}
+
+	jobs, err := h.repos.BacktestJob.CancelMatching(r.Context(), req.JobCancelFilter, cancellation)
+	if err != nil {
+		h.logger.Error("Failed to cancel backtest jobs", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to cancel jobs")
+		return
+	}
+
+	resp := BulkCancelResponse{Cancelled: make([]uuid.UUID, 0, len(jobs)), Count: len(jobs)}
+	for _, job := range jobs {
+		resp.Cancelled = append(resp.Cancelled, job.ID)
+	}
+
+	h.logger.Info("Backtest jobs cancelled",
+		zap.Int("count", len(jobs)),
+		zap.String("status", string(req.Status)),
+		zap.String("reason", cancellation.Reason),
+		zap.String("cancelled_by", cancellation.CancelledBy),
+	)
+
+	if h.eventPublisher != nil {
+		failed := 0
+		for _, job := range jobs {
+			if err := h.eventPublisher.PublishTaskCancelled(job); err != nil {
+				failed++
+			}
+		}
+		if failed > 0 {
+			h.logger.Error("Failed to publish task cancelled events", zap.Int("failed", failed))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// SetPriorityResponse represents the response for re-prioritizing backtest jobs.
+type SetPriorityResponse struct {
+	Priority int         `json:"priority"`
+	Updated  []uuid.UUID `json:"updated"`           // Pending jobs whose priority was set
+	Skipped  []uuid.UUID `json:"skipped,omitempty"` // Listed jobs that are not pending or do not exist
+}
+
+// HandleSetPriority sets the priority of pending backtest jobs in bulk: the
+// listed jobs and all pending jobs of an optimization run. Jobs that already
+// left the queue are skipped.
+// PATCH /api/v1/backtests/priority
+func (h *Handler) HandleSetPriority(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var change domain.PriorityChange
+	if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+	if err := change.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "")
+		return
+	}
+	if !h.checkSelectedRun(w, r, change.OptimizationRunID) {
+		return
+	}
+
+	updated, err := h.repos.BacktestJob.SetPriority(r.Context(), change)
+	if err != nil {
+		h.logger.Error("Failed to set backtest job priority", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to set priority")
+		return
+	}
+
+	resp := SetPriorityResponse{Priority: change.Priority, Updated: updated}
+	for _, id := range change.JobIDs {
+		if !slices.Contains(updated, id) {
+			resp.Skipped = append(resp.Skipped, id)
+		}
+	}
+
+	h.logger.Info("Re-prioritized backtest jobs",
+		zap.Int("priority", change.Priority),
+		zap.Int("updated", len(updated)),
+		zap.Int("skipped", len(resp.Skipped)),
+	)
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// checkSelectedRun checks that the optimization run a bulk change selects
+// exists, writing an error response if not. A nil run selects none.
+func (h *Handler) checkSelectedRun(w http.ResponseWriter, r *http.Request, runID *uuid.UUID) bool {
+	if runID == nil {
+		return true
+	}
+	if _, err := h.repos.Optimization.GetByID(r.Context(), *runID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "optimization run not found")
+			return false
+		}
+		h.logger.Error("Failed to get optimization run", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get optimization run")
+		return false
 	}
-
-	w.WriteHeader(http.StatusNoContent)
+	return true
 }
 
 // QueryBacktestResultsResponse represents the response for querying backtest results.
@@ -580,13 +1555,51 @@ func (h *Handler) HandleQueryBacktestResults(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	queryParams := r.URL.Query()
+	query, err := parseBacktestResultFilters(queryParams)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid time range")
+		return
+	}
+	if page := queryParams.Get("page"); page != "" {
+		if val, err := strconv.Atoi(page); err == nil {
+			query.Page = val
+		}
+	}
+	if pageSize := queryParams.Get("page_size"); pageSize != "" {
+		if val, err := strconv.Atoi(pageSize); err == nil {
+			query.PageSize = val
+		}
+	}
+
+	requested := query.PageSize
+	if query.SetDefaults() {
+		warnPageSizeClamped(w, requested, query.PageSize)
+	}
+
+	results, totalCount, err := h.repos.Result.Query(r.Context(), query)
+	if err != nil {
+		h.logger.Error("Failed to query backtest results", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to query results")
+		return
+	}
+
+	pagination := domain.NewPaginationResponse(totalCount, query.Page, query.PageSize)
+
+	writeJSON(w, http.StatusOK, QueryBacktestResultsResponse{
+		Results:    results,
+		Pagination: pagination,
+	})
+}
+
+// parseBacktestResultFilters parses the filters and order of a backtest
+// result query from query parameters, skipping malformed values. It fails
+// only on an invalid time range.
+func parseBacktestResultFilters(queryParams url.Values) (domain.BacktestResultQuery, error) {
 	query := domain.BacktestResultQuery{
-		Page:     1,
-		PageSize: 20,
+		Page: 1,
 	}
 
-	// Parse query parameters
-	queryParams := r.URL.Query()
 	if strategyID := queryParams.Get("strategy_id"); strategyID != "" {
 		if id, err := parseUUID(strategyID); err == nil {
 			query.StrategyID = &id
@@ -612,6 +1625,16 @@ func (h *Handler) HandleQueryBacktestResults(w http.ResponseWriter, r *http.Requ
 			query.MaxDrawdownPct = &val
 		}
 	}
+	if maxStoploss := queryParams.Get("max_stoploss_exit_pct"); maxStoploss != "" {
+		if val, err := strconv.ParseFloat(maxStoploss, 64); err == nil {
+			query.MaxStoplossExitPct = &val
+		}
+	}
+	if maxTrailing := queryParams.Get("max_trailing_stop_exit_pct"); maxTrailing != "" {
+		if val, err := strconv.ParseFloat(maxTrailing, 64); err == nil {
+			query.MaxTrailingStopExitPct = &val
+		}
+	}
 	if minTrades := queryParams.Get("min_trades"); minTrades != "" {
 		if val, err := strconv.Atoi(minTrades); err == nil {
 			query.MinTrades = &val
@@ -623,50 +1646,26 @@ func (h *Handler) HandleQueryBacktestResults(w http.ResponseWriter, r *http.Requ
 	if ascending := queryParams.Get("ascending"); ascending == "true" {
 		query.Ascending = true
 	}
-	if page := queryParams.Get("page"); page != "" {
-		if val, err := strconv.Atoi(page); err == nil {
-			query.Page = val
-		}
+	if freqtradeVersion := queryParams.Get("freqtrade_version"); freqtradeVersion != "" {
+		query.FreqtradeVersion = &freqtradeVersion
 	}
-	if pageSize := queryParams.Get("page_size"); pageSize != "" {
-		if val, err := strconv.Atoi(pageSize); err == nil {
-			query.PageSize = val
-		}
+	if imageDigest := queryParams.Get("image_digest"); imageDigest != "" {
+		query.ImageDigest = &imageDigest
 	}
-
-	// Parse time range if provided
-	if startStr := queryParams.Get("start_time"); startStr != "" {
-		if start, err := time.Parse(time.RFC3339, startStr); err == nil {
-			if query.TimeRange == nil {
-				query.TimeRange = &domain.TimeRange{}
-			}
-			query.TimeRange.Start = start
-		}
+	if host := queryParams.Get("host"); host != "" {
+		query.Host = &host
 	}
-	if endStr := queryParams.Get("end_time"); endStr != "" {
-		if end, err := time.Parse(time.RFC3339, endStr); err == nil {
-			if query.TimeRange == nil {
-				query.TimeRange = &domain.TimeRange{}
-			}
-			query.TimeRange.End = end
-		}
+	if includeSuperseded := queryParams.Get("include_superseded"); includeSuperseded == "true" {
+		query.IncludeSuperseded = true
 	}
 
-	query.SetDefaults()
-
-	results, totalCount, err := h.repos.Result.Query(r.Context(), query)
+	timeRange, err := parseTimeRangeParams(queryParams)
 	if err != nil {
-		h.logger.Error("Failed to query backtest results", zap.Error(err))
-		writeError(w, http.StatusInternalServerError, err, "failed to query results")
-		return
+		return query, err
 	}
+	query.TimeRange = timeRange
 
-	pagination := domain.NewPaginationResponse(totalCount, query.Page, query.PageSize)
-
-	writeJSON(w, http.StatusOK, QueryBacktestResultsResponse{
-		Results:    results,
-		Pagination: pagination,
-	})
+	return query, nil
 }
 
 // ListBacktestJobsResponse represents the response for listing backtest jobs.
@@ -683,8 +1682,7 @@ func (h *Handler) HandleListBacktestJobs(w http.ResponseWriter, r *http.Request)
 	}
 
 	query := &domain.BacktestJobQuery{
-		Page:     1,
-		PageSize: 20,
+		Page: 1,
 	}
 
 	// Parse query parameters
@@ -699,6 +1697,11 @@ func (h *Handler) HandleListBacktestJobs(w http.ResponseWriter, r *http.Request)
 			query.OptimizationRunID = &id
 		}
 	}
+	if campaignID := queryParams.Get("campaign_id"); campaignID != "" {
+		if id, err := parseUUID(campaignID); err == nil {
+			query.CampaignID = &id
+		}
+	}
 	if status := queryParams.Get("status"); status != "" {
 		jobStatus := domain.JobStatusFromString(status)
 		query.Status = &jobStatus
@@ -720,7 +1723,10 @@ func (h *Handler) HandleListBacktestJobs(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	query.SetDefaults()
+	requested := query.PageSize
+	if query.SetDefaults() {
+		warnPageSizeClamped(w, requested, query.PageSize)
+	}
 
 	jobs, pagination, err := h.repos.BacktestJob.Query(r.Context(), query)
 	if err != nil {
@@ -762,10 +1768,14 @@ func (h *Handler) HandleGetQueueStats(w http.ResponseWriter, r *http.Request) {
 // ========================================
 
 // StartOptimizationRequest represents the request body for starting an optimization.
+// BaseStrategyIDs seeds the run with several strategies; base_strategy_id,
+// if also given, is the first seed.
 type StartOptimizationRequest struct {
-	Name           string                    `json:"name"`
-	BaseStrategyID string                    `json:"base_strategy_id"`
-	Config         domain.OptimizationConfig `json:"config"`
+	Name            string                    `json:"name"`
+	BaseStrategyID  string                    `json:"base_strategy_id"`
+	BaseStrategyIDs []string                  `json:"base_strategy_ids,omitempty"`
+	Config          domain.OptimizationConfig `json:"config"`
+	ExternalRef     *string                   `json:"external_ref,omitempty"`
 }
 
 // StartOptimizationResponse represents the response for starting an optimization.
@@ -786,7 +1796,7 @@ func (h *Handler) HandleStartOptimization(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	baseStrategyID, err := parseUUID(req.BaseStrategyID)
+	seeds, err := domain.ParseSeedStrategyIDs(req.BaseStrategyID, req.BaseStrategyIDs)
 	if err != nil {
 		writeError(w, http.StatusBadRequest, err, "invalid base_strategy_id")
 		return
@@ -800,10 +1810,54 @@ func (h *Handler) HandleStartOptimization(w http.ResponseWriter, r *http.Request
 	if req.Config.MaxIterations == 0 {
 		req.Config.MaxIterations = 10
 	}
+	if req.Config.Quota != nil {
+		if err := req.Config.Quota.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid quota")
+			return
+		}
+	}
+
+	run := domain.NewOptimizationRun(req.Name, uuid.Nil, req.Config)
+	if err := run.SetSeeds(seeds); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid base_strategy_ids")
+		return
+	}
+
+	found, err := h.repos.Strategy.GetByIDs(r.Context(), run.SeedStrategyIDs)
+	if err != nil {
+		h.logger.Error("Failed to get seed strategies", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get seed strategies")
+		return
+	}
+	if len(found) != len(run.SeedStrategyIDs) {
+		writeError(w, http.StatusBadRequest, errors.New("seed strategy not found"), "invalid base_strategy_ids")
+		return
+	}
+
+	if req.ExternalRef != nil {
+		if err := domain.ValidateExternalRef(*req.ExternalRef); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid external_ref")
+			return
+		}
+		run.SetExternalRef(requestOwner(r), *req.ExternalRef)
+	}
 
-	run := domain.NewOptimizationRun(req.Name, baseStrategyID, req.Config)
+	unmet, err := h.checkRunAgents(r.Context(), run)
+	if err != nil {
+		h.logger.Error("Failed to list agents", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to check registered agents")
+		return
+	}
+	if len(unmet) > 0 {
+		writeError(w, http.StatusConflict, errors.New("no registered agent can serve this run"), "requires a live "+describeAgentRequirements(unmet))
+		return
+	}
 
 	if err := h.repos.Optimization.Create(r.Context(), run); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			writeError(w, http.StatusConflict, err, "optimization run with same external_ref already exists")
+			return
+		}
 		h.logger.Error("Failed to create optimization run", zap.Error(err))
 		writeError(w, http.StatusInternalServerError, err, "failed to create optimization run")
 		return
@@ -822,9 +1876,11 @@ func (h *Handler) HandleStartOptimization(w http.ResponseWriter, r *http.Request
 type GetOptimizationRunResponse struct {
 	Run        *domain.OptimizationRun        `json:"run"`
 	Iterations []*domain.OptimizationIteration `json:"iterations"`
+	Progress   *domain.OptimizationProgress   `json:"progress"`
 }
 
-// HandleGetOptimizationRun retrieves an optimization run with its iterations.
+// HandleGetOptimizationRun retrieves an optimization run with its iterations
+// and their artifacts.
 func (h *Handler) HandleGetOptimizationRun(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
@@ -855,10 +1911,60 @@ func (h *Handler) HandleGetOptimizationRun(w http.ResponseWriter, r *http.Reques
 		writeError(w, http.StatusInternalServerError, err, "failed to get iterations")
 		return
 	}
+	if err := h.attachIterationArtifacts(r.Context(), iterations); err != nil {
+		h.logger.Error("Failed to get iteration artifacts", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get iteration artifacts")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, GetOptimizationRunResponse{
+		Run:        run,
+		Iterations: iterations,
+		Progress:   domain.NewOptimizationProgress(run, time.Now()),
+	})
+}
+
+// HandleGetOptimizationRunByRef retrieves an optimization run by the requester's external reference.
+// GET /api/v1/optimizations/by-ref/:ref
+func (h *Handler) HandleGetOptimizationRunByRef(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	ref := extractID(r.URL.Path, "/api/v1/optimizations/by-ref/")
+	if err := domain.ValidateExternalRef(ref); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid external_ref")
+		return
+	}
+
+	run, err := h.repos.Optimization.GetByExternalRef(r.Context(), requestOwner(r), ref)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "optimization run not found")
+			return
+		}
+		h.logger.Error("Failed to get optimization run by external ref", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get optimization run")
+		return
+	}
+
+	iterations, err := h.repos.Optimization.GetIterations(r.Context(), run.ID)
+	if err != nil {
+		h.logger.Error("Failed to get optimization iterations", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get iterations")
+		return
+	}
+	if err := h.attachIterationArtifacts(r.Context(), iterations); err != nil {
+		h.logger.Error("Failed to get iteration artifacts", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get iteration artifacts")
+		return
+	}
 
 	writeJSON(w, http.StatusOK, GetOptimizationRunResponse{
 		Run:        run,
 		Iterations: iterations,
+		Progress:   domain.NewOptimizationProgress(run, time.Now()),
 	})
 }
 
@@ -876,8 +1982,7 @@ func (h *Handler) HandleListOptimizationRuns(w http.ResponseWriter, r *http.Requ
 	}
 
 	query := domain.OptimizationListQuery{
-		Page:     1,
-		PageSize: 20,
+		Page: 1,
 	}
 
 	// Parse query parameters
@@ -886,6 +1991,10 @@ func (h *Handler) HandleListOptimizationRuns(w http.ResponseWriter, r *http.Requ
 		optStatus := domain.OptimizationStatusFromString(status)
 		query.Status = &optStatus
 	}
+	if starred := queryParams.Get("starred"); starred == "true" {
+		owner := requestOwner(r)
+		query.StarredBy = &owner
+	}
 	if orderBy := queryParams.Get("order_by"); orderBy != "" {
 		query.OrderBy = orderBy
 	}
@@ -904,24 +2013,17 @@ func (h *Handler) HandleListOptimizationRuns(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Parse time range if provided
-	if startStr := queryParams.Get("start_time"); startStr != "" {
-		if start, err := time.Parse(time.RFC3339, startStr); err == nil {
-			if query.TimeRange == nil {
-				query.TimeRange = &domain.TimeRange{}
-			}
-			query.TimeRange.Start = start
-		}
-	}
-	if endStr := queryParams.Get("end_time"); endStr != "" {
-		if end, err := time.Parse(time.RFC3339, endStr); err == nil {
-			if query.TimeRange == nil {
-				query.TimeRange = &domain.TimeRange{}
-			}
-			query.TimeRange.End = end
-		}
+	timeRange, err := parseTimeRangeParams(queryParams)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid time range")
+		return
 	}
+	query.TimeRange = timeRange
 
-	query.SetDefaults()
+	requested := query.PageSize
+	if query.SetDefaults() {
+		warnPageSizeClamped(w, requested, query.PageSize)
+	}
 
 	runs, totalCount, err := h.repos.Optimization.List(r.Context(), query)
 	if err != nil {
@@ -940,7 +2042,8 @@ func (h *Handler) HandleListOptimizationRuns(w http.ResponseWriter, r *http.Requ
 
 // ControlOptimizationRequest represents the request body for controlling an optimization.
 type ControlOptimizationRequest struct {
-	Action string `json:"action"` // "pause", "resume", "cancel"
+	Action string `json:"action"`           // "pause", "resume", "cancel"
+	Reason string `json:"reason,omitempty"` // Why the run is cancelled
 }
 
 // ControlOptimizationResponse represents the response for controlling an optimization.
@@ -994,6 +2097,11 @@ func (h *Handler) HandleControlOptimization(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if newStatus == domain.OptimizationStatusCancelled {
+		h.cancelOptimization(w, r, id, domain.NewCancellation(req.Reason, requestOwner(r)))
+		return
+	}
+
 	if err := h.repos.Optimization.UpdateStatus(r.Context(), id, newStatus); err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			writeError(w, http.StatusNotFound, err, "optimization run not found")
@@ -1017,6 +2125,176 @@ func (h *Handler) HandleControlOptimization(w http.ResponseWriter, r *http.Reque
 	})
 }
 
+// cancelOptimization cancels an optimization run, recording the reason and
+// canceller, and publishes the status change.
+func (h *Handler) cancelOptimization(w http.ResponseWriter, r *http.Request, id uuid.UUID, cancellation domain.Cancellation) {
+	if err := cancellation.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid cancellation")
+		return
+	}
+
+	previous, err := h.repos.Optimization.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "optimization run not found")
+			return
+		}
+		h.logger.Error("Failed to get optimization run", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get optimization run")
+		return
+	}
+
+	run, err := h.repos.Optimization.Cancel(r.Context(), id, cancellation)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "optimization run not found")
+			return
+		}
+		h.logger.Error("Failed to cancel optimization run", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to control optimization")
+		return
+	}
+
+	h.logger.Info("Optimization run cancelled",
+		zap.String("run_id", id.String()),
+		zap.String("reason", cancellation.Reason),
+		zap.String("cancelled_by", cancellation.CancelledBy),
+	)
+
+	if h.eventPublisher != nil {
+		if err := h.eventPublisher.PublishOptimizationStatusChanged(run, previous.Status.String(), run.Status.String()); err != nil {
+			h.logger.Error("Failed to publish optimization status changed event", zap.Error(err))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, ControlOptimizationResponse{
+		Success: true,
+		Run:     run,
+	})
+}
+
+// RetryIterationRequest represents the request body for retrying an optimization iteration.
+type RetryIterationRequest struct {
+	Feedback string `json:"feedback"`
+}
+
+// RetryIterationResponse represents the response for retrying an optimization iteration.
+type RetryIterationResponse struct {
+	Iteration        *domain.OptimizationIteration `json:"iteration"`
+	SourceStrategyID uuid.UUID                     `json:"source_strategy_id"`
+}
+
+// HandleRetryOptimizationIteration re-opens an iteration with operator feedback appended
+// and asks the orchestrator to regenerate it, without restarting the whole run.
+// POST /api/v1/optimizations/:id/iterations/:n/retry
+func (h *Handler) HandleRetryOptimizationIteration(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID and iteration number from path like /api/v1/optimizations/:id/iterations/:n/retry
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/optimizations/"), "/"), "/")
+	if len(parts) != 4 || parts[1] != "iterations" || parts[3] != "retry" {
+		writeError(w, http.StatusNotFound, errors.New("not found"), "")
+		return
+	}
+
+	id, err := parseUUID(parts[0])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid optimization run id")
+		return
+	}
+	number, err := strconv.Atoi(parts[2])
+	if err != nil || number < 1 {
+		writeError(w, http.StatusBadRequest, errors.New("invalid iteration number"), "iteration number must be a positive integer")
+		return
+	}
+
+	var req RetryIterationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	if h.eventPublisher == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("event publisher not configured"), "cannot reach orchestrator")
+		return
+	}
+
+	run, err := h.repos.Optimization.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "optimization run not found")
+			return
+		}
+		h.logger.Error("Failed to get optimization run", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get optimization run")
+		return
+	}
+
+	if run.Status != domain.OptimizationStatusRunning && run.Status != domain.OptimizationStatusPaused &&
+		run.Status != domain.OptimizationStatusStalled {
+		writeError(w, http.StatusConflict, errors.New("optimization run is not active"),
+			"cannot retry iterations of a "+run.Status.String()+" run")
+		return
+	}
+
+	iterations, err := h.repos.Optimization.GetIterations(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get optimization iterations", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get iterations")
+		return
+	}
+
+	// The iteration is regenerated from the previous iteration's strategy,
+	// or from the base strategy when retrying the first one
+	var iteration *domain.OptimizationIteration
+	sourceStrategyID := run.BaseStrategyID
+	for _, iter := range iterations {
+		if iter.IterationNumber == number-1 {
+			sourceStrategyID = iter.StrategyID
+		}
+		if iter.IterationNumber == number {
+			iteration = iter
+		}
+	}
+	if iteration == nil {
+		writeError(w, http.StatusNotFound, domain.NewNotFoundError("optimization_iteration", strconv.Itoa(number)), "iteration not found")
+		return
+	}
+
+	if err := iteration.Reopen(req.Feedback); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid feedback")
+		return
+	}
+
+	if err := h.repos.Optimization.UpdateIterationFeedback(r.Context(), iteration.ID,
+		iteration.EngineerChanges, iteration.AnalystFeedback, iteration.Approval); err != nil {
+		h.logger.Error("Failed to update iteration feedback", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to update iteration")
+		return
+	}
+
+	event := events.NewOptimizationIterationRetryEvent(iteration, sourceStrategyID, strings.TrimSpace(req.Feedback), requestOwner(r))
+	if err := h.eventPublisher.PublishOptimizationIterationRetry(event); err != nil {
+		h.logger.Error("Failed to publish iteration retry event", zap.Error(err))
+		writeError(w, http.StatusBadGateway, err, "failed to notify orchestrator")
+		return
+	}
+
+	h.logger.Info("Optimization iteration retry requested",
+		zap.String("run_id", id.String()),
+		zap.Int("iteration", number),
+		zap.String("requested_by", event.RequestedBy),
+	)
+
+	writeJSON(w, http.StatusAccepted, RetryIterationResponse{
+		Iteration:        iteration,
+		SourceStrategyID: sourceStrategyID,
+	})
+}
+
 // ============================================================================
 // Agent Status Handlers
 // ============================================================================
@@ -1083,18 +2361,25 @@ func (h *Handler) HandleGetAgentStatus(w http.ResponseWriter, r *http.Request) {
 // Performance Data Handlers
 // ============================================================================
 
-// PerformanceDataPoint represents a single data point for performance charts.
-type PerformanceDataPoint struct {
-	Timestamp        time.Time `json:"timestamp"`
-	SharpeRatio      float64   `json:"sharpe_ratio"`
-	OptimizationID   string    `json:"optimization_id"`
-	OptimizationName string    `json:"optimization_name"`
+// OptimizationPerformanceResponse is the iteration metric series for the
+// performance chart.
+type OptimizationPerformanceResponse struct {
+	Metric domain.PerformanceMetric    `json:"metric"`
+	Bucket domain.PerformanceBucket    `json:"bucket"`
+	Start    time.Time                   `json:"start"`
+	End      time.Time                   `json:"end"`
+	Timezone string                      `json:"timezone"` // Zone the buckets are aligned to
+	Series   []*domain.PerformanceSeries `json:"series"`
 }
 
-// HandleGetOptimizationPerformance retrieves performance data for the chart.
-// GET /api/v1/optimizations/performance?period=24h
+// HandleGetOptimizationPerformance retrieves performance data for the chart:
+// the chosen result metric of optimization iterations, joined through their
+// backtest results and aggregated into hourly or daily buckets, per run or
+// across all runs.
+// GET /api/v1/optimizations/performance?period=24h&bucket=hourly&metric=sharpe&group_by=run
 func (h *Handler) HandleGetOptimizationPerformance(w http.ResponseWriter, r *http.Request) {
-	period := r.URL.Query().Get("period")
+	query := r.URL.Query()
+	period := query.Get("period")
 	if period == "" {
 		period = "24h"
 	}
@@ -1112,34 +2397,59 @@ func (h *Handler) HandleGetOptimizationPerformance(w http.ResponseWriter, r *htt
 		duration = 24 * time.Hour
 	case "7d":
 		duration = 7 * 24 * time.Hour
+	case "30d":
+		duration = 30 * 24 * time.Hour
 	default:
 		duration = 24 * time.Hour
 	}
 
+	metric, err := domain.ParsePerformanceMetric(query.Get("metric"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid metric")
+		return
+	}
+	bucket, err := domain.ParsePerformanceBucket(query.Get("bucket"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid bucket")
+		return
+	}
+	var perRun bool
+	switch query.Get("group_by") {
+	case "", "run":
+		perRun = true
+	case "all":
+	default:
+		writeError(w, http.StatusBadRequest, errors.New("group_by must be run or all"), "invalid group_by")
+		return
+	}
+
 	// Calculate time range
 	endTime := time.Now()
 	startTime := endTime.Add(-duration)
 
-	// Query optimization iterations within the time range
-	iterations, err := h.repos.Optimization.GetIterationsInTimeRange(r.Context(), startTime, endTime)
+	series, err := h.repos.Optimization.GetPerformanceSeries(r.Context(), domain.PerformanceQuery{
+		Start:  startTime,
+		End:    endTime,
+		Metric:   metric,
+		Bucket:   bucket,
+		PerRun:   perRun,
+		Location: h.timezone(),
+	})
 	if err != nil {
-		// If the method fails, return empty data
-		h.logger.Warn("Failed to get optimization iterations for performance", zap.Error(err))
-		writeJSON(w, http.StatusOK, []PerformanceDataPoint{})
+		h.logger.Error("Failed to get optimization performance", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get optimization performance")
 		return
 	}
-
-	// For now, return iterations as data points without detailed result info
-	// In a future enhancement, we would join with backtest_results to get sharpe ratios
-	dataPoints := make([]PerformanceDataPoint, 0, len(iterations))
-	for _, iter := range iterations {
-		dataPoints = append(dataPoints, PerformanceDataPoint{
-			Timestamp:        iter.CreatedAt,
-			SharpeRatio:      0, // Would need to query backtest_results by iter.ResultID
-			OptimizationID:   iter.OptimizationRunID.String(),
-			OptimizationName: iter.OptimizationRunID.String(),
-		})
+	if series == nil {
+		series = []*domain.PerformanceSeries{}
 	}
 
-	writeJSON(w, http.StatusOK, dataPoints)
+	writeJSON(w, http.StatusOK, OptimizationPerformanceResponse{
+		Metric:   metric,
+		Bucket:   bucket,
+		Start:    startTime,
+		End:      endTime,
+		Timezone: h.timezone().String(),
+		Series:   series,
+	})
 }
@@ -0,0 +1,446 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
+)
+
+// ============================================================================
+// Admin Handlers
+// ============================================================================
+
+const (
+	// capacityLookback is how far back to sample historical job runtimes.
+	capacityLookback = 30 * 24 * time.Hour
+	// capacityMaxSamples caps the number of historical runtimes sampled.
+	capacityMaxSamples = 1000
+	// capacityMaxWorkers caps hypothetical worker counts.
+	capacityMaxWorkers = 1024
+	// capacityMaxScenarios caps the number of worker counts simulated per request.
+	capacityMaxScenarios = 8
+	// capacityMaxQueue caps a hypothetical queue length.
+	capacityMaxQueue = 100000
+)
+
+// CapacityResponse represents the response for the capacity simulation.
+type CapacityResponse struct {
+	QueueLength    int                          `json:"queue_length"`
+	RunningJobs    int                          `json:"running_jobs"`
+	CurrentWorkers int                          `json:"current_workers"`
+	RuntimeSource  string                       `json:"runtime_source"` // "historical", "override"
+	RuntimeSamples int                          `json:"runtime_samples"`
+	Trials         int                          `json:"trials"`
+	Scenarios      []scheduler.CapacityScenario `json:"scenarios"`
+}
+
+// HandleGetCapacity simulates queue drain time under hypothetical worker counts.
+// GET /api/v1/admin/capacity?workers=4,8,16&avg_runtime=15m&queue=500
+func (h *Handler) HandleGetCapacity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	queryParams := r.URL.Query()
+
+	currentWorkers := 0
+	if h.scheduler != nil {
+		currentWorkers = h.scheduler.WorkerCount()
+	}
+
+	workerCounts, err := parseWorkerCounts(queryParams.Get("workers"), currentWorkers)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid workers parameter")
+		return
+	}
+
+	stats, err := h.repos.BacktestJob.GetQueueStats(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get queue stats", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get queue stats")
+		return
+	}
+
+	input := scheduler.CapacityInput{
+		QueueLength: stats.PendingJobs,
+		RunningJobs: stats.RunningJobs,
+		Trials:      scheduler.DefaultCapacityTrials,
+		Seed:        1,
+	}
+
+	if queue := queryParams.Get("queue"); queue != "" {
+		val, err := strconv.Atoi(queue)
+		if err != nil || val < 0 || val > capacityMaxQueue {
+			writeError(w, http.StatusBadRequest, errors.New("queue must be an integer between 0 and 100000"), "")
+			return
+		}
+		input.QueueLength = val
+	}
+
+	runtimeSource := "historical"
+	if avgRuntime := queryParams.Get("avg_runtime"); avgRuntime != "" {
+		runtime, err := parseRuntime(avgRuntime)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid avg_runtime parameter")
+			return
+		}
+		input.Runtimes = []time.Duration{runtime}
+		runtimeSource = "override"
+	} else {
+		runtimes, err := h.repos.BacktestJob.GetRecentRuntimes(r.Context(), time.Now().Add(-capacityLookback), capacityMaxSamples)
+		if err != nil {
+			h.logger.Error("Failed to get historical runtimes", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to get historical runtimes")
+			return
+		}
+		if len(runtimes) == 0 {
+			writeError(w, http.StatusUnprocessableEntity, errors.New("no historical runtimes available"),
+				"no completed jobs in the last 30 days; provide avg_runtime")
+			return
+		}
+		input.Runtimes = runtimes
+	}
+
+	writeJSON(w, http.StatusOK, CapacityResponse{
+		QueueLength:    input.QueueLength,
+		RunningJobs:    input.RunningJobs,
+		CurrentWorkers: currentWorkers,
+		RuntimeSource:  runtimeSource,
+		RuntimeSamples: len(input.Runtimes),
+		Trials:         input.Trials,
+		Scenarios:      scheduler.SimulateCapacity(input, workerCounts),
+	})
+}
+
+// HandleSimulatePriority previews changing the priority of pending jobs: the
+// listed jobs and all pending jobs of an optimization run. It reports the new
+// expected start of every pending job that would move, assuming each job takes
+// the median recent runtime (or avg_runtime) on the current worker count.
+// Nothing is changed; see PATCH /api/v1/backtests/priority.
+// GET /api/v1/admin/priority-simulation?job_ids=a,b&optimization_run_id=c&priority=10&workers=8&avg_runtime=15m
+func (h *Handler) HandleSimulatePriority(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	queryParams := r.URL.Query()
+
+	var change domain.PriorityChange
+	priority, err := strconv.Atoi(queryParams.Get("priority"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("priority must be an integer"), "")
+		return
+	}
+	change.Priority = priority
+	if v := queryParams.Get("job_ids"); v != "" {
+		for _, part := range strings.Split(v, ",") {
+			id, err := parseUUID(strings.TrimSpace(part))
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err, "invalid job_ids parameter")
+				return
+			}
+			change.JobIDs = append(change.JobIDs, id)
+		}
+	}
+	if v := queryParams.Get("optimization_run_id"); v != "" {
+		id, err := parseUUID(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid optimization_run_id parameter")
+			return
+		}
+		change.OptimizationRunID = &id
+	}
+	if err := change.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "")
+		return
+	}
+
+	workers := 0
+	if h.scheduler != nil {
+		workers = h.scheduler.WorkerCount()
+	}
+	if v := queryParams.Get("workers"); v != "" {
+		val, err := strconv.Atoi(v)
+		if err != nil || val <= 0 || val > capacityMaxWorkers {
+			writeError(w, http.StatusBadRequest, errors.New("workers must be between 1 and 1024"), "")
+			return
+		}
+		workers = val
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var runtime time.Duration
+	if avgRuntime := queryParams.Get("avg_runtime"); avgRuntime != "" {
+		runtime, err = parseRuntime(avgRuntime)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid avg_runtime parameter")
+			return
+		}
+	} else {
+		runtimes, err := h.repos.BacktestJob.GetRecentRuntimes(r.Context(), time.Now().Add(-capacityLookback), capacityMaxSamples)
+		if err != nil {
+			h.logger.Error("Failed to get historical runtimes", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to get historical runtimes")
+			return
+		}
+		if len(runtimes) == 0 {
+			writeError(w, http.StatusUnprocessableEntity, errors.New("no historical runtimes available"),
+				"no completed jobs in the last 30 days; provide avg_runtime")
+			return
+		}
+		slices.Sort(runtimes)
+		runtime = runtimes[len(runtimes)/2]
+	}
+
+	if !h.checkSelectedRun(w, r, change.OptimizationRunID) {
+		return
+	}
+
+	queue, err := h.repos.BacktestJob.GetPendingQueue(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get pending queue", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get pending queue")
+		return
+	}
+	stats, err := h.repos.BacktestJob.GetQueueStats(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get queue stats", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get queue stats")
+		return
+	}
+
+	agingPerHour := 0.0
+	if h.scheduler != nil {
+		agingPerHour = h.scheduler.PriorityAgingPerHour()
+	}
+	writeJSON(w, http.StatusOK, scheduler.SimulatePriorityChange(queue, change, stats.RunningJobs, workers, runtime, time.Now(), agingPerHour))
+}
+
+// parseWorkerCounts parses a comma-separated list of worker counts.
+// When empty, it defaults to the current worker count and its 2x and 4x multiples.
+func parseWorkerCounts(s string, current int) ([]int, error) {
+	if s == "" {
+		if current <= 0 {
+			current = 1
+		}
+		return []int{current, current * 2, current * 4}, nil
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) > capacityMaxScenarios {
+		return nil, errors.New("at most 8 worker counts can be simulated")
+	}
+	var counts []int
+	for _, part := range parts {
+		val, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if val <= 0 || val > capacityMaxWorkers {
+			return nil, errors.New("worker counts must be between 1 and 1024")
+		}
+		counts = append(counts, val)
+	}
+	return counts, nil
+}
+
+// parseRuntime parses a runtime given as a Go duration ("15m") or as seconds ("900").
+func parseRuntime(s string) (time.Duration, error) {
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		if secs <= 0 {
+			return 0, errors.New("avg_runtime must be positive")
+		}
+		return time.Duration(secs * float64(time.Second)), nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, errors.New("avg_runtime must be positive")
+	}
+	return d, nil
+}
+
+// ActiveQueriesResponse represents the response for the active queries dump.
+type ActiveQueriesResponse struct {
+	Queries      []db.ActiveQuery `json:"queries"`
+	InFlight     int64            `json:"in_flight"`
+	TotalQueries int64            `json:"total_queries"`
+	SlowQueries  int64            `json:"slow_queries"`
+}
+
+// HandleGetActiveQueries dumps the database queries currently executing, longest-running first.
+// GET /api/v1/admin/queries?min_duration_ms=100
+func (h *Handler) HandleGetActiveQueries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	if h.queryMonitor == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("query monitor not available"), "")
+		return
+	}
+
+	var minDurationMs int64
+	if v := r.URL.Query().Get("min_duration_ms"); v != "" {
+		val, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || val < 0 {
+			writeError(w, http.StatusBadRequest, errors.New("min_duration_ms must be a non-negative integer"), "")
+			return
+		}
+		minDurationMs = val
+	}
+
+	queries := []db.ActiveQuery{}
+	for _, q := range h.queryMonitor.ActiveQueries() {
+		if q.DurationMs >= minDurationMs {
+			queries = append(queries, q)
+		}
+	}
+
+	stats := h.queryMonitor.QueryStats()
+	writeJSON(w, http.StatusOK, ActiveQueriesResponse{
+		Queries:      queries,
+		InFlight:     stats.InFlight,
+		TotalQueries: stats.TotalQueries,
+		SlowQueries:  stats.SlowTotal,
+	})
+}
+
+// HandleGetSchedulerState dumps the scheduler's in-memory state, as saved to
+// its state file across restarts: the jobs it has claimed, retry backoffs and
+// jobs deferred by affinity hints.
+// GET /api/v1/admin/scheduler/state
+func (h *Handler) HandleGetSchedulerState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	if h.scheduler == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("scheduler not available"), "")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.scheduler.State())
+}
+
+// ConsistencyRepairRequest represents the request body for repairing
+// cross-table invariants.
+type ConsistencyRepairRequest struct {
+	Checks []string `json:"checks,omitempty"` // Defaults to all checks
+	DryRun bool     `json:"dry_run"`
+}
+
+// HandleCheckConsistency scans for violations of cross-table invariants, such as
+// iterations pointing at missing jobs or running jobs without a container.
+// GET /api/v1/admin/consistency?checks=iteration_missing_job,result_missing_job
+func (h *Handler) HandleCheckConsistency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var names []string
+	if v := r.URL.Query().Get("checks"); v != "" {
+		names = strings.Split(v, ",")
+	}
+	checks, err := parseConsistencyChecks(names)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid checks parameter")
+		return
+	}
+
+	report, err := h.repos.Consistency.Check(r.Context(), checks, time.Now())
+	if err != nil {
+		h.logger.Error("Failed to check consistency", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to check consistency")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// HandleRepairConsistency repairs violations of cross-table invariants in a
+// single transaction. With dry_run the transaction is rolled back and the
+// report lists what would have been repaired.
+// POST /api/v1/admin/consistency/repair
+func (h *Handler) HandleRepairConsistency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var req ConsistencyRepairRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	checks, err := parseConsistencyChecks(req.Checks)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid checks")
+		return
+	}
+
+	report, err := h.repos.Consistency.Repair(r.Context(), checks, req.DryRun, time.Now())
+	if err != nil {
+		h.logger.Error("Failed to repair consistency", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to repair consistency")
+		return
+	}
+
+	if !req.DryRun && report.Total() > 0 {
+		h.logger.Info("Repaired consistency violations",
+			zap.Int("violations", report.Total()),
+			zap.String("owner", requestOwner(r)),
+		)
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// parseConsistencyChecks parses check names, defaulting to all checks. The
+// checks are returned in the order they run in, whatever order they were given in.
+func parseConsistencyChecks(names []string) ([]domain.ConsistencyCheck, error) {
+	selected := make(map[domain.ConsistencyCheck]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		check, err := domain.ParseConsistencyCheck(name)
+		if err != nil {
+			return nil, err
+		}
+		selected[check] = true
+	}
+	if len(selected) == 0 {
+		return domain.ConsistencyChecks, nil
+	}
+
+	var checks []domain.ConsistencyCheck
+	for _, check := range domain.ConsistencyChecks {
+		if selected[check] {
+			checks = append(checks, check)
+		}
+	}
+	return checks, nil
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Agent Registration Handlers
+// ============================================================================
+
+// RegisterAgentRequest represents the request body for registering an agent.
+type RegisterAgentRequest struct {
+	AgentID             string           `json:"agent_id"`
+	Type                domain.AgentType `json:"type"`
+	Version             string           `json:"version"`
+	EventSchemaVersions []int            `json:"event_schema_versions"`
+	Capabilities        []string         `json:"capabilities,omitempty"`
+}
+
+// RegisterAgentResponse represents the response for registering an agent.
+type RegisterAgentResponse struct {
+	Agent              *domain.AgentRegistration `json:"agent"`
+	EventSchemaVersion int                       `json:"event_schema_version"` // Version the backend publishes
+	Warnings           []string                  `json:"warnings,omitempty"`
+}
+
+// HandleRegisterAgent stores what an agent instance announces about itself:
+// its type, version, the event schema versions it understands and its
+// capabilities. Registering again with the same agent_id replaces the
+// registration. An agent that does not understand the backend's event schema
+// is registered with a warning, but never counts towards a run's requirements.
+// POST /api/v1/agents/register
+func (h *Handler) HandleRegisterAgent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var req RegisterAgentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	now := time.Now()
+	reg := &domain.AgentRegistration{
+		AgentID:             req.AgentID,
+		Type:                req.Type,
+		Version:             req.Version,
+		EventSchemaVersions: req.EventSchemaVersions,
+		Capabilities:        req.Capabilities,
+		RegisteredAt:        now,
+		LastSeenAt:          now,
+	}
+	if reg.Capabilities == nil {
+		reg.Capabilities = []string{}
+	}
+	if err := reg.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid registration")
+		return
+	}
+
+	if err := h.repos.Agent.Register(r.Context(), reg); err != nil {
+		h.logger.Error("Failed to register agent", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to register agent")
+		return
+	}
+
+	var warnings []string
+	if !reg.SupportsSchema() {
+		warnings = append(warnings, fmt.Sprintf("agent does not support event schema v%d published by this backend", domain.EventSchemaVersion))
+	}
+
+	h.logger.Info("Agent registered",
+		zap.String("agent_id", reg.AgentID),
+		zap.String("type", string(reg.Type)),
+		zap.String("version", reg.Version),
+		zap.Strings("capabilities", reg.Capabilities),
+	)
+
+	writeJSON(w, http.StatusOK, RegisterAgentResponse{
+		Agent:              reg,
+		EventSchemaVersion: domain.EventSchemaVersion,
+		Warnings:           warnings,
+	})
+}
+
+// RegisteredAgent is an agent registration and whether it is live.
+type RegisteredAgent struct {
+	*domain.AgentRegistration
+	Live bool `json:"live"` // Seen within the registration TTL
+}
+
+// ListAgentsResponse represents the response for listing registered agents.
+type ListAgentsResponse struct {
+	Agents             []RegisteredAgent `json:"agents"`
+	EventSchemaVersion int               `json:"event_schema_version"`
+}
+
+// HandleListAgents lists the registered agents, live or not.
+// GET /api/v1/agents
+func (h *Handler) HandleListAgents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	regs, err := h.repos.Agent.List(r.Context(), nil)
+	if err != nil {
+		h.logger.Error("Failed to list agents", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list agents")
+		return
+	}
+
+	cutoff := time.Now().Add(-h.agentTTL)
+	agents := make([]RegisteredAgent, 0, len(regs))
+	for _, reg := range regs {
+		agents = append(agents, RegisteredAgent{
+			AgentRegistration: reg,
+			Live:              h.agentTTL <= 0 || !reg.LastSeenAt.Before(cutoff),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, ListAgentsResponse{
+		Agents:             agents,
+		EventSchemaVersion: domain.EventSchemaVersion,
+	})
+}
+
+// checkRunAgents returns the agents a run needs that no live registered agent
+// provides. Nothing is checked unless capabilities are enforced.
+func (h *Handler) checkRunAgents(ctx context.Context, run *domain.OptimizationRun) ([]domain.AgentRequirement, error) {
+	if !h.enforceAgents {
+		return nil, nil
+	}
+
+	var since *time.Time
+	if h.agentTTL > 0 {
+		cutoff := time.Now().Add(-h.agentTTL)
+		since = &cutoff
+	}
+	regs, err := h.repos.Agent.List(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	return domain.UnmetAgentRequirements(run.RequiredAgents(), regs), nil
+}
+
+// describeAgentRequirements joins requirements for an error message.
+func describeAgentRequirements(reqs []domain.AgentRequirement) string {
+	parts := make([]string, len(reqs))
+	for i, req := range reqs {
+		parts[i] = req.String()
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,189 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/stats"
+)
+
+// ============================================================================
+// Analytics Handlers
+// ============================================================================
+
+// HandleGetEvolutionAnalytics returns per-generation statistics across all
+// strategies: counts, best and mean sharpe ratio, the share of strategies that
+// were evolved further, and the change from the previous generation.
+// GET /api/v1/analytics/evolution
+func (h *Handler) HandleGetEvolutionAnalytics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	generations, err := h.repos.Strategy.GetGenerationStatistics(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get generation statistics", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get generation statistics")
+		return
+	}
+
+	summary := domain.NewEvolutionSummary(generations, time.Now().In(h.timezone()))
+	summary.Timezone = h.timezone().String()
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// HandleGetFailureAnalytics returns the failure rate of backtest jobs by
+// failure category, over the jobs that finished within the optional
+// start_time and end_time (RFC3339).
+// GET /api/v1/analytics/failures
+func (h *Handler) HandleGetFailureAnalytics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	window, err := parseTimeRangeParams(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid time range")
+		return
+	}
+
+	analytics, err := h.repos.BacktestJob.GetFailureAnalytics(r.Context(), window)
+	if err != nil {
+		h.logger.Error("Failed to get failure analytics", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get failure analytics")
+		return
+	}
+	analytics.Timezone = h.timezone().String()
+
+	writeJSON(w, http.StatusOK, analytics)
+}
+
+// HandleGetDescriptionCoverage returns how many active strategies have a
+// description, how many were generated by the Analyst Agent, and how many
+// missing ones have been requested and are still outstanding.
+// GET /api/v1/analytics/description-coverage
+func (h *Handler) HandleGetDescriptionCoverage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	coverage, err := h.repos.Strategy.GetDescriptionCoverage(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get description coverage", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get description coverage")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, coverage)
+}
+
+// SignificanceRequest represents the request body for a significance test.
+type SignificanceRequest struct {
+	A      domain.SignificanceSide `json:"a"`
+	B      domain.SignificanceSide `json:"b"`
+	Method string                  `json:"method"`          // "welch_t" (default) or "bootstrap"
+	Alpha  *float64                `json:"alpha,omitempty"` // Significance level, default 0.05
+
+	// Bootstrap only
+	Iterations int    `json:"iterations,omitempty"` // Default 2000
+	Seed       uint64 `json:"seed,omitempty"`
+}
+
+// HandleTestSignificance tests whether the mean per-trade return of side B
+// differs significantly from side A's, so an optimization loop can tell a
+// real improvement from noise. Each side is a single result or all current
+// results of a strategy, and needs per-trade returns, which are recorded
+// when the results format maps trade_returns.
+// POST /api/v1/analytics/significance
+func (h *Handler) HandleTestSignificance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var req SignificanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	method, err := domain.ParseSignificanceMethod(req.Method)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "")
+		return
+	}
+	opts := stats.Options{
+		Method:     method,
+		Alpha:      domain.DefaultSignificanceAlpha,
+		Iterations: domain.DefaultBootstrapIterations,
+		Seed:       req.Seed,
+	}
+	if req.Alpha != nil {
+		if *req.Alpha <= 0 || *req.Alpha > 0.5 {
+			writeError(w, http.StatusBadRequest, errors.New("alpha must be in (0, 0.5]"), "")
+			return
+		}
+		opts.Alpha = *req.Alpha
+	}
+	if req.Iterations != 0 {
+		if req.Iterations < 100 || req.Iterations > domain.MaxBootstrapIterations {
+			writeError(w, http.StatusBadRequest,
+				fmt.Errorf("iterations must be between 100 and %d", domain.MaxBootstrapIterations), "")
+			return
+		}
+		opts.Iterations = req.Iterations
+	}
+
+	var returns [2][]float64
+	for i, side := range []struct {
+		name string
+		side domain.SignificanceSide
+	}{{"a", req.A}, {"b", req.B}} {
+		if err := side.side.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid side "+side.name)
+			return
+		}
+
+		returns[i], err = h.sideTradeReturns(r.Context(), side.side)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				writeError(w, http.StatusNotFound, err, "side "+side.name+" not found")
+				return
+			}
+			h.logger.Error("Failed to get trade returns", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to get trade returns")
+			return
+		}
+		if len(returns[i]) < domain.MinSignificanceTrades {
+			writeError(w, http.StatusUnprocessableEntity,
+				fmt.Errorf("side %s has %d trades with recorded returns, at least %d are needed",
+					side.name, len(returns[i]), domain.MinSignificanceTrades),
+				"per-trade returns are only recorded for results formats that map trade_returns")
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, stats.CompareReturns(returns[0], returns[1], opts))
+}
+
+// sideTradeReturns loads the per-trade returns of one side of a significance
+// test.
+func (h *Handler) sideTradeReturns(ctx context.Context, side domain.SignificanceSide) ([]float64, error) {
+	if side.ResultID != nil {
+		return h.repos.Result.GetTradeReturns(ctx, *side.ResultID)
+	}
+	if _, err := h.repos.Strategy.GetByID(ctx, *side.StrategyID); err != nil {
+		return nil, err
+	}
+	return h.repos.Result.GetStrategyTradeReturns(ctx, *side.StrategyID)
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// API Key Handlers
+// ============================================================================
+
+// CreateAPIKeyRequest represents the request body for creating an API key.
+type CreateAPIKeyRequest struct {
+	Name      string      `json:"name"`
+	Role      domain.Role `json:"role,omitempty"` // viewer, operator (default) or admin
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+}
+
+// CreateAPIKeyResponse represents the response for creating an API key. The
+// key is only ever returned here.
+type CreateAPIKeyResponse struct {
+	APIKey *domain.APIKey `json:"api_key"`
+	Key    string         `json:"key"`
+}
+
+// ListAPIKeysResponse represents the response for listing API keys.
+type ListAPIKeysResponse struct {
+	APIKeys []*domain.APIKey `json:"api_keys"`
+}
+
+// HandleCreateAPIKey creates an API key. Requires an admin key while
+// authentication is enabled.
+// POST /api/v1/auth/keys
+func (h *Handler) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+	if !h.checkKeyManagement(w, r) {
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	key, secret, err := h.auth.CreateKey(r.Context(), req.Name, req.Role, req.ExpiresAt)
+	if err != nil {
+		h.writeAPIKeyError(w, err, "failed to create API key")
+		return
+	}
+
+	h.logger.Info("API key created",
+		zap.String("key_id", key.ID.String()),
+		zap.String("name", key.Name),
+		zap.String("role", string(key.Role)),
+		zap.String("created_by", key.CreatedBy),
+	)
+
+	writeJSON(w, http.StatusCreated, CreateAPIKeyResponse{APIKey: key, Key: secret})
+}
+
+// HandleListAPIKeys lists stored API keys, without the keys themselves.
+// Revoked keys are included with include_revoked=true.
+// GET /api/v1/auth/keys?include_revoked=true
+func (h *Handler) HandleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+	if !h.checkKeyManagement(w, r) {
+		return
+	}
+
+	includeRevoked := false
+	if v := r.URL.Query().Get("include_revoked"); v != "" {
+		var err error
+		includeRevoked, err = strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid include_revoked")
+			return
+		}
+	}
+
+	keys, err := h.repos.APIKey.List(r.Context(), includeRevoked)
+	if err != nil {
+		h.writeAPIKeyError(w, err, "failed to list API keys")
+		return
+	}
+	if keys == nil {
+		keys = []*domain.APIKey{}
+	}
+
+	writeJSON(w, http.StatusOK, ListAPIKeysResponse{APIKeys: keys})
+}
+
+// HandleRevokeAPIKey revokes a stored API key. Keys given in configuration
+// are removed from the configuration instead.
+// DELETE /api/v1/auth/keys/:id
+func (h *Handler) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+	if !h.checkKeyManagement(w, r) {
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/auth/keys/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid API key id")
+		return
+	}
+
+	if err := h.auth.RevokeKey(r.Context(), id); err != nil {
+		h.writeAPIKeyError(w, err, "failed to revoke API key")
+		return
+	}
+
+	revokedBy := ""
+	if p, ok := auth.FromContext(r.Context()); ok {
+		revokedBy = p.Name
+	}
+	h.logger.Info("API key revoked",
+		zap.String("key_id", id.String()),
+		zap.String("revoked_by", revokedBy),
+	)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkKeyManagement checks that API keys are available and the caller may
+// manage them, writing the error response if not.
+func (h *Handler) checkKeyManagement(w http.ResponseWriter, r *http.Request) bool {
+	if h.auth == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("API key management is not configured"), "")
+		return false
+	}
+	if err := h.auth.CanManageKeys(r.Context()); err != nil {
+		writeError(w, http.StatusForbidden, err, "")
+		return false
+	}
+	return true
+}
+
+// writeAPIKeyError maps authenticator and repository errors to responses.
+func (h *Handler) writeAPIKeyError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		writeError(w, http.StatusBadRequest, err, "")
+	case errors.Is(err, domain.ErrNotFound):
+		writeError(w, http.StatusNotFound, err, "API key not found or already revoked")
+	case errors.Is(err, domain.ErrDuplicate):
+		writeError(w, http.StatusConflict, err, "an active API key with this name already exists")
+	default:
+		h.logger.Error("API key operation failed", zap.String("operation", msg), zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, msg)
+	}
+}
@@ -0,0 +1,217 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Artifact Handlers
+// ============================================================================
+
+// maxArtifactRequestSize bounds the JSON request body; base64 inflates content by ~4/3.
+const maxArtifactRequestSize = domain.MaxArtifactSize*4/3 + 64*1024
+
+// CreateArtifactRequest represents the request body for storing an artifact.
+// Content is base64-encoded in JSON.
+type CreateArtifactRequest struct {
+	Kind        string                 `json:"kind"`
+	Name        string                 `json:"name"`
+	ContentType string                 `json:"content_type"`
+	SourceURL   *string                `json:"source_url,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Content     []byte                 `json:"content"`
+}
+
+// CreateArtifactResponse represents the response for storing an artifact.
+type CreateArtifactResponse struct {
+	Artifact *domain.Artifact `json:"artifact"`
+}
+
+// ListArtifactsResponse represents the response for listing artifacts.
+type ListArtifactsResponse struct {
+	Artifacts []*domain.Artifact `json:"artifacts"`
+}
+
+// GetArtifactResponse represents the response for getting artifact metadata.
+type GetArtifactResponse struct {
+	Artifact *domain.Artifact `json:"artifact"`
+}
+
+// scoutRunIDFromArtifactPath extracts the run ID from /api/v1/agents/scout/runs/:id/artifacts.
+func scoutRunIDFromArtifactPath(path string) (uuid.UUID, error) {
+	path = strings.TrimPrefix(path, "/api/v1/agents/scout/runs/")
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), "/artifacts")
+	return parseUUID(path)
+}
+
+// HandleScoutRunArtifacts stores or lists raw payloads captured during a Scout run.
+// GET  /api/v1/agents/scout/runs/:id/artifacts
+// POST /api/v1/agents/scout/runs/:id/artifacts
+func (h *Handler) HandleScoutRunArtifacts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	runID, err := scoutRunIDFromArtifactPath(r.URL.Path)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid run id")
+		return
+	}
+
+	if _, err := h.repos.Scout.GetRunByID(r.Context(), runID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "scout run not found")
+			return
+		}
+		h.logger.Error("Failed to get scout run", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get scout run")
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		artifacts, err := h.repos.Artifact.ListByOwner(r.Context(), domain.ArtifactOwnerScoutRun, runID)
+		if err != nil {
+			h.logger.Error("Failed to list scout run artifacts", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to list artifacts")
+			return
+		}
+		writeJSON(w, http.StatusOK, ListArtifactsResponse{Artifacts: artifacts})
+		return
+	}
+
+	var req CreateArtifactRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxArtifactRequestSize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	artifact := domain.NewArtifact(domain.ArtifactOwnerScoutRun, runID, domain.ArtifactKind(req.Kind), req.Name, req.ContentType, req.Content)
+	artifact.SourceURL = req.SourceURL
+	artifact.Metadata = req.Metadata
+	if err := artifact.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid artifact")
+		return
+	}
+
+	if err := h.repos.Artifact.Create(r.Context(), artifact); err != nil {
+		h.logger.Error("Failed to store scout run artifact", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to store artifact")
+		return
+	}
+
+	h.logger.Debug("Stored scout run artifact",
+		zap.String("run_id", runID.String()),
+		zap.String("artifact_id", artifact.ID.String()),
+		zap.String("kind", artifact.Kind.String()),
+		zap.Int64("size_bytes", artifact.SizeBytes),
+	)
+
+	writeJSON(w, http.StatusCreated, CreateArtifactResponse{Artifact: artifact})
+}
+
+// HandleBacktestJobArtifacts lists the files stored for a backtest job, such
+// as the output of its container up to when the job was cancelled.
+// GET /api/v1/backtests/:id/artifacts
+func (h *Handler) HandleBacktestJobArtifacts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	idStr := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/backtests/"), "/"), "/artifacts")
+	jobID, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid job id")
+		return
+	}
+
+	if _, err := h.repos.BacktestJob.GetByID(r.Context(), jobID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "job not found")
+			return
+		}
+		h.logger.Error("Failed to get backtest job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job")
+		return
+	}
+
+	artifacts, err := h.repos.Artifact.ListByOwner(r.Context(), domain.ArtifactOwnerJob, jobID)
+	if err != nil {
+		h.logger.Error("Failed to list backtest job artifacts", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list artifacts")
+		return
+	}
+	writeJSON(w, http.StatusOK, ListArtifactsResponse{Artifacts: artifacts})
+}
+
+// HandleGetArtifact retrieves artifact metadata by ID.
+// GET /api/v1/artifacts/:id
+func (h *Handler) HandleGetArtifact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/artifacts/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid artifact id")
+		return
+	}
+
+	artifact, err := h.repos.Artifact.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "artifact not found")
+			return
+		}
+		h.logger.Error("Failed to get artifact", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get artifact")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, GetArtifactResponse{Artifact: artifact})
+}
+
+// HandleGetArtifactContent downloads the raw content of an artifact.
+// GET /api/v1/artifacts/:id/content
+func (h *Handler) HandleGetArtifactContent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/artifacts/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid artifact id")
+		return
+	}
+
+	artifact, err := h.repos.Artifact.GetContent(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "artifact not found")
+			return
+		}
+		h.logger.Error("Failed to get artifact content", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get artifact content")
+		return
+	}
+
+	w.Header().Set("Content-Type", artifact.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(artifact.Content)))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": artifact.Name}))
+	w.Header().Set("X-Content-SHA256", artifact.SHA256)
+	w.WriteHeader(http.StatusOK)
+	w.Write(artifact.Content)
+}
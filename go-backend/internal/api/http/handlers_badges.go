@@ -0,0 +1,107 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Badge Handlers
+// ============================================================================
+
+// badgeCacheControl lets wikis and proxies embedding a badge reuse it for a
+// few minutes while keeping it live.
+const badgeCacheControl = "public, max-age=300"
+
+// StrategyBadgeSummary is the JSON variant of a strategy badge: the metrics
+// of the strategy's best result by sharpe ratio.
+type StrategyBadgeSummary struct {
+	StrategyID     uuid.UUID  `json:"strategy_id"`
+	Name           string     `json:"name"`
+	HasResults     bool       `json:"has_results"`
+	BestResultID   *uuid.UUID `json:"best_result_id,omitempty"`
+	SharpeRatio    *float64   `json:"sharpe_ratio,omitempty"`
+	ProfitPct      *float64   `json:"profit_pct,omitempty"`
+	MaxDrawdownPct *float64   `json:"max_drawdown_pct,omitempty"`
+	TotalTrades    *int       `json:"total_trades,omitempty"`
+	Text           string     `json:"text"`
+	Color          string     `json:"color"`
+}
+
+// HandleStrategyBadge renders the best sharpe ratio and profit of a strategy
+// as an SVG badge for embedding, or as a JSON summary.
+// GET /api/v1/strategies/:id/badge.svg
+// GET /api/v1/strategies/:id/badge.json
+func (h *Handler) HandleStrategyBadge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID from path like /api/v1/strategies/:id/badge.svg
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	idStr, format, _ := strings.Cut(path, "/badge.")
+	if format != "svg" && format != "json" {
+		writeError(w, http.StatusNotFound, errors.New("not found"), "badge format must be svg or json")
+		return
+	}
+
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
+	best, err := h.repos.Result.GetBestByStrategyID(r.Context(), id)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		h.logger.Error("Failed to get best result", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get best result")
+		return
+	}
+	text, color := badgeText(best)
+
+	w.Header().Set("Cache-Control", badgeCacheControl)
+
+	if format == "json" {
+		summary := StrategyBadgeSummary{
+			StrategyID: strategy.ID,
+			Name:       strategy.Name,
+			HasResults: best != nil,
+			Text:       text,
+			Color:      color,
+		}
+		if best != nil {
+			summary.BestResultID = &best.ID
+			summary.SharpeRatio = best.SharpeRatio
+			summary.ProfitPct = &best.ProfitPct
+			summary.MaxDrawdownPct = &best.MaxDrawdownPct
+			summary.TotalTrades = &best.TotalTrades
+		}
+		writeJSON(w, http.StatusOK, summary)
+		return
+	}
+
+	svg := renderBadge(strategy.Name, text, color)
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Content-Length", strconv.Itoa(len(svg)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(svg)
+}
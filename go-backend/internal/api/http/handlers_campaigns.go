@@ -0,0 +1,246 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Campaign Handlers
+// ============================================================================
+
+// CampaignRequest represents the request body for creating or updating a campaign.
+type CampaignRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CampaignResponse represents the response for a single campaign.
+type CampaignResponse struct {
+	Campaign *domain.Campaign `json:"campaign"`
+}
+
+// GetCampaignResponse represents the response for getting a campaign with its
+// aggregate progress and results.
+type GetCampaignResponse struct {
+	Campaign *domain.CampaignSummary `json:"campaign"`
+}
+
+// ListCampaignsResponse represents the response for listing campaigns.
+type ListCampaignsResponse struct {
+	Campaigns  []*domain.Campaign        `json:"campaigns"`
+	Pagination domain.PaginationResponse `json:"pagination"`
+}
+
+// HandleCreateCampaign creates a campaign that backtests can be submitted under.
+// POST /api/v1/campaigns
+func (h *Handler) HandleCreateCampaign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var req CampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	campaign := domain.NewCampaign(req.Name, req.Description, requestOwner(r))
+	if err := campaign.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid campaign")
+		return
+	}
+
+	if err := h.repos.Campaign.Create(r.Context(), campaign); err != nil {
+		h.logger.Error("Failed to create campaign", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to create campaign")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, CampaignResponse{Campaign: campaign})
+}
+
+// HandleListCampaigns lists campaigns, newest first.
+// GET /api/v1/campaigns
+func (h *Handler) HandleListCampaigns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	query := domain.CampaignListQuery{}
+
+	queryParams := r.URL.Query()
+	if name := queryParams.Get("name"); name != "" {
+		query.NamePattern = &name
+	}
+	if page := queryParams.Get("page"); page != "" {
+		if val, err := strconv.Atoi(page); err == nil {
+			query.Page = val
+		}
+	}
+	if pageSize := queryParams.Get("page_size"); pageSize != "" {
+		if val, err := strconv.Atoi(pageSize); err == nil {
+			query.PageSize = val
+		}
+	}
+
+	requested := query.PageSize
+	if query.SetDefaults() {
+		warnPageSizeClamped(w, requested, query.PageSize)
+	}
+
+	campaigns, total, err := h.repos.Campaign.List(r.Context(), query)
+	if err != nil {
+		h.logger.Error("Failed to list campaigns", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list campaigns")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListCampaignsResponse{
+		Campaigns:  campaigns,
+		Pagination: domain.NewPaginationResponse(total, query.Page, query.PageSize),
+	})
+}
+
+// HandleGetCampaign retrieves a campaign with its job progress and the
+// aggregate of its current results, including the best result by Sharpe ratio.
+// Use GET /api/v1/backtests?campaign_id=:id to list the jobs themselves.
+// GET /api/v1/campaigns/:id
+func (h *Handler) HandleGetCampaign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/campaigns/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid campaign id")
+		return
+	}
+
+	campaign, err := h.repos.Campaign.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "campaign not found")
+			return
+		}
+		h.logger.Error("Failed to get campaign", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get campaign")
+		return
+	}
+
+	progress, err := h.repos.Campaign.GetProgress(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get campaign progress", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get campaign progress")
+		return
+	}
+
+	results, err := h.repos.Campaign.GetResults(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get campaign results", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get campaign results")
+		return
+	}
+
+	best, err := h.repos.Result.GetBestByCampaignID(r.Context(), id)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		h.logger.Error("Failed to get best campaign result", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get campaign results")
+		return
+	}
+	results.BestResult = best
+
+	writeJSON(w, http.StatusOK, GetCampaignResponse{Campaign: &domain.CampaignSummary{
+		Campaign: campaign,
+		Progress: *progress,
+		Results:  *results,
+	}})
+}
+
+// HandleUpdateCampaign updates a campaign's name and description.
+// PUT /api/v1/campaigns/:id
+func (h *Handler) HandleUpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/campaigns/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid campaign id")
+		return
+	}
+
+	var req CampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	campaign, err := h.repos.Campaign.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "campaign not found")
+			return
+		}
+		h.logger.Error("Failed to get campaign", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get campaign")
+		return
+	}
+
+	campaign.Name = strings.TrimSpace(req.Name)
+	campaign.Description = req.Description
+	if err := campaign.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid campaign")
+		return
+	}
+
+	if err := h.repos.Campaign.Update(r.Context(), campaign); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "campaign not found")
+			return
+		}
+		h.logger.Error("Failed to update campaign", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to update campaign")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, CampaignResponse{Campaign: campaign})
+}
+
+// HandleDeleteCampaign deletes a campaign. Its jobs and results are kept.
+// DELETE /api/v1/campaigns/:id
+func (h *Handler) HandleDeleteCampaign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/campaigns/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid campaign id")
+		return
+	}
+
+	if err := h.repos.Campaign.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "campaign not found")
+			return
+		}
+		h.logger.Error("Failed to delete campaign", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to delete campaign")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Dead Letter Handlers
+// ============================================================================
+
+// DeadLetterResponse represents a dead letter with its message body: as JSON
+// when the body is valid JSON, as text otherwise.
+type DeadLetterResponse struct {
+	*domain.DeadLetter
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodyText string          `json:"body_text,omitempty"`
+}
+
+// newDeadLetterResponse builds the response for a dead letter.
+func newDeadLetterResponse(letter *domain.DeadLetter) DeadLetterResponse {
+	resp := DeadLetterResponse{DeadLetter: letter, Body: letter.BodyJSON()}
+	if resp.Body == nil {
+		resp.BodyText = strings.ToValidUTF8(string(letter.Body), "�")
+	}
+	return resp
+}
+
+// ListDeadLettersResponse represents the response for listing dead letters.
+type ListDeadLettersResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+}
+
+// HandleListDeadLetters lists event messages consumers gave up on, newest
+// first. Requeued ones are left out unless include_requeued is set.
+// GET /api/v1/admin/dead-letters?routing_key=scout.completed&include_requeued=true&limit=50
+func (h *Handler) HandleListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	query := domain.DeadLetterQuery{
+		RoutingKey: r.URL.Query().Get("routing_key"),
+		Limit:      50,
+	}
+	if v := r.URL.Query().Get("include_requeued"); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid include_requeued")
+			return
+		}
+		query.IncludeRequeued = include
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 200 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be between 1 and 200"), "")
+			return
+		}
+		query.Limit = n
+	}
+
+	letters, err := h.repos.DeadLetter.List(r.Context(), query)
+	if err != nil {
+		h.logger.Error("Failed to list dead letters", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list dead letters")
+		return
+	}
+
+	resp := ListDeadLettersResponse{DeadLetters: make([]DeadLetterResponse, len(letters))}
+	for i, letter := range letters {
+		resp.DeadLetters[i] = newDeadLetterResponse(letter)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// HandleGetDeadLetter returns a dead letter with its message body.
+// GET /api/v1/admin/dead-letters/:id
+func (h *Handler) HandleGetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/admin/dead-letters/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid dead letter id")
+		return
+	}
+
+	letter, err := h.repos.DeadLetter.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "dead letter not found")
+			return
+		}
+		h.logger.Error("Failed to get dead letter", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get dead letter")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newDeadLetterResponse(letter))
+}
+
+// HandleRequeueDeadLetter publishes a dead letter's message again with its
+// original routing key, e.g. once the bug that made its consumer give up is
+// fixed. Every queue bound to the routing key receives it again.
+// POST /api/v1/admin/dead-letters/:id/requeue
+func (h *Handler) HandleRequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/admin/dead-letters/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid dead letter id")
+		return
+	}
+
+	if h.eventPublisher == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("event publishing is not configured"), "")
+		return
+	}
+
+	letter, err := h.repos.DeadLetter.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "dead letter not found")
+			return
+		}
+		h.logger.Error("Failed to get dead letter", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get dead letter")
+		return
+	}
+
+	body := letter.BodyJSON()
+	if body == nil {
+		writeError(w, http.StatusUnprocessableEntity, errors.New("message body is not valid JSON"), "dead letter cannot be requeued")
+		return
+	}
+
+	if err := h.eventPublisher.Publish(r.Context(), letter.RoutingKey, body); err != nil {
+		h.logger.Error("Failed to requeue dead letter", zap.Error(err))
+		writeError(w, http.StatusBadGateway, err, "failed to publish dead letter")
+		return
+	}
+
+	letter, err = h.repos.DeadLetter.MarkRequeued(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to mark dead letter requeued", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "dead letter was published but not marked requeued")
+		return
+	}
+
+	h.logger.Info("Requeued dead letter",
+		zap.String("id", id.String()),
+		zap.String("routing_key", letter.RoutingKey),
+		zap.String("requeued_by", requestOwner(r)),
+	)
+
+	writeJSON(w, http.StatusOK, newDeadLetterResponse(letter))
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Equity Curve Handlers
+// ============================================================================
+
+// EquityCurveResponse represents the equity curve of a backtest.
+type EquityCurveResponse struct {
+	JobID         uuid.UUID            `json:"job_id"`
+	ResultID      uuid.UUID            `json:"result_id"`
+	StakeCurrency *string              `json:"stake_currency,omitempty"`
+	Points        []domain.EquityPoint `json:"points"`
+}
+
+// HandleGetBacktestEquity returns a job's balance at the end of each day with
+// the drawdown from the highest balance so far. Points are only recorded for
+// results formats that map daily_profit; otherwise the list is empty.
+// GET /api/v1/backtests/:id/equity
+func (h *Handler) HandleGetBacktestEquity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	idStr := extractID(r.URL.Path, "/api/v1/backtests/")
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid job id")
+		return
+	}
+
+	job, err := h.repos.BacktestJob.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "job not found")
+			return
+		}
+		h.logger.Error("Failed to get backtest job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job")
+		return
+	}
+
+	result, err := h.repos.Result.GetByJobID(r.Context(), job.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusConflict, err, "job has no result")
+			return
+		}
+		h.logger.Error("Failed to get backtest result", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get result")
+		return
+	}
+
+	points, err := h.repos.Result.GetEquityCurve(r.Context(), result.ID)
+	if err != nil {
+		h.logger.Error("Failed to get equity curve", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get equity curve")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, EquityCurveResponse{
+		JobID:         job.ID,
+		ResultID:      result.ID,
+		StakeCurrency: result.StakeCurrency,
+		Points:        points,
+	})
+}
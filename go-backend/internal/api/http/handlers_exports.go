@@ -0,0 +1,196 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/parquet"
+)
+
+// ============================================================================
+// Export Handlers
+// ============================================================================
+
+// CreateExportRequest represents the request body for starting a bulk export.
+type CreateExportRequest struct {
+	Format string              `json:"format"` // Defaults to jsonl
+	Filter domain.ExportFilter `json:"filter"`
+}
+
+// ExportResponse represents the response for an export job. DownloadURL is
+// set once the job has completed.
+type ExportResponse struct {
+	Export      *domain.ExportJob `json:"export"`
+	DownloadURL string            `json:"download_url,omitempty"`
+}
+
+// newExportResponse builds the response for an export job.
+func newExportResponse(job *domain.ExportJob) ExportResponse {
+	resp := ExportResponse{Export: job}
+	if job.Status == domain.ExportStatusCompleted && job.ArtifactID != nil {
+		resp.DownloadURL = "/api/v1/artifacts/" + job.ArtifactID.String() + "/content"
+	}
+	return resp
+}
+
+// HandleCreateExport starts an asynchronous export of the backtest results
+// matching the filter, together with their strategies and optimization
+// iterations. Poll the returned job for its download URL.
+// POST /api/v1/exports
+func (h *Handler) HandleCreateExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var req CreateExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	job := domain.NewExportJob(requestOwner(r), domain.ExportFormat(req.Format), req.Filter)
+	if err := job.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid export")
+		return
+	}
+
+	if err := h.repos.Export.Create(r.Context(), job); err != nil {
+		h.logger.Error("Failed to create export", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to create export")
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, newExportResponse(job))
+}
+
+// HandleGetExport returns the status of an export job of the requesting
+// user, with its download URL once completed.
+// GET /api/v1/exports/:id
+func (h *Handler) HandleGetExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/exports/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid export id")
+		return
+	}
+
+	job, err := h.repos.Export.GetByID(r.Context(), id)
+	if err == nil && job.Owner != requestOwner(r) {
+		err = domain.NewNotFoundError("export", id.String())
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "export not found")
+			return
+		}
+		h.logger.Error("Failed to get export", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get export")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newExportResponse(job))
+}
+
+// Streaming of backtest result exports.
+const (
+	// resultExportBatchSize is the number of results read per page.
+	resultExportBatchSize = 500
+	// resultExportWriteTimeout bounds the write of each page, replacing the
+	// server's write timeout so large exports are not cut off.
+	resultExportWriteTimeout = 30 * time.Second
+)
+
+// resultExportWriter encodes the pages of a backtest result export.
+type resultExportWriter interface {
+	// WritePage writes a page of results, which may be empty.
+	WritePage(results []*domain.BacktestResult) error
+	// Close ends the file after the last page.
+	Close() error
+}
+
+// HandleExportBacktestResults streams every backtest result matching the
+// filters of a result query as a CSV or Parquet file, without pagination,
+// for loading into pandas or DuckDB. Results are ordered by ID; order_by is
+// ignored. An error after the first page aborts the response, so a
+// truncated file is not mistaken for a complete one.
+// GET /api/v1/backtests/export?format=csv|parquet
+func (h *Handler) HandleExportBacktestResults(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	queryParams := r.URL.Query()
+	var contentType, filename string
+	var ew resultExportWriter
+	switch format := queryParams.Get("format"); format {
+	case "", "csv":
+		contentType, filename = "text/csv; charset=utf-8", "backtest-results.csv"
+		ew = &resultCSVWriter{cw: csv.NewWriter(w)}
+	case "parquet":
+		contentType, filename = "application/vnd.apache.parquet", "backtest-results.parquet"
+		ew = &resultParquetWriter{pw: parquet.NewWriter(w, resultParquetColumns)}
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported format %q", format), "format must be csv or parquet")
+		return
+	}
+	query, err := parseBacktestResultFilters(queryParams)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid time range")
+		return
+	}
+
+	page, err := h.repos.Result.ListAfterID(r.Context(), query, nil, resultExportBatchSize)
+	if err != nil {
+		h.logger.Error("Failed to export backtest results", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to export results")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	_ = rc.SetWriteDeadline(time.Now().Add(resultExportWriteTimeout))
+
+	exported := 0
+	for {
+		if err := ew.WritePage(page); err != nil {
+			return
+		}
+		exported += len(page)
+		_ = rc.Flush()
+
+		if len(page) < resultExportBatchSize {
+			break
+		}
+		last := page[len(page)-1].ID
+		if page, err = h.repos.Result.ListAfterID(r.Context(), query, &last, resultExportBatchSize); err != nil {
+			if r.Context().Err() == nil {
+				h.logger.Error("Failed to export backtest results",
+					zap.Int("exported", exported),
+					zap.Error(err),
+				)
+			}
+			panic(http.ErrAbortHandler)
+		}
+		_ = rc.SetWriteDeadline(time.Now().Add(resultExportWriteTimeout))
+	}
+	if err := ew.Close(); err != nil {
+		return
+	}
+
+	h.logger.Debug("Backtest results exported", zap.Int("results", exported))
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Feature Flag Handlers
+// ============================================================================
+
+// ListFeaturesResponse represents the response for listing feature flags.
+type ListFeaturesResponse struct {
+	Enabled  map[string]bool       `json:"enabled"` // Name -> effective state, for quick lookups
+	Features []*domain.FeatureFlag `json:"features"`
+}
+
+// HandleListFeatures lists the effective feature flags of this deployment so
+// clients can adapt to optional capabilities instead of probing endpoints.
+// GET /api/v1/features
+func (h *Handler) HandleListFeatures(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	overrides, err := h.repos.FeatureFlag.List(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list feature flags", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list feature flags")
+		return
+	}
+
+	flags := domain.ResolveFeatureFlags(h.features, overrides)
+	enabled := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		enabled[flag.Name] = flag.Enabled
+	}
+
+	writeJSON(w, http.StatusOK, ListFeaturesResponse{
+		Enabled:  enabled,
+		Features: flags,
+	})
+}
+
+// SetFeatureRequest represents the request body for overriding a feature flag.
+type SetFeatureRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// FeatureResponse represents the response for a single feature flag.
+type FeatureResponse struct {
+	Feature *domain.FeatureFlag `json:"feature"`
+}
+
+// isKnownFeature returns true if the feature is built in or configured.
+func (h *Handler) isKnownFeature(name string) bool {
+	_, configured := h.features[name]
+	return configured || slices.Contains(domain.KnownFeatures, name)
+}
+
+// HandleSetFeature overrides a feature flag at runtime.
+// PUT /api/v1/features/:name
+func (h *Handler) HandleSetFeature(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	name := extractID(r.URL.Path, "/api/v1/features/")
+	if !domain.IsValidFeatureName(name) || !h.isKnownFeature(name) {
+		writeError(w, http.StatusNotFound, domain.ErrNotFound, "unknown feature")
+		return
+	}
+
+	var req SetFeatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+	if req.Enabled == nil {
+		writeError(w, http.StatusBadRequest, domain.ErrInvalidInput, "enabled is required")
+		return
+	}
+
+	override := &domain.FeatureFlagOverride{
+		Name:      name,
+		Enabled:   *req.Enabled,
+		UpdatedBy: requestOwner(r),
+	}
+	if err := h.repos.FeatureFlag.Upsert(r.Context(), override); err != nil {
+		h.logger.Error("Failed to set feature flag", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to set feature flag")
+		return
+	}
+
+	h.logger.Info("Feature flag overridden",
+		zap.String("feature", name),
+		zap.Bool("enabled", override.Enabled),
+		zap.String("updated_by", override.UpdatedBy),
+	)
+
+	writeJSON(w, http.StatusOK, FeatureResponse{Feature: &domain.FeatureFlag{
+		Name:      override.Name,
+		Enabled:   override.Enabled,
+		Source:    domain.FeatureSourceOverride,
+		UpdatedBy: &override.UpdatedBy,
+		UpdatedAt: &override.UpdatedAt,
+	}})
+}
+
+// HandleDeleteFeature removes a runtime override, reverting the flag to its
+// configured value.
+// DELETE /api/v1/features/:name
+func (h *Handler) HandleDeleteFeature(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	name := extractID(r.URL.Path, "/api/v1/features/")
+	if !domain.IsValidFeatureName(name) {
+		writeError(w, http.StatusBadRequest, domain.ErrInvalidInput, "invalid feature name")
+		return
+	}
+
+	if err := h.repos.FeatureFlag.Delete(r.Context(), name); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "feature flag override not found")
+			return
+		}
+		h.logger.Error("Failed to delete feature flag", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to delete feature flag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
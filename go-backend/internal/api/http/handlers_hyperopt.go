@@ -0,0 +1,270 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Hyperopt Handlers
+// ============================================================================
+
+// HyperoptRunnerInterface defines the hyperopt runner operations the
+// handlers need.
+type HyperoptRunnerInterface interface {
+	CancelRunning(id uuid.UUID) bool
+}
+
+// SubmitHyperoptRequest represents the request body for submitting a
+// hyperopt job.
+type SubmitHyperoptRequest struct {
+	StrategyID          string                `json:"strategy_id"`
+	Config              domain.HyperoptConfig `json:"config"`
+	SkipValidationCheck bool                  `json:"skip_validation_check"` // Submit a strategy that failed validation anyway
+}
+
+// SubmitHyperoptResponse represents the response for submitting a hyperopt job.
+type SubmitHyperoptResponse struct {
+	Job      *domain.HyperoptJob `json:"job"`
+	Warnings []string            `json:"warnings,omitempty"`
+}
+
+// ListHyperoptJobsResponse represents the response for listing hyperopt jobs.
+type ListHyperoptJobsResponse struct {
+	Jobs []*domain.HyperoptJob `json:"jobs"`
+}
+
+// HandleHyperoptJobs lists hyperopt jobs, newest first, or submits one. A
+// job runs Freqtrade's hyperopt over the strategy's hyperoptable parameters;
+// once completed, its result holds the best epoch and a params_patch that
+// POST /api/v1/strategies/:id/params writes back as a child strategy.
+// GET  /api/v1/hyperopt?strategy_id=...&status=running&limit=50
+// POST /api/v1/hyperopt
+func (h *Handler) HandleHyperoptJobs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.handleListHyperoptJobs(w, r)
+	case http.MethodPost:
+		h.handleSubmitHyperopt(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+	}
+}
+
+func (h *Handler) handleListHyperoptJobs(w http.ResponseWriter, r *http.Request) {
+	query := domain.HyperoptJobQuery{Limit: 50}
+	if v := r.URL.Query().Get("strategy_id"); v != "" {
+		id, err := parseUUID(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid strategy_id")
+			return
+		}
+		query.StrategyID = &id
+	}
+	if v := r.URL.Query().Get("status"); v != "" {
+		status := domain.JobStatus(v)
+		if !status.IsValid() {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("unknown status %q", v), "")
+			return
+		}
+		query.Status = &status
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 200 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be between 1 and 200"), "")
+			return
+		}
+		query.Limit = n
+	}
+
+	jobs, err := h.repos.Hyperopt.List(r.Context(), query)
+	if err != nil {
+		h.logger.Error("Failed to list hyperopt jobs", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list hyperopt jobs")
+		return
+	}
+	if jobs == nil {
+		jobs = []*domain.HyperoptJob{}
+	}
+
+	writeJSON(w, http.StatusOK, ListHyperoptJobsResponse{Jobs: jobs})
+}
+
+func (h *Handler) handleSubmitHyperopt(w http.ResponseWriter, r *http.Request) {
+	if h.hyperopt == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("hyperopt is disabled"), "")
+		return
+	}
+
+	var req SubmitHyperoptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	strategyID, err := parseUUID(req.StrategyID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy_id")
+		return
+	}
+
+	job := domain.NewHyperoptJob(strategyID, requestOwner(r), req.Config)
+	if err := job.Config.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid hyperopt config")
+		return
+	}
+	if h.maxEpochs > 0 && job.Config.Epochs > h.maxEpochs {
+		err := fmt.Errorf("%w: epochs must be at most %d", domain.ErrInvalidInput, h.maxEpochs)
+		writeError(w, http.StatusBadRequest, err, "invalid hyperopt config")
+		return
+	}
+
+	// Save a container from crashing on a strategy that is known not to load
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), strategyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+	var warnings []string
+	warning, err := strategy.CheckSubmittable(req.SkipValidationCheck)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err, "strategy failed validation; set skip_validation_check to submit anyway")
+		return
+	}
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	if err := h.repos.Hyperopt.Create(r.Context(), job); err != nil {
+		h.logger.Error("Failed to create hyperopt job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to create hyperopt job")
+		return
+	}
+
+	h.logger.Info("Submitted hyperopt job",
+		zap.String("job_id", job.ID.String()),
+		zap.String("strategy_id", strategyID.String()),
+		zap.Int("epochs", job.Config.Epochs),
+		zap.String("loss", job.Config.Loss),
+	)
+
+	writeJSON(w, http.StatusCreated, SubmitHyperoptResponse{Job: job, Warnings: warnings})
+}
+
+// HandleGetHyperoptJob returns a hyperopt job with its result once completed.
+// GET /api/v1/hyperopt/:id
+func (h *Handler) HandleGetHyperoptJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/hyperopt/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid hyperopt job id")
+		return
+	}
+
+	job, err := h.repos.Hyperopt.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "hyperopt job not found")
+			return
+		}
+		h.logger.Error("Failed to get hyperopt job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get hyperopt job")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// HandleCancelHyperoptJob cancels a pending or running hyperopt job,
+// stopping its container if it is running.
+// POST /api/v1/hyperopt/:id/cancel
+func (h *Handler) HandleCancelHyperoptJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/hyperopt/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid hyperopt job id")
+		return
+	}
+
+	job, err := h.repos.Hyperopt.Cancel(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "hyperopt job not found")
+			return
+		}
+		if errors.Is(err, domain.ErrConflict) {
+			writeError(w, http.StatusConflict, err, "hyperopt job already finished")
+			return
+		}
+		h.logger.Error("Failed to cancel hyperopt job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to cancel hyperopt job")
+		return
+	}
+
+	stopped := false
+	if job.Status == domain.JobStatusRunning && h.hyperopt != nil {
+		stopped = h.hyperopt.CancelRunning(id)
+	}
+	job.Status = domain.JobStatusCancelled
+
+	h.logger.Info("Cancelled hyperopt job",
+		zap.String("job_id", id.String()),
+		zap.Bool("container_stopped", stopped),
+	)
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// HandleStrategyHyperopt returns a strategy's best hyperopt result: the
+// completed job whose best epoch has the lowest loss.
+// GET /api/v1/strategies/:id/hyperopt
+func (h *Handler) HandleStrategyHyperopt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID from path like /api/v1/strategies/:id/hyperopt
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	id, err := parseUUID(strings.TrimSuffix(path, "/hyperopt"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+
+	job, err := h.repos.Hyperopt.GetBest(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "no completed hyperopt job for strategy")
+			return
+		}
+		h.logger.Error("Failed to get best hyperopt job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get best hyperopt job")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Incident Handlers
+// ============================================================================
+
+// openIncidentsLimit caps the open incidents reported by /health and the
+// dashboard summary.
+const openIncidentsLimit = 20
+
+// CreateIncidentRequest represents a request to open an incident.
+type CreateIncidentRequest struct {
+	Title    string                  `json:"title"`
+	Severity domain.IncidentSeverity `json:"severity"`
+	Note     string                  `json:"note,omitempty"` // Optional first note
+}
+
+// IncidentNoteRequest represents a note added to an incident, or the
+// optional note an incident is closed with.
+type IncidentNoteRequest struct {
+	Note string `json:"note"`
+}
+
+// ListIncidentsResponse represents the response for listing incidents.
+type ListIncidentsResponse struct {
+	Incidents []*domain.Incident `json:"incidents"`
+}
+
+// HandleIncidents lists incidents, newest first, or opens one.
+// GET /api/v1/incidents?status=open&limit=50
+// POST /api/v1/incidents
+func (h *Handler) HandleIncidents(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		query := domain.IncidentListQuery{Limit: 50}
+		if v := r.URL.Query().Get("status"); v != "" {
+			status := domain.IncidentStatus(v)
+			if !status.IsValid() {
+				writeError(w, http.StatusBadRequest, errors.New("status must be open or closed"), "")
+				return
+			}
+			query.Status = &status
+		}
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 || n > 200 {
+				writeError(w, http.StatusBadRequest, errors.New("limit must be between 1 and 200"), "")
+				return
+			}
+			query.Limit = n
+		}
+
+		incidents, err := h.repos.Incident.List(r.Context(), query)
+		if err != nil {
+			h.logger.Error("Failed to list incidents", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to list incidents")
+			return
+		}
+		if incidents == nil {
+			incidents = []*domain.Incident{}
+		}
+
+		writeJSON(w, http.StatusOK, ListIncidentsResponse{Incidents: incidents})
+	case http.MethodPost:
+		var req CreateIncidentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+
+		owner := requestOwner(r)
+		incident := domain.NewIncident(req.Title, req.Severity, domain.IncidentSourceManual, owner)
+		if err := incident.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid incident")
+			return
+		}
+		if strings.TrimSpace(req.Note) != "" {
+			note := domain.NewIncidentNote(incident.ID, owner, req.Note)
+			if err := note.Validate(); err != nil {
+				writeError(w, http.StatusBadRequest, err, "invalid note")
+				return
+			}
+			incident.Notes = []domain.IncidentNote{*note}
+		}
+
+		if err := h.repos.Incident.Create(r.Context(), incident); err != nil {
+			h.logger.Error("Failed to create incident", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to create incident")
+			return
+		}
+
+		h.logger.Warn("Incident opened",
+			zap.String("incident_id", incident.ID.String()),
+			zap.String("severity", string(incident.Severity)),
+			zap.String("title", incident.Title),
+			zap.String("opened_by", owner),
+		)
+		writeJSON(w, http.StatusCreated, incident)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+	}
+}
+
+// HandleIncident returns an incident with its notes or changes its title or
+// severity.
+// GET /api/v1/incidents/:id
+// PATCH /api/v1/incidents/:id
+func (h *Handler) HandleIncident(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/incidents/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid incident id")
+		return
+	}
+
+	var incident *domain.Incident
+	action := "get"
+	switch r.Method {
+	case http.MethodGet:
+		incident, err = h.repos.Incident.GetByID(r.Context(), id)
+	case http.MethodPatch:
+		action = "update"
+		var update domain.IncidentUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+		if err := update.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid incident update")
+			return
+		}
+		incident, err = h.repos.Incident.Update(r.Context(), id, update)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "incident not found")
+			return
+		}
+		h.logger.Error("Failed to "+action+" incident", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to "+action+" incident")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, incident)
+}
+
+// HandleAddIncidentNote adds a note to an incident, open or closed.
+// POST /api/v1/incidents/:id/notes
+func (h *Handler) HandleAddIncidentNote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/incidents/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid incident id")
+		return
+	}
+
+	var req IncidentNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+	note := domain.NewIncidentNote(id, requestOwner(r), req.Note)
+	if err := note.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid note")
+		return
+	}
+
+	if err := h.repos.Incident.AddNote(r.Context(), note); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "incident not found")
+			return
+		}
+		h.logger.Error("Failed to add incident note", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to add incident note")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, note)
+}
+
+// HandleCloseIncident closes an open incident with an optional note.
+// POST /api/v1/incidents/:id/close
+func (h *Handler) HandleCloseIncident(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/incidents/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid incident id")
+		return
+	}
+
+	var req IncidentNoteRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+	}
+
+	owner := requestOwner(r)
+	var note *domain.IncidentNote
+	if strings.TrimSpace(req.Note) != "" {
+		note = domain.NewIncidentNote(id, owner, req.Note)
+		if err := note.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid note")
+			return
+		}
+	}
+
+	incident, err := h.repos.Incident.Close(r.Context(), id, owner, note)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, err, "incident not found")
+		case errors.Is(err, domain.ErrConflict):
+			writeError(w, http.StatusConflict, err, "")
+		default:
+			h.logger.Error("Failed to close incident", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to close incident")
+		}
+		return
+	}
+
+	h.logger.Info("Incident closed",
+		zap.String("incident_id", incident.ID.String()),
+		zap.String("closed_by", owner),
+	)
+	writeJSON(w, http.StatusOK, incident)
+}
+
+// openIncidents returns the open incidents for /health and the dashboard,
+// or nil if none are open or they cannot be loaded.
+func (h *Handler) openIncidents(ctx context.Context) []*domain.Incident {
+	if h.repos == nil || h.repos.Incident == nil {
+		return nil
+	}
+
+	open := domain.IncidentStatusOpen
+	incidents, err := h.repos.Incident.List(ctx, domain.IncidentListQuery{Status: &open, Limit: openIncidentsLimit})
+	if err != nil {
+		h.logger.Warn("Failed to get open incidents", zap.Error(err))
+		return nil
+	}
+	return incidents
+}
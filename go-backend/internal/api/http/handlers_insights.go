@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Insight Handlers
+// ============================================================================
+
+// ListInsightsResponse represents the response for listing insights.
+type ListInsightsResponse struct {
+	Insights []*domain.InsightTemplate `json:"insights"`
+}
+
+// HandleListInsights lists the insight templates that can be run, from config
+// and the database.
+// GET /api/v1/insights
+func (h *Handler) HandleListInsights(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+	if h.insights == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("insights are disabled"), "")
+		return
+	}
+
+	templates, err := h.insights.List(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list insights", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list insights")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListInsightsResponse{Insights: templates})
+}
+
+// HandleRunInsight runs an insight template read-only with its query
+// parameters bound to the template's parameters. The reserved limit parameter
+// lowers the row limit.
+// GET /api/v1/insights/:name?since=2024-01-15T00:00:00Z&limit=50
+func (h *Handler) HandleRunInsight(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+	if h.insights == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("insights are disabled"), "")
+		return
+	}
+
+	name := extractID(r.URL.Path, "/api/v1/insights/")
+	result, err := h.insights.Run(r.Context(), name, r.URL.Query())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, err, "insight not found")
+		case errors.Is(err, domain.ErrInvalidInput):
+			writeError(w, http.StatusBadRequest, err, "invalid insight parameters")
+		case errors.Is(err, domain.ErrInsightTimeout):
+			writeError(w, http.StatusGatewayTimeout, err, "insight query exceeded its time limit")
+		default:
+			h.logger.Error("Failed to run insight", zap.String("insight", name), zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to run insight")
+		}
+		return
+	}
+
+	h.logger.Info("Insight run",
+		zap.String("insight", name),
+		zap.Int("rows", result.RowCount),
+		zap.Bool("truncated", result.Truncated),
+		zap.Int64("duration_ms", result.DurationMs),
+	)
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Iteration Artifact Handlers
+// ============================================================================
+
+// iterationFromArtifactPath extracts the run ID and iteration number from
+// /api/v1/optimizations/:id/iterations/:n/artifacts.
+func iterationFromArtifactPath(path string) (uuid.UUID, int, error) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api/v1/optimizations/"), "/"), "/")
+	if len(parts) != 4 || parts[1] != "iterations" || parts[3] != "artifacts" {
+		return uuid.Nil, 0, errors.New("not found")
+	}
+
+	runID, err := parseUUID(parts[0])
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+	number, err := strconv.Atoi(parts[2])
+	if err != nil || number < 1 {
+		return uuid.Nil, 0, errors.New("iteration number must be a positive integer")
+	}
+	return runID, number, nil
+}
+
+// HandleIterationArtifacts stores or lists the files behind an optimization
+// iteration, such as the LLM prompt and raw response that produced its
+// generation, so prompting can be audited after the fact. Content is
+// downloaded through the artifact endpoints.
+// GET  /api/v1/optimizations/:id/iterations/:n/artifacts
+// POST /api/v1/optimizations/:id/iterations/:n/artifacts
+func (h *Handler) HandleIterationArtifacts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	runID, number, err := iterationFromArtifactPath(r.URL.Path)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid iteration path")
+		return
+	}
+
+	iterations, err := h.repos.Optimization.GetIterations(r.Context(), runID)
+	if err != nil {
+		h.logger.Error("Failed to get optimization iterations", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get iterations")
+		return
+	}
+	var iteration *domain.OptimizationIteration
+	for _, iter := range iterations {
+		if iter.IterationNumber == number {
+			iteration = iter
+		}
+	}
+	if iteration == nil {
+		writeError(w, http.StatusNotFound, domain.NewNotFoundError("optimization_iteration", strconv.Itoa(number)), "iteration not found")
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		artifacts, err := h.repos.Artifact.ListByOwner(r.Context(), domain.ArtifactOwnerIteration, iteration.ID)
+		if err != nil {
+			h.logger.Error("Failed to list iteration artifacts", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to list artifacts")
+			return
+		}
+		writeJSON(w, http.StatusOK, ListArtifactsResponse{Artifacts: artifacts})
+		return
+	}
+
+	var req CreateArtifactRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxArtifactRequestSize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	artifact := domain.NewArtifact(domain.ArtifactOwnerIteration, iteration.ID, domain.ArtifactKind(req.Kind), req.Name, req.ContentType, req.Content)
+	artifact.SourceURL = req.SourceURL
+	artifact.Metadata = req.Metadata
+	if err := artifact.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid artifact")
+		return
+	}
+
+	if err := h.repos.Artifact.Create(r.Context(), artifact); err != nil {
+		h.logger.Error("Failed to store iteration artifact", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to store artifact")
+		return
+	}
+
+	h.logger.Debug("Stored iteration artifact",
+		zap.String("run_id", runID.String()),
+		zap.Int("iteration", number),
+		zap.String("artifact_id", artifact.ID.String()),
+		zap.String("kind", artifact.Kind.String()),
+		zap.Int64("size_bytes", artifact.SizeBytes),
+	)
+
+	writeJSON(w, http.StatusCreated, CreateArtifactResponse{Artifact: artifact})
+}
+
+// attachIterationArtifacts sets the artifacts of each iteration, without
+// their content.
+func (h *Handler) attachIterationArtifacts(ctx context.Context, iterations []*domain.OptimizationIteration) error {
+	ids := make([]uuid.UUID, len(iterations))
+	byID := make(map[uuid.UUID]*domain.OptimizationIteration, len(iterations))
+	for i, iter := range iterations {
+		ids[i] = iter.ID
+		byID[iter.ID] = iter
+	}
+
+	artifacts, err := h.repos.Artifact.ListByOwners(ctx, domain.ArtifactOwnerIteration, ids)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range artifacts {
+		if iter, ok := byID[artifact.OwnerID]; ok {
+			iter.Artifacts = append(iter.Artifacts, artifact)
+		}
+	}
+	return nil
+}
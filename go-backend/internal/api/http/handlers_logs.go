@@ -0,0 +1,197 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/parser"
+	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
+)
+
+// ============================================================================
+// Job Log Handlers
+// ============================================================================
+
+// ContainerLogStreamer streams the output of a running job's container.
+type ContainerLogStreamer interface {
+	StreamContainerLogs(ctx context.Context, containerID string, follow bool, w io.Writer) error
+}
+
+// HandleGetBacktestJobLogs returns a job's container output as plain text.
+// A running job's output is read from its container, and with follow=true
+// streamed as the container writes it until the job finishes or the client
+// goes away. A finished job's output is the log stored with it: the whole
+// log of a completed job, what a cancelled job's container wrote until it
+// was stopped, or the tail kept with a failed job's error.
+// GET /api/v1/backtests/:id/logs?follow=true
+func (h *Handler) HandleGetBacktestJobLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/backtests/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid job id")
+		return
+	}
+
+	follow := false
+	if v := r.URL.Query().Get("follow"); v != "" {
+		follow, err = strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid follow")
+			return
+		}
+	}
+
+	job, err := h.repos.BacktestJob.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "job not found")
+			return
+		}
+		h.logger.Error("Failed to get backtest job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job")
+		return
+	}
+
+	if job.Status == domain.JobStatusRunning && job.ContainerID != nil && *job.ContainerID != "" && h.containerLogs != nil {
+		if h.streamContainerLogs(w, r, *job.ContainerID, follow) {
+			return
+		}
+
+		// The container is gone; the job most likely finished meanwhile
+		job, err = h.repos.BacktestJob.GetByID(r.Context(), id)
+		if err != nil {
+			h.logger.Error("Failed to get backtest job", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to get job")
+			return
+		}
+	}
+
+	if !job.Status.IsTerminal() {
+		writeError(w, http.StatusConflict, fmt.Errorf("job is %s", job.Status), "job has no log yet")
+		return
+	}
+
+	logs, err := h.storedJobLog(r.Context(), job)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "no log stored for job")
+			return
+		}
+		h.logger.Error("Failed to get job log", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job log")
+		return
+	}
+
+	setLogHeaders(w)
+	io.WriteString(w, logs)
+}
+
+// streamContainerLogs writes a container's output to the response, flushing
+// as it goes. It reports whether it responded; it does not if the container
+// could not be read before anything was written.
+func (h *Handler) streamContainerLogs(w http.ResponseWriter, r *http.Request, containerID string, follow bool) bool {
+	rc := http.NewResponseController(w)
+	if follow {
+		// Following outlives the server's write timeout
+		rc.SetWriteDeadline(time.Time{})
+	}
+
+	setLogHeaders(w)
+	out := &flushWriter{w: w, rc: rc}
+	err := h.containerLogs.StreamContainerLogs(r.Context(), containerID, follow, out)
+	if err != nil && !out.wrote {
+		h.logger.Debug("Failed to read container logs",
+			zap.String("container_id", containerID),
+			zap.Error(err),
+		)
+		return false
+	}
+	if err != nil && r.Context().Err() == nil {
+		h.logger.Warn("Container log stream interrupted",
+			zap.String("container_id", containerID),
+			zap.Error(err),
+		)
+	}
+	return true
+}
+
+// storedJobLog returns the log stored with a finished job.
+func (h *Handler) storedJobLog(ctx context.Context, job *domain.BacktestJob) (string, error) {
+	switch job.Status {
+	case domain.JobStatusCompleted:
+		result, err := h.repos.Result.GetByJobID(ctx, job.ID)
+		if err != nil {
+			return "", err
+		}
+		raw, err := h.repos.Result.GetRawLog(ctx, result.ID)
+		if err != nil {
+			return "", err
+		}
+		return parser.DecompressLog(raw)
+
+	case domain.JobStatusCancelled:
+		artifacts, err := h.repos.Artifact.ListByOwner(ctx, domain.ArtifactOwnerJob, job.ID)
+		if err != nil {
+			return "", err
+		}
+		for _, artifact := range artifacts {
+			if artifact.Kind == domain.ArtifactKindLog && artifact.Name == scheduler.PartialLogName {
+				artifact, err := h.repos.Artifact.GetContent(ctx, artifact.ID)
+				if err != nil {
+					return "", err
+				}
+				return string(artifact.Content), nil
+			}
+		}
+
+	case domain.JobStatusFailed:
+		if job.ErrorMessage != nil {
+			if _, logs, ok := strings.Cut(*job.ErrorMessage, scheduler.ContainerLogsMarker); ok {
+				return logs, nil
+			}
+		}
+	}
+
+	return "", domain.NewNotFoundError("job log", job.ID.String())
+}
+
+// setLogHeaders marks a response as a plain-text log.
+func setLogHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+// flushWriter flushes every write to the client, so streamed output shows
+// up as it is produced.
+type flushWriter struct {
+	w     io.Writer
+	rc    *http.ResponseController
+	wrote bool
+}
+
+// Write writes and flushes p.
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if n > 0 {
+		f.wrote = true
+	}
+	if err != nil {
+		return n, err
+	}
+	f.rc.Flush()
+	return n, nil
+}
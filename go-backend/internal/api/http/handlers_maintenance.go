@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// MaintenanceInterface defines the interface for maintenance mode operations.
+type MaintenanceInterface interface {
+	Current() domain.MaintenanceMode
+	Set(ctx context.Context, enabled bool, message, by string) (*domain.MaintenanceMode, error)
+}
+
+// ============================================================================
+// Maintenance Mode Handlers
+// ============================================================================
+
+// SetMaintenanceRequest represents a request to enable or disable maintenance mode.
+type SetMaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+}
+
+// HandleMaintenance reports or changes the system-wide maintenance mode.
+// While it is enabled, submissions are rejected with 503 and the schedulers
+// of every instance are paused; reads keep working.
+// GET /api/v1/admin/maintenance
+// PUT /api/v1/admin/maintenance
+func (h *Handler) HandleMaintenance(w http.ResponseWriter, r *http.Request) {
+	if h.maintenance == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("maintenance mode not available"), "")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, h.maintenance.Current())
+	case http.MethodPut:
+		var req SetMaintenanceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+
+		mode, err := h.maintenance.Set(r.Context(), req.Enabled, req.Message, requestOwner(r))
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidInput) {
+				writeError(w, http.StatusBadRequest, err, "")
+				return
+			}
+			h.logger.Error("Failed to set maintenance mode", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to set maintenance mode")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, mode)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+	}
+}
+
+// currentMaintenance returns the maintenance mode for responses, or nil if
+// it is not available.
+func (h *Handler) currentMaintenance() *domain.MaintenanceMode {
+	if h.maintenance == nil {
+		return nil
+	}
+	mode := h.maintenance.Current()
+	return &mode
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Market Data Handlers
+// ============================================================================
+
+// MarketDataInventoryInterface defines the market data listing the handlers
+// need.
+type MarketDataInventoryInterface interface {
+	List(filter domain.MarketDataFilter) ([]domain.MarketDataSet, error)
+}
+
+// DataDownloaderInterface defines the market data download operations the
+// handlers need.
+type DataDownloaderInterface interface {
+	Start(req domain.DataDownloadRequest, requestedBy string) *domain.DataDownload
+	Get(id uuid.UUID) (*domain.DataDownload, error)
+	List() []*domain.DataDownload
+}
+
+// ListMarketDataResponse represents the response for listing market data.
+type ListMarketDataResponse struct {
+	Data []domain.MarketDataSet `json:"data"`
+}
+
+// ListDataDownloadsResponse represents the response for listing market data
+// downloads.
+type ListDataDownloadsResponse struct {
+	Downloads []*domain.DataDownload `json:"downloads"`
+}
+
+// HandleListMarketData lists the candle data in the market data directory,
+// one entry per exchange, pair, timeframe and candle type.
+// GET /api/v1/data?exchange=binance&pair=BTC/USDT:USDT&timeframe=5m&trading_mode=futures
+func (h *Handler) HandleListMarketData(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+	if h.marketData == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("market data is not available"), "")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := domain.MarketDataFilter{
+		Exchange:    q.Get("exchange"),
+		Pair:        q.Get("pair"),
+		Timeframe:   q.Get("timeframe"),
+		TradingMode: q.Get("trading_mode"),
+	}
+	if filter.TradingMode != "" && filter.TradingMode != domain.TradingModeSpot && filter.TradingMode != domain.TradingModeFutures {
+		writeError(w, http.StatusBadRequest, errors.New("trading_mode must be spot or futures"), "")
+		return
+	}
+
+	data, err := h.marketData.List(filter)
+	if err != nil {
+		h.logger.Error("Failed to list market data", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list market data")
+		return
+	}
+	if data == nil {
+		data = []domain.MarketDataSet{}
+	}
+
+	writeJSON(w, http.StatusOK, ListMarketDataResponse{Data: data})
+}
+
+// HandleDataDownloads lists the running and recent market data downloads,
+// newest first, or starts one. A download runs Freqtrade's download-data in
+// a container; it is accepted at once and polled until finished. Requesting
+// the same data as a running download returns that download.
+// GET  /api/v1/data/downloads
+// POST /api/v1/data/downloads
+func (h *Handler) HandleDataDownloads(w http.ResponseWriter, r *http.Request) {
+	if h.downloader == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("market data downloads are disabled"), "")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, ListDataDownloadsResponse{Downloads: h.downloader.List()})
+	case http.MethodPost:
+		var req domain.DataDownloadRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+		if err := req.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid download request")
+			return
+		}
+
+		download := h.downloader.Start(req, "api")
+		h.logger.Info("Market data download requested",
+			zap.String("download_id", download.ID.String()),
+			zap.String("exchange", req.Exchange),
+			zap.Strings("pairs", req.Pairs),
+		)
+		writeJSON(w, http.StatusAccepted, download)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+	}
+}
+
+// HandleGetDataDownload returns a market data download.
+// GET /api/v1/data/downloads/:id
+func (h *Handler) HandleGetDataDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+	if h.downloader == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("market data downloads are disabled"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/data/downloads/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid download id")
+		return
+	}
+
+	download, err := h.downloader.Get(id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "download not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err, "failed to get download")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, download)
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// User Preference Handlers
+// ============================================================================
+
+// userIDHeader identifies the user or token that owns preferences and stars.
+const userIDHeader = "X-User-ID"
+
+// requestOwner returns the owner (user or token) for per-user state such as preferences and stars.
+func requestOwner(r *http.Request) string {
+	if owner := strings.TrimSpace(r.Header.Get(userIDHeader)); owner != "" {
+		return owner
+	}
+	return domain.DefaultPreferenceOwner
+}
+
+// ListPreferencesResponse represents the response for listing preferences.
+type ListPreferencesResponse struct {
+	Owner       string                   `json:"owner"`
+	Preferences []*domain.UserPreference `json:"preferences"`
+}
+
+// HandleListPreferences lists all preferences for the requesting user.
+// GET /api/v1/preferences
+func (h *Handler) HandleListPreferences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	owner := requestOwner(r)
+	prefs, err := h.repos.Preference.List(r.Context(), owner)
+	if err != nil {
+		h.logger.Error("Failed to list preferences", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list preferences")
+		return
+	}
+
+	if prefs == nil {
+		prefs = []*domain.UserPreference{}
+	}
+
+	writeJSON(w, http.StatusOK, ListPreferencesResponse{
+		Owner:       owner,
+		Preferences: prefs,
+	})
+}
+
+// PreferenceResponse represents the response for a single preference.
+type PreferenceResponse struct {
+	Preference *domain.UserPreference `json:"preference"`
+}
+
+// HandleGetPreference retrieves a single preference by key.
+// GET /api/v1/preferences/:key
+func (h *Handler) HandleGetPreference(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	key := extractID(r.URL.Path, "/api/v1/preferences/")
+	if !domain.IsValidPreferenceKey(key) {
+		writeError(w, http.StatusBadRequest, domain.ErrInvalidInput, "invalid preference key")
+		return
+	}
+
+	pref, err := h.repos.Preference.Get(r.Context(), requestOwner(r), key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "preference not found")
+			return
+		}
+		h.logger.Error("Failed to get preference", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get preference")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PreferenceResponse{Preference: pref})
+}
+
+// SetPreferenceRequest represents the request body for setting a preference.
+type SetPreferenceRequest struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// HandleSetPreference creates or replaces a preference.
+// PUT /api/v1/preferences/:key
+func (h *Handler) HandleSetPreference(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	key := extractID(r.URL.Path, "/api/v1/preferences/")
+
+	var req SetPreferenceRequest
+	body := http.MaxBytesReader(w, r.Body, domain.MaxPreferenceValueSize+1024)
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	pref := domain.NewUserPreference(requestOwner(r), key, req.Value)
+	if err := pref.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid preference")
+		return
+	}
+
+	if err := h.repos.Preference.Upsert(r.Context(), pref); err != nil {
+		h.logger.Error("Failed to set preference", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to set preference")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PreferenceResponse{Preference: pref})
+}
+
+// HandleDeletePreference removes a preference.
+// DELETE /api/v1/preferences/:key
+func (h *Handler) HandleDeletePreference(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	key := extractID(r.URL.Path, "/api/v1/preferences/")
+	if !domain.IsValidPreferenceKey(key) {
+		writeError(w, http.StatusBadRequest, domain.ErrInvalidInput, "invalid preference key")
+		return
+	}
+
+	if err := h.repos.Preference.Delete(r.Context(), requestOwner(r), key); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "preference not found")
+			return
+		}
+		h.logger.Error("Failed to delete preference", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to delete preference")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Result Comparison Handlers
+// ============================================================================
+
+// HandleGetBacktestVsParent compares a job's result with the best result of
+// its strategy's parent under a comparable config (same exchange, pairs,
+// timeframe, timerange, trading mode and account settings), returning the
+// delta of each metric and whether it improved.
+// GET /api/v1/backtests/:id/vs-parent
+func (h *Handler) HandleGetBacktestVsParent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	idStr := extractID(r.URL.Path, "/api/v1/backtests/")
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid job id")
+		return
+	}
+
+	job, err := h.repos.BacktestJob.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "job not found")
+			return
+		}
+		h.logger.Error("Failed to get backtest job", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get job")
+		return
+	}
+
+	result, err := h.repos.Result.GetByJobID(r.Context(), job.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusConflict, err, "job has no result")
+			return
+		}
+		h.logger.Error("Failed to get backtest result", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get result")
+		return
+	}
+
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), job.StrategyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+	if strategy.ParentID == nil {
+		writeError(w, http.StatusNotFound, errors.New("strategy has no parent"), "")
+		return
+	}
+
+	cfg := job.Config.Comparable()
+	parent, err := h.repos.Result.GetBestComparableByStrategyID(r.Context(), *strategy.ParentID, cfg)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "parent strategy has no result under a comparable config")
+			return
+		}
+		h.logger.Error("Failed to get parent result", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get parent result")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.NewParentResultComparison(result, parent, cfg))
+}
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Strategy Re-validation Handlers
+// ============================================================================
+
+// CreateRevalidationRequest represents a request to re-validate strategies
+// against a Freqtrade image.
+type CreateRevalidationRequest struct {
+	Image       string      `json:"image,omitempty"` // Defaults to the backtest image
+	StrategyIDs []uuid.UUID `json:"strategy_ids"`
+}
+
+// ListRevalidationsResponse represents the response for listing re-validation runs.
+type ListRevalidationsResponse struct {
+	Runs []*domain.RevalidationRun `json:"runs"`
+}
+
+// HandleRevalidations lists recent re-validation runs or starts one, which
+// validates the selected strategies against an image, e.g. after upgrading
+// the Freqtrade base image, through the validation pool.
+// GET /api/v1/admin/revalidations?limit=20
+// POST /api/v1/admin/revalidations
+func (h *Handler) HandleRevalidations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		limit := 20
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 || n > 100 {
+				writeError(w, http.StatusBadRequest, errors.New("limit must be between 1 and 100"), "")
+				return
+			}
+			limit = n
+		}
+
+		runs, err := h.repos.Revalidation.List(r.Context(), limit)
+		if err != nil {
+			h.logger.Error("Failed to list revalidations", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to list revalidations")
+			return
+		}
+		if runs == nil {
+			runs = []*domain.RevalidationRun{}
+		}
+
+		writeJSON(w, http.StatusOK, ListRevalidationsResponse{Runs: runs})
+	case http.MethodPost:
+		var req CreateRevalidationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+		if req.Image == "" {
+			req.Image = h.defaultImage
+		}
+
+		run := domain.NewRevalidationRun(req.Image, req.StrategyIDs, requestOwner(r))
+		if err := run.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid revalidation")
+			return
+		}
+
+		if err := h.repos.Revalidation.Create(r.Context(), run); err != nil {
+			h.logger.Error("Failed to create revalidation", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to create revalidation")
+			return
+		}
+
+		h.logger.Info("Revalidation requested",
+			zap.String("revalidation_id", run.ID.String()),
+			zap.String("image", run.Image),
+			zap.Int("strategies", run.Total()),
+			zap.String("requested_by", run.RequestedBy),
+		)
+		writeJSON(w, http.StatusAccepted, run)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+	}
+}
+
+// HandleGetRevalidation returns the status and progress of a re-validation run.
+// GET /api/v1/admin/revalidations/:id
+func (h *Handler) HandleGetRevalidation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/admin/revalidations/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid revalidation id")
+		return
+	}
+
+	run, err := h.repos.Revalidation.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "revalidation not found")
+			return
+		}
+		h.logger.Error("Failed to get revalidation", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get revalidation")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, run)
+}
+
+// HandleGetCompatibilityMatrix reports whether each strategy was valid on
+// each image when last validated, with per-image counts. The strategies are
+// those of a re-validation run or listed explicitly; images default to every
+// image they were validated on.
+// GET /api/v1/admin/revalidations/matrix?run_id=...
+// GET /api/v1/admin/revalidations/matrix?strategy_ids=a,b&images=x,y
+func (h *Handler) HandleGetCompatibilityMatrix(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	queryParams := r.URL.Query()
+	images := splitCSV(queryParams.Get("images"))
+
+	var strategyIDs []uuid.UUID
+	if v := queryParams.Get("run_id"); v != "" {
+		runID, err := parseUUID(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid run_id parameter")
+			return
+		}
+		run, err := h.repos.Revalidation.GetByID(r.Context(), runID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				writeError(w, http.StatusNotFound, err, "revalidation not found")
+				return
+			}
+			h.logger.Error("Failed to get revalidation", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to get revalidation")
+			return
+		}
+		strategyIDs = run.StrategyIDs
+	}
+	for _, part := range splitCSV(queryParams.Get("strategy_ids")) {
+		id, err := parseUUID(strings.TrimSpace(part))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid strategy_ids parameter")
+			return
+		}
+		strategyIDs = append(strategyIDs, id)
+	}
+	if len(strategyIDs) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("run_id or strategy_ids is required"), "")
+		return
+	}
+	if len(strategyIDs) > domain.MaxRevalidationStrategies {
+		writeError(w, http.StatusBadRequest,
+			fmt.Errorf("at most %d strategies may be compared at once", domain.MaxRevalidationStrategies), "")
+		return
+	}
+
+	images, rows, err := h.repos.Revalidation.GetCompatibility(r.Context(), strategyIDs, images)
+	if err != nil {
+		h.logger.Error("Failed to get compatibility matrix", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get compatibility matrix")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.NewCompatibilityMatrix(images, rows))
+}
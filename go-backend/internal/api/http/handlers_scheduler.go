@@ -0,0 +1,61 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Scheduler Control Handlers
+// ============================================================================
+
+// HandleGetSchedulerStatus reports whether the backtest scheduler dequeues
+// jobs and how many it still holds.
+// GET /api/v1/scheduler/status
+func (h *Handler) HandleGetSchedulerStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	if h.scheduler == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("scheduler not available"), "")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.scheduler.Status())
+}
+
+// HandleControlScheduler pauses, drains or resumes the backtest scheduler.
+// Changing to the current mode is a no-op.
+// POST /api/v1/scheduler/pause, /api/v1/scheduler/drain, /api/v1/scheduler/resume
+func (h *Handler) HandleControlScheduler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	if h.scheduler == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("scheduler not available"), "")
+		return
+	}
+
+	by := requestOwner(r)
+	var status *domain.SchedulerStatus
+	switch action := strings.TrimPrefix(r.URL.Path, "/api/v1/scheduler/"); action {
+	case "pause":
+		status = h.scheduler.Pause(by)
+	case "drain":
+		status = h.scheduler.Drain(by)
+	case "resume":
+		status = h.scheduler.Resume(by)
+	default:
+		writeError(w, http.StatusNotFound, errors.New("unknown scheduler action: "+action), "")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
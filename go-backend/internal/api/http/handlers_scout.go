@@ -3,15 +3,19 @@ package http
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 	"github.com/saltfish/freqsearch/go-backend/internal/events"
+	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
 )
 
 // ============================================================================
@@ -24,6 +28,10 @@ type TriggerScoutRequest struct {
 	MaxStrategies int    `json:"max_strategies"` // Maximum strategies to fetch
 	TriggerType   string `json:"trigger_type"`   // "manual", "scheduled", "event"
 	TriggeredBy   string `json:"triggered_by"`   // User ID or "system"
+
+	// CredentialSecretID references the secret holding the source's
+	// credential, which is passed to the Scout agent.
+	CredentialSecretID *uuid.UUID `json:"credential_secret_id,omitempty"`
 }
 
 // TriggerScoutResponse represents the response for triggering a scout run.
@@ -31,7 +39,8 @@ type TriggerScoutResponse struct {
 	Run *domain.ScoutRun `json:"run"`
 }
 
-// HandleTriggerScout triggers a new Scout run.
+// HandleTriggerScout triggers a new Scout run. Runs that would break the
+// source's etiquette limits are rejected with 429 and a Retry-After header.
 // POST /api/v1/agents/scout/trigger
 func (h *Handler) HandleTriggerScout(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -73,6 +82,11 @@ func (h *Handler) HandleTriggerScout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.checkCredential(r.Context(), req.CredentialSecretID); err != nil {
+		h.writeSecretError(w, err, "failed to read source credential")
+		return
+	}
+
 	// Parse and validate trigger type
 	triggerType := domain.ScoutTriggerTypeFromString(req.TriggerType)
 
@@ -84,7 +98,14 @@ func (h *Handler) HandleTriggerScout(w http.ResponseWriter, r *http.Request) {
 		req.MaxStrategies,
 	)
 
-	if err := h.repos.Scout.CreateRun(r.Context(), run); err != nil {
+	if err := h.repos.Scout.CreateRunWithinLimits(r.Context(), run, h.scoutLimits[run.Source]); err != nil {
+		var limitErr *domain.ScoutRateLimitError
+		if errors.As(err, &limitErr) {
+			retryAfter := int(math.Ceil(time.Until(limitErr.RetryAfter).Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+			writeError(w, http.StatusTooManyRequests, err, "source "+run.Source+" was scouted too recently; try again later")
+			return
+		}
 		h.logger.Error("Failed to create scout run", zap.Error(err))
 		writeError(w, http.StatusInternalServerError, err, "failed to create scout run")
 		return
@@ -93,6 +114,7 @@ func (h *Handler) HandleTriggerScout(w http.ResponseWriter, r *http.Request) {
 	// Publish scout trigger event
 	if h.eventPublisher != nil {
 		event := events.NewScoutTriggerEvent(run)
+		event.CredentialSecretID = req.CredentialSecretID
 		if err := h.eventPublisher.PublishScoutTrigger(event); err != nil {
 			h.logger.Error("Failed to publish scout trigger event", zap.Error(err))
 			// Don't fail the request, just log the error
@@ -123,8 +145,7 @@ func (h *Handler) HandleListScoutRuns(w http.ResponseWriter, r *http.Request) {
 	}
 
 	query := domain.ScoutRunQuery{
-		Page:     1,
-		PageSize: 20,
+		Page: 1,
 	}
 
 	// Parse query parameters
@@ -154,24 +175,17 @@ func (h *Handler) HandleListScoutRuns(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse time range if provided
-	if startStr := queryParams.Get("start_time"); startStr != "" {
-		if start, err := time.Parse(time.RFC3339, startStr); err == nil {
-			if query.TimeRange == nil {
-				query.TimeRange = &domain.TimeRange{}
-			}
-			query.TimeRange.Start = start
-		}
-	}
-	if endStr := queryParams.Get("end_time"); endStr != "" {
-		if end, err := time.Parse(time.RFC3339, endStr); err == nil {
-			if query.TimeRange == nil {
-				query.TimeRange = &domain.TimeRange{}
-			}
-			query.TimeRange.End = end
-		}
+	timeRange, err := parseTimeRangeParams(queryParams)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid time range")
+		return
 	}
+	query.TimeRange = timeRange
 
-	query.SetDefaults()
+	requested := query.PageSize
+	if query.SetDefaults() {
+		warnPageSizeClamped(w, requested, query.PageSize)
+	}
 
 	runs, totalCount, err := h.repos.Scout.ListRuns(r.Context(), query)
 	if err != nil {
@@ -222,8 +236,8 @@ func (h *Handler) HandleGetScoutRun(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, GetScoutRunResponse{Run: run})
 }
 
-// HandleCancelScoutRun cancels a Scout run.
-// DELETE /api/v1/agents/scout/runs/:id
+// HandleCancelScoutRun cancels a Scout run, with an optional reason.
+// DELETE /api/v1/agents/scout/runs/:id?reason=...
 func (h *Handler) HandleCancelScoutRun(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
@@ -237,6 +251,12 @@ func (h *Handler) HandleCancelScoutRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	cancellation, err := requestCancellation(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid cancellation")
+		return
+	}
+
 	// Get run to check status
 	run, err := h.repos.Scout.GetRunByID(r.Context(), id)
 	if err != nil {
@@ -256,8 +276,7 @@ func (h *Handler) HandleCancelScoutRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Update run status to cancelled
-	if err := h.repos.Scout.UpdateRunStatus(r.Context(), id, domain.ScoutRunStatusCancelled, nil); err != nil {
+	if err := h.repos.Scout.CancelRun(r.Context(), id, cancellation); err != nil {
 		h.logger.Error("Failed to cancel Scout run", zap.Error(err))
 		writeError(w, http.StatusInternalServerError, err, "failed to cancel Scout run")
 		return
@@ -265,17 +284,116 @@ func (h *Handler) HandleCancelScoutRun(w http.ResponseWriter, r *http.Request) {
 
 	// Publish Scout cancelled event
 	if h.eventPublisher != nil {
-		if err := h.eventPublisher.PublishScoutCancelled(id); err != nil {
+		if err := h.eventPublisher.PublishScoutCancelled(id, cancellation); err != nil {
 			h.logger.Error("Failed to publish Scout cancelled event", zap.Error(err))
 			// Don't fail the request, just log the error
 		}
 	}
 
-	h.logger.Info("Scout run cancelled", zap.String("run_id", id.String()))
+	h.logger.Info("Scout run cancelled",
+		zap.String("run_id", id.String()),
+		zap.String("reason", cancellation.Reason),
+		zap.String("cancelled_by", cancellation.CancelledBy),
+	)
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// HandleGetScoutMetrics returns the metrics of completed Scout runs summed
+// over an optional source and completion time range, with per-source and
+// per-error-category breakdowns.
+// GET /api/v1/agents/scout/metrics
+func (h *Handler) HandleGetScoutMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var query domain.ScoutMetricsQuery
+	queryParams := r.URL.Query()
+	if source := queryParams.Get("source"); source != "" {
+		query.Source = &source
+	}
+
+	timeRange, err := parseTimeRangeParams(queryParams)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid time range")
+		return
+	}
+	query.TimeRange = timeRange
+
+	summary, err := h.repos.Scout.AggregateMetrics(r.Context(), query)
+	if err != nil {
+		h.logger.Error("Failed to aggregate scout metrics", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to aggregate scout metrics")
+		return
+	}
+	summary.Timezone = h.timezone().String()
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// ListScoutDiscoveriesResponse represents the response for listing the
+// discoveries of a scout run.
+type ListScoutDiscoveriesResponse struct {
+	Discoveries []*domain.ScoutDiscovery `json:"discoveries"`
+}
+
+// HandleListScoutDiscoveries lists the strategies a Scout run discovered, with
+// their validation status and whether they were imported, oldest first.
+// GET /api/v1/agents/scout/runs/:id/discoveries?outcome=imported&validation_status=valid
+func (h *Handler) HandleListScoutDiscoveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/agents/scout/runs/")
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), "/discoveries")
+	runID, err := parseUUID(path)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid run id")
+		return
+	}
+
+	query := domain.ScoutDiscoveryQuery{RunID: runID}
+	if outcome := r.URL.Query().Get("outcome"); outcome != "" {
+		o := domain.ScoutDiscoveryOutcome(outcome)
+		if !o.IsValid() {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("unknown outcome %q", outcome), "")
+			return
+		}
+		query.Outcome = &o
+	}
+	if status := r.URL.Query().Get("validation_status"); status != "" {
+		s := domain.ScoutValidationStatus(status)
+		if !s.IsValid() {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("unknown validation_status %q", status), "")
+			return
+		}
+		query.ValidationStatus = &s
+	}
+
+	if _, err := h.repos.Scout.GetRunByID(r.Context(), runID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "scout run not found")
+			return
+		}
+		h.logger.Error("Failed to get scout run", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get scout run")
+		return
+	}
+
+	discoveries, err := h.repos.Scout.ListDiscoveries(r.Context(), query)
+	if err != nil {
+		h.logger.Error("Failed to list scout discoveries", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list discoveries")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ListScoutDiscoveriesResponse{Discoveries: discoveries})
+}
+
 // ============================================================================
 // Scout Schedule Handlers
 // ============================================================================
@@ -295,8 +413,7 @@ func (h *Handler) HandleListScoutSchedules(w http.ResponseWriter, r *http.Reques
 	}
 
 	query := domain.ScoutScheduleQuery{
-		Page:     1,
-		PageSize: 20,
+		Page: 1,
 	}
 
 	// Parse query parameters
@@ -326,7 +443,10 @@ func (h *Handler) HandleListScoutSchedules(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	query.SetDefaults()
+	requested := query.PageSize
+	if query.SetDefaults() {
+		warnPageSizeClamped(w, requested, query.PageSize)
+	}
 
 	schedules, totalCount, err := h.repos.Scout.ListSchedules(r.Context(), query)
 	if err != nil {
@@ -337,18 +457,31 @@ func (h *Handler) HandleListScoutSchedules(w http.ResponseWriter, r *http.Reques
 
 	pagination := domain.NewPaginationResponse(totalCount, query.Page, query.PageSize)
 
+	h.setScheduleTimezones(schedules...)
 	writeJSON(w, http.StatusOK, ListScoutSchedulesResponse{
 		Schedules:  schedules,
 		Pagination: pagination,
 	})
 }
 
+// setScheduleTimezones fills in the zone each schedule's cron expression is
+// evaluated in.
+func (h *Handler) setScheduleTimezones(schedules ...*domain.ScoutSchedule) {
+	for _, schedule := range schedules {
+		schedule.Timezone = scheduler.ScheduleTimezone(schedule.CronExpression, h.timezone()).String()
+	}
+}
+
 // CreateScoutScheduleRequest represents the request body for creating a scout schedule.
 type CreateScoutScheduleRequest struct {
 	Name           string `json:"name"`
 	CronExpression string `json:"cron_expression"`
 	Source         string `json:"source"`
 	MaxStrategies  int    `json:"max_strategies"`
+
+	// CredentialSecretID references the secret holding the source's
+	// credential, which is passed to the Scout agent on each run.
+	CredentialSecretID *uuid.UUID `json:"credential_secret_id,omitempty"`
 }
 
 // CreateScoutScheduleResponse represents the response for creating a scout schedule.
@@ -401,8 +534,13 @@ func (h *Handler) HandleCreateScoutSchedule(w http.ResponseWriter, r *http.Reque
 
 	// Create schedule
 	schedule := domain.NewScoutSchedule(req.Name, req.CronExpression, req.Source, req.MaxStrategies)
+	schedule.CredentialSecretID = req.CredentialSecretID
 
 	if err := h.repos.Scout.CreateSchedule(r.Context(), schedule); err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			writeError(w, http.StatusBadRequest, err, "")
+			return
+		}
 		h.logger.Error("Failed to create Scout schedule", zap.Error(err))
 		writeError(w, http.StatusInternalServerError, err, "failed to create Scout schedule")
 		return
@@ -419,6 +557,7 @@ func (h *Handler) HandleCreateScoutSchedule(w http.ResponseWriter, r *http.Reque
 		zap.String("cron", schedule.CronExpression),
 	)
 
+	h.setScheduleTimezones(schedule)
 	writeJSON(w, http.StatusCreated, CreateScoutScheduleResponse{Schedule: schedule})
 }
 
@@ -453,9 +592,78 @@ func (h *Handler) HandleGetScoutSchedule(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	h.setScheduleTimezones(schedule)
 	writeJSON(w, http.StatusOK, GetScoutScheduleResponse{Schedule: schedule})
 }
 
+// Bounds of the count parameter of a schedule preview.
+const (
+	defaultSchedulePreviewCount = 10
+	maxSchedulePreviewCount     = 100
+)
+
+// ScoutSchedulePreviewResponse represents the upcoming fire times of a scout schedule.
+type ScoutSchedulePreviewResponse struct {
+	ScheduleID     uuid.UUID   `json:"schedule_id"`
+	CronExpression string      `json:"cron_expression"`
+	Timezone       string      `json:"timezone"`
+	Enabled        bool        `json:"enabled"`
+	FireTimes      []time.Time `json:"fire_times"`
+}
+
+// HandlePreviewScoutSchedule returns the next fire times of a Scout schedule's
+// cron expression, so it can be checked before the schedule is enabled.
+// GET /api/v1/agents/scout/schedules/:id/preview?count=10
+func (h *Handler) HandlePreviewScoutSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/agents/scout/schedules/")
+	idStr = strings.TrimSuffix(idStr, "/preview")
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid schedule id")
+		return
+	}
+
+	count := defaultSchedulePreviewCount
+	if raw := r.URL.Query().Get("count"); raw != "" {
+		count, err = strconv.Atoi(raw)
+		if err != nil || count < 1 || count > maxSchedulePreviewCount {
+			writeError(w, http.StatusBadRequest,
+				fmt.Errorf("count must be between 1 and %d", maxSchedulePreviewCount), "invalid count")
+			return
+		}
+	}
+
+	schedule, err := h.repos.Scout.GetScheduleByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "scout schedule not found")
+			return
+		}
+		h.logger.Error("Failed to get scout schedule", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get scout schedule")
+		return
+	}
+
+	fireTimes, loc, err := scheduler.PreviewFireTimes(schedule.CronExpression, time.Now().In(h.timezone()), count)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err, "invalid cron expression")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ScoutSchedulePreviewResponse{
+		ScheduleID:     schedule.ID,
+		CronExpression: schedule.CronExpression,
+		Timezone:       loc.String(),
+		Enabled:        schedule.Enabled,
+		FireTimes:      fireTimes,
+	})
+}
+
 // UpdateScoutScheduleRequest represents the request body for updating a scout schedule.
 type UpdateScoutScheduleRequest struct {
 	Name           *string `json:"name,omitempty"`
@@ -463,6 +671,10 @@ type UpdateScoutScheduleRequest struct {
 	Source         *string `json:"source,omitempty"`
 	MaxStrategies  *int    `json:"max_strategies,omitempty"`
 	Enabled        *bool   `json:"enabled,omitempty"`
+
+	// CredentialSecretID replaces the referenced credential secret; an empty
+	// string removes it.
+	CredentialSecretID *string `json:"credential_secret_id,omitempty"`
 }
 
 // UpdateScoutScheduleResponse represents the response for updating a scout schedule.
@@ -519,9 +731,24 @@ func (h *Handler) HandleUpdateScoutSchedule(w http.ResponseWriter, r *http.Reque
 	if req.Enabled != nil {
 		schedule.Enabled = *req.Enabled
 	}
+	if req.CredentialSecretID != nil {
+		schedule.CredentialSecretID = nil
+		if *req.CredentialSecretID != "" {
+			secretID, err := parseUUID(*req.CredentialSecretID)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err, "invalid credential_secret_id")
+				return
+			}
+			schedule.CredentialSecretID = &secretID
+		}
+	}
 	schedule.UpdatedAt = time.Now()
 
 	if err := h.repos.Scout.UpdateSchedule(r.Context(), schedule); err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			writeError(w, http.StatusBadRequest, err, "")
+			return
+		}
 		h.logger.Error("Failed to update Scout schedule", zap.Error(err))
 		writeError(w, http.StatusInternalServerError, err, "failed to update Scout schedule")
 		return
@@ -537,6 +764,7 @@ func (h *Handler) HandleUpdateScoutSchedule(w http.ResponseWriter, r *http.Reque
 		zap.String("name", schedule.Name),
 	)
 
+	h.setScheduleTimezones(schedule)
 	writeJSON(w, http.StatusOK, UpdateScoutScheduleResponse{Schedule: schedule})
 }
 
@@ -631,5 +859,6 @@ func (h *Handler) HandleToggleScoutSchedule(w http.ResponseWriter, r *http.Reque
 		zap.Bool("enabled", schedule.Enabled),
 	)
 
+	h.setScheduleTimezones(schedule)
 	writeJSON(w, http.StatusOK, ToggleScoutScheduleResponse{Schedule: schedule})
 }
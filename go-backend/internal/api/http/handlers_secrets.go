@@ -0,0 +1,206 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
+)
+
+// ============================================================================
+// Secret Handlers
+// ============================================================================
+
+// CreateSecretRequest represents the request body for creating a secret.
+type CreateSecretRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Value       string `json:"value"`
+}
+
+// UpdateSecretRequest represents the request body for updating a secret.
+// Setting value rotates the secret.
+type UpdateSecretRequest struct {
+	Description *string `json:"description,omitempty"`
+	Value       *string `json:"value,omitempty"`
+}
+
+// SecretResponse represents the response for a single secret. It only ever
+// carries metadata; the value is never returned.
+type SecretResponse struct {
+	Secret *domain.Secret `json:"secret"`
+}
+
+// ListSecretsResponse represents the response for listing secrets.
+type ListSecretsResponse struct {
+	Secrets []*domain.Secret `json:"secrets"`
+}
+
+// HandleCreateSecret encrypts and stores a credential, e.g. an API token for
+// a scout source. The value cannot be read back; entities reference the
+// secret by ID.
+// POST /api/v1/secrets
+func (h *Handler) HandleCreateSecret(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var req CreateSecretRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	secret, err := h.secrets.Create(r.Context(), req.Name, req.Description, req.Value)
+	if err != nil {
+		h.writeSecretError(w, err, "failed to create secret")
+		return
+	}
+
+	h.logger.Info("Secret created",
+		zap.String("secret_id", secret.ID.String()),
+		zap.String("name", secret.Name),
+	)
+
+	writeJSON(w, http.StatusCreated, SecretResponse{Secret: secret})
+}
+
+// HandleListSecrets lists secrets by name.
+// GET /api/v1/secrets
+func (h *Handler) HandleListSecrets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	list, err := h.repos.Secret.List(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list secrets", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list secrets")
+		return
+	}
+	if list == nil {
+		list = []*domain.Secret{}
+	}
+
+	writeJSON(w, http.StatusOK, ListSecretsResponse{Secrets: list})
+}
+
+// HandleGetSecret retrieves a secret's metadata.
+// GET /api/v1/secrets/:id
+func (h *Handler) HandleGetSecret(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/secrets/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid secret id")
+		return
+	}
+
+	secret, err := h.repos.Secret.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeSecretError(w, err, "failed to get secret")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SecretResponse{Secret: secret})
+}
+
+// HandleUpdateSecret updates a secret's description or rotates its value.
+// Entities referencing the secret use the new value from their next use on.
+// PUT /api/v1/secrets/:id
+func (h *Handler) HandleUpdateSecret(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/secrets/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid secret id")
+		return
+	}
+
+	var req UpdateSecretRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	secret, err := h.secrets.Update(r.Context(), id, req.Description, req.Value)
+	if err != nil {
+		h.writeSecretError(w, err, "failed to update secret")
+		return
+	}
+
+	h.logger.Info("Secret updated",
+		zap.String("secret_id", secret.ID.String()),
+		zap.Bool("rotated", req.Value != nil),
+	)
+
+	writeJSON(w, http.StatusOK, SecretResponse{Secret: secret})
+}
+
+// HandleDeleteSecret deletes a secret that is no longer referenced.
+// DELETE /api/v1/secrets/:id
+func (h *Handler) HandleDeleteSecret(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	id, err := parseUUID(extractID(r.URL.Path, "/api/v1/secrets/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid secret id")
+		return
+	}
+
+	if err := h.repos.Secret.Delete(r.Context(), id); err != nil {
+		h.writeSecretError(w, err, "failed to delete secret")
+		return
+	}
+
+	h.logger.Info("Secret deleted", zap.String("secret_id", id.String()))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeSecretError maps secrets store and repository errors to responses.
+func (h *Handler) writeSecretError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, secrets.ErrNotConfigured):
+		writeError(w, http.StatusServiceUnavailable, err, "")
+	case errors.Is(err, domain.ErrInvalidInput):
+		writeError(w, http.StatusBadRequest, err, "")
+	case errors.Is(err, domain.ErrNotFound):
+		writeError(w, http.StatusNotFound, err, "secret not found")
+	case errors.Is(err, domain.ErrDuplicate):
+		writeError(w, http.StatusConflict, err, "secret with this name already exists")
+	case errors.Is(err, domain.ErrSecretInUse):
+		writeError(w, http.StatusConflict, err, "secret is referenced by a scout schedule")
+	default:
+		h.logger.Error("Secret operation failed", zap.String("operation", msg), zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, msg)
+	}
+}
+
+// checkCredential checks that the referenced credential for a scout run can
+// be decrypted, so a run the agent cannot fetch for is not triggered. A nil ID
+// needs no credential.
+func (h *Handler) checkCredential(ctx context.Context, id *uuid.UUID) error {
+	if id == nil {
+		return nil
+	}
+	_, err := h.secrets.Reveal(ctx, *id)
+	return err
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// SLA Handlers
+// ============================================================================
+
+// SLAMonitorInterface defines the interface for inspecting queue SLA breaches.
+type SLAMonitorInterface interface {
+	Status() domain.SLAStatus
+	Report(since time.Time) *domain.SLAReport
+}
+
+// HandleGetSLA returns the queue SLA targets, the jobs currently in breach, and
+// the breach time series. The window is bounded by since (RFC3339) or window
+// (a duration such as 6h); by default the whole retained history is returned.
+// GET /api/v1/sla?window=6h
+func (h *Handler) HandleGetSLA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	if h.slaMonitor == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("SLA monitoring is disabled"), "")
+		return
+	}
+
+	queryParams := r.URL.Query()
+
+	var since time.Time
+	if sinceStr := queryParams.Get("since"); sinceStr != "" {
+		t, err := parseTimestamp("since", sinceStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid since parameter")
+			return
+		}
+		since = t
+	} else if windowStr := queryParams.Get("window"); windowStr != "" {
+		d, err := time.ParseDuration(windowStr)
+		if err != nil || d <= 0 {
+			writeError(w, http.StatusBadRequest, errors.New("window must be a positive duration"), "invalid window parameter")
+			return
+		}
+		since = time.Now().Add(-d)
+	}
+
+	report := h.slaMonitor.Report(since)
+	report.Timezone = h.timezone().String()
+	writeJSON(w, http.StatusOK, report)
+}
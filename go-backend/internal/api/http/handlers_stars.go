@@ -0,0 +1,158 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Star (Favorites) Handlers
+// ============================================================================
+
+// StarResponse represents the response for starring or unstarring an entity.
+type StarResponse struct {
+	EntityType domain.StarEntityType `json:"entity_type"`
+	EntityID   uuid.UUID             `json:"entity_id"`
+	Starred    bool                  `json:"starred"`
+}
+
+// HandleStarStrategy stars or unstars a strategy.
+// POST /api/v1/strategies/:id/star
+// DELETE /api/v1/strategies/:id/star
+func (h *Handler) HandleStarStrategy(w http.ResponseWriter, r *http.Request) {
+	h.handleStar(w, r, domain.StarEntityStrategy, "/api/v1/strategies/")
+}
+
+// HandleStarOptimization stars or unstars an optimization run.
+// POST /api/v1/optimizations/:id/star
+// DELETE /api/v1/optimizations/:id/star
+func (h *Handler) HandleStarOptimization(w http.ResponseWriter, r *http.Request) {
+	h.handleStar(w, r, domain.StarEntityOptimizationRun, "/api/v1/optimizations/")
+}
+
+// handleStar implements star/unstar for any starrable entity type.
+func (h *Handler) handleStar(w http.ResponseWriter, r *http.Request, entityType domain.StarEntityType, prefix string) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	idStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix), "/star")
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid "+entityType.String()+" id")
+		return
+	}
+
+	owner := requestOwner(r)
+	starred := r.Method == http.MethodPost
+	if starred {
+		err = h.repos.Star.Star(r.Context(), owner, entityType, id)
+	} else {
+		err = h.repos.Star.Unstar(r.Context(), owner, entityType, id)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, entityType.String()+" not found")
+			return
+		}
+		h.logger.Error("Failed to update star",
+			zap.String("entity_type", entityType.String()),
+			zap.String("entity_id", id.String()),
+			zap.Error(err),
+		)
+		writeError(w, http.StatusInternalServerError, err, "failed to update star")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, StarResponse{
+		EntityType: entityType,
+		EntityID:   id,
+		Starred:    starred,
+	})
+}
+
+// ============================================================================
+// Dashboard Handlers
+// ============================================================================
+
+// dashboardStarredLimit caps the number of starred entities in the dashboard summary.
+const dashboardStarredLimit = 20
+
+// DashboardSummaryResponse represents the response for the dashboard summary.
+type DashboardSummaryResponse struct {
+	QueueStats                *domain.QueueStats           `json:"queue_stats"`
+	StarredStrategies         []domain.StrategyWithMetrics `json:"starred_strategies"`
+	StarredOptimizations      []*domain.OptimizationRun    `json:"starred_optimizations"`
+	TotalStarredStrategies    int                          `json:"total_starred_strategies"`
+	TotalStarredOptimizations int                          `json:"total_starred_optimizations"`
+	Maintenance               *domain.MaintenanceMode      `json:"maintenance,omitempty"`
+	OpenIncidents             []*domain.Incident           `json:"open_incidents,omitempty"`
+}
+
+// HandleGetDashboardSummary returns queue stats, the requesting user's starred
+// entities, the maintenance mode and open incidents.
+// GET /api/v1/dashboard/summary
+func (h *Handler) HandleGetDashboardSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	ctx := r.Context()
+	owner := requestOwner(r)
+
+	stats, err := h.repos.BacktestJob.GetQueueStats(ctx)
+	if err != nil {
+		h.logger.Error("Failed to get queue stats", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get queue stats")
+		return
+	}
+
+	strategyQuery := domain.StrategySearchQuery{
+		StarredBy: &owner,
+		PageSize:  dashboardStarredLimit,
+	}
+	strategyQuery.SetDefaults()
+	strategies, totalStrategies, err := h.repos.Strategy.Search(ctx, strategyQuery)
+	if err != nil {
+		h.logger.Error("Failed to get starred strategies", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get starred strategies")
+		return
+	}
+
+	optQuery := domain.OptimizationListQuery{
+		StarredBy: &owner,
+		PageSize:  dashboardStarredLimit,
+	}
+	optQuery.SetDefaults()
+	runs, totalRuns, err := h.repos.Optimization.List(ctx, optQuery)
+	if err != nil {
+		h.logger.Error("Failed to get starred optimization runs", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get starred optimization runs")
+		return
+	}
+
+	if strategies == nil {
+		strategies = []domain.StrategyWithMetrics{}
+	}
+	if runs == nil {
+		runs = []*domain.OptimizationRun{}
+	}
+
+	writeJSON(w, http.StatusOK, DashboardSummaryResponse{
+		QueueStats:                stats,
+		StarredStrategies:         strategies,
+		StarredOptimizations:      runs,
+		TotalStarredStrategies:    totalStrategies,
+		TotalStarredOptimizations: totalRuns,
+		Maintenance:               h.currentMaintenance(),
+		OpenIncidents:             h.openIncidents(ctx),
+	})
+}
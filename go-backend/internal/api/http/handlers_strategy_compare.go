@@ -0,0 +1,121 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Strategy Comparison Handlers
+// ============================================================================
+
+// maxComparedStrategies bounds the strategies one comparison may cover.
+const maxComparedStrategies = 20
+
+// CompareStrategiesRequest represents the request body for comparing strategies.
+type CompareStrategiesRequest struct {
+	StrategyIDs []string `json:"strategy_ids"`
+
+	// TimeRange limits the comparison to results created within it; a zero
+	// start or end leaves that side unbounded.
+	TimeRange *domain.TimeRange `json:"time_range,omitempty"`
+}
+
+// ComparedStrategy is one strategy of a comparison with the statistics of its
+// results.
+type ComparedStrategy struct {
+	StrategyID uuid.UUID                  `json:"strategy_id"`
+	Name       string                     `json:"name"`
+	Statistics *domain.StrategyStatistics `json:"statistics"`
+}
+
+// CompareStrategiesResponse represents the response for comparing strategies.
+type CompareStrategiesResponse struct {
+	Strategies []ComparedStrategy `json:"strategies"` // In request order
+	Rankings   StrategyRankings   `json:"rankings"`
+}
+
+// HandleCompareStrategies compares strategies side by side: the best, mean
+// and spread of each one's Sharpe ratio, profit and drawdown across its
+// current results, and head-to-head rankings by each metric.
+// POST /api/v1/strategies/compare
+func (h *Handler) HandleCompareStrategies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	var req CompareStrategiesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	if len(req.StrategyIDs) < 2 || len(req.StrategyIDs) > maxComparedStrategies {
+		writeError(w, http.StatusBadRequest,
+			fmt.Errorf("strategy_ids must list between 2 and %d strategies", maxComparedStrategies), "")
+		return
+	}
+	ids := make([]uuid.UUID, len(req.StrategyIDs))
+	seen := make(map[uuid.UUID]bool, len(req.StrategyIDs))
+	for i, s := range req.StrategyIDs {
+		id, err := parseUUID(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid strategy_ids")
+			return
+		}
+		if seen[id] {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("strategy %s is listed twice", id), "invalid strategy_ids")
+			return
+		}
+		seen[id] = true
+		ids[i] = id
+	}
+
+	if req.TimeRange != nil && !req.TimeRange.Start.IsZero() && !req.TimeRange.End.IsZero() &&
+		!req.TimeRange.End.After(req.TimeRange.Start) {
+		writeError(w, http.StatusBadRequest, errors.New("time_range end must be after its start"), "")
+		return
+	}
+
+	strategies, err := h.repos.Strategy.GetByIDs(r.Context(), ids)
+	if err != nil {
+		h.logger.Error("Failed to get strategies", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategies")
+		return
+	}
+	names := make(map[uuid.UUID]string, len(strategies))
+	for _, strategy := range strategies {
+		names[strategy.ID] = strategy.Name
+	}
+	for _, id := range ids {
+		if _, ok := names[id]; !ok {
+			writeError(w, http.StatusNotFound, domain.NewNotFoundError("strategy", id.String()), "strategy not found")
+			return
+		}
+	}
+
+	stats, err := h.repos.Result.GetStatisticsBatch(r.Context(), ids, req.TimeRange)
+	if err != nil {
+		h.logger.Error("Failed to get strategy statistics", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy statistics")
+		return
+	}
+
+	compared := make([]ComparedStrategy, len(ids))
+	for i, id := range ids {
+		compared[i] = ComparedStrategy{StrategyID: id, Name: names[id], Statistics: stats[i]}
+	}
+
+	writeJSON(w, http.StatusOK, CompareStrategiesResponse{
+		Strategies: compared,
+		Rankings:   rankStrategies(stats),
+	})
+}
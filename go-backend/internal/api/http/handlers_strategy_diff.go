@@ -0,0 +1,105 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/strategycode"
+)
+
+// ============================================================================
+// Strategy Diff Handlers
+// ============================================================================
+
+// HandleDiffStrategy compares a strategy with another one, by default its
+// parent: a unified diff of the code, the settings that differ (stoploss,
+// timeframe, minimal_roi, hyperopt parameters, ...) and the indicators added
+// or removed. context sets the unchanged lines around each change (default 3).
+// GET /api/v1/strategies/:id/diff?against=<other_id>&context=3
+func (h *Handler) HandleDiffStrategy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID from path like /api/v1/strategies/:id/diff
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	idStr := strings.TrimSuffix(path, "/diff")
+
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+
+	q := r.URL.Query()
+	var againstID *uuid.UUID
+	if v := q.Get("against"); v != "" {
+		parsed, err := parseUUID(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid against")
+			return
+		}
+		againstID = &parsed
+	}
+
+	contextLines := domain.DefaultDiffContextLines
+	if v := q.Get("context"); v != "" {
+		contextLines, err = strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid context")
+			return
+		}
+	}
+	if err := domain.ValidateDiffContextLines(contextLines); err != nil {
+		writeError(w, http.StatusBadRequest, err, "")
+		return
+	}
+
+	strategy, ok := h.getStrategyForDiff(w, r, id)
+	if !ok {
+		return
+	}
+	if againstID == nil {
+		if strategy.ParentID == nil {
+			writeError(w, http.StatusBadRequest, errors.New("strategy has no parent; against is required"), "")
+			return
+		}
+		againstID = strategy.ParentID
+	}
+	against, ok := h.getStrategyForDiff(w, r, *againstID)
+	if !ok {
+		return
+	}
+
+	diff, err := strategycode.Diff(against, strategy, contextLines)
+	if err != nil {
+		h.logger.Error("Failed to diff strategies", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to diff strategies")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, diff)
+}
+
+// getStrategyForDiff loads a strategy, writing the error response if it
+// can't.
+func (h *Handler) getStrategyForDiff(w http.ResponseWriter, r *http.Request, id uuid.UUID) (*domain.Strategy, bool) {
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return nil, false
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return nil, false
+	}
+	return strategy, true
+}
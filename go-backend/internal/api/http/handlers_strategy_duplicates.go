@@ -0,0 +1,44 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/strategycode"
+)
+
+// ============================================================================
+// Strategy Duplicates Handlers
+// ============================================================================
+
+// HandleGetStrategyDuplicates reports clusters of strategies with the same
+// code under different names. Strategies match when their code is identical
+// apart from the strategy class name; with normalized=true, comments,
+// docstrings and whitespace are ignored as well. The oldest strategy of each
+// cluster is the one to keep. Archived strategies are skipped unless
+// include_archived=true.
+// GET /api/v1/strategies/duplicates?normalized=true&include_archived=false
+func (h *Handler) HandleGetStrategyDuplicates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	q := r.URL.Query()
+	query := domain.StrategyDuplicatesQuery{
+		Normalized:      q.Get("normalized") == "true",
+		IncludeArchived: q.Get("include_archived") == "true",
+	}
+
+	strategies, err := h.repos.Strategy.ListAll(r.Context(), query.IncludeArchived)
+	if err != nil {
+		h.logger.Error("Failed to list strategies", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list strategies")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, strategycode.FindDuplicates(strategies, query.Normalized))
+}
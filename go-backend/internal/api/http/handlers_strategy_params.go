@@ -0,0 +1,156 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/strategycode"
+)
+
+// ============================================================================
+// Strategy Parameter Handlers
+// ============================================================================
+
+// StrategyParamsResponse represents the structured parameters of a strategy.
+type StrategyParamsResponse struct {
+	StrategyID uuid.UUID              `json:"strategy_id"`
+	Params     *domain.StrategyParams `json:"params"`
+}
+
+// PatchStrategyParamsRequest represents the request body for editing strategy parameters.
+// Strategy code is immutable, so the patched code is saved as a child strategy.
+type PatchStrategyParamsRequest struct {
+	domain.StrategyParamsPatch
+	Name        string `json:"name,omitempty"`        // Defaults to the parent's name
+	Description string `json:"description,omitempty"` // Defaults to a summary of the change
+	DryRun      bool   `json:"dry_run,omitempty"`     // Return the patched code without saving
+}
+
+// PatchStrategyParamsResponse represents the response for editing strategy parameters.
+type PatchStrategyParamsResponse struct {
+	Strategy *domain.Strategy       `json:"strategy,omitempty"`
+	Params   *domain.StrategyParams `json:"params"`
+	Code     string                 `json:"code"`
+	DryRun   bool                   `json:"dry_run"`
+}
+
+// HandleStrategyParams extracts the ROI table, stoploss, trailing stop, and hyperopt
+// parameters from a strategy's code, or writes edited values back as a child strategy.
+// GET  /api/v1/strategies/:id/params
+// POST /api/v1/strategies/:id/params
+func (h *Handler) HandleStrategyParams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID from path like /api/v1/strategies/:id/params
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	idStr := strings.TrimSuffix(path, "/params")
+
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		params, err := strategycode.Parse(strategy.Code)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err, "failed to parse strategy code")
+			return
+		}
+		writeJSON(w, http.StatusOK, StrategyParamsResponse{StrategyID: id, Params: params})
+		return
+	}
+
+	var req PatchStrategyParamsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	code, err := strategycode.Apply(strategy.Code, &req.StrategyParamsPatch)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			writeError(w, http.StatusBadRequest, err, "invalid parameters")
+			return
+		}
+		writeError(w, http.StatusUnprocessableEntity, err, "failed to patch strategy code")
+		return
+	}
+
+	params, err := strategycode.Parse(code)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err, "failed to parse patched code")
+		return
+	}
+
+	if req.DryRun {
+		writeJSON(w, http.StatusOK, PatchStrategyParamsResponse{Params: params, Code: code, DryRun: true})
+		return
+	}
+
+	name := strategy.Name
+	if req.Name != "" {
+		name = domain.SanitizeStrategyName(req.Name)
+		code = strings.Replace(code, "class "+strategy.Name+"(", "class "+name+"(", 1)
+	}
+	description := req.Description
+	if description == "" {
+		description = "Parameter edit of " + strategy.Name
+	}
+
+	// The child inherits the parent's metadata, overridden by the patched settings
+	child := domain.NewStrategy(name, code, description, &strategy.ID)
+	child.Tags = strategy.Tags
+	child.Timeframe = strategy.Timeframe
+	child.Stoploss = strategy.Stoploss
+	child.TrailingStop = strategy.TrailingStop
+	child.TrailingStopPositive = strategy.TrailingStopPositive
+	child.TrailingStopPositiveOffset = strategy.TrailingStopPositiveOffset
+	child.StartupCandleCount = strategy.StartupCandleCount
+	child.Indicators = strategy.Indicators
+	child.MinimalROI = strategy.MinimalROI
+	params.ApplyTo(child)
+
+	if !h.checkCodeSafety(w, child.Code) {
+		return
+	}
+
+	if err := h.repos.Strategy.Create(r.Context(), child); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			writeError(w, http.StatusConflict, err, "strategy with same code already exists")
+			return
+		}
+		h.logger.Error("Failed to create strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to create strategy")
+		return
+	}
+
+	h.publishStrategyCreated(child)
+
+	h.logger.Info("Created strategy from parameter edit",
+		zap.String("parent_id", strategy.ID.String()),
+		zap.String("strategy_id", child.ID.String()),
+	)
+
+	writeJSON(w, http.StatusCreated, PatchStrategyParamsResponse{Strategy: child, Params: params, Code: code})
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Strategy Version Handlers
+// ============================================================================
+
+// ListStrategyVersionsResponse represents the response for listing strategy versions.
+type ListStrategyVersionsResponse struct {
+	StrategyID uuid.UUID                 `json:"strategy_id"`
+	Versions   []*domain.StrategyVersion `json:"versions"`
+}
+
+// RollbackStrategyResponse represents the response for rolling back a strategy.
+type RollbackStrategyResponse struct {
+	Strategy *domain.Strategy        `json:"strategy"`
+	Version  *domain.StrategyVersion `json:"version"` // The version the rollback created
+}
+
+// HandleListStrategyVersions lists the versions of a strategy, newest first.
+// Code is included with include_code=true.
+// GET /api/v1/strategies/:id/versions?include_code=true
+func (h *Handler) HandleListStrategyVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID from path like /api/v1/strategies/:id/versions
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	idStr := strings.TrimSuffix(path, "/versions")
+
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+
+	includeCode := false
+	if v := r.URL.Query().Get("include_code"); v != "" {
+		includeCode, err = strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid include_code")
+			return
+		}
+	}
+
+	// Ensure the strategy exists
+	if _, err := h.repos.Strategy.GetByID(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
+	versions, err := h.repos.StrategyVersion.List(r.Context(), id, includeCode)
+	if err != nil {
+		h.logger.Error("Failed to list strategy versions", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to list strategy versions")
+		return
+	}
+	if versions == nil {
+		versions = []*domain.StrategyVersion{}
+	}
+
+	writeJSON(w, http.StatusOK, ListStrategyVersionsResponse{StrategyID: id, Versions: versions})
+}
+
+// HandleRollbackStrategy restores a strategy's name, code, description and
+// parent from one of its versions. The rollback is itself stored as a new
+// version, so it can be undone the same way.
+// POST /api/v1/strategies/:id/rollback/:version
+func (h *Handler) HandleRollbackStrategy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID and version from path like /api/v1/strategies/:id/rollback/:version
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	idStr, versionStr, _ := strings.Cut(path, "/rollback/")
+
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version <= 0 {
+		writeError(w, http.StatusBadRequest, errors.New("version must be a positive integer"), "invalid version")
+		return
+	}
+
+	// The version's code may predate the safety checks, or rules added since
+	if h.codeSafety != nil {
+		target, err := h.repos.StrategyVersion.Get(r.Context(), id, version)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				writeError(w, http.StatusNotFound, err, "strategy version not found")
+				return
+			}
+			h.logger.Error("Failed to get strategy version", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to get strategy version")
+			return
+		}
+		if !h.checkCodeSafety(w, target.Code) {
+			return
+		}
+	}
+
+	created, err := h.repos.StrategyVersion.Rollback(r.Context(), id, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, err, "strategy version not found")
+		case errors.Is(err, domain.ErrDuplicate):
+			writeError(w, http.StatusConflict, err, "another strategy has the code of this version")
+		default:
+			h.logger.Error("Failed to roll back strategy", zap.Error(err))
+			writeError(w, http.StatusInternalServerError, err, "failed to roll back strategy")
+		}
+		return
+	}
+
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
+	h.logger.Info("Strategy rolled back",
+		zap.String("strategy_id", id.String()),
+		zap.Int("to_version", version),
+		zap.Int("new_version", created.Version),
+	)
+
+	if h.eventPublisher != nil {
+		if err := h.eventPublisher.PublishStrategyUpdated(strategy); err != nil {
+			h.logger.Error("Failed to publish strategy updated event", zap.Error(err))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, RollbackStrategyResponse{Strategy: strategy, Version: created})
+}
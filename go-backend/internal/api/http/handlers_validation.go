@@ -0,0 +1,165 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
+)
+
+// ============================================================================
+// Strategy Validation Handlers
+// ============================================================================
+
+// ValidateBatchRequest represents the request body for batch strategy validation.
+type ValidateBatchRequest struct {
+	Strategies []scheduler.StrategyValidationItem `json:"strategies"`
+}
+
+// HandleValidateStrategyBatch validates a batch of strategy code blobs
+// concurrently against the validation container pool and returns a result
+// per item, in request order. Items still running when the batch time budget
+// runs out are reported with timed_out set.
+// POST /api/v1/strategies/validate-batch
+func (h *Handler) HandleValidateStrategyBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	if h.scheduler == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("strategy validation is unavailable"), "")
+		return
+	}
+
+	var req ValidateBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	if len(req.Strategies) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("strategies must not be empty"), "")
+		return
+	}
+	if max := h.scheduler.MaxValidationBatch(); len(req.Strategies) > max {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("at most %d strategies can be validated per batch", max), "")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.scheduler.ValidateStrategies(r.Context(), req.Strategies))
+}
+
+// SetStrategyValidationRequest represents the request body for recording a
+// strategy's validation outcome.
+type SetStrategyValidationRequest struct {
+	Status domain.ValidationStatus `json:"status"`
+	Errors []string                `json:"errors,omitempty"`
+}
+
+// HandleStrategyValidation records a strategy's validation outcome. PUT stores
+// an outcome determined elsewhere (e.g. by the Scout importer); POST validates
+// the stored code in a container and stores the result.
+// Backtests of strategies marked validation_failed are rejected on submission.
+// PUT|POST /api/v1/strategies/:id/validation
+func (h *Handler) HandleStrategyValidation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"), "")
+		return
+	}
+
+	// Extract ID from path like /api/v1/strategies/:id/validation
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/strategies/")
+	idStr := strings.TrimSuffix(path, "/validation")
+
+	id, err := parseUUID(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "invalid strategy id")
+		return
+	}
+
+	strategy, err := h.repos.Strategy.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err, "strategy not found")
+			return
+		}
+		h.logger.Error("Failed to get strategy", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to get strategy")
+		return
+	}
+
+	var req SetStrategyValidationRequest
+	if r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err, "invalid request body")
+			return
+		}
+		if !req.Status.IsValid() {
+			writeError(w, http.StatusBadRequest, domain.ErrInvalidInput, "invalid status")
+			return
+		}
+	} else {
+		if h.scheduler == nil {
+			writeError(w, http.StatusServiceUnavailable, errors.New("strategy validation is unavailable"), "")
+			return
+		}
+		batch := h.scheduler.ValidateStrategies(r.Context(), []scheduler.StrategyValidationItem{
+			{Name: strategy.Name, Code: strategy.Code},
+		})
+		outcome := batch.Results[0]
+		if outcome.Error != "" || outcome.TimedOut {
+			// Infrastructure trouble says nothing about the code, so leave the status alone
+			writeError(w, http.StatusServiceUnavailable, errors.New("strategy could not be validated"), outcome.Error)
+			return
+		}
+		req.Status = domain.ValidationStatusValidated
+		if !outcome.Valid {
+			req.Status = domain.ValidationStatusFailed
+		}
+		req.Errors = outcome.Errors
+	}
+
+	if err := h.repos.Strategy.SetValidation(r.Context(), id, req.Status, req.Errors); err != nil {
+		h.logger.Error("Failed to set strategy validation", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err, "failed to set strategy validation")
+		return
+	}
+
+	now := time.Now()
+	strategy.ValidationStatus = req.Status
+	strategy.ValidationErrors = req.Errors
+	strategy.ValidatedAt = &now
+
+	writeJSON(w, http.StatusOK, GetStrategyResponse{Strategy: strategy})
+}
+
+// UnsafeCodeResponse represents the response for strategy code rejected by
+// the safety checks.
+type UnsafeCodeResponse struct {
+	Error      string                   `json:"error"`
+	Message    string                   `json:"message"`
+	Violations []domain.SafetyViolation `json:"violations"`
+}
+
+// checkCodeSafety runs the safety checks on strategy code about to be stored,
+// writing a 422 response listing the violations if it fails.
+func (h *Handler) checkCodeSafety(w http.ResponseWriter, code string) bool {
+	var unsafe *domain.UnsafeCodeError
+	if err := h.codeSafety.Verify(code); errors.As(err, &unsafe) {
+		writeJSON(w, http.StatusUnprocessableEntity, UnsafeCodeResponse{
+			Error:      unsafe.Error(),
+			Message:    "strategy code failed safety checks",
+			Violations: unsafe.Violations,
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// errOverloaded is returned to clients when a request is shed.
+var errOverloaded = errors.New("server overloaded")
+
+// loadShedder rejects API requests with 503 once concurrency limits are reached,
+// instead of queueing them until the database pool is exhausted.
+type loadShedder struct {
+	global     chan struct{} // nil when the global limit is disabled
+	endpoints  []endpointLimit
+	retryAfter string
+	logger     *zap.Logger
+
+	inFlight  atomic.Int64
+	shedTotal atomic.Int64
+}
+
+// endpointLimit is a concurrency limit for a path prefix.
+type endpointLimit struct {
+	prefix string
+	sem    chan struct{}
+}
+
+// newLoadShedder creates a load shedder from configuration.
+func newLoadShedder(cfg *config.LoadSheddingConfig, logger *zap.Logger) *loadShedder {
+	l := &loadShedder{
+		retryAfter: strconv.Itoa(cfg.RetryAfterSeconds),
+		logger:     logger,
+	}
+
+	if cfg.MaxInFlight > 0 {
+		l.global = make(chan struct{}, cfg.MaxInFlight)
+	}
+
+	for prefix, limit := range cfg.EndpointLimits {
+		if limit <= 0 {
+			continue
+		}
+		l.endpoints = append(l.endpoints, endpointLimit{
+			prefix: prefix,
+			sem:    make(chan struct{}, limit),
+		})
+	}
+
+	// Longest prefix first so the most specific limit wins
+	sort.Slice(l.endpoints, func(i, j int) bool {
+		return len(l.endpoints[i].prefix) > len(l.endpoints[j].prefix)
+	})
+
+	return l
+}
+
+// middleware wraps next with load shedding. Only REST API requests are limited;
+// health checks, metrics, WebSocket upgrades, followed job logs, and static
+// files always pass.
+func (l *loadShedder) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.applies(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if endpoint := l.match(r.URL.Path); endpoint != nil {
+			if !tryAcquire(endpoint.sem) {
+				l.reject(w, r, endpoint.prefix)
+				return
+			}
+			defer release(endpoint.sem)
+		}
+
+		if l.global != nil {
+			if !tryAcquire(l.global) {
+				l.reject(w, r, "global")
+				return
+			}
+			defer release(l.global)
+		}
+
+		l.inFlight.Add(1)
+		defer l.inFlight.Add(-1)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// applies reports whether the request is subject to load shedding. Like
+// WebSocket connections, followed job logs stay open while the job runs, so
+// they would hold a slot throughout.
+func (l *loadShedder) applies(r *http.Request) bool {
+	path := r.URL.Path
+	return strings.HasPrefix(path, "/api/") && !strings.HasPrefix(path, "/api/v1/ws/") && !isLogFollow(r)
+}
+
+// isLogFollow reports whether a request follows the logs of a backtest job.
+func isLogFollow(r *http.Request) bool {
+	if !strings.HasPrefix(r.URL.Path, "/api/v1/backtests/") || !strings.HasSuffix(r.URL.Path, "/logs") {
+		return false
+	}
+	follow, _ := strconv.ParseBool(r.URL.Query().Get("follow"))
+	return follow
+}
+
+// match returns the most specific endpoint limit for the path, if any.
+func (l *loadShedder) match(path string) *endpointLimit {
+	for i := range l.endpoints {
+		if strings.HasPrefix(path, l.endpoints[i].prefix) {
+			return &l.endpoints[i]
+		}
+	}
+	return nil
+}
+
+// reject writes a fast 503 response with a Retry-After hint.
+func (l *loadShedder) reject(w http.ResponseWriter, r *http.Request, limit string) {
+	l.shedTotal.Add(1)
+	l.logger.Debug("Shedding request",
+		zap.String("method", r.Method),
+		zap.String("path", r.URL.Path),
+		zap.String("limit", limit),
+	)
+
+	w.Header().Set("Retry-After", l.retryAfter)
+	writeError(w, http.StatusServiceUnavailable, errOverloaded, "too many concurrent requests, retry later")
+}
+
+// metrics returns the current load shedding metrics.
+func (l *loadShedder) metrics() LoadSheddingMetrics {
+	return LoadSheddingMetrics{
+		InFlight:    l.inFlight.Load(),
+		MaxInFlight: cap(l.global),
+		ShedTotal:   l.shedTotal.Load(),
+	}
+}
+
+// tryAcquire takes a slot from the semaphore without blocking.
+func tryAcquire(sem chan struct{}) bool {
+	select {
+	case sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release returns a slot to the semaphore.
+func release(sem chan struct{}) {
+	<-sem
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// blockingHandler holds requests open until released.
+type blockingHandler struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{
+		entered: make(chan struct{}, 16),
+		release: make(chan struct{}),
+	}
+}
+
+func (b *blockingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.entered <- struct{}{}
+	<-b.release
+	w.WriteHeader(http.StatusOK)
+}
+
+// startBlocked issues a request in the background and waits until it is being handled.
+func startBlocked(t *testing.T, h http.Handler, b *blockingHandler, path string, wg *sync.WaitGroup) {
+	t.Helper()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}()
+	<-b.entered
+}
+
+func serve(h http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestLoadShedder_GlobalLimit(t *testing.T) {
+	shedder := newLoadShedder(&config.LoadSheddingConfig{
+		MaxInFlight:       1,
+		RetryAfterSeconds: 3,
+	}, zap.NewNop())
+	backend := newBlockingHandler()
+	h := shedder.middleware(backend)
+
+	var wg sync.WaitGroup
+	startBlocked(t, h, backend, "/api/v1/strategies", &wg)
+
+	rec := serve(h, "/api/v1/backtests")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("Retry-After"))
+	assert.Equal(t, int64(1), shedder.metrics().InFlight)
+	assert.Equal(t, int64(1), shedder.metrics().ShedTotal)
+
+	close(backend.release)
+	wg.Wait()
+
+	assert.Equal(t, http.StatusOK, serve(h, "/api/v1/backtests").Code)
+	assert.Equal(t, int64(0), shedder.metrics().InFlight)
+}
+
+func TestLoadShedder_EndpointLimit(t *testing.T) {
+	shedder := newLoadShedder(&config.LoadSheddingConfig{
+		EndpointLimits: map[string]int{
+			"/api/v1/":           10,
+			"/api/v1/dashboard/": 1,
+		},
+	}, zap.NewNop())
+	backend := newBlockingHandler()
+	h := shedder.middleware(backend)
+
+	var wg sync.WaitGroup
+	startBlocked(t, h, backend, "/api/v1/dashboard/summary", &wg)
+
+	// The most specific prefix is saturated
+	assert.Equal(t, http.StatusServiceUnavailable, serve(h, "/api/v1/dashboard/summary").Code)
+
+	// Other endpoints are unaffected
+	startBlocked(t, h, backend, "/api/v1/strategies", &wg)
+
+	close(backend.release)
+	wg.Wait()
+}
+
+func TestLoadShedder_ExemptPaths(t *testing.T) {
+	shedder := newLoadShedder(&config.LoadSheddingConfig{MaxInFlight: 1}, zap.NewNop())
+	backend := newBlockingHandler()
+	h := shedder.middleware(backend)
+
+	var wg sync.WaitGroup
+	startBlocked(t, h, backend, "/api/v1/strategies", &wg)
+
+	// Health checks, WebSocket upgrades, followed job logs, and static files
+	// bypass the limits
+	startBlocked(t, h, backend, "/health/ready", &wg)
+	startBlocked(t, h, backend, "/api/v1/ws/events", &wg)
+	startBlocked(t, h, backend, "/api/v1/backtests/abc/logs?follow=true", &wg)
+	startBlocked(t, h, backend, "/index.html", &wg)
+
+	// Reading logs without following them is limited
+	assert.Equal(t, http.StatusServiceUnavailable, serve(h, "/api/v1/backtests/abc/logs").Code)
+
+	close(backend.release)
+	wg.Wait()
+	assert.Equal(t, int64(1), shedder.metrics().ShedTotal)
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// maintenanceRetryAfter is the Retry-After hint, in seconds, of submissions
+// rejected in maintenance mode.
+const maintenanceRetryAfter = "300"
+
+// submissionPaths are the endpoints that take on new work when POSTed to.
+// Everything else, reads in particular, keeps working in maintenance mode.
+var submissionPaths = map[string]bool{
+	"/api/v1/strategies":                true,
+	"/api/v1/strategies/validate-batch": true,
+	"/api/v1/backtests":                 true,
+	"/api/v1/optimizations":             true,
+	"/api/v1/campaigns":                 true,
+	"/api/v1/exports":                   true,
+	"/api/v1/hyperopt":                  true,
+	"/api/v1/data/downloads":            true,
+	"/api/v1/admin/revalidations":       true,
+	"/api/v1/agents/scout/trigger":      true,
+}
+
+// isSubmission reports whether the request submits new work.
+func isSubmission(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if submissionPaths[path] {
+		return true
+	}
+	return strings.HasPrefix(path, "/api/v1/backtests/") && strings.HasSuffix(path, "/resubmit")
+}
+
+// maintenanceMiddleware rejects submissions with 503 and the maintenance
+// message while maintenance mode is enabled.
+func maintenanceMiddleware(m MaintenanceInterface, logger *zap.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isSubmission(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		mode := m.Current()
+		if !mode.Enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		logger.Debug("Rejecting submission in maintenance mode",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+		)
+
+		w.Header().Set("Retry-After", maintenanceRetryAfter)
+		writeError(w, http.StatusServiceUnavailable, domain.ErrMaintenance, mode.RejectionMessage())
+	})
+}
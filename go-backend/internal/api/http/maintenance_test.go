@@ -0,0 +1,63 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// staticMaintenance reports a fixed maintenance mode.
+type staticMaintenance struct {
+	mode domain.MaintenanceMode
+}
+
+func (m *staticMaintenance) Current() domain.MaintenanceMode {
+	return m.mode
+}
+
+func (m *staticMaintenance) Set(ctx context.Context, enabled bool, message, by string) (*domain.MaintenanceMode, error) {
+	m.mode = domain.MaintenanceMode{Enabled: enabled, Message: message, ChangedBy: by}
+	return &m.mode, nil
+}
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	maintenance := &staticMaintenance{}
+	h := maintenanceMiddleware(maintenance, zap.NewNop(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	do := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/v1/backtests").Code)
+
+	maintenance.mode = domain.MaintenanceMode{Enabled: true, Message: "Database upgrade"}
+
+	// Submissions are rejected with the message
+	for _, path := range []string{
+		"/api/v1/backtests",
+		"/api/v1/backtests/",
+		"/api/v1/backtests/6f1c2d3e-0000-4000-8000-000000000000/resubmit",
+		"/api/v1/strategies",
+		"/api/v1/optimizations",
+		"/api/v1/agents/scout/trigger",
+	} {
+		rec := do(http.MethodPost, path)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code, path)
+		assert.Equal(t, maintenanceRetryAfter, rec.Header().Get("Retry-After"), path)
+		assert.Contains(t, rec.Body.String(), "Database upgrade", path)
+	}
+
+	// Reads and changes to existing work keep working
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/v1/backtests").Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, "/api/v1/backtests/6f1c2d3e-0000-4000-8000-000000000000").Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodPut, "/api/v1/admin/maintenance").Code)
+}
@@ -0,0 +1,12 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// warnPageSizeClamped tells the client with a Warning header that its
+// requested page size exceeded the maximum and was lowered to pageSize.
+func warnPageSizeClamped(w http.ResponseWriter, requested, pageSize int) {
+	w.Header().Add("Warning", fmt.Sprintf(`299 - "page_size %d exceeds the maximum of %d; pages hold %d items"`, requested, pageSize, pageSize))
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestPageSizeClamping(t *testing.T) {
+	domain.SetPageLimits(
+		domain.PageLimits{DefaultPageSize: 20, MaxPageSize: 100},
+		map[string]domain.PageLimits{domain.PageResourceResults: {DefaultPageSize: 50, MaxPageSize: 500}},
+	)
+	t.Cleanup(func() {
+		domain.SetPageLimits(domain.PageLimits{DefaultPageSize: domain.DefaultPageSize, MaxPageSize: domain.DefaultMaxPageSize}, nil)
+	})
+
+	q := domain.StrategySearchQuery{}
+	assert.False(t, q.SetDefaults())
+	assert.Equal(t, 1, q.Page)
+	assert.Equal(t, 20, q.PageSize)
+
+	q = domain.StrategySearchQuery{PageSize: 1000}
+	assert.True(t, q.SetDefaults())
+	assert.Equal(t, 100, q.PageSize)
+
+	// Per-resource overrides
+	r := domain.BacktestResultQuery{}
+	assert.False(t, r.SetDefaults())
+	assert.Equal(t, 50, r.PageSize)
+
+	r = domain.BacktestResultQuery{PageSize: 300}
+	assert.False(t, r.SetDefaults())
+	assert.Equal(t, 300, r.PageSize)
+
+	w := httptest.NewRecorder()
+	warnPageSizeClamped(w, 1000, 100)
+	assert.Equal(t, `299 - "page_size 1000 exceeds the maximum of 100; pages hold 100 items"`, w.Header().Get("Warning"))
+}
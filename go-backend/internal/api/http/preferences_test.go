@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockPreferenceRepository keeps preferences in memory, keyed by owner and key.
+type mockPreferenceRepository struct {
+	prefs map[[2]string]*domain.UserPreference
+}
+
+func (m *mockPreferenceRepository) Get(ctx context.Context, owner, key string) (*domain.UserPreference, error) {
+	if p, ok := m.prefs[[2]string{owner, key}]; ok {
+		return p, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockPreferenceRepository) List(ctx context.Context, owner string) ([]*domain.UserPreference, error) {
+	var prefs []*domain.UserPreference
+	for k, p := range m.prefs {
+		if k[0] == owner {
+			prefs = append(prefs, p)
+		}
+	}
+	sort.Slice(prefs, func(i, j int) bool { return prefs[i].Key < prefs[j].Key })
+	return prefs, nil
+}
+
+func (m *mockPreferenceRepository) Upsert(ctx context.Context, pref *domain.UserPreference) error {
+	m.prefs[[2]string{pref.Owner, pref.Key}] = pref
+	return nil
+}
+
+func (m *mockPreferenceRepository) Delete(ctx context.Context, owner, key string) error {
+	if _, ok := m.prefs[[2]string{owner, key}]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(m.prefs, [2]string{owner, key})
+	return nil
+}
+
+func TestPreferenceHandlers(t *testing.T) {
+	repo := &mockPreferenceRepository{prefs: map[[2]string]*domain.UserPreference{}}
+	h := NewHandler(&repository.Repositories{Preference: repo}, nil, zaptest.NewLogger(t))
+
+	serve := func(handler http.HandlerFunc, method, target, user, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if user != "" {
+			req.Header.Set(userIDHeader, user)
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	// Unknown preferences are not found
+	rec := serve(h.HandleGetPreference, http.MethodGet, "/api/v1/preferences/default_page_size", "alice", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serve(h.HandleSetPreference, http.MethodPut, "/api/v1/preferences/default_page_size", "alice", `{"value": 50}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = serve(h.HandleSetPreference, http.MethodPut, "/api/v1/preferences/default_page_size", "bob", `{"value": 20}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Setting again replaces the value
+	rec = serve(h.HandleSetPreference, http.MethodPut, "/api/v1/preferences/dashboard_layout", "alice", `{"value": {"columns": 2}}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = serve(h.HandleSetPreference, http.MethodPut, "/api/v1/preferences/dashboard_layout", "alice", `{"value": {"columns": 3}}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var got PreferenceResponse
+	rec = serve(h.HandleGetPreference, http.MethodGet, "/api/v1/preferences/dashboard_layout", "alice", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, "alice", got.Preference.Owner)
+	assert.JSONEq(t, `{"columns": 3}`, string(got.Preference.Value))
+
+	// Each user only sees their own preferences
+	rec = serve(h.HandleGetPreference, http.MethodGet, "/api/v1/preferences/default_page_size", "bob", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.JSONEq(t, `20`, string(got.Preference.Value))
+
+	rec = serve(h.HandleGetPreference, http.MethodGet, "/api/v1/preferences/dashboard_layout", "bob", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var list ListPreferencesResponse
+	rec = serve(h.HandleListPreferences, http.MethodGet, "/api/v1/preferences", "alice", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	assert.Equal(t, "alice", list.Owner)
+	require.Len(t, list.Preferences, 2)
+	assert.Equal(t, "dashboard_layout", list.Preferences[0].Key)
+	assert.Equal(t, "default_page_size", list.Preferences[1].Key)
+
+	// Requests without a user share the default owner
+	rec = serve(h.HandleListPreferences, http.MethodGet, "/api/v1/preferences", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	assert.Equal(t, domain.DefaultPreferenceOwner, list.Owner)
+	assert.Empty(t, list.Preferences)
+
+	// Deleting only removes the caller's preference
+	rec = serve(h.HandleDeletePreference, http.MethodDelete, "/api/v1/preferences/default_page_size", "alice", "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = serve(h.HandleGetPreference, http.MethodGet, "/api/v1/preferences/default_page_size", "alice", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = serve(h.HandleGetPreference, http.MethodGet, "/api/v1/preferences/default_page_size", "bob", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandleSetPreference_Invalid(t *testing.T) {
+	repo := &mockPreferenceRepository{prefs: map[[2]string]*domain.UserPreference{}}
+	h := NewHandler(&repository.Repositories{Preference: repo}, nil, zaptest.NewLogger(t))
+
+	for _, tc := range []struct {
+		name, target, body string
+	}{
+		{"invalid key", "/api/v1/preferences/bad%20key", `{"value": 1}`},
+		{"missing value", "/api/v1/preferences/default_page_size", `{}`},
+		{"malformed body", "/api/v1/preferences/default_page_size", `{"value":`},
+		{"oversized value", "/api/v1/preferences/default_page_size", `{"value": "` + strings.Repeat("x", domain.MaxPreferenceValueSize) + `"}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.HandleSetPreference(rec, httptest.NewRequest(http.MethodPut, tc.target, strings.NewReader(tc.body)))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+	assert.Empty(t, repo.prefs)
+}
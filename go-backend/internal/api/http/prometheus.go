@@ -0,0 +1,103 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// prometheusContentType is the Prometheus text exposition format content type.
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// writeMetric writes a single unlabelled metric in Prometheus text format.
+func writeMetric(w io.Writer, name, metricType, help string, value float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+	fmt.Fprintf(w, "%s %g\n", name, value)
+}
+
+// handlePrometheusMetrics exposes database pool, query, load shedding, and
+// queue SLA metrics in Prometheus text format.
+// GET /metrics/prometheus
+func (s *Server) handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", prometheusContentType)
+
+	// Database pool
+	if s.pool != nil {
+		stat := s.pool.Stat()
+		writeMetric(w, "freqsearch_db_pool_total_conns", "gauge", "Total number of connections in the pool.", float64(stat.TotalConns()))
+		writeMetric(w, "freqsearch_db_pool_acquired_conns", "gauge", "Number of currently acquired connections.", float64(stat.AcquiredConns()))
+		writeMetric(w, "freqsearch_db_pool_idle_conns", "gauge", "Number of currently idle connections.", float64(stat.IdleConns()))
+		writeMetric(w, "freqsearch_db_pool_constructing_conns", "gauge", "Number of connections being constructed.", float64(stat.ConstructingConns()))
+		writeMetric(w, "freqsearch_db_pool_max_conns", "gauge", "Maximum size of the pool.", float64(stat.MaxConns()))
+		writeMetric(w, "freqsearch_db_pool_acquire_count_total", "counter", "Cumulative count of successful acquires.", float64(stat.AcquireCount()))
+		writeMetric(w, "freqsearch_db_pool_acquire_duration_seconds_total", "counter", "Total time spent acquiring connections.", stat.AcquireDuration().Seconds())
+		writeMetric(w, "freqsearch_db_pool_empty_acquire_count_total", "counter", "Cumulative count of acquires that waited for a connection.", float64(stat.EmptyAcquireCount()))
+		writeMetric(w, "freqsearch_db_pool_canceled_acquire_count_total", "counter", "Cumulative count of acquires canceled by context.", float64(stat.CanceledAcquireCount()))
+		writeMetric(w, "freqsearch_db_pool_new_conns_total", "counter", "Cumulative count of new connections opened.", float64(stat.NewConnsCount()))
+		writeMetric(w, "freqsearch_db_pool_max_lifetime_destroy_total", "counter", "Connections closed for exceeding max lifetime.", float64(stat.MaxLifetimeDestroyCount()))
+		writeMetric(w, "freqsearch_db_pool_max_idle_destroy_total", "counter", "Connections closed for exceeding max idle time.", float64(stat.MaxIdleDestroyCount()))
+
+		stats := s.pool.QueryStats()
+		writeMetric(w, "freqsearch_db_queries_in_flight", "gauge", "Number of queries currently executing.", float64(stats.InFlight))
+		writeMetric(w, "freqsearch_db_queries_total", "counter", "Cumulative count of completed queries.", float64(stats.TotalQueries))
+		writeMetric(w, "freqsearch_db_queries_failed_total", "counter", "Cumulative count of queries that returned an error.", float64(stats.FailedTotal))
+		writeMetric(w, "freqsearch_db_slow_queries_total", "counter", "Cumulative count of queries slower than the slow query threshold.", float64(stats.SlowTotal))
+	}
+
+	// HTTP load shedding
+	if s.shedder != nil {
+		metrics := s.shedder.metrics()
+		writeMetric(w, "freqsearch_http_requests_in_flight", "gauge", "Number of API requests currently being served.", float64(metrics.InFlight))
+		writeMetric(w, "freqsearch_http_requests_shed_total", "counter", "Cumulative count of API requests rejected by load shedding.", float64(metrics.ShedTotal))
+	}
+
+	// HTTP response cache
+	if s.cache != nil {
+		metrics := s.cache.metrics()
+		writeMetric(w, "freqsearch_http_cache_entries", "gauge", "Number of cached API responses.", float64(metrics.Entries))
+		writeMetric(w, "freqsearch_http_cache_hits_total", "counter", "Cumulative count of API requests served from the response cache.", float64(metrics.Hits))
+		writeMetric(w, "freqsearch_http_cache_misses_total", "counter", "Cumulative count of cacheable API requests not served from the cache.", float64(metrics.Misses))
+		writeMetric(w, "freqsearch_http_cache_invalidations_total", "counter", "Cumulative count of cached API responses dropped by writes and events.", float64(metrics.Invalidations))
+	}
+
+	// Queue SLA
+	if s.slaMonitor != nil {
+		status := s.slaMonitor.Status()
+		writeMetric(w, "freqsearch_sla_queue_wait_breaches", "gauge", "Number of pending jobs exceeding the queue wait target.", float64(status.QueueWaitBreaches))
+		writeMetric(w, "freqsearch_sla_run_duration_breaches", "gauge", "Number of running jobs exceeding the run duration target.", float64(status.RunDurationBreaches))
+		writeMetric(w, "freqsearch_sla_queue_wait_breaches_total", "counter", "Cumulative count of jobs that breached the queue wait target.", float64(status.BreachesTotal[domain.SLAKindQueueWait]))
+		writeMetric(w, "freqsearch_sla_run_duration_breaches_total", "counter", "Cumulative count of jobs that breached the run duration target.", float64(status.BreachesTotal[domain.SLAKindRunDuration]))
+	}
+
+	// WebSocket
+	writeMetric(w, "freqsearch_websocket_clients", "gauge", "Number of connected WebSocket clients.", float64(s.wsHub.GetClientCount()))
+	if guard := s.wsHub.guard; guard != nil {
+		principals := guard.principals()
+		names := make([]string, 0, len(principals))
+		for name := range principals {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "# HELP freqsearch_websocket_principal_clients Number of connected WebSocket clients by principal.\n")
+		fmt.Fprintf(w, "# TYPE freqsearch_websocket_principal_clients gauge\n")
+		for _, name := range names {
+			fmt.Fprintf(w, "freqsearch_websocket_principal_clients{principal=%q} %d\n", name, principals[name])
+		}
+
+		rejected := guard.rejected()
+		fmt.Fprintf(w, "# HELP freqsearch_websocket_rejected_total Cumulative count of rejected WebSocket upgrades by reason.\n")
+		fmt.Fprintf(w, "# TYPE freqsearch_websocket_rejected_total counter\n")
+		for _, reason := range []string{wsRejectOrigin, wsRejectAuth, wsRejectLimit} {
+			fmt.Fprintf(w, "freqsearch_websocket_rejected_total{reason=%q} %d\n", reason, rejected[reason])
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// responseCache serves repeated GET requests for expensive read endpoints from
+// memory for a short TTL, so many dashboard users polling the same view cost
+// one query per TTL instead of one each.
+type responseCache struct {
+	endpoints  map[string]*cachedEndpoint // by exact path
+	maxEntries int
+	logger     *zap.Logger
+	now        func() time.Time
+
+	mu          sync.Mutex
+	entries     map[string]*cachedResponse
+	generations map[string]uint64 // by endpoint path, bumped on invalidation
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	invalidations atomic.Int64
+}
+
+// cachedEndpoint is the cache policy of one endpoint.
+type cachedEndpoint struct {
+	path          string
+	ttl           time.Duration
+	writePrefixes []string
+	events        map[string]bool
+}
+
+// cachedResponse is a stored 200 response.
+type cachedResponse struct {
+	endpoint  string
+	header    http.Header
+	body      []byte
+	storedAt  time.Time
+	expiresAt time.Time
+}
+
+// newResponseCache creates a response cache from configuration. Endpoints
+// with an invalid TTL are skipped; config validation rejects them upfront.
+func newResponseCache(cfg *config.ResponseCacheConfig, logger *zap.Logger) *responseCache {
+	c := &responseCache{
+		endpoints:   make(map[string]*cachedEndpoint, len(cfg.Endpoints)),
+		maxEntries:  cfg.MaxEntries,
+		logger:      logger,
+		now:         time.Now,
+		entries:     make(map[string]*cachedResponse),
+		generations: make(map[string]uint64),
+	}
+
+	for path, endpoint := range cfg.Endpoints {
+		ttl, err := time.ParseDuration(endpoint.TTL)
+		if err != nil || ttl <= 0 {
+			continue
+		}
+		events := make(map[string]bool, len(endpoint.InvalidateOnEvents))
+		for _, key := range endpoint.InvalidateOnEvents {
+			events[key] = true
+		}
+		c.endpoints[path] = &cachedEndpoint{
+			path:          path,
+			ttl:           ttl,
+			writePrefixes: endpoint.InvalidateOnWrites,
+			events:        events,
+		}
+	}
+
+	return c
+}
+
+// middleware wraps next with response caching. GET requests to cached
+// endpoints are served from the cache while fresh; successful writes drop
+// the cached responses of endpoints they invalidate.
+func (c *responseCache) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			endpoint := c.endpoints[r.URL.Path]
+			if endpoint == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			c.serve(w, r, next, endpoint)
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			if rec.status < http.StatusBadRequest {
+				c.invalidateWrite(r.URL.Path)
+			}
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// serve answers a GET request from the cache, or from next and stores the
+// response. A request with "Cache-Control: no-cache" skips the cached
+// response but refreshes it.
+func (c *responseCache) serve(w http.ResponseWriter, r *http.Request, next http.Handler, endpoint *cachedEndpoint) {
+	key := cacheKey(r)
+	now := c.now()
+
+	if !strings.Contains(r.Header.Get("Cache-Control"), "no-cache") {
+		if entry := c.get(key, now); entry != nil {
+			c.hits.Add(1)
+			for name, values := range entry.header {
+				w.Header()[name] = values
+			}
+			w.Header().Set("Cache-Control", cacheControl(entry.expiresAt.Sub(now)))
+			w.Header().Set("Age", strconv.Itoa(int(now.Sub(entry.storedAt).Seconds())))
+			w.Header().Set("X-Cache", "HIT")
+			w.WriteHeader(http.StatusOK)
+			w.Write(entry.body)
+			return
+		}
+	}
+
+	c.misses.Add(1)
+	w.Header().Set("Cache-Control", cacheControl(endpoint.ttl))
+	w.Header().Set("Age", "0")
+	w.Header().Set("X-Cache", "MISS")
+
+	generation := c.generation(endpoint.path)
+	rec := &cacheRecorder{statusRecorder: statusRecorder{ResponseWriter: w, status: http.StatusOK}}
+	next.ServeHTTP(rec, r)
+
+	if rec.status != http.StatusOK {
+		return
+	}
+
+	header := make(http.Header)
+	if contentType := w.Header().Get("Content-Type"); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	c.put(key, generation, &cachedResponse{
+		endpoint:  endpoint.path,
+		header:    header,
+		body:      rec.body.Bytes(),
+		storedAt:  now,
+		expiresAt: now.Add(endpoint.ttl),
+	})
+}
+
+// generation returns the invalidation generation of an endpoint.
+func (c *responseCache) generation(endpoint string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[endpoint]
+}
+
+// get returns the fresh response stored under key, if any.
+func (c *responseCache) get(key string, now time.Time) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entries[key]
+	if entry == nil {
+		return nil
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil
+	}
+	return entry
+}
+
+// put stores a response, evicting expired entries and then the oldest ones
+// once the cache is full. The response is dropped if its endpoint was
+// invalidated since generation, as it may predate the invalidating write.
+func (c *responseCache) put(key string, generation uint64, entry *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[entry.endpoint] != generation {
+		return
+	}
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if !entry.storedAt.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		for len(c.entries) >= c.maxEntries {
+			var oldestKey string
+			var oldest *cachedResponse
+			for k, e := range c.entries {
+				if oldest == nil || e.storedAt.Before(oldest.storedAt) {
+					oldestKey, oldest = k, e
+				}
+			}
+			delete(c.entries, oldestKey)
+		}
+	}
+
+	c.entries[key] = entry
+}
+
+// invalidateWrite drops the cached responses of endpoints invalidated by a
+// write to path.
+func (c *responseCache) invalidateWrite(path string) {
+	var endpoints []string
+	for _, endpoint := range c.endpoints {
+		for _, prefix := range endpoint.writePrefixes {
+			if strings.HasPrefix(path, prefix) {
+				endpoints = append(endpoints, endpoint.path)
+				break
+			}
+		}
+	}
+	c.invalidate(endpoints...)
+}
+
+// invalidateEvent drops the cached responses of endpoints invalidated by an
+// event with the given routing key.
+func (c *responseCache) invalidateEvent(routingKey string) {
+	var endpoints []string
+	for _, endpoint := range c.endpoints {
+		if endpoint.events[routingKey] {
+			endpoints = append(endpoints, endpoint.path)
+		}
+	}
+	c.invalidate(endpoints...)
+}
+
+// invalidate drops the cached responses of the given endpoint paths.
+func (c *responseCache) invalidate(endpoints ...string) {
+	if len(endpoints) == 0 {
+		return
+	}
+
+	drop := make(map[string]bool, len(endpoints))
+
+	c.mu.Lock()
+	for _, endpoint := range endpoints {
+		drop[endpoint] = true
+		c.generations[endpoint]++
+	}
+	dropped := 0
+	for key, entry := range c.entries {
+		if drop[entry.endpoint] {
+			delete(c.entries, key)
+			dropped++
+		}
+	}
+	c.mu.Unlock()
+
+	if dropped > 0 {
+		c.invalidations.Add(int64(dropped))
+		c.logger.Debug("Invalidated cached responses",
+			zap.Strings("endpoints", endpoints),
+			zap.Int("entries", dropped),
+		)
+	}
+}
+
+// metrics returns the current response cache metrics.
+func (c *responseCache) metrics() ResponseCacheMetrics {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	return ResponseCacheMetrics{
+		Entries:       entries,
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+}
+
+// cacheKey identifies a cached response. Query parameters are sorted, and the
+// requesting user is included since responses may depend on it (e.g. starred).
+func cacheKey(r *http.Request) string {
+	return requestOwner(r) + " " + r.URL.Path + "?" + r.URL.Query().Encode()
+}
+
+// cacheControl returns the Cache-Control value for a response fresh for d.
+// Responses are private since they can depend on the requesting user.
+func cacheControl(d time.Duration) string {
+	seconds := int(d.Seconds())
+	if seconds < 0 {
+		seconds = 0
+	}
+	return "private, max-age=" + strconv.Itoa(seconds)
+}
+
+// statusRecorder records the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code.
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// cacheRecorder records the status code and a copy of the body written by a
+// handler. Responses other than 200 are marked no-store.
+type cacheRecorder struct {
+	statusRecorder
+	body bytes.Buffer
+}
+
+// WriteHeader records the status code.
+func (r *cacheRecorder) WriteHeader(status int) {
+	if status != http.StatusOK {
+		r.Header().Set("Cache-Control", "no-store")
+		r.Header().Del("Age")
+		r.Header().Del("X-Cache")
+	}
+	r.statusRecorder.WriteHeader(status)
+}
+
+// Write records a copy of the body.
+func (r *cacheRecorder) Write(b []byte) (int, error) {
+	if r.status == http.StatusOK {
+		r.body.Write(b)
+	}
+	return r.ResponseWriter.Write(b)
+}
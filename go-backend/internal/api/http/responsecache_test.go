@@ -0,0 +1,132 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// countingHandler counts requests and answers with the count.
+type countingHandler struct {
+	calls  int
+	status int
+}
+
+func (c *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.calls++
+	w.Header().Set("Content-Type", "application/json")
+	if c.status != 0 {
+		w.WriteHeader(c.status)
+	}
+	w.Write([]byte(`{"calls":` + strconv.Itoa(c.calls) + `}`))
+}
+
+func newTestResponseCache(now *time.Time) *responseCache {
+	cache := newResponseCache(&config.ResponseCacheConfig{
+		Enabled:    true,
+		MaxEntries: 2,
+		Endpoints: map[string]config.CachedEndpointConfig{
+			"/api/v1/dashboard/summary": {
+				TTL:                "10s",
+				InvalidateOnWrites: []string{"/api/v1/strategies"},
+				InvalidateOnEvents: []string{"task.completed"},
+			},
+		},
+	}, zap.NewNop())
+	cache.now = func() time.Time { return *now }
+	return cache
+}
+
+func TestResponseCache_HitAndExpiry(t *testing.T) {
+	now := time.Now()
+	cache := newTestResponseCache(&now)
+	backend := &countingHandler{}
+	h := cache.middleware(backend)
+
+	rec := serve(h, "/api/v1/dashboard/summary")
+	assert.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+	assert.Equal(t, "private, max-age=10", rec.Header().Get("Cache-Control"))
+
+	now = now.Add(4 * time.Second)
+	rec = serve(h, "/api/v1/dashboard/summary")
+	assert.Equal(t, "HIT", rec.Header().Get("X-Cache"))
+	assert.Equal(t, "4", rec.Header().Get("Age"))
+	assert.Equal(t, "private, max-age=6", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `{"calls":1}`, rec.Body.String())
+	assert.Equal(t, 1, backend.calls)
+
+	// Different query strings and uncached paths go to the backend
+	serve(h, "/api/v1/dashboard/summary?range=7d")
+	serve(h, "/api/v1/backtests")
+	assert.Equal(t, 3, backend.calls)
+
+	now = now.Add(7 * time.Second)
+	rec = serve(h, "/api/v1/dashboard/summary")
+	assert.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+	assert.Equal(t, 4, backend.calls)
+
+	metrics := cache.metrics()
+	assert.Equal(t, int64(1), metrics.Hits)
+	assert.Equal(t, int64(3), metrics.Misses)
+}
+
+func TestResponseCache_Invalidation(t *testing.T) {
+	now := time.Now()
+	cache := newTestResponseCache(&now)
+	backend := &countingHandler{}
+	h := cache.middleware(backend)
+
+	serve(h, "/api/v1/dashboard/summary")
+
+	// A write elsewhere keeps the cached response
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/campaigns", nil))
+	assert.Equal(t, "HIT", serve(h, "/api/v1/dashboard/summary").Header().Get("X-Cache"))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/strategies", nil))
+	assert.Equal(t, "MISS", serve(h, "/api/v1/dashboard/summary").Header().Get("X-Cache"))
+
+	cache.invalidateEvent("task.completed")
+	assert.Equal(t, "MISS", serve(h, "/api/v1/dashboard/summary").Header().Get("X-Cache"))
+
+	// Failed writes don't invalidate
+	backend.status = http.StatusBadRequest
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/strategies", nil))
+	backend.status = 0
+	assert.Equal(t, "HIT", serve(h, "/api/v1/dashboard/summary").Header().Get("X-Cache"))
+}
+
+func TestResponseCache_ErrorsNotCached(t *testing.T) {
+	now := time.Now()
+	cache := newTestResponseCache(&now)
+	backend := &countingHandler{status: http.StatusInternalServerError}
+	h := cache.middleware(backend)
+
+	rec := serve(h, "/api/v1/dashboard/summary")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	serve(h, "/api/v1/dashboard/summary")
+	assert.Equal(t, 2, backend.calls)
+}
+
+func TestResponseCache_Eviction(t *testing.T) {
+	now := time.Now()
+	cache := newTestResponseCache(&now)
+	h := cache.middleware(&countingHandler{})
+
+	for _, query := range []string{"a", "b", "c"} {
+		now = now.Add(time.Second)
+		serve(h, "/api/v1/dashboard/summary?q="+query)
+	}
+
+	assert.Equal(t, 2, cache.metrics().Entries)
+	assert.Equal(t, "MISS", serve(h, "/api/v1/dashboard/summary?q=a").Header().Get("X-Cache"), "oldest entry is evicted")
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// resultCSVColumns are the columns of a backtest result CSV export. Pair
+// results, exit and entry breakdowns and installed packages are nested, so
+// they are left out; fetch them from the result endpoints.
+var resultCSVColumns = []string{
+	"id", "job_id", "strategy_id",
+	"total_trades", "winning_trades", "losing_trades", "win_rate",
+	"profit_total", "profit_pct", "profit_factor",
+	"max_drawdown", "max_drawdown_pct", "sharpe_ratio", "sortino_ratio", "calmar_ratio",
+	"avg_trade_duration_minutes", "avg_profit_per_trade", "best_trade_pct", "worst_trade_pct",
+	"stoploss_exit_pct", "trailing_stop_exit_pct",
+	"stake_currency", "reference_currency", "reference_rate", "profit_total_normalized",
+	"freqtrade_version", "image_digest", "host",
+	"superseded_by", "created_at",
+}
+
+// resultCSVRecord returns the fields of a result in resultCSVColumns order.
+// Metrics that are not set are left empty.
+func resultCSVRecord(r *domain.BacktestResult) []string {
+	var env domain.ExecutionEnvironment
+	if r.Environment != nil {
+		env = *r.Environment
+	}
+	supersededBy := ""
+	if r.SupersededBy != nil {
+		supersededBy = r.SupersededBy.String()
+	}
+
+	return []string{
+		r.ID.String(), r.JobID.String(), r.StrategyID.String(),
+		strconv.Itoa(r.TotalTrades), strconv.Itoa(r.WinningTrades), strconv.Itoa(r.LosingTrades), csvFloat(r.WinRate),
+		csvFloat(r.ProfitTotal), csvFloat(r.ProfitPct), csvOptionalFloat(r.ProfitFactor),
+		csvFloat(r.MaxDrawdown), csvFloat(r.MaxDrawdownPct), csvOptionalFloat(r.SharpeRatio), csvOptionalFloat(r.SortinoRatio), csvOptionalFloat(r.CalmarRatio),
+		csvOptionalFloat(r.AvgTradeDurationMinutes), csvOptionalFloat(r.AvgProfitPerTrade), csvOptionalFloat(r.BestTradePct), csvOptionalFloat(r.WorstTradePct),
+		csvOptionalFloat(r.StoplossExitPct), csvOptionalFloat(r.TrailingStopExitPct),
+		csvOptionalString(r.StakeCurrency), csvOptionalString(r.ReferenceCurrency), csvOptionalFloat(r.ReferenceRate), csvOptionalFloat(r.ProfitTotalNormalized),
+		env.FreqtradeVersion, env.ImageDigest, env.Host,
+		supersededBy, r.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// csvFloat formats a float with as many digits as needed to read it back.
+func csvFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// csvOptionalFloat formats a float, or returns an empty field if it is nil.
+func csvOptionalFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return csvFloat(*v)
+}
+
+// csvOptionalString returns a string, or an empty field if it is nil.
+func csvOptionalString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// resultCSVWriter writes a result export as CSV, starting with a header row.
+type resultCSVWriter struct {
+	cw          *csv.Writer
+	wroteHeader bool
+}
+
+func (w *resultCSVWriter) WritePage(results []*domain.BacktestResult) error {
+	if !w.wroteHeader {
+		if err := w.cw.Write(resultCSVColumns); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	for _, result := range results {
+		if err := w.cw.Write(resultCSVRecord(result)); err != nil {
+			return err
+		}
+	}
+	w.cw.Flush()
+	return w.cw.Error()
+}
+
+func (w *resultCSVWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockResultRepository pages through a fixed list of results ordered by ID.
+type mockResultRepository struct {
+	repository.BacktestResultRepository
+	results []*domain.BacktestResult
+	queries []domain.BacktestResultQuery
+}
+
+func (m *mockResultRepository) ListAfterID(ctx context.Context, query domain.BacktestResultQuery, afterID *uuid.UUID, limit int) ([]*domain.BacktestResult, error) {
+	m.queries = append(m.queries, query)
+	start := 0
+	if afterID != nil {
+		for i, r := range m.results {
+			if r.ID == *afterID {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+limit, len(m.results))
+	return m.results[start:end], nil
+}
+
+func TestResultCSVRecord(t *testing.T) {
+	sharpe := 1.25
+	currency := "USDT"
+	result := &domain.BacktestResult{
+		ID:            uuid.New(),
+		JobID:         uuid.New(),
+		StrategyID:    uuid.New(),
+		TotalTrades:   10,
+		WinRate:       0.6,
+		ProfitPct:     12.5,
+		SharpeRatio:   &sharpe,
+		StakeCurrency: &currency,
+		Environment:   &domain.ExecutionEnvironment{Host: "worker-1"},
+		CreatedAt:     time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	record := resultCSVRecord(result)
+	require.Len(t, record, len(resultCSVColumns))
+	field := func(column string) string {
+		for i, c := range resultCSVColumns {
+			if c == column {
+				return record[i]
+			}
+		}
+		t.Fatalf("unknown column %s", column)
+		return ""
+	}
+	assert.Equal(t, result.ID.String(), field("id"))
+	assert.Equal(t, "10", field("total_trades"))
+	assert.Equal(t, "0.6", field("win_rate"))
+	assert.Equal(t, "1.25", field("sharpe_ratio"))
+	assert.Empty(t, field("sortino_ratio"))
+	assert.Equal(t, "USDT", field("stake_currency"))
+	assert.Equal(t, "worker-1", field("host"))
+	assert.Empty(t, field("superseded_by"))
+	assert.Equal(t, "2024-06-01T12:00:00Z", field("created_at"))
+}
+
+func TestHandleExportBacktestResults(t *testing.T) {
+	repo := &mockResultRepository{}
+	for i := 0; i < resultExportBatchSize+2; i++ {
+		repo.results = append(repo.results, &domain.BacktestResult{ID: uuid.New(), CreatedAt: time.Now()})
+	}
+	h := NewHandler(&repository.Repositories{Result: repo}, nil, zaptest.NewLogger(t))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/backtests/export?format=csv&min_sharpe=1.5&page_size=10", nil)
+	rec := httptest.NewRecorder()
+	h.HandleExportBacktestResults(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, len(repo.results)+1)
+	assert.Equal(t, resultCSVColumns, rows[0])
+	assert.Equal(t, repo.results[len(repo.results)-1].ID.String(), rows[len(rows)-1][0])
+
+	// Every page has the filters; page_size does not limit the export
+	require.Len(t, repo.queries, 2)
+	require.NotNil(t, repo.queries[1].MinSharpe)
+	assert.Equal(t, 1.5, *repo.queries[1].MinSharpe)
+
+	rec = httptest.NewRecorder()
+	h.HandleExportBacktestResults(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/export?format=xlsx", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/parquet"
+)
+
+// resultParquetColumns are the columns of a backtest result Parquet export,
+// named and ordered as resultCSVColumns. Unset metrics, and the environment
+// of results recorded without one, are null.
+var resultParquetColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "job_id", Type: parquet.String},
+	{Name: "strategy_id", Type: parquet.String},
+	{Name: "total_trades", Type: parquet.Int64},
+	{Name: "winning_trades", Type: parquet.Int64},
+	{Name: "losing_trades", Type: parquet.Int64},
+	{Name: "win_rate", Type: parquet.Double},
+	{Name: "profit_total", Type: parquet.Double},
+	{Name: "profit_pct", Type: parquet.Double},
+	{Name: "profit_factor", Type: parquet.Double, Optional: true},
+	{Name: "max_drawdown", Type: parquet.Double},
+	{Name: "max_drawdown_pct", Type: parquet.Double},
+	{Name: "sharpe_ratio", Type: parquet.Double, Optional: true},
+	{Name: "sortino_ratio", Type: parquet.Double, Optional: true},
+	{Name: "calmar_ratio", Type: parquet.Double, Optional: true},
+	{Name: "avg_trade_duration_minutes", Type: parquet.Double, Optional: true},
+	{Name: "avg_profit_per_trade", Type: parquet.Double, Optional: true},
+	{Name: "best_trade_pct", Type: parquet.Double, Optional: true},
+	{Name: "worst_trade_pct", Type: parquet.Double, Optional: true},
+	{Name: "stoploss_exit_pct", Type: parquet.Double, Optional: true},
+	{Name: "trailing_stop_exit_pct", Type: parquet.Double, Optional: true},
+	{Name: "stake_currency", Type: parquet.String, Optional: true},
+	{Name: "reference_currency", Type: parquet.String, Optional: true},
+	{Name: "reference_rate", Type: parquet.Double, Optional: true},
+	{Name: "profit_total_normalized", Type: parquet.Double, Optional: true},
+	{Name: "freqtrade_version", Type: parquet.String, Optional: true},
+	{Name: "image_digest", Type: parquet.String, Optional: true},
+	{Name: "host", Type: parquet.String, Optional: true},
+	{Name: "superseded_by", Type: parquet.String, Optional: true},
+	{Name: "created_at", Type: parquet.Timestamp},
+}
+
+// resultParquetRow returns the values of a result in resultParquetColumns
+// order.
+func resultParquetRow(r *domain.BacktestResult) []any {
+	var freqtradeVersion, imageDigest, host any
+	if r.Environment != nil {
+		freqtradeVersion, imageDigest, host = r.Environment.FreqtradeVersion, r.Environment.ImageDigest, r.Environment.Host
+	}
+	var supersededBy any
+	if r.SupersededBy != nil {
+		supersededBy = r.SupersededBy.String()
+	}
+
+	return []any{
+		r.ID.String(), r.JobID.String(), r.StrategyID.String(),
+		int64(r.TotalTrades), int64(r.WinningTrades), int64(r.LosingTrades), r.WinRate,
+		r.ProfitTotal, r.ProfitPct, parquetOptional(r.ProfitFactor),
+		r.MaxDrawdown, r.MaxDrawdownPct, parquetOptional(r.SharpeRatio), parquetOptional(r.SortinoRatio), parquetOptional(r.CalmarRatio),
+		parquetOptional(r.AvgTradeDurationMinutes), parquetOptional(r.AvgProfitPerTrade), parquetOptional(r.BestTradePct), parquetOptional(r.WorstTradePct),
+		parquetOptional(r.StoplossExitPct), parquetOptional(r.TrailingStopExitPct),
+		parquetOptional(r.StakeCurrency), parquetOptional(r.ReferenceCurrency), parquetOptional(r.ReferenceRate), parquetOptional(r.ProfitTotalNormalized),
+		freqtradeVersion, imageDigest, host,
+		supersededBy, r.CreatedAt,
+	}
+}
+
+// parquetOptional returns the value v points to, or nil for a null if v is
+// nil.
+func parquetOptional[T any](v *T) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// resultParquetWriter writes a result export as Parquet, one row group per
+// page.
+type resultParquetWriter struct {
+	pw *parquet.Writer
+}
+
+func (w *resultParquetWriter) WritePage(results []*domain.BacktestResult) error {
+	rows := make([][]any, len(results))
+	for i, result := range results {
+		rows[i] = resultParquetRow(result)
+	}
+	return w.pw.WriteRowGroup(rows)
+}
+
+func (w *resultParquetWriter) Close() error {
+	return w.pw.Close()
+}
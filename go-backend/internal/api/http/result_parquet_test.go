@@ -0,0 +1,108 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/parquet"
+)
+
+func TestResultParquetColumns(t *testing.T) {
+	require.Len(t, resultParquetColumns, len(resultCSVColumns))
+	for i, col := range resultParquetColumns {
+		assert.Equal(t, resultCSVColumns[i], col.Name)
+	}
+	assert.Len(t, resultParquetRow(&domain.BacktestResult{}), len(resultParquetColumns))
+}
+
+func TestHandleExportBacktestResults_Parquet(t *testing.T) {
+	sharpe := 1.25
+	currency := "USDT"
+	supersededBy := uuid.New()
+	first := &domain.BacktestResult{
+		ID:            uuid.New(),
+		JobID:         uuid.New(),
+		StrategyID:    uuid.New(),
+		TotalTrades:   10,
+		WinningTrades: 6,
+		WinRate:       0.6,
+		ProfitPct:     12.5,
+		SharpeRatio:   &sharpe,
+		StakeCurrency: &currency,
+		Environment:   &domain.ExecutionEnvironment{FreqtradeVersion: "2024.5", Host: "worker-1"},
+		SupersededBy:  &supersededBy,
+		CreatedAt:     time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	repo := &mockResultRepository{results: []*domain.BacktestResult{first}}
+	for i := 0; i < resultExportBatchSize+1; i++ {
+		repo.results = append(repo.results, &domain.BacktestResult{ID: uuid.New(), CreatedAt: time.Now()})
+	}
+	h := NewHandler(&repository.Repositories{Result: repo}, nil, zaptest.NewLogger(t))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/backtests/export?format=parquet&min_sharpe=1.5", nil)
+	rec := httptest.NewRecorder()
+	h.HandleExportBacktestResults(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/vnd.apache.parquet", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="backtest-results.parquet"`, rec.Header().Get("Content-Disposition"))
+	require.Len(t, repo.queries, 2)
+	require.NotNil(t, repo.queries[1].MinSharpe)
+
+	// The export is the results written a page at a time.
+	var want bytes.Buffer
+	pw := parquet.NewWriter(&want, resultParquetColumns)
+	for start := 0; start < len(repo.results); start += resultExportBatchSize {
+		var rows [][]any
+		for _, result := range repo.results[start:min(start+resultExportBatchSize, len(repo.results))] {
+			rows = append(rows, resultParquetRow(result))
+		}
+		require.NoError(t, pw.WriteRowGroup(rows))
+	}
+	require.NoError(t, pw.Close())
+	assert.Equal(t, want.Bytes(), rec.Body.Bytes())
+
+	field := func(row []any, column string) any {
+		for i, c := range resultCSVColumns {
+			if c == column {
+				return row[i]
+			}
+		}
+		t.Fatalf("unknown column %s", column)
+		return nil
+	}
+	row := resultParquetRow(first)
+	assert.Equal(t, first.ID.String(), field(row, "id"))
+	assert.Equal(t, int64(10), field(row, "total_trades"))
+	assert.Equal(t, 0.6, field(row, "win_rate"))
+	assert.Equal(t, 1.25, field(row, "sharpe_ratio"))
+	assert.Nil(t, field(row, "sortino_ratio"))
+	assert.Equal(t, "USDT", field(row, "stake_currency"))
+	assert.Equal(t, "2024.5", field(row, "freqtrade_version"))
+	assert.Equal(t, "", field(row, "image_digest"))
+	assert.Equal(t, supersededBy.String(), field(row, "superseded_by"))
+	assert.Equal(t, first.CreatedAt, field(row, "created_at"))
+	assert.Nil(t, field(resultParquetRow(repo.results[1]), "host"), "results without an environment have null environment columns")
+}
+
+func TestHandleExportBacktestResults_ParquetEmpty(t *testing.T) {
+	h := NewHandler(&repository.Repositories{Result: &mockResultRepository{}}, nil, zaptest.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	h.HandleExportBacktestResults(rec, httptest.NewRequest(http.MethodGet, "/api/v1/backtests/export?format=parquet", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var want bytes.Buffer
+	require.NoError(t, parquet.NewWriter(&want, resultParquetColumns).Close())
+	assert.Equal(t, want.Bytes(), rec.Body.Bytes())
+}
@@ -14,24 +14,37 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/saltfish/freqsearch/go-backend/internal/accesslog"
+	"github.com/saltfish/freqsearch/go-backend/internal/auth"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
 	"github.com/saltfish/freqsearch/go-backend/internal/db"
 	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 	"github.com/saltfish/freqsearch/go-backend/internal/events"
+	"github.com/saltfish/freqsearch/go-backend/internal/insights"
+	"github.com/saltfish/freqsearch/go-backend/internal/ranking"
 	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
 	"github.com/saltfish/freqsearch/go-backend/web"
 )
 
 // Server provides HTTP endpoints for health checks, metrics, REST API, and WebSocket.
 type Server struct {
-	server     *http.Server
-	pool       *db.Pool
-	scheduler  *scheduler.Scheduler
-	logger     *zap.Logger
-	handler    *Handler
-	wsHub      *Hub
-	subscriber events.Subscriber
-	agentStore *AgentStore
+	server      *http.Server
+	pool        *db.Pool
+	scheduler   *scheduler.Scheduler
+	logger      *zap.Logger
+	handler     *Handler
+	wsHub       *Hub
+	subscriber  events.Subscriber
+	agentStore  *AgentStore
+	mux         *http.ServeMux
+	shedder     *loadShedder
+	cache       *responseCache
+	accessLog   *accesslog.Logger
+	slaMonitor  SLAMonitorInterface
+	auth        *auth.Authenticator
+	maintenance MaintenanceInterface
 }
 
 // NewServer creates a new HTTP server.
@@ -53,6 +66,13 @@ func NewServer(
 		agentStore: agentStore,
 	}
 
+	if sched != nil {
+		s.handler.SetScheduler(sched)
+	}
+	if pool != nil {
+		s.handler.SetQueryMonitor(pool)
+	}
+
 	mux := http.NewServeMux()
 
 	// Health and metrics endpoints
@@ -60,6 +80,7 @@ func NewServer(
 	mux.HandleFunc("/health/live", s.handleLiveness)
 	mux.HandleFunc("/health/ready", s.handleReadiness)
 	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/metrics/prometheus", s.handlePrometheusMetrics)
 
 	// REST API endpoints
 	s.setupAPIRoutes(mux)
@@ -71,6 +92,7 @@ func NewServer(
 
 	// Serve embedded frontend files
 	s.setupFrontendRoutes(mux, logger)
+	s.mux = mux
 
 	// Wrap with CORS middleware
 	handler := corsMiddleware(mux)
@@ -96,11 +118,208 @@ func (s *Server) SetEventPublisher(publisher events.Publisher) {
 	s.handler.SetEventPublisher(publisher)
 }
 
+// SetLoadShedding enables overload protection for REST API requests.
+// It must be called before Start.
+func (s *Server) SetLoadShedding(cfg *config.LoadSheddingConfig) {
+	s.shedder = newLoadShedder(cfg, s.logger)
+	s.buildHandler()
+
+	s.logger.Info("HTTP load shedding enabled",
+		zap.Int("max_in_flight", cfg.MaxInFlight),
+		zap.Int("endpoint_limits", len(cfg.EndpointLimits)),
+	)
+}
+
+// SetResponseCache enables short-lived caching of expensive read endpoints.
+// It must be called before Start.
+func (s *Server) SetResponseCache(cfg *config.ResponseCacheConfig) {
+	s.cache = newResponseCache(cfg, s.logger)
+	s.buildHandler()
+
+	s.logger.Info("HTTP response cache enabled",
+		zap.Int("endpoints", len(s.cache.endpoints)),
+		zap.Int("max_entries", cfg.MaxEntries),
+	)
+}
+
+// SetAccessLog logs every request, including those rejected by the other
+// middleware. It must be called before Start.
+func (s *Server) SetAccessLog(cfg *config.AccessLogConfig) {
+	s.accessLog = accesslog.New(s.logger.Named("access"), cfg.HTTPSampleRates, cfg.SlowThresholdDuration())
+	s.buildHandler()
+
+	s.logger.Info("HTTP access log enabled",
+		zap.Int("sampled_prefixes", len(cfg.HTTPSampleRates)),
+		zap.String("slow_threshold", cfg.SlowThreshold),
+	)
+}
+
+// SetWebSocket enables access control for the event WebSocket.
+// It must be called before Start.
+func (s *Server) SetWebSocket(cfg *config.WebSocketConfig) {
+	s.wsHub.SetAccessControl(cfg)
+
+	s.logger.Info("WebSocket access control enabled",
+		zap.Strings("allowed_origins", cfg.AllowedOrigins),
+		zap.Bool("require_auth", cfg.RequireAuth),
+		zap.Int("principals", len(cfg.Tokens)),
+		zap.Int("max_connections_per_principal", cfg.MaxConnectionsPerPrincipal),
+	)
+}
+
+// SetAuth sets the authenticator behind the API key endpoints and, if
+// authentication is enabled, requires an API key on REST API requests.
+// It must be called before Start.
+func (s *Server) SetAuth(a *auth.Authenticator) {
+	s.auth = a
+	s.handler.SetAuth(a)
+	s.buildHandler()
+
+	if a.Enabled() {
+		s.logger.Info("API key authentication enabled")
+	}
+}
+
+// SetGRPCWeb mounts a gRPC-Web handler for the gRPC service at its path
+// prefix, so browsers can call the gRPC API on the HTTP port.
+// It must be called before Start.
+func (s *Server) SetGRPCWeb(prefix string, handler http.Handler) {
+	s.mux.Handle(prefix, handler)
+
+	s.logger.Info("gRPC-Web enabled", zap.String("prefix", prefix))
+}
+
+// InvalidateResponseCache drops the cached responses of the given endpoint
+// paths, for callers that change data outside the HTTP API and events.
+func (s *Server) InvalidateResponseCache(endpoints ...string) {
+	if s.cache != nil {
+		s.cache.invalidate(endpoints...)
+	}
+}
+
+// SetMaintenance sets the maintenance mode, which rejects submissions while
+// it is enabled and is reported by /health and the admin and dashboard
+// endpoints. It must be called before Start.
+func (s *Server) SetMaintenance(m MaintenanceInterface) {
+	s.maintenance = m
+	s.handler.SetMaintenance(m)
+	s.buildHandler()
+}
+
+// SetDefaultImage sets the image re-validation runs use when none is given,
+// the one backtests run on.
+func (s *Server) SetDefaultImage(image string) {
+	s.handler.SetDefaultImage(image)
+}
+
+// buildHandler wraps the mux with the configured middleware. Cache hits are
+// served before load shedding, so they never take a concurrency slot, but
+// only to authenticated requests. The access log sees every request.
+func (s *Server) buildHandler() {
+	var handler http.Handler = s.mux
+	if s.maintenance != nil {
+		handler = maintenanceMiddleware(s.maintenance, s.logger, handler)
+	}
+	if s.shedder != nil {
+		handler = s.shedder.middleware(handler)
+	}
+	if s.cache != nil {
+		handler = s.cache.middleware(handler)
+	}
+	if s.auth.Enabled() {
+		handler = authMiddleware(s.auth, s.logger, handler)
+	}
+	handler = corsMiddleware(handler)
+	if s.accessLog != nil {
+		handler = accessLogMiddleware(s.accessLog, handler)
+	}
+	s.server.Handler = handler
+}
+
+// SetSLAMonitor sets the queue SLA monitor for the SLA endpoint and metrics.
+func (s *Server) SetSLAMonitor(monitor SLAMonitorInterface) {
+	s.slaMonitor = monitor
+	s.handler.SetSLAMonitor(monitor)
+}
+
+// SetFeatures sets the configured feature flags reported by GET /api/v1/features.
+func (s *Server) SetFeatures(cfg *config.FeaturesConfig) {
+	s.handler.SetFeatures(cfg.Flags)
+}
+
+// SetAgents sets how agent registrations are tracked. The TTL is validated
+// when the config is loaded.
+func (s *Server) SetAgents(cfg *config.AgentsConfig) {
+	ttl, _ := time.ParseDuration(cfg.RegistrationTTL)
+	s.handler.SetAgents(ttl, cfg.EnforceCapabilities)
+}
+
+// SetScout sets the etiquette limits of Scout sources.
+func (s *Server) SetScout(cfg *config.ScoutConfig) {
+	s.handler.SetScoutSourceLimits(cfg.SourceLimits())
+}
+
+// SetInsights sets the service behind the insights API. Without it the
+// insights endpoints are unavailable.
+func (s *Server) SetInsights(svc *insights.Service) {
+	s.handler.SetInsights(svc)
+}
+
+// SetRanker sets the ranker behind the percentile ranks of backtest results.
+// Without it results are returned without percentile ranks.
+func (s *Server) SetRanker(r *ranking.Ranker) {
+	s.handler.SetRanker(r)
+}
+
+// SetTimezone sets the server timezone reported with schedules and reports and
+// used to align daily report buckets.
+func (s *Server) SetTimezone(loc *time.Location) {
+	s.handler.SetTimezone(loc)
+}
+
+// SetSecrets sets the secrets store behind the secrets API and scout source
+// credentials.
+func (s *Server) SetSecrets(store *secrets.Store) {
+	s.handler.SetSecrets(store)
+}
+
+// SetHyperopt sets the hyperopt runner, which stops the containers of
+// cancelled jobs, and the largest epochs a hyperopt job may ask for. Without
+// it hyperopt jobs cannot be submitted.
+func (s *Server) SetHyperopt(runner HyperoptRunnerInterface, maxEpochs int) {
+	s.handler.SetHyperopt(runner, maxEpochs)
+}
+
+// SetContainerLogs sets where the output of running jobs' containers is read
+// from. Without it only finished jobs' logs are served.
+func (s *Server) SetContainerLogs(streamer ContainerLogStreamer) {
+	s.handler.SetContainerLogs(streamer)
+}
+
+// SetCodeSafety sets the static checks strategy code must pass before it is
+// stored, whether submitted over the API or discovered by the Scout agent.
+func (s *Server) SetCodeSafety(policy *domain.CodeSafetyPolicy) {
+	s.handler.SetCodeSafety(policy)
+}
+
+// SetMarketData sets the market data listing and the downloader that runs
+// download-data containers. Without them the market data endpoints answer
+// 503.
+func (s *Server) SetMarketData(inventory MarketDataInventoryInterface, downloader DataDownloaderInterface) {
+	s.handler.SetMarketData(inventory, downloader)
+}
+
 // SetScoutScheduler sets the scout scheduler for the HTTP handler.
 func (s *Server) SetScoutScheduler(scheduler ScoutSchedulerInterface) {
 	s.handler.SetScoutScheduler(scheduler)
 }
 
+// Handler returns the server's root HTTP handler, including middleware.
+// It lets tests serve the API without binding the configured address.
+func (s *Server) Handler() http.Handler {
+	return s.server.Handler
+}
+
 // setupAPIRoutes configures REST API routes.
 func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 	// Strategy endpoints
@@ -115,6 +334,21 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 		}
 	})
 
+	// Batch validation endpoint (for Scout scrape batches)
+	mux.HandleFunc("/api/v1/strategies/validate-batch", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleValidateStrategyBatch(w, r)
+	})
+
+	// Strategy comparison endpoint
+	mux.HandleFunc("/api/v1/strategies/compare", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleCompareStrategies(w, r)
+	})
+
+	// Duplicate strategies report
+	mux.HandleFunc("/api/v1/strategies/duplicates", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetStrategyDuplicates(w, r)
+	})
+
 	// Strategy by ID endpoints - need custom routing
 	mux.HandleFunc("/api/v1/strategies/", func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
@@ -125,6 +359,66 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 			return
 		}
 
+		// Check for /star suffix
+		if strings.HasSuffix(path, "/star") {
+			s.handler.HandleStarStrategy(w, r)
+			return
+		}
+
+		// Check for /coverage suffix
+		if strings.HasSuffix(path, "/coverage") {
+			s.handler.HandleGetStrategyCoverage(w, r)
+			return
+		}
+
+		// Check for /archive suffix
+		if strings.HasSuffix(path, "/archive") {
+			s.handler.HandleArchiveStrategy(w, r)
+			return
+		}
+
+		// Check for /params suffix
+		if strings.HasSuffix(path, "/params") {
+			s.handler.HandleStrategyParams(w, r)
+			return
+		}
+
+		// Check for /hyperopt suffix
+		if strings.HasSuffix(path, "/hyperopt") {
+			s.handler.HandleStrategyHyperopt(w, r)
+			return
+		}
+
+		// Check for /validation suffix
+		if strings.HasSuffix(path, "/validation") {
+			s.handler.HandleStrategyValidation(w, r)
+			return
+		}
+
+		// Check for /diff suffix
+		if strings.HasSuffix(path, "/diff") {
+			s.handler.HandleDiffStrategy(w, r)
+			return
+		}
+
+		// Check for /versions suffix
+		if strings.HasSuffix(path, "/versions") {
+			s.handler.HandleListStrategyVersions(w, r)
+			return
+		}
+
+		// Check for /rollback/:version
+		if strings.Contains(path, "/rollback/") {
+			s.handler.HandleRollbackStrategy(w, r)
+			return
+		}
+
+		// Check for /badge.svg and /badge.json suffixes
+		if strings.Contains(path, "/badge.") {
+			s.handler.HandleStrategyBadge(w, r)
+			return
+		}
+
 		// Check if it's a specific ID (has more than just "/api/v1/strategies/")
 		if strings.TrimPrefix(path, "/api/v1/strategies/") != "" {
 			switch r.Method {
@@ -172,6 +466,54 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 			return
 		}
 
+		// Check for /export endpoint
+		if path == "/api/v1/backtests/export" {
+			s.handler.HandleExportBacktestResults(w, r)
+			return
+		}
+
+		// Check for /by-ref/:ref lookup
+		if strings.HasPrefix(path, "/api/v1/backtests/by-ref/") {
+			s.handler.HandleGetBacktestJobByRef(w, r)
+			return
+		}
+
+		// Check for /events suffix
+		if strings.HasSuffix(path, "/events") {
+			s.handler.HandleGetBacktestJobEvents(w, r)
+			return
+		}
+
+		// Check for /logs suffix
+		if strings.HasSuffix(path, "/logs") {
+			s.handler.HandleGetBacktestJobLogs(w, r)
+			return
+		}
+
+		// Check for /vs-parent suffix
+		if strings.HasSuffix(path, "/vs-parent") {
+			s.handler.HandleGetBacktestVsParent(w, r)
+			return
+		}
+
+		// Check for /equity suffix
+		if strings.HasSuffix(path, "/equity") {
+			s.handler.HandleGetBacktestEquity(w, r)
+			return
+		}
+
+		// Check for /artifacts suffix
+		if strings.HasSuffix(strings.TrimSuffix(path, "/"), "/artifacts") {
+			s.handler.HandleBacktestJobArtifacts(w, r)
+			return
+		}
+
+		// Check for /resubmit suffix
+		if strings.HasSuffix(path, "/resubmit") {
+			s.handler.HandleResubmitBacktest(w, r)
+			return
+		}
+
 		// Check if it's a specific ID
 		if strings.TrimPrefix(path, "/api/v1/backtests/") != "" {
 			switch r.Method {
@@ -200,6 +542,19 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 		s.handler.HandleGetQueueStats(w, r)
 	})
 
+	mux.HandleFunc("/api/v1/backtests/priority", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleSetPriority(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/backtests/cancel", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleBulkCancelBacktests(w, r)
+	})
+
+	// Queue SLA endpoint
+	mux.HandleFunc("/api/v1/sla", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetSLA(w, r)
+	})
+
 	// Optimization endpoints
 	mux.HandleFunc("/api/v1/optimizations", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -220,12 +575,36 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/v1/optimizations/", func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 
+		// Check for /by-ref/:ref lookup
+		if strings.HasPrefix(path, "/api/v1/optimizations/by-ref/") {
+			s.handler.HandleGetOptimizationRunByRef(w, r)
+			return
+		}
+
 		// Check for /control suffix
 		if strings.HasSuffix(path, "/control") {
 			s.handler.HandleControlOptimization(w, r)
 			return
 		}
 
+		// Check for /iterations/:n/retry suffix
+		if strings.Contains(path, "/iterations/") && strings.HasSuffix(path, "/retry") {
+			s.handler.HandleRetryOptimizationIteration(w, r)
+			return
+		}
+
+		// Check for /iterations/:n/artifacts suffix
+		if strings.Contains(path, "/iterations/") && strings.HasSuffix(strings.TrimSuffix(path, "/"), "/artifacts") {
+			s.handler.HandleIterationArtifacts(w, r)
+			return
+		}
+
+		// Check for /star suffix
+		if strings.HasSuffix(path, "/star") {
+			s.handler.HandleStarOptimization(w, r)
+			return
+		}
+
 		// Check if it's a specific ID
 		if strings.TrimPrefix(path, "/api/v1/optimizations/") != "" {
 			switch r.Method {
@@ -253,6 +632,14 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 		s.handler.HandleGetAgentStatus(w, r)
 	})
 
+	// Agent registration endpoints
+	mux.HandleFunc("/api/v1/agents", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleListAgents(w, r)
+	})
+	mux.HandleFunc("/api/v1/agents/register", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleRegisterAgent(w, r)
+	})
+
 	// Scout endpoints
 	mux.HandleFunc("/api/v1/agents/scout/trigger", func(w http.ResponseWriter, r *http.Request) {
 		s.handler.HandleTriggerScout(w, r)
@@ -262,9 +649,25 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 		s.handler.HandleListScoutRuns(w, r)
 	})
 
+	mux.HandleFunc("/api/v1/agents/scout/metrics", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetScoutMetrics(w, r)
+	})
+
 	mux.HandleFunc("/api/v1/agents/scout/runs/", func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 
+		// Check for /artifacts suffix
+		if strings.HasSuffix(strings.TrimSuffix(path, "/"), "/artifacts") {
+			s.handler.HandleScoutRunArtifacts(w, r)
+			return
+		}
+
+		// Check for /discoveries suffix
+		if strings.HasSuffix(strings.TrimSuffix(path, "/"), "/discoveries") {
+			s.handler.HandleListScoutDiscoveries(w, r)
+			return
+		}
+
 		// Check if it's a specific ID
 		if strings.TrimPrefix(path, "/api/v1/agents/scout/runs/") != "" {
 			switch r.Method {
@@ -282,6 +685,50 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 		s.handler.HandleListScoutRuns(w, r)
 	})
 
+	// Artifact endpoints
+	mux.HandleFunc("/api/v1/artifacts/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/content") {
+			s.handler.HandleGetArtifactContent(w, r)
+			return
+		}
+		s.handler.HandleGetArtifact(w, r)
+	})
+
+	// Export endpoints
+	mux.HandleFunc("/api/v1/exports", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleCreateExport(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/exports/", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetExport(w, r)
+	})
+
+	// Hyperopt endpoints
+	mux.HandleFunc("/api/v1/hyperopt", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleHyperoptJobs(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/hyperopt/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			s.handler.HandleCancelHyperoptJob(w, r)
+			return
+		}
+		s.handler.HandleGetHyperoptJob(w, r)
+	})
+
+	// Market data endpoints
+	mux.HandleFunc("/api/v1/data", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleListMarketData(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/data/downloads", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleDataDownloads(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/data/downloads/", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetDataDownload(w, r)
+	})
+
 	mux.HandleFunc("/api/v1/agents/scout/schedules", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -301,6 +748,10 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 			s.handler.HandleToggleScoutSchedule(w, r)
 			return
 		}
+		if strings.HasSuffix(path, "/preview") {
+			s.handler.HandlePreviewScoutSchedule(w, r)
+			return
+		}
 
 		// Check if it's a specific ID
 		if strings.TrimPrefix(path, "/api/v1/agents/scout/schedules/") != "" {
@@ -327,6 +778,237 @@ func (s *Server) setupAPIRoutes(mux *http.ServeMux) {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
+
+	// Dashboard endpoints
+	mux.HandleFunc("/api/v1/dashboard/summary", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetDashboardSummary(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/analytics/evolution", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetEvolutionAnalytics(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/analytics/failures", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetFailureAnalytics(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/analytics/significance", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleTestSignificance(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/analytics/description-coverage", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetDescriptionCoverage(w, r)
+	})
+
+	// Scheduler control endpoints
+	mux.HandleFunc("/api/v1/scheduler/status", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetSchedulerStatus(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/scheduler/pause", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleControlScheduler(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/scheduler/drain", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleControlScheduler(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/scheduler/resume", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleControlScheduler(w, r)
+	})
+
+	// Admin endpoints
+	mux.HandleFunc("/api/v1/admin/queries", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetActiveQueries(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/capacity", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetCapacity(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/priority-simulation", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleSimulatePriority(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/scheduler/state", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetSchedulerState(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleMaintenance(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/revalidations", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleRevalidations(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/revalidations/matrix", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetCompatibilityMatrix(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/revalidations/", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleGetRevalidation(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/dead-letters", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleListDeadLetters(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/dead-letters/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/requeue") {
+			s.handler.HandleRequeueDeadLetter(w, r)
+			return
+		}
+		s.handler.HandleGetDeadLetter(w, r)
+	})
+
+	// Incident endpoints
+	mux.HandleFunc("/api/v1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleIncidents(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/incidents/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		switch {
+		case strings.HasSuffix(path, "/notes"):
+			s.handler.HandleAddIncidentNote(w, r)
+		case strings.HasSuffix(path, "/close"):
+			s.handler.HandleCloseIncident(w, r)
+		default:
+			s.handler.HandleIncident(w, r)
+		}
+	})
+
+	mux.HandleFunc("/api/v1/admin/consistency", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleCheckConsistency(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/admin/consistency/repair", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleRepairConsistency(w, r)
+	})
+
+	// Preference endpoints
+	mux.HandleFunc("/api/v1/preferences", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleListPreferences(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/preferences/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/api/v1/preferences/") == "" {
+			s.handler.HandleListPreferences(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			s.handler.HandleGetPreference(w, r)
+		case http.MethodPut:
+			s.handler.HandleSetPreference(w, r)
+		case http.MethodDelete:
+			s.handler.HandleDeletePreference(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Campaign endpoints
+	mux.HandleFunc("/api/v1/campaigns", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.handler.HandleListCampaigns(w, r)
+		case http.MethodPost:
+			s.handler.HandleCreateCampaign(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/api/v1/campaigns/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.handler.HandleGetCampaign(w, r)
+		case http.MethodPut:
+			s.handler.HandleUpdateCampaign(w, r)
+		case http.MethodDelete:
+			s.handler.HandleDeleteCampaign(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Feature flag endpoints
+	mux.HandleFunc("/api/v1/features", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleListFeatures(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/features/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/api/v1/features/") == "" {
+			s.handler.HandleListFeatures(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			s.handler.HandleSetFeature(w, r)
+		case http.MethodDelete:
+			s.handler.HandleDeleteFeature(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Secret endpoints
+	mux.HandleFunc("/api/v1/secrets", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.handler.HandleListSecrets(w, r)
+		case http.MethodPost:
+			s.handler.HandleCreateSecret(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/api/v1/secrets/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.handler.HandleGetSecret(w, r)
+		case http.MethodPut:
+			s.handler.HandleUpdateSecret(w, r)
+		case http.MethodDelete:
+			s.handler.HandleDeleteSecret(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// API key endpoints
+	mux.HandleFunc("/api/v1/auth/keys", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.handler.HandleListAPIKeys(w, r)
+		case http.MethodPost:
+			s.handler.HandleCreateAPIKey(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/api/v1/auth/keys/", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleRevokeAPIKey(w, r)
+	})
+
+	// Insight endpoints
+	mux.HandleFunc("/api/v1/insights", func(w http.ResponseWriter, r *http.Request) {
+		s.handler.HandleListInsights(w, r)
+	})
+
+	mux.HandleFunc("/api/v1/insights/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/api/v1/insights/") == "" {
+			s.handler.HandleListInsights(w, r)
+			return
+		}
+		s.handler.HandleRunInsight(w, r)
+	})
 }
 
 // setupFrontendRoutes configures routes for serving the embedded frontend.
@@ -383,7 +1065,8 @@ func corsMiddleware(next http.Handler) http.Handler {
 		// Allow requests from any origin (configure more restrictively in production)
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, X-User-ID, X-Grpc-Web, X-User-Agent, Grpc-Timeout")
+		w.Header().Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message")
 		w.Header().Set("Access-Control-Max-Age", "3600")
 
 		// Handle preflight OPTIONS request
@@ -429,13 +1112,16 @@ func (s *Server) Stop(ctx context.Context) error {
 
 // startEventSubscription starts subscribing to RabbitMQ events.
 func (s *Server) startEventSubscription() {
-	// Define routing keys to subscribe to
+	// Define routing keys to subscribe to. scout.trigger is not relayed: it is
+	// addressed to the Scout agent and references its source credential.
 	routingKeys := []string{
 		events.RoutingKeyTaskRunning,
 		events.RoutingKeyTaskCompleted,
 		events.RoutingKeyTaskFailed,
 		events.RoutingKeyTaskCancelled,
 		events.RoutingKeyOptIteration,
+		events.RoutingKeyOptProgress,
+		events.RoutingKeyOptStalled,
 		events.RoutingKeyBacktestCompleted,
 		events.RoutingKeyBacktestFailed,
 		events.RoutingKeyStrategyDiscovered,
@@ -444,13 +1130,16 @@ func (s *Server) startEventSubscription() {
 		events.RoutingKeyStrategyApproved,
 		events.RoutingKeyStrategyEvolve,
 		events.RoutingKeyStrategyArchived,
+		events.RoutingKeyStrategyCreated,
+		events.RoutingKeyStrategyUpdated,
+		events.RoutingKeyStrategyDeleted,
 		events.RoutingKeyAgentHeartbeat,
-		events.RoutingKeyScoutTrigger,
 		events.RoutingKeyScoutStarted,
 		events.RoutingKeyScoutProgress,
 		events.RoutingKeyScoutCompleted,
 		events.RoutingKeyScoutFailed,
 		events.RoutingKeyScoutCancelled,
+		events.RoutingKeySystemMaintenance,
 	}
 
 	// Start subscription
@@ -471,16 +1160,22 @@ func (s *Server) handleRabbitMQEvent(routingKey string, body []byte) error {
 		zap.Int("body_size", len(body)),
 	)
 
+	// Drop cached responses made stale by the event
+	if s.cache != nil {
+		s.cache.invalidateEvent(routingKey)
+	}
+
 	// Handle agent heartbeat events specially
 	if routingKey == events.RoutingKeyAgentHeartbeat {
 		return s.handleAgentHeartbeat(body)
 	}
 
 	// Handle scout lifecycle events - update database
-	if err := s.handleScoutEvent(routingKey, body); err != nil {
+	scoutErr := s.handleScoutEvent(routingKey, body)
+	if scoutErr != nil {
 		s.logger.Error("Failed to handle scout event",
 			zap.String("routing_key", routingKey),
-			zap.Error(err))
+			zap.Error(scoutErr))
 		// Continue to broadcast even if database update fails
 	}
 
@@ -496,6 +1191,10 @@ func (s *Server) handleRabbitMQEvent(routingKey string, body []byte) error {
 	// Broadcast to WebSocket clients
 	s.wsHub.BroadcastEvent(eventType, eventData)
 
+	// Report the failed update so the event is retried, then dead-lettered
+	if scoutErr != nil {
+		return fmt.Errorf("failed to handle scout event: %w", scoutErr)
+	}
 	return nil
 }
 
@@ -524,14 +1223,15 @@ func (s *Server) handleScoutEvent(routingKey string, body []byte) error {
 			zap.Int("total_fetched", event.TotalFetched),
 			zap.Int("validated", event.Validated),
 			zap.Int("submitted", event.Submitted))
-		metrics := &domain.ScoutMetrics{
-			TotalFetched:      event.TotalFetched,
-			Validated:         event.Validated,
-			ValidationFailed:  event.ValidationFailed,
-			DuplicatesRemoved: event.DuplicatesRemoved,
-			Submitted:         event.Submitted,
+		err := s.handler.repos.Scout.CompleteRun(ctx, event.RunID, event.Metrics())
+		if errors.Is(err, domain.ErrInvalidInput) {
+			// Don't leave the run active when the agent reports malformed metrics
+			s.logger.Warn("Scout run reported invalid metrics",
+				zap.String("run_id", event.RunID.String()),
+				zap.Error(err))
+			return s.handler.repos.Scout.FailRun(ctx, event.RunID, "invalid metrics: "+err.Error())
 		}
-		return s.handler.repos.Scout.CompleteRun(ctx, event.RunID, metrics)
+		return err
 
 	case events.RoutingKeyScoutFailed:
 		var event events.ScoutFailedEvent
@@ -549,8 +1249,11 @@ func (s *Server) handleScoutEvent(routingKey string, body []byte) error {
 		if err := json.Unmarshal(body, &event); err != nil {
 			return fmt.Errorf("unmarshal scout cancelled: %w", err)
 		}
-		s.logger.Info("Scout run cancelled", zap.String("run_id", event.RunID.String()))
-		return s.handler.repos.Scout.UpdateRunStatus(ctx, event.RunID, domain.ScoutRunStatusCancelled, nil)
+		s.logger.Info("Scout run cancelled",
+			zap.String("run_id", event.RunID.String()),
+			zap.String("reason", event.Reason),
+			zap.String("cancelled_by", event.CancelledBy))
+		return s.handler.repos.Scout.CancelRun(ctx, event.RunID, domain.NewCancellation(event.Reason, event.CancelledBy))
 
 	case events.RoutingKeyStrategyDiscovered:
 		var event events.StrategyDiscoveredEvent
@@ -571,17 +1274,35 @@ func (s *Server) handleScoutEvent(routingKey string, body []byte) error {
 			UpdatedAt:   time.Now(),
 		}
 
+		// Discovered code is untrusted; unsafe code is dropped, not retried
+		if err := s.handler.codeSafety.Verify(strategy.Code); err != nil {
+			s.logger.Warn("Discovered strategy rejected",
+				zap.String("name", event.Name),
+				zap.String("source", event.SourceType),
+				zap.Error(err))
+			s.recordDiscovery(ctx, &event, domain.ScoutOutcomeRejected, nil, err.Error())
+			return nil
+		}
+
 		// Save to database
 		if err := s.handler.repos.Strategy.Create(ctx, strategy); err != nil {
 			if errors.Is(err, domain.ErrDuplicate) {
 				s.logger.Debug("Strategy already exists (duplicate code_hash)",
 					zap.String("name", event.Name),
 					zap.String("source", event.SourceType))
+				var existingID *uuid.UUID
+				if existing, err := s.handler.repos.Strategy.GetByCodeHash(ctx, domain.HashCode(strategy.Code)); err == nil {
+					existingID = &existing.ID
+				}
+				s.recordDiscovery(ctx, &event, domain.ScoutOutcomeDuplicate, existingID, "")
 				return nil // Not an error, just skip duplicates
 			}
 			return fmt.Errorf("create strategy: %w", err)
 		}
 
+		s.handler.publishStrategyCreated(strategy)
+		s.recordDiscovery(ctx, &event, domain.ScoutOutcomeImported, &strategy.ID, "")
+
 		s.logger.Info("Strategy discovered and saved",
 			zap.String("id", strategy.ID.String()),
 			zap.String("name", strategy.Name),
@@ -592,6 +1313,34 @@ func (s *Server) handleScoutEvent(routingKey string, body []byte) error {
 	return nil
 }
 
+// recordDiscovery records what became of a strategy discovered by a scout
+// run. Events not sent for a run are not recorded. The record is kept for
+// auditing only, so failing to store it is logged rather than retried.
+func (s *Server) recordDiscovery(ctx context.Context, event *events.StrategyDiscoveredEvent, outcome domain.ScoutDiscoveryOutcome, strategyID *uuid.UUID, detail string) {
+	if event.RunID == nil {
+		return
+	}
+
+	status := domain.ScoutValidationValid
+	if !event.IsValid {
+		status = domain.ScoutValidationInvalid
+	}
+	codeHash := event.CodeHash
+	if codeHash == "" {
+		codeHash = domain.HashCode(event.Code)
+	}
+
+	discovery := domain.NewScoutDiscovery(*event.RunID, event.Name, event.SourceType, event.SourceURL, codeHash, status)
+	discovery.ValidationErrors = event.ValidationErrors
+	discovery.SetOutcome(outcome, strategyID, detail)
+	if err := s.handler.repos.Scout.RecordDiscoveries(ctx, []*domain.ScoutDiscovery{discovery}); err != nil {
+		s.logger.Warn("Failed to record scout discovery",
+			zap.String("run_id", event.RunID.String()),
+			zap.String("name", event.Name),
+			zap.Error(err))
+	}
+}
+
 // handleAgentHeartbeat processes agent heartbeat events.
 func (s *Server) handleAgentHeartbeat(body []byte) error {
 	var payload AgentHeartbeatPayload
@@ -602,6 +1351,14 @@ func (s *Server) handleAgentHeartbeat(body []byte) error {
 	// Update the agent store
 	s.agentStore.UpdateHeartbeat(payload.AgentType, payload.Status, payload.CurrentTask)
 
+	// Keep the agent's registration live
+	if payload.AgentID != "" {
+		err := s.handler.repos.Agent.Touch(context.Background(), payload.AgentID, time.Now())
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			s.logger.Warn("Failed to refresh agent registration", zap.String("agent_id", payload.AgentID), zap.Error(err))
+		}
+	}
+
 	s.logger.Debug("Updated agent heartbeat",
 		zap.String("agent_type", payload.AgentType),
 		zap.String("status", payload.Status),
@@ -641,9 +1398,11 @@ func (s *Server) GetHub() *Hub {
 
 // HealthResponse represents the health check response.
 type HealthResponse struct {
-	Status   string            `json:"status"`
-	Version  string            `json:"version"`
-	Services map[string]string `json:"services"`
+	Status      string                  `json:"status"`
+	Version     string                  `json:"version"`
+	Services    map[string]string       `json:"services"`
+	Maintenance *domain.MaintenanceMode `json:"maintenance,omitempty"`
+	Incidents   []*domain.Incident      `json:"incidents,omitempty"` // Open incidents
 }
 
 // handleHealth handles the /health endpoint.
@@ -672,6 +1431,15 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 		response.Services["scheduler"] = "not configured"
 	}
 
+	// Maintenance mode keeps the service up for reads, so it doesn't make it unhealthy
+	if s.maintenance != nil {
+		mode := s.maintenance.Current()
+		response.Maintenance = &mode
+	}
+
+	// Open incidents are reported for on-call; they don't change the status either
+	response.Incidents = s.handler.openIncidents(ctx)
+
 	w.Header().Set("Content-Type", "application/json")
 	if response.Status == "unhealthy" {
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -710,6 +1478,9 @@ type MetricsResponse struct {
 	Scheduler SchedulerMetrics `json:"scheduler"`
 	Database  DatabaseMetrics  `json:"database"`
 	WebSocket WebSocketMetrics `json:"websocket"`
+
+	LoadShedding  *LoadSheddingMetrics  `json:"load_shedding,omitempty"`
+	ResponseCache *ResponseCacheMetrics `json:"response_cache,omitempty"`
 }
 
 // SchedulerMetrics represents scheduler-related metrics.
@@ -737,6 +1508,25 @@ type DatabaseMetrics struct {
 // WebSocketMetrics represents WebSocket-related metrics.
 type WebSocketMetrics struct {
 	ConnectedClients int `json:"connected_clients"`
+
+	// Set when access control is enabled
+	Principals map[string]int   `json:"principals,omitempty"` // Open connections by principal
+	Rejected   map[string]int64 `json:"rejected,omitempty"`   // Rejected upgrades by reason: origin, auth, limit
+}
+
+// LoadSheddingMetrics represents HTTP overload protection metrics.
+type LoadSheddingMetrics struct {
+	InFlight    int64 `json:"in_flight"`
+	MaxInFlight int   `json:"max_in_flight"` // 0 when only per-endpoint limits apply
+	ShedTotal   int64 `json:"shed_total"`
+}
+
+// ResponseCacheMetrics represents HTTP response cache metrics.
+type ResponseCacheMetrics struct {
+	Entries       int   `json:"entries"`
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Invalidations int64 `json:"invalidations"` // Cached responses dropped by writes and events
 }
 
 // handleMetrics handles the /metrics endpoint.
@@ -795,6 +1585,22 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	response.WebSocket = WebSocketMetrics{
 		ConnectedClients: s.wsHub.GetClientCount(),
 	}
+	if s.wsHub.guard != nil {
+		response.WebSocket.Principals = s.wsHub.guard.principals()
+		response.WebSocket.Rejected = s.wsHub.guard.rejected()
+	}
+
+	// Get load shedding stats
+	if s.shedder != nil {
+		metrics := s.shedder.metrics()
+		response.LoadShedding = &metrics
+	}
+
+	// Get response cache stats
+	if s.cache != nil {
+		metrics := s.cache.metrics()
+		response.ResponseCache = &metrics
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// starKey identifies one owner's star on an entity.
+type starKey struct {
+	owner      string
+	entityType domain.StarEntityType
+	entityID   uuid.UUID
+}
+
+// mockStarRepository keeps stars in memory. Starring an unknown entity is
+// not found, as with the foreign keys of the Postgres repository.
+type mockStarRepository struct {
+	entities map[uuid.UUID]bool
+	stars    map[starKey]bool
+}
+
+func (m *mockStarRepository) Star(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) error {
+	if !m.entities[entityID] {
+		return domain.ErrNotFound
+	}
+	m.stars[starKey{owner, entityType, entityID}] = true
+	return nil
+}
+
+func (m *mockStarRepository) Unstar(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) error {
+	delete(m.stars, starKey{owner, entityType, entityID})
+	return nil
+}
+
+func (m *mockStarRepository) IsStarred(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) (bool, error) {
+	return m.stars[starKey{owner, entityType, entityID}], nil
+}
+
+func (m *mockStarRepository) ListStarredIDs(ctx context.Context, owner string, entityType domain.StarEntityType) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for k := range m.stars {
+		if k.owner == owner && k.entityType == entityType {
+			ids = append(ids, k.entityID)
+		}
+	}
+	return ids, nil
+}
+
+// mockStarredStrategyRepository searches a fixed list of strategies,
+// applying only the starred filter.
+type mockStarredStrategyRepository struct {
+	repository.StrategyRepository
+	stars      *mockStarRepository
+	strategies []*domain.Strategy
+}
+
+func (m *mockStarredStrategyRepository) Search(ctx context.Context, query domain.StrategySearchQuery) ([]domain.StrategyWithMetrics, int, error) {
+	var results []domain.StrategyWithMetrics
+	for _, s := range m.strategies {
+		if query.StarredBy != nil && !m.stars.stars[starKey{*query.StarredBy, domain.StarEntityStrategy, s.ID}] {
+			continue
+		}
+		results = append(results, domain.StrategyWithMetrics{Strategy: s})
+	}
+	return results, len(results), nil
+}
+
+// mockStarredOptimizationRepository lists a fixed list of optimization runs,
+// applying only the starred filter.
+type mockStarredOptimizationRepository struct {
+	repository.OptimizationRepository
+	stars *mockStarRepository
+	runs  []*domain.OptimizationRun
+}
+
+func (m *mockStarredOptimizationRepository) List(ctx context.Context, query domain.OptimizationListQuery) ([]*domain.OptimizationRun, int, error) {
+	var results []*domain.OptimizationRun
+	for _, run := range m.runs {
+		if query.StarredBy != nil && !m.stars.stars[starKey{*query.StarredBy, domain.StarEntityOptimizationRun, run.ID}] {
+			continue
+		}
+		results = append(results, run)
+	}
+	return results, len(results), nil
+}
+
+func TestStarHandlers(t *testing.T) {
+	starred, other := &domain.Strategy{ID: uuid.New()}, &domain.Strategy{ID: uuid.New()}
+	run, otherRun := &domain.OptimizationRun{ID: uuid.New()}, &domain.OptimizationRun{ID: uuid.New()}
+	stars := &mockStarRepository{
+		entities: map[uuid.UUID]bool{starred.ID: true, other.ID: true, run.ID: true, otherRun.ID: true},
+		stars:    map[starKey]bool{},
+	}
+	h := NewHandler(&repository.Repositories{
+		Star:         stars,
+		Strategy:     &mockStarredStrategyRepository{stars: stars, strategies: []*domain.Strategy{starred, other}},
+		Optimization: &mockStarredOptimizationRepository{stars: stars, runs: []*domain.OptimizationRun{run, otherRun}},
+	}, nil, zaptest.NewLogger(t))
+
+	serve := func(handler http.HandlerFunc, method, target, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set(userIDHeader, user)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+	starredStrategies := func(user string) []uuid.UUID {
+		rec := serve(h.HandleSearchStrategies, http.MethodGet, "/api/v1/strategies?starred=true", user)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp SearchStrategiesResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		var ids []uuid.UUID
+		for _, s := range resp.Strategies {
+			ids = append(ids, s.Strategy.ID)
+		}
+		return ids
+	}
+	starredRuns := func(user string) []uuid.UUID {
+		rec := serve(h.HandleListOptimizationRuns, http.MethodGet, "/api/v1/optimizations?starred=true", user)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp ListOptimizationRunsResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		var ids []uuid.UUID
+		for _, r := range resp.Runs {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	// Starring is idempotent
+	strategyStar := "/api/v1/strategies/" + starred.ID.String() + "/star"
+	for i := 0; i < 2; i++ {
+		rec := serve(h.HandleStarStrategy, http.MethodPost, strategyStar, "alice")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp StarResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, StarResponse{EntityType: domain.StarEntityStrategy, EntityID: starred.ID, Starred: true}, resp)
+	}
+	assert.Len(t, stars.stars, 1)
+
+	rec := serve(h.HandleStarOptimization, http.MethodPost, "/api/v1/optimizations/"+run.ID.String()+"/star", "alice")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = serve(h.HandleStarOptimization, http.MethodPost, "/api/v1/optimizations/"+otherRun.ID.String()+"/star", "bob")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// The starred filter only returns the caller's stars
+	assert.Equal(t, []uuid.UUID{starred.ID}, starredStrategies("alice"))
+	assert.Empty(t, starredStrategies("bob"))
+	assert.Equal(t, []uuid.UUID{run.ID}, starredRuns("alice"))
+	assert.Equal(t, []uuid.UUID{otherRun.ID}, starredRuns("bob"))
+
+	// Unstarring removes the star, and is idempotent too
+	for i := 0; i < 2; i++ {
+		rec = serve(h.HandleStarStrategy, http.MethodDelete, strategyStar, "alice")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp StarResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.False(t, resp.Starred)
+	}
+	assert.Empty(t, starredStrategies("alice"))
+	assert.Equal(t, []uuid.UUID{run.ID}, starredRuns("alice"))
+
+	assert.Equal(t, http.StatusNotFound, serve(h.HandleStarStrategy, http.MethodPost, "/api/v1/strategies/"+uuid.NewString()+"/star", "alice").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(h.HandleStarStrategy, http.MethodPost, "/api/v1/strategies/not-a-uuid/star", "alice").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(h.HandleStarStrategy, http.MethodGet, strategyStar, "alice").Code)
+}
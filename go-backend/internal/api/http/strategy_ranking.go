@@ -0,0 +1,68 @@
+package http
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// StrategyRank is one strategy's place in a head-to-head ranking.
+type StrategyRank struct {
+	Rank       int       `json:"rank"` // 1 is best; tied strategies share a rank
+	StrategyID uuid.UUID `json:"strategy_id"`
+	Value      float64   `json:"value"`
+}
+
+// StrategyRankings ranks compared strategies head to head by their best
+// result on each metric: the highest Sharpe ratio, the highest profit and the
+// lowest max drawdown. Strategies without a value for a metric are left out
+// of its ranking.
+type StrategyRankings struct {
+	Sharpe   []StrategyRank `json:"sharpe"`
+	Profit   []StrategyRank `json:"profit"`
+	Drawdown []StrategyRank `json:"drawdown"`
+}
+
+// rankStrategies ranks the strategies of stats by their best results.
+func rankStrategies(stats []*domain.StrategyStatistics) StrategyRankings {
+	return StrategyRankings{
+		Sharpe: rankBy(stats, func(s *domain.StrategyStatistics) (float64, bool) {
+			return s.SharpeRatio.Max, s.SharpeRatio.Count > 0
+		}, true),
+		Profit: rankBy(stats, func(s *domain.StrategyStatistics) (float64, bool) {
+			return s.ProfitPct.Max, s.ProfitPct.Count > 0
+		}, true),
+		Drawdown: rankBy(stats, func(s *domain.StrategyStatistics) (float64, bool) {
+			return s.MaxDrawdownPct.Min, s.MaxDrawdownPct.Count > 0
+		}, false),
+	}
+}
+
+// rankBy ranks the strategies with a value for a metric, highest first if
+// higherIsBetter and lowest first otherwise. Ties keep the order of stats.
+func rankBy(stats []*domain.StrategyStatistics, value func(*domain.StrategyStatistics) (float64, bool), higherIsBetter bool) []StrategyRank {
+	ranks := []StrategyRank{}
+	for _, s := range stats {
+		if v, ok := value(s); ok {
+			ranks = append(ranks, StrategyRank{StrategyID: s.StrategyID, Value: v})
+		}
+	}
+
+	sort.SliceStable(ranks, func(i, j int) bool {
+		if higherIsBetter {
+			return ranks[i].Value > ranks[j].Value
+		}
+		return ranks[i].Value < ranks[j].Value
+	})
+
+	for i := range ranks {
+		if i > 0 && ranks[i].Value == ranks[i-1].Value {
+			ranks[i].Rank = ranks[i-1].Rank
+		} else {
+			ranks[i].Rank = i + 1
+		}
+	}
+	return ranks
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestRankStrategies(t *testing.T) {
+	a, b, c, empty := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	stats := []*domain.StrategyStatistics{
+		{
+			StrategyID:     a,
+			ResultCount:    2,
+			SharpeRatio:    domain.MetricStatistics{Count: 2, Max: 1.5, Mean: 1.0},
+			ProfitPct:      domain.MetricStatistics{Count: 2, Max: 12},
+			MaxDrawdownPct: domain.MetricStatistics{Count: 2, Min: 8},
+		},
+		{
+			StrategyID:     b,
+			ResultCount:    1,
+			SharpeRatio:    domain.MetricStatistics{Count: 1, Max: 2.1},
+			ProfitPct:      domain.MetricStatistics{Count: 1, Max: 12},
+			MaxDrawdownPct: domain.MetricStatistics{Count: 1, Min: 15},
+		},
+		{
+			// Older results without a Sharpe ratio
+			StrategyID:     c,
+			ResultCount:    1,
+			ProfitPct:      domain.MetricStatistics{Count: 1, Max: -3},
+			MaxDrawdownPct: domain.MetricStatistics{Count: 1, Min: 4},
+		},
+		{StrategyID: empty},
+	}
+
+	rankings := rankStrategies(stats)
+	assert.Equal(t, []StrategyRank{
+		{Rank: 1, StrategyID: b, Value: 2.1},
+		{Rank: 2, StrategyID: a, Value: 1.5},
+	}, rankings.Sharpe)
+	// Ties share a rank
+	assert.Equal(t, []StrategyRank{
+		{Rank: 1, StrategyID: a, Value: 12},
+		{Rank: 1, StrategyID: b, Value: 12},
+		{Rank: 3, StrategyID: c, Value: -3},
+	}, rankings.Profit)
+	// The smallest drawdown ranks first
+	assert.Equal(t, []StrategyRank{
+		{Rank: 1, StrategyID: c, Value: 4},
+		{Rank: 2, StrategyID: a, Value: 8},
+		{Rank: 3, StrategyID: b, Value: 15},
+	}, rankings.Drawdown)
+
+	assert.Empty(t, rankStrategies(stats[3:]).Sharpe)
+}
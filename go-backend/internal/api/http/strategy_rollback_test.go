@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockStrategyVersionRepository serves fixed versions and records rollbacks.
+type mockStrategyVersionRepository struct {
+	repository.StrategyVersionRepository
+	versions   map[int]*domain.StrategyVersion
+	rolledBack []int
+}
+
+func (m *mockStrategyVersionRepository) Get(ctx context.Context, strategyID uuid.UUID, version int) (*domain.StrategyVersion, error) {
+	if v, ok := m.versions[version]; ok {
+		return v, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockStrategyVersionRepository) Rollback(ctx context.Context, strategyID uuid.UUID, version int) (*domain.StrategyVersion, error) {
+	m.rolledBack = append(m.rolledBack, version)
+	return &domain.StrategyVersion{StrategyID: strategyID, Version: len(m.versions) + len(m.rolledBack)}, nil
+}
+
+func TestHandleRollbackStrategy_CodeSafety(t *testing.T) {
+	id := uuid.New()
+	versions := &mockStrategyVersionRepository{versions: map[int]*domain.StrategyVersion{
+		1: {StrategyID: id, Version: 1, Code: "import subprocess\n"},
+		2: {StrategyID: id, Version: 2, Code: "import talib\n"},
+	}}
+	h := NewHandler(&repository.Repositories{
+		Strategy:        &mockStrategyRepository{strategies: map[uuid.UUID]*domain.Strategy{id: {ID: id}}},
+		StrategyVersion: versions,
+	}, nil, zaptest.NewLogger(t))
+	h.SetCodeSafety(domain.NewCodeSafetyPolicy(nil, nil))
+
+	serve := func(version string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.HandleRollbackStrategy(rec, httptest.NewRequest(http.MethodPost, "/api/v1/strategies/"+id.String()+"/rollback/"+version, nil))
+		return rec
+	}
+
+	rec := serve("1")
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	var unsafe UnsafeCodeResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&unsafe))
+	assert.Equal(t, []domain.SafetyViolation{{Kind: domain.SafetyViolationImport, Name: "subprocess", Line: 1}}, unsafe.Violations)
+	assert.Empty(t, versions.rolledBack)
+
+	assert.Equal(t, http.StatusNotFound, serve("3").Code)
+	assert.Empty(t, versions.rolledBack)
+
+	rec = serve("2")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, []int{2}, versions.rolledBack)
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ============================================================================
+// Timestamp Parameters
+// ============================================================================
+
+// timestampExample is quoted in errors for unparseable timestamps.
+const timestampExample = "2024-01-15T10:30:00Z"
+
+// parseTimestamp parses an API timestamp. Timestamps must be RFC3339 with an
+// explicit offset (Z or ±hh:mm) so they never depend on the server timezone.
+func parseTimestamp(name, value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+	// An unescaped '+' in a query string decodes to a space, which turns a
+	// valid offset such as +02:00 into " 02:00".
+	if strings.Contains(value, " ") {
+		return time.Time{}, fmt.Errorf("%s %q is not an RFC3339 timestamp: encode '+' in the offset as %%2B", name, value)
+	}
+	return time.Time{}, fmt.Errorf("%s %q is not an RFC3339 timestamp with a timezone offset, e.g. %s", name, value, timestampExample)
+}
+
+// parseTimeParam parses an optional RFC3339 query parameter. It returns nil
+// if the parameter is absent.
+func parseTimeParam(q url.Values, name string) (*time.Time, error) {
+	value := q.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := parseTimestamp(name, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// parseTimeRangeParams parses the start_time and end_time query parameters.
+// It returns nil if neither is given. A missing start_time leaves the range
+// open at the start and a missing end_time ends it now.
+func parseTimeRangeParams(q url.Values) (*domain.TimeRange, error) {
+	start, err := parseTimeParam(q, "start_time")
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeParam(q, "end_time")
+	if err != nil {
+		return nil, err
+	}
+	if start == nil && end == nil {
+		return nil, nil
+	}
+
+	tr := &domain.TimeRange{End: time.Now()}
+	if start != nil {
+		tr.Start = *start
+	}
+	if end != nil {
+		tr.End = *end
+	}
+	if start != nil && end != nil && tr.End.Before(tr.Start) {
+		return nil, errors.New("end_time must not be before start_time")
+	}
+	return tr, nil
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	ts, err := parseTimestamp("since", "2024-01-15T12:30:00+02:00")
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)))
+
+	_, err = parseTimestamp("since", "2024-01-15T10:30:00")
+	assert.ErrorContains(t, err, "timezone offset")
+
+	_, err = parseTimestamp("since", "2024-01-15")
+	assert.ErrorContains(t, err, "not an RFC3339 timestamp")
+
+	// An unescaped '+' arrives as a space
+	_, err = parseTimestamp("since", "2024-01-15T12:30:00 02:00")
+	assert.ErrorContains(t, err, "%2B")
+}
+
+func TestParseTimeRangeParams(t *testing.T) {
+	tr, err := parseTimeRangeParams(url.Values{})
+	require.NoError(t, err)
+	assert.Nil(t, tr)
+
+	tr, err = parseTimeRangeParams(url.Values{
+		"start_time": {"2024-01-15T00:00:00Z"},
+		"end_time":   {"2024-01-16T00:00:00Z"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, tr.End.Sub(tr.Start))
+
+	// A missing end_time ends the range now
+	before := time.Now()
+	tr, err = parseTimeRangeParams(url.Values{"start_time": {"2024-01-15T00:00:00Z"}})
+	require.NoError(t, err)
+	assert.False(t, tr.End.Before(before))
+
+	_, err = parseTimeRangeParams(url.Values{"end_time": {"yesterday"}})
+	assert.ErrorContains(t, err, "end_time")
+
+	_, err = parseTimeRangeParams(url.Values{
+		"start_time": {"2024-01-16T00:00:00Z"},
+		"end_time":   {"2024-01-15T00:00:00Z"},
+	})
+	assert.Error(t, err)
+}
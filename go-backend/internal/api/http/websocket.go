@@ -9,6 +9,8 @@ import (
 
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
 )
 
 const (
@@ -21,8 +23,9 @@ const (
 	// Send pings to peer with this period. Must be less than pongWait.
 	pingPeriod = (pongWait * 9) / 10
 
-	// Maximum message size allowed from peer.
-	maxMessageSize = 512
+	// Maximum message size allowed from peer; large enough to subscribe to
+	// the topics of dozens of runs at once.
+	maxMessageSize = 8192
 
 	// Size of the send buffer for each client.
 	sendBufferSize = 256
@@ -59,10 +62,15 @@ type WSMessage struct {
 	Timestamp time.Time   `json:"timestamp"`
 }
 
-// SubscriptionMessage represents a subscription request from a client.
+// SubscriptionMessage represents a subscription request from a client. It
+// either changes the event types subscribed to with Action, or the topics
+// with Subscribe and Unsubscribe (see eventTopics).
 type SubscriptionMessage struct {
 	Action     string   `json:"action"` // "subscribe" or "unsubscribe"
 	EventTypes []string `json:"event_types"`
+
+	Subscribe   []string `json:"subscribe,omitempty"`   // Topic patterns, e.g. "optimization.<run_id>" or "backtest.*"
+	Unsubscribe []string `json:"unsubscribe,omitempty"` // Topic patterns subscribed to before
 }
 
 // Client represents a WebSocket client connection.
@@ -75,10 +83,15 @@ type Client struct {
 	// Buffered channel of outbound messages.
 	send chan []byte
 
-	// Subscribed event types (if empty, receives all events).
+	// Subscribed event types and topic patterns (if both are empty,
+	// receives all events).
 	subscriptions map[string]bool
+	topics        map[string]bool
 	mu            sync.RWMutex
 
+	// Principal the client authenticated as, or anonymousPrincipal.
+	principal string
+
 	// Logger for this client.
 	logger *zap.Logger
 }
@@ -103,6 +116,9 @@ type Hub struct {
 	// Logger.
 	logger *zap.Logger
 
+	// Access control for upgrade requests; nil admits everyone.
+	guard *wsGuard
+
 	// Shutdown channel.
 	done chan struct{}
 }
@@ -130,7 +146,10 @@ func (h *Hub) Run() {
 			h.mu.Lock()
 			h.clients[client] = true
 			h.mu.Unlock()
-			h.logger.Info("Client registered", zap.Int("total_clients", len(h.clients)))
+			h.logger.Info("Client registered",
+				zap.String("principal", client.principal),
+				zap.Int("total_clients", len(h.clients)),
+			)
 
 		case client := <-h.unregister:
 			h.mu.Lock()
@@ -160,11 +179,13 @@ func (h *Hub) broadcastMessage(message []byte) {
 		return
 	}
 
+	topics := eventTopics(wsMsg.Type, wsMsg.Data)
+
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
 	for client := range h.clients {
-		if client.isSubscribed(wsMsg.Type) {
+		if client.accepts(wsMsg.Type, topics) {
 			select {
 			case client.send <- message:
 			default:
@@ -205,6 +226,12 @@ func (h *Hub) GetClientCount() int {
 	return len(h.clients)
 }
 
+// SetAccessControl enables origin checks, token authentication and
+// per-principal connection limits. It must be called before ServeWS.
+func (h *Hub) SetAccessControl(cfg *config.WebSocketConfig) {
+	h.guard = newWSGuard(cfg)
+}
+
 // Shutdown gracefully shuts down the hub.
 func (h *Hub) Shutdown() {
 	close(h.done)
@@ -226,15 +253,31 @@ func (h *Hub) shutdown() {
 
 // isSubscribed checks if the client is subscribed to the given event type.
 func (c *Client) isSubscribed(eventType string) bool {
+	return c.accepts(eventType, []string{eventType})
+}
+
+// accepts checks if the client is subscribed to an event of the given type
+// published under the given topics.
+func (c *Client) accepts(eventType string, topics []string) bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	// If no specific subscriptions, receive all events
-	if len(c.subscriptions) == 0 {
+	if len(c.subscriptions) == 0 && len(c.topics) == 0 {
 		return true
 	}
 
-	return c.subscriptions[eventType]
+	if c.subscriptions[eventType] {
+		return true
+	}
+	for pattern := range c.topics {
+		for _, topic := range topics {
+			if topicMatches(pattern, topic) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // subscribe adds event types to the client's subscriptions.
@@ -270,6 +313,9 @@ func (c *Client) readPump() {
 	defer func() {
 		c.hub.unregister <- c
 		c.conn.Close()
+		if c.hub.guard != nil {
+			c.hub.guard.release(c.principal)
+		}
 	}()
 
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
@@ -312,6 +358,16 @@ func (c *Client) readPump() {
 			continue
 		}
 
+		if len(subMsg.Subscribe) > 0 || len(subMsg.Unsubscribe) > 0 {
+			if len(subMsg.Subscribe) > 0 {
+				c.subscribeTopics(subMsg.Subscribe)
+			}
+			if len(subMsg.Unsubscribe) > 0 {
+				c.unsubscribeTopics(subMsg.Unsubscribe)
+			}
+			continue
+		}
+
 		switch subMsg.Action {
 		case "subscribe":
 			c.subscribe(subMsg.EventTypes)
@@ -372,16 +428,36 @@ var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
 	CheckOrigin: func(r *http.Request) bool {
-		// TODO: Implement proper origin checking in production
+		// Origins are checked by the hub's access control before upgrading
 		return true
 	},
 }
 
 // ServeWS handles websocket requests from the peer.
 func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request, logger *zap.Logger) {
+	principal := anonymousPrincipal
+	if h.guard != nil {
+		var status int
+		var err error
+		principal, status, err = h.guard.admit(r)
+		if err != nil {
+			logger.Warn("Rejected WebSocket connection",
+				zap.String("remote_addr", r.RemoteAddr),
+				zap.String("origin", r.Header.Get("Origin")),
+				zap.String("principal", principal),
+				zap.Error(err),
+			)
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		logger.Error("Failed to upgrade connection", zap.Error(err))
+		if h.guard != nil {
+			h.guard.release(principal)
+		}
 		return
 	}
 
@@ -390,7 +466,11 @@ func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request, logger *zap.Logger
 		conn:          conn,
 		send:          make(chan []byte, sendBufferSize),
 		subscriptions: make(map[string]bool),
-		logger:        logger.With(zap.String("remote_addr", r.RemoteAddr)),
+		principal:     principal,
+		logger: logger.With(
+			zap.String("remote_addr", r.RemoteAddr),
+			zap.String("principal", principal),
+		),
 	}
 
 	h.register <- client
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// anonymousPrincipal identifies clients connecting without a token.
+const anonymousPrincipal = "anonymous"
+
+// WebSocket upgrade rejection reasons, reported in metrics.
+const (
+	wsRejectOrigin = "origin"
+	wsRejectAuth   = "auth"
+	wsRejectLimit  = "limit"
+)
+
+var (
+	errWSOriginNotAllowed = errors.New("origin not allowed")
+	errWSUnauthorized     = errors.New("missing or invalid token")
+	errWSTooManyConns     = errors.New("too many connections for principal")
+)
+
+// wsGuard checks WebSocket upgrade requests against the configured origins
+// and tokens, and caps the connections each principal holds.
+type wsGuard struct {
+	allowedOrigins  []string
+	requireAuth     bool
+	tokens          map[string]string // token -> principal
+	maxPerPrincipal int
+
+	mu          sync.Mutex
+	connections map[string]int // by principal
+
+	rejectedOrigin atomic.Int64
+	rejectedAuth   atomic.Int64
+	rejectedLimit  atomic.Int64
+}
+
+// newWSGuard creates a guard from configuration.
+func newWSGuard(cfg *config.WebSocketConfig) *wsGuard {
+	g := &wsGuard{
+		allowedOrigins:  cfg.AllowedOrigins,
+		requireAuth:     cfg.RequireAuth,
+		tokens:          make(map[string]string, len(cfg.Tokens)),
+		maxPerPrincipal: cfg.MaxConnectionsPerPrincipal,
+		connections:     make(map[string]int),
+	}
+	for principal, token := range cfg.Tokens {
+		g.tokens[token] = principal
+	}
+	return g
+}
+
+// admit checks an upgrade request and reserves a connection slot for its
+// principal, which the caller must free with release. It returns the
+// principal, or an error and the HTTP status to reject the request with.
+func (g *wsGuard) admit(r *http.Request) (string, int, error) {
+	if !g.checkOrigin(r) {
+		g.rejectedOrigin.Add(1)
+		return "", http.StatusForbidden, errWSOriginNotAllowed
+	}
+
+	principal, ok := g.authenticate(r)
+	if !ok {
+		g.rejectedAuth.Add(1)
+		return "", http.StatusUnauthorized, errWSUnauthorized
+	}
+
+	if !g.acquire(principal) {
+		g.rejectedLimit.Add(1)
+		return principal, http.StatusTooManyRequests, errWSTooManyConns
+	}
+	return principal, http.StatusOK, nil
+}
+
+// checkOrigin reports whether the request's origin may connect.
+func (g *wsGuard) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, pattern := range g.allowedOrigins {
+		if matched, _ := path.Match(pattern, origin); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate returns the principal of the request's token. Requests
+// without a token are anonymous unless auth is required.
+func (g *wsGuard) authenticate(r *http.Request) (string, bool) {
+	token := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = strings.TrimSpace(bearer)
+	}
+	if token == "" {
+		return anonymousPrincipal, !g.requireAuth
+	}
+
+	principal := ""
+	for known, p := range g.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(known)) == 1 {
+			principal = p
+		}
+	}
+	return principal, principal != ""
+}
+
+// acquire reserves a connection slot for principal.
+func (g *wsGuard) acquire(principal string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.maxPerPrincipal > 0 && g.connections[principal] >= g.maxPerPrincipal {
+		return false
+	}
+	g.connections[principal]++
+	return true
+}
+
+// release frees a connection slot of principal.
+func (g *wsGuard) release(principal string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.connections[principal] <= 1 {
+		delete(g.connections, principal)
+		return
+	}
+	g.connections[principal]--
+}
+
+// principals returns the number of open connections by principal.
+func (g *wsGuard) principals() map[string]int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	counts := make(map[string]int, len(g.connections))
+	for principal, n := range g.connections {
+		counts[principal] = n
+	}
+	return counts
+}
+
+// rejected returns the number of rejected upgrade requests by reason.
+func (g *wsGuard) rejected() map[string]int64 {
+	return map[string]int64{
+		wsRejectOrigin: g.rejectedOrigin.Load(),
+		wsRejectAuth:   g.rejectedAuth.Load(),
+		wsRejectLimit:  g.rejectedLimit.Load(),
+	}
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+func TestWSGuard_Admit(t *testing.T) {
+	guard := newWSGuard(&config.WebSocketConfig{
+		AllowedOrigins:             []string{"https://*.example.com"},
+		Tokens:                     map[string]string{"dashboard": "secret"},
+		MaxConnectionsPerPrincipal: 1,
+	})
+
+	request := func(origin, token string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "http://backend:8083/api/v1/ws/events", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return r
+	}
+
+	_, status, err := guard.admit(request("https://evil.test", "secret"))
+	assert.ErrorIs(t, err, errWSOriginNotAllowed)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	_, status, err = guard.admit(request("https://app.example.com", "wrong"))
+	assert.ErrorIs(t, err, errWSUnauthorized)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	principal, _, err := guard.admit(request("https://app.example.com", "secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "dashboard", principal)
+
+	_, status, err = guard.admit(request("http://backend:8083", "secret"))
+	assert.ErrorIs(t, err, errWSTooManyConns)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+
+	// Tokenless clients share the anonymous principal's limit
+	principal, _, err = guard.admit(request("", ""))
+	require.NoError(t, err)
+	assert.Equal(t, anonymousPrincipal, principal)
+
+	guard.release("dashboard")
+	assert.Equal(t, map[string]int{anonymousPrincipal: 1}, guard.principals())
+	assert.Equal(t, map[string]int64{wsRejectOrigin: 1, wsRejectAuth: 1, wsRejectLimit: 1}, guard.rejected())
+}
+
+func TestWSGuard_RequireAuth(t *testing.T) {
+	guard := newWSGuard(&config.WebSocketConfig{
+		RequireAuth: true,
+		Tokens:      map[string]string{"dashboard": "secret"},
+	})
+
+	_, status, err := guard.admit(httptest.NewRequest(http.MethodGet, "/api/v1/ws/events", nil))
+	assert.ErrorIs(t, err, errWSUnauthorized)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	principal, _, err := guard.admit(httptest.NewRequest(http.MethodGet, "/api/v1/ws/events?token=secret", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "dashboard", principal)
+}
+
+func TestHub_ServeWSReleasesSlot(t *testing.T) {
+	hub := NewHub(zap.NewNop())
+	hub.SetAccessControl(&config.WebSocketConfig{
+		Tokens:                     map[string]string{"dashboard": "secret"},
+		MaxConnectionsPerPrincipal: 1,
+	})
+	go hub.Run()
+	defer hub.Shutdown()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.ServeWS(w, r, zap.NewNop())
+	}))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "?token=secret"
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// Closing the connection frees the slot
+	conn.Close()
+	assert.Eventually(t, func() bool {
+		return len(hub.guard.principals()) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	conn, _, err = websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	conn.Close()
+}
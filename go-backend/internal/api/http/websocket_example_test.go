@@ -69,6 +69,22 @@ package http
 //     event_types: ['backtest.failed']
 //   }));
 //
+// Subscribe to topics instead, to follow particular runs and jobs:
+//   ws.send(JSON.stringify({
+//     subscribe: ['optimization.' + runId, 'backtest.*']
+//   }));
+//
+//   ws.send(JSON.stringify({
+//     unsubscribe: ['optimization.' + runId]
+//   }));
+//
+// Every event is published under its type and the entities it is about:
+// "optimization.<run_id>", "backtest.<job_id>", "strategy.<strategy_id>"
+// and "scout.<run_id>". A topic pattern matches a topic exactly, or ends in
+// ".*" to match every topic with that prefix; "*" matches all. A client
+// receives the events matching any of its event types or topic patterns,
+// and every event until it subscribes to either.
+//
 // Event Types:
 //   - optimization.iteration.started
 //   - optimization.iteration.completed
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxTopicsPerClient bounds the topics one client may subscribe to.
+	maxTopicsPerClient = 200
+
+	// maxTopicLength bounds the length of a topic pattern.
+	maxTopicLength = 128
+)
+
+// topicFields maps fields of event payloads to the topics of the entities
+// they identify. run_id is ambiguous and handled by eventTopics.
+var topicFields = map[string]string{
+	"optimization_run_id": "optimization",
+	"job_id":              "backtest",
+	"strategy_id":         "strategy",
+}
+
+// eventTopics returns the topics an event is published under: its type, such
+// as "backtest.completed", and one per entity it is about, such as
+// "optimization.<run_id>" for the events of an optimization run and
+// "backtest.<job_id>" for those of a backtest job (including task events).
+func eventTopics(eventType string, data interface{}) []string {
+	topics := []string{eventType}
+
+	payload, ok := data.(map[string]interface{})
+	if !ok {
+		return topics
+	}
+
+	for field, prefix := range topicFields {
+		if id, ok := payload[field].(string); ok && id != "" {
+			topics = append(topics, prefix+"."+id)
+		}
+	}
+
+	// run_id is the optimization run of optimization events and the Scout
+	// run of Scout events
+	if id, ok := payload["run_id"].(string); ok && id != "" {
+		switch category, _, _ := strings.Cut(eventType, "."); category {
+		case "optimization", "scout":
+			topics = append(topics, category+"."+id)
+		}
+	}
+
+	return topics
+}
+
+// validTopic reports whether a topic pattern can be subscribed to: "*", an
+// exact topic, or a prefix ending in ".*".
+func validTopic(pattern string) bool {
+	if pattern == "" || len(pattern) > maxTopicLength {
+		return false
+	}
+	if pattern == "*" {
+		return true
+	}
+	return !strings.Contains(strings.TrimSuffix(pattern, ".*"), "*")
+}
+
+// topicMatches reports whether a topic pattern matches a topic. "*" matches
+// every topic and "backtest.*" every topic starting with "backtest.".
+func topicMatches(pattern, topic string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(topic, prefix)
+	}
+	return pattern == topic
+}
+
+// subscribeTopics adds topic patterns to the client's subscriptions. Invalid
+// patterns and patterns beyond maxTopicsPerClient are ignored.
+func (c *Client) subscribeTopics(patterns []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.topics == nil {
+		c.topics = make(map[string]bool)
+	}
+
+	var added, ignored []string
+	for _, pattern := range patterns {
+		if !validTopic(pattern) || (!c.topics[pattern] && len(c.topics) >= maxTopicsPerClient) {
+			ignored = append(ignored, pattern)
+			continue
+		}
+		c.topics[pattern] = true
+		added = append(added, pattern)
+	}
+
+	c.logger.Info("Client subscribed to topics", zap.Strings("topics", added))
+	if len(ignored) > 0 {
+		c.logger.Warn("Ignored topic subscriptions",
+			zap.Strings("topics", ignored),
+			zap.Int("max_topics", maxTopicsPerClient),
+		)
+	}
+}
+
+// unsubscribeTopics removes topic patterns from the client's subscriptions.
+func (c *Client) unsubscribeTopics(patterns []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, pattern := range patterns {
+		delete(c.topics, pattern)
+	}
+
+	c.logger.Info("Client unsubscribed from topics", zap.Strings("topics", patterns))
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestEventTopics(t *testing.T) {
+	tests := []struct {
+		eventType string
+		data      interface{}
+		expected  []string
+	}{
+		{EventTypeOptIterationCompleted, map[string]interface{}{"run_id": "r1", "strategy_id": "s1"},
+			[]string{EventTypeOptIterationCompleted, "optimization.r1", "strategy.s1"}},
+		{EventTypeTaskCancelled, map[string]interface{}{"job_id": "j1", "optimization_run_id": "r1"},
+			[]string{EventTypeTaskCancelled, "backtest.j1", "optimization.r1"}},
+		{"scout.completed", map[string]interface{}{"run_id": "r2"},
+			[]string{"scout.completed", "scout.r2"}},
+		{"strategy.created", map[string]interface{}{"run_id": "r3"},
+			[]string{"strategy.created"}}, // run_id of unknown runs is not a topic
+		{EventTypeAgentStatusUpdate, []interface{}{"agent"},
+			[]string{EventTypeAgentStatusUpdate}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			topics := eventTopics(tt.eventType, tt.data)
+			sort.Strings(topics)
+			sort.Strings(tt.expected)
+			if len(topics) != len(tt.expected) {
+				t.Fatalf("Expected topics %v, got %v", tt.expected, topics)
+			}
+			for i := range topics {
+				if topics[i] != tt.expected[i] {
+					t.Errorf("Expected topics %v, got %v", tt.expected, topics)
+				}
+			}
+		})
+	}
+}
+
+func TestTopicMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		matches bool
+	}{
+		{"*", "optimization.r1", true},
+		{"backtest.*", "backtest.j1", true},
+		{"backtest.*", "backtest.completed", true},
+		{"backtest.*", "backtests.j1", false},
+		{"optimization.r1", "optimization.r1", true},
+		{"optimization.r1", "optimization.r2", false},
+	}
+
+	for _, tt := range tests {
+		if got := topicMatches(tt.pattern, tt.topic); got != tt.matches {
+			t.Errorf("topicMatches(%q, %q) = %v, expected %v", tt.pattern, tt.topic, got, tt.matches)
+		}
+	}
+
+	for _, pattern := range []string{"", "back*test", "*.completed"} {
+		if validTopic(pattern) {
+			t.Errorf("Topic pattern %q should be invalid", pattern)
+		}
+	}
+}
+
+func TestClient_TopicSubscriptions(t *testing.T) {
+	logger := zap.NewNop()
+	hub := NewHub(logger)
+
+	// Start hub
+	go hub.Run()
+	defer hub.Shutdown()
+
+	client := &Client{
+		hub:           hub,
+		send:          make(chan []byte, sendBufferSize),
+		subscriptions: make(map[string]bool),
+		logger:        logger,
+	}
+	client.subscribeTopics([]string{"optimization.r1", "backtest.*", "bad*topic"})
+
+	hub.register <- client
+	time.Sleep(10 * time.Millisecond)
+
+	// Events of the subscribed run and of any backtest are received
+	hub.BroadcastEvent(EventTypeOptIterationCompleted, map[string]string{"run_id": "r1"})
+	hub.BroadcastEvent(EventTypeOptIterationCompleted, map[string]string{"run_id": "r2"})
+	hub.BroadcastEvent(EventTypeTaskCancelled, map[string]string{"job_id": "j1"})
+	hub.BroadcastEvent(EventTypeAgentStatusUpdate, []string{"agent"})
+
+	for _, expected := range []string{EventTypeOptIterationCompleted, EventTypeTaskCancelled} {
+		select {
+		case msg := <-client.send:
+			var wsMsg WSMessage
+			if err := json.Unmarshal(msg, &wsMsg); err != nil {
+				t.Fatalf("Failed to unmarshal message: %v", err)
+			}
+			if wsMsg.Type != expected {
+				t.Errorf("Expected event type %s, got %s", expected, wsMsg.Type)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Should have received %s", expected)
+		}
+	}
+	select {
+	case msg := <-client.send:
+		t.Fatalf("Should not have received %s", msg)
+	case <-time.After(100 * time.Millisecond):
+		// Expected - no message received
+	}
+
+	// Unsubscribing from every topic restores receiving all events
+	client.unsubscribeTopics([]string{"optimization.r1", "backtest.*"})
+	if !client.isSubscribed(EventTypeAgentStatusUpdate) {
+		t.Error("Client with no subscriptions should receive all events")
+	}
+}
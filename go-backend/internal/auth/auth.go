@@ -0,0 +1,220 @@
+// Package auth authenticates REST and gRPC clients by API key. Keys are
+// stored hashed in the database or given in configuration; a key's name is
+// the principal its calls act as.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+var (
+	// ErrUnauthenticated is returned for a missing, unknown, revoked or
+	// expired API key.
+	ErrUnauthenticated = errors.New("missing or invalid API key")
+
+	// ErrForbidden is returned when the role of a principal doesn't allow
+	// an operation.
+	ErrForbidden = errors.New("API key role does not permit this operation")
+)
+
+// Principal is the authenticated caller.
+type Principal struct {
+	Name string
+	Role domain.Role
+
+	// KeyID is the stored key the caller authenticated with; it is nil for
+	// keys given in configuration.
+	KeyID *uuid.UUID
+}
+
+type principalKey struct{}
+
+// NewContext returns a context carrying the principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal carried by the context, if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// Authenticator checks API keys against configuration and the database and
+// manages stored keys.
+type Authenticator struct {
+	enabled     bool
+	repo        repository.APIKeyRepository
+	static      map[string]*Principal // by key hash
+	publicPaths []string
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+// NewAuthenticator creates an authenticator. Keys given by environment
+// variable must be set. Without a repository only configured keys are
+// accepted, and stored keys can't be managed.
+func NewAuthenticator(cfg *config.AuthConfig, repo repository.APIKeyRepository, logger *zap.Logger) (*Authenticator, error) {
+	a := &Authenticator{
+		enabled:     cfg.Enabled,
+		repo:        repo,
+		static:      make(map[string]*Principal, len(cfg.Keys)),
+		publicPaths: cfg.PublicPaths,
+		logger:      logger,
+		now:         time.Now,
+	}
+	for _, k := range cfg.Keys {
+		hash := k.KeyHash
+		if k.KeyEnv != "" {
+			key := os.Getenv(k.KeyEnv)
+			if key == "" {
+				return nil, fmt.Errorf("API key %q: environment variable %s is not set", k.Name, k.KeyEnv)
+			}
+			hash = domain.HashAPIKey(key)
+		}
+		role, err := domain.ParseRole(k.Role)
+		if err != nil {
+			return nil, fmt.Errorf("API key %q: %w", k.Name, err)
+		}
+		a.static[hash] = &Principal{Name: k.Name, Role: role}
+	}
+	return a, nil
+}
+
+// Enabled reports whether calls must carry an API key.
+func (a *Authenticator) Enabled() bool {
+	return a != nil && a.enabled
+}
+
+// IsPublicPath reports whether a REST path is served without a key.
+func (a *Authenticator) IsPublicPath(p string) bool {
+	for _, pattern := range a.publicPaths {
+		if matched, _ := path.Match(pattern, p); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate returns the principal of an API key. It fails with
+// ErrUnauthenticated if the key is empty, unknown, revoked or expired.
+func (a *Authenticator) Authenticate(ctx context.Context, key string) (*Principal, error) {
+	if key == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	hash := domain.HashAPIKey(key)
+	if p, ok := a.static[hash]; ok {
+		return p, nil
+	}
+	if a.repo == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	stored, err := a.repo.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUnauthenticated
+		}
+		return nil, err
+	}
+
+	now := a.now()
+	if !stored.Active(now) {
+		return nil, ErrUnauthenticated
+	}
+	if err := a.repo.TouchLastUsed(ctx, stored.ID, now); err != nil {
+		a.logger.Warn("Failed to record API key use", zap.String("key_id", stored.ID.String()), zap.Error(err))
+	}
+
+	return &Principal{Name: stored.Name, Role: stored.Role, KeyID: &stored.ID}, nil
+}
+
+// Authorize checks that the caller's role includes required. Any caller is
+// allowed while authentication is disabled. It fails with ErrForbidden.
+func (a *Authenticator) Authorize(ctx context.Context, required domain.Role) error {
+	if !a.Enabled() {
+		return nil
+	}
+	if p, ok := FromContext(ctx); ok && p.Role.Allows(required) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanManageKeys checks that the caller may manage API keys: any caller while
+// authentication is disabled, otherwise admins only.
+func (a *Authenticator) CanManageKeys(ctx context.Context) error {
+	return a.Authorize(ctx, domain.RoleAdmin)
+}
+
+// CreateKey stores a new API key and returns it with the key itself, which
+// is not stored and cannot be retrieved later. An empty role is
+// domain.DefaultRole.
+func (a *Authenticator) CreateKey(ctx context.Context, name string, role domain.Role, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	if err := domain.ValidateAPIKeyName(name); err != nil {
+		return nil, "", err
+	}
+	role, err := domain.ParseRole(string(role))
+	if err != nil {
+		return nil, "", err
+	}
+	for _, p := range a.static {
+		if p.Name == name {
+			return nil, "", domain.NewDuplicateError("api key", "name", name)
+		}
+	}
+	now := a.now().UTC()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", fmt.Errorf("%w: expires_at must be in the future", domain.ErrInvalidInput)
+	}
+
+	key, prefix, err := domain.GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	stored := &domain.APIKey{
+		ID:        uuid.New(),
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   domain.HashAPIKey(key),
+		Role:      role,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if p, ok := FromContext(ctx); ok {
+		stored.CreatedBy = p.Name
+	}
+	if err := a.repo.Create(ctx, stored); err != nil {
+		return nil, "", err
+	}
+	return stored, key, nil
+}
+
+// RevokeKey revokes a stored API key. Calls made with it fail from then on.
+func (a *Authenticator) RevokeKey(ctx context.Context, id uuid.UUID) error {
+	return a.repo.Revoke(ctx, id, a.now().UTC())
+}
+
+// BearerKey returns the API key of an Authorization header value of the form
+// "Bearer <key>", or "" if there is none.
+func BearerKey(authorization string) string {
+	if key, ok := strings.CutPrefix(authorization, "Bearer "); ok {
+		return strings.TrimSpace(key)
+	}
+	return ""
+}
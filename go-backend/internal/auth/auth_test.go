@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// memKeyRepo is an in-memory APIKeyRepository.
+type memKeyRepo struct {
+	keys    map[string]*domain.APIKey // by hash
+	touched int
+}
+
+func newMemKeyRepo() *memKeyRepo {
+	return &memKeyRepo{keys: map[string]*domain.APIKey{}}
+}
+
+func (r *memKeyRepo) Create(_ context.Context, key *domain.APIKey) error {
+	for _, k := range r.keys {
+		if k.Name == key.Name && k.RevokedAt == nil {
+			return domain.NewDuplicateError("api key", "name", key.Name)
+		}
+	}
+	r.keys[key.KeyHash] = key
+	return nil
+}
+
+func (r *memKeyRepo) GetByHash(_ context.Context, keyHash string) (*domain.APIKey, error) {
+	if k, ok := r.keys[keyHash]; ok {
+		return k, nil
+	}
+	return nil, domain.NewNotFoundError("api key", "hash")
+}
+
+func (r *memKeyRepo) List(context.Context, bool) ([]*domain.APIKey, error) {
+	return nil, nil
+}
+
+func (r *memKeyRepo) Revoke(_ context.Context, id uuid.UUID, at time.Time) error {
+	for _, k := range r.keys {
+		if k.ID == id && k.RevokedAt == nil {
+			k.RevokedAt = &at
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("api key", id.String())
+}
+
+func (r *memKeyRepo) TouchLastUsed(context.Context, uuid.UUID, time.Time) error {
+	r.touched++
+	return nil
+}
+
+var _ repository.APIKeyRepository = (*memKeyRepo)(nil)
+
+func TestAuthenticator(t *testing.T) {
+	t.Setenv("TEST_ADMIN_KEY", "bootstrap-secret")
+	repo := newMemKeyRepo()
+	a, err := NewAuthenticator(&config.AuthConfig{
+		Enabled: true,
+		Keys: []config.AuthKeyConfig{
+			{Name: "ops", KeyEnv: "TEST_ADMIN_KEY", Role: "admin"},
+			{Name: "ci", KeyHash: domain.HashAPIKey("ci-secret"), Role: "viewer"},
+		},
+		PublicPaths: []string{"/api/v1/strategies/*/badge.svg"},
+	}, repo, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Configured keys
+	p, err := a.Authenticate(ctx, "bootstrap-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "ops", p.Name)
+	assert.Equal(t, domain.RoleAdmin, p.Role)
+
+	p, err = a.Authenticate(ctx, "ci-secret")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleViewer, p.Role)
+	assert.ErrorIs(t, a.CanManageKeys(NewContext(ctx, p)), ErrForbidden)
+	assert.ErrorIs(t, a.Authorize(NewContext(ctx, p), domain.RoleOperator), ErrForbidden)
+	require.NoError(t, a.Authorize(NewContext(ctx, p), domain.RoleViewer))
+
+	_, err = a.Authenticate(ctx, "")
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+	_, err = a.Authenticate(ctx, "wrong")
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	// Stored keys
+	admin := NewContext(ctx, &Principal{Name: "ops", Role: domain.RoleAdmin})
+	require.NoError(t, a.CanManageKeys(admin))
+
+	stored, key, err := a.CreateKey(admin, "scout-agent", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ops", stored.CreatedBy)
+	assert.Equal(t, domain.DefaultRole, stored.Role)
+	assert.Equal(t, key[:len(stored.Prefix)], stored.Prefix)
+	assert.Equal(t, domain.HashAPIKey(key), stored.KeyHash)
+
+	p, err = a.Authenticate(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, "scout-agent", p.Name)
+	assert.Equal(t, stored.ID, *p.KeyID)
+	assert.Equal(t, domain.RoleOperator, p.Role)
+	assert.Equal(t, 1, repo.touched)
+
+	_, _, err = a.CreateKey(admin, "ops", "", nil)
+	assert.ErrorIs(t, err, domain.ErrDuplicate)
+	_, _, err = a.CreateKey(admin, "bad name", "", nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	_, _, err = a.CreateKey(admin, "superuser", "root", nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	past := time.Now().Add(-time.Hour)
+	_, _, err = a.CreateKey(admin, "late", "", &past)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	require.NoError(t, a.RevokeKey(admin, stored.ID))
+	_, err = a.Authenticate(ctx, key)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	// Expired keys
+	now := time.Now()
+	a.now = func() time.Time { return now }
+	soon := now.Add(time.Minute)
+	_, key, err = a.CreateKey(admin, "temp", domain.RoleViewer, &soon)
+	require.NoError(t, err)
+	_, err = a.Authenticate(ctx, key)
+	require.NoError(t, err)
+	a.now = func() time.Time { return now.Add(2 * time.Minute) }
+	_, err = a.Authenticate(ctx, key)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	assert.True(t, a.IsPublicPath("/api/v1/strategies/abc/badge.svg"))
+	assert.False(t, a.IsPublicPath("/api/v1/strategies/abc"))
+}
+
+func TestNewAuthenticator_MissingKeyEnv(t *testing.T) {
+	_, err := NewAuthenticator(&config.AuthConfig{
+		Keys: []config.AuthKeyConfig{{Name: "ops", KeyEnv: "TEST_UNSET_ADMIN_KEY"}},
+	}, newMemKeyRepo(), zap.NewNop())
+	assert.ErrorContains(t, err, "TEST_UNSET_ADMIN_KEY")
+}
+
+func TestNewAuthenticator_InvalidRole(t *testing.T) {
+	_, err := NewAuthenticator(&config.AuthConfig{
+		Keys: []config.AuthKeyConfig{{Name: "ops", KeyHash: domain.HashAPIKey("x"), Role: "root"}},
+	}, newMemKeyRepo(), zap.NewNop())
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestRole_Allows(t *testing.T) {
+	assert.True(t, domain.RoleAdmin.Allows(domain.RoleOperator))
+	assert.True(t, domain.RoleOperator.Allows(domain.RoleViewer))
+	assert.True(t, domain.RoleViewer.Allows(domain.RoleViewer))
+	assert.False(t, domain.RoleViewer.Allows(domain.RoleOperator))
+	assert.False(t, domain.RoleOperator.Allows(domain.RoleAdmin))
+	assert.False(t, domain.Role("").Allows(domain.RoleViewer))
+}
+
+func TestBearerKey(t *testing.T) {
+	assert.Equal(t, "abc", BearerKey("Bearer abc"))
+	assert.Equal(t, "", BearerKey("Basic abc"))
+	assert.Equal(t, "", BearerKey(""))
+}
@@ -0,0 +1,208 @@
+// Package clock provides an injectable time source so schedulers can be driven
+// deterministically in tests and simulations.
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock abstracts the time functions used by the schedulers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+
+	// After waits for the duration to elapse and then sends the current time.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTicker returns a ticker that fires every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker abstracts time.Ticker.
+type Ticker interface {
+	// C returns the channel on which ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// ============================================================================
+// Real Clock
+// ============================================================================
+
+// realClock implements Clock using the time package.
+type realClock struct{}
+
+// Real returns a Clock backed by the system time.
+func Real() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTicker(d time.Duration) Ticker       { return &realTicker{t: time.NewTicker(d)} }
+
+// realTicker wraps time.Ticker.
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r *realTicker) C() <-chan time.Time { return r.t.C }
+func (r *realTicker) Stop()               { r.t.Stop() }
+
+// ============================================================================
+// Fake Clock
+// ============================================================================
+
+// Fake is a manually advanced Clock. Timers and tickers fire only when
+// Advance moves the clock past their deadline, so tests never sleep.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending After channel or ticker.
+type fakeWaiter struct {
+	deadline time.Time
+	period   time.Duration // zero for one-shot timers
+	ch       chan time.Time
+	stopped  bool
+}
+
+// NewFake creates a fake clock set to the given time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake clock's current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the fake time elapsed since t.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After returns a channel that receives once the clock is advanced by d.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w := &fakeWaiter{deadline: f.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.ch <- f.now
+		return w.ch
+	}
+	f.waiters = append(f.waiters, w)
+	return w.ch
+}
+
+// NewTicker returns a ticker that fires each time the clock advances past a period.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w := &fakeWaiter{deadline: f.now.Add(d), period: d, ch: make(chan time.Time, 1)}
+	f.waiters = append(f.waiters, w)
+	return &fakeTicker{clock: f, w: w}
+}
+
+// Advance moves the clock forward by d, firing any timers and tickers that
+// become due. Like time.Ticker, a ticker whose receiver is slow drops ticks.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	target := f.now.Add(d)
+	for {
+		w := f.nextDue(target)
+		if w == nil {
+			break
+		}
+
+		f.now = w.deadline
+		select {
+		case w.ch <- f.now:
+		default:
+		}
+
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+		} else {
+			w.stopped = true
+		}
+	}
+	f.now = target
+	f.prune()
+}
+
+// Set moves the clock to t, firing due timers. It never moves the clock backwards.
+func (f *Fake) Set(t time.Time) {
+	if d := t.Sub(f.Now()); d > 0 {
+		f.Advance(d)
+	}
+}
+
+// Waiters returns the number of pending timers and tickers. Tests use it to
+// wait until a goroutine has blocked on the clock before advancing it.
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prune()
+	return len(f.waiters)
+}
+
+// nextDue returns the earliest active waiter due at or before target.
+func (f *Fake) nextDue(target time.Time) *fakeWaiter {
+	var due []*fakeWaiter
+	for _, w := range f.waiters {
+		if !w.stopped && !w.deadline.After(target) {
+			due = append(due, w)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].deadline.Before(due[j].deadline) })
+	return due[0]
+}
+
+// prune drops fired one-shot timers and stopped tickers.
+func (f *Fake) prune() {
+	active := f.waiters[:0]
+	for _, w := range f.waiters {
+		if !w.stopped {
+			active = append(active, w)
+		}
+	}
+	f.waiters = active
+}
+
+// fakeTicker implements Ticker on top of a Fake clock.
+type fakeTicker struct {
+	clock *Fake
+	w     *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.w.ch }
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.w.stopped = true
+}
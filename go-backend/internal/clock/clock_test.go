@@ -0,0 +1,67 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var epoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFake_After(t *testing.T) {
+	c := NewFake(epoch)
+	ch := c.After(time.Minute)
+
+	c.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	c.Advance(30 * time.Second)
+	select {
+	case got := <-ch:
+		assert.Equal(t, epoch.Add(time.Minute), got)
+	default:
+		t.Fatal("timer did not fire")
+	}
+	assert.Equal(t, 0, c.Waiters())
+}
+
+func TestFake_Ticker(t *testing.T) {
+	c := NewFake(epoch)
+	ticker := c.NewTicker(10 * time.Second)
+
+	c.Advance(10 * time.Second)
+	assert.Equal(t, epoch.Add(10*time.Second), <-ticker.C())
+
+	// A slow receiver drops ticks rather than queueing them
+	c.Advance(35 * time.Second)
+	assert.Equal(t, epoch.Add(20*time.Second), <-ticker.C())
+	select {
+	case <-ticker.C():
+		t.Fatal("expected dropped ticks")
+	default:
+	}
+
+	ticker.Stop()
+	c.Advance(time.Minute)
+	select {
+	case <-ticker.C():
+		t.Fatal("stopped ticker fired")
+	default:
+	}
+	assert.Equal(t, 0, c.Waiters())
+}
+
+func TestFake_NowAndSince(t *testing.T) {
+	c := NewFake(epoch)
+	c.Advance(time.Hour)
+	assert.Equal(t, epoch.Add(time.Hour), c.Now())
+	assert.Equal(t, time.Hour, c.Since(epoch))
+
+	c.Set(epoch)
+	assert.Equal(t, epoch.Add(time.Hour), c.Now(), "Set never moves backwards")
+}
@@ -67,6 +67,9 @@ func applyEnvOverrides(cfg *Config) {
 			cfg.GoBackend.HTTPPort = port
 		}
 	}
+	if v := os.Getenv("SERVER_TIMEZONE"); v != "" {
+		cfg.GoBackend.Timezone = v
+	}
 
 	// Database
 	if v := os.Getenv("DB_HOST"); v != "" {
@@ -89,6 +92,9 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("DB_SSLMODE"); v != "" {
 		cfg.GoBackend.Database.SSLMode = v
 	}
+	if v := os.Getenv("DB_SLOW_QUERY_THRESHOLD"); v != "" {
+		cfg.GoBackend.Database.SlowQueryThreshold = v
+	}
 	if v := os.Getenv("DB_MAX_CONNECTIONS"); v != "" {
 		if n, err := strconv.Atoi(v); err == nil {
 			cfg.GoBackend.Database.MaxConnections = n
@@ -102,6 +108,12 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("RABBITMQ_EXCHANGE"); v != "" {
 		cfg.GoBackend.RabbitMQ.Exchange = v
 	}
+	if v := os.Getenv("RABBITMQ_QUEUE_PREFIX"); v != "" {
+		cfg.GoBackend.RabbitMQ.QueuePrefix = v
+	}
+	if v := os.Getenv("RABBITMQ_DEAD_LETTER_EXCHANGE"); v != "" {
+		cfg.GoBackend.RabbitMQ.DeadLetterExchange = v
+	}
 
 	// Scheduler
 	if v := os.Getenv("MAX_CONCURRENT_BACKTESTS"); v != "" {
@@ -119,6 +131,169 @@ func applyEnvOverrides(cfg *Config) {
 			cfg.GoBackend.Scheduler.MaxRetries = n
 		}
 	}
+	if v := os.Getenv("VALIDATION_CONCURRENCY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.GoBackend.Scheduler.ValidationConcurrency = n
+		}
+	}
+	if v := os.Getenv("SCHEDULER_STATE_FILE"); v != "" {
+		cfg.GoBackend.Scheduler.StateFile = v
+	}
+
+	// Load shedding
+	if v := os.Getenv("HTTP_MAX_IN_FLIGHT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.GoBackend.LoadShedding.MaxInFlight = n
+		}
+	}
+
+	// Response cache
+	if v := os.Getenv("HTTP_RESPONSE_CACHE_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.ResponseCache.Enabled = b
+		}
+	}
+
+	// API key authentication
+	if v := os.Getenv("AUTH_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.Auth.Enabled = b
+		}
+	}
+
+	// WebSocket access control, e.g. WS_TOKENS="dashboard=secret1,ops=secret2"
+	if v := os.Getenv("WS_ALLOWED_ORIGINS"); v != "" {
+		cfg.GoBackend.WebSocket.AllowedOrigins = splitList(v)
+	}
+	if v := os.Getenv("WS_REQUIRE_AUTH"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.WebSocket.RequireAuth = b
+		}
+	}
+	if v := os.Getenv("WS_TOKENS"); v != "" {
+		if cfg.GoBackend.WebSocket.Tokens == nil {
+			cfg.GoBackend.WebSocket.Tokens = make(map[string]string)
+		}
+		for _, pair := range splitList(v) {
+			principal, token, ok := strings.Cut(pair, "=")
+			if !ok {
+				continue
+			}
+			cfg.GoBackend.WebSocket.Tokens[strings.TrimSpace(principal)] = strings.TrimSpace(token)
+		}
+	}
+	if v := os.Getenv("WS_MAX_CONNECTIONS_PER_PRINCIPAL"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.GoBackend.WebSocket.MaxConnectionsPerPrincipal = n
+		}
+	}
+
+	// Archival
+	if v := os.Getenv("STRATEGY_ARCHIVAL_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.Archival.Enabled = b
+		}
+	}
+	if v := os.Getenv("STRATEGY_ARCHIVAL_DRY_RUN"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.Archival.DryRun = b
+		}
+	}
+
+	// Descriptions
+	if v := os.Getenv("DESCRIPTION_QUEUE_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.Descriptions.Enabled = b
+		}
+	}
+
+	// Export
+	if v := os.Getenv("EXPORT_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.Export.Enabled = b
+		}
+	}
+
+	// gRPC-Web
+	if v := os.Getenv("GRPC_WEB_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.GRPCWeb.Enabled = b
+		}
+	}
+
+	// SLA
+	if v := os.Getenv("SLA_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.SLA.Enabled = b
+		}
+	}
+	if v := os.Getenv("SLA_MAX_QUEUE_WAIT"); v != "" {
+		cfg.GoBackend.SLA.MaxQueueWait = v
+	}
+	if v := os.Getenv("SLA_MAX_RUN_DURATION"); v != "" {
+		cfg.GoBackend.SLA.MaxRunDuration = v
+	}
+
+	// Auto-pause
+	if v := os.Getenv("OPTIMIZATION_AUTO_PAUSE_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.AutoPause.Enabled = b
+		}
+	}
+
+	// Stall detection
+	if v := os.Getenv("OPTIMIZATION_STALL_DETECTION_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.Stall.Enabled = b
+		}
+	}
+	if v := os.Getenv("OPTIMIZATION_STALL_THRESHOLD"); v != "" {
+		cfg.GoBackend.Stall.Threshold = v
+	}
+
+	// Currency normalization
+	if v := os.Getenv("RESULT_REFERENCE_CURRENCY"); v != "" {
+		cfg.GoBackend.Currency.ReferenceCurrency = v
+	}
+	if v := os.Getenv("RESULT_PRICE_SOURCE"); v != "" {
+		cfg.GoBackend.Currency.PriceSource = v
+	}
+
+	// Optimization progress events
+	if v := os.Getenv("OPTIMIZATION_PROGRESS_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.Progress.Enabled = b
+		}
+	}
+
+	// Agent registration
+	if v := os.Getenv("AGENTS_ENFORCE_CAPABILITIES"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.GoBackend.Agents.EnforceCapabilities = b
+		}
+	}
+
+	// Secrets master key file, e.g. mounted by a KMS agent
+	if v := os.Getenv("SECRETS_MASTER_KEY_FILE"); v != "" {
+		cfg.GoBackend.Secrets.MasterKeySource = SecretsKeySourceFile
+		cfg.GoBackend.Secrets.MasterKeyFile = v
+	}
+
+	// Feature flags, e.g. FEATURE_FLAGS="hyperopt=true,auth=false"
+	if v := os.Getenv("FEATURE_FLAGS"); v != "" {
+		if cfg.GoBackend.Features.Flags == nil {
+			cfg.GoBackend.Features.Flags = make(map[string]bool)
+		}
+		for _, pair := range strings.Split(v, ",") {
+			name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok {
+				continue
+			}
+			if b, err := strconv.ParseBool(value); err == nil {
+				cfg.GoBackend.Features.Flags[strings.TrimSpace(name)] = b
+			}
+		}
+	}
 
 	// Docker
 	if v := os.Getenv("DOCKER_IMAGE"); v != "" {
@@ -136,9 +311,17 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("DOCKER_MEMORY_LIMIT"); v != "" {
 		cfg.GoBackend.Docker.MemoryLimit = v
 	}
+	if v := os.Getenv("DOCKER_MAX_CONTAINERS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.GoBackend.Docker.MaxContainers = n
+		}
+	}
 	if v := os.Getenv("FREQTRADE_BASE_CONFIG"); v != "" {
 		cfg.GoBackend.Docker.BaseConfigPath = v
 	}
+	if v := os.Getenv("BACKTEST_EXECUTOR"); v != "" {
+		cfg.GoBackend.Docker.Executor = v
+	}
 
 	// Logging
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
@@ -147,6 +330,39 @@ func applyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("LOG_FORMAT"); v != "" {
 		cfg.Logging.Format = strings.ToLower(v)
 	}
+	if v := os.Getenv("LOG_REDACTION_ENABLED"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Logging.Redaction.Enabled = b
+		}
+	}
+	// Comma-separated, e.g. LOG_REDACTION_FIELDS="code,password,token"
+	if v := os.Getenv("LOG_REDACTION_FIELDS"); v != "" {
+		cfg.Logging.Redaction.LogFields = splitList(v)
+	}
+	if v := os.Getenv("LOG_REDACTION_MAX_FIELD_BYTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Logging.Redaction.MaxLogFieldBytes = n
+		}
+	}
+	if v := os.Getenv("EVENT_REDACTION_FIELDS"); v != "" {
+		cfg.Logging.Redaction.EventFields = splitList(v)
+	}
+	if v := os.Getenv("EVENT_REDACTION_MAX_FIELD_BYTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Logging.Redaction.MaxEventFieldBytes = n
+		}
+	}
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // MustLoad loads configuration and panics on error.
@@ -3,7 +3,13 @@ package config
 import (
 	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 )
 
 // ValidationError represents a configuration validation error.
@@ -67,6 +73,14 @@ func Validate(cfg *Config) error {
 			Message: "gRPC and HTTP ports must be different",
 		})
 	}
+	if cfg.GoBackend.Timezone != "" {
+		if _, err := time.LoadLocation(cfg.GoBackend.Timezone); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.timezone",
+				Message: fmt.Sprintf("unknown timezone %q: must be an IANA name such as UTC or Europe/Berlin", cfg.GoBackend.Timezone),
+			})
+		}
+	}
 
 	// Validate database
 	errs = append(errs, validateDatabase(&cfg.GoBackend.Database)...)
@@ -77,6 +91,76 @@ func Validate(cfg *Config) error {
 	// Validate Scheduler
 	errs = append(errs, validateScheduler(&cfg.GoBackend.Scheduler)...)
 
+	// Validate Load Shedding
+	errs = append(errs, validateLoadShedding(&cfg.GoBackend.LoadShedding)...)
+
+	// Validate response cache
+	errs = append(errs, validateResponseCache(&cfg.GoBackend.ResponseCache)...)
+	errs = append(errs, validateAccessLog(&cfg.GoBackend.AccessLog)...)
+
+	// Validate WebSocket access control
+	errs = append(errs, validateWebSocket(&cfg.GoBackend.WebSocket)...)
+
+	// Validate archival policy
+	errs = append(errs, validateArchival(&cfg.GoBackend.Archival)...)
+	errs = append(errs, validateDescriptions(&cfg.GoBackend.Descriptions)...)
+
+	// Validate export worker
+	errs = append(errs, validateExport(&cfg.GoBackend.Export)...)
+
+	// Validate re-validation worker
+	errs = append(errs, validateRevalidation(&cfg.GoBackend.Revalidation)...)
+	errs = append(errs, validateHyperopt(&cfg.GoBackend.Hyperopt)...)
+
+	// Validate gRPC-Web
+	errs = append(errs, validateGRPCWeb(&cfg.GoBackend.GRPCWeb)...)
+
+	// Validate job watch streams
+	errs = append(errs, validateJobWatch(&cfg.GoBackend.JobWatch)...)
+
+	// Validate market data management
+	errs = append(errs, validateMarketData(&cfg.GoBackend.MarketData)...)
+
+	// Validate SLA targets
+	errs = append(errs, validateSLA(&cfg.GoBackend.SLA)...)
+
+	// Validate auto-pause
+	errs = append(errs, validateAutoPause(&cfg.GoBackend.AutoPause)...)
+
+	// Validate stall detection
+	errs = append(errs, validateStall(&cfg.GoBackend.Stall)...)
+
+	// Validate maintenance mode
+	errs = append(errs, validateMaintenance(&cfg.GoBackend.Maintenance)...)
+
+	// Validate currency normalization
+	errs = append(errs, validateCurrency(&cfg.GoBackend.Currency)...)
+
+	// Validate optimization progress events
+	errs = append(errs, validateProgress(&cfg.GoBackend.Progress)...)
+
+	// Validate feature flags
+	errs = append(errs, validateFeatures(&cfg.GoBackend.Features)...)
+
+	// Validate agent registration
+	errs = append(errs, validateAgents(&cfg.GoBackend.Agents)...)
+	errs = append(errs, validateSecrets(&cfg.GoBackend.Secrets)...)
+
+	// Validate Scout source etiquette
+	errs = append(errs, validateScout(&cfg.GoBackend.Scout)...)
+
+	// Validate percentile ranking
+	errs = append(errs, validateRanking(&cfg.GoBackend.Ranking)...)
+
+	// Validate insight templates
+	errs = append(errs, validateInsights(&cfg.GoBackend.Insights)...)
+
+	// Validate API key authentication
+	errs = append(errs, validateAuth(&cfg.GoBackend.Auth)...)
+
+	// Validate pagination limits
+	errs = append(errs, validatePagination(&cfg.GoBackend.Pagination)...)
+
 	// Validate Docker
 	errs = append(errs, validateDocker(&cfg.GoBackend.Docker)...)
 
@@ -154,6 +238,14 @@ func validateDatabase(db *DatabaseConfig) ValidationErrors {
 			Message: "must not exceed max_connections",
 		})
 	}
+	if db.SlowQueryThreshold != "" {
+		if d, err := time.ParseDuration(db.SlowQueryThreshold); err != nil || d < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.database.slow_query_threshold",
+				Message: "must be a valid non-negative duration",
+			})
+		}
+	}
 
 	return errs
 }
@@ -178,6 +270,29 @@ func validateRabbitMQ(mq *RabbitMQConfig) ValidationErrors {
 			Field:   "go_backend.rabbitmq.exchange",
 			Message: "is required",
 		})
+	} else if msg := amqpNameError(mq.Exchange); msg != "" {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.rabbitmq.exchange",
+			Message: msg,
+		})
+	}
+
+	switch mq.ExchangeType {
+	case "", "topic", "direct":
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.rabbitmq.exchange_type",
+			Message: "must be topic or direct",
+		})
+	}
+
+	if mq.QueuePrefix != "" {
+		if msg := amqpNameError(mq.QueuePrefix); msg != "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.rabbitmq.queue_prefix",
+				Message: msg,
+			})
+		}
 	}
 
 	if mq.PrefetchCount <= 0 {
@@ -187,9 +302,51 @@ func validateRabbitMQ(mq *RabbitMQConfig) ValidationErrors {
 		})
 	}
 
+	if mq.DeadLetterExchange != "" {
+		if msg := amqpNameError(mq.DeadLetterExchange); msg != "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.rabbitmq.dead_letter_exchange",
+				Message: msg,
+			})
+		} else if mq.DeadLetterExchange == mq.Exchange {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.rabbitmq.dead_letter_exchange",
+				Message: "must differ from exchange",
+			})
+		}
+	}
+	if mq.DeadLetterQueue != "" {
+		if mq.DeadLetterExchange == "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.rabbitmq.dead_letter_queue",
+				Message: "requires dead_letter_exchange",
+			})
+		} else if msg := amqpNameError(mq.QueueName(mq.DeadLetterQueue)); msg != "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.rabbitmq.dead_letter_queue",
+				Message: msg,
+			})
+		}
+	}
+
 	return errs
 }
 
+// amqpNameRegex matches the exchange and queue names the backend declares.
+var amqpNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.:-]{1,255}$`)
+
+// amqpNameError returns why an exchange or queue name can't be declared, or
+// "" if it can. Names starting with "amq." are reserved by the broker.
+func amqpNameError(name string) string {
+	if !amqpNameRegex.MatchString(name) {
+		return "must be 1-255 characters of letters, digits, '_', '.', ':' or '-'"
+	}
+	if strings.HasPrefix(name, "amq.") {
+		return `must not start with "amq.", which is reserved by the broker`
+	}
+	return ""
+}
+
 func validateScheduler(s *SchedulerConfig) ValidationErrors {
 	var errs ValidationErrors
 
@@ -227,67 +384,1147 @@ func validateScheduler(s *SchedulerConfig) ValidationErrors {
 		})
 	}
 
+	if s.CancelGracePeriod != "" {
+		if d, err := time.ParseDuration(s.CancelGracePeriod); err != nil || d < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.scheduler.cancel_grace_period",
+				Message: "must be a non-negative duration (e.g., 30s)",
+			})
+		}
+	}
+
+	if s.ValidationConcurrency < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.scheduler.validation_concurrency",
+			Message: "must be non-negative",
+		})
+	}
+	if s.MaxValidationBatch < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.scheduler.max_validation_batch",
+			Message: "must be non-negative",
+		})
+	}
+	if s.ValidationBatchTimeout != "" {
+		if d, err := time.ParseDuration(s.ValidationBatchTimeout); err != nil || d <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.scheduler.validation_batch_timeout",
+				Message: "must be a positive duration (e.g., 2m)",
+			})
+		}
+	}
+
+	if s.AffinityMaxDeferral != "" {
+		if d, err := time.ParseDuration(s.AffinityMaxDeferral); err != nil || d < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.scheduler.affinity_max_deferral",
+				Message: "must be a non-negative duration (e.g., 10m)",
+			})
+		}
+	}
+	if s.PriorityAgingPerHour < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.scheduler.priority_aging_per_hour",
+			Message: "must be non-negative",
+		})
+	}
+
+	errs = append(errs, validateParser(&s.Parser)...)
+	errs = append(errs, validateBackpressure(&s.Backpressure)...)
+	errs = append(errs, validateCodeSafety(&s.CodeSafety)...)
+
 	return errs
 }
 
-func validateDocker(d *DockerConfig) ValidationErrors {
+func validateBackpressure(b *BackpressureConfig) ValidationErrors {
 	var errs ValidationErrors
 
-	if d.Image == "" {
+	if !b.Enabled {
+		return errs
+	}
+
+	if b.CheckInterval != "" {
+		if d, err := time.ParseDuration(b.CheckInterval); err != nil || d <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.scheduler.backpressure.check_interval",
+				Message: "must be a positive duration (e.g., 15s)",
+			})
+		}
+	}
+
+	if b.MaxCPUPercent <= 0 || b.MaxCPUPercent > 100 {
 		errs = append(errs, ValidationError{
-			Field:   "go_backend.docker.image",
-			Message: "is required",
+			Field:   "go_backend.scheduler.backpressure.max_cpu_percent",
+			Message: "must be between 0 and 100",
+		})
+	}
+	if b.MaxMemoryPercent <= 0 || b.MaxMemoryPercent > 100 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.scheduler.backpressure.max_memory_percent",
+			Message: "must be between 0 and 100",
 		})
 	}
 
-	if d.DataMount == "" {
+	// Without a gap between the thresholds dequeuing would flap
+	resumeCPU, resumeMemory := b.ResumeThresholds()
+	if resumeCPU <= 0 || resumeCPU >= b.MaxCPUPercent {
 		errs = append(errs, ValidationError{
-			Field:   "go_backend.docker.data_mount",
-			Message: "is required",
+			Field:   "go_backend.scheduler.backpressure.resume_cpu_percent",
+			Message: "must be positive and below max_cpu_percent",
+		})
+	}
+	if resumeMemory <= 0 || resumeMemory >= b.MaxMemoryPercent {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.scheduler.backpressure.resume_memory_percent",
+			Message: "must be positive and below max_memory_percent",
 		})
 	}
 
-	if d.CPULimit == "" {
+	return errs
+}
+
+// pythonIdentifier matches a builtin name or a dotted module name.
+var pythonIdentifier = regexp.MustCompile(`^[A-Za-z_]\w*(\.[A-Za-z_]\w*)*$`)
+
+func validateCodeSafety(c *CodeSafetyConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	for _, module := range c.DeniedImports {
+		if !pythonIdentifier.MatchString(module) {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.scheduler.code_safety.denied_imports",
+				Message: fmt.Sprintf("%q is not a module name", module),
+			})
+		}
+	}
+	for _, name := range c.DeniedCalls {
+		if !pythonIdentifier.MatchString(name) || strings.Contains(name, ".") {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.scheduler.code_safety.denied_calls",
+				Message: fmt.Sprintf("%q is not a builtin name", name),
+			})
+		}
+	}
+
+	return errs
+}
+
+func validateParser(p *ParserConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	names := map[string]bool{"builtin": true}
+	for i, f := range p.Formats {
+		field := fmt.Sprintf("go_backend.scheduler.parser.formats[%d]", i)
+		if f.Name == "" {
+			errs = append(errs, ValidationError{Field: field + ".name", Message: "is required"})
+		} else if names[f.Name] {
+			errs = append(errs, ValidationError{Field: field + ".name", Message: fmt.Sprintf("duplicate format %q", f.Name)})
+		}
+		if f.Extends != "" && !names[f.Extends] {
+			errs = append(errs, ValidationError{
+				Field:   field + ".extends",
+				Message: fmt.Sprintf("must be \"builtin\" or an earlier format, got %q", f.Extends),
+			})
+		}
+		names[f.Name] = true
+
+		for metric, pattern := range f.Patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, ValidationError{
+					Field:   field + ".patterns." + metric,
+					Message: fmt.Sprintf("invalid regular expression: %v", err),
+				})
+			}
+		}
+		rowPatterns := []struct{ name, pattern string }{
+			{"pair_pattern", f.PairPattern},
+			{"exit_reason_pattern", f.ExitReasonPattern},
+			{"entry_tag_pattern", f.EntryTagPattern},
+		}
+		for _, p := range rowPatterns {
+			if _, err := regexp.Compile(p.pattern); err != nil {
+				errs = append(errs, ValidationError{
+					Field:   field + "." + p.name,
+					Message: fmt.Sprintf("invalid regular expression: %v", err),
+				})
+			}
+		}
+	}
+
+	if p.DefaultFormat != "" && !names[p.DefaultFormat] {
 		errs = append(errs, ValidationError{
-			Field:   "go_backend.docker.cpu_limit",
-			Message: "is required",
+			Field:   "go_backend.scheduler.parser.default_format",
+			Message: fmt.Sprintf("unknown format %q", p.DefaultFormat),
 		})
 	}
 
-	if d.MemoryLimit == "" {
+	return errs
+}
+
+func validateLoadShedding(l *LoadSheddingConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if l.MaxInFlight < 0 {
 		errs = append(errs, ValidationError{
-			Field:   "go_backend.docker.memory_limit",
-			Message: "is required",
+			Field:   "go_backend.load_shedding.max_in_flight",
+			Message: "must be non-negative (0 disables the limit)",
+		})
+	}
+	if l.RetryAfterSeconds < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.load_shedding.retry_after_seconds",
+			Message: "must be non-negative",
 		})
 	}
+	for prefix, limit := range l.EndpointLimits {
+		if !strings.HasPrefix(prefix, "/") {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.load_shedding.endpoint_limits",
+				Message: fmt.Sprintf("path prefix %q must start with /", prefix),
+			})
+		}
+		if limit <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.load_shedding.endpoint_limits",
+				Message: fmt.Sprintf("limit for %q must be positive", prefix),
+			})
+		}
+	}
 
 	return errs
 }
 
-func validateLogging(l *LoggingConfig) ValidationErrors {
+func validateResponseCache(c *ResponseCacheConfig) ValidationErrors {
 	var errs ValidationErrors
 
-	validLevels := map[string]bool{
-		"debug": true,
-		"info":  true,
-		"warn":  true,
-		"error": true,
+	if !c.Enabled {
+		return errs
 	}
-	if !validLevels[l.Level] {
+
+	if c.MaxEntries <= 0 {
 		errs = append(errs, ValidationError{
-			Field:   "logging.level",
-			Message: "must be one of: debug, info, warn, error",
+			Field:   "go_backend.response_cache.max_entries",
+			Message: "must be positive",
 		})
 	}
+	for path, endpoint := range c.Endpoints {
+		if !strings.HasPrefix(path, "/") {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.response_cache.endpoints",
+				Message: fmt.Sprintf("path %q must start with /", path),
+			})
+		}
+		if d, err := time.ParseDuration(endpoint.TTL); err != nil || d <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.response_cache.endpoints",
+				Message: fmt.Sprintf("ttl for %q must be a positive duration", path),
+			})
+		}
+		for _, prefix := range endpoint.InvalidateOnWrites {
+			if !strings.HasPrefix(prefix, "/") {
+				errs = append(errs, ValidationError{
+					Field:   "go_backend.response_cache.endpoints",
+					Message: fmt.Sprintf("invalidation prefix %q for %q must start with /", prefix, path),
+				})
+			}
+		}
+	}
 
-	validFormats := map[string]bool{
-		"json":    true,
-		"console": true,
+	return errs
+}
+
+func validateAccessLog(a *AccessLogConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !a.Enabled {
+		return errs
 	}
-	if !validFormats[l.Format] {
+
+	if a.SlowThreshold != "" {
+		if d, err := time.ParseDuration(a.SlowThreshold); err != nil || d < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.access_log.slow_threshold",
+				Message: "must be a valid non-negative duration",
+			})
+		}
+	}
+	for prefix, rate := range a.HTTPSampleRates {
+		if !strings.HasPrefix(prefix, "/") {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.access_log.http_sample_rates",
+				Message: fmt.Sprintf("path prefix %q must start with /", prefix),
+			})
+		}
+		if rate < 0 || rate > 1 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.access_log.http_sample_rates",
+				Message: fmt.Sprintf("rate for %q must be between 0 and 1", prefix),
+			})
+		}
+	}
+	for method, rate := range a.GRPCSampleRates {
+		if method == "" || strings.Contains(method, "/") {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.access_log.grpc_sample_rates",
+				Message: fmt.Sprintf("%q must be a method name prefix without the service", method),
+			})
+		}
+		if rate < 0 || rate > 1 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.access_log.grpc_sample_rates",
+				Message: fmt.Sprintf("rate for %q must be between 0 and 1", method),
+			})
+		}
+	}
+
+	return errs
+}
+
+func validateWebSocket(ws *WebSocketConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	for _, origin := range ws.AllowedOrigins {
+		if _, err := path.Match(origin, ""); err != nil || origin == "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.websocket.allowed_origins",
+				Message: fmt.Sprintf("invalid origin pattern %q", origin),
+			})
+		}
+	}
+
+	principals := make(map[string]string, len(ws.Tokens))
+	for principal, token := range ws.Tokens {
+		if principal == "" || token == "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.websocket.tokens",
+				Message: "principal names and tokens must not be empty",
+			})
+			continue
+		}
+		if other, ok := principals[token]; ok {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.websocket.tokens",
+				Message: fmt.Sprintf("principals %q and %q share a token", other, principal),
+			})
+		}
+		principals[token] = principal
+	}
+	if ws.RequireAuth && len(ws.Tokens) == 0 {
 		errs = append(errs, ValidationError{
-			Field:   "logging.format",
-			Message: "must be one of: json, console",
+			Field:   "go_backend.websocket.tokens",
+			Message: "at least one token is required when require_auth is set",
+		})
+	}
+
+	if ws.MaxConnectionsPerPrincipal < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.websocket.max_connections_per_principal",
+			Message: "must not be negative",
+		})
+	}
+
+	return errs
+}
+
+func validateDescriptions(d *DescriptionsConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !d.Enabled {
+		return errs
+	}
+
+	if v, err := time.ParseDuration(d.Interval); err != nil || v < time.Second {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.descriptions.interval",
+			Message: "must be a valid duration of at least 1s (e.g., 5m)",
+		})
+	}
+	if d.BatchSize <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.descriptions.batch_size",
+			Message: "must be positive",
+		})
+	}
+	if d.MaxPerHour < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.descriptions.max_per_hour",
+			Message: "must be non-negative",
+		})
+	}
+	if v, err := time.ParseDuration(d.RetryAfter); err != nil || v <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.descriptions.retry_after",
+			Message: "must be a positive duration (e.g., 24h)",
+		})
+	}
+
+	return errs
+}
+
+func validateArchival(a *ArchivalConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !a.Enabled {
+		return errs
+	}
+
+	if d, err := time.ParseDuration(a.Interval); err != nil || d < time.Minute {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.archival.interval",
+			Message: "must be a valid duration of at least 1m (e.g., 6h)",
+		})
+	}
+	if a.MinBacktests < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.archival.min_backtests",
+			Message: "must be non-negative",
+		})
+	}
+	if a.InactiveDays <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.archival.inactive_days",
+			Message: "must be positive",
+		})
+	}
+	if a.BatchSize <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.archival.batch_size",
+			Message: "must be positive",
+		})
+	}
+
+	return errs
+}
+
+func validateExport(e *ExportConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !e.Enabled {
+		return errs
+	}
+
+	if d, err := time.ParseDuration(e.PollInterval); err != nil || d <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.export.poll_interval",
+			Message: "must be a valid positive duration (e.g., 5s)",
+		})
+	}
+	if e.BatchSize <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.export.batch_size",
+			Message: "must be positive",
+		})
+	}
+
+	return errs
+}
+
+func validateRevalidation(r *RevalidationConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !r.Enabled {
+		return errs
+	}
+
+	if d, err := time.ParseDuration(r.PollInterval); err != nil || d <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.revalidation.poll_interval",
+			Message: "must be a valid positive duration (e.g., 5s)",
+		})
+	}
+	if r.Concurrency <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.revalidation.concurrency",
+			Message: "must be positive",
+		})
+	}
+
+	return errs
+}
+
+func validateHyperopt(h *HyperoptConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !h.Enabled {
+		return errs
+	}
+
+	if d, err := time.ParseDuration(h.PollInterval); err != nil || d <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.hyperopt.poll_interval",
+			Message: "must be a valid positive duration (e.g., 10s)",
+		})
+	}
+	if h.TimeoutMinutes <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.hyperopt.timeout_minutes",
+			Message: "must be positive",
+		})
+	}
+	if h.MaxEpochs <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.hyperopt.max_epochs",
+			Message: "must be positive",
+		})
+	}
+	if h.CPULimit < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.hyperopt.cpu_limit",
+			Message: "must not be negative",
+		})
+	}
+	if h.MemoryLimitMB < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.hyperopt.memory_limit_mb",
+			Message: "must not be negative",
+		})
+	}
+
+	return errs
+}
+
+func validateJobWatch(j *JobWatchConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if d, err := time.ParseDuration(j.PollInterval); err != nil || d <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.job_watch.poll_interval",
+			Message: "must be a valid positive duration (e.g., 1s)",
+		})
+	}
+
+	return errs
+}
+
+func validateMarketData(m *MarketDataConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if m.AutoDownload && !m.VerifyBeforeStart {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.market_data.auto_download",
+			Message: "requires verify_before_start",
+		})
+	}
+	if m.DownloadTimeoutMinutes < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.market_data.download_timeout_minutes",
+			Message: "must not be negative",
+		})
+	}
+	if m.DownloadHistory < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.market_data.download_history",
+			Message: "must not be negative",
+		})
+	}
+
+	return errs
+}
+
+func validateGRPCWeb(g *GRPCWebConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !g.Enabled {
+		return errs
+	}
+
+	if g.MaxMessageSize <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.grpc_web.max_message_size",
+			Message: "must be positive",
+		})
+	}
+
+	return errs
+}
+
+func validateSLA(sla *SLAConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !sla.Enabled {
+		return errs
+	}
+
+	if d, err := time.ParseDuration(sla.CheckInterval); err != nil || d < time.Second {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.sla.check_interval",
+			Message: "must be a valid duration of at least 1s (e.g., 1m)",
+		})
+	}
+	if sla.MaxQueueWait == "" && sla.MaxRunDuration == "" {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.sla",
+			Message: "at least one of max_queue_wait or max_run_duration must be set",
+		})
+	}
+	if sla.MaxQueueWait != "" {
+		if d, err := time.ParseDuration(sla.MaxQueueWait); err != nil || d <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.sla.max_queue_wait",
+				Message: "must be a valid positive duration (e.g., 15m)",
+			})
+		}
+	}
+	if sla.MaxRunDuration != "" {
+		if d, err := time.ParseDuration(sla.MaxRunDuration); err != nil || d <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.sla.max_run_duration",
+				Message: "must be a valid positive duration (e.g., 30m)",
+			})
+		}
+	}
+	if sla.HistorySize <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.sla.history_size",
+			Message: "must be positive",
+		})
+	}
+
+	return errs
+}
+
+func validateAutoPause(a *AutoPauseConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !a.Enabled {
+		return errs
+	}
+
+	if d, err := time.ParseDuration(a.CheckInterval); err != nil || d < time.Second {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.auto_pause.check_interval",
+			Message: "must be a valid duration of at least 1s (e.g., 15s)",
+		})
+	}
+	if a.FailureThreshold <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.auto_pause.failure_threshold",
+			Message: "must be positive",
+		})
+	}
+	if a.RecoveryThreshold <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.auto_pause.recovery_threshold",
+			Message: "must be positive",
+		})
+	}
+
+	return errs
+}
+
+func validateMaintenance(m *MaintenanceConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if d, err := time.ParseDuration(m.CheckInterval); err != nil || d < time.Second {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.maintenance.check_interval",
+			Message: "must be a valid duration of at least 1s (e.g., 10s)",
+		})
+	}
+
+	return errs
+}
+
+func validateStall(s *StallConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !s.Enabled {
+		return errs
+	}
+
+	if d, err := time.ParseDuration(s.CheckInterval); err != nil || d < time.Second {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.stall_detection.check_interval",
+			Message: "must be a valid duration of at least 1s (e.g., 1m)",
+		})
+	}
+	if d, err := time.ParseDuration(s.Threshold); err != nil || d < time.Minute {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.stall_detection.threshold",
+			Message: "must be a valid duration of at least 1m (e.g., 30m)",
+		})
+	}
+
+	return errs
+}
+
+func validateCurrency(c *CurrencyConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if c.ReferenceCurrency == "" {
+		return errs
+	}
+
+	switch c.PriceSource {
+	case PriceSourceStatic:
+		for pair, rate := range c.StaticRates {
+			if strings.Count(pair, "/") != 1 || rate <= 0 {
+				errs = append(errs, ValidationError{
+					Field:   "go_backend.currency.static_rates",
+					Message: fmt.Sprintf("%q must be a BASE/QUOTE pair with a positive rate", pair),
+				})
+			}
+		}
+	case PriceSourceHTTP:
+		if !strings.Contains(c.PriceURL, "{base}") || !strings.Contains(c.PriceURL, "{quote}") {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.currency.price_url",
+				Message: "must contain {base} and {quote} placeholders",
+			})
+		}
+		if d, err := time.ParseDuration(c.CacheTTL); err != nil || d < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.currency.cache_ttl",
+				Message: "must be a valid non-negative duration (e.g., 5m)",
+			})
+		}
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.currency.price_source",
+			Message: fmt.Sprintf("must be one of: %s, %s", PriceSourceStatic, PriceSourceHTTP),
+		})
+	}
+
+	return errs
+}
+
+func validateAgents(a *AgentsConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if d, err := time.ParseDuration(a.RegistrationTTL); err != nil || d < time.Second {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.agents.registration_ttl",
+			Message: "must be a valid duration of at least 1s (e.g., 5m)",
+		})
+	}
+
+	return errs
+}
+
+func validateScout(s *ScoutConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	for source, cfg := range s.Sources {
+		field := "go_backend.scout.sources." + source
+		if source == "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.scout.sources",
+				Message: "source names must not be empty",
+			})
+		}
+		if cfg.MinInterval != "" {
+			if d, err := time.ParseDuration(cfg.MinInterval); err != nil || d < 0 {
+				errs = append(errs, ValidationError{
+					Field:   field + ".min_interval",
+					Message: "must be a valid non-negative duration (e.g., 1h)",
+				})
+			}
+		}
+		if cfg.MaxRunsPerDay < 0 {
+			errs = append(errs, ValidationError{
+				Field:   field + ".max_runs_per_day",
+				Message: "must not be negative",
+			})
+		}
+	}
+
+	return errs
+}
+
+func validateRanking(r *RankingConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if d, err := time.ParseDuration(r.CacheTTL); err != nil || d <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.ranking.cache_ttl",
+			Message: "must be a valid positive duration (e.g., 5m)",
+		})
+	}
+	if r.MaxCohorts <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.ranking.max_cohorts",
+			Message: "must be positive",
+		})
+	}
+
+	return errs
+}
+
+func validateSecrets(s *SecretsConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	switch s.MasterKeySource {
+	case SecretsKeySourceEnv:
+		if s.MasterKeyEnv == "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.secrets.master_key_env",
+				Message: "is required when master_key_source is env",
+			})
+		}
+	case SecretsKeySourceFile:
+		if s.MasterKeyFile == "" {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.secrets.master_key_file",
+				Message: "is required when master_key_source is file",
+			})
+		}
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.secrets.master_key_source",
+			Message: fmt.Sprintf("must be one of: %s, %s", SecretsKeySourceEnv, SecretsKeySourceFile),
+		})
+	}
+
+	return errs
+}
+
+// authKeyHashRegex matches a hex SHA-256 hash.
+var authKeyHashRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+func validateAuth(a *AuthConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	names := make(map[string]bool, len(a.Keys))
+	for i, k := range a.Keys {
+		field := fmt.Sprintf("go_backend.auth.keys[%d]", i)
+		if err := domain.ValidateAPIKeyName(k.Name); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   field + ".name",
+				Message: "must be 1-128 characters of letters, digits, '_', '.' or '-'",
+			})
+		} else if names[k.Name] {
+			errs = append(errs, ValidationError{
+				Field:   field + ".name",
+				Message: fmt.Sprintf("duplicate key name %q", k.Name),
+			})
+		}
+		names[k.Name] = true
+
+		switch {
+		case (k.KeyHash == "") == (k.KeyEnv == ""):
+			errs = append(errs, ValidationError{
+				Field:   field,
+				Message: "exactly one of key_hash and key_env is required",
+			})
+		case k.KeyHash != "" && !authKeyHashRegex.MatchString(k.KeyHash):
+			errs = append(errs, ValidationError{
+				Field:   field + ".key_hash",
+				Message: "must be a lowercase hex SHA-256 hash",
+			})
+		}
+
+		if _, err := domain.ParseRole(k.Role); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   field + ".role",
+				Message: "must be viewer, operator or admin",
+			})
+		}
+	}
+
+	for i, pattern := range a.PublicPaths {
+		if _, err := path.Match(pattern, "/"); err != nil || !strings.HasPrefix(pattern, "/") {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("go_backend.auth.public_paths[%d]", i),
+				Message: fmt.Sprintf("invalid path pattern %q", pattern),
+			})
+		}
+	}
+
+	return errs
+}
+
+func validatePagination(p *PaginationConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if p.DefaultPageSize <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.pagination.default_page_size",
+			Message: "must be positive",
+		})
+	}
+	if p.MaxPageSize <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.pagination.max_page_size",
+			Message: "must be positive",
+		})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if p.DefaultPageSize > p.MaxPageSize {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.pagination.default_page_size",
+			Message: "must not exceed max_page_size",
+		})
+	}
+
+	for resource, o := range p.Overrides {
+		field := "go_backend.pagination.overrides." + resource
+		if !slices.Contains(domain.PageResources, resource) {
+			errs = append(errs, ValidationError{
+				Field:   field,
+				Message: "unknown resource, must be one of: " + strings.Join(domain.PageResources, ", "),
+			})
+			continue
+		}
+		if o.DefaultPageSize < 0 || o.MaxPageSize < 0 {
+			errs = append(errs, ValidationError{
+				Field:   field,
+				Message: "page sizes must not be negative",
+			})
+			continue
+		}
+		if def, max := p.Limits(resource); def > max {
+			errs = append(errs, ValidationError{
+				Field:   field + ".default_page_size",
+				Message: fmt.Sprintf("must not exceed the max_page_size of %d", max),
+			})
+		}
+	}
+
+	return errs
+}
+
+func validateInsights(in *InsightsConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !in.Enabled {
+		return errs
+	}
+
+	if in.MaxRows <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.insights.max_rows",
+			Message: "must be positive",
+		})
+	}
+	if d, err := time.ParseDuration(in.Timeout); err != nil || d <= 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.insights.timeout",
+			Message: "must be a positive duration (e.g., 5s)",
+		})
+	}
+	// The templates themselves are checked when the insights service loads
+	// them, with the same rules as templates stored in the database.
+	for i, t := range in.Templates {
+		if t.Timeout != "" {
+			if d, err := time.ParseDuration(t.Timeout); err != nil || d <= 0 {
+				errs = append(errs, ValidationError{
+					Field:   fmt.Sprintf("go_backend.insights.templates[%d].timeout", i),
+					Message: "must be a positive duration (e.g., 2s)",
+				})
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateProgress(p *ProgressConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if !p.Enabled {
+		return errs
+	}
+
+	if d, err := time.ParseDuration(p.Interval); err != nil || d < time.Second {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.progress.interval",
+			Message: "must be a valid duration of at least 1s (e.g., 30s)",
+		})
+	}
+
+	return errs
+}
+
+func validateFeatures(f *FeaturesConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	for name := range f.Flags {
+		if !isValidFeatureName(name) {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.features.flags",
+				Message: fmt.Sprintf("%q must be 1-64 lowercase letters, digits or '_', starting with a letter", name),
+			})
+		}
+	}
+
+	return errs
+}
+
+// isValidFeatureName mirrors domain.IsValidFeatureName.
+func isValidFeatureName(name string) bool {
+	if len(name) == 0 || len(name) > 64 || name[0] < 'a' || name[0] > 'z' {
+		return false
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func validateDocker(d *DockerConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	if d.Image == "" {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.image",
+			Message: "is required",
+		})
+	}
+
+	if d.DataMount == "" {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.data_mount",
+			Message: "is required",
+		})
+	}
+
+	if d.CPULimit == "" {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.cpu_limit",
+			Message: "is required",
+		})
+	}
+
+	if d.MemoryLimit == "" {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.memory_limit",
+			Message: "is required",
+		})
+	}
+
+	if d.MaxContainers < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.max_containers",
+			Message: "must not be negative",
+		})
+	}
+	if d.ReservedValidationSlots < 0 || (d.MaxContainers > 0 && d.ReservedValidationSlots >= d.MaxContainers) {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.reserved_validation_slots",
+			Message: "must be non-negative and less than max_containers",
+		})
+	}
+
+	names := make(map[string]bool)
+	for i, h := range d.Hosts {
+		field := fmt.Sprintf("go_backend.docker.hosts[%d]", i)
+		if h.Name == "" || names[h.Name] {
+			errs = append(errs, ValidationError{
+				Field:   field + ".name",
+				Message: "must be set and unique",
+			})
+		}
+		names[h.Name] = true
+		if h.Host == "" {
+			errs = append(errs, ValidationError{
+				Field:   field + ".host",
+				Message: "is required",
+			})
+		}
+		if h.MaxContainers < 0 {
+			errs = append(errs, ValidationError{
+				Field:   field + ".max_containers",
+				Message: "must not be negative",
+			})
+		}
+	}
+	if d.HealthCheckInterval != "" {
+		if ttl, err := time.ParseDuration(d.HealthCheckInterval); err != nil || ttl <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "go_backend.docker.health_check_interval",
+				Message: "must be a positive duration (e.g., 15s)",
+			})
+		}
+	}
+
+	switch d.Executor {
+	case "", ExecutorDocker:
+	case ExecutorFake:
+		errs = append(errs, validateFakeExecutor(&d.Fake)...)
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.executor",
+			Message: "must be one of: docker, fake",
+		})
+	}
+
+	return errs
+}
+
+func validateFakeExecutor(f *FakeExecutorConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	minDuration, err := time.ParseDuration(f.MinDuration)
+	if err != nil || minDuration < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.fake.min_duration",
+			Message: "must be a valid non-negative duration",
+		})
+	}
+	maxDuration, err := time.ParseDuration(f.MaxDuration)
+	if err != nil || maxDuration < minDuration {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.fake.max_duration",
+			Message: "must be a valid duration not less than min_duration",
+		})
+	}
+	if f.FailureRate < 0 || f.FailureRate > 1 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.fake.failure_rate",
+			Message: "must be between 0 and 1",
+		})
+	}
+	if f.ProfitStdDevPct < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "go_backend.docker.fake.profit_stddev_pct",
+			Message: "must be non-negative",
+		})
+	}
+
+	return errs
+}
+
+func validateLogging(l *LoggingConfig) ValidationErrors {
+	var errs ValidationErrors
+
+	validLevels := map[string]bool{
+		"debug": true,
+		"info":  true,
+		"warn":  true,
+		"error": true,
+	}
+	if !validLevels[l.Level] {
+		errs = append(errs, ValidationError{
+			Field:   "logging.level",
+			Message: "must be one of: debug, info, warn, error",
+		})
+	}
+
+	validFormats := map[string]bool{
+		"json":    true,
+		"console": true,
+	}
+	if !validFormats[l.Format] {
+		errs = append(errs, ValidationError{
+			Field:   "logging.format",
+			Message: "must be one of: json, console",
+		})
+	}
+
+	if l.Redaction.MaxLogFieldBytes < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "logging.redaction.max_log_field_bytes",
+			Message: "must be non-negative",
+		})
+	}
+	if l.Redaction.MaxEventFieldBytes < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "logging.redaction.max_event_field_bytes",
+			Message: "must be non-negative",
 		})
 	}
 
@@ -0,0 +1,325 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// migrationLockID is the advisory lock held while migrating, so servers
+// starting together apply each migration once.
+const migrationLockID int64 = 0x66726571 // "freq"
+
+// ErrUnversionedSchema is returned when migrating a database whose schema was
+// set up by hand, before schema_version tracked it. Baseline records the
+// migrations it already has.
+var ErrUnversionedSchema = errors.New("database has a schema but no recorded migrations; baseline it first")
+
+// migrationFile matches migration file names, e.g. 045_dead_letters.up.sql.
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one schema change and its rollback.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string // Empty if the migration cannot be rolled back
+}
+
+// MigrationStatus reports whether a migration has been applied.
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// LoadMigrations reads the migrations in fsys, in version order.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		sql, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %03d has two names: %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(sql)
+		} else {
+			m.Down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %03d_%s has no up migration", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	return migrations, nil
+}
+
+// Migrator applies and rolls back migrations, recording the applied ones in
+// the schema_version table.
+type Migrator struct {
+	pool       *Pool
+	migrations []Migration
+	logger     *zap.Logger
+}
+
+// NewMigrator creates a migrator for the migrations in fsys.
+func NewMigrator(pool *Pool, fsys fs.FS, logger *zap.Logger) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, errors.New("no migrations found")
+	}
+	return &Migrator{pool: pool, migrations: migrations, logger: logger}, nil
+}
+
+// Latest returns the version of the newest migration.
+func (m *Migrator) Latest() int {
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every migration not applied yet, in version order, each in its
+// own transaction. It returns the number applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	var count int
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			var exists bool
+			err := conn.QueryRow(ctx, "SELECT to_regclass('strategies') IS NOT NULL").Scan(&exists)
+			if err != nil {
+				return fmt.Errorf("failed to check for an existing schema: %w", err)
+			}
+			if exists {
+				return ErrUnversionedSchema
+			}
+		}
+
+		for version := range applied {
+			if version > m.Latest() {
+				m.logger.Warn("Database has a migration this build does not know",
+					zap.Int("version", version),
+					zap.Int("latest_known", m.Latest()),
+				)
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// apply runs a migration and records it in one transaction.
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	start := time.Now()
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, "INSERT INTO schema_version (version, name) VALUES ($1, $2)",
+			migration.Version, migration.Name)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %03d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	m.logger.Info("Applied migration",
+		zap.Int("version", migration.Version),
+		zap.String("name", migration.Name),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
+
+// Down rolls back the applied migrations newer than the target version,
+// newest first. It returns the number rolled back.
+func (m *Migrator) Down(ctx context.Context, target int) (int, error) {
+	var count int
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version <= target {
+				break
+			}
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %03d_%s cannot be rolled back", migration.Version, migration.Name)
+			}
+
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "DELETE FROM schema_version WHERE version = $1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to roll back migration %03d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			m.logger.Info("Rolled back migration",
+				zap.Int("version", migration.Version),
+				zap.String("name", migration.Name),
+			)
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Baseline records the migrations up to and including version as applied
+// without running them, for databases migrated by hand.
+func (m *Migrator) Baseline(ctx context.Context, version int) error {
+	if version < m.migrations[0].Version || version > m.Latest() {
+		return fmt.Errorf("baseline version must be between %d and %d", m.migrations[0].Version, m.Latest())
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			for _, migration := range m.migrations {
+				if migration.Version > version {
+					break
+				}
+				_, err := tx.Exec(ctx, `
+					INSERT INTO schema_version (version, name) VALUES ($1, $2)
+					ON CONFLICT (version) DO NOTHING
+				`, migration.Version, migration.Name)
+				if err != nil {
+					return fmt.Errorf("failed to record migration %03d: %w", migration.Version, err)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// Status reports every known migration and when it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		statuses = make([]MigrationStatus, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock runs fn on one connection holding the migration lock, creating
+// the schema_version table first if needed.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	_, err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions returns the applied migrations and when they were applied.
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int]time.Time, error) {
+	rows, err := conn.Query(ctx, "SELECT version, applied_at FROM schema_version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema_version: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_version row: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schema_version: %w", err)
+	}
+
+	return applied, nil
+}
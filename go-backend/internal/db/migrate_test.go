@@ -0,0 +1,53 @@
+package db
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"010_second.up.sql":   {Data: []byte("ALTER TABLE a ADD COLUMN b INT;")},
+		"002_first.up.sql":    {Data: []byte("CREATE TABLE a (id INT);")},
+		"002_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+		"README.md":           {Data: []byte("not a migration")},
+		"003_draft.sql":       {Data: []byte("not a migration either")},
+		"010_second.down.sql": {Data: []byte("ALTER TABLE a DROP COLUMN b;")},
+	}
+
+	got, err := LoadMigrations(fsys)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, Migration{Version: 2, Name: "first", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"}, got[0])
+	assert.Equal(t, 10, got[1].Version)
+	assert.Equal(t, "second", got[1].Name)
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	_, err := LoadMigrations(fstest.MapFS{
+		"001_first.down.sql": {Data: []byte("DROP TABLE a;")},
+	})
+	assert.ErrorContains(t, err, "no up migration")
+
+	_, err = LoadMigrations(fstest.MapFS{
+		"001_first.up.sql":   {Data: []byte("CREATE TABLE a (id INT);")},
+		"001_renamed.up.sql": {Data: []byte("CREATE TABLE b (id INT);")},
+	})
+	assert.ErrorContains(t, err, "two names")
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	got, err := LoadMigrations(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, got)
+
+	for i, m := range got {
+		assert.Equal(t, i+1, m.Version, "migrations are numbered without gaps")
+		assert.NotEmpty(t, m.Down, "migration %03d_%s has no down migration", m.Version, m.Name)
+	}
+}
@@ -0,0 +1,4 @@
+-- Rollback: Remove user preferences
+
+DROP TRIGGER IF EXISTS trg_user_preferences_updated_at ON user_preferences;
+DROP TABLE IF EXISTS user_preferences;
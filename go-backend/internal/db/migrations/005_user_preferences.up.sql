@@ -0,0 +1,27 @@
+-- Migration: User preferences and UI state
+-- Version: 005
+-- Description: Key-value store for per-user UI preferences (dashboard layouts, page sizes, favorites)
+
+-- =====================================================
+-- USER PREFERENCES TABLE
+-- =====================================================
+CREATE TABLE user_preferences (
+    owner VARCHAR(255) NOT NULL,       -- User ID or API token identifier
+    key VARCHAR(100) NOT NULL,
+    value JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (owner, key)
+);
+
+CREATE INDEX idx_user_preferences_updated_at ON user_preferences(updated_at DESC);
+
+COMMENT ON TABLE user_preferences IS 'Per-user key-value preferences and persisted UI state';
+COMMENT ON COLUMN user_preferences.owner IS 'User ID or API token identifier owning the preference';
+COMMENT ON COLUMN user_preferences.value IS 'Arbitrary JSON value (layout, page size, favorite strategy IDs, ...)';
+
+-- Auto-update updated_at timestamp
+CREATE TRIGGER trg_user_preferences_updated_at
+    BEFORE UPDATE ON user_preferences
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
@@ -0,0 +1,4 @@
+-- Rollback: Remove favorites / starring
+
+DROP TABLE IF EXISTS optimization_run_stars;
+DROP TABLE IF EXISTS strategy_stars;
@@ -0,0 +1,33 @@
+-- Migration: Favorites / starring
+-- Version: 006
+-- Description: Per-user stars on strategies and optimization runs
+
+-- =====================================================
+-- STRATEGY STARS TABLE
+-- =====================================================
+CREATE TABLE strategy_stars (
+    owner VARCHAR(255) NOT NULL,
+    strategy_id UUID NOT NULL REFERENCES strategies(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (owner, strategy_id)
+);
+
+CREATE INDEX idx_strategy_stars_strategy_id ON strategy_stars(strategy_id);
+
+COMMENT ON TABLE strategy_stars IS 'Strategies starred by a user';
+
+-- =====================================================
+-- OPTIMIZATION RUN STARS TABLE
+-- =====================================================
+CREATE TABLE optimization_run_stars (
+    owner VARCHAR(255) NOT NULL,
+    optimization_run_id UUID NOT NULL REFERENCES optimization_runs(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (owner, optimization_run_id)
+);
+
+CREATE INDEX idx_optimization_run_stars_run_id ON optimization_run_stars(optimization_run_id);
+
+COMMENT ON TABLE optimization_run_stars IS 'Optimization runs starred by a user';
@@ -0,0 +1,11 @@
+-- Rollback: Remove external reference IDs
+
+DROP INDEX IF EXISTS idx_optimization_runs_external_ref;
+ALTER TABLE optimization_runs
+    DROP COLUMN IF EXISTS external_ref_owner,
+    DROP COLUMN IF EXISTS external_ref;
+
+DROP INDEX IF EXISTS idx_backtest_jobs_external_ref;
+ALTER TABLE backtest_jobs
+    DROP COLUMN IF EXISTS external_ref_owner,
+    DROP COLUMN IF EXISTS external_ref;
@@ -0,0 +1,27 @@
+-- Migration: External reference IDs
+-- Version: 007
+-- Description: Submitter-supplied external references on jobs and optimization runs, unique per principal
+
+-- =====================================================
+-- BACKTEST JOBS
+-- =====================================================
+ALTER TABLE backtest_jobs
+    ADD COLUMN external_ref VARCHAR(255),
+    ADD COLUMN external_ref_owner VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_backtest_jobs_external_ref ON backtest_jobs(external_ref_owner, external_ref)
+    WHERE external_ref IS NOT NULL;
+
+COMMENT ON COLUMN backtest_jobs.external_ref IS 'Submitter-supplied reference, unique per external_ref_owner';
+
+-- =====================================================
+-- OPTIMIZATION RUNS
+-- =====================================================
+ALTER TABLE optimization_runs
+    ADD COLUMN external_ref VARCHAR(255),
+    ADD COLUMN external_ref_owner VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_optimization_runs_external_ref ON optimization_runs(external_ref_owner, external_ref)
+    WHERE external_ref IS NOT NULL;
+
+COMMENT ON COLUMN optimization_runs.external_ref IS 'Submitter-supplied reference, unique per external_ref_owner';
@@ -0,0 +1,5 @@
+-- Rollback: Remove artifact store
+
+DROP TRIGGER IF EXISTS scout_runs_delete_artifacts ON scout_runs;
+DROP FUNCTION IF EXISTS delete_scout_run_artifacts();
+DROP TABLE IF EXISTS artifacts;
@@ -0,0 +1,43 @@
+-- Migration: Artifact store
+-- Version: 008
+-- Description: Raw payloads (HTML/JSON/source files) attached to entities such as scout runs
+
+-- =====================================================
+-- ARTIFACTS TABLE
+-- =====================================================
+CREATE TABLE artifacts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner_type VARCHAR(50) NOT NULL,   -- e.g. 'scout_run'
+    owner_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,         -- html, json, source, other
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL DEFAULT 'application/octet-stream',
+    source_url TEXT,
+    size_bytes BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    content BYTEA NOT NULL,            -- TOAST-compressed by PostgreSQL
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_artifact_kind CHECK (kind IN ('html', 'json', 'source', 'other')),
+    CONSTRAINT chk_artifact_size CHECK (size_bytes >= 0)
+);
+
+CREATE INDEX idx_artifacts_owner ON artifacts(owner_type, owner_id, created_at);
+CREATE INDEX idx_artifacts_sha256 ON artifacts(sha256);
+
+COMMENT ON TABLE artifacts IS 'Raw payloads stored for debugging and re-processing';
+
+-- Remove scout run artifacts together with their run
+CREATE OR REPLACE FUNCTION delete_scout_run_artifacts()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM artifacts WHERE owner_type = 'scout_run' AND owner_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER scout_runs_delete_artifacts
+    AFTER DELETE ON scout_runs
+    FOR EACH ROW
+    EXECUTE FUNCTION delete_scout_run_artifacts();
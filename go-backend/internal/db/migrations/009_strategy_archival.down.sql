@@ -0,0 +1,6 @@
+-- Rollback: Remove strategy archival
+
+DROP INDEX IF EXISTS idx_strategies_active;
+ALTER TABLE strategies
+    DROP COLUMN IF EXISTS archive_reason,
+    DROP COLUMN IF EXISTS archived_at;
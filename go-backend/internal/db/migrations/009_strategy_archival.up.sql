@@ -0,0 +1,16 @@
+-- Migration: Strategy archival
+-- Version: 009
+-- Description: Soft-archive underperforming strategies so they drop out of default search results
+
+-- =====================================================
+-- STRATEGY ARCHIVAL COLUMNS
+-- =====================================================
+ALTER TABLE strategies
+    ADD COLUMN archived_at TIMESTAMPTZ,
+    ADD COLUMN archive_reason TEXT;
+
+-- Most queries only look at active strategies
+CREATE INDEX idx_strategies_active ON strategies(created_at DESC) WHERE archived_at IS NULL;
+
+COMMENT ON COLUMN strategies.archived_at IS 'When the strategy was archived (NULL = active)';
+COMMENT ON COLUMN strategies.archive_reason IS 'Why the strategy was archived (policy description or operator note)';
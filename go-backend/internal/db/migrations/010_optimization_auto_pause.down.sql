@@ -0,0 +1,6 @@
+-- Rollback: Remove optimization auto-pause tracking
+
+DROP INDEX IF EXISTS idx_optimization_runs_auto_paused;
+ALTER TABLE optimization_runs
+    DROP COLUMN IF EXISTS incidents,
+    DROP COLUMN IF EXISTS pause_reason;
@@ -0,0 +1,17 @@
+-- Migration: Optimization auto-pause
+-- Version: 010
+-- Description: Track why an optimization run is paused and the infrastructure outages it sat through
+
+-- =====================================================
+-- OPTIMIZATION PAUSE TRACKING
+-- =====================================================
+ALTER TABLE optimization_runs
+    ADD COLUMN pause_reason VARCHAR(20),
+    ADD COLUMN incidents JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- Runs paused by the backend are resumed once their dependencies recover
+CREATE INDEX idx_optimization_runs_auto_paused ON optimization_runs(updated_at)
+    WHERE status = 'paused' AND pause_reason = 'auto_paused';
+
+COMMENT ON COLUMN optimization_runs.pause_reason IS 'Why the run is paused: manual or auto_paused (NULL when not paused)';
+COMMENT ON COLUMN optimization_runs.incidents IS 'Outage windows during which the run was auto-paused';
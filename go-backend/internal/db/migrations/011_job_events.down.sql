@@ -0,0 +1,3 @@
+-- Rollback: Remove backtest job event timeline
+
+DROP TABLE IF EXISTS job_events;
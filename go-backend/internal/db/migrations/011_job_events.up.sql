@@ -0,0 +1,25 @@
+-- Migration: Backtest job event timeline
+-- Version: 011
+-- Description: Record every state transition of a backtest job to analyze where time is lost
+
+-- =====================================================
+-- JOB EVENTS TABLE
+-- =====================================================
+CREATE TABLE job_events (
+    id BIGSERIAL PRIMARY KEY,
+    job_id UUID NOT NULL REFERENCES backtest_jobs(id) ON DELETE CASCADE,
+    event_type VARCHAR(30) NOT NULL,   -- queued, dispatched, container_started, retried, completed, failed, cancelled
+    status VARCHAR(20) NOT NULL,       -- Job status after the transition
+    container_id VARCHAR(64),
+    worker VARCHAR(50),
+    host VARCHAR(255),
+    detail TEXT,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_job_events_job ON job_events(job_id, occurred_at, id);
+
+COMMENT ON TABLE job_events IS 'Timeline of backtest job state transitions';
+COMMENT ON COLUMN job_events.worker IS 'Scheduler worker that handled the job (e.g. worker-3)';
+COMMENT ON COLUMN job_events.host IS 'Host the scheduler was running on';
+COMMENT ON COLUMN job_events.detail IS 'Error message or other context for the transition';
@@ -0,0 +1,22 @@
+-- Rollback: Remove result supersede semantics
+
+CREATE OR REPLACE VIEW v_strategy_performance AS
+SELECT
+    s.id AS strategy_id,
+    s.name AS strategy_name,
+    s.generation,
+    s.parent_id,
+    COUNT(br.id) AS backtest_count,
+    MAX(br.sharpe_ratio) AS best_sharpe,
+    AVG(br.sharpe_ratio) AS avg_sharpe,
+    MAX(br.profit_pct) AS best_profit_pct,
+    AVG(br.profit_pct) AS avg_profit_pct,
+    MIN(br.max_drawdown_pct) AS best_drawdown,
+    AVG(br.win_rate) AS avg_win_rate,
+    s.created_at
+FROM strategies s
+LEFT JOIN backtest_results br ON br.strategy_id = s.id
+GROUP BY s.id;
+
+DROP INDEX IF EXISTS idx_backtest_results_current;
+ALTER TABLE backtest_results DROP COLUMN IF EXISTS superseded_by;
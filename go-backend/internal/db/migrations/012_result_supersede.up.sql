@@ -0,0 +1,52 @@
+-- Migration: Result supersede semantics
+-- Version: 012
+-- Description: Link results that were replaced by a re-run of the same strategy/config so aggregates only count the latest
+
+-- =====================================================
+-- SUPERSEDED RESULTS
+-- =====================================================
+ALTER TABLE backtest_results
+    ADD COLUMN superseded_by UUID REFERENCES backtest_results(id) ON DELETE SET NULL;
+
+-- Most queries only look at the latest result per strategy/config
+CREATE INDEX idx_backtest_results_current ON backtest_results(strategy_id, created_at DESC)
+    WHERE superseded_by IS NULL;
+
+COMMENT ON COLUMN backtest_results.superseded_by IS 'Later result for the same strategy and config (NULL = latest)';
+
+-- Backfill: every result except the latest per strategy/config is superseded by the latest
+WITH ranked AS (
+    SELECT
+        br.id,
+        FIRST_VALUE(br.id) OVER (
+            PARTITION BY br.strategy_id, bj.config
+            ORDER BY br.created_at DESC, br.id DESC
+        ) AS latest_id
+    FROM backtest_results br
+    JOIN backtest_jobs bj ON bj.id = br.job_id
+)
+UPDATE backtest_results br
+SET superseded_by = ranked.latest_id
+FROM ranked
+WHERE br.id = ranked.id AND ranked.id <> ranked.latest_id;
+
+-- =====================================================
+-- VIEWS
+-- =====================================================
+CREATE OR REPLACE VIEW v_strategy_performance AS
+SELECT
+    s.id AS strategy_id,
+    s.name AS strategy_name,
+    s.generation,
+    s.parent_id,
+    COUNT(br.id) AS backtest_count,
+    MAX(br.sharpe_ratio) AS best_sharpe,
+    AVG(br.sharpe_ratio) AS avg_sharpe,
+    MAX(br.profit_pct) AS best_profit_pct,
+    AVG(br.profit_pct) AS avg_profit_pct,
+    MIN(br.max_drawdown_pct) AS best_drawdown,
+    AVG(br.win_rate) AS avg_win_rate,
+    s.created_at
+FROM strategies s
+LEFT JOIN backtest_results br ON br.strategy_id = s.id AND br.superseded_by IS NULL
+GROUP BY s.id;
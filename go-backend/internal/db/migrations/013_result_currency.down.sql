@@ -0,0 +1,9 @@
+-- Rollback: Remove result currency normalization
+
+DROP INDEX IF EXISTS idx_backtest_results_profit_normalized;
+
+ALTER TABLE backtest_results
+    DROP COLUMN IF EXISTS profit_total_normalized,
+    DROP COLUMN IF EXISTS reference_rate,
+    DROP COLUMN IF EXISTS reference_currency,
+    DROP COLUMN IF EXISTS stake_currency;
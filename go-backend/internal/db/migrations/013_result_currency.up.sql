@@ -0,0 +1,22 @@
+-- Migration: Result currency normalization
+-- Version: 013
+-- Description: Record the stake currency of each result and its profit in a reference currency
+
+-- =====================================================
+-- RESULT CURRENCY
+-- =====================================================
+ALTER TABLE backtest_results
+    ADD COLUMN stake_currency VARCHAR(20),
+    ADD COLUMN reference_currency VARCHAR(20),
+    ADD COLUMN reference_rate DOUBLE PRECISION,
+    ADD COLUMN profit_total_normalized DECIMAL(20, 8);
+
+-- Cross-strategy ranking by normalized profit
+CREATE INDEX idx_backtest_results_profit_normalized
+    ON backtest_results(reference_currency, profit_total_normalized DESC)
+    WHERE profit_total_normalized IS NOT NULL;
+
+COMMENT ON COLUMN backtest_results.stake_currency IS 'Currency of profit_total and max_drawdown (NULL = unknown)';
+COMMENT ON COLUMN backtest_results.reference_currency IS 'Currency profit_total_normalized is expressed in';
+COMMENT ON COLUMN backtest_results.reference_rate IS 'Reference currency units per stake currency unit at ingestion';
+COMMENT ON COLUMN backtest_results.profit_total_normalized IS 'profit_total converted to reference_currency';
@@ -0,0 +1,7 @@
+-- Rollback: Remove iteration code pinning
+
+DROP INDEX IF EXISTS idx_optimization_iterations_code_hash;
+
+ALTER TABLE optimization_iterations
+    DROP COLUMN IF EXISTS code_snapshot,
+    DROP COLUMN IF EXISTS code_hash;
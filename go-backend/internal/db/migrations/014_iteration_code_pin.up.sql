@@ -0,0 +1,24 @@
+-- Migration: Iteration code pinning
+-- Version: 014
+-- Description: Pin the strategy code hash (and optionally the code) on each optimization iteration
+
+-- =====================================================
+-- ITERATION CODE PIN
+-- =====================================================
+ALTER TABLE optimization_iterations
+    ADD COLUMN code_hash VARCHAR(64),
+    ADD COLUMN code_snapshot TEXT;
+
+-- Backfill from the strategies' current code; edits made before this
+-- migration can't be recovered
+UPDATE optimization_iterations oi
+SET code_hash = s.code_hash
+FROM strategies s
+WHERE s.id = oi.strategy_id;
+
+ALTER TABLE optimization_iterations ALTER COLUMN code_hash SET NOT NULL;
+
+CREATE INDEX idx_optimization_iterations_code_hash ON optimization_iterations(code_hash);
+
+COMMENT ON COLUMN optimization_iterations.code_hash IS 'SHA256 of the strategy code when the iteration was created';
+COMMENT ON COLUMN optimization_iterations.code_snapshot IS 'Strategy code when the iteration was created (runs with snapshot_code only)';
@@ -0,0 +1,3 @@
+-- Rollback: Remove feature flag overrides
+
+DROP TABLE IF EXISTS feature_flags;
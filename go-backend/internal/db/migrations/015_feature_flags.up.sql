@@ -0,0 +1,16 @@
+-- Migration: Feature flags
+-- Version: 015
+-- Description: Runtime overrides for deployment feature flags
+
+-- =====================================================
+-- FEATURE FLAG OVERRIDES
+-- =====================================================
+CREATE TABLE feature_flags (
+    name VARCHAR(64) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE feature_flags IS 'Runtime overrides of the feature flags set in go_backend.features';
+COMMENT ON COLUMN feature_flags.updated_by IS 'User or token that last set the override';
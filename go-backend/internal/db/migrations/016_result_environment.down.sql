@@ -0,0 +1,7 @@
+-- Rollback: Remove result execution environment
+
+DROP INDEX IF EXISTS idx_backtest_results_image_digest;
+DROP INDEX IF EXISTS idx_backtest_results_freqtrade_version;
+
+ALTER TABLE backtest_results
+    DROP COLUMN IF EXISTS environment;
@@ -0,0 +1,19 @@
+-- Migration: Result execution environment
+-- Version: 016
+-- Description: Record the execution environment (Freqtrade version, image, host, packages) of each result
+
+-- =====================================================
+-- RESULT ENVIRONMENT
+-- =====================================================
+ALTER TABLE backtest_results
+    ADD COLUMN environment JSONB;
+
+-- Correlating results with environment changes
+CREATE INDEX idx_backtest_results_freqtrade_version
+    ON backtest_results((environment->>'freqtrade_version'))
+    WHERE environment IS NOT NULL;
+CREATE INDEX idx_backtest_results_image_digest
+    ON backtest_results((environment->>'image_digest'))
+    WHERE environment IS NOT NULL;
+
+COMMENT ON COLUMN backtest_results.environment IS 'Execution environment: freqtrade_version, python_version, image, image_digest, host, packages (NULL = not captured)';
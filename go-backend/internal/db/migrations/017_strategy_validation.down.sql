@@ -0,0 +1,8 @@
+-- Rollback: Remove strategy validation status
+
+DROP INDEX IF EXISTS idx_strategies_validation_status;
+
+ALTER TABLE strategies
+    DROP COLUMN IF EXISTS validated_at,
+    DROP COLUMN IF EXISTS validation_errors,
+    DROP COLUMN IF EXISTS validation_status;
@@ -0,0 +1,23 @@
+-- Migration: Strategy validation status
+-- Version: 017
+-- Description: Record whether each strategy's code passed validation so submissions can be gated on it
+
+-- =====================================================
+-- STRATEGY VALIDATION
+-- =====================================================
+ALTER TABLE strategies
+    ADD COLUMN validation_status VARCHAR(20) NOT NULL DEFAULT 'unvalidated'
+        CHECK (validation_status IN ('unvalidated', 'validated', 'validation_failed')),
+    ADD COLUMN validation_errors JSONB,
+    ADD COLUMN validated_at TIMESTAMPTZ;
+
+-- Strategies that already produced a result evidently load in Freqtrade
+UPDATE strategies s
+SET validation_status = 'validated'
+WHERE EXISTS (SELECT 1 FROM backtest_results br WHERE br.strategy_id = s.id);
+
+CREATE INDEX idx_strategies_validation_status ON strategies(validation_status);
+
+COMMENT ON COLUMN strategies.validation_status IS 'unvalidated, validated or validation_failed; backtests for failed strategies are rejected unless overridden';
+COMMENT ON COLUMN strategies.validation_errors IS 'Errors reported by the last failed validation';
+COMMENT ON COLUMN strategies.validated_at IS 'When the validation status was last recorded';
@@ -0,0 +1,9 @@
+-- Rollback: Remove backtest campaigns
+
+DROP INDEX IF EXISTS idx_backtest_jobs_campaign;
+
+ALTER TABLE backtest_jobs
+    DROP COLUMN IF EXISTS campaign_id;
+
+DROP TRIGGER IF EXISTS trg_campaigns_updated_at ON campaigns;
+DROP TABLE IF EXISTS campaigns;
@@ -0,0 +1,36 @@
+-- Migration: Backtest campaigns
+-- Version: 018
+-- Description: Named groups of related backtest jobs for human-driven bulk testing
+
+-- =====================================================
+-- CAMPAIGNS
+-- =====================================================
+CREATE TABLE campaigns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_campaigns_created_at ON campaigns(created_at DESC);
+
+COMMENT ON TABLE campaigns IS 'Named groups of backtest jobs submitted together';
+COMMENT ON COLUMN campaigns.created_by IS 'User or token that created the campaign';
+
+-- Auto-update updated_at timestamp
+CREATE TRIGGER trg_campaigns_updated_at
+    BEFORE UPDATE ON campaigns
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- =====================================================
+-- JOB MEMBERSHIP
+-- =====================================================
+ALTER TABLE backtest_jobs
+    ADD COLUMN campaign_id UUID REFERENCES campaigns(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_backtest_jobs_campaign ON backtest_jobs(campaign_id)
+    WHERE campaign_id IS NOT NULL;
+
+COMMENT ON COLUMN backtest_jobs.campaign_id IS 'Campaign the job was submitted under (NULL = none)';
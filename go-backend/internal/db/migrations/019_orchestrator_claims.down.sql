@@ -0,0 +1,3 @@
+-- Rollback: Remove orchestrator action claims
+
+DROP TABLE IF EXISTS optimization_action_claims;
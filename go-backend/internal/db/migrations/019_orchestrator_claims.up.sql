@@ -0,0 +1,20 @@
+-- Migration: Orchestrator action claims
+-- Version: 019
+-- Description: Leases on the next step of an optimization run so multiple orchestrator replicas don't duplicate work
+
+-- =====================================================
+-- ORCHESTRATOR ACTION CLAIMS
+-- =====================================================
+CREATE TABLE optimization_action_claims (
+    optimization_run_id UUID PRIMARY KEY REFERENCES optimization_runs(id) ON DELETE CASCADE,
+    action VARCHAR(32) NOT NULL,
+    iteration_number INTEGER NOT NULL,
+    claimed_by VARCHAR(255) NOT NULL,
+    claimed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+COMMENT ON TABLE optimization_action_claims IS 'Latest claim on the next step of each optimization run (see ClaimNextOptimizationAction)';
+COMMENT ON COLUMN optimization_action_claims.action IS 'generate_candidate, evaluate_criteria or finalize';
+COMMENT ON COLUMN optimization_action_claims.iteration_number IS 'Iteration the action applies to; the claim lapses once the run moves on';
+COMMENT ON COLUMN optimization_action_claims.claimed_by IS 'Orchestrator replica holding the claim';
@@ -0,0 +1,13 @@
+-- Rollback: Store raw logs inline in backtest results again
+
+ALTER TABLE backtest_results
+    ADD COLUMN raw_log TEXT;
+
+COMMENT ON COLUMN backtest_results.raw_log IS 'Full Freqtrade output';
+
+UPDATE backtest_results br
+SET raw_log = replace(encode(bl.content, 'base64'), E'\n', '')
+FROM backtest_logs bl
+WHERE bl.result_id = br.id;
+
+DROP TABLE IF EXISTS backtest_logs;
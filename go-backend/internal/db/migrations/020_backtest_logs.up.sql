@@ -0,0 +1,31 @@
+-- Migration: Split raw logs from backtest results
+-- Version: 020
+-- Description: Move gzip-compressed Freqtrade logs out of backtest_results into their own table, loaded on demand
+
+-- =====================================================
+-- BACKTEST LOGS TABLE
+-- =====================================================
+CREATE TABLE backtest_logs (
+    result_id UUID PRIMARY KEY REFERENCES backtest_results(id) ON DELETE CASCADE,
+    content BYTEA NOT NULL,     -- gzip-compressed Freqtrade output
+    size_bytes INTEGER NOT NULL, -- Compressed size
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE backtest_logs IS 'Raw Freqtrade output of backtest results, kept apart so result scans stay small';
+COMMENT ON COLUMN backtest_logs.content IS 'gzip-compressed Freqtrade output log';
+
+-- =====================================================
+-- MOVE EXISTING LOGS
+-- =====================================================
+-- Logs were stored base64-encoded in a TEXT column; malformed values are dropped
+INSERT INTO backtest_logs (result_id, content, size_bytes, created_at)
+SELECT id, decode(raw_log, 'base64'), length(decode(raw_log, 'base64')), created_at
+FROM backtest_results
+WHERE raw_log IS NOT NULL
+    AND raw_log <> ''
+    AND raw_log ~ '^[A-Za-z0-9+/]*={0,2}$'
+    AND length(raw_log) % 4 = 0;
+
+ALTER TABLE backtest_results
+    DROP COLUMN raw_log;
@@ -0,0 +1,7 @@
+-- Rollback: Remove result trade reasons
+
+ALTER TABLE backtest_results
+    DROP COLUMN IF EXISTS trailing_stop_exit_pct,
+    DROP COLUMN IF EXISTS stoploss_exit_pct,
+    DROP COLUMN IF EXISTS entry_tags,
+    DROP COLUMN IF EXISTS exit_reasons;
@@ -0,0 +1,17 @@
+-- Migration: Result trade reasons
+-- Version: 021
+-- Description: Store the exit reason and entry tag breakdown of each result
+
+-- =====================================================
+-- RESULT TRADE REASONS
+-- =====================================================
+ALTER TABLE backtest_results
+    ADD COLUMN exit_reasons JSONB,
+    ADD COLUMN entry_tags JSONB,
+    ADD COLUMN stoploss_exit_pct DECIMAL(6, 2),
+    ADD COLUMN trailing_stop_exit_pct DECIMAL(6, 2);
+
+COMMENT ON COLUMN backtest_results.exit_reasons IS 'Trades per exit reason: [{reason, trades, avg_profit_pct}]';
+COMMENT ON COLUMN backtest_results.entry_tags IS 'Trades per entry tag: [{reason, trades, avg_profit_pct}]';
+COMMENT ON COLUMN backtest_results.stoploss_exit_pct IS 'Percentage of trades exited by stop loss (NULL = no breakdown)';
+COMMENT ON COLUMN backtest_results.trailing_stop_exit_pct IS 'Percentage of trades exited by trailing stop (NULL = no breakdown)';
@@ -0,0 +1,6 @@
+-- Rollback: Remove export jobs
+
+DROP TRIGGER IF EXISTS export_jobs_delete_artifacts ON export_jobs;
+DROP FUNCTION IF EXISTS delete_export_job_artifacts();
+DELETE FROM artifacts WHERE owner_type = 'export';
+DROP TABLE IF EXISTS export_jobs;
@@ -0,0 +1,47 @@
+-- Migration: Export jobs
+-- Version: 022
+-- Description: Asynchronous bulk exports of strategies, results and iterations into artifact archives
+
+-- =====================================================
+-- EXPORT JOBS TABLE
+-- =====================================================
+CREATE TABLE export_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    owner VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    format VARCHAR(20) NOT NULL DEFAULT 'jsonl',
+    filter JSONB NOT NULL DEFAULT '{}'::jsonb,
+
+    artifact_id UUID REFERENCES artifacts(id) ON DELETE SET NULL,
+    strategy_count INTEGER NOT NULL DEFAULT 0,
+    result_count INTEGER NOT NULL DEFAULT 0,
+    iteration_count INTEGER NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_export_status CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+CREATE INDEX idx_export_jobs_pending ON export_jobs(created_at) WHERE status = 'pending';
+CREATE INDEX idx_export_jobs_owner ON export_jobs(owner, created_at DESC);
+
+COMMENT ON TABLE export_jobs IS 'Bulk exports for offline analysis; the archive is stored as an artifact owned by the job';
+COMMENT ON COLUMN export_jobs.filter IS 'Result filter; strategies and iterations of matching results are exported alongside';
+
+-- Remove export archives together with their job
+CREATE OR REPLACE FUNCTION delete_export_job_artifacts()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM artifacts WHERE owner_type = 'export' AND owner_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER export_jobs_delete_artifacts
+    AFTER DELETE ON export_jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION delete_export_job_artifacts();
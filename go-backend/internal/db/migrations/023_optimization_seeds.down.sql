@@ -0,0 +1,6 @@
+-- Rollback: Remove optimization seeds
+
+DROP INDEX IF EXISTS idx_optimization_runs_seeds;
+
+ALTER TABLE optimization_runs
+    DROP COLUMN IF EXISTS seed_strategy_ids;
@@ -0,0 +1,16 @@
+-- Migration: Optimization seeds
+-- Version: 023
+-- Description: Record the initial population of seed strategies of each optimization run
+
+-- =====================================================
+-- OPTIMIZATION SEEDS
+-- =====================================================
+ALTER TABLE optimization_runs
+    ADD COLUMN seed_strategy_ids UUID[] NOT NULL DEFAULT '{}';
+
+-- Existing runs were seeded by their base strategy alone
+UPDATE optimization_runs SET seed_strategy_ids = ARRAY[base_strategy_id];
+
+CREATE INDEX idx_optimization_runs_seeds ON optimization_runs USING GIN (seed_strategy_ids);
+
+COMMENT ON COLUMN optimization_runs.seed_strategy_ids IS 'Initial population of the run; base_strategy_id is its first member';
@@ -0,0 +1,8 @@
+-- Rollback: Remove stalled optimization runs
+-- PostgreSQL cannot drop an enum value, so stalled runs are returned to
+-- running and the 'stalled' value is left in place.
+
+UPDATE optimization_runs SET status = 'running' WHERE status = 'stalled';
+
+ALTER TABLE optimization_runs
+    DROP COLUMN IF EXISTS stalled_at;
@@ -0,0 +1,15 @@
+-- Migration: Stalled optimization runs
+-- Version: 024
+-- Description: Flag running optimizations that stopped making progress
+
+-- =====================================================
+-- STALLED STATUS
+-- =====================================================
+-- The new value is not used in this migration, so it can be added in the
+-- same transaction (PostgreSQL 12+).
+ALTER TYPE optimization_status ADD VALUE IF NOT EXISTS 'stalled';
+
+ALTER TABLE optimization_runs
+    ADD COLUMN stalled_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN optimization_runs.stalled_at IS 'When the stall watchdog flagged the run (NULL when not stalled)';
@@ -0,0 +1,4 @@
+-- Rollback: Remove optimization data snapshots
+
+ALTER TABLE optimization_runs
+    DROP COLUMN IF EXISTS data_snapshot;
@@ -0,0 +1,13 @@
+-- Migration: Optimization data snapshots
+-- Version: 025
+-- Description: Pin the pairs and data coverage of an optimization run when it starts
+
+-- =====================================================
+-- DATA SNAPSHOT
+-- =====================================================
+-- Runs started before this migration have no snapshot and their jobs are
+-- not pinned.
+ALTER TABLE optimization_runs
+    ADD COLUMN data_snapshot JSONB;
+
+COMMENT ON COLUMN optimization_runs.data_snapshot IS 'Pairs, timerange and market settings resolved at start; backtest jobs of the run are forced onto them';
@@ -0,0 +1,6 @@
+-- Rollback: Remove backtest failure categories
+
+DROP INDEX IF EXISTS idx_backtest_jobs_failure_category;
+
+ALTER TABLE backtest_jobs
+    DROP COLUMN IF EXISTS failure_category;
@@ -0,0 +1,18 @@
+-- Migration: Backtest failure categories
+-- Version: 026
+-- Description: Classify why backtest jobs failed
+
+-- =====================================================
+-- FAILURE CATEGORY
+-- =====================================================
+-- Jobs that failed before this migration keep a NULL category and are
+-- reported as unknown.
+ALTER TABLE backtest_jobs
+    ADD COLUMN failure_category VARCHAR(32);
+
+COMMENT ON COLUMN backtest_jobs.failure_category IS 'Why the job failed: strategy_import_error, data_missing, timeout, oom, docker_error, parser_error or unknown';
+
+-- Failure analytics group failed jobs by category over a completion window
+CREATE INDEX idx_backtest_jobs_failure_category
+    ON backtest_jobs (completed_at, failure_category)
+    WHERE status = 'failed';
@@ -0,0 +1,6 @@
+-- Rollback: Remove backtest job resubmission links
+
+DROP INDEX IF EXISTS idx_backtest_jobs_resubmitted_from;
+
+ALTER TABLE backtest_jobs
+    DROP COLUMN IF EXISTS resubmitted_from;
@@ -0,0 +1,16 @@
+-- Migration: Backtest job resubmission
+-- Version: 027
+-- Description: Link resubmitted backtest jobs to the job they repeat
+
+-- =====================================================
+-- RESUBMITTED FROM
+-- =====================================================
+ALTER TABLE backtest_jobs
+    ADD COLUMN resubmitted_from UUID REFERENCES backtest_jobs(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN backtest_jobs.resubmitted_from IS 'Job this one repeats, set by POST /api/v1/backtests/:id/resubmit';
+
+-- Find the resubmissions of a job
+CREATE INDEX idx_backtest_jobs_resubmitted_from
+    ON backtest_jobs (resubmitted_from)
+    WHERE resubmitted_from IS NOT NULL;
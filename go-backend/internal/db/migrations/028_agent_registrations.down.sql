@@ -0,0 +1,3 @@
+-- Rollback: Remove agent registrations
+
+DROP TABLE IF EXISTS agent_registrations;
@@ -0,0 +1,21 @@
+-- Migration: Agent registrations
+-- Version: 028
+-- Description: Store the type, version, event schema versions and capabilities Python agents announce
+
+-- =====================================================
+-- AGENT REGISTRATIONS
+-- =====================================================
+CREATE TABLE agent_registrations (
+    agent_id VARCHAR(128) PRIMARY KEY,
+    agent_type VARCHAR(32) NOT NULL,
+    version VARCHAR(128) NOT NULL,
+    event_schema_versions INTEGER[] NOT NULL DEFAULT '{}',
+    capabilities TEXT[] NOT NULL DEFAULT '{}',
+    registered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE agent_registrations IS 'Agent instances registered via RegisterAgent; last_seen_at is refreshed by re-registration and heartbeats';
+
+-- Find live agents
+CREATE INDEX idx_agent_registrations_last_seen ON agent_registrations (last_seen_at DESC);
@@ -0,0 +1,5 @@
+-- Rollback: Remove encrypted secrets
+
+ALTER TABLE scout_schedules DROP COLUMN IF EXISTS credential_secret_id;
+
+DROP TABLE IF EXISTS secrets;
@@ -0,0 +1,27 @@
+-- Migration: Encrypted secrets
+-- Version: 029
+-- Description: Store credentials encrypted with AES-GCM and reference them from scout schedules
+
+-- =====================================================
+-- SECRETS
+-- =====================================================
+CREATE TABLE secrets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(128) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    ciphertext BYTEA NOT NULL,
+    nonce BYTEA NOT NULL,
+    key_id VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE secrets IS 'Credentials encrypted under the master key identified by key_id; plaintext is never stored';
+
+-- =====================================================
+-- SCOUT SCHEDULE CREDENTIALS
+-- =====================================================
+ALTER TABLE scout_schedules
+    ADD COLUMN credential_secret_id UUID REFERENCES secrets(id) ON DELETE RESTRICT;
+
+COMMENT ON COLUMN scout_schedules.credential_secret_id IS 'Secret holding the source credential passed to the Scout agent on each run';
@@ -0,0 +1,3 @@
+-- Rollback: Remove per-trade returns
+
+ALTER TABLE backtest_results DROP COLUMN IF EXISTS trade_returns;
@@ -0,0 +1,11 @@
+-- Migration: Per-trade returns
+-- Version: 030
+-- Description: Store the profit of each trade of a backtest for significance testing
+
+-- =====================================================
+-- BACKTEST RESULTS
+-- =====================================================
+ALTER TABLE backtest_results
+    ADD COLUMN trade_returns DOUBLE PRECISION[];
+
+COMMENT ON COLUMN backtest_results.trade_returns IS 'Profit % of each trade in exit order (NULL = not reported by the results format)';
@@ -0,0 +1,4 @@
+-- Rollback: Remove insight templates
+
+DROP TRIGGER IF EXISTS trg_insight_templates_updated_at ON insight_templates;
+DROP TABLE IF EXISTS insight_templates;
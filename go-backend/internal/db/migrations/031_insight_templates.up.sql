@@ -0,0 +1,29 @@
+-- Migration: Insight templates
+-- Version: 031
+-- Description: Store pre-approved SQL templates that analysts run through /api/v1/insights
+
+-- =====================================================
+-- INSIGHT TEMPLATES
+-- =====================================================
+CREATE TABLE insight_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    sql_text TEXT NOT NULL,
+    params JSONB NOT NULL DEFAULT '[]',
+    max_rows INTEGER NOT NULL DEFAULT 0 CHECK (max_rows >= 0),
+    timeout_ms BIGINT NOT NULL DEFAULT 0 CHECK (timeout_ms >= 0),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    approved_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_insight_templates_updated_at
+    BEFORE UPDATE ON insight_templates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+COMMENT ON TABLE insight_templates IS 'Pre-approved parameterized SELECT queries, run read-only with row and time limits';
+COMMENT ON COLUMN insight_templates.params IS 'Parameters bound to $1..$n in order: [{"name", "type", "required", "default", "description"}]';
+COMMENT ON COLUMN insight_templates.max_rows IS 'Row limit, 0 for the configured limit; never above it';
+COMMENT ON COLUMN insight_templates.timeout_ms IS 'Statement timeout, 0 for the configured timeout; never above it';
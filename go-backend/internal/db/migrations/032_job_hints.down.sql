@@ -0,0 +1,3 @@
+-- Rollback: Remove backtest job placement hints
+
+ALTER TABLE backtest_jobs DROP COLUMN IF EXISTS hints;
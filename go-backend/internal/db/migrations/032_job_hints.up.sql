@@ -0,0 +1,11 @@
+-- Migration: Backtest job placement hints
+-- Version: 032
+-- Description: Store best-effort affinity and anti-affinity hints on backtest jobs
+
+-- =====================================================
+-- JOB HINTS
+-- =====================================================
+ALTER TABLE backtest_jobs
+    ADD COLUMN hints JSONB;
+
+COMMENT ON COLUMN backtest_jobs.hints IS 'Best-effort placement hints: {"prefer_cached_data": ["binance/5m"], "anti_affinity": ["optimization"]}';
@@ -0,0 +1,3 @@
+-- Rollback: Remove strategy versions
+
+DROP TABLE IF EXISTS strategy_versions;
@@ -0,0 +1,30 @@
+-- Migration: Strategy versions
+-- Version: 033
+-- Description: Snapshot a strategy's code on creation and every update so edits can be rolled back
+
+-- =====================================================
+-- STRATEGY VERSIONS
+-- =====================================================
+CREATE TABLE strategy_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    strategy_id UUID NOT NULL REFERENCES strategies(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL CHECK (version > 0),
+    name VARCHAR(255) NOT NULL,
+    code TEXT NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    description TEXT,
+    parent_id UUID,
+    rolled_back_from INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (strategy_id, version)
+);
+
+-- Existing strategies start at version 1 with their current code; earlier
+-- edits can't be recovered
+INSERT INTO strategy_versions (strategy_id, version, name, code, code_hash, description, parent_id, created_at)
+SELECT id, 1, name, code, code_hash, description, parent_id, updated_at
+FROM strategies;
+
+COMMENT ON TABLE strategy_versions IS 'Snapshot of a strategy after its creation and each update, numbered from 1';
+COMMENT ON COLUMN strategy_versions.parent_id IS 'Parent at the time; not a foreign key, as the parent may since be deleted';
+COMMENT ON COLUMN strategy_versions.rolled_back_from IS 'Version this one restored, for versions created by a rollback';
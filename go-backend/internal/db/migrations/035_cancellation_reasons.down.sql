@@ -0,0 +1,13 @@
+-- Rollback: Remove cancellation reasons
+
+ALTER TABLE scout_runs
+    DROP COLUMN IF EXISTS cancelled_by,
+    DROP COLUMN IF EXISTS cancel_reason;
+
+ALTER TABLE optimization_runs
+    DROP COLUMN IF EXISTS cancelled_by,
+    DROP COLUMN IF EXISTS cancel_reason;
+
+ALTER TABLE backtest_jobs
+    DROP COLUMN IF EXISTS cancelled_by,
+    DROP COLUMN IF EXISTS cancel_reason;
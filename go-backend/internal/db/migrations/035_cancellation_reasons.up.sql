@@ -0,0 +1,18 @@
+-- Migration: Cancellation reasons
+-- Version: 035
+-- Description: Record why and by whom jobs, optimization runs and scout runs were cancelled
+
+-- =====================================================
+-- CANCELLATION REASONS
+-- =====================================================
+ALTER TABLE backtest_jobs
+    ADD COLUMN cancel_reason TEXT,
+    ADD COLUMN cancelled_by VARCHAR(255);
+
+ALTER TABLE optimization_runs
+    ADD COLUMN cancel_reason TEXT,
+    ADD COLUMN cancelled_by VARCHAR(255);
+
+ALTER TABLE scout_runs
+    ADD COLUMN cancel_reason TEXT,
+    ADD COLUMN cancelled_by VARCHAR(255);
@@ -0,0 +1,5 @@
+-- Rollback: Remove strategy description queue
+
+DROP INDEX IF EXISTS idx_strategies_missing_description;
+
+ALTER TABLE strategies DROP COLUMN IF EXISTS description_requested_at;
@@ -0,0 +1,13 @@
+-- Migration: Strategy description queue
+-- Version: 036
+-- Description: Track requests to the analyst agent for descriptions of strategies lacking one
+
+-- =====================================================
+-- DESCRIPTION REQUESTS
+-- =====================================================
+ALTER TABLE strategies ADD COLUMN description_requested_at TIMESTAMPTZ;
+
+CREATE INDEX idx_strategies_missing_description ON strategies(created_at)
+    WHERE archived_at IS NULL AND COALESCE(description, '') = '';
+
+COMMENT ON COLUMN strategies.description_requested_at IS 'When a description was last requested from the analyst agent (NULL = never or fulfilled)';
@@ -0,0 +1,7 @@
+-- Rollback: Remove API key roles
+
+ALTER TABLE api_keys ADD COLUMN admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE api_keys SET admin = TRUE WHERE role = 'admin';
+
+ALTER TABLE api_keys DROP COLUMN role;
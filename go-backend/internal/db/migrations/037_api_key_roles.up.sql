@@ -0,0 +1,16 @@
+-- Migration: API key roles
+-- Version: 037
+-- Description: Replace the admin flag of API keys with a viewer, operator or admin role
+
+-- =====================================================
+-- API KEY ROLES
+-- =====================================================
+-- Existing keys keep the access they had: admins stay admins, every other
+-- key could already submit and cancel work, so it becomes an operator
+ALTER TABLE api_keys
+    ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'operator'
+        CHECK (role IN ('viewer', 'operator', 'admin'));
+
+UPDATE api_keys SET role = 'admin' WHERE admin;
+
+ALTER TABLE api_keys DROP COLUMN admin;
@@ -0,0 +1,3 @@
+-- Rollback: Remove maintenance mode
+
+DROP TABLE IF EXISTS maintenance_mode;
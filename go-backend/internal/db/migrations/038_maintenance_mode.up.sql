@@ -0,0 +1,21 @@
+-- Migration: Maintenance mode
+-- Version: 038
+-- Description: System-wide maintenance mode flag shared by every backend instance
+
+-- =====================================================
+-- MAINTENANCE MODE
+-- =====================================================
+-- A single row; no row means maintenance mode is off
+CREATE TABLE maintenance_mode (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    enabled BOOLEAN NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    changed_by VARCHAR(255) NOT NULL,
+    since TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE maintenance_mode IS 'Maintenance mode: new submissions are rejected and schedulers paused while enabled';
+COMMENT ON COLUMN maintenance_mode.message IS 'Shown to clients whose submissions are rejected';
+COMMENT ON COLUMN maintenance_mode.changed_by IS 'User or token that last enabled or disabled maintenance mode';
+COMMENT ON COLUMN maintenance_mode.since IS 'When maintenance mode was enabled (NULL while disabled)';
@@ -0,0 +1,3 @@
+-- Rollback: Remove backtest equity curves
+
+DROP TABLE IF EXISTS backtest_equity_points;
@@ -0,0 +1,15 @@
+-- Migration: Backtest equity curves
+-- Version: 039
+-- Description: Store the daily balance of each backtest for drawdown charts
+
+-- =====================================================
+-- BACKTEST EQUITY POINTS TABLE
+-- =====================================================
+CREATE TABLE backtest_equity_points (
+    result_id UUID NOT NULL REFERENCES backtest_results(id) ON DELETE CASCADE,
+    ts TIMESTAMPTZ NOT NULL,           -- Start of the day (UTC)
+    balance DOUBLE PRECISION NOT NULL, -- Wallet balance at the end of the day, in the stake currency
+    PRIMARY KEY (result_id, ts)
+);
+
+COMMENT ON TABLE backtest_equity_points IS 'Daily equity curve of backtest results, from the daily profit the results format reports';
@@ -0,0 +1,4 @@
+-- Rollback: Remove strategy re-validation
+
+DROP TABLE IF EXISTS strategy_image_validations;
+DROP TABLE IF EXISTS revalidation_runs;
@@ -0,0 +1,49 @@
+-- Migration: Strategy re-validation
+-- Version: 040
+-- Description: Bulk re-validation of strategies against Freqtrade images and per-image validity
+
+-- =====================================================
+-- REVALIDATION RUNS TABLE
+-- =====================================================
+CREATE TABLE revalidation_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    image VARCHAR(255) NOT NULL,
+    strategy_ids UUID[] NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    requested_by VARCHAR(255) NOT NULL,
+
+    validated INTEGER NOT NULL DEFAULT 0,
+    valid INTEGER NOT NULL DEFAULT 0,
+    invalid INTEGER NOT NULL DEFAULT 0,
+    errors INTEGER NOT NULL DEFAULT 0,
+
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_revalidation_status CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+CREATE INDEX idx_revalidation_runs_pending ON revalidation_runs(created_at) WHERE status = 'pending';
+CREATE INDEX idx_revalidation_runs_created ON revalidation_runs(created_at DESC);
+
+COMMENT ON TABLE revalidation_runs IS 'Admin-triggered re-validation of selected strategies against a Freqtrade image';
+COMMENT ON COLUMN revalidation_runs.errors IS 'Strategies that could not be validated at all, e.g. deleted ones';
+
+-- =====================================================
+-- STRATEGY IMAGE VALIDATIONS TABLE
+-- =====================================================
+CREATE TABLE strategy_image_validations (
+    strategy_id UUID NOT NULL REFERENCES strategies(id) ON DELETE CASCADE,
+    image VARCHAR(255) NOT NULL,
+    valid BOOLEAN NOT NULL,
+    errors TEXT[] NOT NULL DEFAULT '{}',
+    run_id UUID REFERENCES revalidation_runs(id) ON DELETE SET NULL,
+    validated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (strategy_id, image)
+);
+
+CREATE INDEX idx_strategy_image_validations_image ON strategy_image_validations(image);
+
+COMMENT ON TABLE strategy_image_validations IS 'Whether each strategy was valid on each Freqtrade image when last validated';
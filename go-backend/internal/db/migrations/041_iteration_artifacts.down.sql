@@ -0,0 +1,9 @@
+-- Rollback: Remove iteration artifacts
+
+DROP TRIGGER IF EXISTS optimization_iterations_delete_artifacts ON optimization_iterations;
+DROP FUNCTION IF EXISTS delete_iteration_artifacts();
+DELETE FROM artifacts WHERE owner_type = 'optimization_iteration' OR kind IN ('prompt', 'response');
+
+ALTER TABLE artifacts DROP CONSTRAINT chk_artifact_kind;
+ALTER TABLE artifacts ADD CONSTRAINT chk_artifact_kind
+    CHECK (kind IN ('html', 'json', 'source', 'other'));
@@ -0,0 +1,28 @@
+-- Migration: Iteration artifacts
+-- Version: 041
+-- Description: LLM prompts and responses stored as artifacts of optimization iterations
+
+-- =====================================================
+-- ARTIFACT KINDS
+-- =====================================================
+ALTER TABLE artifacts DROP CONSTRAINT chk_artifact_kind;
+ALTER TABLE artifacts ADD CONSTRAINT chk_artifact_kind
+    CHECK (kind IN ('html', 'json', 'source', 'prompt', 'response', 'other'));
+
+-- =====================================================
+-- ITERATION ARTIFACT CLEANUP
+-- =====================================================
+-- Remove iteration artifacts together with their iteration, including when
+-- the iteration's run is deleted
+CREATE OR REPLACE FUNCTION delete_iteration_artifacts()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM artifacts WHERE owner_type = 'optimization_iteration' AND owner_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER optimization_iterations_delete_artifacts
+    AFTER DELETE ON optimization_iterations
+    FOR EACH ROW
+    EXECUTE FUNCTION delete_iteration_artifacts();
@@ -0,0 +1,9 @@
+-- Rollback: Remove backtest job artifacts
+
+DROP TRIGGER IF EXISTS backtest_jobs_delete_artifacts ON backtest_jobs;
+DROP FUNCTION IF EXISTS delete_job_artifacts();
+DELETE FROM artifacts WHERE owner_type = 'backtest_job' OR kind = 'log';
+
+ALTER TABLE artifacts DROP CONSTRAINT chk_artifact_kind;
+ALTER TABLE artifacts ADD CONSTRAINT chk_artifact_kind
+    CHECK (kind IN ('html', 'json', 'source', 'prompt', 'response', 'other'));
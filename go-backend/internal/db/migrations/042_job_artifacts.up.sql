@@ -0,0 +1,28 @@
+-- Migration: Backtest job artifacts
+-- Version: 042
+-- Description: Container output of cancelled backtests stored as artifacts of their jobs
+
+-- =====================================================
+-- ARTIFACT KINDS
+-- =====================================================
+ALTER TABLE artifacts DROP CONSTRAINT chk_artifact_kind;
+ALTER TABLE artifacts ADD CONSTRAINT chk_artifact_kind
+    CHECK (kind IN ('html', 'json', 'source', 'prompt', 'response', 'log', 'other'));
+
+-- =====================================================
+-- JOB ARTIFACT CLEANUP
+-- =====================================================
+-- Remove job artifacts together with their job, including when the job's
+-- strategy is deleted
+CREATE OR REPLACE FUNCTION delete_job_artifacts()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM artifacts WHERE owner_type = 'backtest_job' AND owner_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER backtest_jobs_delete_artifacts
+    AFTER DELETE ON backtest_jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION delete_job_artifacts();
@@ -0,0 +1,4 @@
+-- Rollback: Remove incidents
+
+DROP TABLE IF EXISTS incident_notes;
+DROP TABLE IF EXISTS incidents;
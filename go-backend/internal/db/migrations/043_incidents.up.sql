@@ -0,0 +1,41 @@
+-- Migration: Incidents
+-- Version: 043
+-- Description: Operator incident log with notes, reported by /health and the dashboard
+
+-- =====================================================
+-- INCIDENTS TABLE
+-- =====================================================
+CREATE TABLE incidents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(200) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    opened_by VARCHAR(255) NOT NULL,
+    closed_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_incident_severity CHECK (severity IN ('minor', 'major', 'critical')),
+    CONSTRAINT chk_incident_status CHECK (status IN ('open', 'closed')),
+    CONSTRAINT chk_incident_source CHECK (source IN ('manual', 'watchdog'))
+);
+
+CREATE INDEX idx_incidents_open ON incidents(created_at DESC) WHERE status = 'open';
+CREATE INDEX idx_incidents_created ON incidents(created_at DESC);
+
+COMMENT ON TABLE incidents IS 'Incidents recorded by on-call or opened by the dependency watchdog';
+
+-- =====================================================
+-- INCIDENT NOTES TABLE
+-- =====================================================
+CREATE TABLE incident_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    author VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_incident_notes_incident ON incident_notes(incident_id, created_at);
@@ -0,0 +1,3 @@
+-- Rollback: Remove hyperopt jobs
+
+DROP TABLE IF EXISTS hyperopt_jobs;
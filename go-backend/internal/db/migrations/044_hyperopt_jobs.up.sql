@@ -0,0 +1,32 @@
+-- Migration: Hyperopt jobs
+-- Version: 044
+-- Description: Freqtrade hyperopt runs on a strategy and the best parameter set each found
+
+-- =====================================================
+-- HYPEROPT JOBS TABLE
+-- =====================================================
+CREATE TABLE hyperopt_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    strategy_id UUID NOT NULL REFERENCES strategies(id) ON DELETE CASCADE,
+    owner VARCHAR(255) NOT NULL,
+    config JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    container_id VARCHAR(100),
+
+    result JSONB,
+    best_loss DOUBLE PRECISION,
+
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_hyperopt_status CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled'))
+);
+
+CREATE INDEX idx_hyperopt_jobs_pending ON hyperopt_jobs(created_at) WHERE status = 'pending';
+CREATE INDEX idx_hyperopt_jobs_strategy ON hyperopt_jobs(strategy_id, created_at DESC);
+
+COMMENT ON TABLE hyperopt_jobs IS 'Hyperopt runs searching a strategy''s hyperoptable parameters';
+COMMENT ON COLUMN hyperopt_jobs.result IS 'Best epoch found: loss, parameters by space and its backtest metrics';
+COMMENT ON COLUMN hyperopt_jobs.best_loss IS 'Loss of the best epoch, copied from result for ranking a strategy''s runs';
@@ -0,0 +1,3 @@
+-- Rollback: Remove dead letters
+
+DROP TABLE IF EXISTS dead_letters;
@@ -0,0 +1,26 @@
+-- Migration: Dead letters
+-- Version: 045
+-- Description: Event messages collected from the dead-letter queue for inspection and requeueing
+
+-- =====================================================
+-- DEAD LETTERS TABLE
+-- =====================================================
+CREATE TABLE dead_letters (
+    id UUID PRIMARY KEY,
+    routing_key VARCHAR(255) NOT NULL,
+    exchange VARCHAR(255) NOT NULL DEFAULT '',
+    queue VARCHAR(255) NOT NULL DEFAULT '',
+    error TEXT NOT NULL,
+    body BYTEA NOT NULL,
+    failed_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    requeued_at TIMESTAMPTZ,
+    requeue_count INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_dead_letters_created ON dead_letters(created_at DESC);
+CREATE INDEX idx_dead_letters_pending ON dead_letters(created_at DESC) WHERE requeued_at IS NULL;
+CREATE INDEX idx_dead_letters_routing_key ON dead_letters(routing_key, created_at DESC);
+
+COMMENT ON TABLE dead_letters IS 'Event messages consumers gave up on, drained from the dead-letter queue';
+COMMENT ON COLUMN dead_letters.id IS 'Message ID set when the message was dead-lettered, so redelivered copies are stored once';
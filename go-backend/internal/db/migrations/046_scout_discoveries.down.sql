@@ -0,0 +1,3 @@
+-- Rollback: Remove scout discoveries
+
+DROP TABLE IF EXISTS scout_discoveries;
@@ -0,0 +1,31 @@
+-- Migration: Scout discoveries
+-- Version: 046
+-- Description: Each strategy a Scout run fetched, with its validation and import outcome
+
+-- =====================================================
+-- SCOUT DISCOVERIES TABLE
+-- =====================================================
+CREATE TABLE scout_discoveries (
+    id UUID PRIMARY KEY,
+    run_id UUID NOT NULL REFERENCES scout_runs(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    source VARCHAR(50) NOT NULL DEFAULT '',
+    source_url TEXT NOT NULL DEFAULT '',
+    code_hash VARCHAR(64) NOT NULL,
+    validation_status VARCHAR(20) NOT NULL,
+    validation_errors JSONB NOT NULL DEFAULT '[]'::jsonb,
+    outcome VARCHAR(20) NOT NULL DEFAULT 'not_submitted',
+    strategy_id UUID REFERENCES strategies(id) ON DELETE SET NULL,
+    outcome_detail TEXT NOT NULL DEFAULT '',
+    discovered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_scout_discoveries_run_code UNIQUE (run_id, code_hash),
+    CONSTRAINT chk_scout_discovery_validation CHECK (validation_status IN ('valid', 'invalid')),
+    CONSTRAINT chk_scout_discovery_outcome CHECK (outcome IN ('not_submitted', 'imported', 'duplicate', 'rejected'))
+);
+
+CREATE INDEX idx_scout_discoveries_strategy ON scout_discoveries(strategy_id) WHERE strategy_id IS NOT NULL;
+
+COMMENT ON TABLE scout_discoveries IS 'Strategies fetched by Scout runs, reported by the agent and by strategy.discovered events';
+COMMENT ON COLUMN scout_discoveries.outcome IS 'not_submitted, imported, duplicate (code already stored) or rejected (code safety)';
+COMMENT ON COLUMN scout_discoveries.strategy_id IS 'The imported strategy, or the stored strategy a duplicate repeats';
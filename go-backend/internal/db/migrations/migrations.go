@@ -0,0 +1,11 @@
+// Package migrations embeds the database schema migrations, so the server
+// binary can apply them without the SQL files on disk.
+package migrations
+
+import "embed"
+
+// FS holds the migrations, named NNN_description.up.sql and
+// NNN_description.down.sql.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -16,6 +16,7 @@ import (
 // Pool wraps pgxpool.Pool with additional functionality.
 type Pool struct {
 	*pgxpool.Pool
+	tracer *QueryTracer
 	logger *zap.Logger
 }
 
@@ -44,6 +45,17 @@ func NewPool(ctx context.Context, cfg *config.DatabaseConfig, logger *zap.Logger
 	// Configure connection options
 	poolConfig.ConnConfig.ConnectTimeout = 10 * time.Second
 
+	// Trace queries for slow query logging and active query dumps
+	var slowThreshold time.Duration
+	if cfg.SlowQueryThreshold != "" {
+		slowThreshold, err = time.ParseDuration(cfg.SlowQueryThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slow_query_threshold: %w", err)
+		}
+	}
+	tracer := NewQueryTracer(slowThreshold, logger)
+	poolConfig.ConnConfig.Tracer = tracer
+
 	// Create pool
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
@@ -65,10 +77,21 @@ func NewPool(ctx context.Context, cfg *config.DatabaseConfig, logger *zap.Logger
 
 	return &Pool{
 		Pool:   pool,
+		tracer: tracer,
 		logger: logger,
 	}, nil
 }
 
+// ActiveQueries returns the queries currently executing on the pool.
+func (p *Pool) ActiveQueries() []ActiveQuery {
+	return p.tracer.ActiveQueries()
+}
+
+// QueryStats returns cumulative query counters for the pool.
+func (p *Pool) QueryStats() QueryStats {
+	return p.tracer.Stats()
+}
+
 // Ping checks if the database connection is alive.
 func (p *Pool) Ping(ctx context.Context) error {
 	return p.Pool.Ping(ctx)
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// agentRepo implements AgentRepository using PostgreSQL.
+type agentRepo struct {
+	pool *db.Pool
+}
+
+// NewAgentRepository creates a new PostgreSQL agent registration repository.
+func NewAgentRepository(pool *db.Pool) AgentRepository {
+	return &agentRepo{pool: pool}
+}
+
+// Register creates or replaces an agent's registration.
+func (r *agentRepo) Register(ctx context.Context, reg *domain.AgentRegistration) error {
+	query := `
+		INSERT INTO agent_registrations (
+			agent_id, agent_type, version, event_schema_versions, capabilities,
+			registered_at, last_seen_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (agent_id) DO UPDATE SET
+			agent_type = EXCLUDED.agent_type,
+			version = EXCLUDED.version,
+			event_schema_versions = EXCLUDED.event_schema_versions,
+			capabilities = EXCLUDED.capabilities,
+			registered_at = EXCLUDED.registered_at,
+			last_seen_at = EXCLUDED.last_seen_at
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		reg.AgentID,
+		string(reg.Type),
+		reg.Version,
+		reg.EventSchemaVersions,
+		reg.Capabilities,
+		reg.RegisteredAt,
+		reg.LastSeenAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register agent: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the agents seen since the given time, or all agents if
+// since is nil, ordered by type and agent ID.
+func (r *agentRepo) List(ctx context.Context, since *time.Time) ([]*domain.AgentRegistration, error) {
+	query := `
+		SELECT agent_id, agent_type, version, event_schema_versions, capabilities,
+			registered_at, last_seen_at
+		FROM agent_registrations
+		WHERE $1::timestamptz IS NULL OR last_seen_at >= $1
+		ORDER BY agent_type, agent_id
+	`
+
+	rows, err := r.pool.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	defer rows.Close()
+
+	var agents []*domain.AgentRegistration
+	for rows.Next() {
+		var a domain.AgentRegistration
+		var agentType string
+		if err := rows.Scan(
+			&a.AgentID, &agentType, &a.Version, &a.EventSchemaVersions, &a.Capabilities,
+			&a.RegisteredAt, &a.LastSeenAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan agent: %w", err)
+		}
+		a.Type = domain.AgentType(agentType)
+		agents = append(agents, &a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agents: %w", err)
+	}
+
+	return agents, nil
+}
+
+// Touch records that a registered agent was seen at the given time.
+func (r *agentRepo) Touch(ctx context.Context, agentID string, at time.Time) error {
+	result, err := r.pool.Exec(ctx,
+		"UPDATE agent_registrations SET last_seen_at = GREATEST(last_seen_at, $2) WHERE agent_id = $1",
+		agentID, at,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to touch agent: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("agent_registration", agentID)
+	}
+
+	return nil
+}
+
+// Ensure interface implementation at compile time.
+var _ AgentRepository = (*agentRepo)(nil)
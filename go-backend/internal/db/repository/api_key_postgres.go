@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// apiKeyTouchInterval is how stale last_used_at may get before a use of the
+// key updates it, so busy keys don't write on every request.
+const apiKeyTouchInterval = time.Minute
+
+// apiKeyRepo implements APIKeyRepository using PostgreSQL.
+type apiKeyRepo struct {
+	pool *db.Pool
+}
+
+// NewAPIKeyRepository creates a new PostgreSQL API key repository.
+func NewAPIKeyRepository(pool *db.Pool) APIKeyRepository {
+	return &apiKeyRepo{pool: pool}
+}
+
+const apiKeyColumns = `
+	id, name, prefix, key_hash, role, created_by, expires_at, last_used_at, revoked_at, created_at
+`
+
+// Create inserts a new API key.
+func (r *apiKeyRepo) Create(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		key.ID, key.Name, key.Prefix, key.KeyHash, key.Role, key.CreatedBy,
+		key.ExpiresAt, key.LastUsedAt, key.RevokedAt, key.CreatedAt,
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.NewDuplicateError("api key", "name", key.Name)
+		}
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash retrieves the API key with the given hash, revoked or not.
+func (r *apiKeyRepo) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(r.pool.QueryRow(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("api key", "hash")
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// List retrieves API keys ordered by name, newest first within a name.
+func (r *apiKeyRepo) List(ctx context.Context, includeRevoked bool) ([]*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys`
+	if !includeRevoked {
+		query += ` WHERE revoked_at IS NULL`
+	}
+	query += ` ORDER BY name, created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes an API key.
+func (r *apiKeyRepo) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result, err := r.pool.Exec(ctx,
+		"UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL", id, at)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("api key", id.String())
+	}
+
+	return nil
+}
+
+// TouchLastUsed records a use of an API key, at most once per
+// apiKeyTouchInterval.
+func (r *apiKeyRepo) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `
+		UPDATE api_keys SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $3)
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, at, at.Add(-apiKeyTouchInterval)); err != nil {
+		return fmt.Errorf("failed to touch API key: %w", err)
+	}
+
+	return nil
+}
+
+// scanAPIKey scans a row of apiKeyColumns.
+func scanAPIKey(row pgx.Row) (*domain.APIKey, error) {
+	k := &domain.APIKey{}
+	if err := row.Scan(
+		&k.ID, &k.Name, &k.Prefix, &k.KeyHash, &k.Role, &k.CreatedBy,
+		&k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ APIKeyRepository = (*apiKeyRepo)(nil)
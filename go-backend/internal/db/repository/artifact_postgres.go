@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// artifactRepo implements ArtifactRepository using PostgreSQL.
+type artifactRepo struct {
+	pool *db.Pool
+}
+
+// NewArtifactRepository creates a new PostgreSQL artifact repository.
+func NewArtifactRepository(pool *db.Pool) ArtifactRepository {
+	return &artifactRepo{pool: pool}
+}
+
+// Create stores a new artifact with its content.
+func (r *artifactRepo) Create(ctx context.Context, artifact *domain.Artifact) error {
+	return insertArtifact(ctx, r.pool, artifact)
+}
+
+// execer is implemented by both the pool and transactions.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+// insertArtifact stores an artifact, for repositories that create artifacts
+// within their own transactions.
+func insertArtifact(ctx context.Context, db execer, artifact *domain.Artifact) error {
+	metadata := artifact.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO artifacts (
+			id, owner_type, owner_id, kind, name, content_type, source_url,
+			size_bytes, sha256, metadata, content, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)
+	`
+
+	_, err = db.Exec(ctx, query,
+		artifact.ID,
+		artifact.OwnerType.String(),
+		artifact.OwnerID,
+		artifact.Kind.String(),
+		artifact.Name,
+		artifact.ContentType,
+		artifact.SourceURL,
+		artifact.SizeBytes,
+		artifact.SHA256,
+		metadataJSON,
+		artifact.Content,
+		artifact.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create artifact: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves artifact metadata by ID. Content is not loaded.
+func (r *artifactRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Artifact, error) {
+	query := `
+		SELECT
+			id, owner_type, owner_id, kind, name, content_type, source_url,
+			size_bytes, sha256, metadata, created_at
+		FROM artifacts
+		WHERE id = $1
+	`
+
+	artifact, err := r.scanArtifact(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("artifact", id.String())
+		}
+		return nil, fmt.Errorf("failed to get artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// GetContent retrieves an artifact with its content.
+func (r *artifactRepo) GetContent(ctx context.Context, id uuid.UUID) (*domain.Artifact, error) {
+	query := `
+		SELECT
+			id, owner_type, owner_id, kind, name, content_type, source_url,
+			size_bytes, sha256, metadata, created_at, content
+		FROM artifacts
+		WHERE id = $1
+	`
+
+	var content []byte
+	artifact, err := r.scanArtifact(r.pool.QueryRow(ctx, query, id), &content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("artifact", id.String())
+		}
+		return nil, fmt.Errorf("failed to get artifact content: %w", err)
+	}
+	artifact.Content = content
+
+	return artifact, nil
+}
+
+// ListByOwner retrieves metadata for all artifacts of an entity, oldest first.
+func (r *artifactRepo) ListByOwner(ctx context.Context, ownerType domain.ArtifactOwnerType, ownerID uuid.UUID) ([]*domain.Artifact, error) {
+	query := `
+		SELECT
+			id, owner_type, owner_id, kind, name, content_type, source_url,
+			size_bytes, sha256, metadata, created_at
+		FROM artifacts
+		WHERE owner_type = $1 AND owner_id = $2
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, ownerType.String(), ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return r.collectArtifacts(rows)
+}
+
+// ListByOwners retrieves metadata for all artifacts of several entities of
+// one type, oldest first.
+func (r *artifactRepo) ListByOwners(ctx context.Context, ownerType domain.ArtifactOwnerType, ownerIDs []uuid.UUID) ([]*domain.Artifact, error) {
+	if len(ownerIDs) == 0 {
+		return []*domain.Artifact{}, nil
+	}
+
+	query := `
+		SELECT
+			id, owner_type, owner_id, kind, name, content_type, source_url,
+			size_bytes, sha256, metadata, created_at
+		FROM artifacts
+		WHERE owner_type = $1 AND owner_id = ANY($2)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, ownerType.String(), ownerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return r.collectArtifacts(rows)
+}
+
+// collectArtifacts scans and closes rows of artifact metadata.
+func (r *artifactRepo) collectArtifacts(rows pgx.Rows) ([]*domain.Artifact, error) {
+	defer rows.Close()
+
+	artifacts := []*domain.Artifact{}
+	for rows.Next() {
+		artifact, err := r.scanArtifact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan artifact: %w", err)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// scanArtifact scans artifact metadata columns, followed by any extra destinations.
+func (r *artifactRepo) scanArtifact(row pgx.Row, extra ...interface{}) (*domain.Artifact, error) {
+	artifact := &domain.Artifact{}
+	var ownerType, kind string
+	var metadataJSON []byte
+
+	dest := []interface{}{
+		&artifact.ID,
+		&ownerType,
+		&artifact.OwnerID,
+		&kind,
+		&artifact.Name,
+		&artifact.ContentType,
+		&artifact.SourceURL,
+		&artifact.SizeBytes,
+		&artifact.SHA256,
+		&metadataJSON,
+		&artifact.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	artifact.OwnerType = domain.ArtifactOwnerType(ownerType)
+	artifact.Kind = domain.ArtifactKind(kind)
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &artifact.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+	if len(artifact.Metadata) == 0 {
+		artifact.Metadata = nil
+	}
+
+	return artifact, nil
+}
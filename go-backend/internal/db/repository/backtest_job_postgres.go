@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,6 +25,22 @@ func NewBacktestJobRepository(pool *db.Pool) BacktestJobRepository {
 	return &backtestJobRepo{pool: pool}
 }
 
+// insertJobSQL inserts a backtest job and records the queued event of its timeline.
+const insertJobSQL = `
+	WITH job AS (
+		INSERT INTO backtest_jobs (
+			id, strategy_id, optimization_run_id, config, priority, status,
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, resubmitted_from, hints
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+		)
+		RETURNING id, status, created_at
+	)
+	INSERT INTO job_events (job_id, event_type, status, occurred_at)
+	SELECT id, 'queued', status, created_at FROM job
+`
+
 // Create creates a new backtest job.
 func (r *backtestJobRepo) Create(ctx context.Context, job *domain.BacktestJob) error {
 	configJSON, err := json.Marshal(job.Config)
@@ -31,14 +48,7 @@ func (r *backtestJobRepo) Create(ctx context.Context, job *domain.BacktestJob) e
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	query := `
-		INSERT INTO backtest_jobs (
-			id, strategy_id, optimization_run_id, config, priority, status,
-			container_id, error_message, retry_count, created_at, started_at, completed_at
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
-		)
-	`
+	query := insertJobSQL
 
 	_, err = r.pool.Exec(ctx, query,
 		job.ID,
@@ -53,8 +63,16 @@ func (r *backtestJobRepo) Create(ctx context.Context, job *domain.BacktestJob) e
 		job.CreatedAt,
 		job.StartedAt,
 		job.CompletedAt,
+		job.ExternalRef,
+		job.ExternalRefOwner,
+		job.CampaignID,
+		job.ResubmittedFrom,
+		job.Hints,
 	)
 	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.NewDuplicateError("backtest_job", "external_ref", derefString(job.ExternalRef))
+		}
 		return fmt.Errorf("failed to create backtest job: %w", err)
 	}
 
@@ -73,37 +91,9 @@ func (r *backtestJobRepo) CreateBatch(ctx context.Context, jobs []*domain.Backte
 	}
 	defer tx.Rollback(ctx)
 
-	query := `
-		INSERT INTO backtest_jobs (
-			id, strategy_id, optimization_run_id, config, priority, status,
-			container_id, error_message, retry_count, created_at, started_at, completed_at
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
-		)
-	`
-
 	for _, job := range jobs {
-		configJSON, err := json.Marshal(job.Config)
-		if err != nil {
-			return fmt.Errorf("failed to marshal config for job %s: %w", job.ID, err)
-		}
-
-		_, err = tx.Exec(ctx, query,
-			job.ID,
-			job.StrategyID,
-			job.OptimizationRunID,
-			configJSON,
-			job.Priority,
-			job.Status.String(),
-			job.ContainerID,
-			job.ErrorMessage,
-			job.RetryCount,
-			job.CreatedAt,
-			job.StartedAt,
-			job.CompletedAt,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create backtest job %s: %w", job.ID, err)
+		if err := insertBatchJob(ctx, tx, job); err != nil {
+			return err
 		}
 	}
 
@@ -114,12 +104,106 @@ func (r *backtestJobRepo) CreateBatch(ctx context.Context, jobs []*domain.Backte
 	return nil
 }
 
+// CreateBatchPartial creates multiple backtest jobs in a single transaction,
+// inserting each one under its own savepoint so that a failing job is rolled
+// back alone instead of aborting the batch. It returns one error per job, nil
+// for the jobs that were created.
+func (r *backtestJobRepo) CreateBatchPartial(ctx context.Context, jobs []*domain.BacktestJob) ([]error, error) {
+	errs := make([]error, len(jobs))
+	if len(jobs) == 0 {
+		return errs, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for i, job := range jobs {
+		// Begin on a transaction creates a savepoint
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		if err := insertBatchJob(ctx, savepoint, job); err != nil {
+			errs[i] = err
+			if err := savepoint.Rollback(ctx); err != nil {
+				return nil, fmt.Errorf("failed to roll back to savepoint: %w", err)
+			}
+			continue
+		}
+
+		if err := savepoint.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return errs, nil
+}
+
+// insertBatchJob inserts one job of a batch. Duplicate external refs and
+// missing referenced rows are returned as domain errors.
+func insertBatchJob(ctx context.Context, tx pgx.Tx, job *domain.BacktestJob) error {
+	configJSON, err := json.Marshal(job.Config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config for job %s: %w", job.ID, err)
+	}
+
+	_, err = tx.Exec(ctx, insertJobSQL,
+		job.ID,
+		job.StrategyID,
+		job.OptimizationRunID,
+		configJSON,
+		job.Priority,
+		job.Status.String(),
+		job.ContainerID,
+		job.ErrorMessage,
+		job.RetryCount,
+		job.CreatedAt,
+		job.StartedAt,
+		job.CompletedAt,
+		job.ExternalRef,
+		job.ExternalRefOwner,
+		job.CampaignID,
+		job.ResubmittedFrom,
+		job.Hints,
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.NewDuplicateError("backtest_job", "external_ref", derefString(job.ExternalRef))
+		}
+		if isForeignKeyViolation(err) {
+			switch {
+			case strings.Contains(err.Error(), "strategy_id"):
+				return domain.NewNotFoundError("strategy", job.StrategyID.String())
+			case strings.Contains(err.Error(), "optimization_run_id") && job.OptimizationRunID != nil:
+				return domain.NewNotFoundError("optimization_run", job.OptimizationRunID.String())
+			case strings.Contains(err.Error(), "campaign_id") && job.CampaignID != nil:
+				return domain.NewNotFoundError("campaign", job.CampaignID.String())
+			case strings.Contains(err.Error(), "resubmitted_from") && job.ResubmittedFrom != nil:
+				return domain.NewNotFoundError("backtest_job", job.ResubmittedFrom.String())
+			}
+		}
+		return fmt.Errorf("failed to create backtest job %s: %w", job.ID, err)
+	}
+
+	return nil
+}
+
 // GetByID retrieves a job by ID.
 func (r *backtestJobRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.BacktestJob, error) {
 	query := `
 		SELECT
 			id, strategy_id, optimization_run_id, config, priority, status,
-			container_id, error_message, retry_count, created_at, started_at, completed_at
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+			cancel_reason, cancelled_by
 		FROM backtest_jobs
 		WHERE id = $1
 	`
@@ -127,6 +211,7 @@ func (r *backtestJobRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ba
 	job := &domain.BacktestJob{}
 	var configJSON []byte
 	var statusStr string
+	var failureCategory *string
 
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&job.ID,
@@ -141,6 +226,14 @@ func (r *backtestJobRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ba
 		&job.CreatedAt,
 		&job.StartedAt,
 		&job.CompletedAt,
+		&job.ExternalRef,
+		&job.ExternalRefOwner,
+		&job.CampaignID,
+		&failureCategory,
+		&job.ResubmittedFrom,
+		&job.Hints,
+		&job.CancelReason,
+		&job.CancelledBy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -154,6 +247,7 @@ func (r *backtestJobRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ba
 	}
 
 	job.Status = domain.JobStatusFromString(statusStr)
+	job.FailureCategory = failureCategoryFromDB(failureCategory)
 	return job, nil
 }
 
@@ -203,21 +297,46 @@ func (r *backtestJobRepo) Update(ctx context.Context, job *domain.BacktestJob) e
 	return nil
 }
 
-// GetPendingJobs retrieves pending jobs for processing.
-// Uses FOR UPDATE SKIP LOCKED for concurrent-safe dequeuing.
-func (r *backtestJobRepo) GetPendingJobs(ctx context.Context, limit int) ([]*domain.BacktestJob, error) {
+// effectivePrioritySQL is the SQL of domain.BacktestJob.EffectivePriority,
+// given the query parameters of the aging rate and the time it is taken at.
+// The time is passed in rather than taken from NOW(), so the database orders
+// jobs by the scheduler's clock as the scheduler does.
+func effectivePrioritySQL(agingParam, nowParam string) string {
+	return fmt.Sprintf(
+		"(priority + FLOOR(%s * GREATEST(EXTRACT(EPOCH FROM (%s::timestamptz - created_at)), 0) / 3600)::int)",
+		agingParam, nowParam,
+	)
+}
+
+// GetPendingJobs retrieves pending jobs for processing, by effective priority
+// at now (see domain.BacktestJob.EffectivePriority) and then age.
+// Uses FOR UPDATE SKIP LOCKED for concurrent-safe dequeuing. Jobs of
+// optimization runs already running as many jobs as their quota allows are
+// skipped, so they don't crowd out other runs' jobs.
+func (r *backtestJobRepo) GetPendingJobs(ctx context.Context, limit int, agingPerHour float64, now time.Time) ([]*domain.BacktestJob, error) {
 	query := `
 		SELECT
 			id, strategy_id, optimization_run_id, config, priority, status,
-			container_id, error_message, retry_count, created_at, started_at, completed_at
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+			cancel_reason, cancelled_by
 		FROM backtest_jobs
 		WHERE status = 'pending'
-		ORDER BY priority DESC, created_at ASC
+			AND NOT EXISTS (
+				SELECT 1 FROM optimization_runs o
+				WHERE o.id = backtest_jobs.optimization_run_id
+					AND COALESCE((o.config->'quota'->>'max_concurrent_jobs')::int, 0) > 0
+					AND (
+						SELECT COUNT(*) FROM backtest_jobs running
+						WHERE running.optimization_run_id = o.id AND running.status = 'running'
+					) >= (o.config->'quota'->>'max_concurrent_jobs')::int
+			)
+		ORDER BY ` + effectivePrioritySQL("$2", "$3") + ` DESC, created_at ASC
 		LIMIT $1
 		FOR UPDATE SKIP LOCKED
 	`
 
-	rows, err := r.pool.Query(ctx, query, limit)
+	rows, err := r.pool.Query(ctx, query, limit, agingPerHour, now)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query pending jobs: %w", err)
 	}
@@ -308,16 +427,17 @@ func (r *backtestJobRepo) MarkCompleted(ctx context.Context, id uuid.UUID) error
 }
 
 // MarkFailed marks a job as failed with an error message.
-func (r *backtestJobRepo) MarkFailed(ctx context.Context, id uuid.UUID, errMsg string) error {
+func (r *backtestJobRepo) MarkFailed(ctx context.Context, id uuid.UUID, category domain.FailureCategory, errMsg string) error {
 	query := `
 		UPDATE backtest_jobs SET
 			status = 'failed',
 			error_message = $2,
+			failure_category = $3,
 			completed_at = NOW()
 		WHERE id = $1 AND status IN ('pending', 'running')
 	`
 
-	result, err := r.pool.Exec(ctx, query, id, errMsg)
+	result, err := r.pool.Exec(ctx, query, id, errMsg, category.String())
 	if err != nil {
 		return fmt.Errorf("failed to mark job failed: %w", err)
 	}
@@ -329,16 +449,25 @@ func (r *backtestJobRepo) MarkFailed(ctx context.Context, id uuid.UUID, errMsg s
 	return nil
 }
 
-// Cancel cancels a pending or running job.
-func (r *backtestJobRepo) Cancel(ctx context.Context, id uuid.UUID) error {
+// Cancel cancels a pending or running job, recording the reason and who
+// cancelled it on the job and in its timeline.
+func (r *backtestJobRepo) Cancel(ctx context.Context, id uuid.UUID, c domain.Cancellation) error {
 	query := `
-		UPDATE backtest_jobs SET
-			status = 'cancelled',
-			completed_at = NOW()
-		WHERE id = $1 AND status IN ('pending', 'running')
+		WITH job AS (
+			UPDATE backtest_jobs SET
+				status = 'cancelled',
+				completed_at = NOW(),
+				cancel_reason = $2,
+				cancelled_by = $3
+			WHERE id = $1 AND status IN ('pending', 'running')
+			RETURNING id, container_id, completed_at
+		)
+		INSERT INTO job_events (job_id, event_type, status, container_id, detail, occurred_at)
+		SELECT id, 'cancelled', 'cancelled', container_id, $4, completed_at FROM job
 	`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := r.pool.Exec(ctx, query, id,
+		nullIfEmptyString(c.Reason), nullIfEmptyString(c.CancelledBy), nullIfEmptyString(c.Detail()))
 	if err != nil {
 		return fmt.Errorf("failed to cancel job: %w", err)
 	}
@@ -359,12 +488,56 @@ func (r *backtestJobRepo) Cancel(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// CancelMatching cancels all jobs selected by the filter. Updating the jobs
+// and recording their timeline events in one statement keeps the bulk
+// cancellation atomic.
+func (r *backtestJobRepo) CancelMatching(ctx context.Context, filter domain.JobCancelFilter, c domain.Cancellation) ([]*domain.BacktestJob, error) {
+	query := `
+		WITH jobs AS (
+			UPDATE backtest_jobs SET
+				status = 'cancelled',
+				completed_at = NOW(),
+				cancel_reason = $4,
+				cancelled_by = $5
+			WHERE status = $1
+				AND ($2::uuid IS NULL OR optimization_run_id = $2)
+				AND ($3::uuid IS NULL OR strategy_id = $3)
+			RETURNING
+				id, strategy_id, optimization_run_id, config, priority, status,
+				container_id, error_message, retry_count, created_at, started_at, completed_at,
+				external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+				cancel_reason, cancelled_by
+		), events AS (
+			INSERT INTO job_events (job_id, event_type, status, container_id, detail, occurred_at)
+			SELECT id, 'cancelled', 'cancelled', container_id, $6, completed_at FROM jobs
+		)
+		SELECT
+			id, strategy_id, optimization_run_id, config, priority, status,
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+			cancel_reason, cancelled_by
+		FROM jobs
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, string(filter.Status), filter.OptimizationRunID, filter.StrategyID,
+		nullIfEmptyString(c.Reason), nullIfEmptyString(c.CancelledBy), nullIfEmptyString(c.Detail()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel jobs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanJobs(rows)
+}
+
 // GetRunningJobs retrieves all currently running jobs.
 func (r *backtestJobRepo) GetRunningJobs(ctx context.Context) ([]*domain.BacktestJob, error) {
 	query := `
 		SELECT
 			id, strategy_id, optimization_run_id, config, priority, status,
-			container_id, error_message, retry_count, created_at, started_at, completed_at
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+			cancel_reason, cancelled_by
 		FROM backtest_jobs
 		WHERE status = 'running'
 		ORDER BY started_at ASC
@@ -379,12 +552,133 @@ func (r *backtestJobRepo) GetRunningJobs(ctx context.Context) ([]*domain.Backtes
 	return r.scanJobs(rows)
 }
 
+// Requeue returns the listed running jobs to pending, clearing their
+// container and start time. It returns the IDs of the jobs requeued.
+func (r *backtestJobRepo) Requeue(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		UPDATE backtest_jobs SET
+			status = 'pending',
+			container_id = NULL,
+			started_at = NULL
+		WHERE id = ANY($1) AND status = 'running'
+		RETURNING id
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to requeue jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var requeued []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan requeued job: %w", err)
+		}
+		requeued = append(requeued, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to requeue jobs: %w", err)
+	}
+
+	return requeued, nil
+}
+
+// GetActiveDuplicates finds the oldest pending or running job doing the same
+// work as each of the given jobs. Configs are compared as JSONB, so key order
+// does not matter.
+func (r *backtestJobRepo) GetActiveDuplicates(ctx context.Context, jobs []*domain.BacktestJob) ([]*uuid.UUID, error) {
+	duplicates := make([]*uuid.UUID, len(jobs))
+	if len(jobs) == 0 {
+		return duplicates, nil
+	}
+
+	strategyIDs := make([]uuid.UUID, len(jobs))
+	configs := make([]string, len(jobs))
+	for i, job := range jobs {
+		configJSON, err := json.Marshal(job.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		strategyIDs[i] = job.StrategyID
+		configs[i] = string(configJSON)
+	}
+
+	query := `
+		SELECT c.idx, d.id
+		FROM unnest($1::uuid[], $2::text[]::jsonb[]) WITH ORDINALITY AS c(strategy_id, config, idx)
+		CROSS JOIN LATERAL (
+			SELECT j.id
+			FROM backtest_jobs j
+			WHERE j.strategy_id = c.strategy_id
+				AND j.status IN ('pending', 'running')
+				AND j.config = c.config
+			ORDER BY j.created_at
+			LIMIT 1
+		) d
+	`
+
+	rows, err := r.pool.Query(ctx, query, strategyIDs, configs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate jobs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var idx int
+		var id uuid.UUID
+		if err := rows.Scan(&idx, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate job: %w", err)
+		}
+		duplicates[idx-1] = &id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duplicate jobs: %w", err)
+	}
+
+	return duplicates, nil
+}
+
+// GetCancelledIDs returns the IDs of the listed jobs that have been cancelled.
+func (r *backtestJobRepo) GetCancelledIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `SELECT id FROM backtest_jobs WHERE id = ANY($1) AND status = 'cancelled'`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancelled jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var cancelled []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan cancelled job: %w", err)
+		}
+		cancelled = append(cancelled, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cancelled jobs: %w", err)
+	}
+
+	return cancelled, nil
+}
+
 // GetTimedOutJobs retrieves jobs that have exceeded the timeout.
 func (r *backtestJobRepo) GetTimedOutJobs(ctx context.Context, timeout time.Duration) ([]*domain.BacktestJob, error) {
 	query := `
 		SELECT
 			id, strategy_id, optimization_run_id, config, priority, status,
-			container_id, error_message, retry_count, created_at, started_at, completed_at
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+			cancel_reason, cancelled_by
 		FROM backtest_jobs
 		WHERE status = 'running'
 			AND started_at < NOW() - $1::interval
@@ -405,7 +699,9 @@ func (r *backtestJobRepo) GetByOptimizationRunID(ctx context.Context, runID uuid
 	query := `
 		SELECT
 			id, strategy_id, optimization_run_id, config, priority, status,
-			container_id, error_message, retry_count, created_at, started_at, completed_at
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+			cancel_reason, cancelled_by
 		FROM backtest_jobs
 		WHERE optimization_run_id = $1
 		ORDER BY created_at ASC
@@ -445,6 +741,16 @@ func (r *backtestJobRepo) Query(ctx context.Context, query *domain.BacktestJobQu
 		args = append(args, *query.OptimizationRunID)
 	}
 
+	if query.CampaignID != nil {
+		argCount++
+		if whereClause == "" {
+			whereClause = fmt.Sprintf("WHERE campaign_id = $%d", argCount)
+		} else {
+			whereClause += fmt.Sprintf(" AND campaign_id = $%d", argCount)
+		}
+		args = append(args, *query.CampaignID)
+	}
+
 	if query.Status != nil {
 		argCount++
 		if whereClause == "" {
@@ -491,7 +797,9 @@ func (r *backtestJobRepo) Query(ctx context.Context, query *domain.BacktestJobQu
 	selectQuery := fmt.Sprintf(`
 		SELECT
 			id, strategy_id, optimization_run_id, config, priority, status,
-			container_id, error_message, retry_count, created_at, started_at, completed_at
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+			cancel_reason, cancelled_by
 		FROM backtest_jobs
 		%s
 		%s
@@ -559,6 +867,378 @@ func (r *backtestJobRepo) GetQueueStats(ctx context.Context) (*domain.QueueStats
 	return stats, nil
 }
 
+// GetFailureAnalytics counts finished jobs and their failures by category.
+func (r *backtestJobRepo) GetFailureAnalytics(ctx context.Context, window *domain.TimeRange) (*domain.FailureAnalytics, error) {
+	var start, end *time.Time
+	if window != nil {
+		if !window.Start.IsZero() {
+			start = &window.Start
+		}
+		if !window.End.IsZero() {
+			end = &window.End
+		}
+	}
+
+	query := `
+		SELECT
+			CASE WHEN status = 'failed' THEN COALESCE(failure_category, $3) END AS category,
+			COUNT(*)
+		FROM backtest_jobs
+		WHERE status IN ('completed', 'failed')
+			AND ($1::timestamptz IS NULL OR completed_at >= $1)
+			AND ($2::timestamptz IS NULL OR completed_at < $2)
+		GROUP BY 1
+	`
+
+	rows, err := r.pool.Query(ctx, query, start, end, domain.FailureCategoryUnknown.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failure analytics: %w", err)
+	}
+	defer rows.Close()
+
+	var finished int
+	failures := make(map[domain.FailureCategory]int)
+	for rows.Next() {
+		var category *string
+		var count int
+		if err := rows.Scan(&category, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan failure count: %w", err)
+		}
+		finished += count
+		if category != nil {
+			failures[domain.FailureCategoryFromString(*category)] += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating failure counts: %w", err)
+	}
+
+	return domain.NewFailureAnalytics(window, finished, failures, time.Now()), nil
+}
+
+// CountPendingAhead counts the pending jobs dequeued before a new job of the
+// given priority submitted at now: jobs of a higher effective priority and
+// older jobs of the same one. A new job has not aged.
+func (r *backtestJobRepo) CountPendingAhead(ctx context.Context, priority int, agingPerHour float64, now time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM backtest_jobs
+		WHERE status = 'pending' AND ` + effectivePrioritySQL("$2", "$3") + ` >= $1
+	`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, priority, agingPerHour, now).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count pending jobs: %w", err)
+	}
+	return count, nil
+}
+
+// GetPendingQueue retrieves all pending jobs by priority and age;
+// domain.SortQueue puts them in dequeue order.
+func (r *backtestJobRepo) GetPendingQueue(ctx context.Context) ([]domain.QueuedJob, error) {
+	query := `
+		SELECT id, optimization_run_id, priority, created_at
+		FROM backtest_jobs
+		WHERE status = 'pending'
+		ORDER BY priority DESC, created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending queue: %w", err)
+	}
+	defer rows.Close()
+
+	var queue []domain.QueuedJob
+	for rows.Next() {
+		var job domain.QueuedJob
+		if err := rows.Scan(&job.JobID, &job.OptimizationRunID, &job.Priority, &job.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan queued job: %w", err)
+		}
+		queue = append(queue, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending queue: %w", err)
+	}
+
+	return queue, nil
+}
+
+// SetPriority sets the priority of the pending jobs selected by the change.
+func (r *backtestJobRepo) SetPriority(ctx context.Context, change domain.PriorityChange) ([]uuid.UUID, error) {
+	query := `
+		UPDATE backtest_jobs SET priority = $1
+		WHERE status = 'pending'
+			AND (id = ANY($2) OR optimization_run_id = $3)
+		RETURNING id
+	`
+
+	jobIDs := change.JobIDs
+	if jobIDs == nil {
+		jobIDs = []uuid.UUID{}
+	}
+	rows, err := r.pool.Query(ctx, query, change.Priority, jobIDs, change.OptimizationRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set job priority: %w", err)
+	}
+	defer rows.Close()
+
+	updated := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan job id: %w", err)
+		}
+		updated = append(updated, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating updated jobs: %w", err)
+	}
+
+	return updated, nil
+}
+
+// GetRecentRuntimes retrieves runtimes of the most recently completed jobs.
+func (r *backtestJobRepo) GetRecentRuntimes(ctx context.Context, since time.Time, limit int) ([]time.Duration, error) {
+	query := `
+		SELECT EXTRACT(EPOCH FROM (completed_at - started_at)) * 1000 AS run_ms
+		FROM backtest_jobs
+		WHERE status = 'completed'
+			AND started_at IS NOT NULL
+			AND completed_at IS NOT NULL
+			AND completed_at >= $1
+		ORDER BY completed_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent runtimes: %w", err)
+	}
+	defer rows.Close()
+
+	var runtimes []time.Duration
+	for rows.Next() {
+		var runMs float64
+		if err := rows.Scan(&runMs); err != nil {
+			return nil, fmt.Errorf("failed to scan runtime: %w", err)
+		}
+		if runMs > 0 {
+			runtimes = append(runtimes, time.Duration(runMs)*time.Millisecond)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating runtimes: %w", err)
+	}
+
+	return runtimes, nil
+}
+
+// GetByExternalRef retrieves a job by the external reference supplied by its owner.
+func (r *backtestJobRepo) GetByExternalRef(ctx context.Context, owner, ref string) (*domain.BacktestJob, error) {
+	query := `
+		SELECT
+			id, strategy_id, optimization_run_id, config, priority, status,
+			container_id, error_message, retry_count, created_at, started_at, completed_at,
+			external_ref, external_ref_owner, campaign_id, failure_category, resubmitted_from, hints,
+			cancel_reason, cancelled_by
+		FROM backtest_jobs
+		WHERE external_ref_owner = $1 AND external_ref = $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, owner, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backtest job by external ref: %w", err)
+	}
+	defer rows.Close()
+
+	jobs, err := r.scanJobs(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, domain.NewNotFoundError("backtest_job", ref)
+	}
+
+	return jobs[0], nil
+}
+
+// GetCoverageEntries retrieves the configs and outcomes of all jobs for a strategy.
+func (r *backtestJobRepo) GetCoverageEntries(ctx context.Context, strategyID uuid.UUID) ([]domain.CoverageEntry, error) {
+	query := `
+		SELECT j.config, j.status, r.id IS NOT NULL AS has_result
+		FROM backtest_jobs j
+		LEFT JOIN backtest_results r ON r.job_id = j.id
+		WHERE j.strategy_id = $1
+		ORDER BY j.created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, strategyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coverage entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.CoverageEntry
+	for rows.Next() {
+		var (
+			configJSON []byte
+			status     string
+			entry      domain.CoverageEntry
+		)
+		if err := rows.Scan(&configJSON, &status, &entry.HasResult); err != nil {
+			return nil, fmt.Errorf("failed to scan coverage entry: %w", err)
+		}
+		if err := json.Unmarshal(configJSON, &entry.Config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+		entry.Status = domain.JobStatusFromString(status)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating coverage entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetSLABreaches retrieves pending and running jobs that exceed the SLA targets at now.
+func (r *backtestJobRepo) GetSLABreaches(ctx context.Context, targets domain.SLATargets, now time.Time) ([]*domain.SLABreach, error) {
+	// A zero target disables the corresponding branch
+	query := `
+		SELECT id, strategy_id, optimization_run_id, priority, 'queue_wait' AS kind, created_at AS since
+		FROM backtest_jobs
+		WHERE status = 'pending'
+			AND $1::bigint > 0
+			AND created_at < $3::timestamptz - $1::bigint * INTERVAL '1 millisecond'
+		UNION ALL
+		SELECT id, strategy_id, optimization_run_id, priority, 'run_duration' AS kind, started_at AS since
+		FROM backtest_jobs
+		WHERE status = 'running'
+			AND $2::bigint > 0
+			AND started_at < $3::timestamptz - $2::bigint * INTERVAL '1 millisecond'
+		ORDER BY since ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query,
+		targets.MaxQueueWait.Milliseconds(),
+		targets.MaxRunDuration.Milliseconds(),
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SLA breaches: %w", err)
+	}
+	defer rows.Close()
+
+	var breaches []*domain.SLABreach
+	for rows.Next() {
+		var (
+			breach domain.SLABreach
+			kind   string
+		)
+		if err := rows.Scan(
+			&breach.JobID,
+			&breach.StrategyID,
+			&breach.OptimizationRunID,
+			&breach.Priority,
+			&kind,
+			&breach.Since,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan SLA breach: %w", err)
+		}
+		breach.Kind = domain.SLAKind(kind)
+		breach.ElapsedMs = now.Sub(breach.Since).Milliseconds()
+		breach.TargetMs = targets.Target(breach.Kind).Milliseconds()
+		breaches = append(breaches, &breach)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating SLA breaches: %w", err)
+	}
+
+	return breaches, nil
+}
+
+// AddEvent appends an event to a job's timeline.
+func (r *backtestJobRepo) AddEvent(ctx context.Context, event *domain.JobEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO job_events (job_id, event_type, status, container_id, worker, host, detail, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		event.JobID,
+		string(event.Type),
+		event.Status.String(),
+		event.ContainerID,
+		event.Worker,
+		event.Host,
+		event.Detail,
+		event.OccurredAt,
+	).Scan(&event.ID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.NewNotFoundError("backtest_job", event.JobID.String())
+		}
+		return fmt.Errorf("failed to add job event: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents retrieves a job's timeline, oldest first.
+func (r *backtestJobRepo) GetEvents(ctx context.Context, jobID uuid.UUID) ([]*domain.JobEvent, error) {
+	query := `
+		SELECT id, job_id, event_type, status, container_id, worker, host, detail, occurred_at
+		FROM job_events
+		WHERE job_id = $1
+		ORDER BY occurred_at, id
+	`
+
+	rows, err := r.pool.Query(ctx, query, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.JobEvent
+	for rows.Next() {
+		event := &domain.JobEvent{}
+		var eventType, statusStr string
+		if err := rows.Scan(
+			&event.ID,
+			&event.JobID,
+			&eventType,
+			&statusStr,
+			&event.ContainerID,
+			&event.Worker,
+			&event.Host,
+			&event.Detail,
+			&event.OccurredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan job event: %w", err)
+		}
+		event.Type = domain.JobEventType(eventType)
+		event.Status = domain.JobStatusFromString(statusStr)
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job events: %w", err)
+	}
+
+	return events, nil
+}
+
 // IncrementRetryCount increments the retry count for a job.
 func (r *backtestJobRepo) IncrementRetryCount(ctx context.Context, id uuid.UUID) error {
 	query := `
@@ -587,6 +1267,7 @@ func (r *backtestJobRepo) scanJobs(rows pgx.Rows) ([]*domain.BacktestJob, error)
 		job := &domain.BacktestJob{}
 		var configJSON []byte
 		var statusStr string
+		var failureCategory *string
 
 		err := rows.Scan(
 			&job.ID,
@@ -601,6 +1282,14 @@ func (r *backtestJobRepo) scanJobs(rows pgx.Rows) ([]*domain.BacktestJob, error)
 			&job.CreatedAt,
 			&job.StartedAt,
 			&job.CompletedAt,
+			&job.ExternalRef,
+			&job.ExternalRefOwner,
+			&job.CampaignID,
+			&failureCategory,
+			&job.ResubmittedFrom,
+			&job.Hints,
+			&job.CancelReason,
+			&job.CancelledBy,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan job row: %w", err)
@@ -611,6 +1300,7 @@ func (r *backtestJobRepo) scanJobs(rows pgx.Rows) ([]*domain.BacktestJob, error)
 		}
 
 		job.Status = domain.JobStatusFromString(statusStr)
+		job.FailureCategory = failureCategoryFromDB(failureCategory)
 		jobs = append(jobs, job)
 	}
 
@@ -620,3 +1310,20 @@ func (r *backtestJobRepo) scanJobs(rows pgx.Rows) ([]*domain.BacktestJob, error)
 
 	return jobs, nil
 }
+
+// failureCategoryFromDB converts a nullable failure_category column.
+func failureCategoryFromDB(s *string) *domain.FailureCategory {
+	if s == nil {
+		return nil
+	}
+	category := domain.FailureCategoryFromString(*s)
+	return &category
+}
+
+// derefString returns the value of s, or "" if s is nil.
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -2,11 +2,11 @@ package repository
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -32,32 +32,69 @@ func (r *backtestResultRepo) Create(ctx context.Context, result *domain.Backtest
 		return fmt.Errorf("failed to marshal pair_results: %w", err)
 	}
 
-	// Encode RawLog as base64 for TEXT column storage (gzip data is binary)
-	var rawLogEncoded *string
-	if len(result.RawLog) > 0 {
-		encoded := base64.StdEncoding.EncodeToString(result.RawLog)
-		rawLogEncoded = &encoded
+	var environmentJSON []byte
+	if result.Environment != nil {
+		environmentJSON, err = json.Marshal(result.Environment)
+		if err != nil {
+			return fmt.Errorf("failed to marshal environment: %w", err)
+		}
+	}
+
+	exitReasonsJSON, err := marshalTradeReasons(result.ExitReasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal exit_reasons: %w", err)
+	}
+	entryTagsJSON, err := marshalTradeReasons(result.EntryTags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entry_tags: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
+	// Earlier results for the same strategy and config (retries, manual re-submits)
+	// are superseded by the new one so aggregates only count the latest run
 	query := `
-		INSERT INTO backtest_results (
-			id, job_id, strategy_id,
-			total_trades, winning_trades, losing_trades, win_rate,
-			profit_total, profit_pct, profit_factor,
-			max_drawdown, max_drawdown_pct, sharpe_ratio, sortino_ratio, calmar_ratio,
-			avg_trade_duration_minutes, avg_profit_per_trade, best_trade_pct, worst_trade_pct,
-			pair_results, raw_log, created_at
-		) VALUES (
-			$1, $2, $3,
-			$4, $5, $6, $7,
-			$8, $9, $10,
-			$11, $12, $13, $14, $15,
-			$16, $17, $18, $19,
-			$20, $21, $22
+		WITH inserted AS (
+			INSERT INTO backtest_results (
+				id, job_id, strategy_id,
+				total_trades, winning_trades, losing_trades, win_rate,
+				profit_total, profit_pct, profit_factor,
+				max_drawdown, max_drawdown_pct, sharpe_ratio, sortino_ratio, calmar_ratio,
+				avg_trade_duration_minutes, avg_profit_per_trade, best_trade_pct, worst_trade_pct,
+				pair_results, created_at,
+				stake_currency, reference_currency, reference_rate, profit_total_normalized,
+				environment,
+				exit_reasons, entry_tags, stoploss_exit_pct, trailing_stop_exit_pct,
+				trade_returns
+			) VALUES (
+				$1, $2, $3,
+				$4, $5, $6, $7,
+				$8, $9, $10,
+				$11, $12, $13, $14, $15,
+				$16, $17, $18, $19,
+				$20, $21,
+				$22, $23, $24, $25,
+				$26,
+				$27, $28, $29, $30,
+				$31
+			)
+			RETURNING id, job_id, strategy_id
 		)
+		UPDATE backtest_results br
+		SET superseded_by = inserted.id
+		FROM inserted, backtest_jobs new_job, backtest_jobs old_job
+		WHERE new_job.id = inserted.job_id
+			AND old_job.id = br.job_id
+			AND br.strategy_id = inserted.strategy_id
+			AND br.superseded_by IS NULL
+			AND old_job.config = new_job.config
 	`
 
-	_, err = r.pool.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		result.ID,
 		result.JobID,
 		result.StrategyID,
@@ -78,16 +115,134 @@ func (r *backtestResultRepo) Create(ctx context.Context, result *domain.Backtest
 		result.BestTradePct,
 		result.WorstTradePct,
 		pairResultsJSON,
-		rawLogEncoded,
 		result.CreatedAt,
+		result.StakeCurrency,
+		result.ReferenceCurrency,
+		result.ReferenceRate,
+		result.ProfitTotalNormalized,
+		environmentJSON,
+		exitReasonsJSON,
+		entryTagsJSON,
+		result.StoplossExitPct,
+		result.TrailingStopExitPct,
+		result.TradeReturns,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create backtest result: %w", err)
 	}
 
+	// The raw log is stored apart so result scans don't read it
+	if len(result.RawLog) > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO backtest_logs (result_id, content, size_bytes, created_at)
+			VALUES ($1, $2, $3, $4)
+		`, result.ID, result.RawLog, len(result.RawLog), result.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to store backtest log: %w", err)
+		}
+	}
+
+	if len(result.EquityCurve) > 0 {
+		timestamps := make([]time.Time, len(result.EquityCurve))
+		balances := make([]float64, len(result.EquityCurve))
+		for i, point := range result.EquityCurve {
+			timestamps[i] = point.Timestamp
+			balances[i] = point.Balance
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO backtest_equity_points (result_id, ts, balance)
+			SELECT $1, ts, balance FROM unnest($2::timestamptz[], $3::double precision[]) AS t(ts, balance)
+		`, result.ID, timestamps, balances)
+		if err != nil {
+			return fmt.Errorf("failed to store equity curve: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
+// GetRawLog retrieves the gzip-compressed raw log of a result. Logs are not
+// loaded with results; callers that need one fetch it here.
+func (r *backtestResultRepo) GetRawLog(ctx context.Context, resultID uuid.UUID) ([]byte, error) {
+	var content []byte
+	err := r.pool.QueryRow(ctx, "SELECT content FROM backtest_logs WHERE result_id = $1", resultID).Scan(&content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("backtest_log", resultID.String())
+		}
+		return nil, fmt.Errorf("failed to get backtest log: %w", err)
+	}
+
+	return content, nil
+}
+
+// GetTradeReturns retrieves the per-trade returns of a result. A result whose
+// format did not report trades has none.
+func (r *backtestResultRepo) GetTradeReturns(ctx context.Context, resultID uuid.UUID) ([]float64, error) {
+	var returns []float64
+	err := r.pool.QueryRow(ctx, "SELECT trade_returns FROM backtest_results WHERE id = $1", resultID).Scan(&returns)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("backtest_result", resultID.String())
+		}
+		return nil, fmt.Errorf("failed to get trade returns: %w", err)
+	}
+
+	return returns, nil
+}
+
+// GetEquityCurve retrieves the equity curve of a result, oldest point first,
+// with the drawdown of each point. A result whose format did not report daily
+// profits has none.
+func (r *backtestResultRepo) GetEquityCurve(ctx context.Context, resultID uuid.UUID) ([]domain.EquityPoint, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT ts, balance FROM backtest_equity_points
+		WHERE result_id = $1
+		ORDER BY ts
+	`, resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equity curve: %w", err)
+	}
+	defer rows.Close()
+
+	points := []domain.EquityPoint{}
+	for rows.Next() {
+		var point domain.EquityPoint
+		if err := rows.Scan(&point.Timestamp, &point.Balance); err != nil {
+			return nil, fmt.Errorf("failed to scan equity point: %w", err)
+		}
+		points = append(points, point)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating equity points: %w", err)
+	}
+
+	domain.SetEquityDrawdowns(points)
+	return points, nil
+}
+
+// GetStrategyTradeReturns retrieves the per-trade returns of all of a
+// strategy's current results, oldest result first.
+func (r *backtestResultRepo) GetStrategyTradeReturns(ctx context.Context, strategyID uuid.UUID) ([]float64, error) {
+	query := `
+		SELECT COALESCE(array_agg(ret ORDER BY br.created_at, br.id, ord), '{}')
+		FROM backtest_results br
+		CROSS JOIN LATERAL unnest(br.trade_returns) WITH ORDINALITY AS t(ret, ord)
+		WHERE br.strategy_id = $1 AND br.superseded_by IS NULL
+	`
+
+	var returns []float64
+	if err := r.pool.QueryRow(ctx, query, strategyID).Scan(&returns); err != nil {
+		return nil, fmt.Errorf("failed to get strategy trade returns: %w", err)
+	}
+
+	return returns, nil
+}
+
 // GetByID retrieves a result by ID.
 func (r *backtestResultRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.BacktestResult, error) {
 	query := `
@@ -97,7 +252,10 @@ func (r *backtestResultRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain
 			profit_total, profit_pct, profit_factor,
 			max_drawdown, max_drawdown_pct, sharpe_ratio, sortino_ratio, calmar_ratio,
 			avg_trade_duration_minutes, avg_profit_per_trade, best_trade_pct, worst_trade_pct,
-			pair_results, raw_log, created_at
+			pair_results, created_at, superseded_by,
+			stake_currency, reference_currency, reference_rate, profit_total_normalized,
+			environment,
+			exit_reasons, entry_tags, stoploss_exit_pct, trailing_stop_exit_pct
 		FROM backtest_results
 		WHERE id = $1
 	`
@@ -114,7 +272,10 @@ func (r *backtestResultRepo) GetByJobID(ctx context.Context, jobID uuid.UUID) (*
 			profit_total, profit_pct, profit_factor,
 			max_drawdown, max_drawdown_pct, sharpe_ratio, sortino_ratio, calmar_ratio,
 			avg_trade_duration_minutes, avg_profit_per_trade, best_trade_pct, worst_trade_pct,
-			pair_results, raw_log, created_at
+			pair_results, created_at, superseded_by,
+			stake_currency, reference_currency, reference_rate, profit_total_normalized,
+			environment,
+			exit_reasons, entry_tags, stoploss_exit_pct, trailing_stop_exit_pct
 		FROM backtest_results
 		WHERE job_id = $1
 	`
@@ -131,7 +292,10 @@ func (r *backtestResultRepo) GetByStrategyID(ctx context.Context, strategyID uui
 			profit_total, profit_pct, profit_factor,
 			max_drawdown, max_drawdown_pct, sharpe_ratio, sortino_ratio, calmar_ratio,
 			avg_trade_duration_minutes, avg_profit_per_trade, best_trade_pct, worst_trade_pct,
-			pair_results, raw_log, created_at
+			pair_results, created_at, superseded_by,
+			stake_currency, reference_currency, reference_rate, profit_total_normalized,
+			environment,
+			exit_reasons, entry_tags, stoploss_exit_pct, trailing_stop_exit_pct
 		FROM backtest_results
 		WHERE strategy_id = $1
 		ORDER BY created_at DESC
@@ -153,55 +317,8 @@ func (r *backtestResultRepo) Query(
 ) ([]*domain.BacktestResult, int, error) {
 	query.SetDefaults()
 
-	var conditions []string
-	var args []interface{}
-	argNum := 1
-
-	// Build WHERE conditions
-	if query.StrategyID != nil {
-		conditions = append(conditions, fmt.Sprintf("br.strategy_id = $%d", argNum))
-		args = append(args, *query.StrategyID)
-		argNum++
-	}
-
-	if query.OptimizationRunID != nil {
-		conditions = append(conditions, fmt.Sprintf("bj.optimization_run_id = $%d", argNum))
-		args = append(args, *query.OptimizationRunID)
-		argNum++
-	}
-
-	if query.MinSharpe != nil {
-		conditions = append(conditions, fmt.Sprintf("br.sharpe_ratio >= $%d", argNum))
-		args = append(args, *query.MinSharpe)
-		argNum++
-	}
-
-	if query.MinProfitPct != nil {
-		conditions = append(conditions, fmt.Sprintf("br.profit_pct >= $%d", argNum))
-		args = append(args, *query.MinProfitPct)
-		argNum++
-	}
-
-	if query.MaxDrawdownPct != nil {
-		conditions = append(conditions, fmt.Sprintf("br.max_drawdown_pct <= $%d", argNum))
-		args = append(args, *query.MaxDrawdownPct)
-		argNum++
-	}
-
-	if query.MinTrades != nil {
-		conditions = append(conditions, fmt.Sprintf("br.total_trades >= $%d", argNum))
-		args = append(args, *query.MinTrades)
-		argNum++
-	}
-
-	if query.TimeRange != nil {
-		conditions = append(conditions, fmt.Sprintf("br.created_at >= $%d", argNum))
-		args = append(args, query.TimeRange.Start)
-		argNum++
-		conditions = append(conditions, fmt.Sprintf("br.created_at <= $%d", argNum))
-		args = append(args, query.TimeRange.End)
-		argNum++
-	}
+	conditions, args := resultQueryConditions(query)
+	argNum := len(args) + 1
 
 	whereClause := ""
 	if len(conditions) > 0 {
@@ -215,6 +332,8 @@ func (r *backtestResultRepo) Query(
 		orderColumn = "br.sharpe_ratio"
 	case "profit":
 		orderColumn = "br.profit_pct"
+	case "normalized_profit":
+		orderColumn = "br.profit_total_normalized"
 	case "created_at":
 		orderColumn = "br.created_at"
 	}
@@ -226,7 +345,7 @@ func (r *backtestResultRepo) Query(
 
 	// Handle NULL values for sharpe_ratio ordering
 	nullsOrder := ""
-	if orderColumn == "br.sharpe_ratio" {
+	if orderColumn == "br.sharpe_ratio" || orderColumn == "br.profit_total_normalized" {
 		if query.Ascending {
 			nullsOrder = " NULLS FIRST"
 		} else {
@@ -256,7 +375,10 @@ func (r *backtestResultRepo) Query(
 			br.profit_total, br.profit_pct, br.profit_factor,
 			br.max_drawdown, br.max_drawdown_pct, br.sharpe_ratio, br.sortino_ratio, br.calmar_ratio,
 			br.avg_trade_duration_minutes, br.avg_profit_per_trade, br.best_trade_pct, br.worst_trade_pct,
-			br.pair_results, br.raw_log, br.created_at
+			br.pair_results, br.created_at, br.superseded_by,
+			br.stake_currency, br.reference_currency, br.reference_rate, br.profit_total_normalized,
+			br.environment,
+			br.exit_reasons, br.entry_tags, br.stoploss_exit_pct, br.trailing_stop_exit_pct
 		FROM backtest_results br
 		LEFT JOIN backtest_jobs bj ON br.job_id = bj.id
 		%s
@@ -280,7 +402,55 @@ func (r *backtestResultRepo) Query(
 	return results, totalCount, nil
 }
 
-// GetBestByStrategyID retrieves the best result for a strategy based on sharpe ratio.
+// ListAfterID lists up to limit results matching the query's filters ordered
+// by ID, starting after afterID. Pagination and ordering of the query are ignored.
+func (r *backtestResultRepo) ListAfterID(
+	ctx context.Context,
+	query domain.BacktestResultQuery,
+	afterID *uuid.UUID,
+	limit int,
+) ([]*domain.BacktestResult, error) {
+	conditions, args := resultQueryConditions(query)
+	if afterID != nil {
+		args = append(args, *afterID)
+		conditions = append(conditions, fmt.Sprintf("br.id > $%d", len(args)))
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT
+			br.id, br.job_id, br.strategy_id,
+			br.total_trades, br.winning_trades, br.losing_trades, br.win_rate,
+			br.profit_total, br.profit_pct, br.profit_factor,
+			br.max_drawdown, br.max_drawdown_pct, br.sharpe_ratio, br.sortino_ratio, br.calmar_ratio,
+			br.avg_trade_duration_minutes, br.avg_profit_per_trade, br.best_trade_pct, br.worst_trade_pct,
+			br.pair_results, br.created_at, br.superseded_by,
+			br.stake_currency, br.reference_currency, br.reference_rate, br.profit_total_normalized,
+			br.environment,
+			br.exit_reasons, br.entry_tags, br.stoploss_exit_pct, br.trailing_stop_exit_pct
+		FROM backtest_results br
+		LEFT JOIN backtest_jobs bj ON br.job_id = bj.id
+		%s
+		ORDER BY br.id
+		LIMIT $%d
+	`, whereClause, len(args)+1)
+
+	args = append(args, limit)
+
+	rows, err := r.pool.Query(ctx, selectQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list results: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanResults(rows)
+}
+
+// GetBestByStrategyID retrieves the best current result for a strategy based on sharpe ratio.
 func (r *backtestResultRepo) GetBestByStrategyID(ctx context.Context, strategyID uuid.UUID) (*domain.BacktestResult, error) {
 	query := `
 		SELECT
@@ -289,9 +459,12 @@ func (r *backtestResultRepo) GetBestByStrategyID(ctx context.Context, strategyID
 			profit_total, profit_pct, profit_factor,
 			max_drawdown, max_drawdown_pct, sharpe_ratio, sortino_ratio, calmar_ratio,
 			avg_trade_duration_minutes, avg_profit_per_trade, best_trade_pct, worst_trade_pct,
-			pair_results, raw_log, created_at
+			pair_results, created_at, superseded_by,
+			stake_currency, reference_currency, reference_rate, profit_total_normalized,
+			environment,
+			exit_reasons, entry_tags, stoploss_exit_pct, trailing_stop_exit_pct
 		FROM backtest_results
-		WHERE strategy_id = $1 AND sharpe_ratio IS NOT NULL
+		WHERE strategy_id = $1 AND sharpe_ratio IS NOT NULL AND superseded_by IS NULL
 		ORDER BY sharpe_ratio DESC
 		LIMIT 1
 	`
@@ -299,11 +472,325 @@ func (r *backtestResultRepo) GetBestByStrategyID(ctx context.Context, strategyID
 	return r.scanResult(r.pool.QueryRow(ctx, query, strategyID))
 }
 
+// GetBestByCampaignID retrieves the best current result of a campaign's jobs based on sharpe ratio.
+func (r *backtestResultRepo) GetBestByCampaignID(ctx context.Context, campaignID uuid.UUID) (*domain.BacktestResult, error) {
+	query := `
+		SELECT
+			br.id, br.job_id, br.strategy_id,
+			br.total_trades, br.winning_trades, br.losing_trades, br.win_rate,
+			br.profit_total, br.profit_pct, br.profit_factor,
+			br.max_drawdown, br.max_drawdown_pct, br.sharpe_ratio, br.sortino_ratio, br.calmar_ratio,
+			br.avg_trade_duration_minutes, br.avg_profit_per_trade, br.best_trade_pct, br.worst_trade_pct,
+			br.pair_results, br.created_at, br.superseded_by,
+			br.stake_currency, br.reference_currency, br.reference_rate, br.profit_total_normalized,
+			br.environment,
+			br.exit_reasons, br.entry_tags, br.stoploss_exit_pct, br.trailing_stop_exit_pct
+		FROM backtest_results br
+		JOIN backtest_jobs bj ON bj.id = br.job_id
+		WHERE bj.campaign_id = $1 AND br.sharpe_ratio IS NOT NULL AND br.superseded_by IS NULL
+		ORDER BY br.sharpe_ratio DESC
+		LIMIT 1
+	`
+
+	return r.scanResult(r.pool.QueryRow(ctx, query, campaignID))
+}
+
+// GetBestComparableByStrategyID retrieves a strategy's best current result based
+// on sharpe ratio among jobs whose config is comparable to cfg.
+func (r *backtestResultRepo) GetBestComparableByStrategyID(ctx context.Context, strategyID uuid.UUID, cfg domain.ComparableConfig) (*domain.BacktestResult, error) {
+	query := `
+		SELECT
+			br.id, br.job_id, br.strategy_id,
+			br.total_trades, br.winning_trades, br.losing_trades, br.win_rate,
+			br.profit_total, br.profit_pct, br.profit_factor,
+			br.max_drawdown, br.max_drawdown_pct, br.sharpe_ratio, br.sortino_ratio, br.calmar_ratio,
+			br.avg_trade_duration_minutes, br.avg_profit_per_trade, br.best_trade_pct, br.worst_trade_pct,
+			br.pair_results, br.created_at, br.superseded_by,
+			br.stake_currency, br.reference_currency, br.reference_rate, br.profit_total_normalized,
+			br.environment,
+			br.exit_reasons, br.entry_tags, br.stoploss_exit_pct, br.trailing_stop_exit_pct
+		FROM backtest_results br
+		JOIN backtest_jobs bj ON bj.id = br.job_id
+		WHERE br.strategy_id = $1 AND br.sharpe_ratio IS NOT NULL AND br.superseded_by IS NULL
+			AND COALESCE(bj.config->>'exchange', '') = $2
+			AND ARRAY(
+				SELECT DISTINCT p FROM jsonb_array_elements_text(COALESCE(NULLIF(bj.config->'pairs', 'null'), '[]')) p ORDER BY p
+			) = $3::text[]
+			AND COALESCE(bj.config->>'timeframe', '') = $4
+			AND replace(COALESCE(bj.config->>'timerange_start', ''), '-', '') = $5
+			AND replace(COALESCE(bj.config->>'timerange_end', ''), '-', '') = $6
+			AND COALESCE(NULLIF(bj.config->>'trading_mode', ''), 'futures') = $7
+			AND COALESCE(bj.config->>'stake_currency', '') = $8
+			AND COALESCE((bj.config->>'max_open_trades')::int, 0) = $9
+			AND COALESCE(bj.config->>'stake_amount', '') = $10
+			AND COALESCE((bj.config->>'dry_run_wallet')::float8, 0) = $11
+		ORDER BY br.sharpe_ratio DESC
+		LIMIT 1
+	`
+
+	pairs := cfg.Pairs
+	if pairs == nil {
+		pairs = []string{}
+	}
+
+	return r.scanResult(r.pool.QueryRow(ctx, query,
+		strategyID,
+		cfg.Exchange,
+		pairs,
+		cfg.Timeframe,
+		cfg.TimerangeStart,
+		cfg.TimerangeEnd,
+		cfg.TradingMode,
+		cfg.StakeCurrency,
+		cfg.MaxOpenTrades,
+		cfg.StakeAmount,
+		cfg.DryRunWallet,
+	))
+}
+
+// GetStatistics aggregates a strategy's current results created within the window.
+// A nil window, or a zero start or end, leaves that side unbounded.
+func (r *backtestResultRepo) GetStatistics(ctx context.Context, strategyID uuid.UUID, window *domain.TimeRange) (*domain.StrategyStatistics, error) {
+	stats, err := r.GetStatisticsBatch(ctx, []uuid.UUID{strategyID}, window)
+	if err != nil {
+		return nil, err
+	}
+	return stats[0], nil
+}
+
+// GetStatisticsBatch aggregates the current results of each strategy created
+// within the window, returning the statistics in the order of strategyIDs.
+// Strategies without results get zero statistics.
+func (r *backtestResultRepo) GetStatisticsBatch(ctx context.Context, strategyIDs []uuid.UUID, window *domain.TimeRange) ([]*domain.StrategyStatistics, error) {
+	var start, end *time.Time
+	if window != nil {
+		if !window.Start.IsZero() {
+			start = &window.Start
+		}
+		if !window.End.IsZero() {
+			end = &window.End
+		}
+	}
+
+	query := `
+		WITH windowed AS (
+			SELECT
+				strategy_id,
+				sharpe_ratio::float8 AS sharpe_ratio,
+				profit_pct::float8 AS profit_pct,
+				max_drawdown_pct::float8 AS max_drawdown_pct,
+				created_at
+			FROM backtest_results
+			WHERE strategy_id = ANY($1)
+				AND superseded_by IS NULL
+				AND ($2::timestamptz IS NULL OR created_at >= $2)
+				AND ($3::timestamptz IS NULL OR created_at < $3)
+		)
+		SELECT
+			strategy_id,
+			COUNT(*), MIN(created_at), MAX(created_at),
+			COUNT(sharpe_ratio),
+			COALESCE(AVG(sharpe_ratio), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY sharpe_ratio), 0),
+			COALESCE(stddev_samp(sharpe_ratio), 0),
+			COALESCE(MIN(sharpe_ratio), 0),
+			COALESCE(MAX(sharpe_ratio), 0),
+			COUNT(profit_pct),
+			COALESCE(AVG(profit_pct), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY profit_pct), 0),
+			COALESCE(stddev_samp(profit_pct), 0),
+			COALESCE(MIN(profit_pct), 0),
+			COALESCE(MAX(profit_pct), 0),
+			COUNT(max_drawdown_pct),
+			COALESCE(AVG(max_drawdown_pct), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY max_drawdown_pct), 0),
+			COALESCE(stddev_samp(max_drawdown_pct), 0),
+			COALESCE(MIN(max_drawdown_pct), 0),
+			COALESCE(MAX(max_drawdown_pct), 0)
+		FROM windowed
+		GROUP BY strategy_id
+	`
+
+	rows, err := r.pool.Query(ctx, query, strategyIDs, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get strategy statistics: %w", err)
+	}
+	defer rows.Close()
+
+	byStrategy := make(map[uuid.UUID]*domain.StrategyStatistics, len(strategyIDs))
+	for rows.Next() {
+		stats := &domain.StrategyStatistics{Window: window}
+		sharpe, profit, drawdown := &stats.SharpeRatio, &stats.ProfitPct, &stats.MaxDrawdownPct
+		if err := rows.Scan(
+			&stats.StrategyID,
+			&stats.ResultCount, &stats.FirstResultAt, &stats.LastResultAt,
+			&sharpe.Count, &sharpe.Mean, &sharpe.Median, &sharpe.StdDev, &sharpe.Min, &sharpe.Max,
+			&profit.Count, &profit.Mean, &profit.Median, &profit.StdDev, &profit.Min, &profit.Max,
+			&drawdown.Count, &drawdown.Mean, &drawdown.Median, &drawdown.StdDev, &drawdown.Min, &drawdown.Max,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan strategy statistics: %w", err)
+		}
+		byStrategy[stats.StrategyID] = stats
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating strategy statistics: %w", err)
+	}
+
+	stats := make([]*domain.StrategyStatistics, len(strategyIDs))
+	for i, id := range strategyIDs {
+		stats[i] = byStrategy[id]
+		if stats[i] == nil {
+			stats[i] = &domain.StrategyStatistics{StrategyID: id, Window: window}
+		}
+	}
+	return stats, nil
+}
+
+// GetCohortMetrics retrieves the metrics of all current results in a cohort,
+// each sorted ascending. Pairs and timeranges are normalized as in
+// GetBestComparableByStrategyID.
+func (r *backtestResultRepo) GetCohortMetrics(ctx context.Context, cohort domain.ResultCohort) (*domain.CohortMetrics, error) {
+	query := `
+		SELECT
+			COALESCE(array_agg(br.sharpe_ratio::float8 ORDER BY br.sharpe_ratio) FILTER (WHERE br.sharpe_ratio IS NOT NULL), '{}'),
+			COALESCE(array_agg(br.profit_pct::float8 ORDER BY br.profit_pct), '{}'),
+			COALESCE(array_agg(br.max_drawdown_pct::float8 ORDER BY br.max_drawdown_pct), '{}')
+		FROM backtest_results br
+		JOIN backtest_jobs bj ON bj.id = br.job_id
+		WHERE br.superseded_by IS NULL
+			AND ARRAY(
+				SELECT DISTINCT p FROM jsonb_array_elements_text(COALESCE(NULLIF(bj.config->'pairs', 'null'), '[]')) p ORDER BY p
+			) = $1::text[]
+			AND replace(COALESCE(bj.config->>'timerange_start', ''), '-', '') = $2
+			AND replace(COALESCE(bj.config->>'timerange_end', ''), '-', '') = $3
+	`
+
+	pairs := cohort.Pairs
+	if pairs == nil {
+		pairs = []string{}
+	}
+
+	metrics := &domain.CohortMetrics{}
+	err := r.pool.QueryRow(ctx, query, pairs, cohort.TimerangeStart, cohort.TimerangeEnd).Scan(
+		&metrics.SharpeRatios, &metrics.ProfitPcts, &metrics.MaxDrawdownPcts,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cohort metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// resultQueryConditions builds the WHERE conditions and arguments for the
+// filters of a result query. Conditions reference backtest_results as br and
+// backtest_jobs as bj.
+func resultQueryConditions(query domain.BacktestResultQuery) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	argNum := 1
+
+	if query.StrategyID != nil {
+		conditions = append(conditions, fmt.Sprintf("br.strategy_id = $%d", argNum))
+		args = append(args, *query.StrategyID)
+		argNum++
+	}
+
+	if query.OptimizationRunID != nil {
+		conditions = append(conditions, fmt.Sprintf("bj.optimization_run_id = $%d", argNum))
+		args = append(args, *query.OptimizationRunID)
+		argNum++
+	}
+
+	if query.MinSharpe != nil {
+		conditions = append(conditions, fmt.Sprintf("br.sharpe_ratio >= $%d", argNum))
+		args = append(args, *query.MinSharpe)
+		argNum++
+	}
+
+	if query.MinProfitPct != nil {
+		conditions = append(conditions, fmt.Sprintf("br.profit_pct >= $%d", argNum))
+		args = append(args, *query.MinProfitPct)
+		argNum++
+	}
+
+	if query.MaxDrawdownPct != nil {
+		conditions = append(conditions, fmt.Sprintf("br.max_drawdown_pct <= $%d", argNum))
+		args = append(args, *query.MaxDrawdownPct)
+		argNum++
+	}
+
+	if query.MinTrades != nil {
+		conditions = append(conditions, fmt.Sprintf("br.total_trades >= $%d", argNum))
+		args = append(args, *query.MinTrades)
+		argNum++
+	}
+
+	if query.MaxStoplossExitPct != nil {
+		conditions = append(conditions, fmt.Sprintf("br.stoploss_exit_pct <= $%d", argNum))
+		args = append(args, *query.MaxStoplossExitPct)
+		argNum++
+	}
+
+	if query.MaxTrailingStopExitPct != nil {
+		conditions = append(conditions, fmt.Sprintf("br.trailing_stop_exit_pct <= $%d", argNum))
+		args = append(args, *query.MaxTrailingStopExitPct)
+		argNum++
+	}
+
+	if query.FreqtradeVersion != nil {
+		conditions = append(conditions, fmt.Sprintf("br.environment->>'freqtrade_version' = $%d", argNum))
+		args = append(args, *query.FreqtradeVersion)
+		argNum++
+	}
+
+	if query.ImageDigest != nil {
+		conditions = append(conditions, fmt.Sprintf("br.environment->>'image_digest' = $%d", argNum))
+		args = append(args, *query.ImageDigest)
+		argNum++
+	}
+
+	if query.Host != nil {
+		conditions = append(conditions, fmt.Sprintf("br.environment->>'host' = $%d", argNum))
+		args = append(args, *query.Host)
+		argNum++
+	}
+
+	if !query.IncludeSuperseded {
+		conditions = append(conditions, "br.superseded_by IS NULL")
+	}
+
+	if query.TimeRange != nil {
+		conditions = append(conditions, fmt.Sprintf("br.created_at >= $%d", argNum))
+		args = append(args, query.TimeRange.Start)
+		argNum++
+		conditions = append(conditions, fmt.Sprintf("br.created_at <= $%d", argNum))
+		args = append(args, query.TimeRange.End)
+		argNum++
+	}
+
+	return conditions, args
+}
+
+// marshalTradeReasons encodes a trade reason breakdown, storing NULL for none.
+func marshalTradeReasons(reasons []domain.TradeReasonStats) ([]byte, error) {
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(reasons)
+}
+
+// unmarshalTradeReasons decodes a trade reason breakdown, if stored.
+func unmarshalTradeReasons(data []byte, reasons *[]domain.TradeReasonStats) error {
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(data, reasons)
+}
+
 // scanResult scans a single row into a BacktestResult.
 func (r *backtestResultRepo) scanResult(row pgx.Row) (*domain.BacktestResult, error) {
 	result := &domain.BacktestResult{}
-	var pairResultsJSON []byte
-	var rawLogEncoded *string
+	var pairResultsJSON, environmentJSON, exitReasonsJSON, entryTagsJSON []byte
 
 	err := row.Scan(
 		&result.ID,
@@ -326,8 +813,17 @@ func (r *backtestResultRepo) scanResult(row pgx.Row) (*domain.BacktestResult, er
 		&result.BestTradePct,
 		&result.WorstTradePct,
 		&pairResultsJSON,
-		&rawLogEncoded,
 		&result.CreatedAt,
+		&result.SupersededBy,
+		&result.StakeCurrency,
+		&result.ReferenceCurrency,
+		&result.ReferenceRate,
+		&result.ProfitTotalNormalized,
+		&environmentJSON,
+		&exitReasonsJSON,
+		&entryTagsJSON,
+		&result.StoplossExitPct,
+		&result.TrailingStopExitPct,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -342,14 +838,19 @@ func (r *backtestResultRepo) scanResult(row pgx.Row) (*domain.BacktestResult, er
 		}
 	}
 
-	// Decode base64-encoded RawLog
-	if rawLogEncoded != nil && *rawLogEncoded != "" {
-		decoded, err := base64.StdEncoding.DecodeString(*rawLogEncoded)
-		if err == nil {
-			result.RawLog = decoded
+	if environmentJSON != nil {
+		if err := json.Unmarshal(environmentJSON, &result.Environment); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal environment: %w", err)
 		}
 	}
 
+	if err := unmarshalTradeReasons(exitReasonsJSON, &result.ExitReasons); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exit_reasons: %w", err)
+	}
+	if err := unmarshalTradeReasons(entryTagsJSON, &result.EntryTags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal entry_tags: %w", err)
+	}
+
 	return result, nil
 }
 
@@ -359,8 +860,7 @@ func (r *backtestResultRepo) scanResults(rows pgx.Rows) ([]*domain.BacktestResul
 
 	for rows.Next() {
 		result := &domain.BacktestResult{}
-		var pairResultsJSON []byte
-		var rawLogEncoded *string
+		var pairResultsJSON, environmentJSON, exitReasonsJSON, entryTagsJSON []byte
 
 		err := rows.Scan(
 			&result.ID,
@@ -383,8 +883,17 @@ func (r *backtestResultRepo) scanResults(rows pgx.Rows) ([]*domain.BacktestResul
 			&result.BestTradePct,
 			&result.WorstTradePct,
 			&pairResultsJSON,
-			&rawLogEncoded,
 			&result.CreatedAt,
+			&result.SupersededBy,
+			&result.StakeCurrency,
+			&result.ReferenceCurrency,
+			&result.ReferenceRate,
+			&result.ProfitTotalNormalized,
+			&environmentJSON,
+			&exitReasonsJSON,
+			&entryTagsJSON,
+			&result.StoplossExitPct,
+			&result.TrailingStopExitPct,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan result row: %w", err)
@@ -396,14 +905,19 @@ func (r *backtestResultRepo) scanResults(rows pgx.Rows) ([]*domain.BacktestResul
 			}
 		}
 
-		// Decode base64-encoded RawLog
-		if rawLogEncoded != nil && *rawLogEncoded != "" {
-			decoded, err := base64.StdEncoding.DecodeString(*rawLogEncoded)
-			if err == nil {
-				result.RawLog = decoded
+		if environmentJSON != nil {
+			if err := json.Unmarshal(environmentJSON, &result.Environment); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal environment: %w", err)
 			}
 		}
 
+		if err := unmarshalTradeReasons(exitReasonsJSON, &result.ExitReasons); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal exit_reasons: %w", err)
+		}
+		if err := unmarshalTradeReasons(entryTagsJSON, &result.EntryTags); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal entry_tags: %w", err)
+		}
+
 		results = append(results, result)
 	}
 
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// campaignRepo implements CampaignRepository using PostgreSQL.
+type campaignRepo struct {
+	pool *db.Pool
+}
+
+// NewCampaignRepository creates a new PostgreSQL campaign repository.
+func NewCampaignRepository(pool *db.Pool) CampaignRepository {
+	return &campaignRepo{pool: pool}
+}
+
+// Create creates a new campaign.
+func (r *campaignRepo) Create(ctx context.Context, campaign *domain.Campaign) error {
+	query := `
+		INSERT INTO campaigns (id, name, description, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		campaign.ID,
+		campaign.Name,
+		campaign.Description,
+		campaign.CreatedBy,
+		campaign.CreatedAt,
+		campaign.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a campaign by ID.
+func (r *campaignRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	query := `
+		SELECT id, name, description, created_by, created_at, updated_at
+		FROM campaigns
+		WHERE id = $1
+	`
+
+	campaign := &domain.Campaign{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&campaign.ID,
+		&campaign.Name,
+		&campaign.Description,
+		&campaign.CreatedBy,
+		&campaign.CreatedAt,
+		&campaign.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("campaign", id.String())
+		}
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	return campaign, nil
+}
+
+// Update updates a campaign's name and description.
+func (r *campaignRepo) Update(ctx context.Context, campaign *domain.Campaign) error {
+	query := `
+		UPDATE campaigns SET name = $2, description = $3
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, campaign.ID, campaign.Name, campaign.Description).Scan(&campaign.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.NewNotFoundError("campaign", campaign.ID.String())
+		}
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a campaign. Its jobs are kept and detached from it.
+func (r *campaignRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, "DELETE FROM campaigns WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete campaign: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("campaign", id.String())
+	}
+
+	return nil
+}
+
+// List lists campaigns, newest first.
+func (r *campaignRepo) List(ctx context.Context, query domain.CampaignListQuery) ([]*domain.Campaign, int, error) {
+	query.SetDefaults()
+
+	var namePattern *string
+	if query.NamePattern != nil && *query.NamePattern != "" {
+		pattern := "%" + *query.NamePattern + "%"
+		namePattern = &pattern
+	}
+
+	var totalCount int
+	countQuery := "SELECT COUNT(*) FROM campaigns WHERE ($1::text IS NULL OR name ILIKE $1)"
+	if err := r.pool.QueryRow(ctx, countQuery, namePattern).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count campaigns: %w", err)
+	}
+
+	selectQuery := `
+		SELECT id, name, description, created_by, created_at, updated_at
+		FROM campaigns
+		WHERE ($1::text IS NULL OR name ILIKE $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, selectQuery, namePattern, query.PageSize, query.Offset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	var campaigns []*domain.Campaign
+	for rows.Next() {
+		campaign := &domain.Campaign{}
+		if err := rows.Scan(
+			&campaign.ID,
+			&campaign.Name,
+			&campaign.Description,
+			&campaign.CreatedBy,
+			&campaign.CreatedAt,
+			&campaign.UpdatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan campaign: %w", err)
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating campaigns: %w", err)
+	}
+
+	return campaigns, totalCount, nil
+}
+
+// GetProgress counts a campaign's jobs by status.
+func (r *campaignRepo) GetProgress(ctx context.Context, id uuid.UUID) (*domain.CampaignProgress, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'running'),
+			COUNT(*) FILTER (WHERE status = 'completed'),
+			COUNT(*) FILTER (WHERE status = 'failed'),
+			COUNT(*) FILTER (WHERE status = 'cancelled')
+		FROM backtest_jobs
+		WHERE campaign_id = $1
+	`
+
+	progress := &domain.CampaignProgress{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&progress.TotalJobs,
+		&progress.PendingJobs,
+		&progress.RunningJobs,
+		&progress.CompletedJobs,
+		&progress.FailedJobs,
+		&progress.CancelledJobs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign progress: %w", err)
+	}
+
+	progress.SetPercentComplete()
+	return progress, nil
+}
+
+// GetResults aggregates the current results of a campaign's jobs. The best
+// result is loaded separately with BacktestResultRepository.GetBestByCampaignID.
+func (r *campaignRepo) GetResults(ctx context.Context, id uuid.UUID) (*domain.CampaignResults, error) {
+	query := `
+		SELECT
+			COUNT(br.id),
+			COALESCE(SUM(br.total_trades), 0),
+			AVG(br.sharpe_ratio)::float8,
+			AVG(br.profit_pct)::float8,
+			AVG(br.max_drawdown_pct)::float8
+		FROM backtest_results br
+		JOIN backtest_jobs bj ON bj.id = br.job_id
+		WHERE bj.campaign_id = $1 AND br.superseded_by IS NULL
+	`
+
+	results := &domain.CampaignResults{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&results.ResultCount,
+		&results.TotalTrades,
+		&results.AvgSharpeRatio,
+		&results.AvgProfitPct,
+		&results.AvgMaxDrawdownPct,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign results: %w", err)
+	}
+
+	return results, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ CampaignRepository = (*campaignRepo)(nil)
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// orphanedRunningJobError is the error message set on jobs that were marked
+// running without a container.
+const orphanedRunningJobError = "consistency repair: job was running without a container"
+
+// consistencyRepo implements ConsistencyRepository using PostgreSQL.
+type consistencyRepo struct {
+	pool *db.Pool
+}
+
+// NewConsistencyRepository creates a new PostgreSQL consistency repository.
+func NewConsistencyRepository(pool *db.Pool) ConsistencyRepository {
+	return &consistencyRepo{pool: pool}
+}
+
+// Check scans for violations of the given invariants without changing anything.
+func (r *consistencyRepo) Check(ctx context.Context, checks []domain.ConsistencyCheck, now time.Time) (*domain.ConsistencyReport, error) {
+	return r.run(ctx, checks, false, false, now)
+}
+
+// Repair scans for violations of the given invariants and repairs them in a
+// single transaction. In dry-run mode the transaction is rolled back, so the
+// report shows exactly what a repair would do.
+func (r *consistencyRepo) Repair(ctx context.Context, checks []domain.ConsistencyCheck, dryRun bool, now time.Time) (*domain.ConsistencyReport, error) {
+	return r.run(ctx, checks, true, dryRun, now)
+}
+
+// run runs the checks in order, repairing each one's violations before the
+// next check so later checks see the repaired state.
+func (r *consistencyRepo) run(
+	ctx context.Context,
+	checks []domain.ConsistencyCheck,
+	repair, dryRun bool,
+	now time.Time,
+) (*domain.ConsistencyReport, error) {
+	if len(checks) == 0 {
+		checks = domain.ConsistencyChecks
+	}
+	report := domain.NewConsistencyReport(checks, repair, dryRun, now)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, check := range checks {
+		var violations []domain.ConsistencyViolation
+		switch check {
+		case domain.ConsistencyCheckIterationMissingJob:
+			violations, err = r.repairIterationsMissingJob(ctx, tx, repair)
+		case domain.ConsistencyCheckResultMissingJob:
+			violations, err = r.repairResultsMissingJob(ctx, tx, repair)
+		case domain.ConsistencyCheckRunIterationMismatch:
+			violations, err = r.repairRunIterationMismatch(ctx, tx, repair)
+		case domain.ConsistencyCheckRunningJobWithoutContainer:
+			violations, err = r.repairRunningJobsWithoutContainer(ctx, tx, repair, now)
+		default:
+			return nil, fmt.Errorf("unknown consistency check: %s", check)
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Add(violations...)
+	}
+
+	if !repair || dryRun {
+		return report, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for i := range report.Violations {
+		report.Violations[i].Repaired = true
+	}
+	return report, nil
+}
+
+// repairIterationsMissingJob finds iterations whose backtest job no longer
+// exists and, if repair is set, deletes them.
+func (r *consistencyRepo) repairIterationsMissingJob(ctx context.Context, tx pgx.Tx, repair bool) ([]domain.ConsistencyViolation, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT i.id, i.optimization_run_id, i.iteration_number, i.backtest_job_id
+		FROM optimization_iterations i
+		LEFT JOIN backtest_jobs bj ON bj.id = i.backtest_job_id
+		WHERE bj.id IS NULL
+		ORDER BY i.optimization_run_id, i.iteration_number
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find iterations missing jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []domain.ConsistencyViolation
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id, runID, jobID uuid.UUID
+		var iterationNumber int
+		if err := rows.Scan(&id, &runID, &iterationNumber, &jobID); err != nil {
+			return nil, fmt.Errorf("failed to scan iteration: %w", err)
+		}
+		ids = append(ids, id)
+		violations = append(violations, domain.ConsistencyViolation{
+			Check:      domain.ConsistencyCheckIterationMissingJob,
+			EntityType: "optimization_iteration",
+			EntityID:   id,
+			Detail:     fmt.Sprintf("iteration %d of run %s points at missing job %s", iterationNumber, runID, jobID),
+			Repair:     "delete iteration",
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating iterations: %w", err)
+	}
+
+	if repair && len(ids) > 0 {
+		if _, err := tx.Exec(ctx, "DELETE FROM optimization_iterations WHERE id = ANY($1)", ids); err != nil {
+			return nil, fmt.Errorf("failed to delete iterations: %w", err)
+		}
+	}
+
+	return violations, nil
+}
+
+// repairResultsMissingJob finds results whose job no longer exists and, if
+// repair is set, clears the runs and iterations pointing at them and deletes them.
+func (r *consistencyRepo) repairResultsMissingJob(ctx context.Context, tx pgx.Tx, repair bool) ([]domain.ConsistencyViolation, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT br.id, br.job_id, br.strategy_id
+		FROM backtest_results br
+		LEFT JOIN backtest_jobs bj ON bj.id = br.job_id
+		WHERE bj.id IS NULL
+		ORDER BY br.created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find results missing jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []domain.ConsistencyViolation
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id, jobID, strategyID uuid.UUID
+		if err := rows.Scan(&id, &jobID, &strategyID); err != nil {
+			return nil, fmt.Errorf("failed to scan result: %w", err)
+		}
+		ids = append(ids, id)
+		violations = append(violations, domain.ConsistencyViolation{
+			Check:      domain.ConsistencyCheckResultMissingJob,
+			EntityType: "backtest_result",
+			EntityID:   id,
+			Detail:     fmt.Sprintf("result for strategy %s points at missing job %s", strategyID, jobID),
+			Repair:     "detach from optimization runs and iterations, then delete result",
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating results: %w", err)
+	}
+
+	if repair && len(ids) > 0 {
+		if _, err := tx.Exec(ctx, "UPDATE optimization_runs SET best_result_id = NULL WHERE best_result_id = ANY($1)", ids); err != nil {
+			return nil, fmt.Errorf("failed to detach results from optimization runs: %w", err)
+		}
+		if _, err := tx.Exec(ctx, "UPDATE optimization_iterations SET result_id = NULL WHERE result_id = ANY($1)", ids); err != nil {
+			return nil, fmt.Errorf("failed to detach results from iterations: %w", err)
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM backtest_results WHERE id = ANY($1)", ids); err != nil {
+			return nil, fmt.Errorf("failed to delete results: %w", err)
+		}
+	}
+
+	return violations, nil
+}
+
+// repairRunIterationMismatch finds runs whose current_iteration differs from
+// their latest iteration number and, if repair is set, corrects it.
+func (r *consistencyRepo) repairRunIterationMismatch(ctx context.Context, tx pgx.Tx, repair bool) ([]domain.ConsistencyViolation, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT r.id, r.current_iteration, COALESCE(MAX(i.iteration_number), 0), COUNT(i.id)
+		FROM optimization_runs r
+		LEFT JOIN optimization_iterations i ON i.optimization_run_id = r.id
+		GROUP BY r.id, r.current_iteration
+		HAVING r.current_iteration <> COALESCE(MAX(i.iteration_number), 0)
+		ORDER BY r.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runs with mismatched iterations: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []domain.ConsistencyViolation
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		var current, latest, count int
+		if err := rows.Scan(&id, &current, &latest, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan optimization run: %w", err)
+		}
+		ids = append(ids, id)
+		violations = append(violations, domain.ConsistencyViolation{
+			Check:      domain.ConsistencyCheckRunIterationMismatch,
+			EntityType: "optimization_run",
+			EntityID:   id,
+			Detail:     fmt.Sprintf("current_iteration is %d but the run has %d iterations, latest %d", current, count, latest),
+			Repair:     fmt.Sprintf("set current_iteration to %d", latest),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating optimization runs: %w", err)
+	}
+
+	if repair && len(ids) > 0 {
+		_, err := tx.Exec(ctx, `
+			UPDATE optimization_runs r SET current_iteration = COALESCE((
+				SELECT MAX(i.iteration_number) FROM optimization_iterations i WHERE i.optimization_run_id = r.id
+			), 0)
+			WHERE r.id = ANY($1)
+		`, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update current iterations: %w", err)
+		}
+	}
+
+	return violations, nil
+}
+
+// repairRunningJobsWithoutContainer finds jobs marked running without a
+// container and, if repair is set, marks them failed and records the failure
+// on their timeline.
+func (r *consistencyRepo) repairRunningJobsWithoutContainer(ctx context.Context, tx pgx.Tx, repair bool, now time.Time) ([]domain.ConsistencyViolation, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, started_at
+		FROM backtest_jobs
+		WHERE status = 'running' AND (container_id IS NULL OR container_id = '')
+		ORDER BY started_at NULLS FIRST, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find running jobs without containers: %w", err)
+	}
+	defer rows.Close()
+
+	var violations []domain.ConsistencyViolation
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		var startedAt *time.Time
+		if err := rows.Scan(&id, &startedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan backtest job: %w", err)
+		}
+		detail := "job is running without a container"
+		if startedAt != nil {
+			detail = fmt.Sprintf("job has been running without a container since %s", startedAt.UTC().Format(time.RFC3339))
+		}
+		ids = append(ids, id)
+		violations = append(violations, domain.ConsistencyViolation{
+			Check:      domain.ConsistencyCheckRunningJobWithoutContainer,
+			EntityType: "backtest_job",
+			EntityID:   id,
+			Detail:     detail,
+			Repair:     "mark job failed",
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating backtest jobs: %w", err)
+	}
+
+	if repair && len(ids) > 0 {
+		_, err := tx.Exec(ctx, `
+			UPDATE backtest_jobs SET status = 'failed', error_message = $2, failure_category = $4, completed_at = $3
+			WHERE id = ANY($1) AND status = 'running'
+		`, ids, orphanedRunningJobError, now, domain.FailureCategoryDocker.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark jobs failed: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO job_events (job_id, event_type, status, detail, occurred_at)
+			SELECT id, $2, $3, $4, $5 FROM UNNEST($1::uuid[]) AS id
+		`, ids, string(domain.JobEventFailed), domain.JobStatusFailed.String(), orphanedRunningJobError, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add job events: %w", err)
+		}
+	}
+
+	return violations, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ ConsistencyRepository = (*consistencyRepo)(nil)
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// deadLetterRepo implements DeadLetterRepository using PostgreSQL.
+type deadLetterRepo struct {
+	pool *db.Pool
+}
+
+// NewDeadLetterRepository creates a new PostgreSQL dead letter repository.
+func NewDeadLetterRepository(pool *db.Pool) DeadLetterRepository {
+	return &deadLetterRepo{pool: pool}
+}
+
+// deadLetterColumns are the columns scanned by scanDeadLetter.
+const deadLetterColumns = `
+	id, routing_key, exchange, queue, error, body, failed_at, created_at,
+	requeued_at, requeue_count
+`
+
+// Create stores a dead letter. A dead letter already stored under the same
+// ID is left as it is, so a message collected twice is kept once.
+func (r *deadLetterRepo) Create(ctx context.Context, letter *domain.DeadLetter) error {
+	query := `
+		INSERT INTO dead_letters (id, routing_key, exchange, queue, error, body, failed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		letter.ID,
+		letter.RoutingKey,
+		letter.Exchange,
+		letter.Queue,
+		letter.Error,
+		letter.Body,
+		letter.FailedAt,
+		letter.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a dead letter by ID.
+func (r *deadLetterRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error) {
+	query := `SELECT ` + deadLetterColumns + ` FROM dead_letters WHERE id = $1`
+
+	letter, err := scanDeadLetter(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("dead letter", id.String())
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+
+	return letter, nil
+}
+
+// List retrieves dead letters matching the query, newest first.
+func (r *deadLetterRepo) List(ctx context.Context, query domain.DeadLetterQuery) ([]*domain.DeadLetter, error) {
+	var conditions []string
+	var args []interface{}
+	if query.RoutingKey != "" {
+		args = append(args, query.RoutingKey)
+		conditions = append(conditions, fmt.Sprintf("routing_key = $%d", len(args)))
+	}
+	if !query.IncludeRequeued {
+		conditions = append(conditions, "requeued_at IS NULL")
+	}
+
+	sql := `SELECT ` + deadLetterColumns + ` FROM dead_letters`
+	if len(conditions) > 0 {
+		sql += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	args = append(args, query.Limit)
+	sql += fmt.Sprintf(` ORDER BY created_at DESC LIMIT $%d`, len(args))
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var letters []*domain.DeadLetter
+	for rows.Next() {
+		letter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		letters = append(letters, letter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dead letters: %w", err)
+	}
+
+	return letters, nil
+}
+
+// MarkRequeued records that a dead letter was published again and returns it.
+func (r *deadLetterRepo) MarkRequeued(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error) {
+	query := `
+		UPDATE dead_letters SET requeued_at = NOW(), requeue_count = requeue_count + 1
+		WHERE id = $1
+		RETURNING ` + deadLetterColumns
+
+	letter, err := scanDeadLetter(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("dead letter", id.String())
+		}
+		return nil, fmt.Errorf("failed to mark dead letter requeued: %w", err)
+	}
+
+	return letter, nil
+}
+
+// scanDeadLetter scans a dead letter row selected with deadLetterColumns.
+func scanDeadLetter(row pgx.Row) (*domain.DeadLetter, error) {
+	letter := &domain.DeadLetter{}
+	err := row.Scan(
+		&letter.ID,
+		&letter.RoutingKey,
+		&letter.Exchange,
+		&letter.Queue,
+		&letter.Error,
+		&letter.Body,
+		&letter.FailedAt,
+		&letter.CreatedAt,
+		&letter.RequeuedAt,
+		&letter.RequeueCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return letter, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ DeadLetterRepository = (*deadLetterRepo)(nil)
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// exportRepo implements ExportRepository using PostgreSQL.
+type exportRepo struct {
+	pool *db.Pool
+}
+
+// NewExportRepository creates a new PostgreSQL export job repository.
+func NewExportRepository(pool *db.Pool) ExportRepository {
+	return &exportRepo{pool: pool}
+}
+
+// exportColumns are the columns scanned by scanExport.
+const exportColumns = `
+	id, owner, status, format, filter, artifact_id,
+	strategy_count, result_count, iteration_count, size_bytes,
+	error, created_at, started_at, completed_at
+`
+
+// Create creates a new export job.
+func (r *exportRepo) Create(ctx context.Context, job *domain.ExportJob) error {
+	filterJSON, err := json.Marshal(job.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal export filter: %w", err)
+	}
+
+	query := `
+		INSERT INTO export_jobs (id, owner, status, format, filter, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = r.pool.Exec(ctx, query,
+		job.ID,
+		job.Owner,
+		job.Status.String(),
+		job.Format.String(),
+		filterJSON,
+		job.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create export job: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an export job by ID.
+func (r *exportRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.ExportJob, error) {
+	query := `SELECT ` + exportColumns + ` FROM export_jobs WHERE id = $1`
+
+	job, err := r.scanExport(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("export", id.String())
+		}
+		return nil, fmt.Errorf("failed to get export job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ClaimNext marks the oldest pending export job as running and returns it,
+// or nil if none is pending. Uses FOR UPDATE SKIP LOCKED so concurrent
+// exporters never claim the same job.
+func (r *exportRepo) ClaimNext(ctx context.Context) (*domain.ExportJob, error) {
+	query := `
+		UPDATE export_jobs SET status = 'running', started_at = NOW()
+		WHERE id = (
+			SELECT id FROM export_jobs
+			WHERE status = 'pending'
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + exportColumns
+
+	job, err := r.scanExport(r.pool.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim export job: %w", err)
+	}
+
+	return job, nil
+}
+
+// Complete stores the archive of a running export job and marks the job
+// completed with its counts, in one transaction.
+func (r *exportRepo) Complete(ctx context.Context, job *domain.ExportJob, archive *domain.Artifact) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertArtifact(ctx, tx, archive); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE export_jobs SET
+			status = 'completed',
+			artifact_id = $2,
+			strategy_count = $3,
+			result_count = $4,
+			iteration_count = $5,
+			size_bytes = $6,
+			completed_at = NOW()
+		WHERE id = $1 AND status = 'running'
+		RETURNING completed_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		job.ID,
+		archive.ID,
+		job.StrategyCount,
+		job.ResultCount,
+		job.IterationCount,
+		archive.SizeBytes,
+	).Scan(&job.CompletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.NewNotFoundError("export", job.ID.String())
+		}
+		return fmt.Errorf("failed to complete export job: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	job.Status = domain.ExportStatusCompleted
+	job.ArtifactID = &archive.ID
+	job.SizeBytes = archive.SizeBytes
+	return nil
+}
+
+// Fail marks an export job as failed with an error message.
+func (r *exportRepo) Fail(ctx context.Context, id uuid.UUID, errMsg string) error {
+	query := `
+		UPDATE export_jobs SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to mark export job failed: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("export", id.String())
+	}
+
+	return nil
+}
+
+// RequeueRunning returns running export jobs to pending, for jobs left
+// behind by an exporter that stopped mid-export. It returns how many were
+// requeued.
+func (r *exportRepo) RequeueRunning(ctx context.Context) (int, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE export_jobs SET status = 'pending', started_at = NULL
+		WHERE status = 'running'
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue export jobs: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// scanExport scans an export job row selected with exportColumns.
+func (r *exportRepo) scanExport(row pgx.Row) (*domain.ExportJob, error) {
+	job := &domain.ExportJob{}
+	var status, format string
+	var filterJSON []byte
+
+	err := row.Scan(
+		&job.ID,
+		&job.Owner,
+		&status,
+		&format,
+		&filterJSON,
+		&job.ArtifactID,
+		&job.StrategyCount,
+		&job.ResultCount,
+		&job.IterationCount,
+		&job.SizeBytes,
+		&job.Error,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Status = domain.ExportStatus(status)
+	job.Format = domain.ExportFormat(format)
+	if err := json.Unmarshal(filterJSON, &job.Filter); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal export filter: %w", err)
+	}
+
+	return job, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ ExportRepository = (*exportRepo)(nil)
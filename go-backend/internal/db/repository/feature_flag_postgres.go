@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// featureFlagRepo implements FeatureFlagRepository using PostgreSQL.
+type featureFlagRepo struct {
+	pool *db.Pool
+}
+
+// NewFeatureFlagRepository creates a new PostgreSQL feature flag repository.
+func NewFeatureFlagRepository(pool *db.Pool) FeatureFlagRepository {
+	return &featureFlagRepo{pool: pool}
+}
+
+// List retrieves all feature flag overrides ordered by name.
+func (r *featureFlagRepo) List(ctx context.Context) ([]*domain.FeatureFlagOverride, error) {
+	query := `
+		SELECT name, enabled, updated_by, updated_at
+		FROM feature_flags
+		ORDER BY name ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	var overrides []*domain.FeatureFlagOverride
+	for rows.Next() {
+		var o domain.FeatureFlagOverride
+		if err := rows.Scan(&o.Name, &o.Enabled, &o.UpdatedBy, &o.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+		overrides = append(overrides, &o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feature flags: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// Upsert creates or replaces a feature flag override.
+func (r *featureFlagRepo) Upsert(ctx context.Context, override *domain.FeatureFlagOverride) error {
+	query := `
+		INSERT INTO feature_flags (name, enabled, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (name) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		override.Name,
+		override.Enabled,
+		override.UpdatedBy,
+	).Scan(&override.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert feature flag: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a feature flag override.
+func (r *featureFlagRepo) Delete(ctx context.Context, name string) error {
+	result, err := r.pool.Exec(ctx, "DELETE FROM feature_flags WHERE name = $1", name)
+	if err != nil {
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("feature_flag", name)
+	}
+
+	return nil
+}
+
+// Ensure interface implementation at compile time.
+var _ FeatureFlagRepository = (*featureFlagRepo)(nil)
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// hyperoptRepo implements HyperoptRepository using PostgreSQL.
+type hyperoptRepo struct {
+	pool *db.Pool
+}
+
+// NewHyperoptRepository creates a new PostgreSQL hyperopt job repository.
+func NewHyperoptRepository(pool *db.Pool) HyperoptRepository {
+	return &hyperoptRepo{pool: pool}
+}
+
+// hyperoptColumns are the columns scanned by scanHyperopt.
+const hyperoptColumns = `
+	id, strategy_id, owner, config, status, container_id, result,
+	error, created_at, started_at, completed_at
+`
+
+// Create creates a new hyperopt job.
+func (r *hyperoptRepo) Create(ctx context.Context, job *domain.HyperoptJob) error {
+	configJSON, err := json.Marshal(job.Config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hyperopt config: %w", err)
+	}
+
+	query := `
+		INSERT INTO hyperopt_jobs (id, strategy_id, owner, config, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = r.pool.Exec(ctx, query,
+		job.ID,
+		job.StrategyID,
+		job.Owner,
+		configJSON,
+		job.Status.String(),
+		job.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create hyperopt job: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a hyperopt job by ID.
+func (r *hyperoptRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.HyperoptJob, error) {
+	query := `SELECT ` + hyperoptColumns + ` FROM hyperopt_jobs WHERE id = $1`
+
+	job, err := scanHyperopt(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("hyperopt job", id.String())
+		}
+		return nil, fmt.Errorf("failed to get hyperopt job: %w", err)
+	}
+
+	return job, nil
+}
+
+// List retrieves hyperopt jobs matching the query, newest first.
+func (r *hyperoptRepo) List(ctx context.Context, query domain.HyperoptJobQuery) ([]*domain.HyperoptJob, error) {
+	var conditions []string
+	var args []interface{}
+	if query.StrategyID != nil {
+		args = append(args, *query.StrategyID)
+		conditions = append(conditions, fmt.Sprintf("strategy_id = $%d", len(args)))
+	}
+	if query.Status != nil {
+		args = append(args, query.Status.String())
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	sql := `SELECT ` + hyperoptColumns + ` FROM hyperopt_jobs`
+	if len(conditions) > 0 {
+		sql += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	args = append(args, query.Limit)
+	sql += fmt.Sprintf(` ORDER BY created_at DESC LIMIT $%d`, len(args))
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hyperopt jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*domain.HyperoptJob
+	for rows.Next() {
+		job, err := scanHyperopt(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan hyperopt job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating hyperopt jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// GetBest retrieves the completed hyperopt job of a strategy whose best epoch
+// has the lowest loss.
+func (r *hyperoptRepo) GetBest(ctx context.Context, strategyID uuid.UUID) (*domain.HyperoptJob, error) {
+	query := `
+		SELECT ` + hyperoptColumns + ` FROM hyperopt_jobs
+		WHERE strategy_id = $1 AND status = 'completed'
+		ORDER BY best_loss, completed_at DESC
+		LIMIT 1
+	`
+
+	job, err := scanHyperopt(r.pool.QueryRow(ctx, query, strategyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("hyperopt result", strategyID.String())
+		}
+		return nil, fmt.Errorf("failed to get best hyperopt job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ClaimNext marks the oldest pending hyperopt job as running and returns it,
+// or nil if none is pending. Uses FOR UPDATE SKIP LOCKED so concurrent
+// runners never claim the same job.
+func (r *hyperoptRepo) ClaimNext(ctx context.Context) (*domain.HyperoptJob, error) {
+	query := `
+		UPDATE hyperopt_jobs SET status = 'running', started_at = NOW()
+		WHERE id = (
+			SELECT id FROM hyperopt_jobs
+			WHERE status = 'pending'
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + hyperoptColumns
+
+	job, err := scanHyperopt(r.pool.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim hyperopt job: %w", err)
+	}
+
+	return job, nil
+}
+
+// SetContainer records the container a running hyperopt job runs in.
+func (r *hyperoptRepo) SetContainer(ctx context.Context, id uuid.UUID, containerID string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE hyperopt_jobs SET container_id = $2 WHERE id = $1 AND status = 'running'
+	`, id, containerID)
+	if err != nil {
+		return fmt.Errorf("failed to set hyperopt container: %w", err)
+	}
+	return nil
+}
+
+// Complete stores the best epoch of a running hyperopt job and marks it
+// completed.
+func (r *hyperoptRepo) Complete(ctx context.Context, id uuid.UUID, result *domain.HyperoptResult) error {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hyperopt result: %w", err)
+	}
+
+	query := `
+		UPDATE hyperopt_jobs SET status = 'completed', result = $2, best_loss = $3, completed_at = NOW()
+		WHERE id = $1 AND status = 'running'
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id, resultJSON, result.Loss)
+	if err != nil {
+		return fmt.Errorf("failed to complete hyperopt job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: hyperopt job %s is not running", domain.ErrConflict, id)
+	}
+
+	return nil
+}
+
+// Fail marks a running hyperopt job as failed with an error message.
+func (r *hyperoptRepo) Fail(ctx context.Context, id uuid.UUID, errMsg string) error {
+	query := `
+		UPDATE hyperopt_jobs SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1 AND status = 'running'
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to mark hyperopt job failed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: hyperopt job %s is not running", domain.ErrConflict, id)
+	}
+
+	return nil
+}
+
+// Cancel cancels a pending or running hyperopt job and returns it as it was
+// before, so callers can tell whether its container needs stopping.
+func (r *hyperoptRepo) Cancel(ctx context.Context, id uuid.UUID) (*domain.HyperoptJob, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `SELECT ` + hyperoptColumns + ` FROM hyperopt_jobs WHERE id = $1 FOR UPDATE`
+	job, err := scanHyperopt(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("hyperopt job", id.String())
+		}
+		return nil, fmt.Errorf("failed to get hyperopt job: %w", err)
+	}
+	if job.Status.IsTerminal() {
+		return nil, fmt.Errorf("%w: hyperopt job is already %s", domain.ErrConflict, job.Status)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE hyperopt_jobs SET status = 'cancelled', completed_at = NOW() WHERE id = $1
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel hyperopt job: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return job, nil
+}
+
+// RequeueRunning returns running hyperopt jobs to pending, for jobs left
+// behind by a runner that stopped mid-run. It returns how many were
+// requeued.
+func (r *hyperoptRepo) RequeueRunning(ctx context.Context) (int, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE hyperopt_jobs SET status = 'pending', started_at = NULL, container_id = NULL
+		WHERE status = 'running'
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue hyperopt jobs: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// scanHyperopt scans a hyperopt job row selected with hyperoptColumns.
+func scanHyperopt(row pgx.Row) (*domain.HyperoptJob, error) {
+	job := &domain.HyperoptJob{}
+	var status string
+	var configJSON, resultJSON []byte
+
+	err := row.Scan(
+		&job.ID,
+		&job.StrategyID,
+		&job.Owner,
+		&configJSON,
+		&status,
+		&job.ContainerID,
+		&resultJSON,
+		&job.Error,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Status = domain.JobStatusFromString(status)
+	if err := json.Unmarshal(configJSON, &job.Config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal hyperopt config: %w", err)
+	}
+	if resultJSON != nil {
+		job.Result = &domain.HyperoptResult{}
+		if err := json.Unmarshal(resultJSON, job.Result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal hyperopt result: %w", err)
+		}
+	}
+
+	return job, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ HyperoptRepository = (*hyperoptRepo)(nil)
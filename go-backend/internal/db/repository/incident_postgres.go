@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// incidentRepo implements IncidentRepository using PostgreSQL.
+type incidentRepo struct {
+	pool *db.Pool
+}
+
+// NewIncidentRepository creates a new PostgreSQL incident repository.
+func NewIncidentRepository(pool *db.Pool) IncidentRepository {
+	return &incidentRepo{pool: pool}
+}
+
+// incidentColumns are the columns scanned by scanIncident.
+const incidentColumns = `
+	id, title, severity, status, source, opened_by, closed_by,
+	created_at, updated_at, closed_at
+`
+
+// Create creates a new incident along with its notes.
+func (r *incidentRepo) Create(ctx context.Context, incident *domain.Incident) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO incidents (id, title, severity, status, source, opened_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		incident.ID,
+		incident.Title,
+		string(incident.Severity),
+		string(incident.Status),
+		incident.Source,
+		incident.OpenedBy,
+		incident.CreatedAt,
+		incident.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	for i := range incident.Notes {
+		if err := insertIncidentNote(ctx, tx, &incident.Notes[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an incident and its notes by ID.
+func (r *incidentRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Incident, error) {
+	query := `SELECT ` + incidentColumns + ` FROM incidents WHERE id = $1`
+
+	incident, err := scanIncident(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("incident", id.String())
+		}
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	if err := r.loadNotes(ctx, []*domain.Incident{incident}); err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+// List retrieves incidents matching the query with their notes, newest first.
+func (r *incidentRepo) List(ctx context.Context, query domain.IncidentListQuery) ([]*domain.Incident, error) {
+	var conditions []string
+	var args []interface{}
+	if query.Status != nil {
+		args = append(args, string(*query.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if query.Source != "" {
+		args = append(args, query.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+
+	sql := `SELECT ` + incidentColumns + ` FROM incidents`
+	if len(conditions) > 0 {
+		sql += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	args = append(args, query.Limit)
+	sql += fmt.Sprintf(` ORDER BY created_at DESC LIMIT $%d`, len(args))
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*domain.Incident
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incidents: %w", err)
+	}
+
+	if err := r.loadNotes(ctx, incidents); err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+// Update changes the title or severity of an incident.
+func (r *incidentRepo) Update(ctx context.Context, id uuid.UUID, update domain.IncidentUpdate) (*domain.Incident, error) {
+	var severity *string
+	if update.Severity != nil {
+		s := string(*update.Severity)
+		severity = &s
+	}
+
+	query := `
+		UPDATE incidents SET
+			title = COALESCE($2, title),
+			severity = COALESCE($3, severity),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + incidentColumns
+
+	incident, err := scanIncident(r.pool.QueryRow(ctx, query, id, update.Title, severity))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("incident", id.String())
+		}
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	if err := r.loadNotes(ctx, []*domain.Incident{incident}); err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+// AddNote adds a note to an incident.
+func (r *incidentRepo) AddNote(ctx context.Context, note *domain.IncidentNote) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `UPDATE incidents SET updated_at = NOW() WHERE id = $1`, note.IncidentID)
+	if err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("incident", note.IncidentID.String())
+	}
+
+	if err := insertIncidentNote(ctx, tx, note); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes an open incident, adding the optional closing note.
+func (r *incidentRepo) Close(ctx context.Context, id uuid.UUID, by string, note *domain.IncidentNote) (*domain.Incident, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE incidents SET status = 'closed', closed_by = $2, closed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'open'
+		RETURNING ` + incidentColumns
+
+	incident, err := scanIncident(tx.QueryRow(ctx, query, id, by))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to close incident: %w", err)
+		}
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM incidents WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to check incident: %w", err)
+		}
+		if !exists {
+			return nil, domain.NewNotFoundError("incident", id.String())
+		}
+		return nil, fmt.Errorf("%w: incident is already closed", domain.ErrConflict)
+	}
+
+	if note != nil {
+		if err := insertIncidentNote(ctx, tx, note); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if err := r.loadNotes(ctx, []*domain.Incident{incident}); err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+// CloseBySource closes the open incidents of a source, adding a closing note
+// to each, and returns how many were closed.
+func (r *incidentRepo) CloseBySource(ctx context.Context, source, by, note string) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE incidents SET status = 'closed', closed_by = $2, closed_at = NOW(), updated_at = NOW()
+		WHERE source = $1 AND status = 'open'
+		RETURNING id
+	`, source, by)
+	if err != nil {
+		return 0, fmt.Errorf("failed to close incidents: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return 0, fmt.Errorf("error iterating closed incidents: %w", err)
+	}
+
+	for _, id := range ids {
+		if err := insertIncidentNote(ctx, tx, domain.NewIncidentNote(id, by, note)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(ids), nil
+}
+
+// loadNotes fills in the notes of incidents, oldest first.
+func (r *incidentRepo) loadNotes(ctx context.Context, incidents []*domain.Incident) error {
+	if len(incidents) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*domain.Incident, len(incidents))
+	ids := make([]uuid.UUID, len(incidents))
+	for i, incident := range incidents {
+		incident.Notes = []domain.IncidentNote{}
+		byID[incident.ID] = incident
+		ids[i] = incident.ID
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, incident_id, author, body, created_at
+		FROM incident_notes
+		WHERE incident_id = ANY($1)
+		ORDER BY created_at, id
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get incident notes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var note domain.IncidentNote
+		if err := rows.Scan(&note.ID, &note.IncidentID, &note.Author, &note.Body, &note.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan incident note: %w", err)
+		}
+		incident := byID[note.IncidentID]
+		incident.Notes = append(incident.Notes, note)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating incident notes: %w", err)
+	}
+
+	return nil
+}
+
+// insertIncidentNote inserts a note on an incident.
+func insertIncidentNote(ctx context.Context, db execer, note *domain.IncidentNote) error {
+	_, err := db.Exec(ctx, `
+		INSERT INTO incident_notes (id, incident_id, author, body, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, note.ID, note.IncidentID, note.Author, note.Body, note.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add incident note: %w", err)
+	}
+	return nil
+}
+
+// scanIncident scans a row of incidentColumns.
+func scanIncident(row pgx.Row) (*domain.Incident, error) {
+	var incident domain.Incident
+	var severity, status string
+	err := row.Scan(
+		&incident.ID,
+		&incident.Title,
+		&severity,
+		&status,
+		&incident.Source,
+		&incident.OpenedBy,
+		&incident.ClosedBy,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+		&incident.ClosedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	incident.Severity = domain.IncidentSeverity(severity)
+	incident.Status = domain.IncidentStatus(status)
+	return &incident, nil
+}
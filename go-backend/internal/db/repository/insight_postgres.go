@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// insightRepo implements InsightRepository using PostgreSQL.
+type insightRepo struct {
+	pool *db.Pool
+}
+
+// NewInsightRepository creates a new PostgreSQL insight repository.
+func NewInsightRepository(pool *db.Pool) InsightRepository {
+	return &insightRepo{pool: pool}
+}
+
+const insightTemplateColumns = `name, description, sql_text, params, max_rows, timeout_ms, approved_by`
+
+// CreateTemplate stores an approved insight template.
+func (r *insightRepo) CreateTemplate(ctx context.Context, tmpl *domain.InsightTemplate) error {
+	params, err := json.Marshal(tmpl.Params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal insight params: %w", err)
+	}
+
+	query := `
+		INSERT INTO insight_templates (` + insightTemplateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = r.pool.Exec(ctx, query,
+		tmpl.Name, tmpl.Description, tmpl.SQL, params, tmpl.MaxRows, tmpl.TimeoutMs, tmpl.ApprovedBy,
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.NewDuplicateError("insight", "name", tmpl.Name)
+		}
+		return fmt.Errorf("failed to create insight template: %w", err)
+	}
+
+	return nil
+}
+
+// GetTemplate retrieves an enabled insight template by name.
+func (r *insightRepo) GetTemplate(ctx context.Context, name string) (*domain.InsightTemplate, error) {
+	query := `SELECT ` + insightTemplateColumns + ` FROM insight_templates WHERE name = $1 AND enabled`
+
+	tmpl, err := scanInsightTemplate(r.pool.QueryRow(ctx, query, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("insight", name)
+		}
+		return nil, fmt.Errorf("failed to get insight template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// ListTemplates retrieves the enabled insight templates ordered by name.
+func (r *insightRepo) ListTemplates(ctx context.Context) ([]*domain.InsightTemplate, error) {
+	query := `SELECT ` + insightTemplateColumns + ` FROM insight_templates WHERE enabled ORDER BY name`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list insight templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*domain.InsightTemplate
+	for rows.Next() {
+		tmpl, err := scanInsightTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan insight template: %w", err)
+		}
+		templates = append(templates, tmpl)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating insight templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// scanInsightTemplate scans a row of insightTemplateColumns.
+func scanInsightTemplate(row pgx.Row) (*domain.InsightTemplate, error) {
+	tmpl := &domain.InsightTemplate{Source: domain.InsightSourceDatabase}
+	var params []byte
+	if err := row.Scan(
+		&tmpl.Name, &tmpl.Description, &tmpl.SQL, &params, &tmpl.MaxRows, &tmpl.TimeoutMs, &tmpl.ApprovedBy,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(params, &tmpl.Params); err != nil {
+		return nil, fmt.Errorf("invalid params for insight %s: %w", tmpl.Name, err)
+	}
+	return tmpl, nil
+}
+
+// Execute runs an insight query in a read-only transaction under a statement
+// timeout, reading at most maxRows rows. Truncated reports whether more rows
+// matched. A query that exceeds the timeout fails with ErrInsightTimeout.
+func (r *insightRepo) Execute(ctx context.Context, sql string, args []interface{}, maxRows int, timeout time.Duration) (*domain.InsightResult, error) {
+	tx, err := r.pool.Pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin read-only transaction: %w", err)
+	}
+	// Nothing is written, so the transaction is always rolled back
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT set_config('statement_timeout', $1, true)", fmt.Sprintf("%dms", timeout.Milliseconds())); err != nil {
+		return nil, fmt.Errorf("failed to set statement timeout: %w", err)
+	}
+
+	started := time.Now()
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, insightQueryError(err)
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	result := &domain.InsightResult{
+		Columns:    make([]string, len(fields)),
+		Rows:       [][]interface{}{},
+		MaxRows:    maxRows,
+		ExecutedAt: started,
+	}
+	for i, f := range fields {
+		result.Columns[i] = f.Name
+	}
+
+	for rows.Next() {
+		if len(result.Rows) == maxRows {
+			result.Truncated = true
+			break
+		}
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read insight row: %w", err)
+		}
+		for i, v := range values {
+			values[i] = insightValue(v)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, insightQueryError(err)
+	}
+
+	result.RowCount = len(result.Rows)
+	result.DurationMs = time.Since(started).Milliseconds()
+	return result, nil
+}
+
+// insightQueryError maps a statement timeout to ErrInsightTimeout.
+func insightQueryError(err error) error {
+	if strings.Contains(err.Error(), "statement timeout") {
+		return domain.ErrInsightTimeout
+	}
+	return fmt.Errorf("failed to run insight query: %w", err)
+}
+
+// insightValue converts values pgx decodes into types that encode naturally as
+// JSON: UUIDs as strings and raw bytes as text.
+func insightValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case [16]byte:
+		return uuid.UUID(v).String()
+	case []byte:
+		return string(v)
+	}
+	return v
+}
+
+// Ensure interface implementation at compile time.
+var _ InsightRepository = (*insightRepo)(nil)
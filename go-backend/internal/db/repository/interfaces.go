@@ -13,7 +13,7 @@ import (
 
 // StrategyRepository defines the interface for strategy data access.
 type StrategyRepository interface {
-	// Create creates a new strategy.
+	// Create creates a new strategy and stores it as version 1.
 	Create(ctx context.Context, strategy *domain.Strategy) error
 
 	// GetByID retrieves a strategy by ID.
@@ -22,7 +22,9 @@ type StrategyRepository interface {
 	// GetByCodeHash retrieves a strategy by its code hash.
 	GetByCodeHash(ctx context.Context, hash string) (*domain.Strategy, error)
 
-	// Update updates an existing strategy.
+	// Update updates an existing strategy, including its code, and stores the
+	// result as a new version. Returns Duplicate if another strategy has the
+	// same code.
 	Update(ctx context.Context, strategy *domain.Strategy) error
 
 	// Delete deletes a strategy by ID.
@@ -31,14 +33,48 @@ type StrategyRepository interface {
 	// Search searches for strategies with filters and pagination.
 	Search(ctx context.Context, query domain.StrategySearchQuery) ([]domain.StrategyWithMetrics, int, error)
 
-	// GetLineage retrieves the strategy lineage tree.
-	GetLineage(ctx context.Context, strategyID uuid.UUID, depth int) (*domain.StrategyLineageNode, error)
+	// GetLineage retrieves the lineage tree of a strategy: its descendants,
+	// its ancestors, or both.
+	GetLineage(ctx context.Context, strategyID uuid.UUID, query domain.StrategyLineageQuery) (*domain.StrategyLineageNode, error)
 
 	// GetDescendants retrieves all descendants of a strategy.
 	GetDescendants(ctx context.Context, strategyID uuid.UUID) ([]*domain.Strategy, error)
 
 	// GetAncestors retrieves all ancestors of a strategy.
 	GetAncestors(ctx context.Context, strategyID uuid.UUID) ([]*domain.Strategy, error)
+
+	// GetByIDs retrieves the strategies with the given IDs, skipping unknown ones.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Strategy, error)
+
+	// ListAll retrieves all strategies, oldest first, optionally including archived ones.
+	ListAll(ctx context.Context, includeArchived bool) ([]*domain.Strategy, error)
+
+	// GetGenerationStatistics aggregates all strategies by lineage generation, ordered by generation.
+	GetGenerationStatistics(ctx context.Context) ([]domain.GenerationStatistics, error)
+
+	// FindArchivalCandidates returns active strategies matching the archival policy as of now.
+	FindArchivalCandidates(ctx context.Context, policy domain.ArchivalPolicy, now time.Time) ([]*domain.ArchivalCandidate, error)
+
+	// Archive marks a strategy as archived with a reason.
+	Archive(ctx context.Context, id uuid.UUID, reason string) error
+
+	// Unarchive restores an archived strategy.
+	Unarchive(ctx context.Context, id uuid.UUID) error
+
+	// SetValidation records the outcome of validating a strategy's code.
+	SetValidation(ctx context.Context, id uuid.UUID, status domain.ValidationStatus, validationErrors []string) error
+
+	// ClaimDescriptionRequests marks up to limit active strategies lacking a
+	// description as requested at now and returns them, oldest first.
+	// Strategies requested within retryAfter are skipped.
+	ClaimDescriptionRequests(ctx context.Context, limit int, retryAfter time.Duration, now time.Time) ([]*domain.DescriptionRequest, error)
+
+	// SetGeneratedDescription stores a generated description for a strategy
+	// that has none. Returns Conflict if it already has one.
+	SetGeneratedDescription(ctx context.Context, id uuid.UUID, description string) error
+
+	// GetDescriptionCoverage counts the active strategies with and without a description.
+	GetDescriptionCoverage(ctx context.Context) (*domain.DescriptionCoverage, error)
 }
 
 // BacktestJobRepository defines the interface for backtest job data access.
@@ -49,15 +85,22 @@ type BacktestJobRepository interface {
 	// CreateBatch creates multiple backtest jobs in a single transaction.
 	CreateBatch(ctx context.Context, jobs []*domain.BacktestJob) error
 
+	// CreateBatchPartial creates multiple backtest jobs in a single transaction,
+	// skipping the jobs that fail to insert. It returns one error per job, nil
+	// for the jobs that were created.
+	CreateBatchPartial(ctx context.Context, jobs []*domain.BacktestJob) ([]error, error)
+
 	// GetByID retrieves a job by ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.BacktestJob, error)
 
 	// Update updates an existing job.
 	Update(ctx context.Context, job *domain.BacktestJob) error
 
-	// GetPendingJobs retrieves pending jobs for processing.
-	// Uses FOR UPDATE SKIP LOCKED for concurrent-safe dequeuing.
-	GetPendingJobs(ctx context.Context, limit int) ([]*domain.BacktestJob, error)
+	// GetPendingJobs retrieves pending jobs for processing, highest
+	// effective priority at now first: priority plus agingPerHour levels per
+	// hour waited. Uses FOR UPDATE SKIP LOCKED for concurrent-safe dequeuing.
+	// Jobs of optimization runs at their concurrency quota are skipped.
+	GetPendingJobs(ctx context.Context, limit int, agingPerHour float64, now time.Time) ([]*domain.BacktestJob, error)
 
 	// UpdateStatus updates the job status with optional container ID and error message.
 	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.JobStatus, containerID, errMsg *string) error
@@ -68,11 +111,17 @@ type BacktestJobRepository interface {
 	// MarkCompleted marks a job as completed.
 	MarkCompleted(ctx context.Context, id uuid.UUID) error
 
-	// MarkFailed marks a job as failed with an error message.
-	MarkFailed(ctx context.Context, id uuid.UUID, errMsg string) error
+	// MarkFailed marks a job as failed with the category of the failure and an error message.
+	MarkFailed(ctx context.Context, id uuid.UUID, category domain.FailureCategory, errMsg string) error
+
+	// Cancel cancels a pending or running job, recording why and by whom.
+	// The reason also appears in the job's timeline.
+	Cancel(ctx context.Context, id uuid.UUID, c domain.Cancellation) error
 
-	// Cancel cancels a pending or running job.
-	Cancel(ctx context.Context, id uuid.UUID) error
+	// CancelMatching cancels all jobs selected by the filter in one
+	// statement, recording why and by whom as Cancel does. It returns the
+	// jobs cancelled.
+	CancelMatching(ctx context.Context, filter domain.JobCancelFilter, c domain.Cancellation) ([]*domain.BacktestJob, error)
 
 	// GetRunningJobs retrieves all currently running jobs.
 	GetRunningJobs(ctx context.Context) ([]*domain.BacktestJob, error)
@@ -89,8 +138,59 @@ type BacktestJobRepository interface {
 	// GetQueueStats retrieves queue statistics.
 	GetQueueStats(ctx context.Context) (*domain.QueueStats, error)
 
+	// GetFailureAnalytics counts the jobs that finished within the window and
+	// their failures by category. A nil window, or a zero start or end, leaves
+	// that side unbounded.
+	GetFailureAnalytics(ctx context.Context, window *domain.TimeRange) (*domain.FailureAnalytics, error)
+
 	// IncrementRetryCount increments the retry count for a job.
 	IncrementRetryCount(ctx context.Context, id uuid.UUID) error
+
+	// Requeue returns the listed running jobs to pending, e.g. jobs a
+	// scheduler claimed before it restarted. Jobs in any other status are
+	// left alone. It returns the IDs of the jobs requeued.
+	Requeue(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+
+	// GetActiveDuplicates finds, for each of the given jobs, the oldest
+	// pending or running job of the same strategy with an equal config. It
+	// returns one ID per job, nil if there is none.
+	GetActiveDuplicates(ctx context.Context, jobs []*domain.BacktestJob) ([]*uuid.UUID, error)
+
+	// GetCancelledIDs returns the IDs of the listed jobs that have been
+	// cancelled, e.g. while their containers were running.
+	GetCancelledIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+
+	// CountPendingAhead counts the pending jobs that would be dequeued before
+	// a job of the given priority submitted at now, with priority aging at
+	// agingPerHour.
+	CountPendingAhead(ctx context.Context, priority int, agingPerHour float64, now time.Time) (int, error)
+
+	// GetPendingQueue retrieves all pending jobs by priority and age;
+	// domain.SortQueue puts them in dequeue order.
+	GetPendingQueue(ctx context.Context) ([]domain.QueuedJob, error)
+
+	// SetPriority sets the priority of the pending jobs selected by the
+	// change and returns their IDs. Jobs that are not pending are left alone.
+	SetPriority(ctx context.Context, change domain.PriorityChange) ([]uuid.UUID, error)
+
+	// GetRecentRuntimes retrieves runtimes of jobs completed since the given time (most recent first).
+	GetRecentRuntimes(ctx context.Context, since time.Time, limit int) ([]time.Duration, error)
+
+	// GetByExternalRef retrieves a job by the external reference supplied by its owner.
+	GetByExternalRef(ctx context.Context, owner, ref string) (*domain.BacktestJob, error)
+
+	// GetCoverageEntries retrieves the configs and outcomes of all jobs for a strategy.
+	GetCoverageEntries(ctx context.Context, strategyID uuid.UUID) ([]domain.CoverageEntry, error)
+
+	// GetSLABreaches retrieves pending and running jobs that exceed the SLA targets at now.
+	// Breaches are ordered by how long the job has been waiting or running, longest first.
+	GetSLABreaches(ctx context.Context, targets domain.SLATargets, now time.Time) ([]*domain.SLABreach, error)
+
+	// AddEvent appends an event to a job's timeline.
+	AddEvent(ctx context.Context, event *domain.JobEvent) error
+
+	// GetEvents retrieves a job's timeline, oldest first.
+	GetEvents(ctx context.Context, jobID uuid.UUID) ([]*domain.JobEvent, error)
 }
 
 // BacktestResultRepository defines the interface for backtest result data access.
@@ -110,8 +210,51 @@ type BacktestResultRepository interface {
 	// Query queries results with filters and pagination.
 	Query(ctx context.Context, query domain.BacktestResultQuery) ([]*domain.BacktestResult, int, error)
 
+	// ListAfterID lists up to limit results matching the query's filters
+	// ordered by ID, starting after afterID (from the start if nil). Unlike
+	// Query, paging through all results this way stays fast for large exports.
+	ListAfterID(ctx context.Context, query domain.BacktestResultQuery, afterID *uuid.UUID, limit int) ([]*domain.BacktestResult, error)
+
 	// GetBestByStrategyID retrieves the best result for a strategy based on sharpe ratio.
 	GetBestByStrategyID(ctx context.Context, strategyID uuid.UUID) (*domain.BacktestResult, error)
+
+	// GetBestByCampaignID retrieves the best current result of a campaign's jobs based on sharpe ratio.
+	GetBestByCampaignID(ctx context.Context, campaignID uuid.UUID) (*domain.BacktestResult, error)
+
+	// GetBestComparableByStrategyID retrieves a strategy's best current result
+	// based on sharpe ratio among jobs whose config is comparable to cfg (see
+	// BacktestConfig.Comparable).
+	GetBestComparableByStrategyID(ctx context.Context, strategyID uuid.UUID, cfg domain.ComparableConfig) (*domain.BacktestResult, error)
+
+	// GetRawLog retrieves the gzip-compressed raw log of a result, which is
+	// not loaded with the result itself.
+	GetRawLog(ctx context.Context, resultID uuid.UUID) ([]byte, error)
+
+	// GetTradeReturns retrieves the per-trade returns (profit %) of a result,
+	// which are not loaded with the result itself. Empty if the result's
+	// format did not report individual trades.
+	GetTradeReturns(ctx context.Context, resultID uuid.UUID) ([]float64, error)
+
+	// GetEquityCurve retrieves the daily balance of a result, oldest first,
+	// with the drawdown at each point. Empty if the result's format did not
+	// report daily profits.
+	GetEquityCurve(ctx context.Context, resultID uuid.UUID) ([]domain.EquityPoint, error)
+
+	// GetStrategyTradeReturns pools the per-trade returns of all of a
+	// strategy's current (not superseded) results.
+	GetStrategyTradeReturns(ctx context.Context, strategyID uuid.UUID) ([]float64, error)
+
+	// GetStatistics aggregates a strategy's results created within the window.
+	// A nil window, or a zero start or end, leaves that side unbounded.
+	GetStatistics(ctx context.Context, strategyID uuid.UUID, window *domain.TimeRange) (*domain.StrategyStatistics, error)
+
+	// GetCohortMetrics retrieves the sorted metrics of all current results
+	// in a cohort, which percentile ranks are computed against.
+	GetCohortMetrics(ctx context.Context, cohort domain.ResultCohort) (*domain.CohortMetrics, error)
+
+	// GetStatisticsBatch aggregates the results of each strategy like
+	// GetStatistics, in the order of strategyIDs.
+	GetStatisticsBatch(ctx context.Context, strategyIDs []uuid.UUID, window *domain.TimeRange) ([]*domain.StrategyStatistics, error)
 }
 
 // OptimizationRepository defines the interface for optimization run data access.
@@ -122,6 +265,9 @@ type OptimizationRepository interface {
 	// GetByID retrieves an optimization run by ID.
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.OptimizationRun, error)
 
+	// GetByExternalRef retrieves an optimization run by the external reference supplied by its owner.
+	GetByExternalRef(ctx context.Context, owner, ref string) (*domain.OptimizationRun, error)
+
 	// Update updates an existing optimization run.
 	Update(ctx context.Context, run *domain.OptimizationRun) error
 
@@ -129,8 +275,29 @@ type OptimizationRepository interface {
 	List(ctx context.Context, query domain.OptimizationListQuery) ([]*domain.OptimizationRun, int, error)
 
 	// UpdateStatus updates the status of an optimization run.
+	// Pausing through this method is recorded as a manual pause.
 	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.OptimizationStatus) error
 
+	// Cancel cancels an optimization run, recording why and by whom, and
+	// returns the updated run.
+	Cancel(ctx context.Context, id uuid.UUID, c domain.Cancellation) (*domain.OptimizationRun, error)
+
+	// AutoPause pauses all running runs because of an infrastructure outage,
+	// recording an incident on each. It returns the paused runs.
+	AutoPause(ctx context.Context, dependencies []string, at time.Time) ([]*domain.OptimizationRun, error)
+
+	// AutoResume resumes the runs paused by AutoPause and closes their incident.
+	// It returns the resumed runs.
+	AutoResume(ctx context.Context, at time.Time) ([]*domain.OptimizationRun, error)
+
+	// MarkStalled flags running runs without activity since inactiveSince as
+	// stalled at the given time. It returns the flagged runs.
+	MarkStalled(ctx context.Context, inactiveSince, at time.Time) ([]*domain.StalledOptimization, error)
+
+	// ReviveStalled returns stalled runs that showed activity since they were
+	// flagged to running. It returns the revived runs.
+	ReviveStalled(ctx context.Context) ([]*domain.OptimizationRun, error)
+
 	// SetBestResult sets the best strategy and result for an optimization run.
 	SetBestResult(ctx context.Context, id uuid.UUID, strategyID, resultID uuid.UUID) error
 
@@ -143,7 +310,8 @@ type OptimizationRepository interface {
 	// IncrementIteration increments the current iteration counter.
 	IncrementIteration(ctx context.Context, id uuid.UUID) error
 
-	// AddIteration adds a new iteration record.
+	// AddIteration adds a new iteration record, pinning the strategy's current
+	// code hash (and code, if the run snapshots code) on it.
 	AddIteration(ctx context.Context, iteration *domain.OptimizationIteration) error
 
 	// GetIterations retrieves all iterations for an optimization run.
@@ -157,6 +325,26 @@ type OptimizationRepository interface {
 
 	// GetIterationsInTimeRange retrieves iterations within a time range (for performance charts).
 	GetIterationsInTimeRange(ctx context.Context, start, end time.Time) ([]*domain.OptimizationIteration, error)
+
+	// GetPerformanceSeries aggregates the result metric of the iterations
+	// created in the query's window into time buckets, one series per run or a
+	// single series across runs. Iterations without a result or without a
+	// value for the metric are left out.
+	GetPerformanceSeries(ctx context.Context, query domain.PerformanceQuery) ([]*domain.PerformanceSeries, error)
+
+	// GetIterationsByResultIDs retrieves the iterations that produced the given results.
+	GetIterationsByResultIDs(ctx context.Context, resultIDs []uuid.UUID) ([]*domain.OptimizationIteration, error)
+
+	// ClaimNextAction atomically determines the next step of a run and, if it
+	// is claimable, claims it for claimant until now+lease. Another claimant
+	// gets OrchestratorActionNone while the claim holds. A stalled run is
+	// returned to running first.
+	ClaimNextAction(ctx context.Context, runID uuid.UUID, claimant string, lease time.Duration, now time.Time) (*domain.OrchestratorAction, error)
+
+	// GetQuotaUsage retrieves the quotas of the given runs with the number of
+	// their backtest jobs running, keyed by run ID. Runs without a quota are
+	// left out.
+	GetQuotaUsage(ctx context.Context, runIDs []uuid.UUID) (map[uuid.UUID]*domain.OptimizationQuotaUsage, error)
 }
 
 // ScoutRepository defines the interface for scout data access.
@@ -166,11 +354,26 @@ type ScoutRepository interface {
 	GetRunByID(ctx context.Context, id uuid.UUID) (*domain.ScoutRun, error)
 	UpdateRun(ctx context.Context, run *domain.ScoutRun) error
 	UpdateRunStatus(ctx context.Context, id uuid.UUID, status domain.ScoutRunStatus, errorMsg *string) error
+	CancelRun(ctx context.Context, id uuid.UUID, c domain.Cancellation) error
 	CompleteRun(ctx context.Context, id uuid.UUID, metrics *domain.ScoutMetrics) error
 	FailRun(ctx context.Context, id uuid.UUID, errorMsg string) error
 	ListRuns(ctx context.Context, query domain.ScoutRunQuery) ([]*domain.ScoutRun, int, error)
 	GetActiveRun(ctx context.Context) (*domain.ScoutRun, error)
 
+	// CreateRunWithinLimits creates a run unless it would break the etiquette
+	// limits of its source, returning a *domain.ScoutRateLimitError if so.
+	CreateRunWithinLimits(ctx context.Context, run *domain.ScoutRun, limits domain.ScoutSourceLimits) error
+
+	// AggregateMetrics sums the metrics of the completed runs matching the
+	// query, broken down by source and error category.
+	AggregateMetrics(ctx context.Context, query domain.ScoutMetricsQuery) (*domain.ScoutMetricsSummary, error)
+
+	// Discovery operations
+	// RecordDiscoveries stores the strategies a run fetched, merging them
+	// with those already recorded for the run by code hash.
+	RecordDiscoveries(ctx context.Context, discoveries []*domain.ScoutDiscovery) error
+	ListDiscoveries(ctx context.Context, query domain.ScoutDiscoveryQuery) ([]*domain.ScoutDiscovery, error)
+
 	// Schedule operations
 	CreateSchedule(ctx context.Context, schedule *domain.ScoutSchedule) error
 	GetScheduleByID(ctx context.Context, id uuid.UUID) (*domain.ScoutSchedule, error)
@@ -183,6 +386,351 @@ type ScoutRepository interface {
 	UpdateScheduleNextRun(ctx context.Context, scheduleID uuid.UUID, nextRunAt time.Time) error
 }
 
+// PreferenceRepository defines the interface for user preference data access.
+type PreferenceRepository interface {
+	// Get retrieves a single preference by owner and key.
+	Get(ctx context.Context, owner, key string) (*domain.UserPreference, error)
+
+	// List retrieves all preferences for an owner.
+	List(ctx context.Context, owner string) ([]*domain.UserPreference, error)
+
+	// Upsert creates or replaces a preference.
+	Upsert(ctx context.Context, pref *domain.UserPreference) error
+
+	// Delete removes a preference.
+	Delete(ctx context.Context, owner, key string) error
+}
+
+// AgentRepository defines the interface for agent registration data access.
+type AgentRepository interface {
+	// Register creates or replaces an agent's registration, keyed by agent ID.
+	Register(ctx context.Context, reg *domain.AgentRegistration) error
+
+	// List retrieves the agents seen since the given time, or all agents if
+	// since is nil.
+	List(ctx context.Context, since *time.Time) ([]*domain.AgentRegistration, error)
+
+	// Touch records that a registered agent was seen, e.g. on a heartbeat.
+	// Returns NotFound if the agent never registered.
+	Touch(ctx context.Context, agentID string, at time.Time) error
+}
+
+// SecretRepository defines the interface for encrypted secret data access.
+type SecretRepository interface {
+	// Create inserts a new secret. Returns Duplicate if the name is taken.
+	Create(ctx context.Context, secret *domain.Secret) error
+
+	// GetByID retrieves a secret, including its ciphertext.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Secret, error)
+
+	// List retrieves all secrets ordered by name, without their ciphertext.
+	List(ctx context.Context) ([]*domain.Secret, error)
+
+	// Update replaces a secret's description and encrypted value.
+	Update(ctx context.Context, secret *domain.Secret) error
+
+	// Delete removes a secret. Returns ErrSecretInUse while it is referenced.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// APIKeyRepository defines the interface for API key data access. Keys are
+// stored and looked up by hash only.
+type APIKeyRepository interface {
+	// Create inserts a new API key. Returns Duplicate if an unrevoked key has
+	// the same name.
+	Create(ctx context.Context, key *domain.APIKey) error
+
+	// GetByHash retrieves the API key with the given hash, revoked or not.
+	GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+
+	// List retrieves API keys ordered by name, leaving out revoked keys
+	// unless includeRevoked is set.
+	List(ctx context.Context, includeRevoked bool) ([]*domain.APIKey, error)
+
+	// Revoke revokes an API key. Returns NotFound if there is no unrevoked
+	// key with the ID.
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// TouchLastUsed records a use of an API key. Uses close together are
+	// recorded once.
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// StarRepository defines the interface for favorites (stars) data access.
+type StarRepository interface {
+	// Star stars an entity for an owner.
+	Star(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) error
+
+	// Unstar removes an owner's star from an entity.
+	Unstar(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) error
+
+	// IsStarred returns true if the owner has starred the entity.
+	IsStarred(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) (bool, error)
+
+	// ListStarredIDs returns the IDs of entities starred by the owner.
+	ListStarredIDs(ctx context.Context, owner string, entityType domain.StarEntityType) ([]uuid.UUID, error)
+}
+
+// ArtifactRepository defines the interface for artifact store access.
+type ArtifactRepository interface {
+	// Create stores a new artifact with its content.
+	Create(ctx context.Context, artifact *domain.Artifact) error
+
+	// GetByID retrieves artifact metadata by ID. Content is not loaded.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Artifact, error)
+
+	// GetContent retrieves an artifact with its content.
+	GetContent(ctx context.Context, id uuid.UUID) (*domain.Artifact, error)
+
+	// ListByOwner retrieves metadata for all artifacts of an entity, oldest first.
+	ListByOwner(ctx context.Context, ownerType domain.ArtifactOwnerType, ownerID uuid.UUID) ([]*domain.Artifact, error)
+
+	// ListByOwners retrieves metadata for all artifacts of several entities of
+	// one type, oldest first.
+	ListByOwners(ctx context.Context, ownerType domain.ArtifactOwnerType, ownerIDs []uuid.UUID) ([]*domain.Artifact, error)
+}
+
+// FeatureFlagRepository defines the interface for feature flag override data access.
+type FeatureFlagRepository interface {
+	// List retrieves all feature flag overrides.
+	List(ctx context.Context) ([]*domain.FeatureFlagOverride, error)
+
+	// Upsert creates or replaces a feature flag override.
+	Upsert(ctx context.Context, override *domain.FeatureFlagOverride) error
+
+	// Delete removes a feature flag override, reverting the flag to its configured value.
+	Delete(ctx context.Context, name string) error
+}
+
+// MaintenanceRepository defines the interface for the system-wide maintenance
+// mode flag.
+type MaintenanceRepository interface {
+	// Get retrieves the maintenance mode; it is disabled if never set.
+	Get(ctx context.Context) (*domain.MaintenanceMode, error)
+
+	// Set enables or disables maintenance mode. Enabling it while enabled
+	// replaces the message but keeps the time it was enabled.
+	Set(ctx context.Context, enabled bool, message, changedBy string) (*domain.MaintenanceMode, error)
+}
+
+// CampaignRepository defines the interface for backtest campaign data access.
+type CampaignRepository interface {
+	// Create creates a new campaign.
+	Create(ctx context.Context, campaign *domain.Campaign) error
+
+	// GetByID retrieves a campaign by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Campaign, error)
+
+	// Update updates a campaign's name and description.
+	Update(ctx context.Context, campaign *domain.Campaign) error
+
+	// Delete deletes a campaign. Its jobs are kept and detached from it.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List lists campaigns, newest first.
+	List(ctx context.Context, query domain.CampaignListQuery) ([]*domain.Campaign, int, error)
+
+	// GetProgress counts a campaign's jobs by status.
+	GetProgress(ctx context.Context, id uuid.UUID) (*domain.CampaignProgress, error)
+
+	// GetResults aggregates the current results of a campaign's jobs.
+	GetResults(ctx context.Context, id uuid.UUID) (*domain.CampaignResults, error)
+}
+
+// ExportRepository defines the interface for bulk export job data access.
+type ExportRepository interface {
+	// Create creates a new export job.
+	Create(ctx context.Context, job *domain.ExportJob) error
+
+	// GetByID retrieves an export job by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ExportJob, error)
+
+	// ClaimNext marks the oldest pending export job as running and returns
+	// it, or nil if none is pending.
+	ClaimNext(ctx context.Context) (*domain.ExportJob, error)
+
+	// Complete stores the archive of a running export job and marks the job
+	// completed with its counts, in one transaction.
+	Complete(ctx context.Context, job *domain.ExportJob, archive *domain.Artifact) error
+
+	// Fail marks an export job as failed with an error message.
+	Fail(ctx context.Context, id uuid.UUID, errMsg string) error
+
+	// RequeueRunning returns running export jobs to pending, e.g. after a
+	// restart interrupted them, and returns how many were requeued.
+	RequeueRunning(ctx context.Context) (int, error)
+}
+
+// ConsistencyRepository checks and repairs invariants that span tables.
+type ConsistencyRepository interface {
+	// Check scans for violations of the given invariants (all when empty)
+	// without changing anything.
+	Check(ctx context.Context, checks []domain.ConsistencyCheck, now time.Time) (*domain.ConsistencyReport, error)
+
+	// Repair scans for violations of the given invariants (all when empty) and
+	// repairs them in a single transaction, which is rolled back when dryRun is set.
+	Repair(ctx context.Context, checks []domain.ConsistencyCheck, dryRun bool, now time.Time) (*domain.ConsistencyReport, error)
+}
+
+// InsightRepository defines the interface for stored insight templates and
+// running insight queries.
+type InsightRepository interface {
+	// CreateTemplate stores an approved insight template. Returns Duplicate if
+	// the name is taken.
+	CreateTemplate(ctx context.Context, tmpl *domain.InsightTemplate) error
+
+	// GetTemplate retrieves an enabled insight template by name.
+	GetTemplate(ctx context.Context, name string) (*domain.InsightTemplate, error)
+
+	// ListTemplates retrieves the enabled insight templates ordered by name.
+	ListTemplates(ctx context.Context) ([]*domain.InsightTemplate, error)
+
+	// Execute runs a query in a read-only transaction with a statement timeout,
+	// returning at most maxRows rows. Returns ErrInsightTimeout if the timeout
+	// is exceeded.
+	Execute(ctx context.Context, sql string, args []interface{}, maxRows int, timeout time.Duration) (*domain.InsightResult, error)
+}
+
+// StrategyVersionRepository defines the interface for strategy version
+// history.
+type StrategyVersionRepository interface {
+	// List retrieves the versions of a strategy, newest first. Code is left
+	// out unless includeCode is set.
+	List(ctx context.Context, strategyID uuid.UUID, includeCode bool) ([]*domain.StrategyVersion, error)
+
+	// Get retrieves one version of a strategy.
+	Get(ctx context.Context, strategyID uuid.UUID, version int) (*domain.StrategyVersion, error)
+
+	// Rollback restores a strategy to one of its versions and returns the new
+	// version this creates. Returns NotFound if the strategy has no such
+	// version and Duplicate if another strategy now has that code.
+	Rollback(ctx context.Context, strategyID uuid.UUID, version int) (*domain.StrategyVersion, error)
+}
+
+// RevalidationRepository defines the interface for strategy re-validation
+// runs and the validity of strategies on each Freqtrade image.
+type RevalidationRepository interface {
+	// Create creates a new re-validation run.
+	Create(ctx context.Context, run *domain.RevalidationRun) error
+
+	// GetByID retrieves a re-validation run by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.RevalidationRun, error)
+
+	// List retrieves the most recent re-validation runs, newest first.
+	List(ctx context.Context, limit int) ([]*domain.RevalidationRun, error)
+
+	// ClaimNext marks the oldest pending re-validation run as running and
+	// returns it, or nil if none is pending.
+	ClaimNext(ctx context.Context) (*domain.RevalidationRun, error)
+
+	// Record stores a strategy's validity on the run's image and counts it
+	// towards the run's progress, in one transaction. A nil validation counts
+	// the strategy as one that could not be validated.
+	Record(ctx context.Context, runID uuid.UUID, strategyID uuid.UUID, v *domain.StrategyImageValidation) error
+
+	// Complete marks a running re-validation run as completed.
+	Complete(ctx context.Context, id uuid.UUID) error
+
+	// Fail marks a re-validation run as failed with an error message.
+	Fail(ctx context.Context, id uuid.UUID, errMsg string) error
+
+	// RequeueRunning returns running re-validation runs to pending with their
+	// progress reset, e.g. after a restart interrupted them, and returns how
+	// many were requeued.
+	RequeueRunning(ctx context.Context) (int, error)
+
+	// GetCompatibility retrieves the validity of the given strategies on the
+	// given images, one row per existing strategy. With no images, every
+	// image any of the strategies was validated on is included. It returns
+	// the images along with the rows.
+	GetCompatibility(ctx context.Context, strategyIDs []uuid.UUID, images []string) ([]string, []domain.CompatibilityRow, error)
+}
+
+// IncidentRepository defines the interface for the operator incident log.
+type IncidentRepository interface {
+	// Create creates a new incident along with its notes.
+	Create(ctx context.Context, incident *domain.Incident) error
+
+	// GetByID retrieves an incident and its notes by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Incident, error)
+
+	// List retrieves incidents matching the query with their notes, newest
+	// first.
+	List(ctx context.Context, query domain.IncidentListQuery) ([]*domain.Incident, error)
+
+	// Update changes the title or severity of an incident.
+	Update(ctx context.Context, id uuid.UUID, update domain.IncidentUpdate) (*domain.Incident, error)
+
+	// AddNote adds a note to an incident, open or closed.
+	AddNote(ctx context.Context, note *domain.IncidentNote) error
+
+	// Close closes an open incident, adding the optional closing note.
+	// Returns Conflict if the incident is already closed.
+	Close(ctx context.Context, id uuid.UUID, by string, note *domain.IncidentNote) (*domain.Incident, error)
+
+	// CloseBySource closes the open incidents of a source, adding a closing
+	// note to each, and returns how many were closed.
+	CloseBySource(ctx context.Context, source, by, note string) (int, error)
+}
+
+// HyperoptRepository defines the interface for hyperopt job data access.
+type HyperoptRepository interface {
+	// Create creates a new hyperopt job.
+	Create(ctx context.Context, job *domain.HyperoptJob) error
+
+	// GetByID retrieves a hyperopt job by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.HyperoptJob, error)
+
+	// List retrieves hyperopt jobs matching the query, newest first.
+	List(ctx context.Context, query domain.HyperoptJobQuery) ([]*domain.HyperoptJob, error)
+
+	// GetBest retrieves the completed hyperopt job of a strategy with the
+	// lowest loss. Returns NotFound if the strategy has none.
+	GetBest(ctx context.Context, strategyID uuid.UUID) (*domain.HyperoptJob, error)
+
+	// ClaimNext marks the oldest pending hyperopt job as running and returns
+	// it, or nil if none is pending.
+	ClaimNext(ctx context.Context) (*domain.HyperoptJob, error)
+
+	// SetContainer records the container a running hyperopt job runs in.
+	SetContainer(ctx context.Context, id uuid.UUID, containerID string) error
+
+	// Complete stores the best epoch of a running hyperopt job and marks it
+	// completed. Returns Conflict if the job is no longer running, e.g.
+	// because it was cancelled.
+	Complete(ctx context.Context, id uuid.UUID, result *domain.HyperoptResult) error
+
+	// Fail marks a running hyperopt job as failed with an error message.
+	// Returns Conflict if the job is no longer running.
+	Fail(ctx context.Context, id uuid.UUID, errMsg string) error
+
+	// Cancel cancels a pending or running hyperopt job and returns the job as
+	// it was before. Returns Conflict if the job already finished.
+	Cancel(ctx context.Context, id uuid.UUID) (*domain.HyperoptJob, error)
+
+	// RequeueRunning returns running hyperopt jobs to pending, e.g. after a
+	// restart interrupted them, and returns how many were requeued.
+	RequeueRunning(ctx context.Context) (int, error)
+}
+
+// DeadLetterRepository defines the interface for event messages collected
+// from the dead-letter queue.
+type DeadLetterRepository interface {
+	// Create stores a dead letter. One already stored under the same ID is
+	// left as it is.
+	Create(ctx context.Context, letter *domain.DeadLetter) error
+
+	// GetByID retrieves a dead letter by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error)
+
+	// List retrieves dead letters matching the query, newest first.
+	List(ctx context.Context, query domain.DeadLetterQuery) ([]*domain.DeadLetter, error)
+
+	// MarkRequeued records that a dead letter was published again and
+	// returns it.
+	MarkRequeued(ctx context.Context, id uuid.UUID) (*domain.DeadLetter, error)
+}
+
 // Repositories aggregates all repository interfaces.
 type Repositories struct {
 	Strategy     StrategyRepository
@@ -190,6 +738,24 @@ type Repositories struct {
 	Result       BacktestResultRepository
 	Optimization OptimizationRepository
 	Scout        ScoutRepository
+	Preference   PreferenceRepository
+	Star         StarRepository
+	Artifact     ArtifactRepository
+	FeatureFlag  FeatureFlagRepository
+	Campaign     CampaignRepository
+	Consistency  ConsistencyRepository
+	Export       ExportRepository
+	Agent        AgentRepository
+	Secret       SecretRepository
+	Insight      InsightRepository
+
+	StrategyVersion StrategyVersionRepository
+	APIKey          APIKeyRepository
+	Maintenance     MaintenanceRepository
+	Revalidation    RevalidationRepository
+	Incident        IncidentRepository
+	Hyperopt        HyperoptRepository
+	DeadLetter      DeadLetterRepository
 }
 
 // NewRepositories creates a new Repositories instance with all PostgreSQL implementations.
@@ -200,5 +766,23 @@ func NewRepositories(pool *db.Pool) *Repositories {
 		Result:       NewBacktestResultRepository(pool),
 		Optimization: NewOptimizationRepository(pool),
 		Scout:        NewScoutRepository(pool),
+		Preference:   NewPreferenceRepository(pool),
+		Star:         NewStarRepository(pool),
+		Artifact:     NewArtifactRepository(pool),
+		FeatureFlag:  NewFeatureFlagRepository(pool),
+		Campaign:     NewCampaignRepository(pool),
+		Consistency:  NewConsistencyRepository(pool),
+		Export:       NewExportRepository(pool),
+		Agent:        NewAgentRepository(pool),
+		Secret:       NewSecretRepository(pool),
+		Insight:      NewInsightRepository(pool),
+
+		StrategyVersion: NewStrategyVersionRepository(pool),
+		APIKey:          NewAPIKeyRepository(pool),
+		Maintenance:     NewMaintenanceRepository(pool),
+		Revalidation:    NewRevalidationRepository(pool),
+		Incident:        NewIncidentRepository(pool),
+		Hyperopt:        NewHyperoptRepository(pool),
+		DeadLetter:      NewDeadLetterRepository(pool),
 	}
 }
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// maintenanceRepo implements MaintenanceRepository using PostgreSQL.
+type maintenanceRepo struct {
+	pool *db.Pool
+}
+
+// NewMaintenanceRepository creates a new PostgreSQL maintenance mode repository.
+func NewMaintenanceRepository(pool *db.Pool) MaintenanceRepository {
+	return &maintenanceRepo{pool: pool}
+}
+
+// Get retrieves the maintenance mode. It is disabled if it was never set.
+func (r *maintenanceRepo) Get(ctx context.Context) (*domain.MaintenanceMode, error) {
+	query := `
+		SELECT enabled, message, changed_by, since, updated_at
+		FROM maintenance_mode
+	`
+
+	mode := &domain.MaintenanceMode{}
+	err := r.pool.QueryRow(ctx, query).Scan(
+		&mode.Enabled,
+		&mode.Message,
+		&mode.ChangedBy,
+		&mode.Since,
+		&mode.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.MaintenanceMode{}, nil
+		}
+		return nil, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+
+	return mode, nil
+}
+
+// Set enables or disables maintenance mode. Enabling it again keeps the
+// time it was first enabled but replaces the message.
+func (r *maintenanceRepo) Set(ctx context.Context, enabled bool, message, changedBy string) (*domain.MaintenanceMode, error) {
+	query := `
+		INSERT INTO maintenance_mode (enabled, message, changed_by, since, updated_at)
+		VALUES ($1, $2, $3, CASE WHEN $1 THEN NOW() END, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			message = EXCLUDED.message,
+			changed_by = EXCLUDED.changed_by,
+			since = CASE WHEN EXCLUDED.enabled THEN COALESCE(maintenance_mode.since, EXCLUDED.since) END,
+			updated_at = EXCLUDED.updated_at
+		RETURNING enabled, message, changed_by, since, updated_at
+	`
+
+	mode := &domain.MaintenanceMode{}
+	err := r.pool.QueryRow(ctx, query, enabled, message, changedBy).Scan(
+		&mode.Enabled,
+		&mode.Message,
+		&mode.ChangedBy,
+		&mode.Since,
+		&mode.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set maintenance mode: %w", err)
+	}
+
+	return mode, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ MaintenanceRepository = (*maintenanceRepo)(nil)
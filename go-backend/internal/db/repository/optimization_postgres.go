@@ -32,6 +32,14 @@ func (r *optimizationRepo) Create(ctx context.Context, run *domain.OptimizationR
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
+	var snapshotJSON []byte
+	if run.DataSnapshot != nil {
+		snapshotJSON, err = json.Marshal(run.DataSnapshot)
+		if err != nil {
+			return fmt.Errorf("failed to marshal data snapshot: %w", err)
+		}
+	}
+
 	query := `
 		INSERT INTO optimization_runs (
 			id, name, base_strategy_id, config, mode,
@@ -39,13 +47,17 @@ func (r *optimizationRepo) Create(ctx context.Context, run *domain.OptimizationR
 			criteria_min_trades, criteria_min_win_rate,
 			status, current_iteration, max_iterations,
 			best_strategy_id, best_result_id, termination_reason,
-			created_at, updated_at, completed_at
+			created_at, updated_at, completed_at,
+			external_ref, external_ref_owner,
+			seed_strategy_ids, data_snapshot
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7, $8, $9, $10,
 			$11, $12, $13,
 			$14, $15, $16,
-			$17, $18, $19
+			$17, $18, $19,
+			$20, $21,
+			$22, $23
 		)
 	`
 
@@ -69,8 +81,15 @@ func (r *optimizationRepo) Create(ctx context.Context, run *domain.OptimizationR
 		run.CreatedAt,
 		run.UpdatedAt,
 		run.CompletedAt,
+		run.ExternalRef,
+		run.ExternalRefOwner,
+		seedStrategyIDs(run),
+		snapshotJSON,
 	)
 	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.NewDuplicateError("optimization_run", "external_ref", derefString(run.ExternalRef))
+		}
 		return fmt.Errorf("failed to create optimization run: %w", err)
 	}
 
@@ -86,7 +105,10 @@ func (r *optimizationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.O
 			criteria_min_trades, criteria_min_win_rate,
 			status, current_iteration, max_iterations,
 			best_strategy_id, best_result_id, termination_reason,
-			created_at, updated_at, completed_at
+			created_at, updated_at, completed_at,
+			external_ref, external_ref_owner,
+			pause_reason, incidents, seed_strategy_ids, stalled_at,
+			data_snapshot, cancel_reason, cancelled_by
 		FROM optimization_runs
 		WHERE id = $1
 	`
@@ -94,6 +116,26 @@ func (r *optimizationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.O
 	return r.scanRun(r.pool.QueryRow(ctx, query, id))
 }
 
+// GetByExternalRef retrieves an optimization run by the external reference supplied by its owner.
+func (r *optimizationRepo) GetByExternalRef(ctx context.Context, owner, ref string) (*domain.OptimizationRun, error) {
+	query := `
+		SELECT
+			id, name, base_strategy_id, config, mode,
+			criteria_min_sharpe, criteria_min_profit_pct, criteria_max_drawdown_pct,
+			criteria_min_trades, criteria_min_win_rate,
+			status, current_iteration, max_iterations,
+			best_strategy_id, best_result_id, termination_reason,
+			created_at, updated_at, completed_at,
+			external_ref, external_ref_owner,
+			pause_reason, incidents, seed_strategy_ids, stalled_at,
+			data_snapshot, cancel_reason, cancelled_by
+		FROM optimization_runs
+		WHERE external_ref_owner = $1 AND external_ref = $2
+	`
+
+	return r.scanRun(r.pool.QueryRow(ctx, query, owner, ref))
+}
+
 // Update updates an existing optimization run.
 func (r *optimizationRepo) Update(ctx context.Context, run *domain.OptimizationRun) error {
 	configJSON, err := json.Marshal(run.Config)
@@ -178,6 +220,12 @@ func (r *optimizationRepo) List(
 		argNum++
 	}
 
+	if query.StarredBy != nil {
+		conditions = append(conditions, fmt.Sprintf("id IN (SELECT optimization_run_id FROM optimization_run_stars WHERE owner = $%d)", argNum))
+		args = append(args, *query.StarredBy)
+		argNum++
+	}
+
 	whereClause := ""
 	if len(conditions) > 0 {
 		whereClause = "WHERE " + strings.Join(conditions, " AND ")
@@ -219,7 +267,10 @@ func (r *optimizationRepo) List(
 			criteria_min_trades, criteria_min_win_rate,
 			status, current_iteration, max_iterations,
 			best_strategy_id, best_result_id, termination_reason,
-			created_at, updated_at, completed_at
+			created_at, updated_at, completed_at,
+			external_ref, external_ref_owner,
+			pause_reason, incidents, seed_strategy_ids, stalled_at,
+			data_snapshot, cancel_reason, cancelled_by
 		FROM optimization_runs
 		%s
 		ORDER BY %s %s
@@ -242,7 +293,38 @@ func (r *optimizationRepo) List(
 	return runs, totalCount, nil
 }
 
+// seedStrategyIDs returns the seeds of a run, defaulting to its base strategy.
+func seedStrategyIDs(run *domain.OptimizationRun) []uuid.UUID {
+	if len(run.SeedStrategyIDs) == 0 {
+		return []uuid.UUID{run.BaseStrategyID}
+	}
+	return run.SeedStrategyIDs
+}
+
+// closeIncidentSQL closes the latest incident of an auto-paused run.
+// The %s verb is the SQL expression for the resume time.
+const closeIncidentSQL = `CASE
+		WHEN pause_reason = 'auto_paused' AND jsonb_array_length(incidents) > 0
+		THEN jsonb_set(incidents, ARRAY[(jsonb_array_length(incidents) - 1)::text, 'resumed_at'], to_jsonb(%s::timestamptz))
+		ELSE incidents
+	END`
+
+// optimizationRunColumns are the columns scanned by scanRun and scanRuns.
+const optimizationRunColumns = `
+	id, name, base_strategy_id, config, mode,
+	criteria_min_sharpe, criteria_min_profit_pct, criteria_max_drawdown_pct,
+	criteria_min_trades, criteria_min_win_rate,
+	status, current_iteration, max_iterations,
+	best_strategy_id, best_result_id, termination_reason,
+	created_at, updated_at, completed_at,
+	external_ref, external_ref_owner,
+	pause_reason, incidents, seed_strategy_ids, stalled_at,
+	data_snapshot, cancel_reason, cancelled_by`
+
 // UpdateStatus updates the status of an optimization run.
+// Pausing through this method is recorded as a manual pause, and any open
+// outage incident and stall flag are cleared since an operator has taken over
+// the run.
 func (r *optimizationRepo) UpdateStatus(
 	ctx context.Context,
 	id uuid.UUID,
@@ -250,24 +332,36 @@ func (r *optimizationRepo) UpdateStatus(
 ) error {
 	statusStr := status.String()
 
+	var pauseReason *string
+	if status == domain.OptimizationStatusPaused {
+		reason := domain.PauseReasonManual.String()
+		pauseReason = &reason
+	}
+
 	// For terminal statuses, also set completed_at
 	var query string
 	if status.IsTerminal() {
 		query = `
 			UPDATE optimization_runs SET
 				status = $2,
+				pause_reason = $3,
+				incidents = ` + fmt.Sprintf(closeIncidentSQL, "NOW()") + `,
+				stalled_at = NULL,
 				completed_at = NOW()
 			WHERE id = $1
 		`
 	} else {
 		query = `
 			UPDATE optimization_runs SET
-				status = $2
+				status = $2,
+				pause_reason = $3,
+				incidents = ` + fmt.Sprintf(closeIncidentSQL, "NOW()") + `,
+				stalled_at = NULL
 			WHERE id = $1
 		`
 	}
 
-	result, err := r.pool.Exec(ctx, query, id, statusStr)
+	result, err := r.pool.Exec(ctx, query, id, statusStr, pauseReason)
 	if err != nil {
 		return fmt.Errorf("failed to update optimization status: %w", err)
 	}
@@ -279,6 +373,184 @@ func (r *optimizationRepo) UpdateStatus(
 	return nil
 }
 
+// Cancel cancels an optimization run, recording why and by whom, and returns
+// the updated run. Like UpdateStatus, it clears the pause state and stall
+// flag.
+func (r *optimizationRepo) Cancel(ctx context.Context, id uuid.UUID, c domain.Cancellation) (*domain.OptimizationRun, error) {
+	query := `
+		UPDATE optimization_runs SET
+			status = 'cancelled',
+			pause_reason = NULL,
+			incidents = ` + fmt.Sprintf(closeIncidentSQL, "NOW()") + `,
+			stalled_at = NULL,
+			completed_at = NOW(),
+			cancel_reason = $2,
+			cancelled_by = $3
+		WHERE id = $1
+		RETURNING ` + optimizationRunColumns
+
+	run, err := r.scanRun(r.pool.QueryRow(ctx, query, id, nullIfEmptyString(c.Reason), nullIfEmptyString(c.CancelledBy)))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewNotFoundError("optimization_run", id.String())
+		}
+		return nil, fmt.Errorf("failed to cancel optimization run: %w", err)
+	}
+
+	return run, nil
+}
+
+// AutoPause pauses all running optimization runs because of an infrastructure
+// outage, recording an incident on each. It returns the paused runs.
+func (r *optimizationRepo) AutoPause(ctx context.Context, dependencies []string, at time.Time) ([]*domain.OptimizationRun, error) {
+	query := `
+		UPDATE optimization_runs SET
+			status = 'paused',
+			pause_reason = 'auto_paused',
+			incidents = incidents || jsonb_build_array(jsonb_build_object(
+				'dependencies', to_jsonb($1::text[]),
+				'paused_at', to_jsonb($2::timestamptz)
+			))
+		WHERE status = 'running'
+		RETURNING ` + optimizationRunColumns
+
+	rows, err := r.pool.Query(ctx, query, dependencies, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to auto-pause optimization runs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRuns(rows)
+}
+
+// AutoResume resumes the runs paused by AutoPause, closing their open incident.
+// Runs an operator paused, or took over during the outage, are left alone.
+// It returns the resumed runs.
+func (r *optimizationRepo) AutoResume(ctx context.Context, at time.Time) ([]*domain.OptimizationRun, error) {
+	query := `
+		UPDATE optimization_runs SET
+			status = 'running',
+			pause_reason = NULL,
+			incidents = ` + fmt.Sprintf(closeIncidentSQL, "$1") + `
+		WHERE status = 'paused' AND pause_reason = 'auto_paused'
+		RETURNING ` + optimizationRunColumns
+
+	rows, err := r.pool.Query(ctx, query, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to auto-resume optimization runs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRuns(rows)
+}
+
+// optimizationActivitySQL is the time of a run's last activity: a change to
+// the run, a new iteration, or a change to one of its backtest jobs. The %s
+// verb is the SQL reference to the run's row.
+const optimizationActivitySQL = `GREATEST(
+		%[1]s.updated_at,
+		(SELECT MAX(i.created_at) FROM optimization_iterations i WHERE i.optimization_run_id = %[1]s.id),
+		(SELECT MAX(GREATEST(bj.created_at, bj.started_at, bj.completed_at))
+			FROM backtest_jobs bj WHERE bj.optimization_run_id = %[1]s.id)
+	)`
+
+// MarkStalled flags running runs without activity since inactiveSince as
+// stalled at the given time. It returns the flagged runs.
+func (r *optimizationRepo) MarkStalled(ctx context.Context, inactiveSince, at time.Time) ([]*domain.StalledOptimization, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		WITH activity AS (
+			SELECT o.id AS run_id, ` + fmt.Sprintf(optimizationActivitySQL, "o") + ` AS last_activity_at
+			FROM optimization_runs o
+			WHERE o.status = 'running'
+		)
+		UPDATE optimization_runs SET status = 'stalled', stalled_at = $2
+		FROM activity
+		WHERE optimization_runs.id = activity.run_id
+			AND optimization_runs.status = 'running'
+			AND activity.last_activity_at < $1
+		RETURNING optimization_runs.id, activity.last_activity_at
+	`
+
+	rows, err := tx.Query(ctx, query, inactiveSince, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark stalled optimization runs: %w", err)
+	}
+
+	lastActivity := make(map[uuid.UUID]time.Time)
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		var last time.Time
+		if err := rows.Scan(&id, &last); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan stalled optimization run: %w", err)
+		}
+		lastActivity[id] = last
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to mark stalled optimization runs: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	runRows, err := tx.Query(ctx, "SELECT "+optimizationRunColumns+" FROM optimization_runs WHERE id = ANY($1) ORDER BY created_at", ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stalled optimization runs: %w", err)
+	}
+	runs, err := r.scanRuns(runRows)
+	runRows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	stalled := make([]*domain.StalledOptimization, 0, len(runs))
+	for _, run := range runs {
+		stalled = append(stalled, &domain.StalledOptimization{Run: run, LastActivityAt: lastActivity[run.ID]})
+	}
+	return stalled, nil
+}
+
+// ReviveStalled returns stalled runs that got a new iteration or backtest job
+// activity since they were flagged to running. It returns the revived runs.
+func (r *optimizationRepo) ReviveStalled(ctx context.Context) ([]*domain.OptimizationRun, error) {
+	query := `
+		UPDATE optimization_runs o SET status = 'running', stalled_at = NULL
+		WHERE o.status = 'stalled' AND (
+			EXISTS (
+				SELECT 1 FROM optimization_iterations i
+				WHERE i.optimization_run_id = o.id AND i.created_at > o.stalled_at
+			)
+			OR EXISTS (
+				SELECT 1 FROM backtest_jobs bj
+				WHERE bj.optimization_run_id = o.id
+					AND GREATEST(bj.created_at, bj.started_at, bj.completed_at) > o.stalled_at
+			)
+		)
+		RETURNING ` + optimizationRunColumns
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revive stalled optimization runs: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRuns(rows)
+}
+
 // SetBestResult sets the best strategy and result for an optimization run.
 func (r *optimizationRepo) SetBestResult(
 	ctx context.Context,
@@ -317,8 +589,9 @@ func (r *optimizationRepo) Complete(
 			termination_reason = $2,
 			best_strategy_id = COALESCE($3, best_strategy_id),
 			best_result_id = COALESCE($4, best_result_id),
+			stalled_at = NULL,
 			completed_at = NOW()
-		WHERE id = $1 AND status IN ('running', 'paused')
+		WHERE id = $1 AND status IN ('running', 'paused', 'stalled')
 	`
 
 	result, err := r.pool.Exec(ctx, query, id, reason, bestStrategyID, bestResultID)
@@ -348,8 +621,9 @@ func (r *optimizationRepo) Fail(ctx context.Context, id uuid.UUID, reason string
 		UPDATE optimization_runs SET
 			status = 'failed',
 			termination_reason = $2,
+			stalled_at = NULL,
 			completed_at = NOW()
-		WHERE id = $1 AND status IN ('pending', 'running', 'paused')
+		WHERE id = $1 AND status IN ('pending', 'running', 'paused', 'stalled')
 	`
 
 	result, err := r.pool.Exec(ctx, query, id, reason)
@@ -385,18 +659,25 @@ func (r *optimizationRepo) IncrementIteration(ctx context.Context, id uuid.UUID)
 }
 
 // AddIteration adds a new iteration record.
+// The strategy's current code hash is pinned on the iteration, along with the
+// code itself when the run has snapshot_code enabled.
 func (r *optimizationRepo) AddIteration(ctx context.Context, iteration *domain.OptimizationIteration) error {
 	query := `
 		INSERT INTO optimization_iterations (
 			id, optimization_run_id, iteration_number, strategy_id,
 			backtest_job_id, result_id, engineer_changes, analyst_feedback,
-			approval, created_at
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+			approval, created_at, code_hash, code_snapshot
 		)
+		SELECT
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+			s.code_hash,
+			CASE WHEN COALESCE((run.config->>'snapshot_code')::boolean, FALSE) THEN s.code END
+		FROM strategies s, optimization_runs run
+		WHERE s.id = $4 AND run.id = $2
+		RETURNING code_hash, code_snapshot
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	err := r.pool.QueryRow(ctx, query,
 		iteration.ID,
 		iteration.OptimizationRunID,
 		iteration.IterationNumber,
@@ -407,8 +688,11 @@ func (r *optimizationRepo) AddIteration(ctx context.Context, iteration *domain.O
 		nullIfEmptyString(iteration.AnalystFeedback),
 		iteration.Approval.String(),
 		iteration.CreatedAt,
-	)
+	).Scan(&iteration.CodeHash, &iteration.CodeSnapshot)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.NewNotFoundError("strategy or optimization_run", iteration.StrategyID.String())
+		}
 		return fmt.Errorf("failed to add iteration: %w", err)
 	}
 
@@ -421,7 +705,7 @@ func (r *optimizationRepo) GetIterations(ctx context.Context, runID uuid.UUID) (
 		SELECT
 			id, optimization_run_id, iteration_number, strategy_id,
 			backtest_job_id, result_id, engineer_changes, analyst_feedback,
-			approval, created_at
+			approval, created_at, code_hash, code_snapshot
 		FROM optimization_iterations
 		WHERE optimization_run_id = $1
 		ORDER BY iteration_number ASC
@@ -450,6 +734,8 @@ func (r *optimizationRepo) GetIterations(ctx context.Context, runID uuid.UUID) (
 			&analystFeedback,
 			&approvalStr,
 			&iter.CreatedAt,
+			&iter.CodeHash,
+			&iter.CodeSnapshot,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan iteration row: %w", err)
@@ -526,7 +812,7 @@ func (r *optimizationRepo) GetIterationsInTimeRange(ctx context.Context, start,
 		SELECT
 			oi.id, oi.optimization_run_id, oi.iteration_number, oi.strategy_id,
 			oi.backtest_job_id, oi.result_id, oi.engineer_changes, oi.analyst_feedback,
-			oi.approval, oi.created_at
+			oi.approval, oi.created_at, oi.code_hash, oi.code_snapshot
 		FROM optimization_iterations oi
 		WHERE oi.created_at >= $1 AND oi.created_at <= $2
 		ORDER BY oi.created_at ASC
@@ -555,6 +841,161 @@ func (r *optimizationRepo) GetIterationsInTimeRange(ctx context.Context, start,
 			&analystFeedback,
 			&approvalStr,
 			&iter.CreatedAt,
+			&iter.CodeHash,
+			&iter.CodeSnapshot,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan iteration row: %w", err)
+		}
+
+		if engineerChanges != nil {
+			iter.EngineerChanges = *engineerChanges
+		}
+		if analystFeedback != nil {
+			iter.AnalystFeedback = *analystFeedback
+		}
+		iter.Approval = domain.ApprovalStatusFromString(approvalStr)
+
+		iterations = append(iterations, iter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating iteration rows: %w", err)
+	}
+
+	return iterations, nil
+}
+
+// performanceMetricColumns maps each chartable metric to its result column.
+var performanceMetricColumns = map[domain.PerformanceMetric]string{
+	domain.PerformanceMetricSharpe:   "br.sharpe_ratio",
+	domain.PerformanceMetricProfit:   "br.profit_pct",
+	domain.PerformanceMetricDrawdown: "br.max_drawdown_pct",
+}
+
+// GetPerformanceSeries aggregates iteration result metrics into time buckets.
+func (r *optimizationRepo) GetPerformanceSeries(ctx context.Context, q domain.PerformanceQuery) ([]*domain.PerformanceSeries, error) {
+	column, ok := performanceMetricColumns[q.Metric]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown metric %q", domain.ErrInvalidInput, q.Metric)
+	}
+	best := "MAX"
+	if !q.Metric.HigherIsBetter() {
+		best = "MIN"
+	}
+
+	args := []interface{}{q.Start, q.End}
+	bucket := "oi.created_at"
+	groupBy := "1, 2, 3"
+	var field string
+	switch q.Bucket {
+	case domain.PerformanceBucketHourly:
+		field = "hour"
+	case domain.PerformanceBucketDaily:
+		field = "day"
+	default:
+		// One point per iteration, even if two share a timestamp
+		groupBy += ", oi.id"
+	}
+	if field != "" {
+		// Truncate in the query's zone so daily buckets start at its midnight
+		tz := "UTC"
+		if q.Location != nil {
+			tz = q.Location.String()
+		}
+		args = append(args, tz)
+		bucket = fmt.Sprintf("date_trunc('%s', oi.created_at, $3)", field)
+	}
+
+	runID, runName := "NULL::uuid", "''"
+	if q.PerRun {
+		runID, runName = "oi.optimization_run_id", "r.name"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			%[1]s AS run_id, %[2]s AS run_name, %[3]s AS bucket,
+			AVG(%[4]s)::float8, %[5]s(%[4]s)::float8, COUNT(*)
+		FROM optimization_iterations oi
+		JOIN backtest_results br ON br.id = oi.result_id
+		JOIN optimization_runs r ON r.id = oi.optimization_run_id
+		WHERE oi.created_at >= $1 AND oi.created_at <= $2 AND %[4]s IS NOT NULL
+		GROUP BY %[6]s
+		ORDER BY 2, 1, 3
+	`, runID, runName, bucket, column, best, groupBy)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query performance series: %w", err)
+	}
+	defer rows.Close()
+
+	var series []*domain.PerformanceSeries
+	var current *domain.PerformanceSeries
+	for rows.Next() {
+		var (
+			id    *uuid.UUID
+			name  string
+			point domain.PerformancePoint
+		)
+		if err := rows.Scan(&id, &name, &point.Timestamp, &point.Value, &point.Best, &point.Iterations); err != nil {
+			return nil, fmt.Errorf("failed to scan performance row: %w", err)
+		}
+
+		if current == nil || (id != nil && *id != *current.OptimizationRunID) {
+			if !q.PerRun {
+				name = "All optimizations"
+			}
+			current = &domain.PerformanceSeries{OptimizationRunID: id, OptimizationName: name}
+			series = append(series, current)
+		}
+		current.Points = append(current.Points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating performance rows: %w", err)
+	}
+
+	return series, nil
+}
+
+// GetIterationsByResultIDs retrieves the iterations that produced the given results.
+func (r *optimizationRepo) GetIterationsByResultIDs(ctx context.Context, resultIDs []uuid.UUID) ([]*domain.OptimizationIteration, error) {
+	query := `
+		SELECT
+			id, optimization_run_id, iteration_number, strategy_id,
+			backtest_job_id, result_id, engineer_changes, analyst_feedback,
+			approval, created_at, code_hash, code_snapshot
+		FROM optimization_iterations
+		WHERE result_id = ANY($1)
+		ORDER BY optimization_run_id, iteration_number
+	`
+
+	rows, err := r.pool.Query(ctx, query, resultIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query iterations by result: %w", err)
+	}
+	defer rows.Close()
+
+	var iterations []*domain.OptimizationIteration
+	for rows.Next() {
+		iter := &domain.OptimizationIteration{}
+		var engineerChanges, analystFeedback *string
+		var approvalStr string
+
+		err := rows.Scan(
+			&iter.ID,
+			&iter.OptimizationRunID,
+			&iter.IterationNumber,
+			&iter.StrategyID,
+			&iter.BacktestJobID,
+			&iter.ResultID,
+			&engineerChanges,
+			&analystFeedback,
+			&approvalStr,
+			&iter.CreatedAt,
+			&iter.CodeHash,
+			&iter.CodeSnapshot,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan iteration row: %w", err)
@@ -578,6 +1019,219 @@ func (r *optimizationRepo) GetIterationsInTimeRange(ctx context.Context, start,
 	return iterations, nil
 }
 
+// GetQuotaUsage retrieves the quotas of the given runs with the number of their
+// backtest jobs running.
+func (r *optimizationRepo) GetQuotaUsage(ctx context.Context, runIDs []uuid.UUID) (map[uuid.UUID]*domain.OptimizationQuotaUsage, error) {
+	query := `
+		SELECT
+			o.id, o.config->'quota',
+			(SELECT COUNT(*) FROM backtest_jobs bj
+				WHERE bj.optimization_run_id = o.id AND bj.status = 'running')
+		FROM optimization_runs o
+		WHERE o.id = ANY($1) AND jsonb_typeof(o.config->'quota') = 'object'
+	`
+
+	rows, err := r.pool.Query(ctx, query, runIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quota usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := make(map[uuid.UUID]*domain.OptimizationQuotaUsage)
+	for rows.Next() {
+		var id uuid.UUID
+		var quotaJSON []byte
+		u := &domain.OptimizationQuotaUsage{}
+		if err := rows.Scan(&id, &quotaJSON, &u.RunningJobs); err != nil {
+			return nil, fmt.Errorf("failed to scan quota usage row: %w", err)
+		}
+		if err := json.Unmarshal(quotaJSON, &u.Quota); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal quota: %w", err)
+		}
+		usage[id] = u
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quota usage rows: %w", err)
+	}
+
+	return usage, nil
+}
+
+// ClaimNextAction determines the next step of a run and, if it is claimable,
+// claims it for claimant until now+lease. The run row is locked while the step
+// is determined, so concurrent callers see a consistent state and only one of
+// them gets a given claimable step; the others get none with the holder set.
+// Calling again with the same claimant renews the claim.
+func (r *optimizationRepo) ClaimNextAction(
+	ctx context.Context,
+	runID uuid.UUID,
+	claimant string,
+	lease time.Duration,
+	now time.Time,
+) (*domain.OrchestratorAction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	run, err := r.scanRun(tx.QueryRow(ctx, "SELECT "+optimizationRunColumns+" FROM optimization_runs WHERE id = $1 FOR UPDATE", runID))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewNotFoundError("optimization_run", runID.String())
+		}
+		return nil, err
+	}
+
+	// An orchestrator asking for work is activity: a stalled run resumes
+	revived := false
+	if run.Status == domain.OptimizationStatusStalled {
+		_, err := tx.Exec(ctx, `UPDATE optimization_runs SET status = 'running', stalled_at = NULL WHERE id = $1`, runID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to revive stalled optimization run: %w", err)
+		}
+		run.Status = domain.OptimizationStatusRunning
+		run.StalledAt = nil
+		revived = true
+	}
+
+	latest, err := r.getLatestIterationState(ctx, tx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := domain.NextOrchestratorAction(run, latest)
+	if !action.Type.IsClaimable() {
+		if revived {
+			if err := tx.Commit(ctx); err != nil {
+				return nil, fmt.Errorf("failed to commit transaction: %w", err)
+			}
+		}
+		return action, nil
+	}
+
+	claim := &domain.OrchestratorActionClaim{}
+	var actionStr string
+	err = tx.QueryRow(ctx, `
+		SELECT optimization_run_id, action, iteration_number, claimed_by, claimed_at, expires_at
+		FROM optimization_action_claims
+		WHERE optimization_run_id = $1
+	`, runID).Scan(
+		&claim.RunID,
+		&actionStr,
+		&claim.IterationNumber,
+		&claim.ClaimedBy,
+		&claim.ClaimedAt,
+		&claim.ExpiresAt,
+	)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get action claim: %w", err)
+		}
+		claim = nil
+	} else {
+		claim.Action = domain.OrchestratorActionType(actionStr)
+	}
+
+	if claim.Blocks(action, claimant, now) {
+		if revived {
+			if err := tx.Commit(ctx); err != nil {
+				return nil, fmt.Errorf("failed to commit transaction: %w", err)
+			}
+		}
+		return &domain.OrchestratorAction{
+			RunID:           runID,
+			Type:            domain.OrchestratorActionNone,
+			IterationNumber: action.IterationNumber,
+			Reason:          fmt.Sprintf("%s is claimed by %s", action.Type, claim.ClaimedBy),
+			ClaimedBy:       &claim.ClaimedBy,
+			ClaimExpiresAt:  &claim.ExpiresAt,
+		}, nil
+	}
+
+	expiresAt := now.Add(lease)
+	_, err = tx.Exec(ctx, `
+		INSERT INTO optimization_action_claims (
+			optimization_run_id, action, iteration_number, claimed_by, claimed_at, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (optimization_run_id) DO UPDATE SET
+			action = EXCLUDED.action,
+			iteration_number = EXCLUDED.iteration_number,
+			claimed_by = EXCLUDED.claimed_by,
+			claimed_at = EXCLUDED.claimed_at,
+			expires_at = EXCLUDED.expires_at
+	`, runID, action.Type.String(), action.IterationNumber, claimant, now, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim action: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	action.ClaimedBy = &claimant
+	action.ClaimExpiresAt = &expiresAt
+	return action, nil
+}
+
+// getLatestIterationState retrieves a run's highest-numbered iteration with the
+// status and result of its backtest job, or nil if the run has no iterations.
+func (r *optimizationRepo) getLatestIterationState(ctx context.Context, tx pgx.Tx, runID uuid.UUID) (*domain.OptimizationIterationState, error) {
+	query := `
+		SELECT
+			i.id, i.optimization_run_id, i.iteration_number, i.strategy_id,
+			i.backtest_job_id, i.result_id, i.engineer_changes, i.analyst_feedback,
+			i.approval, i.created_at, i.code_hash, i.code_snapshot,
+			bj.status, br.id
+		FROM optimization_iterations i
+		JOIN backtest_jobs bj ON bj.id = i.backtest_job_id
+		LEFT JOIN backtest_results br ON br.job_id = bj.id
+		WHERE i.optimization_run_id = $1
+		ORDER BY i.iteration_number DESC, i.created_at DESC
+		LIMIT 1
+	`
+
+	iter := &domain.OptimizationIteration{}
+	state := &domain.OptimizationIterationState{Iteration: iter}
+	var engineerChanges, analystFeedback *string
+	var approvalStr, jobStatusStr string
+
+	err := tx.QueryRow(ctx, query, runID).Scan(
+		&iter.ID,
+		&iter.OptimizationRunID,
+		&iter.IterationNumber,
+		&iter.StrategyID,
+		&iter.BacktestJobID,
+		&iter.ResultID,
+		&engineerChanges,
+		&analystFeedback,
+		&approvalStr,
+		&iter.CreatedAt,
+		&iter.CodeHash,
+		&iter.CodeSnapshot,
+		&jobStatusStr,
+		&state.ResultID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest iteration: %w", err)
+	}
+
+	if engineerChanges != nil {
+		iter.EngineerChanges = *engineerChanges
+	}
+	if analystFeedback != nil {
+		iter.AnalystFeedback = *analystFeedback
+	}
+	iter.Approval = domain.ApprovalStatusFromString(approvalStr)
+	state.JobStatus = domain.JobStatusFromString(jobStatusStr)
+
+	return state, nil
+}
+
 // scanRun scans a single row into an OptimizationRun.
 func (r *optimizationRepo) scanRun(row pgx.Row) (*domain.OptimizationRun, error) {
 	run := &domain.OptimizationRun{}
@@ -585,7 +1239,8 @@ func (r *optimizationRepo) scanRun(row pgx.Row) (*domain.OptimizationRun, error)
 	var modeStr, statusStr string
 	var minSharpe, minProfitPct, maxDrawdownPct, minWinRate *float64
 	var minTrades *int
-	var terminationReason *string
+	var terminationReason, pauseReason *string
+	var incidentsJSON, snapshotJSON []byte
 
 	err := row.Scan(
 		&run.ID,
@@ -607,6 +1262,15 @@ func (r *optimizationRepo) scanRun(row pgx.Row) (*domain.OptimizationRun, error)
 		&run.CreatedAt,
 		&run.UpdatedAt,
 		&run.CompletedAt,
+		&run.ExternalRef,
+		&run.ExternalRefOwner,
+		&pauseReason,
+		&incidentsJSON,
+		&run.SeedStrategyIDs,
+		&run.StalledAt,
+		&snapshotJSON,
+		&run.CancelReason,
+		&run.CancelledBy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -642,10 +1306,42 @@ func (r *optimizationRepo) scanRun(row pgx.Row) (*domain.OptimizationRun, error)
 	if terminationReason != nil {
 		run.TerminationReason = *terminationReason
 	}
+	if err := applyPauseState(run, pauseReason, incidentsJSON); err != nil {
+		return nil, err
+	}
+	if err := applyDataSnapshot(run, snapshotJSON); err != nil {
+		return nil, err
+	}
 
 	return run, nil
 }
 
+// applyPauseState populates the pause reason and incident history of a run.
+func applyPauseState(run *domain.OptimizationRun, pauseReason *string, incidentsJSON []byte) error {
+	if pauseReason != nil {
+		reason := domain.PauseReason(*pauseReason)
+		run.PauseReason = &reason
+	}
+	if len(incidentsJSON) > 0 {
+		if err := json.Unmarshal(incidentsJSON, &run.Incidents); err != nil {
+			return fmt.Errorf("failed to unmarshal incidents: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyDataSnapshot populates the data snapshot of a run.
+func applyDataSnapshot(run *domain.OptimizationRun, snapshotJSON []byte) error {
+	if len(snapshotJSON) == 0 {
+		return nil
+	}
+	run.DataSnapshot = &domain.OptimizationDataSnapshot{}
+	if err := json.Unmarshal(snapshotJSON, run.DataSnapshot); err != nil {
+		return fmt.Errorf("failed to unmarshal data snapshot: %w", err)
+	}
+	return nil
+}
+
 // scanRuns scans multiple rows into a slice of OptimizationRun.
 func (r *optimizationRepo) scanRuns(rows pgx.Rows) ([]*domain.OptimizationRun, error) {
 	var runs []*domain.OptimizationRun
@@ -656,7 +1352,8 @@ func (r *optimizationRepo) scanRuns(rows pgx.Rows) ([]*domain.OptimizationRun, e
 		var modeStr, statusStr string
 		var minSharpe, minProfitPct, maxDrawdownPct, minWinRate *float64
 		var minTrades *int
-		var terminationReason *string
+		var terminationReason, pauseReason *string
+		var incidentsJSON, snapshotJSON []byte
 
 		err := rows.Scan(
 			&run.ID,
@@ -678,6 +1375,15 @@ func (r *optimizationRepo) scanRuns(rows pgx.Rows) ([]*domain.OptimizationRun, e
 			&run.CreatedAt,
 			&run.UpdatedAt,
 			&run.CompletedAt,
+			&run.ExternalRef,
+			&run.ExternalRefOwner,
+			&pauseReason,
+			&incidentsJSON,
+			&run.SeedStrategyIDs,
+			&run.StalledAt,
+			&snapshotJSON,
+			&run.CancelReason,
+			&run.CancelledBy,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan optimization run row: %w", err)
@@ -709,6 +1415,12 @@ func (r *optimizationRepo) scanRuns(rows pgx.Rows) ([]*domain.OptimizationRun, e
 		if terminationReason != nil {
 			run.TerminationReason = *terminationReason
 		}
+		if err := applyPauseState(run, pauseReason, incidentsJSON); err != nil {
+			return nil, err
+		}
+		if err := applyDataSnapshot(run, snapshotJSON); err != nil {
+			return nil, err
+		}
 
 		runs = append(runs, run)
 	}
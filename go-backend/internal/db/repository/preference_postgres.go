@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// preferenceRepo implements PreferenceRepository using PostgreSQL.
+type preferenceRepo struct {
+	pool *db.Pool
+}
+
+// NewPreferenceRepository creates a new PostgreSQL preference repository.
+func NewPreferenceRepository(pool *db.Pool) PreferenceRepository {
+	return &preferenceRepo{pool: pool}
+}
+
+// Get retrieves a single preference by owner and key.
+func (r *preferenceRepo) Get(ctx context.Context, owner, key string) (*domain.UserPreference, error) {
+	query := `
+		SELECT owner, key, value, created_at, updated_at
+		FROM user_preferences
+		WHERE owner = $1 AND key = $2
+	`
+
+	var p domain.UserPreference
+	err := r.pool.QueryRow(ctx, query, owner, key).Scan(
+		&p.Owner, &p.Key, &p.Value, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("user_preference", key)
+		}
+		return nil, fmt.Errorf("failed to get preference: %w", err)
+	}
+
+	return &p, nil
+}
+
+// List retrieves all preferences for an owner ordered by key.
+func (r *preferenceRepo) List(ctx context.Context, owner string) ([]*domain.UserPreference, error) {
+	query := `
+		SELECT owner, key, value, created_at, updated_at
+		FROM user_preferences
+		WHERE owner = $1
+		ORDER BY key ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preferences: %w", err)
+	}
+	defer rows.Close()
+
+	var prefs []*domain.UserPreference
+	for rows.Next() {
+		var p domain.UserPreference
+		if err := rows.Scan(&p.Owner, &p.Key, &p.Value, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan preference: %w", err)
+		}
+		prefs = append(prefs, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// Upsert creates or replaces a preference.
+func (r *preferenceRepo) Upsert(ctx context.Context, pref *domain.UserPreference) error {
+	query := `
+		INSERT INTO user_preferences (owner, key, value, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (owner, key) DO UPDATE SET value = EXCLUDED.value
+		RETURNING created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		pref.Owner,
+		pref.Key,
+		pref.Value,
+		pref.CreatedAt,
+		pref.UpdatedAt,
+	).Scan(&pref.CreatedAt, &pref.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert preference: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a preference.
+func (r *preferenceRepo) Delete(ctx context.Context, owner, key string) error {
+	result, err := r.pool.Exec(ctx, "DELETE FROM user_preferences WHERE owner = $1 AND key = $2", owner, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete preference: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("user_preference", key)
+	}
+
+	return nil
+}
+
+// Ensure interface implementation at compile time.
+var _ PreferenceRepository = (*preferenceRepo)(nil)
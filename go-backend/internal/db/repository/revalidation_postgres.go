@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// revalidationRepo implements RevalidationRepository using PostgreSQL.
+type revalidationRepo struct {
+	pool *db.Pool
+}
+
+// NewRevalidationRepository creates a new PostgreSQL re-validation repository.
+func NewRevalidationRepository(pool *db.Pool) RevalidationRepository {
+	return &revalidationRepo{pool: pool}
+}
+
+// revalidationColumns are the columns scanned by scanRun.
+const revalidationColumns = `
+	id, image, strategy_ids, status, requested_by,
+	validated, valid, invalid, errors,
+	error, created_at, started_at, completed_at
+`
+
+// Create creates a new re-validation run.
+func (r *revalidationRepo) Create(ctx context.Context, run *domain.RevalidationRun) error {
+	query := `
+		INSERT INTO revalidation_runs (id, image, strategy_ids, status, requested_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		run.ID,
+		run.Image,
+		run.StrategyIDs,
+		run.Status.String(),
+		run.RequestedBy,
+		run.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create revalidation run: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a re-validation run by ID.
+func (r *revalidationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.RevalidationRun, error) {
+	query := `SELECT ` + revalidationColumns + ` FROM revalidation_runs WHERE id = $1`
+
+	run, err := r.scanRun(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("revalidation", id.String())
+		}
+		return nil, fmt.Errorf("failed to get revalidation run: %w", err)
+	}
+
+	return run, nil
+}
+
+// List retrieves the most recent re-validation runs, newest first.
+func (r *revalidationRepo) List(ctx context.Context, limit int) ([]*domain.RevalidationRun, error) {
+	query := `SELECT ` + revalidationColumns + ` FROM revalidation_runs ORDER BY created_at DESC LIMIT $1`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revalidation runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*domain.RevalidationRun
+	for rows.Next() {
+		run, err := r.scanRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan revalidation run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating revalidation runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// ClaimNext marks the oldest pending re-validation run as running and
+// returns it, or nil if none is pending. Uses FOR UPDATE SKIP LOCKED so
+// concurrent workers never claim the same run.
+func (r *revalidationRepo) ClaimNext(ctx context.Context) (*domain.RevalidationRun, error) {
+	query := `
+		UPDATE revalidation_runs SET status = 'running', started_at = NOW()
+		WHERE id = (
+			SELECT id FROM revalidation_runs
+			WHERE status = 'pending'
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + revalidationColumns
+
+	run, err := r.scanRun(r.pool.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim revalidation run: %w", err)
+	}
+
+	return run, nil
+}
+
+// Record stores a strategy's validity on the run's image and counts it
+// towards the run's progress, in one transaction. A nil validation counts
+// the strategy as one that could not be validated.
+func (r *revalidationRepo) Record(ctx context.Context, runID uuid.UUID, strategyID uuid.UUID, v *domain.StrategyImageValidation) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	valid, invalid, failed := 0, 0, 0
+	switch {
+	case v == nil:
+		failed = 1
+	case v.Valid:
+		valid = 1
+	default:
+		invalid = 1
+	}
+
+	if v != nil {
+		errs := v.Errors
+		if errs == nil {
+			errs = []string{}
+		}
+		query := `
+			INSERT INTO strategy_image_validations (strategy_id, image, valid, errors, run_id, validated_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (strategy_id, image) DO UPDATE SET
+				valid = EXCLUDED.valid,
+				errors = EXCLUDED.errors,
+				run_id = EXCLUDED.run_id,
+				validated_at = EXCLUDED.validated_at
+		`
+		_, err := tx.Exec(ctx, query, strategyID, v.Image, v.Valid, errs, v.RunID, v.ValidatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record strategy validation: %w", err)
+		}
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE revalidation_runs SET
+			validated = validated + 1,
+			valid = valid + $2,
+			invalid = invalid + $3,
+			errors = errors + $4
+		WHERE id = $1 AND status = 'running'
+	`, runID, valid, invalid, failed)
+	if err != nil {
+		return fmt.Errorf("failed to update revalidation progress: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("revalidation", runID.String())
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Complete marks a running re-validation run as completed.
+func (r *revalidationRepo) Complete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE revalidation_runs SET status = 'completed', completed_at = NOW()
+		WHERE id = $1 AND status = 'running'
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to complete revalidation run: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("revalidation", id.String())
+	}
+
+	return nil
+}
+
+// Fail marks a re-validation run as failed with an error message.
+func (r *revalidationRepo) Fail(ctx context.Context, id uuid.UUID, errMsg string) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE revalidation_runs SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1
+	`, id, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to mark revalidation run failed: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("revalidation", id.String())
+	}
+
+	return nil
+}
+
+// RequeueRunning returns running re-validation runs to pending, for runs
+// left behind by a worker that stopped mid-run. Their progress is reset, as
+// the whole run is validated again. It returns how many were requeued.
+func (r *revalidationRepo) RequeueRunning(ctx context.Context) (int, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE revalidation_runs SET
+			status = 'pending', started_at = NULL,
+			validated = 0, valid = 0, invalid = 0, errors = 0
+		WHERE status = 'running'
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue revalidation runs: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// GetCompatibility retrieves the validity of the given strategies on the
+// given images. With no images, every image any of the strategies was
+// validated on is included. Deleted strategies are left out; rows are
+// ordered by strategy name.
+func (r *revalidationRepo) GetCompatibility(ctx context.Context, strategyIDs []uuid.UUID, images []string) ([]string, []domain.CompatibilityRow, error) {
+	if len(images) == 0 {
+		rows, err := r.pool.Query(ctx, `
+			SELECT DISTINCT image FROM strategy_image_validations
+			WHERE strategy_id = ANY($1)
+			ORDER BY image
+		`, strategyIDs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list validated images: %w", err)
+		}
+		images, err = pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return nil, nil, fmt.Errorf("error iterating validated images: %w", err)
+		}
+	}
+
+	query := `
+		SELECT s.id, s.name, v.image, v.valid, v.errors, v.run_id, v.validated_at
+		FROM strategies s
+		LEFT JOIN strategy_image_validations v
+			ON v.strategy_id = s.id AND v.image = ANY($2)
+		WHERE s.id = ANY($1)
+		ORDER BY s.name, s.id
+	`
+
+	rows, err := r.pool.Query(ctx, query, strategyIDs, images)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get strategy compatibility: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.CompatibilityRow
+	for rows.Next() {
+		var id uuid.UUID
+		var name string
+		var image *string
+		var valid *bool
+		var errs []string
+		var runID *uuid.UUID
+		var validatedAt *time.Time
+		if err := rows.Scan(&id, &name, &image, &valid, &errs, &runID, &validatedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan strategy compatibility: %w", err)
+		}
+
+		if len(result) == 0 || result[len(result)-1].StrategyID != id {
+			result = append(result, domain.CompatibilityRow{
+				StrategyID:   id,
+				StrategyName: name,
+				Images:       make(map[string]*domain.StrategyImageValidation),
+			})
+		}
+		if image != nil {
+			result[len(result)-1].Images[*image] = &domain.StrategyImageValidation{
+				StrategyID:  id,
+				Image:       *image,
+				Valid:       *valid,
+				Errors:      errs,
+				RunID:       runID,
+				ValidatedAt: *validatedAt,
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating strategy compatibility: %w", err)
+	}
+
+	return images, result, nil
+}
+
+// scanRun scans a re-validation run row selected with revalidationColumns.
+func (r *revalidationRepo) scanRun(row pgx.Row) (*domain.RevalidationRun, error) {
+	run := &domain.RevalidationRun{}
+	var status string
+
+	err := row.Scan(
+		&run.ID,
+		&run.Image,
+		&run.StrategyIDs,
+		&status,
+		&run.RequestedBy,
+		&run.Validated,
+		&run.Valid,
+		&run.Invalid,
+		&run.Errors,
+		&run.Error,
+		&run.CreatedAt,
+		&run.StartedAt,
+		&run.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Status = domain.RevalidationStatus(status)
+	return run, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ RevalidationRepository = (*revalidationRepo)(nil)
@@ -31,6 +31,60 @@ func NewScoutRepository(pool *db.Pool) ScoutRepository {
 
 // CreateRun creates a new scout run.
 func (r *scoutRepo) CreateRun(ctx context.Context, run *domain.ScoutRun) error {
+	return insertScoutRun(ctx, r.pool, run)
+}
+
+// CreateRunWithinLimits creates a scout run unless it would break its
+// source's etiquette limits. Runs of the same source are created one at a
+// time under an advisory lock, so overlapping triggers, even on different
+// instances, cannot both pass the check.
+func (r *scoutRepo) CreateRunWithinLimits(ctx context.Context, run *domain.ScoutRun, limits domain.ScoutSourceLimits) error {
+	if limits.IsZero() {
+		return r.CreateRun(ctx, run)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('scout_source:' || $1))`, run.Source); err != nil {
+		return fmt.Errorf("failed to lock scout source: %w", err)
+	}
+
+	usage := &domain.ScoutSourceUsage{}
+	err = tx.QueryRow(ctx, `
+		SELECT
+			MAX(created_at),
+			COUNT(*) FILTER (WHERE created_at > $2),
+			MIN(created_at) FILTER (WHERE created_at > $2)
+		FROM scout_runs
+		WHERE source = $1
+	`, run.Source, run.CreatedAt.Add(-domain.ScoutDailyWindow)).Scan(
+		&usage.LastRunAt, &usage.RunsInWindow, &usage.OldestInWindow,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get scout source usage: %w", err)
+	}
+
+	if err := limits.Check(run.Source, usage, run.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := insertScoutRun(ctx, tx, run); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertScoutRun stores a scout run on the pool or within a transaction.
+func insertScoutRun(ctx context.Context, db execer, run *domain.ScoutRun) error {
 	var metricsJSON []byte
 	var err error
 	if run.Metrics != nil {
@@ -52,7 +106,7 @@ func (r *scoutRepo) CreateRun(ctx context.Context, run *domain.ScoutRun) error {
 		)
 	`
 
-	_, err = r.pool.Exec(ctx, query,
+	_, err = db.Exec(ctx, query,
 		run.ID,
 		run.TriggerType.String(),
 		nullIfEmptyString(run.TriggeredBy),
@@ -78,7 +132,8 @@ func (r *scoutRepo) GetRunByID(ctx context.Context, id uuid.UUID) (*domain.Scout
 		SELECT
 			id, trigger_type, triggered_by, source, max_strategies,
 			status, error_message, metrics,
-			created_at, started_at, completed_at
+			created_at, started_at, completed_at,
+			cancel_reason, cancelled_by
 		FROM scout_runs
 		WHERE id = $1
 	`
@@ -168,11 +223,49 @@ func (r *scoutRepo) UpdateRunStatus(
 	return nil
 }
 
-// CompleteRun marks a scout run as completed with metrics.
+// CancelRun cancels a pending or running scout run, recording why and by
+// whom. Cancelling a cancelled run again is a no-op, except that it fills in
+// a reason or canceller not recorded the first time.
+func (r *scoutRepo) CancelRun(ctx context.Context, id uuid.UUID, c domain.Cancellation) error {
+	query := `
+		UPDATE scout_runs SET
+			status = 'cancelled',
+			completed_at = COALESCE(completed_at, NOW()),
+			cancel_reason = COALESCE(cancel_reason, $2),
+			cancelled_by = COALESCE(cancelled_by, $3)
+		WHERE id = $1 AND status IN ('pending', 'running', 'cancelled')
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, nullIfEmptyString(c.Reason), nullIfEmptyString(c.CancelledBy))
+	if err != nil {
+		return fmt.Errorf("failed to cancel scout run: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		// Check if run exists and its status
+		var status string
+		err := r.pool.QueryRow(ctx, "SELECT status FROM scout_runs WHERE id = $1", id).Scan(&status)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.NewNotFoundError("scout_run", id.String())
+			}
+			return fmt.Errorf("failed to check run status: %w", err)
+		}
+		return fmt.Errorf("cannot cancel scout run in status: %s", status)
+	}
+
+	return nil
+}
+
+// CompleteRun marks a scout run as completed with metrics. Metrics that fail
+// validation are rejected.
 func (r *scoutRepo) CompleteRun(ctx context.Context, id uuid.UUID, metrics *domain.ScoutMetrics) error {
 	var metricsJSON []byte
 	var err error
 	if metrics != nil {
+		if err := metrics.Validate(); err != nil {
+			return err
+		}
 		metricsJSON, err = json.Marshal(metrics)
 		if err != nil {
 			return fmt.Errorf("failed to marshal metrics: %w", err)
@@ -298,7 +391,8 @@ func (r *scoutRepo) ListRuns(
 		SELECT
 			id, trigger_type, triggered_by, source, max_strategies,
 			status, error_message, metrics,
-			created_at, started_at, completed_at
+			created_at, started_at, completed_at,
+			cancel_reason, cancelled_by
 		FROM scout_runs
 		%s
 		ORDER BY %s %s
@@ -327,7 +421,8 @@ func (r *scoutRepo) GetActiveRun(ctx context.Context) (*domain.ScoutRun, error)
 		SELECT
 			id, trigger_type, triggered_by, source, max_strategies,
 			status, error_message, metrics,
-			created_at, started_at, completed_at
+			created_at, started_at, completed_at,
+			cancel_reason, cancelled_by
 		FROM scout_runs
 		WHERE status IN ('pending', 'running')
 		ORDER BY created_at DESC
@@ -345,6 +440,145 @@ func (r *scoutRepo) GetActiveRun(ctx context.Context) (*domain.ScoutRun, error)
 	return run, nil
 }
 
+// scoutMetricCountsSQL sums the pipeline counts of a metrics JSON expression (%[1]s).
+const scoutMetricCountsSQL = `
+	COALESCE(SUM((%[1]s->>'found')::bigint), 0),
+	COALESCE(SUM((%[1]s->>'total_fetched')::bigint), 0),
+	COALESCE(SUM((%[1]s->>'parsed')::bigint), 0),
+	COALESCE(SUM((%[1]s->>'validated')::bigint), 0),
+	COALESCE(SUM((%[1]s->>'validation_failed')::bigint), 0),
+	COALESCE(SUM((%[1]s->>'duplicates_removed')::bigint), 0),
+	COALESCE(SUM((%[1]s->>'submitted')::bigint), 0)`
+
+// scoutMetricsObjectSQL returns the member of r.metrics named by key (%[1]s)
+// if it is an object, or an empty object.
+const scoutMetricsObjectSQL = `CASE WHEN jsonb_typeof(r.metrics->'%[1]s') = 'object'
+	THEN r.metrics->'%[1]s' ELSE '{}'::jsonb END`
+
+// AggregateMetrics sums the metrics of the completed scout runs matching the
+// query, with per-source and per-error-category breakdowns.
+func (r *scoutRepo) AggregateMetrics(ctx context.Context, query domain.ScoutMetricsQuery) (*domain.ScoutMetricsSummary, error) {
+	conditions := []string{"r.status = 'completed'"}
+	var args []interface{}
+	argNum := 1
+
+	if query.Source != nil {
+		conditions = append(conditions, fmt.Sprintf("(r.source = $%d OR r.metrics->'sources' ? $%d)", argNum, argNum))
+		args = append(args, *query.Source)
+		argNum++
+	}
+
+	if query.TimeRange != nil {
+		conditions = append(conditions, fmt.Sprintf("r.completed_at >= $%d", argNum))
+		args = append(args, query.TimeRange.Start)
+		argNum++
+		conditions = append(conditions, fmt.Sprintf("r.completed_at <= $%d", argNum))
+		args = append(args, query.TimeRange.End)
+		argNum++
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	summary := &domain.ScoutMetricsSummary{}
+	totals := &summary.Metrics
+
+	totalsQuery := `SELECT COUNT(*),` + fmt.Sprintf(scoutMetricCountsSQL, "r.metrics") + `
+		FROM scout_runs r ` + whereClause
+	err := r.pool.QueryRow(ctx, totalsQuery, args...).Scan(
+		&summary.Runs,
+		&totals.Found,
+		&totals.TotalFetched,
+		&totals.Parsed,
+		&totals.Validated,
+		&totals.ValidationFailed,
+		&totals.DuplicatesRemoved,
+		&totals.Submitted,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate scout metrics: %w", err)
+	}
+
+	// Runs without a per-source breakdown count towards their own source
+	sourcesQuery := `
+		SELECT src.name,` + fmt.Sprintf(scoutMetricCountsSQL, "src.m") + `
+		FROM (
+			SELECT s.key AS name, s.value AS m
+			FROM scout_runs r
+			CROSS JOIN LATERAL jsonb_each(` + fmt.Sprintf(scoutMetricsObjectSQL, "sources") + `) s
+			` + whereClause + `
+			UNION ALL
+			SELECT r.source, r.metrics
+			FROM scout_runs r
+			` + whereClause + ` AND jsonb_typeof(r.metrics->'sources') IS DISTINCT FROM 'object'
+		) src
+		GROUP BY src.name
+		ORDER BY src.name
+	`
+	rows, err := r.pool.Query(ctx, sourcesQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate scout metrics by source: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		source := &domain.ScoutSourceMetrics{}
+		if err := rows.Scan(
+			&name,
+			&source.Found,
+			&source.TotalFetched,
+			&source.Parsed,
+			&source.Validated,
+			&source.ValidationFailed,
+			&source.DuplicatesRemoved,
+			&source.Submitted,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan scout source metrics: %w", err)
+		}
+		if totals.Sources == nil {
+			totals.Sources = make(map[string]*domain.ScoutSourceMetrics)
+		}
+		totals.Sources[name] = source
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating scout source metrics: %w", err)
+	}
+	rows.Close()
+
+	errorsQuery := `
+		SELECT e.key, SUM(e.value::bigint)
+		FROM scout_runs r
+		CROSS JOIN LATERAL jsonb_each_text(` + fmt.Sprintf(scoutMetricsObjectSQL, "error_categories") + `) e
+		` + whereClause + `
+		GROUP BY e.key
+		ORDER BY e.key
+	`
+	errorRows, err := r.pool.Query(ctx, errorsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate scout metrics by error category: %w", err)
+	}
+	defer errorRows.Close()
+
+	for errorRows.Next() {
+		var category string
+		var count int
+		if err := errorRows.Scan(&category, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan scout error category: %w", err)
+		}
+		if totals.ErrorCategories == nil {
+			totals.ErrorCategories = make(map[string]int)
+		}
+		totals.ErrorCategories[category] = count
+	}
+	if err := errorRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating scout error categories: %w", err)
+	}
+
+	summary.ValidationRate = totals.ValidationRate()
+	summary.SubmissionRate = totals.SubmissionRate()
+	return summary, nil
+}
+
 // =============================================================================
 // Schedule Operations
 // =============================================================================
@@ -355,11 +589,11 @@ func (r *scoutRepo) CreateSchedule(ctx context.Context, schedule *domain.ScoutSc
 		INSERT INTO scout_schedules (
 			id, name, cron_expression, source, max_strategies,
 			enabled, last_run_id, last_run_at, next_run_at,
-			created_at, updated_at
+			credential_secret_id, created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7, $8, $9,
-			$10, $11
+			$10, $11, $12
 		)
 	`
 
@@ -373,10 +607,14 @@ func (r *scoutRepo) CreateSchedule(ctx context.Context, schedule *domain.ScoutSc
 		schedule.LastRunID,
 		schedule.LastRunAt,
 		schedule.NextRunAt,
+		schedule.CredentialSecretID,
 		schedule.CreatedAt,
 		schedule.UpdatedAt,
 	)
 	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%w: credential secret does not exist", domain.ErrInvalidInput)
+		}
 		return fmt.Errorf("failed to create scout schedule: %w", err)
 	}
 
@@ -389,7 +627,7 @@ func (r *scoutRepo) GetScheduleByID(ctx context.Context, id uuid.UUID) (*domain.
 		SELECT
 			id, name, cron_expression, source, max_strategies,
 			enabled, last_run_id, last_run_at, next_run_at,
-			created_at, updated_at
+			credential_secret_id, created_at, updated_at
 		FROM scout_schedules
 		WHERE id = $1
 	`
@@ -403,7 +641,7 @@ func (r *scoutRepo) GetScheduleByName(ctx context.Context, name string) (*domain
 		SELECT
 			id, name, cron_expression, source, max_strategies,
 			enabled, last_run_id, last_run_at, next_run_at,
-			created_at, updated_at
+			credential_secret_id, created_at, updated_at
 		FROM scout_schedules
 		WHERE name = $1
 	`
@@ -423,6 +661,7 @@ func (r *scoutRepo) UpdateSchedule(ctx context.Context, schedule *domain.ScoutSc
 			last_run_id = $7,
 			last_run_at = $8,
 			next_run_at = $9,
+			credential_secret_id = $10,
 			updated_at = NOW()
 		WHERE id = $1
 	`
@@ -437,8 +676,12 @@ func (r *scoutRepo) UpdateSchedule(ctx context.Context, schedule *domain.ScoutSc
 		schedule.LastRunID,
 		schedule.LastRunAt,
 		schedule.NextRunAt,
+		schedule.CredentialSecretID,
 	)
 	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%w: credential secret does not exist", domain.ErrInvalidInput)
+		}
 		return fmt.Errorf("failed to update scout schedule: %w", err)
 	}
 
@@ -533,7 +776,7 @@ func (r *scoutRepo) ListSchedules(
 		SELECT
 			id, name, cron_expression, source, max_strategies,
 			enabled, last_run_id, last_run_at, next_run_at,
-			created_at, updated_at
+			credential_secret_id, created_at, updated_at
 		FROM scout_schedules
 		%s
 		ORDER BY %s %s
@@ -562,7 +805,7 @@ func (r *scoutRepo) GetActiveSchedules(ctx context.Context) ([]*domain.ScoutSche
 		SELECT
 			id, name, cron_expression, source, max_strategies,
 			enabled, last_run_id, last_run_at, next_run_at,
-			created_at, updated_at
+			credential_secret_id, created_at, updated_at
 		FROM scout_schedules
 		WHERE enabled = true
 		ORDER BY next_run_at ASC NULLS LAST
@@ -620,10 +863,169 @@ func (r *scoutRepo) UpdateScheduleNextRun(ctx context.Context, scheduleID uuid.U
 	return nil
 }
 
+// =============================================================================
+// Discovery Operations
+// =============================================================================
+
+// scoutDiscoveryColumns are the columns scanned by scanDiscovery.
+const scoutDiscoveryColumns = `
+	id, run_id, name, source, source_url, code_hash, validation_status,
+	validation_errors, outcome, strategy_id, outcome_detail, discovered_at
+`
+
+// RecordDiscoveries stores the discoveries of a scout run in one
+// transaction. A strategy already recorded for the run, by code hash, is
+// updated: reported names and URLs fill in the record, and an import outcome
+// replaces not_submitted. An imported strategy stays imported. The
+// discoveries are updated to the stored records.
+func (r *scoutRepo) RecordDiscoveries(ctx context.Context, discoveries []*domain.ScoutDiscovery) error {
+	if len(discoveries) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO scout_discoveries (
+			id, run_id, name, source, source_url, code_hash, validation_status,
+			validation_errors, outcome, strategy_id, outcome_detail, discovered_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (run_id, code_hash) DO UPDATE SET
+			name = COALESCE(NULLIF(EXCLUDED.name, ''), scout_discoveries.name),
+			source = COALESCE(NULLIF(EXCLUDED.source, ''), scout_discoveries.source),
+			source_url = COALESCE(NULLIF(EXCLUDED.source_url, ''), scout_discoveries.source_url),
+			validation_status = EXCLUDED.validation_status,
+			validation_errors = EXCLUDED.validation_errors,
+			outcome = CASE WHEN EXCLUDED.outcome = 'not_submitted' OR scout_discoveries.outcome = 'imported'
+				THEN scout_discoveries.outcome ELSE EXCLUDED.outcome END,
+			strategy_id = CASE WHEN EXCLUDED.outcome = 'not_submitted' OR scout_discoveries.outcome = 'imported'
+				THEN scout_discoveries.strategy_id ELSE EXCLUDED.strategy_id END,
+			outcome_detail = CASE WHEN EXCLUDED.outcome = 'not_submitted' OR scout_discoveries.outcome = 'imported'
+				THEN scout_discoveries.outcome_detail ELSE EXCLUDED.outcome_detail END
+		RETURNING ` + scoutDiscoveryColumns
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, d := range discoveries {
+		validationErrors, err := json.Marshal(d.ValidationErrors)
+		if err != nil {
+			return fmt.Errorf("failed to marshal validation errors: %w", err)
+		}
+		if d.ValidationErrors == nil {
+			validationErrors = []byte("[]")
+		}
+
+		stored, err := scanDiscovery(tx.QueryRow(ctx, query,
+			d.ID,
+			d.RunID,
+			d.Name,
+			d.Source,
+			d.SourceURL,
+			d.CodeHash,
+			string(d.ValidationStatus),
+			validationErrors,
+			string(d.Outcome),
+			d.StrategyID,
+			d.OutcomeDetail,
+			d.DiscoveredAt,
+		))
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return domain.NewNotFoundError("scout_run", d.RunID.String())
+			}
+			return fmt.Errorf("failed to record scout discovery: %w", err)
+		}
+		*d = *stored
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListDiscoveries retrieves the discoveries of a scout run in the order
+// they were discovered.
+func (r *scoutRepo) ListDiscoveries(ctx context.Context, query domain.ScoutDiscoveryQuery) ([]*domain.ScoutDiscovery, error) {
+	conditions := []string{"run_id = $1"}
+	args := []interface{}{query.RunID}
+	if query.Outcome != nil {
+		args = append(args, string(*query.Outcome))
+		conditions = append(conditions, fmt.Sprintf("outcome = $%d", len(args)))
+	}
+	if query.ValidationStatus != nil {
+		args = append(args, string(*query.ValidationStatus))
+		conditions = append(conditions, fmt.Sprintf("validation_status = $%d", len(args)))
+	}
+
+	sql := `SELECT ` + scoutDiscoveryColumns + ` FROM scout_discoveries
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY discovered_at, id`
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scout discoveries: %w", err)
+	}
+	defer rows.Close()
+
+	discoveries := []*domain.ScoutDiscovery{}
+	for rows.Next() {
+		d, err := scanDiscovery(rows)
+		if err != nil {
+			return nil, err
+		}
+		discoveries = append(discoveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating scout discoveries: %w", err)
+	}
+
+	return discoveries, nil
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
 
+// scanDiscovery scans a row of scoutDiscoveryColumns into a ScoutDiscovery.
+func scanDiscovery(row pgx.Row) (*domain.ScoutDiscovery, error) {
+	d := &domain.ScoutDiscovery{}
+	var validationStatus, outcome string
+	var validationErrors []byte
+
+	err := row.Scan(
+		&d.ID,
+		&d.RunID,
+		&d.Name,
+		&d.Source,
+		&d.SourceURL,
+		&d.CodeHash,
+		&validationStatus,
+		&validationErrors,
+		&outcome,
+		&d.StrategyID,
+		&d.OutcomeDetail,
+		&d.DiscoveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	d.ValidationStatus = domain.ScoutValidationStatus(validationStatus)
+	d.Outcome = domain.ScoutDiscoveryOutcome(outcome)
+	d.Imported = d.Outcome == domain.ScoutOutcomeImported
+	if len(validationErrors) > 0 {
+		if err := json.Unmarshal(validationErrors, &d.ValidationErrors); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal validation errors: %w", err)
+		}
+	}
+
+	return d, nil
+}
+
 // scanRun scans a single row into a ScoutRun.
 func (r *scoutRepo) scanRun(row pgx.Row) (*domain.ScoutRun, error) {
 	run := &domain.ScoutRun{}
@@ -643,6 +1045,8 @@ func (r *scoutRepo) scanRun(row pgx.Row) (*domain.ScoutRun, error) {
 		&run.CreatedAt,
 		&run.StartedAt,
 		&run.CompletedAt,
+		&run.CancelReason,
+		&run.CancelledBy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -690,6 +1094,8 @@ func (r *scoutRepo) scanRuns(rows pgx.Rows) ([]*domain.ScoutRun, error) {
 			&run.CreatedAt,
 			&run.StartedAt,
 			&run.CompletedAt,
+			&run.CancelReason,
+			&run.CancelledBy,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan scout run row: %w", err)
@@ -733,6 +1139,7 @@ func (r *scoutRepo) scanSchedule(row pgx.Row) (*domain.ScoutSchedule, error) {
 		&schedule.LastRunID,
 		&schedule.LastRunAt,
 		&schedule.NextRunAt,
+		&schedule.CredentialSecretID,
 		&schedule.CreatedAt,
 		&schedule.UpdatedAt,
 	)
@@ -763,6 +1170,7 @@ func (r *scoutRepo) scanSchedules(rows pgx.Rows) ([]*domain.ScoutSchedule, error
 			&schedule.LastRunID,
 			&schedule.LastRunAt,
 			&schedule.NextRunAt,
+			&schedule.CredentialSecretID,
 			&schedule.CreatedAt,
 			&schedule.UpdatedAt,
 		)
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// secretRepo implements SecretRepository using PostgreSQL.
+type secretRepo struct {
+	pool *db.Pool
+}
+
+// NewSecretRepository creates a new PostgreSQL secret repository.
+func NewSecretRepository(pool *db.Pool) SecretRepository {
+	return &secretRepo{pool: pool}
+}
+
+const secretColumns = `id, name, description, ciphertext, nonce, key_id, created_at, updated_at`
+
+// Create inserts a new secret.
+func (r *secretRepo) Create(ctx context.Context, secret *domain.Secret) error {
+	query := `
+		INSERT INTO secrets (` + secretColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		secret.ID, secret.Name, secret.Description, secret.Ciphertext, secret.Nonce,
+		secret.KeyID, secret.CreatedAt, secret.UpdatedAt,
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.NewDuplicateError("secret", "name", secret.Name)
+		}
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a secret, including its ciphertext.
+func (r *secretRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Secret, error) {
+	query := `SELECT ` + secretColumns + ` FROM secrets WHERE id = $1`
+
+	var s domain.Secret
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&s.ID, &s.Name, &s.Description, &s.Ciphertext, &s.Nonce, &s.KeyID, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("secret", id.String())
+		}
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+
+	return &s, nil
+}
+
+// List retrieves all secrets ordered by name, without their ciphertext.
+func (r *secretRepo) List(ctx context.Context) ([]*domain.Secret, error) {
+	query := `
+		SELECT id, name, description, key_id, created_at, updated_at
+		FROM secrets
+		ORDER BY name
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	defer rows.Close()
+
+	var secrets []*domain.Secret
+	for rows.Next() {
+		var s domain.Secret
+		if err := rows.Scan(&s.ID, &s.Name, &s.Description, &s.KeyID, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan secret: %w", err)
+		}
+		secrets = append(secrets, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating secrets: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// Update replaces a secret's description, ciphertext, nonce and key ID.
+func (r *secretRepo) Update(ctx context.Context, secret *domain.Secret) error {
+	query := `
+		UPDATE secrets
+		SET description = $2, ciphertext = $3, nonce = $4, key_id = $5, updated_at = $6
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		secret.ID, secret.Description, secret.Ciphertext, secret.Nonce, secret.KeyID, secret.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update secret: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("secret", secret.ID.String())
+	}
+
+	return nil
+}
+
+// Delete removes a secret. It fails with ErrSecretInUse while the secret is
+// referenced.
+func (r *secretRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, "DELETE FROM secrets WHERE id = $1", id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ErrSecretInUse
+		}
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("secret", id.String())
+	}
+
+	return nil
+}
+
+// Ensure interface implementation at compile time.
+var _ SecretRepository = (*secretRepo)(nil)
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// starRepo implements StarRepository using PostgreSQL.
+type starRepo struct {
+	pool *db.Pool
+}
+
+// NewStarRepository creates a new PostgreSQL star repository.
+func NewStarRepository(pool *db.Pool) StarRepository {
+	return &starRepo{pool: pool}
+}
+
+// starTable returns the table and column storing stars for an entity type.
+func starTable(entityType domain.StarEntityType) (table, column string, err error) {
+	switch entityType {
+	case domain.StarEntityStrategy:
+		return "strategy_stars", "strategy_id", nil
+	case domain.StarEntityOptimizationRun:
+		return "optimization_run_stars", "optimization_run_id", nil
+	default:
+		return "", "", fmt.Errorf("%w: unknown star entity type %q", domain.ErrInvalidInput, entityType)
+	}
+}
+
+// Star stars an entity for an owner. Starring an already starred entity is a no-op.
+func (r *starRepo) Star(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) error {
+	table, column, err := starTable(entityType)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (owner, %s)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, table, column)
+
+	if _, err := r.pool.Exec(ctx, query, owner, entityID); err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.NewNotFoundError(entityType.String(), entityID.String())
+		}
+		return fmt.Errorf("failed to star %s: %w", entityType, err)
+	}
+
+	return nil
+}
+
+// Unstar removes an owner's star from an entity. Unstarring an entity that is not starred is a no-op.
+func (r *starRepo) Unstar(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) error {
+	table, column, err := starTable(entityType)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE owner = $1 AND %s = $2", table, column)
+	if _, err := r.pool.Exec(ctx, query, owner, entityID); err != nil {
+		return fmt.Errorf("failed to unstar %s: %w", entityType, err)
+	}
+
+	return nil
+}
+
+// IsStarred returns true if the owner has starred the entity.
+func (r *starRepo) IsStarred(ctx context.Context, owner string, entityType domain.StarEntityType, entityID uuid.UUID) (bool, error) {
+	table, column, err := starTable(entityType)
+	if err != nil {
+		return false, err
+	}
+
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE owner = $1 AND %s = $2)", table, column)
+	var starred bool
+	if err := r.pool.QueryRow(ctx, query, owner, entityID).Scan(&starred); err != nil {
+		return false, fmt.Errorf("failed to check star: %w", err)
+	}
+
+	return starred, nil
+}
+
+// ListStarredIDs returns the IDs of entities starred by the owner, most recent first.
+func (r *starRepo) ListStarredIDs(ctx context.Context, owner string, entityType domain.StarEntityType) ([]uuid.UUID, error) {
+	table, column, err := starTable(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE owner = $1 ORDER BY created_at DESC", column, table)
+	rows, err := r.pool.Query(ctx, query, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stars: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan star: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stars: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ StarRepository = (*starRepo)(nil)
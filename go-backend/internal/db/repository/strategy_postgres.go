@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -26,27 +27,40 @@ func NewStrategyRepository(pool *db.Pool) StrategyRepository {
 func (r *strategyRepo) Create(ctx context.Context, strategy *domain.Strategy) error {
 	indicators, _ := json.Marshal(strategy.Indicators)
 	minimalROI, _ := json.Marshal(strategy.MinimalROI)
+	validationErrors, _ := json.Marshal(strategy.ValidationErrors)
+	if strategy.ValidationStatus == "" {
+		strategy.ValidationStatus = domain.ValidationStatusUnvalidated
+	}
 
 	query := `
 		INSERT INTO strategies (
 			id, name, code, parent_id, description,
 			timeframe, stoploss, trailing_stop, trailing_stop_positive,
 			trailing_stop_positive_offset, startup_candle_count,
-			indicators, minimal_roi, created_at, updated_at
+			indicators, minimal_roi, created_at, updated_at,
+			validation_status, validation_errors, validated_at
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7, $8, $9,
 			$10, $11,
-			$12, $13, $14, $15
+			$12, $13, $14, $15,
+			$16, $17, $18
 		)
 		RETURNING code_hash, generation
 	`
 
-	err := r.pool.QueryRow(ctx, query,
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
 		strategy.ID, strategy.Name, strategy.Code, strategy.ParentID, strategy.Description,
 		strategy.Timeframe, strategy.Stoploss, strategy.TrailingStop, strategy.TrailingStopPositive,
 		strategy.TrailingStopPositiveOffset, strategy.StartupCandleCount,
 		indicators, minimalROI, strategy.CreatedAt, strategy.UpdatedAt,
+		strategy.ValidationStatus, validationErrors, strategy.ValidatedAt,
 	).Scan(&strategy.CodeHash, &strategy.Generation)
 
 	if err != nil {
@@ -56,6 +70,14 @@ func (r *strategyRepo) Create(ctx context.Context, strategy *domain.Strategy) er
 		return fmt.Errorf("failed to create strategy: %w", err)
 	}
 
+	if _, err := snapshotStrategy(ctx, tx, strategy.ID, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -65,13 +87,15 @@ func (r *strategyRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Strat
 			id, name, code, code_hash, parent_id, generation, description,
 			timeframe, stoploss, trailing_stop, trailing_stop_positive,
 			trailing_stop_positive_offset, startup_candle_count,
-			indicators, minimal_roi, created_at, updated_at
+			indicators, minimal_roi, created_at, updated_at,
+			archived_at, archive_reason,
+			validation_status, validation_errors, validated_at
 		FROM strategies
 		WHERE id = $1
 	`
 
 	strategy := &domain.Strategy{}
-	var indicators, minimalROI []byte
+	var indicators, minimalROI, validationErrors []byte
 
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&strategy.ID, &strategy.Name, &strategy.Code, &strategy.CodeHash,
@@ -80,6 +104,8 @@ func (r *strategyRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Strat
 		&strategy.TrailingStopPositive, &strategy.TrailingStopPositiveOffset,
 		&strategy.StartupCandleCount, &indicators, &minimalROI,
 		&strategy.CreatedAt, &strategy.UpdatedAt,
+		&strategy.ArchivedAt, &strategy.ArchiveReason,
+		&strategy.ValidationStatus, &validationErrors, &strategy.ValidatedAt,
 	)
 
 	if err != nil {
@@ -91,6 +117,7 @@ func (r *strategyRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Strat
 
 	_ = json.Unmarshal(indicators, &strategy.Indicators)
 	_ = json.Unmarshal(minimalROI, &strategy.MinimalROI)
+	_ = json.Unmarshal(validationErrors, &strategy.ValidationErrors)
 
 	return strategy, nil
 }
@@ -101,13 +128,15 @@ func (r *strategyRepo) GetByCodeHash(ctx context.Context, hash string) (*domain.
 			id, name, code, code_hash, parent_id, generation, description,
 			timeframe, stoploss, trailing_stop, trailing_stop_positive,
 			trailing_stop_positive_offset, startup_candle_count,
-			indicators, minimal_roi, created_at, updated_at
+			indicators, minimal_roi, created_at, updated_at,
+			archived_at, archive_reason,
+			validation_status, validation_errors, validated_at
 		FROM strategies
 		WHERE code_hash = $1
 	`
 
 	strategy := &domain.Strategy{}
-	var indicators, minimalROI []byte
+	var indicators, minimalROI, validationErrors []byte
 
 	err := r.pool.QueryRow(ctx, query, hash).Scan(
 		&strategy.ID, &strategy.Name, &strategy.Code, &strategy.CodeHash,
@@ -116,6 +145,8 @@ func (r *strategyRepo) GetByCodeHash(ctx context.Context, hash string) (*domain.
 		&strategy.TrailingStopPositive, &strategy.TrailingStopPositiveOffset,
 		&strategy.StartupCandleCount, &indicators, &minimalROI,
 		&strategy.CreatedAt, &strategy.UpdatedAt,
+		&strategy.ArchivedAt, &strategy.ArchiveReason,
+		&strategy.ValidationStatus, &validationErrors, &strategy.ValidatedAt,
 	)
 
 	if err != nil {
@@ -127,6 +158,7 @@ func (r *strategyRepo) GetByCodeHash(ctx context.Context, hash string) (*domain.
 
 	_ = json.Unmarshal(indicators, &strategy.Indicators)
 	_ = json.Unmarshal(minimalROI, &strategy.MinimalROI)
+	_ = json.Unmarshal(validationErrors, &strategy.ValidationErrors)
 
 	return strategy, nil
 }
@@ -140,23 +172,45 @@ func (r *strategyRepo) Update(ctx context.Context, strategy *domain.Strategy) er
 			name = $2, description = $3,
 			timeframe = $4, stoploss = $5, trailing_stop = $6,
 			trailing_stop_positive = $7, trailing_stop_positive_offset = $8,
-			startup_candle_count = $9, indicators = $10, minimal_roi = $11
+			startup_candle_count = $9, indicators = $10, minimal_roi = $11,
+			code = $12, parent_id = $13
 		WHERE id = $1
+		RETURNING code_hash
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
 		strategy.ID, strategy.Name, strategy.Description,
 		strategy.Timeframe, strategy.Stoploss, strategy.TrailingStop,
 		strategy.TrailingStopPositive, strategy.TrailingStopPositiveOffset,
 		strategy.StartupCandleCount, indicators, minimalROI,
-	)
+		strategy.Code, strategy.ParentID,
+	).Scan(&strategy.CodeHash)
 
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.NewNotFoundError("strategy", strategy.ID.String())
+		}
+		if isDuplicateKeyError(err) {
+			return domain.NewDuplicateError("strategy", "code_hash", "")
+		}
+		if isForeignKeyViolation(err) {
+			return domain.NewNotFoundError("strategy", strategy.ParentID.String())
+		}
 		return fmt.Errorf("failed to update strategy: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return domain.NewNotFoundError("strategy", strategy.ID.String())
+	if _, err := snapshotStrategy(ctx, tx, strategy.ID, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -201,6 +255,9 @@ func (r *strategyRepo) Search(ctx context.Context, query domain.StrategySearchQu
 				s.trailing_stop,
 				s.created_at,
 				s.updated_at,
+				s.archived_at,
+				s.archive_reason,
+				s.validation_status,
 				COUNT(br.id) as backtest_count,
 				MAX(br.sharpe_ratio) as best_sharpe,
 				MAX(br.profit_pct) as best_profit_pct,
@@ -208,7 +265,7 @@ func (r *strategyRepo) Search(ctx context.Context, query domain.StrategySearchQu
 				MAX(br.total_trades) as max_trades,
 				AVG(br.win_rate) as avg_win_rate
 			FROM strategies s
-			LEFT JOIN backtest_results br ON br.strategy_id = s.id
+			LEFT JOIN backtest_results br ON br.strategy_id = s.id AND br.superseded_by IS NULL
 			%s
 			GROUP BY s.id
 		)
@@ -239,6 +296,22 @@ func (r *strategyRepo) Search(ctx context.Context, query domain.StrategySearchQu
 		argIndex++
 	}
 
+	if query.StarredBy != nil {
+		conditions = append(conditions, fmt.Sprintf("s.id IN (SELECT strategy_id FROM strategy_stars WHERE owner = $%d)", argIndex))
+		args = append(args, *query.StarredBy)
+		argIndex++
+	}
+
+	if query.ValidationStatus != nil {
+		conditions = append(conditions, fmt.Sprintf("s.validation_status = $%d", argIndex))
+		args = append(args, *query.ValidationStatus)
+		argIndex++
+	}
+
+	if !query.IncludeArchived {
+		conditions = append(conditions, "s.archived_at IS NULL")
+	}
+
 	whereClause := ""
 	if len(conditions) > 0 {
 		whereClause = "WHERE " + strings.Join(conditions, " AND ")
@@ -300,6 +373,7 @@ func (r *strategyRepo) Search(ctx context.Context, query domain.StrategySearchQu
 		SELECT
 			id, name, code_hash, parent_id, generation, description,
 			timeframe, stoploss, trailing_stop, created_at, updated_at,
+			archived_at, archive_reason, validation_status,
 			backtest_count, best_sharpe,
 			COALESCE(best_profit_pct, 0) as best_profit_pct,
 			COALESCE(best_drawdown, 0) as best_drawdown,
@@ -327,6 +401,7 @@ func (r *strategyRepo) Search(ctx context.Context, query domain.StrategySearchQu
 		err := rows.Scan(
 			&s.ID, &s.Name, &s.CodeHash, &s.ParentID, &s.Generation, &s.Description,
 			&s.Timeframe, &s.Stoploss, &s.TrailingStop, &s.CreatedAt, &s.UpdatedAt,
+			&s.ArchivedAt, &s.ArchiveReason, &s.ValidationStatus,
 			&metrics.BacktestCount, &metrics.SharpeRatio, &metrics.ProfitPct,
 			&metrics.MaxDrawdownPct, &metrics.TotalTrades, &metrics.WinRate,
 		)
@@ -345,7 +420,7 @@ func (r *strategyRepo) Search(ctx context.Context, query domain.StrategySearchQu
 		SELECT COUNT(*) FROM (
 			SELECT s.id
 			FROM strategies s
-			LEFT JOIN backtest_results br ON br.strategy_id = s.id
+			LEFT JOIN backtest_results br ON br.strategy_id = s.id AND br.superseded_by IS NULL
 			%s
 			GROUP BY s.id
 			%s
@@ -363,32 +438,65 @@ func (r *strategyRepo) Search(ctx context.Context, query domain.StrategySearchQu
 	return results, totalCount, nil
 }
 
-func (r *strategyRepo) GetLineage(ctx context.Context, strategyID uuid.UUID, depth int) (*domain.StrategyLineageNode, error) {
-	if depth > 100 {
-		depth = 100
-	}
+func (r *strategyRepo) GetLineage(ctx context.Context, strategyID uuid.UUID, query domain.StrategyLineageQuery) (*domain.StrategyLineageNode, error) {
+	query.SetDefaults()
+	down := query.Direction == domain.LineageDown || query.Direction == domain.LineageBoth
+	up := query.Direction == domain.LineageUp || query.Direction == domain.LineageBoth
 
-	query := `
-		WITH RECURSIVE lineage AS (
+	sql := `
+		WITH RECURSIVE descendants AS (
 			-- Base: starting strategy
-			SELECT id, name, parent_id, generation, 0 as level
+			SELECT id, parent_id, 0 AS level
 			FROM strategies
 			WHERE id = $1
 
 			UNION ALL
 
 			-- Recursive: descendants
-			SELECT s.id, s.name, s.parent_id, s.generation, l.level + 1
+			SELECT s.id, s.parent_id, d.level + 1
 			FROM strategies s
-			INNER JOIN lineage l ON s.parent_id = l.id
-			WHERE l.level < $2
+			INNER JOIN descendants d ON s.parent_id = d.id
+			WHERE d.level < $2 AND $3
+		), ancestors AS (
+			SELECT id, parent_id, 0 AS level
+			FROM strategies
+			WHERE id = $1
+
+			UNION ALL
+
+			-- Recursive: ancestors, at negative levels
+			SELECT s.id, s.parent_id, a.level - 1
+			FROM strategies s
+			INNER JOIN ancestors a ON s.id = a.parent_id
+			WHERE a.level > -$2 AND $4
+		), lineage AS (
+			SELECT id, level FROM descendants
+			UNION
+			SELECT id, level FROM ancestors
 		)
-		SELECT id, name, parent_id, generation, level
-		FROM lineage
-		ORDER BY level, generation
+		SELECT
+			s.id, s.name, s.parent_id, s.generation, l.level,
+			r.backtest_count, r.best_sharpe,
+			(SELECT COUNT(*) FROM strategies c WHERE c.parent_id = l.id) AS child_count,
+			r.best_sortino, r.best_profit_pct, r.best_drawdown, r.max_trades, r.avg_win_rate
+		FROM lineage l
+		INNER JOIN strategies s ON s.id = l.id
+		CROSS JOIN LATERAL (
+			SELECT
+				COUNT(*) AS backtest_count,
+				MAX(br.sharpe_ratio) AS best_sharpe,
+				MAX(br.sortino_ratio) AS best_sortino,
+				COALESCE(MAX(br.profit_pct), 0) AS best_profit_pct,
+				COALESCE(MIN(br.max_drawdown_pct), 0) AS best_drawdown,
+				COALESCE(MAX(br.total_trades), 0) AS max_trades,
+				COALESCE(AVG(br.win_rate), 0) AS avg_win_rate
+			FROM backtest_results br
+			WHERE br.strategy_id = l.id AND br.superseded_by IS NULL
+		) r
+		ORDER BY l.level, s.generation
 	`
 
-	rows, err := r.pool.Query(ctx, query, strategyID, depth)
+	rows, err := r.pool.Query(ctx, sql, strategyID, query.Depth, down, up)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get lineage: %w", err)
 	}
@@ -396,18 +504,31 @@ func (r *strategyRepo) GetLineage(ctx context.Context, strategyID uuid.UUID, dep
 
 	nodeMap := make(map[uuid.UUID]*domain.StrategyLineageNode)
 	var root *domain.StrategyLineageNode
+	var found bool
 
 	for rows.Next() {
 		node := &domain.StrategyLineageNode{}
-		err := rows.Scan(&node.ID, &node.Name, &node.ParentID, &node.Generation, &node.Level)
+		var metrics domain.StrategyPerformanceMetrics
+		err := rows.Scan(&node.ID, &node.Name, &node.ParentID, &node.Generation, &node.Level,
+			&node.BacktestCount, &node.BestSharpe, &node.ChildCount,
+			&metrics.SortinoRatio, &metrics.ProfitPct, &metrics.MaxDrawdownPct,
+			&metrics.TotalTrades, &metrics.WinRate)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan lineage node: %w", err)
 		}
+		if query.IncludeMetrics && node.BacktestCount > 0 {
+			metrics.SharpeRatio = node.BestSharpe
+			metrics.BacktestCount = node.BacktestCount
+			node.Metrics = &metrics
+		}
 
 		node.Children = make([]*domain.StrategyLineageNode, 0)
 		nodeMap[node.ID] = node
+		found = found || node.Level == 0
 
-		if node.Level == 0 {
+		// Rows are ordered by level, so the root comes first and parents
+		// before their children
+		if root == nil {
 			root = node
 		} else if node.ParentID != nil {
 			if parent, ok := nodeMap[*node.ParentID]; ok {
@@ -416,10 +537,18 @@ func (r *strategyRepo) GetLineage(ctx context.Context, strategyID uuid.UUID, dep
 		}
 	}
 
-	if root == nil {
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating lineage: %w", err)
+	}
+
+	if !found {
 		return nil, domain.NewNotFoundError("strategy", strategyID.String())
 	}
 
+	if query.IncludeMetrics {
+		root.SetDeltas()
+	}
+
 	return root, nil
 }
 
@@ -435,7 +564,9 @@ func (r *strategyRepo) GetDescendants(ctx context.Context, strategyID uuid.UUID)
 			s.id, s.name, s.code, s.code_hash, s.parent_id, s.generation, s.description,
 			s.timeframe, s.stoploss, s.trailing_stop, s.trailing_stop_positive,
 			s.trailing_stop_positive_offset, s.startup_candle_count,
-			s.indicators, s.minimal_roi, s.created_at, s.updated_at
+			s.indicators, s.minimal_roi, s.created_at, s.updated_at,
+			s.archived_at, s.archive_reason,
+			s.validation_status, s.validation_errors, s.validated_at
 		FROM strategies s
 		WHERE s.id IN (SELECT id FROM descendants)
 		ORDER BY s.generation
@@ -457,7 +588,9 @@ func (r *strategyRepo) GetAncestors(ctx context.Context, strategyID uuid.UUID) (
 			s.id, s.name, s.code, s.code_hash, s.parent_id, s.generation, s.description,
 			s.timeframe, s.stoploss, s.trailing_stop, s.trailing_stop_positive,
 			s.trailing_stop_positive_offset, s.startup_candle_count,
-			s.indicators, s.minimal_roi, s.created_at, s.updated_at
+			s.indicators, s.minimal_roi, s.created_at, s.updated_at,
+			s.archived_at, s.archive_reason,
+			s.validation_status, s.validation_errors, s.validated_at
 		FROM strategies s
 		WHERE s.id IN (SELECT parent_id FROM ancestors)
 		ORDER BY s.generation DESC
@@ -466,6 +599,314 @@ func (r *strategyRepo) GetAncestors(ctx context.Context, strategyID uuid.UUID) (
 	return r.queryStrategies(ctx, query, strategyID)
 }
 
+// GetByIDs retrieves the strategies with the given IDs, skipping unknown ones.
+func (r *strategyRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Strategy, error) {
+	query := `
+		SELECT
+			id, name, code, code_hash, parent_id, generation, description,
+			timeframe, stoploss, trailing_stop, trailing_stop_positive,
+			trailing_stop_positive_offset, startup_candle_count,
+			indicators, minimal_roi, created_at, updated_at,
+			archived_at, archive_reason,
+			validation_status, validation_errors, validated_at
+		FROM strategies
+		WHERE id = ANY($1)
+		ORDER BY id
+	`
+
+	return r.queryStrategies(ctx, query, ids)
+}
+
+// ListAll retrieves all strategies, oldest first. Archived strategies are
+// skipped unless includeArchived is set.
+func (r *strategyRepo) ListAll(ctx context.Context, includeArchived bool) ([]*domain.Strategy, error) {
+	query := `
+		SELECT
+			id, name, code, code_hash, parent_id, generation, description,
+			timeframe, stoploss, trailing_stop, trailing_stop_positive,
+			trailing_stop_positive_offset, startup_candle_count,
+			indicators, minimal_roi, created_at, updated_at,
+			archived_at, archive_reason,
+			validation_status, validation_errors, validated_at
+		FROM strategies
+		WHERE $1 OR archived_at IS NULL
+		ORDER BY created_at, id
+	`
+
+	return r.queryStrategies(ctx, query, includeArchived)
+}
+
+// FindArchivalCandidates returns active strategies matching the archival policy.
+// Starred strategies, strategies with queued or running jobs, and strategies
+// used by active optimization runs are never candidates.
+func (r *strategyRepo) FindArchivalCandidates(ctx context.Context, policy domain.ArchivalPolicy, now time.Time) ([]*domain.ArchivalCandidate, error) {
+	query := `
+		WITH stats AS (
+			SELECT
+				s.id, s.name,
+				COUNT(br.id) AS backtest_count,
+				MAX(br.sharpe_ratio) AS best_sharpe,
+				MAX(br.profit_pct) AS best_profit_pct,
+				GREATEST(
+					s.updated_at,
+					(SELECT MAX(bj.created_at) FROM backtest_jobs bj WHERE bj.strategy_id = s.id),
+					(SELECT MAX(c.created_at) FROM strategies c WHERE c.parent_id = s.id)
+				) AS last_activity_at
+			FROM strategies s
+			LEFT JOIN backtest_results br ON br.strategy_id = s.id AND br.superseded_by IS NULL
+			WHERE s.archived_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM strategy_stars st WHERE st.strategy_id = s.id)
+				AND NOT EXISTS (
+					SELECT 1 FROM backtest_jobs bj
+					WHERE bj.strategy_id = s.id AND bj.status IN ('pending', 'running')
+				)
+				AND NOT EXISTS (
+					SELECT 1 FROM optimization_runs o
+					WHERE o.status IN ('pending', 'running', 'paused', 'stalled')
+						AND (s.id = ANY(o.seed_strategy_ids) OR o.best_strategy_id = s.id)
+				)
+			GROUP BY s.id
+		)
+		SELECT id, name, backtest_count, best_sharpe, best_profit_pct, last_activity_at
+		FROM stats
+		WHERE backtest_count > $1
+			AND COALESCE(best_sharpe, 0) <= $2
+			AND last_activity_at < $3
+		ORDER BY last_activity_at ASC
+		LIMIT $4
+	`
+
+	rows, err := r.pool.Query(ctx, query,
+		policy.MinBacktests, policy.MaxBestSharpe, now.Add(-policy.InactiveFor), policy.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archival candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*domain.ArchivalCandidate
+	for rows.Next() {
+		c := &domain.ArchivalCandidate{}
+		if err := rows.Scan(
+			&c.StrategyID, &c.Name, &c.BacktestCount,
+			&c.BestSharpe, &c.BestProfitPct, &c.LastActivityAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan archival candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+// GetGenerationStatistics aggregates strategies by lineage generation: their
+// count, the best and mean of each strategy's best current sharpe ratio, and
+// how many have children. Survival rates and deltas are left to the caller.
+func (r *strategyRepo) GetGenerationStatistics(ctx context.Context) ([]domain.GenerationStatistics, error) {
+	query := `
+		WITH best AS (
+			SELECT strategy_id, MAX(sharpe_ratio)::float8 AS sharpe
+			FROM backtest_results
+			WHERE superseded_by IS NULL AND sharpe_ratio IS NOT NULL
+			GROUP BY strategy_id
+		)
+		SELECT
+			s.generation,
+			COUNT(*),
+			COUNT(b.sharpe),
+			MAX(b.sharpe),
+			AVG(b.sharpe),
+			COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM strategies c WHERE c.parent_id = s.id))
+		FROM strategies s
+		LEFT JOIN best b ON b.strategy_id = s.id
+		GROUP BY s.generation
+		ORDER BY s.generation
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query generation statistics: %w", err)
+	}
+	defer rows.Close()
+
+	var generations []domain.GenerationStatistics
+	for rows.Next() {
+		var g domain.GenerationStatistics
+		if err := rows.Scan(
+			&g.Generation, &g.StrategyCount, &g.TestedCount,
+			&g.BestSharpe, &g.MeanSharpe, &g.SurvivorCount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan generation statistics: %w", err)
+		}
+		generations = append(generations, g)
+	}
+
+	return generations, rows.Err()
+}
+
+// Archive marks a strategy as archived. Archiving an archived strategy is a no-op.
+func (r *strategyRepo) Archive(ctx context.Context, id uuid.UUID, reason string) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE strategies SET archived_at = COALESCE(archived_at, NOW()), archive_reason = COALESCE(archive_reason, $2)
+		WHERE id = $1
+	`, id, reason)
+	if err != nil {
+		return fmt.Errorf("failed to archive strategy: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("strategy", id.String())
+	}
+
+	return nil
+}
+
+// Unarchive restores an archived strategy.
+func (r *strategyRepo) Unarchive(ctx context.Context, id uuid.UUID) error {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE strategies SET archived_at = NULL, archive_reason = NULL
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to unarchive strategy: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("strategy", id.String())
+	}
+
+	return nil
+}
+
+// SetValidation records the outcome of validating a strategy's code.
+func (r *strategyRepo) SetValidation(ctx context.Context, id uuid.UUID, status domain.ValidationStatus, validationErrors []string) error {
+	errorsJSON, err := json.Marshal(validationErrors)
+	if err != nil {
+		return fmt.Errorf("failed to marshal validation errors: %w", err)
+	}
+
+	result, err := r.pool.Exec(ctx, `
+		UPDATE strategies SET validation_status = $2, validation_errors = $3, validated_at = NOW()
+		WHERE id = $1
+	`, id, status, errorsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to set strategy validation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.NewNotFoundError("strategy", id.String())
+	}
+
+	return nil
+}
+
+// ClaimDescriptionRequests marks up to limit active strategies lacking a
+// description as requested, oldest first, and returns them. Strategies
+// requested within retryAfter of now are skipped.
+func (r *strategyRepo) ClaimDescriptionRequests(ctx context.Context, limit int, retryAfter time.Duration, now time.Time) ([]*domain.DescriptionRequest, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE strategies s SET description_requested_at = $3
+			FROM (
+				SELECT id FROM strategies
+				WHERE archived_at IS NULL
+					AND COALESCE(description, '') = ''
+					AND (description_requested_at IS NULL OR description_requested_at < $2)
+				ORDER BY created_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			) candidates
+			WHERE s.id = candidates.id
+			RETURNING s.id, s.name, COALESCE(s.timeframe, '') AS timeframe, s.created_at
+		)
+		SELECT id, name, timeframe FROM claimed ORDER BY created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit, now.Add(-retryAfter), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim description requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*domain.DescriptionRequest
+	for rows.Next() {
+		req := &domain.DescriptionRequest{}
+		if err := rows.Scan(&req.StrategyID, &req.Name, &req.Timeframe); err != nil {
+			return nil, fmt.Errorf("failed to scan description request: %w", err)
+		}
+		requests = append(requests, req)
+	}
+
+	return requests, rows.Err()
+}
+
+// SetGeneratedDescription stores a generated description for a strategy that
+// has none, storing the result as a new version. Returns Conflict if the
+// strategy was described in the meantime.
+func (r *strategyRepo) SetGeneratedDescription(ctx context.Context, id uuid.UUID, description string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var existing *string
+	err = tx.QueryRow(ctx, "SELECT description FROM strategies WHERE id = $1 FOR UPDATE", id).Scan(&existing)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.NewNotFoundError("strategy", id.String())
+		}
+		return fmt.Errorf("failed to get strategy: %w", err)
+	}
+	if existing != nil && *existing != "" {
+		return fmt.Errorf("%w: strategy already has a description", domain.ErrConflict)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE strategies SET
+			description = $2,
+			description_generated_at = NOW(),
+			description_requested_at = NULL
+		WHERE id = $1
+	`, id, description)
+	if err != nil {
+		return fmt.Errorf("failed to set strategy description: %w", err)
+	}
+
+	if _, err := snapshotStrategy(ctx, tx, id, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetDescriptionCoverage counts the active strategies with and without a
+// description.
+func (r *strategyRepo) GetDescriptionCoverage(ctx context.Context) (*domain.DescriptionCoverage, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE COALESCE(description, '') <> ''),
+			COUNT(*) FILTER (WHERE COALESCE(description, '') <> '' AND description_generated_at IS NOT NULL),
+			COUNT(*) FILTER (WHERE COALESCE(description, '') = '' AND description_requested_at IS NOT NULL)
+		FROM strategies
+		WHERE archived_at IS NULL
+	`
+
+	c := &domain.DescriptionCoverage{}
+	if err := r.pool.QueryRow(ctx, query).Scan(&c.Strategies, &c.Described, &c.Generated, &c.Requested); err != nil {
+		return nil, fmt.Errorf("failed to get description coverage: %w", err)
+	}
+	c.Missing = c.Strategies - c.Described
+	c.ComputeCoveragePct()
+
+	return c, nil
+}
+
 func (r *strategyRepo) queryStrategies(ctx context.Context, query string, args ...interface{}) ([]*domain.Strategy, error) {
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -476,7 +917,7 @@ func (r *strategyRepo) queryStrategies(ctx context.Context, query string, args .
 	var strategies []*domain.Strategy
 	for rows.Next() {
 		strategy := &domain.Strategy{}
-		var indicators, minimalROI []byte
+		var indicators, minimalROI, validationErrors []byte
 
 		err := rows.Scan(
 			&strategy.ID, &strategy.Name, &strategy.Code, &strategy.CodeHash,
@@ -485,6 +926,8 @@ func (r *strategyRepo) queryStrategies(ctx context.Context, query string, args .
 			&strategy.TrailingStopPositive, &strategy.TrailingStopPositiveOffset,
 			&strategy.StartupCandleCount, &indicators, &minimalROI,
 			&strategy.CreatedAt, &strategy.UpdatedAt,
+			&strategy.ArchivedAt, &strategy.ArchiveReason,
+			&strategy.ValidationStatus, &validationErrors, &strategy.ValidatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan strategy: %w", err)
@@ -492,6 +935,7 @@ func (r *strategyRepo) queryStrategies(ctx context.Context, query string, args .
 
 		_ = json.Unmarshal(indicators, &strategy.Indicators)
 		_ = json.Unmarshal(minimalROI, &strategy.MinimalROI)
+		_ = json.Unmarshal(validationErrors, &strategy.ValidationErrors)
 
 		strategies = append(strategies, strategy)
 	}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// strategyVersionRepo implements StrategyVersionRepository using PostgreSQL.
+type strategyVersionRepo struct {
+	pool *db.Pool
+}
+
+// NewStrategyVersionRepository creates a new PostgreSQL strategy version repository.
+func NewStrategyVersionRepository(pool *db.Pool) StrategyVersionRepository {
+	return &strategyVersionRepo{pool: pool}
+}
+
+const strategyVersionColumns = `
+	id, strategy_id, version, name, code, code_hash, COALESCE(description, ''),
+	parent_id, rolled_back_from, created_at
+`
+
+// snapshotStrategySQL stores the strategy's current row as its next version.
+// The strategy row must be locked by the transaction, which serializes the
+// version numbers.
+var snapshotStrategySQL = `
+	INSERT INTO strategy_versions (
+		strategy_id, version, name, code, code_hash, description, parent_id, rolled_back_from
+	)
+	SELECT
+		s.id,
+		COALESCE((SELECT MAX(v.version) FROM strategy_versions v WHERE v.strategy_id = s.id), 0) + 1,
+		s.name, s.code, s.code_hash, s.description, s.parent_id, $2
+	FROM strategies s
+	WHERE s.id = $1
+	RETURNING ` + strategyVersionColumns
+
+// snapshotStrategy stores the current state of a strategy as a new version.
+func snapshotStrategy(ctx context.Context, tx pgx.Tx, strategyID uuid.UUID, rolledBackFrom *int) (*domain.StrategyVersion, error) {
+	v, err := scanStrategyVersion(tx.QueryRow(ctx, snapshotStrategySQL, strategyID, rolledBackFrom))
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot strategy version: %w", err)
+	}
+	return v, nil
+}
+
+// List retrieves the versions of a strategy, newest first.
+func (r *strategyVersionRepo) List(ctx context.Context, strategyID uuid.UUID, includeCode bool) ([]*domain.StrategyVersion, error) {
+	query := `SELECT ` + strategyVersionColumns + ` FROM strategy_versions WHERE strategy_id = $1 ORDER BY version DESC`
+
+	rows, err := r.pool.Query(ctx, query, strategyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list strategy versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*domain.StrategyVersion
+	for rows.Next() {
+		v, err := scanStrategyVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan strategy version: %w", err)
+		}
+		if !includeCode {
+			v.Code = ""
+		}
+		versions = append(versions, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating strategy versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// Get retrieves one version of a strategy.
+func (r *strategyVersionRepo) Get(ctx context.Context, strategyID uuid.UUID, version int) (*domain.StrategyVersion, error) {
+	query := `SELECT ` + strategyVersionColumns + ` FROM strategy_versions WHERE strategy_id = $1 AND version = $2`
+
+	v, err := scanStrategyVersion(r.pool.QueryRow(ctx, query, strategyID, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewNotFoundError("strategy version", fmt.Sprintf("%s@%d", strategyID, version))
+		}
+		return nil, fmt.Errorf("failed to get strategy version: %w", err)
+	}
+
+	return v, nil
+}
+
+// Rollback restores the name, code, description and parent of a strategy
+// from one of its versions, storing the result as a new version. A parent
+// deleted since is left unset.
+func (r *strategyVersionRepo) Rollback(ctx context.Context, strategyID uuid.UUID, version int) (*domain.StrategyVersion, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE strategies s SET
+			name = v.name,
+			code = v.code,
+			description = v.description,
+			parent_id = (SELECT p.id FROM strategies p WHERE p.id = v.parent_id)
+		FROM strategy_versions v
+		WHERE s.id = $1 AND v.strategy_id = s.id AND v.version = $2
+	`
+
+	result, err := tx.Exec(ctx, query, strategyID, version)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.NewDuplicateError("strategy", "code_hash", "")
+		}
+		return nil, fmt.Errorf("failed to roll back strategy: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, domain.NewNotFoundError("strategy version", fmt.Sprintf("%s@%d", strategyID, version))
+	}
+
+	v, err := snapshotStrategy(ctx, tx, strategyID, &version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return v, nil
+}
+
+// scanStrategyVersion scans a row of strategyVersionColumns.
+func scanStrategyVersion(row pgx.Row) (*domain.StrategyVersion, error) {
+	v := &domain.StrategyVersion{}
+	if err := row.Scan(
+		&v.ID, &v.StrategyID, &v.Version, &v.Name, &v.Code, &v.CodeHash, &v.Description,
+		&v.ParentID, &v.RolledBackFrom, &v.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Ensure interface implementation at compile time.
+var _ StrategyVersionRepository = (*strategyVersionRepo)(nil)
@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// maxLoggedSQLLength truncates SQL in logs and query dumps.
+const maxLoggedSQLLength = 2000
+
+// repositoryPackage identifies repository frames when inferring the query name.
+const repositoryPackage = "/internal/db/repository."
+
+// queryNameKey is the context key for the query name.
+type queryNameKey struct{}
+
+// queryStateKey is the context key for per-query tracing state.
+type queryStateKey struct{}
+
+// WithQueryName attaches a name (e.g. "strategyRepo.Search") to queries run with ctx.
+// When no name is attached, the tracer infers the calling repository method.
+func WithQueryName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, queryNameKey{}, name)
+}
+
+// QueryNameFromContext returns the query name attached to ctx, if any.
+func QueryNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(queryNameKey{}).(string)
+	return name
+}
+
+// ActiveQuery describes a query that is currently executing.
+type ActiveQuery struct {
+	ID         uint64    `json:"id"`
+	Name       string    `json:"name"`
+	SQL        string    `json:"sql"`
+	ArgCount   int       `json:"arg_count"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// QueryStats contains cumulative query counters.
+type QueryStats struct {
+	InFlight     int64
+	TotalQueries int64
+	FailedTotal  int64
+	SlowTotal    int64
+}
+
+// queryState is carried in the context between TraceQueryStart and TraceQueryEnd.
+type queryState struct {
+	id        uint64
+	name      string
+	sql       string
+	argCount  int
+	startedAt time.Time
+}
+
+// QueryTracer implements pgx.QueryTracer. It tracks executing queries and
+// logs queries slower than the configured threshold with their repository method.
+type QueryTracer struct {
+	slowThreshold time.Duration // zero disables slow query logging
+	logger        *zap.Logger
+
+	nextID atomic.Uint64
+	active sync.Map // id -> *queryState
+
+	inFlight atomic.Int64
+	total    atomic.Int64
+	failed   atomic.Int64
+	slow     atomic.Int64
+}
+
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+// NewQueryTracer creates a query tracer.
+func NewQueryTracer(slowThreshold time.Duration, logger *zap.Logger) *QueryTracer {
+	return &QueryTracer{
+		slowThreshold: slowThreshold,
+		logger:        logger,
+	}
+}
+
+// TraceQueryStart records the query as active.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	name := QueryNameFromContext(ctx)
+	if name == "" {
+		name = callerRepositoryMethod()
+	}
+
+	state := &queryState{
+		id:        t.nextID.Add(1),
+		name:      name,
+		sql:       data.SQL,
+		argCount:  len(data.Args),
+		startedAt: time.Now(),
+	}
+	t.active.Store(state.id, state)
+	t.inFlight.Add(1)
+
+	return context.WithValue(ctx, queryStateKey{}, state)
+}
+
+// TraceQueryEnd removes the query from the active set and logs it if slow.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	state, ok := ctx.Value(queryStateKey{}).(*queryState)
+	if !ok {
+		return
+	}
+
+	t.active.Delete(state.id)
+	t.inFlight.Add(-1)
+	t.total.Add(1)
+	if data.Err != nil {
+		t.failed.Add(1)
+	}
+
+	duration := time.Since(state.startedAt)
+	if t.slowThreshold <= 0 || duration < t.slowThreshold {
+		return
+	}
+
+	t.slow.Add(1)
+	fields := []zap.Field{
+		zap.String("query_name", state.name),
+		zap.Duration("duration", duration),
+		zap.Duration("threshold", t.slowThreshold),
+		zap.String("sql", truncateSQL(state.sql)),
+		zap.Int("arg_count", state.argCount),
+		zap.String("command_tag", data.CommandTag.String()),
+	}
+	if data.Err != nil {
+		fields = append(fields, zap.Error(data.Err))
+	}
+	t.logger.Warn("Slow query", fields...)
+}
+
+// ActiveQueries returns the currently executing queries, longest-running first.
+func (t *QueryTracer) ActiveQueries() []ActiveQuery {
+	now := time.Now()
+	queries := []ActiveQuery{}
+	t.active.Range(func(_, value any) bool {
+		state := value.(*queryState)
+		queries = append(queries, ActiveQuery{
+			ID:         state.id,
+			Name:       state.name,
+			SQL:        truncateSQL(state.sql),
+			ArgCount:   state.argCount,
+			StartedAt:  state.startedAt,
+			DurationMs: now.Sub(state.startedAt).Milliseconds(),
+		})
+		return true
+	})
+
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].StartedAt.Before(queries[j].StartedAt)
+	})
+	return queries
+}
+
+// Stats returns cumulative query counters.
+func (t *QueryTracer) Stats() QueryStats {
+	return QueryStats{
+		InFlight:     t.inFlight.Load(),
+		TotalQueries: t.total.Load(),
+		FailedTotal:  t.failed.Load(),
+		SlowTotal:    t.slow.Load(),
+	}
+}
+
+// callerRepositoryMethod returns the innermost repository method on the call stack,
+// e.g. "strategyRepo.Search", or "unknown" if the query did not come from a repository.
+func callerRepositoryMethod() string {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if idx := strings.Index(frame.Function, repositoryPackage); idx != -1 {
+			name := frame.Function[idx+len(repositoryPackage):]
+			// "(*strategyRepo).Search" -> "strategyRepo.Search"
+			name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+			return name
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// truncateSQL collapses whitespace and caps the SQL length.
+func truncateSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > maxLoggedSQLLength {
+		sql = sql[:maxLoggedSQLLength] + "..."
+	}
+	return sql
+}
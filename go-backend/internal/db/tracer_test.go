@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestQueryTracer_TracksActiveQueries(t *testing.T) {
+	tracer := NewQueryTracer(0, zap.NewNop())
+
+	ctx := tracer.TraceQueryStart(WithQueryName(context.Background(), "strategyRepo.Search"), nil, pgx.TraceQueryStartData{
+		SQL:  "SELECT *\n\tFROM strategies\n\tWHERE id = $1",
+		Args: []any{1},
+	})
+
+	active := tracer.ActiveQueries()
+	require.Len(t, active, 1)
+	assert.Equal(t, "strategyRepo.Search", active[0].Name)
+	assert.Equal(t, "SELECT * FROM strategies WHERE id = $1", active[0].SQL)
+	assert.Equal(t, 1, active[0].ArgCount)
+	assert.Equal(t, int64(1), tracer.Stats().InFlight)
+
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("boom")})
+
+	assert.Empty(t, tracer.ActiveQueries())
+	stats := tracer.Stats()
+	assert.Equal(t, int64(0), stats.InFlight)
+	assert.Equal(t, int64(1), stats.TotalQueries)
+	assert.Equal(t, int64(1), stats.FailedTotal)
+	assert.Equal(t, int64(0), stats.SlowTotal)
+}
+
+func TestQueryTracer_LogsSlowQueries(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	tracer := NewQueryTracer(time.Nanosecond, zap.New(core))
+
+	ctx := tracer.TraceQueryStart(WithQueryName(context.Background(), "resultRepo.Query"), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	time.Sleep(time.Millisecond)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+	assert.Equal(t, int64(1), tracer.Stats().SlowTotal)
+	entries := logs.FilterMessage("Slow query").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "resultRepo.Query", entries[0].ContextMap()["query_name"])
+}
+
+func TestTruncateSQL(t *testing.T) {
+	long := strings.Repeat("x", maxLoggedSQLLength+10)
+	assert.Len(t, truncateSQL(long), maxLoggedSQLLength+3)
+	assert.Equal(t, "SELECT 1", truncateSQL("  SELECT\n 1 "))
+}
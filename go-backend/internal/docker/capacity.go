@@ -0,0 +1,161 @@
+package docker
+
+import (
+	"context"
+	"sync"
+)
+
+// lane is the kind of container a capacity slot is taken for.
+type lane int
+
+const (
+	laneBacktest lane = iota
+	laneValidation
+)
+
+// capacityPools splits the containers the Docker host runs at once between
+// backtests and validations. Validations are interactive, so they may take
+// any free slot and waiting validations are served before waiting backtests,
+// while backtests leave reserved slots free for validations. A zero maximum
+// leaves containers unbounded.
+type capacityPools struct {
+	max      int // Containers run at once; 0 is unbounded
+	reserved int // Slots backtests leave for validations
+
+	mu        sync.Mutex
+	backtests int
+	inUse     int
+	waiting   [2][]chan struct{}  // Waiters by lane, oldest first
+	held      map[string]struct{} // Backtest containers holding a slot
+}
+
+func newCapacityPools(max, reserved int) *capacityPools {
+	if reserved > max {
+		reserved = max
+	}
+	return &capacityPools{
+		max:      max,
+		reserved: reserved,
+		held:     make(map[string]struct{}),
+	}
+}
+
+// acquire waits for a slot in a lane. It fails only if ctx is done first.
+func (p *capacityPools) acquire(ctx context.Context, l lane) error {
+	if p.max <= 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	if p.canTake(l) && (l == laneValidation || len(p.waiting[laneValidation]) == 0) && len(p.waiting[l]) == 0 {
+		p.take(l)
+		p.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	p.waiting[l] = append(p.waiting[l], ready)
+	p.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for i, w := range p.waiting[l] {
+			if w == ready {
+				p.waiting[l] = append(p.waiting[l][:i], p.waiting[l][i+1:]...)
+				return ctx.Err()
+			}
+		}
+		// The slot was handed over as ctx ended; pass it on
+		p.free(l)
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken for a lane.
+func (p *capacityPools) release(l lane) {
+	if p.max <= 0 {
+		return
+	}
+	p.mu.Lock()
+	p.free(l)
+	p.mu.Unlock()
+}
+
+// hold records that a started backtest container keeps its slot until it is
+// released by container ID.
+func (p *capacityPools) hold(containerID string) {
+	if p.max <= 0 {
+		return
+	}
+	p.mu.Lock()
+	p.held[containerID] = struct{}{}
+	p.mu.Unlock()
+}
+
+// releaseContainer frees the slot of a backtest container once it has
+// exited, been stopped or removed. Containers without a slot are ignored, so
+// it may be called for each of those.
+func (p *capacityPools) releaseContainer(containerID string) {
+	if p.max <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.held[containerID]; ok {
+		delete(p.held, containerID)
+		p.free(laneBacktest)
+	}
+}
+
+// freeSlots returns how many more containers of a lane could start now,
+// less those already waiting for a slot, so it is negative while more wait
+// than are free. bounded is false, and free meaningless, for an unbounded
+// pool.
+func (p *capacityPools) freeSlots(l lane) (free int, bounded bool) {
+	if p.max <= 0 {
+		return 0, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	free = p.max - p.inUse
+	if l == laneBacktest {
+		free = min(free, p.max-p.reserved-p.backtests) - len(p.waiting[laneBacktest])
+	}
+	return free - len(p.waiting[laneValidation]), true
+}
+
+// canTake reports whether a slot is free for the lane.
+func (p *capacityPools) canTake(l lane) bool {
+	if l == laneBacktest {
+		return p.inUse < p.max && p.backtests < p.max-p.reserved
+	}
+	return p.inUse < p.max
+}
+
+func (p *capacityPools) take(l lane) {
+	p.inUse++
+	if l == laneBacktest {
+		p.backtests++
+	}
+}
+
+// free returns a slot and hands free slots to waiters, validations first.
+func (p *capacityPools) free(l lane) {
+	p.inUse--
+	if l == laneBacktest {
+		p.backtests--
+	}
+
+	for _, next := range []lane{laneValidation, laneBacktest} {
+		for len(p.waiting[next]) > 0 && p.canTake(next) {
+			ready := p.waiting[next][0]
+			p.waiting[next] = p.waiting[next][1:]
+			p.take(next)
+			close(ready)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acquireAsync starts acquiring a slot and returns a channel closed once it
+// is granted.
+func acquireAsync(t *testing.T, p *capacityPools, l lane) <-chan struct{} {
+	t.Helper()
+	granted := make(chan struct{})
+	go func() {
+		if err := p.acquire(context.Background(), l); err == nil {
+			close(granted)
+		}
+	}()
+	return granted
+}
+
+// waitingFor waits until n acquirers wait in a lane.
+func waitingFor(t *testing.T, p *capacityPools, l lane, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return len(p.waiting[l]) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestCapacityPools_BacktestsLeaveReservedSlots(t *testing.T) {
+	p := newCapacityPools(3, 1)
+	ctx := context.Background()
+
+	require.NoError(t, p.acquire(ctx, laneBacktest))
+	require.NoError(t, p.acquire(ctx, laneBacktest))
+	p.hold("c1")
+
+	backtest := acquireAsync(t, p, laneBacktest)
+	waitingFor(t, p, laneBacktest, 1)
+
+	// The reserved slot is free for a validation
+	require.NoError(t, p.acquire(ctx, laneValidation))
+
+	p.releaseContainer("c1")
+	p.releaseContainer("c1") // Already released
+	<-backtest
+	assert.Equal(t, 2, p.backtests)
+	assert.Equal(t, 3, p.inUse)
+}
+
+func TestCapacityPools_ValidationsGoFirst(t *testing.T) {
+	p := newCapacityPools(2, 0)
+	ctx := context.Background()
+
+	require.NoError(t, p.acquire(ctx, laneBacktest))
+	require.NoError(t, p.acquire(ctx, laneBacktest))
+
+	backtest := acquireAsync(t, p, laneBacktest)
+	waitingFor(t, p, laneBacktest, 1)
+	validation := acquireAsync(t, p, laneValidation)
+	waitingFor(t, p, laneValidation, 1)
+
+	p.release(laneBacktest)
+	<-validation
+	select {
+	case <-backtest:
+		t.Fatal("backtest started ahead of a waiting validation")
+	default:
+	}
+
+	p.release(laneValidation)
+	<-backtest
+}
+
+func TestCapacityPools_CancelledWait(t *testing.T) {
+	p := newCapacityPools(1, 0)
+	require.NoError(t, p.acquire(context.Background(), laneBacktest))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.acquire(ctx, laneValidation), context.DeadlineExceeded)
+
+	p.release(laneBacktest)
+	assert.Equal(t, 0, p.inUse)
+	assert.Empty(t, p.waiting[laneValidation])
+}
+
+func TestCapacityPools_Unbounded(t *testing.T) {
+	p := newCapacityPools(0, 0)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, p.acquire(context.Background(), laneBacktest))
+	}
+	p.hold("c1")
+	p.releaseContainer("c1")
+	assert.Equal(t, 0, p.inUse)
+}
+
+func TestCapacityPools_FreeSlots(t *testing.T) {
+	p := newCapacityPools(3, 1)
+	ctx := context.Background()
+
+	free, bounded := p.freeSlots(laneBacktest)
+	assert.True(t, bounded)
+	assert.Equal(t, 2, free)
+
+	require.NoError(t, p.acquire(ctx, laneBacktest))
+	require.NoError(t, p.acquire(ctx, laneBacktest))
+	free, _ = p.freeSlots(laneBacktest)
+	assert.Equal(t, 0, free)
+	free, _ = p.freeSlots(laneValidation)
+	assert.Equal(t, 1, free)
+
+	acquireAsync(t, p, laneBacktest)
+	waitingFor(t, p, laneBacktest, 1)
+	free, _ = p.freeSlots(laneBacktest)
+	assert.Equal(t, -1, free)
+
+	_, bounded = newCapacityPools(0, 0).freeSlots(laneBacktest)
+	assert.False(t, bounded)
+}
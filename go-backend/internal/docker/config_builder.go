@@ -158,6 +158,11 @@ func (b *ConfigBuilder) applyBacktestConfig(config map[string]interface{}, btCon
 		}
 	}
 
+	// Stake currency - only override if specified
+	if btConfig.StakeCurrency != "" {
+		config["stake_currency"] = btConfig.StakeCurrency
+	}
+
 	// Transform pairs for futures trading mode - only if pairs are specified
 	// Futures pairs need format: "BTC/USDT:USDT" instead of "BTC/USDT"
 	if len(btConfig.Pairs) > 0 {
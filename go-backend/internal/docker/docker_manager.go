@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -21,13 +23,35 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/parser"
 )
 
 const (
 	// Validator image name
 	validatorImage = "freqsearch/strategy-validator:latest"
+
+	// validatorRepository is the repository of validator images built on
+	// other Freqtrade images
+	validatorRepository = "freqsearch/strategy-validator"
 )
 
+// validatorTagChars matches characters not allowed in an image tag.
+var validatorTagChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// validatorImageFor returns the validator image built on a Freqtrade base
+// image, or the default validator image for an empty base.
+func validatorImageFor(baseImage string) string {
+	if baseImage == "" {
+		return validatorImage
+	}
+	tag := validatorTagChars.ReplaceAllString(baseImage, "_")
+	if len(tag) > 128 {
+		tag = tag[len(tag)-128:]
+	}
+	return validatorRepository + ":" + tag
+}
+
 // toAbsolutePath converts a relative path to absolute path.
 // If the path is already absolute, it returns as-is.
 func toAbsolutePath(path string) string {
@@ -48,21 +72,53 @@ const (
 	labelManaged = "freqsearch.managed"
 
 	// Default resource limits
+	cpuPeriod        = 100000 // CPU quota per CPU
 	defaultCPUQuota  = 200000 // 2 CPUs (100000 per CPU)
 	defaultMemoryMB  = 2048   // 2 GB
 )
 
+// probedPackages are the Python packages whose versions are recorded with
+// each result, besides Freqtrade and Python themselves.
+var probedPackages = []string{
+	"ccxt", "numpy", "pandas", "pandas-ta", "ta-lib", "technical",
+	"scipy", "scikit-learn", "lightgbm", "xgboost", "torch",
+}
+
+// environmentProbeCmd prints the container's Freqtrade, Python and package
+// versions between the markers the result parser looks for.
+var environmentProbeCmd = fmt.Sprintf(
+	`echo "%s"; freqtrade --version 2>/dev/null; python -V 2>&1; pip freeze 2>/dev/null | grep -iE '^(%s)=='; echo "%s"`,
+	parser.EnvironmentBlockStart,
+	strings.Join(probedPackages, "|"),
+	parser.EnvironmentBlockEnd,
+)
+
+// resultsExportCmd prints the results file the backtest exported between the
+// markers the result parser looks for. Freqtrade names the latest file in
+// .last_result.json; newer versions zip it together with the config.
+var resultsExportCmd = fmt.Sprintf(
+	`echo "%s"; python -c "import json,zipfile,pathlib;d=pathlib.Path('/freqtrade/user_data/backtest_results');f=d/json.loads((d/'.last_result.json').read_text())['latest_backtest'];print(zipfile.ZipFile(f).read(f.stem+'.json').decode() if f.suffix=='.zip' else f.read_text())" 2>/dev/null; echo "%s"`,
+	parser.ExportBlockStart,
+	parser.ExportBlockEnd,
+)
+
 // dockerManager implements Manager using the Docker SDK.
 type dockerManager struct {
 	client         *client.Client
 	config         *config.DockerConfig
 	configBuilder  *ConfigBuilder
 	injector       *StrategyInjector
+	pools          *capacityPools
 	logger         *zap.Logger
 }
 
-// NewDockerManager creates a new Docker manager.
+// NewDockerManager creates a new Docker manager. With hosts configured, it
+// manages the pool of them; otherwise the host the environment points to.
 func NewDockerManager(cfg *config.DockerConfig, logger *zap.Logger) (Manager, error) {
+	if len(cfg.Hosts) > 0 {
+		return newHostPool(cfg, logger)
+	}
+
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Docker client: %w", err)
@@ -81,13 +137,20 @@ func NewDockerManager(cfg *config.DockerConfig, logger *zap.Logger) (Manager, er
 		zap.String("image", cfg.Image),
 	)
 
+	return newDockerManager(cli, cfg, cfg.MaxContainers, logger), nil
+}
+
+// newDockerManager creates a manager of the host a client is connected to,
+// running at most maxContainers containers there at once.
+func newDockerManager(cli *client.Client, cfg *config.DockerConfig, maxContainers int, logger *zap.Logger) *dockerManager {
 	return &dockerManager{
 		client:        cli,
 		config:        cfg,
 		configBuilder: NewConfigBuilder(cfg.BaseConfigPath, logger),
 		injector:      NewStrategyInjector(logger),
+		pools:         newCapacityPools(maxContainers, cfg.ReservedValidationSlots),
 		logger:        logger,
-	}, nil
+	}
 }
 
 // RunBacktest starts a Freqtrade backtest container.
@@ -107,26 +170,77 @@ func (m *dockerManager) RunBacktest(ctx context.Context, params *RunBacktestPara
 	}
 	// Note: Cleanup is called by the scheduler after container completion
 
-	// 3. Build timerange
-	timerange := params.Config.Timerange()
+	// 3. Determine the pairs, timeframe and timerange to download data for
+	pairsArg, timeframe, timerange := marketDataArgs(params.Config, configResult)
+
+	// 4. Create container with download + backtest command
+	// First download data, then run backtest
+	backtestCmd := fmt.Sprintf(
+		"freqtrade backtesting --strategy %s --config /freqtrade/config.json --timerange %s --export trades",
+		params.StrategyName,
+		timerange,
+	)
+
+	containerConfig := &container.Config{
+		Image:      m.config.Image,
+		Entrypoint: []string{"/bin/sh", "-c"},
+		Cmd:        []string{environmentProbeCmd + "; " + downloadDataCmd(pairsArg, timeframe, timerange) + " && " + backtestCmd + " && { " + resultsExportCmd + "; }"},
+		Labels: map[string]string{
+			labelJobID:   params.JobID.String(),
+			labelManaged: "true",
+		},
+		Env: []string{
+			"FREQTRADE_STRATEGY=" + params.StrategyName,
+		},
+	}
+
+	hostConfig := &container.HostConfig{
+		Binds:       m.freqtradeBinds(params.StrategyName, strategyResult, configResult),
+		Resources:   backtestResources(params.CPULimit, params.MemoryLimitMB),
+		NetworkMode: container.NetworkMode(m.config.Network),
+		AutoRemove:  false, // We handle removal manually
+	}
 
-	// 4. Transform pairs for futures trading mode and build pairs string
+	containerID, err := m.startFreqtradeContainer(ctx, containerConfig, hostConfig, func() {
+		strategyResult.Cleanup()
+		configResult.Cleanup()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	m.logger.Info("Started backtest container",
+		zap.String("container_id", containerID[:12]),
+		zap.String("job_id", params.JobID.String()),
+		zap.String("strategy", params.StrategyName),
+		zap.String("timerange", timerange),
+	)
+
+	// Store cleanup functions for later (will be called by scheduler)
+	// Note: In production, these should be stored and called appropriately
+
+	return containerID, nil
+}
+
+// marketDataArgs returns the pairs argument, timeframe and timerange a
+// Freqtrade container runs with, falling back to the base config's pairs and
+// timeframe.
+func marketDataArgs(cfg domain.BacktestConfig, configResult *BuildResult) (string, string, string) {
+	// Transform pairs for futures trading mode
 	// Futures pairs need format: "BTC/USDT:USDT" instead of "BTC/USDT"
-	pairs := params.Config.Pairs
-	if params.Config.GetTradingMode() == "futures" {
+	pairs := cfg.Pairs
+	if cfg.GetTradingMode() == "futures" {
 		pairs = transformPairsForFutures(pairs, "USDT")
 	}
 
-	// 5. Get pairs from config file if not specified in params
+	// Get pairs from config file if not specified
 	// This allows us to use the base_config.json's pair_whitelist
 	if len(pairs) == 0 {
 		pairs = configResult.Pairs
 	}
-	pairsArg := strings.Join(pairs, " ")
 
-	// 6. Determine timeframe - use config file's timeframe if not specified
-	// Default to "5m" if no timeframe is specified anywhere
-	timeframe := params.Config.Timeframe
+	// Use config file's timeframe if not specified, defaulting to "5m"
+	timeframe := cfg.Timeframe
 	if timeframe == "" {
 		timeframe = configResult.Timeframe
 	}
@@ -134,61 +248,51 @@ func (m *dockerManager) RunBacktest(ctx context.Context, params *RunBacktestPara
 		timeframe = "5m" // Default timeframe
 	}
 
-	// 7. Create container with download + backtest command
-	// First download data, then run backtest
-	downloadCmd := fmt.Sprintf(
+	return strings.Join(pairs, " "), timeframe, cfg.Timerange()
+}
+
+// downloadDataCmd returns the command downloading the market data a
+// container runs on. A failed download is left to the command that follows.
+func downloadDataCmd(pairsArg, timeframe, timerange string) string {
+	return fmt.Sprintf(
 		"freqtrade download-data --config /freqtrade/config.json --pairs %s --timeframes %s --timerange %s --trading-mode futures || true",
 		pairsArg,
 		timeframe,
 		timerange,
 	)
-	backtestCmd := fmt.Sprintf(
-		"freqtrade backtesting --strategy %s --config /freqtrade/config.json --timerange %s --export none",
-		params.StrategyName,
-		timerange,
-	)
-
-	containerConfig := &container.Config{
-		Image:      m.config.Image,
-		Entrypoint: []string{"/bin/sh", "-c"},
-		Cmd:        []string{downloadCmd + " && " + backtestCmd},
-		Labels: map[string]string{
-			labelJobID:   params.JobID.String(),
-			labelManaged: "true",
-		},
-		Env: []string{
-			"FREQTRADE_STRATEGY=" + params.StrategyName,
-		},
-	}
+}
 
-	// 6. Configure host settings (convert relative paths to absolute)
+// freqtradeBinds returns the mounts of a Freqtrade container: the market
+// data, the strategy file and the runtime config. Relative paths are made
+// absolute.
+func (m *dockerManager) freqtradeBinds(strategyName string, strategyResult *InjectResult, configResult *BuildResult) []string {
 	dataMount := toAbsolutePath(m.config.DataMount)
-	hostConfig := &container.HostConfig{
-		Binds: []string{
-			dataMount + ":/freqtrade/user_data/data:rw", // rw needed for leverage_tiers cache and download
-			strategyResult.StrategyPath + ":/freqtrade/user_data/strategies/" + params.StrategyName + ".py:rw",
-			configResult.ConfigPath + ":/freqtrade/config.json:ro",
-		},
-		Resources: container.Resources{
-			CPUQuota: defaultCPUQuota,
-			Memory:   int64(defaultMemoryMB) * 1024 * 1024,
-		},
-		NetworkMode: container.NetworkMode(m.config.Network),
-		AutoRemove:  false, // We handle removal manually
+	return []string{
+		dataMount + ":/freqtrade/user_data/data:rw", // rw needed for leverage_tiers cache and download
+		strategyResult.StrategyPath + ":/freqtrade/user_data/strategies/" + strategyName + ".py:rw",
+		configResult.ConfigPath + ":/freqtrade/config.json:ro",
 	}
+}
 
-	// 6. Ensure image exists
+// startFreqtradeContainer creates and starts a Freqtrade container once the
+// image is present and a backtest slot is free; the slot is held until the
+// container is done. cleanup removes the container's temporary files if it
+// cannot be started.
+func (m *dockerManager) startFreqtradeContainer(ctx context.Context, containerConfig *container.Config, hostConfig *container.HostConfig, cleanup func()) (string, error) {
 	if err := m.ensureImage(ctx); err != nil {
-		strategyResult.Cleanup()
-		configResult.Cleanup()
+		cleanup()
 		return "", fmt.Errorf("failed to ensure image: %w", err)
 	}
 
-	// 7. Create and start container
+	if err := m.pools.acquire(ctx, laneBacktest); err != nil {
+		cleanup()
+		return "", fmt.Errorf("failed to wait for container capacity: %w", err)
+	}
+
 	resp, err := m.client.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, "")
 	if err != nil {
-		strategyResult.Cleanup()
-		configResult.Cleanup()
+		m.pools.release(laneBacktest)
+		cleanup()
 		return "", fmt.Errorf("failed to create container: %w", err)
 	}
 
@@ -197,24 +301,31 @@ func (m *dockerManager) RunBacktest(ctx context.Context, params *RunBacktestPara
 	if err := m.client.ContainerStart(ctx, containerID, container.StartOptions{}); err != nil {
 		// Cleanup container
 		m.client.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true})
-		strategyResult.Cleanup()
-		configResult.Cleanup()
+		m.pools.release(laneBacktest)
+		cleanup()
 		return "", fmt.Errorf("failed to start container: %w", err)
 	}
-
-	m.logger.Info("Started backtest container",
-		zap.String("container_id", containerID[:12]),
-		zap.String("job_id", params.JobID.String()),
-		zap.String("strategy", params.StrategyName),
-		zap.String("timerange", timerange),
-	)
-
-	// Store cleanup functions for later (will be called by scheduler)
-	// Note: In production, these should be stored and called appropriately
+	m.pools.hold(containerID)
 
 	return containerID, nil
 }
 
+// backtestResources returns the resource limits of a Freqtrade container:
+// the given limits where set, the defaults otherwise.
+func backtestResources(cpuLimit float64, memoryLimitMB int) container.Resources {
+	resources := container.Resources{
+		CPUQuota: defaultCPUQuota,
+		Memory:   int64(defaultMemoryMB) * 1024 * 1024,
+	}
+	if cpuLimit > 0 {
+		resources.CPUQuota = int64(cpuLimit * cpuPeriod)
+	}
+	if memoryLimitMB > 0 {
+		resources.Memory = int64(memoryLimitMB) * 1024 * 1024
+	}
+	return resources
+}
+
 // ValidateStrategy validates a strategy using the validator container.
 // This is much faster than running a full backtest as it only checks:
 // - Python syntax
@@ -230,13 +341,14 @@ func (m *dockerManager) ValidateStrategy(ctx context.Context, params *ValidateSt
 	defer strategyResult.Cleanup()
 
 	// 2. Ensure validator image exists
-	if err := m.ensureValidatorImage(ctx); err != nil {
+	validator := validatorImageFor(params.BaseImage)
+	if err := m.ensureValidatorImage(ctx, validator, params.BaseImage); err != nil {
 		return nil, fmt.Errorf("failed to ensure validator image: %w", err)
 	}
 
 	// 3. Create container config
 	containerConfig := &container.Config{
-		Image: validatorImage,
+		Image: validator,
 		Cmd:   []string{"/strategy.py"},
 		Labels: map[string]string{
 			labelManaged: "true",
@@ -255,7 +367,13 @@ func (m *dockerManager) ValidateStrategy(ctx context.Context, params *ValidateSt
 		AutoRemove:  true,
 	}
 
-	// 4. Create and start container
+	// 4. Wait for a slot; validations go ahead of waiting backtests
+	if err := m.pools.acquire(ctx, laneValidation); err != nil {
+		return nil, fmt.Errorf("failed to wait for container capacity: %w", err)
+	}
+	defer m.pools.release(laneValidation)
+
+	// 5. Create and start container
 	resp, err := m.client.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create validator container: %w", err)
@@ -268,7 +386,7 @@ func (m *dockerManager) ValidateStrategy(ctx context.Context, params *ValidateSt
 		return nil, fmt.Errorf("failed to start validator container: %w", err)
 	}
 
-	// 5. Wait for completion (with timeout)
+	// 6. Wait for completion (with timeout)
 	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -287,7 +405,7 @@ func (m *dockerManager) ValidateStrategy(ctx context.Context, params *ValidateSt
 		return nil, fmt.Errorf("validation timeout")
 	}
 
-	// 6. Parse JSON result from logs
+	// 7. Parse JSON result from logs
 	var result ValidationResult
 	if err := json.Unmarshal([]byte(strings.TrimSpace(logs)), &result); err != nil {
 		// Fallback: treat logs as error message
@@ -307,10 +425,11 @@ func (m *dockerManager) ValidateStrategy(ctx context.Context, params *ValidateSt
 }
 
 // ensureValidatorImage ensures the validator image is available locally.
-// If not found, it will build the image automatically.
-func (m *dockerManager) ensureValidatorImage(ctx context.Context) error {
+// If not found, it will build the image automatically, on baseImage unless
+// that is empty.
+func (m *dockerManager) ensureValidatorImage(ctx context.Context, validator, baseImage string) error {
 	// Check if image exists
-	_, _, err := m.client.ImageInspectWithRaw(ctx, validatorImage)
+	_, _, err := m.client.ImageInspectWithRaw(ctx, validator)
 	if err == nil {
 		return nil // Image exists
 	}
@@ -320,13 +439,17 @@ func (m *dockerManager) ensureValidatorImage(ctx context.Context) error {
 	}
 
 	// Image not found - build it
-	m.logger.Info("Validator image not found, building...", zap.String("image", validatorImage))
+	m.logger.Info("Validator image not found, building...",
+		zap.String("image", validator),
+		zap.String("base_image", baseImage),
+	)
 
-	return m.buildValidatorImage(ctx)
+	return m.buildValidatorImage(ctx, validator, baseImage)
 }
 
-// buildValidatorImage builds the validator image from Dockerfile.
-func (m *dockerManager) buildValidatorImage(ctx context.Context) error {
+// buildValidatorImage builds the validator image from Dockerfile, on
+// baseImage unless that is empty.
+func (m *dockerManager) buildValidatorImage(ctx context.Context, validator, baseImage string) error {
 	// Get the docker directory path (relative to working directory)
 	dockerfilePath := "docker/freqtrade"
 
@@ -345,10 +468,13 @@ func (m *dockerManager) buildValidatorImage(ctx context.Context) error {
 
 	// Build the image
 	buildOptions := types.ImageBuildOptions{
-		Tags:       []string{validatorImage},
+		Tags:       []string{validator},
 		Dockerfile: "Dockerfile",
 		Remove:     true,
 	}
+	if baseImage != "" {
+		buildOptions.BuildArgs = map[string]*string{"BASE_IMAGE": &baseImage}
+	}
 
 	resp, err := m.client.ImageBuild(ctx, buildCtx, buildOptions)
 	if err != nil {
@@ -379,7 +505,7 @@ func (m *dockerManager) buildValidatorImage(ctx context.Context) error {
 	}
 
 	m.logger.Info("Validator image built successfully",
-		zap.String("image", validatorImage),
+		zap.String("image", validator),
 		zap.String("last_output", strings.TrimSpace(lastLine)),
 	)
 
@@ -456,6 +582,8 @@ func (m *dockerManager) WaitContainer(ctx context.Context, containerID string) (
 			return -1, "", fmt.Errorf("error waiting for container: %w", err)
 		}
 	case status := <-statusCh:
+		m.pools.releaseContainer(containerID)
+
 		// Get logs
 		logs, err := m.GetContainerLogs(ctx, containerID)
 		if err != nil {
@@ -488,6 +616,7 @@ func (m *dockerManager) StopContainer(ctx context.Context, containerID string) e
 	if err := m.client.ContainerStop(ctx, containerID, stopOptions); err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
+	m.pools.releaseContainer(containerID)
 
 	m.logger.Info("Stopped container",
 		zap.String("container_id", containerID[:12]),
@@ -496,6 +625,28 @@ func (m *dockerManager) StopContainer(ctx context.Context, containerID string) e
 	return nil
 }
 
+// TerminateContainer sends a running container SIGTERM and kills it if it
+// has not exited within the grace period.
+func (m *dockerManager) TerminateContainer(ctx context.Context, containerID string, grace time.Duration) error {
+	timeout := int(math.Ceil(grace.Seconds()))
+	stopOptions := container.StopOptions{
+		Signal:  "SIGTERM",
+		Timeout: &timeout,
+	}
+
+	if err := m.client.ContainerStop(ctx, containerID, stopOptions); err != nil {
+		return fmt.Errorf("failed to terminate container: %w", err)
+	}
+	m.pools.releaseContainer(containerID)
+
+	m.logger.Info("Terminated container",
+		zap.String("container_id", containerID[:12]),
+		zap.Duration("grace_period", grace),
+	)
+
+	return nil
+}
+
 // RemoveContainer removes a container.
 func (m *dockerManager) RemoveContainer(ctx context.Context, containerID string) error {
 	removeOptions := container.RemoveOptions{
@@ -506,6 +657,7 @@ func (m *dockerManager) RemoveContainer(ctx context.Context, containerID string)
 	if err := m.client.ContainerRemove(ctx, containerID, removeOptions); err != nil {
 		return fmt.Errorf("failed to remove container: %w", err)
 	}
+	m.pools.releaseContainer(containerID)
 
 	m.logger.Debug("Removed container",
 		zap.String("container_id", containerID[:12]),
@@ -608,6 +760,65 @@ func (m *dockerManager) IsContainerRunning(ctx context.Context, containerID stri
 	return inspect.State.Running, nil
 }
 
+// GetContainerState reports whether a container is running, has exited or
+// is missing.
+func (m *dockerManager) GetContainerState(ctx context.Context, containerID string) (ContainerState, error) {
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return ContainerStateMissing, nil
+		}
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	if inspect.State.Running {
+		return ContainerStateRunning, nil
+	}
+	return ContainerStateExited, nil
+}
+
+// InspectEnvironment returns the image and host a finished container ran on.
+func (m *dockerManager) InspectEnvironment(ctx context.Context, containerID string) (*domain.ExecutionEnvironment, error) {
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	// The local image ID is a content digest; prefer the registry digest,
+	// which identifies the image across hosts
+	env := &domain.ExecutionEnvironment{ImageDigest: inspect.Image}
+	if inspect.Config != nil {
+		env.Image = inspect.Config.Image
+	}
+	if img, _, err := m.client.ImageInspectWithRaw(ctx, inspect.Image); err == nil {
+		for _, repoDigest := range img.RepoDigests {
+			if i := strings.Index(repoDigest, "@"); i >= 0 {
+				env.ImageDigest = repoDigest[i+1:]
+				break
+			}
+		}
+	}
+
+	if info, err := m.client.Info(ctx); err == nil {
+		env.Host = info.Name
+	}
+
+	return env, nil
+}
+
+// Ping checks that the Docker daemon is reachable.
+func (m *dockerManager) Ping(ctx context.Context) error {
+	if _, err := m.client.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping Docker daemon: %w", err)
+	}
+	return nil
+}
+
+// ContainerHost returns no host name; there is only the one host.
+func (m *dockerManager) ContainerHost(containerID string) string {
+	return ""
+}
+
 // ensureImage ensures the Freqtrade image is available locally.
 func (m *dockerManager) ensureImage(ctx context.Context) error {
 	// Check if image exists
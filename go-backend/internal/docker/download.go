@@ -0,0 +1,86 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// labelDownloadID labels the containers of market data downloads.
+const labelDownloadID = "freqsearch.download_id"
+
+// RunDownloadData starts a Freqtrade download-data container. Unlike the
+// download backtest containers run first, it fails if the download does.
+func (m *dockerManager) RunDownloadData(ctx context.Context, params *DownloadDataParams) (string, error) {
+	req := params.Request
+	configResult, err := m.configBuilder.BuildRuntimeConfig(domain.BacktestConfig{
+		Exchange:    req.Exchange,
+		Pairs:       req.Pairs,
+		TradingMode: req.TradingMode,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build config: %w", err)
+	}
+
+	containerConfig := &container.Config{
+		Image:      m.config.Image,
+		Entrypoint: []string{"/bin/sh", "-c"},
+		Cmd:        []string{downloadDataRequestCmd(req)},
+		Labels: map[string]string{
+			labelDownloadID: params.DownloadID.String(),
+			labelManaged:    "true",
+		},
+	}
+
+	hostConfig := &container.HostConfig{
+		Binds: []string{
+			toAbsolutePath(m.config.DataMount) + ":/freqtrade/user_data/data:rw",
+			configResult.ConfigPath + ":/freqtrade/config.json:ro",
+		},
+		Resources:   backtestResources(0, 0),
+		NetworkMode: container.NetworkMode(m.config.Network),
+	}
+
+	containerID, err := m.startFreqtradeContainer(ctx, containerConfig, hostConfig, configResult.Cleanup)
+	if err != nil {
+		return "", err
+	}
+
+	m.logger.Info("Started download-data container",
+		zap.String("container_id", containerID[:12]),
+		zap.String("download_id", params.DownloadID.String()),
+		zap.String("exchange", req.Exchange),
+		zap.Strings("pairs", req.Pairs),
+		zap.Strings("timeframes", req.Timeframes),
+	)
+
+	return containerID, nil
+}
+
+// downloadDataRequestCmd returns the freqtrade download-data command of a
+// download. Unset pairs, timeframes and timerange are left to Freqtrade: the
+// config's pair whitelist, its default timeframes and the last 30 days.
+func downloadDataRequestCmd(req domain.DataDownloadRequest) string {
+	cmd := "freqtrade download-data --config /freqtrade/config.json --trading-mode " + req.GetTradingMode()
+
+	if len(req.Pairs) > 0 {
+		pairs := req.Pairs
+		if req.GetTradingMode() == domain.TradingModeFutures {
+			pairs = transformPairsForFutures(pairs, "USDT")
+		}
+		cmd += " --pairs " + strings.Join(pairs, " ")
+	}
+	if len(req.Timeframes) > 0 {
+		cmd += " --timeframes " + strings.Join(req.Timeframes, " ")
+	}
+	if req.TimerangeStart != "" {
+		timerange := domain.BacktestConfig{TimerangeStart: req.TimerangeStart, TimerangeEnd: req.TimerangeEnd}
+		cmd += " --timerange " + timerange.Timerange()
+	}
+	return cmd
+}
@@ -0,0 +1,524 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/parser"
+)
+
+// Environment reported for simulated runs.
+const (
+	fakeImage            = "freqsearch/fake-executor"
+	fakeImageDigest      = "sha256:fake"
+	fakeHost             = "fake-executor"
+	fakeFreqtradeVersion = "2025.4"
+	fakePythonVersion    = "3.12.0"
+)
+
+// Load each simulated run puts on the simulated host.
+const (
+	fakeContainerCPUPercent    = 10
+	fakeContainerMemoryPercent = 8
+	fakeHostMemoryBytes        = 16 << 30
+)
+
+// fakeManager implements Manager by simulating backtests in memory.
+// It produces Freqtrade-style summary output that the result parser understands,
+// so the scheduler, events, and APIs can be exercised without Docker.
+type fakeManager struct {
+	config      *config.FakeExecutorConfig
+	clock       clock.Clock
+	minDuration time.Duration
+	maxDuration time.Duration
+	logger      *zap.Logger
+
+	mu         sync.Mutex
+	rng        *rand.Rand
+	containers map[string]*fakeContainer
+}
+
+// fakeContainer tracks a simulated backtest run.
+type fakeContainer struct {
+	params    *RunBacktestParams
+	createdAt time.Time
+	duration  time.Duration
+	exitCode  int64
+	logs      string
+	finished  bool
+	stopped   chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewFakeManager creates a Manager that simulates backtests.
+// Run durations and outcomes are drawn from cfg.Fake using a seeded source,
+// and all waiting goes through clk so tests can advance time manually.
+func NewFakeManager(cfg *config.DockerConfig, clk clock.Clock, logger *zap.Logger) (Manager, error) {
+	minDuration, err := time.ParseDuration(cfg.Fake.MinDuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fake min_duration: %w", err)
+	}
+	maxDuration, err := time.ParseDuration(cfg.Fake.MaxDuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fake max_duration: %w", err)
+	}
+	if maxDuration < minDuration {
+		return nil, fmt.Errorf("fake max_duration %s is less than min_duration %s", maxDuration, minDuration)
+	}
+	if clk == nil {
+		clk = clock.Real()
+	}
+
+	logger.Info("Using fake backtest executor",
+		zap.Duration("min_duration", minDuration),
+		zap.Duration("max_duration", maxDuration),
+		zap.Float64("failure_rate", cfg.Fake.FailureRate),
+		zap.Int64("seed", cfg.Fake.Seed),
+	)
+
+	return &fakeManager{
+		config:      &cfg.Fake,
+		clock:       clk,
+		minDuration: minDuration,
+		maxDuration: maxDuration,
+		logger:      logger,
+		rng:         rand.New(rand.NewSource(cfg.Fake.Seed)),
+		containers:  make(map[string]*fakeContainer),
+	}, nil
+}
+
+// RunBacktest starts a simulated backtest and decides its outcome up front.
+func (m *fakeManager) RunBacktest(ctx context.Context, params *RunBacktestParams) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	duration := m.minDuration
+	if spread := m.maxDuration - m.minDuration; spread > 0 {
+		duration += time.Duration(m.rng.Int63n(int64(spread) + 1))
+	}
+
+	c := &fakeContainer{
+		params:    params,
+		createdAt: m.clock.Now(),
+		duration:  duration,
+		stopped:   make(chan struct{}),
+	}
+
+	if m.rng.Float64() < m.config.FailureRate {
+		c.exitCode = 1
+		c.logs = fakeFailureLogs(params)
+	} else {
+		c.logs = m.fakeSuccessLogs(params)
+	}
+
+	containerID := "fake-" + uuid.New().String()
+	m.containers[containerID] = c
+
+	m.logger.Debug("Started fake backtest",
+		zap.String("job_id", params.JobID.String()),
+		zap.String("container_id", containerID),
+		zap.Duration("duration", duration),
+		zap.Int64("exit_code", c.exitCode),
+	)
+
+	return containerID, nil
+}
+
+// RunHyperopt starts a simulated hyperopt whose best epoch only tunes the
+// stoploss. It runs as long as a simulated backtest.
+func (m *fakeManager) RunHyperopt(ctx context.Context, params *RunHyperoptParams) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	duration := m.minDuration
+	if spread := m.maxDuration - m.minDuration; spread > 0 {
+		duration += time.Duration(m.rng.Int63n(int64(spread) + 1))
+	}
+
+	c := &fakeContainer{
+		createdAt: m.clock.Now(),
+		duration:  duration,
+		stopped:   make(chan struct{}),
+	}
+
+	if m.rng.Float64() < m.config.FailureRate {
+		c.exitCode = 1
+		c.logs = fakeFailureLogs(&RunBacktestParams{JobID: params.JobID, StrategyName: params.StrategyName})
+	} else {
+		c.logs = m.fakeHyperoptLogs(params)
+	}
+
+	containerID := "fake-" + uuid.New().String()
+	m.containers[containerID] = c
+
+	m.logger.Debug("Started fake hyperopt",
+		zap.String("job_id", params.JobID.String()),
+		zap.String("container_id", containerID),
+		zap.Duration("duration", duration),
+		zap.Int64("exit_code", c.exitCode),
+	)
+
+	return containerID, nil
+}
+
+// RunDownloadData starts a simulated download that always succeeds. No data
+// is written.
+func (m *fakeManager) RunDownloadData(ctx context.Context, params *DownloadDataParams) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := &fakeContainer{
+		createdAt: m.clock.Now(),
+		duration:  m.minDuration,
+		stopped:   make(chan struct{}),
+		logs: fmt.Sprintf("Downloading data for %d pairs (%s) on %s\n",
+			len(params.Request.Pairs), strings.Join(params.Request.Timeframes, ", "), params.Request.GetTradingMode()),
+	}
+
+	containerID := "fake-" + uuid.New().String()
+	m.containers[containerID] = c
+
+	m.logger.Debug("Started fake download",
+		zap.String("download_id", params.DownloadID.String()),
+		zap.String("container_id", containerID),
+	)
+
+	return containerID, nil
+}
+
+// ValidateStrategy accepts any strategy whose code defines the named class.
+func (m *fakeManager) ValidateStrategy(ctx context.Context, params *ValidateStrategyParams) (*ValidationResult, error) {
+	result := &ValidationResult{
+		Valid:     true,
+		Errors:    []string{},
+		Warnings:  []string{},
+		ClassName: params.StrategyName,
+	}
+
+	if !strings.Contains(params.StrategyCode, "class "+params.StrategyName) {
+		result.Valid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("strategy class %q not found", params.StrategyName))
+	}
+
+	return result, nil
+}
+
+// WaitContainer blocks until the simulated run finishes, is stopped, or ctx is done.
+func (m *fakeManager) WaitContainer(ctx context.Context, containerID string) (int64, string, error) {
+	c, err := m.get(containerID)
+	if err != nil {
+		return -1, "", err
+	}
+
+	remaining := c.duration - m.clock.Since(c.createdAt)
+	select {
+	case <-ctx.Done():
+		return -1, "", ctx.Err()
+	case <-c.stopped:
+		return 137, "", nil
+	case <-m.clock.After(remaining):
+	}
+
+	m.mu.Lock()
+	c.finished = true
+	m.mu.Unlock()
+
+	return c.exitCode, c.logs, nil
+}
+
+// StopContainer stops a simulated run.
+func (m *fakeManager) StopContainer(ctx context.Context, containerID string) error {
+	c, err := m.get(containerID)
+	if err != nil {
+		return err
+	}
+	c.stopOnce.Do(func() { close(c.stopped) })
+	return nil
+}
+
+// TerminateContainer stops a simulated run at once; it has nothing to wind
+// down within the grace period.
+func (m *fakeManager) TerminateContainer(ctx context.Context, containerID string, grace time.Duration) error {
+	return m.StopContainer(ctx, containerID)
+}
+
+// RemoveContainer forgets a simulated run.
+func (m *fakeManager) RemoveContainer(ctx context.Context, containerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.containers, containerID)
+	return nil
+}
+
+// GetContainerLogs returns the logs of a finished run.
+func (m *fakeManager) GetContainerLogs(ctx context.Context, containerID string) (string, error) {
+	c, err := m.get(containerID)
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !c.finished {
+		return "", nil
+	}
+	return c.logs, nil
+}
+
+// StreamContainerLogs writes a simulated run's output, which appears all at
+// once as it finishes; with follow it waits for that.
+func (m *fakeManager) StreamContainerLogs(ctx context.Context, containerID string, follow bool, w io.Writer) error {
+	var logs string
+	var err error
+	if follow {
+		_, logs, err = m.WaitContainer(ctx, containerID)
+	} else {
+		logs, err = m.GetContainerLogs(ctx, containerID)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, logs)
+	return err
+}
+
+// CleanupStaleContainers removes simulated runs older than maxAge.
+func (m *fakeManager) CleanupStaleContainers(ctx context.Context, maxAge time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := m.clock.Now().Add(-maxAge)
+	removed := 0
+	for id, c := range m.containers {
+		if c.createdAt.Before(cutoff) {
+			c.stopOnce.Do(func() { close(c.stopped) })
+			delete(m.containers, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// IsContainerRunning reports whether a simulated run is still in progress.
+func (m *fakeManager) IsContainerRunning(ctx context.Context, containerID string) (bool, error) {
+	c, err := m.get(containerID)
+	if err != nil {
+		return false, nil
+	}
+
+	select {
+	case <-c.stopped:
+		return false, nil
+	default:
+	}
+	return m.clock.Since(c.createdAt) < c.duration, nil
+}
+
+// GetContainerState reports whether a simulated run is in progress, has
+// finished or been stopped, or was removed.
+func (m *fakeManager) GetContainerState(ctx context.Context, containerID string) (ContainerState, error) {
+	running, err := m.IsContainerRunning(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	if running {
+		return ContainerStateRunning, nil
+	}
+	if _, err := m.get(containerID); err != nil {
+		return ContainerStateMissing, nil
+	}
+	return ContainerStateExited, nil
+}
+
+// InspectEnvironment reports the fixed environment of simulated runs.
+func (m *fakeManager) InspectEnvironment(ctx context.Context, containerID string) (*domain.ExecutionEnvironment, error) {
+	if _, err := m.get(containerID); err != nil {
+		return nil, err
+	}
+	return &domain.ExecutionEnvironment{
+		Image:       fakeImage,
+		ImageDigest: fakeImageDigest,
+		Host:        fakeHost,
+	}, nil
+}
+
+// Ping always succeeds; the simulated executor has no daemon to lose.
+func (m *fakeManager) Ping(ctx context.Context) error {
+	return nil
+}
+
+// HostUsage reports a fixed share of the simulated host per run in
+// progress, so backpressure can be exercised without Docker.
+func (m *fakeManager) HostUsage(ctx context.Context) (*domain.HostUsage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	running := 0
+	for _, c := range m.containers {
+		select {
+		case <-c.stopped:
+			continue
+		default:
+		}
+		if now.Sub(c.createdAt) < c.duration {
+			running++
+		}
+	}
+
+	memPercent := math.Min(float64(running*fakeContainerMemoryPercent), 100)
+	return &domain.HostUsage{
+		CPUPercent:       math.Min(float64(running*fakeContainerCPUPercent), 100),
+		MemoryPercent:    memPercent,
+		MemoryUsedBytes:  int64(memPercent / 100 * fakeHostMemoryBytes),
+		MemoryTotalBytes: fakeHostMemoryBytes,
+		Containers:       running,
+		SampledAt:        now,
+	}, nil
+}
+
+// ContainerHost returns no host name; simulated runs have no pool to be
+// placed in.
+func (m *fakeManager) ContainerHost(containerID string) string {
+	return ""
+}
+
+// get looks up a simulated run.
+func (m *fakeManager) get(containerID string) (*fakeContainer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.containers[containerID]
+	if !ok {
+		return nil, fmt.Errorf("container %s not found", containerID)
+	}
+	return c, nil
+}
+
+// fakeSuccessLogs renders a Freqtrade-style summary with sampled metrics.
+// Callers must hold m.mu.
+func (m *fakeManager) fakeSuccessLogs(params *RunBacktestParams) string {
+	wallet := params.Config.DryRunWallet
+	if wallet <= 0 {
+		wallet = 1000
+	}
+
+	trades := 10 + m.rng.Intn(190)
+	wins := int(float64(trades) * (0.35 + 0.3*m.rng.Float64()))
+	profitPct := m.config.ProfitMeanPct + m.rng.NormFloat64()*m.config.ProfitStdDevPct
+	drawdownPct := math.Abs(m.rng.NormFloat64())*5 + 1
+	sharpe := profitPct / 10
+	avgMinutes := 30 + m.rng.Intn(600)
+	stakeCurrency := params.Config.StakeCurrency
+	if stakeCurrency == "" {
+		stakeCurrency = "USDT"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\nfreqtrade %s\nPython %s\n%s\n",
+		parser.EnvironmentBlockStart, fakeFreqtradeVersion, fakePythonVersion, parser.EnvironmentBlockEnd)
+	fmt.Fprintf(&b, "Result for strategy %s\n", params.StrategyName)
+	b.WriteString("┃ Pair            ┃ Trades ┃ Avg Profit % ┃ Win % ┃\n")
+
+	pairs := params.Config.Pairs
+	if len(pairs) == 0 {
+		pairs = []string{"BTC/USDT:USDT"}
+	}
+	remaining := trades
+	for i, pair := range pairs {
+		if !strings.Contains(pair, ":") {
+			pair += ":" + pair[strings.LastIndex(pair, "/")+1:]
+		}
+		pairTrades := remaining / (len(pairs) - i)
+		remaining -= pairTrades
+		fmt.Fprintf(&b, "│ %s │ %d │ %.2f │ %.1f │\n",
+			pair, pairTrades, profitPct/float64(len(pairs)), 100*float64(wins)/float64(trades))
+	}
+
+	losses := trades - wins
+	stoplosses := losses / 2
+	trailing := wins / 4
+	b.WriteString("EXIT REASON STATS\n")
+	b.WriteString("┃ Exit Reason        ┃ Exits ┃ Avg Profit % ┃\n")
+	fmt.Fprintf(&b, "│ roi │ %d │ %.2f │\n", wins-trailing, 1+m.rng.Float64())
+	fmt.Fprintf(&b, "│ trailing_stop_loss │ %d │ %.2f │\n", trailing, 0.5+m.rng.Float64())
+	fmt.Fprintf(&b, "│ exit_signal │ %d │ %.2f │\n", losses-stoplosses, -m.rng.Float64())
+	fmt.Fprintf(&b, "│ stop_loss │ %d │ %.2f │\n", stoplosses, -5-m.rng.Float64())
+	fmt.Fprintf(&b, "│ TOTAL │ %d │ %.2f │\n", trades, profitPct/float64(trades))
+	b.WriteString("└────────────────────┴───────┴──────────────┘\n")
+
+	b.WriteString("SUMMARY METRICS\n")
+	fmt.Fprintf(&b, "│ Total/Daily Avg Trades │ %d / %.2f │\n", trades, float64(trades)/30)
+	fmt.Fprintf(&b, "│ Abs. profit │ %.3f %s │\n", wallet*profitPct/100, stakeCurrency)
+	fmt.Fprintf(&b, "│ Total profit %% │ %.2f%% │\n", profitPct)
+	fmt.Fprintf(&b, "│ Sharpe │ %.2f │\n", sharpe)
+	fmt.Fprintf(&b, "│ Sortino │ %.2f │\n", sharpe*1.3)
+	fmt.Fprintf(&b, "│ Calmar │ %.2f │\n", profitPct/drawdownPct)
+	fmt.Fprintf(&b, "│ Profit factor │ %.2f │\n", math.Max(0.1, 1+profitPct/20))
+	fmt.Fprintf(&b, "│ Avg. Duration │ %d:%02d:00 │\n", avgMinutes/60, avgMinutes%60)
+	fmt.Fprintf(&b, "│ Best trade │ %.2f%% │\n", 2+10*m.rng.Float64())
+	fmt.Fprintf(&b, "│ Worst trade │ %.2f%% │\n", -2-10*m.rng.Float64())
+	fmt.Fprintf(&b, "│ Win Rate │ %.1f%% [%d/%d] │\n", 100*float64(wins)/float64(trades), wins, trades)
+	fmt.Fprintf(&b, "│ Max Drawdown │ %.2f%% │\n", drawdownPct)
+	fmt.Fprintf(&b, "│ Max Drawdown (Abs) │ %.3f %s │\n", wallet*drawdownPct/100, stakeCurrency)
+
+	return b.String()
+}
+
+// fakeFailureLogs renders the output of a simulated strategy crash.
+func fakeFailureLogs(params *RunBacktestParams) string {
+	return fmt.Sprintf("Traceback (most recent call last):\n"+
+		"  File \"/freqtrade/user_data/strategies/%s.py\", line 1, in populate_indicators\n"+
+		"Exception: simulated backtest failure for job %s\n",
+		params.StrategyName, params.JobID)
+}
+
+// fakeHyperoptLogs renders the best epoch of a simulated hyperopt with
+// sampled metrics. Callers must hold m.mu.
+func (m *fakeManager) fakeHyperoptLogs(params *RunHyperoptParams) string {
+	epochs := params.Config.Epochs
+	if epochs <= 0 {
+		epochs = domain.DefaultHyperoptEpochs
+	}
+
+	trades := 10 + m.rng.Intn(190)
+	wins := int(float64(trades) * (0.4 + 0.3*m.rng.Float64()))
+	profit := (m.config.ProfitMeanPct + math.Abs(m.rng.NormFloat64())*m.config.ProfitStdDevPct) / 100
+	stoploss := -0.02 - 0.2*m.rng.Float64()
+
+	best := 1 + m.rng.Intn(epochs)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Best result:\n    %d/%d: %d trades.\n", best, epochs, trades)
+	fmt.Fprintf(&b, "%s\n", parser.HyperoptBlockStart)
+	fmt.Fprintf(&b, `{"total_epochs": %d, "best_epoch": %d, "loss": %.5f, "params": {"stoploss": {"stoploss": %.3f}}, `+
+		`"metrics": {"total_trades": %d, "wins": %d, "losses": %d, "profit_total": %.5f, "profit_total_abs": %.3f, "max_drawdown_account": %.5f}}`+"\n",
+		epochs, best, -profit*10, stoploss,
+		trades, wins, trades-wins, profit, profit*1000, 0.01+0.05*m.rng.Float64())
+	fmt.Fprintf(&b, "%s\n", parser.HyperoptBlockEnd)
+	return b.String()
+}
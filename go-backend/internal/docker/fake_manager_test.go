@@ -0,0 +1,218 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/parser"
+)
+
+func newTestFakeManager(t *testing.T, failureRate float64) (Manager, *clock.Fake) {
+	t.Helper()
+
+	cfg := &config.DockerConfig{
+		Fake: config.FakeExecutorConfig{
+			MinDuration:     "10m",
+			MaxDuration:     "10m",
+			FailureRate:     failureRate,
+			ProfitMeanPct:   5,
+			ProfitStdDevPct: 1,
+			Seed:            7,
+		},
+	}
+	clk := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	m, err := NewFakeManager(cfg, clk, zap.NewNop())
+	require.NoError(t, err)
+	return m, clk
+}
+
+func runFakeBacktest(t *testing.T, m Manager, clk *clock.Fake, job *domain.BacktestJob) (int64, string) {
+	t.Helper()
+
+	ctx := context.Background()
+	containerID, err := m.RunBacktest(ctx, &RunBacktestParams{
+		JobID:        job.ID,
+		StrategyName: "TestStrategy",
+		Config:       job.Config,
+	})
+	require.NoError(t, err)
+
+	type waitResult struct {
+		exitCode int64
+		logs     string
+		err      error
+	}
+	done := make(chan waitResult, 1)
+	go func() {
+		exitCode, logs, err := m.WaitContainer(ctx, containerID)
+		done <- waitResult{exitCode, logs, err}
+	}()
+
+	// Wait until WaitContainer blocks on the clock, then finish the run
+	require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+	running, _ := m.IsContainerRunning(ctx, containerID)
+	assert.True(t, running)
+
+	clk.Advance(10 * time.Minute)
+	res := <-done
+	require.NoError(t, res.err)
+	return res.exitCode, res.logs
+}
+
+func TestFakeManager_SuccessParses(t *testing.T) {
+	m, clk := newTestFakeManager(t, 0)
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{
+		Pairs:        []string{"BTC/USDT:USDT", "ETH/USDT"},
+		DryRunWallet: 1000,
+	}, 0, nil)
+
+	exitCode, logs := runFakeBacktest(t, m, clk, job)
+	require.Equal(t, int64(0), exitCode)
+
+	result, err := parser.NewParser(zap.NewNop()).ParseResult(logs, job, nil)
+	require.NoError(t, err)
+	assert.Greater(t, result.TotalTrades, 0)
+	assert.Equal(t, result.TotalTrades, result.WinningTrades+result.LosingTrades)
+	assert.NotZero(t, result.ProfitPct)
+	assert.NotNil(t, result.SharpeRatio)
+	assert.Len(t, result.PairResults, 2)
+	assert.Len(t, result.ExitReasons, 4)
+	assert.NotNil(t, result.StoplossExitPct)
+	require.NotNil(t, result.StakeCurrency)
+	assert.Equal(t, "USDT", *result.StakeCurrency)
+	require.NotNil(t, result.Environment)
+	assert.Equal(t, fakeFreqtradeVersion, result.Environment.FreqtradeVersion)
+	assert.Equal(t, fakePythonVersion, result.Environment.PythonVersion)
+}
+
+func TestFakeManager_Failure(t *testing.T) {
+	m, clk := newTestFakeManager(t, 1)
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+
+	exitCode, logs := runFakeBacktest(t, m, clk, job)
+	assert.Equal(t, int64(1), exitCode)
+	assert.Contains(t, logs, "Exception:")
+}
+
+func TestFakeManager_StopAndCancel(t *testing.T) {
+	m, _ := newTestFakeManager(t, 0)
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+
+	containerID, err := m.RunBacktest(context.Background(), &RunBacktestParams{JobID: job.ID})
+	require.NoError(t, err)
+	require.NoError(t, m.StopContainer(context.Background(), containerID))
+
+	exitCode, _, err := m.WaitContainer(context.Background(), containerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(137), exitCode)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	containerID, err = m.RunBacktest(context.Background(), &RunBacktestParams{JobID: job.ID})
+	require.NoError(t, err)
+	_, _, err = m.WaitContainer(ctx, containerID)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFakeManager_ContainerState(t *testing.T) {
+	m, clk := newTestFakeManager(t, 0)
+	ctx := context.Background()
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+
+	containerID, err := m.RunBacktest(ctx, &RunBacktestParams{JobID: job.ID})
+	require.NoError(t, err)
+	state, err := m.GetContainerState(ctx, containerID)
+	require.NoError(t, err)
+	assert.Equal(t, ContainerStateRunning, state)
+
+	clk.Advance(10 * time.Minute)
+	state, err = m.GetContainerState(ctx, containerID)
+	require.NoError(t, err)
+	assert.Equal(t, ContainerStateExited, state)
+
+	require.NoError(t, m.RemoveContainer(ctx, containerID))
+	state, err = m.GetContainerState(ctx, containerID)
+	require.NoError(t, err)
+	assert.Equal(t, ContainerStateMissing, state)
+}
+
+func TestFakeManager_HyperoptOutputParses(t *testing.T) {
+	m, clk := newTestFakeManager(t, 0)
+	ctx := context.Background()
+
+	containerID, err := m.RunHyperopt(ctx, &RunHyperoptParams{
+		JobID:        uuid.New(),
+		StrategyName: "TestStrategy",
+		Config:       domain.HyperoptConfig{Epochs: 50},
+	})
+	require.NoError(t, err)
+
+	done := make(chan string, 1)
+	go func() {
+		_, logs, _ := m.WaitContainer(ctx, containerID)
+		done <- logs
+	}()
+	require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+	clk.Advance(10 * time.Minute)
+
+	result, err := parser.ParseHyperoptResult(<-done)
+	require.NoError(t, err)
+	assert.Equal(t, 50, result.TotalEpochs)
+	assert.Positive(t, result.TotalTrades)
+	require.NotNil(t, result.ParamsPatch)
+	require.NotNil(t, result.ParamsPatch.Stoploss)
+}
+
+func TestFakeManager_HostUsage(t *testing.T) {
+	m, clk := newTestFakeManager(t, 0)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := m.RunBacktest(ctx, &RunBacktestParams{JobID: uuid.New()})
+		require.NoError(t, err)
+	}
+
+	usage, err := m.HostUsage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, usage.Containers)
+	assert.InDelta(t, 3*fakeContainerCPUPercent, usage.CPUPercent, 0.001)
+	assert.InDelta(t, 3*fakeContainerMemoryPercent, usage.MemoryPercent, 0.001)
+
+	// Finished runs no longer load the host
+	clk.Advance(10 * time.Minute)
+	usage, err = m.HostUsage(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, usage.Containers)
+	assert.Zero(t, usage.CPUPercent)
+}
+
+func TestFakeManager_StreamContainerLogs(t *testing.T) {
+	m, clk := newTestFakeManager(t, 0)
+	ctx := context.Background()
+
+	containerID, err := m.RunBacktest(ctx, &RunBacktestParams{JobID: uuid.New(), StrategyName: "TestStrategy"})
+	require.NoError(t, err)
+
+	// Nothing is written before the run finishes
+	var out strings.Builder
+	require.NoError(t, m.StreamContainerLogs(ctx, containerID, false, &out))
+	assert.Empty(t, out.String())
+
+	done := make(chan error, 1)
+	go func() { done <- m.StreamContainerLogs(ctx, containerID, true, &out) }()
+	require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+	clk.Advance(10 * time.Minute)
+
+	require.NoError(t, <-done)
+	assert.Contains(t, out.String(), "TestStrategy")
+}
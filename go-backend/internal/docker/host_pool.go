@@ -0,0 +1,457 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/client"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// hostPingTimeout bounds a health check of a pool host.
+const hostPingTimeout = 5 * time.Second
+
+// hostPool implements Manager over a pool of Docker hosts. Containers are
+// placed on the healthy host with the most free slots, and every later call
+// about a container is routed to the host it was placed on. A host's health
+// is trusted for the configured TTL; hosts that fail a health check, or that
+// cannot be reached to start a container, take no new containers until they
+// pass one again.
+type hostPool struct {
+	hosts  []*poolHost
+	ttl    time.Duration
+	logger *zap.Logger
+
+	mu         sync.Mutex
+	containers map[string]*poolHost // Containers placed by this process, by ID
+}
+
+// poolHost is a Docker host of the pool.
+type poolHost struct {
+	name    string
+	manager *dockerManager
+
+	mu        sync.Mutex
+	healthy   bool
+	checkedAt time.Time
+	placed    int // Containers placed here and not yet removed
+}
+
+// newHostPool connects to the configured hosts. Hosts that cannot be reached
+// are left out of placement until they pass a health check; it fails only if
+// none can be reached.
+func newHostPool(cfg *config.DockerConfig, logger *zap.Logger) (*hostPool, error) {
+	pool := &hostPool{
+		ttl:        cfg.HealthCheckTTL(),
+		logger:     logger,
+		containers: make(map[string]*poolHost),
+	}
+
+	for _, hc := range cfg.Hosts {
+		opts := []client.Opt{client.WithHost(hc.Host), client.WithAPIVersionNegotiation()}
+		if hc.TLSCertPath != "" {
+			opts = append(opts, client.WithTLSClientConfig(
+				filepath.Join(hc.TLSCertPath, "ca.pem"),
+				filepath.Join(hc.TLSCertPath, "cert.pem"),
+				filepath.Join(hc.TLSCertPath, "key.pem"),
+			))
+		}
+		cli, err := client.NewClientWithOpts(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Docker client for host %s: %w", hc.Name, err)
+		}
+
+		maxContainers := hc.MaxContainers
+		if maxContainers == 0 {
+			maxContainers = cfg.MaxContainers
+		}
+		pool.hosts = append(pool.hosts, &poolHost{
+			name:    hc.Name,
+			manager: newDockerManager(cli, cfg, maxContainers, logger.With(zap.String("docker_host", hc.Name))),
+		})
+	}
+
+	healthy := pool.healthyHosts(context.Background())
+	if len(healthy) == 0 {
+		return nil, errors.New("failed to connect to any Docker host of the pool")
+	}
+
+	logger.Info("Docker host pool connected",
+		zap.String("image", cfg.Image),
+		zap.Int("hosts", len(pool.hosts)),
+		zap.Int("healthy", len(healthy)),
+	)
+
+	return pool, nil
+}
+
+// RunBacktest starts a backtest container on the host with the most free
+// slots.
+func (p *hostPool) RunBacktest(ctx context.Context, params *RunBacktestParams) (string, error) {
+	host, err := p.place(ctx, laneBacktest)
+	if err != nil {
+		return "", err
+	}
+	containerID, err := host.manager.RunBacktest(ctx, params)
+	if err != nil {
+		p.checkFailure(host, err)
+		return "", err
+	}
+	p.track(containerID, host)
+	return containerID, nil
+}
+
+// RunHyperopt starts a hyperopt container on the host with the most free
+// slots.
+func (p *hostPool) RunHyperopt(ctx context.Context, params *RunHyperoptParams) (string, error) {
+	host, err := p.place(ctx, laneBacktest)
+	if err != nil {
+		return "", err
+	}
+	containerID, err := host.manager.RunHyperopt(ctx, params)
+	if err != nil {
+		p.checkFailure(host, err)
+		return "", err
+	}
+	p.track(containerID, host)
+	return containerID, nil
+}
+
+// RunDownloadData starts a download-data container on the host with the
+// most free slots. The data mount is shared, so any host will do.
+func (p *hostPool) RunDownloadData(ctx context.Context, params *DownloadDataParams) (string, error) {
+	host, err := p.place(ctx, laneBacktest)
+	if err != nil {
+		return "", err
+	}
+	containerID, err := host.manager.RunDownloadData(ctx, params)
+	if err != nil {
+		p.checkFailure(host, err)
+		return "", err
+	}
+	p.track(containerID, host)
+	return containerID, nil
+}
+
+// ValidateStrategy validates a strategy on the host with the most free
+// validation slots.
+func (p *hostPool) ValidateStrategy(ctx context.Context, params *ValidateStrategyParams) (*ValidationResult, error) {
+	host, err := p.place(ctx, laneValidation)
+	if err != nil {
+		return nil, err
+	}
+	result, err := host.manager.ValidateStrategy(ctx, params)
+	if err != nil {
+		p.checkFailure(host, err)
+	}
+	return result, err
+}
+
+// WaitContainer waits for a container on its host.
+func (p *hostPool) WaitContainer(ctx context.Context, containerID string) (int64, string, error) {
+	host, err := p.hostOf(ctx, containerID)
+	if err != nil {
+		return -1, "", err
+	}
+	return host.manager.WaitContainer(ctx, containerID)
+}
+
+// StopContainer stops a container on its host.
+func (p *hostPool) StopContainer(ctx context.Context, containerID string) error {
+	host, err := p.hostOf(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	return host.manager.StopContainer(ctx, containerID)
+}
+
+// TerminateContainer terminates a container on its host.
+func (p *hostPool) TerminateContainer(ctx context.Context, containerID string, grace time.Duration) error {
+	host, err := p.hostOf(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	return host.manager.TerminateContainer(ctx, containerID, grace)
+}
+
+// RemoveContainer removes a container from its host.
+func (p *hostPool) RemoveContainer(ctx context.Context, containerID string) error {
+	host, err := p.hostOf(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	if err := host.manager.RemoveContainer(ctx, containerID); err != nil {
+		return err
+	}
+	p.untrack(containerID)
+	return nil
+}
+
+// GetContainerLogs retrieves a container's logs from its host.
+func (p *hostPool) GetContainerLogs(ctx context.Context, containerID string) (string, error) {
+	host, err := p.hostOf(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	return host.manager.GetContainerLogs(ctx, containerID)
+}
+
+// StreamContainerLogs streams a container's output from its host.
+func (p *hostPool) StreamContainerLogs(ctx context.Context, containerID string, follow bool, w io.Writer) error {
+	host, err := p.hostOf(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	return host.manager.StreamContainerLogs(ctx, containerID, follow, w)
+}
+
+// CleanupStaleContainers removes stale containers from every healthy host.
+func (p *hostPool) CleanupStaleContainers(ctx context.Context, maxAge time.Duration) (int, error) {
+	cleaned := 0
+	var errs []error
+	for _, host := range p.healthyHosts(ctx) {
+		n, err := host.manager.CleanupStaleContainers(ctx, maxAge)
+		cleaned += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("host %s: %w", host.name, err))
+		}
+	}
+	return cleaned, errors.Join(errs...)
+}
+
+// IsContainerRunning checks on its host whether a container is running. A
+// container no host knows is not.
+func (p *hostPool) IsContainerRunning(ctx context.Context, containerID string) (bool, error) {
+	host, err := p.hostOf(ctx, containerID)
+	if err != nil {
+		return false, nil
+	}
+	return host.manager.IsContainerRunning(ctx, containerID)
+}
+
+// GetContainerState reports a container's state on its host. A container no
+// host knows is missing, unless a host could not be asked.
+func (p *hostPool) GetContainerState(ctx context.Context, containerID string) (ContainerState, error) {
+	p.mu.Lock()
+	host, ok := p.containers[containerID]
+	p.mu.Unlock()
+	if ok {
+		return host.manager.GetContainerState(ctx, containerID)
+	}
+
+	var errs []error
+	for _, host := range p.hosts {
+		state, err := host.manager.GetContainerState(ctx, containerID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("host %s: %w", host.name, err))
+			continue
+		}
+		if state != ContainerStateMissing {
+			return state, nil
+		}
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	return ContainerStateMissing, nil
+}
+
+// InspectEnvironment returns the image and host a container ran on.
+func (p *hostPool) InspectEnvironment(ctx context.Context, containerID string) (*domain.ExecutionEnvironment, error) {
+	host, err := p.hostOf(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	return host.manager.InspectEnvironment(ctx, containerID)
+}
+
+// Ping checks that at least one host of the pool is reachable.
+func (p *hostPool) Ping(ctx context.Context) error {
+	if len(p.healthyHosts(ctx)) == 0 {
+		return errors.New("no Docker host of the pool is reachable")
+	}
+	return nil
+}
+
+// HostUsage samples the healthy hosts together: their memory is added up and
+// their CPU use averaged.
+func (p *hostPool) HostUsage(ctx context.Context) (*domain.HostUsage, error) {
+	total := &domain.HostUsage{SampledAt: time.Now()}
+	sampled := 0
+	var errs []error
+	for _, host := range p.healthyHosts(ctx) {
+		usage, err := host.manager.HostUsage(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("host %s: %w", host.name, err))
+			continue
+		}
+		total.CPUPercent += usage.CPUPercent
+		total.MemoryUsedBytes += usage.MemoryUsedBytes
+		total.MemoryTotalBytes += usage.MemoryTotalBytes
+		total.Containers += usage.Containers
+		sampled++
+	}
+	if sampled == 0 {
+		if len(errs) == 0 {
+			return nil, errors.New("no Docker host of the pool is reachable")
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	total.CPUPercent /= float64(sampled)
+	if total.MemoryTotalBytes > 0 {
+		total.MemoryPercent = float64(total.MemoryUsedBytes) / float64(total.MemoryTotalBytes) * 100
+	}
+	return total, nil
+}
+
+// ContainerHost returns the name of the host a container was placed on, or
+// "" if it was not placed by this process.
+func (p *hostPool) ContainerHost(containerID string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if host, ok := p.containers[containerID]; ok {
+		return host.name
+	}
+	return ""
+}
+
+// place picks the healthy host to start a container of a lane on: the one
+// with the most free slots, or with unbounded hosts the fewest containers
+// placed. Ties go to the host configured first.
+func (p *hostPool) place(ctx context.Context, l lane) (*poolHost, error) {
+	var best *poolHost
+	bestScore := math.MinInt
+	for _, host := range p.healthyHosts(ctx) {
+		score, bounded := host.manager.pools.freeSlots(l)
+		if !bounded {
+			host.mu.Lock()
+			score = math.MaxInt32 - host.placed
+			host.mu.Unlock()
+		}
+		if score > bestScore {
+			best, bestScore = host, score
+		}
+	}
+	if best == nil {
+		return nil, errors.New("no healthy Docker host to place the container on")
+	}
+	return best, nil
+}
+
+// healthyHosts returns the hosts that passed their latest health check,
+// checking again those whose result is older than the TTL.
+func (p *hostPool) healthyHosts(ctx context.Context) []*poolHost {
+	now := time.Now()
+	var wg sync.WaitGroup
+	for _, host := range p.hosts {
+		host.mu.Lock()
+		stale := now.Sub(host.checkedAt) >= p.ttl
+		host.mu.Unlock()
+		if !stale {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, hostPingTimeout)
+			defer cancel()
+			err := host.manager.Ping(pingCtx)
+			p.setHealth(host, err)
+		}()
+	}
+	wg.Wait()
+
+	var healthy []*poolHost
+	for _, host := range p.hosts {
+		host.mu.Lock()
+		if host.healthy {
+			healthy = append(healthy, host)
+		}
+		host.mu.Unlock()
+	}
+	return healthy
+}
+
+// setHealth records the result of a health check, logging changes.
+func (p *hostPool) setHealth(host *poolHost, err error) {
+	host.mu.Lock()
+	wasHealthy, checked := host.healthy, !host.checkedAt.IsZero()
+	host.healthy, host.checkedAt = err == nil, time.Now()
+	host.mu.Unlock()
+
+	switch {
+	case err != nil && (wasHealthy || !checked):
+		p.logger.Warn("Docker host unhealthy, placing no containers on it",
+			zap.String("docker_host", host.name),
+			zap.Error(err),
+		)
+	case err == nil && !wasHealthy && checked:
+		p.logger.Info("Docker host healthy again", zap.String("docker_host", host.name))
+	}
+}
+
+// checkFailure marks a host unhealthy if a container could not be started
+// because the host could not be reached.
+func (p *hostPool) checkFailure(host *poolHost, err error) {
+	if client.IsErrConnectionFailed(err) {
+		p.setHealth(host, err)
+	}
+}
+
+// track records the host a container was placed on.
+func (p *hostPool) track(containerID string, host *poolHost) {
+	p.mu.Lock()
+	p.containers[containerID] = host
+	p.mu.Unlock()
+
+	host.mu.Lock()
+	host.placed++
+	host.mu.Unlock()
+}
+
+// untrack forgets a removed container.
+func (p *hostPool) untrack(containerID string) {
+	p.mu.Lock()
+	host, ok := p.containers[containerID]
+	delete(p.containers, containerID)
+	p.mu.Unlock()
+
+	if ok {
+		host.mu.Lock()
+		host.placed--
+		host.mu.Unlock()
+	}
+}
+
+// hostOf returns the host a container runs on. Containers this process did
+// not place, e.g. ones started before a restart, are looked up on every
+// host each time.
+func (p *hostPool) hostOf(ctx context.Context, containerID string) (*poolHost, error) {
+	p.mu.Lock()
+	host, ok := p.containers[containerID]
+	p.mu.Unlock()
+	if ok {
+		return host, nil
+	}
+
+	for _, host := range p.hosts {
+		if _, err := host.manager.client.ContainerInspect(ctx, containerID); err == nil {
+			return host, nil
+		}
+	}
+	return nil, fmt.Errorf("container %s not found on any Docker host", containerID)
+}
+
+// Ensure interface implementation at compile time.
+var _ Manager = (*hostPool)(nil)
@@ -0,0 +1,69 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// newTestPoolHost returns a healthy pool host that needs no health check
+// within the test.
+func newTestPoolHost(name string, maxContainers int) *poolHost {
+	return &poolHost{
+		name:      name,
+		manager:   &dockerManager{pools: newCapacityPools(maxContainers, 0)},
+		healthy:   true,
+		checkedAt: time.Now(),
+	}
+}
+
+func TestHostPool_PlacesOnMostFreeSlots(t *testing.T) {
+	a, b := newTestPoolHost("a", 2), newTestPoolHost("b", 4)
+	pool := &hostPool{hosts: []*poolHost{a, b}, ttl: time.Hour, logger: zaptest.NewLogger(t), containers: map[string]*poolHost{}}
+	ctx := context.Background()
+
+	host, err := pool.place(ctx, laneBacktest)
+	require.NoError(t, err)
+	assert.Equal(t, "b", host.name)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, b.manager.pools.acquire(ctx, laneBacktest))
+	}
+	host, err = pool.place(ctx, laneBacktest)
+	require.NoError(t, err)
+	assert.Equal(t, "a", host.name)
+
+	// Unhealthy hosts take nothing
+	a.healthy = false
+	host, err = pool.place(ctx, laneBacktest)
+	require.NoError(t, err)
+	assert.Equal(t, "b", host.name)
+
+	b.healthy = false
+	_, err = pool.place(ctx, laneBacktest)
+	assert.Error(t, err)
+}
+
+func TestHostPool_UnboundedHostsBalancePlacedContainers(t *testing.T) {
+	a, b := newTestPoolHost("a", 0), newTestPoolHost("b", 0)
+	pool := &hostPool{hosts: []*poolHost{a, b}, ttl: time.Hour, logger: zaptest.NewLogger(t), containers: map[string]*poolHost{}}
+	ctx := context.Background()
+
+	host, err := pool.place(ctx, laneBacktest)
+	require.NoError(t, err)
+	assert.Equal(t, "a", host.name)
+	pool.track("c1", host)
+	assert.Equal(t, "a", pool.ContainerHost("c1"))
+
+	host, err = pool.place(ctx, laneBacktest)
+	require.NoError(t, err)
+	assert.Equal(t, "b", host.name)
+
+	pool.untrack("c1")
+	assert.Empty(t, pool.ContainerHost("c1"))
+	assert.Zero(t, a.placed)
+}
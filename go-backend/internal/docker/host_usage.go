@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// HostUsage samples every running container on the host, not just ours,
+// since anything else running there takes from the same memory. CPU is the
+// containers' share of all the host's CPUs since the daemon's previous
+// sample; memory excludes page cache, which the kernel reclaims under
+// pressure rather than OOM-killing.
+func (m *dockerManager) HostUsage(ctx context.Context) (*domain.HostUsage, error) {
+	info, err := m.client.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker host info: %w", err)
+	}
+
+	containers, err := m.client.ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	type sample struct {
+		cpu    float64
+		memory uint64
+		err    error
+	}
+	samples := make([]sample, len(containers))
+
+	var wg sync.WaitGroup
+	for i, c := range containers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stats, err := m.containerStats(ctx, c.ID)
+			if err != nil {
+				samples[i].err = err
+				return
+			}
+			samples[i] = sample{cpu: cpuShare(&stats.Stats), memory: memoryInUse(&stats.MemoryStats)}
+		}()
+	}
+	wg.Wait()
+
+	usage := &domain.HostUsage{
+		MemoryTotalBytes: info.MemTotal,
+		SampledAt:        time.Now(),
+	}
+	var memory uint64
+	for _, s := range samples {
+		if s.err != nil {
+			// Exited between listing and sampling
+			continue
+		}
+		usage.CPUPercent += s.cpu * 100
+		memory += s.memory
+		usage.Containers++
+	}
+	usage.CPUPercent = math.Min(usage.CPUPercent, 100)
+	usage.MemoryUsedBytes = int64(memory)
+	if info.MemTotal > 0 {
+		usage.MemoryPercent = math.Min(float64(memory)/float64(info.MemTotal)*100, 100)
+	}
+
+	return usage, nil
+}
+
+// containerStats takes a single stats sample of a container. A one-off
+// sample still carries the daemon's previous CPU reading to diff against.
+func (m *dockerManager) containerStats(ctx context.Context, containerID string) (*container.StatsResponse, error) {
+	resp, err := m.client.ContainerStats(ctx, containerID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container stats: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var stats container.StatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("failed to decode container stats: %w", err)
+	}
+	return &stats, nil
+}
+
+// cpuShare returns the share of all the host's CPU time a container used
+// between two stats samples.
+func cpuShare(stats *container.Stats) float64 {
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	return cpuDelta / systemDelta
+}
+
+// memoryInUse returns a container's memory usage less its inactive page
+// cache, as docker stats reports it. cgroup v2 reports the cache as
+// inactive_file, v1 as total_inactive_file.
+func memoryInUse(stats *container.MemoryStats) uint64 {
+	cache, ok := stats.Stats["inactive_file"]
+	if !ok {
+		cache = stats.Stats["total_inactive_file"]
+	}
+	if cache > stats.Usage {
+		return 0
+	}
+	return stats.Usage - cache
+}
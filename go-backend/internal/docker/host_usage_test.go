@@ -0,0 +1,41 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPUShare(t *testing.T) {
+	stats := &container.Stats{
+		CPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 3_000},
+			SystemUsage: 20_000,
+		},
+		PreCPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 1_000},
+			SystemUsage: 10_000,
+		},
+	}
+	assert.InDelta(t, 0.2, cpuShare(stats), 1e-9)
+
+	// First sample of a container has no previous reading
+	stats.PreCPUStats = container.CPUStats{}
+	stats.CPUStats.SystemUsage = 0
+	assert.Zero(t, cpuShare(stats))
+}
+
+func TestMemoryInUse(t *testing.T) {
+	// cgroup v2
+	assert.Equal(t, uint64(700), memoryInUse(&container.MemoryStats{
+		Usage: 1000,
+		Stats: map[string]uint64{"inactive_file": 300},
+	}))
+	// cgroup v1
+	assert.Equal(t, uint64(600), memoryInUse(&container.MemoryStats{
+		Usage: 1000,
+		Stats: map[string]uint64{"total_inactive_file": 400},
+	}))
+	assert.Equal(t, uint64(1000), memoryInUse(&container.MemoryStats{Usage: 1000}))
+}
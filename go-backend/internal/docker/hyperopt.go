@@ -0,0 +1,107 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/parser"
+)
+
+// hyperoptResultCmd prints the best epoch of the latest hyperopt run between
+// the markers the hyperopt parser looks for. Freqtrade writes an epoch per
+// line and names the latest file in .last_result.json. Only the metrics the
+// parser reads are kept, as others may not be valid JSON (e.g. Infinity).
+var hyperoptResultCmd = fmt.Sprintf(
+	`echo "%s"; python -c "import json,pathlib;d=pathlib.Path('/freqtrade/user_data/hyperopt_results');f=d/json.loads((d/'.last_result.json').read_text())['latest_hyperopt'];e=[json.loads(l) for l in f.read_text().splitlines() if l.strip()];b=min(e,key=lambda x:x['loss']);m=b.get('results_metrics') or {};print(json.dumps({'total_epochs':len(e),'best_epoch':b.get('current_epoch'),'loss':b['loss'],'params':b.get('params_details') or {},'metrics':{k:m.get(k) for k in ('total_trades','wins','losses','profit_total','profit_total_abs','max_drawdown_account','max_relative_drawdown','holding_avg_s')}}))"; echo "%s"`,
+	parser.HyperoptBlockStart,
+	parser.HyperoptBlockEnd,
+)
+
+// RunHyperopt starts a Freqtrade hyperopt container.
+func (m *dockerManager) RunHyperopt(ctx context.Context, params *RunHyperoptParams) (string, error) {
+	strategyResult, err := m.injector.InjectStrategy(params.StrategyCode, params.StrategyName)
+	if err != nil {
+		return "", fmt.Errorf("failed to inject strategy: %w", err)
+	}
+
+	configResult, err := m.configBuilder.BuildRuntimeConfig(params.Config.Backtest)
+	if err != nil {
+		strategyResult.Cleanup()
+		return "", fmt.Errorf("failed to build config: %w", err)
+	}
+
+	pairsArg, timeframe, timerange := marketDataArgs(params.Config.Backtest, configResult)
+
+	containerConfig := &container.Config{
+		Image:      m.config.Image,
+		Entrypoint: []string{"/bin/sh", "-c"},
+		Cmd:        []string{downloadDataCmd(pairsArg, timeframe, timerange) + " && " + hyperoptCmd(params, timerange) + " && { " + hyperoptResultCmd + "; }"},
+		Labels: map[string]string{
+			labelJobID:   params.JobID.String(),
+			labelManaged: "true",
+		},
+		Env: []string{
+			"FREQTRADE_STRATEGY=" + params.StrategyName,
+		},
+	}
+
+	hostConfig := &container.HostConfig{
+		Binds:       m.freqtradeBinds(params.StrategyName, strategyResult, configResult),
+		Resources:   backtestResources(params.CPULimit, params.MemoryLimitMB),
+		NetworkMode: container.NetworkMode(m.config.Network),
+	}
+
+	containerID, err := m.startFreqtradeContainer(ctx, containerConfig, hostConfig, func() {
+		strategyResult.Cleanup()
+		configResult.Cleanup()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	m.logger.Info("Started hyperopt container",
+		zap.String("container_id", containerID[:12]),
+		zap.String("job_id", params.JobID.String()),
+		zap.String("strategy", params.StrategyName),
+		zap.Int("epochs", params.Config.Epochs),
+		zap.String("timerange", timerange),
+	)
+
+	return containerID, nil
+}
+
+// hyperoptCmd returns the freqtrade hyperopt command of a job. It runs as
+// many parallel workers as the container has CPUs, rather than one per host
+// CPU throttled to the container's quota.
+func hyperoptCmd(params *RunHyperoptParams, timerange string) string {
+	cfg := params.Config
+
+	spaces := make([]string, len(cfg.Spaces))
+	for i, space := range cfg.Spaces {
+		spaces[i] = string(space)
+	}
+
+	workers := int(math.Ceil(float64(backtestResources(params.CPULimit, params.MemoryLimitMB).CPUQuota) / cpuPeriod))
+
+	cmd := fmt.Sprintf(
+		"freqtrade hyperopt --strategy %s --config /freqtrade/config.json --timerange %s --epochs %d --spaces %s --hyperopt-loss %s --job-workers %d",
+		params.StrategyName,
+		timerange,
+		cfg.Epochs,
+		strings.Join(spaces, " "),
+		cfg.Loss,
+		max(workers, 1),
+	)
+	if cfg.MinTrades > 0 {
+		cmd += fmt.Sprintf(" --min-trades %d", cfg.MinTrades)
+	}
+	if cfg.RandomState != nil {
+		cmd += fmt.Sprintf(" --random-state %d", *cfg.RandomState)
+	}
+	return cmd
+}
@@ -0,0 +1,30 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// StreamContainerLogs writes a container's stdout and stderr to w, in the
+// order the container wrote them. Unlike GetContainerLogs it keeps them
+// interleaved, as a terminal running docker logs would show them.
+func (m *dockerManager) StreamContainerLogs(ctx context.Context, containerID string, follow bool, w io.Writer) error {
+	reader, err := m.client.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get container logs: %w", err)
+	}
+	defer reader.Close()
+
+	if _, err := stdcopy.StdCopy(w, w, reader); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to stream container logs: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package docker
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,14 @@ type Manager interface {
 	// RunBacktest starts a Freqtrade backtest container.
 	RunBacktest(ctx context.Context, params *RunBacktestParams) (containerID string, err error)
 
+	// RunHyperopt starts a Freqtrade hyperopt container. It takes a backtest
+	// slot, and its logs end with the best epoch for the parser.
+	RunHyperopt(ctx context.Context, params *RunHyperoptParams) (containerID string, err error)
+
+	// RunDownloadData starts a Freqtrade download-data container. It takes a
+	// backtest slot, and fails if the download does.
+	RunDownloadData(ctx context.Context, params *DownloadDataParams) (containerID string, err error)
+
 	// ValidateStrategy validates strategy code using Docker container.
 	// Returns validation result with any errors/warnings found.
 	ValidateStrategy(ctx context.Context, params *ValidateStrategyParams) (*ValidationResult, error)
@@ -25,19 +34,56 @@ type Manager interface {
 	// StopContainer stops a running container.
 	StopContainer(ctx context.Context, containerID string) error
 
+	// TerminateContainer sends a running container SIGTERM and kills it if it
+	// has not exited within the grace period. Its logs are kept until it is
+	// removed.
+	TerminateContainer(ctx context.Context, containerID string, grace time.Duration) error
+
 	// RemoveContainer removes a container.
 	RemoveContainer(ctx context.Context, containerID string) error
 
 	// GetContainerLogs retrieves logs from a container.
 	GetContainerLogs(ctx context.Context, containerID string) (string, error)
 
+	// StreamContainerLogs writes a container's output to w as it is
+	// produced. With follow it returns once the container exits or ctx is
+	// done, otherwise once the output so far is written.
+	StreamContainerLogs(ctx context.Context, containerID string, follow bool, w io.Writer) error
+
 	// CleanupStaleContainers removes containers that exceed the maximum age.
 	CleanupStaleContainers(ctx context.Context, maxAge time.Duration) (int, error)
 
 	// IsContainerRunning checks if a container is still running.
 	IsContainerRunning(ctx context.Context, containerID string) (bool, error)
+
+	// GetContainerState reports whether a container is running, has exited
+	// or is missing.
+	GetContainerState(ctx context.Context, containerID string) (ContainerState, error)
+
+	// InspectEnvironment returns the image and host a container ran on.
+	InspectEnvironment(ctx context.Context, containerID string) (*domain.ExecutionEnvironment, error)
+
+	// Ping checks that the container runtime is reachable.
+	Ping(ctx context.Context) error
+
+	// HostUsage samples how much of the host's CPU and memory its running
+	// containers use.
+	HostUsage(ctx context.Context) (*domain.HostUsage, error)
+
+	// ContainerHost returns the name of the pool host a container was
+	// placed on, or "" without a pool of hosts.
+	ContainerHost(containerID string) string
 }
 
+// ContainerState is the state of a container on its Docker host.
+type ContainerState string
+
+const (
+	ContainerStateRunning ContainerState = "running"
+	ContainerStateExited  ContainerState = "exited"  // Not running and not removed; its output can still be read
+	ContainerStateMissing ContainerState = "missing" // Removed, or unknown to the host
+)
+
 // ValidateStrategyParams contains parameters for strategy validation.
 type ValidateStrategyParams struct {
 	// StrategyCode is the Python source code for the strategy.
@@ -45,6 +91,10 @@ type ValidateStrategyParams struct {
 
 	// StrategyName is the class name of the strategy.
 	StrategyName string
+
+	// BaseImage is the Freqtrade image to validate against, e.g. one being
+	// upgraded to. Empty uses the validator image's own base.
+	BaseImage string
 }
 
 // ValidationResult contains the result of strategy validation.
@@ -60,6 +110,14 @@ type ValidationResult struct {
 
 	// ClassName is the detected strategy class name.
 	ClassName string `json:"class_name"`
+
+	// UnresolvedImports lists imports the backtest image cannot satisfy,
+	// found by static analysis before the container runs.
+	UnresolvedImports []domain.UnresolvedImport `json:"unresolved_imports,omitempty"`
+
+	// SafetyViolations lists the constructs that failed the static safety
+	// checks; code with any is rejected before the container runs.
+	SafetyViolations []domain.SafetyViolation `json:"safety_violations,omitempty"`
 }
 
 // RunBacktestParams contains parameters for running a backtest.
@@ -75,6 +133,41 @@ type RunBacktestParams struct {
 
 	// Config contains the backtest configuration.
 	Config domain.BacktestConfig
+
+	// CPULimit and MemoryLimitMB limit the container's CPUs and memory, e.g.
+	// from the quota of the job's optimization run. Zero uses the defaults.
+	CPULimit      float64
+	MemoryLimitMB int
+}
+
+// RunHyperoptParams contains parameters for running a hyperopt.
+type RunHyperoptParams struct {
+	// JobID is the unique identifier for this hyperopt job.
+	JobID uuid.UUID
+
+	// StrategyCode is the Python source code for the strategy.
+	StrategyCode string
+
+	// StrategyName is the class name of the strategy.
+	StrategyName string
+
+	// Config contains the hyperopt configuration, including the backtest
+	// each epoch runs.
+	Config domain.HyperoptConfig
+
+	// CPULimit and MemoryLimitMB limit the container's CPUs and memory. Zero
+	// uses the defaults.
+	CPULimit      float64
+	MemoryLimitMB int
+}
+
+// DownloadDataParams contains parameters for downloading market data.
+type DownloadDataParams struct {
+	// DownloadID is the unique identifier for this download.
+	DownloadID uuid.UUID
+
+	// Request selects the exchange, pairs, timeframes and timerange.
+	Request domain.DataDownloadRequest
 }
 
 // ContainerResult represents the result of a container execution.
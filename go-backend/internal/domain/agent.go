@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// AgentType is the role a Python agent plays.
+type AgentType string
+
+const (
+	AgentTypeOrchestrator AgentType = "orchestrator"
+	AgentTypeEngineer     AgentType = "engineer"
+	AgentTypeAnalyst      AgentType = "analyst"
+	AgentTypeScout        AgentType = "scout"
+)
+
+// IsValid reports whether the agent type is known.
+func (t AgentType) IsValid() bool {
+	switch t {
+	case AgentTypeOrchestrator, AgentTypeEngineer, AgentTypeAnalyst, AgentTypeScout:
+		return true
+	}
+	return false
+}
+
+// EventSchemaVersion is the version of the event payloads this backend
+// publishes. Agents list the versions they understand when registering.
+const EventSchemaVersion = 1
+
+// Capabilities agents advertise. Agents may advertise others; the backend
+// only acts on the ones listed here.
+const (
+	// CapabilityPopulationMode means an orchestrator can evolve a run from
+	// several seed strategies.
+	CapabilityPopulationMode = "population_mode"
+)
+
+// agentTokenRegex restricts agent IDs, versions and capabilities.
+var agentTokenRegex = regexp.MustCompile(`^[a-zA-Z0-9_.:+-]{1,128}$`)
+
+// AgentRegistration is what an agent instance announced about itself.
+// Registering again with the same agent ID replaces it.
+type AgentRegistration struct {
+	AgentID             string    `json:"agent_id"` // Stable per instance, e.g. "orchestrator@host-1"
+	Type                AgentType `json:"type"`
+	Version             string    `json:"version"`
+	EventSchemaVersions []int     `json:"event_schema_versions"`
+	Capabilities        []string  `json:"capabilities"`
+	RegisteredAt        time.Time `json:"registered_at"`
+	LastSeenAt          time.Time `json:"last_seen_at"` // Registration or heartbeat
+}
+
+// Validate checks the registration's fields.
+func (a *AgentRegistration) Validate() error {
+	if !agentTokenRegex.MatchString(a.AgentID) {
+		return fmt.Errorf("%w: agent_id must be 1-128 characters of letters, digits, '_', '.', ':', '+' or '-'", ErrInvalidInput)
+	}
+	if !a.Type.IsValid() {
+		return fmt.Errorf("%w: unknown agent type %q", ErrInvalidInput, a.Type)
+	}
+	if !agentTokenRegex.MatchString(a.Version) {
+		return fmt.Errorf("%w: version is required", ErrInvalidInput)
+	}
+	if len(a.EventSchemaVersions) == 0 {
+		return fmt.Errorf("%w: event_schema_versions is required", ErrInvalidInput)
+	}
+	for _, c := range a.Capabilities {
+		if !agentTokenRegex.MatchString(c) {
+			return fmt.Errorf("%w: invalid capability %q", ErrInvalidInput, c)
+		}
+	}
+	return nil
+}
+
+// SupportsSchema reports whether the agent understands the events this
+// backend publishes.
+func (a *AgentRegistration) SupportsSchema() bool {
+	return slices.Contains(a.EventSchemaVersions, EventSchemaVersion)
+}
+
+// AgentRequirement is an agent a run needs to make progress: one of the given
+// type that understands the backend's events and, if set, has the capability.
+type AgentRequirement struct {
+	Type       AgentType `json:"type"`
+	Capability string    `json:"capability,omitempty"`
+}
+
+// String describes the requirement for error messages.
+func (r AgentRequirement) String() string {
+	if r.Capability == "" {
+		return fmt.Sprintf("%s supporting event schema v%d", r.Type, EventSchemaVersion)
+	}
+	return fmt.Sprintf("%s supporting event schema v%d with capability %s", r.Type, EventSchemaVersion, r.Capability)
+}
+
+// SatisfiedBy reports whether a registered agent meets the requirement.
+func (r AgentRequirement) SatisfiedBy(a *AgentRegistration) bool {
+	if a.Type != r.Type || !a.SupportsSchema() {
+		return false
+	}
+	return r.Capability == "" || slices.Contains(a.Capabilities, r.Capability)
+}
+
+// RequiredAgents lists the agents a run needs: an orchestrator, which must
+// support population mode when the run has several seed strategies.
+func (r *OptimizationRun) RequiredAgents() []AgentRequirement {
+	orchestrator := AgentRequirement{Type: AgentTypeOrchestrator}
+	if len(r.SeedStrategyIDs) > 1 {
+		orchestrator.Capability = CapabilityPopulationMode
+	}
+	return []AgentRequirement{orchestrator}
+}
+
+// UnmetAgentRequirements returns the requirements no registered agent meets.
+func UnmetAgentRequirements(required []AgentRequirement, agents []*AgentRegistration) []AgentRequirement {
+	var unmet []AgentRequirement
+	for _, req := range required {
+		if !slices.ContainsFunc(agents, req.SatisfiedBy) {
+			unmet = append(unmet, req)
+		}
+	}
+	return unmet
+}
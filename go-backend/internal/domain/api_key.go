@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every generated API key, so leaked keys are easy to
+// recognize.
+const APIKeyPrefix = "fsk_"
+
+// apiKeyDisplayLength is the number of leading key characters stored in the
+// clear to tell keys apart.
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// apiKeyNameRegex restricts API key names, e.g. "scout-agent".
+var apiKeyNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,128}$`)
+
+// APIKey authenticates a REST or gRPC client. Only the SHA-256 hash of the
+// key is stored; the key itself is returned once, when it is created. The
+// name is the principal requests made with the key act as.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // Leading characters of the key
+	KeyHash    string     `json:"-"`
+	Role       Role       `json:"role"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Role is what an API key may do. Each role includes the permissions of the
+// ones before it.
+type Role string
+
+const (
+	// RoleViewer may call read endpoints and manage its own preferences.
+	RoleViewer Role = "viewer"
+	// RoleOperator may also submit and cancel backtests, control
+	// optimizations and create, change or delete strategies.
+	RoleOperator Role = "operator"
+	// RoleAdmin may also manage API keys and run admin operations.
+	RoleAdmin Role = "admin"
+)
+
+// DefaultRole is the role of keys created without one. It is the access keys
+// had before roles were introduced.
+const DefaultRole = RoleOperator
+
+// roleRanks orders the roles.
+var roleRanks = map[Role]int{RoleViewer: 1, RoleOperator: 2, RoleAdmin: 3}
+
+// IsValid checks if the role is valid.
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows reports whether the role includes the permissions of required.
+func (r Role) Allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// ParseRole parses a role name, returning DefaultRole for an empty one.
+func ParseRole(s string) (Role, error) {
+	if s == "" {
+		return DefaultRole, nil
+	}
+	r := Role(s)
+	if !r.IsValid() {
+		return "", fmt.Errorf("%w: role must be one of viewer, operator or admin", ErrInvalidInput)
+	}
+	return r, nil
+}
+
+// Active reports whether the key is neither revoked nor expired at now.
+func (k *APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// GenerateAPIKey returns a new random API key and its display prefix.
+func GenerateAPIKey() (key, prefix string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, key[:apiKeyDisplayLength], nil
+}
+
+// HashAPIKey returns the hex SHA-256 hash an API key is stored under. Keys
+// are random, so an unsalted hash is enough.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateAPIKeyName checks that an API key name is 1-128 characters of
+// letters, digits, '_', '.' or '-'.
+func ValidateAPIKeyName(name string) error {
+	if !apiKeyNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: API key name must be 1-128 characters of letters, digits, '_', '.' or '-'", ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchivalPolicy describes when a strategy is considered an underperformer
+// that should be archived automatically.
+type ArchivalPolicy struct {
+	MinBacktests  int           // Only judge strategies with more than this many backtests
+	MaxBestSharpe float64       // Archive if the best sharpe ever recorded is at or below this
+	InactiveFor   time.Duration // Archive only if there was no activity for this long
+	Limit         int           // Maximum strategies archived per pass
+}
+
+// Validate checks the policy parameters.
+func (p ArchivalPolicy) Validate() error {
+	if p.MinBacktests < 0 {
+		return errors.New("min_backtests must be non-negative")
+	}
+	if p.InactiveFor <= 0 {
+		return errors.New("inactive period must be positive")
+	}
+	if p.Limit <= 0 {
+		return errors.New("limit must be positive")
+	}
+	return nil
+}
+
+// Reason returns a human-readable description of the policy, stored as the archive reason.
+func (p ArchivalPolicy) Reason() string {
+	return fmt.Sprintf("auto-archived: >%d backtests, best sharpe never above %.2f, no activity in %d days",
+		p.MinBacktests, p.MaxBestSharpe, int(p.InactiveFor.Hours()/24))
+}
+
+// ArchivalCandidate is a strategy matching an archival policy.
+type ArchivalCandidate struct {
+	StrategyID     uuid.UUID `json:"strategy_id"`
+	Name           string    `json:"name"`
+	BacktestCount  int       `json:"backtest_count"`
+	BestSharpe     *float64  `json:"best_sharpe,omitempty"`
+	BestProfitPct  *float64  `json:"best_profit_pct,omitempty"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+}
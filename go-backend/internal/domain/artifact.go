@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxArtifactSize is the maximum size of an artifact's content in bytes.
+const MaxArtifactSize = 10 * 1024 * 1024
+
+// ArtifactOwnerType identifies the kind of entity an artifact belongs to.
+type ArtifactOwnerType string
+
+const (
+	ArtifactOwnerScoutRun  ArtifactOwnerType = "scout_run"
+	ArtifactOwnerExport    ArtifactOwnerType = "export"
+	ArtifactOwnerIteration ArtifactOwnerType = "optimization_iteration"
+	ArtifactOwnerJob       ArtifactOwnerType = "backtest_job"
+)
+
+// IsValid returns true if the owner type is valid.
+func (t ArtifactOwnerType) IsValid() bool {
+	switch t {
+	case ArtifactOwnerScoutRun, ArtifactOwnerExport, ArtifactOwnerIteration, ArtifactOwnerJob:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the owner type.
+func (t ArtifactOwnerType) String() string {
+	return string(t)
+}
+
+// ArtifactKind describes what an artifact contains.
+type ArtifactKind string
+
+const (
+	ArtifactKindHTML     ArtifactKind = "html"     // Raw fetched HTML page
+	ArtifactKindJSON     ArtifactKind = "json"     // Raw API/JSON payload
+	ArtifactKindSource   ArtifactKind = "source"   // Original strategy source file
+	ArtifactKindPrompt   ArtifactKind = "prompt"   // LLM prompt behind a generation
+	ArtifactKindResponse ArtifactKind = "response" // Raw LLM response to a prompt
+	ArtifactKindLog      ArtifactKind = "log"      // Container output, e.g. of a cancelled backtest
+	ArtifactKindOther    ArtifactKind = "other"
+)
+
+// IsValid returns true if the artifact kind is valid.
+func (k ArtifactKind) IsValid() bool {
+	switch k {
+	case ArtifactKindHTML, ArtifactKindJSON, ArtifactKindSource, ArtifactKindPrompt, ArtifactKindResponse, ArtifactKindLog, ArtifactKindOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the artifact kind.
+func (k ArtifactKind) String() string {
+	return string(k)
+}
+
+// Artifact is a stored blob (e.g. a raw scraped payload) attached to an entity.
+// Content is loaded separately from metadata so listings stay cheap.
+type Artifact struct {
+	ID          uuid.UUID              `json:"id"`
+	OwnerType   ArtifactOwnerType      `json:"owner_type"`
+	OwnerID     uuid.UUID              `json:"owner_id"`
+	Kind        ArtifactKind           `json:"kind"`
+	Name        string                 `json:"name"`
+	ContentType string                 `json:"content_type"`
+	SourceURL   *string                `json:"source_url,omitempty"`
+	SizeBytes   int64                  `json:"size_bytes"`
+	SHA256      string                 `json:"sha256"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Content     []byte                 `json:"-"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// NewArtifact creates a new Artifact with generated UUID, size, and checksum.
+func NewArtifact(ownerType ArtifactOwnerType, ownerID uuid.UUID, kind ArtifactKind, name, contentType string, content []byte) *Artifact {
+	sum := sha256.Sum256(content)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &Artifact{
+		ID:          uuid.New(),
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		Kind:        kind,
+		Name:        name,
+		ContentType: contentType,
+		SizeBytes:   int64(len(content)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		Content:     content,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// MaxSize returns the maximum content size for the artifact's owner type.
+func (a *Artifact) MaxSize() int {
+	if a.OwnerType == ArtifactOwnerExport {
+		return MaxExportArchiveSize
+	}
+	return MaxArtifactSize
+}
+
+// Validate checks the artifact fields and content size.
+func (a *Artifact) Validate() error {
+	if !a.OwnerType.IsValid() {
+		return errors.New("invalid owner_type")
+	}
+	if !a.Kind.IsValid() {
+		return errors.New("kind must be one of html, json, source, prompt, response, log, other")
+	}
+	if a.Name == "" || len(a.Name) > 255 {
+		return errors.New("name must be 1-255 characters")
+	}
+	if len(a.Content) == 0 {
+		return errors.New("content is required")
+	}
+	if len(a.Content) > a.MaxSize() {
+		return fmt.Errorf("content exceeds maximum size of %dMB", a.MaxSize()/(1024*1024))
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -9,18 +11,37 @@ import (
 
 // BacktestJob represents a backtest execution task.
 type BacktestJob struct {
-	ID                uuid.UUID      `json:"id"`
-	StrategyID        uuid.UUID      `json:"strategy_id"`
-	OptimizationRunID *uuid.UUID     `json:"optimization_run_id,omitempty"`
-	Config            BacktestConfig `json:"config"`
-	Priority          int            `json:"priority"`
-	Status            JobStatus      `json:"status"`
-	ContainerID       *string        `json:"container_id,omitempty"`
-	ErrorMessage      *string        `json:"error_message,omitempty"`
-	RetryCount        int            `json:"retry_count"`
-	CreatedAt         time.Time      `json:"created_at"`
-	StartedAt         *time.Time     `json:"started_at,omitempty"`
-	CompletedAt       *time.Time     `json:"completed_at,omitempty"`
+	ID                uuid.UUID        `json:"id"`
+	StrategyID        uuid.UUID        `json:"strategy_id"`
+	OptimizationRunID *uuid.UUID       `json:"optimization_run_id,omitempty"`
+	Config            BacktestConfig   `json:"config"`
+	Priority          int              `json:"priority"`
+	Status            JobStatus        `json:"status"`
+	ContainerID       *string          `json:"container_id,omitempty"`
+	ErrorMessage      *string          `json:"error_message,omitempty"`
+	FailureCategory   *FailureCategory `json:"failure_category,omitempty"` // Set when the job fails
+	RetryCount        int              `json:"retry_count"`
+	CreatedAt         time.Time        `json:"created_at"`
+	StartedAt         *time.Time       `json:"started_at,omitempty"`
+	CompletedAt       *time.Time       `json:"completed_at,omitempty"`
+
+	// ExternalRef is a submitter-supplied ID, unique per ExternalRefOwner.
+	ExternalRef      *string `json:"external_ref,omitempty"`
+	ExternalRefOwner *string `json:"external_ref_owner,omitempty"`
+
+	// CampaignID is the campaign the job was submitted under, if any.
+	CampaignID *uuid.UUID `json:"campaign_id,omitempty"`
+
+	// ResubmittedFrom is the job this one repeats, if it was resubmitted.
+	ResubmittedFrom *uuid.UUID `json:"resubmitted_from,omitempty"`
+
+	// Hints are best-effort placement hints for the scheduler.
+	Hints *JobHints `json:"hints,omitempty"`
+
+	// CancelReason and CancelledBy record why, and by whom, the job was
+	// cancelled.
+	CancelReason *string `json:"cancel_reason,omitempty"`
+	CancelledBy  *string `json:"cancelled_by,omitempty"`
 }
 
 // NewBacktestJob creates a new BacktestJob with generated UUID.
@@ -37,6 +58,53 @@ func NewBacktestJob(strategyID uuid.UUID, config BacktestConfig, priority int, o
 	}
 }
 
+// SetExternalRef sets the external reference and the principal that owns it.
+func (j *BacktestJob) SetExternalRef(owner, ref string) {
+	j.ExternalRef = &ref
+	j.ExternalRefOwner = &owner
+}
+
+// ResubmitOverrides are the settings a resubmitted job changes from the
+// original. Unset fields keep the original's value.
+type ResubmitOverrides struct {
+	TimerangeStart *string  `json:"timerange_start,omitempty"`
+	TimerangeEnd   *string  `json:"timerange_end,omitempty"`
+	Pairs          []string `json:"pairs,omitempty"`
+	Priority       *int     `json:"priority,omitempty"`
+}
+
+// Resubmit returns a new pending job repeating this one with the overrides
+// applied, linked to it through ResubmittedFrom. The campaign and hints are
+// kept; the optimization run is not, as the run's iterations are driven by
+// its orchestrator, and neither is the external ref, which is unique per
+// owner.
+func (j *BacktestJob) Resubmit(overrides ResubmitOverrides) *BacktestJob {
+	config := j.Config
+	config.Pairs = slices.Clone(j.Config.Pairs)
+	config.HyperoptOverrides = maps.Clone(j.Config.HyperoptOverrides)
+	if overrides.TimerangeStart != nil {
+		config.TimerangeStart = *overrides.TimerangeStart
+	}
+	if overrides.TimerangeEnd != nil {
+		config.TimerangeEnd = *overrides.TimerangeEnd
+	}
+	if len(overrides.Pairs) > 0 {
+		config.Pairs = slices.Clone(overrides.Pairs)
+	}
+
+	priority := j.Priority
+	if overrides.Priority != nil {
+		priority = *overrides.Priority
+	}
+
+	job := NewBacktestJob(j.StrategyID, config, priority, nil)
+	job.CampaignID = j.CampaignID
+	job.Hints = j.Hints
+	id := j.ID
+	job.ResubmittedFrom = &id
+	return job
+}
+
 // Duration returns the duration of the job execution.
 func (j *BacktestJob) Duration() time.Duration {
 	if j.StartedAt == nil {
@@ -60,6 +128,7 @@ type BacktestConfig struct {
 	MaxOpenTrades     int                    `json:"max_open_trades"`
 	StakeAmount       string                 `json:"stake_amount"`
 	TradingMode       string                 `json:"trading_mode"`
+	StakeCurrency     string                 `json:"stake_currency,omitempty"` // Overrides the base config's stake currency
 	HyperoptOverrides map[string]interface{} `json:"hyperopt_overrides,omitempty"`
 }
 
@@ -112,9 +181,98 @@ type BacktestResult struct {
 
 	// Detailed data
 	PairResults []PairResult `json:"pair_results,omitempty"`
-	RawLog      []byte       `json:"-"` // gzip compressed, not serialized to JSON
+	RawLog      []byte       `json:"-"` // gzip compressed, not serialized to JSON; only set on create, load with GetRawLog
 
 	CreatedAt time.Time `json:"created_at"`
+
+	// SupersededBy is the later result for the same strategy and config, if any.
+	// Superseded results are excluded from result queries and aggregates by default.
+	SupersededBy *uuid.UUID `json:"superseded_by,omitempty"`
+
+	// Currency. ProfitTotal and MaxDrawdown are in StakeCurrency. When a
+	// reference currency is configured, ProfitTotalNormalized is ProfitTotal
+	// converted at ReferenceRate (reference units per stake unit) at ingestion.
+	StakeCurrency         *string  `json:"stake_currency,omitempty"`
+	ReferenceCurrency     *string  `json:"reference_currency,omitempty"`
+	ReferenceRate         *float64 `json:"reference_rate,omitempty"`
+	ProfitTotalNormalized *float64 `json:"profit_total_normalized,omitempty"`
+
+	// Environment is the execution environment the backtest ran in, if captured.
+	Environment *ExecutionEnvironment `json:"environment,omitempty"`
+
+	// Trades broken down by why they were exited (e.g. roi, stop_loss) and
+	// by entry tag, if Freqtrade reported them. The exit percentages are the
+	// share of trades (0-100) exited by stop loss and by trailing stop; use
+	// SetExitReasons to keep them in sync.
+	ExitReasons         []TradeReasonStats `json:"exit_reasons,omitempty"`
+	EntryTags           []TradeReasonStats `json:"entry_tags,omitempty"`
+	StoplossExitPct     *float64           `json:"stoploss_exit_pct,omitempty"`
+	TrailingStopExitPct *float64           `json:"trailing_stop_exit_pct,omitempty"`
+
+	// Percentiles ranks the metrics within the result's cohort. It is not
+	// stored; it is only set when requested.
+	Percentiles *PercentileRanks `json:"percentiles,omitempty"`
+
+	// TradeReturns is the profit % of each trade, if the results format
+	// reports individual trades. Like RawLog it is only set on create; load
+	// it with GetTradeReturns.
+	TradeReturns []float64 `json:"-"`
+
+	// EquityCurve is the balance at the end of each day, if the results
+	// format reports daily profits. It is only set on create; load it with
+	// GetEquityCurve.
+	EquityCurve []EquityPoint `json:"-"`
+}
+
+// SetExitReasons sets the exit reason breakdown and the share of trades
+// exited by stop loss and by trailing stop.
+func (r *BacktestResult) SetExitReasons(reasons []TradeReasonStats) {
+	r.ExitReasons = reasons
+	r.StoplossExitPct = exitReasonPct(reasons, StoplossExitReasons)
+	r.TrailingStopExitPct = exitReasonPct(reasons, TrailingStopExitReasons)
+}
+
+// Freqtrade exit reasons counted as stop loss and trailing stop exits.
+var (
+	StoplossExitReasons     = []string{"stop_loss", "stoploss_on_exchange"}
+	TrailingStopExitReasons = []string{"trailing_stop_loss"}
+)
+
+// exitReasonPct returns the percentage of trades exited for one of the given
+// reasons, or nil if no trades were broken down.
+func exitReasonPct(reasons []TradeReasonStats, match []string) *float64 {
+	total, matched := 0, 0
+	for _, r := range reasons {
+		total += r.Trades
+		for _, m := range match {
+			if r.Reason == m {
+				matched += r.Trades
+				break
+			}
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	pct := 100 * float64(matched) / float64(total)
+	return &pct
+}
+
+// TradeReasonStats summarizes the trades of a backtest entered or exited for
+// one reason.
+type TradeReasonStats struct {
+	Reason       string  `json:"reason"`
+	Trades       int     `json:"trades"`
+	AvgProfitPct float64 `json:"avg_profit_pct"`
+}
+
+// Normalize converts the absolute profit to the reference currency at rate,
+// expressed in reference units per unit of stake currency.
+func (r *BacktestResult) Normalize(referenceCurrency string, rate float64) {
+	normalized := r.ProfitTotal * rate
+	r.ReferenceCurrency = &referenceCurrency
+	r.ReferenceRate = &rate
+	r.ProfitTotalNormalized = &normalized
 }
 
 // NewBacktestResult creates a new BacktestResult with generated UUID.
@@ -139,33 +297,32 @@ type PairResult struct {
 
 // BacktestResultQuery represents query parameters for backtest results.
 type BacktestResultQuery struct {
-	StrategyID        *uuid.UUID `json:"strategy_id,omitempty"`
-	OptimizationRunID *uuid.UUID `json:"optimization_run_id,omitempty"`
-	MinSharpe         *float64   `json:"min_sharpe,omitempty"`
-	MinProfitPct      *float64   `json:"min_profit_pct,omitempty"`
-	MaxDrawdownPct    *float64   `json:"max_drawdown_pct,omitempty"`
-	MinTrades         *int       `json:"min_trades,omitempty"`
-	TimeRange         *TimeRange `json:"time_range,omitempty"`
-	OrderBy           string     `json:"order_by,omitempty"` // "sharpe", "profit", "created_at"
-	Ascending         bool       `json:"ascending,omitempty"`
-	Page              int        `json:"page"`
-	PageSize          int        `json:"page_size"`
+	StrategyID             *uuid.UUID `json:"strategy_id,omitempty"`
+	OptimizationRunID      *uuid.UUID `json:"optimization_run_id,omitempty"`
+	MinSharpe              *float64   `json:"min_sharpe,omitempty"`
+	MinProfitPct           *float64   `json:"min_profit_pct,omitempty"`
+	MaxDrawdownPct         *float64   `json:"max_drawdown_pct,omitempty"`
+	MinTrades              *int       `json:"min_trades,omitempty"`
+	MaxStoplossExitPct     *float64   `json:"max_stoploss_exit_pct,omitempty"`      // Results without an exit breakdown are excluded
+	MaxTrailingStopExitPct *float64   `json:"max_trailing_stop_exit_pct,omitempty"` // Results without an exit breakdown are excluded
+	TimeRange              *TimeRange `json:"time_range,omitempty"`
+	FreqtradeVersion       *string    `json:"freqtrade_version,omitempty"`
+	ImageDigest            *string    `json:"image_digest,omitempty"`
+	Host                   *string    `json:"host,omitempty"`
+	IncludeSuperseded      bool       `json:"include_superseded,omitempty"` // Also return results replaced by a re-run
+	OrderBy                string     `json:"order_by,omitempty"`           // "sharpe", "profit", "created_at"
+	Ascending              bool       `json:"ascending,omitempty"`
+	Page                   int        `json:"page"`
+	PageSize               int        `json:"page_size"`
 }
 
-// SetDefaults sets default values for the query.
-func (q *BacktestResultQuery) SetDefaults() {
+// SetDefaults sets default values for the query, capping the page size. It
+// reports whether the requested page size was clamped.
+func (q *BacktestResultQuery) SetDefaults() bool {
 	if q.OrderBy == "" {
 		q.OrderBy = "created_at"
 	}
-	if q.Page <= 0 {
-		q.Page = 1
-	}
-	if q.PageSize <= 0 {
-		q.PageSize = 20
-	}
-	if q.PageSize > 100 {
-		q.PageSize = 100
-	}
+	return applyPageDefaults(PageResourceResults, &q.Page, &q.PageSize)
 }
 
 // Offset returns the offset for pagination.
@@ -183,6 +340,7 @@ type TimeRange struct {
 type BacktestJobQuery struct {
 	StrategyID        *uuid.UUID `json:"strategy_id,omitempty"`
 	OptimizationRunID *uuid.UUID `json:"optimization_run_id,omitempty"`
+	CampaignID        *uuid.UUID `json:"campaign_id,omitempty"`
 	Status            *JobStatus `json:"status,omitempty"`
 	Page              int        `json:"page"`
 	PageSize          int        `json:"page_size"`
@@ -190,20 +348,13 @@ type BacktestJobQuery struct {
 	Ascending         bool       `json:"ascending,omitempty"`
 }
 
-// SetDefaults sets default values for the query.
-func (q *BacktestJobQuery) SetDefaults() {
+// SetDefaults sets default values for the query, capping the page size. It
+// reports whether the requested page size was clamped.
+func (q *BacktestJobQuery) SetDefaults() bool {
 	if q.OrderBy == "" {
 		q.OrderBy = "created_at"
 	}
-	if q.Page <= 0 {
-		q.Page = 1
-	}
-	if q.PageSize <= 0 {
-		q.PageSize = 20
-	}
-	if q.PageSize > 100 {
-		q.PageSize = 100
-	}
+	return applyPageDefaults(PageResourceJobs, &q.Page, &q.PageSize)
 }
 
 // Offset returns the offset for pagination.
@@ -220,3 +371,26 @@ type QueueStats struct {
 	AvgWaitTimeMs  int64 `json:"avg_wait_time_ms"`
 	AvgRunTimeMs   int64 `json:"avg_run_time_ms"`
 }
+
+// MetricStatistics summarizes one metric across a set of backtest results.
+// Results without a value for the metric are not counted.
+type MetricStatistics struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	StdDev float64 `json:"stddev"` // Sample standard deviation, 0 with fewer than two values
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// StrategyStatistics aggregates a strategy's backtest results within a window.
+type StrategyStatistics struct {
+	StrategyID     uuid.UUID        `json:"strategy_id"`
+	Window         *TimeRange       `json:"window,omitempty"`
+	ResultCount    int              `json:"result_count"`
+	SharpeRatio    MetricStatistics `json:"sharpe_ratio"`
+	ProfitPct      MetricStatistics `json:"profit_pct"`
+	MaxDrawdownPct MetricStatistics `json:"max_drawdown_pct"`
+	FirstResultAt  *time.Time       `json:"first_result_at,omitempty"`
+	LastResultAt   *time.Time       `json:"last_result_at,omitempty"`
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCampaignNameLength is the maximum length of a campaign name.
+const MaxCampaignNameLength = 255
+
+// Campaign is a named group of backtest jobs submitted together, e.g. a
+// human-driven sweep of strategies over a set of configs. Jobs are attached
+// to a campaign at submission.
+type Campaign struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewCampaign creates a new campaign with a generated UUID.
+func NewCampaign(name, description, createdBy string) *Campaign {
+	now := time.Now()
+	return &Campaign{
+		ID:          uuid.New(),
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate checks the campaign name.
+func (c *Campaign) Validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(c.Name) > MaxCampaignNameLength {
+		return errors.New("name exceeds maximum length of 255 characters")
+	}
+	return nil
+}
+
+// CampaignProgress counts a campaign's jobs by status.
+type CampaignProgress struct {
+	TotalJobs       int     `json:"total_jobs"`
+	PendingJobs     int     `json:"pending_jobs"`
+	RunningJobs     int     `json:"running_jobs"`
+	CompletedJobs   int     `json:"completed_jobs"`
+	FailedJobs      int     `json:"failed_jobs"`
+	CancelledJobs   int     `json:"cancelled_jobs"`
+	PercentComplete float64 `json:"percent_complete"` // Share of jobs in a terminal status
+}
+
+// SetPercentComplete derives PercentComplete from the job counts.
+func (p *CampaignProgress) SetPercentComplete() {
+	p.PercentComplete = 0
+	if p.TotalJobs > 0 {
+		terminal := p.CompletedJobs + p.FailedJobs + p.CancelledJobs
+		p.PercentComplete = 100 * float64(terminal) / float64(p.TotalJobs)
+	}
+}
+
+// CampaignResults aggregates the current (not superseded) results of a
+// campaign's jobs. The averages and best result are nil until a job of the
+// campaign has produced a result.
+type CampaignResults struct {
+	ResultCount       int      `json:"result_count"`
+	TotalTrades       int      `json:"total_trades"`
+	AvgSharpeRatio    *float64 `json:"avg_sharpe_ratio,omitempty"`
+	AvgProfitPct      *float64 `json:"avg_profit_pct,omitempty"`
+	AvgMaxDrawdownPct *float64 `json:"avg_max_drawdown_pct,omitempty"`
+
+	// BestResult is the result with the highest Sharpe ratio.
+	BestResult *BacktestResult `json:"best_result,omitempty"`
+}
+
+// CampaignSummary is a campaign with its aggregate progress and results.
+type CampaignSummary struct {
+	*Campaign
+	Progress CampaignProgress `json:"progress"`
+	Results  CampaignResults  `json:"results"`
+}
+
+// CampaignListQuery represents query parameters for listing campaigns.
+type CampaignListQuery struct {
+	NamePattern *string `json:"name_pattern,omitempty"` // SQL ILIKE pattern
+	Page        int     `json:"page"`
+	PageSize    int     `json:"page_size"`
+}
+
+// SetDefaults sets default values for the query, capping the page size. It
+// reports whether the requested page size was clamped.
+func (q *CampaignListQuery) SetDefaults() bool {
+	return applyPageDefaults(PageResourceCampaigns, &q.Page, &q.PageSize)
+}
+
+// Offset returns the SQL offset for pagination.
+func (q *CampaignListQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxCancelReasonLength is the longest cancellation reason accepted, in
+// characters.
+const MaxCancelReasonLength = 1000
+
+// Cancellation records why, and by whom, a job, optimization run or scout run
+// was cancelled. Both fields are optional.
+type Cancellation struct {
+	Reason      string `json:"reason,omitempty"`
+	CancelledBy string `json:"cancelled_by,omitempty"`
+}
+
+// NewCancellation creates a cancellation with the reason trimmed.
+func NewCancellation(reason, cancelledBy string) Cancellation {
+	return Cancellation{Reason: strings.TrimSpace(reason), CancelledBy: cancelledBy}
+}
+
+// Validate checks the length of the reason.
+func (c Cancellation) Validate() error {
+	if utf8.RuneCountInString(c.Reason) > MaxCancelReasonLength {
+		return fmt.Errorf("%w: cancellation reason exceeds %d characters", ErrInvalidInput, MaxCancelReasonLength)
+	}
+	return nil
+}
+
+// Detail describes the cancellation for a job's timeline, e.g.
+// "cancelled by ops: superseded by run 42". It is empty if neither field is
+// set.
+func (c Cancellation) Detail() string {
+	switch {
+	case c.CancelledBy != "" && c.Reason != "":
+		return "cancelled by " + c.CancelledBy + ": " + c.Reason
+	case c.CancelledBy != "":
+		return "cancelled by " + c.CancelledBy
+	default:
+		return c.Reason
+	}
+}
+
+// Cancellation returns why, and by whom, the job was cancelled.
+func (j *BacktestJob) Cancellation() Cancellation {
+	return cancellationOf(j.CancelReason, j.CancelledBy)
+}
+
+// Cancellation returns why, and by whom, the run was cancelled.
+func (r *OptimizationRun) Cancellation() Cancellation {
+	return cancellationOf(r.CancelReason, r.CancelledBy)
+}
+
+// Cancellation returns why, and by whom, the run was cancelled.
+func (r *ScoutRun) Cancellation() Cancellation {
+	return cancellationOf(r.CancelReason, r.CancelledBy)
+}
+
+func cancellationOf(reason, cancelledBy *string) Cancellation {
+	var c Cancellation
+	if reason != nil {
+		c.Reason = *reason
+	}
+	if cancelledBy != nil {
+		c.CancelledBy = *cancelledBy
+	}
+	return c
+}
+
+// JobCancelFilter selects the jobs a bulk cancellation cancels: the jobs in
+// the given status of an optimization run, a strategy, or both. At least one
+// of the two is required, so a filter never selects every job.
+type JobCancelFilter struct {
+	OptimizationRunID *uuid.UUID `json:"optimization_run_id,omitempty"`
+	StrategyID        *uuid.UUID `json:"strategy_id,omitempty"`
+	Status            JobStatus  `json:"status"` // pending or running
+}
+
+// Validate checks that the filter selects a run or strategy and a
+// cancellable status.
+func (f JobCancelFilter) Validate() error {
+	if f.OptimizationRunID == nil && f.StrategyID == nil {
+		return fmt.Errorf("%w: optimization_run_id or strategy_id is required", ErrInvalidInput)
+	}
+	if f.Status != JobStatusPending && f.Status != JobStatusRunning {
+		return fmt.Errorf("%w: status must be pending or running", ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConsistencyCheck identifies a cross-table invariant checked by the
+// consistency tool.
+type ConsistencyCheck string
+
+const (
+	// ConsistencyCheckIterationMissingJob finds optimization iterations whose
+	// backtest job no longer exists. Repair deletes the iteration.
+	ConsistencyCheckIterationMissingJob ConsistencyCheck = "iteration_missing_job"
+	// ConsistencyCheckResultMissingJob finds backtest results whose job no
+	// longer exists. Repair detaches the result from runs and iterations and
+	// deletes it.
+	ConsistencyCheckResultMissingJob ConsistencyCheck = "result_missing_job"
+	// ConsistencyCheckRunIterationMismatch finds optimization runs whose
+	// current_iteration disagrees with their latest iteration. Repair sets it
+	// to the latest iteration number.
+	ConsistencyCheckRunIterationMismatch ConsistencyCheck = "run_iteration_mismatch"
+	// ConsistencyCheckRunningJobWithoutContainer finds jobs marked running
+	// that have no container. Repair marks them failed.
+	ConsistencyCheckRunningJobWithoutContainer ConsistencyCheck = "running_job_without_container"
+)
+
+// ConsistencyChecks lists every check, in the order they are run. Repairs
+// run in the same order, so iterations deleted by an earlier repair are
+// reflected in the run iteration check.
+var ConsistencyChecks = []ConsistencyCheck{
+	ConsistencyCheckIterationMissingJob,
+	ConsistencyCheckResultMissingJob,
+	ConsistencyCheckRunIterationMismatch,
+	ConsistencyCheckRunningJobWithoutContainer,
+}
+
+// IsValid checks if the consistency check is known.
+func (c ConsistencyCheck) IsValid() bool {
+	for _, check := range ConsistencyChecks {
+		if c == check {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the string representation of the check.
+func (c ConsistencyCheck) String() string {
+	return string(c)
+}
+
+// ParseConsistencyCheck parses a check name.
+func ParseConsistencyCheck(s string) (ConsistencyCheck, error) {
+	check := ConsistencyCheck(s)
+	if !check.IsValid() {
+		return "", fmt.Errorf("unknown consistency check: %s", s)
+	}
+	return check, nil
+}
+
+// ConsistencyViolation is a row that breaks an invariant.
+type ConsistencyViolation struct {
+	Check      ConsistencyCheck `json:"check"`
+	EntityType string           `json:"entity_type"` // "optimization_iteration", "backtest_result", "optimization_run", "backtest_job"
+	EntityID   uuid.UUID        `json:"entity_id"`
+	Detail     string           `json:"detail"`
+	Repair     string           `json:"repair"`   // What the repair does, or did
+	Repaired   bool             `json:"repaired"` // True once the repair has been committed
+}
+
+// ConsistencyReport is the outcome of a consistency check or repair.
+type ConsistencyReport struct {
+	CheckedAt  time.Time                `json:"checked_at"`
+	Checks     []ConsistencyCheck       `json:"checks"`
+	Repair     bool                     `json:"repair"`  // Repairs were applied
+	DryRun     bool                     `json:"dry_run"` // Repairs were applied and rolled back
+	Counts     map[ConsistencyCheck]int `json:"counts"`
+	Violations []ConsistencyViolation   `json:"violations"`
+}
+
+// NewConsistencyReport creates an empty report for the given checks.
+func NewConsistencyReport(checks []ConsistencyCheck, repair, dryRun bool, now time.Time) *ConsistencyReport {
+	counts := make(map[ConsistencyCheck]int, len(checks))
+	for _, check := range checks {
+		counts[check] = 0
+	}
+	return &ConsistencyReport{
+		CheckedAt:  now,
+		Checks:     checks,
+		Repair:     repair,
+		DryRun:     repair && dryRun,
+		Counts:     counts,
+		Violations: []ConsistencyViolation{},
+	}
+}
+
+// Add records violations found by a check.
+func (r *ConsistencyReport) Add(violations ...ConsistencyViolation) {
+	for _, v := range violations {
+		r.Counts[v.Check]++
+		r.Violations = append(r.Violations, v)
+	}
+}
+
+// Total returns the number of violations found.
+func (r *ConsistencyReport) Total() int {
+	return len(r.Violations)
+}
@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// coverageDateLayout is the date format used in coverage reports.
+const coverageDateLayout = "2006-01-02"
+
+// CoverageEntry is a single backtest job considered when computing coverage.
+type CoverageEntry struct {
+	Config    BacktestConfig
+	Status    JobStatus
+	HasResult bool
+}
+
+// CoverageTarget describes the universe a strategy is expected to be tested on.
+// Empty fields are ignored when computing gaps.
+type CoverageTarget struct {
+	Pairs          []string `json:"pairs,omitempty"`
+	Timeframes     []string `json:"timeframes,omitempty"`
+	TimerangeStart string   `json:"timerange_start,omitempty"`
+	TimerangeEnd   string   `json:"timerange_end,omitempty"`
+}
+
+// Validate checks that the target timerange dates are well-formed.
+func (t CoverageTarget) Validate() error {
+	start, okStart := parseCoverageDate(t.TimerangeStart)
+	if t.TimerangeStart != "" && !okStart {
+		return errors.New("start must be a date in YYYY-MM-DD or YYYYMMDD format")
+	}
+	end, okEnd := parseCoverageDate(t.TimerangeEnd)
+	if t.TimerangeEnd != "" && !okEnd {
+		return errors.New("end must be a date in YYYY-MM-DD or YYYYMMDD format")
+	}
+	if okStart && okEnd && end.Before(start) {
+		return errors.New("end must not be before start")
+	}
+	return nil
+}
+
+// CoverageBucket counts jobs for a single pair or timeframe.
+type CoverageBucket struct {
+	Value     string `json:"value"`
+	Jobs      int    `json:"jobs"`
+	Completed int    `json:"completed"`
+}
+
+// DateRange is an inclusive range of dates (YYYY-MM-DD).
+type DateRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Days  int    `json:"days"`
+}
+
+// CoverageGaps lists the parts of the target universe that have not been tested.
+type CoverageGaps struct {
+	Pairs      []string    `json:"pairs"`
+	Timeframes []string    `json:"timeframes"`
+	Timeranges []DateRange `json:"timeranges"`
+}
+
+// StrategyCoverage summarizes what a strategy has been backtested on.
+type StrategyCoverage struct {
+	StrategyID    uuid.UUID        `json:"strategy_id"`
+	TotalJobs     int              `json:"total_jobs"`
+	CompletedJobs int              `json:"completed_jobs"`
+	Pairs         []CoverageBucket `json:"pairs"`
+	Timeframes    []CoverageBucket `json:"timeframes"`
+	Timeranges    []DateRange      `json:"timeranges"` // merged ranges covered by completed jobs
+	Target        CoverageTarget   `json:"target"`
+	Gaps          CoverageGaps     `json:"gaps"`
+}
+
+// BuildStrategyCoverage computes a coverage report from a strategy's jobs.
+// Only completed jobs with results count as coverage; other jobs are counted
+// per pair and timeframe so pending work is visible. When the target timerange
+// is unset, the span of tested ranges is used so gaps between them are reported.
+func BuildStrategyCoverage(strategyID uuid.UUID, entries []CoverageEntry, target CoverageTarget) *StrategyCoverage {
+	coverage := &StrategyCoverage{
+		StrategyID: strategyID,
+		Target:     target,
+		Gaps: CoverageGaps{
+			Pairs:      []string{},
+			Timeframes: []string{},
+			Timeranges: []DateRange{},
+		},
+	}
+
+	pairs := map[string]*CoverageBucket{}
+	timeframes := map[string]*CoverageBucket{}
+	var ranges [][2]time.Time
+
+	for _, entry := range entries {
+		if entry.Status == JobStatusCancelled {
+			continue
+		}
+		coverage.TotalJobs++
+
+		completed := entry.Status == JobStatusCompleted && entry.HasResult
+		if completed {
+			coverage.CompletedJobs++
+		}
+
+		countBucket(timeframes, entry.Config.Timeframe, completed)
+		for _, pair := range entry.Config.Pairs {
+			countBucket(pairs, pair, completed)
+		}
+
+		if !completed {
+			continue
+		}
+		start, okStart := parseCoverageDate(entry.Config.TimerangeStart)
+		end, okEnd := parseCoverageDate(entry.Config.TimerangeEnd)
+		if okStart && okEnd && !end.Before(start) {
+			ranges = append(ranges, [2]time.Time{start, end})
+		}
+	}
+
+	coverage.Pairs = sortedBuckets(pairs)
+	coverage.Timeframes = sortedBuckets(timeframes)
+
+	merged := mergeDateRanges(ranges)
+	coverage.Timeranges = make([]DateRange, 0, len(merged))
+	for _, r := range merged {
+		coverage.Timeranges = append(coverage.Timeranges, newDateRange(r[0], r[1]))
+	}
+
+	// Untested pairs and timeframes
+	for _, pair := range target.Pairs {
+		if b, ok := pairs[pair]; !ok || b.Completed == 0 {
+			coverage.Gaps.Pairs = append(coverage.Gaps.Pairs, pair)
+		}
+	}
+	for _, tf := range target.Timeframes {
+		if b, ok := timeframes[tf]; !ok || b.Completed == 0 {
+			coverage.Gaps.Timeframes = append(coverage.Gaps.Timeframes, tf)
+		}
+	}
+
+	// Untested date ranges within the target window
+	windowStart, okStart := parseCoverageDate(target.TimerangeStart)
+	windowEnd, okEnd := parseCoverageDate(target.TimerangeEnd)
+	if len(merged) > 0 {
+		if !okStart {
+			windowStart = merged[0][0]
+		}
+		if !okEnd {
+			windowEnd = merged[len(merged)-1][1]
+		}
+	} else if !okStart || !okEnd {
+		return coverage
+	}
+
+	cursor := windowStart
+	for _, r := range merged {
+		if r[1].Before(cursor) {
+			continue
+		}
+		if r[0].After(windowEnd) {
+			break
+		}
+		if r[0].After(cursor) {
+			coverage.Gaps.Timeranges = append(coverage.Gaps.Timeranges, newDateRange(cursor, r[0].AddDate(0, 0, -1)))
+		}
+		cursor = r[1].AddDate(0, 0, 1)
+	}
+	if !cursor.After(windowEnd) {
+		coverage.Gaps.Timeranges = append(coverage.Gaps.Timeranges, newDateRange(cursor, windowEnd))
+	}
+
+	return coverage
+}
+
+// countBucket increments the job counters for a bucket value.
+func countBucket(buckets map[string]*CoverageBucket, value string, completed bool) {
+	if value == "" {
+		return
+	}
+	b, ok := buckets[value]
+	if !ok {
+		b = &CoverageBucket{Value: value}
+		buckets[value] = b
+	}
+	b.Jobs++
+	if completed {
+		b.Completed++
+	}
+}
+
+// sortedBuckets returns buckets ordered by value.
+func sortedBuckets(buckets map[string]*CoverageBucket) []CoverageBucket {
+	result := make([]CoverageBucket, 0, len(buckets))
+	for _, b := range buckets {
+		result = append(result, *b)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
+
+// mergeDateRanges merges overlapping or adjacent inclusive date ranges.
+func mergeDateRanges(ranges [][2]time.Time) [][2]time.Time {
+	if len(ranges) == 0 {
+		return nil
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i][0].Before(ranges[j][0])
+	})
+
+	merged := [][2]time.Time{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if !r[0].After(last[1].AddDate(0, 0, 1)) {
+			if r[1].After(last[1]) {
+				last[1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// newDateRange creates an inclusive DateRange.
+func newDateRange(start, end time.Time) DateRange {
+	return DateRange{
+		Start: start.Format(coverageDateLayout),
+		End:   end.Format(coverageDateLayout),
+		Days:  int(end.Sub(start).Hours()/24) + 1,
+	}
+}
+
+// parseCoverageDate parses a timerange date in YYYY-MM-DD or YYYYMMDD format.
+func parseCoverageDate(s string) (time.Time, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "-", "")
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("20060102", s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
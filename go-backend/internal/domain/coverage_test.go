@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseDate(s string) time.Time {
+	t, err := time.Parse(coverageDateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func dateRanges(pairs ...string) [][2]time.Time {
+	ranges := make([][2]time.Time, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		ranges = append(ranges, [2]time.Time{mustParseDate(pairs[i]), mustParseDate(pairs[i+1])})
+	}
+	return ranges
+}
+
+func TestMergeDateRanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges [][2]time.Time
+		want   [][2]time.Time
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:   "single",
+			ranges: dateRanges("2024-01-01", "2024-01-31"),
+			want:   dateRanges("2024-01-01", "2024-01-31"),
+		},
+		{
+			name:   "overlapping",
+			ranges: dateRanges("2024-01-01", "2024-01-20", "2024-01-10", "2024-02-10"),
+			want:   dateRanges("2024-01-01", "2024-02-10"),
+		},
+		{
+			name:   "adjacent",
+			ranges: dateRanges("2024-01-01", "2024-01-31", "2024-02-01", "2024-02-29"),
+			want:   dateRanges("2024-01-01", "2024-02-29"),
+		},
+		{
+			name:   "nested",
+			ranges: dateRanges("2024-01-01", "2024-06-30", "2024-02-01", "2024-03-01"),
+			want:   dateRanges("2024-01-01", "2024-06-30"),
+		},
+		{
+			name:   "disjoint",
+			ranges: dateRanges("2024-01-01", "2024-01-31", "2024-02-02", "2024-02-29"),
+			want:   dateRanges("2024-01-01", "2024-01-31", "2024-02-02", "2024-02-29"),
+		},
+		{
+			name:   "unsorted",
+			ranges: dateRanges("2024-03-01", "2024-03-31", "2024-01-01", "2024-01-31", "2024-01-15", "2024-02-10"),
+			want:   dateRanges("2024-01-01", "2024-02-10", "2024-03-01", "2024-03-31"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeDateRanges(tt.ranges))
+		})
+	}
+}
+
+func TestBuildStrategyCoverage(t *testing.T) {
+	completed := func(start, end string, pairs ...string) CoverageEntry {
+		return CoverageEntry{
+			Config: BacktestConfig{
+				Timeframe:      "1h",
+				Pairs:          pairs,
+				TimerangeStart: start,
+				TimerangeEnd:   end,
+			},
+			Status:    JobStatusCompleted,
+			HasResult: true,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		entries    []CoverageEntry
+		target     CoverageTarget
+		timeranges []DateRange
+		gaps       []DateRange
+	}{
+		{
+			name:       "no entries",
+			timeranges: []DateRange{},
+			gaps:       []DateRange{},
+		},
+		{
+			name:       "no entries with target",
+			target:     CoverageTarget{TimerangeStart: "2024-01-01", TimerangeEnd: "2024-01-31"},
+			timeranges: []DateRange{},
+			gaps:       []DateRange{{Start: "2024-01-01", End: "2024-01-31", Days: 31}},
+		},
+		{
+			name: "gap between ranges without target",
+			entries: []CoverageEntry{
+				completed("20240301", "20240331"),
+				completed("2024-01-01", "2024-01-31"),
+			},
+			timeranges: []DateRange{
+				{Start: "2024-01-01", End: "2024-01-31", Days: 31},
+				{Start: "2024-03-01", End: "2024-03-31", Days: 31},
+			},
+			gaps: []DateRange{{Start: "2024-02-01", End: "2024-02-29", Days: 29}},
+		},
+		{
+			name: "gaps at start and end of target",
+			entries: []CoverageEntry{
+				completed("2024-02-01", "2024-02-10"),
+				completed("2024-02-05", "2024-02-20"),
+			},
+			target: CoverageTarget{TimerangeStart: "2024-01-01", TimerangeEnd: "2024-03-31"},
+			timeranges: []DateRange{
+				{Start: "2024-02-01", End: "2024-02-20", Days: 20},
+			},
+			gaps: []DateRange{
+				{Start: "2024-01-01", End: "2024-01-31", Days: 31},
+				{Start: "2024-02-21", End: "2024-03-31", Days: 40},
+			},
+		},
+		{
+			name: "ranges beyond target",
+			entries: []CoverageEntry{
+				completed("2023-12-01", "2024-01-10"),
+				completed("2024-01-21", "2024-02-15"),
+			},
+			target: CoverageTarget{TimerangeStart: "2024-01-01", TimerangeEnd: "2024-01-31"},
+			timeranges: []DateRange{
+				{Start: "2023-12-01", End: "2024-01-10", Days: 41},
+				{Start: "2024-01-21", End: "2024-02-15", Days: 26},
+			},
+			gaps: []DateRange{{Start: "2024-01-11", End: "2024-01-20", Days: 10}},
+		},
+		{
+			name: "fully covered",
+			entries: []CoverageEntry{
+				completed("2024-01-01", "2024-01-15"),
+				completed("2024-01-16", "2024-01-31"),
+			},
+			target:     CoverageTarget{TimerangeStart: "2024-01-01", TimerangeEnd: "2024-01-31"},
+			timeranges: []DateRange{{Start: "2024-01-01", End: "2024-01-31", Days: 31}},
+			gaps:       []DateRange{},
+		},
+		{
+			name: "pending and failed jobs are not coverage",
+			entries: []CoverageEntry{
+				{Config: BacktestConfig{TimerangeStart: "2024-01-01", TimerangeEnd: "2024-01-31"}, Status: JobStatusPending},
+				{Config: BacktestConfig{TimerangeStart: "2024-01-01", TimerangeEnd: "2024-01-31"}, Status: JobStatusCompleted},
+				{Config: BacktestConfig{TimerangeStart: "2024-01-01", TimerangeEnd: "2024-01-31"}, Status: JobStatusFailed, HasResult: true},
+			},
+			target:     CoverageTarget{TimerangeStart: "2024-01-01", TimerangeEnd: "2024-01-10"},
+			timeranges: []DateRange{},
+			gaps:       []DateRange{{Start: "2024-01-01", End: "2024-01-10", Days: 10}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coverage := BuildStrategyCoverage(uuid.New(), tt.entries, tt.target)
+			assert.Equal(t, tt.timeranges, coverage.Timeranges)
+			assert.Equal(t, tt.gaps, coverage.Gaps.Timeranges)
+		})
+	}
+}
+
+func TestBuildStrategyCoverage_Buckets(t *testing.T) {
+	entries := []CoverageEntry{
+		{Config: BacktestConfig{Timeframe: "1h", Pairs: []string{"BTC/USDT", "ETH/USDT"}}, Status: JobStatusCompleted, HasResult: true},
+		{Config: BacktestConfig{Timeframe: "5m", Pairs: []string{"ETH/USDT"}}, Status: JobStatusPending},
+		{Config: BacktestConfig{Timeframe: "4h", Pairs: []string{"SOL/USDT"}}, Status: JobStatusCancelled},
+	}
+	target := CoverageTarget{
+		Pairs:      []string{"BTC/USDT", "ETH/USDT", "SOL/USDT"},
+		Timeframes: []string{"1h", "5m"},
+	}
+
+	coverage := BuildStrategyCoverage(uuid.New(), entries, target)
+	assert.Equal(t, 2, coverage.TotalJobs)
+	assert.Equal(t, 1, coverage.CompletedJobs)
+	assert.Equal(t, []CoverageBucket{
+		{Value: "BTC/USDT", Jobs: 1, Completed: 1},
+		{Value: "ETH/USDT", Jobs: 2, Completed: 1},
+	}, coverage.Pairs)
+	assert.Equal(t, []CoverageBucket{
+		{Value: "1h", Jobs: 1, Completed: 1},
+		{Value: "5m", Jobs: 1, Completed: 0},
+	}, coverage.Timeframes)
+
+	// Pending and cancelled work leaves gaps
+	assert.Equal(t, []string{"SOL/USDT"}, coverage.Gaps.Pairs)
+	assert.Equal(t, []string{"5m"}, coverage.Gaps.Timeframes)
+	require.Empty(t, coverage.Gaps.Timeranges)
+}
+
+func TestCoverageTarget_Validate(t *testing.T) {
+	assert.NoError(t, CoverageTarget{}.Validate())
+	assert.NoError(t, CoverageTarget{TimerangeStart: "20240101", TimerangeEnd: "2024-01-31"}.Validate())
+	assert.Error(t, CoverageTarget{TimerangeStart: "01/01/2024"}.Validate())
+	assert.Error(t, CoverageTarget{TimerangeEnd: "2024-13-01"}.Validate())
+	assert.Error(t, CoverageTarget{TimerangeStart: "2024-02-01", TimerangeEnd: "2024-01-31"}.Validate())
+}
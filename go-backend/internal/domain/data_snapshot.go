@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OptimizationDataSnapshot pins the market data an optimization run is
+// evaluated on: the pairs and data coverage resolved when the run started.
+// Every backtest job of the run is forced onto the snapshot, so later
+// iterations are not silently backtested against more or different data than
+// early ones and their results stay comparable.
+type OptimizationDataSnapshot struct {
+	Exchange       string    `json:"exchange,omitempty"`
+	Pairs          []string  `json:"pairs,omitempty"` // Sorted, without duplicates
+	Timeframe      string    `json:"timeframe,omitempty"`
+	TimerangeStart string    `json:"timerange_start,omitempty"`
+	TimerangeEnd   string    `json:"timerange_end"` // Pinned to the capture date for open-ended ranges
+	TradingMode    string    `json:"trading_mode"`
+	StakeCurrency  string    `json:"stake_currency,omitempty"`
+	CapturedAt     time.Time `json:"captured_at"`
+}
+
+// NewOptimizationDataSnapshot resolves the data environment of a run's
+// backtest config at the given time. An open-ended timerange is closed at the
+// capture date, so data downloaded later does not extend it.
+func NewOptimizationDataSnapshot(cfg BacktestConfig, at time.Time) *OptimizationDataSnapshot {
+	end := cfg.TimerangeEnd
+	if end == "" {
+		end = at.UTC().Format("20060102")
+	}
+
+	return &OptimizationDataSnapshot{
+		Exchange:       cfg.Exchange,
+		Pairs:          normalizePairs(cfg.Pairs),
+		Timeframe:      cfg.Timeframe,
+		TimerangeStart: cfg.TimerangeStart,
+		TimerangeEnd:   end,
+		TradingMode:    cfg.GetTradingMode(),
+		StakeCurrency:  cfg.StakeCurrency,
+		CapturedAt:     at,
+	}
+}
+
+// Pin forces the config onto the snapshot. Fields left empty in the config
+// are filled in; fields that conflict with the snapshot are overridden and
+// reported, one message per field, so the submitter can be warned.
+func (s *OptimizationDataSnapshot) Pin(cfg *BacktestConfig) []string {
+	var overridden []string
+	pin := func(field string, value *string, pinned string) {
+		if pinned == "" || *value == pinned {
+			return
+		}
+		if *value != "" {
+			overridden = append(overridden, fmt.Sprintf("%s %q overridden by the run's data snapshot (%q)", field, *value, pinned))
+		}
+		*value = pinned
+	}
+
+	pin("exchange", &cfg.Exchange, s.Exchange)
+	pin("timeframe", &cfg.Timeframe, s.Timeframe)
+	pin("timerange_start", &cfg.TimerangeStart, s.TimerangeStart)
+	pin("timerange_end", &cfg.TimerangeEnd, s.TimerangeEnd)
+	pin("trading_mode", &cfg.TradingMode, s.TradingMode)
+	pin("stake_currency", &cfg.StakeCurrency, s.StakeCurrency)
+
+	if len(s.Pairs) > 0 {
+		if len(cfg.Pairs) > 0 && !slices.Equal(normalizePairs(cfg.Pairs), s.Pairs) {
+			overridden = append(overridden, fmt.Sprintf("pairs %s overridden by the run's data snapshot (%s)",
+				strings.Join(cfg.Pairs, ","), strings.Join(s.Pairs, ",")))
+		}
+		cfg.Pairs = slices.Clone(s.Pairs)
+	}
+
+	return overridden
+}
+
+// normalizePairs returns the pairs sorted and without duplicates.
+func normalizePairs(pairs []string) []string {
+	if len(pairs) == 0 {
+		return nil
+	}
+	out := slices.Clone(pairs)
+	sort.Strings(out)
+	return slices.Compact(out)
+}
+
+// PinBacktestConfig forces a backtest config of the run onto its data
+// snapshot, returning a message for each overridden field. Configs of runs
+// without a snapshot are left unchanged.
+func (r *OptimizationRun) PinBacktestConfig(cfg *BacktestConfig) []string {
+	if r.DataSnapshot == nil {
+		return nil
+	}
+	return r.DataSnapshot.Pin(cfg)
+}
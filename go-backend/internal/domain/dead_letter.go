@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxDeadLetterErrorLength is the longest error kept with a dead letter.
+const MaxDeadLetterErrorLength = 2000
+
+// DeadLetter is an event message a consumer gave up on, collected from the
+// dead-letter queue so it can be inspected and requeued.
+type DeadLetter struct {
+	ID         uuid.UUID `json:"id"`
+	RoutingKey string    `json:"routing_key"`
+	Exchange   string    `json:"exchange,omitempty"` // Exchange the message was originally published to
+	Queue      string    `json:"queue,omitempty"`    // Queue whose consumer gave up on it
+	Error      string    `json:"error"`
+	Body       []byte    `json:"-"`
+	FailedAt   time.Time `json:"failed_at"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	RequeuedAt   *time.Time `json:"requeued_at,omitempty"` // Last time it was requeued
+	RequeueCount int        `json:"requeue_count"`
+}
+
+// BodyJSON returns the message body if it is valid JSON, or nil otherwise.
+func (d *DeadLetter) BodyJSON() json.RawMessage {
+	if !json.Valid(d.Body) {
+		return nil
+	}
+	return json.RawMessage(d.Body)
+}
+
+// DeadLetterQuery filters a listing of dead letters.
+type DeadLetterQuery struct {
+	RoutingKey      string // Exact routing key; empty matches all
+	IncludeRequeued bool   // Also list dead letters that were requeued
+	Limit           int
+}
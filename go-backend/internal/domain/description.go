@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxStrategyDescriptionLength is the longest generated description accepted,
+// in characters.
+const MaxStrategyDescriptionLength = 5000
+
+// DescriptionRequest is a strategy the analyst agent is asked to describe.
+type DescriptionRequest struct {
+	StrategyID uuid.UUID `json:"strategy_id"`
+	Name       string    `json:"name"`
+	Timeframe  string    `json:"timeframe,omitempty"`
+}
+
+// ValidateGeneratedDescription trims a generated description and checks that
+// it is non-empty and not too long.
+func ValidateGeneratedDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return "", fmt.Errorf("%w: description is required", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(description) > MaxStrategyDescriptionLength {
+		return "", fmt.Errorf("%w: description exceeds %d characters", ErrInvalidInput, MaxStrategyDescriptionLength)
+	}
+	return description, nil
+}
+
+// DescriptionCoverage summarizes how many active strategies have a
+// description.
+type DescriptionCoverage struct {
+	Strategies int `json:"strategies"` // Active (non-archived) strategies
+	Described  int `json:"described"`
+	Generated  int `json:"generated"` // Described strategies whose description was generated
+	Missing    int `json:"missing"`
+	Requested  int `json:"requested"` // Missing descriptions requested and not yet delivered
+
+	CoveragePct float64 `json:"coverage_pct"`
+}
+
+// ComputeCoveragePct sets CoveragePct from the counts. No strategies count as
+// full coverage.
+func (c *DescriptionCoverage) ComputeCoveragePct() {
+	if c.Strategies == 0 {
+		c.CoveragePct = 100
+		return
+	}
+	c.CoveragePct = float64(c.Described) / float64(c.Strategies) * 100
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrDuplicateJob is returned when submitting a backtest identical to a job
+// that is pending or running.
+var ErrDuplicateJob = errors.New("identical job is already pending or running")
+
+// DuplicateJobError wraps ErrDuplicateJob with the job already queued.
+type DuplicateJobError struct {
+	ExistingJobID uuid.UUID
+}
+
+func (e DuplicateJobError) Error() string {
+	return ErrDuplicateJob.Error() + ": " + e.ExistingJobID.String()
+}
+
+func (e DuplicateJobError) Unwrap() error {
+	return ErrDuplicateJob
+}
+
+// Hash returns a hash identifying the config; equal configs hash equally.
+func (c *BacktestConfig) Hash() string {
+	// Marshaling a struct cannot fail, and map keys are sorted
+	data, _ := json.Marshal(c)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// WorkKey identifies the work a job does: its strategy's code, which is
+// unique per strategy, and its config. Jobs with equal keys produce the same
+// result.
+func (j *BacktestJob) WorkKey() string {
+	return j.StrategyID.String() + ":" + j.Config.Hash()
+}
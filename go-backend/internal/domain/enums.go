@@ -51,6 +51,11 @@ const (
 	OptimizationStatusCompleted OptimizationStatus = "completed"
 	OptimizationStatusFailed    OptimizationStatus = "failed"
 	OptimizationStatusCancelled OptimizationStatus = "cancelled"
+
+	// OptimizationStatusStalled marks a run that is nominally running but made
+	// no progress for the stall threshold, e.g. because the orchestrator crashed.
+	// It returns to running as soon as the run shows activity again.
+	OptimizationStatusStalled OptimizationStatus = "stalled"
 )
 
 // IsTerminal returns true if the status is terminal.
@@ -62,7 +67,8 @@ func (s OptimizationStatus) IsTerminal() bool {
 func (s OptimizationStatus) IsValid() bool {
 	switch s {
 	case OptimizationStatusPending, OptimizationStatusRunning, OptimizationStatusPaused,
-		OptimizationStatusCompleted, OptimizationStatusFailed, OptimizationStatusCancelled:
+		OptimizationStatusCompleted, OptimizationStatusFailed, OptimizationStatusCancelled,
+		OptimizationStatusStalled:
 		return true
 	default:
 		return false
@@ -83,6 +89,22 @@ func OptimizationStatusFromString(s string) OptimizationStatus {
 	return OptimizationStatusPending
 }
 
+// PauseReason records why an optimization run is paused.
+type PauseReason string
+
+const (
+	// PauseReasonManual is set when an operator pauses the run.
+	PauseReasonManual PauseReason = "manual"
+	// PauseReasonAutoPaused is set when the backend pauses the run during an
+	// infrastructure outage. Such runs are resumed automatically on recovery.
+	PauseReasonAutoPaused PauseReason = "auto_paused"
+)
+
+// String returns the string representation of the pause reason.
+func (r PauseReason) String() string {
+	return string(r)
+}
+
 // OptimizationMode represents the optimization goal.
 type OptimizationMode string
 
@@ -151,3 +173,27 @@ func ApprovalStatusFromString(s string) ApprovalStatus {
 	}
 	return ApprovalStatusPending
 }
+
+// ValidationStatus represents whether a strategy's code has passed validation.
+type ValidationStatus string
+
+const (
+	ValidationStatusUnvalidated ValidationStatus = "unvalidated"
+	ValidationStatusValidated   ValidationStatus = "validated"
+	ValidationStatusFailed      ValidationStatus = "validation_failed"
+)
+
+// IsValid returns true if the status is a valid ValidationStatus.
+func (s ValidationStatus) IsValid() bool {
+	switch s {
+	case ValidationStatusUnvalidated, ValidationStatusValidated, ValidationStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the status.
+func (s ValidationStatus) String() string {
+	return string(s)
+}
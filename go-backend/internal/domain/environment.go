@@ -0,0 +1,58 @@
+package domain
+
+// ExecutionEnvironment describes where and with what a backtest ran, so
+// anomalies in results can be correlated with environment changes.
+type ExecutionEnvironment struct {
+	FreqtradeVersion string            `json:"freqtrade_version,omitempty"`
+	PythonVersion    string            `json:"python_version,omitempty"`
+	Image            string            `json:"image,omitempty"`        // Image reference the container was created from
+	ImageDigest      string            `json:"image_digest,omitempty"` // e.g. "sha256:..."
+	Host             string            `json:"host,omitempty"`         // Docker host name
+	Packages         map[string]string `json:"packages,omitempty"`     // Python package -> version
+}
+
+// IsEmpty returns true if nothing about the environment is known.
+func (e *ExecutionEnvironment) IsEmpty() bool {
+	return e == nil || (e.FreqtradeVersion == "" && e.PythonVersion == "" && e.Image == "" &&
+		e.ImageDigest == "" && e.Host == "" && len(e.Packages) == 0)
+}
+
+// MergeExecutionEnvironments combines environment details from several
+// sources, e.g. versions reported inside the container and image details
+// from the container runtime. Fields already set in a take precedence.
+func MergeExecutionEnvironments(a, b *ExecutionEnvironment) *ExecutionEnvironment {
+	if a.IsEmpty() {
+		return b
+	}
+	if b.IsEmpty() {
+		return a
+	}
+
+	merged := *a
+	if merged.FreqtradeVersion == "" {
+		merged.FreqtradeVersion = b.FreqtradeVersion
+	}
+	if merged.PythonVersion == "" {
+		merged.PythonVersion = b.PythonVersion
+	}
+	if merged.Image == "" {
+		merged.Image = b.Image
+	}
+	if merged.ImageDigest == "" {
+		merged.ImageDigest = b.ImageDigest
+	}
+	if merged.Host == "" {
+		merged.Host = b.Host
+	}
+	if len(b.Packages) > 0 {
+		merged.Packages = make(map[string]string, len(a.Packages)+len(b.Packages))
+		for name, version := range b.Packages {
+			merged.Packages[name] = version
+		}
+		for name, version := range a.Packages {
+			merged.Packages[name] = version
+		}
+	}
+
+	return &merged
+}
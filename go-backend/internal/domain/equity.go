@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// DailyProfit is the absolute profit of a backtest's trades closed on one day.
+type DailyProfit struct {
+	Date      time.Time // Start of the day (UTC)
+	ProfitAbs float64   // In the stake currency
+}
+
+// EquityPoint is the wallet balance of a backtest at the end of a day.
+type EquityPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Balance   float64   `json:"balance"`
+
+	// DrawdownPct is how far the balance is below its highest point so far,
+	// in percent. It is derived when the curve is loaded.
+	DrawdownPct float64 `json:"drawdown_pct"`
+}
+
+// NewEquityCurve builds the equity curve of a backtest from its starting
+// balance and daily profits, in date order. Days reported more than once are
+// summed.
+func NewEquityCurve(startingBalance float64, daily []DailyProfit) []EquityPoint {
+	if len(daily) == 0 {
+		return nil
+	}
+
+	sorted := make([]DailyProfit, len(daily))
+	copy(sorted, daily)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	points := make([]EquityPoint, 0, len(sorted))
+	balance := startingBalance
+	for _, day := range sorted {
+		balance += day.ProfitAbs
+		if n := len(points); n > 0 && points[n-1].Timestamp.Equal(day.Date) {
+			points[n-1].Balance = balance
+			continue
+		}
+		points = append(points, EquityPoint{Timestamp: day.Date, Balance: balance})
+	}
+	SetEquityDrawdowns(points)
+	return points
+}
+
+// SetEquityDrawdowns sets the drawdown of each point of an equity curve from
+// the running peak balance.
+func SetEquityDrawdowns(points []EquityPoint) {
+	peak := 0.0
+	for i := range points {
+		if i == 0 || points[i].Balance > peak {
+			peak = points[i].Balance
+		}
+		points[i].DrawdownPct = 0
+		if peak > 0 {
+			points[i].DrawdownPct = (peak - points[i].Balance) / peak * 100
+		}
+	}
+}
@@ -27,6 +27,18 @@ var (
 
 	// ErrStrategyInUse is returned when trying to delete a strategy that is in use.
 	ErrStrategyInUse = errors.New("strategy is in use")
+
+	// ErrStrategyValidationFailed is returned when submitting a backtest for a
+	// strategy whose code failed validation.
+	ErrStrategyValidationFailed = errors.New("strategy failed validation")
+
+	// ErrSecretInUse is returned when trying to delete a secret that is still
+	// referenced, e.g. by a scout schedule.
+	ErrSecretInUse = errors.New("secret is in use")
+
+	// ErrInsightTimeout is returned when an insight query exceeds its time
+	// limit.
+	ErrInsightTimeout = errors.New("insight query timed out")
 )
 
 // NotFoundError wraps ErrNotFound with additional context.
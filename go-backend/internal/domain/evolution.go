@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// GenerationStatistics summarizes one lineage generation across all strategies.
+// A strategy's sharpe ratio is the best of its current (non-superseded) results.
+type GenerationStatistics struct {
+	Generation    int      `json:"generation"`
+	StrategyCount int      `json:"strategy_count"`
+	TestedCount   int      `json:"tested_count"` // Strategies with a sharpe ratio
+	BestSharpe    *float64 `json:"best_sharpe,omitempty"`
+	MeanSharpe    *float64 `json:"mean_sharpe,omitempty"`
+	SurvivorCount int      `json:"survivor_count"` // Strategies with at least one child
+	SurvivalRate  float64  `json:"survival_rate"`  // Share of strategies with at least one child
+
+	// Change from the previous generation, when both have tested strategies
+	BestSharpeDelta *float64 `json:"best_sharpe_delta,omitempty"`
+	MeanSharpeDelta *float64 `json:"mean_sharpe_delta,omitempty"`
+}
+
+// EvolutionSummary reports per-generation statistics of the whole corpus, to
+// show whether the evolution loop improves strategies over generations.
+type EvolutionSummary struct {
+	Generations   []GenerationStatistics `json:"generations"`
+	StrategyCount int                    `json:"strategy_count"`
+
+	// Consecutive generation pairs with tested strategies on both sides, and
+	// how many of them raised the mean sharpe ratio
+	ComparedGenerations  int `json:"compared_generations"`
+	ImprovingGenerations int `json:"improving_generations"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+	Timezone    string    `json:"timezone,omitempty"` // Server timezone of the timestamps
+}
+
+// NewEvolutionSummary builds a summary from per-generation counts and sharpe
+// ratios ordered by generation, filling in survival rates and the changes
+// between consecutive generations.
+func NewEvolutionSummary(generations []GenerationStatistics, now time.Time) *EvolutionSummary {
+	summary := &EvolutionSummary{
+		Generations: generations,
+		GeneratedAt: now,
+	}
+	if summary.Generations == nil {
+		summary.Generations = []GenerationStatistics{}
+	}
+
+	for i := range summary.Generations {
+		gen := &summary.Generations[i]
+		summary.StrategyCount += gen.StrategyCount
+		if gen.StrategyCount > 0 {
+			gen.SurvivalRate = float64(gen.SurvivorCount) / float64(gen.StrategyCount)
+		}
+
+		if i == 0 {
+			continue
+		}
+		prev := summary.Generations[i-1]
+		if prev.Generation != gen.Generation-1 || prev.MeanSharpe == nil || gen.MeanSharpe == nil {
+			continue
+		}
+
+		bestDelta := *gen.BestSharpe - *prev.BestSharpe
+		meanDelta := *gen.MeanSharpe - *prev.MeanSharpe
+		gen.BestSharpeDelta = &bestDelta
+		gen.MeanSharpeDelta = &meanDelta
+
+		summary.ComparedGenerations++
+		if meanDelta > 0 {
+			summary.ImprovingGenerations++
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxExportArchiveSize is the maximum size of an export archive in bytes.
+// Exports are stored in the artifact store, where they are exempt from
+// MaxArtifactSize.
+const MaxExportArchiveSize = 512 * 1024 * 1024
+
+// ExportStatus is the lifecycle status of an export job.
+type ExportStatus string
+
+const (
+	ExportStatusPending   ExportStatus = "pending"
+	ExportStatusRunning   ExportStatus = "running"
+	ExportStatusCompleted ExportStatus = "completed"
+	ExportStatusFailed    ExportStatus = "failed"
+)
+
+// String returns the string representation of the export status.
+func (s ExportStatus) String() string {
+	return string(s)
+}
+
+// ExportFormat is the file format of the entity files in an export archive.
+type ExportFormat string
+
+const (
+	// ExportFormatJSONL writes one JSON object per line.
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+// IsValid returns true if the export format is supported.
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatJSONL
+}
+
+// String returns the string representation of the export format.
+func (f ExportFormat) String() string {
+	return string(f)
+}
+
+// ExportFilter selects the backtest results to export. The strategies and
+// optimization iterations that produced them are exported alongside.
+type ExportFilter struct {
+	StrategyID        *uuid.UUID `json:"strategy_id,omitempty"`
+	OptimizationRunID *uuid.UUID `json:"optimization_run_id,omitempty"`
+	MinSharpe         *float64   `json:"min_sharpe,omitempty"`
+	MinProfitPct      *float64   `json:"min_profit_pct,omitempty"`
+	MaxDrawdownPct    *float64   `json:"max_drawdown_pct,omitempty"`
+	MinTrades         *int       `json:"min_trades,omitempty"`
+	TimeRange         *TimeRange `json:"time_range,omitempty"`
+	IncludeSuperseded bool       `json:"include_superseded,omitempty"`
+
+	// IncludeCode keeps strategy source code in the export; it is left out
+	// by default as it dominates the archive size.
+	IncludeCode bool `json:"include_code,omitempty"`
+}
+
+// ResultQuery returns the backtest result query matching the filter.
+func (f ExportFilter) ResultQuery() BacktestResultQuery {
+	return BacktestResultQuery{
+		StrategyID:        f.StrategyID,
+		OptimizationRunID: f.OptimizationRunID,
+		MinSharpe:         f.MinSharpe,
+		MinProfitPct:      f.MinProfitPct,
+		MaxDrawdownPct:    f.MaxDrawdownPct,
+		MinTrades:         f.MinTrades,
+		TimeRange:         f.TimeRange,
+		IncludeSuperseded: f.IncludeSuperseded,
+	}
+}
+
+// ExportJob is an asynchronous bulk export of strategies, backtest results and
+// optimization iterations into a downloadable archive in the artifact store.
+type ExportJob struct {
+	ID        uuid.UUID    `json:"id"`
+	Owner     string       `json:"owner"`
+	Status    ExportStatus `json:"status"`
+	Format    ExportFormat `json:"format"`
+	Filter    ExportFilter `json:"filter"`
+	CreatedAt time.Time    `json:"created_at"`
+
+	// Set once the job completes
+	ArtifactID     *uuid.UUID `json:"artifact_id,omitempty"`
+	StrategyCount  int        `json:"strategy_count"`
+	ResultCount    int        `json:"result_count"`
+	IterationCount int        `json:"iteration_count"`
+	SizeBytes      int64      `json:"size_bytes"`
+
+	Error       *string    `json:"error,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// NewExportJob creates a new pending export job.
+func NewExportJob(owner string, format ExportFormat, filter ExportFilter) *ExportJob {
+	if format == "" {
+		format = ExportFormatJSONL
+	}
+	return &ExportJob{
+		ID:        uuid.New(),
+		Owner:     owner,
+		Status:    ExportStatusPending,
+		Format:    format,
+		Filter:    filter,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Validate checks the export format and filter.
+func (j *ExportJob) Validate() error {
+	if !j.Format.IsValid() {
+		return errors.New("format must be jsonl")
+	}
+	if tr := j.Filter.TimeRange; tr != nil && tr.End.Before(tr.Start) {
+		return errors.New("time_range end must not be before start")
+	}
+	return nil
+}
+
+// ExportArchiveName returns the file name of an export job's archive.
+func ExportArchiveName(id uuid.UUID) string {
+	return "export-" + id.String() + ".tar.gz"
+}
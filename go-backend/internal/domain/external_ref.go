@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+// MaxExternalRefLength is the maximum length of an external reference.
+const MaxExternalRefLength = 255
+
+// externalRefPattern restricts external references to URL-path-safe characters.
+var externalRefPattern = regexp.MustCompile(`^[A-Za-z0-9_.:\-]+$`)
+
+// ValidateExternalRef checks that a submitter-supplied external reference is well-formed.
+// External references are unique per principal and can be used to look entities up
+// without keeping a separate ID mapping on the client side.
+func ValidateExternalRef(ref string) error {
+	if ref == "" {
+		return errors.New("external_ref must not be empty")
+	}
+	if len(ref) > MaxExternalRefLength {
+		return errors.New("external_ref exceeds maximum length of 255 characters")
+	}
+	if !externalRefPattern.MatchString(ref) {
+		return errors.New("external_ref may only contain letters, digits, '_', '.', ':' or '-'")
+	}
+	return nil
+}
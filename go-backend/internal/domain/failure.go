@@ -0,0 +1,124 @@
+package domain
+
+import "time"
+
+// FailureCategory classifies why a backtest job failed. The category is
+// determined when the job fails and decides whether it is retried and what
+// feedback the optimization agents get.
+type FailureCategory string
+
+// Failure categories.
+const (
+	FailureCategoryStrategyImport FailureCategory = "strategy_import_error" // Strategy failed to load: syntax, import or class errors
+	FailureCategoryDataMissing    FailureCategory = "data_missing"          // No candle data for the pairs, timeframe or timerange
+	FailureCategoryTimeout        FailureCategory = "timeout"               // Job exceeded its time limit
+	FailureCategoryOOM            FailureCategory = "oom"                   // Container was killed for running out of memory
+	FailureCategoryDocker         FailureCategory = "docker_error"          // Container could not be started or waited on
+	FailureCategoryParser         FailureCategory = "parser_error"          // Backtest ran but its output could not be parsed
+	FailureCategoryUnknown        FailureCategory = "unknown"               // Anything else, e.g. a strategy crashing at runtime
+)
+
+// FailureCategories lists all failure categories.
+var FailureCategories = []FailureCategory{
+	FailureCategoryStrategyImport,
+	FailureCategoryDataMissing,
+	FailureCategoryTimeout,
+	FailureCategoryOOM,
+	FailureCategoryDocker,
+	FailureCategoryParser,
+	FailureCategoryUnknown,
+}
+
+// FailureCategoryFromString converts a string to a FailureCategory, returning
+// FailureCategoryUnknown for unrecognized values.
+func FailureCategoryFromString(s string) FailureCategory {
+	for _, c := range FailureCategories {
+		if string(c) == s {
+			return c
+		}
+	}
+	return FailureCategoryUnknown
+}
+
+// String returns the string representation of the category.
+func (c FailureCategory) String() string {
+	return string(c)
+}
+
+// Retryable reports whether a job failing this way may succeed when run
+// again: infrastructure failures and timeouts are transient, and missing data
+// may be downloaded on the next attempt. Failures caused by the strategy or
+// its output recur and are not retried.
+func (c FailureCategory) Retryable() bool {
+	switch c {
+	case FailureCategoryDocker, FailureCategoryTimeout, FailureCategoryDataMissing:
+		return true
+	default:
+		return false
+	}
+}
+
+// StrategyFault reports whether the failure is caused by the strategy code,
+// so the optimization agents should fix the code rather than treat the
+// attempt as an infrastructure problem.
+func (c FailureCategory) StrategyFault() bool {
+	switch c {
+	case FailureCategoryStrategyImport, FailureCategoryOOM, FailureCategoryUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// FailureCategoryStats is the failure count of one category.
+type FailureCategoryStats struct {
+	Category FailureCategory `json:"category"`
+	Failures int             `json:"failures"`
+	// FailureRate is the share of finished jobs that failed this way.
+	FailureRate float64 `json:"failure_rate"`
+	// ShareOfFailures is the share of failed jobs that failed this way.
+	ShareOfFailures float64 `json:"share_of_failures"`
+}
+
+// FailureAnalytics summarizes backtest job failures by category over the
+// jobs that finished (completed or failed) in a time range. Failures
+// recorded before categories were introduced count as unknown.
+type FailureAnalytics struct {
+	TimeRange    *TimeRange             `json:"time_range,omitempty"`
+	FinishedJobs int                    `json:"finished_jobs"`
+	FailedJobs   int                    `json:"failed_jobs"`
+	FailureRate  float64                `json:"failure_rate"`
+	Categories   []FailureCategoryStats `json:"categories"`
+	GeneratedAt  time.Time              `json:"generated_at"`
+	Timezone     string                 `json:"timezone,omitempty"` // Server timezone of the timestamps
+}
+
+// NewFailureAnalytics computes the rates from the number of finished jobs and
+// the failure counts by category. Every category is listed, in the order of
+// FailureCategories.
+func NewFailureAnalytics(timeRange *TimeRange, finished int, failures map[FailureCategory]int, now time.Time) *FailureAnalytics {
+	a := &FailureAnalytics{
+		TimeRange:    timeRange,
+		FinishedJobs: finished,
+		Categories:   make([]FailureCategoryStats, 0, len(FailureCategories)),
+		GeneratedAt:  now,
+	}
+	for _, n := range failures {
+		a.FailedJobs += n
+	}
+	if finished > 0 {
+		a.FailureRate = float64(a.FailedJobs) / float64(finished)
+	}
+
+	for _, c := range FailureCategories {
+		stats := FailureCategoryStats{Category: c, Failures: failures[c]}
+		if finished > 0 {
+			stats.FailureRate = float64(stats.Failures) / float64(finished)
+		}
+		if a.FailedJobs > 0 {
+			stats.ShareOfFailures = float64(stats.Failures) / float64(a.FailedJobs)
+		}
+		a.Categories = append(a.Categories, stats)
+	}
+	return a
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Optional capabilities that clients can discover via GET /api/v1/features.
+const (
+	FeatureHyperopt    = "hyperopt"
+	FeatureWalkforward = "walkforward"
+	FeatureArtifacts   = "artifacts"
+	FeatureAuth        = "auth"
+)
+
+// KnownFeatures lists the features every deployment reports, enabled or not.
+var KnownFeatures = []string{FeatureArtifacts, FeatureAuth, FeatureHyperopt, FeatureWalkforward}
+
+// FeatureSource describes where a feature flag's value came from.
+type FeatureSource string
+
+const (
+	FeatureSourceDefault  FeatureSource = "default"  // Known feature not set in config
+	FeatureSourceConfig   FeatureSource = "config"   // Set in go_backend.features.flags
+	FeatureSourceOverride FeatureSource = "override" // Set at runtime, stored in the database
+)
+
+// featureNameRegex restricts feature names to lowercase snake_case.
+var featureNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// IsValidFeatureName returns true if the name is a valid feature name.
+func IsValidFeatureName(name string) bool {
+	return featureNameRegex.MatchString(name)
+}
+
+// FeatureFlagOverride is a runtime override of a feature flag stored in the database.
+type FeatureFlagOverride struct {
+	Name      string    `json:"name"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FeatureFlag is the effective state of a feature on this deployment.
+type FeatureFlag struct {
+	Name      string        `json:"name"`
+	Enabled   bool          `json:"enabled"`
+	Source    FeatureSource `json:"source"`
+	UpdatedBy *string       `json:"updated_by,omitempty"` // Overrides only
+	UpdatedAt *time.Time    `json:"updated_at,omitempty"` // Overrides only
+}
+
+// ResolveFeatureFlags merges the configured flags with runtime overrides.
+// Known features are always included; overrides win over config.
+// The result is sorted by name.
+func ResolveFeatureFlags(configured map[string]bool, overrides []*FeatureFlagOverride) []*FeatureFlag {
+	flags := make(map[string]*FeatureFlag, len(KnownFeatures)+len(configured))
+
+	for _, name := range KnownFeatures {
+		flags[name] = &FeatureFlag{Name: name, Source: FeatureSourceDefault}
+	}
+	for name, enabled := range configured {
+		flags[name] = &FeatureFlag{Name: name, Enabled: enabled, Source: FeatureSourceConfig}
+	}
+	for _, o := range overrides {
+		updatedBy := o.UpdatedBy
+		updatedAt := o.UpdatedAt
+		flags[o.Name] = &FeatureFlag{
+			Name:      o.Name,
+			Enabled:   o.Enabled,
+			Source:    FeatureSourceOverride,
+			UpdatedBy: &updatedBy,
+			UpdatedAt: &updatedAt,
+		}
+	}
+
+	result := make([]*FeatureFlag, 0, len(flags))
+	for _, flag := range flags {
+		result = append(result, flag)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultHyperoptEpochs is the number of epochs a hyperopt job runs when
+	// none is given.
+	DefaultHyperoptEpochs = 100
+
+	// MaxHyperoptEpochs is the most epochs a hyperopt job may run.
+	MaxHyperoptEpochs = 10000
+
+	// DefaultHyperoptLoss is the loss function used when none is given.
+	DefaultHyperoptLoss = "SharpeHyperOptLossDaily"
+)
+
+// HyperoptSpace is a Freqtrade hyperopt search space.
+type HyperoptSpace string
+
+const (
+	HyperoptSpaceBuy        HyperoptSpace = "buy"
+	HyperoptSpaceSell       HyperoptSpace = "sell"
+	HyperoptSpaceROI        HyperoptSpace = "roi"
+	HyperoptSpaceStoploss   HyperoptSpace = "stoploss"
+	HyperoptSpaceTrailing   HyperoptSpace = "trailing"
+	HyperoptSpaceProtection HyperoptSpace = "protection"
+	HyperoptSpaceTrades     HyperoptSpace = "trades"
+	HyperoptSpaceDefault    HyperoptSpace = "default" // buy, sell, roi and stoploss
+	HyperoptSpaceAll        HyperoptSpace = "all"
+)
+
+// IsValid returns true if the space is known to Freqtrade.
+func (s HyperoptSpace) IsValid() bool {
+	switch s {
+	case HyperoptSpaceBuy, HyperoptSpaceSell, HyperoptSpaceROI, HyperoptSpaceStoploss,
+		HyperoptSpaceTrailing, HyperoptSpaceProtection, HyperoptSpaceTrades,
+		HyperoptSpaceDefault, HyperoptSpaceAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// hyperoptLossRegex matches the class name of a hyperopt loss function.
+var hyperoptLossRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,99}$`)
+
+// HyperoptConfig configures a hyperopt job: the backtest each epoch runs and
+// how the parameters are searched.
+type HyperoptConfig struct {
+	Backtest    BacktestConfig  `json:"backtest"`
+	Epochs      int             `json:"epochs"`
+	Spaces      []HyperoptSpace `json:"spaces"`
+	Loss        string          `json:"loss"`                   // Loss function class, e.g. SharpeHyperOptLossDaily
+	MinTrades   int             `json:"min_trades,omitempty"`   // Epochs with fewer trades are rejected
+	RandomState *int            `json:"random_state,omitempty"` // Seed for reproducible runs
+}
+
+// ApplyDefaults fills in the epochs, spaces and loss function when unset.
+func (c *HyperoptConfig) ApplyDefaults() {
+	if c.Epochs == 0 {
+		c.Epochs = DefaultHyperoptEpochs
+	}
+	if len(c.Spaces) == 0 {
+		c.Spaces = []HyperoptSpace{HyperoptSpaceDefault}
+	}
+	if c.Loss == "" {
+		c.Loss = DefaultHyperoptLoss
+	}
+}
+
+// Validate checks the search settings. They end up on a command line, so
+// the loss function must be a plain class name.
+func (c *HyperoptConfig) Validate() error {
+	if c.Epochs < 1 || c.Epochs > MaxHyperoptEpochs {
+		return fmt.Errorf("%w: epochs must be between 1 and %d", ErrInvalidInput, MaxHyperoptEpochs)
+	}
+	for _, space := range c.Spaces {
+		if !space.IsValid() {
+			return fmt.Errorf("%w: unknown hyperopt space %q", ErrInvalidInput, space)
+		}
+	}
+	if !hyperoptLossRegex.MatchString(c.Loss) {
+		return fmt.Errorf("%w: loss must be the class name of a loss function", ErrInvalidInput)
+	}
+	if c.MinTrades < 0 {
+		return fmt.Errorf("%w: min_trades must be non-negative", ErrInvalidInput)
+	}
+	if c.RandomState != nil && *c.RandomState < 0 {
+		return fmt.Errorf("%w: random_state must be non-negative", ErrInvalidInput)
+	}
+	return nil
+}
+
+// HyperoptParamSet holds the best parameter values of a hyperopt run by
+// space, as Freqtrade exports them, e.g.
+// {"buy": {"buy_rsi": 25}, "roi": {"0": 0.1}, "stoploss": {"stoploss": -0.1}}.
+type HyperoptParamSet map[string]map[string]interface{}
+
+// Patch returns the parameter values as a patch to write back into the
+// strategy's code: the ROI table, stoploss, trailing stop and the defaults
+// of the hyperopt parameters. Values of spaces without a place in the code,
+// such as trades, are left out.
+func (p HyperoptParamSet) Patch() *StrategyParamsPatch {
+	patch := &StrategyParamsPatch{}
+
+	for space, values := range p {
+		switch HyperoptSpace(space) {
+		case HyperoptSpaceROI:
+			for key, value := range values {
+				minutes, err := strconv.Atoi(key)
+				roi, ok := value.(float64)
+				if err != nil || !ok {
+					continue
+				}
+				patch.MinimalROI = append(patch.MinimalROI, ROIStep{Minutes: minutes, ROI: roi})
+			}
+			sort.Slice(patch.MinimalROI, func(i, j int) bool {
+				return patch.MinimalROI[i].Minutes < patch.MinimalROI[j].Minutes
+			})
+		case HyperoptSpaceStoploss:
+			if v, ok := values["stoploss"].(float64); ok {
+				patch.Stoploss = &v
+			}
+		case HyperoptSpaceTrailing:
+			if v, ok := values["trailing_stop"].(bool); ok {
+				patch.TrailingStop = &v
+			}
+			if v, ok := values["trailing_stop_positive"].(float64); ok {
+				patch.TrailingStopPositive = &v
+			}
+			if v, ok := values["trailing_stop_positive_offset"].(float64); ok {
+				patch.TrailingStopPositiveOffset = &v
+			}
+			if v, ok := values["trailing_only_offset_is_reached"].(bool); ok {
+				patch.TrailingOnlyOffsetIsReached = &v
+			}
+		case HyperoptSpaceTrades:
+			// max_open_trades is set in the config, not the code
+		default:
+			// buy, sell, protection and custom spaces hold *Parameter values
+			for name, value := range values {
+				if patch.HyperoptDefaults == nil {
+					patch.HyperoptDefaults = make(map[string]interface{})
+				}
+				patch.HyperoptDefaults[name] = value
+			}
+		}
+	}
+
+	return patch
+}
+
+// HyperoptResult is the best epoch of a finished hyperopt job.
+type HyperoptResult struct {
+	BestEpoch   int              `json:"best_epoch"`
+	TotalEpochs int              `json:"total_epochs"`
+	Loss        float64          `json:"loss"` // Lower is better
+	Params      HyperoptParamSet `json:"params"`
+
+	// Backtest metrics of the best epoch
+	TotalTrades             int      `json:"total_trades"`
+	WinningTrades           int      `json:"winning_trades"`
+	LosingTrades            int      `json:"losing_trades"`
+	ProfitTotal             float64  `json:"profit_total"`
+	ProfitPct               float64  `json:"profit_pct"`
+	MaxDrawdownPct          float64  `json:"max_drawdown_pct"`
+	AvgTradeDurationMinutes *float64 `json:"avg_trade_duration_minutes,omitempty"`
+
+	// ParamsPatch is Params as a patch for POST /api/v1/strategies/:id/params,
+	// which writes them into a child of the strategy.
+	ParamsPatch *StrategyParamsPatch `json:"params_patch,omitempty"`
+}
+
+// HyperoptJob runs Freqtrade's hyperopt on a strategy, searching its
+// hyperoptable parameters for the set that minimizes the loss function. The
+// best set found is attached to the job's result.
+type HyperoptJob struct {
+	ID          uuid.UUID       `json:"id"`
+	StrategyID  uuid.UUID       `json:"strategy_id"`
+	Owner       string          `json:"owner"`
+	Config      HyperoptConfig  `json:"config"`
+	Status      JobStatus       `json:"status"`
+	ContainerID *string         `json:"container_id,omitempty"`
+	Result      *HyperoptResult `json:"result,omitempty"` // Set once the job completes
+	Error       *string         `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// NewHyperoptJob creates a new pending hyperopt job, filling in the config's
+// defaults.
+func NewHyperoptJob(strategyID uuid.UUID, owner string, config HyperoptConfig) *HyperoptJob {
+	config.ApplyDefaults()
+	return &HyperoptJob{
+		ID:         uuid.New(),
+		StrategyID: strategyID,
+		Owner:      owner,
+		Config:     config,
+		Status:     JobStatusPending,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// HyperoptJobQuery filters a listing of hyperopt jobs.
+type HyperoptJobQuery struct {
+	StrategyID *uuid.UUID
+	Status     *JobStatus
+	Limit      int
+}
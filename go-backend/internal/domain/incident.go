@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxIncidentTitleLength is the longest title an incident may have.
+	MaxIncidentTitleLength = 200
+
+	// MaxIncidentNoteLength is the longest note that may be added to an
+	// incident.
+	MaxIncidentNoteLength = 5000
+)
+
+// IncidentSeverity is how badly an incident affects the backend.
+type IncidentSeverity string
+
+const (
+	IncidentSeverityMinor    IncidentSeverity = "minor"
+	IncidentSeverityMajor    IncidentSeverity = "major"
+	IncidentSeverityCritical IncidentSeverity = "critical"
+)
+
+// IsValid reports whether the severity is known.
+func (s IncidentSeverity) IsValid() bool {
+	switch s {
+	case IncidentSeverityMinor, IncidentSeverityMajor, IncidentSeverityCritical:
+		return true
+	}
+	return false
+}
+
+// IncidentStatus is whether an incident is still ongoing.
+type IncidentStatus string
+
+const (
+	IncidentStatusOpen   IncidentStatus = "open"
+	IncidentStatusClosed IncidentStatus = "closed"
+)
+
+// IsValid reports whether the status is known.
+func (s IncidentStatus) IsValid() bool {
+	return s == IncidentStatusOpen || s == IncidentStatusClosed
+}
+
+// Incident sources: opened by an operator through the API, or by the
+// dependency watchdog when a dependency goes down.
+const (
+	IncidentSourceManual   = "manual"
+	IncidentSourceWatchdog = "watchdog"
+)
+
+// Incident records something going wrong with the backend, with the notes
+// on-call added while handling it, so there is one place to see what is
+// going on. Open incidents are reported by /health and the dashboard.
+type Incident struct {
+	ID        uuid.UUID        `json:"id"`
+	Title     string           `json:"title"`
+	Severity  IncidentSeverity `json:"severity"`
+	Status    IncidentStatus   `json:"status"`
+	Source    string           `json:"source"` // IncidentSourceManual or IncidentSourceWatchdog
+	OpenedBy  string           `json:"opened_by"`
+	ClosedBy  *string          `json:"closed_by,omitempty"`
+	Notes     []IncidentNote   `json:"notes"` // Oldest first
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	ClosedAt  *time.Time       `json:"closed_at,omitempty"`
+}
+
+// IncidentNote is a timestamped note on an incident.
+type IncidentNote struct {
+	ID         uuid.UUID `json:"id"`
+	IncidentID uuid.UUID `json:"incident_id"`
+	Author     string    `json:"author"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewIncident creates a new open incident.
+func NewIncident(title string, severity IncidentSeverity, source, openedBy string) *Incident {
+	now := time.Now()
+	return &Incident{
+		ID:        uuid.New(),
+		Title:     strings.TrimSpace(title),
+		Severity:  severity,
+		Status:    IncidentStatusOpen,
+		Source:    source,
+		OpenedBy:  openedBy,
+		Notes:     []IncidentNote{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks the incident's title and severity.
+func (i *Incident) Validate() error {
+	if err := validateIncidentTitle(i.Title); err != nil {
+		return err
+	}
+	if !i.Severity.IsValid() {
+		return fmt.Errorf("%w: severity must be minor, major or critical", ErrInvalidInput)
+	}
+	return nil
+}
+
+// NewIncidentNote creates a note on an incident.
+func NewIncidentNote(incidentID uuid.UUID, author, body string) *IncidentNote {
+	return &IncidentNote{
+		ID:         uuid.New(),
+		IncidentID: incidentID,
+		Author:     author,
+		Body:       strings.TrimSpace(body),
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Validate checks the note's body.
+func (n *IncidentNote) Validate() error {
+	if n.Body == "" {
+		return fmt.Errorf("%w: note is required", ErrInvalidInput)
+	}
+	if len(n.Body) > MaxIncidentNoteLength {
+		return fmt.Errorf("%w: note must be at most %d characters", ErrInvalidInput, MaxIncidentNoteLength)
+	}
+	return nil
+}
+
+// IncidentUpdate changes the title or severity of an incident. Unset fields
+// are left unchanged.
+type IncidentUpdate struct {
+	Title    *string           `json:"title,omitempty"`
+	Severity *IncidentSeverity `json:"severity,omitempty"`
+}
+
+// Validate checks the fields being changed.
+func (u *IncidentUpdate) Validate() error {
+	if u.Title == nil && u.Severity == nil {
+		return fmt.Errorf("%w: nothing to update", ErrInvalidInput)
+	}
+	if u.Title != nil {
+		title := strings.TrimSpace(*u.Title)
+		if err := validateIncidentTitle(title); err != nil {
+			return err
+		}
+		u.Title = &title
+	}
+	if u.Severity != nil && !u.Severity.IsValid() {
+		return fmt.Errorf("%w: severity must be minor, major or critical", ErrInvalidInput)
+	}
+	return nil
+}
+
+// IncidentListQuery filters a listing of incidents.
+type IncidentListQuery struct {
+	Status *IncidentStatus
+	Source string // Empty for every source
+	Limit  int
+}
+
+func validateIncidentTitle(title string) error {
+	if title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidInput)
+	}
+	if len(title) > MaxIncidentTitleLength {
+		return fmt.Errorf("%w: title must be at most %d characters", ErrInvalidInput, MaxIncidentTitleLength)
+	}
+	return nil
+}
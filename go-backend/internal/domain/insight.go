@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InsightParamType is the type a query parameter of an insight is bound as.
+type InsightParamType string
+
+const (
+	InsightParamString InsightParamType = "string"
+	InsightParamInt    InsightParamType = "int"
+	InsightParamFloat  InsightParamType = "float"
+	InsightParamBool   InsightParamType = "bool"
+	InsightParamTime   InsightParamType = "time" // RFC3339 with an offset
+	InsightParamUUID   InsightParamType = "uuid"
+)
+
+// IsValid checks if the parameter type is known.
+func (t InsightParamType) IsValid() bool {
+	switch t {
+	case InsightParamString, InsightParamInt, InsightParamFloat, InsightParamBool, InsightParamTime, InsightParamUUID:
+		return true
+	}
+	return false
+}
+
+// Insight template sources.
+const (
+	InsightSourceConfig   = "config"
+	InsightSourceDatabase = "database"
+)
+
+// insightNameRegex restricts insight names, which appear in the URL.
+var insightNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,99}$`)
+
+// insightPlaceholderRegex matches positional SQL placeholders such as $1.
+var insightPlaceholderRegex = regexp.MustCompile(`\$(\d+)`)
+
+// InsightParam is a query parameter of an insight, bound to the placeholder
+// of its position: the first parameter is $1.
+type InsightParam struct {
+	Name        string           `json:"name"`
+	Type        InsightParamType `json:"type"`
+	Required    bool             `json:"required,omitempty"`
+	Default     string           `json:"default,omitempty"` // Used when the parameter is not given
+	Description string           `json:"description,omitempty"`
+}
+
+// InsightTemplate is a pre-approved, parameterized SQL query that analysts run
+// by name. Templates are defined in config or in the insight_templates table
+// and always run read-only with row and time limits.
+type InsightTemplate struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	SQL         string         `json:"sql"`
+	Params      []InsightParam `json:"params"`
+	MaxRows     int            `json:"max_rows,omitempty"`   // 0 uses the configured limit
+	TimeoutMs   int64          `json:"timeout_ms,omitempty"` // 0 uses the configured limit
+	Source      string         `json:"source"`               // "config" or "database"
+	ApprovedBy  string         `json:"approved_by,omitempty"`
+}
+
+// Validate checks that a template is a single SELECT statement whose
+// placeholders all have a parameter.
+func (t *InsightTemplate) Validate() error {
+	if !insightNameRegex.MatchString(t.Name) {
+		return fmt.Errorf("%w: insight name must be 1-100 lowercase letters, digits, '_' or '-'", ErrInvalidInput)
+	}
+
+	sql := strings.TrimSpace(t.SQL)
+	sql = strings.TrimSpace(strings.TrimSuffix(sql, ";"))
+	if sql == "" {
+		return fmt.Errorf("%w: insight %s has no SQL", ErrInvalidInput, t.Name)
+	}
+	if strings.Contains(sql, ";") {
+		return fmt.Errorf("%w: insight %s must be a single statement", ErrInvalidInput, t.Name)
+	}
+	head := strings.ToUpper(strings.Fields(sql)[0])
+	if head != "SELECT" && head != "WITH" {
+		return fmt.Errorf("%w: insight %s must be a SELECT query", ErrInvalidInput, t.Name)
+	}
+	t.SQL = sql
+
+	seen := make(map[string]bool, len(t.Params))
+	for _, p := range t.Params {
+		if p.Name == "" || p.Name == "limit" || seen[p.Name] {
+			return fmt.Errorf("%w: insight %s has a missing, reserved or duplicate parameter name %q", ErrInvalidInput, t.Name, p.Name)
+		}
+		seen[p.Name] = true
+		if !p.Type.IsValid() {
+			return fmt.Errorf("%w: insight %s parameter %s has unknown type %q", ErrInvalidInput, t.Name, p.Name, p.Type)
+		}
+		if p.Default != "" {
+			if _, err := p.Bind(p.Default); err != nil {
+				return fmt.Errorf("%w: insight %s parameter %s has an invalid default", ErrInvalidInput, t.Name, p.Name)
+			}
+		}
+	}
+	for _, m := range insightPlaceholderRegex.FindAllStringSubmatch(sql, -1) {
+		if n, _ := strconv.Atoi(m[1]); n < 1 || n > len(t.Params) {
+			return fmt.Errorf("%w: insight %s uses $%s but has %d parameters", ErrInvalidInput, t.Name, m[1], len(t.Params))
+		}
+	}
+
+	if t.MaxRows < 0 || t.TimeoutMs < 0 {
+		return fmt.Errorf("%w: insight %s limits must not be negative", ErrInvalidInput, t.Name)
+	}
+	return nil
+}
+
+// Bind converts a parameter value to the parameter's type.
+func (p InsightParam) Bind(value string) (interface{}, error) {
+	var (
+		v   interface{}
+		err error
+	)
+	switch p.Type {
+	case InsightParamString:
+		v = value
+	case InsightParamInt:
+		v, err = strconv.ParseInt(value, 10, 64)
+	case InsightParamFloat:
+		v, err = strconv.ParseFloat(value, 64)
+	case InsightParamBool:
+		v, err = strconv.ParseBool(value)
+	case InsightParamTime:
+		v, err = time.Parse(time.RFC3339, value)
+	case InsightParamUUID:
+		v, err = uuid.Parse(value)
+	default:
+		err = fmt.Errorf("unknown type %q", p.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: parameter %s must be a %s", ErrInvalidInput, p.Name, p.Type)
+	}
+	return v, nil
+}
+
+// InsightResult is the outcome of running an insight.
+type InsightResult struct {
+	Name       string          `json:"name"`
+	Columns    []string        `json:"columns"`
+	Rows       [][]interface{} `json:"rows"`
+	RowCount   int             `json:"row_count"`
+	Truncated  bool            `json:"truncated"` // More rows matched than the row limit
+	MaxRows    int             `json:"max_rows"`
+	DurationMs int64           `json:"duration_ms"`
+	ExecutedAt time.Time       `json:"executed_at"`
+}
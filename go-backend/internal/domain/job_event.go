@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobEventType identifies a backtest job state transition.
+type JobEventType string
+
+const (
+	// JobEventQueued is recorded when the job is created.
+	JobEventQueued JobEventType = "queued"
+	// JobEventDispatched is recorded when the scheduler hands the job to a worker.
+	JobEventDispatched JobEventType = "dispatched"
+	// JobEventContainerStarted is recorded once the backtest container is running.
+	JobEventContainerStarted JobEventType = "container_started"
+	// JobEventRetried is recorded when a failed attempt is retried.
+	JobEventRetried JobEventType = "retried"
+	// JobEventCompleted is recorded when the job finishes successfully.
+	JobEventCompleted JobEventType = "completed"
+	// JobEventFailed is recorded when the job fails or times out.
+	JobEventFailed JobEventType = "failed"
+	// JobEventCancelled is recorded when the job is cancelled.
+	JobEventCancelled JobEventType = "cancelled"
+	// JobEventRequeued is recorded when a restarted scheduler returns a job it
+	// had claimed to the queue.
+	JobEventRequeued JobEventType = "requeued"
+	// JobEventResumed is recorded when a restarted scheduler takes over a job
+	// whose container outlived the previous one.
+	JobEventResumed JobEventType = "resumed"
+	// JobEventDataDownloaded is recorded when market data the job lacked is
+	// downloaded before its container starts.
+	JobEventDataDownloaded JobEventType = "data_downloaded"
+)
+
+// JobEvent is one entry of a backtest job's timeline.
+type JobEvent struct {
+	ID          int64        `json:"id"`
+	JobID       uuid.UUID    `json:"job_id"`
+	Type        JobEventType `json:"type"`
+	Status      JobStatus    `json:"status"` // Job status after the transition
+	ContainerID *string      `json:"container_id,omitempty"`
+	Worker      *string      `json:"worker,omitempty"`
+	Host        *string      `json:"host,omitempty"`
+	Detail      *string      `json:"detail,omitempty"`
+	OccurredAt  time.Time    `json:"occurred_at"`
+}
+
+// JobTimeline is a job's events along with the time spent in each phase.
+// A phase is nil until both of its bounding events have been recorded.
+type JobTimeline struct {
+	JobID            uuid.UUID   `json:"job_id"`
+	Events           []*JobEvent `json:"events"`
+	QueueWaitMs      *int64      `json:"queue_wait_ms,omitempty"`      // queued -> dispatched
+	ContainerStartMs *int64      `json:"container_start_ms,omitempty"` // dispatched -> container_started
+	RunMs            *int64      `json:"run_ms,omitempty"`             // container_started -> terminal
+	TotalMs          *int64      `json:"total_ms,omitempty"`           // queued -> terminal
+}
+
+// NewJobTimeline builds a timeline from events ordered by occurrence.
+// Phases are measured from the first occurrence of each event, so time lost
+// to retries is counted in the run phase.
+func NewJobTimeline(jobID uuid.UUID, events []*JobEvent) *JobTimeline {
+	timeline := &JobTimeline{JobID: jobID, Events: events}
+	if timeline.Events == nil {
+		timeline.Events = []*JobEvent{}
+	}
+
+	first := make(map[JobEventType]time.Time)
+	var terminal *time.Time
+	for _, e := range events {
+		if _, ok := first[e.Type]; !ok {
+			first[e.Type] = e.OccurredAt
+		}
+		switch e.Type {
+		case JobEventCompleted, JobEventFailed, JobEventCancelled:
+			if terminal == nil {
+				t := e.OccurredAt
+				terminal = &t
+			}
+		}
+	}
+
+	between := func(from time.Time, ok bool, to *time.Time) *int64 {
+		if !ok || to == nil {
+			return nil
+		}
+		ms := to.Sub(from).Milliseconds()
+		return &ms
+	}
+
+	queued, hasQueued := first[JobEventQueued]
+	dispatched, hasDispatched := first[JobEventDispatched]
+	started, hasStarted := first[JobEventContainerStarted]
+
+	if hasDispatched {
+		timeline.QueueWaitMs = between(queued, hasQueued, &dispatched)
+	}
+	if hasStarted {
+		timeline.ContainerStartMs = between(dispatched, hasDispatched, &started)
+	}
+	timeline.RunMs = between(started, hasStarted, terminal)
+	timeline.TotalMs = between(queued, hasQueued, terminal)
+
+	return timeline
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxJobHints is the most entries a job may list per hint.
+const MaxJobHints = 16
+
+// AntiAffinityOptimization is an anti-affinity key that stands for the job's
+// optimization run, so jobs of the same run are spread across hosts.
+const AntiAffinityOptimization = "optimization"
+
+var (
+	// dataSetRegex matches "exchange/timeframe", e.g. "binance/5m".
+	dataSetRegex = regexp.MustCompile(`^[a-z0-9_-]+/[0-9]+[smhdwM]$`)
+
+	// affinityKeyRegex restricts anti-affinity keys, e.g. "optimization:<id>".
+	affinityKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_.:/-]{1,128}$`)
+)
+
+// JobHints are placement hints for a backtest job. The scheduler honors them
+// best-effort: a job is never held back for long because of them.
+type JobHints struct {
+	// PreferCachedData asks for a host that already has market data for
+	// these data sets, given as "exchange/timeframe", e.g. "binance/5m".
+	PreferCachedData []string `json:"prefer_cached_data,omitempty"`
+
+	// AntiAffinity lists keys of which no two jobs run on the same host at
+	// the same time. "optimization" stands for the job's optimization run.
+	AntiAffinity []string `json:"anti_affinity,omitempty"`
+}
+
+// Validate checks the data sets and keys of the hints.
+func (h *JobHints) Validate() error {
+	if len(h.PreferCachedData) > MaxJobHints || len(h.AntiAffinity) > MaxJobHints {
+		return fmt.Errorf("%w: at most %d entries per hint", ErrInvalidInput, MaxJobHints)
+	}
+	for _, ds := range h.PreferCachedData {
+		if !dataSetRegex.MatchString(ds) {
+			return fmt.Errorf("%w: data set %q must be exchange/timeframe, e.g. binance/5m", ErrInvalidInput, ds)
+		}
+	}
+	for _, key := range h.AntiAffinity {
+		if !affinityKeyRegex.MatchString(key) {
+			return fmt.Errorf("%w: anti-affinity key %q must be 1-128 letters, digits or '_', '.', ':', '/', '-'", ErrInvalidInput, key)
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether no hint is set.
+func (h *JobHints) IsZero() bool {
+	return h == nil || (len(h.PreferCachedData) == 0 && len(h.AntiAffinity) == 0)
+}
+
+// AntiAffinityKeys returns the job's anti-affinity keys, with "optimization"
+// resolved to its run. The key is dropped for jobs outside a run.
+func (j *BacktestJob) AntiAffinityKeys() []string {
+	if j.Hints == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(j.Hints.AntiAffinity))
+	for _, key := range j.Hints.AntiAffinity {
+		if key == AntiAffinityOptimization {
+			if j.OptimizationRunID == nil {
+				continue
+			}
+			key = AntiAffinityOptimization + ":" + j.OptimizationRunID.String()
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// ParseDataSet splits an "exchange/timeframe" data set.
+func ParseDataSet(ds string) (exchange, timeframe string, ok bool) {
+	return strings.Cut(ds, "/")
+}
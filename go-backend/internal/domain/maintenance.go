@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultMaintenanceMessage is shown to clients whose submissions are
+	// rejected when maintenance mode was enabled without a message.
+	DefaultMaintenanceMessage = "FreqSearch is down for maintenance; new submissions are not accepted"
+
+	// MaxMaintenanceMessageLength is the longest message maintenance mode may
+	// be enabled with.
+	MaxMaintenanceMessageLength = 1000
+
+	// MaintenanceActor is who pauses and resumes schedulers for maintenance
+	// mode, as recorded on their status.
+	MaintenanceActor = "maintenance"
+)
+
+// ErrMaintenance is returned for submissions rejected in maintenance mode.
+var ErrMaintenance = errors.New("maintenance mode")
+
+// MaintenanceMode is the system-wide maintenance flag. While it is enabled,
+// every backend instance rejects new submissions and pauses its schedulers;
+// reads keep working, so planned database maintenance doesn't require
+// stopping the service.
+type MaintenanceMode struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message,omitempty"`
+	ChangedBy string     `json:"changed_by,omitempty"` // Who last enabled or disabled it
+	Since     *time.Time `json:"since,omitempty"`      // When it was enabled; unset while disabled
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ValidateMaintenanceMessage checks the message maintenance mode is enabled with.
+func ValidateMaintenanceMessage(message string) error {
+	if len(message) > MaxMaintenanceMessageLength {
+		return fmt.Errorf("%w: message must be at most %d characters", ErrInvalidInput, MaxMaintenanceMessageLength)
+	}
+	return nil
+}
+
+// RejectionMessage returns the message for rejected submissions.
+func (m *MaintenanceMode) RejectionMessage() string {
+	if m.Message == "" {
+		return DefaultMaintenanceMessage
+	}
+	return m.Message
+}
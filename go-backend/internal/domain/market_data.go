@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trading modes of market data.
+const (
+	TradingModeSpot    = "spot"
+	TradingModeFutures = "futures"
+)
+
+// MarketDataSet is one file of candle data in the market data directory.
+type MarketDataSet struct {
+	Exchange    string    `json:"exchange"`
+	Pair        string    `json:"pair"` // e.g. "BTC/USDT", or "BTC/USDT:USDT" for futures
+	Timeframe   string    `json:"timeframe"`
+	TradingMode string    `json:"trading_mode"`
+	CandleType  string    `json:"candle_type"` // "spot", "futures", "mark", "index" or "funding_rate"
+	Format      string    `json:"format"`      // File extension, e.g. "feather" or "json"
+	SizeBytes   int64     `json:"size_bytes"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MarketDataFilter narrows a market data listing; empty fields match all.
+type MarketDataFilter struct {
+	Exchange    string
+	Pair        string
+	Timeframe   string
+	TradingMode string
+}
+
+// Matches reports whether a data set passes the filter.
+func (f MarketDataFilter) Matches(ds MarketDataSet) bool {
+	return (f.Exchange == "" || f.Exchange == ds.Exchange) &&
+		(f.Pair == "" || f.Pair == ds.Pair) &&
+		(f.Timeframe == "" || f.Timeframe == ds.Timeframe) &&
+		(f.TradingMode == "" || f.TradingMode == ds.TradingMode)
+}
+
+// MissingMarketData lists the pairs a backtest lacks candle data for.
+type MissingMarketData struct {
+	Exchange    string   `json:"exchange"`
+	TradingMode string   `json:"trading_mode"`
+	Timeframe   string   `json:"timeframe"`
+	Pairs       []string `json:"pairs"`
+}
+
+// String describes the missing data, e.g. "no binance futures 5m candles
+// for BTC/USDT:USDT, ETH/USDT:USDT".
+func (m *MissingMarketData) String() string {
+	return fmt.Sprintf("no %s %s %s candles for %s", m.Exchange, m.TradingMode, m.Timeframe, strings.Join(m.Pairs, ", "))
+}
+
+// DownloadRequest returns the request downloading the missing data over
+// a backtest's timerange.
+func (m *MissingMarketData) DownloadRequest(cfg BacktestConfig) DataDownloadRequest {
+	return DataDownloadRequest{
+		Exchange:       m.Exchange,
+		Pairs:          m.Pairs,
+		Timeframes:     []string{m.Timeframe},
+		TradingMode:    m.TradingMode,
+		TimerangeStart: cfg.TimerangeStart,
+		TimerangeEnd:   cfg.TimerangeEnd,
+	}
+}
+
+// DataDownloadStatus is the state of a market data download.
+type DataDownloadStatus string
+
+const (
+	DataDownloadRunning   DataDownloadStatus = "running"
+	DataDownloadCompleted DataDownloadStatus = "completed"
+	DataDownloadFailed    DataDownloadStatus = "failed"
+)
+
+// DataDownloadRequest selects the market data a download fetches. Fields
+// left empty fall back to the base Freqtrade config, as for backtests.
+type DataDownloadRequest struct {
+	Exchange       string   `json:"exchange,omitempty"`
+	Pairs          []string `json:"pairs,omitempty"`
+	Timeframes     []string `json:"timeframes,omitempty"`
+	TradingMode    string   `json:"trading_mode,omitempty"`    // "spot" or "futures"; empty is futures
+	TimerangeStart string   `json:"timerange_start,omitempty"` // YYYY-MM-DD or YYYYMMDD
+	TimerangeEnd   string   `json:"timerange_end,omitempty"`   // Empty downloads up to now
+}
+
+// Validate checks the trading mode and timerange of a download request.
+func (r *DataDownloadRequest) Validate() error {
+	if r.TradingMode != "" && r.TradingMode != TradingModeSpot && r.TradingMode != TradingModeFutures {
+		return fmt.Errorf("%w: trading_mode must be spot or futures", ErrInvalidInput)
+	}
+	if r.TimerangeEnd != "" && r.TimerangeStart == "" {
+		return fmt.Errorf("%w: timerange_end requires timerange_start", ErrInvalidInput)
+	}
+	return nil
+}
+
+// GetTradingMode returns the trading mode, defaulting to "futures" as
+// backtests do.
+func (r *DataDownloadRequest) GetTradingMode() string {
+	if r.TradingMode == "" {
+		return TradingModeFutures
+	}
+	return r.TradingMode
+}
+
+// DataDownload is a Freqtrade download-data container run, started over the
+// API or by the scheduler for a backtest lacking data.
+type DataDownload struct {
+	ID          uuid.UUID           `json:"id"`
+	Request     DataDownloadRequest `json:"request"`
+	Status      DataDownloadStatus  `json:"status"`
+	RequestedBy string              `json:"requested_by"` // "api", or the backtest job it was started for
+	ContainerID string              `json:"container_id,omitempty"`
+	Error       string              `json:"error,omitempty"` // Tail of the container output for failed downloads
+	CreatedAt   time.Time           `json:"created_at"`
+	FinishedAt  *time.Time          `json:"finished_at,omitempty"`
+}
+
+// IsFinished reports whether the download has completed or failed.
+func (d *DataDownload) IsFinished() bool {
+	return d.Status != DataDownloadRunning
+}
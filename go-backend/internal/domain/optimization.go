@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +18,10 @@ type OptimizationRun struct {
 	Mode           OptimizationMode   `json:"mode"`
 	Criteria       OptimizationCriteria `json:"criteria"`
 
+	// SeedStrategyIDs is the initial population the run evolves from, e.g.
+	// several promising parents for crossover. BaseStrategyID is its first member.
+	SeedStrategyIDs []uuid.UUID `json:"seed_strategy_ids"`
+
 	Status           OptimizationStatus `json:"status"`
 	CurrentIteration int                `json:"current_iteration"`
 	MaxIterations    int                `json:"max_iterations"`
@@ -23,9 +30,38 @@ type OptimizationRun struct {
 	BestResultID      *uuid.UUID `json:"best_result_id,omitempty"`
 	TerminationReason string     `json:"termination_reason,omitempty"`
 
+	// PauseReason is set while the run is paused; Incidents lists the outages
+	// during which the backend paused the run.
+	PauseReason *PauseReason           `json:"pause_reason,omitempty"`
+	Incidents   []OptimizationIncident `json:"incidents,omitempty"`
+
+	// StalledAt is set while the run is stalled.
+	StalledAt *time.Time `json:"stalled_at,omitempty"`
+
+	// DataSnapshot is the data environment captured when the run started,
+	// which all of its backtest jobs are pinned to. Nil for runs started
+	// before snapshots were introduced.
+	DataSnapshot *OptimizationDataSnapshot `json:"data_snapshot,omitempty"`
+
+	// CancelReason and CancelledBy record why, and by whom, the run was
+	// cancelled.
+	CancelReason *string `json:"cancel_reason,omitempty"`
+	CancelledBy  *string `json:"cancelled_by,omitempty"`
+
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// ExternalRef is a submitter-supplied ID, unique per ExternalRefOwner.
+	ExternalRef      *string `json:"external_ref,omitempty"`
+	ExternalRefOwner *string `json:"external_ref_owner,omitempty"`
+}
+
+// OptimizationIncident is an infrastructure outage window during which a run was auto-paused.
+type OptimizationIncident struct {
+	Dependencies []string   `json:"dependencies"` // Unhealthy dependencies, e.g. "rabbitmq", "docker"
+	PausedAt     time.Time  `json:"paused_at"`
+	ResumedAt    *time.Time `json:"resumed_at,omitempty"`
 }
 
 // NewOptimizationRun creates a new OptimizationRun with generated UUID.
@@ -35,17 +71,73 @@ func NewOptimizationRun(name string, baseStrategyID uuid.UUID, config Optimizati
 		ID:               uuid.New(),
 		Name:             name,
 		BaseStrategyID:   baseStrategyID,
+		SeedStrategyIDs:  []uuid.UUID{baseStrategyID},
 		Config:           config,
 		Mode:             config.Mode,
 		Criteria:         config.Criteria,
 		Status:           OptimizationStatusPending,
 		CurrentIteration: 0,
 		MaxIterations:    config.MaxIterations,
+		DataSnapshot:     NewOptimizationDataSnapshot(config.BacktestConfig, now),
 		CreatedAt:        now,
 		UpdatedAt:        now,
 	}
 }
 
+// MaxSeedStrategies is the maximum number of seed strategies of a run.
+const MaxSeedStrategies = 16
+
+// SetSeeds sets the seed strategies of the run, dropping duplicates. The first
+// seed becomes the base strategy.
+func (r *OptimizationRun) SetSeeds(seeds []uuid.UUID) error {
+	unique := make([]uuid.UUID, 0, len(seeds))
+	seen := make(map[uuid.UUID]bool, len(seeds))
+	for _, id := range seeds {
+		if id == uuid.Nil {
+			return errors.New("seed strategy id must not be empty")
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if len(unique) == 0 {
+		return errors.New("at least one seed strategy is required")
+	}
+	if len(unique) > MaxSeedStrategies {
+		return fmt.Errorf("at most %d seed strategies are allowed", MaxSeedStrategies)
+	}
+
+	r.BaseStrategyID = unique[0]
+	r.SeedStrategyIDs = unique
+	return nil
+}
+
+// ParseSeedStrategyIDs parses the seed strategies of a start request: the base
+// strategy, if given, followed by the additional seeds.
+func ParseSeedStrategyIDs(base string, seeds []string) ([]uuid.UUID, error) {
+	if base != "" {
+		seeds = append([]string{base}, seeds...)
+	}
+
+	ids := make([]uuid.UUID, 0, len(seeds))
+	for _, s := range seeds {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid seed strategy id %q: %w", s, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// SetExternalRef sets the external reference and the principal that owns it.
+func (r *OptimizationRun) SetExternalRef(owner, ref string) {
+	r.ExternalRef = &ref
+	r.ExternalRefOwner = &owner
+}
+
 // Duration returns the duration of the optimization run.
 func (r *OptimizationRun) Duration() time.Duration {
 	end := time.Now()
@@ -66,6 +158,8 @@ type OptimizationConfig struct {
 	MaxIterations  int                  `json:"max_iterations"`
 	Criteria       OptimizationCriteria `json:"criteria"`
 	Mode           OptimizationMode     `json:"mode"`
+	SnapshotCode   bool                 `json:"snapshot_code,omitempty"` // Store each iteration's strategy code, not just its hash
+	Quota          *OptimizationQuota   `json:"quota,omitempty"`         // Limits on the run's backtests; nil is unlimited
 }
 
 // OptimizationCriteria represents the success criteria for optimization.
@@ -125,6 +219,17 @@ type OptimizationIteration struct {
 	AnalystFeedback   string         `json:"analyst_feedback,omitempty"`
 	Approval          ApprovalStatus `json:"approval"`
 	CreatedAt         time.Time      `json:"created_at"`
+
+	// CodeHash pins the strategy code the iteration ran, since the strategy
+	// may be edited later. CodeSnapshot holds the code itself when the run
+	// has SnapshotCode enabled.
+	CodeHash     string  `json:"code_hash"`
+	CodeSnapshot *string `json:"code_snapshot,omitempty"`
+
+	// Artifacts are the files the orchestrator stored for the iteration,
+	// such as the LLM prompt and raw response behind it, without content.
+	// Only set by the iteration endpoints.
+	Artifacts []*Artifact `json:"artifacts,omitempty"`
 }
 
 // NewOptimizationIteration creates a new OptimizationIteration.
@@ -145,30 +250,45 @@ func NewOptimizationIteration(
 	}
 }
 
+// MaxRetryFeedbackLength is the maximum length of operator feedback attached to an iteration retry.
+const MaxRetryFeedbackLength = 4000
+
+// Reopen appends operator feedback to the iteration and marks it for regeneration.
+func (i *OptimizationIteration) Reopen(feedback string) error {
+	feedback = strings.TrimSpace(feedback)
+	if feedback == "" {
+		return errors.New("feedback is required")
+	}
+	if len(feedback) > MaxRetryFeedbackLength {
+		return errors.New("feedback must be at most 4000 characters")
+	}
+
+	if i.AnalystFeedback != "" {
+		i.AnalystFeedback += "\n\n"
+	}
+	i.AnalystFeedback += "Operator feedback: " + feedback
+	i.Approval = ApprovalStatusNeedsIteration
+	return nil
+}
+
 // OptimizationListQuery represents query parameters for listing optimization runs.
 type OptimizationListQuery struct {
 	Status    *OptimizationStatus `json:"status,omitempty"`
 	TimeRange *TimeRange          `json:"time_range,omitempty"`
+	StarredBy *string             `json:"starred_by,omitempty"` // Only runs starred by this owner
 	OrderBy   string              `json:"order_by,omitempty"`
 	Ascending bool                `json:"ascending,omitempty"`
 	Page      int                 `json:"page"`
 	PageSize  int                 `json:"page_size"`
 }
 
-// SetDefaults sets default values for the query.
-func (q *OptimizationListQuery) SetDefaults() {
+// SetDefaults sets default values for the query, capping the page size. It
+// reports whether the requested page size was clamped.
+func (q *OptimizationListQuery) SetDefaults() bool {
 	if q.OrderBy == "" {
 		q.OrderBy = "created_at"
 	}
-	if q.Page <= 0 {
-		q.Page = 1
-	}
-	if q.PageSize <= 0 {
-		q.PageSize = 20
-	}
-	if q.PageSize > 100 {
-		q.PageSize = 100
-	}
+	return applyPageDefaults(PageResourceOptimizations, &q.Page, &q.PageSize)
 }
 
 // Offset returns the offset for pagination.
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PerformanceMetric is the backtest result metric charted for optimization
+// iterations.
+type PerformanceMetric string
+
+const (
+	PerformanceMetricSharpe   PerformanceMetric = "sharpe"
+	PerformanceMetricProfit   PerformanceMetric = "profit"
+	PerformanceMetricDrawdown PerformanceMetric = "drawdown"
+)
+
+// ParsePerformanceMetric parses a metric name; empty selects the Sharpe ratio.
+func ParsePerformanceMetric(s string) (PerformanceMetric, error) {
+	switch PerformanceMetric(s) {
+	case "":
+		return PerformanceMetricSharpe, nil
+	case PerformanceMetricSharpe, PerformanceMetricProfit, PerformanceMetricDrawdown:
+		return PerformanceMetric(s), nil
+	}
+	return "", fmt.Errorf("%w: unknown metric %q, expected sharpe, profit or drawdown", ErrInvalidInput, s)
+}
+
+// HigherIsBetter reports whether larger values of the metric are better.
+// Drawdown is the only metric where smaller is better.
+func (m PerformanceMetric) HigherIsBetter() bool {
+	return m != PerformanceMetricDrawdown
+}
+
+// PerformanceBucket is the time bucket iterations are aggregated into.
+type PerformanceBucket string
+
+const (
+	PerformanceBucketNone   PerformanceBucket = "none" // One point per iteration
+	PerformanceBucketHourly PerformanceBucket = "hourly"
+	PerformanceBucketDaily  PerformanceBucket = "daily"
+)
+
+// ParsePerformanceBucket parses a bucket name; empty selects hourly buckets.
+func ParsePerformanceBucket(s string) (PerformanceBucket, error) {
+	switch PerformanceBucket(s) {
+	case "":
+		return PerformanceBucketHourly, nil
+	case PerformanceBucketNone, PerformanceBucketHourly, PerformanceBucketDaily:
+		return PerformanceBucket(s), nil
+	}
+	return "", fmt.Errorf("%w: unknown bucket %q, expected none, hourly or daily", ErrInvalidInput, s)
+}
+
+// PerformanceQuery selects the optimization iterations to chart: those
+// created in [Start, End] whose backtest produced a value for the metric.
+type PerformanceQuery struct {
+	Start  time.Time
+	End    time.Time
+	Metric PerformanceMetric
+	Bucket PerformanceBucket
+
+	// PerRun splits the series by optimization run; otherwise all runs are
+	// aggregated into one series.
+	PerRun bool
+
+	// Location is the timezone whose hours and days bound the buckets; nil
+	// means UTC.
+	Location *time.Location
+}
+
+// PerformancePoint aggregates the metric over the iterations of one bucket.
+type PerformancePoint struct {
+	Timestamp  time.Time `json:"timestamp"` // Start of the bucket
+	Value      float64   `json:"value"`     // Mean over the bucket's iterations
+	Best       float64   `json:"best"`      // Best value in the bucket
+	Iterations int       `json:"iterations"`
+}
+
+// PerformanceSeries is the metric over time for one optimization run, or for
+// all runs when not split per run.
+type PerformanceSeries struct {
+	OptimizationRunID *uuid.UUID         `json:"optimization_id,omitempty"`
+	OptimizationName  string             `json:"optimization_name"`
+	Points            []PerformancePoint `json:"points"`
+}
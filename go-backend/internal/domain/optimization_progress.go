@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OptimizationProgress is a run's progress and completion forecast.
+// The forecast fields are nil until an iteration has completed and once the
+// run has finished.
+type OptimizationProgress struct {
+	RunID               uuid.UUID          `json:"run_id"`
+	Status              OptimizationStatus `json:"status"`
+	CompletedIterations int                `json:"completed_iterations"`
+	MaxIterations       int                `json:"max_iterations"`
+	PercentComplete     float64            `json:"percent_complete"`
+	ElapsedMs           int64              `json:"elapsed_ms"` // Wall time, excluding outage pauses
+	AvgIterationMs      *int64             `json:"avg_iteration_ms,omitempty"`
+	RemainingMs         *int64             `json:"remaining_ms,omitempty"`
+
+	// EstimatedCompletionAt is only set while the run is running.
+	EstimatedCompletionAt *time.Time `json:"estimated_completion_at,omitempty"`
+}
+
+// NewOptimizationProgress estimates a run's progress at now. The average
+// iteration wall time is the run's elapsed time divided by the iterations
+// completed; time spent auto-paused during outages is not counted.
+func NewOptimizationProgress(run *OptimizationRun, now time.Time) *OptimizationProgress {
+	progress := &OptimizationProgress{
+		RunID:               run.ID,
+		Status:              run.Status,
+		CompletedIterations: run.CurrentIteration,
+		MaxIterations:       run.MaxIterations,
+	}
+
+	if run.MaxIterations > 0 {
+		progress.PercentComplete = 100 * float64(run.CurrentIteration) / float64(run.MaxIterations)
+		if progress.PercentComplete > 100 {
+			progress.PercentComplete = 100
+		}
+	}
+
+	end := now
+	if run.CompletedAt != nil {
+		end = *run.CompletedAt
+	}
+	elapsed := end.Sub(run.CreatedAt)
+	for _, incident := range run.Incidents {
+		resumed := end
+		if incident.ResumedAt != nil && incident.ResumedAt.Before(end) {
+			resumed = *incident.ResumedAt
+		}
+		if resumed.After(incident.PausedAt) {
+			elapsed -= resumed.Sub(incident.PausedAt)
+		}
+	}
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	progress.ElapsedMs = elapsed.Milliseconds()
+
+	if run.CurrentIteration == 0 || run.Status.IsTerminal() {
+		return progress
+	}
+
+	avg := elapsed / time.Duration(run.CurrentIteration)
+	avgMs := avg.Milliseconds()
+	progress.AvgIterationMs = &avgMs
+
+	remainingIterations := run.MaxIterations - run.CurrentIteration
+	if remainingIterations < 0 {
+		remainingIterations = 0
+	}
+	remaining := avg * time.Duration(remainingIterations)
+	remainingMs := remaining.Milliseconds()
+	progress.RemainingMs = &remainingMs
+
+	if run.Status == OptimizationStatusRunning {
+		eta := now.Add(remaining)
+		progress.EstimatedCompletionAt = &eta
+	}
+
+	return progress
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// StalledOptimization is a run flagged as stalled, with the time of its last
+// activity: a change to the run, a new iteration, or a change to one of its
+// backtest jobs.
+type StalledOptimization struct {
+	Run            *OptimizationRun `json:"run"`
+	LastActivityAt time.Time        `json:"last_activity_at"`
+}
+
+// StalledFor returns how long the run had been inactive at now.
+func (s *StalledOptimization) StalledFor(now time.Time) time.Duration {
+	return now.Sub(s.LastActivityAt)
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrchestratorActionType is the next step an orchestrator has to take for an
+// optimization run.
+type OrchestratorActionType string
+
+const (
+	// OrchestratorActionNone means there is nothing to do: the run is not
+	// running, or another orchestrator holds the claim on the next step.
+	OrchestratorActionNone OrchestratorActionType = "none"
+	// OrchestratorActionGenerateCandidate asks for the next iteration's strategy
+	// to be generated and submitted for backtesting.
+	OrchestratorActionGenerateCandidate OrchestratorActionType = "generate_candidate"
+	// OrchestratorActionAwaitResults means the latest iteration's backtest is still queued or running.
+	OrchestratorActionAwaitResults OrchestratorActionType = "await_results"
+	// OrchestratorActionEvaluateCriteria asks for the latest iteration's result to be analyzed and approved or rejected.
+	OrchestratorActionEvaluateCriteria OrchestratorActionType = "evaluate_criteria"
+	// OrchestratorActionFinalize asks for the run to be completed.
+	OrchestratorActionFinalize OrchestratorActionType = "finalize"
+)
+
+// IsClaimable returns true if the action is work that only one orchestrator
+// may perform at a time.
+func (t OrchestratorActionType) IsClaimable() bool {
+	switch t {
+	case OrchestratorActionGenerateCandidate, OrchestratorActionEvaluateCriteria, OrchestratorActionFinalize:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the action type.
+func (t OrchestratorActionType) String() string {
+	return string(t)
+}
+
+// Termination reasons suggested with OrchestratorActionFinalize. They match the
+// reasons the orchestrator reports when completing a run.
+const (
+	TerminationReasonApproved      = "approved"
+	TerminationReasonArchived      = "archived"
+	TerminationReasonMaxIterations = "max_iterations_reached"
+)
+
+// OptimizationIterationState is an iteration with the status of its backtest job.
+type OptimizationIterationState struct {
+	Iteration *OptimizationIteration
+	JobStatus JobStatus
+	ResultID  *uuid.UUID // Result of the iteration's job, if recorded
+}
+
+// OrchestratorAction is the next step for an optimization run, as determined
+// by the backend from the run's status and its latest iteration.
+type OrchestratorAction struct {
+	RunID           uuid.UUID              `json:"run_id"`
+	Type            OrchestratorActionType `json:"type"`
+	IterationNumber int                    `json:"iteration_number"` // Iteration to generate, or the latest iteration
+	Reason          string                 `json:"reason,omitempty"`
+
+	// Set for generate_candidate: the strategy to improve on and the
+	// feedback from the previous iteration.
+	SourceStrategyID *uuid.UUID `json:"source_strategy_id,omitempty"`
+	Feedback         string     `json:"feedback,omitempty"`
+
+	// Latest iteration and its result, if any.
+	Iteration *OptimizationIteration `json:"iteration,omitempty"`
+	ResultID  *uuid.UUID             `json:"result_id,omitempty"`
+
+	// Claim on a claimable action. When another orchestrator holds it, Type
+	// is none and these identify the holder.
+	ClaimedBy      *string    `json:"claimed_by,omitempty"`
+	ClaimExpiresAt *time.Time `json:"claim_expires_at,omitempty"`
+}
+
+// NextOrchestratorAction determines the next step of a run from its status and
+// latest iteration (nil before the first iteration).
+func NextOrchestratorAction(run *OptimizationRun, latest *OptimizationIterationState) *OrchestratorAction {
+	action := &OrchestratorAction{RunID: run.ID, Type: OrchestratorActionNone}
+
+	if run.Status != OptimizationStatusRunning {
+		action.Reason = fmt.Sprintf("run is %s", run.Status)
+		return action
+	}
+
+	if latest == nil {
+		action.Type = OrchestratorActionGenerateCandidate
+		action.IterationNumber = 1
+		action.SourceStrategyID = &run.BaseStrategyID
+		return action
+	}
+
+	iter := latest.Iteration
+	action.IterationNumber = iter.IterationNumber
+	action.Iteration = iter
+	action.ResultID = latest.ResultID
+	if action.ResultID == nil {
+		action.ResultID = iter.ResultID
+	}
+
+	if !latest.JobStatus.IsTerminal() {
+		action.Type = OrchestratorActionAwaitResults
+		return action
+	}
+
+	// A job that failed or was cancelled has nothing to evaluate, so the
+	// iteration is regenerated like one that needs another iteration
+	approval := iter.Approval
+	if approval == ApprovalStatusPending && latest.JobStatus != JobStatusCompleted {
+		approval = ApprovalStatusNeedsIteration
+	}
+
+	switch approval {
+	case ApprovalStatusPending:
+		action.Type = OrchestratorActionEvaluateCriteria
+	case ApprovalStatusApproved:
+		action.Type = OrchestratorActionFinalize
+		action.Reason = TerminationReasonApproved
+	case ApprovalStatusRejected:
+		action.Type = OrchestratorActionFinalize
+		action.Reason = TerminationReasonArchived
+	default:
+		if run.MaxIterations > 0 && iter.IterationNumber >= run.MaxIterations {
+			action.Type = OrchestratorActionFinalize
+			action.Reason = TerminationReasonMaxIterations
+			return action
+		}
+		action.Type = OrchestratorActionGenerateCandidate
+		action.IterationNumber = iter.IterationNumber + 1
+		action.SourceStrategyID = &iter.StrategyID
+		action.Feedback = iter.AnalystFeedback
+	}
+
+	return action
+}
+
+// OrchestratorActionClaim records which orchestrator is performing a claimable
+// action of a run, until it expires.
+type OrchestratorActionClaim struct {
+	RunID           uuid.UUID              `json:"run_id"`
+	Action          OrchestratorActionType `json:"action"`
+	IterationNumber int                    `json:"iteration_number"`
+	ClaimedBy       string                 `json:"claimed_by"`
+	ClaimedAt       time.Time              `json:"claimed_at"`
+	ExpiresAt       time.Time              `json:"expires_at"`
+}
+
+// Blocks returns true if the claim keeps claimant from taking the action at
+// now. A claim only covers the action it was taken for: once the run has moved
+// on to another step it no longer applies.
+func (c *OrchestratorActionClaim) Blocks(action *OrchestratorAction, claimant string, now time.Time) bool {
+	return c != nil &&
+		c.ClaimedBy != claimant &&
+		now.Before(c.ExpiresAt) &&
+		c.Action == action.Type &&
+		c.IterationNumber == action.IterationNumber
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "sync"
+
+// Built-in page size limits, used unless configured otherwise.
+const (
+	DefaultPageSize    = 20
+	DefaultMaxPageSize = 100
+)
+
+// Resources whose list queries can be given their own page size limits.
+const (
+	PageResourceStrategies     = "strategies"
+	PageResourceResults        = "results"
+	PageResourceJobs           = "jobs"
+	PageResourceOptimizations  = "optimizations"
+	PageResourceCampaigns      = "campaigns"
+	PageResourceScoutRuns      = "scout_runs"
+	PageResourceScoutSchedules = "scout_schedules"
+)
+
+// PageResources lists the resources with paginated list queries.
+var PageResources = []string{
+	PageResourceStrategies, PageResourceResults, PageResourceJobs, PageResourceOptimizations,
+	PageResourceCampaigns, PageResourceScoutRuns, PageResourceScoutSchedules,
+}
+
+// PageLimits are the page size a list query gets when it asks for none and
+// the most it can ask for.
+type PageLimits struct {
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+var (
+	pageLimitsMu sync.RWMutex
+	pageLimits   = PageLimits{DefaultPageSize: DefaultPageSize, MaxPageSize: DefaultMaxPageSize}
+	pageLimitsBy = map[string]PageLimits{}
+)
+
+// SetPageLimits replaces the page size limits of all list queries, with
+// overrides by resource. It is meant to be called once at startup.
+func SetPageLimits(limits PageLimits, overrides map[string]PageLimits) {
+	pageLimitsMu.Lock()
+	defer pageLimitsMu.Unlock()
+
+	pageLimits = limits
+	pageLimitsBy = make(map[string]PageLimits, len(overrides))
+	for resource, l := range overrides {
+		pageLimitsBy[resource] = l
+	}
+}
+
+// PageLimitsFor returns the page size limits of a resource's list queries.
+func PageLimitsFor(resource string) PageLimits {
+	pageLimitsMu.RLock()
+	defer pageLimitsMu.RUnlock()
+
+	if l, ok := pageLimitsBy[resource]; ok {
+		return l
+	}
+	return pageLimits
+}
+
+// applyPageDefaults defaults the page and page size of a resource's list
+// query and caps the page size. It reports whether the page size was
+// clamped.
+func applyPageDefaults(resource string, page, pageSize *int) bool {
+	limits := PageLimitsFor(resource)
+	if *page <= 0 {
+		*page = 1
+	}
+	if *pageSize <= 0 {
+		*pageSize = limits.DefaultPageSize
+	}
+	if *pageSize > limits.MaxPageSize {
+		*pageSize = limits.MaxPageSize
+		return true
+	}
+	return false
+}
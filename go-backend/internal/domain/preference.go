@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+)
+
+// Well-known preference keys used by the frontend.
+const (
+	PreferenceKeyDashboardLayout    = "dashboard_layout"
+	PreferenceKeyDefaultPageSize    = "default_page_size"
+	PreferenceKeyFavoriteStrategies = "favorite_strategies"
+)
+
+// DefaultPreferenceOwner is used when a request does not identify a user.
+const DefaultPreferenceOwner = "default"
+
+// MaxPreferenceValueSize is the maximum size of a preference value in bytes.
+const MaxPreferenceValueSize = 64 * 1024
+
+// preferenceKeyRegex restricts keys to a URL-safe subset.
+var preferenceKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,100}$`)
+
+// UserPreference is a single key-value preference owned by a user or token.
+type UserPreference struct {
+	Owner     string          `json:"owner"`
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// NewUserPreference creates a new preference for the given owner and key.
+func NewUserPreference(owner, key string, value json.RawMessage) *UserPreference {
+	now := time.Now()
+	return &UserPreference{
+		Owner:     owner,
+		Key:       key,
+		Value:     value,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks the preference key and value.
+func (p *UserPreference) Validate() error {
+	if p.Owner == "" {
+		return errors.New("owner is required")
+	}
+	if !IsValidPreferenceKey(p.Key) {
+		return errors.New("key must be 1-100 characters of letters, digits, '_', '.' or '-'")
+	}
+	if len(p.Value) == 0 {
+		return errors.New("value is required")
+	}
+	if len(p.Value) > MaxPreferenceValueSize {
+		return errors.New("value exceeds maximum size of 64KB")
+	}
+	if !json.Valid(p.Value) {
+		return errors.New("value must be valid JSON")
+	}
+	return nil
+}
+
+// IsValidPreferenceKey returns true if the key is a valid preference key.
+func IsValidPreferenceKey(key string) bool {
+	return preferenceKeyRegex.MatchString(key)
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxPriorityChangeJobs caps the number of jobs listed in a priority change.
+const MaxPriorityChangeJobs = 1000
+
+// QueuedJob is a pending backtest job reduced to what decides its place in
+// the queue.
+type QueuedJob struct {
+	JobID             uuid.UUID  `json:"job_id"`
+	OptimizationRunID *uuid.UUID `json:"optimization_run_id,omitempty"`
+	Priority          int        `json:"priority"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// SortQueue orders pending jobs the way the scheduler dequeues them at now:
+// higher effective priority first, then oldest first.
+func SortQueue(jobs []QueuedJob, now time.Time, agingPerHour float64) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		pi, pj := jobs[i].EffectivePriority(now, agingPerHour), jobs[j].EffectivePriority(now, agingPerHour)
+		if pi != pj {
+			return pi > pj
+		}
+		return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+	})
+}
+
+// EffectivePriority returns the priority a queued job is dequeued at; see
+// BacktestJob.EffectivePriority.
+func (j QueuedJob) EffectivePriority(now time.Time, agingPerHour float64) int {
+	return agedPriority(j.Priority, j.CreatedAt, now, agingPerHour)
+}
+
+// EffectivePriority returns the priority a pending job is dequeued at: its
+// priority raised by agingPerHour levels for every hour it has waited, in
+// whole levels. Aging keeps a steady stream of high-priority jobs from
+// starving older low-priority ones; an agingPerHour of 0 disables it.
+func (j *BacktestJob) EffectivePriority(now time.Time, agingPerHour float64) int {
+	return agedPriority(j.Priority, j.CreatedAt, now, agingPerHour)
+}
+
+// agedPriority raises a priority by agingPerHour levels per hour waited since
+// createdAt, rounded down.
+func agedPriority(priority int, createdAt, now time.Time, agingPerHour float64) int {
+	waited := now.Sub(createdAt)
+	if agingPerHour <= 0 || waited <= 0 {
+		return priority
+	}
+	return priority + int(math.Floor(agingPerHour*waited.Hours()))
+}
+
+// PriorityChange sets the priority of pending jobs: the listed jobs and all
+// pending jobs of an optimization run. Jobs that already left the queue keep
+// their priority.
+type PriorityChange struct {
+	JobIDs            []uuid.UUID `json:"job_ids,omitempty"`
+	OptimizationRunID *uuid.UUID  `json:"optimization_run_id,omitempty"`
+	Priority          int         `json:"priority"`
+}
+
+// Validate checks that the change selects jobs.
+func (c PriorityChange) Validate() error {
+	if len(c.JobIDs) == 0 && c.OptimizationRunID == nil {
+		return fmt.Errorf("%w: job_ids or optimization_run_id is required", ErrInvalidInput)
+	}
+	if len(c.JobIDs) > MaxPriorityChangeJobs {
+		return fmt.Errorf("%w: at most %d job_ids can be changed at once", ErrInvalidInput, MaxPriorityChangeJobs)
+	}
+	return nil
+}
+
+// Selects reports whether the change applies to a pending job.
+func (c PriorityChange) Selects(job QueuedJob) bool {
+	if c.OptimizationRunID != nil && job.OptimizationRunID != nil && *job.OptimizationRunID == *c.OptimizationRunID {
+		return true
+	}
+	return slices.Contains(c.JobIDs, job.JobID)
+}
+
+// PriorityImpact is how a priority change moves one pending job. Positions
+// count the pending jobs ahead; start times are relative to now.
+type PriorityImpact struct {
+	JobID        uuid.UUID `json:"job_id"`
+	Selected     bool      `json:"selected"` // The change applies to the job itself
+	OldPriority  int       `json:"old_priority"`
+	NewPriority  int       `json:"new_priority"`
+	OldPosition  int       `json:"old_position"`
+	NewPosition  int       `json:"new_position"`
+	OldStartMs   int64     `json:"old_expected_start_ms"`
+	NewStartMs   int64     `json:"new_expected_start_ms"`
+	StartDeltaMs int64     `json:"start_delta_ms"` // Negative when the job starts earlier
+}
+
+// PrioritySimulation previews a priority change: the pending jobs it would
+// select and every pending job whose expected start time would move.
+type PrioritySimulation struct {
+	Change       PriorityChange   `json:"change"`
+	SelectedJobs int              `json:"selected_jobs"`
+	QueueLength  int              `json:"queue_length"`
+	RunningJobs  int              `json:"running_jobs"`
+	Workers      int              `json:"workers"`
+	RuntimeMs    int64            `json:"runtime_ms"` // Runtime assumed for every job
+	Affected     []PriorityImpact `json:"affected"`   // By new position
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "fmt"
+
+const (
+	// MaxQuotaCPUs is the most CPUs a quota may give a backtest container.
+	MaxQuotaCPUs = 32
+
+	// MinQuotaMemoryMB and MaxQuotaMemoryMB bound the memory a quota may
+	// give a backtest container.
+	MinQuotaMemoryMB = 256
+	MaxQuotaMemoryMB = 65536
+)
+
+// OptimizationQuota limits the resources an optimization run's backtests
+// take, so one aggressive run does not starve the others. Zero fields are
+// unlimited or use the backend's defaults.
+type OptimizationQuota struct {
+	// MaxConcurrentJobs is the most backtest jobs of the run that run at
+	// once. Further jobs stay queued, and jobs of other runs go ahead.
+	MaxConcurrentJobs int `json:"max_concurrent_jobs,omitempty"`
+
+	// CPULimit and MemoryLimitMB are the CPUs and memory of each of the
+	// run's backtest containers.
+	CPULimit      float64 `json:"cpu_limit,omitempty"`
+	MemoryLimitMB int     `json:"memory_limit_mb,omitempty"`
+}
+
+// Validate checks the limits of the quota.
+func (q *OptimizationQuota) Validate() error {
+	if q.MaxConcurrentJobs < 0 {
+		return fmt.Errorf("%w: max_concurrent_jobs must not be negative", ErrInvalidInput)
+	}
+	if q.CPULimit < 0 || q.CPULimit > MaxQuotaCPUs {
+		return fmt.Errorf("%w: cpu_limit must be between 0 and %d", ErrInvalidInput, MaxQuotaCPUs)
+	}
+	if q.MemoryLimitMB != 0 && (q.MemoryLimitMB < MinQuotaMemoryMB || q.MemoryLimitMB > MaxQuotaMemoryMB) {
+		return fmt.Errorf("%w: memory_limit_mb must be between %d and %d", ErrInvalidInput, MinQuotaMemoryMB, MaxQuotaMemoryMB)
+	}
+	return nil
+}
+
+// OptimizationQuotaUsage is a run's quota along with its backtest jobs
+// running now, on any scheduler.
+type OptimizationQuotaUsage struct {
+	Quota       OptimizationQuota `json:"quota"`
+	RunningJobs int               `json:"running_jobs"`
+}
+
+// Remaining returns how many more of the run's jobs may start, or -1 if the
+// number is unlimited.
+func (u *OptimizationQuotaUsage) Remaining() int {
+	if u.Quota.MaxConcurrentJobs <= 0 {
+		return -1
+	}
+	return max(u.Quota.MaxConcurrentJobs-u.RunningJobs, 0)
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ComparableConfig is the part of a backtest config that decides whether two
+// results can be compared: the market data and the account they ran with.
+// Hyperopt overrides are left out, since they tune the strategy itself.
+type ComparableConfig struct {
+	Exchange       string   `json:"exchange"`
+	Pairs          []string `json:"pairs"` // Sorted, without duplicates
+	Timeframe      string   `json:"timeframe"`
+	TimerangeStart string   `json:"timerange_start"` // YYYYMMDD
+	TimerangeEnd   string   `json:"timerange_end"`   // YYYYMMDD
+	TradingMode    string   `json:"trading_mode"`
+	StakeCurrency  string   `json:"stake_currency,omitempty"`
+	MaxOpenTrades  int      `json:"max_open_trades"`
+	StakeAmount    string   `json:"stake_amount"`
+	DryRunWallet   float64  `json:"dry_run_wallet"`
+}
+
+// Comparable returns the comparable part of the config, normalized so that
+// configs written differently but running the same backtest are equal.
+func (c *BacktestConfig) Comparable() ComparableConfig {
+	return ComparableConfig{
+		Exchange:       c.Exchange,
+		Pairs:          normalizePairs(c.Pairs),
+		Timeframe:      c.Timeframe,
+		TimerangeStart: strings.ReplaceAll(c.TimerangeStart, "-", ""),
+		TimerangeEnd:   strings.ReplaceAll(c.TimerangeEnd, "-", ""),
+		TradingMode:    c.GetTradingMode(),
+		StakeCurrency:  c.StakeCurrency,
+		MaxOpenTrades:  c.MaxOpenTrades,
+		StakeAmount:    c.StakeAmount,
+		DryRunWallet:   c.DryRunWallet,
+	}
+}
+
+// Metric directions for MetricDelta.Better.
+const (
+	BetterHigher = "higher"
+	BetterLower  = "lower"
+)
+
+// MetricDelta compares one metric of a result with the parent's. Values are
+// nil when a result did not report the metric, and the delta is nil unless
+// both did. Better is the direction that counts as an improvement, empty for
+// metrics that are neither better nor worse, such as the number of trades.
+type MetricDelta struct {
+	Metric      string   `json:"metric"`
+	Value       *float64 `json:"value,omitempty"`
+	ParentValue *float64 `json:"parent_value,omitempty"`
+	Delta       *float64 `json:"delta,omitempty"` // Value - ParentValue
+	Better      string   `json:"better,omitempty"`
+	Improved    *bool    `json:"improved,omitempty"` // Unset without a delta or direction
+}
+
+// ParentResultComparison compares a backtest result with the best result of
+// the strategy's parent under a comparable config.
+type ParentResultComparison struct {
+	JobID            uuid.UUID `json:"job_id"`
+	ResultID         uuid.UUID `json:"result_id"`
+	StrategyID       uuid.UUID `json:"strategy_id"`
+	ParentStrategyID uuid.UUID `json:"parent_strategy_id"`
+	ParentJobID      uuid.UUID `json:"parent_job_id"`
+	ParentResultID   uuid.UUID `json:"parent_result_id"`
+
+	Config ComparableConfig `json:"config"`
+	Deltas []MetricDelta    `json:"deltas"`
+
+	Improved  int `json:"improved"`  // Metrics that got better
+	Regressed int `json:"regressed"` // Metrics that got worse
+}
+
+// NewParentResultComparison compares a result with the parent's result.
+func NewParentResultComparison(result, parent *BacktestResult, cfg ComparableConfig) *ParentResultComparison {
+	c := &ParentResultComparison{
+		JobID:            result.JobID,
+		ResultID:         result.ID,
+		StrategyID:       result.StrategyID,
+		ParentStrategyID: parent.StrategyID,
+		ParentJobID:      parent.JobID,
+		ParentResultID:   parent.ID,
+		Config:           cfg,
+	}
+
+	value := func(v float64) *float64 { return &v }
+	add := func(metric, better string, v, p *float64) {
+		d := MetricDelta{Metric: metric, Value: v, ParentValue: p, Better: better}
+		if v != nil && p != nil {
+			d.Delta = value(*v - *p)
+			if better != "" && *d.Delta != 0 {
+				improved := (*d.Delta > 0) == (better == BetterHigher)
+				d.Improved = &improved
+				if improved {
+					c.Improved++
+				} else {
+					c.Regressed++
+				}
+			}
+		}
+		c.Deltas = append(c.Deltas, d)
+	}
+
+	add("sharpe_ratio", BetterHigher, result.SharpeRatio, parent.SharpeRatio)
+	add("sortino_ratio", BetterHigher, result.SortinoRatio, parent.SortinoRatio)
+	add("calmar_ratio", BetterHigher, result.CalmarRatio, parent.CalmarRatio)
+	add("profit_pct", BetterHigher, value(result.ProfitPct), value(parent.ProfitPct))
+	add("profit_total", BetterHigher, value(result.ProfitTotal), value(parent.ProfitTotal))
+	add("profit_factor", BetterHigher, result.ProfitFactor, parent.ProfitFactor)
+	add("win_rate", BetterHigher, value(result.WinRate), value(parent.WinRate))
+	add("max_drawdown_pct", BetterLower, value(result.MaxDrawdownPct), value(parent.MaxDrawdownPct))
+	add("avg_profit_per_trade", BetterHigher, result.AvgProfitPerTrade, parent.AvgProfitPerTrade)
+	add("total_trades", "", value(float64(result.TotalTrades)), value(float64(parent.TotalTrades)))
+	add("avg_trade_duration_minutes", "", result.AvgTradeDurationMinutes, parent.AvgTradeDurationMinutes)
+
+	return c
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// ResultCohort is the set of results a result's metrics are ranked against:
+// all current results backtested on the same pairs over the same timerange.
+type ResultCohort struct {
+	Pairs          []string `json:"pairs"`           // Sorted, without duplicates
+	TimerangeStart string   `json:"timerange_start"` // YYYYMMDD
+	TimerangeEnd   string   `json:"timerange_end"`   // YYYYMMDD
+}
+
+// Cohort returns the cohort of results backtested with the config.
+func (c *BacktestConfig) Cohort() ResultCohort {
+	comparable := c.Comparable()
+	return ResultCohort{
+		Pairs:          comparable.Pairs,
+		TimerangeStart: comparable.TimerangeStart,
+		TimerangeEnd:   comparable.TimerangeEnd,
+	}
+}
+
+// Key identifies the cohort, e.g. for caching its metrics.
+func (c ResultCohort) Key() string {
+	return c.TimerangeStart + "-" + c.TimerangeEnd + "|" + strings.Join(c.Pairs, ",")
+}
+
+// CohortMetrics holds the metrics of every result in a cohort, each sorted
+// ascending. Results that did not report a metric are left out of it.
+type CohortMetrics struct {
+	SharpeRatios    []float64
+	ProfitPcts      []float64
+	MaxDrawdownPcts []float64
+}
+
+// PercentileRanks place a result's metrics within its cohort, from 0 to 100.
+// Higher is always better: a drawdown rank of 90 means the drawdown is
+// smaller than that of 90% of the cohort. Ties count half, so a cohort of
+// identical results ranks each at 50.
+type PercentileRanks struct {
+	Cohort     ResultCohort `json:"cohort"`
+	CohortSize int          `json:"cohort_size"` // Results in the cohort when it was aggregated
+	Sharpe     *float64     `json:"sharpe,omitempty"`
+	Profit     *float64     `json:"profit,omitempty"`
+	Drawdown   *float64     `json:"drawdown,omitempty"`
+}
+
+// Rank returns the percentile ranks of a result within the cohort.
+func (m *CohortMetrics) Rank(cohort ResultCohort, result *BacktestResult) *PercentileRanks {
+	ranks := &PercentileRanks{
+		Cohort:     cohort,
+		CohortSize: len(m.ProfitPcts),
+		Profit:     percentileRank(m.ProfitPcts, result.ProfitPct, true),
+		Drawdown:   percentileRank(m.MaxDrawdownPcts, result.MaxDrawdownPct, false),
+	}
+	if result.SharpeRatio != nil {
+		ranks.Sharpe = percentileRank(m.SharpeRatios, *result.SharpeRatio, true)
+	}
+	return ranks
+}
+
+// percentileRank returns the share of sorted values, in percent, that value
+// beats, counting ties half. It returns nil for an empty cohort.
+func percentileRank(sorted []float64, value float64, higherIsBetter bool) *float64 {
+	if len(sorted) == 0 {
+		return nil
+	}
+	below := sort.SearchFloat64s(sorted, value)
+	above := len(sorted) - sort.Search(len(sorted), func(i int) bool { return sorted[i] > value })
+	ties := len(sorted) - below - above
+
+	beaten := below
+	if !higherIsBetter {
+		beaten = above
+	}
+	rank := (float64(beaten) + float64(ties)/2) / float64(len(sorted)) * 100
+	return &rank
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxRevalidationStrategies is the most strategies one re-validation run may
+// select.
+const MaxRevalidationStrategies = 1000
+
+// RevalidationStatus is the lifecycle status of a re-validation run.
+type RevalidationStatus string
+
+const (
+	RevalidationStatusPending   RevalidationStatus = "pending"
+	RevalidationStatusRunning   RevalidationStatus = "running"
+	RevalidationStatusCompleted RevalidationStatus = "completed"
+	RevalidationStatusFailed    RevalidationStatus = "failed"
+)
+
+// String returns the string representation of the re-validation status.
+func (s RevalidationStatus) String() string {
+	return string(s)
+}
+
+// RevalidationRun re-validates selected strategies against a Freqtrade image,
+// e.g. after the backtest image was upgraded, recording whether each is valid
+// on that image.
+type RevalidationRun struct {
+	ID          uuid.UUID          `json:"id"`
+	Image       string             `json:"image"`
+	StrategyIDs []uuid.UUID        `json:"strategy_ids"`
+	Status      RevalidationStatus `json:"status"`
+	RequestedBy string             `json:"requested_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+
+	// Progress, updated as strategies are validated. Errors counts the
+	// strategies that could not be validated at all, e.g. deleted ones.
+	Validated int `json:"validated"`
+	Valid     int `json:"valid"`
+	Invalid   int `json:"invalid"`
+	Errors    int `json:"errors"`
+
+	Error       *string    `json:"error,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// NewRevalidationRun creates a new pending re-validation run. Duplicate
+// strategy IDs are dropped.
+func NewRevalidationRun(image string, strategyIDs []uuid.UUID, requestedBy string) *RevalidationRun {
+	seen := make(map[uuid.UUID]bool, len(strategyIDs))
+	ids := make([]uuid.UUID, 0, len(strategyIDs))
+	for _, id := range strategyIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return &RevalidationRun{
+		ID:          uuid.New(),
+		Image:       image,
+		StrategyIDs: ids,
+		Status:      RevalidationStatusPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Validate checks the image and strategy selection.
+func (r *RevalidationRun) Validate() error {
+	if r.Image == "" {
+		return fmt.Errorf("%w: image is required", ErrInvalidInput)
+	}
+	if len(r.StrategyIDs) == 0 {
+		return fmt.Errorf("%w: strategy_ids is required", ErrInvalidInput)
+	}
+	if len(r.StrategyIDs) > MaxRevalidationStrategies {
+		return fmt.Errorf("%w: at most %d strategies may be re-validated at once", ErrInvalidInput, MaxRevalidationStrategies)
+	}
+	return nil
+}
+
+// Total returns the number of strategies the run validates.
+func (r *RevalidationRun) Total() int {
+	return len(r.StrategyIDs)
+}
+
+// StrategyImageValidation is whether a strategy is valid on a Freqtrade image,
+// as last validated.
+type StrategyImageValidation struct {
+	StrategyID  uuid.UUID  `json:"strategy_id"`
+	Image       string     `json:"image"`
+	Valid       bool       `json:"valid"`
+	Errors      []string   `json:"errors,omitempty"`
+	RunID       *uuid.UUID `json:"run_id,omitempty"`
+	ValidatedAt time.Time  `json:"validated_at"`
+}
+
+// CompatibilityRow is a strategy's validity on each image of a compatibility
+// matrix. Images the strategy was not validated on are missing from Images.
+type CompatibilityRow struct {
+	StrategyID   uuid.UUID                           `json:"strategy_id"`
+	StrategyName string                              `json:"strategy_name"`
+	Images       map[string]*StrategyImageValidation `json:"images"`
+}
+
+// CompatibilityImageSummary counts the strategies of a compatibility matrix
+// by their validity on one image.
+type CompatibilityImageSummary struct {
+	Image       string `json:"image"`
+	Valid       int    `json:"valid"`
+	Invalid     int    `json:"invalid"`
+	Unvalidated int    `json:"unvalidated"`
+}
+
+// CompatibilityMatrix is the validity of strategies across Freqtrade images.
+type CompatibilityMatrix struct {
+	Images []CompatibilityImageSummary `json:"images"`
+	Rows   []CompatibilityRow          `json:"rows"`
+}
+
+// NewCompatibilityMatrix builds the matrix of the given images from the
+// strategies' rows, counting each image's valid, invalid and unvalidated
+// strategies.
+func NewCompatibilityMatrix(images []string, rows []CompatibilityRow) *CompatibilityMatrix {
+	m := &CompatibilityMatrix{
+		Images: make([]CompatibilityImageSummary, len(images)),
+		Rows:   rows,
+	}
+	if m.Rows == nil {
+		m.Rows = []CompatibilityRow{}
+	}
+	for i, image := range images {
+		summary := CompatibilityImageSummary{Image: image}
+		for _, row := range rows {
+			switch v := row.Images[image]; {
+			case v == nil:
+				summary.Unvalidated++
+			case v.Valid:
+				summary.Valid++
+			default:
+				summary.Invalid++
+			}
+		}
+		m.Images[i] = summary
+	}
+	return m
+}
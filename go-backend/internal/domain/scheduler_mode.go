@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// SchedulerMode is whether the backtest scheduler takes jobs from the queue.
+type SchedulerMode string
+
+const (
+	// SchedulerModeRunning dequeues and runs jobs.
+	SchedulerModeRunning SchedulerMode = "running"
+	// SchedulerModePaused dequeues nothing; running jobs continue, and jobs
+	// handed to busy workers but not started go back to the queue.
+	SchedulerModePaused SchedulerMode = "paused"
+	// SchedulerModeDraining dequeues nothing but finishes every job already
+	// dispatched, e.g. ahead of maintenance on the Docker host.
+	SchedulerModeDraining SchedulerMode = "draining"
+)
+
+// IsValid checks if the mode is valid.
+func (m SchedulerMode) IsValid() bool {
+	switch m {
+	case SchedulerModeRunning, SchedulerModePaused, SchedulerModeDraining:
+		return true
+	}
+	return false
+}
+
+// SchedulerStatus reports the mode of a backtest scheduler and the jobs it
+// still holds. Each scheduler process has its own mode.
+type SchedulerStatus struct {
+	Mode      SchedulerMode `json:"mode"`
+	Since     time.Time     `json:"since"`                // When the mode last changed
+	ChangedBy string        `json:"changed_by,omitempty"` // Who changed it; empty at startup
+
+	ClaimedJobs int `json:"claimed_jobs"` // Dispatched here and not finished
+	ActiveJobs  int `json:"active_jobs"`  // Claimed jobs a worker is running
+
+	// Drained is set once a paused or draining scheduler holds no jobs, so
+	// the Docker host can be taken down.
+	Drained bool `json:"drained"`
+
+	// Backpressure reports whether dequeuing is held back by the Docker
+	// host's load; nil unless backpressure is enabled.
+	Backpressure *BackpressureStatus `json:"backpressure,omitempty"`
+}
+
+// HostUsage is the share of the Docker host's CPU and memory its running
+// containers use.
+type HostUsage struct {
+	CPUPercent       float64   `json:"cpu_percent"`    // Of all the host's CPUs
+	MemoryPercent    float64   `json:"memory_percent"` // Of the host's memory, excluding page cache
+	MemoryUsedBytes  int64     `json:"memory_used_bytes"`
+	MemoryTotalBytes int64     `json:"memory_total_bytes"`
+	Containers       int       `json:"containers"` // Running containers sampled
+	SampledAt        time.Time `json:"sampled_at"`
+}
+
+// BackpressureStatus reports whether a scheduler stopped dequeuing jobs
+// because the Docker host is loaded, and the latest usage sample.
+type BackpressureStatus struct {
+	Throttled bool       `json:"throttled"`
+	Reason    string     `json:"reason,omitempty"` // Threshold exceeded while throttled
+	Since     *time.Time `json:"since,omitempty"`  // When throttling started
+	Usage     *HostUsage `json:"usage,omitempty"`  // Nil until the host was sampled
+}
@@ -101,6 +101,11 @@ type ScoutRun struct {
 	CreatedAt     time.Time        `json:"created_at"`
 	StartedAt     *time.Time       `json:"started_at,omitempty"`
 	CompletedAt   *time.Time       `json:"completed_at,omitempty"`
+
+	// CancelReason and CancelledBy record why, and by whom, the run was
+	// cancelled.
+	CancelReason *string `json:"cancel_reason,omitempty"`
+	CancelledBy  *string `json:"cancelled_by,omitempty"`
 }
 
 // NewScoutRun creates a new Scout run with generated UUID.
@@ -134,13 +139,25 @@ func (r *ScoutRun) IsComplete() bool {
 	return r.Status.IsTerminal()
 }
 
-// ScoutMetrics represents metrics collected during a Scout run.
+// ScoutMetrics represents metrics collected during a Scout run. The counts
+// follow the Scout pipeline: strategies found at the source, fetched, parsed,
+// validated (or failed validation), removed as duplicates and submitted as new
+// strategies. Found and Parsed are optional; zero means not reported.
+// See Validate for the invariants between them.
 type ScoutMetrics struct {
-	TotalFetched       int `json:"total_fetched"`
-	Validated          int `json:"validated"`
-	ValidationFailed   int `json:"validation_failed"`
-	DuplicatesRemoved  int `json:"duplicates_removed"`
-	Submitted          int `json:"submitted"`
+	Found             int `json:"found,omitempty"`
+	TotalFetched      int `json:"total_fetched"`
+	Parsed            int `json:"parsed,omitempty"`
+	Validated         int `json:"validated"`
+	ValidationFailed  int `json:"validation_failed"`
+	DuplicatesRemoved int `json:"duplicates_removed"`
+	Submitted         int `json:"submitted"`
+
+	// Sources breaks the counts down by source when a run scouts several
+	Sources map[string]*ScoutSourceMetrics `json:"sources,omitempty"`
+
+	// ErrorCategories counts failures by category, e.g. "fetch_timeout" or "syntax_error"
+	ErrorCategories map[string]int `json:"error_categories,omitempty"`
 }
 
 // ValidationRate returns the percentage of strategies that passed validation.
@@ -170,8 +187,14 @@ type ScoutSchedule struct {
 	LastRunID      *uuid.UUID `json:"last_run_id,omitempty"`
 	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
 	NextRunAt      *time.Time `json:"next_run_at,omitempty"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
+	// CredentialSecretID references the secret holding the source's
+	// credential, which is passed to the Scout agent on each run.
+	CredentialSecretID *uuid.UUID `json:"credential_secret_id,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	// Timezone is the zone the cron expression is evaluated in: its CRON_TZ
+	// zone or the server timezone. Filled in for API responses, not stored.
+	Timezone string `json:"timezone,omitempty"`
 }
 
 // NewScoutSchedule creates a new Scout schedule with generated UUID.
@@ -212,20 +235,13 @@ type ScoutRunQuery struct {
 	PageSize  int             `json:"page_size"`
 }
 
-// SetDefaults sets default values for the query.
-func (q *ScoutRunQuery) SetDefaults() {
+// SetDefaults sets default values for the query, capping the page size. It
+// reports whether the requested page size was clamped.
+func (q *ScoutRunQuery) SetDefaults() bool {
 	if q.OrderBy == "" {
 		q.OrderBy = "created_at"
 	}
-	if q.Page <= 0 {
-		q.Page = 1
-	}
-	if q.PageSize <= 0 {
-		q.PageSize = 20
-	}
-	if q.PageSize > 100 {
-		q.PageSize = 100
-	}
+	return applyPageDefaults(PageResourceScoutRuns, &q.Page, &q.PageSize)
 }
 
 // Offset returns the offset for pagination.
@@ -244,20 +260,13 @@ type ScoutScheduleQuery struct {
 	PageSize  int        `json:"page_size"`
 }
 
-// SetDefaults sets default values for the query.
-func (q *ScoutScheduleQuery) SetDefaults() {
+// SetDefaults sets default values for the query, capping the page size. It
+// reports whether the requested page size was clamped.
+func (q *ScoutScheduleQuery) SetDefaults() bool {
 	if q.OrderBy == "" {
 		q.OrderBy = "created_at"
 	}
-	if q.Page <= 0 {
-		q.Page = 1
-	}
-	if q.PageSize <= 0 {
-		q.PageSize = 20
-	}
-	if q.PageSize > 100 {
-		q.PageSize = 100
-	}
+	return applyPageDefaults(PageResourceScoutSchedules, &q.Page, &q.PageSize)
 }
 
 // Offset returns the offset for pagination.
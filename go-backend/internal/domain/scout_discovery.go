@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScoutValidationStatus is the Scout agent's verdict on a discovered
+// strategy's code.
+type ScoutValidationStatus string
+
+const (
+	ScoutValidationValid   ScoutValidationStatus = "valid"
+	ScoutValidationInvalid ScoutValidationStatus = "invalid"
+)
+
+// IsValid returns true if the validation status is known.
+func (s ScoutValidationStatus) IsValid() bool {
+	return s == ScoutValidationValid || s == ScoutValidationInvalid
+}
+
+// ScoutDiscoveryOutcome is what became of a discovered strategy.
+type ScoutDiscoveryOutcome string
+
+const (
+	// ScoutOutcomeNotSubmitted is a strategy the agent fetched but did not
+	// submit for import, e.g. because it failed validation or repeated
+	// another strategy of the run.
+	ScoutOutcomeNotSubmitted ScoutDiscoveryOutcome = "not_submitted"
+	// ScoutOutcomeImported is a strategy stored as a new strategy.
+	ScoutOutcomeImported ScoutDiscoveryOutcome = "imported"
+	// ScoutOutcomeDuplicate is a strategy whose code was already stored.
+	ScoutOutcomeDuplicate ScoutDiscoveryOutcome = "duplicate"
+	// ScoutOutcomeRejected is a strategy refused by the code safety checks.
+	ScoutOutcomeRejected ScoutDiscoveryOutcome = "rejected"
+)
+
+// IsValid returns true if the outcome is known.
+func (o ScoutDiscoveryOutcome) IsValid() bool {
+	switch o {
+	case ScoutOutcomeNotSubmitted, ScoutOutcomeImported, ScoutOutcomeDuplicate, ScoutOutcomeRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// ScoutDiscovery records one strategy a Scout run fetched, identified within
+// the run by its code hash. The agent reports what it fetched and how it
+// validated; the backend records the outcome of importing it.
+type ScoutDiscovery struct {
+	ID               uuid.UUID             `json:"id"`
+	RunID            uuid.UUID             `json:"run_id"`
+	Name             string                `json:"name"`
+	Source           string                `json:"source"` // "stratninja", "github", etc.
+	SourceURL        string                `json:"source_url,omitempty"`
+	CodeHash         string                `json:"code_hash"`
+	ValidationStatus ScoutValidationStatus `json:"validation_status"`
+	ValidationErrors []string              `json:"validation_errors,omitempty"`
+	Outcome          ScoutDiscoveryOutcome `json:"outcome"`
+	Imported         bool                  `json:"imported"`
+	StrategyID       *uuid.UUID            `json:"strategy_id,omitempty"`    // Imported strategy, or the stored duplicate
+	OutcomeDetail    string                `json:"outcome_detail,omitempty"` // Why a strategy was rejected
+	DiscoveredAt     time.Time             `json:"discovered_at"`
+}
+
+// NewScoutDiscovery creates a discovery of a Scout run not yet submitted for
+// import.
+func NewScoutDiscovery(runID uuid.UUID, name, source, sourceURL, codeHash string, status ScoutValidationStatus) *ScoutDiscovery {
+	return &ScoutDiscovery{
+		ID:               uuid.New(),
+		RunID:            runID,
+		Name:             name,
+		Source:           source,
+		SourceURL:        sourceURL,
+		CodeHash:         codeHash,
+		ValidationStatus: status,
+		Outcome:          ScoutOutcomeNotSubmitted,
+		DiscoveredAt:     time.Now(),
+	}
+}
+
+// SetOutcome records the outcome of importing the discovery.
+func (d *ScoutDiscovery) SetOutcome(outcome ScoutDiscoveryOutcome, strategyID *uuid.UUID, detail string) {
+	d.Outcome = outcome
+	d.Imported = outcome == ScoutOutcomeImported
+	d.StrategyID = strategyID
+	d.OutcomeDetail = detail
+}
+
+// Validate checks that the discovery identifies its strategy and has a known
+// validation status and outcome.
+func (d *ScoutDiscovery) Validate() error {
+	if d.CodeHash == "" {
+		return fmt.Errorf("%w: code_hash is required", ErrInvalidInput)
+	}
+	if !d.ValidationStatus.IsValid() {
+		return fmt.Errorf("%w: unknown validation_status %q", ErrInvalidInput, d.ValidationStatus)
+	}
+	if !d.Outcome.IsValid() {
+		return fmt.Errorf("%w: unknown outcome %q", ErrInvalidInput, d.Outcome)
+	}
+	return nil
+}
+
+// ScoutDiscoveryQuery selects the discoveries of a Scout run.
+type ScoutDiscoveryQuery struct {
+	RunID            uuid.UUID
+	Outcome          *ScoutDiscoveryOutcome
+	ValidationStatus *ScoutValidationStatus
+}
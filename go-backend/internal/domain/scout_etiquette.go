@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ScoutDailyWindow is the window MaxRunsPerDay is counted over: the last 24
+// hours rather than the calendar day, so a cap cannot be doubled around
+// midnight.
+const ScoutDailyWindow = 24 * time.Hour
+
+// ErrScoutRateLimited is returned when triggering a Scout run would break its
+// source's etiquette limits.
+var ErrScoutRateLimited = errors.New("scout source rate limited")
+
+// ScoutSourceLimits are the etiquette limits of one Scout source. They keep
+// runs against an upstream strategy site spaced out, whether triggered
+// manually or by overlapping schedules, so the site doesn't rate-limit or ban
+// us. Zero values disable a limit.
+type ScoutSourceLimits struct {
+	MinInterval   time.Duration // Least time between the triggering of two runs
+	MaxRunsPerDay int           // Most runs triggered within ScoutDailyWindow
+}
+
+// IsZero returns true if no limit is set.
+func (l ScoutSourceLimits) IsZero() bool {
+	return l.MinInterval <= 0 && l.MaxRunsPerDay <= 0
+}
+
+// ScoutSourceUsage is how a source has recently been scouted.
+type ScoutSourceUsage struct {
+	LastRunAt      *time.Time // When the latest run was triggered
+	RunsInWindow   int        // Runs triggered within ScoutDailyWindow
+	OldestInWindow *time.Time // When the oldest of those was triggered
+}
+
+// ScoutRateLimitError wraps ErrScoutRateLimited with the limit that was hit
+// and when the source may be scouted again.
+type ScoutRateLimitError struct {
+	Source     string
+	Reason     string
+	RetryAfter time.Time
+}
+
+func (e *ScoutRateLimitError) Error() string {
+	return fmt.Sprintf("%v: source %q %s; next run allowed at %s",
+		ErrScoutRateLimited, e.Source, e.Reason, e.RetryAfter.UTC().Format(time.RFC3339))
+}
+
+func (e *ScoutRateLimitError) Unwrap() error {
+	return ErrScoutRateLimited
+}
+
+// Check returns a *ScoutRateLimitError if a run of source triggered at now
+// would break the limits given the source's usage.
+func (l ScoutSourceLimits) Check(source string, usage *ScoutSourceUsage, now time.Time) error {
+	if l.MinInterval > 0 && usage.LastRunAt != nil {
+		if next := usage.LastRunAt.Add(l.MinInterval); now.Before(next) {
+			return &ScoutRateLimitError{
+				Source:     source,
+				Reason:     fmt.Sprintf("was scouted less than %s ago", l.MinInterval),
+				RetryAfter: next,
+			}
+		}
+	}
+	if l.MaxRunsPerDay > 0 && usage.RunsInWindow >= l.MaxRunsPerDay {
+		retryAfter := now
+		if usage.OldestInWindow != nil {
+			retryAfter = usage.OldestInWindow.Add(ScoutDailyWindow)
+		}
+		return &ScoutRateLimitError{
+			Source:     source,
+			Reason:     fmt.Sprintf("reached its cap of %d runs per day", l.MaxRunsPerDay),
+			RetryAfter: retryAfter,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MaxScoutMetricsLabelLength is the maximum length of a source or error
+// category name in Scout metrics.
+const MaxScoutMetricsLabelLength = 64
+
+// ScoutSourceMetrics are the pipeline counts of a Scout run for one source.
+// The fields match those of ScoutMetrics.
+type ScoutSourceMetrics struct {
+	Found             int `json:"found,omitempty"`
+	TotalFetched      int `json:"total_fetched"`
+	Parsed            int `json:"parsed,omitempty"`
+	Validated         int `json:"validated"`
+	ValidationFailed  int `json:"validation_failed"`
+	DuplicatesRemoved int `json:"duplicates_removed"`
+	Submitted         int `json:"submitted"`
+}
+
+// Add adds the counts of other to the counts.
+func (c *ScoutSourceMetrics) Add(other *ScoutSourceMetrics) {
+	c.Found += other.Found
+	c.TotalFetched += other.TotalFetched
+	c.Parsed += other.Parsed
+	c.Validated += other.Validated
+	c.ValidationFailed += other.ValidationFailed
+	c.DuplicatesRemoved += other.DuplicatesRemoved
+	c.Submitted += other.Submitted
+}
+
+// validate checks that the counts are non-negative and consistent with the
+// order of the pipeline: found >= fetched >= parsed >= validated + failed,
+// and validated >= duplicates + submitted.
+func (c *ScoutSourceMetrics) validate() error {
+	counts := []struct {
+		name  string
+		value int
+	}{
+		{"found", c.Found},
+		{"total_fetched", c.TotalFetched},
+		{"parsed", c.Parsed},
+		{"validated", c.Validated},
+		{"validation_failed", c.ValidationFailed},
+		{"duplicates_removed", c.DuplicatesRemoved},
+		{"submitted", c.Submitted},
+	}
+	for _, count := range counts {
+		if count.value < 0 {
+			return fmt.Errorf("%s must not be negative", count.name)
+		}
+	}
+
+	if c.Found > 0 && c.TotalFetched > c.Found {
+		return fmt.Errorf("total_fetched (%d) exceeds found (%d)", c.TotalFetched, c.Found)
+	}
+	checked, checkedName := c.TotalFetched, "total_fetched"
+	if c.Parsed > 0 {
+		if c.Parsed > c.TotalFetched {
+			return fmt.Errorf("parsed (%d) exceeds total_fetched (%d)", c.Parsed, c.TotalFetched)
+		}
+		checked, checkedName = c.Parsed, "parsed"
+	}
+	if c.Validated+c.ValidationFailed > checked {
+		return fmt.Errorf("validated plus validation_failed (%d) exceeds %s (%d)",
+			c.Validated+c.ValidationFailed, checkedName, checked)
+	}
+	if c.DuplicatesRemoved+c.Submitted > c.Validated {
+		return fmt.Errorf("duplicates_removed plus submitted (%d) exceeds validated (%d)",
+			c.DuplicatesRemoved+c.Submitted, c.Validated)
+	}
+	return nil
+}
+
+// within reports whether no count exceeds the matching count of total.
+func (c *ScoutSourceMetrics) within(total *ScoutSourceMetrics) bool {
+	return c.Found <= total.Found &&
+		c.TotalFetched <= total.TotalFetched &&
+		c.Parsed <= total.Parsed &&
+		c.Validated <= total.Validated &&
+		c.ValidationFailed <= total.ValidationFailed &&
+		c.DuplicatesRemoved <= total.DuplicatesRemoved &&
+		c.Submitted <= total.Submitted
+}
+
+// Counts returns the run-level pipeline counts.
+func (m *ScoutMetrics) Counts() *ScoutSourceMetrics {
+	return &ScoutSourceMetrics{
+		Found:             m.Found,
+		TotalFetched:      m.TotalFetched,
+		Parsed:            m.Parsed,
+		Validated:         m.Validated,
+		ValidationFailed:  m.ValidationFailed,
+		DuplicatesRemoved: m.DuplicatesRemoved,
+		Submitted:         m.Submitted,
+	}
+}
+
+// Validate checks the metrics against the schema. The run-level counts and
+// those of every source must follow the pipeline order, the per-source counts
+// must not add up to more than the run-level ones, and error category counts
+// must not be negative. Errors wrap ErrInvalidInput.
+func (m *ScoutMetrics) Validate() error {
+	if err := m.Counts().validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if len(m.Sources) > 0 {
+		names := make([]string, 0, len(m.Sources))
+		for name := range m.Sources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		sum := &ScoutSourceMetrics{}
+		for _, name := range names {
+			if err := validateScoutMetricsLabel("source", name); err != nil {
+				return err
+			}
+			source := m.Sources[name]
+			if source == nil {
+				return fmt.Errorf("%w: source %s has no counts", ErrInvalidInput, name)
+			}
+			if err := source.validate(); err != nil {
+				return fmt.Errorf("%w: source %s: %v", ErrInvalidInput, name, err)
+			}
+			sum.Add(source)
+		}
+		if !sum.within(m.Counts()) {
+			return fmt.Errorf("%w: per-source counts exceed the run totals", ErrInvalidInput)
+		}
+	}
+
+	for category, n := range m.ErrorCategories {
+		if err := validateScoutMetricsLabel("error category", category); err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("%w: error category %s must not be negative", ErrInvalidInput, category)
+		}
+	}
+
+	return nil
+}
+
+// validateScoutMetricsLabel checks a source or error category name.
+func validateScoutMetricsLabel(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: %s name is required", ErrInvalidInput, kind)
+	}
+	if len(name) > MaxScoutMetricsLabelLength {
+		return fmt.Errorf("%w: %s name exceeds %d characters", ErrInvalidInput, kind, MaxScoutMetricsLabelLength)
+	}
+	return nil
+}
+
+// ScoutMetricsQuery selects the completed Scout runs aggregated by a
+// metrics summary.
+type ScoutMetricsQuery struct {
+	Source    *string    `json:"source,omitempty"` // Runs of this source, or with it in their breakdown
+	TimeRange *TimeRange `json:"time_range,omitempty"`
+}
+
+// ScoutMetricsSummary aggregates the metrics of completed Scout runs. The
+// per-source breakdown attributes runs that did not report one to the run's
+// source.
+type ScoutMetricsSummary struct {
+	Runs           int          `json:"runs"`
+	Metrics        ScoutMetrics `json:"metrics"`
+	ValidationRate float64      `json:"validation_rate"`
+	SubmissionRate float64      `json:"submission_rate"`
+	Timezone       string       `json:"timezone,omitempty"` // Server timezone of the timestamps
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxSecretValueSize is the largest secret value accepted, in bytes.
+const MaxSecretValueSize = 8 * 1024
+
+// secretNameRegex restricts secret names, e.g. "github-token".
+var secretNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,128}$`)
+
+// Secret is a credential, such as an API token for a scout source, stored
+// encrypted. Other entities reference it by ID; the plaintext is only
+// decrypted inside the backend when it is used and is never returned by the
+// API.
+type Secret struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	KeyID       string    `json:"key_id"` // Master key the value is encrypted under
+	Ciphertext  []byte    `json:"-"`
+	Nonce       []byte    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ValidateSecretName checks that a secret name is 1-128 characters of
+// letters, digits, '_', '.' or '-'.
+func ValidateSecretName(name string) error {
+	if !secretNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: secret name must be 1-128 characters of letters, digits, '_', '.' or '-'", ErrInvalidInput)
+	}
+	return nil
+}
+
+// ValidateSecretValue checks that a secret value is non-empty and at most
+// MaxSecretValueSize bytes.
+func ValidateSecretValue(value string) error {
+	if value == "" {
+		return fmt.Errorf("%w: secret value is required", ErrInvalidInput)
+	}
+	if len(value) > MaxSecretValueSize {
+		return fmt.Errorf("%w: secret value exceeds %d bytes", ErrInvalidInput, MaxSecretValueSize)
+	}
+	return nil
+}
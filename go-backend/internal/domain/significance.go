@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SignificanceMethod is the statistical test comparing two sets of per-trade
+// returns.
+type SignificanceMethod string
+
+const (
+	// SignificanceWelchT is Welch's t-test on the mean trade return, which
+	// does not assume equal variances.
+	SignificanceWelchT SignificanceMethod = "welch_t"
+
+	// SignificanceBootstrap resamples the trades of each side with
+	// replacement, making no assumption about the return distribution.
+	SignificanceBootstrap SignificanceMethod = "bootstrap"
+)
+
+// ParseSignificanceMethod parses a method name; empty selects Welch's t-test.
+func ParseSignificanceMethod(s string) (SignificanceMethod, error) {
+	switch SignificanceMethod(s) {
+	case "":
+		return SignificanceWelchT, nil
+	case SignificanceWelchT, SignificanceBootstrap:
+		return SignificanceMethod(s), nil
+	}
+	return "", fmt.Errorf("%w: unknown method %q, expected welch_t or bootstrap", ErrInvalidInput, s)
+}
+
+// Defaults and limits for significance tests.
+const (
+	DefaultSignificanceAlpha   = 0.05
+	DefaultBootstrapIterations = 2000
+	MaxBootstrapIterations     = 100000
+
+	// MinSignificanceTrades is the fewest trades a side needs to estimate
+	// its variance.
+	MinSignificanceTrades = 2
+)
+
+// SignificanceSide selects the trades of one side of a comparison: those of a
+// single result, or pooled over all current results of a strategy.
+type SignificanceSide struct {
+	ResultID   *uuid.UUID `json:"result_id,omitempty"`
+	StrategyID *uuid.UUID `json:"strategy_id,omitempty"`
+}
+
+// Validate checks that exactly one of result_id and strategy_id is set.
+func (s SignificanceSide) Validate() error {
+	if (s.ResultID == nil) == (s.StrategyID == nil) {
+		return fmt.Errorf("%w: exactly one of result_id and strategy_id is required", ErrInvalidInput)
+	}
+	return nil
+}
+
+// TradeSample summarizes the per-trade returns of one side.
+type TradeSample struct {
+	Trades         int     `json:"trades"`
+	MeanReturnPct  float64 `json:"mean_return_pct"`
+	StdDevPct      float64 `json:"stddev_pct"`
+	TotalReturnPct float64 `json:"total_return_pct"` // Sum of trade returns
+}
+
+// SignificanceReport is the outcome of testing whether side B's mean trade
+// return differs from side A's.
+type SignificanceReport struct {
+	Method SignificanceMethod `json:"method"`
+	Alpha  float64            `json:"alpha"`
+	A      TradeSample        `json:"a"`
+	B      TradeSample        `json:"b"`
+
+	// DifferencePct is B's mean trade return minus A's, and ConfidenceLow
+	// and ConfidenceHigh bound it at the 1 - alpha level.
+	DifferencePct  float64 `json:"difference_pct"`
+	ConfidenceLow  float64 `json:"confidence_low"`
+	ConfidenceHigh float64 `json:"confidence_high"`
+
+	// PValue is the two-sided probability of a difference at least this
+	// large if both sides performed the same.
+	PValue float64 `json:"p_value"`
+
+	// Welch's t-test only
+	TStatistic       *float64 `json:"t_statistic,omitempty"`
+	DegreesOfFreedom *float64 `json:"degrees_of_freedom,omitempty"`
+
+	// Bootstrap only
+	Iterations int `json:"iterations,omitempty"`
+
+	// Significant is whether PValue is below Alpha; Better is then "a" or
+	// "b", the side with the higher mean return.
+	Significant bool   `json:"significant"`
+	Better      string `json:"better,omitempty"`
+}
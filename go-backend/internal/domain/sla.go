@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SLAKind identifies which queue service level target a job breached.
+type SLAKind string
+
+const (
+	// SLAKindQueueWait is breached by pending jobs that have not started in time.
+	SLAKindQueueWait SLAKind = "queue_wait"
+	// SLAKindRunDuration is breached by running jobs that have not finished in time.
+	SLAKindRunDuration SLAKind = "run_duration"
+)
+
+// SLATargets are the queue service level targets. A zero target is not tracked.
+type SLATargets struct {
+	MaxQueueWait   time.Duration
+	MaxRunDuration time.Duration
+}
+
+// Target returns the target for the given kind.
+func (t SLATargets) Target(kind SLAKind) time.Duration {
+	switch kind {
+	case SLAKindQueueWait:
+		return t.MaxQueueWait
+	case SLAKindRunDuration:
+		return t.MaxRunDuration
+	default:
+		return 0
+	}
+}
+
+// SLABreach is a job that currently exceeds a service level target.
+type SLABreach struct {
+	Kind              SLAKind    `json:"kind"`
+	JobID             uuid.UUID  `json:"job_id"`
+	StrategyID        uuid.UUID  `json:"strategy_id"`
+	OptimizationRunID *uuid.UUID `json:"optimization_run_id,omitempty"`
+	Priority          int        `json:"priority"`
+	Since             time.Time  `json:"since"` // When the job was queued (queue_wait) or started (run_duration)
+	ElapsedMs         int64      `json:"elapsed_ms"`
+	TargetMs          int64      `json:"target_ms"`
+}
+
+// SLASample is one point of the breach time series.
+type SLASample struct {
+	Timestamp           time.Time `json:"timestamp"`
+	QueueWaitBreaches   int       `json:"queue_wait_breaches"`
+	RunDurationBreaches int       `json:"run_duration_breaches"`
+	NewBreaches         int       `json:"new_breaches"` // Breaches first seen in this sample
+}
+
+// SLAStatus summarizes the latest SLA check.
+type SLAStatus struct {
+	MaxQueueWaitMs      int64             `json:"max_queue_wait_ms"`
+	MaxRunDurationMs    int64             `json:"max_run_duration_ms"`
+	QueueWaitBreaches   int               `json:"queue_wait_breaches"`
+	RunDurationBreaches int               `json:"run_duration_breaches"`
+	BreachesTotal       map[SLAKind]int64 `json:"breaches_total"` // Distinct breaches since startup
+	LastCheckAt         *time.Time        `json:"last_check_at,omitempty"`
+}
+
+// SLAReport is the breach time series along with the jobs currently in breach.
+type SLAReport struct {
+	SLAStatus
+	Breaches []*SLABreach `json:"breaches"`
+	Samples  []SLASample  `json:"samples"`
+	Timezone string       `json:"timezone,omitempty"` // Server timezone of the timestamps
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StarEntityType represents the kind of entity that can be starred.
+type StarEntityType string
+
+const (
+	StarEntityStrategy        StarEntityType = "strategy"
+	StarEntityOptimizationRun StarEntityType = "optimization_run"
+)
+
+// IsValid returns true if the entity type is valid.
+func (t StarEntityType) IsValid() bool {
+	switch t {
+	case StarEntityStrategy, StarEntityOptimizationRun:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the entity type.
+func (t StarEntityType) String() string {
+	return string(t)
+}
+
+// Star represents a user's star on a strategy or optimization run.
+type Star struct {
+	Owner      string         `json:"owner"`
+	EntityType StarEntityType `json:"entity_type"`
+	EntityID   uuid.UUID      `json:"entity_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"regexp"
 	"strings"
 	"time"
@@ -72,24 +74,64 @@ type Strategy struct {
 	Indicators                 []string           `json:"indicators,omitempty"`
 	MinimalROI                 map[string]float64 `json:"minimal_roi,omitempty"`
 
+	// Archival (archived strategies are hidden from default search results)
+	ArchivedAt    *time.Time `json:"archived_at,omitempty"`
+	ArchiveReason *string    `json:"archive_reason,omitempty"`
+
+	// Validation (set by ValidateStrategy or by the importer, e.g. Scout)
+	ValidationStatus ValidationStatus `json:"validation_status"`
+	ValidationErrors []string         `json:"validation_errors,omitempty"`
+	ValidatedAt      *time.Time       `json:"validated_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// IsArchived returns true if the strategy has been archived.
+func (s *Strategy) IsArchived() bool {
+	return s.ArchivedAt != nil
+}
+
+// CheckSubmittable reports whether backtests may be submitted for the strategy.
+// Strategies that failed validation are rejected with ErrStrategyValidationFailed
+// unless override is set; a warning is returned for strategies that failed
+// (with override) or were never validated.
+func (s *Strategy) CheckSubmittable(override bool) (warning string, err error) {
+	switch s.ValidationStatus {
+	case ValidationStatusFailed:
+		if !override {
+			return "", ErrStrategyValidationFailed
+		}
+		return "strategy " + s.Name + " failed validation; submitted with override", nil
+	case ValidationStatusValidated:
+		return "", nil
+	default:
+		return "strategy " + s.Name + " has not been validated", nil
+	}
+}
+
+// HashCode returns the hash strategies are deduplicated by, as the database
+// computes it for stored strategies.
+func HashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
 // NewStrategy creates a new Strategy with generated UUID and timestamps.
 func NewStrategy(name, code, description string, parentID *uuid.UUID) *Strategy {
 	now := time.Now()
 	return &Strategy{
-		ID:          uuid.New(),
-		Name:        name,
-		Code:        code,
-		Description: description,
-		ParentID:    parentID,
-		Generation:  0, // Will be set by database trigger
-		Indicators:  make([]string, 0),
-		MinimalROI:  make(map[string]float64),
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:               uuid.New(),
+		Name:             name,
+		Code:             code,
+		Description:      description,
+		ParentID:         parentID,
+		Generation:       0, // Will be set by database trigger
+		Indicators:       make([]string, 0),
+		MinimalROI:       make(map[string]float64),
+		ValidationStatus: ValidationStatusUnvalidated,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}
 }
 
@@ -118,38 +160,105 @@ type StrategyLineageNode struct {
 	ParentID   *uuid.UUID             `json:"parent_id,omitempty"`
 	Children   []*StrategyLineageNode `json:"children,omitempty"`
 	Level      int                    `json:"level"` // Distance from the queried node
+
+	// Aggregates of the strategy, so trees can be colored by performance
+	BacktestCount int      `json:"backtest_count"`        // Current (not superseded) results
+	BestSharpe    *float64 `json:"best_sharpe,omitempty"` // Best sharpe among them
+	ChildCount    int      `json:"child_count"`           // All direct children, including those beyond the depth
+
+	// Set with IncludeMetrics: the best of each metric among the current
+	// results, and how the best sharpe and profit changed from the parent's.
+	// The changes are only set if the parent is in the tree and both have
+	// results.
+	Metrics     *StrategyPerformanceMetrics `json:"metrics,omitempty"`
+	SharpeDelta *float64                    `json:"sharpe_delta,omitempty"`
+	ProfitDelta *float64                    `json:"profit_delta,omitempty"`
+}
+
+// SetDeltas sets the changes in best sharpe and profit of the node's
+// descendants from their parents'.
+func (n *StrategyLineageNode) SetDeltas() {
+	for _, child := range n.Children {
+		if n.Metrics != nil && child.Metrics != nil {
+			profit := child.Metrics.ProfitPct - n.Metrics.ProfitPct
+			child.ProfitDelta = &profit
+			if n.Metrics.SharpeRatio != nil && child.Metrics.SharpeRatio != nil {
+				sharpe := *child.Metrics.SharpeRatio - *n.Metrics.SharpeRatio
+				child.SharpeDelta = &sharpe
+			}
+		}
+		child.SetDeltas()
+	}
+}
+
+// LineageDirection selects the relatives of a strategy its lineage includes.
+type LineageDirection string
+
+const (
+	LineageDown LineageDirection = "down" // Descendants
+	LineageUp   LineageDirection = "up"   // Ancestors
+	LineageBoth LineageDirection = "both" // Ancestors and descendants
+)
+
+// IsValid reports whether the direction is known.
+func (d LineageDirection) IsValid() bool {
+	switch d {
+	case LineageDown, LineageUp, LineageBoth:
+		return true
+	default:
+		return false
+	}
+}
+
+// StrategyLineageQuery selects the lineage tree of a strategy. Walking up,
+// the tree is rooted at the furthest ancestor included, whose level is
+// negative, and holds only the line down to the strategy; walking down it
+// holds all descendants.
+type StrategyLineageQuery struct {
+	Depth          int              `json:"depth"`           // Generations walked in each direction
+	Direction      LineageDirection `json:"direction"`       // Defaults to down
+	IncludeMetrics bool             `json:"include_metrics"` // Annotate nodes with their results' metrics
+}
+
+// SetDefaults applies default values to the query.
+func (q *StrategyLineageQuery) SetDefaults() {
+	if q.Depth <= 0 {
+		q.Depth = 2
+	}
+	if q.Depth > 100 {
+		q.Depth = 100
+	}
+	if q.Direction == "" {
+		q.Direction = LineageDown
+	}
 }
 
 // StrategySearchQuery represents query parameters for searching strategies.
 type StrategySearchQuery struct {
-	NamePattern    *string  `json:"name_pattern,omitempty"`
-	MinSharpe      *float64 `json:"min_sharpe,omitempty"`
-	MinProfitPct   *float64 `json:"min_profit_pct,omitempty"`
-	MaxDrawdownPct *float64 `json:"max_drawdown_pct,omitempty"`
-	MinTrades      *int     `json:"min_trades,omitempty"`
-	MinGeneration  *int     `json:"min_generation,omitempty"`
-	MaxGeneration  *int     `json:"max_generation,omitempty"`
-	ParentID       *string  `json:"parent_id,omitempty"`
-	OrderBy        string   `json:"order_by,omitempty"` // "sharpe", "profit", "created_at", "generation"
-	Ascending      bool     `json:"ascending,omitempty"`
-	Page           int      `json:"page"`
-	PageSize       int      `json:"page_size"`
-}
-
-// SetDefaults sets default values for the search query.
-func (q *StrategySearchQuery) SetDefaults() {
+	NamePattern      *string           `json:"name_pattern,omitempty"`
+	MinSharpe        *float64          `json:"min_sharpe,omitempty"`
+	MinProfitPct     *float64          `json:"min_profit_pct,omitempty"`
+	MaxDrawdownPct   *float64          `json:"max_drawdown_pct,omitempty"`
+	MinTrades        *int              `json:"min_trades,omitempty"`
+	MinGeneration    *int              `json:"min_generation,omitempty"`
+	MaxGeneration    *int              `json:"max_generation,omitempty"`
+	ParentID         *string           `json:"parent_id,omitempty"`
+	StarredBy        *string           `json:"starred_by,omitempty"` // Only strategies starred by this owner
+	ValidationStatus *ValidationStatus `json:"validation_status,omitempty"`
+	IncludeArchived  bool              `json:"include_archived,omitempty"`
+	OrderBy          string            `json:"order_by,omitempty"` // "sharpe", "profit", "created_at", "generation"
+	Ascending        bool              `json:"ascending,omitempty"`
+	Page             int               `json:"page"`
+	PageSize         int               `json:"page_size"`
+}
+
+// SetDefaults sets default values for the search query, capping the page
+// size. It reports whether the requested page size was clamped.
+func (q *StrategySearchQuery) SetDefaults() bool {
 	if q.OrderBy == "" {
 		q.OrderBy = "created_at"
 	}
-	if q.Page <= 0 {
-		q.Page = 1
-	}
-	if q.PageSize <= 0 {
-		q.PageSize = 20
-	}
-	if q.PageSize > 100 {
-		q.PageSize = 100
-	}
+	return applyPageDefaults(PageResourceStrategies, &q.Page, &q.PageSize)
 }
 
 // Offset returns the offset for pagination.
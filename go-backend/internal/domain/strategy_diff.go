@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultDiffContextLines is the number of unchanged lines shown around
+	// each change of a code diff.
+	DefaultDiffContextLines = 3
+
+	// MaxDiffContextLines is the most context lines a diff may ask for.
+	MaxDiffContextLines = 100
+)
+
+// StrategyDiff compares two strategies: a unified diff of their code and the
+// settings that differ. From is the strategy compared against, usually the
+// parent, so the diff shows what changed to produce To.
+type StrategyDiff struct {
+	FromID uuid.UUID `json:"from_id"`
+	ToID   uuid.UUID `json:"to_id"`
+
+	Identical    bool   `json:"identical"`    // Same code
+	UnifiedDiff  string `json:"unified_diff"` // Empty when the code is identical
+	LinesAdded   int    `json:"lines_added"`
+	LinesRemoved int    `json:"lines_removed"`
+
+	Changes           []SettingChange `json:"changes"`
+	IndicatorsAdded   []string        `json:"indicators_added"`
+	IndicatorsRemoved []string        `json:"indicators_removed"`
+}
+
+// SettingChange is a strategy setting that differs between two strategies,
+// e.g. "stoploss", "minimal_roi" or "hyperopt.buy_rsi". Values are formatted
+// as in strategy code; an empty value means the setting is unset.
+type SettingChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ValidateDiffContextLines checks the number of context lines of a diff.
+func ValidateDiffContextLines(n int) error {
+	if n < 0 || n > MaxDiffContextLines {
+		return fmt.Errorf("%w: context must be between 0 and %d", ErrInvalidInput, MaxDiffContextLines)
+	}
+	return nil
+}
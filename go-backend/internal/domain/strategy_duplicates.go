@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateMatch is how closely the strategies of a duplicate cluster match.
+type DuplicateMatch string
+
+const (
+	// DuplicateMatchExact means the code is identical apart from the name of
+	// the strategy class. Stored code is unique, so a rename is the closest
+	// two strategies can match.
+	DuplicateMatchExact DuplicateMatch = "exact"
+
+	// DuplicateMatchNormalized means the code is identical once comments,
+	// docstrings and whitespace are ignored as well.
+	DuplicateMatchNormalized DuplicateMatch = "normalized"
+)
+
+// StrategyDuplicatesQuery selects how duplicate strategies are detected.
+type StrategyDuplicatesQuery struct {
+	Normalized      bool // Also ignore comments, docstrings and whitespace
+	IncludeArchived bool
+}
+
+// DuplicateStrategy is a strategy of a duplicate cluster.
+type DuplicateStrategy struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	CodeHash   string     `json:"code_hash"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+// DuplicateCluster is a group of strategies with matching code.
+type DuplicateCluster struct {
+	Match       DuplicateMatch      `json:"match"`
+	Fingerprint string              `json:"fingerprint"` // SHA256 of the compared code
+	KeepID      uuid.UUID           `json:"keep_id"`     // The oldest strategy; the others duplicate it
+	Strategies  []DuplicateStrategy `json:"strategies"`  // Oldest first
+}
+
+// StrategyDuplicatesReport lists the clusters of duplicate strategies,
+// largest first.
+type StrategyDuplicatesReport struct {
+	Scanned    int                `json:"scanned"`
+	Duplicates int                `json:"duplicates"` // Strategies other than the kept one of each cluster
+	Clusters   []DuplicateCluster `json:"clusters"`
+}
@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BundledPackages lists the top-level third-party packages installed in the
+// backtest image (freqtrade's freqai image plus the indicator libraries added
+// by docker/freqtrade/Dockerfile). Strategies may import these and the Python
+// standard library; anything else fails to load in the container.
+var BundledPackages = []string{
+	"freqtrade", "numpy", "pandas", "talib", "technical", "pandas_ta",
+	"scipy", "sklearn", "joblib", "lightgbm", "xgboost", "catboost", "datasieve", "tensorboard", "optuna",
+	"ccxt", "arrow", "cachetools", "dateutil", "pytz", "requests", "rapidjson", "orjson",
+	"sqlalchemy", "tabulate", "pydantic", "typing_extensions",
+}
+
+// pythonStdlibModules lists the top-level modules of the Python 3.12 standard library.
+var pythonStdlibModules = []string{
+	"__future__", "abc", "aifc", "antigravity", "argparse", "array", "ast", "asyncio",
+	"atexit", "audioop", "base64", "bdb", "binascii", "bisect", "builtins", "bz2", "cProfile",
+	"calendar", "cgi", "cgitb", "chunk", "cmath", "cmd", "code", "codecs", "codeop",
+	"collections", "colorsys", "compileall", "concurrent", "configparser", "contextlib",
+	"contextvars", "copy", "copyreg", "crypt", "csv", "ctypes", "curses", "dataclasses",
+	"datetime", "dbm", "decimal", "difflib", "dis", "doctest", "email", "encodings",
+	"ensurepip", "enum", "errno", "faulthandler", "fcntl", "filecmp", "fileinput", "fnmatch",
+	"fractions", "ftplib", "functools", "gc", "genericpath", "getopt", "getpass", "gettext",
+	"glob", "graphlib", "grp", "gzip", "hashlib", "heapq", "hmac", "html", "http", "idlelib",
+	"imaplib", "imghdr", "importlib", "inspect", "io", "ipaddress", "itertools", "json",
+	"keyword", "lib2to3", "linecache", "locale", "logging", "lzma", "mailbox", "mailcap",
+	"marshal", "math", "mimetypes", "mmap", "modulefinder", "msilib", "msvcrt",
+	"multiprocessing", "netrc", "nis", "nntplib", "nt", "ntpath", "nturl2path", "numbers",
+	"opcode", "operator", "optparse", "os", "ossaudiodev", "pathlib", "pdb", "pickle",
+	"pickletools", "pipes", "pkgutil", "platform", "plistlib", "poplib", "posix", "posixpath",
+	"pprint", "profile", "pstats", "pty", "pwd", "py_compile", "pyclbr", "pydoc",
+	"pydoc_data", "pyexpat", "queue", "quopri", "random", "re", "readline", "reprlib",
+	"resource", "rlcompleter", "runpy", "sched", "secrets", "select", "selectors", "shelve",
+	"shlex", "shutil", "signal", "site", "smtplib", "sndhdr", "socket", "socketserver",
+	"spwd", "sqlite3", "sre_compile", "sre_constants", "sre_parse", "ssl", "stat",
+	"statistics", "string", "stringprep", "struct", "subprocess", "sunau", "symtable", "sys",
+	"sysconfig", "syslog", "tabnanny", "tarfile", "telnetlib", "tempfile", "termios",
+	"textwrap", "this", "threading", "time", "timeit", "tkinter", "token", "tokenize",
+	"tomllib", "trace", "traceback", "tracemalloc", "tty", "turtle", "turtledemo", "types",
+	"typing", "unicodedata", "unittest", "urllib", "uu", "uuid", "venv", "warnings", "wave",
+	"weakref", "webbrowser", "winreg", "winsound", "wsgiref", "xdrlib", "xml", "xmlrpc",
+	"zipapp", "zipfile", "zipimport", "zlib", "zoneinfo",
+}
+
+// importReplacements suggests bundled alternatives for packages scraped
+// strategies commonly depend on.
+var importReplacements = map[string]string{
+	"qtpylib": `use "import freqtrade.vendor.qtpylib.indicators as qtpylib" instead`,
+	"ta":      "use talib.abstract, pandas_ta or technical.indicators instead",
+	"finta":   "use talib.abstract, pandas_ta or technical.indicators instead",
+	"tulipy":  "use talib.abstract, pandas_ta or technical.indicators instead",
+	"btalib":  "use talib.abstract, pandas_ta or technical.indicators instead",
+}
+
+// ImportAllowlist is the set of top-level packages a strategy may import.
+type ImportAllowlist map[string]struct{}
+
+// NewImportAllowlist returns the standard library and BundledPackages, plus
+// the extra packages installed in a customized backtest image.
+func NewImportAllowlist(extra ...string) ImportAllowlist {
+	allowed := make(ImportAllowlist, len(pythonStdlibModules)+len(BundledPackages)+len(extra))
+	for _, lists := range [][]string{pythonStdlibModules, BundledPackages, extra} {
+		for _, pkg := range lists {
+			allowed[pkg] = struct{}{}
+		}
+	}
+	return allowed
+}
+
+// Allows reports whether the top-level package of a module may be imported.
+func (a ImportAllowlist) Allows(module string) bool {
+	pkg, _, _ := strings.Cut(module, ".")
+	_, ok := a[pkg]
+	return ok
+}
+
+// Packages returns the allowed third-party packages, sorted.
+func (a ImportAllowlist) Packages() []string {
+	stdlib := make(map[string]struct{}, len(pythonStdlibModules))
+	for _, m := range pythonStdlibModules {
+		stdlib[m] = struct{}{}
+	}
+	var pkgs []string
+	for pkg := range a {
+		if _, ok := stdlib[pkg]; !ok {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+// UnresolvedImport is an import of a strategy that the backtest image cannot
+// satisfy, typically a helper module (custom indicators) that was published
+// next to a scraped strategy. Fix tells the Engineer agent what to change.
+type UnresolvedImport struct {
+	Module  string   `json:"module"`            // As written, e.g. "custom_indicators.momentum" or ".helpers"
+	Package string   `json:"package,omitempty"` // Top-level package; empty for relative imports
+	Names   []string `json:"names,omitempty"`   // Names imported from the module, i.e. what to inline
+	Line    int      `json:"line"`
+	// Optional is set for imports that are not at module level, e.g. in a
+	// try block guarding an optional dependency; they may never run.
+	Optional bool   `json:"optional"`
+	Fix      string `json:"fix"`
+}
+
+// Message describes the import and its fix in one line.
+func (u UnresolvedImport) Message() string {
+	return fmt.Sprintf("unresolved import %q (line %d): %s", u.Module, u.Line, u.Fix)
+}
+
+// AnalyzeImports statically lists the imports of a strategy's Python code
+// whose top-level package is not allowed, in source order. Imports in
+// strings, such as docstrings, are ignored.
+func AnalyzeImports(code string, allowed ImportAllowlist) []UnresolvedImport {
+	var unresolved []UnresolvedImport
+	for _, stmt := range importStatements(code) {
+		for _, imp := range parseImportStatement(stmt.text) {
+			if !imp.relative && allowed.Allows(imp.module) {
+				continue
+			}
+			u := UnresolvedImport{
+				Module:   imp.module,
+				Names:    imp.names,
+				Line:     stmt.line,
+				Optional: stmt.indented,
+			}
+			if !imp.relative {
+				u.Package, _, _ = strings.Cut(imp.module, ".")
+			}
+			u.Fix = importFix(u)
+			unresolved = append(unresolved, u)
+		}
+	}
+	return unresolved
+}
+
+// importFix suggests how to remove an unresolved import.
+func importFix(u UnresolvedImport) string {
+	if fix, ok := importReplacements[u.Package]; ok {
+		return fix
+	}
+	what := "the code used from " + u.Module
+	if len(u.Names) > 0 && u.Names[0] != "*" {
+		what = strings.Join(u.Names, ", ") + " from " + u.Module
+	}
+	if u.Package == "" || u.Package == "user_data" {
+		return fmt.Sprintf("inline %s into the strategy file; only the strategy file is copied into the backtest container", what)
+	}
+	return fmt.Sprintf("package %s is not installed in the backtest image; inline %s into the strategy file or use a bundled package", u.Package, what)
+}
+
+// importStatement is a logical line of code starting with import or from.
+type importStatement struct {
+	text     string
+	line     int
+	indented bool
+}
+
+// importStatements splits code into logical lines, joining backslash
+// continuations and parenthesized import lists, and returns the import
+// statements among them.
+func importStatements(code string) []importStatement {
+	var stmts []importStatement
+	var quote string // Delimiter of the triple-quoted string being skipped
+	var current *importStatement
+
+	for i, line := range strings.Split(code, "\n") {
+		if current != nil {
+			current.text += " " + strings.TrimSpace(stripComment(line))
+			if !importContinues(current.text) {
+				stmts = append(stmts, *current)
+				current = nil
+			}
+			continue
+		}
+
+		if quote != "" {
+			if strings.Count(line, quote)%2 == 1 {
+				quote = ""
+			}
+			continue
+		}
+
+		for _, q := range []string{`"""`, "'''"} {
+			if strings.Count(line, q)%2 == 1 {
+				quote = q
+				break
+			}
+		}
+		trimmed := strings.TrimSpace(stripComment(line))
+		if quote != "" || !(strings.HasPrefix(trimmed, "import ") || strings.HasPrefix(trimmed, "from ")) {
+			continue
+		}
+
+		for _, part := range strings.Split(trimmed, ";") {
+			part = strings.TrimSpace(part)
+			stmt := importStatement{text: part, line: i + 1, indented: line[0] == ' ' || line[0] == '\t'}
+			if importContinues(part) {
+				current = &stmt
+				break
+			}
+			stmts = append(stmts, stmt)
+		}
+	}
+	if current != nil {
+		stmts = append(stmts, *current)
+	}
+	return stmts
+}
+
+// importContinues reports whether an import statement continues on the next line.
+func importContinues(stmt string) bool {
+	return strings.HasSuffix(stmt, "\\") || strings.Count(stmt, "(") > strings.Count(stmt, ")")
+}
+
+// stripComment removes a trailing comment from a line.
+func stripComment(line string) string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// parsedImport is a module imported by a statement.
+type parsedImport struct {
+	module   string
+	names    []string
+	relative bool
+}
+
+// parseImportStatement returns the modules an import statement imports.
+func parseImportStatement(stmt string) []parsedImport {
+	stmt = strings.NewReplacer("\\", " ", "(", " ", ")", " ").Replace(stmt)
+
+	if rest, ok := strings.CutPrefix(stmt, "import "); ok {
+		var imports []parsedImport
+		for _, item := range strings.Split(rest, ",") {
+			if module := importedName(item); module != "" {
+				imports = append(imports, parsedImport{module: module})
+			}
+		}
+		return imports
+	}
+
+	rest, _ := strings.CutPrefix(stmt, "from ")
+	module, names, ok := strings.Cut(rest, " import ")
+	module = strings.TrimSpace(module)
+	if !ok || module == "" {
+		return nil
+	}
+	imp := parsedImport{module: module, relative: strings.HasPrefix(module, ".")}
+	for _, item := range strings.Split(names, ",") {
+		if name := importedName(item); name != "" {
+			imp.names = append(imp.names, name)
+		}
+	}
+	return []parsedImport{imp}
+}
+
+// importedName returns the name of an import list item, without its alias.
+func importedName(item string) string {
+	fields := strings.Fields(item)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
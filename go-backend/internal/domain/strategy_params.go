@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// timeframeRegex matches Freqtrade timeframes such as 5m, 1h, 1d.
+var timeframeRegex = regexp.MustCompile(`^\d+[smhdwM]$`)
+
+// HyperoptParamType is the Freqtrade parameter class of a hyperopt parameter.
+type HyperoptParamType string
+
+const (
+	HyperoptParamInt         HyperoptParamType = "int"
+	HyperoptParamDecimal     HyperoptParamType = "decimal"
+	HyperoptParamReal        HyperoptParamType = "real"
+	HyperoptParamBoolean     HyperoptParamType = "boolean"
+	HyperoptParamCategorical HyperoptParamType = "categorical"
+)
+
+// ROIStep is a single entry of a strategy's minimal_roi table.
+type ROIStep struct {
+	Minutes int     `json:"minutes"`
+	ROI     float64 `json:"roi"`
+}
+
+// HyperoptParam describes a hyperoptable parameter declared on a strategy class.
+type HyperoptParam struct {
+	Name     string            `json:"name"`
+	Type     HyperoptParamType `json:"type"`
+	Default  interface{}       `json:"default"`
+	Low      *float64          `json:"low,omitempty"`
+	High     *float64          `json:"high,omitempty"`
+	Options  []interface{}     `json:"options,omitempty"`
+	Decimals *int              `json:"decimals,omitempty"`
+	Space    string            `json:"space,omitempty"`
+	Optimize *bool             `json:"optimize,omitempty"`
+}
+
+// StrategyParams is a structured, editable view of the tunable settings in strategy code.
+type StrategyParams struct {
+	MinimalROI                  []ROIStep       `json:"minimal_roi"`
+	Stoploss                    *float64        `json:"stoploss,omitempty"`
+	TrailingStop                *bool           `json:"trailing_stop,omitempty"`
+	TrailingStopPositive        *float64        `json:"trailing_stop_positive,omitempty"`
+	TrailingStopPositiveOffset  *float64        `json:"trailing_stop_positive_offset,omitempty"`
+	TrailingOnlyOffsetIsReached *bool           `json:"trailing_only_offset_is_reached,omitempty"`
+	Timeframe                   *string         `json:"timeframe,omitempty"`
+	HyperoptParams              []HyperoptParam `json:"hyperopt_params"`
+}
+
+// HyperoptParam returns the hyperopt parameter with the given name, or nil.
+func (p *StrategyParams) HyperoptParam(name string) *HyperoptParam {
+	for i := range p.HyperoptParams {
+		if p.HyperoptParams[i].Name == name {
+			return &p.HyperoptParams[i]
+		}
+	}
+	return nil
+}
+
+// ApplyTo copies the parsed settings into the strategy's metadata columns.
+// Settings not declared in code leave the existing metadata untouched.
+func (p *StrategyParams) ApplyTo(s *Strategy) {
+	if len(p.MinimalROI) > 0 {
+		s.MinimalROI = make(map[string]float64, len(p.MinimalROI))
+		for _, step := range p.MinimalROI {
+			s.MinimalROI[strconv.Itoa(step.Minutes)] = step.ROI
+		}
+	}
+	if p.Stoploss != nil {
+		s.Stoploss = p.Stoploss
+	}
+	if p.TrailingStop != nil {
+		s.TrailingStop = *p.TrailingStop
+	}
+	if p.TrailingStopPositive != nil {
+		s.TrailingStopPositive = p.TrailingStopPositive
+	}
+	if p.TrailingStopPositiveOffset != nil {
+		s.TrailingStopPositiveOffset = p.TrailingStopPositiveOffset
+	}
+	if p.Timeframe != nil {
+		s.Timeframe = *p.Timeframe
+	}
+}
+
+// StrategyParamsPatch lists parameter changes to write back into strategy code.
+// Nil fields are left unchanged.
+type StrategyParamsPatch struct {
+	MinimalROI                  []ROIStep              `json:"minimal_roi,omitempty"`
+	Stoploss                    *float64               `json:"stoploss,omitempty"`
+	TrailingStop                *bool                  `json:"trailing_stop,omitempty"`
+	TrailingStopPositive        *float64               `json:"trailing_stop_positive,omitempty"`
+	TrailingStopPositiveOffset  *float64               `json:"trailing_stop_positive_offset,omitempty"`
+	TrailingOnlyOffsetIsReached *bool                  `json:"trailing_only_offset_is_reached,omitempty"`
+	Timeframe                   *string                `json:"timeframe,omitempty"`
+	HyperoptDefaults            map[string]interface{} `json:"hyperopt_defaults,omitempty"`
+}
+
+// IsEmpty returns true if the patch changes nothing.
+func (p *StrategyParamsPatch) IsEmpty() bool {
+	return p.MinimalROI == nil && p.Stoploss == nil && p.TrailingStop == nil &&
+		p.TrailingStopPositive == nil && p.TrailingStopPositiveOffset == nil &&
+		p.TrailingOnlyOffsetIsReached == nil && p.Timeframe == nil && len(p.HyperoptDefaults) == 0
+}
+
+// Validate checks the patch values against Freqtrade's constraints and the
+// declared hyperopt parameters of the current code.
+func (p *StrategyParamsPatch) Validate(current *StrategyParams) error {
+	if p.IsEmpty() {
+		return errors.New("patch contains no changes")
+	}
+
+	seen := map[int]bool{}
+	for _, step := range p.MinimalROI {
+		if step.Minutes < 0 {
+			return errors.New("minimal_roi minutes must be non-negative")
+		}
+		if seen[step.Minutes] {
+			return fmt.Errorf("minimal_roi has duplicate entry for minute %d", step.Minutes)
+		}
+		seen[step.Minutes] = true
+	}
+	if p.Stoploss != nil && (*p.Stoploss >= 0 || *p.Stoploss < -1) {
+		return errors.New("stoploss must be in the range [-1, 0)")
+	}
+	if p.TrailingStopPositive != nil && *p.TrailingStopPositive < 0 {
+		return errors.New("trailing_stop_positive must be non-negative")
+	}
+	if p.TrailingStopPositiveOffset != nil && *p.TrailingStopPositiveOffset < 0 {
+		return errors.New("trailing_stop_positive_offset must be non-negative")
+	}
+	if p.Timeframe != nil && !timeframeRegex.MatchString(*p.Timeframe) {
+		return errors.New("timeframe must look like 5m, 1h, or 1d")
+	}
+
+	for name, value := range p.HyperoptDefaults {
+		param := current.HyperoptParam(name)
+		if param == nil {
+			return fmt.Errorf("unknown hyperopt parameter %q", name)
+		}
+		if err := param.ValidateDefault(value); err != nil {
+			return fmt.Errorf("hyperopt parameter %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateDefault checks that value is a valid default for the parameter.
+func (h *HyperoptParam) ValidateDefault(value interface{}) error {
+	switch h.Type {
+	case HyperoptParamInt, HyperoptParamDecimal, HyperoptParamReal:
+		v, ok := value.(float64)
+		if !ok {
+			return errors.New("default must be a number")
+		}
+		if h.Type == HyperoptParamInt && v != math.Trunc(v) {
+			return errors.New("default must be an integer")
+		}
+		if (h.Low != nil && v < *h.Low) || (h.High != nil && v > *h.High) {
+			return errors.New("default is outside the parameter range")
+		}
+	case HyperoptParamBoolean:
+		if _, ok := value.(bool); !ok {
+			return errors.New("default must be a boolean")
+		}
+	case HyperoptParamCategorical:
+		for _, option := range h.Options {
+			if option == value {
+				return nil
+			}
+		}
+		return errors.New("default must be one of the declared options")
+	}
+	return nil
+}
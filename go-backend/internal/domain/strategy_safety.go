@@ -0,0 +1,399 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultDeniedImports lists the modules strategies may not import: process
+// and filesystem control, native code, dynamic imports and networking. A
+// strategy only needs the candles Freqtrade hands it.
+var DefaultDeniedImports = []string{
+	"os", "posix", "nt", "subprocess", "pty", "shutil", "ctypes", "multiprocessing", "importlib",
+	"socket", "socketserver", "ssl", "http", "urllib", "urllib3", "requests", "httpx", "aiohttp",
+	"ftplib", "smtplib", "poplib", "imaplib", "telnetlib", "xmlrpc", "webbrowser", "paramiko",
+}
+
+// DefaultDeniedCalls lists the builtins strategies may not call, since they
+// run code not visible to the checks.
+var DefaultDeniedCalls = []string{"eval", "exec", "compile", "__import__", "breakpoint"}
+
+// SafetyViolationKind says which check strategy code failed.
+type SafetyViolationKind string
+
+const (
+	SafetyViolationImport    SafetyViolationKind = "import"
+	SafetyViolationCall      SafetyViolationKind = "call"
+	SafetyViolationFileWrite SafetyViolationKind = "file_write"
+)
+
+// SafetyViolation is a construct in strategy code the safety checks reject.
+type SafetyViolation struct {
+	Kind SafetyViolationKind `json:"kind"`
+	Name string              `json:"name"` // Module imported, builtin called, or the write as written
+	Line int                 `json:"line"`
+}
+
+// Message describes the violation in one line.
+func (v SafetyViolation) Message() string {
+	switch v.Kind {
+	case SafetyViolationImport:
+		return fmt.Sprintf("import of %q is not allowed (line %d)", v.Name, v.Line)
+	case SafetyViolationCall:
+		return fmt.Sprintf("call to %s() is not allowed (line %d)", v.Name, v.Line)
+	default:
+		return fmt.Sprintf("file write outside the strategy directory is not allowed (line %d): %s", v.Line, v.Name)
+	}
+}
+
+// UnsafeCodeError is returned for strategy code that fails the safety checks.
+type UnsafeCodeError struct {
+	Violations []SafetyViolation
+}
+
+func (e *UnsafeCodeError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message()
+	}
+	return "strategy code failed safety checks: " + strings.Join(messages, "; ")
+}
+
+// Unwrap makes unsafe code an invalid input.
+func (e *UnsafeCodeError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// CodeSafetyPolicy statically checks strategy code before it is stored or
+// run: no denied imports, no calls to denied builtins, and no file writes
+// that may leave the strategy directory. Like AnalyzeImports it scans the
+// source rather than parsing Python, so it catches the plain cases, not a
+// determined attacker; the container sandbox remains the real boundary.
+// A nil policy allows everything.
+type CodeSafetyPolicy struct {
+	deniedImports []string
+	deniedCalls   *regexp.Regexp
+}
+
+// NewCodeSafetyPolicy creates a policy. Empty lists use DefaultDeniedImports
+// and DefaultDeniedCalls.
+func NewCodeSafetyPolicy(deniedImports, deniedCalls []string) *CodeSafetyPolicy {
+	if len(deniedImports) == 0 {
+		deniedImports = DefaultDeniedImports
+	}
+	if len(deniedCalls) == 0 {
+		deniedCalls = DefaultDeniedCalls
+	}
+	quoted := make([]string, len(deniedCalls))
+	for i, name := range deniedCalls {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return &CodeSafetyPolicy{
+		deniedImports: deniedImports,
+		// Bare calls only: df.eval() is pandas, not the builtin
+		deniedCalls: regexp.MustCompile(`(?:^|[^\w.])(` + strings.Join(quoted, "|") + `)\s*\(`),
+	}
+}
+
+// Check returns the violations in code, in source order.
+func (p *CodeSafetyPolicy) Check(code string) []SafetyViolation {
+	if p == nil {
+		return nil
+	}
+
+	var violations []SafetyViolation
+	for _, stmt := range importStatements(code) {
+		for _, imp := range parseImportStatement(stmt.text) {
+			if !imp.relative && p.deniesImport(imp.module) {
+				violations = append(violations, SafetyViolation{Kind: SafetyViolationImport, Name: imp.module, Line: stmt.line})
+			}
+		}
+	}
+
+	masked, literals := maskStrings(code)
+	for i, line := range strings.Split(masked, "\n") {
+		for _, match := range p.deniedCalls.FindAllStringSubmatch(line, -1) {
+			violations = append(violations, SafetyViolation{Kind: SafetyViolationCall, Name: match[1], Line: i + 1})
+		}
+		for _, write := range fileWrites(line, literals) {
+			violations = append(violations, SafetyViolation{Kind: SafetyViolationFileWrite, Name: write, Line: i + 1})
+		}
+	}
+
+	// Imports come first on a line
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Line < violations[j].Line })
+	return violations
+}
+
+// Verify returns an UnsafeCodeError listing the violations in code, if any.
+func (p *CodeSafetyPolicy) Verify(code string) error {
+	if violations := p.Check(code); len(violations) > 0 {
+		return &UnsafeCodeError{Violations: violations}
+	}
+	return nil
+}
+
+// deniesImport reports whether a module or a package containing it is denied.
+func (p *CodeSafetyPolicy) deniesImport(module string) bool {
+	for _, denied := range p.deniedImports {
+		if module == denied || strings.HasPrefix(module, denied+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// stringLiteral is a string literal cut out of code by maskStrings.
+type stringLiteral struct {
+	value     string
+	formatted bool // An f-string, whose value is only known at run time
+}
+
+// literalToken is what maskStrings puts in place of the n-th string literal.
+func literalToken(n int) string {
+	return "\x00" + strconv.Itoa(n) + "\x00"
+}
+
+// literalTokenRegex matches a masked string literal, with its prefix.
+var literalTokenRegex = regexp.MustCompile(`^[A-Za-z]{0,2}\x00(\d+)\x00$`)
+
+// maskStrings removes comments from code and replaces each string literal
+// with a token, so code inside strings is not mistaken for calls. Newlines
+// inside triple-quoted strings are kept after the token, so line numbers
+// stay as in the source.
+func maskStrings(code string) (string, []stringLiteral) {
+	var out []byte
+	var literals []stringLiteral
+
+	for i := 0; i < len(code); {
+		c := code[i]
+		switch {
+		case c == '#':
+			for i < len(code) && code[i] != '\n' {
+				i++
+			}
+
+		case c == '"' || c == '\'':
+			delim := string(c)
+			if strings.HasPrefix(code[i:], strings.Repeat(delim, 3)) {
+				delim = strings.Repeat(delim, 3)
+			}
+			start := i + len(delim)
+			end := start
+			for end < len(code) && !strings.HasPrefix(code[end:], delim) {
+				if code[end] == '\\' {
+					end++
+				} else if code[end] == '\n' && len(delim) == 1 {
+					break
+				}
+				end++
+			}
+			end = min(end, len(code))
+			value := code[start:end]
+
+			prefix := strings.ToLower(literalPrefix(out))
+			literals = append(literals, stringLiteral{value: value, formatted: strings.Contains(prefix, "f")})
+			out = append(out, literalToken(len(literals)-1)...)
+			out = append(out, strings.Repeat("\n", strings.Count(value, "\n"))...)
+			i = end
+			if strings.HasPrefix(code[end:], delim) {
+				i += len(delim)
+			}
+
+		default:
+			out = append(out, c)
+			i++
+		}
+	}
+	return string(out), literals
+}
+
+// literalPrefix returns the prefix of a string literal about to start at the
+// end of s, e.g. "f" or "rb".
+func literalPrefix(s []byte) string {
+	i := len(s)
+	for i > 0 && len(s)-i < 2 && strings.IndexByte("rRbBuUfF", s[i-1]) >= 0 {
+		i--
+	}
+	if i > 0 && (s[i-1] == '_' || unicode.IsLetter(rune(s[i-1])) || unicode.IsDigit(rune(s[i-1]))) {
+		// Part of a longer name, not a prefix
+		return ""
+	}
+	return string(s[i:])
+}
+
+var (
+	// openCallRegex matches calls of the open builtin.
+	openCallRegex = regexp.MustCompile(`(?:^|[^\w.])(open)\s*\(`)
+
+	// pathWriteRegex matches methods that write to the path they are called on.
+	pathWriteRegex = regexp.MustCompile(`\.(open|write_text|write_bytes)\s*\(`)
+
+	// frameWriteRegex matches pandas methods that write to the path passed first.
+	frameWriteRegex = regexp.MustCompile(`\.(to_csv|to_json|to_pickle|to_parquet|to_feather|to_hdf|to_excel)\s*\(`)
+
+	// pathReceiverRegex matches a Path built from a single argument, right
+	// before a method call on it.
+	pathReceiverRegex = regexp.MustCompile(`Path\s*\(\s*([^()]*?)\s*\)\s*$`)
+
+	// maskedLiteralRegex matches a token maskStrings put in place of a literal.
+	maskedLiteralRegex = regexp.MustCompile(`\x00(\d+)\x00`)
+)
+
+// fileWrites returns the writes on a masked line whose target may lie
+// outside the strategy directory, as written.
+func fileWrites(line string, literals []stringLiteral) []string {
+	var writes []string
+	unsafe := func(start, end int, target string) {
+		if !insideStrategyDir(target, literals) {
+			writes = append(writes, unmask(line[start:end], literals))
+		}
+	}
+
+	for _, loc := range openCallRegex.FindAllStringSubmatchIndex(line, -1) {
+		args, end := callArgs(line, loc[1])
+		if isWriteMode(argument(args, 1, "mode"), literals) {
+			unsafe(loc[2], end, argument(args, 0, "file"))
+		}
+	}
+
+	for _, loc := range pathWriteRegex.FindAllStringSubmatchIndex(line, -1) {
+		args, end := callArgs(line, loc[1])
+		if line[loc[2]:loc[3]] == "open" && !isWriteMode(argument(args, 0, "mode"), literals) {
+			continue
+		}
+		var target string
+		start := receiverStart(line, loc[0])
+		if m := pathReceiverRegex.FindStringSubmatchIndex(line[:loc[0]]); m != nil {
+			target, start = line[m[2]:m[3]], m[0]
+		}
+		unsafe(start, end, target)
+	}
+
+	for _, loc := range frameWriteRegex.FindAllStringIndex(line, -1) {
+		args, end := callArgs(line, loc[1])
+		target := argument(args, 0, "path_or_buf")
+		if target == "" {
+			target = argument(args, 0, "path")
+		}
+		if target == "" {
+			// Without a path the frame is returned as a string
+			continue
+		}
+		unsafe(receiverStart(line, loc[0]), end, target)
+	}
+
+	return writes
+}
+
+// receiverStart returns where the name a method is called on, ending at end,
+// starts.
+func receiverStart(line string, end int) int {
+	start := end
+	for start > 0 && (line[start-1] == '_' || line[start-1] == '.' ||
+		unicode.IsLetter(rune(line[start-1])) || unicode.IsDigit(rune(line[start-1]))) {
+		start--
+	}
+	return start
+}
+
+// callArgs splits the arguments of a call whose opening parenthesis ends at
+// start, returning them and the index after the call. A call continuing on
+// the next line is cut at the end of this one.
+func callArgs(line string, start int) ([]string, int) {
+	var args []string
+	depth, argStart := 0, start
+	for i := start; i < len(line); i++ {
+		switch line[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				if arg := strings.TrimSpace(line[argStart:i]); arg != "" {
+					args = append(args, arg)
+				}
+				return args, i + 1
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(line[argStart:i]))
+				argStart = i + 1
+			}
+		}
+	}
+	if arg := strings.TrimSpace(line[argStart:]); arg != "" {
+		args = append(args, arg)
+	}
+	return args, len(line)
+}
+
+// argument returns a call argument given by position or keyword, or empty.
+func argument(args []string, position int, keyword string) string {
+	for _, arg := range args {
+		if name, value, ok := strings.Cut(arg, "="); ok && strings.TrimSpace(name) == keyword {
+			return strings.TrimSpace(value)
+		}
+	}
+	if position < len(args) && !strings.Contains(args[position], "=") {
+		return args[position]
+	}
+	return ""
+}
+
+// literalValue returns the value of a masked string literal argument.
+func literalValue(arg string, literals []stringLiteral) (stringLiteral, bool) {
+	m := literalTokenRegex.FindStringSubmatch(arg)
+	if m == nil {
+		return stringLiteral{}, false
+	}
+	n, _ := strconv.Atoi(m[1])
+	if n >= len(literals) {
+		return stringLiteral{}, false
+	}
+	return literals[n], true
+}
+
+// isWriteMode reports whether a file mode argument may open for writing.
+// Only a literal read mode, or none, is known not to.
+func isWriteMode(mode string, literals []stringLiteral) bool {
+	if mode == "" {
+		return false
+	}
+	lit, ok := literalValue(mode, literals)
+	if !ok || lit.formatted {
+		return true
+	}
+	return strings.ContainsAny(lit.value, "wax+")
+}
+
+// insideStrategyDir reports whether a write target is a literal relative
+// path that stays below the working directory.
+func insideStrategyDir(target string, literals []stringLiteral) bool {
+	lit, ok := literalValue(target, literals)
+	if !ok || lit.formatted || lit.value == "" {
+		return false
+	}
+	p := strings.ReplaceAll(lit.value, "\\", "/")
+	if strings.HasPrefix(p, "/") || strings.HasPrefix(p, "~") || (len(p) > 1 && p[1] == ':') {
+		return false
+	}
+	clean := path.Clean(p)
+	return clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
+// unmask puts the string literals back into masked code.
+func unmask(s string, literals []stringLiteral) string {
+	return maskedLiteralRegex.ReplaceAllStringFunc(s, func(token string) string {
+		n, _ := strconv.Atoi(strings.Trim(token, "\x00"))
+		if n >= len(literals) {
+			return token
+		}
+		return strconv.Quote(literals[n].value)
+	})
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StrategyVersion is a snapshot of a strategy taken when it was created and
+// after each update, numbered from 1.
+type StrategyVersion struct {
+	ID          uuid.UUID  `json:"id"`
+	StrategyID  uuid.UUID  `json:"strategy_id"`
+	Version     int        `json:"version"`
+	Name        string     `json:"name"`
+	Code        string     `json:"code,omitempty"` // Left out of version listings unless asked for
+	CodeHash    string     `json:"code_hash"`
+	Description string     `json:"description,omitempty"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+
+	// RolledBackFrom is the version this one restored, if it was created by
+	// a rollback.
+	RolledBackFrom *int `json:"rolled_back_from,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
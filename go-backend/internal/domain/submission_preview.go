@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SubmissionPreview describes what submitting a backtest job would do. Dry-run
+// submissions return it after running every check of a real submission,
+// without persisting the job, so agents can pre-check candidates cheaply.
+type SubmissionPreview struct {
+	// QueuePosition is the number of pending jobs that would run before the job.
+	QueuePosition int `json:"queue_position"`
+	RunningJobs   int `json:"running_jobs"`
+	Workers       int `json:"workers"`
+
+	// Estimates are simulated from recent job runtimes and are unset when
+	// there is no runtime history or no worker.
+	EstimatedRuntimeMs       *int64 `json:"estimated_runtime_ms,omitempty"`
+	EstimatedWaitMs          *int64 `json:"estimated_wait_ms,omitempty"`
+	EstimatedCompletionMs    *int64 `json:"estimated_completion_ms,omitempty"`
+	EstimatedCompletionP90Ms *int64 `json:"estimated_completion_p90_ms,omitempty"`
+
+	// NewCoverage lists the parts of the job's pairs, timeframe and timerange
+	// that no completed backtest of the strategy covers yet.
+	NewCoverage CoverageGaps `json:"new_coverage"`
+}
+
+// CoverageTargetOf returns the coverage target spanned by a backtest config.
+func CoverageTargetOf(cfg BacktestConfig) CoverageTarget {
+	target := CoverageTarget{
+		Pairs:          cfg.Pairs,
+		TimerangeStart: cfg.TimerangeStart,
+		TimerangeEnd:   cfg.TimerangeEnd,
+	}
+	if cfg.Timeframe != "" {
+		target.Timeframes = []string{cfg.Timeframe}
+	}
+	return target
+}
+
+// CheckBacktestData returns warnings about the data a backtest config asks
+// for that would make the job fail or cover less than expected.
+func CheckBacktestData(cfg BacktestConfig, now time.Time) []string {
+	var warnings []string
+	if len(cfg.Pairs) == 0 {
+		warnings = append(warnings, "no pairs set; the pair whitelist of the base config is used")
+	}
+	if cfg.Timeframe == "" {
+		warnings = append(warnings, "no timeframe set; the timeframe of the base config is used")
+	}
+
+	target := CoverageTargetOf(cfg)
+	if err := target.Validate(); err != nil {
+		return append(warnings, fmt.Sprintf("invalid timerange: %v", err))
+	}
+	if start, ok := parseCoverageDate(cfg.TimerangeStart); ok && start.After(now) {
+		warnings = append(warnings, fmt.Sprintf("timerange_start %s is in the future; no data is available", cfg.TimerangeStart))
+	} else if end, ok := parseCoverageDate(cfg.TimerangeEnd); ok && end.After(now) {
+		warnings = append(warnings, fmt.Sprintf("timerange_end %s is in the future; the backtest stops at the latest available data", cfg.TimerangeEnd))
+	}
+	return warnings
+}
+
+// AlreadyCovered reports whether completed backtests of the strategy already
+// cover everything the job would test.
+func (p *SubmissionPreview) AlreadyCovered() bool {
+	return len(p.NewCoverage.Pairs) == 0 && len(p.NewCoverage.Timeframes) == 0 && len(p.NewCoverage.Timeranges) == 0
+}
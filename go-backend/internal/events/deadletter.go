@@ -0,0 +1,267 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Headers a message is republished to the dead-letter exchange with, saying
+// why and where it failed.
+const (
+	HeaderDeadLetterError    = "x-freqsearch-error"
+	HeaderDeadLetterQueue    = "x-freqsearch-queue"
+	HeaderDeadLetterExchange = "x-freqsearch-exchange" // Exchange the message was originally published to
+	HeaderDeadLetterFailedAt = "x-freqsearch-failed-at"
+)
+
+// deadLetterChannel is the part of an AMQP channel that republishes
+// messages to the dead-letter exchange.
+type deadLetterChannel interface {
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+// deadLetterPublishing returns a failed message as it is republished to the
+// dead-letter exchange: the same body and headers, plus the error and where
+// it failed. It gets a message ID the dead letter is stored under.
+func deadLetterPublishing(msg amqp.Delivery, queue string, procErr error, now time.Time) amqp.Publishing {
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[HeaderDeadLetterError] = truncateDeadLetterError(procErr.Error())
+	headers[HeaderDeadLetterQueue] = queue
+	headers[HeaderDeadLetterExchange] = msg.Exchange
+	headers[HeaderDeadLetterFailedAt] = now.UTC().Format(time.RFC3339Nano)
+
+	return amqp.Publishing{
+		Headers:      headers,
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    uuid.New().String(),
+		Timestamp:    now,
+		Body:         msg.Body,
+	}
+}
+
+// DeadLetterFromDelivery returns the dead letter a message received from
+// the dead-letter queue holds. Messages republished by a subscriber carry
+// the error in their headers; messages the broker dead-lettered, e.g. ones
+// another consumer rejected, only say why in their x-death header.
+func DeadLetterFromDelivery(msg amqp.Delivery, now time.Time) *domain.DeadLetter {
+	letter := &domain.DeadLetter{
+		ID:         uuid.New(),
+		RoutingKey: msg.RoutingKey,
+		Body:       msg.Body,
+		FailedAt:   now,
+		CreatedAt:  now,
+	}
+	if id, err := uuid.Parse(msg.MessageId); err == nil {
+		letter.ID = id
+	}
+
+	if errMsg, ok := msg.Headers[HeaderDeadLetterError].(string); ok {
+		letter.Error = errMsg
+		letter.Queue, _ = msg.Headers[HeaderDeadLetterQueue].(string)
+		letter.Exchange, _ = msg.Headers[HeaderDeadLetterExchange].(string)
+		if s, ok := msg.Headers[HeaderDeadLetterFailedAt].(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				letter.FailedAt = t
+			}
+		}
+	} else if deaths, ok := msg.Headers["x-death"].([]interface{}); ok && len(deaths) > 0 {
+		if death, ok := deaths[0].(amqp.Table); ok {
+			reason, _ := death["reason"].(string)
+			letter.Error = "dead-lettered by broker: " + reason
+			letter.Queue, _ = death["queue"].(string)
+			letter.Exchange, _ = death["exchange"].(string)
+			if t, ok := death["time"].(time.Time); ok {
+				letter.FailedAt = t
+			}
+		}
+	}
+	if letter.Error == "" {
+		letter.Error = "unknown"
+	}
+	letter.Error = truncateDeadLetterError(letter.Error)
+
+	return letter
+}
+
+// truncateDeadLetterError cuts an error down to the length kept with a dead
+// letter.
+func truncateDeadLetterError(s string) string {
+	if len(s) <= domain.MaxDeadLetterErrorLength {
+		return s
+	}
+	return strings.ToValidUTF8(s[:domain.MaxDeadLetterErrorLength], "")
+}
+
+// DeadLetterSink stores dead letters drained from the dead-letter queue.
+type DeadLetterSink interface {
+	Create(ctx context.Context, letter *domain.DeadLetter) error
+}
+
+// DeadLetterCollector drains the dead-letter queue into a sink, where dead
+// letters can be listed and requeued. A message is only acknowledged once
+// stored.
+type DeadLetterCollector struct {
+	config *config.RabbitMQConfig
+	queue  string
+	sink   DeadLetterSink
+	logger *zap.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDeadLetterCollector creates a collector for the configured dead-letter
+// queue.
+func NewDeadLetterCollector(cfg *config.RabbitMQConfig, sink DeadLetterSink, logger *zap.Logger) *DeadLetterCollector {
+	return &DeadLetterCollector{
+		config: cfg,
+		queue:  cfg.QueueName(cfg.DeadLetterQueue),
+		sink:   sink,
+		logger: logger,
+	}
+}
+
+// Start starts draining the dead-letter queue, reconnecting to RabbitMQ with
+// backoff whenever the connection is lost.
+func (c *DeadLetterCollector) Start() {
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	c.wg.Add(1)
+	go c.run()
+
+	c.logger.Info("Started dead letter collector", zap.String("queue", c.queue))
+}
+
+// Stop stops the collector and closes its connection.
+func (c *DeadLetterCollector) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	c.logger.Info("Dead letter collector stopped")
+	return nil
+}
+
+// run collects until stopped, reconnecting after errors.
+func (c *DeadLetterCollector) run() {
+	defer c.wg.Done()
+
+	reconnectDelay := 5 * time.Second
+	maxReconnectWait := 30 * time.Second
+	if d, err := time.ParseDuration(c.config.ReconnectDelay); err == nil {
+		reconnectDelay = d
+	}
+	if d, err := time.ParseDuration(c.config.MaxReconnectWait); err == nil {
+		maxReconnectWait = d
+	}
+
+	delay := reconnectDelay
+	for {
+		connected, err := c.collect()
+		if c.ctx.Err() != nil {
+			return
+		}
+		if connected {
+			delay = reconnectDelay
+		}
+		c.logger.Warn("Dead letter collection interrupted",
+			zap.Error(err),
+			zap.Duration("next_attempt", delay),
+		)
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectWait)
+	}
+}
+
+// collect consumes the dead-letter queue over a new connection until it is
+// lost or the collector stops. It reports whether it got to consume.
+func (c *DeadLetterCollector) collect() (bool, error) {
+	conn, err := amqp.Dial(c.config.URL)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	defer conn.Close()
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return false, fmt.Errorf("failed to create channel: %w", err)
+	}
+
+	// Declares the dead-letter queue in case no publisher has yet
+	if err := declareExchanges(channel, c.config); err != nil {
+		return false, err
+	}
+
+	if err := channel.Qos(10, 0, false); err != nil {
+		return false, fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := channel.Consume(c.queue, "", false, false, false, false, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to consume dead-letter queue: %w", err)
+	}
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return true, nil
+		case msg, ok := <-msgs:
+			if !ok {
+				return true, fmt.Errorf("dead-letter delivery channel closed")
+			}
+			c.store(msg)
+		}
+	}
+}
+
+// store stores a dead letter and acknowledges it. If storing fails, it is
+// requeued and collection pauses briefly rather than spinning on it.
+func (c *DeadLetterCollector) store(msg amqp.Delivery) {
+	letter := DeadLetterFromDelivery(msg, time.Now())
+
+	ctx, cancel := context.WithTimeout(c.ctx, 10*time.Second)
+	defer cancel()
+
+	if err := c.sink.Create(ctx, letter); err != nil {
+		c.logger.Error("Failed to store dead letter",
+			zap.String("routing_key", letter.RoutingKey),
+			zap.Error(err),
+		)
+		msg.Nack(false, true)
+
+		select {
+		case <-c.ctx.Done():
+		case <-time.After(time.Second):
+		}
+		return
+	}
+	msg.Ack(false)
+
+	c.logger.Warn("Collected dead letter",
+		zap.String("id", letter.ID.String()),
+		zap.String("routing_key", letter.RoutingKey),
+		zap.String("queue", letter.Queue),
+		zap.String("error", letter.Error),
+	)
+}
@@ -0,0 +1,184 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// recordingAcknowledger records how a delivery was settled.
+type recordingAcknowledger struct {
+	acked   bool
+	nacked  bool
+	requeue bool
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.nacked, a.requeue = true, requeue
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// recordingPublisher records messages published to it.
+type recordingPublisher struct {
+	err       error
+	exchanges []string
+	keys      []string
+	msgs      []amqp.Publishing
+}
+
+func (p *recordingPublisher) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.exchanges = append(p.exchanges, exchange)
+	p.keys = append(p.keys, key)
+	p.msgs = append(p.msgs, msg)
+	return nil
+}
+
+func TestDeadLetterPublishing_RoundTrip(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg := amqp.Delivery{
+		Exchange:    "freqsearch.events",
+		RoutingKey:  "scout.completed",
+		ContentType: "application/json",
+		Headers:     amqp.Table{"trace-id": "abc"},
+		Body:        []byte(`{"run_id": "not-a-uuid"}`),
+	}
+
+	publishing := deadLetterPublishing(msg, "go-backend-events", errors.New("unmarshal scout completed: invalid UUID"), now)
+	if publishing.Headers["trace-id"] != "abc" {
+		t.Errorf("original headers not kept: %v", publishing.Headers)
+	}
+	if len(msg.Headers) != 1 {
+		t.Errorf("original delivery headers modified: %v", msg.Headers)
+	}
+
+	// As collected from the dead-letter queue
+	letter := DeadLetterFromDelivery(amqp.Delivery{
+		Exchange:   "freqsearch.events.dlx",
+		RoutingKey: msg.RoutingKey,
+		MessageId:  publishing.MessageId,
+		Headers:    publishing.Headers,
+		Body:       publishing.Body,
+	}, now.Add(time.Minute))
+
+	if letter.ID.String() != publishing.MessageId {
+		t.Errorf("ID = %s, want message ID %s", letter.ID, publishing.MessageId)
+	}
+	if letter.RoutingKey != "scout.completed" || letter.Exchange != "freqsearch.events" || letter.Queue != "go-backend-events" {
+		t.Errorf("letter = %+v, want original routing key, exchange and queue", letter)
+	}
+	if letter.Error != "unmarshal scout completed: invalid UUID" {
+		t.Errorf("Error = %q", letter.Error)
+	}
+	if !letter.FailedAt.Equal(now) {
+		t.Errorf("FailedAt = %v, want %v", letter.FailedAt, now)
+	}
+	if string(letter.Body) != string(msg.Body) {
+		t.Errorf("Body = %s, want %s", letter.Body, msg.Body)
+	}
+}
+
+func TestDeadLetterFromDelivery_Broker(t *testing.T) {
+	diedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	letter := DeadLetterFromDelivery(amqp.Delivery{
+		RoutingKey: "strategy.discovered",
+		Headers: amqp.Table{"x-death": []interface{}{amqp.Table{
+			"reason":   "rejected",
+			"queue":    "python-agent.scout",
+			"exchange": "freqsearch.events",
+			"time":     diedAt,
+		}}},
+		Body: []byte("not json"),
+	}, diedAt.Add(time.Hour))
+
+	if letter.Error != "dead-lettered by broker: rejected" {
+		t.Errorf("Error = %q", letter.Error)
+	}
+	if letter.Queue != "python-agent.scout" || letter.Exchange != "freqsearch.events" {
+		t.Errorf("letter = %+v, want queue and exchange from x-death", letter)
+	}
+	if !letter.FailedAt.Equal(diedAt) {
+		t.Errorf("FailedAt = %v, want %v", letter.FailedAt, diedAt)
+	}
+	if letter.BodyJSON() != nil {
+		t.Errorf("BodyJSON() = %s, want nil for invalid JSON", letter.BodyJSON())
+	}
+
+	long := DeadLetterFromDelivery(amqp.Delivery{
+		Headers: amqp.Table{HeaderDeadLetterError: strings.Repeat("é", domain.MaxDeadLetterErrorLength)},
+	}, diedAt)
+	if len(long.Error) > domain.MaxDeadLetterErrorLength || !strings.HasPrefix(long.Error, "é") {
+		t.Errorf("long error not truncated to valid UTF-8: %d bytes", len(long.Error))
+	}
+}
+
+func TestRabbitMQSubscriber_Reject(t *testing.T) {
+	procErr := errors.New("handler error: boom")
+
+	tests := []struct {
+		name        string
+		redelivered bool
+		dlx         string
+		publishErr  error
+		wantRequeue bool
+		wantAcked   bool
+		wantDLX     bool
+	}{
+		{name: "first failure is retried", dlx: "events.dlx", wantRequeue: true},
+		{name: "second failure is dead-lettered", redelivered: true, dlx: "events.dlx", wantAcked: true, wantDLX: true},
+		{name: "second failure is requeued without DLX", redelivered: true, wantRequeue: true},
+		{name: "broker dead-letters when republishing fails", redelivered: true, dlx: "events.dlx", publishErr: errors.New("channel closed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &RabbitMQSubscriber{
+				config: &config.RabbitMQConfig{DeadLetterExchange: tt.dlx},
+				queue:  "go-backend-events",
+				logger: zap.NewNop(),
+			}
+			ack := &recordingAcknowledger{}
+			pub := &recordingPublisher{err: tt.publishErr}
+			msg := amqp.Delivery{Acknowledger: ack, RoutingKey: "scout.failed", Redelivered: tt.redelivered}
+
+			s.reject(context.Background(), pub, msg, procErr)
+
+			if ack.acked != tt.wantAcked {
+				t.Errorf("acked = %v, want %v", ack.acked, tt.wantAcked)
+			}
+			if !tt.wantAcked && (!ack.nacked || ack.requeue != tt.wantRequeue) {
+				t.Errorf("nacked = %v requeue = %v, want nack with requeue %v", ack.nacked, ack.requeue, tt.wantRequeue)
+			}
+			if got := len(pub.msgs) == 1; got != tt.wantDLX {
+				t.Fatalf("dead-lettered = %v, want %v", got, tt.wantDLX)
+			}
+			if tt.wantDLX {
+				if pub.exchanges[0] != "events.dlx" || pub.keys[0] != "scout.failed" {
+					t.Errorf("published to %s/%s, want events.dlx/scout.failed", pub.exchanges[0], pub.keys[0])
+				}
+				if pub.msgs[0].Headers[HeaderDeadLetterError] != procErr.Error() {
+					t.Errorf("error header = %v", pub.msgs[0].Headers[HeaderDeadLetterError])
+				}
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/saltfish/freqsearch/go-backend/internal/config"
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/redact"
 )
 
 // Publisher provides event publishing to RabbitMQ.
@@ -50,11 +51,23 @@ type Publisher interface {
 	// PublishOptimizationStatusChanged publishes an optimization status changed event.
 	PublishOptimizationStatusChanged(run *domain.OptimizationRun, oldStatus, newStatus string) error
 
+	// PublishOptimizationIterationRetry publishes an optimization iteration retry event.
+	PublishOptimizationIterationRetry(event *OptimizationIterationRetryEvent) error
+
+	// PublishStrategyCreated publishes a strategy created event.
+	PublishStrategyCreated(strategy *domain.Strategy) error
+
+	// PublishStrategyUpdated publishes a strategy updated event.
+	PublishStrategyUpdated(strategy *domain.Strategy) error
+
+	// PublishStrategyDeleted publishes a strategy deleted event.
+	PublishStrategyDeleted(strategy *domain.Strategy) error
+
 	// PublishScoutTrigger publishes a scout trigger event.
 	PublishScoutTrigger(event *ScoutTriggerEvent) error
 
 	// PublishScoutCancelled publishes a scout cancelled event.
-	PublishScoutCancelled(runID uuid.UUID) error
+	PublishScoutCancelled(runID uuid.UUID, c domain.Cancellation) error
 
 	// Close closes the publisher connection.
 	Close() error
@@ -67,6 +80,7 @@ type RabbitMQPublisher struct {
 	channel  *amqp.Channel
 	exchange string
 	logger   *zap.Logger
+	redactor *redact.Redactor
 
 	mu           sync.RWMutex
 	closed       bool
@@ -88,6 +102,12 @@ func NewRabbitMQPublisher(cfg *config.RabbitMQConfig, logger *zap.Logger) (*Rabb
 	return p, nil
 }
 
+// SetRedactor sets the redactor applied to event payloads before publishing.
+// It must be called before the publisher is used.
+func (p *RabbitMQPublisher) SetRedactor(r *redact.Redactor) {
+	p.redactor = r
+}
+
 // connect establishes connection to RabbitMQ.
 func (p *RabbitMQPublisher) connect() error {
 	p.mu.Lock()
@@ -112,20 +132,11 @@ func (p *RabbitMQPublisher) connect() error {
 		return fmt.Errorf("failed to create channel: %w", err)
 	}
 
-	// Declare exchange
-	err = p.channel.ExchangeDeclare(
-		p.exchange, // name
-		"topic",    // type
-		true,       // durable
-		false,      // auto-deleted
-		false,      // internal
-		false,      // no-wait
-		nil,        // arguments
-	)
-	if err != nil {
+	// Declare exchanges
+	if err := declareExchanges(p.channel, p.config); err != nil {
 		p.channel.Close()
 		p.conn.Close()
-		return fmt.Errorf("failed to declare exchange: %w", err)
+		return err
 	}
 
 	// Set up connection close notification
@@ -212,6 +223,20 @@ func (p *RabbitMQPublisher) reconnect() {
 	}
 }
 
+// Ping reports whether the publisher currently holds an open connection.
+func (p *RabbitMQPublisher) Ping(ctx context.Context) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return fmt.Errorf("publisher is closed")
+	}
+	if p.reconnecting || p.conn == nil || p.conn.IsClosed() {
+		return fmt.Errorf("not connected to RabbitMQ")
+	}
+	return nil
+}
+
 // Publish publishes an event with the given routing key.
 func (p *RabbitMQPublisher) Publish(ctx context.Context, routingKey string, event interface{}) error {
 	p.mu.RLock()
@@ -232,6 +257,11 @@ func (p *RabbitMQPublisher) Publish(ctx context.Context, routingKey string, even
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
+	body, err = p.redactor.JSON(body)
+	if err != nil {
+		return fmt.Errorf("failed to redact event: %w", err)
+	}
+
 	// Publish message
 	err = channel.PublishWithContext(
 		ctx,
@@ -327,14 +357,37 @@ func (p *RabbitMQPublisher) PublishOptimizationStatusChanged(run *domain.Optimiz
 	return p.Publish(context.Background(), RoutingKeyOptStatusChanged, event)
 }
 
+// PublishOptimizationIterationRetry publishes an optimization iteration retry event.
+func (p *RabbitMQPublisher) PublishOptimizationIterationRetry(event *OptimizationIterationRetryEvent) error {
+	return p.Publish(context.Background(), RoutingKeyOptIterationRetry, event)
+}
+
+// PublishStrategyCreated publishes a strategy created event.
+func (p *RabbitMQPublisher) PublishStrategyCreated(strategy *domain.Strategy) error {
+	event := NewStrategyChangedEvent(EventTypeStrategyCreated, strategy)
+	return p.Publish(context.Background(), RoutingKeyStrategyCreated, event)
+}
+
+// PublishStrategyUpdated publishes a strategy updated event.
+func (p *RabbitMQPublisher) PublishStrategyUpdated(strategy *domain.Strategy) error {
+	event := NewStrategyChangedEvent(EventTypeStrategyUpdated, strategy)
+	return p.Publish(context.Background(), RoutingKeyStrategyUpdated, event)
+}
+
+// PublishStrategyDeleted publishes a strategy deleted event.
+func (p *RabbitMQPublisher) PublishStrategyDeleted(strategy *domain.Strategy) error {
+	event := NewStrategyChangedEvent(EventTypeStrategyDeleted, strategy)
+	return p.Publish(context.Background(), RoutingKeyStrategyDeleted, event)
+}
+
 // PublishScoutTrigger publishes a scout trigger event.
 func (p *RabbitMQPublisher) PublishScoutTrigger(event *ScoutTriggerEvent) error {
 	return p.Publish(context.Background(), RoutingKeyScoutTrigger, event)
 }
 
 // PublishScoutCancelled publishes a scout cancelled event.
-func (p *RabbitMQPublisher) PublishScoutCancelled(runID uuid.UUID) error {
-	event := NewScoutCancelledEvent(runID)
+func (p *RabbitMQPublisher) PublishScoutCancelled(runID uuid.UUID, c domain.Cancellation) error {
+	event := NewScoutCancelledEvent(runID, c)
 	return p.Publish(context.Background(), RoutingKeyScoutCancelled, event)
 }
 
@@ -422,11 +475,27 @@ func (p *NoOpPublisher) PublishOptimizationStatusChanged(run *domain.Optimizatio
 	return nil
 }
 
+func (p *NoOpPublisher) PublishOptimizationIterationRetry(event *OptimizationIterationRetryEvent) error {
+	return nil
+}
+
+func (p *NoOpPublisher) PublishStrategyCreated(strategy *domain.Strategy) error {
+	return nil
+}
+
+func (p *NoOpPublisher) PublishStrategyUpdated(strategy *domain.Strategy) error {
+	return nil
+}
+
+func (p *NoOpPublisher) PublishStrategyDeleted(strategy *domain.Strategy) error {
+	return nil
+}
+
 func (p *NoOpPublisher) PublishScoutTrigger(event *ScoutTriggerEvent) error {
 	return nil
 }
 
-func (p *NoOpPublisher) PublishScoutCancelled(runID uuid.UUID) error {
+func (p *NoOpPublisher) PublishScoutCancelled(runID uuid.UUID, c domain.Cancellation) error {
 	return nil
 }
 
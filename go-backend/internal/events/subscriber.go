@@ -14,6 +14,11 @@ import (
 	"github.com/saltfish/freqsearch/go-backend/internal/config"
 )
 
+// redeliveryDelay is how long a message that failed again waits before it is
+// requeued when no dead-letter exchange is configured, so a message that
+// keeps failing doesn't spin.
+const redeliveryDelay = 5 * time.Second
+
 // EventHandler is a function that processes received events.
 type EventHandler func(routingKey string, body []byte) error
 
@@ -35,6 +40,8 @@ type RabbitMQSubscriber struct {
 	queue    string
 	logger   *zap.Logger
 
+	redeliveryDelay time.Duration
+
 	mu           sync.RWMutex
 	closed       bool
 	reconnecting bool
@@ -44,13 +51,16 @@ type RabbitMQSubscriber struct {
 	cancel       context.CancelFunc
 }
 
-// NewRabbitMQSubscriber creates a new RabbitMQ subscriber.
+// NewRabbitMQSubscriber creates a new RabbitMQ subscriber. The queue is
+// declared with the configured queue prefix.
 func NewRabbitMQSubscriber(cfg *config.RabbitMQConfig, queueName string, logger *zap.Logger) (*RabbitMQSubscriber, error) {
 	s := &RabbitMQSubscriber{
 		config:   cfg,
 		exchange: cfg.Exchange,
-		queue:    queueName,
+		queue:    cfg.QueueName(queueName),
 		logger:   logger,
+
+		redeliveryDelay: redeliveryDelay,
 	}
 
 	if err := s.connect(); err != nil {
@@ -84,30 +94,21 @@ func (s *RabbitMQSubscriber) connect() error {
 		return fmt.Errorf("failed to create channel: %w", err)
 	}
 
-	// Declare exchange (idempotent)
-	err = s.channel.ExchangeDeclare(
-		s.exchange, // name
-		"topic",    // type
-		true,       // durable
-		false,      // auto-deleted
-		false,      // internal
-		false,      // no-wait
-		nil,        // arguments
-	)
-	if err != nil {
+	// Declare exchanges (idempotent)
+	if err := declareExchanges(s.channel, s.config); err != nil {
 		s.channel.Close()
 		s.conn.Close()
-		return fmt.Errorf("failed to declare exchange: %w", err)
+		return err
 	}
 
 	// Declare queue
 	_, err = s.channel.QueueDeclare(
-		s.queue, // name
-		false,   // durable (use false for temporary queues)
-		true,    // auto-delete when no consumers
-		false,   // exclusive
-		false,   // no-wait
-		nil,     // arguments
+		s.queue,             // name
+		false,               // durable (use false for temporary queues)
+		true,                // auto-delete when no consumers
+		false,               // exclusive
+		false,               // no-wait
+		queueArgs(s.config), // arguments
 	)
 	if err != nil {
 		s.channel.Close()
@@ -134,10 +135,14 @@ func (s *RabbitMQSubscriber) connect() error {
 	}
 
 	// Set QoS (prefetch count)
+	prefetch := s.config.PrefetchCount
+	if prefetch <= 0 {
+		prefetch = 10
+	}
 	err = s.channel.Qos(
-		10,    // prefetch count
-		0,     // prefetch size
-		false, // global
+		prefetch, // prefetch count
+		0,        // prefetch size
+		false,    // global
 	)
 	if err != nil {
 		s.channel.Close()
@@ -321,9 +326,9 @@ func (s *RabbitMQSubscriber) consume(ctx context.Context, handler EventHandler)
 				s.logger.Error("Failed to process message",
 					zap.Error(err),
 					zap.String("routing_key", msg.RoutingKey),
+					zap.Bool("redelivered", msg.Redelivered),
 				)
-				// Reject and requeue the message
-				msg.Nack(false, true)
+				s.reject(ctx, channel, msg, err)
 			} else {
 				// Acknowledge successful processing
 				msg.Ack(false)
@@ -336,6 +341,52 @@ func (s *RabbitMQSubscriber) consume(ctx context.Context, handler EventHandler)
 	}
 }
 
+// reject handles a message that failed processing. It is requeued for one
+// more attempt; if that fails too, it is republished to the dead-letter
+// exchange with the error. Without one the message is kept: it is requeued
+// again after a delay.
+func (s *RabbitMQSubscriber) reject(ctx context.Context, channel deadLetterChannel, msg amqp.Delivery, procErr error) {
+	if !msg.Redelivered {
+		msg.Nack(false, true)
+		return
+	}
+
+	if s.config.DeadLetterExchange == "" {
+		s.logger.Warn("Requeueing message that failed again; configure a dead-letter exchange to set such messages aside",
+			zap.String("routing_key", msg.RoutingKey),
+			zap.Duration("delay", s.redeliveryDelay),
+		)
+		select {
+		case <-time.After(s.redeliveryDelay):
+		case <-ctx.Done():
+		}
+		msg.Nack(false, true)
+		return
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	publishing := deadLetterPublishing(msg, s.queue, procErr, time.Now())
+	err := channel.PublishWithContext(publishCtx, s.config.DeadLetterExchange, msg.RoutingKey, false, false, publishing)
+	if err != nil {
+		// The queue dead-letters rejected messages itself, only without
+		// the error
+		s.logger.Error("Failed to dead-letter message",
+			zap.String("routing_key", msg.RoutingKey),
+			zap.Error(err),
+		)
+		msg.Nack(false, false)
+		return
+	}
+	msg.Ack(false)
+
+	s.logger.Warn("Dead-lettered message",
+		zap.String("routing_key", msg.RoutingKey),
+		zap.String("message_id", publishing.MessageId),
+	)
+}
+
 // processMessage processes a single message.
 func (s *RabbitMQSubscriber) processMessage(msg amqp.Delivery, handler EventHandler) error {
 	s.logger.Debug("Received message",
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// topologyChannel is the part of an AMQP channel that declares topology.
+type topologyChannel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+}
+
+// exchangeType returns the configured type of the event exchange.
+func exchangeType(cfg *config.RabbitMQConfig) string {
+	if cfg.ExchangeType == "" {
+		return amqp.ExchangeTopic
+	}
+	return cfg.ExchangeType
+}
+
+// declareExchanges declares the event exchange and, if configured, the
+// dead-letter exchange with its queue. Declaring is idempotent, so it is done
+// on every connection; it fails if an exchange already exists with another
+// type, e.g. when two environments share a broker and an exchange name.
+func declareExchanges(ch topologyChannel, cfg *config.RabbitMQConfig) error {
+	err := ch.ExchangeDeclare(
+		cfg.Exchange,      // name
+		exchangeType(cfg), // type
+		true,              // durable
+		false,             // auto-deleted
+		false,             // internal
+		false,             // no-wait
+		nil,               // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", cfg.Exchange, err)
+	}
+
+	if cfg.DeadLetterExchange == "" {
+		return nil
+	}
+
+	// Dead-lettered messages keep their routing key, so a topic exchange
+	// lets one queue collect them all
+	err = ch.ExchangeDeclare(cfg.DeadLetterExchange, amqp.ExchangeTopic, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange %s: %w", cfg.DeadLetterExchange, err)
+	}
+
+	if cfg.DeadLetterQueue == "" {
+		return nil
+	}
+
+	queue := cfg.QueueName(cfg.DeadLetterQueue)
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue %s: %w", queue, err)
+	}
+	if err := ch.QueueBind(queue, "#", cfg.DeadLetterExchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue %s: %w", queue, err)
+	}
+
+	return nil
+}
+
+// queueArgs returns the arguments the backend's queues are declared with.
+// RabbitMQ refuses to redeclare a queue with other arguments, so the
+// subscriber queues are temporary: a changed dead-letter exchange applies
+// once the old queue is gone.
+func queueArgs(cfg *config.RabbitMQConfig) amqp.Table {
+	if cfg.DeadLetterExchange == "" {
+		return nil
+	}
+	return amqp.Table{"x-dead-letter-exchange": cfg.DeadLetterExchange}
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// recordingChannel records the topology declared on it.
+type recordingChannel struct {
+	exchanges map[string]string // name -> type
+	queues    []string
+	bindings  []string // queue/key/exchange
+}
+
+func (c *recordingChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	if c.exchanges == nil {
+		c.exchanges = map[string]string{}
+	}
+	c.exchanges[name] = kind
+	return nil
+}
+
+func (c *recordingChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	c.queues = append(c.queues, name)
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c *recordingChannel) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	c.bindings = append(c.bindings, name+"/"+key+"/"+exchange)
+	return nil
+}
+
+func TestDeclareExchanges_Default(t *testing.T) {
+	ch := &recordingChannel{}
+	cfg := &config.RabbitMQConfig{Exchange: "freqsearch.events"}
+
+	if err := declareExchanges(ch, cfg); err != nil {
+		t.Fatalf("declareExchanges() error = %v", err)
+	}
+	if want := map[string]string{"freqsearch.events": "topic"}; !reflect.DeepEqual(ch.exchanges, want) {
+		t.Errorf("exchanges = %v, want %v", ch.exchanges, want)
+	}
+	if len(ch.queues) != 0 {
+		t.Errorf("queues = %v, want none", ch.queues)
+	}
+	if args := queueArgs(cfg); args != nil {
+		t.Errorf("queueArgs() = %v, want nil", args)
+	}
+	if got := cfg.QueueName("go-backend-events"); got != "go-backend-events" {
+		t.Errorf("QueueName() = %q, want unprefixed name", got)
+	}
+}
+
+func TestDeclareExchanges_DeadLetter(t *testing.T) {
+	ch := &recordingChannel{}
+	cfg := &config.RabbitMQConfig{
+		Exchange:           "staging.events",
+		ExchangeType:       "direct",
+		QueuePrefix:        "staging.",
+		DeadLetterExchange: "staging.events.dlx",
+		DeadLetterQueue:    "dead-letters",
+	}
+
+	if err := declareExchanges(ch, cfg); err != nil {
+		t.Fatalf("declareExchanges() error = %v", err)
+	}
+	want := map[string]string{"staging.events": "direct", "staging.events.dlx": "topic"}
+	if !reflect.DeepEqual(ch.exchanges, want) {
+		t.Errorf("exchanges = %v, want %v", ch.exchanges, want)
+	}
+	if want := []string{"staging.dead-letters"}; !reflect.DeepEqual(ch.queues, want) {
+		t.Errorf("queues = %v, want %v", ch.queues, want)
+	}
+	if want := []string{"staging.dead-letters/#/staging.events.dlx"}; !reflect.DeepEqual(ch.bindings, want) {
+		t.Errorf("bindings = %v, want %v", ch.bindings, want)
+	}
+	if got := queueArgs(cfg)["x-dead-letter-exchange"]; got != "staging.events.dlx" {
+		t.Errorf("x-dead-letter-exchange = %v, want staging.events.dlx", got)
+	}
+}
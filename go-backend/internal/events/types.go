@@ -19,11 +19,14 @@ const (
 	RoutingKeyTaskCancelled = "task.cancelled"
 
 	// Optimization lifecycle events
-	RoutingKeyOptStarted       = "optimization.started"
-	RoutingKeyOptIteration     = "optimization.iteration"
-	RoutingKeyOptCompleted     = "optimization.completed"
-	RoutingKeyOptFailed        = "optimization.failed"
-	RoutingKeyOptStatusChanged = "optimization.status_changed"
+	RoutingKeyOptStarted        = "optimization.started"
+	RoutingKeyOptIteration      = "optimization.iteration"
+	RoutingKeyOptCompleted      = "optimization.completed"
+	RoutingKeyOptFailed         = "optimization.failed"
+	RoutingKeyOptStatusChanged  = "optimization.status_changed"
+	RoutingKeyOptIterationRetry = "optimization.iteration_retry"
+	RoutingKeyOptProgress       = "optimization.progress"
+	RoutingKeyOptStalled        = "optimization.stalled"
 
 	// Strategy lifecycle events (for Python Agents)
 	RoutingKeyStrategyDiscovered       = "strategy.discovered"
@@ -32,6 +35,12 @@ const (
 	RoutingKeyStrategyApproved         = "strategy.approved"
 	RoutingKeyStrategyEvolve           = "strategy.evolve"
 	RoutingKeyStrategyArchived         = "strategy.archived"
+	RoutingKeyStrategyNeedsDescription = "strategy.needs_description"
+
+	// Strategy CRUD events (for dashboards and agents)
+	RoutingKeyStrategyCreated = "strategy.created"
+	RoutingKeyStrategyUpdated = "strategy.updated"
+	RoutingKeyStrategyDeleted = "strategy.deleted"
 
 	// Backtest events (bridging Go backend and Python agents)
 	RoutingKeyBacktestCompleted = "backtest.completed"
@@ -47,6 +56,10 @@ const (
 	RoutingKeyScoutCompleted = "scout.completed"
 	RoutingKeyScoutFailed    = "scout.failed"
 	RoutingKeyScoutCancelled = "scout.cancelled"
+
+	// System events (for the notification subsystem)
+	RoutingKeySystemSLABreach   = "system.sla_breach"
+	RoutingKeySystemMaintenance = "system.maintenance"
 )
 
 // Event types.
@@ -59,11 +72,14 @@ const (
 	EventTypeTaskCancelled = "task.cancelled"
 
 	// Optimization events
-	EventTypeOptStarted       = "optimization.started"
-	EventTypeOptIteration     = "optimization.iteration"
-	EventTypeOptCompleted     = "optimization.completed"
-	EventTypeOptFailed        = "optimization.failed"
-	EventTypeOptStatusChanged = "optimization.status_changed"
+	EventTypeOptStarted        = "optimization.started"
+	EventTypeOptIteration      = "optimization.iteration"
+	EventTypeOptCompleted      = "optimization.completed"
+	EventTypeOptFailed         = "optimization.failed"
+	EventTypeOptStatusChanged  = "optimization.status_changed"
+	EventTypeOptIterationRetry = "optimization.iteration_retry"
+	EventTypeOptProgress       = "optimization.progress"
+	EventTypeOptStalled        = "optimization.stalled"
 
 	// Strategy events
 	EventTypeStrategyDiscovered       = "strategy.discovered"
@@ -72,6 +88,12 @@ const (
 	EventTypeStrategyApproved         = "strategy.approved"
 	EventTypeStrategyEvolve           = "strategy.evolve"
 	EventTypeStrategyArchived         = "strategy.archived"
+	EventTypeStrategyNeedsDescription = "strategy.needs_description"
+
+	// Strategy CRUD events
+	EventTypeStrategyCreated = "strategy.created"
+	EventTypeStrategyUpdated = "strategy.updated"
+	EventTypeStrategyDeleted = "strategy.deleted"
 
 	// Backtest bridge events
 	EventTypeBacktestCompleted = "backtest.completed"
@@ -87,6 +109,10 @@ const (
 	EventTypeScoutCompleted = "scout.completed"
 	EventTypeScoutFailed    = "scout.failed"
 	EventTypeScoutCancelled = "scout.cancelled"
+
+	// System events
+	EventTypeSystemSLABreach   = "system.sla_breach"
+	EventTypeSystemMaintenance = "system.maintenance"
 )
 
 // BaseEvent contains common fields for all events.
@@ -187,32 +213,46 @@ type TaskFailedEvent struct {
 	StrategyID   uuid.UUID `json:"strategy_id"`
 	ErrorMessage string    `json:"error_message"`
 	RetryCount   int       `json:"retry_count"`
+
+	// FailureCategory classifies the failure; StrategyFault is set when the
+	// strategy code caused it, rather than the infrastructure or data.
+	FailureCategory string `json:"failure_category,omitempty"`
+	StrategyFault   bool   `json:"strategy_fault"`
 }
 
 // NewTaskFailedEvent creates a new TaskFailedEvent.
 func NewTaskFailedEvent(job *domain.BacktestJob, errMsg string) *TaskFailedEvent {
-	return &TaskFailedEvent{
+	event := &TaskFailedEvent{
 		BaseEvent:    NewBaseEvent(EventTypeTaskFailed),
 		JobID:        job.ID,
 		StrategyID:   job.StrategyID,
 		ErrorMessage: errMsg,
 		RetryCount:   job.RetryCount,
 	}
+	if job.FailureCategory != nil {
+		event.FailureCategory = job.FailureCategory.String()
+		event.StrategyFault = job.FailureCategory.StrategyFault()
+	}
+	return event
 }
 
 // TaskCancelledEvent is published when a backtest job is cancelled.
 type TaskCancelledEvent struct {
 	BaseEvent
-	JobID      uuid.UUID `json:"job_id"`
-	StrategyID uuid.UUID `json:"strategy_id"`
+	JobID       uuid.UUID `json:"job_id"`
+	StrategyID  uuid.UUID `json:"strategy_id"`
+	Reason      string    `json:"reason,omitempty"`
+	CancelledBy string    `json:"cancelled_by,omitempty"`
 }
 
 // NewTaskCancelledEvent creates a new TaskCancelledEvent.
 func NewTaskCancelledEvent(job *domain.BacktestJob) *TaskCancelledEvent {
 	return &TaskCancelledEvent{
-		BaseEvent:  NewBaseEvent(EventTypeTaskCancelled),
-		JobID:      job.ID,
-		StrategyID: job.StrategyID,
+		BaseEvent:   NewBaseEvent(EventTypeTaskCancelled),
+		JobID:       job.ID,
+		StrategyID:  job.StrategyID,
+		Reason:      job.Cancellation().Reason,
+		CancelledBy: job.Cancellation().CancelledBy,
 	}
 }
 
@@ -251,6 +291,48 @@ func NewOptimizationIterationEvent(
 	return event
 }
 
+// OptimizationProgressEvent is published periodically for active runs so
+// dashboards can render progress bars and completion estimates.
+type OptimizationProgressEvent struct {
+	BaseEvent
+	*domain.OptimizationProgress
+}
+
+// NewOptimizationProgressEvent creates a new OptimizationProgressEvent.
+func NewOptimizationProgressEvent(progress *domain.OptimizationProgress) *OptimizationProgressEvent {
+	return &OptimizationProgressEvent{
+		BaseEvent:            NewBaseEvent(EventTypeOptProgress),
+		OptimizationProgress: progress,
+	}
+}
+
+// OptimizationStalledEvent is published when a running optimization is
+// flagged as stalled for lack of activity, for operator action.
+type OptimizationStalledEvent struct {
+	BaseEvent
+	OptimizationRunID uuid.UUID `json:"optimization_run_id"`
+	Name              string    `json:"name"`
+	CurrentIteration  int       `json:"current_iteration"`
+	MaxIterations     int       `json:"max_iterations"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	InactiveMs        int64     `json:"inactive_ms"`
+	ThresholdMs       int64     `json:"threshold_ms"`
+}
+
+// NewOptimizationStalledEvent creates a new OptimizationStalledEvent.
+func NewOptimizationStalledEvent(stalled *domain.StalledOptimization, now time.Time, threshold time.Duration) *OptimizationStalledEvent {
+	return &OptimizationStalledEvent{
+		BaseEvent:         NewBaseEvent(EventTypeOptStalled),
+		OptimizationRunID: stalled.Run.ID,
+		Name:              stalled.Run.Name,
+		CurrentIteration:  stalled.Run.CurrentIteration,
+		MaxIterations:     stalled.Run.MaxIterations,
+		LastActivityAt:    stalled.LastActivityAt,
+		InactiveMs:        stalled.StalledFor(now).Milliseconds(),
+		ThresholdMs:       threshold.Milliseconds(),
+	}
+}
+
 // =============================================================================
 // Strategy Lifecycle Events (for Python Agents integration)
 // =============================================================================
@@ -258,16 +340,17 @@ func NewOptimizationIterationEvent(
 // StrategyDiscoveredEvent is published when Scout Agent finds a new strategy.
 type StrategyDiscoveredEvent struct {
 	BaseEvent
-	Name               string   `json:"name"`
-	SourceType         string   `json:"source_type"` // "stratninja", "github", etc.
-	SourceURL          string   `json:"source_url"`
-	Code               string   `json:"code"`
-	CodeHash           string   `json:"code_hash"`
-	DetectedIndicators []string `json:"detected_indicators,omitempty"`
-	Timeframe          string   `json:"timeframe,omitempty"`
-	Stoploss           *float64 `json:"stoploss,omitempty"`
-	IsValid            bool     `json:"is_valid"`
-	ValidationErrors   []string `json:"validation_errors,omitempty"`
+	RunID              *uuid.UUID `json:"run_id,omitempty"` // Scout run that found the strategy, if any
+	Name               string     `json:"name"`
+	SourceType         string     `json:"source_type"` // "stratninja", "github", etc.
+	SourceURL          string     `json:"source_url"`
+	Code               string     `json:"code"`
+	CodeHash           string     `json:"code_hash"`
+	DetectedIndicators []string   `json:"detected_indicators,omitempty"`
+	Timeframe          string     `json:"timeframe,omitempty"`
+	Stoploss           *float64   `json:"stoploss,omitempty"`
+	IsValid            bool       `json:"is_valid"`
+	ValidationErrors   []string   `json:"validation_errors,omitempty"`
 }
 
 // StrategyNeedsProcessingEvent is published when a discovered strategy needs
@@ -280,6 +363,22 @@ type StrategyNeedsProcessingEvent struct {
 	SourceType string    `json:"source_type"`
 }
 
+// StrategyNeedsDescriptionEvent is published with a batch of strategies that
+// lack a description, for the Analyst Agent to describe. Descriptions are
+// delivered back with the SetStrategyDescription RPC.
+type StrategyNeedsDescriptionEvent struct {
+	BaseEvent
+	Strategies []*domain.DescriptionRequest `json:"strategies"`
+}
+
+// NewStrategyNeedsDescriptionEvent creates a new StrategyNeedsDescriptionEvent.
+func NewStrategyNeedsDescriptionEvent(requests []*domain.DescriptionRequest) *StrategyNeedsDescriptionEvent {
+	return &StrategyNeedsDescriptionEvent{
+		BaseEvent:  NewBaseEvent(EventTypeStrategyNeedsDescription),
+		Strategies: requests,
+	}
+}
+
 // StrategyReadyForBacktestEvent is published when Engineer Agent completes processing
 // and the strategy is ready for backtesting.
 type StrategyReadyForBacktestEvent struct {
@@ -299,17 +398,18 @@ type StrategyReadyForBacktestEvent struct {
 // This is separate from TaskCompletedEvent to allow different routing.
 type BacktestCompletedBridgeEvent struct {
 	BaseEvent
-	JobID          uuid.UUID  `json:"job_id"`
-	StrategyID     uuid.UUID  `json:"strategy_id"`
-	StrategyName   string     `json:"strategy_name"`
-	Success        bool       `json:"success"`
-	ErrorMessage   string     `json:"error_message,omitempty"`
-	TotalTrades    *int       `json:"total_trades,omitempty"`
-	ProfitPct      *float64   `json:"profit_pct,omitempty"`
-	WinRate        *float64   `json:"win_rate,omitempty"`
-	MaxDrawdownPct *float64   `json:"max_drawdown_pct,omitempty"`
-	SharpeRatio    *float64   `json:"sharpe_ratio,omitempty"`
-	ResultID       *uuid.UUID `json:"result_id,omitempty"`
+	JobID           uuid.UUID  `json:"job_id"`
+	StrategyID      uuid.UUID  `json:"strategy_id"`
+	StrategyName    string     `json:"strategy_name"`
+	Success         bool       `json:"success"`
+	ErrorMessage    string     `json:"error_message,omitempty"`
+	FailureCategory string     `json:"failure_category,omitempty"`
+	TotalTrades     *int       `json:"total_trades,omitempty"`
+	ProfitPct       *float64   `json:"profit_pct,omitempty"`
+	WinRate         *float64   `json:"win_rate,omitempty"`
+	MaxDrawdownPct  *float64   `json:"max_drawdown_pct,omitempty"`
+	SharpeRatio     *float64   `json:"sharpe_ratio,omitempty"`
+	ResultID        *uuid.UUID `json:"result_id,omitempty"`
 }
 
 // NewBacktestCompletedBridgeEvent creates a BacktestCompletedBridgeEvent from job and result.
@@ -332,6 +432,9 @@ func NewBacktestCompletedBridgeEvent(
 	if job.ErrorMessage != nil {
 		event.ErrorMessage = *job.ErrorMessage
 	}
+	if job.FailureCategory != nil {
+		event.FailureCategory = job.FailureCategory.String()
+	}
 
 	if result != nil {
 		event.ResultID = &result.ID
@@ -383,6 +486,50 @@ type StrategyArchivedEvent struct {
 	FinalMetrics map[string]float64 `json:"final_metrics,omitempty"`
 }
 
+// NewStrategyArchivedEvent creates a StrategyArchivedEvent for a strategy archived by policy.
+func NewStrategyArchivedEvent(candidate *domain.ArchivalCandidate, reason string) *StrategyArchivedEvent {
+	metrics := map[string]float64{
+		"backtest_count": float64(candidate.BacktestCount),
+	}
+	if candidate.BestSharpe != nil {
+		metrics["best_sharpe"] = *candidate.BestSharpe
+	}
+	if candidate.BestProfitPct != nil {
+		metrics["best_profit_pct"] = *candidate.BestProfitPct
+	}
+
+	return &StrategyArchivedEvent{
+		BaseEvent:    NewBaseEvent(EventTypeStrategyArchived),
+		StrategyID:   candidate.StrategyID,
+		StrategyName: candidate.Name,
+		Reason:       reason,
+		FinalMetrics: metrics,
+	}
+}
+
+// StrategyChangedEvent is published when a strategy is created, updated, or deleted.
+type StrategyChangedEvent struct {
+	BaseEvent
+	StrategyID uuid.UUID  `json:"strategy_id"`
+	Name       string     `json:"name"`
+	CodeHash   string     `json:"code_hash"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	Generation int        `json:"generation"`
+}
+
+// NewStrategyChangedEvent creates a StrategyChangedEvent of the given type
+// (EventTypeStrategyCreated, EventTypeStrategyUpdated, or EventTypeStrategyDeleted).
+func NewStrategyChangedEvent(eventType string, strategy *domain.Strategy) *StrategyChangedEvent {
+	return &StrategyChangedEvent{
+		BaseEvent:  NewBaseEvent(eventType),
+		StrategyID: strategy.ID,
+		Name:       strategy.Name,
+		CodeHash:   strategy.CodeHash,
+		ParentID:   strategy.ParentID,
+		Generation: strategy.Generation,
+	}
+}
+
 // =============================================================================
 // Scout Lifecycle Events (for strategy discovery)
 // =============================================================================
@@ -395,6 +542,11 @@ type ScoutTriggerEvent struct {
 	MaxStrategies int       `json:"max_strategies"` // Maximum strategies to fetch
 	TriggerType   string    `json:"trigger_type"`   // "manual", "scheduled"
 	TriggeredBy   string    `json:"triggered_by"`   // User ID or "system"
+
+	// CredentialSecretID references the secret holding the source
+	// credential, e.g. an API token, which the agent reads with the
+	// GetScoutCredential RPC. The credential itself is never published.
+	CredentialSecretID *uuid.UUID `json:"credential_secret_id,omitempty"`
 }
 
 // NewScoutTriggerEvent creates a new ScoutTriggerEvent.
@@ -451,11 +603,31 @@ func NewScoutProgressEvent(runID uuid.UUID, stage string, progress int, message
 type ScoutCompletedEvent struct {
 	BaseEvent
 	RunID             uuid.UUID `json:"run_id"`
+	Found             int       `json:"found,omitempty"`
 	TotalFetched      int       `json:"total_fetched"`
+	Parsed            int       `json:"parsed,omitempty"`
 	Validated         int       `json:"validated"`
 	ValidationFailed  int       `json:"validation_failed"`
 	DuplicatesRemoved int       `json:"duplicates_removed"`
 	Submitted         int       `json:"submitted"`
+
+	Sources         map[string]*domain.ScoutSourceMetrics `json:"sources,omitempty"`
+	ErrorCategories map[string]int                        `json:"error_categories,omitempty"`
+}
+
+// Metrics returns the run metrics reported by the event.
+func (e *ScoutCompletedEvent) Metrics() *domain.ScoutMetrics {
+	return &domain.ScoutMetrics{
+		Found:             e.Found,
+		TotalFetched:      e.TotalFetched,
+		Parsed:            e.Parsed,
+		Validated:         e.Validated,
+		ValidationFailed:  e.ValidationFailed,
+		DuplicatesRemoved: e.DuplicatesRemoved,
+		Submitted:         e.Submitted,
+		Sources:           e.Sources,
+		ErrorCategories:   e.ErrorCategories,
+	}
 }
 
 // NewScoutCompletedEvent creates a new ScoutCompletedEvent.
@@ -466,11 +638,15 @@ func NewScoutCompletedEvent(run *domain.ScoutRun) *ScoutCompletedEvent {
 	}
 
 	if run.Metrics != nil {
+		event.Found = run.Metrics.Found
 		event.TotalFetched = run.Metrics.TotalFetched
+		event.Parsed = run.Metrics.Parsed
 		event.Validated = run.Metrics.Validated
 		event.ValidationFailed = run.Metrics.ValidationFailed
 		event.DuplicatesRemoved = run.Metrics.DuplicatesRemoved
 		event.Submitted = run.Metrics.Submitted
+		event.Sources = run.Metrics.Sources
+		event.ErrorCategories = run.Metrics.ErrorCategories
 	}
 
 	return event
@@ -502,14 +678,18 @@ func NewScoutFailedEvent(run *domain.ScoutRun, stage string) *ScoutFailedEvent {
 // ScoutCancelledEvent is published when a Scout run is cancelled.
 type ScoutCancelledEvent struct {
 	BaseEvent
-	RunID uuid.UUID `json:"run_id"`
+	RunID       uuid.UUID `json:"run_id"`
+	Reason      string    `json:"reason,omitempty"`
+	CancelledBy string    `json:"cancelled_by,omitempty"`
 }
 
 // NewScoutCancelledEvent creates a new ScoutCancelledEvent.
-func NewScoutCancelledEvent(runID uuid.UUID) *ScoutCancelledEvent {
+func NewScoutCancelledEvent(runID uuid.UUID, c domain.Cancellation) *ScoutCancelledEvent {
 	return &ScoutCancelledEvent{
-		BaseEvent: NewBaseEvent(EventTypeScoutCancelled),
-		RunID:     runID,
+		BaseEvent:   NewBaseEvent(EventTypeScoutCancelled),
+		RunID:       runID,
+		Reason:      c.Reason,
+		CancelledBy: c.CancelledBy,
 	}
 }
 
@@ -523,9 +703,13 @@ type OptimizationStartedEvent struct {
 	OptimizationRunID uuid.UUID                  `json:"optimization_run_id"`
 	Name              string                     `json:"name"`
 	BaseStrategyID    uuid.UUID                  `json:"base_strategy_id"`
+	SeedStrategyIDs   []uuid.UUID                `json:"seed_strategy_ids"` // Initial population, base strategy first
 	MaxIterations     int                        `json:"max_iterations"`
 	Mode              string                     `json:"mode"`
 	Config            *domain.OptimizationConfig `json:"config,omitempty"`
+
+	// DataSnapshot is the data environment the run's backtests are pinned to.
+	DataSnapshot *domain.OptimizationDataSnapshot `json:"data_snapshot,omitempty"`
 }
 
 // NewOptimizationStartedEvent creates a new OptimizationStartedEvent.
@@ -535,9 +719,11 @@ func NewOptimizationStartedEvent(run *domain.OptimizationRun) *OptimizationStart
 		OptimizationRunID: run.ID,
 		Name:              run.Name,
 		BaseStrategyID:    run.BaseStrategyID,
+		SeedStrategyIDs:   run.SeedStrategyIDs,
 		MaxIterations:     run.MaxIterations,
 		Mode:              run.Mode.String(),
 		Config:            &run.Config,
+		DataSnapshot:      run.DataSnapshot,
 	}
 }
 
@@ -588,21 +774,114 @@ func NewOptimizationFailedEvent(run *domain.OptimizationRun, reason string) *Opt
 }
 
 // OptimizationStatusChangedEvent is published when an optimization run status changes.
+// Reason and CancelledBy are set when the run was cancelled.
 type OptimizationStatusChangedEvent struct {
 	BaseEvent
-	RunID     uuid.UUID `json:"run_id"`
-	Name      string    `json:"name"`
-	OldStatus string    `json:"old_status"`
-	NewStatus string    `json:"new_status"`
+	RunID       uuid.UUID `json:"run_id"`
+	Name        string    `json:"name"`
+	OldStatus   string    `json:"old_status"`
+	NewStatus   string    `json:"new_status"`
+	Reason      string    `json:"reason,omitempty"`
+	CancelledBy string    `json:"cancelled_by,omitempty"`
 }
 
 // NewOptimizationStatusChangedEvent creates a new OptimizationStatusChangedEvent.
 func NewOptimizationStatusChangedEvent(run *domain.OptimizationRun, oldStatus, newStatus string) *OptimizationStatusChangedEvent {
-	return &OptimizationStatusChangedEvent{
+	event := &OptimizationStatusChangedEvent{
 		BaseEvent: NewBaseEvent(EventTypeOptStatusChanged),
 		RunID:     run.ID,
 		Name:      run.Name,
 		OldStatus: oldStatus,
 		NewStatus: newStatus,
 	}
+	if newStatus == domain.OptimizationStatusCancelled.String() {
+		c := run.Cancellation()
+		event.Reason, event.CancelledBy = c.Reason, c.CancelledBy
+	}
+	return event
+}
+
+// OptimizationIterationRetryEvent is published when an operator re-opens an iteration.
+// It instructs the orchestrator to regenerate that iteration from SourceStrategyID,
+// taking the operator feedback into account, without restarting the whole run.
+type OptimizationIterationRetryEvent struct {
+	BaseEvent
+	RunID            uuid.UUID `json:"run_id"`
+	IterationNumber  int       `json:"iteration_number"`
+	StrategyID       uuid.UUID `json:"strategy_id"`        // Strategy produced by the iteration being retried
+	SourceStrategyID uuid.UUID `json:"source_strategy_id"` // Strategy to regenerate from
+	Feedback         string    `json:"feedback"`           // Operator feedback for this retry
+	AnalystFeedback  string    `json:"analyst_feedback"`   // Accumulated feedback including the operator's
+	RequestedBy      string    `json:"requested_by"`
+}
+
+// NewOptimizationIterationRetryEvent creates a new OptimizationIterationRetryEvent.
+func NewOptimizationIterationRetryEvent(
+	iteration *domain.OptimizationIteration,
+	sourceStrategyID uuid.UUID,
+	feedback, requestedBy string,
+) *OptimizationIterationRetryEvent {
+	return &OptimizationIterationRetryEvent{
+		BaseEvent:        NewBaseEvent(EventTypeOptIterationRetry),
+		RunID:            iteration.OptimizationRunID,
+		IterationNumber:  iteration.IterationNumber,
+		StrategyID:       iteration.StrategyID,
+		SourceStrategyID: sourceStrategyID,
+		Feedback:         feedback,
+		AnalystFeedback:  iteration.AnalystFeedback,
+		RequestedBy:      requestedBy,
+	}
+}
+
+// =============================================================================
+// System Events (for the notification subsystem)
+// =============================================================================
+
+// SLABreachEvent is published when a job first exceeds a queue SLA target.
+type SLABreachEvent struct {
+	BaseEvent
+	Kind              domain.SLAKind `json:"kind"`
+	JobID             uuid.UUID      `json:"job_id"`
+	StrategyID        uuid.UUID      `json:"strategy_id"`
+	OptimizationRunID *uuid.UUID     `json:"optimization_run_id,omitempty"`
+	Priority          int            `json:"priority"`
+	Since             time.Time      `json:"since"`
+	ElapsedMs         int64          `json:"elapsed_ms"`
+	TargetMs          int64          `json:"target_ms"`
+}
+
+// NewSLABreachEvent creates a new SLABreachEvent.
+func NewSLABreachEvent(breach *domain.SLABreach) *SLABreachEvent {
+	return &SLABreachEvent{
+		BaseEvent:         NewBaseEvent(EventTypeSystemSLABreach),
+		Kind:              breach.Kind,
+		JobID:             breach.JobID,
+		StrategyID:        breach.StrategyID,
+		OptimizationRunID: breach.OptimizationRunID,
+		Priority:          breach.Priority,
+		Since:             breach.Since,
+		ElapsedMs:         breach.ElapsedMs,
+		TargetMs:          breach.TargetMs,
+	}
+}
+
+// MaintenanceEvent is published when maintenance mode is enabled or disabled,
+// so clients can warn their users before submissions start failing.
+type MaintenanceEvent struct {
+	BaseEvent
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message,omitempty"`
+	ChangedBy string     `json:"changed_by,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+}
+
+// NewMaintenanceEvent creates a new MaintenanceEvent.
+func NewMaintenanceEvent(mode *domain.MaintenanceMode) *MaintenanceEvent {
+	return &MaintenanceEvent{
+		BaseEvent: NewBaseEvent(EventTypeSystemMaintenance),
+		Enabled:   mode.Enabled,
+		Message:   mode.Message,
+		ChangedBy: mode.ChangedBy,
+		Since:     mode.Since,
+	}
 }
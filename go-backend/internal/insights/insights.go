@@ -0,0 +1,220 @@
+// Package insights runs pre-approved, parameterized SQL templates, giving
+// analysts one-off aggregates without raw SQL access. Templates come from
+// config or the insight_templates table and only ever run read-only, with
+// row and time limits.
+package insights
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// LimitParam is the reserved query parameter that lowers a run's row limit.
+const LimitParam = "limit"
+
+// Service looks up insight templates and runs them.
+type Service struct {
+	repo      repository.InsightRepository
+	templates map[string]*domain.InsightTemplate // Defined in config
+	maxRows   int
+	timeout   time.Duration
+}
+
+// NewService creates a service for the configured templates and limits. It
+// fails if a configured template is invalid.
+func NewService(cfg *config.InsightsConfig, repo repository.InsightRepository) (*Service, error) {
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid insights timeout: %w", err)
+	}
+
+	s := &Service{
+		repo:      repo,
+		templates: make(map[string]*domain.InsightTemplate, len(cfg.Templates)),
+		maxRows:   cfg.MaxRows,
+		timeout:   timeout,
+	}
+	for _, tc := range cfg.Templates {
+		tmpl, err := templateFromConfig(tc)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.templates[tmpl.Name]; ok {
+			return nil, fmt.Errorf("duplicate insight template %q", tmpl.Name)
+		}
+		s.templates[tmpl.Name] = tmpl
+	}
+	return s, nil
+}
+
+// templateFromConfig converts and validates a configured template.
+func templateFromConfig(tc config.InsightTemplateConfig) (*domain.InsightTemplate, error) {
+	tmpl := &domain.InsightTemplate{
+		Name:        tc.Name,
+		Description: tc.Description,
+		SQL:         tc.SQL,
+		Params:      make([]domain.InsightParam, len(tc.Params)),
+		MaxRows:     tc.MaxRows,
+		Source:      domain.InsightSourceConfig,
+	}
+	for i, p := range tc.Params {
+		tmpl.Params[i] = domain.InsightParam{
+			Name:        p.Name,
+			Type:        domain.InsightParamType(p.Type),
+			Required:    p.Required,
+			Default:     p.Default,
+			Description: p.Description,
+		}
+	}
+	if tc.Timeout != "" {
+		d, err := time.ParseDuration(tc.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for insight %q: %w", tc.Name, err)
+		}
+		tmpl.TimeoutMs = d.Milliseconds()
+	}
+	if err := tmpl.Validate(); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// List returns the templates from config and the database, ordered by name.
+// A stored template shadowed by a configured one, or one that is invalid, is
+// left out.
+func (s *Service) List(ctx context.Context) ([]*domain.InsightTemplate, error) {
+	stored, err := s.repo.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*domain.InsightTemplate, 0, len(s.templates)+len(stored))
+	for _, tmpl := range s.templates {
+		templates = append(templates, tmpl)
+	}
+	for _, tmpl := range stored {
+		if _, ok := s.templates[tmpl.Name]; ok || tmpl.Validate() != nil {
+			continue
+		}
+		templates = append(templates, tmpl)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Get returns a template by name, from config or else the database.
+func (s *Service) Get(ctx context.Context, name string) (*domain.InsightTemplate, error) {
+	if tmpl, ok := s.templates[name]; ok {
+		return tmpl, nil
+	}
+	tmpl, err := s.repo.GetTemplate(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	// Stored templates are checked on every run; a broken one is a server
+	// problem, not the caller's, so the validation error is not wrapped.
+	if err := tmpl.Validate(); err != nil {
+		return nil, fmt.Errorf("stored insight %s is invalid: %v", name, err)
+	}
+	return tmpl, nil
+}
+
+// Run binds the query parameters to a template and runs it within the row and
+// time limits.
+func (s *Service) Run(ctx context.Context, name string, values url.Values) (*domain.InsightResult, error) {
+	tmpl, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := bindParams(tmpl, values)
+	if err != nil {
+		return nil, err
+	}
+	maxRows, err := s.rowLimit(tmpl, values.Get(LimitParam))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.Execute(ctx, tmpl.SQL, args, maxRows, s.timeLimit(tmpl))
+	if err != nil {
+		return nil, err
+	}
+	result.Name = tmpl.Name
+	return result, nil
+}
+
+// bindParams converts the query parameters to the template's parameter types,
+// in placeholder order. Missing optional parameters take their default, or
+// NULL without one.
+func bindParams(tmpl *domain.InsightTemplate, values url.Values) ([]interface{}, error) {
+	known := make(map[string]bool, len(tmpl.Params)+1)
+	known[LimitParam] = true
+	for _, p := range tmpl.Params {
+		known[p.Name] = true
+	}
+	for name := range values {
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown parameter %s", domain.ErrInvalidInput, name)
+		}
+	}
+
+	args := make([]interface{}, len(tmpl.Params))
+	for i, p := range tmpl.Params {
+		given := values[p.Name]
+		if len(given) > 1 {
+			return nil, fmt.Errorf("%w: parameter %s given more than once", domain.ErrInvalidInput, p.Name)
+		}
+		value := p.Default
+		if len(given) == 1 {
+			value = given[0]
+		}
+		if value == "" {
+			if p.Required {
+				return nil, fmt.Errorf("%w: parameter %s is required", domain.ErrInvalidInput, p.Name)
+			}
+			continue
+		}
+		arg, err := p.Bind(value)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// rowLimit returns the lowest of the configured, template and requested row
+// limits.
+func (s *Service) rowLimit(tmpl *domain.InsightTemplate, requested string) (int, error) {
+	limit := s.maxRows
+	if tmpl.MaxRows > 0 && tmpl.MaxRows < limit {
+		limit = tmpl.MaxRows
+	}
+	if requested != "" {
+		n, err := strconv.Atoi(requested)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("%w: %s must be a positive integer", domain.ErrInvalidInput, LimitParam)
+		}
+		if n < limit {
+			limit = n
+		}
+	}
+	return limit, nil
+}
+
+// timeLimit returns the lower of the configured and template timeouts.
+func (s *Service) timeLimit(tmpl *domain.InsightTemplate) time.Duration {
+	if d := time.Duration(tmpl.TimeoutMs) * time.Millisecond; d > 0 && d < s.timeout {
+		return d
+	}
+	return s.timeout
+}
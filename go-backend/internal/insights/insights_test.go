@@ -0,0 +1,154 @@
+package insights
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// fakeRepo records the last Execute call and serves stored templates.
+type fakeRepo struct {
+	stored  map[string]*domain.InsightTemplate
+	sql     string
+	args    []interface{}
+	maxRows int
+	timeout time.Duration
+}
+
+func (f *fakeRepo) CreateTemplate(ctx context.Context, tmpl *domain.InsightTemplate) error {
+	f.stored[tmpl.Name] = tmpl
+	return nil
+}
+
+func (f *fakeRepo) GetTemplate(ctx context.Context, name string) (*domain.InsightTemplate, error) {
+	if tmpl, ok := f.stored[name]; ok {
+		return tmpl, nil
+	}
+	return nil, domain.NewNotFoundError("insight", name)
+}
+
+func (f *fakeRepo) ListTemplates(ctx context.Context) ([]*domain.InsightTemplate, error) {
+	var templates []*domain.InsightTemplate
+	for _, tmpl := range f.stored {
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+func (f *fakeRepo) Execute(ctx context.Context, sql string, args []interface{}, maxRows int, timeout time.Duration) (*domain.InsightResult, error) {
+	f.sql, f.args, f.maxRows, f.timeout = sql, args, maxRows, timeout
+	return &domain.InsightResult{MaxRows: maxRows}, nil
+}
+
+func newTestService(t *testing.T, repo *fakeRepo) *Service {
+	t.Helper()
+	svc, err := NewService(&config.InsightsConfig{
+		Enabled: true,
+		MaxRows: 100,
+		Timeout: "5s",
+		Templates: []config.InsightTemplateConfig{
+			{
+				Name: "jobs-by-status",
+				SQL:  "SELECT status, COUNT(*) FROM backtest_jobs WHERE created_at >= $1 AND ($2::uuid IS NULL OR strategy_id = $2) GROUP BY 1;",
+				Params: []config.InsightParamConfig{
+					{Name: "since", Type: "time", Required: true},
+					{Name: "strategy_id", Type: "uuid"},
+				},
+				MaxRows: 20,
+				Timeout: "1s",
+			},
+		},
+	}, repo)
+	require.NoError(t, err)
+	return svc
+}
+
+func TestService_RunBindsParamsAndLimits(t *testing.T) {
+	repo := &fakeRepo{stored: map[string]*domain.InsightTemplate{}}
+	svc := newTestService(t, repo)
+
+	result, err := svc.Run(context.Background(), "jobs-by-status", url.Values{
+		"since": {"2024-01-15T00:00:00Z"},
+		"limit": {"500"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "jobs-by-status", result.Name)
+	assert.NotContains(t, repo.sql, ";")
+	require.Len(t, repo.args, 2)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), repo.args[0])
+	assert.Nil(t, repo.args[1]) // Optional without default binds NULL
+	assert.Equal(t, 20, repo.maxRows, "template limit caps the requested one")
+	assert.Equal(t, time.Second, repo.timeout)
+
+	_, err = svc.Run(context.Background(), "jobs-by-status", url.Values{"since": {"2024-01-15T00:00:00Z"}, "limit": {"5"}})
+	require.NoError(t, err)
+	assert.Equal(t, 5, repo.maxRows)
+}
+
+func TestService_RunRejectsBadParams(t *testing.T) {
+	repo := &fakeRepo{stored: map[string]*domain.InsightTemplate{}}
+	svc := newTestService(t, repo)
+	ctx := context.Background()
+
+	for name, values := range map[string]url.Values{
+		"missing required": {},
+		"wrong type":       {"since": {"yesterday"}},
+		"unknown":          {"since": {"2024-01-15T00:00:00Z"}, "status": {"failed"}},
+		"repeated":         {"since": {"2024-01-15T00:00:00Z", "2024-01-16T00:00:00Z"}},
+		"bad limit":        {"since": {"2024-01-15T00:00:00Z"}, "limit": {"0"}},
+	} {
+		_, err := svc.Run(ctx, "jobs-by-status", values)
+		assert.ErrorIs(t, err, domain.ErrInvalidInput, name)
+	}
+
+	_, err := svc.Run(ctx, "missing", url.Values{})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestService_StoredTemplates(t *testing.T) {
+	repo := &fakeRepo{stored: map[string]*domain.InsightTemplate{
+		"top-strategies": {Name: "top-strategies", SQL: "SELECT name FROM strategies LIMIT 10", Source: domain.InsightSourceDatabase},
+		"jobs-by-status": {Name: "jobs-by-status", SQL: "SELECT 1", Source: domain.InsightSourceDatabase},
+		"purge":          {Name: "purge", SQL: "DELETE FROM strategies", Source: domain.InsightSourceDatabase},
+	}}
+	svc := newTestService(t, repo)
+
+	templates, err := svc.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, domain.InsightSourceConfig, templates[0].Source, "config shadows the stored template")
+	assert.Equal(t, "top-strategies", templates[1].Name)
+
+	_, err = svc.Run(context.Background(), "top-strategies", url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, 100, repo.maxRows)
+	assert.Equal(t, 5*time.Second, repo.timeout)
+
+	_, err = svc.Run(context.Background(), "purge", url.Values{})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestTemplateValidate(t *testing.T) {
+	for name, tmpl := range map[string]domain.InsightTemplate{
+		"not a select":       {Name: "x", SQL: "UPDATE strategies SET name = 'x'"},
+		"two statements":     {Name: "x", SQL: "SELECT 1; DROP TABLE strategies"},
+		"unbound $2":         {Name: "x", SQL: "SELECT $1, $2", Params: []domain.InsightParam{{Name: "a", Type: domain.InsightParamInt}}},
+		"bad name":           {Name: "Top Strategies", SQL: "SELECT 1"},
+		"reserved parameter": {Name: "x", SQL: "SELECT $1", Params: []domain.InsightParam{{Name: "limit", Type: domain.InsightParamInt}}},
+		"bad default":        {Name: "x", SQL: "SELECT $1", Params: []domain.InsightParam{{Name: "n", Type: domain.InsightParamInt, Default: "ten"}}},
+	} {
+		assert.ErrorIs(t, tmpl.Validate(), domain.ErrInvalidInput, name)
+	}
+
+	ok := domain.InsightTemplate{Name: "x", SQL: "  WITH t AS (SELECT $1::int AS n) SELECT n FROM t;  ", Params: []domain.InsightParam{{Name: "n", Type: domain.InsightParamInt, Default: "3"}}}
+	require.NoError(t, ok.Validate())
+	assert.Equal(t, "WITH t AS (SELECT $1::int AS n) SELECT n FROM t", ok.SQL)
+}
@@ -0,0 +1,270 @@
+package marketdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// downloadLogTail is how much of a failed download container's output is
+// kept as the download's error.
+const downloadLogTail = 2000
+
+// ErrDownloadFailed is returned when a download container fails.
+var ErrDownloadFailed = errors.New("market data download failed")
+
+// Downloader runs freqtrade download-data containers and keeps the recent
+// downloads for the API. Downloads are kept in memory only; a restart
+// forgets them and stops those running. An identical download requested
+// while one runs joins it rather than writing the same files twice.
+type Downloader struct {
+	dockerManager docker.Manager
+	timeout       time.Duration
+	history       int
+	clock         clock.Clock
+	logger        *zap.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	downloads []*download          // Oldest first
+	running   map[string]*download // By request key
+}
+
+// download is a download and a channel closed once it finishes.
+type download struct {
+	info domain.DataDownload
+	key  string
+	done chan struct{}
+}
+
+// NewDownloader creates a downloader running containers on dockerManager.
+func NewDownloader(cfg *config.MarketDataConfig, dockerManager docker.Manager, logger *zap.Logger) *Downloader {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Downloader{
+		dockerManager: dockerManager,
+		timeout:       cfg.DownloadTimeout(),
+		history:       cfg.DownloadHistory,
+		clock:         clock.Real(),
+		logger:        logger,
+		ctx:           ctx,
+		cancel:        cancel,
+		running:       make(map[string]*download),
+	}
+}
+
+// SetClock replaces the downloader's time source. It must be called before
+// any download starts.
+func (d *Downloader) SetClock(c clock.Clock) {
+	d.clock = c
+}
+
+// Start starts a download in the background, or returns the identical one
+// already running.
+func (d *Downloader) Start(req domain.DataDownloadRequest, requestedBy string) *domain.DataDownload {
+	dl := d.start(req, requestedBy)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	info := dl.info
+	return &info
+}
+
+// Download runs a download, or joins the identical one already running, and
+// waits for it to finish. It returns ErrDownloadFailed if the container
+// failed.
+func (d *Downloader) Download(ctx context.Context, req domain.DataDownloadRequest, requestedBy string) (*domain.DataDownload, error) {
+	dl := d.start(req, requestedBy)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-dl.done:
+	}
+
+	d.mu.Lock()
+	info := dl.info
+	d.mu.Unlock()
+	if info.Status == domain.DataDownloadFailed {
+		return &info, fmt.Errorf("%w: %s", ErrDownloadFailed, info.Error)
+	}
+	return &info, nil
+}
+
+// Get returns a download.
+func (d *Downloader) Get(id uuid.UUID) (*domain.DataDownload, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, dl := range d.downloads {
+		if dl.info.ID == id {
+			info := dl.info
+			return &info, nil
+		}
+	}
+	return nil, domain.NewNotFoundError("data download", id.String())
+}
+
+// List returns the running and recent downloads, newest first.
+func (d *Downloader) List() []*domain.DataDownload {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := make([]*domain.DataDownload, 0, len(d.downloads))
+	for i := len(d.downloads) - 1; i >= 0; i-- {
+		info := d.downloads[i].info
+		out = append(out, &info)
+	}
+	return out
+}
+
+// Stop stops the running downloads' containers and waits for them.
+func (d *Downloader) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// start registers a download and runs it, unless an identical one runs.
+func (d *Downloader) start(req domain.DataDownloadRequest, requestedBy string) *download {
+	key := requestKey(req)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if dl, ok := d.running[key]; ok {
+		return dl
+	}
+
+	dl := &download{
+		info: domain.DataDownload{
+			ID:          uuid.New(),
+			Request:     req,
+			Status:      domain.DataDownloadRunning,
+			RequestedBy: requestedBy,
+			CreatedAt:   d.clock.Now(),
+		},
+		key:  key,
+		done: make(chan struct{}),
+	}
+	d.running[key] = dl
+	d.downloads = append(d.downloads, dl)
+
+	d.wg.Add(1)
+	go d.run(dl)
+	return dl
+}
+
+// run runs a download's container and records how it ended.
+func (d *Downloader) run(dl *download) {
+	defer d.wg.Done()
+
+	ctx, cancel := context.WithTimeout(d.ctx, d.timeout)
+	defer cancel()
+
+	d.logger.Info("Starting market data download",
+		zap.String("download_id", dl.info.ID.String()),
+		zap.String("requested_by", dl.info.RequestedBy),
+		zap.String("exchange", dl.info.Request.Exchange),
+		zap.Strings("pairs", dl.info.Request.Pairs),
+		zap.Strings("timeframes", dl.info.Request.Timeframes),
+	)
+
+	containerID, err := d.dockerManager.RunDownloadData(ctx, &docker.DownloadDataParams{
+		DownloadID: dl.info.ID,
+		Request:    dl.info.Request,
+	})
+	if err != nil {
+		d.finish(dl, fmt.Sprintf("failed to start container: %v", err))
+		return
+	}
+
+	d.mu.Lock()
+	dl.info.ContainerID = containerID
+	d.mu.Unlock()
+
+	exitCode, logs, err := d.dockerManager.WaitContainer(ctx, containerID)
+	if rmErr := d.dockerManager.RemoveContainer(context.Background(), containerID); rmErr != nil {
+		d.logger.Warn("Failed to remove download container",
+			zap.String("container_id", containerID),
+			zap.Error(rmErr),
+		)
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		d.finish(dl, fmt.Sprintf("timed out after %s", d.timeout))
+	case err != nil:
+		d.finish(dl, err.Error())
+	case exitCode != 0:
+		if len(logs) > downloadLogTail {
+			logs = logs[len(logs)-downloadLogTail:]
+		}
+		d.finish(dl, fmt.Sprintf("exited with code %d: %s", exitCode, logs))
+	default:
+		d.finish(dl, "")
+	}
+}
+
+// finish records the end of a download, failed if errMsg is set, and drops
+// the oldest finished downloads beyond the history size.
+func (d *Downloader) finish(dl *download, errMsg string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.clock.Now()
+	dl.info.FinishedAt = &now
+	dl.info.Status = domain.DataDownloadCompleted
+	if errMsg != "" {
+		dl.info.Status = domain.DataDownloadFailed
+		dl.info.Error = errMsg
+		d.logger.Warn("Market data download failed",
+			zap.String("download_id", dl.info.ID.String()),
+			zap.String("error", errMsg),
+		)
+	} else {
+		d.logger.Info("Market data download completed",
+			zap.String("download_id", dl.info.ID.String()),
+			zap.Duration("duration", now.Sub(dl.info.CreatedAt)),
+		)
+	}
+	delete(d.running, dl.key)
+	close(dl.done)
+
+	finished := len(d.downloads) - len(d.running)
+	for i := 0; i < len(d.downloads) && finished > d.history; {
+		if d.downloads[i].info.IsFinished() {
+			d.downloads = slices.Delete(d.downloads, i, i+1)
+			finished--
+			continue
+		}
+		i++
+	}
+}
+
+// requestKey identifies the data a request downloads; requests with equal
+// keys download the same files.
+func requestKey(req domain.DataDownloadRequest) string {
+	pairs := slices.Clone(req.Pairs)
+	slices.Sort(pairs)
+	timeframes := slices.Clone(req.Timeframes)
+	slices.Sort(timeframes)
+	return strings.Join([]string{
+		req.Exchange, req.GetTradingMode(),
+		strings.Join(pairs, ","), strings.Join(timeframes, ","),
+		req.TimerangeStart, req.TimerangeEnd,
+	}, "|")
+}
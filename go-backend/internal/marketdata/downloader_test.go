@@ -0,0 +1,97 @@
+package marketdata
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockDownloadManager runs download containers that exit once released.
+type mockDownloadManager struct {
+	docker.Manager
+	exitCode int64
+	release  chan struct{}
+
+	mu      sync.Mutex
+	started []*docker.DownloadDataParams
+	removed []string
+}
+
+func (m *mockDownloadManager) RunDownloadData(ctx context.Context, params *docker.DownloadDataParams) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = append(m.started, params)
+	return "container-" + params.DownloadID.String(), nil
+}
+
+func (m *mockDownloadManager) WaitContainer(ctx context.Context, containerID string) (int64, string, error) {
+	<-m.release
+	return m.exitCode, "Downloading BTC/USDT\nERROR - Pair ETH/USDT not available", nil
+}
+
+func (m *mockDownloadManager) RemoveContainer(ctx context.Context, containerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.removed = append(m.removed, containerID)
+	return nil
+}
+
+func TestDownloader_JoinsIdenticalDownloads(t *testing.T) {
+	manager := &mockDownloadManager{release: make(chan struct{})}
+	d := NewDownloader(&config.MarketDataConfig{DownloadHistory: 10}, manager, zaptest.NewLogger(t))
+	defer d.Stop()
+
+	req := domain.DataDownloadRequest{Exchange: "binance", Pairs: []string{"ETH/USDT", "BTC/USDT"}, Timeframes: []string{"5m"}}
+	first := d.Start(req, "api")
+	assert.Equal(t, domain.DataDownloadRunning, first.Status)
+
+	// The same data in another order joins the running download
+	joined := d.Start(domain.DataDownloadRequest{Exchange: "binance", Pairs: []string{"BTC/USDT", "ETH/USDT"}, Timeframes: []string{"5m"}}, "job:1")
+	assert.Equal(t, first.ID, joined.ID)
+
+	close(manager.release)
+	got, err := d.Download(context.Background(), req, "job:2")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DataDownloadCompleted, got.Status)
+	require.NotNil(t, got.FinishedAt)
+
+	assert.Len(t, d.List(), 1)
+	manager.mu.Lock()
+	assert.Len(t, manager.started, 1)
+	assert.Len(t, manager.removed, 1)
+	manager.mu.Unlock()
+}
+
+func TestDownloader_Failure(t *testing.T) {
+	manager := &mockDownloadManager{exitCode: 2, release: make(chan struct{})}
+	close(manager.release)
+	d := NewDownloader(&config.MarketDataConfig{DownloadHistory: 1}, manager, zaptest.NewLogger(t))
+	defer d.Stop()
+
+	got, err := d.Download(context.Background(), domain.DataDownloadRequest{Exchange: "binance"}, "api")
+	require.ErrorIs(t, err, ErrDownloadFailed)
+	assert.Equal(t, domain.DataDownloadFailed, got.Status)
+	assert.Contains(t, got.Error, "exited with code 2")
+	assert.Contains(t, got.Error, "Pair ETH/USDT not available")
+
+	stored, err := d.Get(got.ID)
+	require.NoError(t, err)
+	assert.Equal(t, got.Error, stored.Error)
+
+	// Only the most recent finished download is kept
+	second, err := d.Download(context.Background(), domain.DataDownloadRequest{Exchange: "kraken"}, "api")
+	require.Error(t, err)
+	_, err = d.Get(got.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	list := d.List()
+	require.Len(t, list, 1)
+	assert.Equal(t, second.ID, list[0].ID)
+}
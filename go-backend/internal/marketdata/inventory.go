@@ -0,0 +1,258 @@
+// Package marketdata manages the Freqtrade market data backtests run on: it
+// lists the candle files in the data directory, tells which ones a backtest
+// lacks, and downloads data with freqtrade download-data containers.
+//
+// The data directory is laid out as Freqtrade writes it:
+// <dir>/<exchange>/<PAIR>-<timeframe>.<ext> for spot candles and
+// <dir>/<exchange>/futures/<PAIR>-<timeframe>-<candle type>.<ext> for
+// futures, where PAIR is the pair with "/" and ":" replaced by "_".
+package marketdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// defaultTimeframe is the timeframe backtests run on when neither their
+// config nor the base config sets one.
+const defaultTimeframe = "5m"
+
+// defaultSettleCurrency settles futures pairs given without one, as the
+// backtest containers do.
+const defaultSettleCurrency = "USDT"
+
+// candleTypes are the futures candle file suffixes.
+var candleTypes = map[string]bool{
+	"futures": true, "mark": true, "index": true, "funding_rate": true, "premiumIndex": true,
+}
+
+// Defaults are the base Freqtrade config's settings that apply to backtests
+// leaving them unset.
+type Defaults struct {
+	Exchange  string
+	Pairs     []string
+	Timeframe string
+}
+
+// LoadDefaults reads the exchange, pair whitelist and timeframe of the base
+// Freqtrade config.
+func LoadDefaults(path string) (Defaults, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Defaults{}, fmt.Errorf("failed to read base config: %w", err)
+	}
+
+	var base struct {
+		Exchange struct {
+			Name          string   `json:"name"`
+			PairWhitelist []string `json:"pair_whitelist"`
+		} `json:"exchange"`
+		Timeframe string `json:"timeframe"`
+	}
+	if err := json.Unmarshal(data, &base); err != nil {
+		return Defaults{}, fmt.Errorf("failed to parse base config: %w", err)
+	}
+
+	return Defaults{
+		Exchange:  base.Exchange.Name,
+		Pairs:     base.Exchange.PairWhitelist,
+		Timeframe: base.Timeframe,
+	}, nil
+}
+
+// Inventory reads the market data directory.
+type Inventory struct {
+	dir      string
+	defaults Defaults
+}
+
+// NewInventory creates an inventory of a data directory. defaults resolve
+// the data of backtests that leave the exchange, pairs or timeframe unset.
+func NewInventory(dir string, defaults Defaults) *Inventory {
+	return &Inventory{dir: dir, defaults: defaults}
+}
+
+// List returns the candle files matching the filter, ordered by exchange,
+// trading mode, pair, timeframe and candle type. A missing data directory
+// holds no data.
+func (inv *Inventory) List(filter domain.MarketDataFilter) ([]domain.MarketDataSet, error) {
+	exchanges, err := os.ReadDir(inv.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []domain.MarketDataSet{}, nil
+		}
+		return nil, fmt.Errorf("failed to read data directory: %w", err)
+	}
+
+	sets := []domain.MarketDataSet{}
+	for _, ex := range exchanges {
+		if !ex.IsDir() || (filter.Exchange != "" && ex.Name() != filter.Exchange) {
+			continue
+		}
+		for _, mode := range []string{domain.TradingModeSpot, domain.TradingModeFutures} {
+			dir := filepath.Join(inv.dir, ex.Name())
+			if mode == domain.TradingModeFutures {
+				dir = filepath.Join(dir, "futures")
+			}
+			files, err := os.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+			for _, f := range files {
+				ds, ok := parseDataFile(f.Name(), mode)
+				if !ok || f.IsDir() {
+					continue
+				}
+				ds.Exchange = ex.Name()
+				if !filter.Matches(ds) {
+					continue
+				}
+				if info, err := f.Info(); err == nil {
+					ds.SizeBytes = info.Size()
+					ds.UpdatedAt = info.ModTime().UTC()
+				}
+				sets = append(sets, ds)
+			}
+		}
+	}
+
+	sort.Slice(sets, func(i, j int) bool {
+		a, b := sets[i], sets[j]
+		if a.Exchange != b.Exchange {
+			return a.Exchange < b.Exchange
+		}
+		if a.TradingMode != b.TradingMode {
+			return a.TradingMode < b.TradingMode
+		}
+		if a.Pair != b.Pair {
+			return a.Pair < b.Pair
+		}
+		if a.Timeframe != b.Timeframe {
+			return a.Timeframe < b.Timeframe
+		}
+		return a.CandleType < b.CandleType
+	})
+	return sets, nil
+}
+
+// Missing returns the pairs a backtest lacks candle files for, or nil if it
+// has them all. Backtests whose exchange or pairs are set neither in their
+// config nor in the base config cannot be checked and are reported complete.
+// Only the presence of the files is checked, not the timerange they cover.
+func (inv *Inventory) Missing(cfg domain.BacktestConfig) (*domain.MissingMarketData, error) {
+	exchange := firstNonEmpty(cfg.Exchange, inv.defaults.Exchange)
+	pairs := cfg.Pairs
+	if len(pairs) == 0 {
+		pairs = inv.defaults.Pairs
+	}
+	if exchange == "" || len(pairs) == 0 {
+		return nil, nil
+	}
+
+	missing := &domain.MissingMarketData{
+		Exchange:    exchange,
+		TradingMode: cfg.GetTradingMode(),
+		Timeframe:   firstNonEmpty(cfg.Timeframe, inv.defaults.Timeframe, defaultTimeframe),
+	}
+
+	dir := filepath.Join(inv.dir, exchange)
+	suffix := "-" + missing.Timeframe
+	if missing.TradingMode == domain.TradingModeFutures {
+		dir = filepath.Join(dir, "futures")
+		suffix += "-futures"
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read data directory: %w", err)
+	}
+	present := make(map[string]bool, len(files))
+	for _, f := range files {
+		base, _, _ := strings.Cut(f.Name(), ".")
+		present[base] = true
+	}
+
+	for _, pair := range pairs {
+		if missing.TradingMode == domain.TradingModeFutures && !strings.Contains(pair, ":") {
+			pair += ":" + firstNonEmpty(cfg.StakeCurrency, defaultSettleCurrency)
+		}
+		if !present[pairFileName(pair)+suffix] {
+			missing.Pairs = append(missing.Pairs, pair)
+		}
+	}
+	if len(missing.Pairs) == 0 {
+		return nil, nil
+	}
+	return missing, nil
+}
+
+// parseDataFile parses a candle file name such as "BTC_USDT-5m.feather" or
+// "BTC_USDT_USDT-1h-futures.feather". Trades files and files not named
+// after a timeframe are skipped.
+func parseDataFile(name, mode string) (domain.MarketDataSet, bool) {
+	base, format, ok := strings.Cut(name, ".")
+	if !ok {
+		return domain.MarketDataSet{}, false
+	}
+
+	parts := strings.Split(base, "-")
+	candleType := domain.TradingModeSpot
+	if len(parts) > 2 && candleTypes[parts[len(parts)-1]] {
+		candleType = parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) < 2 {
+		return domain.MarketDataSet{}, false
+	}
+	timeframe := parts[len(parts)-1]
+	if timeframe == "" || timeframe[0] < '0' || timeframe[0] > '9' {
+		return domain.MarketDataSet{}, false
+	}
+	if mode == domain.TradingModeFutures && candleType == domain.TradingModeSpot {
+		return domain.MarketDataSet{}, false
+	}
+
+	return domain.MarketDataSet{
+		Pair:        filePair(strings.Join(parts[:len(parts)-1], "-"), mode),
+		Timeframe:   timeframe,
+		TradingMode: mode,
+		CandleType:  candleType,
+		Format:      format,
+	}, true
+}
+
+// pairFileName returns the file name stem Freqtrade stores a pair's data
+// under.
+func pairFileName(pair string) string {
+	return strings.NewReplacer("/", "_", ":", "_", " ", "_", ".", "_", "@", "_", "$", "_", "+", "_").Replace(pair)
+}
+
+// filePair recovers the pair from a file name stem: the last part is the
+// quote currency, or for futures the quote and settle currencies.
+func filePair(stem, mode string) string {
+	parts := strings.Split(stem, "_")
+	if mode == domain.TradingModeFutures && len(parts) >= 3 {
+		n := len(parts)
+		return strings.Join(parts[:n-2], "_") + "/" + parts[n-2] + ":" + parts[n-1]
+	}
+	if len(parts) >= 2 {
+		n := len(parts)
+		return strings.Join(parts[:n-1], "_") + "/" + parts[n-1]
+	}
+	return stem
+}
+
+// firstNonEmpty returns the first of values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
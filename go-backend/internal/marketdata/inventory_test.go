@@ -0,0 +1,98 @@
+package marketdata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// writeDataFiles creates empty files under dir.
+func writeDataFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0o644))
+	}
+}
+
+func TestInventory_List(t *testing.T) {
+	dir := t.TempDir()
+	writeDataFiles(t, dir,
+		"binance/BTC_USDT-5m.feather",
+		"binance/BTC_USDT-trades.feather",
+		"binance/futures/BTC_USDT_USDT-1h-futures.feather",
+		"binance/futures/BTC_USDT_USDT-8h-funding_rate.feather",
+		"binance/futures/1000SHIB_USDT_USDT-1h-futures.json",
+		"kraken/ETH_EUR-1d.json.gz",
+		"leverage_tiers.json",
+	)
+	inv := NewInventory(dir, Defaults{})
+
+	sets, err := inv.List(domain.MarketDataFilter{})
+	require.NoError(t, err)
+	var got []string
+	for _, ds := range sets {
+		got = append(got, ds.Exchange+" "+ds.TradingMode+" "+ds.Pair+" "+ds.Timeframe+" "+ds.CandleType+" "+ds.Format)
+	}
+	assert.Equal(t, []string{
+		"binance futures 1000SHIB/USDT:USDT 1h futures json",
+		"binance futures BTC/USDT:USDT 1h futures feather",
+		"binance futures BTC/USDT:USDT 8h funding_rate feather",
+		"binance spot BTC/USDT 5m spot feather",
+		"kraken spot ETH/EUR 1d spot json.gz",
+	}, got)
+	assert.Equal(t, int64(1), sets[0].SizeBytes)
+
+	sets, err = inv.List(domain.MarketDataFilter{Exchange: "binance", Timeframe: "1h"})
+	require.NoError(t, err)
+	assert.Len(t, sets, 2)
+
+	sets, err = NewInventory(filepath.Join(dir, "missing"), Defaults{}).List(domain.MarketDataFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, sets)
+}
+
+func TestInventory_Missing(t *testing.T) {
+	dir := t.TempDir()
+	writeDataFiles(t, dir,
+		"binance/BTC_USDT-5m.feather",
+		"binance/futures/BTC_USDT_USDT-1h-futures.feather",
+	)
+	inv := NewInventory(dir, Defaults{Exchange: "binance", Pairs: []string{"BTC/USDT", "ETH/USDT"}, Timeframe: "1h"})
+
+	t.Run("FuturesFromDefaults", func(t *testing.T) {
+		missing, err := inv.Missing(domain.BacktestConfig{})
+		require.NoError(t, err)
+		require.NotNil(t, missing)
+		assert.Equal(t, &domain.MissingMarketData{
+			Exchange:    "binance",
+			TradingMode: "futures",
+			Timeframe:   "1h",
+			Pairs:       []string{"ETH/USDT:USDT"},
+		}, missing)
+		assert.Equal(t, "no binance futures 1h candles for ETH/USDT:USDT", missing.String())
+	})
+
+	t.Run("Spot", func(t *testing.T) {
+		missing, err := inv.Missing(domain.BacktestConfig{Pairs: []string{"BTC/USDT"}, Timeframe: "5m", TradingMode: "spot"})
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+
+		missing, err = inv.Missing(domain.BacktestConfig{Exchange: "kraken", Pairs: []string{"BTC/USDT"}, TradingMode: "spot"})
+		require.NoError(t, err)
+		require.NotNil(t, missing)
+		assert.Equal(t, []string{"BTC/USDT"}, missing.Pairs)
+	})
+
+	t.Run("Unresolved", func(t *testing.T) {
+		missing, err := NewInventory(dir, Defaults{}).Missing(domain.BacktestConfig{Exchange: "binance"})
+		require.NoError(t, err)
+		assert.Nil(t, missing, "without pairs nothing can be checked")
+	})
+}
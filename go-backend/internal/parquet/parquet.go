@@ -0,0 +1,327 @@
+// Package parquet writes flat tables as Apache Parquet files, one row group
+// at a time, so large exports can be streamed. Pages are PLAIN encoded and
+// uncompressed, which every Parquet reader supports.
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Type is the type of a column.
+type Type int
+
+const (
+	// String columns hold UTF-8 strings.
+	String Type = iota
+	// Int64 columns hold 64-bit integers.
+	Int64
+	// Double columns hold 64-bit floats.
+	Double
+	// Timestamp columns hold instants in UTC, with microsecond precision.
+	Timestamp
+)
+
+// Column describes a column of a table. Optional columns may hold nulls.
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool
+}
+
+// magic starts and ends every Parquet file.
+const magic = "PAR1"
+
+// createdBy names the writer in the file footer.
+const createdBy = "freqsearch"
+
+// Physical types, encodings and other enums of the Parquet format.
+const (
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	convertedUTF8            = 0
+	convertedTimestampMicros = 10
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	pageTypeData = 0
+)
+
+// columnChunk records where a column of a row group was written.
+type columnChunk struct {
+	offset int64
+	size   int64
+	values int64
+}
+
+// rowGroup records a written row group for the footer.
+type rowGroup struct {
+	rows    int64
+	columns []columnChunk
+}
+
+// Writer writes a Parquet file. Each call to WriteRowGroup writes its rows
+// straight to the underlying writer; Close writes the footer, without which
+// the file cannot be read.
+type Writer struct {
+	w         io.Writer
+	columns   []Column
+	offset    int64
+	rowGroups []rowGroup
+	err       error
+}
+
+// NewWriter returns a Writer of a table with the given columns.
+func NewWriter(w io.Writer, columns []Column) *Writer {
+	return &Writer{w: w, columns: columns}
+}
+
+func (w *Writer) write(b []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	w.err = err
+}
+
+// WriteRowGroup writes rows as a row group. A row has a value for each
+// column, in order: a string, int64, float64 or time.Time to match the
+// column's type, or nil in an optional column. An empty set of rows writes
+// nothing.
+func (w *Writer) WriteRowGroup(rows [][]any) error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	for _, row := range rows {
+		if len(row) != len(w.columns) {
+			return fmt.Errorf("parquet: row has %d values, want %d", len(row), len(w.columns))
+		}
+	}
+	pages := make([][]byte, len(w.columns))
+	for i, col := range w.columns {
+		page, err := encodePage(col, i, rows)
+		if err != nil {
+			return err
+		}
+		pages[i] = page
+	}
+
+	if w.offset == 0 {
+		w.write([]byte(magic))
+	}
+	group := rowGroup{rows: int64(len(rows)), columns: make([]columnChunk, len(pages))}
+	for i, page := range pages {
+		group.columns[i] = columnChunk{offset: w.offset, size: int64(len(page)), values: int64(len(rows))}
+		w.write(page)
+	}
+	if w.err != nil {
+		return w.err
+	}
+	w.rowGroups = append(w.rowGroups, group)
+	return nil
+}
+
+// Close writes the file footer. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.offset == 0 {
+		w.write([]byte(magic))
+	}
+	footer := w.footer()
+	w.write(footer)
+	w.write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer))))
+	w.write([]byte(magic))
+	if w.err == nil {
+		w.err = errors.New("parquet: writer is closed")
+		return nil
+	}
+	return w.err
+}
+
+// encodePage encodes column i of rows as a data page with its header.
+func encodePage(col Column, i int, rows [][]any) ([]byte, error) {
+	var levels, values []byte
+	for _, row := range rows {
+		v := row[i]
+		if v == nil {
+			if !col.Optional {
+				return nil, fmt.Errorf("parquet: null in required column %s", col.Name)
+			}
+			levels = append(levels, 0)
+			continue
+		}
+		levels = append(levels, 1)
+
+		var ok bool
+		switch col.Type {
+		case String:
+			var s string
+			if s, ok = v.(string); ok {
+				values = binary.LittleEndian.AppendUint32(values, uint32(len(s)))
+				values = append(values, s...)
+			}
+		case Int64:
+			var n int64
+			if n, ok = v.(int64); ok {
+				values = binary.LittleEndian.AppendUint64(values, uint64(n))
+			}
+		case Double:
+			var f float64
+			if f, ok = v.(float64); ok {
+				values = binary.LittleEndian.AppendUint64(values, math.Float64bits(f))
+			}
+		case Timestamp:
+			var t time.Time
+			if t, ok = v.(time.Time); ok {
+				values = binary.LittleEndian.AppendUint64(values, uint64(t.UnixMicro()))
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("parquet: value of type %T in column %s", v, col.Name)
+		}
+	}
+
+	// Without repetition, a page is its definition levels, if the column is
+	// optional, followed by the values
+	var data []byte
+	if col.Optional {
+		encoded := appendLevels(nil, levels)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(encoded)))
+		data = append(data, encoded...)
+	}
+	data = append(data, values...)
+
+	t := newThriftWriter()
+	t.i32(1, pageTypeData)
+	t.i32(2, int32(len(data)))
+	t.i32(3, int32(len(data)))
+	t.beginStruct(5)
+	t.i32(1, int32(len(rows)))
+	t.i32(2, encodingPlain)
+	t.i32(3, encodingRLE)
+	t.i32(4, encodingRLE)
+	t.endStruct()
+	t.endStruct()
+	return append(t.buf, data...), nil
+}
+
+// appendLevels appends definition levels of bit width 1 as RLE runs.
+func appendLevels(buf []byte, levels []byte) []byte {
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		buf = binary.AppendUvarint(buf, uint64(j-i)<<1)
+		buf = append(buf, levels[i])
+		i = j
+	}
+	return buf
+}
+
+// footer encodes the file metadata: the schema and the written row groups.
+func (w *Writer) footer() []byte {
+	var rows int64
+	for _, g := range w.rowGroups {
+		rows += g.rows
+	}
+
+	t := newThriftWriter()
+	t.i32(1, 1)
+	t.list(2, thriftStruct, len(w.columns)+1)
+	t.beginElem()
+	t.string(4, "schema")
+	t.i32(5, int32(len(w.columns)))
+	t.endStruct()
+	for _, col := range w.columns {
+		t.beginElem()
+		t.i32(1, col.Type.physical())
+		repetition := int32(repetitionRequired)
+		if col.Optional {
+			repetition = repetitionOptional
+		}
+		t.i32(3, repetition)
+		t.string(4, col.Name)
+		switch col.Type {
+		case String:
+			t.i32(6, convertedUTF8)
+			t.beginStruct(10)
+			t.beginStruct(1)
+			t.endStruct()
+			t.endStruct()
+		case Timestamp:
+			t.i32(6, convertedTimestampMicros)
+			t.beginStruct(10)
+			t.beginStruct(8)
+			t.bool(1, true)
+			t.beginStruct(2)
+			t.beginStruct(2)
+			t.endStruct()
+			t.endStruct()
+			t.endStruct()
+			t.endStruct()
+		}
+		t.endStruct()
+	}
+	t.i64(3, rows)
+
+	t.list(4, thriftStruct, len(w.rowGroups))
+	for _, g := range w.rowGroups {
+		var size int64
+		for _, c := range g.columns {
+			size += c.size
+		}
+		t.beginElem()
+		t.list(1, thriftStruct, len(g.columns))
+		for i, c := range g.columns {
+			t.beginElem()
+			t.i64(2, c.offset)
+			t.beginStruct(3)
+			t.i32(1, w.columns[i].Type.physical())
+			t.list(2, thriftI32, 2)
+			t.appendI32(encodingPlain)
+			t.appendI32(encodingRLE)
+			t.list(3, thriftBinary, 1)
+			t.appendString(w.columns[i].Name)
+			t.i32(4, 0)
+			t.i64(5, c.values)
+			t.i64(6, c.size)
+			t.i64(7, c.size)
+			t.i64(9, c.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64(2, size)
+		t.i64(3, g.rows)
+		t.endStruct()
+	}
+	t.string(6, createdBy)
+	t.endStruct()
+	return t.buf
+}
+
+// physical returns the physical type a column type is stored as.
+func (t Type) physical() int32 {
+	switch t {
+	case String:
+		return physicalByteArray
+	case Double:
+		return physicalDouble
+	default:
+		return physicalInt64
+	}
+}
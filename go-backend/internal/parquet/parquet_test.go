@@ -0,0 +1,186 @@
+package parquet
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testColumns = []Column{
+	{Name: "id", Type: String},
+	{Name: "trades", Type: Int64},
+	{Name: "sharpe", Type: Double, Optional: true},
+	{Name: "host", Type: String, Optional: true},
+	{Name: "created_at", Type: Timestamp},
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 123456000, time.UTC)
+	groups := [][][]any{
+		{
+			{"a", int64(10), 1.25, "worker-1", created},
+			{"b", int64(0), nil, nil, created.Add(time.Hour)},
+			{"", int64(-3), -0.5, "", created.Add(2 * time.Hour)},
+		},
+		{},
+		{
+			{"c", int64(7), nil, "worker-2", created},
+		},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, testColumns)
+	var want [][]any
+	for _, rows := range groups {
+		require.NoError(t, w.WriteRowGroup(rows))
+		want = append(want, rows...)
+	}
+	require.NoError(t, w.Close())
+	assert.Error(t, w.WriteRowGroup(groups[0]), "writes after Close fail")
+
+	columns, rows, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, testColumns, columns)
+	assert.Equal(t, want, rows)
+}
+
+func TestWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf, testColumns).Close())
+
+	columns, rows, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, testColumns, columns)
+	assert.Empty(t, rows)
+}
+
+func TestWriter_InvalidRows(t *testing.T) {
+	created := time.Now()
+	for _, tc := range []struct {
+		name string
+		row  []any
+	}{
+		{"too few values", []any{"a", int64(1)}},
+		{"null in required column", []any{nil, int64(1), nil, nil, created}},
+		{"wrong type", []any{"a", 1, nil, nil, created}},
+		{"wrong type in optional column", []any{"a", int64(1), "1.5", nil, created}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, testColumns)
+			assert.Error(t, w.WriteRowGroup([][]any{tc.row}))
+			assert.Zero(t, buf.Len(), "invalid rows write nothing")
+		})
+	}
+}
+
+// goldenColumns and goldenRows are encoded by hand in golden, following
+// the Parquet format specification rather than Writer.
+var (
+	goldenColumns = []Column{
+		{Name: "id", Type: String},
+		{Name: "sharpe", Type: Double, Optional: true},
+		{Name: "created_at", Type: Timestamp},
+	}
+	goldenCreated = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	goldenRows    = [][]any{
+		{"a", 1.5, goldenCreated},
+		{"b", nil, goldenCreated},
+	}
+)
+
+var golden = strings.Join([]string{
+	"PAR1",
+	// Column chunk id at offset 4: a PageHeader of type DATA_PAGE with
+	// 10 byte pages and a DataPageHeader of 2 PLAIN values with RLE levels,
+	// then the length prefixed values.
+	"\x15\x00\x15\x14\x15\x14\x2c\x15\x04\x15\x00\x15\x06\x15\x06\x00\x00",
+	"\x01\x00\x00\x00a\x01\x00\x00\x00b",
+	// Column chunk sharpe at offset 31: 16 byte pages holding the length
+	// prefixed definition levels, runs of one 1 and one 0, then 1.5.
+	"\x15\x00\x15\x20\x15\x20\x2c\x15\x04\x15\x00\x15\x06\x15\x06\x00\x00",
+	"\x04\x00\x00\x00\x02\x01\x02\x00",
+	"\x00\x00\x00\x00\x00\x00\xf8\x3f",
+	// Column chunk created_at at offset 64: microseconds since the epoch.
+	"\x15\x00\x15\x20\x15\x20\x2c\x15\x04\x15\x00\x15\x06\x15\x06\x00\x00",
+	"\x00\xd0\xed\xd6\xd2\x19\x06\x00\x00\xd0\xed\xd6\xd2\x19\x06\x00",
+	// FileMetaData: version 1 and a schema of the root and three elements.
+	"\x15\x02\x19\x4c",
+	"\x48\x06schema\x15\x06\x00",
+	// BYTE_ARRAY, REQUIRED, converted type UTF8, logical type STRING.
+	"\x15\x0c\x25\x00\x18\x02id\x25\x00\x4c\x1c\x00\x00\x00",
+	// DOUBLE, OPTIONAL.
+	"\x15\x0a\x25\x02\x18\x06sharpe\x00",
+	// INT64, REQUIRED, converted type TIMESTAMP_MICROS, logical type
+	// TIMESTAMP adjusted to UTC in MICROS.
+	"\x15\x04\x25\x00\x18\x0acreated_at\x25\x14\x4c\x8c\x11\x1c\x2c\x00\x00\x00\x00\x00",
+	// 2 rows in one row group of three ColumnChunks, each with its file
+	// offset and ColumnMetaData: type, encodings PLAIN and RLE, path,
+	// codec UNCOMPRESSED, 2 values, sizes and data page offset.
+	"\x16\x04\x19\x1c\x19\x3c",
+	"\x26\x08\x1c\x15\x0c\x19\x25\x00\x06\x19\x18\x02id\x15\x00\x16\x04\x16\x36\x16\x36\x26\x08\x00\x00",
+	"\x26\x3e\x1c\x15\x0a\x19\x25\x00\x06\x19\x18\x06sharpe\x15\x00\x16\x04\x16\x42\x16\x42\x26\x3e\x00\x00",
+	"\x26\x80\x01\x1c\x15\x04\x19\x25\x00\x06\x19\x18\x0acreated_at\x15\x00\x16\x04\x16\x42\x16\x42\x26\x80\x01\x00\x00",
+	// The row group's 93 bytes and 2 rows, then created_by.
+	"\x16\xba\x01\x16\x04\x00",
+	"\x28\x0afreqsearch\x00",
+	// The 188 byte footer length.
+	"\xbc\x00\x00\x00PAR1",
+}, "")
+
+func TestWriter_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, goldenColumns)
+	require.NoError(t, w.WriteRowGroup(goldenRows))
+	require.NoError(t, w.Close())
+	assert.Equal(t, []byte(golden), buf.Bytes())
+
+	columns, rows, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, goldenColumns, columns)
+	assert.Equal(t, goldenRows, rows)
+}
+
+// TestWriter_PyArrow reads a written file with pyarrow, when it is
+// installed, to check that another implementation reads it as written.
+func TestWriter_PyArrow(t *testing.T) {
+	if err := exec.Command("python3", "-c", "import pyarrow.parquet").Run(); err != nil {
+		t.Skip("pyarrow is not installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "golden.parquet")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := NewWriter(f, goldenColumns)
+	require.NoError(t, w.WriteRowGroup(goldenRows))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	const script = `
+import json, sys
+import pyarrow.parquet as pq
+table = pq.read_table(sys.argv[1])
+schema = [[f.name, str(f.type), f.nullable] for f in table.schema]
+print(json.dumps({"schema": schema, "rows": table.to_pylist()}, default=str))
+`
+	out, err := exec.Command("python3", "-c", script, path).Output()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schema": [
+			["id", "string", false],
+			["sharpe", "double", true],
+			["created_at", "timestamp[us, tz=UTC]", false]
+		],
+		"rows": [
+			{"id": "a", "sharpe": 1.5, "created_at": "2024-06-01 12:00:00+00:00"},
+			{"id": "b", "sharpe": null, "created_at": "2024-06-01 12:00:00+00:00"}
+		]
+	}`, string(out))
+}
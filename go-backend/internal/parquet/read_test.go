@@ -0,0 +1,295 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// errMalformed is returned for data that is not a Parquet file as written
+// by Writer.
+var errMalformed = errors.New("parquet: malformed file")
+
+// Read decodes a file written by Writer into its columns and rows, with
+// values typed as WriteRowGroup takes them, so tests can check what was
+// written. It reads only what Writer writes: one uncompressed, PLAIN encoded
+// data page per column chunk.
+func Read(data []byte) ([]Column, [][]any, error) {
+	if len(data) < 2*len(magic)+4 || !bytes.HasPrefix(data, []byte(magic)) || !bytes.HasSuffix(data, []byte(magic)) {
+		return nil, nil, errMalformed
+	}
+	end := len(data) - len(magic) - 4
+	size := int(binary.LittleEndian.Uint32(data[end:]))
+	if size > end-len(magic) {
+		return nil, nil, errMalformed
+	}
+	meta, err := (&thriftReader{buf: data[end-size : end]}).readStruct()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema, _ := meta[2].([]any)
+	if len(schema) == 0 {
+		return nil, nil, errMalformed
+	}
+	columns := make([]Column, 0, len(schema)-1)
+	for _, elem := range schema[1:] {
+		fields, _ := elem.(map[int16]any)
+		name, _ := fields[4].([]byte)
+		col := Column{Name: string(name), Optional: fields[3] == int64(repetitionOptional)}
+		switch fields[1] {
+		case int64(physicalByteArray):
+			col.Type = String
+		case int64(physicalDouble):
+			col.Type = Double
+		case int64(physicalInt64):
+			col.Type = Int64
+			if fields[6] == int64(convertedTimestampMicros) {
+				col.Type = Timestamp
+			}
+		default:
+			return nil, nil, fmt.Errorf("parquet: unsupported type of column %s", col.Name)
+		}
+		columns = append(columns, col)
+	}
+
+	var rows [][]any
+	groups, _ := meta[4].([]any)
+	for _, g := range groups {
+		group, _ := g.(map[int16]any)
+		chunks, _ := group[1].([]any)
+		numRows, _ := group[3].(int64)
+		if len(chunks) != len(columns) || numRows < 0 {
+			return nil, nil, errMalformed
+		}
+
+		groupRows := make([][]any, numRows)
+		for i := range groupRows {
+			groupRows[i] = make([]any, len(columns))
+		}
+		for i, c := range chunks {
+			chunk, _ := c.(map[int16]any)
+			chunkMeta, _ := chunk[3].(map[int16]any)
+			offset, _ := chunkMeta[9].(int64)
+			if offset < 0 || offset > int64(len(data)) {
+				return nil, nil, errMalformed
+			}
+			values, err := readPage(data[offset:], columns[i], int(numRows))
+			if err != nil {
+				return nil, nil, err
+			}
+			for r, v := range values {
+				groupRows[r][i] = v
+			}
+		}
+		rows = append(rows, groupRows...)
+	}
+	return columns, rows, nil
+}
+
+// readPage decodes the n values of a column from a data page.
+func readPage(data []byte, col Column, n int) ([]any, error) {
+	t := &thriftReader{buf: data}
+	header, err := t.readStruct()
+	if err != nil {
+		return nil, err
+	}
+	size, _ := header[3].(int64)
+	if header[1] != int64(pageTypeData) || size < 0 || size > int64(len(data)-t.pos) {
+		return nil, errMalformed
+	}
+	page := data[t.pos : t.pos+int(size)]
+
+	levels := bytes.Repeat([]byte{1}, n)
+	if col.Optional {
+		if len(page) < 4 {
+			return nil, errMalformed
+		}
+		length := int(binary.LittleEndian.Uint32(page))
+		if length > len(page)-4 {
+			return nil, errMalformed
+		}
+		if levels, err = readLevels(page[4:4+length], n); err != nil {
+			return nil, err
+		}
+		page = page[4+length:]
+	}
+
+	values := make([]any, n)
+	for i, level := range levels {
+		if level == 0 {
+			continue
+		}
+		width := 8
+		if col.Type == String {
+			if len(page) < 4 {
+				return nil, errMalformed
+			}
+			width = 4 + int(binary.LittleEndian.Uint32(page))
+		}
+		if width > len(page) {
+			return nil, errMalformed
+		}
+
+		switch col.Type {
+		case String:
+			values[i] = string(page[4:width])
+		case Int64:
+			values[i] = int64(binary.LittleEndian.Uint64(page))
+		case Double:
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(page))
+		case Timestamp:
+			values[i] = time.UnixMicro(int64(binary.LittleEndian.Uint64(page))).UTC()
+		}
+		page = page[width:]
+	}
+	return values, nil
+}
+
+// readLevels decodes n definition levels of bit width 1 from RLE runs.
+func readLevels(data []byte, n int) ([]byte, error) {
+	levels := make([]byte, 0, n)
+	for len(levels) < n {
+		header, m := binary.Uvarint(data)
+		if m <= 0 || header&1 != 0 || m >= len(data) {
+			return nil, errMalformed
+		}
+		run := int(header >> 1)
+		if run == 0 || run > n-len(levels) {
+			return nil, errMalformed
+		}
+		levels = append(levels, bytes.Repeat([]byte{data[m]}, run)...)
+		data = data[m+1:]
+	}
+	return levels, nil
+}
+
+// errTruncated is returned when Thrift data ends in the middle of a value.
+var errTruncated = errors.New("parquet: truncated thrift data")
+
+// thriftReader decodes structs encoded with the Thrift compact protocol into
+// maps of field ID to value. Integers decode to int64, lists to []any and
+// structs to map[int16]any.
+type thriftReader struct {
+	buf []byte
+	pos int
+}
+
+func (t *thriftReader) byte() (byte, error) {
+	if t.pos >= len(t.buf) {
+		return 0, errTruncated
+	}
+	b := t.buf[t.pos]
+	t.pos++
+	return b, nil
+}
+
+func (t *thriftReader) varint() (int64, error) {
+	v, n := binary.Varint(t.buf[t.pos:])
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	t.pos += n
+	return v, nil
+}
+
+func (t *thriftReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(t.buf[t.pos:])
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	t.pos += n
+	return v, nil
+}
+
+func (t *thriftReader) readStruct() (map[int16]any, error) {
+	fields := make(map[int16]any)
+	var last int16
+	for {
+		header, err := t.byte()
+		if err != nil {
+			return nil, err
+		}
+		if header == 0 {
+			return fields, nil
+		}
+
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			v, err := t.varint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		last = id
+
+		typ := header & 0x0f
+		switch typ {
+		case thriftBoolTrue:
+			fields[id] = true
+		case thriftBoolFalse:
+			fields[id] = false
+		default:
+			if fields[id], err = t.readValue(typ); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (t *thriftReader) readValue(typ byte) (any, error) {
+	switch typ {
+	case thriftByte:
+		b, err := t.byte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return t.varint()
+	case thriftDouble:
+		if t.pos+8 > len(t.buf) {
+			return nil, errTruncated
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(t.buf[t.pos:]))
+		t.pos += 8
+		return v, nil
+	case thriftBinary:
+		n, err := t.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(t.buf)-t.pos) < n {
+			return nil, errTruncated
+		}
+		b := t.buf[t.pos : t.pos+int(n)]
+		t.pos += int(n)
+		return b, nil
+	case thriftList, thriftSet:
+		header, err := t.byte()
+		if err != nil {
+			return nil, err
+		}
+		n := uint64(header >> 4)
+		if n == 15 {
+			if n, err = t.uvarint(); err != nil {
+				return nil, err
+			}
+		}
+		if n > uint64(len(t.buf)-t.pos) {
+			return nil, errTruncated
+		}
+		elems := make([]any, n)
+		for i := range elems {
+			if elems[i], err = t.readValue(header & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return elems, nil
+	case thriftStruct:
+		return t.readStruct()
+	default:
+		return nil, fmt.Errorf("parquet: unsupported thrift type %d", typ)
+	}
+}
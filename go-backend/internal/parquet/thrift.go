@@ -0,0 +1,103 @@
+package parquet
+
+import "encoding/binary"
+
+// Types of the Thrift compact protocol, which encodes the Parquet page
+// headers and footer.
+const (
+	thriftBoolTrue  = 1
+	thriftBoolFalse = 2
+	thriftByte      = 3
+	thriftI16       = 4
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftDouble    = 7
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftSet       = 10
+	thriftStruct    = 12
+)
+
+// thriftWriter encodes a struct with the Thrift compact protocol. Field IDs
+// are delta encoded against the last field of the innermost open struct.
+type thriftWriter struct {
+	buf  []byte
+	last []int16
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{last: []int16{0}}
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	last := &t.last[len(t.last)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|typ)
+	} else {
+		t.buf = append(t.buf, typ)
+		t.buf = binary.AppendVarint(t.buf, int64(id))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.buf = binary.AppendVarint(t.buf, v)
+}
+
+func (t *thriftWriter) bool(id int16, v bool) {
+	if v {
+		t.field(id, thriftBoolTrue)
+	} else {
+		t.field(id, thriftBoolFalse)
+	}
+}
+
+func (t *thriftWriter) string(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.appendString(s)
+}
+
+func (t *thriftWriter) appendString(s string) {
+	t.buf = binary.AppendUvarint(t.buf, uint64(len(s)))
+	t.buf = append(t.buf, s...)
+}
+
+// list starts a list field of n elements. Elements follow: i32 elements
+// with appendI32, strings with appendString and structs with beginElem.
+func (t *thriftWriter) list(id int16, elem byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.buf = append(t.buf, byte(n)<<4|elem)
+	} else {
+		t.buf = append(t.buf, 0xf0|elem)
+		t.buf = binary.AppendUvarint(t.buf, uint64(n))
+	}
+}
+
+func (t *thriftWriter) appendI32(v int32) {
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+// beginStruct starts a struct field; endStruct ends it.
+func (t *thriftWriter) beginStruct(id int16) {
+	t.field(id, thriftStruct)
+	t.beginElem()
+}
+
+// beginElem starts a struct element of a list; endStruct ends it.
+func (t *thriftWriter) beginElem() {
+	t.last = append(t.last, 0)
+}
+
+// endStruct ends the innermost open struct. Ending the outermost struct
+// completes the encoding.
+func (t *thriftWriter) endStruct() {
+	t.buf = append(t.buf, 0)
+	t.last = t.last[:len(t.last)-1]
+}
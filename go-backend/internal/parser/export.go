@@ -0,0 +1,202 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Markers around the results file Freqtrade exported, which the backtest
+// container prints after the backtest.
+const (
+	ExportBlockStart = "=== FREQSEARCH RESULTS EXPORT ==="
+	ExportBlockEnd   = "=== END FREQSEARCH RESULTS EXPORT ==="
+)
+
+// ExportFormat is the format name logged for results parsed from the
+// exported results file rather than the console output.
+const ExportFormat = "freqtrade-export"
+
+// extractExport splits the exported results file off the logs. It returns
+// the file's contents, empty if the block is missing or empty, and the logs
+// without the block.
+func extractExport(logs string) (string, string) {
+	return extractBlock(logs, ExportBlockStart, ExportBlockEnd)
+}
+
+// extractBlock splits the block between the markers off the logs, returning
+// its trimmed contents and the logs without it. A block missing its end
+// marker runs to the end of the logs.
+func extractBlock(logs, startMarker, endMarker string) (string, string) {
+	start := strings.Index(logs, startMarker)
+	if start < 0 {
+		return "", logs
+	}
+	block := logs[start+len(startMarker):]
+	rest := logs[:start]
+	if end := strings.Index(block, endMarker); end >= 0 {
+		rest += block[end+len(endMarker):]
+		block = block[:end]
+	}
+	return strings.TrimSpace(block), rest
+}
+
+// exportedResults is the part of Freqtrade's backtest results file that is
+// parsed: the statistics of each strategy backtested, by strategy name.
+type exportedResults struct {
+	Strategy map[string]exportedStrategy `json:"strategy"`
+}
+
+// exportedStrategy holds the statistics Freqtrade exports for a strategy.
+// Ratios such as profit_total are fractions, not percentages. Older
+// versions lack some fields, which are then derived from the others.
+type exportedStrategy struct {
+	TotalTrades        int                 `json:"total_trades"`
+	Wins               int                 `json:"wins"`
+	Losses             int                 `json:"losses"`
+	Winrate            *float64            `json:"winrate"`
+	ProfitTotal        float64             `json:"profit_total"`
+	ProfitTotalAbs     float64             `json:"profit_total_abs"`
+	ProfitFactor       float64             `json:"profit_factor"`
+	StakeCurrency      string              `json:"stake_currency"`
+	StartingBalance    float64             `json:"starting_balance"`
+	Sharpe             float64             `json:"sharpe"`
+	Sortino            float64             `json:"sortino"`
+	Calmar             float64             `json:"calmar"`
+	MaxDrawdownAccount *float64            `json:"max_drawdown_account"`
+	MaxRelativeDD      float64             `json:"max_relative_drawdown"`
+	MaxDrawdownAbs     float64             `json:"max_drawdown_abs"`
+	HoldingAvgS        *float64            `json:"holding_avg_s"`
+	HoldingAvg         string              `json:"holding_avg"`
+	ResultsPerPair     []exportedBreakdown `json:"results_per_pair"`
+	ExitReasonSummary  json.RawMessage     `json:"exit_reason_summary"`
+	SellReasonSummary  json.RawMessage     `json:"sell_reason_summary"` // Before exits were renamed
+	ResultsPerEnterTag json.RawMessage     `json:"results_per_enter_tag"`
+	DailyProfit        json.RawMessage     `json:"daily_profit"`
+	Trades             json.RawMessage     `json:"trades"`
+}
+
+// exportedBreakdown is a row of Freqtrade's per-pair results.
+type exportedBreakdown struct {
+	Key           string   `json:"key"`
+	Trades        int      `json:"trades"`
+	ProfitMeanPct float64  `json:"profit_mean_pct"`
+	Wins          int      `json:"wins"`
+	Winrate       *float64 `json:"winrate"`
+	DurationAvg   string   `json:"duration_avg"`
+}
+
+// parseExport parses the backtest results file Freqtrade exports. The
+// structured file keeps every metric when Freqtrade changes the layout of its
+// console tables. With several strategies in the file, the first by name is
+// used.
+func parseExport(data string) (*SummaryStats, []domain.PairResult, error) {
+	var results exportedResults
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode results export: %w", err)
+	}
+	if len(results.Strategy) == 0 {
+		return nil, nil, fmt.Errorf("results export holds no strategy")
+	}
+
+	names := make([]string, 0, len(results.Strategy))
+	for name := range results.Strategy {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	s := results.Strategy[names[0]]
+
+	stats := &SummaryStats{
+		TotalTrades:     s.TotalTrades,
+		WinningTrades:   s.Wins,
+		LosingTrades:    s.Losses,
+		ProfitTotal:     s.ProfitTotalAbs,
+		ProfitPct:       s.ProfitTotal * 100,
+		ProfitFactor:    s.ProfitFactor,
+		MaxDrawdown:     s.MaxDrawdownAbs,
+		SharpeRatio:     s.Sharpe,
+		SortinoRatio:    s.Sortino,
+		CalmarRatio:     s.Calmar,
+		StakeCurrency:   strings.ToUpper(s.StakeCurrency),
+		StartingBalance: s.StartingBalance,
+	}
+
+	switch {
+	case s.Winrate != nil:
+		stats.WinRate = *s.Winrate
+	case s.TotalTrades > 0:
+		stats.WinRate = float64(s.Wins) / float64(s.TotalTrades)
+	}
+
+	if s.MaxDrawdownAccount != nil {
+		stats.MaxDrawdownPct = *s.MaxDrawdownAccount * 100
+	} else {
+		stats.MaxDrawdownPct = s.MaxRelativeDD * 100
+	}
+
+	if s.HoldingAvgS != nil {
+		stats.AvgTradeDuration = *s.HoldingAvgS / 60
+	} else {
+		stats.AvgTradeDuration = parseDuration(s.HoldingAvg)
+	}
+
+	if stats.TotalTrades > 0 {
+		stats.AvgProfitPerTrade = stats.ProfitTotal / float64(stats.TotalTrades)
+	}
+
+	exitReasons := s.ExitReasonSummary
+	if exitReasons == nil {
+		exitReasons = s.SellReasonSummary
+	}
+	stats.ExitReasons = jsonTradeReasons(decodeRaw(exitReasons))
+	stats.EntryTags = jsonTradeReasons(decodeRaw(s.ResultsPerEnterTag))
+	stats.DailyProfit = jsonDailyProfit(decodeRaw(s.DailyProfit))
+
+	stats.TradeReturns = jsonTradeReturns(decodeRaw(s.Trades))
+	if len(stats.TradeReturns) > 0 {
+		best, worst := math.Inf(-1), math.Inf(1)
+		for _, r := range stats.TradeReturns {
+			best, worst = math.Max(best, r), math.Min(worst, r)
+		}
+		stats.BestTradePct, stats.WorstTradePct = best, worst
+	}
+
+	var pairResults []domain.PairResult
+	for _, row := range s.ResultsPerPair {
+		if row.Key == "" || strings.EqualFold(row.Key, "total") {
+			continue
+		}
+		pair := domain.PairResult{
+			Pair:               row.Key,
+			Trades:             row.Trades,
+			ProfitPct:          row.ProfitMeanPct,
+			AvgDurationMinutes: parseDuration(row.DurationAvg),
+		}
+		switch {
+		case row.Winrate != nil:
+			pair.WinRate = *row.Winrate
+		case row.Trades > 0:
+			pair.WinRate = float64(row.Wins) / float64(row.Trades)
+		}
+		pairResults = append(pairResults, pair)
+	}
+
+	return stats, pairResults, nil
+}
+
+// decodeRaw decodes a JSON value for the helpers shared with the configured
+// JSON paths, returning nil if it is missing or invalid.
+func decodeRaw(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+const exportLogs = tableLogs + ExportBlockStart + `
+{"metadata": {"MyStrategy": {"run_id": "abc"}},
+ "strategy": {"MyStrategy": {"total_trades": 20, "wins": 15, "losses": 4, "draws": 1,
+  "profit_total": 0.305, "profit_total_abs": 305.0, "profit_factor": 2.1, "stake_currency": "usdt",
+  "starting_balance": 1000, "sharpe": 2.5, "sortino": 3.1, "calmar": 4.2,
+  "max_drawdown_account": 0.042, "max_drawdown_abs": 42.0, "holding_avg": "1 day, 1:15:00",
+  "results_per_pair": [{"key": "BTC/USDT:USDT", "trades": 20, "profit_mean_pct": 1.5, "wins": 15, "duration_avg": "1:15:00"},
+    {"key": "TOTAL", "trades": 20, "profit_mean_pct": 1.5, "wins": 15}],
+  "sell_reason_summary": [{"sell_reason": "roi", "trades": 15, "profit_mean_pct": 2.4},
+    {"sell_reason": "stop_loss", "trades": 5, "profit_mean_pct": -1.1}],
+  "trades": [{"profit_ratio": 0.031}, {"profit_ratio": -0.012}, {"profit_ratio": 0.004}],
+  "daily_profit": [["2025-01-01", 50], ["2025-01-02", -30]]}}}
+` + ExportBlockEnd + "\n"
+
+func TestParser_Export(t *testing.T) {
+	p := NewParser(zaptest.NewLogger(t))
+
+	result, err := p.ParseResult(exportLogs, newTestJob(), nil)
+	require.NoError(t, err)
+	// The export takes precedence over the console tables
+	assert.Equal(t, 20, result.TotalTrades)
+	assert.Equal(t, 15, result.WinningTrades)
+	assert.Equal(t, 4, result.LosingTrades)
+	assert.InDelta(t, 0.75, result.WinRate, 1e-9)
+	assert.InDelta(t, 30.5, result.ProfitPct, 1e-9)
+	assert.InDelta(t, 305.0, result.ProfitTotal, 1e-9)
+	assert.InDelta(t, 4.2, result.MaxDrawdownPct, 1e-9)
+	require.NotNil(t, result.SharpeRatio)
+	assert.InDelta(t, 2.5, *result.SharpeRatio, 1e-9)
+	require.NotNil(t, result.AvgTradeDurationMinutes)
+	assert.InDelta(t, 1515.0, *result.AvgTradeDurationMinutes, 1e-9)
+	require.NotNil(t, result.BestTradePct)
+	assert.InDelta(t, 3.1, *result.BestTradePct, 1e-9)
+	require.NotNil(t, result.WorstTradePct)
+	assert.InDelta(t, -1.2, *result.WorstTradePct, 1e-9)
+	require.NotNil(t, result.StakeCurrency)
+	assert.Equal(t, "USDT", *result.StakeCurrency)
+	assert.Equal(t, []domain.PairResult{
+		{Pair: "BTC/USDT:USDT", Trades: 20, ProfitPct: 1.5, WinRate: 0.75, AvgDurationMinutes: 75},
+	}, result.PairResults)
+	assert.Equal(t, []domain.TradeReasonStats{
+		{Reason: "roi", Trades: 15, AvgProfitPct: 2.4},
+		{Reason: "stop_loss", Trades: 5, AvgProfitPct: -1.1},
+	}, result.ExitReasons)
+	assert.Len(t, result.TradeReturns, 3)
+	assert.Len(t, result.EquityCurve, 2)
+	require.NotNil(t, result.Environment)
+	assert.Equal(t, "2024.1", result.Environment.FreqtradeVersion)
+
+	// The export is not stored with the logs
+	logs, err := DecompressLog(result.RawLog)
+	require.NoError(t, err)
+	assert.NotContains(t, logs, ExportBlockStart)
+	assert.Contains(t, logs, "Total profit %")
+}
+
+func TestParser_ExportFallback(t *testing.T) {
+	p := NewParser(zaptest.NewLogger(t))
+
+	// An unreadable or empty export falls back to the console tables
+	for _, export := range []string{"{not json", `{"strategy": {}}`, ""} {
+		logs := tableLogs + ExportBlockStart + "\n" + export + "\n" + ExportBlockEnd + "\n"
+		result, err := p.ParseResult(logs, newTestJob(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 12, result.TotalTrades)
+		assert.InDelta(t, 15.0, result.ProfitPct, 1e-9)
+		require.Len(t, result.PairResults, 1)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	assert.InDelta(t, 150.0, parseDuration("2:30:00"), 1e-9)
+	assert.InDelta(t, 12.5, parseDuration("12.5 min"), 1e-9)
+	assert.InDelta(t, 2*24*60+30.0, parseDuration("2 days, 0:30:00"), 1e-9)
+	assert.Zero(t, parseDuration(""))
+}
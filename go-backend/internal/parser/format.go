@@ -0,0 +1,708 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// BuiltinFormat is the name of the format for the Freqtrade table output this
+// parser was written against. Other formats extend it by default.
+const BuiltinFormat = "builtin"
+
+// Metric names of the summary statistics a format extracts.
+const (
+	MetricTotalTrades    = "total_trades"
+	MetricWinningTrades  = "winning_trades" // JSON only; the win_rate pattern captures it
+	MetricLosingTrades   = "losing_trades"  // JSON only
+	MetricWinRate        = "win_rate"       // Pattern groups: rate, wins, total
+	MetricProfitPct      = "profit_pct"
+	MetricProfitAbs      = "profit_abs"
+	MetricStakeCurrency  = "stake_currency"
+	MetricProfitFactor   = "profit_factor"
+	MetricSharpeRatio    = "sharpe_ratio"
+	MetricSortinoRatio   = "sortino_ratio"
+	MetricCalmarRatio    = "calmar_ratio"
+	MetricMaxDrawdownPct = "max_drawdown_pct"
+	MetricMaxDrawdownAbs = "max_drawdown_abs"
+	MetricAvgDuration    = "avg_duration" // "HH:MM:SS", "N min", or minutes in JSON
+	MetricBestTradePct   = "best_trade_pct"
+	MetricWorstTradePct  = "worst_trade_pct"
+	MetricStartBalance   = "starting_balance"
+
+	// JSON only: arrays of {"key", "trades", "profit_mean_pct"} objects. In
+	// table output the breakdowns are sections, see FormatSpec.
+	MetricExitReasons = "exit_reasons"
+	MetricEntryTags   = "entry_tags"
+
+	// JSON only: an array of trade objects, e.g. Freqtrade's
+	// "strategy.*.trades", whose "profit_ratio" (or "profit_pct") is the
+	// trade's return.
+	MetricTradeReturns = "trade_returns"
+
+	// JSON only: Freqtrade's "strategy.*.daily_profit", an array of
+	// [date, absolute profit] pairs, or an array of {"date", "abs_profit"}
+	// objects like its periodic breakdown. It makes up the equity curve.
+	MetricDailyProfit = "daily_profit"
+)
+
+// metrics lists the known metric names.
+var metrics = map[string]bool{
+	MetricTotalTrades: true, MetricWinningTrades: true, MetricLosingTrades: true,
+	MetricWinRate: true, MetricProfitPct: true, MetricProfitAbs: true,
+	MetricStakeCurrency: true, MetricProfitFactor: true, MetricSharpeRatio: true,
+	MetricSortinoRatio: true, MetricCalmarRatio: true, MetricMaxDrawdownPct: true,
+	MetricMaxDrawdownAbs: true, MetricAvgDuration: true, MetricBestTradePct: true,
+	MetricWorstTradePct: true, MetricExitReasons: true, MetricEntryTags: true,
+	MetricTradeReturns: true, MetricStartBalance: true, MetricDailyProfit: true,
+}
+
+// FormatSpec describes how to extract results from one Freqtrade output
+// layout, and which Freqtrade versions and images produce it.
+type FormatSpec struct {
+	Name string
+
+	// Extends names the format whose rules this one starts from; empty means
+	// the builtin format.
+	Extends string
+
+	// Glob patterns (path.Match syntax) selecting the format by the Freqtrade
+	// version reported in the logs or by the tag of the image that ran.
+	FreqtradeVersions []string
+	ImageTags         []string
+
+	// Patterns maps metric names to regular expressions whose first group
+	// captures the value; an empty pattern removes an inherited one.
+	Patterns map[string]string
+
+	// PairPattern captures pair, trades, profit % and win rate of each pair row.
+	PairPattern string
+
+	// The exit reason and entry tag breakdowns are the rows matching the
+	// pattern (groups: reason, trades, avg profit %) in the table following
+	// the section header, up to its closing border or a blank line.
+	ExitReasonSection string
+	ExitReasonPattern string
+	EntryTagSection   string
+	EntryTagPattern   string
+
+	// ErrorPatterns are substrings marking a failed backtest, matched
+	// case-insensitively; they replace the inherited ones if set.
+	ErrorPatterns []string
+
+	// JSONStart marks the line after which the first JSON object in the logs
+	// holds results; JSONPaths maps metric names to dotted paths in it, where
+	// "*" matches any key (e.g. "strategy.*.sharpe").
+	JSONStart string
+	JSONPaths map[string]string
+}
+
+// reasonRowPattern matches a row of Freqtrade's exit reason and enter tag tables.
+const reasonRowPattern = `^\s*[│|]\s*([^│|]*?\S)\s*[│|]\s*(\d+)\s*[│|]\s*([-\d.]+)`
+
+// builtinSpec reproduces the original Freqtrade table parsing.
+var builtinSpec = FormatSpec{
+	Name: BuiltinFormat,
+	Patterns: map[string]string{
+		MetricTotalTrades:    `(?i)Total[/\s].*Trades?\s*[│|]\s*(\d+)`,
+		MetricProfitPct:      `(?i)Total profit\s*%?\s*[│|]\s*([-\d.]+)\s*%?`,
+		MetricProfitAbs:      `(?i)Abs\. profit\s*[│|]\s*([-\d.]+)`,
+		MetricStakeCurrency:  `(?i)Abs\. profit\s*[│|]\s*[-\d.]+\s+([A-Z]{2,10})\b`,
+		MetricSharpeRatio:    `(?i)Sharpe\s*[│|]\s*([-\d.]+)`,
+		MetricSortinoRatio:   `(?i)Sortino\s*[│|]\s*([-\d.]+)`,
+		MetricCalmarRatio:    `(?i)Calmar\s*[│|]\s*([-\d.]+)`,
+		MetricMaxDrawdownPct: `(?i)Max\s*[dD]rawdown\s*[│|]\s*([-\d.]+)\s*%?`,
+		MetricMaxDrawdownAbs: `(?i)Max\s*[dD]rawdown\s*\([Aa]bs\)\s*[│|]\s*([-\d.]+)`,
+		MetricWinRate:        `(?i)Win\s*[rR]ate\s*[│|]?\s*([\d.]+)\s*%?\s*\[?(\d+)[/](\d+)\]?`,
+		MetricAvgDuration:    `(?i)Avg\.\s*[dD]uration\s*[│|]\s*(\d+:\d+:\d+|[\d.]+\s*min)`,
+		MetricProfitFactor:   `(?i)Profit\s*[fF]actor\s*[│|]\s*([-\d.]+)`,
+		MetricBestTradePct:   `(?i)Best\s*[tT]rade\s*[│|]\s*([-\d.]+)\s*%?`,
+		MetricWorstTradePct:  `(?i)Worst\s*[tT]rade\s*[│|]\s*([-\d.]+)\s*%?`,
+		MetricStartBalance:   `(?i)Starting\s*balance\s*[│|]\s*([\d.]+)`,
+	},
+	PairPattern:       `(?i)([\w/]+:[\w]+)\s+[│|]\s+(\d+)\s+[│|]\s+([-\d.]+)\s*%?\s+[│|]\s+([-\d.]+)\s*%?\s+[│|]`,
+	ExitReasonSection: "EXIT REASON STATS",
+	ExitReasonPattern: reasonRowPattern,
+	EntryTagSection:   "ENTER TAG STATS",
+	EntryTagPattern:   reasonRowPattern,
+	ErrorPatterns: []string{
+		"Error:",
+		"CRITICAL:",
+		"Exception:",
+		"Traceback (most recent call last):",
+		"Strategy file not found",
+		"No data found",
+		"ImportError:",
+		"ModuleNotFoundError:",
+		"SyntaxError:",
+	},
+}
+
+// Format is a compiled FormatSpec.
+type Format struct {
+	name              string
+	freqtradeVersions []string
+	imageTags         []string
+	patterns          map[string]*regexp.Regexp
+	pairPattern       *regexp.Regexp
+	exitReasons       sectionRule
+	entryTags         sectionRule
+	errorPatterns     []string
+	jsonStart         string
+	jsonPaths         map[string][]string
+}
+
+// sectionRule extracts the rows of one table section of the output.
+type sectionRule struct {
+	start string
+	row   *regexp.Regexp
+}
+
+// Name returns the format name.
+func (f *Format) Name() string {
+	return f.name
+}
+
+// compileFormat compiles spec on top of the rules of base, which is nil for
+// the builtin format.
+func compileFormat(spec FormatSpec, base *Format) (*Format, error) {
+	f := &Format{
+		name:              spec.Name,
+		freqtradeVersions: spec.FreqtradeVersions,
+		imageTags:         spec.ImageTags,
+		patterns:          make(map[string]*regexp.Regexp),
+		jsonPaths:         make(map[string][]string),
+	}
+	if base != nil {
+		for metric, re := range base.patterns {
+			f.patterns[metric] = re
+		}
+		for metric, p := range base.jsonPaths {
+			f.jsonPaths[metric] = p
+		}
+		f.pairPattern = base.pairPattern
+		f.exitReasons = base.exitReasons
+		f.entryTags = base.entryTags
+		f.errorPatterns = base.errorPatterns
+		f.jsonStart = base.jsonStart
+	}
+
+	for _, patterns := range [][]string{spec.FreqtradeVersions, spec.ImageTags} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("format %s: invalid glob %q: %w", spec.Name, pattern, err)
+			}
+		}
+	}
+
+	for metric, pattern := range spec.Patterns {
+		if !metrics[metric] {
+			return nil, fmt.Errorf("format %s: unknown metric %q", spec.Name, metric)
+		}
+		if pattern == "" {
+			delete(f.patterns, metric)
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("format %s: invalid pattern for %s: %w", spec.Name, metric, err)
+		}
+		if re.NumSubexp() < 1 || (metric == MetricWinRate && re.NumSubexp() < 3) {
+			return nil, fmt.Errorf("format %s: pattern for %s is missing capture groups", spec.Name, metric)
+		}
+		f.patterns[metric] = re
+	}
+
+	if spec.PairPattern != "" {
+		re, err := regexp.Compile(spec.PairPattern)
+		if err != nil {
+			return nil, fmt.Errorf("format %s: invalid pair pattern: %w", spec.Name, err)
+		}
+		if re.NumSubexp() < 4 {
+			return nil, fmt.Errorf("format %s: pair pattern needs 4 capture groups", spec.Name)
+		}
+		f.pairPattern = re
+	}
+
+	if err := f.exitReasons.compile(spec.Name, spec.ExitReasonSection, spec.ExitReasonPattern); err != nil {
+		return nil, err
+	}
+	if err := f.entryTags.compile(spec.Name, spec.EntryTagSection, spec.EntryTagPattern); err != nil {
+		return nil, err
+	}
+
+	if len(spec.ErrorPatterns) > 0 {
+		f.errorPatterns = spec.ErrorPatterns
+	}
+
+	if spec.JSONStart != "" {
+		f.jsonStart = spec.JSONStart
+	}
+	for metric, jsonPath := range spec.JSONPaths {
+		if !metrics[metric] {
+			return nil, fmt.Errorf("format %s: unknown metric %q", spec.Name, metric)
+		}
+		if jsonPath == "" {
+			delete(f.jsonPaths, metric)
+			continue
+		}
+		f.jsonPaths[metric] = strings.Split(jsonPath, ".")
+	}
+
+	return f, nil
+}
+
+// compile overrides the section header and row pattern that are set.
+func (r *sectionRule) compile(format, start, pattern string) error {
+	if start != "" {
+		r.start = start
+	}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("format %s: invalid section pattern: %w", format, err)
+		}
+		if re.NumSubexp() < 3 {
+			return fmt.Errorf("format %s: section pattern needs 3 capture groups", format)
+		}
+		r.row = re
+	}
+	return nil
+}
+
+// parse returns the rows of the section, skipping its TOTAL row. It returns
+// nil if the section is missing.
+func (r *sectionRule) parse(logs string) []domain.TradeReasonStats {
+	if r.start == "" || r.row == nil {
+		return nil
+	}
+	idx := strings.Index(logs, r.start)
+	if idx < 0 {
+		return nil
+	}
+
+	var rows []domain.TradeReasonStats
+	for _, line := range strings.Split(logs[idx+len(r.start):], "\n") {
+		trimmed := strings.TrimSpace(line)
+		if (trimmed == "" && len(rows) > 0) || strings.HasPrefix(trimmed, "└") {
+			break
+		}
+
+		match := r.row.FindStringSubmatch(line)
+		if len(match) < 4 || strings.EqualFold(strings.TrimSpace(match[1]), "total") {
+			continue
+		}
+		trades, _ := strconv.Atoi(match[2])
+		profitPct, _ := strconv.ParseFloat(match[3], 64)
+		rows = append(rows, domain.TradeReasonStats{
+			Reason:       strings.TrimSpace(match[1]),
+			Trades:       trades,
+			AvgProfitPct: profitPct,
+		})
+	}
+	return rows
+}
+
+// matches returns true if the format is selected by the given Freqtrade
+// version or image tag.
+func (f *Format) matches(freqtradeVersion, imageTag string) bool {
+	for _, pattern := range f.freqtradeVersions {
+		if ok, _ := path.Match(pattern, freqtradeVersion); ok && freqtradeVersion != "" {
+			return true
+		}
+	}
+	for _, pattern := range f.imageTags {
+		if ok, _ := path.Match(pattern, imageTag); ok && imageTag != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkForErrors checks the log output for the format's error indicators.
+func (f *Format) checkForErrors(logs string) error {
+	logsLower := strings.ToLower(logs)
+	for _, pattern := range f.errorPatterns {
+		if strings.Contains(logsLower, strings.ToLower(pattern)) {
+			// Extract error context
+			errorMsg := extractErrorMessage(logs, pattern)
+			return fmt.Errorf("backtest error: %s", errorMsg)
+		}
+	}
+
+	return nil
+}
+
+// submatch returns the groups the metric's pattern captures in logs, or nil.
+func (f *Format) submatch(metric, logs string) []string {
+	re := f.patterns[metric]
+	if re == nil {
+		return nil
+	}
+	return re.FindStringSubmatch(logs)
+}
+
+// parseSummary extracts summary statistics from Freqtrade output. Values
+// found in the JSON document take precedence over pattern matches.
+func (f *Format) parseSummary(logs string) (*SummaryStats, error) {
+	stats := &SummaryStats{}
+
+	floats := map[string]*float64{
+		MetricProfitPct:      &stats.ProfitPct,
+		MetricProfitAbs:      &stats.ProfitTotal,
+		MetricSharpeRatio:    &stats.SharpeRatio,
+		MetricSortinoRatio:   &stats.SortinoRatio,
+		MetricCalmarRatio:    &stats.CalmarRatio,
+		MetricMaxDrawdownPct: &stats.MaxDrawdownPct,
+		MetricMaxDrawdownAbs: &stats.MaxDrawdown,
+		MetricProfitFactor:   &stats.ProfitFactor,
+		MetricBestTradePct:   &stats.BestTradePct,
+		MetricWorstTradePct:  &stats.WorstTradePct,
+		MetricStartBalance:   &stats.StartingBalance,
+	}
+	for metric, dst := range floats {
+		if matches := f.submatch(metric, logs); len(matches) > 1 {
+			*dst, _ = strconv.ParseFloat(matches[1], 64)
+		}
+	}
+
+	if matches := f.submatch(MetricTotalTrades, logs); len(matches) > 1 {
+		stats.TotalTrades, _ = strconv.Atoi(matches[1])
+	}
+	if matches := f.submatch(MetricStakeCurrency, logs); len(matches) > 1 {
+		stats.StakeCurrency = strings.ToUpper(matches[1])
+	}
+	if matches := f.submatch(MetricAvgDuration, logs); len(matches) > 1 {
+		stats.AvgTradeDuration = parseDuration(matches[1])
+	}
+
+	stats.ExitReasons = f.exitReasons.parse(logs)
+	stats.EntryTags = f.entryTags.parse(logs)
+
+	winRateParsed := false
+	if matches := f.submatch(MetricWinRate, logs); len(matches) > 3 {
+		stats.WinRate, _ = strconv.ParseFloat(matches[1], 64)
+		stats.WinningTrades, _ = strconv.Atoi(matches[2])
+		totalFromWinRate, _ := strconv.Atoi(matches[3])
+		stats.LosingTrades = totalFromWinRate - stats.WinningTrades
+		winRateParsed = true
+	}
+
+	if len(f.jsonPaths) > 0 {
+		doc, err := f.findJSON(logs)
+		if err != nil {
+			return stats, err
+		}
+		if doc != nil {
+			winRateParsed = f.applyJSON(doc, stats, floats) || winRateParsed
+		}
+	}
+
+	if stats.WinRate > 1 {
+		stats.WinRate /= 100 // Convert percentage to decimal
+	}
+	if !winRateParsed && stats.TotalTrades > 0 && stats.WinRate > 0 {
+		// Calculate winning/losing from total and win rate if available
+		stats.WinningTrades = int(float64(stats.TotalTrades) * stats.WinRate)
+		stats.LosingTrades = stats.TotalTrades - stats.WinningTrades
+	}
+
+	// Calculate average profit per trade
+	if stats.TotalTrades > 0 {
+		stats.AvgProfitPerTrade = stats.ProfitTotal / float64(stats.TotalTrades)
+	}
+
+	return stats, nil
+}
+
+// findJSON decodes the first JSON object after the format's JSON start
+// marker. It returns nil if the marker or the object is missing.
+func (f *Format) findJSON(logs string) (interface{}, error) {
+	if f.jsonStart != "" {
+		idx := strings.Index(logs, f.jsonStart)
+		if idx < 0 {
+			return nil, nil
+		}
+		logs = logs[idx+len(f.jsonStart):]
+	}
+
+	start := strings.Index(logs, "{")
+	if start < 0 {
+		return nil, nil
+	}
+
+	var doc interface{}
+	if err := json.NewDecoder(strings.NewReader(logs[start:])).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode results JSON: %w", err)
+	}
+	return doc, nil
+}
+
+// applyJSON sets the statistics found at the format's JSON paths. It returns
+// true if the winning and losing trade counts were set.
+func (f *Format) applyJSON(doc interface{}, stats *SummaryStats, floats map[string]*float64) bool {
+	counts := false
+	for metric, jsonPath := range f.jsonPaths {
+		value, ok := lookupJSON(doc, jsonPath)
+		if !ok {
+			continue
+		}
+
+		if dst, ok := floats[metric]; ok {
+			if v, ok := jsonFloat(value); ok {
+				*dst = v
+			}
+			continue
+		}
+
+		switch metric {
+		case MetricTotalTrades:
+			if v, ok := jsonFloat(value); ok {
+				stats.TotalTrades = int(v)
+			}
+		case MetricWinningTrades:
+			if v, ok := jsonFloat(value); ok {
+				stats.WinningTrades = int(v)
+				counts = true
+			}
+		case MetricLosingTrades:
+			if v, ok := jsonFloat(value); ok {
+				stats.LosingTrades = int(v)
+				counts = true
+			}
+		case MetricWinRate:
+			if v, ok := jsonFloat(value); ok {
+				stats.WinRate = v
+			}
+		case MetricStakeCurrency:
+			if v, ok := value.(string); ok {
+				stats.StakeCurrency = strings.ToUpper(v)
+			}
+		case MetricAvgDuration:
+			if v, ok := value.(string); ok {
+				stats.AvgTradeDuration = parseDuration(v)
+			} else if v, ok := jsonFloat(value); ok {
+				stats.AvgTradeDuration = v
+			}
+		case MetricExitReasons:
+			if rows := jsonTradeReasons(value); rows != nil {
+				stats.ExitReasons = rows
+			}
+		case MetricEntryTags:
+			if rows := jsonTradeReasons(value); rows != nil {
+				stats.EntryTags = rows
+			}
+		case MetricTradeReturns:
+			if returns := jsonTradeReturns(value); returns != nil {
+				stats.TradeReturns = returns
+			}
+		case MetricDailyProfit:
+			if daily := jsonDailyProfit(value); daily != nil {
+				stats.DailyProfit = daily
+			}
+		}
+	}
+	return counts
+}
+
+// lookupJSON follows a dotted path through decoded JSON. A "*" segment
+// matches any key, trying keys in sorted order.
+func lookupJSON(value interface{}, jsonPath []string) (interface{}, bool) {
+	if len(jsonPath) == 0 {
+		return value, true
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	if jsonPath[0] != "*" {
+		child, ok := obj[jsonPath[0]]
+		if !ok {
+			return nil, false
+		}
+		return lookupJSON(child, jsonPath[1:])
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if found, ok := lookupJSON(obj[key], jsonPath[1:]); ok {
+			return found, true
+		}
+	}
+	return nil, false
+}
+
+// jsonTradeReasons converts a JSON array of Freqtrade reason summaries, keyed
+// by "key" (or "exit_reason", "sell_reason" or "enter_tag" in older
+// versions), to trade reason stats. It skips the TOTAL row and returns nil if
+// value is not an array.
+func jsonTradeReasons(value interface{}) []domain.TradeReasonStats {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	rows := make([]domain.TradeReasonStats, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var reason string
+		for _, key := range []string{"key", "exit_reason", "sell_reason", "enter_tag"} {
+			if v, ok := obj[key].(string); ok {
+				reason = v
+				break
+			}
+		}
+		if reason == "" || strings.EqualFold(reason, "total") {
+			continue
+		}
+
+		row := domain.TradeReasonStats{Reason: reason}
+		if v, ok := jsonFloat(obj["trades"]); ok {
+			row.Trades = int(v)
+		}
+		if v, ok := jsonFloat(obj["profit_mean_pct"]); ok {
+			row.AvgProfitPct = v
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// jsonTradeReturns converts a JSON array of Freqtrade trades to their returns
+// in percent, from "profit_ratio" or, failing that, "profit_pct". It skips
+// trades without either and returns nil if value is not an array.
+func jsonTradeReturns(value interface{}) []float64 {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	returns := make([]float64, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := jsonFloat(obj["profit_ratio"]); ok {
+			returns = append(returns, v*100)
+		} else if v, ok := jsonFloat(obj["profit_pct"]); ok {
+			returns = append(returns, v)
+		}
+	}
+	return returns
+}
+
+// dayLayouts are the date layouts Freqtrade reports days in.
+var dayLayouts = []string{time.DateOnly, "02/01/2006", time.RFC3339}
+
+// parseDay parses a reported day to the start of the day (UTC).
+func parseDay(s string) (time.Time, bool) {
+	for _, layout := range dayLayouts {
+		if day, err := time.Parse(layout, s); err == nil {
+			return day.UTC().Truncate(24 * time.Hour), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// jsonDailyProfit converts a JSON array of daily profits, as [date, profit]
+// pairs or {"date", "abs_profit"} objects, to daily profits. It skips days
+// with dates it can't parse and returns nil if value is not an array.
+func jsonDailyProfit(value interface{}) []domain.DailyProfit {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	daily := make([]domain.DailyProfit, 0, len(items))
+	for _, item := range items {
+		var date, profit interface{}
+		switch v := item.(type) {
+		case []interface{}:
+			if len(v) < 2 {
+				continue
+			}
+			date, profit = v[0], v[1]
+		case map[string]interface{}:
+			date, profit = v["date"], v["abs_profit"]
+		default:
+			continue
+		}
+
+		s, ok := date.(string)
+		if !ok {
+			continue
+		}
+		day, ok := parseDay(s)
+		if !ok {
+			continue
+		}
+		abs, ok := jsonFloat(profit)
+		if !ok {
+			continue
+		}
+		daily = append(daily, domain.DailyProfit{Date: day, ProfitAbs: abs})
+	}
+	return daily
+}
+
+// jsonFloat converts a decoded JSON number, or a numeric string, to a float.
+func jsonFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(v), "%"), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// parsePairResults extracts per-pair results from Freqtrade output.
+func (f *Format) parsePairResults(logs string) []domain.PairResult {
+	if f.pairPattern == nil {
+		return nil
+	}
+
+	var results []domain.PairResult
+
+	matches := f.pairPattern.FindAllStringSubmatch(logs, -1)
+	for _, match := range matches {
+		if len(match) < 5 {
+			continue
+		}
+
+		trades, _ := strconv.Atoi(match[2])
+		profitPct, _ := strconv.ParseFloat(match[3], 64)
+		winRate, _ := strconv.ParseFloat(match[4], 64)
+		if winRate > 1 {
+			winRate /= 100
+		}
+
+		results = append(results, domain.PairResult{
+			Pair:      match[1],
+			Trades:    trades,
+			ProfitPct: profitPct,
+			WinRate:   winRate,
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Markers around the best epoch of a hyperopt run, which the hyperopt
+// container prints once the run is done.
+const (
+	HyperoptBlockStart = "=== FREQSEARCH HYPEROPT RESULT ==="
+	HyperoptBlockEnd   = "=== END FREQSEARCH HYPEROPT RESULT ==="
+)
+
+// exportedHyperopt is the best epoch of a hyperopt run as the container
+// prints it: the epoch's fields from Freqtrade's results file, with the
+// backtest metrics cut down to those parsed.
+type exportedHyperopt struct {
+	TotalEpochs int                     `json:"total_epochs"`
+	BestEpoch   int                     `json:"best_epoch"`
+	Loss        float64                 `json:"loss"`
+	Params      domain.HyperoptParamSet `json:"params"`
+	Metrics     exportedStrategy        `json:"metrics"`
+}
+
+// ParseHyperoptResult parses the best epoch of a hyperopt run from the
+// container's logs. A run whose best epoch made no trades found no usable
+// parameters and is an error.
+func ParseHyperoptResult(logs string) (*domain.HyperoptResult, error) {
+	block, _ := extractBlock(logs, HyperoptBlockStart, HyperoptBlockEnd)
+	if block == "" {
+		return nil, errors.New("no hyperopt result found in output")
+	}
+
+	var best exportedHyperopt
+	if err := json.Unmarshal([]byte(block), &best); err != nil {
+		return nil, fmt.Errorf("failed to decode hyperopt result: %w", err)
+	}
+	if best.Metrics.TotalTrades == 0 {
+		return nil, errors.New("no epoch made any trades")
+	}
+
+	m := best.Metrics
+	result := &domain.HyperoptResult{
+		BestEpoch:     best.BestEpoch,
+		TotalEpochs:   best.TotalEpochs,
+		Loss:          best.Loss,
+		Params:        best.Params,
+		TotalTrades:   m.TotalTrades,
+		WinningTrades: m.Wins,
+		LosingTrades:  m.Losses,
+		ProfitTotal:   m.ProfitTotalAbs,
+		ProfitPct:     m.ProfitTotal * 100,
+	}
+	if m.MaxDrawdownAccount != nil {
+		result.MaxDrawdownPct = *m.MaxDrawdownAccount * 100
+	} else {
+		result.MaxDrawdownPct = m.MaxRelativeDD * 100
+	}
+	if m.HoldingAvgS != nil {
+		minutes := *m.HoldingAvgS / 60
+		result.AvgTradeDurationMinutes = &minutes
+	}
+	if len(result.Params) > 0 {
+		result.ParamsPatch = result.Params.Patch()
+	}
+
+	return result, nil
+}
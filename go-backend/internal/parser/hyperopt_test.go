@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+const hyperoptLogs = `Best result:
+    41/100:     62 trades. 44/11/7 Wins/Draws/Losses.
+` + HyperoptBlockStart + `
+{"total_epochs": 100, "best_epoch": 41, "loss": -2.11338,
+ "params": {"buy": {"buy_rsi": 25, "buy_enabled": true}, "roi": {"0": 0.12, "30": 0.05, "120": 0},
+  "stoploss": {"stoploss": -0.08}, "trades": {"max_open_trades": 3}},
+ "metrics": {"total_trades": 62, "wins": 44, "losses": 7, "profit_total": 0.0565,
+  "profit_total_abs": 56.53, "max_drawdown_account": 0.021, "holding_avg_s": 11100}}
+` + HyperoptBlockEnd + "\n"
+
+func TestParseHyperoptResult(t *testing.T) {
+	result, err := ParseHyperoptResult(hyperoptLogs)
+	require.NoError(t, err)
+
+	assert.Equal(t, 41, result.BestEpoch)
+	assert.Equal(t, 100, result.TotalEpochs)
+	assert.InDelta(t, -2.11338, result.Loss, 1e-9)
+	assert.Equal(t, 62, result.TotalTrades)
+	assert.Equal(t, 44, result.WinningTrades)
+	assert.Equal(t, 7, result.LosingTrades)
+	assert.InDelta(t, 5.65, result.ProfitPct, 1e-9)
+	assert.InDelta(t, 56.53, result.ProfitTotal, 1e-9)
+	assert.InDelta(t, 2.1, result.MaxDrawdownPct, 1e-9)
+	require.NotNil(t, result.AvgTradeDurationMinutes)
+	assert.InDelta(t, 185.0, *result.AvgTradeDurationMinutes, 1e-9)
+
+	patch := result.ParamsPatch
+	require.NotNil(t, patch)
+	assert.Equal(t, []domain.ROIStep{{Minutes: 0, ROI: 0.12}, {Minutes: 30, ROI: 0.05}, {Minutes: 120, ROI: 0}}, patch.MinimalROI)
+	require.NotNil(t, patch.Stoploss)
+	assert.InDelta(t, -0.08, *patch.Stoploss, 1e-9)
+	assert.Equal(t, map[string]interface{}{"buy_rsi": 25.0, "buy_enabled": true}, patch.HyperoptDefaults)
+	assert.Nil(t, patch.TrailingStop)
+}
+
+func TestParseHyperoptResult_Errors(t *testing.T) {
+	tests := map[string]string{
+		"missing":   "Hyperopt failed\n",
+		"invalid":   HyperoptBlockStart + "\n{not json\n" + HyperoptBlockEnd,
+		"no trades": HyperoptBlockStart + "\n" + `{"total_epochs": 10, "loss": 100000, "metrics": {"total_trades": 0}}` + "\n" + HyperoptBlockEnd,
+	}
+	for name, logs := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseHyperoptResult(logs)
+			assert.Error(t, err)
+		})
+	}
+}
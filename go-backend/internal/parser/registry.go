@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Registry selects the output format to parse a backtest with, by the
+// Freqtrade version reported in its logs or the tag of the image it ran.
+type Registry struct {
+	formats  []*Format // In configuration order; the first match wins
+	fallback *Format
+}
+
+// NewRegistry compiles the given formats on top of the builtin one. Formats
+// may extend the builtin format or any format listed before them. Backtests
+// matching no format are parsed with defaultFormat, or the builtin format if
+// it is empty.
+func NewRegistry(specs []FormatSpec, defaultFormat string) (*Registry, error) {
+	builtin, err := compileFormat(builtinSpec, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*Format{BuiltinFormat: builtin}
+	r := &Registry{fallback: builtin}
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("format name is required")
+		}
+		if _, ok := byName[spec.Name]; ok {
+			return nil, fmt.Errorf("duplicate format %s", spec.Name)
+		}
+
+		extends := spec.Extends
+		if extends == "" {
+			extends = BuiltinFormat
+		}
+		base, ok := byName[extends]
+		if !ok {
+			return nil, fmt.Errorf("format %s extends unknown format %s", spec.Name, extends)
+		}
+
+		f, err := compileFormat(spec, base)
+		if err != nil {
+			return nil, err
+		}
+		byName[spec.Name] = f
+		r.formats = append(r.formats, f)
+	}
+
+	if defaultFormat != "" {
+		f, ok := byName[defaultFormat]
+		if !ok {
+			return nil, fmt.Errorf("unknown default format %s", defaultFormat)
+		}
+		r.fallback = f
+	}
+
+	return r, nil
+}
+
+// Select returns the format for a backtest run with the given Freqtrade
+// version and image; either may be empty if unknown.
+func (r *Registry) Select(freqtradeVersion, image string) *Format {
+	tag := imageTag(image)
+	for _, f := range r.formats {
+		if f.matches(freqtradeVersion, tag) {
+			return f
+		}
+	}
+	return r.fallback
+}
+
+// imageTag returns the tag of an image reference such as
+// "freqtradeorg/freqtrade:2025.4", or "latest" if it has none.
+func imageTag(image string) string {
+	if image == "" {
+		return ""
+	}
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return "latest"
+}
@@ -0,0 +1,193 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+const tableLogs = `=== FREQSEARCH ENVIRONMENT ===
+freqtrade 2024.1
+=== END FREQSEARCH ENVIRONMENT ===
+│ BTC/USDT:USDT │ 12 │ 1.50 │ 58.3 │
+│ Total/Daily Avg Trades │ 12 / 0.40 │
+│ Abs. profit │ 150.000 USDT │
+│ Total profit % │ 15.00% │
+│ Sharpe │ 1.25 │
+│ Avg. Duration │ 2:30:00 │
+│ Win Rate │ 58.3% [7/12] │
+│ Max Drawdown │ 4.20% │
+EXIT REASON STATS
+┃ Exit Reason        ┃ Exits ┃ Avg Profit % ┃ Tot Profit USDT ┃
+│ roi                │     6 │         3.10 │         186.000 │
+│ trailing_stop_loss │     2 │         1.50 │          30.000 │
+│ stop_loss          │     4 │        -1.65 │         -66.000 │
+│ TOTAL              │    12 │         1.25 │         150.000 │
+└────────────────────┴───────┴──────────────┴─────────────────┘
+ENTER TAG STATS
+┃ Enter Tag    ┃ Entries ┃ Avg Profit % ┃
+│ rsi cross    │      12 │         1.25 │
+│ TOTAL        │      12 │         1.25 │
+`
+
+const jsonLogs = `=== FREQSEARCH ENVIRONMENT ===
+freqtrade 2025.6
+=== END FREQSEARCH ENVIRONMENT ===
+│ Sharpe ratio │ 9.99 │
+=== RESULTS ===
+{"strategy": {"MyStrategy": {"total_trades": 20, "wins": 15, "losses": 5,
+  "sharpe": 2.5, "profit_total_pct": 30.5, "holding_avg": "1:15:00",
+  "exit_reason_summary": [{"key": "roi", "trades": 15, "profit_mean_pct": 2.4},
+    {"key": "stop_loss", "trades": 5, "profit_mean_pct": -1.1},
+    {"key": "TOTAL", "trades": 20, "profit_mean_pct": 1.5}],
+  "trades": [{"pair": "BTC/USDT:USDT", "profit_ratio": 0.024}, {"pair": "ETH/USDT:USDT", "profit_pct": -1.1},
+    {"pair": "BTC/USDT:USDT"}],
+  "starting_balance": 1000,
+  "daily_profit": [["2025-01-02", -30], ["2025-01-01", 50], ["bad", 1], ["2025-01-03", 10]]}}}
+`
+
+func newTestJob() *domain.BacktestJob {
+	return domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{StakeCurrency: "USDT"}, 0, nil)
+}
+
+func TestParser_BuiltinFormat(t *testing.T) {
+	p := NewParser(zaptest.NewLogger(t))
+
+	result, err := p.ParseResult(tableLogs, newTestJob(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 12, result.TotalTrades)
+	assert.Equal(t, 7, result.WinningTrades)
+	assert.Equal(t, 5, result.LosingTrades)
+	assert.InDelta(t, 0.583, result.WinRate, 1e-9)
+	assert.InDelta(t, 15.0, result.ProfitPct, 1e-9)
+	require.NotNil(t, result.SharpeRatio)
+	assert.InDelta(t, 1.25, *result.SharpeRatio, 1e-9)
+	require.NotNil(t, result.AvgTradeDurationMinutes)
+	assert.InDelta(t, 150.0, *result.AvgTradeDurationMinutes, 1e-9)
+	require.Len(t, result.PairResults, 1)
+	assert.Equal(t, "BTC/USDT:USDT", result.PairResults[0].Pair)
+	assert.Equal(t, []domain.TradeReasonStats{
+		{Reason: "roi", Trades: 6, AvgProfitPct: 3.1},
+		{Reason: "trailing_stop_loss", Trades: 2, AvgProfitPct: 1.5},
+		{Reason: "stop_loss", Trades: 4, AvgProfitPct: -1.65},
+	}, result.ExitReasons)
+	require.NotNil(t, result.StoplossExitPct)
+	assert.InDelta(t, 100.0/3, *result.StoplossExitPct, 1e-9)
+	require.NotNil(t, result.TrailingStopExitPct)
+	assert.InDelta(t, 100.0/6, *result.TrailingStopExitPct, 1e-9)
+	assert.Equal(t, []domain.TradeReasonStats{{Reason: "rsi cross", Trades: 12, AvgProfitPct: 1.25}}, result.EntryTags)
+	require.NotNil(t, result.Environment)
+	assert.Equal(t, "2024.1", result.Environment.FreqtradeVersion)
+
+	_, err = p.ParseResult("Traceback (most recent call last):\nboom", newTestJob(), nil)
+	assert.Error(t, err)
+}
+
+func TestParser_ConfiguredFormat(t *testing.T) {
+	registry, err := NewRegistry([]FormatSpec{{
+		Name:              "ft-2025",
+		FreqtradeVersions: []string{"2025.*"},
+		Patterns: map[string]string{
+			MetricSharpeRatio: `(?i)Sharpe ratio\s*[│|]\s*([-\d.]+)`,
+		},
+		JSONStart: "=== RESULTS ===",
+		JSONPaths: map[string]string{
+			MetricTotalTrades:   "strategy.*.total_trades",
+			MetricWinningTrades: "strategy.*.wins",
+			MetricLosingTrades:  "strategy.*.losses",
+			MetricSharpeRatio:   "strategy.*.sharpe",
+			MetricProfitPct:     "strategy.*.profit_total_pct",
+			MetricAvgDuration:   "strategy.*.holding_avg",
+			MetricExitReasons:   "strategy.*.exit_reason_summary",
+			MetricTradeReturns:  "strategy.*.trades",
+			MetricStartBalance:  "strategy.*.starting_balance",
+			MetricDailyProfit:   "strategy.*.daily_profit",
+		},
+	}}, "")
+	require.NoError(t, err)
+
+	p := NewParser(zaptest.NewLogger(t))
+	p.SetRegistry(registry)
+
+	result, err := p.ParseResult(jsonLogs, newTestJob(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 20, result.TotalTrades)
+	assert.Equal(t, 15, result.WinningTrades)
+	assert.Equal(t, 5, result.LosingTrades)
+	assert.InDelta(t, 30.5, result.ProfitPct, 1e-9)
+	// The JSON value takes precedence over the pattern match
+	require.NotNil(t, result.SharpeRatio)
+	assert.InDelta(t, 2.5, *result.SharpeRatio, 1e-9)
+	require.NotNil(t, result.AvgTradeDurationMinutes)
+	assert.InDelta(t, 75.0, *result.AvgTradeDurationMinutes, 1e-9)
+	require.Len(t, result.ExitReasons, 2)
+	require.NotNil(t, result.StoplossExitPct)
+	assert.InDelta(t, 25.0, *result.StoplossExitPct, 1e-9)
+	require.NotNil(t, result.TrailingStopExitPct)
+	assert.Zero(t, *result.TrailingStopExitPct)
+	require.Len(t, result.TradeReturns, 2)
+	assert.InDelta(t, 2.4, result.TradeReturns[0], 1e-9)
+	assert.InDelta(t, -1.1, result.TradeReturns[1], 1e-9)
+
+	// Days are put in order; the unparseable one is skipped
+	require.Len(t, result.EquityCurve, 3)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), result.EquityCurve[0].Timestamp)
+	assert.InDelta(t, 1050.0, result.EquityCurve[0].Balance, 1e-9)
+	assert.InDelta(t, 1020.0, result.EquityCurve[1].Balance, 1e-9)
+	assert.InDelta(t, 30.0/1050*100, result.EquityCurve[1].DrawdownPct, 1e-9)
+	assert.InDelta(t, 1030.0, result.EquityCurve[2].Balance, 1e-9)
+
+	// Older versions still use the builtin format
+	result, err = p.ParseResult(tableLogs, newTestJob(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 12, result.TotalTrades)
+	assert.Empty(t, result.EquityCurve)
+}
+
+func TestRegistry_Select(t *testing.T) {
+	registry, err := NewRegistry([]FormatSpec{
+		{Name: "by-version", FreqtradeVersions: []string{"2025.*"}},
+		{Name: "by-tag", Extends: "by-version", ImageTags: []string{"develop*"}},
+		{Name: "fallback"},
+	}, "fallback")
+	require.NoError(t, err)
+
+	assert.Equal(t, "by-version", registry.Select("2025.4", "freqtradeorg/freqtrade:develop").Name())
+	assert.Equal(t, "by-tag", registry.Select("", "freqtradeorg/freqtrade:develop_freqai").Name())
+	assert.Equal(t, "by-tag", registry.Select("", "localhost:5000/freqtrade:develop@sha256:abc").Name())
+	assert.Equal(t, "fallback", registry.Select("2024.1", "freqtradeorg/freqtrade").Name())
+	assert.Equal(t, "fallback", registry.Select("", "").Name())
+}
+
+func TestNewRegistry_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		specs         []FormatSpec
+		defaultFormat string
+	}{
+		{"missing name", []FormatSpec{{}}, ""},
+		{"duplicate name", []FormatSpec{{Name: "a"}, {Name: "a"}}, ""},
+		{"builtin name", []FormatSpec{{Name: BuiltinFormat}}, ""},
+		{"unknown base", []FormatSpec{{Name: "a", Extends: "b"}}, ""},
+		{"unknown metric", []FormatSpec{{Name: "a", Patterns: map[string]string{"sharpe": `(\d+)`}}}, ""},
+		{"invalid pattern", []FormatSpec{{Name: "a", Patterns: map[string]string{MetricSharpeRatio: `(`}}}, ""},
+		{"missing group", []FormatSpec{{Name: "a", Patterns: map[string]string{MetricSharpeRatio: `Sharpe`}}}, ""},
+		{"short pair pattern", []FormatSpec{{Name: "a", PairPattern: `(\w+) (\d+)`}}, ""},
+		{"short section pattern", []FormatSpec{{Name: "a", ExitReasonPattern: `(\w+) (\d+)`}}, ""},
+		{"invalid glob", []FormatSpec{{Name: "a", ImageTags: []string{"["}}}, ""},
+		{"unknown default", nil, "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistry(tt.specs, tt.defaultFormat)
+			assert.Error(t, err)
+		})
+	}
+}
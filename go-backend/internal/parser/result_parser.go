@@ -20,33 +20,75 @@ const MaxLogSize = 1024 * 1024
 
 // Parser parses Freqtrade backtest output into structured results.
 type Parser struct {
-	logger *zap.Logger
+	registry *Registry
+	logger   *zap.Logger
 }
 
-// NewParser creates a new Parser.
+// NewParser creates a new Parser that only knows the builtin format.
 func NewParser(logger *zap.Logger) *Parser {
-	return &Parser{logger: logger}
+	registry, _ := NewRegistry(nil, "")
+	return &Parser{registry: registry, logger: logger}
+}
+
+// SetRegistry replaces the formats the parser selects from.
+func (p *Parser) SetRegistry(r *Registry) {
+	p.registry = r
 }
 
 // ParseResult parses Freqtrade backtest output and creates a BacktestResult.
-func (p *Parser) ParseResult(logs string, job *domain.BacktestJob) (*domain.BacktestResult, error) {
+// Results are taken from the results file Freqtrade exported if the logs
+// include it, and otherwise parsed from the console output, whose format is
+// selected by the Freqtrade version in the logs, falling back to the one in
+// env, or by the image in env; env may be nil.
+func (p *Parser) ParseResult(logs string, job *domain.BacktestJob, env *domain.ExecutionEnvironment) (*domain.BacktestResult, error) {
+	// The export is parsed on its own and not stored with the logs
+	export, logs := extractExport(logs)
+
+	probed := ParseEnvironment(logs)
+	merged := domain.MergeExecutionEnvironments(probed, env)
+	var version, image string
+	if merged != nil {
+		version, image = merged.FreqtradeVersion, merged.Image
+	}
+	format := p.registry.Select(version, image)
+
 	// Check for errors in output
-	if err := p.checkForErrors(logs); err != nil {
+	if err := format.checkForErrors(logs); err != nil {
 		return nil, err
 	}
 
-	// Parse summary statistics
-	summary, err := p.parseSummary(logs)
-	if err != nil {
-		p.logger.Warn("Failed to parse summary, using defaults",
-			zap.Error(err),
-			zap.String("job_id", job.ID.String()),
-		)
-		summary = &SummaryStats{}
+	// Prefer the exported results, falling back to the console output
+	var summary *SummaryStats
+	var pairResults []domain.PairResult
+	formatName := format.Name()
+	if export != "" {
+		var err error
+		summary, pairResults, err = parseExport(export)
+		if err != nil {
+			p.logger.Warn("Failed to parse results export, parsing console output",
+				zap.Error(err),
+				zap.String("job_id", job.ID.String()),
+			)
+			summary = nil
+		} else {
+			formatName = ExportFormat
+		}
 	}
 
-	// Parse per-pair results
-	pairResults := p.parsePairResults(logs)
+	if summary == nil {
+		var err error
+		summary, err = format.parseSummary(logs)
+		if err != nil {
+			p.logger.Warn("Failed to parse summary, using defaults",
+				zap.Error(err),
+				zap.String("job_id", job.ID.String()),
+				zap.String("format", format.Name()),
+			)
+			summary = &SummaryStats{}
+		}
+
+		pairResults = format.parsePairResults(logs)
+	}
 
 	// Create result
 	result := domain.NewBacktestResult(job.ID, job.StrategyID)
@@ -91,9 +133,37 @@ func (p *Parser) ParseResult(logs string, job *domain.BacktestJob) (*domain.Back
 		result.WorstTradePct = &summary.WorstTradePct
 	}
 
+	// Absolute amounts are in the stake currency printed next to them,
+	// falling back to the one the job configured
+	if summary.StakeCurrency != "" {
+		result.StakeCurrency = &summary.StakeCurrency
+	} else if job.Config.StakeCurrency != "" {
+		stakeCurrency := job.Config.StakeCurrency
+		result.StakeCurrency = &stakeCurrency
+	}
+
 	// Fill in pair results
 	result.PairResults = pairResults
 
+	// Fill in trade reason breakdowns
+	result.SetExitReasons(summary.ExitReasons)
+	result.EntryTags = summary.EntryTags
+	result.TradeReturns = summary.TradeReturns
+
+	// The equity curve starts from the reported starting balance, falling
+	// back to the wallet the job configured
+	startingBalance := summary.StartingBalance
+	if startingBalance == 0 {
+		startingBalance = job.Config.DryRunWallet
+	}
+	result.EquityCurve = domain.NewEquityCurve(startingBalance, summary.DailyProfit)
+
+	// Versions reported by the container's environment probe, if it ran,
+	// and the image and host the container ran on
+	if !merged.IsEmpty() {
+		result.Environment = merged
+	}
+
 	// Compress and store raw log
 	compressed, err := p.compressLog(logs)
 	if err != nil {
@@ -107,6 +177,7 @@ func (p *Parser) ParseResult(logs string, job *domain.BacktestJob) (*domain.Back
 
 	p.logger.Info("Parsed backtest result",
 		zap.String("job_id", job.ID.String()),
+		zap.String("format", formatName),
 		zap.Int("total_trades", result.TotalTrades),
 		zap.Float64("profit_pct", result.ProfitPct),
 	)
@@ -132,33 +203,12 @@ type SummaryStats struct {
 	AvgProfitPerTrade float64
 	BestTradePct      float64
 	WorstTradePct     float64
-}
-
-// checkForErrors checks the log output for error indicators.
-func (p *Parser) checkForErrors(logs string) error {
-	// Check for common error patterns
-	errorPatterns := []string{
-		"Error:",
-		"CRITICAL:",
-		"Exception:",
-		"Traceback (most recent call last):",
-		"Strategy file not found",
-		"No data found",
-		"ImportError:",
-		"ModuleNotFoundError:",
-		"SyntaxError:",
-	}
-
-	logsLower := strings.ToLower(logs)
-	for _, pattern := range errorPatterns {
-		if strings.Contains(logsLower, strings.ToLower(pattern)) {
-			// Extract error context
-			errorMsg := extractErrorMessage(logs, pattern)
-			return fmt.Errorf("backtest error: %s", errorMsg)
-		}
-	}
-
-	return nil
+	StakeCurrency     string
+	ExitReasons       []domain.TradeReasonStats
+	EntryTags         []domain.TradeReasonStats
+	TradeReturns      []float64
+	StartingBalance   float64
+	DailyProfit       []domain.DailyProfit
 }
 
 // extractErrorMessage extracts the error message from logs.
@@ -236,117 +286,61 @@ func DecompressLog(compressed []byte) (string, error) {
 	return buf.String(), nil
 }
 
-// Regular expressions for parsing Freqtrade output
-var (
-	// Summary patterns
-	totalTradesRe    = regexp.MustCompile(`(?i)Total[/\s].*Trades?\s*[│|]\s*(\d+)`)
-	profitPctRe      = regexp.MustCompile(`(?i)Total profit\s*%?\s*[│|]\s*([-\d.]+)\s*%?`)
-	profitAbsRe      = regexp.MustCompile(`(?i)Abs\. profit\s*[│|]\s*([-\d.]+)`)
-	sharpeRe         = regexp.MustCompile(`(?i)Sharpe\s*[│|]\s*([-\d.]+)`)
-	sortinoRe        = regexp.MustCompile(`(?i)Sortino\s*[│|]\s*([-\d.]+)`)
-	calmarRe         = regexp.MustCompile(`(?i)Calmar\s*[│|]\s*([-\d.]+)`)
-	maxDrawdownRe    = regexp.MustCompile(`(?i)Max\s*[dD]rawdown\s*[│|]\s*([-\d.]+)\s*%?`)
-	maxDrawdownAbsRe = regexp.MustCompile(`(?i)Max\s*[dD]rawdown\s*\([Aa]bs\)\s*[│|]\s*([-\d.]+)`)
-	winRateRe        = regexp.MustCompile(`(?i)Win\s*[rR]ate\s*[│|]?\s*([\d.]+)\s*%?\s*\[?(\d+)[/](\d+)\]?`)
-	avgDurationRe    = regexp.MustCompile(`(?i)Avg\.\s*[dD]uration\s*[│|]\s*(\d+:\d+:\d+|[\d.]+\s*min)`)
-	profitFactorRe   = regexp.MustCompile(`(?i)Profit\s*[fF]actor\s*[│|]\s*([-\d.]+)`)
-	bestTradeRe      = regexp.MustCompile(`(?i)Best\s*[tT]rade\s*[│|]\s*([-\d.]+)\s*%?`)
-	worstTradeRe     = regexp.MustCompile(`(?i)Worst\s*[tT]rade\s*[│|]\s*([-\d.]+)\s*%?`)
+// Markers around the environment probe the backtest container prints
+// before running Freqtrade.
+const (
+	EnvironmentBlockStart = "=== FREQSEARCH ENVIRONMENT ==="
+	EnvironmentBlockEnd   = "=== END FREQSEARCH ENVIRONMENT ==="
 )
 
-// parseSummary extracts summary statistics from Freqtrade output.
-func (p *Parser) parseSummary(logs string) (*SummaryStats, error) {
-	stats := &SummaryStats{}
-
-	// Parse total trades
-	if matches := totalTradesRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.TotalTrades, _ = strconv.Atoi(matches[1])
-	}
-
-	// Parse profit percentage
-	if matches := profitPctRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.ProfitPct, _ = strconv.ParseFloat(matches[1], 64)
-	}
-
-	// Parse absolute profit
-	if matches := profitAbsRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.ProfitTotal, _ = strconv.ParseFloat(matches[1], 64)
-	}
-
-	// Parse Sharpe ratio
-	if matches := sharpeRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.SharpeRatio, _ = strconv.ParseFloat(matches[1], 64)
-	}
-
-	// Parse Sortino ratio
-	if matches := sortinoRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.SortinoRatio, _ = strconv.ParseFloat(matches[1], 64)
-	}
-
-	// Parse Calmar ratio
-	if matches := calmarRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.CalmarRatio, _ = strconv.ParseFloat(matches[1], 64)
-	}
-
-	// Parse max drawdown percentage
-	if matches := maxDrawdownRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.MaxDrawdownPct, _ = strconv.ParseFloat(matches[1], 64)
-	}
-
-	// Parse max drawdown absolute
-	if matches := maxDrawdownAbsRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.MaxDrawdown, _ = strconv.ParseFloat(matches[1], 64)
-	}
+// Environment probe patterns. "freqtrade --version" prints either
+// "freqtrade 2024.1" or, in newer releases, "Freqtrade Version: freqtrade 2024.1".
+var (
+	freqtradeVersionRe = regexp.MustCompile(`(?i)^(?:freqtrade version:\s*)?freqtrade\s+v?(\d[\w.+-]*)`)
+	pythonVersionRe    = regexp.MustCompile(`(?i)^(?:python version:\s*)?python\s+(\d+\.\d+[\w.+-]*)`)
+	packageVersionRe   = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)==(\S+)$`)
+)
 
-	// Parse win rate
-	if matches := winRateRe.FindStringSubmatch(logs); len(matches) > 3 {
-		stats.WinRate, _ = strconv.ParseFloat(matches[1], 64)
-		if stats.WinRate > 1 {
-			stats.WinRate /= 100 // Convert percentage to decimal
+// ParseEnvironment extracts the execution environment from the probe block
+// in the container logs. It returns nil if the block is missing.
+func ParseEnvironment(logs string) *domain.ExecutionEnvironment {
+	start := strings.Index(logs, EnvironmentBlockStart)
+	if start < 0 {
+		return nil
+	}
+	block := logs[start+len(EnvironmentBlockStart):]
+	if end := strings.Index(block, EnvironmentBlockEnd); end >= 0 {
+		block = block[:end]
+	}
+
+	env := &domain.ExecutionEnvironment{}
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimSpace(line)
+		if matches := freqtradeVersionRe.FindStringSubmatch(line); len(matches) > 1 {
+			env.FreqtradeVersion = matches[1]
+		} else if matches := pythonVersionRe.FindStringSubmatch(line); len(matches) > 1 {
+			env.PythonVersion = matches[1]
+		} else if matches := packageVersionRe.FindStringSubmatch(line); len(matches) > 2 {
+			if env.Packages == nil {
+				env.Packages = make(map[string]string)
+			}
+			env.Packages[strings.ToLower(matches[1])] = matches[2]
 		}
-		stats.WinningTrades, _ = strconv.Atoi(matches[2])
-		totalFromWinRate, _ := strconv.Atoi(matches[3])
-		stats.LosingTrades = totalFromWinRate - stats.WinningTrades
-	} else {
-		// Calculate winning/losing from total and win rate if available
-		if stats.TotalTrades > 0 && stats.WinRate > 0 {
-			stats.WinningTrades = int(float64(stats.TotalTrades) * stats.WinRate)
-			stats.LosingTrades = stats.TotalTrades - stats.WinningTrades
-		}
-	}
-
-	// Parse profit factor
-	if matches := profitFactorRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.ProfitFactor, _ = strconv.ParseFloat(matches[1], 64)
-	}
-
-	// Parse best trade
-	if matches := bestTradeRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.BestTradePct, _ = strconv.ParseFloat(matches[1], 64)
-	}
-
-	// Parse worst trade
-	if matches := worstTradeRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.WorstTradePct, _ = strconv.ParseFloat(matches[1], 64)
 	}
 
-	// Parse average duration
-	if matches := avgDurationRe.FindStringSubmatch(logs); len(matches) > 1 {
-		stats.AvgTradeDuration = parseDuration(matches[1])
-	}
-
-	// Calculate average profit per trade
-	if stats.TotalTrades > 0 {
-		stats.AvgProfitPerTrade = stats.ProfitTotal / float64(stats.TotalTrades)
-	}
-
-	return stats, nil
+	return env
 }
 
 // parseDuration parses duration string to minutes.
 func parseDuration(s string) float64 {
 	s = strings.TrimSpace(s)
 
+	// Durations of a day or more are printed as "N day(s), HH:MM:SS"
+	if days, rest, ok := strings.Cut(s, ","); ok && strings.Contains(days, "day") {
+		n, _ := strconv.Atoi(strings.TrimSpace(strings.Fields(days)[0]))
+		return float64(n*24*60) + parseDuration(rest)
+	}
+
 	// Try HH:MM:SS format
 	if strings.Contains(s, ":") {
 		parts := strings.Split(s, ":")
@@ -368,37 +362,6 @@ func parseDuration(s string) float64 {
 	return 0
 }
 
-// Per-pair result parsing patterns
-var pairResultRe = regexp.MustCompile(`(?i)([\w/]+:[\w]+)\s+[│|]\s+(\d+)\s+[│|]\s+([-\d.]+)\s*%?\s+[│|]\s+([-\d.]+)\s*%?\s+[│|]`)
-
-// parsePairResults extracts per-pair results from Freqtrade output.
-func (p *Parser) parsePairResults(logs string) []domain.PairResult {
-	var results []domain.PairResult
-
-	matches := pairResultRe.FindAllStringSubmatch(logs, -1)
-	for _, match := range matches {
-		if len(match) < 5 {
-			continue
-		}
-
-		trades, _ := strconv.Atoi(match[2])
-		profitPct, _ := strconv.ParseFloat(match[3], 64)
-		winRate, _ := strconv.ParseFloat(match[4], 64)
-		if winRate > 1 {
-			winRate /= 100
-		}
-
-		results = append(results, domain.PairResult{
-			Pair:      match[1],
-			Trades:    trades,
-			ProfitPct: profitPct,
-			WinRate:   winRate,
-		})
-	}
-
-	return results
-}
-
 // ValidateResult performs basic validation on a parsed result.
 func ValidateResult(result *domain.BacktestResult) error {
 	if result.ID == uuid.Nil {
@@ -0,0 +1,216 @@
+// Package pricing converts backtest profits between currencies so results
+// staked in different currencies can be compared.
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ErrNoRate is returned when a source has no price for a currency pair.
+var ErrNoRate = errors.New("no rate available")
+
+// Source provides exchange rates between currencies.
+type Source interface {
+	// Rate returns how many units of quote one unit of base is worth.
+	Rate(ctx context.Context, base, quote string) (float64, error)
+}
+
+// NewSource creates the price source selected by the configuration.
+func NewSource(cfg *config.CurrencyConfig) (Source, error) {
+	switch cfg.PriceSource {
+	case config.PriceSourceStatic, "":
+		return NewStaticSource(cfg.StaticRates), nil
+	case config.PriceSourceHTTP:
+		ttl, err := time.ParseDuration(cfg.CacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache_ttl %q: %w", cfg.CacheTTL, err)
+		}
+		return NewHTTPSource(cfg.PriceURL, ttl), nil
+	default:
+		return nil, fmt.Errorf("unknown price source %q", cfg.PriceSource)
+	}
+}
+
+// StaticSource serves fixed rates from configuration. A rate for BASE/QUOTE
+// also answers QUOTE/BASE with its inverse.
+type StaticSource struct {
+	rates map[string]float64
+}
+
+// NewStaticSource creates a source from rates keyed by "BASE/QUOTE".
+func NewStaticSource(rates map[string]float64) *StaticSource {
+	normalized := make(map[string]float64, len(rates))
+	for pair, rate := range rates {
+		normalized[strings.ToUpper(pair)] = rate
+	}
+	return &StaticSource{rates: normalized}
+}
+
+// Rate implements Source.
+func (s *StaticSource) Rate(ctx context.Context, base, quote string) (float64, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if base == quote {
+		return 1, nil
+	}
+	if rate, ok := s.rates[base+"/"+quote]; ok && rate > 0 {
+		return rate, nil
+	}
+	if rate, ok := s.rates[quote+"/"+base]; ok && rate > 0 {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("%w for %s/%s", ErrNoRate, base, quote)
+}
+
+// HTTPSource fetches prices from a ticker endpoint such as Binance's
+// /api/v3/ticker/price, which responds with {"price": "..."}. The URL
+// template's {base} and {quote} placeholders are substituted per request.
+// When the pair isn't listed, the inverse pair is tried.
+type HTTPSource struct {
+	urlTemplate string
+	client      *http.Client
+	ttl         time.Duration
+	clock       clock.Clock
+
+	mu    sync.Mutex
+	cache map[string]cachedRate
+}
+
+type cachedRate struct {
+	rate      float64
+	fetchedAt time.Time
+}
+
+// NewHTTPSource creates a source that fetches prices from urlTemplate and
+// reuses them for ttl.
+func NewHTTPSource(urlTemplate string, ttl time.Duration) *HTTPSource {
+	return &HTTPSource{
+		urlTemplate: urlTemplate,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		ttl:         ttl,
+		clock:       clock.Real(),
+		cache:       make(map[string]cachedRate),
+	}
+}
+
+// SetClock replaces the source's time source used for cache expiry.
+func (s *HTTPSource) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// Rate implements Source.
+func (s *HTTPSource) Rate(ctx context.Context, base, quote string) (float64, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if base == quote {
+		return 1, nil
+	}
+
+	key := base + "/" + quote
+	s.mu.Lock()
+	cached, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && s.clock.Since(cached.fetchedAt) < s.ttl {
+		return cached.rate, nil
+	}
+
+	rate, err := s.fetch(ctx, base, quote)
+	if errors.Is(err, ErrNoRate) {
+		var inverse float64
+		if inverse, err = s.fetch(ctx, quote, base); err == nil {
+			rate = 1 / inverse
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedRate{rate: rate, fetchedAt: s.clock.Now()}
+	s.mu.Unlock()
+	return rate, nil
+}
+
+// fetch requests the price of base in quote.
+func (s *HTTPSource) fetch(ctx context.Context, base, quote string) (float64, error) {
+	url := strings.NewReplacer("{base}", base, "{quote}", quote).Replace(s.urlTemplate)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create price request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s/%s price: %w", base, quote, err)
+	}
+	defer resp.Body.Close()
+
+	// Unknown symbols are reported as client errors by ticker APIs
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return 0, fmt.Errorf("%w for %s/%s (HTTP %d)", ErrNoRate, base, quote, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to fetch %s/%s price: HTTP %d", base, quote, resp.StatusCode)
+	}
+
+	var body struct {
+		Price json.RawMessage `json:"price"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("failed to decode %s/%s price: %w", base, quote, err)
+	}
+
+	// Prices may be encoded as strings to preserve precision
+	rate, err := strconv.ParseFloat(strings.Trim(string(body.Price), `"`), 64)
+	if err != nil || rate <= 0 {
+		return 0, fmt.Errorf("invalid %s/%s price %s", base, quote, body.Price)
+	}
+	return rate, nil
+}
+
+// Normalizer converts result profits to the configured reference currency.
+type Normalizer struct {
+	reference string
+	source    Source
+	logger    *zap.Logger
+}
+
+// NewNormalizer creates a normalizer for cfg.ReferenceCurrency.
+func NewNormalizer(cfg *config.CurrencyConfig, source Source, logger *zap.Logger) *Normalizer {
+	return &Normalizer{
+		reference: strings.ToUpper(cfg.ReferenceCurrency),
+		source:    source,
+		logger:    logger,
+	}
+}
+
+// Normalize sets the reference-currency profit on result. Results whose
+// stake currency is unknown are left untouched.
+func (n *Normalizer) Normalize(ctx context.Context, result *domain.BacktestResult) error {
+	if result.StakeCurrency == nil || *result.StakeCurrency == "" {
+		n.logger.Debug("Skipping profit normalization, stake currency unknown",
+			zap.String("result_id", result.ID.String()),
+		)
+		return nil
+	}
+
+	rate, err := n.source.Rate(ctx, *result.StakeCurrency, n.reference)
+	if err != nil {
+		return fmt.Errorf("failed to get %s/%s rate: %w", *result.StakeCurrency, n.reference, err)
+	}
+
+	result.Normalize(n.reference, rate)
+	return nil
+}
@@ -0,0 +1,107 @@
+package pricing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestStaticSource_Rate(t *testing.T) {
+	ctx := context.Background()
+	source := NewStaticSource(map[string]float64{"btc/usdt": 50000})
+
+	rate, err := source.Rate(ctx, "BTC", "USDT")
+	require.NoError(t, err)
+	assert.Equal(t, 50000.0, rate)
+
+	rate, err = source.Rate(ctx, "usdt", "btc")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.00002, rate, 1e-12)
+
+	rate, err = source.Rate(ctx, "ETH", "ETH")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, rate)
+
+	_, err = source.Rate(ctx, "ETH", "USDT")
+	assert.ErrorIs(t, err, ErrNoRate)
+}
+
+func TestHTTPSource_RateCachesAndInverts(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"symbol":"BTCUSDT","price":"40000.00"}`))
+	}))
+	defer server.Close()
+
+	clk := clock.NewFake(time.Now())
+	source := NewHTTPSource(server.URL+"?symbol={base}{quote}", time.Minute)
+	source.SetClock(clk)
+	ctx := context.Background()
+
+	rate, err := source.Rate(ctx, "BTC", "USDT")
+	require.NoError(t, err)
+	assert.Equal(t, 40000.0, rate)
+
+	// The inverse pair isn't listed, so the direct price is inverted
+	rate, err = source.Rate(ctx, "USDT", "BTC")
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0/40000, rate, 1e-12)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// Cached until the TTL passes
+	_, err = source.Rate(ctx, "BTC", "USDT")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+
+	clk.Advance(2 * time.Minute)
+	_, err = source.Rate(ctx, "BTC", "USDT")
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), requests.Load())
+
+	_, err = source.Rate(ctx, "FOO", "BAR")
+	assert.ErrorIs(t, err, ErrNoRate)
+}
+
+func TestNormalizer_Normalize(t *testing.T) {
+	cfg := &config.CurrencyConfig{ReferenceCurrency: "usdt", PriceSource: config.PriceSourceStatic}
+	normalizer := NewNormalizer(cfg, NewStaticSource(map[string]float64{"BTC/USDT": 50000}), zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	result := domain.NewBacktestResult(uuid.New(), uuid.New())
+	result.ProfitTotal = 0.01
+	btc := "BTC"
+	result.StakeCurrency = &btc
+	require.NoError(t, normalizer.Normalize(ctx, result))
+	require.NotNil(t, result.ProfitTotalNormalized)
+	assert.InDelta(t, 500.0, *result.ProfitTotalNormalized, 1e-9)
+	assert.Equal(t, "USDT", *result.ReferenceCurrency)
+	assert.Equal(t, 50000.0, *result.ReferenceRate)
+
+	// Unknown stake currency is left as is
+	unknown := domain.NewBacktestResult(uuid.New(), uuid.New())
+	require.NoError(t, normalizer.Normalize(ctx, unknown))
+	assert.Nil(t, unknown.ProfitTotalNormalized)
+
+	eth := "ETH"
+	missing := domain.NewBacktestResult(uuid.New(), uuid.New())
+	missing.StakeCurrency = &eth
+	assert.ErrorIs(t, normalizer.Normalize(ctx, missing), ErrNoRate)
+	assert.Nil(t, missing.ProfitTotalNormalized)
+}
@@ -0,0 +1,109 @@
+// Package ranking ranks backtest results against their cohort, the results
+// backtested on the same pairs over the same timerange, so a metric such as
+// a Sharpe ratio of 1.4 can be judged in context.
+package ranking
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// CohortSource loads the metrics of a cohort's results.
+type CohortSource interface {
+	GetCohortMetrics(ctx context.Context, cohort domain.ResultCohort) (*domain.CohortMetrics, error)
+}
+
+// Ranker computes percentile ranks against cohort aggregates, which it caches
+// by cohort for the TTL: ranking many results of a cohort, such as the
+// iterations of an optimization run, loads the cohort once.
+type Ranker struct {
+	source     CohortSource
+	ttl        time.Duration
+	maxCohorts int
+	clock      clock.Clock
+
+	mu    sync.Mutex
+	cache map[string]cachedCohort
+}
+
+type cachedCohort struct {
+	metrics  *domain.CohortMetrics
+	loadedAt time.Time
+}
+
+// NewRanker creates a ranker that reuses the aggregate of a cohort for ttl
+// and caches at most maxCohorts cohorts.
+func NewRanker(source CohortSource, ttl time.Duration, maxCohorts int) *Ranker {
+	return &Ranker{
+		source:     source,
+		ttl:        ttl,
+		maxCohorts: maxCohorts,
+		clock:      clock.Real(),
+		cache:      make(map[string]cachedCohort),
+	}
+}
+
+// SetClock replaces the ranker's time source used for cache expiry.
+func (r *Ranker) SetClock(c clock.Clock) {
+	r.clock = c
+}
+
+// Rank sets the percentile ranks of a result backtested with config. The
+// cohort may be up to the TTL old, so it need not include the result itself.
+func (r *Ranker) Rank(ctx context.Context, result *domain.BacktestResult, config *domain.BacktestConfig) error {
+	cohort := config.Cohort()
+	metrics, err := r.metrics(ctx, cohort)
+	if err != nil {
+		return err
+	}
+	result.Percentiles = metrics.Rank(cohort, result)
+	return nil
+}
+
+// metrics returns the cached aggregate of a cohort, loading it if it is
+// missing or expired.
+func (r *Ranker) metrics(ctx context.Context, cohort domain.ResultCohort) (*domain.CohortMetrics, error) {
+	key := cohort.Key()
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && r.clock.Since(cached.loadedAt) < r.ttl {
+		return cached.metrics, nil
+	}
+
+	metrics, err := r.source.GetCohortMetrics(ctx, cohort)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cache[key]; !ok && len(r.cache) >= r.maxCohorts {
+		r.evict()
+	}
+	r.cache[key] = cachedCohort{metrics: metrics, loadedAt: r.clock.Now()}
+	return metrics, nil
+}
+
+// evict makes room in the full cache by dropping the expired cohorts, or the
+// least recently loaded one if none has expired. r.mu must be held.
+func (r *Ranker) evict() {
+	var oldestKey string
+	var oldest time.Time
+	for key, cached := range r.cache {
+		if r.clock.Since(cached.loadedAt) >= r.ttl {
+			delete(r.cache, key)
+			continue
+		}
+		if oldestKey == "" || cached.loadedAt.Before(oldest) {
+			oldestKey, oldest = key, cached.loadedAt
+		}
+	}
+	if len(r.cache) >= r.maxCohorts {
+		delete(r.cache, oldestKey)
+	}
+}
@@ -0,0 +1,115 @@
+package ranking
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// fakeCohortSource serves fixed cohort metrics and counts the loads.
+type fakeCohortSource struct {
+	metrics map[string]*domain.CohortMetrics
+	loads   map[string]int
+}
+
+func (f *fakeCohortSource) GetCohortMetrics(ctx context.Context, cohort domain.ResultCohort) (*domain.CohortMetrics, error) {
+	f.loads[cohort.Key()]++
+	if m, ok := f.metrics[cohort.Key()]; ok {
+		return m, nil
+	}
+	return &domain.CohortMetrics{}, nil
+}
+
+func TestRanker_Rank(t *testing.T) {
+	config := &domain.BacktestConfig{
+		Pairs:          []string{"ETH/USDT", "BTC/USDT", "ETH/USDT"},
+		TimerangeStart: "2024-01-01",
+		TimerangeEnd:   "2024-03-01",
+	}
+	cohort := config.Cohort()
+	assert.Equal(t, []string{"BTC/USDT", "ETH/USDT"}, cohort.Pairs)
+	assert.Equal(t, "20240101", cohort.TimerangeStart)
+
+	source := &fakeCohortSource{
+		metrics: map[string]*domain.CohortMetrics{cohort.Key(): {
+			SharpeRatios:    []float64{0.2, 0.8, 1.4, 1.4, 2.0},
+			ProfitPcts:      []float64{-5, 0, 3, 8, 12, 20},
+			MaxDrawdownPcts: []float64{4, 6, 9, 10, 15, 30},
+		}},
+		loads: make(map[string]int),
+	}
+	clk := clock.NewFake(time.Now())
+	ranker := NewRanker(source, time.Minute, 10)
+	ranker.SetClock(clk)
+
+	sharpe := 1.4
+	result := &domain.BacktestResult{ID: uuid.New(), SharpeRatio: &sharpe, ProfitPct: 12, MaxDrawdownPct: 6}
+	require.NoError(t, ranker.Rank(context.Background(), result, config))
+
+	ranks := result.Percentiles
+	require.NotNil(t, ranks)
+	assert.Equal(t, 6, ranks.CohortSize)
+	assert.Equal(t, cohort, ranks.Cohort)
+	// Two below and two tied out of five
+	require.NotNil(t, ranks.Sharpe)
+	assert.InDelta(t, 60, *ranks.Sharpe, 1e-9)
+	// Four below, one tie out of six
+	require.NotNil(t, ranks.Profit)
+	assert.InDelta(t, 75, *ranks.Profit, 1e-9)
+	// A smaller drawdown is better: four larger, one tie
+	require.NotNil(t, ranks.Drawdown)
+	assert.InDelta(t, 75, *ranks.Drawdown, 1e-9)
+
+	// The cohort is cached until the TTL expires
+	other := &domain.BacktestResult{ID: uuid.New(), ProfitPct: -10, MaxDrawdownPct: 50}
+	require.NoError(t, ranker.Rank(context.Background(), other, config))
+	assert.Nil(t, other.Percentiles.Sharpe)
+	assert.Zero(t, *other.Percentiles.Profit)
+	assert.Zero(t, *other.Percentiles.Drawdown)
+	assert.Equal(t, 1, source.loads[cohort.Key()])
+
+	clk.Advance(time.Minute)
+	require.NoError(t, ranker.Rank(context.Background(), other, config))
+	assert.Equal(t, 2, source.loads[cohort.Key()])
+}
+
+func TestRanker_EmptyCohort(t *testing.T) {
+	source := &fakeCohortSource{loads: make(map[string]int)}
+	ranker := NewRanker(source, time.Minute, 10)
+
+	result := &domain.BacktestResult{ProfitPct: 3}
+	require.NoError(t, ranker.Rank(context.Background(), result, &domain.BacktestConfig{Pairs: []string{"BTC/USDT"}}))
+	assert.Zero(t, result.Percentiles.CohortSize)
+	assert.Nil(t, result.Percentiles.Profit)
+}
+
+func TestRanker_Eviction(t *testing.T) {
+	source := &fakeCohortSource{loads: make(map[string]int)}
+	clk := clock.NewFake(time.Now())
+	ranker := NewRanker(source, time.Hour, 2)
+	ranker.SetClock(clk)
+
+	rank := func(pair string) {
+		result := &domain.BacktestResult{}
+		require.NoError(t, ranker.Rank(context.Background(), result, &domain.BacktestConfig{Pairs: []string{pair}}))
+	}
+	rank("BTC/USDT")
+	clk.Advance(time.Second)
+	rank("ETH/USDT")
+	clk.Advance(time.Second)
+	rank("SOL/USDT")
+
+	// The least recently loaded cohort made room
+	assert.Len(t, ranker.cache, 2)
+	rank("ETH/USDT")
+	assert.Equal(t, 1, source.loads[(&domain.BacktestConfig{Pairs: []string{"ETH/USDT"}}).Cohort().Key()])
+	rank("BTC/USDT")
+	assert.Equal(t, 2, source.loads[(&domain.BacktestConfig{Pairs: []string{"BTC/USDT"}}).Cohort().Key()])
+}
@@ -0,0 +1,80 @@
+package redact
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// core is a zapcore.Core that redacts fields before passing them on.
+type core struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+// NewCore wraps c so that fields added with With or logged with an entry are
+// redacted first. Error fields are truncated on their message.
+func NewCore(c zapcore.Core, r *Redactor) zapcore.Core {
+	if !r.Active() {
+		return c
+	}
+	return &core{Core: c, redactor: r}
+}
+
+// With adds redacted fields to the core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{Core: c.Core.With(c.redactor.Fields(fields)), redactor: c.redactor}
+}
+
+// Check adds this core, rather than the wrapped one, to the checked entry so
+// that Write redacts.
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write redacts the fields and writes the entry.
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.redactor.Fields(fields))
+}
+
+// Fields returns a copy of fields with redacted keys replaced by the
+// placeholder and long strings, byte strings and error messages truncated.
+func (r *Redactor) Fields(fields []zapcore.Field) []zapcore.Field {
+	if !r.Active() || len(fields) == 0 {
+		return fields
+	}
+
+	redacted := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		redacted[i] = r.field(f)
+	}
+	return redacted
+}
+
+// field redacts a single log field.
+func (r *Redactor) field(f zapcore.Field) zapcore.Field {
+	if f.Type == zapcore.SkipType {
+		return f
+	}
+	if r.Redacts(f.Key) {
+		return zap.String(f.Key, Placeholder)
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = Truncate(f.String, r.maxBytes)
+	case zapcore.ByteStringType:
+		if b, ok := f.Interface.([]byte); ok && r.maxBytes > 0 && len(b) > r.maxBytes {
+			return zap.String(f.Key, Truncate(string(b), r.maxBytes))
+		}
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok && err != nil && r.maxBytes > 0 {
+			if msg := err.Error(); len(msg) > r.maxBytes {
+				return zap.String(f.Key, Truncate(msg, r.maxBytes))
+			}
+		}
+	}
+	return f
+}
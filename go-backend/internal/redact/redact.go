@@ -0,0 +1,134 @@
+// Package redact removes sensitive values and truncates oversized ones before
+// they are written to logs or published in events.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Placeholder replaces the value of a redacted field.
+const Placeholder = "[REDACTED]"
+
+// Redactor redacts configured fields and truncates long string values.
+// A nil Redactor leaves everything unchanged.
+type Redactor struct {
+	fields   map[string]bool
+	maxBytes int
+}
+
+// New creates a redactor for the given field names, matched case-insensitively.
+// String values longer than maxBytes are truncated; 0 disables truncation.
+func New(fields []string, maxBytes int) *Redactor {
+	r := &Redactor{
+		fields:   make(map[string]bool, len(fields)),
+		maxBytes: maxBytes,
+	}
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			r.fields[strings.ToLower(field)] = true
+		}
+	}
+	return r
+}
+
+// Active returns true if the redactor changes anything.
+func (r *Redactor) Active() bool {
+	return r != nil && (len(r.fields) > 0 || r.maxBytes > 0)
+}
+
+// Redacts returns true if the values of key are replaced with the placeholder.
+func (r *Redactor) Redacts(key string) bool {
+	return r != nil && r.fields[strings.ToLower(key)]
+}
+
+// String returns the value to record for key.
+func (r *Redactor) String(key, value string) string {
+	if r.Redacts(key) {
+		return Placeholder
+	}
+	if r == nil {
+		return value
+	}
+	return Truncate(value, r.maxBytes)
+}
+
+// JSON redacts a JSON document: values of redacted keys are replaced at any
+// depth and long strings are truncated. The document is returned unchanged
+// when there is nothing to redact.
+func (r *Redactor) JSON(body []byte) ([]byte, error) {
+	if !r.Active() {
+		return body, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	doc, changed := r.value("", doc)
+	if !changed {
+		return body, nil
+	}
+
+	redacted, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return redacted, nil
+}
+
+// value redacts a decoded JSON value stored under key.
+func (r *Redactor) value(key string, v interface{}) (interface{}, bool) {
+	if key != "" && r.Redacts(key) {
+		return Placeholder, true
+	}
+
+	switch val := v.(type) {
+	case string:
+		truncated := Truncate(val, r.maxBytes)
+		return truncated, len(truncated) != len(val)
+	case map[string]interface{}:
+		changed := false
+		for k, child := range val {
+			redacted, ok := r.value(k, child)
+			if ok {
+				val[k] = redacted
+				changed = true
+			}
+		}
+		return val, changed
+	case []interface{}:
+		changed := false
+		for i, child := range val {
+			redacted, ok := r.value("", child)
+			if ok {
+				val[i] = redacted
+				changed = true
+			}
+		}
+		return val, changed
+	default:
+		return v, false
+	}
+}
+
+// Truncate shortens s to at most maxBytes, cutting at a rune boundary and
+// noting how much was dropped. A maxBytes of 0 leaves s unchanged.
+func Truncate(s string, maxBytes int) string {
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		return s
+	}
+
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s...[truncated %d bytes]", s[:cut], len(s)-cut)
+}
@@ -0,0 +1,76 @@
+package redact
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short", 10))
+	assert.Equal(t, "unlimited", Truncate("unlimited", 0))
+	assert.Equal(t, "abc...[truncated 3 bytes]", Truncate("abcdef", 3))
+
+	// Cuts at a rune boundary rather than inside "é"
+	assert.Equal(t, "a...[truncated 2 bytes]", Truncate("aé", 2))
+}
+
+func TestRedactor_JSON(t *testing.T) {
+	r := New([]string{"Code", "password"}, 8)
+
+	body := []byte(`{"code":"class S(IStrategy): pass","name":"short","nested":{"password":"hunter2"},` +
+		`"items":[{"code":"x"},"a long string value"],"count":12345678901234567890}`)
+	redacted, err := r.JSON(body)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(redacted, &doc))
+	assert.Equal(t, Placeholder, doc["code"])
+	assert.Equal(t, "short", doc["name"])
+	assert.Equal(t, Placeholder, doc["nested"].(map[string]interface{})["password"])
+
+	items := doc["items"].([]interface{})
+	assert.Equal(t, Placeholder, items[0].(map[string]interface{})["code"])
+	assert.True(t, strings.HasPrefix(items[1].(string), "a long s...[truncated"))
+
+	// Large numbers survive the round trip
+	assert.Contains(t, string(redacted), "12345678901234567890")
+}
+
+func TestRedactor_JSONUnchanged(t *testing.T) {
+	body := []byte(`{"name": "kept as is"}`)
+
+	redacted, err := New([]string{"code"}, 0).JSON(body)
+	require.NoError(t, err)
+	assert.Equal(t, body, redacted)
+
+	var nilRedactor *Redactor
+	redacted, err = nilRedactor.JSON(body)
+	require.NoError(t, err)
+	assert.Equal(t, body, redacted)
+}
+
+func TestNewCore(t *testing.T) {
+	observed, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(NewCore(observed, New([]string{"code"}, 5)))
+
+	logger.With(zap.String("code", "secret strategy")).Info("submitted",
+		zap.String("logs", "0123456789"),
+		zap.Error(errors.New("a long error message")),
+		zap.Int("trades", 42),
+	)
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, Placeholder, fields["code"])
+	assert.Equal(t, "01234...[truncated 5 bytes]", fields["logs"])
+	assert.Equal(t, "a lon...[truncated 15 bytes]", fields["error"])
+	assert.EqualValues(t, 42, fields["trades"])
+}
@@ -0,0 +1,256 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+const (
+	// affinityLookahead is how many pending jobs are considered per free
+	// worker, so hints can pick a better job than the head of the queue.
+	affinityLookahead = 4
+
+	// dataCacheTTL is how long a scan of the market data directory is reused.
+	dataCacheTTL = time.Minute
+)
+
+// DataCache reports which market data is already on this host.
+type DataCache interface {
+	HasData(exchange, timeframe string) bool
+}
+
+// DirDataCache is a DataCache over a Freqtrade data directory, laid out as
+// <dir>/<exchange>/[futures/]<PAIR>-<timeframe>[-futures].<ext>.
+type DirDataCache struct {
+	dir string
+
+	mu        sync.Mutex
+	scannedAt time.Time
+	sets      map[string]bool // "exchange/timeframe"
+}
+
+// NewDirDataCache creates a DataCache for the data directory mounted into
+// backtest containers.
+func NewDirDataCache(dir string) *DirDataCache {
+	return &DirDataCache{dir: dir}
+}
+
+// HasData reports whether any pair has data for the exchange and timeframe.
+func (c *DirDataCache) HasData(exchange, timeframe string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sets == nil || time.Since(c.scannedAt) > dataCacheTTL {
+		c.sets = scanDataDir(c.dir)
+		c.scannedAt = time.Now()
+	}
+	return c.sets[exchange+"/"+timeframe]
+}
+
+// scanDataDir collects the exchange/timeframe data sets found under dir. An
+// unreadable directory has no data.
+func scanDataDir(dir string) map[string]bool {
+	sets := make(map[string]bool)
+	exchanges, err := os.ReadDir(dir)
+	if err != nil {
+		return sets
+	}
+	for _, ex := range exchanges {
+		if !ex.IsDir() {
+			continue
+		}
+		for _, sub := range []string{"", "futures"} {
+			files, err := os.ReadDir(filepath.Join(dir, ex.Name(), sub))
+			if err != nil {
+				continue
+			}
+			for _, f := range files {
+				if tf := dataFileTimeframe(f.Name()); !f.IsDir() && tf != "" {
+					sets[ex.Name()+"/"+tf] = true
+				}
+			}
+		}
+	}
+	return sets
+}
+
+// dataFileTimeframe extracts the timeframe from a data file name such as
+// "BTC_USDT-5m.feather" or "BTC_USDT_USDT-1h-futures.feather".
+func dataFileTimeframe(name string) string {
+	base, _, _ := strings.Cut(name, ".")
+	parts := strings.Split(base, "-")
+	if len(parts) < 2 {
+		return ""
+	}
+	tf := parts[1]
+	if tf == "" || tf[0] < '0' || tf[0] > '9' {
+		return ""
+	}
+	return tf
+}
+
+// affinitySelector picks which pending jobs to start, honoring their hints
+// best-effort: among jobs of equal effective priority those with cached data
+// go first,
+// and a job is skipped while a job sharing an anti-affinity key runs here. A
+// job passed over for maxDeferral is started as if it had no hints.
+type affinitySelector struct {
+	cache        DataCache // Optional; without it data preferences are ignored
+	maxDeferral  time.Duration
+	agingPerHour float64 // Priority levels a job gains per hour waited
+
+	mu         sync.Mutex
+	passedOver map[uuid.UUID]time.Time // When a job was first passed over
+}
+
+func newAffinitySelector(maxDeferral time.Duration, agingPerHour float64) *affinitySelector {
+	return &affinitySelector{
+		maxDeferral:  maxDeferral,
+		agingPerHour: agingPerHour,
+		passedOver:   make(map[uuid.UUID]time.Time),
+	}
+}
+
+// selectJobs picks up to limit of the candidates, which are ordered by
+// effective priority and age. held is the set of anti-affinity keys of running jobs.
+func (a *affinitySelector) selectJobs(candidates []*domain.BacktestJob, held map[string]bool, limit int, now time.Time) []*domain.BacktestJob {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	overdue := make(map[uuid.UUID]bool)
+	for _, job := range candidates {
+		if since, ok := a.passedOver[job.ID]; ok && now.Sub(since) >= a.maxDeferral {
+			overdue[job.ID] = true
+		}
+	}
+	score := func(job *domain.BacktestJob) int {
+		if overdue[job.ID] {
+			return 2
+		}
+		return a.dataScore(job)
+	}
+
+	priority := make(map[uuid.UUID]int, len(candidates))
+	for _, job := range candidates {
+		priority[job.ID] = job.EffectivePriority(now, a.agingPerHour)
+	}
+
+	ordered := make([]*domain.BacktestJob, len(candidates))
+	copy(ordered, candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if pi, pj := priority[ordered[i].ID], priority[ordered[j].ID]; pi != pj {
+			return pi > pj
+		}
+		return score(ordered[i]) > score(ordered[j])
+	})
+
+	taken := make(map[string]bool, len(held))
+	for key := range held {
+		taken[key] = true
+	}
+	picked := make([]*domain.BacktestJob, 0, limit)
+	pickedIDs := make(map[uuid.UUID]bool, limit)
+	skipped := make(map[uuid.UUID]bool)
+	for _, job := range ordered {
+		if len(picked) == limit {
+			break
+		}
+		keys := job.AntiAffinityKeys()
+		if !overdue[job.ID] && anyTaken(keys, taken) {
+			skipped[job.ID] = true
+			continue
+		}
+		for _, key := range keys {
+			taken[key] = true
+		}
+		picked = append(picked, job)
+		pickedIDs[job.ID] = true
+	}
+
+	a.recordPassedOver(candidates, pickedIDs, skipped, now)
+	return picked
+}
+
+// dataScore ranks a job by its data preference: 1 if some preferred data set
+// is cached, 0 without a preference and -1 if none is.
+func (a *affinitySelector) dataScore(job *domain.BacktestJob) int {
+	if a.cache == nil || job.Hints == nil || len(job.Hints.PreferCachedData) == 0 {
+		return 0
+	}
+	for _, ds := range job.Hints.PreferCachedData {
+		if exchange, timeframe, ok := domain.ParseDataSet(ds); ok && a.cache.HasData(exchange, timeframe) {
+			return 1
+		}
+	}
+	return -1
+}
+
+// recordPassedOver notes when each skipped job, or job queued ahead of a picked
+// one, was first left behind, and forgets jobs that were picked or are no
+// longer candidates.
+func (a *affinitySelector) recordPassedOver(candidates []*domain.BacktestJob, picked, skipped map[uuid.UUID]bool, now time.Time) {
+	last := -1
+	seen := make(map[uuid.UUID]bool, len(candidates))
+	for i, job := range candidates {
+		seen[job.ID] = true
+		if picked[job.ID] {
+			last = i
+		}
+	}
+	for i, job := range candidates {
+		if (i < last || skipped[job.ID]) && !picked[job.ID] {
+			if _, ok := a.passedOver[job.ID]; !ok {
+				a.passedOver[job.ID] = now
+			}
+		}
+	}
+	for id := range a.passedOver {
+		if picked[id] || !seen[id] {
+			delete(a.passedOver, id)
+		}
+	}
+}
+
+// deferrals returns when each job currently passed over was first left behind.
+func (a *affinitySelector) deferrals() map[uuid.UUID]time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	out := make(map[uuid.UUID]time.Time, len(a.passedOver))
+	for id, since := range a.passedOver {
+		out[id] = since
+	}
+	return out
+}
+
+// restoreDeferrals resumes deferral timers saved by an earlier scheduler, so
+// a restart does not reset how long a job has been passed over. Timers
+// already running are kept.
+func (a *affinitySelector) restoreDeferrals(since map[uuid.UUID]time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for id, t := range since {
+		if _, ok := a.passedOver[id]; !ok {
+			a.passedOver[id] = t
+		}
+	}
+}
+
+// anyTaken reports whether any of the keys is taken.
+func anyTaken(keys []string, taken map[string]bool) bool {
+	for _, key := range keys {
+		if taken[key] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// staticDataCache has data for a fixed set of "exchange/timeframe" data sets.
+type staticDataCache map[string]bool
+
+func (c staticDataCache) HasData(exchange, timeframe string) bool {
+	return c[exchange+"/"+timeframe]
+}
+
+func hintedJob(priority int, runID *uuid.UUID, hints *domain.JobHints) *domain.BacktestJob {
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, priority, runID)
+	job.Hints = hints
+	return job
+}
+
+func TestSelectJobs_PrefersCachedDataWithinPriority(t *testing.T) {
+	a := newAffinitySelector(10*time.Minute, 0)
+	a.cache = staticDataCache{"binance/5m": true}
+
+	uncached := hintedJob(5, nil, &domain.JobHints{PreferCachedData: []string{"kraken/1h"}})
+	plain := hintedJob(5, nil, nil)
+	cached := hintedJob(5, nil, &domain.JobHints{PreferCachedData: []string{"kraken/1h", "binance/5m"}})
+	low := hintedJob(1, nil, &domain.JobHints{PreferCachedData: []string{"binance/5m"}})
+
+	picked := a.selectJobs([]*domain.BacktestJob{uncached, plain, cached, low}, nil, 3, time.Now())
+	assert.Equal(t, []*domain.BacktestJob{cached, plain, uncached}, picked, "data never outranks priority")
+}
+
+func TestSelectJobs_AntiAffinity(t *testing.T) {
+	a := newAffinitySelector(10*time.Minute, 0)
+	run := uuid.New()
+	spread := &domain.JobHints{AntiAffinity: []string{domain.AntiAffinityOptimization}}
+
+	first := hintedJob(5, &run, spread)
+	second := hintedJob(5, &run, spread)
+	other := hintedJob(5, nil, spread) // Outside a run the key has no effect
+
+	picked := a.selectJobs([]*domain.BacktestJob{first, second, other}, nil, 3, time.Now())
+	assert.Equal(t, []*domain.BacktestJob{first, other}, picked, "two jobs of a run are not started together")
+
+	held := map[string]bool{"optimization:" + run.String(): true}
+	picked = a.selectJobs([]*domain.BacktestJob{second}, held, 1, time.Now())
+	assert.Empty(t, picked, "a job of the run is still running")
+}
+
+func TestSelectJobs_DeferralIsBounded(t *testing.T) {
+	a := newAffinitySelector(10*time.Minute, 0)
+	job := hintedJob(5, nil, &domain.JobHints{AntiAffinity: []string{"exchange:binance"}})
+	held := map[string]bool{"exchange:binance": true}
+	start := time.Now()
+
+	assert.Empty(t, a.selectJobs([]*domain.BacktestJob{job}, held, 1, start))
+	assert.Empty(t, a.selectJobs([]*domain.BacktestJob{job}, held, 1, start.Add(9*time.Minute)))
+
+	picked := a.selectJobs([]*domain.BacktestJob{job}, held, 1, start.Add(10*time.Minute))
+	assert.Equal(t, []*domain.BacktestJob{job}, picked, "hints are dropped once the job waited too long")
+	assert.Empty(t, a.passedOver)
+}
+
+func TestSelectJobs_PriorityAging(t *testing.T) {
+	a := newAffinitySelector(10*time.Minute, 2)
+	a.cache = staticDataCache{"binance/5m": true}
+	now := time.Now()
+
+	waiting := hintedJob(0, nil, nil)
+	waiting.CreatedAt = now.Add(-5 * time.Hour) // Aged to 10
+	optimization := hintedJob(9, nil, nil)
+	optimization.CreatedAt = now
+	cached := hintedJob(9, nil, &domain.JobHints{PreferCachedData: []string{"binance/5m"}})
+	cached.CreatedAt = now.Add(-40 * time.Minute) // Aged to 10
+
+	picked := a.selectJobs([]*domain.BacktestJob{optimization, cached, waiting}, nil, 3, now)
+	assert.Equal(t, []*domain.BacktestJob{cached, waiting, optimization}, picked)
+
+	assert.Equal(t, 0, waiting.EffectivePriority(now, 0))
+	assert.Equal(t, 0, waiting.EffectivePriority(now.Add(-6*time.Hour), 2), "a job created in the future has not waited")
+}
+
+// mockDequeueRepository serves fixed pending jobs and records when they were
+// fetched at.
+type mockDequeueRepository struct {
+	mockRequeueRepository
+	pending   []*domain.BacktestJob
+	fetchedAt []time.Time
+	started   []uuid.UUID
+}
+
+func (m *mockDequeueRepository) GetPendingJobs(ctx context.Context, limit int, agingPerHour float64, now time.Time) ([]*domain.BacktestJob, error) {
+	m.fetchedAt = append(m.fetchedAt, now)
+	return m.pending, nil
+}
+
+func (m *mockDequeueRepository) MarkRunning(ctx context.Context, id uuid.UUID, containerID string) error {
+	m.started = append(m.started, id)
+	return nil
+}
+
+func TestScheduler_AgesByClock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	waiting := hintedJob(0, nil, nil)
+	waiting.CreatedAt = fake.Now().Add(-10 * time.Hour)
+	fresh := hintedJob(9, nil, nil)
+	fresh.CreatedAt = fake.Now()
+
+	repo := &mockDequeueRepository{pending: []*domain.BacktestJob{fresh, waiting}}
+	sched := newStateScheduler(t, "", &repo.mockRequeueRepository, &mockStopManager{}, fake)
+	sched.repos.BacktestJob = repo
+	sched.config.PriorityAgingPerHour = 1
+	sched.affinity = newAffinitySelector(time.Minute, 1)
+	sched.jobChan = make(chan *domain.BacktestJob, 1)
+
+	// By the scheduler's clock the waiting job has aged past the fresh one
+	sched.fetchAndDispatch()
+	assert.Equal(t, []time.Time{fake.Now()}, repo.fetchedAt)
+	assert.Equal(t, []uuid.UUID{waiting.ID}, repo.started)
+}
+
+func TestDirDataCache(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"binance/BTC_USDT-5m.feather",
+		"binance/futures/ETH_USDT_USDT-1h-futures.feather",
+		"binance/futures/ETH_USDT_USDT-1h-funding_rate.feather",
+		"kraken/notes.txt",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+
+	c := NewDirDataCache(dir)
+	assert.True(t, c.HasData("binance", "5m"))
+	assert.True(t, c.HasData("binance", "1h"))
+	assert.False(t, c.HasData("binance", "15m"))
+	assert.False(t, c.HasData("kraken", "5m"))
+}
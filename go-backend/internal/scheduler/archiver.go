@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// StrategyArchiver periodically archives strategies that match the archival
+// policy, keeping default search results focused on promising strategies.
+type StrategyArchiver struct {
+	repos          *repository.Repositories
+	eventPublisher events.Publisher
+	config         *config.ArchivalConfig
+	clock          clock.Clock
+	logger         *zap.Logger
+
+	interval time.Duration
+	mu       sync.Mutex // serializes passes
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewStrategyArchiver creates a new strategy archiver.
+func NewStrategyArchiver(
+	cfg *config.ArchivalConfig,
+	repos *repository.Repositories,
+	publisher events.Publisher,
+	logger *zap.Logger,
+) *StrategyArchiver {
+	interval, err := time.ParseDuration(cfg.Interval)
+	if err != nil || interval <= 0 {
+		interval = 6 * time.Hour
+	}
+
+	return &StrategyArchiver{
+		repos:          repos,
+		eventPublisher: publisher,
+		config:         cfg,
+		clock:          clock.Real(),
+		logger:         logger,
+		interval:       interval,
+	}
+}
+
+// SetClock replaces the archiver's time source. It must be called before Start.
+func (a *StrategyArchiver) SetClock(c clock.Clock) {
+	a.clock = c
+}
+
+// Policy returns the archival policy built from the configuration.
+func (a *StrategyArchiver) Policy() domain.ArchivalPolicy {
+	return domain.ArchivalPolicy{
+		MinBacktests:  a.config.MinBacktests,
+		MaxBestSharpe: a.config.MaxBestSharpe,
+		InactiveFor:   time.Duration(a.config.InactiveDays) * 24 * time.Hour,
+		Limit:         a.config.BatchSize,
+	}
+}
+
+// Start starts evaluating the policy periodically.
+func (a *StrategyArchiver) Start() error {
+	if err := a.Policy().Validate(); err != nil {
+		return fmt.Errorf("invalid archival policy: %w", err)
+	}
+
+	a.logger.Info("Starting strategy archiver",
+		zap.Duration("interval", a.interval),
+		zap.Bool("dry_run", a.config.DryRun),
+		zap.String("policy", a.Policy().Reason()),
+	)
+
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+	a.ticker = a.clock.NewTicker(a.interval)
+	a.wg.Add(1)
+	go a.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the archiver.
+func (a *StrategyArchiver) Stop() error {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	if a.ticker != nil {
+		a.ticker.Stop()
+	}
+	a.wg.Wait()
+
+	a.logger.Info("Strategy archiver stopped")
+	return nil
+}
+
+// loop runs a pass on every tick.
+func (a *StrategyArchiver) loop() {
+	defer a.wg.Done()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-a.ticker.C():
+			if _, err := a.RunOnce(a.ctx); err != nil {
+				a.logger.Error("Strategy archival pass failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RunOnce evaluates the policy and archives matching strategies, publishing a
+// strategy.archived event for each. It returns the matching candidates; in dry-run
+// mode they are only logged.
+func (a *StrategyArchiver) RunOnce(ctx context.Context) ([]*domain.ArchivalCandidate, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	policy := a.Policy()
+	candidates, err := a.repos.Strategy.FindArchivalCandidates(ctx, policy, a.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find archival candidates: %w", err)
+	}
+
+	if a.config.DryRun {
+		for _, c := range candidates {
+			a.logger.Info("Strategy would be archived (dry run)",
+				zap.String("strategy_id", c.StrategyID.String()),
+				zap.String("name", c.Name),
+				zap.Int("backtest_count", c.BacktestCount),
+				zap.Time("last_activity_at", c.LastActivityAt),
+			)
+		}
+		return candidates, nil
+	}
+
+	reason := policy.Reason()
+	archived := make([]*domain.ArchivalCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if err := a.repos.Strategy.Archive(ctx, c.StrategyID, reason); err != nil {
+			a.logger.Warn("Failed to archive strategy",
+				zap.String("strategy_id", c.StrategyID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		archived = append(archived, c)
+
+		if a.eventPublisher != nil {
+			event := events.NewStrategyArchivedEvent(c, reason)
+			if err := a.eventPublisher.Publish(ctx, events.RoutingKeyStrategyArchived, event); err != nil {
+				a.logger.Warn("Failed to publish strategy archived event",
+					zap.String("strategy_id", c.StrategyID.String()),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	if len(archived) > 0 {
+		a.logger.Info("Archived underperforming strategies",
+			zap.Int("archived", len(archived)),
+			zap.Int("candidates", len(candidates)),
+		)
+	}
+
+	return archived, nil
+}
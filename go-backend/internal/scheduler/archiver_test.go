@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// mockArchivalRepository implements the archival methods of StrategyRepository.
+// Other methods panic via the nil embedded interface.
+type mockArchivalRepository struct {
+	repository.StrategyRepository
+	candidates []*domain.ArchivalCandidate
+	archiveErr map[uuid.UUID]error
+	archived   map[uuid.UUID]string
+	lastPolicy domain.ArchivalPolicy
+	lastNow    time.Time
+}
+
+func (m *mockArchivalRepository) FindArchivalCandidates(ctx context.Context, policy domain.ArchivalPolicy, now time.Time) ([]*domain.ArchivalCandidate, error) {
+	m.lastPolicy = policy
+	m.lastNow = now
+	return m.candidates, nil
+}
+
+func (m *mockArchivalRepository) Archive(ctx context.Context, id uuid.UUID, reason string) error {
+	if err := m.archiveErr[id]; err != nil {
+		return err
+	}
+	m.archived[id] = reason
+	return nil
+}
+
+func newTestArchiver(t *testing.T, cfg *config.ArchivalConfig, candidates ...*domain.ArchivalCandidate) (*StrategyArchiver, *mockArchivalRepository, *mockEventPublisher) {
+	repo := &mockArchivalRepository{
+		candidates: candidates,
+		archiveErr: map[uuid.UUID]error{},
+		archived:   map[uuid.UUID]string{},
+	}
+	publisher := newMockEventPublisher()
+	archiver := NewStrategyArchiver(cfg, &repository.Repositories{Strategy: repo}, publisher, zaptest.NewLogger(t))
+	return archiver, repo, publisher
+}
+
+func testArchivalConfig() *config.ArchivalConfig {
+	return &config.ArchivalConfig{
+		Enabled:       true,
+		Interval:      "1h",
+		MinBacktests:  5,
+		MaxBestSharpe: 0.5,
+		InactiveDays:  60,
+		BatchSize:     10,
+	}
+}
+
+func TestStrategyArchiver_ArchivesCandidatesAndPublishes(t *testing.T) {
+	sharpe := 0.3
+	first := &domain.ArchivalCandidate{StrategyID: uuid.New(), Name: "First", BacktestCount: 7, BestSharpe: &sharpe}
+	second := &domain.ArchivalCandidate{StrategyID: uuid.New(), Name: "Second", BacktestCount: 6}
+
+	archiver, repo, publisher := newTestArchiver(t, testArchivalConfig(), first, second)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	archiver.SetClock(clock.NewFake(now))
+
+	// A failed archive is skipped and not announced
+	repo.archiveErr[second.StrategyID] = errors.New("boom")
+
+	archived, err := archiver.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	assert.Equal(t, first.StrategyID, archived[0].StrategyID)
+
+	assert.Equal(t, now, repo.lastNow)
+	assert.Equal(t, 60*24*time.Hour, repo.lastPolicy.InactiveFor)
+	assert.Equal(t, 10, repo.lastPolicy.Limit)
+	assert.Contains(t, repo.archived[first.StrategyID], "auto-archived")
+
+	require.Len(t, publisher.publishedEvents, 1)
+	event, ok := publisher.publishedEvents[0].(*events.StrategyArchivedEvent)
+	require.True(t, ok)
+	assert.Equal(t, events.EventTypeStrategyArchived, event.EventType)
+	assert.Equal(t, first.StrategyID, event.StrategyID)
+	assert.Equal(t, 0.3, event.FinalMetrics["best_sharpe"])
+	assert.Equal(t, 7.0, event.FinalMetrics["backtest_count"])
+}
+
+func TestStrategyArchiver_DryRun(t *testing.T) {
+	cfg := testArchivalConfig()
+	cfg.DryRun = true
+	candidate := &domain.ArchivalCandidate{StrategyID: uuid.New(), Name: "Candidate", BacktestCount: 9}
+
+	archiver, repo, publisher := newTestArchiver(t, cfg, candidate)
+
+	candidates, err := archiver.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, candidates, 1)
+	assert.Empty(t, repo.archived)
+	assert.Empty(t, publisher.publishedEvents)
+}
+
+func TestStrategyArchiver_StartRejectsInvalidPolicy(t *testing.T) {
+	cfg := testArchivalConfig()
+	cfg.InactiveDays = 0
+
+	archiver, _, _ := newTestArchiver(t, cfg)
+	assert.Error(t, archiver.Start())
+}
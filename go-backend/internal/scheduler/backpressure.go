@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// backpressure tracks whether the Docker host is too loaded to start more
+// jobs. It throttles once CPU or memory use exceeds its maximum and lets go
+// only once both are below their resume thresholds, so dequeuing does not
+// flap around a single threshold.
+type backpressure struct {
+	config *config.BackpressureConfig
+
+	mu        sync.Mutex
+	throttled bool
+	reason    string
+	since     time.Time
+	usage     *domain.HostUsage // Latest sample; nil until the host was sampled
+}
+
+func newBackpressure(cfg *config.BackpressureConfig) *backpressure {
+	return &backpressure{config: cfg}
+}
+
+// update records a host usage sample and reports whether it started or
+// stopped throttling.
+func (b *backpressure) update(usage *domain.HostUsage, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.usage = usage
+
+	if !b.throttled {
+		var reason string
+		switch {
+		case usage.MemoryPercent > b.config.MaxMemoryPercent:
+			reason = fmt.Sprintf("memory at %.1f%% exceeds %.1f%%", usage.MemoryPercent, b.config.MaxMemoryPercent)
+		case usage.CPUPercent > b.config.MaxCPUPercent:
+			reason = fmt.Sprintf("CPU at %.1f%% exceeds %.1f%%", usage.CPUPercent, b.config.MaxCPUPercent)
+		default:
+			return false
+		}
+		b.throttled, b.reason, b.since = true, reason, now
+		return true
+	}
+
+	resumeCPU, resumeMemory := b.config.ResumeThresholds()
+	if usage.CPUPercent < resumeCPU && usage.MemoryPercent < resumeMemory {
+		b.throttled, b.reason, b.since = false, "", time.Time{}
+		return true
+	}
+	return false
+}
+
+// holding reports whether dequeuing is held back.
+func (b *backpressure) holding() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.throttled
+}
+
+// status reports whether dequeuing is held back and the latest sample.
+func (b *backpressure) status() *domain.BackpressureStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := &domain.BackpressureStatus{
+		Throttled: b.throttled,
+		Reason:    b.reason,
+	}
+	if b.throttled {
+		since := b.since
+		status.Since = &since
+	}
+	if b.usage != nil {
+		usage := *b.usage
+		status.Usage = &usage
+	}
+	return status
+}
+
+// watchHostLoad samples the Docker host's load until the scheduler stops,
+// holding back dequeuing while it is above the configured thresholds.
+func (s *Scheduler) watchHostLoad() {
+	defer s.wg.Done()
+
+	interval := s.config.Backpressure.Interval()
+	ticker := s.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.sampleHostLoad(interval)
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C():
+			s.sampleHostLoad(interval)
+		}
+	}
+}
+
+// sampleHostLoad takes one host usage sample. A failed sample leaves
+// dequeuing as it was.
+func (s *Scheduler) sampleHostLoad(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	usage, err := s.dockerManager.HostUsage(ctx)
+	if err != nil {
+		if s.ctx.Err() == nil {
+			s.logger.Warn("Failed to sample Docker host usage", zap.Error(err))
+		}
+		return
+	}
+
+	if !s.backpressure.update(usage, s.clock.Now()) {
+		return
+	}
+	status := s.backpressure.status()
+	if status.Throttled {
+		s.logger.Warn("Docker host loaded, holding back new jobs",
+			zap.String("reason", status.Reason),
+			zap.Float64("cpu_percent", usage.CPUPercent),
+			zap.Float64("memory_percent", usage.MemoryPercent),
+			zap.Int("containers", usage.Containers),
+		)
+	} else {
+		s.logger.Info("Docker host load dropped, dequeuing jobs again",
+			zap.Float64("cpu_percent", usage.CPUPercent),
+			zap.Float64("memory_percent", usage.MemoryPercent),
+			zap.Int("containers", usage.Containers),
+		)
+	}
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockLoadManager reports a settable host usage.
+type mockLoadManager struct {
+	docker.Manager
+	usage domain.HostUsage
+}
+
+func (m *mockLoadManager) HostUsage(ctx context.Context) (*domain.HostUsage, error) {
+	usage := m.usage
+	return &usage, nil
+}
+
+func TestBackpressure_Hysteresis(t *testing.T) {
+	b := newBackpressure(&config.BackpressureConfig{MaxCPUPercent: 90, MaxMemoryPercent: 80, ResumeMemoryPercent: 60})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, b.update(&domain.HostUsage{CPUPercent: 50, MemoryPercent: 79}, now))
+	assert.False(t, b.holding())
+
+	assert.True(t, b.update(&domain.HostUsage{CPUPercent: 50, MemoryPercent: 85}, now))
+	assert.True(t, b.holding())
+	status := b.status()
+	assert.Contains(t, status.Reason, "memory at 85.0%")
+	require.NotNil(t, status.Since)
+	assert.Equal(t, now, *status.Since)
+
+	// Below the maximum but not the resume threshold
+	assert.False(t, b.update(&domain.HostUsage{CPUPercent: 50, MemoryPercent: 70}, now.Add(time.Minute)))
+	assert.True(t, b.holding())
+
+	// CPU has to drop below its default resume threshold too
+	assert.False(t, b.update(&domain.HostUsage{CPUPercent: 85, MemoryPercent: 50}, now.Add(2*time.Minute)))
+	assert.True(t, b.holding())
+
+	assert.True(t, b.update(&domain.HostUsage{CPUPercent: 70, MemoryPercent: 50}, now.Add(3*time.Minute)))
+	assert.False(t, b.holding())
+	status = b.status()
+	assert.Empty(t, status.Reason)
+	assert.Nil(t, status.Since)
+	require.NotNil(t, status.Usage)
+	assert.Equal(t, 50.0, status.Usage.MemoryPercent)
+}
+
+func TestScheduler_BackpressureHoldsBackDequeuing(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager := &mockLoadManager{usage: domain.HostUsage{CPUPercent: 95, MemoryPercent: 40}}
+	cfg := &config.SchedulerConfig{
+		MaxConcurrentBacktests: 2,
+		Backpressure:           config.BackpressureConfig{Enabled: true, MaxCPUPercent: 90, MaxMemoryPercent: 85},
+	}
+	sched := NewScheduler(cfg, &repository.Repositories{BacktestJob: &mockRequeueRepository{}}, manager, nil, zaptest.NewLogger(t))
+	sched.SetClock(fake)
+
+	assert.Equal(t, &domain.BackpressureStatus{}, sched.Status().Backpressure)
+
+	sched.sampleHostLoad(time.Second)
+	status := sched.Status().Backpressure
+	require.NotNil(t, status)
+	assert.True(t, status.Throttled)
+	assert.Contains(t, status.Reason, "CPU")
+
+	// Dequeuing is skipped; the mock repository has no GetPendingJobs
+	sched.fetchAndDispatch()
+
+	manager.usage.CPUPercent = 30
+	sched.sampleHostLoad(time.Second)
+	assert.False(t, sched.Status().Backpressure.Throttled)
+}
+
+func TestScheduler_BackpressureDisabled(t *testing.T) {
+	sched := newStateScheduler(t, "", &mockRequeueRepository{}, &mockStopManager{}, clock.NewFake(time.Now()))
+	assert.Nil(t, sched.Status().Backpressure)
+}
@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// StrategyValidationItem is one strategy in a validation batch.
+type StrategyValidationItem struct {
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+// StrategyValidationOutcome is the validation result for one batch item.
+// Error is set when the item could not be validated at all; TimedOut is set
+// when the batch time budget ran out before the item finished.
+type StrategyValidationOutcome struct {
+	Index     int      `json:"index"`
+	Name      string   `json:"name"`
+	Valid     bool     `json:"valid"`
+	Errors    []string `json:"errors"`
+	Warnings  []string `json:"warnings"`
+	ClassName string   `json:"class_name,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	TimedOut  bool     `json:"timed_out,omitempty"`
+
+	UnresolvedImports []domain.UnresolvedImport `json:"unresolved_imports,omitempty"`
+	SafetyViolations  []domain.SafetyViolation  `json:"safety_violations,omitempty"`
+}
+
+// BatchValidationResult contains the per-item outcomes of a validation batch,
+// in the order the items were submitted.
+type BatchValidationResult struct {
+	Results    []*StrategyValidationOutcome `json:"results"`
+	ValidCount int                          `json:"valid_count"`
+	FailCount  int                          `json:"fail_count"`
+	TimedOut   int                          `json:"timed_out_count"`
+	DurationMs int64                        `json:"duration_ms"`
+}
+
+// MaxValidationBatch returns the maximum number of strategies accepted per batch.
+func (s *Scheduler) MaxValidationBatch() int {
+	if s.config.MaxValidationBatch <= 0 {
+		return 50
+	}
+	return s.config.MaxValidationBatch
+}
+
+// ValidateStrategies validates a batch of strategies concurrently against the
+// validation pool. The whole batch shares the ValidationBatchTimeout budget;
+// items that haven't finished when it runs out are reported as timed out
+// rather than failing the batch.
+func (s *Scheduler) ValidateStrategies(ctx context.Context, items []StrategyValidationItem) *BatchValidationResult {
+	start := s.clock.Now()
+	ctx, cancel := context.WithTimeout(ctx, s.config.ValidationBatchBudget())
+	defer cancel()
+
+	// Buffered so validations that finish after the budget don't block
+	done := make(chan *StrategyValidationOutcome, len(items))
+	for i, item := range items {
+		go func(i int, item StrategyValidationItem) {
+			done <- s.validateItem(ctx, i, item)
+		}(i, item)
+	}
+
+	results := make([]*StrategyValidationOutcome, len(items))
+collect:
+	for received := 0; received < len(items); received++ {
+		select {
+		case outcome := <-done:
+			results[outcome.Index] = outcome
+		case <-ctx.Done():
+			break collect
+		}
+	}
+
+	batch := &BatchValidationResult{Results: results}
+	for i, outcome := range results {
+		if outcome == nil {
+			outcome = &StrategyValidationOutcome{
+				Index:    i,
+				Name:     items[i].Name,
+				Error:    "validation time budget exceeded",
+				TimedOut: true,
+			}
+			results[i] = outcome
+		}
+		switch {
+		case outcome.TimedOut:
+			batch.TimedOut++
+		case outcome.Valid:
+			batch.ValidCount++
+		default:
+			batch.FailCount++
+		}
+	}
+	batch.DurationMs = s.clock.Now().Sub(start).Milliseconds()
+
+	s.logger.Info("Strategy batch validated",
+		zap.Int("items", len(items)),
+		zap.Int("valid", batch.ValidCount),
+		zap.Int("failed", batch.FailCount),
+		zap.Int("timed_out", batch.TimedOut),
+		zap.Int64("duration_ms", batch.DurationMs),
+	)
+
+	return batch
+}
+
+// validateItem validates a single batch item.
+func (s *Scheduler) validateItem(ctx context.Context, index int, item StrategyValidationItem) *StrategyValidationOutcome {
+	name := item.Name
+	if name == "" {
+		name = "ValidatedStrategy"
+	}
+	code := item.Code
+	if sanitized := domain.SanitizeStrategyName(name); sanitized != name {
+		// Keep the class in the code in sync, as strategy creation does
+		code = strings.Replace(code, "class "+name+"(", "class "+sanitized+"(", 1)
+		name = sanitized
+	}
+
+	outcome := &StrategyValidationOutcome{Index: index, Name: name}
+	if code == "" {
+		outcome.Error = "code is required"
+		return outcome
+	}
+
+	result, err := s.ValidateStrategy(ctx, code, name)
+	if err != nil {
+		if ctx.Err() != nil {
+			outcome.TimedOut = true
+		}
+		outcome.Error = err.Error()
+		return outcome
+	}
+
+	outcome.Valid = result.Valid
+	outcome.Errors = result.Errors
+	outcome.Warnings = result.Warnings
+	outcome.ClassName = result.ClassName
+	outcome.UnresolvedImports = result.UnresolvedImports
+	outcome.SafetyViolations = result.SafetyViolations
+	return outcome
+}
@@ -0,0 +1,244 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockValidatorManager implements ValidateStrategy of docker.Manager.
+// Other methods panic via the nil embedded interface.
+type mockValidatorManager struct {
+	docker.Manager
+	delay   time.Duration
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (m *mockValidatorManager) ValidateStrategy(ctx context.Context, params *docker.ValidateStrategyParams) (*docker.ValidationResult, error) {
+	n := m.running.Add(1)
+	defer m.running.Add(-1)
+	for {
+		peak := m.peak.Load()
+		if n <= peak || m.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	if strings.Contains(params.StrategyCode, "hang") {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	time.Sleep(m.delay)
+
+	if !strings.Contains(params.StrategyCode, "class "+params.StrategyName+"(") {
+		return &docker.ValidationResult{Valid: false, Errors: []string{"class not found"}}, nil
+	}
+	return &docker.ValidationResult{Valid: true, ClassName: params.StrategyName}, nil
+}
+
+func TestScheduler_ValidateStrategies(t *testing.T) {
+	manager := &mockValidatorManager{delay: 10 * time.Millisecond}
+	cfg := &config.SchedulerConfig{
+		MaxConcurrentBacktests: 1,
+		ValidationConcurrency:  2,
+		ValidationBatchTimeout: "5s",
+	}
+	sched := NewScheduler(cfg, nil, manager, nil, zaptest.NewLogger(t))
+
+	items := []StrategyValidationItem{
+		{Name: "Alpha", Code: "class Alpha(IStrategy): pass"},
+		{Name: "Beta", Code: "class Gamma(IStrategy): pass"},
+		{Name: "Empty"},
+		{Name: "Delta", Code: "class Delta(IStrategy): pass"},
+		{Name: "Epsilon", Code: "class Epsilon(IStrategy): pass"},
+	}
+	batch := sched.ValidateStrategies(context.Background(), items)
+
+	require.Len(t, batch.Results, len(items))
+	for i, outcome := range batch.Results {
+		assert.Equal(t, i, outcome.Index)
+	}
+	assert.True(t, batch.Results[0].Valid)
+	assert.False(t, batch.Results[1].Valid)
+	assert.Equal(t, []string{"class not found"}, batch.Results[1].Errors)
+	assert.Equal(t, "code is required", batch.Results[2].Error)
+	assert.Equal(t, 3, batch.ValidCount)
+	assert.Equal(t, 2, batch.FailCount)
+	assert.Zero(t, batch.TimedOut)
+
+	// The validation pool bounds concurrency
+	assert.LessOrEqual(t, manager.peak.Load(), int32(2))
+}
+
+func TestScheduler_ValidateStrategiesTimeBudget(t *testing.T) {
+	manager := &mockValidatorManager{}
+	cfg := &config.SchedulerConfig{
+		MaxConcurrentBacktests: 1,
+		ValidationConcurrency:  4,
+		ValidationBatchTimeout: "50ms",
+	}
+	sched := NewScheduler(cfg, nil, manager, nil, zaptest.NewLogger(t))
+
+	batch := sched.ValidateStrategies(context.Background(), []StrategyValidationItem{
+		{Name: "Fast", Code: "class Fast(IStrategy): pass"},
+		{Name: "Slow", Code: "class Slow(IStrategy): hang"},
+	})
+
+	require.Len(t, batch.Results, 2)
+	assert.True(t, batch.Results[0].Valid)
+	assert.True(t, batch.Results[1].TimedOut)
+	assert.Equal(t, 1, batch.ValidCount)
+	assert.Equal(t, 1, batch.TimedOut)
+}
+
+func TestScheduler_ValidateStrategyImports(t *testing.T) {
+	manager := &mockValidatorManager{}
+	cfg := &config.SchedulerConfig{
+		MaxConcurrentBacktests: 1,
+		ValidationConcurrency:  1,
+		AllowedImports:         []string{"mylib"},
+	}
+	sched := NewScheduler(cfg, nil, manager, nil, zaptest.NewLogger(t))
+
+	code := `"""
+Scraped from a repository; see example usage:
+from examples import demo
+"""
+import logging, numpy as np
+from functools import reduce  # stdlib
+import talib.abstract as ta
+from freqtrade.strategy import (IStrategy,
+    IntParameter)
+import mylib.helpers
+from custom_indicators import (
+    wavetrend,  # momentum
+    ssl_channels as ssl,
+)
+from .utils import *
+import qtpylib
+
+try:
+    import optional_dep
+except ImportError:
+    optional_dep = None
+
+class Imports(IStrategy): pass
+`
+	result, err := sched.ValidateStrategy(context.Background(), code, "Imports")
+	require.NoError(t, err)
+
+	// Unresolved imports fail the strategy without starting a container
+	assert.False(t, result.Valid)
+	assert.Zero(t, manager.peak.Load())
+
+	require.Len(t, result.UnresolvedImports, 4)
+	custom := result.UnresolvedImports[0]
+	assert.Equal(t, "custom_indicators", custom.Module)
+	assert.Equal(t, "custom_indicators", custom.Package)
+	assert.Equal(t, []string{"wavetrend", "ssl_channels"}, custom.Names)
+	assert.Equal(t, 11, custom.Line)
+	assert.Contains(t, custom.Fix, "inline wavetrend, ssl_channels from custom_indicators")
+
+	relative := result.UnresolvedImports[1]
+	assert.Equal(t, ".utils", relative.Module)
+	assert.Empty(t, relative.Package)
+	assert.Contains(t, relative.Fix, "inline the code used from .utils into the strategy file")
+
+	assert.Equal(t, "qtpylib", result.UnresolvedImports[2].Module)
+	assert.Contains(t, result.UnresolvedImports[2].Fix, "freqtrade.vendor.qtpylib")
+
+	optional := result.UnresolvedImports[3]
+	assert.Equal(t, "optional_dep", optional.Module)
+	assert.True(t, optional.Optional)
+
+	require.Len(t, result.Errors, 3)
+	assert.Equal(t, custom.Message(), result.Errors[0])
+	assert.Equal(t, []string{optional.Message()}, result.Warnings)
+
+	// Optional imports alone only warn, and the container still runs
+	result, err = sched.ValidateStrategy(context.Background(), "try:\n    import optional_dep\nexcept ImportError:\n    pass\n\nclass Imports(IStrategy): pass", "Imports")
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Len(t, result.Warnings, 1)
+	assert.Equal(t, int32(1), manager.peak.Load())
+}
+
+func TestScheduler_ValidateStrategySafety(t *testing.T) {
+	manager := &mockValidatorManager{}
+	cfg := &config.SchedulerConfig{
+		MaxConcurrentBacktests: 1,
+		ValidationConcurrency:  1,
+		CodeSafety:             config.CodeSafetyConfig{Enabled: true},
+	}
+	sched := NewScheduler(cfg, nil, manager, nil, zaptest.NewLogger(t))
+
+	code := `"""
+Never call eval("1") or import os here.
+"""
+import logging
+import os.path
+from subprocess import run
+from freqtrade.strategy import IStrategy
+
+class Unsafe(IStrategy):
+    def populate_indicators(self, dataframe, metadata):
+        dataframe["x"] = dataframe.eval("a + b")  # eval(...) of pandas
+        exec("print(1)")
+        with open("cache/notes.txt", "w") as f:
+            f.write("ok")
+        with open("/etc/cron.d/job", "a") as f:
+            f.write("x")
+        open(path, mode="w")
+        open("../../secrets.txt").read()
+        Path("/tmp/out.txt").write_text("x")
+        dataframe.to_csv(f"/tmp/{metadata['pair']}.csv")
+        dataframe.to_csv("user_data/frame.csv")
+        summary = dataframe.to_json()
+        return dataframe
+`
+	result, err := sched.ValidateStrategy(context.Background(), code, "Unsafe")
+	require.NoError(t, err)
+
+	// Unsafe code fails without starting a container
+	assert.False(t, result.Valid)
+	assert.Zero(t, manager.peak.Load())
+
+	assert.Equal(t, []domain.SafetyViolation{
+		{Kind: domain.SafetyViolationImport, Name: "os.path", Line: 5},
+		{Kind: domain.SafetyViolationImport, Name: "subprocess", Line: 6},
+		{Kind: domain.SafetyViolationCall, Name: "exec", Line: 12},
+		{Kind: domain.SafetyViolationFileWrite, Name: `open("/etc/cron.d/job", "a")`, Line: 15},
+		{Kind: domain.SafetyViolationFileWrite, Name: `open(path, mode="w")`, Line: 17},
+		{Kind: domain.SafetyViolationFileWrite, Name: `Path("/tmp/out.txt").write_text("x")`, Line: 19},
+		{Kind: domain.SafetyViolationFileWrite, Name: `dataframe.to_csv(f"/tmp/{metadata['pair']}.csv")`, Line: 20},
+	}, result.SafetyViolations)
+	require.Len(t, result.Errors, 7)
+	assert.Equal(t, `import of "os.path" is not allowed (line 5)`, result.Errors[0])
+
+	// A configured deny-list replaces the default one
+	cfg.CodeSafety.DeniedImports = []string{"numpy"}
+	sched = NewScheduler(cfg, nil, manager, nil, zaptest.NewLogger(t))
+	result, err = sched.ValidateStrategy(context.Background(), "import os\nimport numpy as np\n\nclass Deny(IStrategy): pass", "Deny")
+	require.NoError(t, err)
+	require.Len(t, result.SafetyViolations, 1)
+	assert.Equal(t, "numpy", result.SafetyViolations[0].Name)
+
+	// Disabled checks let the container decide
+	cfg.CodeSafety.Enabled = false
+	sched = NewScheduler(cfg, nil, manager, nil, zaptest.NewLogger(t))
+	result, err = sched.ValidateStrategy(context.Background(), "import os\n\nclass Allowed(IStrategy): pass", "Allowed")
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.SafetyViolations)
+}
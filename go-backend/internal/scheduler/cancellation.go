@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// defaultCancelGracePeriod is how long the container of a cancelled job has
+// to exit when no grace period is configured.
+const defaultCancelGracePeriod = 30 * time.Second
+
+// PartialLogName is the name of the artifact holding the output of a
+// cancelled job's container.
+const PartialLogName = "partial.log"
+
+// watchCancellations stops the containers of running jobs as they are
+// cancelled.
+func (s *Scheduler) watchCancellations() {
+	defer s.wg.Done()
+
+	ticker := s.clock.NewTicker(time.Duration(s.config.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C():
+			s.checkCancellations()
+		}
+	}
+}
+
+// checkCancellations starts stopping the container of each job running here
+// that has been cancelled. Cancelling only updates the job in the database,
+// so its container is found and stopped here: it is sent SIGTERM and given
+// the grace period to exit before it is killed. The worker then hands its
+// output to processResult, which stores it on the job.
+func (s *Scheduler) checkCancellations() {
+	containers := make(map[uuid.UUID]string)
+	s.stateMu.Lock()
+	for id, claim := range s.claims {
+		if claim.ContainerID != "" {
+			containers[id] = claim.ContainerID
+		}
+	}
+	s.stateMu.Unlock()
+
+	// Only jobs with a container running now; jobs waiting to be retried
+	// have none
+	var ids []uuid.UUID
+	for id := range containers {
+		if running, ok := s.activeJobs.Load(id); ok && !running.(*RunningJob).cancelling.Load() {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	cancelled, err := s.repos.BacktestJob.GetCancelledIDs(s.ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to check for cancelled jobs", zap.Error(err))
+		return
+	}
+
+	for _, id := range cancelled {
+		running, ok := s.activeJobs.Load(id)
+		if !ok || !running.(*RunningJob).cancelling.CompareAndSwap(false, true) {
+			continue
+		}
+		go s.terminate(id, containers[id])
+	}
+}
+
+// terminate stops the container of a cancelled job, killing it if it has not
+// exited within the grace period.
+func (s *Scheduler) terminate(jobID uuid.UUID, containerID string) {
+	grace := s.cancelGracePeriod()
+	s.logger.Info("Stopping container of cancelled job",
+		zap.String("job_id", jobID.String()),
+		zap.String("container_id", containerID),
+		zap.Duration("grace_period", grace),
+	)
+
+	// The container is stopped even if the scheduler is stopping meanwhile
+	if err := s.dockerManager.TerminateContainer(context.Background(), containerID, grace); err != nil {
+		s.logger.Error("Failed to stop container of cancelled job",
+			zap.String("job_id", jobID.String()),
+			zap.String("container_id", containerID),
+			zap.Error(err),
+		)
+	}
+}
+
+// cancelGracePeriod returns how long the container of a cancelled job has to
+// exit after SIGTERM.
+func (s *Scheduler) cancelGracePeriod() time.Duration {
+	if s.config.CancelGracePeriod != "" {
+		if parsed, err := time.ParseDuration(s.config.CancelGracePeriod); err == nil {
+			return parsed
+		}
+	}
+	return defaultCancelGracePeriod
+}
+
+// storePartialLog stores the output of a cancelled job's container as an
+// artifact of the job. The job itself stays cancelled. Output beyond the
+// artifact size limit is cut from the start, keeping its latest lines.
+func (s *Scheduler) storePartialLog(result *JobResult) {
+	job := result.Job
+	if result.Logs == "" || s.repos.Artifact == nil {
+		return
+	}
+
+	logs := result.Logs
+	if len(logs) > domain.MaxArtifactSize {
+		logs = logs[len(logs)-domain.MaxArtifactSize:]
+	}
+
+	artifact := domain.NewArtifact(domain.ArtifactOwnerJob, job.ID, domain.ArtifactKindLog,
+		PartialLogName, "text/plain; charset=utf-8", []byte(logs))
+	if job.ContainerID != nil {
+		artifact.Metadata = map[string]interface{}{"container_id": *job.ContainerID}
+	}
+	if err := s.repos.Artifact.Create(s.ctx, artifact); err != nil {
+		s.logger.Error("Failed to store partial log of cancelled job",
+			zap.String("job_id", job.ID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Info("Stored partial log of cancelled job",
+		zap.String("job_id", job.ID.String()),
+		zap.Int64("size_bytes", artifact.SizeBytes),
+	)
+}
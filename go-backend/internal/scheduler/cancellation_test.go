@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockCancelledRepository reports a fixed set of jobs as cancelled.
+type mockCancelledRepository struct {
+	repository.BacktestJobRepository
+	cancelled map[uuid.UUID]bool
+	asked     [][]uuid.UUID
+}
+
+func (m *mockCancelledRepository) GetCancelledIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	m.asked = append(m.asked, ids)
+	var cancelled []uuid.UUID
+	for _, id := range ids {
+		if m.cancelled[id] {
+			cancelled = append(cancelled, id)
+		}
+	}
+	return cancelled, nil
+}
+
+// mockTerminateManager reports the containers terminated.
+type mockTerminateManager struct {
+	docker.Manager
+	terminated chan string
+	grace      time.Duration
+}
+
+func (m *mockTerminateManager) TerminateContainer(ctx context.Context, containerID string, grace time.Duration) error {
+	m.grace = grace
+	m.terminated <- containerID
+	return nil
+}
+
+// mockArtifactStore records the artifacts created.
+type mockArtifactStore struct {
+	repository.ArtifactRepository
+	created []*domain.Artifact
+}
+
+func (m *mockArtifactStore) Create(ctx context.Context, artifact *domain.Artifact) error {
+	m.created = append(m.created, artifact)
+	return nil
+}
+
+func TestScheduler_CheckCancellations(t *testing.T) {
+	running, cancelled, waiting := uuid.New(), uuid.New(), uuid.New()
+	repo := &mockCancelledRepository{cancelled: map[uuid.UUID]bool{cancelled: true, waiting: true}}
+	manager := &mockTerminateManager{terminated: make(chan string, 3)}
+	cfg := &config.SchedulerConfig{MaxConcurrentBacktests: 2, CancelGracePeriod: "5s"}
+	sched := NewScheduler(cfg, &repository.Repositories{BacktestJob: repo}, manager, nil, zaptest.NewLogger(t))
+
+	now := time.Now()
+	for i, id := range []uuid.UUID{running, cancelled, waiting} {
+		sched.claim(id, now)
+		sched.setClaimContainer(id, []string{"container-1", "container-2", "container-3"}[i], "")
+	}
+	// The job waiting to be retried has no container running
+	sched.activeJobs.Store(running, &RunningJob{Job: &domain.BacktestJob{ID: running}})
+	sched.activeJobs.Store(cancelled, &RunningJob{Job: &domain.BacktestJob{ID: cancelled}})
+
+	sched.checkCancellations()
+	select {
+	case containerID := <-manager.terminated:
+		assert.Equal(t, "container-2", containerID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("container of cancelled job was not terminated")
+	}
+	assert.Equal(t, 5*time.Second, manager.grace)
+	require.Len(t, repo.asked, 1)
+	assert.ElementsMatch(t, []uuid.UUID{running, cancelled}, repo.asked[0])
+
+	// A job whose container is being stopped is not checked again
+	sched.checkCancellations()
+	require.Len(t, repo.asked, 2)
+	assert.Equal(t, []uuid.UUID{running}, repo.asked[1])
+	assert.Empty(t, manager.terminated)
+}
+
+func TestScheduler_ProcessResult_Cancelled(t *testing.T) {
+	artifacts := &mockArtifactStore{}
+	// Cancelled jobs are neither completed nor failed, so the job repository
+	// is not used
+	sched := NewScheduler(&config.SchedulerConfig{MaxConcurrentBacktests: 1},
+		&repository.Repositories{Artifact: artifacts}, nil, nil, zaptest.NewLogger(t))
+
+	containerID := "container-1"
+	job := &domain.BacktestJob{ID: uuid.New(), Status: domain.JobStatusCancelled, ContainerID: &containerID}
+	sched.claim(job.ID, time.Now())
+
+	sched.processResult(&JobResult{Job: job, Cancelled: true, Logs: "Loading data...\nTerminated"})
+	require.Len(t, artifacts.created, 1)
+	artifact := artifacts.created[0]
+	assert.Equal(t, domain.ArtifactOwnerJob, artifact.OwnerType)
+	assert.Equal(t, job.ID, artifact.OwnerID)
+	assert.Equal(t, domain.ArtifactKindLog, artifact.Kind)
+	assert.Equal(t, "Loading data...\nTerminated", string(artifact.Content))
+	assert.Equal(t, containerID, artifact.Metadata["container_id"])
+	assert.Empty(t, sched.State().Claimed)
+
+	// Without output there is nothing to store
+	sched.processResult(&JobResult{Job: job, Cancelled: true})
+	assert.Len(t, artifacts.created, 1)
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// DefaultCapacityTrials is the number of Monte Carlo trials per capacity scenario.
+const DefaultCapacityTrials = 200
+
+// CapacityInput describes the queue and runtime distribution to simulate.
+type CapacityInput struct {
+	// QueueLength is the number of pending jobs to drain.
+	QueueLength int
+	// RunningJobs is the number of jobs already occupying workers.
+	RunningJobs int
+	// Runtimes is the historical runtime distribution sampled for each job.
+	Runtimes []time.Duration
+	// Trials is the number of simulation trials (defaults to DefaultCapacityTrials).
+	Trials int
+	// Seed seeds the sampler so repeated calls are reproducible.
+	Seed int64
+}
+
+// CapacityScenario is the simulated drain time for a hypothetical worker count.
+type CapacityScenario struct {
+	Workers     int   `json:"workers"`
+	DrainMeanMs int64 `json:"drain_mean_ms"`
+	DrainP50Ms  int64 `json:"drain_p50_ms"`
+	DrainP90Ms  int64 `json:"drain_p90_ms"`
+	DrainMaxMs  int64 `json:"drain_max_ms"`
+}
+
+// SimulateCapacity estimates how long the queue takes to drain for each worker count.
+// Each trial assigns jobs (with runtimes sampled from the historical distribution)
+// to the earliest-free worker, which mirrors how the scheduler dispatches work.
+// Running jobs are modelled as occupying a worker for half a sampled runtime.
+func SimulateCapacity(input CapacityInput, workerCounts []int) []CapacityScenario {
+	trials := input.Trials
+	if trials <= 0 {
+		trials = DefaultCapacityTrials
+	}
+
+	scenarios := make([]CapacityScenario, 0, len(workerCounts))
+	for _, workers := range workerCounts {
+		if workers <= 0 {
+			continue
+		}
+
+		rng := rand.New(rand.NewSource(input.Seed))
+		drains := make([]time.Duration, trials)
+		for t := 0; t < trials; t++ {
+			drains[t] = simulateDrain(rng, input, workers)
+		}
+
+		sort.Slice(drains, func(i, j int) bool { return drains[i] < drains[j] })
+
+		var total time.Duration
+		for _, d := range drains {
+			total += d
+		}
+
+		scenarios = append(scenarios, CapacityScenario{
+			Workers:     workers,
+			DrainMeanMs: (total / time.Duration(trials)).Milliseconds(),
+			DrainP50Ms:  percentileDuration(drains, 0.50).Milliseconds(),
+			DrainP90Ms:  percentileDuration(drains, 0.90).Milliseconds(),
+			DrainMaxMs:  drains[len(drains)-1].Milliseconds(),
+		})
+	}
+
+	return scenarios
+}
+
+// simulateDrain runs a single trial and returns the time until all workers are idle.
+func simulateDrain(rng *rand.Rand, input CapacityInput, workers int) time.Duration {
+	if len(input.Runtimes) == 0 {
+		return 0
+	}
+
+	sample := func() time.Duration {
+		return input.Runtimes[rng.Intn(len(input.Runtimes))]
+	}
+
+	freeAt := make([]time.Duration, workers)
+	for i := 0; i < input.RunningJobs && i < workers; i++ {
+		freeAt[i] = sample() / 2
+	}
+
+	for j := 0; j < input.QueueLength; j++ {
+		// Assign to the earliest-free worker
+		next := 0
+		for i := 1; i < workers; i++ {
+			if freeAt[i] < freeAt[next] {
+				next = i
+			}
+		}
+		freeAt[next] += sample()
+	}
+
+	var drain time.Duration
+	for _, t := range freeAt {
+		if t > drain {
+			drain = t
+		}
+	}
+	return drain
+}
+
+// percentileDuration returns the p-th percentile of sorted durations.
+func percentileDuration(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx]
+}
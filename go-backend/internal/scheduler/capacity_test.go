@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateCapacity_ConstantRuntime(t *testing.T) {
+	input := CapacityInput{
+		QueueLength: 10,
+		Runtimes:    []time.Duration{10 * time.Minute},
+	}
+
+	scenarios := SimulateCapacity(input, []int{1, 2, 5, 20})
+	require.Len(t, scenarios, 4)
+
+	// With a constant 10m runtime the drain time is ceil(queue/workers) * 10m.
+	assert.Equal(t, int64(6000000), scenarios[0].DrainP50Ms)
+	assert.Equal(t, int64(3000000), scenarios[1].DrainP50Ms)
+	assert.Equal(t, int64(1200000), scenarios[2].DrainP50Ms)
+	assert.Equal(t, int64(600000), scenarios[3].DrainP50Ms)
+
+	for _, s := range scenarios {
+		assert.Equal(t, s.DrainP50Ms, s.DrainMaxMs)
+		assert.Equal(t, s.DrainP50Ms, s.DrainMeanMs)
+	}
+}
+
+func TestSimulateCapacity_RunningJobsOccupyWorkers(t *testing.T) {
+	input := CapacityInput{
+		QueueLength: 2,
+		RunningJobs: 2,
+		Runtimes:    []time.Duration{10 * time.Minute},
+	}
+
+	scenarios := SimulateCapacity(input, []int{2})
+	require.Len(t, scenarios, 1)
+	assert.Equal(t, int64(900000), scenarios[0].DrainP50Ms)
+}
+
+func TestSimulateCapacity_Deterministic(t *testing.T) {
+	input := CapacityInput{
+		QueueLength: 50,
+		Runtimes:    []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute},
+		Seed:        42,
+	}
+
+	first := SimulateCapacity(input, []int{4})
+	second := SimulateCapacity(input, []int{4})
+	assert.Equal(t, first, second)
+	assert.LessOrEqual(t, first[0].DrainP50Ms, first[0].DrainP90Ms)
+	assert.LessOrEqual(t, first[0].DrainP90Ms, first[0].DrainMaxMs)
+}
+
+func TestSimulateCapacity_SkipsInvalidWorkerCounts(t *testing.T) {
+	input := CapacityInput{QueueLength: 1, Runtimes: []time.Duration{time.Minute}}
+	assert.Empty(t, SimulateCapacity(input, []int{0, -1}))
+}
@@ -0,0 +1,324 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// dependencyProbeTimeout bounds a single dependency probe.
+const dependencyProbeTimeout = 5 * time.Second
+
+// DependencyProbe checks whether an infrastructure dependency is reachable.
+type DependencyProbe struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// DependencyWatchdog probes infrastructure dependencies and pauses running
+// optimizations while any of them is down, so long searches don't burn
+// iterations on failing backtests. Once every dependency has recovered, the
+// runs it paused are resumed and the outage is recorded on each run. Each
+// outage is also recorded as an incident, closed on recovery.
+type DependencyWatchdog struct {
+	repos          *repository.Repositories
+	eventPublisher events.Publisher
+	config         *config.AutoPauseConfig
+	probes         []DependencyProbe
+	clock          clock.Clock
+	logger         *zap.Logger
+
+	interval time.Duration
+
+	mu            sync.Mutex
+	failures      map[string]int // Consecutive failed probes per dependency
+	healthy       int            // Consecutive checks with every dependency healthy
+	inOutage      bool           // Runs were paused for a dependency outage
+	resumePending bool           // Auto-paused runs may be waiting to be resumed
+	outageFor     []string
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDependencyWatchdog creates a new dependency watchdog.
+// Runs auto-paused before a restart are resumed once the dependencies are healthy.
+func NewDependencyWatchdog(
+	cfg *config.AutoPauseConfig,
+	repos *repository.Repositories,
+	publisher events.Publisher,
+	probes []DependencyProbe,
+	logger *zap.Logger,
+) *DependencyWatchdog {
+	interval, err := time.ParseDuration(cfg.CheckInterval)
+	if err != nil || interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	return &DependencyWatchdog{
+		repos:          repos,
+		eventPublisher: publisher,
+		config:         cfg,
+		probes:         probes,
+		clock:          clock.Real(),
+		logger:         logger,
+		interval:       interval,
+		failures:       make(map[string]int),
+		resumePending:  true,
+	}
+}
+
+// SetClock replaces the watchdog's time source. It must be called before Start.
+func (w *DependencyWatchdog) SetClock(c clock.Clock) {
+	w.clock = c
+}
+
+// Start starts probing dependencies periodically.
+func (w *DependencyWatchdog) Start() error {
+	names := make([]string, len(w.probes))
+	for i, p := range w.probes {
+		names[i] = p.Name
+	}
+	w.logger.Info("Starting dependency watchdog",
+		zap.Duration("interval", w.interval),
+		zap.Strings("dependencies", names),
+	)
+
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.ticker = w.clock.NewTicker(w.interval)
+	w.wg.Add(1)
+	go w.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the watchdog.
+func (w *DependencyWatchdog) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+	w.wg.Wait()
+
+	w.logger.Info("Dependency watchdog stopped")
+	return nil
+}
+
+// loop runs a check on every tick.
+func (w *DependencyWatchdog) loop() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-w.ticker.C():
+			if err := w.Check(w.ctx); err != nil {
+				w.logger.Error("Dependency check failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// InOutage reports whether optimizations are paused for a dependency outage.
+func (w *DependencyWatchdog) InOutage() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.inOutage
+}
+
+// Check probes every dependency once. It pauses running optimizations when a
+// dependency has failed FailureThreshold probes in a row, and resumes the runs
+// it paused after RecoveryThreshold consecutive checks with every dependency healthy.
+func (w *DependencyWatchdog) Check(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var down []string
+	for _, probe := range w.probes {
+		probeCtx, cancel := context.WithTimeout(ctx, dependencyProbeTimeout)
+		err := probe.Check(probeCtx)
+		cancel()
+
+		if err == nil {
+			w.failures[probe.Name] = 0
+			continue
+		}
+		w.failures[probe.Name]++
+		if w.failures[probe.Name] >= w.config.FailureThreshold {
+			down = append(down, probe.Name)
+		}
+		w.logger.Debug("Dependency probe failed",
+			zap.String("dependency", probe.Name),
+			zap.Int("consecutive_failures", w.failures[probe.Name]),
+			zap.Error(err),
+		)
+	}
+
+	allHealthy := true
+	for _, n := range w.failures {
+		if n > 0 {
+			allHealthy = false
+			break
+		}
+	}
+
+	if len(down) > 0 {
+		w.healthy = 0
+		if w.inOutage {
+			return nil
+		}
+		sort.Strings(down)
+		return w.pause(ctx, down)
+	}
+
+	if !allHealthy {
+		w.healthy = 0
+		return nil
+	}
+
+	w.healthy++
+	if w.resumePending && w.healthy >= w.config.RecoveryThreshold {
+		return w.resume(ctx)
+	}
+	return nil
+}
+
+// pause auto-pauses all running optimizations. The caller must hold w.mu.
+func (w *DependencyWatchdog) pause(ctx context.Context, down []string) error {
+	runs, err := w.repos.Optimization.AutoPause(ctx, down, w.clock.Now())
+	if err != nil {
+		return fmt.Errorf("failed to auto-pause optimizations: %w", err)
+	}
+
+	w.inOutage = true
+	w.resumePending = true
+	w.outageFor = down
+
+	w.logger.Warn("Dependency outage detected, paused running optimizations",
+		zap.Strings("dependencies", down),
+		zap.Int("paused_runs", len(runs)),
+	)
+	w.openIncident(ctx, down, len(runs))
+
+	// RabbitMQ may be the dependency that is down, in which case these are best effort
+	for _, run := range runs {
+		w.publishStatusChanged(run, domain.OptimizationStatusRunning, domain.OptimizationStatusPaused)
+	}
+
+	return nil
+}
+
+// resume resumes the optimizations paused during the outage. The caller must hold w.mu.
+func (w *DependencyWatchdog) resume(ctx context.Context) error {
+	runs, err := w.repos.Optimization.AutoResume(ctx, w.clock.Now())
+	if err != nil {
+		return fmt.Errorf("failed to auto-resume optimizations: %w", err)
+	}
+
+	if len(runs) > 0 || w.outageFor != nil {
+		w.logger.Info("Dependencies recovered, resumed auto-paused optimizations",
+			zap.Strings("dependencies", w.outageFor),
+			zap.Int("resumed_runs", len(runs)),
+		)
+	}
+
+	w.closeIncidents(ctx, len(runs))
+
+	w.inOutage = false
+	w.resumePending = false
+	w.outageFor = nil
+
+	for _, run := range runs {
+		w.publishStatusChanged(run, domain.OptimizationStatusPaused, domain.OptimizationStatusRunning)
+
+		// Re-announce the run so the orchestrator picks it up again
+		if w.eventPublisher != nil {
+			if err := w.eventPublisher.PublishOptimizationStarted(run); err != nil {
+				w.logger.Warn("Failed to publish optimization resume event",
+					zap.String("run_id", run.ID.String()),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// openIncident records an outage as an incident, or as a note on the open
+// incident of an outage that began before a restart. Failures are logged:
+// the database may be what is down.
+func (w *DependencyWatchdog) openIncident(ctx context.Context, down []string, pausedRuns int) {
+	if w.repos.Incident == nil {
+		return
+	}
+
+	body := fmt.Sprintf("%s down; paused %d running optimizations.", strings.Join(down, ", "), pausedRuns)
+	open := domain.IncidentStatusOpen
+	existing, err := w.repos.Incident.List(ctx, domain.IncidentListQuery{
+		Status: &open,
+		Source: domain.IncidentSourceWatchdog,
+		Limit:  1,
+	})
+	if err == nil {
+		if len(existing) > 0 {
+			err = w.repos.Incident.AddNote(ctx, domain.NewIncidentNote(existing[0].ID, domain.IncidentSourceWatchdog, body))
+		} else {
+			incident := domain.NewIncident("Dependency outage: "+strings.Join(down, ", "),
+				domain.IncidentSeverityCritical, domain.IncidentSourceWatchdog, domain.IncidentSourceWatchdog)
+			incident.Notes = []domain.IncidentNote{*domain.NewIncidentNote(incident.ID, domain.IncidentSourceWatchdog, body)}
+			err = w.repos.Incident.Create(ctx, incident)
+		}
+	}
+	if err != nil {
+		w.logger.Warn("Failed to record dependency outage incident", zap.Error(err))
+	}
+}
+
+// closeIncidents closes the incidents of outages that have ended, including
+// ones left open by a restart.
+func (w *DependencyWatchdog) closeIncidents(ctx context.Context, resumedRuns int) {
+	if w.repos.Incident == nil {
+		return
+	}
+
+	body := fmt.Sprintf("Dependencies recovered; resumed %d optimizations.", resumedRuns)
+	closed, err := w.repos.Incident.CloseBySource(ctx, domain.IncidentSourceWatchdog, domain.IncidentSourceWatchdog, body)
+	if err != nil {
+		w.logger.Warn("Failed to close dependency outage incidents", zap.Error(err))
+		return
+	}
+	if closed > 0 {
+		w.logger.Info("Closed dependency outage incidents", zap.Int("incidents", closed))
+	}
+}
+
+// publishStatusChanged publishes an optimization status change, logging failures.
+func (w *DependencyWatchdog) publishStatusChanged(run *domain.OptimizationRun, oldStatus, newStatus domain.OptimizationStatus) {
+	if w.eventPublisher == nil {
+		return
+	}
+	if err := w.eventPublisher.PublishOptimizationStatusChanged(run, oldStatus.String(), newStatus.String()); err != nil {
+		w.logger.Warn("Failed to publish optimization status change",
+			zap.String("run_id", run.ID.String()),
+			zap.String("new_status", newStatus.String()),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,208 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockAutoPauseRepository implements AutoPause and AutoResume of OptimizationRepository.
+// Other methods panic via the nil embedded interface.
+type mockAutoPauseRepository struct {
+	repository.OptimizationRepository
+	running     []*domain.OptimizationRun
+	autoPaused  []*domain.OptimizationRun
+	pausedFor   []string
+	resumeCalls int
+}
+
+func (m *mockAutoPauseRepository) AutoPause(ctx context.Context, dependencies []string, at time.Time) ([]*domain.OptimizationRun, error) {
+	m.pausedFor = dependencies
+	paused := m.running
+	m.autoPaused = append(m.autoPaused, paused...)
+	m.running = nil
+	return paused, nil
+}
+
+func (m *mockAutoPauseRepository) AutoResume(ctx context.Context, at time.Time) ([]*domain.OptimizationRun, error) {
+	m.resumeCalls++
+	resumed := m.autoPaused
+	m.running = append(m.running, resumed...)
+	m.autoPaused = nil
+	return resumed, nil
+}
+
+// mockIncidentRepository records the incidents the watchdog opens and closes.
+type mockIncidentRepository struct {
+	repository.IncidentRepository
+	incidents []*domain.Incident
+	notes     []*domain.IncidentNote
+	closed    int
+}
+
+func (m *mockIncidentRepository) List(ctx context.Context, query domain.IncidentListQuery) ([]*domain.Incident, error) {
+	var open []*domain.Incident
+	for _, incident := range m.incidents {
+		if incident.Status == domain.IncidentStatusOpen {
+			open = append(open, incident)
+		}
+	}
+	return open, nil
+}
+
+func (m *mockIncidentRepository) Create(ctx context.Context, incident *domain.Incident) error {
+	m.incidents = append(m.incidents, incident)
+	return nil
+}
+
+func (m *mockIncidentRepository) AddNote(ctx context.Context, note *domain.IncidentNote) error {
+	m.notes = append(m.notes, note)
+	return nil
+}
+
+func (m *mockIncidentRepository) CloseBySource(ctx context.Context, source, by, note string) (int, error) {
+	closed := 0
+	for _, incident := range m.incidents {
+		if incident.Status == domain.IncidentStatusOpen && incident.Source == source {
+			incident.Status = domain.IncidentStatusClosed
+			closed++
+		}
+	}
+	m.closed += closed
+	return closed, nil
+}
+
+// recordingPublisher records optimization lifecycle events.
+type recordingPublisher struct {
+	*mockEventPublisher
+	statusChanges []string
+	started       []uuid.UUID
+}
+
+func (p *recordingPublisher) PublishOptimizationStatusChanged(run *domain.OptimizationRun, oldStatus, newStatus string) error {
+	p.statusChanges = append(p.statusChanges, oldStatus+"->"+newStatus)
+	return nil
+}
+
+func (p *recordingPublisher) PublishOptimizationStarted(run *domain.OptimizationRun) error {
+	p.started = append(p.started, run.ID)
+	return nil
+}
+
+func TestDependencyWatchdog_PausesAndResumes(t *testing.T) {
+	ctx := context.Background()
+	run := &domain.OptimizationRun{ID: uuid.New(), Status: domain.OptimizationStatusRunning}
+	repo := &mockAutoPauseRepository{running: []*domain.OptimizationRun{run}}
+	publisher := &recordingPublisher{mockEventPublisher: newMockEventPublisher()}
+
+	var dockerErr error
+	probes := []DependencyProbe{
+		{Name: "docker", Check: func(ctx context.Context) error { return dockerErr }},
+		{Name: "rabbitmq", Check: func(ctx context.Context) error { return nil }},
+	}
+	incidents := &mockIncidentRepository{}
+	cfg := &config.AutoPauseConfig{Enabled: true, CheckInterval: "1s", FailureThreshold: 2, RecoveryThreshold: 2}
+	repos := &repository.Repositories{Optimization: repo, Incident: incidents}
+	watchdog := NewDependencyWatchdog(cfg, repos, publisher, probes, zaptest.NewLogger(t))
+
+	// Healthy at startup: leftovers from a previous outage are resumed once
+	require.NoError(t, watchdog.Check(ctx))
+	require.NoError(t, watchdog.Check(ctx))
+	assert.Equal(t, 1, repo.resumeCalls)
+	require.NoError(t, watchdog.Check(ctx))
+	assert.Equal(t, 1, repo.resumeCalls)
+
+	// A single failed probe is tolerated
+	dockerErr = errors.New("daemon unreachable")
+	require.NoError(t, watchdog.Check(ctx))
+	assert.False(t, watchdog.InOutage())
+	assert.Len(t, repo.running, 1)
+
+	// The second one pauses the running optimizations
+	require.NoError(t, watchdog.Check(ctx))
+	assert.True(t, watchdog.InOutage())
+	assert.Equal(t, []string{"docker"}, repo.pausedFor)
+	assert.Empty(t, repo.running)
+	assert.Equal(t, []string{"running->paused"}, publisher.statusChanges)
+	require.Len(t, incidents.incidents, 1)
+	incident := incidents.incidents[0]
+	assert.Equal(t, "Dependency outage: docker", incident.Title)
+	assert.Equal(t, domain.IncidentSeverityCritical, incident.Severity)
+	assert.Equal(t, domain.IncidentSourceWatchdog, incident.Source)
+	require.Len(t, incident.Notes, 1)
+	assert.Equal(t, "docker down; paused 1 running optimizations.", incident.Notes[0].Body)
+
+	// Staying down doesn't pause again
+	require.NoError(t, watchdog.Check(ctx))
+	assert.Len(t, repo.autoPaused, 1)
+
+	// Recovery needs RecoveryThreshold healthy checks
+	dockerErr = nil
+	require.NoError(t, watchdog.Check(ctx))
+	assert.True(t, watchdog.InOutage())
+	require.NoError(t, watchdog.Check(ctx))
+	assert.False(t, watchdog.InOutage())
+	assert.Len(t, repo.running, 1)
+	assert.Equal(t, []string{"running->paused", "paused->running"}, publisher.statusChanges)
+	assert.Equal(t, []uuid.UUID{run.ID}, publisher.started)
+	assert.Equal(t, domain.IncidentStatusClosed, incident.Status)
+	assert.Equal(t, 1, incidents.closed)
+}
+
+func TestDependencyWatchdog_OutageIncidentAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockAutoPauseRepository{}
+	// An incident left open by an outage that began before a restart
+	open := domain.NewIncident("Dependency outage: rabbitmq", domain.IncidentSeverityCritical,
+		domain.IncidentSourceWatchdog, domain.IncidentSourceWatchdog)
+	incidents := &mockIncidentRepository{incidents: []*domain.Incident{open}}
+
+	probes := []DependencyProbe{
+		{Name: "rabbitmq", Check: func(ctx context.Context) error { return errors.New("connection closed") }},
+	}
+	cfg := &config.AutoPauseConfig{Enabled: true, CheckInterval: "1s", FailureThreshold: 1, RecoveryThreshold: 1}
+	repos := &repository.Repositories{Optimization: repo, Incident: incidents}
+	watchdog := NewDependencyWatchdog(cfg, repos, nil, probes, zaptest.NewLogger(t))
+
+	// Still down: the open incident is noted rather than a new one opened
+	require.NoError(t, watchdog.Check(ctx))
+	assert.Len(t, incidents.incidents, 1)
+	require.Len(t, incidents.notes, 1)
+	assert.Equal(t, open.ID, incidents.notes[0].IncidentID)
+}
+
+func TestDependencyWatchdog_FlappingDoesNotResume(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockAutoPauseRepository{running: []*domain.OptimizationRun{{ID: uuid.New()}}}
+	publisher := &recordingPublisher{mockEventPublisher: newMockEventPublisher()}
+
+	var rabbitErr error = errors.New("connection closed")
+	probes := []DependencyProbe{
+		{Name: "rabbitmq", Check: func(ctx context.Context) error { return rabbitErr }},
+	}
+	cfg := &config.AutoPauseConfig{Enabled: true, CheckInterval: "1s", FailureThreshold: 1, RecoveryThreshold: 2}
+	watchdog := NewDependencyWatchdog(cfg, &repository.Repositories{Optimization: repo}, publisher, probes, zaptest.NewLogger(t))
+
+	require.NoError(t, watchdog.Check(ctx))
+	assert.True(t, watchdog.InOutage())
+
+	rabbitErr = nil
+	require.NoError(t, watchdog.Check(ctx))
+	rabbitErr = errors.New("connection closed")
+	require.NoError(t, watchdog.Check(ctx))
+	rabbitErr = nil
+	require.NoError(t, watchdog.Check(ctx))
+
+	assert.True(t, watchdog.InOutage())
+	assert.Zero(t, repo.resumeCalls)
+}
@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// DescriptionQueue periodically asks the Analyst Agent to describe strategies
+// lacking a description. Each pass claims a batch of strategies and publishes
+// them in one strategy.needs_description event, within an hourly budget.
+// Requests that go unanswered are repeated after the retry period.
+type DescriptionQueue struct {
+	repos          *repository.Repositories
+	eventPublisher events.Publisher
+	config         *config.DescriptionsConfig
+	clock          clock.Clock
+	logger         *zap.Logger
+
+	interval   time.Duration
+	retryAfter time.Duration
+
+	mu        sync.Mutex  // serializes passes and guards requested
+	requested []time.Time // when each strategy of the last hour was requested
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDescriptionQueue creates a new description queue.
+func NewDescriptionQueue(
+	cfg *config.DescriptionsConfig,
+	repos *repository.Repositories,
+	publisher events.Publisher,
+	logger *zap.Logger,
+) *DescriptionQueue {
+	interval, err := time.ParseDuration(cfg.Interval)
+	if err != nil || interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	retryAfter, err := time.ParseDuration(cfg.RetryAfter)
+	if err != nil || retryAfter <= 0 {
+		retryAfter = 24 * time.Hour
+	}
+
+	return &DescriptionQueue{
+		repos:          repos,
+		eventPublisher: publisher,
+		config:         cfg,
+		clock:          clock.Real(),
+		logger:         logger,
+		interval:       interval,
+		retryAfter:     retryAfter,
+	}
+}
+
+// SetClock replaces the queue's time source. It must be called before Start.
+func (q *DescriptionQueue) SetClock(c clock.Clock) {
+	q.clock = c
+}
+
+// Start starts requesting descriptions periodically.
+func (q *DescriptionQueue) Start() error {
+	if q.eventPublisher == nil {
+		return errors.New("description queue requires an event publisher")
+	}
+
+	q.logger.Info("Starting description queue",
+		zap.Duration("interval", q.interval),
+		zap.Int("batch_size", q.config.BatchSize),
+		zap.Int("max_per_hour", q.config.MaxPerHour),
+		zap.Duration("retry_after", q.retryAfter),
+	)
+
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	q.ticker = q.clock.NewTicker(q.interval)
+	q.wg.Add(1)
+	go q.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the queue.
+func (q *DescriptionQueue) Stop() error {
+	if q.cancel != nil {
+		q.cancel()
+	}
+	if q.ticker != nil {
+		q.ticker.Stop()
+	}
+	q.wg.Wait()
+
+	q.logger.Info("Description queue stopped")
+	return nil
+}
+
+// loop runs a pass on every tick.
+func (q *DescriptionQueue) loop() {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case <-q.ticker.C():
+			if _, err := q.RunOnce(q.ctx); err != nil {
+				q.logger.Error("Description request pass failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RunOnce claims a batch of strategies lacking a description, up to what is
+// left of the hourly budget, and publishes them in a strategy.needs_description
+// event. It returns the requested strategies.
+func (q *DescriptionQueue) RunOnce(ctx context.Context) ([]*domain.DescriptionRequest, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.clock.Now()
+	limit := q.batchLimit(now)
+	if limit == 0 {
+		q.logger.Debug("Description request budget exhausted for this hour")
+		return nil, nil
+	}
+
+	requests, err := q.repos.Strategy.ClaimDescriptionRequests(ctx, limit, q.retryAfter, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim description requests: %w", err)
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	// Claimed strategies are requested again after the retry period if the
+	// event is lost, so a failed publish is not rolled back
+	event := events.NewStrategyNeedsDescriptionEvent(requests)
+	if err := q.eventPublisher.Publish(ctx, events.RoutingKeyStrategyNeedsDescription, event); err != nil {
+		return nil, fmt.Errorf("failed to publish strategy needs description event: %w", err)
+	}
+	for range requests {
+		q.requested = append(q.requested, now)
+	}
+
+	q.logger.Info("Requested strategy descriptions", zap.Int("strategies", len(requests)))
+
+	return requests, nil
+}
+
+// batchLimit returns how many strategies may be requested now: the batch
+// size, capped by what remains of the hourly budget.
+func (q *DescriptionQueue) batchLimit(now time.Time) int {
+	limit := q.config.BatchSize
+	if q.config.MaxPerHour <= 0 {
+		return limit
+	}
+
+	cutoff := now.Add(-time.Hour)
+	kept := q.requested[:0]
+	for _, at := range q.requested {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	q.requested = kept
+
+	return min(limit, max(q.config.MaxPerHour-len(q.requested), 0))
+}
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// mockDescriptionRepository implements the description queue methods of
+// StrategyRepository. Other methods panic via the nil embedded interface.
+type mockDescriptionRepository struct {
+	repository.StrategyRepository
+	missing        int // strategies lacking a description
+	lastLimit      int
+	lastRetryAfter time.Duration
+}
+
+func (m *mockDescriptionRepository) ClaimDescriptionRequests(ctx context.Context, limit int, retryAfter time.Duration, now time.Time) ([]*domain.DescriptionRequest, error) {
+	m.lastLimit = limit
+	m.lastRetryAfter = retryAfter
+	n := min(limit, m.missing)
+	m.missing -= n
+	requests := make([]*domain.DescriptionRequest, n)
+	for i := range requests {
+		requests[i] = &domain.DescriptionRequest{StrategyID: uuid.New(), Name: "Strategy"}
+	}
+	return requests, nil
+}
+
+func TestDescriptionQueue_BatchesWithinHourlyBudget(t *testing.T) {
+	repo := &mockDescriptionRepository{missing: 100}
+	publisher := newMockEventPublisher()
+	cfg := &config.DescriptionsConfig{Enabled: true, Interval: "5m", BatchSize: 10, MaxPerHour: 25, RetryAfter: "6h"}
+	queue := NewDescriptionQueue(cfg, &repository.Repositories{Strategy: repo}, publisher, zaptest.NewLogger(t))
+	fake := clock.NewFake(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	queue.SetClock(fake)
+
+	ctx := context.Background()
+	for _, want := range []int{10, 10, 5, 0} {
+		requested, err := queue.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Len(t, requested, want)
+		fake.Advance(5 * time.Minute)
+	}
+	assert.Equal(t, 6*time.Hour, repo.lastRetryAfter)
+
+	require.Len(t, publisher.publishedEvents, 3)
+	event, ok := publisher.publishedEvents[0].(*events.StrategyNeedsDescriptionEvent)
+	require.True(t, ok)
+	assert.Equal(t, events.EventTypeStrategyNeedsDescription, event.EventType)
+	assert.Len(t, event.Strategies, 10)
+
+	// The budget frees up an hour after the first requests
+	fake.Advance(45 * time.Minute)
+	requested, err := queue.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Len(t, requested, 10)
+}
+
+func TestDescriptionQueue_NothingMissing(t *testing.T) {
+	repo := &mockDescriptionRepository{}
+	publisher := newMockEventPublisher()
+	cfg := &config.DescriptionsConfig{Enabled: true, Interval: "5m", BatchSize: 10, RetryAfter: "24h"}
+	queue := NewDescriptionQueue(cfg, &repository.Repositories{Strategy: repo}, publisher, zaptest.NewLogger(t))
+
+	requested, err := queue.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, requested)
+	assert.Equal(t, 10, repo.lastLimit)
+	assert.Empty(t, publisher.publishedEvents)
+}
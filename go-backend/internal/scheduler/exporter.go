@@ -0,0 +1,358 @@
+package scheduler
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Files written to an export archive.
+const (
+	exportManifestFile   = "manifest.json"
+	exportStrategiesFile = "strategies.jsonl"
+	exportResultsFile    = "results.jsonl"
+	exportIterationsFile = "iterations.jsonl"
+)
+
+// ExportManifest describes the contents of an export archive.
+type ExportManifest struct {
+	ExportID       uuid.UUID           `json:"export_id"`
+	Format         domain.ExportFormat `json:"format"`
+	Filter         domain.ExportFilter `json:"filter"`
+	StrategyCount  int                 `json:"strategy_count"`
+	ResultCount    int                 `json:"result_count"`
+	IterationCount int                 `json:"iteration_count"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+// Exporter runs pending export jobs one at a time, writing the matching
+// strategies, backtest results and optimization iterations into a gzipped tar
+// archive stored in the artifact store.
+type Exporter struct {
+	repos  *repository.Repositories
+	config *config.ExportConfig
+	clock  clock.Clock
+	logger *zap.Logger
+
+	interval time.Duration
+	mu       sync.Mutex // serializes passes
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewExporter creates a new export worker.
+func NewExporter(cfg *config.ExportConfig, repos *repository.Repositories, logger *zap.Logger) *Exporter {
+	interval, err := time.ParseDuration(cfg.PollInterval)
+	if err != nil || interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return &Exporter{
+		repos:    repos,
+		config:   cfg,
+		clock:    clock.Real(),
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+// SetClock replaces the exporter's time source. It must be called before Start.
+func (e *Exporter) SetClock(c clock.Clock) {
+	e.clock = c
+}
+
+// Start requeues exports interrupted by a previous shutdown and starts
+// polling for pending export jobs.
+func (e *Exporter) Start() error {
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+
+	requeued, err := e.repos.Export.RequeueRunning(e.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to requeue interrupted exports: %w", err)
+	}
+
+	e.logger.Info("Starting exporter",
+		zap.Duration("poll_interval", e.interval),
+		zap.Int("batch_size", e.batchSize()),
+		zap.Int("requeued", requeued),
+	)
+
+	e.ticker = e.clock.NewTicker(e.interval)
+	e.wg.Add(1)
+	go e.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the exporter. An export in progress is abandoned and
+// requeued on the next start.
+func (e *Exporter) Stop() error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	if e.ticker != nil {
+		e.ticker.Stop()
+	}
+	e.wg.Wait()
+
+	e.logger.Info("Exporter stopped")
+	return nil
+}
+
+// loop drains pending jobs on every tick.
+func (e *Exporter) loop() {
+	defer e.wg.Done()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-e.ticker.C():
+			for {
+				job, err := e.RunOnce(e.ctx)
+				if err != nil {
+					e.logger.Error("Export pass failed", zap.Error(err))
+				}
+				if job == nil || e.ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RunOnce claims the oldest pending export job and runs it. It returns the
+// job, or nil if none was pending. A failed export is recorded on the job;
+// the returned error only reports failures to claim or update jobs.
+func (e *Exporter) RunOnce(ctx context.Context) (*domain.ExportJob, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	job, err := e.repos.Export.ClaimNext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, nil
+	}
+
+	start := e.clock.Now()
+	archive, err := e.build(ctx, job)
+	if err == nil {
+		err = e.repos.Export.Complete(ctx, job, archive)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the job is requeued on the next start
+			return job, nil
+		}
+
+		e.logger.Warn("Export failed",
+			zap.String("export_id", job.ID.String()),
+			zap.Error(err),
+		)
+		msg := err.Error()
+		job.Status = domain.ExportStatusFailed
+		job.Error = &msg
+		if err := e.repos.Export.Fail(ctx, job.ID, msg); err != nil {
+			return job, fmt.Errorf("failed to mark export %s failed: %w", job.ID, err)
+		}
+		return job, nil
+	}
+
+	e.logger.Info("Export completed",
+		zap.String("export_id", job.ID.String()),
+		zap.Int("strategies", job.StrategyCount),
+		zap.Int("results", job.ResultCount),
+		zap.Int("iterations", job.IterationCount),
+		zap.Int64("size_bytes", job.SizeBytes),
+		zap.Duration("duration", e.clock.Since(start)),
+	)
+	return job, nil
+}
+
+// build collects the job's entities page by page and returns the archive
+// artifact. The job's counts are updated as a side effect.
+func (e *Exporter) build(ctx context.Context, job *domain.ExportJob) (*domain.Artifact, error) {
+	var strategies, results, iterations bytes.Buffer
+	strategyEnc := json.NewEncoder(&strategies)
+	resultEnc := json.NewEncoder(&results)
+	iterationEnc := json.NewEncoder(&iterations)
+
+	query := job.Filter.ResultQuery()
+	seen := make(map[uuid.UUID]bool)
+	job.StrategyCount, job.ResultCount, job.IterationCount = 0, 0, 0
+
+	var afterID *uuid.UUID
+	for {
+		page, err := e.repos.Result.ListAfterID(ctx, query, afterID, e.batchSize())
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		resultIDs := make([]uuid.UUID, 0, len(page))
+		var newStrategyIDs []uuid.UUID
+		for _, result := range page {
+			if err := resultEnc.Encode(result); err != nil {
+				return nil, fmt.Errorf("failed to encode result: %w", err)
+			}
+			resultIDs = append(resultIDs, result.ID)
+			if !seen[result.StrategyID] {
+				seen[result.StrategyID] = true
+				newStrategyIDs = append(newStrategyIDs, result.StrategyID)
+			}
+		}
+		job.ResultCount += len(page)
+
+		if len(newStrategyIDs) > 0 {
+			found, err := e.repos.Strategy.GetByIDs(ctx, newStrategyIDs)
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range found {
+				if !job.Filter.IncludeCode {
+					copied := *s
+					copied.Code = ""
+					s = &copied
+				}
+				if err := strategyEnc.Encode(s); err != nil {
+					return nil, fmt.Errorf("failed to encode strategy: %w", err)
+				}
+			}
+			job.StrategyCount += len(found)
+		}
+
+		found, err := e.repos.Optimization.GetIterationsByResultIDs(ctx, resultIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, it := range found {
+			if !job.Filter.IncludeCode && it.CodeSnapshot != nil {
+				copied := *it
+				copied.CodeSnapshot = nil
+				it = &copied
+			}
+			if err := iterationEnc.Encode(it); err != nil {
+				return nil, fmt.Errorf("failed to encode iteration: %w", err)
+			}
+		}
+		job.IterationCount += len(found)
+
+		if strategies.Len()+results.Len()+iterations.Len() > domain.MaxExportArchiveSize {
+			return nil, fmt.Errorf("export exceeds maximum size of %dMB; narrow the filter",
+				domain.MaxExportArchiveSize/(1024*1024))
+		}
+
+		last := page[len(page)-1].ID
+		afterID = &last
+		if len(page) < e.batchSize() {
+			break
+		}
+	}
+
+	manifest, err := json.MarshalIndent(ExportManifest{
+		ExportID:       job.ID,
+		Format:         job.Format,
+		Filter:         job.Filter,
+		StrategyCount:  job.StrategyCount,
+		ResultCount:    job.ResultCount,
+		IterationCount: job.IterationCount,
+		CreatedAt:      e.clock.Now(),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	content, err := e.writeArchive([]archiveFile{
+		{exportManifestFile, manifest},
+		{exportStrategiesFile, strategies.Bytes()},
+		{exportResultsFile, results.Bytes()},
+		{exportIterationsFile, iterations.Bytes()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > domain.MaxExportArchiveSize {
+		return nil, fmt.Errorf("export exceeds maximum size of %dMB; narrow the filter",
+			domain.MaxExportArchiveSize/(1024*1024))
+	}
+
+	archive := domain.NewArtifact(
+		domain.ArtifactOwnerExport,
+		job.ID,
+		domain.ArtifactKindOther,
+		domain.ExportArchiveName(job.ID),
+		"application/gzip",
+		content,
+	)
+	archive.Metadata = map[string]interface{}{
+		"strategy_count":  job.StrategyCount,
+		"result_count":    job.ResultCount,
+		"iteration_count": job.IterationCount,
+	}
+	return archive, nil
+}
+
+// archiveFile is a named file in an export archive.
+type archiveFile struct {
+	name    string
+	content []byte
+}
+
+// writeArchive returns the files as a gzipped tar archive.
+func (e *Exporter) writeArchive(files []archiveFile) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	modTime := e.clock.Now()
+	for _, f := range files {
+		header := &tar.Header{
+			Name:    f.name,
+			Mode:    0o644,
+			Size:    int64(len(f.content)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("failed to write archive header: %w", err)
+		}
+		if _, err := tw.Write(f.content); err != nil {
+			return nil, fmt.Errorf("failed to write archive file: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// batchSize returns the configured page size.
+func (e *Exporter) batchSize() int {
+	if e.config.BatchSize > 0 {
+		return e.config.BatchSize
+	}
+	return 500
+}
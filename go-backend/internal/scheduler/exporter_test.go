@@ -0,0 +1,227 @@
+package scheduler
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockExportRepository implements ExportRepository in memory.
+type mockExportRepository struct {
+	repository.ExportRepository
+	pending   []*domain.ExportJob
+	completed map[uuid.UUID]*domain.Artifact
+	failed    map[uuid.UUID]string
+}
+
+func (m *mockExportRepository) ClaimNext(ctx context.Context) (*domain.ExportJob, error) {
+	if len(m.pending) == 0 {
+		return nil, nil
+	}
+	job := m.pending[0]
+	m.pending = m.pending[1:]
+	job.Status = domain.ExportStatusRunning
+	return job, nil
+}
+
+func (m *mockExportRepository) Complete(ctx context.Context, job *domain.ExportJob, archive *domain.Artifact) error {
+	if err := archive.Validate(); err != nil {
+		return err
+	}
+	m.completed[job.ID] = archive
+	job.Status = domain.ExportStatusCompleted
+	job.ArtifactID = &archive.ID
+	job.SizeBytes = archive.SizeBytes
+	return nil
+}
+
+func (m *mockExportRepository) Fail(ctx context.Context, id uuid.UUID, errMsg string) error {
+	m.failed[id] = errMsg
+	return nil
+}
+
+// mockExportResultRepository pages through results ordered by ID.
+type mockExportResultRepository struct {
+	repository.BacktestResultRepository
+	results []*domain.BacktestResult
+	pages   int
+	err     error
+}
+
+func (m *mockExportResultRepository) ListAfterID(ctx context.Context, query domain.BacktestResultQuery, afterID *uuid.UUID, limit int) ([]*domain.BacktestResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.pages++
+	var page []*domain.BacktestResult
+	for _, r := range m.results {
+		if afterID != nil && r.ID.String() <= afterID.String() {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, r)
+	}
+	return page, nil
+}
+
+type mockExportStrategyRepository struct {
+	repository.StrategyRepository
+	strategies map[uuid.UUID]*domain.Strategy
+	requested  int
+}
+
+func (m *mockExportStrategyRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Strategy, error) {
+	var found []*domain.Strategy
+	for _, id := range ids {
+		m.requested++
+		if s, ok := m.strategies[id]; ok {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+type mockExportOptimizationRepository struct {
+	repository.OptimizationRepository
+	iterations []*domain.OptimizationIteration
+}
+
+func (m *mockExportOptimizationRepository) GetIterationsByResultIDs(ctx context.Context, resultIDs []uuid.UUID) ([]*domain.OptimizationIteration, error) {
+	var found []*domain.OptimizationIteration
+	for _, it := range m.iterations {
+		for _, id := range resultIDs {
+			if it.ResultID != nil && *it.ResultID == id {
+				found = append(found, it)
+			}
+		}
+	}
+	return found, nil
+}
+
+// readArchive returns the files of a gzipped tar archive by name.
+func readArchive(t *testing.T, content []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(data)
+	}
+	return files
+}
+
+func TestExporter_WritesArchive(t *testing.T) {
+	strategy := domain.NewStrategy("RSI", "class RSI: pass", "", nil)
+	strategies := &mockExportStrategyRepository{strategies: map[uuid.UUID]*domain.Strategy{strategy.ID: strategy}}
+
+	// Three results of one strategy, sorted by ID to match ListAfterID
+	results := &mockExportResultRepository{}
+	for i := 0; i < 3; i++ {
+		results.results = append(results.results, &domain.BacktestResult{ID: uuid.New(), StrategyID: strategy.ID})
+	}
+	sort.Slice(results.results, func(i, j int) bool {
+		return results.results[i].ID.String() < results.results[j].ID.String()
+	})
+
+	snapshot := "class RSI: pass"
+	iteration := &domain.OptimizationIteration{ID: uuid.New(), ResultID: &results.results[1].ID, CodeSnapshot: &snapshot}
+	optimizations := &mockExportOptimizationRepository{iterations: []*domain.OptimizationIteration{iteration}}
+
+	job := domain.NewExportJob("alice", "", domain.ExportFilter{})
+	exports := &mockExportRepository{
+		pending:   []*domain.ExportJob{job},
+		completed: map[uuid.UUID]*domain.Artifact{},
+		failed:    map[uuid.UUID]string{},
+	}
+
+	exporter := NewExporter(&config.ExportConfig{Enabled: true, PollInterval: "1s", BatchSize: 2}, &repository.Repositories{
+		Export:       exports,
+		Result:       results,
+		Strategy:     strategies,
+		Optimization: optimizations,
+	}, zaptest.NewLogger(t))
+
+	got, err := exporter.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, domain.ExportStatusCompleted, got.Status)
+	assert.Equal(t, 1, got.StrategyCount)
+	assert.Equal(t, 3, got.ResultCount)
+	assert.Equal(t, 1, got.IterationCount)
+	assert.Equal(t, 2, results.pages, "pages of 2 then 1")
+	assert.Equal(t, 1, strategies.requested, "each strategy is fetched once")
+
+	archive := exports.completed[job.ID]
+	require.NotNil(t, archive)
+	assert.Equal(t, domain.ArtifactOwnerExport, archive.OwnerType)
+	assert.Equal(t, domain.ExportArchiveName(job.ID), archive.Name)
+
+	files := readArchive(t, archive.Content)
+	assert.Len(t, strings.Split(strings.TrimSpace(files["results.jsonl"]), "\n"), 3)
+	assert.Len(t, strings.Split(strings.TrimSpace(files["iterations.jsonl"]), "\n"), 1)
+
+	// Code is left out unless requested
+	var exported domain.Strategy
+	require.NoError(t, json.Unmarshal([]byte(files["strategies.jsonl"]), &exported))
+	assert.Equal(t, strategy.ID, exported.ID)
+	assert.Empty(t, exported.Code)
+	assert.NotContains(t, files["iterations.jsonl"], "code_snapshot")
+	assert.Equal(t, "class RSI: pass", strategy.Code, "stored strategy is not modified")
+
+	var manifest ExportManifest
+	require.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &manifest))
+	assert.Equal(t, job.ID, manifest.ExportID)
+	assert.Equal(t, 3, manifest.ResultCount)
+
+	// Nothing left to claim
+	got, err = exporter.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestExporter_RecordsFailure(t *testing.T) {
+	job := domain.NewExportJob("alice", domain.ExportFormatJSONL, domain.ExportFilter{})
+	exports := &mockExportRepository{
+		pending:   []*domain.ExportJob{job},
+		completed: map[uuid.UUID]*domain.Artifact{},
+		failed:    map[uuid.UUID]string{},
+	}
+
+	exporter := NewExporter(&config.ExportConfig{Enabled: true, PollInterval: "1s", BatchSize: 10}, &repository.Repositories{
+		Export: exports,
+		Result: &mockExportResultRepository{err: errors.New("connection reset")},
+	}, zaptest.NewLogger(t))
+
+	got, err := exporter.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportStatusFailed, got.Status)
+	assert.Contains(t, exports.failed[job.ID], "connection reset")
+	assert.Empty(t, exports.completed)
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// exitCodeKilled is the exit code of a container killed with SIGKILL, which
+// is what the kernel OOM killer sends when a container hits its memory limit.
+const exitCodeKilled = 137
+
+// ContainerLogsMarker separates a failed job's error message from the tail
+// of its container's output appended to it.
+const ContainerLogsMarker = "\n\n--- Container Logs ---\n"
+
+// Log fragments that identify why a backtest container exited with an error.
+// Freqtrade logs the exception of a failed run just before exiting.
+var (
+	strategyImportPatterns = []string{
+		"Impossible to load Strategy",
+		"SyntaxError",
+		"IndentationError",
+		"ImportError",
+		"ModuleNotFoundError",
+		"NameError",
+		"is not a valid strategy",
+		"must be a subclass of IStrategy",
+	}
+	dataMissingPatterns = []string{
+		"No data found",
+		"No history for",
+		"No data for pair",
+		"Terminating.. No data",
+		"does not exist in the exchange",
+		"is not available on",
+	}
+	oomPatterns = []string{
+		"MemoryError",
+		"Out of memory",
+		"OOMKilled",
+	}
+)
+
+// classifyContainerFailure determines why a backtest container exited with a
+// non-zero exit code from the code and its logs.
+func classifyContainerFailure(exitCode int64, logs string) domain.FailureCategory {
+	switch {
+	case containsAny(logs, oomPatterns):
+		return domain.FailureCategoryOOM
+	case containsAny(logs, strategyImportPatterns):
+		return domain.FailureCategoryStrategyImport
+	case containsAny(logs, dataMissingPatterns):
+		return domain.FailureCategoryDataMissing
+	case exitCode == exitCodeKilled:
+		return domain.FailureCategoryOOM
+	default:
+		return domain.FailureCategoryUnknown
+	}
+}
+
+// classifyError determines the category of a failure that happened outside
+// the backtest itself, e.g. while starting or waiting for its container.
+func classifyError(err error) domain.FailureCategory {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.FailureCategoryTimeout
+	case errors.Is(err, ErrContainerStartFailed), errors.Is(err, ErrDockerDaemonError):
+		return domain.FailureCategoryDocker
+	default:
+		return domain.FailureCategoryUnknown
+	}
+}
+
+// containerFailureError returns the error recorded for a container failure of
+// the given category.
+func containerFailureError(category domain.FailureCategory) error {
+	switch category {
+	case domain.FailureCategoryOOM:
+		return ErrOutOfMemory
+	case domain.FailureCategoryDataMissing:
+		return ErrDataMissing
+	default:
+		return ErrStrategyCodeError
+	}
+}
+
+// containsAny reports whether s contains any of the substrings.
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
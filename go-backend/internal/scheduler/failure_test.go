@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestClassifyContainerFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		exitCode int64
+		logs     string
+		want     domain.FailureCategory
+	}{
+		{
+			name:     "strategy import error",
+			exitCode: 2,
+			logs:     "freqtrade.exceptions.OperationalException: Impossible to load Strategy 'MyStrategy'.",
+			want:     domain.FailureCategoryStrategyImport,
+		},
+		{
+			name:     "syntax error",
+			exitCode: 1,
+			logs:     "  File \"/freqtrade/user_data/strategies/MyStrategy.py\", line 12\nSyntaxError: invalid syntax",
+			want:     domain.FailureCategoryStrategyImport,
+		},
+		{
+			name:     "missing data",
+			exitCode: 2,
+			logs:     "freqtrade.exceptions.OperationalException: No data found. Terminating.",
+			want:     domain.FailureCategoryDataMissing,
+		},
+		{
+			name:     "killed by the OOM killer",
+			exitCode: 137,
+			logs:     "Loading data from 2024-01-01 00:00:00 up to 2024-06-01 00:00:00",
+			want:     domain.FailureCategoryOOM,
+		},
+		{
+			name:     "python memory error",
+			exitCode: 1,
+			logs:     "MemoryError: Unable to allocate 2.00 GiB",
+			want:     domain.FailureCategoryOOM,
+		},
+		{
+			name:     "runtime crash",
+			exitCode: 1,
+			logs:     "KeyError: 'rsi'",
+			want:     domain.FailureCategoryUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyContainerFailure(tt.exitCode, tt.logs))
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	assert.Equal(t, domain.FailureCategoryTimeout, classifyError(fmt.Errorf("wait: %w", context.DeadlineExceeded)))
+	assert.Equal(t, domain.FailureCategoryDocker, classifyError(ErrContainerStartFailed))
+	assert.Equal(t, domain.FailureCategoryDocker, classifyError(ErrDockerDaemonError))
+	assert.Equal(t, domain.FailureCategoryUnknown, classifyError(errors.New("boom")))
+}
+
+func TestWorker_ShouldRetry(t *testing.T) {
+	worker := &Worker{scheduler: &Scheduler{config: &config.SchedulerConfig{MaxRetries: 1}}}
+	job := &domain.BacktestJob{}
+
+	retryable := map[domain.FailureCategory]bool{
+		domain.FailureCategoryStrategyImport: false,
+		domain.FailureCategoryDataMissing:    true,
+		domain.FailureCategoryTimeout:        true,
+		domain.FailureCategoryOOM:            false,
+		domain.FailureCategoryDocker:         true,
+		domain.FailureCategoryParser:         false,
+		domain.FailureCategoryUnknown:        false,
+	}
+	for _, category := range domain.FailureCategories {
+		assert.Equal(t, retryable[category], worker.shouldRetry(job, category), category)
+	}
+
+	// Retries are limited whatever the category
+	job.RetryCount = 1
+	assert.False(t, worker.shouldRetry(job, domain.FailureCategoryDocker))
+}
@@ -0,0 +1,281 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/parser"
+)
+
+// hyperoptLogTail is how much of a failed hyperopt container's output is
+// kept as the job's error.
+const hyperoptLogTail = 2000
+
+// HyperoptRunner runs pending hyperopt jobs one at a time. Hyperopt runs
+// many backtests in one container, so it has its own timeout rather than the
+// scheduler's job timeout, and takes a backtest slot on the Docker host for
+// as long as it runs.
+type HyperoptRunner struct {
+	repos         *repository.Repositories
+	config        *config.HyperoptConfig
+	dockerManager docker.Manager
+	clock         clock.Clock
+	logger        *zap.Logger
+
+	interval time.Duration
+	mu       sync.Mutex // serializes passes
+
+	runMu      sync.Mutex
+	running    uuid.UUID          // Job being run, if any
+	cancelRun  context.CancelFunc // Stops waiting for the running job
+	cancelling bool               // Set once the running job is cancelled
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewHyperoptRunner creates a new hyperopt worker.
+func NewHyperoptRunner(cfg *config.HyperoptConfig, repos *repository.Repositories, dockerManager docker.Manager, logger *zap.Logger) *HyperoptRunner {
+	interval, err := time.ParseDuration(cfg.PollInterval)
+	if err != nil || interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	return &HyperoptRunner{
+		repos:         repos,
+		config:        cfg,
+		dockerManager: dockerManager,
+		clock:         clock.Real(),
+		logger:        logger,
+		interval:      interval,
+	}
+}
+
+// SetClock replaces the runner's time source. It must be called before Start.
+func (h *HyperoptRunner) SetClock(c clock.Clock) {
+	h.clock = c
+}
+
+// Start requeues hyperopt jobs interrupted by a previous shutdown and starts
+// polling for pending jobs.
+func (h *HyperoptRunner) Start() error {
+	h.ctx, h.cancel = context.WithCancel(context.Background())
+
+	requeued, err := h.repos.Hyperopt.RequeueRunning(h.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to requeue interrupted hyperopt jobs: %w", err)
+	}
+
+	h.logger.Info("Starting hyperopt runner",
+		zap.Duration("poll_interval", h.interval),
+		zap.Duration("timeout", h.config.Timeout()),
+		zap.Int("requeued", requeued),
+	)
+
+	h.ticker = h.clock.NewTicker(h.interval)
+	h.wg.Add(1)
+	go h.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the runner. A job in progress has its container
+// stopped and is requeued on the next start.
+func (h *HyperoptRunner) Stop() error {
+	if h.cancel != nil {
+		h.cancel()
+	}
+	if h.ticker != nil {
+		h.ticker.Stop()
+	}
+	h.wg.Wait()
+
+	h.logger.Info("Hyperopt runner stopped")
+	return nil
+}
+
+// loop drains pending jobs on every tick.
+func (h *HyperoptRunner) loop() {
+	defer h.wg.Done()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-h.ticker.C():
+			for {
+				job, err := h.RunOnce(h.ctx)
+				if err != nil {
+					h.logger.Error("Hyperopt pass failed", zap.Error(err))
+				}
+				if job == nil || h.ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// CancelRunning stops the container of a cancelled hyperopt job if it is
+// the one running. It returns whether it was.
+func (h *HyperoptRunner) CancelRunning(id uuid.UUID) bool {
+	h.runMu.Lock()
+	defer h.runMu.Unlock()
+
+	if h.running != id || h.cancelRun == nil {
+		return false
+	}
+	h.cancelling = true
+	h.cancelRun()
+	return true
+}
+
+// RunOnce claims the oldest pending hyperopt job and runs it to the end. It
+// returns the job, or nil if none was pending. A failed run is recorded on
+// the job; the returned error only reports failures to claim or update jobs.
+func (h *HyperoptRunner) RunOnce(ctx context.Context) (*domain.HyperoptJob, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	job, err := h.repos.Hyperopt.ClaimNext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, nil
+	}
+
+	start := h.clock.Now()
+	result, err := h.run(ctx, job)
+	if errors.Is(err, errHyperoptInterrupted) {
+		return job, nil
+	}
+	if err != nil {
+		h.logger.Warn("Hyperopt failed",
+			zap.String("job_id", job.ID.String()),
+			zap.Error(err),
+		)
+		msg := err.Error()
+		job.Status = domain.JobStatusFailed
+		job.Error = &msg
+		if err := h.repos.Hyperopt.Fail(ctx, job.ID, msg); err != nil && !errors.Is(err, domain.ErrConflict) {
+			return job, fmt.Errorf("failed to mark hyperopt job %s failed: %w", job.ID, err)
+		}
+		return job, nil
+	}
+
+	if err := h.repos.Hyperopt.Complete(ctx, job.ID, result); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			// Cancelled as it finished
+			return job, nil
+		}
+		return job, fmt.Errorf("failed to complete hyperopt job %s: %w", job.ID, err)
+	}
+	job.Status = domain.JobStatusCompleted
+	job.Result = result
+
+	h.logger.Info("Hyperopt completed",
+		zap.String("job_id", job.ID.String()),
+		zap.String("strategy_id", job.StrategyID.String()),
+		zap.Int("best_epoch", result.BestEpoch),
+		zap.Int("total_epochs", result.TotalEpochs),
+		zap.Float64("loss", result.Loss),
+		zap.Float64("profit_pct", result.ProfitPct),
+		zap.Duration("duration", h.clock.Since(start)),
+	)
+	return job, nil
+}
+
+// errHyperoptInterrupted reports a run that was cancelled or cut short by
+// shutdown, which leaves the job's status to the canceller or the next start.
+var errHyperoptInterrupted = errors.New("hyperopt interrupted")
+
+// run runs a claimed job's container and parses its best epoch.
+func (h *HyperoptRunner) run(ctx context.Context, job *domain.HyperoptJob) (*domain.HyperoptResult, error) {
+	strategy, err := h.repos.Strategy.GetByID(ctx, job.StrategyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get strategy: %w", err)
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, h.config.Timeout())
+	defer cancel()
+
+	h.runMu.Lock()
+	h.running, h.cancelRun, h.cancelling = job.ID, cancel, false
+	h.runMu.Unlock()
+	defer func() {
+		h.runMu.Lock()
+		h.running, h.cancelRun = uuid.Nil, nil
+		h.runMu.Unlock()
+	}()
+
+	containerID, err := h.dockerManager.RunHyperopt(jobCtx, &docker.RunHyperoptParams{
+		JobID:         job.ID,
+		StrategyCode:  strategy.Code,
+		StrategyName:  strategy.Name,
+		Config:        job.Config,
+		CPULimit:      h.config.CPULimit,
+		MemoryLimitMB: h.config.MemoryLimitMB,
+	})
+	if err != nil {
+		if h.interrupted(ctx) {
+			return nil, errHyperoptInterrupted
+		}
+		return nil, fmt.Errorf("%w: %v", ErrContainerStartFailed, err)
+	}
+	defer h.dockerManager.RemoveContainer(context.Background(), containerID)
+
+	if err := h.repos.Hyperopt.SetContainer(ctx, job.ID, containerID); err != nil {
+		h.logger.Warn("Failed to record hyperopt container",
+			zap.String("job_id", job.ID.String()),
+			zap.Error(err),
+		)
+	}
+	job.ContainerID = &containerID
+
+	exitCode, logs, err := h.dockerManager.WaitContainer(jobCtx, containerID)
+	if err != nil {
+		h.dockerManager.StopContainer(context.Background(), containerID)
+		if h.interrupted(ctx) {
+			return nil, errHyperoptInterrupted
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("hyperopt timed out after %s", h.config.Timeout())
+		}
+		return nil, fmt.Errorf("failed to wait for hyperopt container: %w", err)
+	}
+
+	if exitCode != 0 {
+		if len(logs) > hyperoptLogTail {
+			logs = logs[len(logs)-hyperoptLogTail:]
+		}
+		return nil, fmt.Errorf("hyperopt exited with code %d: %s", exitCode, logs)
+	}
+
+	result, err := parser.ParseHyperoptResult(logs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrResultParseFailed, err)
+	}
+	return result, nil
+}
+
+// interrupted reports whether the running job was cancelled or the runner is
+// shutting down.
+func (h *HyperoptRunner) interrupted(ctx context.Context) bool {
+	h.runMu.Lock()
+	defer h.runMu.Unlock()
+	return h.cancelling || ctx.Err() != nil
+}
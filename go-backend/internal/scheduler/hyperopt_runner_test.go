@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockHyperoptRepository hands out pending hyperopt jobs and records how
+// they end.
+type mockHyperoptRepository struct {
+	repository.HyperoptRepository
+	mu        sync.Mutex
+	pending   []*domain.HyperoptJob
+	completed map[uuid.UUID]*domain.HyperoptResult
+	failed    map[uuid.UUID]string
+}
+
+func (m *mockHyperoptRepository) ClaimNext(ctx context.Context) (*domain.HyperoptJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.pending) == 0 {
+		return nil, nil
+	}
+	job := m.pending[0]
+	m.pending = m.pending[1:]
+	job.Status = domain.JobStatusRunning
+	return job, nil
+}
+
+func (m *mockHyperoptRepository) SetContainer(ctx context.Context, id uuid.UUID, containerID string) error {
+	return nil
+}
+
+func (m *mockHyperoptRepository) Complete(ctx context.Context, id uuid.UUID, result *domain.HyperoptResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.completed[id] = result
+	return nil
+}
+
+func (m *mockHyperoptRepository) Fail(ctx context.Context, id uuid.UUID, errMsg string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed[id] = errMsg
+	return nil
+}
+
+// mockHyperoptStrategyRepository returns a fixed strategy.
+type mockHyperoptStrategyRepository struct {
+	repository.StrategyRepository
+}
+
+func (m *mockHyperoptStrategyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Strategy, error) {
+	return &domain.Strategy{ID: id, Name: "TestStrategy", Code: "class TestStrategy(IStrategy): pass"}, nil
+}
+
+func newTestHyperoptRunner(t *testing.T, failureRate float64) (*HyperoptRunner, *mockHyperoptRepository, *clock.Fake) {
+	t.Helper()
+
+	clk := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager, err := docker.NewFakeManager(&config.DockerConfig{
+		Fake: config.FakeExecutorConfig{
+			MinDuration:     "10m",
+			MaxDuration:     "10m",
+			FailureRate:     failureRate,
+			ProfitMeanPct:   5,
+			ProfitStdDevPct: 1,
+			Seed:            3,
+		},
+	}, clk, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	repo := &mockHyperoptRepository{
+		completed: make(map[uuid.UUID]*domain.HyperoptResult),
+		failed:    make(map[uuid.UUID]string),
+	}
+	repos := &repository.Repositories{Hyperopt: repo, Strategy: &mockHyperoptStrategyRepository{}}
+	runner := NewHyperoptRunner(&config.HyperoptConfig{TimeoutMinutes: 60}, repos, manager, zaptest.NewLogger(t))
+	runner.SetClock(clk)
+	return runner, repo, clk
+}
+
+// runHyperoptOnce runs a pass of the runner, calling during once the job's
+// container is running.
+func runHyperoptOnce(t *testing.T, runner *HyperoptRunner, clk *clock.Fake, during func()) *domain.HyperoptJob {
+	t.Helper()
+
+	done := make(chan *domain.HyperoptJob, 1)
+	go func() {
+		job, err := runner.RunOnce(context.Background())
+		assert.NoError(t, err)
+		done <- job
+	}()
+
+	require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+	during()
+	return <-done
+}
+
+func TestHyperoptRunner_RunOnce(t *testing.T) {
+	runner, repo, clk := newTestHyperoptRunner(t, 0)
+	job := domain.NewHyperoptJob(uuid.New(), "alice", domain.HyperoptConfig{Epochs: 30})
+	repo.pending = []*domain.HyperoptJob{job}
+
+	got := runHyperoptOnce(t, runner, clk, func() { clk.Advance(10 * time.Minute) })
+	require.NotNil(t, got)
+	assert.Equal(t, domain.JobStatusCompleted, got.Status)
+
+	result := repo.completed[job.ID]
+	require.NotNil(t, result)
+	assert.Equal(t, 30, result.TotalEpochs)
+	require.NotNil(t, result.ParamsPatch)
+	assert.NotNil(t, result.ParamsPatch.Stoploss)
+	assert.Empty(t, repo.failed)
+
+	// Nothing left to run
+	got, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestHyperoptRunner_ContainerFailure(t *testing.T) {
+	runner, repo, clk := newTestHyperoptRunner(t, 1)
+	job := domain.NewHyperoptJob(uuid.New(), "alice", domain.HyperoptConfig{})
+	repo.pending = []*domain.HyperoptJob{job}
+
+	runHyperoptOnce(t, runner, clk, func() { clk.Advance(10 * time.Minute) })
+	assert.Contains(t, repo.failed[job.ID], "hyperopt exited with code 1")
+	assert.Empty(t, repo.completed)
+}
+
+func TestHyperoptRunner_CancelRunning(t *testing.T) {
+	runner, repo, clk := newTestHyperoptRunner(t, 0)
+	job := domain.NewHyperoptJob(uuid.New(), "alice", domain.HyperoptConfig{})
+	repo.pending = []*domain.HyperoptJob{job}
+
+	assert.False(t, runner.CancelRunning(job.ID))
+	runHyperoptOnce(t, runner, clk, func() {
+		assert.False(t, runner.CancelRunning(uuid.New()))
+		assert.True(t, runner.CancelRunning(job.ID))
+	})
+
+	// The canceller already recorded the job as cancelled
+	assert.Empty(t, repo.completed)
+	assert.Empty(t, repo.failed)
+}
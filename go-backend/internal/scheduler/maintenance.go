@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// MaintenanceTarget is a scheduler that stops taking on work while the
+// system is in maintenance mode.
+type MaintenanceTarget interface {
+	EnterMaintenance()
+	ExitMaintenance()
+}
+
+// MaintenanceWatcher keeps this instance in step with the system-wide
+// maintenance mode. The flag is stored in the database, so every instance
+// picks up a change made on another within the check interval; the instance
+// it was changed on applies it at once. While it is enabled the targets are
+// paused.
+type MaintenanceWatcher struct {
+	repos          *repository.Repositories
+	eventPublisher events.Publisher
+	targets        []MaintenanceTarget
+	clock          clock.Clock
+	logger         *zap.Logger
+
+	interval time.Duration
+
+	mu      sync.RWMutex
+	mode    domain.MaintenanceMode
+	read    bool // The mode was read or set at least once
+	applied bool // The targets are paused for maintenance
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewMaintenanceWatcher creates a new maintenance mode watcher.
+func NewMaintenanceWatcher(
+	cfg *config.MaintenanceConfig,
+	repos *repository.Repositories,
+	publisher events.Publisher,
+	logger *zap.Logger,
+	targets ...MaintenanceTarget,
+) *MaintenanceWatcher {
+	interval, err := time.ParseDuration(cfg.CheckInterval)
+	if err != nil || interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	return &MaintenanceWatcher{
+		repos:          repos,
+		eventPublisher: publisher,
+		targets:        targets,
+		clock:          clock.Real(),
+		logger:         logger,
+		interval:       interval,
+	}
+}
+
+// SetClock replaces the watcher's time source. It must be called before Start.
+func (w *MaintenanceWatcher) SetClock(c clock.Clock) {
+	w.clock = c
+}
+
+// Start reads the maintenance mode, so an instance started during
+// maintenance stays paused, and then keeps reading it periodically.
+func (w *MaintenanceWatcher) Start() error {
+	w.logger.Info("Starting maintenance mode watcher", zap.Duration("interval", w.interval))
+
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	if err := w.Check(w.ctx); err != nil {
+		w.logger.Error("Failed to read maintenance mode", zap.Error(err))
+	}
+
+	w.ticker = w.clock.NewTicker(w.interval)
+	w.wg.Add(1)
+	go w.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the watcher.
+func (w *MaintenanceWatcher) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+	w.wg.Wait()
+
+	w.logger.Info("Maintenance mode watcher stopped")
+	return nil
+}
+
+// loop runs a check on every tick.
+func (w *MaintenanceWatcher) loop() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-w.ticker.C():
+			if err := w.Check(w.ctx); err != nil {
+				w.logger.Error("Maintenance mode check failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Current returns the maintenance mode as last read or set. The mode is
+// kept when it can't be read, e.g. while the database itself is down for
+// maintenance.
+func (w *MaintenanceWatcher) Current() domain.MaintenanceMode {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.mode
+}
+
+// Check reads the maintenance mode and applies it.
+func (w *MaintenanceWatcher) Check(ctx context.Context) error {
+	mode, err := w.repos.Maintenance.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+	w.apply(mode)
+	return nil
+}
+
+// Set enables or disables maintenance mode for every instance, applies it
+// here and publishes a system.maintenance event.
+func (w *MaintenanceWatcher) Set(ctx context.Context, enabled bool, message, by string) (*domain.MaintenanceMode, error) {
+	if err := domain.ValidateMaintenanceMessage(message); err != nil {
+		return nil, err
+	}
+	if !enabled {
+		message = ""
+	}
+
+	mode, err := w.repos.Maintenance.Set(ctx, enabled, message, by)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set maintenance mode: %w", err)
+	}
+	w.apply(mode)
+
+	if w.eventPublisher != nil {
+		if err := w.eventPublisher.Publish(ctx, events.RoutingKeySystemMaintenance, events.NewMaintenanceEvent(mode)); err != nil {
+			w.logger.Warn("Failed to publish maintenance event", zap.Error(err))
+		}
+	}
+
+	return mode, nil
+}
+
+// apply stores the mode and pauses or resumes the targets when it was
+// enabled or disabled. The first mode read is always applied, so a scheduler
+// restored paused for a maintenance that has since ended is resumed.
+func (w *MaintenanceWatcher) apply(mode *domain.MaintenanceMode) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.mode = *mode
+	if w.read && mode.Enabled == w.applied {
+		return
+	}
+	w.read = true
+	w.applied = mode.Enabled
+
+	if mode.Enabled {
+		w.logger.Warn("Maintenance mode enabled, pausing schedulers",
+			zap.String("changed_by", mode.ChangedBy),
+			zap.String("message", mode.Message),
+		)
+		for _, t := range w.targets {
+			t.EnterMaintenance()
+		}
+		return
+	}
+
+	w.logger.Info("Maintenance mode disabled, resuming schedulers",
+		zap.String("changed_by", mode.ChangedBy),
+	)
+	for _, t := range w.targets {
+		t.ExitMaintenance()
+	}
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// mockMaintenanceRepository keeps the maintenance mode in memory.
+type mockMaintenanceRepository struct {
+	mode domain.MaintenanceMode
+}
+
+func (m *mockMaintenanceRepository) Get(ctx context.Context) (*domain.MaintenanceMode, error) {
+	mode := m.mode
+	return &mode, nil
+}
+
+func (m *mockMaintenanceRepository) Set(ctx context.Context, enabled bool, message, changedBy string) (*domain.MaintenanceMode, error) {
+	m.mode.Enabled, m.mode.Message, m.mode.ChangedBy = enabled, message, changedBy
+	mode := m.mode
+	return &mode, nil
+}
+
+func TestMaintenanceWatcher_PausesSchedulers(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	sched := newStateScheduler(t, "", &mockRequeueRepository{running: map[uuid.UUID]bool{}}, &mockStopManager{}, fake)
+	scout := NewScoutScheduler(&repository.Repositories{}, nil, zaptest.NewLogger(t))
+
+	repo := &mockMaintenanceRepository{}
+	publisher := newMockEventPublisher()
+	watcher := NewMaintenanceWatcher(&config.MaintenanceConfig{CheckInterval: "10s"},
+		&repository.Repositories{Maintenance: repo}, publisher, zaptest.NewLogger(t), sched, scout)
+
+	require.NoError(t, watcher.Check(ctx))
+	assert.False(t, watcher.Current().Enabled)
+	assert.Equal(t, domain.SchedulerModeRunning, sched.Status().Mode)
+
+	// Enabling pauses both schedulers and is broadcast
+	mode, err := watcher.Set(ctx, true, "Database upgrade until 14:00 UTC", "ops")
+	require.NoError(t, err)
+	assert.True(t, mode.Enabled)
+	current := watcher.Current()
+	assert.Equal(t, "Database upgrade until 14:00 UTC", current.RejectionMessage())
+	assert.Equal(t, domain.SchedulerModePaused, sched.Status().Mode)
+	assert.Equal(t, domain.MaintenanceActor, sched.Status().ChangedBy)
+	assert.True(t, scout.paused.Load())
+
+	require.Len(t, publisher.publishedEvents, 1)
+	event, ok := publisher.publishedEvents[0].(*events.MaintenanceEvent)
+	require.True(t, ok)
+	assert.True(t, event.Enabled)
+	assert.Equal(t, "ops", event.ChangedBy)
+
+	// Disabling it on another instance resumes them on the next check
+	repo.mode.Enabled = false
+	require.NoError(t, watcher.Check(ctx))
+	assert.Equal(t, domain.SchedulerModeRunning, sched.Status().Mode)
+	assert.False(t, scout.paused.Load())
+
+	_, err = watcher.Set(ctx, true, strings.Repeat("x", domain.MaxMaintenanceMessageLength+1), "ops")
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	assert.False(t, watcher.Current().Enabled)
+}
+
+func TestMaintenanceWatcher_KeepsOperatorMode(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	sched := newStateScheduler(t, "", &mockRequeueRepository{running: map[uuid.UUID]bool{}}, &mockStopManager{}, fake)
+
+	repo := &mockMaintenanceRepository{}
+	watcher := NewMaintenanceWatcher(&config.MaintenanceConfig{CheckInterval: "10s"},
+		&repository.Repositories{Maintenance: repo}, nil, zaptest.NewLogger(t), sched)
+
+	_, err := watcher.Set(ctx, true, "", "ops")
+	require.NoError(t, err)
+	current := watcher.Current()
+	assert.Equal(t, domain.DefaultMaintenanceMessage, current.RejectionMessage())
+
+	// A scheduler drained during maintenance stays drained after it
+	sched.Drain("alice")
+	_, err = watcher.Set(ctx, false, "", "ops")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SchedulerModeDraining, sched.Status().Mode)
+	assert.Equal(t, "alice", sched.Status().ChangedBy)
+}
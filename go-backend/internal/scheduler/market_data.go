@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// MarketDataInventory reports the market data a backtest lacks.
+type MarketDataInventory interface {
+	Missing(cfg domain.BacktestConfig) (*domain.MissingMarketData, error)
+}
+
+// MarketDataDownloader downloads market data, waiting for it to finish.
+type MarketDataDownloader interface {
+	Download(ctx context.Context, req domain.DataDownloadRequest, requestedBy string) (*domain.DataDownload, error)
+}
+
+// SetMarketData makes workers check that the candle data a job needs is
+// present before starting its container; jobs lacking data fail as
+// data_missing. With a downloader, missing data is downloaded first and the
+// job fails only if it is still missing. It must be called before Start.
+func (s *Scheduler) SetMarketData(inventory MarketDataInventory, downloader MarketDataDownloader) {
+	s.marketData = inventory
+	s.downloader = downloader
+}
+
+// checkMarketData checks a job's market data, downloading what is missing if
+// a downloader is set. It returns the failed result of a job lacking data, or
+// nil to start the job. A check that can't be made leaves the job to run, as
+// it would without the check.
+func (w *Worker) checkMarketData(ctx context.Context, job *domain.BacktestJob) *JobResult {
+	s := w.scheduler
+	if s.marketData == nil {
+		return nil
+	}
+
+	missing, err := s.marketData.Missing(job.Config)
+	if err != nil {
+		w.logger.Warn("Failed to check market data",
+			zap.String("job_id", job.ID.String()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if missing == nil {
+		return nil
+	}
+
+	if s.downloader != nil {
+		w.logger.Info("Downloading missing market data",
+			zap.String("job_id", job.ID.String()),
+			zap.String("missing", missing.String()),
+		)
+		download, err := s.downloader.Download(ctx, missing.DownloadRequest(job.Config), "job:"+job.ID.String())
+		if err != nil {
+			w.logger.Warn("Failed to download market data",
+				zap.String("job_id", job.ID.String()),
+				zap.Error(err),
+			)
+		} else {
+			detail := fmt.Sprintf("downloaded %s %s %s candles of %d pairs (download %s)",
+				missing.Exchange, missing.TradingMode, missing.Timeframe, len(missing.Pairs), download.ID)
+			s.recordJobEvent(&domain.JobEvent{
+				JobID:  job.ID,
+				Type:   domain.JobEventDataDownloaded,
+				Status: domain.JobStatusRunning,
+				Worker: optionalString(w.name()),
+				Detail: &detail,
+			})
+		}
+
+		if missing, err = s.marketData.Missing(job.Config); err != nil || missing == nil {
+			return nil
+		}
+	}
+
+	return &JobResult{
+		Job:             job,
+		Success:         false,
+		Error:           fmt.Errorf("%w: %s", ErrDataMissing, missing),
+		FailureCategory: domain.FailureCategoryDataMissing,
+	}
+}
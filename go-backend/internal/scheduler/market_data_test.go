@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockInventory lacks the pairs listed, until they are downloaded.
+type mockInventory struct {
+	missing []string
+}
+
+func (m *mockInventory) Missing(cfg domain.BacktestConfig) (*domain.MissingMarketData, error) {
+	if len(m.missing) == 0 {
+		return nil, nil
+	}
+	return &domain.MissingMarketData{Exchange: "binance", TradingMode: "futures", Timeframe: "5m", Pairs: m.missing}, nil
+}
+
+// mockDownloader downloads all but the unavailable pairs.
+type mockDownloader struct {
+	inventory   *mockInventory
+	unavailable []string
+	requests    []domain.DataDownloadRequest
+}
+
+func (m *mockDownloader) Download(ctx context.Context, req domain.DataDownloadRequest, requestedBy string) (*domain.DataDownload, error) {
+	m.requests = append(m.requests, req)
+	m.inventory.missing = m.unavailable
+	if len(m.unavailable) > 0 {
+		return &domain.DataDownload{ID: uuid.New(), Status: domain.DataDownloadFailed}, errors.New("pair not available")
+	}
+	return &domain.DataDownload{ID: uuid.New(), Status: domain.DataDownloadCompleted}, nil
+}
+
+func TestWorker_CheckMarketData(t *testing.T) {
+	newWorker := func(t *testing.T, inventory *mockInventory, downloader MarketDataDownloader) (*Worker, *mockRequeueRepository) {
+		repo := &mockRequeueRepository{}
+		cfg := &config.SchedulerConfig{MaxConcurrentBacktests: 1, JobTimeoutMinutes: 10}
+		sched := NewScheduler(cfg, &repository.Repositories{BacktestJob: repo}, nil, nil, zaptest.NewLogger(t))
+		if inventory != nil {
+			sched.SetMarketData(inventory, downloader)
+		}
+		return NewWorker(0, sched, zaptest.NewLogger(t)), repo
+	}
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{TimerangeStart: "2024-01-01"}, 0, nil)
+
+	t.Run("Unchecked", func(t *testing.T) {
+		worker, _ := newWorker(t, nil, nil)
+		assert.Nil(t, worker.checkMarketData(context.Background(), job))
+	})
+
+	t.Run("MissingFailsJob", func(t *testing.T) {
+		worker, _ := newWorker(t, &mockInventory{missing: []string{"ETH/USDT:USDT"}}, nil)
+
+		result := worker.checkMarketData(context.Background(), job)
+		require.NotNil(t, result)
+		assert.Equal(t, domain.FailureCategoryDataMissing, result.FailureCategory)
+		assert.ErrorIs(t, result.Error, ErrDataMissing)
+		assert.Contains(t, result.Error.Error(), "no binance futures 5m candles for ETH/USDT:USDT")
+	})
+
+	t.Run("Downloaded", func(t *testing.T) {
+		inventory := &mockInventory{missing: []string{"ETH/USDT:USDT"}}
+		downloader := &mockDownloader{inventory: inventory}
+		worker, repo := newWorker(t, inventory, downloader)
+
+		assert.Nil(t, worker.checkMarketData(context.Background(), job))
+		require.Len(t, downloader.requests, 1)
+		assert.Equal(t, []string{"ETH/USDT:USDT"}, downloader.requests[0].Pairs)
+		assert.Equal(t, "2024-01-01", downloader.requests[0].TimerangeStart)
+		require.Len(t, repo.events, 1)
+		assert.Equal(t, domain.JobEventDataDownloaded, repo.events[0].Type)
+	})
+
+	t.Run("StillMissingAfterDownload", func(t *testing.T) {
+		inventory := &mockInventory{missing: []string{"ETH/USDT:USDT", "XYZ/USDT:USDT"}}
+		downloader := &mockDownloader{inventory: inventory, unavailable: []string{"XYZ/USDT:USDT"}}
+		worker, repo := newWorker(t, inventory, downloader)
+
+		result := worker.checkMarketData(context.Background(), job)
+		require.NotNil(t, result)
+		assert.Equal(t, domain.FailureCategoryDataMissing, result.FailureCategory)
+		assert.Contains(t, result.Error.Error(), "XYZ/USDT:USDT")
+		assert.NotContains(t, result.Error.Error(), "ETH/USDT:USDT")
+		assert.Empty(t, repo.events)
+	})
+}
@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Status reports the scheduler's mode and the jobs it still holds.
+func (s *Scheduler) Status() *domain.SchedulerStatus {
+	s.modeMu.Lock()
+	status := &domain.SchedulerStatus{
+		Mode:      s.mode,
+		Since:     s.modeSince,
+		ChangedBy: s.modeChangedBy,
+	}
+	s.modeMu.Unlock()
+
+	s.stateMu.Lock()
+	status.ClaimedJobs = len(s.claims)
+	s.stateMu.Unlock()
+
+	s.activeJobs.Range(func(key, value interface{}) bool {
+		status.ActiveJobs++
+		return true
+	})
+	status.Drained = status.Mode != domain.SchedulerModeRunning && status.ClaimedJobs == 0
+	if s.backpressure != nil {
+		status.Backpressure = s.backpressure.status()
+	}
+
+	return status
+}
+
+// Pause stops dequeuing jobs until Resume. Running jobs continue; jobs handed
+// to workers that have not picked them up yet are returned to the queue, so
+// other schedulers can run them meanwhile.
+func (s *Scheduler) Pause(by string) *domain.SchedulerStatus {
+	if s.setMode(domain.SchedulerModePaused, by) {
+		s.requeueDispatched()
+	}
+	return s.Status()
+}
+
+// Drain stops dequeuing jobs but finishes every job already dispatched. Once
+// the status reports drained, no job is left running here.
+func (s *Scheduler) Drain(by string) *domain.SchedulerStatus {
+	s.setMode(domain.SchedulerModeDraining, by)
+	return s.Status()
+}
+
+// Resume dequeues jobs again after Pause or Drain.
+func (s *Scheduler) Resume(by string) *domain.SchedulerStatus {
+	s.setMode(domain.SchedulerModeRunning, by)
+	return s.Status()
+}
+
+// EnterMaintenance pauses the scheduler for maintenance mode.
+func (s *Scheduler) EnterMaintenance() {
+	s.Pause(domain.MaintenanceActor)
+}
+
+// ExitMaintenance resumes the scheduler after maintenance mode, unless it was
+// since paused or drained by someone else.
+func (s *Scheduler) ExitMaintenance() {
+	status := s.Status()
+	if status.Mode == domain.SchedulerModePaused && status.ChangedBy == domain.MaintenanceActor {
+		s.Resume(domain.MaintenanceActor)
+	}
+}
+
+// dispatching reports whether jobs may be dequeued.
+func (s *Scheduler) dispatching() bool {
+	s.modeMu.Lock()
+	defer s.modeMu.Unlock()
+	return s.mode == domain.SchedulerModeRunning
+}
+
+// setMode switches the scheduler to a mode, reporting whether it changed.
+// Every mode can be entered from every other.
+func (s *Scheduler) setMode(mode domain.SchedulerMode, by string) bool {
+	s.modeMu.Lock()
+	defer s.modeMu.Unlock()
+
+	if s.mode == mode {
+		return false
+	}
+	s.logger.Info("Scheduler mode changed",
+		zap.String("from", string(s.mode)),
+		zap.String("to", string(mode)),
+		zap.String("by", by),
+	)
+	s.mode = mode
+	s.modeSince = s.clock.Now()
+	s.modeChangedBy = by
+	return true
+}
+
+// requeueDispatched returns the jobs waiting for a worker to the queue.
+func (s *Scheduler) requeueDispatched() {
+	// Hold off the fetch loop so no job is dispatched while the local queue
+	// is emptied
+	s.dispatchMu.Lock()
+	defer s.dispatchMu.Unlock()
+
+	var jobs []*domain.BacktestJob
+	var ids []uuid.UUID
+drain:
+	for {
+		select {
+		case job := <-s.jobChan:
+			jobs = append(jobs, job)
+			ids = append(ids, job.ID)
+		default:
+			break drain
+		}
+	}
+	if len(jobs) == 0 {
+		return
+	}
+
+	requeued, err := s.repos.BacktestJob.Requeue(s.ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to requeue dispatched jobs", zap.Error(err))
+		// Leave them to the workers; they run once the scheduler resumes
+		for _, job := range jobs {
+			s.jobChan <- job
+		}
+		return
+	}
+
+	detail := "requeued when the scheduler was paused"
+	for _, id := range requeued {
+		s.recordJobEvent(&domain.JobEvent{
+			JobID:  id,
+			Type:   domain.JobEventRequeued,
+			Status: domain.JobStatusPending,
+			Detail: &detail,
+		})
+	}
+	for _, job := range jobs {
+		s.releaseAffinityKeys(job.ID)
+		s.releaseQuota(job.ID)
+		s.releaseClaim(job.ID)
+	}
+
+	s.logger.Info("Requeued dispatched jobs",
+		zap.Int("dispatched", len(jobs)),
+		zap.Int("requeued", len(requeued)),
+	)
+}
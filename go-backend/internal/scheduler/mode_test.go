@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// dispatchJob hands a job to the workers' queue as fetchAndDispatch does.
+func dispatchJob(s *Scheduler) *domain.BacktestJob {
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+	s.claim(job.ID, s.clock.Now())
+	s.jobChan <- job
+	return job
+}
+
+func TestScheduler_PauseRequeuesDispatchedJobs(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	repo := &mockRequeueRepository{running: map[uuid.UUID]bool{}}
+	sched := newStateScheduler(t, "", repo, &mockStopManager{}, fake)
+
+	job := dispatchJob(sched)
+	repo.running[job.ID] = true
+
+	status := sched.Pause("ops")
+	assert.Equal(t, domain.SchedulerModePaused, status.Mode)
+	assert.Equal(t, "ops", status.ChangedBy)
+	assert.Equal(t, fake.Now(), status.Since)
+	assert.True(t, status.Drained)
+	assert.Equal(t, []uuid.UUID{job.ID}, repo.requeued)
+	assert.Empty(t, sched.jobChan)
+	require.Len(t, repo.events, 1)
+	assert.Equal(t, domain.JobEventRequeued, repo.events[0].Type)
+
+	// Dequeuing is skipped; the mock repository has no GetPendingJobs
+	sched.fetchAndDispatch()
+
+	status = sched.Resume("ops")
+	assert.Equal(t, domain.SchedulerModeRunning, status.Mode)
+	assert.False(t, status.Drained)
+}
+
+func TestScheduler_DrainFinishesDispatchedJobs(t *testing.T) {
+	repo := &mockRequeueRepository{}
+	sched := newStateScheduler(t, "", repo, &mockStopManager{}, clock.NewFake(time.Now()))
+
+	job := dispatchJob(sched)
+
+	status := sched.Drain("ops")
+	assert.Equal(t, domain.SchedulerModeDraining, status.Mode)
+	assert.Equal(t, 1, status.ClaimedJobs)
+	assert.False(t, status.Drained, "a dispatched job is left to finish")
+	assert.Empty(t, repo.requeued)
+	assert.Len(t, sched.jobChan, 1)
+
+	<-sched.jobChan
+	sched.releaseClaim(job.ID)
+	assert.True(t, sched.Status().Drained)
+
+	// Pausing while draining has nothing left to requeue
+	assert.Equal(t, domain.SchedulerModePaused, sched.Pause("ops").Mode)
+	assert.Empty(t, repo.requeued)
+}
+
+func TestScheduler_ModeSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.json")
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	before := newStateScheduler(t, path, &mockRequeueRepository{}, &mockStopManager{}, fake)
+	before.Pause("ops")
+	require.NoError(t, before.saveState())
+
+	// Jobs of a stale state are ignored, the mode is not
+	fake.Advance(time.Hour)
+	after := newStateScheduler(t, path, &mockRequeueRepository{}, &mockStopManager{}, fake)
+	after.restoreState()
+
+	status := after.Status()
+	assert.Equal(t, domain.SchedulerModePaused, status.Mode)
+	assert.Equal(t, "ops", status.ChangedBy)
+	assert.Equal(t, fake.Now().Add(-time.Hour), status.Since)
+}
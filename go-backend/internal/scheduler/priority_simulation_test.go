@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestSimulatePriorityChange(t *testing.T) {
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	runID := uuid.New()
+	queued := func(priority int, minutes int, run *uuid.UUID) domain.QueuedJob {
+		return domain.QueuedJob{JobID: uuid.New(), OptimizationRunID: run, Priority: priority, CreatedAt: base.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	// Dequeue order: a, b, c, d
+	a := queued(5, 0, nil)
+	b := queued(0, 1, nil)
+	c := queued(0, 2, &runID)
+	d := queued(0, 3, &runID)
+	queue := []domain.QueuedJob{d, b, a, c}
+
+	// Two workers, one busy for another 5 minutes; jobs take 10 minutes.
+	// Before: a@0, b@5, c@10, d@15. After raising the run: c@0, d@5, a@10, b@15.
+	change := domain.PriorityChange{OptimizationRunID: &runID, Priority: 10}
+	sim := SimulatePriorityChange(queue, change, 1, 2, 10*time.Minute, base, 0)
+
+	assert.Equal(t, 2, sim.SelectedJobs)
+	assert.Equal(t, 4, sim.QueueLength)
+	assert.Equal(t, (10 * time.Minute).Milliseconds(), sim.RuntimeMs)
+	require.Len(t, sim.Affected, 4)
+
+	first := sim.Affected[0]
+	assert.Equal(t, c.JobID, first.JobID)
+	assert.True(t, first.Selected)
+	assert.Equal(t, 0, first.OldPriority)
+	assert.Equal(t, 10, first.NewPriority)
+	assert.Equal(t, 2, first.OldPosition)
+	assert.Equal(t, 0, first.NewPosition)
+	assert.Equal(t, (10 * time.Minute).Milliseconds(), first.OldStartMs)
+	assert.Zero(t, first.NewStartMs)
+	assert.Equal(t, -(10 * time.Minute).Milliseconds(), first.StartDeltaMs)
+
+	last := sim.Affected[3]
+	assert.Equal(t, b.JobID, last.JobID)
+	assert.False(t, last.Selected)
+	assert.Equal(t, (10 * time.Minute).Milliseconds(), last.StartDeltaMs)
+
+	// Jobs whose start does not move are left out
+	sim = SimulatePriorityChange(queue, domain.PriorityChange{JobIDs: []uuid.UUID{d.JobID}, Priority: 1}, 1, 2, 10*time.Minute, base, 0)
+	require.Len(t, sim.Affected, 3)
+	assert.Equal(t, d.JobID, sim.Affected[0].JobID)
+	assert.Equal(t, 1, sim.Affected[0].NewPosition)
+	assert.NotContains(t, []uuid.UUID{sim.Affected[1].JobID, sim.Affected[2].JobID}, a.JobID)
+}
+
+func TestSimulatePriorityChange_Aging(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := domain.QueuedJob{JobID: uuid.New(), Priority: 0, CreatedAt: now.Add(-10 * time.Hour)}
+	fresh := domain.QueuedJob{JobID: uuid.New(), Priority: 9, CreatedAt: now}
+	queue := []domain.QueuedJob{fresh, old}
+
+	// Having waited ten hours, the old job is ahead at effective priority 10;
+	// raising the fresh job to 11 puts it back in front
+	sim := SimulatePriorityChange(queue, domain.PriorityChange{JobIDs: []uuid.UUID{fresh.JobID}, Priority: 11}, 0, 1, 10*time.Minute, now, 1)
+	require.Len(t, sim.Affected, 2)
+	assert.Equal(t, fresh.JobID, sim.Affected[0].JobID)
+	assert.Equal(t, 1, sim.Affected[0].OldPosition)
+	assert.Equal(t, 0, sim.Affected[0].NewPosition)
+	assert.Equal(t, old.JobID, sim.Affected[1].JobID)
+	assert.Equal(t, 1, sim.Affected[1].NewPosition)
+
+	// Raising it to 10 ties, and the older job stays ahead
+	sim = SimulatePriorityChange(queue, domain.PriorityChange{JobIDs: []uuid.UUID{fresh.JobID}, Priority: 10}, 0, 1, 10*time.Minute, now, 1)
+	require.Len(t, sim.Affected, 1)
+	assert.Equal(t, 1, sim.Affected[0].NewPosition)
+}
+
+func TestPriorityChange_Validate(t *testing.T) {
+	assert.ErrorIs(t, domain.PriorityChange{Priority: 1}.Validate(), domain.ErrInvalidInput)
+	assert.NoError(t, domain.PriorityChange{JobIDs: []uuid.UUID{uuid.New()}}.Validate())
+}
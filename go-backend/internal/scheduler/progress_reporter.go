@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// progressPageSize is the number of runs loaded per page when reporting.
+const progressPageSize = 100
+
+// ProgressReporter periodically publishes an optimization.progress event with
+// the completion estimate of every running or paused optimization run.
+type ProgressReporter struct {
+	repos          *repository.Repositories
+	eventPublisher events.Publisher
+	clock          clock.Clock
+	logger         *zap.Logger
+
+	interval time.Duration
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewProgressReporter creates a new progress reporter.
+func NewProgressReporter(
+	cfg *config.ProgressConfig,
+	repos *repository.Repositories,
+	publisher events.Publisher,
+	logger *zap.Logger,
+) *ProgressReporter {
+	interval, err := time.ParseDuration(cfg.Interval)
+	if err != nil || interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	return &ProgressReporter{
+		repos:          repos,
+		eventPublisher: publisher,
+		clock:          clock.Real(),
+		logger:         logger,
+		interval:       interval,
+	}
+}
+
+// SetClock replaces the reporter's time source. It must be called before Start.
+func (r *ProgressReporter) SetClock(c clock.Clock) {
+	r.clock = c
+}
+
+// Start starts publishing progress periodically.
+func (r *ProgressReporter) Start() error {
+	r.logger.Info("Starting optimization progress reporter", zap.Duration("interval", r.interval))
+
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	r.ticker = r.clock.NewTicker(r.interval)
+	r.wg.Add(1)
+	go r.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the reporter.
+func (r *ProgressReporter) Stop() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.ticker != nil {
+		r.ticker.Stop()
+	}
+	r.wg.Wait()
+
+	r.logger.Info("Optimization progress reporter stopped")
+	return nil
+}
+
+// loop reports on every tick.
+func (r *ProgressReporter) loop() {
+	defer r.wg.Done()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-r.ticker.C():
+			if err := r.Report(r.ctx); err != nil {
+				r.logger.Error("Optimization progress report failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Report publishes the progress of every active run once.
+func (r *ProgressReporter) Report(ctx context.Context) error {
+	now := r.clock.Now()
+
+	for _, status := range []domain.OptimizationStatus{domain.OptimizationStatusRunning, domain.OptimizationStatusPaused, domain.OptimizationStatusStalled} {
+		query := domain.OptimizationListQuery{
+			Status:   &status,
+			OrderBy:  "created_at",
+			PageSize: progressPageSize,
+		}
+		query.SetDefaults() // Pages are counted in the possibly capped page size
+		for page := 1; ; page++ {
+			query.Page = page
+			runs, total, err := r.repos.Optimization.List(ctx, query)
+			if err != nil {
+				return fmt.Errorf("failed to list %s optimization runs: %w", status, err)
+			}
+
+			for _, run := range runs {
+				event := events.NewOptimizationProgressEvent(domain.NewOptimizationProgress(run, now))
+				if err := r.eventPublisher.Publish(ctx, events.RoutingKeyOptProgress, event); err != nil {
+					r.logger.Warn("Failed to publish optimization progress",
+						zap.String("run_id", run.ID.String()),
+						zap.Error(err),
+					)
+				}
+			}
+
+			if len(runs) == 0 || page*query.PageSize >= total {
+				break
+			}
+		}
+	}
+
+	return nil
+}
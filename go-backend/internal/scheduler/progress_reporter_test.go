@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// mockProgressRepository implements List of OptimizationRepository.
+// Other methods panic via the nil embedded interface.
+type mockProgressRepository struct {
+	repository.OptimizationRepository
+	runs []*domain.OptimizationRun
+}
+
+func (m *mockProgressRepository) List(ctx context.Context, query domain.OptimizationListQuery) ([]*domain.OptimizationRun, int, error) {
+	var matching []*domain.OptimizationRun
+	for _, run := range m.runs {
+		if query.Status == nil || run.Status == *query.Status {
+			matching = append(matching, run)
+		}
+	}
+	total := len(matching)
+	start := (query.Page - 1) * query.PageSize
+	if start > total {
+		start = total
+	}
+	end := start + query.PageSize
+	if end > total {
+		end = total
+	}
+	return matching[start:end], total, nil
+}
+
+func TestProgressReporter_Report(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	resumedAt := now.Add(-30 * time.Minute)
+
+	// Running for 2h with a 20 minute outage: 100 minutes over 4 iterations
+	running := &domain.OptimizationRun{
+		ID:               uuid.New(),
+		Status:           domain.OptimizationStatusRunning,
+		CurrentIteration: 4,
+		MaxIterations:    10,
+		CreatedAt:        now.Add(-2 * time.Hour),
+		Incidents: []domain.OptimizationIncident{
+			{Dependencies: []string{"docker"}, PausedAt: now.Add(-50 * time.Minute), ResumedAt: &resumedAt},
+		},
+	}
+	paused := &domain.OptimizationRun{
+		ID:               uuid.New(),
+		Status:           domain.OptimizationStatusPaused,
+		CurrentIteration: 1,
+		MaxIterations:    5,
+		CreatedAt:        now.Add(-time.Hour),
+	}
+	fresh := &domain.OptimizationRun{
+		ID:            uuid.New(),
+		Status:        domain.OptimizationStatusRunning,
+		MaxIterations: 5,
+		CreatedAt:     now.Add(-time.Minute),
+	}
+	completed := &domain.OptimizationRun{ID: uuid.New(), Status: domain.OptimizationStatusCompleted}
+
+	repo := &mockProgressRepository{runs: []*domain.OptimizationRun{running, paused, fresh, completed}}
+	publisher := newMockEventPublisher()
+	reporter := NewProgressReporter(&config.ProgressConfig{Enabled: true, Interval: "30s"},
+		&repository.Repositories{Optimization: repo}, publisher, zaptest.NewLogger(t))
+	reporter.SetClock(clock.NewFake(now))
+
+	require.NoError(t, reporter.Report(context.Background()))
+	require.Len(t, publisher.publishedEvents, 3)
+
+	progress := make(map[uuid.UUID]*domain.OptimizationProgress)
+	for _, e := range publisher.publishedEvents {
+		event, ok := e.(*events.OptimizationProgressEvent)
+		require.True(t, ok)
+		assert.Equal(t, events.EventTypeOptProgress, event.EventType)
+		progress[event.RunID] = event.OptimizationProgress
+	}
+
+	p := progress[running.ID]
+	assert.InDelta(t, 40.0, p.PercentComplete, 1e-9)
+	assert.Equal(t, (100 * time.Minute).Milliseconds(), p.ElapsedMs)
+	require.NotNil(t, p.AvgIterationMs)
+	assert.Equal(t, (25 * time.Minute).Milliseconds(), *p.AvgIterationMs)
+	require.NotNil(t, p.RemainingMs)
+	assert.Equal(t, (150 * time.Minute).Milliseconds(), *p.RemainingMs)
+	require.NotNil(t, p.EstimatedCompletionAt)
+	assert.Equal(t, now.Add(150*time.Minute), *p.EstimatedCompletionAt)
+
+	// Paused runs get a remaining estimate but no completion time
+	p = progress[paused.ID]
+	require.NotNil(t, p.RemainingMs)
+	assert.Equal(t, (4 * time.Hour).Milliseconds(), *p.RemainingMs)
+	assert.Nil(t, p.EstimatedCompletionAt)
+
+	// No forecast before the first iteration completes
+	p = progress[fresh.ID]
+	assert.Zero(t, p.PercentComplete)
+	assert.Nil(t, p.AvgIterationMs)
+	assert.Nil(t, p.EstimatedCompletionAt)
+}
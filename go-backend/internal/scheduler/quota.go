@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// withinQuotas drops the candidates that would take an optimization run past
+// its concurrency quota, counting the run's running jobs and the candidates
+// ahead of them. The jobs of runs at their quota are already left out of the
+// pending jobs; this keeps one fetch from dispatching more than the rest of a
+// quota. It returns the quota usage by run for the candidates kept.
+func (s *Scheduler) withinQuotas(candidates []*domain.BacktestJob) ([]*domain.BacktestJob, map[uuid.UUID]*domain.OptimizationQuotaUsage, error) {
+	var runIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, job := range candidates {
+		if job.OptimizationRunID != nil && !seen[*job.OptimizationRunID] {
+			seen[*job.OptimizationRunID] = true
+			runIDs = append(runIDs, *job.OptimizationRunID)
+		}
+	}
+	if len(runIDs) == 0 {
+		return candidates, nil, nil
+	}
+
+	usage, err := s.repos.Optimization.GetQuotaUsage(s.ctx, runIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+
+	remaining := make(map[uuid.UUID]int, len(usage))
+	for runID, u := range usage {
+		remaining[runID] = u.Remaining()
+	}
+
+	kept := make([]*domain.BacktestJob, 0, len(candidates))
+	for _, job := range candidates {
+		if job.OptimizationRunID != nil {
+			if left, ok := remaining[*job.OptimizationRunID]; ok && left >= 0 {
+				if left == 0 {
+					continue
+				}
+				remaining[*job.OptimizationRunID] = left - 1
+			}
+		}
+		kept = append(kept, job)
+	}
+	return kept, usage, nil
+}
+
+// holdQuota records the quota of a dispatched job's run, which its container
+// is limited by.
+func (s *Scheduler) holdQuota(job *domain.BacktestJob, usage map[uuid.UUID]*domain.OptimizationQuotaUsage) {
+	if job.OptimizationRunID == nil {
+		return
+	}
+	u, ok := usage[*job.OptimizationRunID]
+	if !ok {
+		return
+	}
+	s.quotaMu.Lock()
+	s.quotas[job.ID] = u.Quota
+	s.quotaMu.Unlock()
+}
+
+// releaseQuota drops the quota of a finished job.
+func (s *Scheduler) releaseQuota(jobID uuid.UUID) {
+	s.quotaMu.Lock()
+	delete(s.quotas, jobID)
+	s.quotaMu.Unlock()
+}
+
+// applyQuota sets the container limits of a dispatched job from its run's
+// quota, if it has one.
+func (s *Scheduler) applyQuota(params *docker.RunBacktestParams) {
+	s.quotaMu.Lock()
+	quota, ok := s.quotas[params.JobID]
+	s.quotaMu.Unlock()
+	if !ok {
+		return
+	}
+	params.CPULimit = quota.CPULimit
+	params.MemoryLimitMB = quota.MemoryLimitMB
+}
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockQuotaRepository reports fixed quota usage.
+type mockQuotaRepository struct {
+	repository.OptimizationRepository
+	usage map[uuid.UUID]*domain.OptimizationQuotaUsage
+}
+
+func (m *mockQuotaRepository) GetQuotaUsage(ctx context.Context, runIDs []uuid.UUID) (map[uuid.UUID]*domain.OptimizationQuotaUsage, error) {
+	usage := make(map[uuid.UUID]*domain.OptimizationQuotaUsage)
+	for _, id := range runIDs {
+		if u, ok := m.usage[id]; ok {
+			usage[id] = u
+		}
+	}
+	return usage, nil
+}
+
+func TestScheduler_WithinQuotas(t *testing.T) {
+	limited, unlimited := uuid.New(), uuid.New()
+	repo := &mockQuotaRepository{usage: map[uuid.UUID]*domain.OptimizationQuotaUsage{
+		limited: {
+			Quota:       domain.OptimizationQuota{MaxConcurrentJobs: 3, CPULimit: 0.5, MemoryLimitMB: 1024},
+			RunningJobs: 1,
+		},
+		unlimited: {Quota: domain.OptimizationQuota{CPULimit: 4}},
+	}}
+	cfg := &config.SchedulerConfig{MaxConcurrentBacktests: 2}
+	sched := NewScheduler(cfg, &repository.Repositories{Optimization: repo}, nil, nil, zaptest.NewLogger(t))
+
+	newJob := func(runID *uuid.UUID) *domain.BacktestJob {
+		return domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, runID)
+	}
+	candidates := []*domain.BacktestJob{
+		newJob(&limited), newJob(&unlimited), newJob(&limited), newJob(nil),
+		newJob(&limited), newJob(&unlimited),
+	}
+
+	kept, usage, err := sched.withinQuotas(candidates)
+	require.NoError(t, err)
+	// The limited run has two slots left, so its third candidate is dropped
+	assert.Equal(t, []*domain.BacktestJob{
+		candidates[0], candidates[1], candidates[2], candidates[3], candidates[5],
+	}, kept)
+
+	sched.holdQuota(kept[0], usage)
+	sched.holdQuota(kept[3], usage)
+	params := &docker.RunBacktestParams{JobID: kept[0].ID}
+	sched.applyQuota(params)
+	assert.Equal(t, 0.5, params.CPULimit)
+	assert.Equal(t, 1024, params.MemoryLimitMB)
+
+	// Jobs outside runs keep the default limits
+	params = &docker.RunBacktestParams{JobID: kept[3].ID}
+	sched.applyQuota(params)
+	assert.Zero(t, params.CPULimit)
+
+	sched.releaseQuota(kept[0].ID)
+	params = &docker.RunBacktestParams{JobID: kept[0].ID}
+	sched.applyQuota(params)
+	assert.Zero(t, params.MemoryLimitMB)
+}
+
+func TestScheduler_WithinQuotas_NoRuns(t *testing.T) {
+	sched := NewScheduler(&config.SchedulerConfig{MaxConcurrentBacktests: 1}, &repository.Repositories{}, nil, nil, zaptest.NewLogger(t))
+	candidates := []*domain.BacktestJob{domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)}
+
+	// The repository is not consulted for jobs outside runs
+	kept, _, err := sched.withinQuotas(candidates)
+	require.NoError(t, err)
+	assert.Equal(t, candidates, kept)
+}
@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// reconcileTimeout bounds how long the scheduler spends checking the
+// containers of running jobs when it starts.
+const reconcileTimeout = time.Minute
+
+// reconcileRunningJobs checks the jobs the database has as running against
+// Docker when the scheduler starts, so jobs orphaned by a crash or restart do
+// not stay running until they time out:
+//
+//   - a job whose container still runs is taken over: a worker waits for the
+//     container and handles its result as if it had started it;
+//   - so is a job whose container exited meanwhile; its output is read as
+//     the worker would have;
+//   - a job whose container is gone, or never started, is requeued.
+//
+// Jobs already past the job timeout, and jobs whose container's state cannot
+// be determined, are left to the timeout watcher. It must be called before
+// the workers are started.
+func (s *Scheduler) reconcileRunningJobs() {
+	ctx, cancel := context.WithTimeout(s.ctx, reconcileTimeout)
+	defer cancel()
+
+	jobs, err := s.repos.BacktestJob.GetRunningJobs(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get running jobs to reconcile", zap.Error(err))
+		return
+	}
+
+	timeout := time.Duration(s.config.JobTimeoutMinutes) * time.Minute
+	var resume []*RunningJob
+	var requeue []uuid.UUID
+	var unknown, timedOut int
+	for _, job := range jobs {
+		if job.StartedAt != nil && s.clock.Since(*job.StartedAt) >= timeout {
+			timedOut++
+			continue
+		}
+		if job.StartedAt == nil || job.ContainerID == nil || *job.ContainerID == "" || *job.ContainerID == "pending" {
+			requeue = append(requeue, job.ID)
+			continue
+		}
+
+		state, err := s.dockerManager.GetContainerState(ctx, *job.ContainerID)
+		if err != nil {
+			s.logger.Warn("Failed to check container of running job",
+				zap.String("job_id", job.ID.String()),
+				zap.String("container_id", *job.ContainerID),
+				zap.Error(err),
+			)
+			unknown++
+			continue
+		}
+
+		switch state {
+		case docker.ContainerStateRunning, docker.ContainerStateExited:
+			resume = append(resume, &RunningJob{
+				Job:         job,
+				ContainerID: *job.ContainerID,
+				DockerHost:  s.dockerManager.ContainerHost(*job.ContainerID),
+				StartedAt:   *job.StartedAt,
+			})
+		default:
+			requeue = append(requeue, job.ID)
+		}
+	}
+
+	s.resumeJobs(ctx, resume)
+	requeued := s.requeueOrphanedJobs(ctx, requeue)
+
+	if len(jobs) > 0 {
+		s.logger.Info("Reconciled running jobs",
+			zap.Int("running", len(jobs)),
+			zap.Int("resumed", len(resume)),
+			zap.Int("requeued", requeued),
+			zap.Int("timed_out", timedOut),
+			zap.Int("unknown", unknown),
+		)
+	}
+}
+
+// resumeJobs claims jobs whose container is taken over and queues them for
+// the workers.
+func (s *Scheduler) resumeJobs(ctx context.Context, resume []*RunningJob) {
+	if len(resume) == 0 {
+		return
+	}
+
+	// Retried attempts are limited by the run's quota, as dispatched jobs are
+	var runIDs []uuid.UUID
+	for _, running := range resume {
+		if running.Job.OptimizationRunID != nil {
+			runIDs = append(runIDs, *running.Job.OptimizationRunID)
+		}
+	}
+	var usage map[uuid.UUID]*domain.OptimizationQuotaUsage
+	if len(runIDs) > 0 {
+		var err error
+		if usage, err = s.repos.Optimization.GetQuotaUsage(ctx, runIDs); err != nil {
+			s.logger.Warn("Failed to get quotas of resumed jobs", zap.Error(err))
+		}
+	}
+
+	s.resumed = make(chan *RunningJob, len(resume))
+	for _, running := range resume {
+		job := running.Job
+		s.holdAffinityKeys(job)
+		s.holdQuota(job, usage)
+		s.claim(job.ID, running.StartedAt)
+		s.setClaimContainer(job.ID, running.ContainerID, running.DockerHost)
+
+		detail := "resumed after scheduler restart"
+		s.recordJobEvent(&domain.JobEvent{
+			JobID:       job.ID,
+			Type:        domain.JobEventResumed,
+			Status:      domain.JobStatusRunning,
+			ContainerID: job.ContainerID,
+			Detail:      &detail,
+		})
+		s.resumed <- running
+	}
+}
+
+// requeueOrphanedJobs returns running jobs without a container to the queue.
+// It returns the number requeued.
+func (s *Scheduler) requeueOrphanedJobs(ctx context.Context, ids []uuid.UUID) int {
+	if len(ids) == 0 {
+		return 0
+	}
+
+	requeued, err := s.repos.BacktestJob.Requeue(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to requeue orphaned jobs", zap.Error(err))
+		return 0
+	}
+	detail := "requeued after scheduler restart: container gone"
+	for _, id := range requeued {
+		s.recordJobEvent(&domain.JobEvent{
+			JobID:  id,
+			Type:   domain.JobEventRequeued,
+			Status: domain.JobStatusPending,
+			Detail: &detail,
+		})
+	}
+	return len(requeued)
+}
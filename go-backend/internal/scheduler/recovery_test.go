@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockRunningRepository lists fixed running jobs; only those are requeued.
+type mockRunningRepository struct {
+	mockRequeueRepository
+	jobs []*domain.BacktestJob
+}
+
+func (m *mockRunningRepository) GetRunningJobs(ctx context.Context) ([]*domain.BacktestJob, error) {
+	return m.jobs, nil
+}
+
+// mockContainerStateManager reports fixed container states and exit codes.
+type mockContainerStateManager struct {
+	docker.Manager
+	states map[string]docker.ContainerState
+	exits  map[string]int64
+}
+
+func (m *mockContainerStateManager) GetContainerState(ctx context.Context, containerID string) (docker.ContainerState, error) {
+	state, ok := m.states[containerID]
+	if !ok {
+		return "", errors.New("docker host unreachable")
+	}
+	return state, nil
+}
+
+func (m *mockContainerStateManager) ContainerHost(containerID string) string {
+	return ""
+}
+
+func (m *mockContainerStateManager) WaitContainer(ctx context.Context, containerID string) (int64, string, error) {
+	return m.exits[containerID], "Traceback: strategy error", nil
+}
+
+func (m *mockContainerStateManager) RemoveContainer(ctx context.Context, containerID string) error {
+	return nil
+}
+
+func TestScheduler_ReconcileRunningJobs(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	started := fake.Now().Add(-2 * time.Minute)
+	expired := fake.Now().Add(-time.Hour)
+	runningJob := func(containerID string, startedAt *time.Time) *domain.BacktestJob {
+		job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+		job.Status = domain.JobStatusRunning
+		job.StartedAt = startedAt
+		if containerID != "" {
+			job.ContainerID = &containerID
+		}
+		return job
+	}
+
+	alive := runningJob("container-alive", &started)
+	exited := runningJob("container-exited", &started)
+	gone := runningJob("container-gone", &started)
+	undispatched := runningJob("pending", &started)
+	unreachable := runningJob("container-unreachable", &started)
+	timedOut := runningJob("container-old", &expired)
+
+	repo := &mockRunningRepository{jobs: []*domain.BacktestJob{alive, exited, gone, undispatched, unreachable, timedOut}}
+	repo.running = map[uuid.UUID]bool{}
+	for _, job := range repo.jobs {
+		repo.running[job.ID] = true
+	}
+	manager := &mockContainerStateManager{
+		states: map[string]docker.ContainerState{
+			"container-alive":  docker.ContainerStateRunning,
+			"container-exited": docker.ContainerStateExited,
+			"container-gone":   docker.ContainerStateMissing,
+			"container-old":    docker.ContainerStateRunning,
+		},
+		exits: map[string]int64{"container-exited": 1},
+	}
+	cfg := &config.SchedulerConfig{MaxConcurrentBacktests: 2, JobTimeoutMinutes: 10, ReconcileOnStart: true}
+	sched := NewScheduler(cfg, &repository.Repositories{BacktestJob: repo}, manager, nil, zaptest.NewLogger(t))
+	sched.SetClock(fake)
+
+	sched.reconcileRunningJobs()
+
+	assert.ElementsMatch(t, []uuid.UUID{gone.ID, undispatched.ID}, repo.requeued)
+	var types []domain.JobEventType
+	for _, event := range repo.events {
+		types = append(types, event.Type)
+	}
+	assert.ElementsMatch(t, []domain.JobEventType{
+		domain.JobEventResumed, domain.JobEventResumed, domain.JobEventRequeued, domain.JobEventRequeued,
+	}, types)
+
+	// Jobs taken over are claimed and wait for a worker
+	claimed := sched.State().Claimed
+	require.Len(t, claimed, 2)
+	assert.ElementsMatch(t, []string{"container-alive", "container-exited"},
+		[]string{claimed[0].ContainerID, claimed[1].ContainerID})
+	require.Len(t, sched.resumed, 2)
+
+	// A worker handles the result of a container that exited meanwhile as
+	// if it had started it
+	worker := NewWorker(0, sched, zaptest.NewLogger(t))
+	for range 2 {
+		running := <-sched.resumed
+		if running.Job.ID != exited.ID {
+			continue
+		}
+		result := worker.resumeJob(context.Background(), running)
+		assert.False(t, result.Success)
+		assert.Equal(t, domain.FailureCategoryUnknown, result.FailureCategory)
+		assert.Contains(t, result.Logs, "Traceback")
+	}
+}
@@ -0,0 +1,300 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// StrategyValidator validates strategy code against a Freqtrade image.
+// Implemented by Scheduler, whose validation pool bounds how many
+// validations run at once.
+type StrategyValidator interface {
+	ValidateStrategyOnImage(ctx context.Context, code, name, image string) (*docker.ValidationResult, error)
+}
+
+// Revalidator runs pending re-validation runs one at a time, validating each
+// selected strategy against the run's image and recording whether it is
+// valid there. Validations go through the scheduler's validation pool, so a
+// large run doesn't hold up validations of new strategies for long.
+type Revalidator struct {
+	repos        *repository.Repositories
+	config       *config.RevalidationConfig
+	validator    StrategyValidator
+	defaultImage string
+	clock        clock.Clock
+	logger       *zap.Logger
+
+	interval time.Duration
+	mu       sync.Mutex // serializes passes
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRevalidator creates a new re-validation worker. Runs against
+// defaultImage, the image backtests run on, also update the validation status
+// of their strategies.
+func NewRevalidator(cfg *config.RevalidationConfig, repos *repository.Repositories, validator StrategyValidator, defaultImage string, logger *zap.Logger) *Revalidator {
+	interval, err := time.ParseDuration(cfg.PollInterval)
+	if err != nil || interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return &Revalidator{
+		repos:        repos,
+		config:       cfg,
+		validator:    validator,
+		defaultImage: defaultImage,
+		clock:        clock.Real(),
+		logger:       logger,
+		interval:     interval,
+	}
+}
+
+// SetClock replaces the revalidator's time source. It must be called before Start.
+func (v *Revalidator) SetClock(c clock.Clock) {
+	v.clock = c
+}
+
+// Start requeues runs interrupted by a previous shutdown and starts polling
+// for pending re-validation runs.
+func (v *Revalidator) Start() error {
+	v.ctx, v.cancel = context.WithCancel(context.Background())
+
+	requeued, err := v.repos.Revalidation.RequeueRunning(v.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to requeue interrupted revalidations: %w", err)
+	}
+
+	v.logger.Info("Starting revalidator",
+		zap.Duration("poll_interval", v.interval),
+		zap.Int("concurrency", v.concurrency()),
+		zap.Int("requeued", requeued),
+	)
+
+	v.ticker = v.clock.NewTicker(v.interval)
+	v.wg.Add(1)
+	go v.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the revalidator. A run in progress is abandoned and
+// requeued on the next start.
+func (v *Revalidator) Stop() error {
+	if v.cancel != nil {
+		v.cancel()
+	}
+	if v.ticker != nil {
+		v.ticker.Stop()
+	}
+	v.wg.Wait()
+
+	v.logger.Info("Revalidator stopped")
+	return nil
+}
+
+// loop drains pending runs on every tick.
+func (v *Revalidator) loop() {
+	defer v.wg.Done()
+
+	for {
+		select {
+		case <-v.ctx.Done():
+			return
+		case <-v.ticker.C():
+			for {
+				run, err := v.RunOnce(v.ctx)
+				if err != nil {
+					v.logger.Error("Revalidation pass failed", zap.Error(err))
+				}
+				if run == nil || v.ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// RunOnce claims the oldest pending re-validation run and validates its
+// strategies. It returns the run, or nil if none was pending. A failed run is
+// recorded on the run; the returned error only reports failures to claim or
+// update runs.
+func (v *Revalidator) RunOnce(ctx context.Context) (*domain.RevalidationRun, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	run, err := v.repos.Revalidation.ClaimNext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, nil
+	}
+
+	start := v.clock.Now()
+	err = v.validateAll(ctx, run)
+	if err == nil {
+		err = v.repos.Revalidation.Complete(ctx, run.ID)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down; the run is requeued on the next start
+			return run, nil
+		}
+
+		v.logger.Warn("Revalidation failed",
+			zap.String("revalidation_id", run.ID.String()),
+			zap.Error(err),
+		)
+		msg := err.Error()
+		run.Status = domain.RevalidationStatusFailed
+		run.Error = &msg
+		if err := v.repos.Revalidation.Fail(ctx, run.ID, msg); err != nil {
+			return run, fmt.Errorf("failed to mark revalidation %s failed: %w", run.ID, err)
+		}
+		return run, nil
+	}
+
+	run.Status = domain.RevalidationStatusCompleted
+	v.logger.Info("Revalidation completed",
+		zap.String("revalidation_id", run.ID.String()),
+		zap.String("image", run.Image),
+		zap.Int("valid", run.Valid),
+		zap.Int("invalid", run.Invalid),
+		zap.Int("errors", run.Errors),
+		zap.Duration("duration", v.clock.Since(start)),
+	)
+	return run, nil
+}
+
+// validateAll validates the run's strategies, at most the configured number
+// at once, recording each outcome as it is known. The run's counts are
+// updated as a side effect. It stops at the first failure to record an
+// outcome.
+func (v *Revalidator) validateAll(ctx context.Context, run *domain.RevalidationRun) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ids := make(chan uuid.UUID)
+	var (
+		wg       sync.WaitGroup
+		countsMu sync.Mutex
+		firstErr error
+	)
+	for range min(v.concurrency(), run.Total()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				validation, err := v.validate(ctx, run, id)
+				if err == nil {
+					err = v.repos.Revalidation.Record(ctx, run.ID, id, validation)
+				}
+
+				countsMu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else {
+					run.Validated++
+					switch {
+					case validation == nil:
+						run.Errors++
+					case validation.Valid:
+						run.Valid++
+					default:
+						run.Invalid++
+					}
+				}
+				countsMu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, id := range run.StrategyIDs {
+		select {
+		case ids <- id:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(ids)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// validate validates one strategy of the run. It returns nil without an error
+// for strategies that could not be validated, e.g. ones deleted since the run
+// was requested; the error only reports a failure that should stop the run.
+func (v *Revalidator) validate(ctx context.Context, run *domain.RevalidationRun, id uuid.UUID) (*domain.StrategyImageValidation, error) {
+	strategy, err := v.repos.Strategy.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result, err := v.validator.ValidateStrategyOnImage(ctx, strategy.Code, strategy.Name, run.Image)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		v.logger.Warn("Failed to validate strategy",
+			zap.String("revalidation_id", run.ID.String()),
+			zap.String("strategy_id", id.String()),
+			zap.Error(err),
+		)
+		return nil, nil
+	}
+
+	if run.Image == v.defaultImage {
+		status := domain.ValidationStatusValidated
+		if !result.Valid {
+			status = domain.ValidationStatusFailed
+		}
+		if err := v.repos.Strategy.SetValidation(ctx, id, status, result.Errors); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("failed to record strategy validation: %w", err)
+		}
+	}
+
+	runID := run.ID
+	return &domain.StrategyImageValidation{
+		StrategyID:  id,
+		Image:       run.Image,
+		Valid:       result.Valid,
+		Errors:      result.Errors,
+		RunID:       &runID,
+		ValidatedAt: v.clock.Now(),
+	}, nil
+}
+
+// concurrency returns the configured number of strategies validated at once.
+func (v *Revalidator) concurrency() int {
+	if v.config.Concurrency > 0 {
+		return v.config.Concurrency
+	}
+	return 1
+}
@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockRevalidationRepository records re-validation outcomes in memory.
+type mockRevalidationRepository struct {
+	repository.RevalidationRepository
+	mu        sync.Mutex
+	pending   []*domain.RevalidationRun
+	recorded  map[uuid.UUID]*domain.StrategyImageValidation
+	completed []uuid.UUID
+	failed    map[uuid.UUID]string
+}
+
+func (m *mockRevalidationRepository) ClaimNext(ctx context.Context) (*domain.RevalidationRun, error) {
+	if len(m.pending) == 0 {
+		return nil, nil
+	}
+	run := m.pending[0]
+	m.pending = m.pending[1:]
+	run.Status = domain.RevalidationStatusRunning
+	return run, nil
+}
+
+func (m *mockRevalidationRepository) Record(ctx context.Context, runID uuid.UUID, strategyID uuid.UUID, v *domain.StrategyImageValidation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recorded[strategyID] = v
+	return nil
+}
+
+func (m *mockRevalidationRepository) Complete(ctx context.Context, id uuid.UUID) error {
+	m.completed = append(m.completed, id)
+	return nil
+}
+
+func (m *mockRevalidationRepository) Fail(ctx context.Context, id uuid.UUID, errMsg string) error {
+	m.failed[id] = errMsg
+	return nil
+}
+
+// mockRevalidationStrategyRepository serves strategies by ID and records
+// validation statuses.
+type mockRevalidationStrategyRepository struct {
+	repository.StrategyRepository
+	mu         sync.Mutex
+	strategies map[uuid.UUID]*domain.Strategy
+	statuses   map[uuid.UUID]domain.ValidationStatus
+}
+
+func (m *mockRevalidationStrategyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Strategy, error) {
+	if s, ok := m.strategies[id]; ok {
+		return s, nil
+	}
+	return nil, domain.NewNotFoundError("strategy", id.String())
+}
+
+func (m *mockRevalidationStrategyRepository) SetValidation(ctx context.Context, id uuid.UUID, status domain.ValidationStatus, validationErrors []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[id] = status
+	return nil
+}
+
+// mockStrategyValidator fails validation of strategies whose code contains
+// "broken" and errors on strategies whose code contains "crash".
+type mockStrategyValidator struct {
+	mu     sync.Mutex
+	images []string
+}
+
+func (m *mockStrategyValidator) ValidateStrategyOnImage(ctx context.Context, code, name, image string) (*docker.ValidationResult, error) {
+	m.mu.Lock()
+	m.images = append(m.images, image)
+	m.mu.Unlock()
+
+	switch code {
+	case "crash":
+		return nil, errors.New("docker unavailable")
+	case "broken":
+		return &docker.ValidationResult{Valid: false, Errors: []string{"ImportError: talib.abstract"}}, nil
+	}
+	return &docker.ValidationResult{Valid: true, ClassName: name}, nil
+}
+
+func newRevalidationFixture(t *testing.T, defaultImage string, codes ...string) (*Revalidator, *mockRevalidationRepository, *mockRevalidationStrategyRepository, []uuid.UUID) {
+	strategies := &mockRevalidationStrategyRepository{
+		strategies: make(map[uuid.UUID]*domain.Strategy),
+		statuses:   make(map[uuid.UUID]domain.ValidationStatus),
+	}
+	var ids []uuid.UUID
+	for _, code := range codes {
+		s := domain.NewStrategy("Strategy", code, "", nil)
+		strategies.strategies[s.ID] = s
+		ids = append(ids, s.ID)
+	}
+	repo := &mockRevalidationRepository{
+		recorded: make(map[uuid.UUID]*domain.StrategyImageValidation),
+		failed:   make(map[uuid.UUID]string),
+	}
+	repos := &repository.Repositories{Revalidation: repo, Strategy: strategies}
+	cfg := &config.RevalidationConfig{PollInterval: "5s", Concurrency: 2}
+	return NewRevalidator(cfg, repos, &mockStrategyValidator{}, defaultImage, zaptest.NewLogger(t)), repo, strategies, ids
+}
+
+func TestRevalidator_RunOnce(t *testing.T) {
+	revalidator, repo, strategies, ids := newRevalidationFixture(t, "freqtrade:2025.4", "ok", "broken", "crash")
+	deleted := uuid.New()
+	run := domain.NewRevalidationRun("freqtrade:2025.6", append(ids, deleted), "admin")
+	repo.pending = []*domain.RevalidationRun{run}
+
+	got, err := revalidator.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Same(t, run, got)
+
+	assert.Equal(t, domain.RevalidationStatusCompleted, run.Status)
+	assert.Equal(t, []uuid.UUID{run.ID}, repo.completed)
+	assert.Equal(t, 4, run.Validated)
+	assert.Equal(t, 1, run.Valid)
+	assert.Equal(t, 1, run.Invalid)
+	// The strategy the validator errored on and the deleted one
+	assert.Equal(t, 2, run.Errors)
+
+	require.Len(t, repo.recorded, 4)
+	assert.True(t, repo.recorded[ids[0]].Valid)
+	assert.Equal(t, "freqtrade:2025.6", repo.recorded[ids[0]].Image)
+	assert.Equal(t, &run.ID, repo.recorded[ids[0]].RunID)
+	assert.False(t, repo.recorded[ids[1]].Valid)
+	assert.Equal(t, []string{"ImportError: talib.abstract"}, repo.recorded[ids[1]].Errors)
+	assert.Nil(t, repo.recorded[ids[2]])
+	assert.Nil(t, repo.recorded[deleted])
+
+	// Another image than the backtest image leaves strategy statuses alone
+	assert.Empty(t, strategies.statuses)
+
+	got, err = revalidator.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestRevalidator_RunOnce_DefaultImage(t *testing.T) {
+	revalidator, repo, strategies, ids := newRevalidationFixture(t, "freqtrade:2025.4", "ok", "broken")
+	run := domain.NewRevalidationRun("freqtrade:2025.4", ids, "admin")
+	repo.pending = []*domain.RevalidationRun{run}
+
+	_, err := revalidator.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[uuid.UUID]domain.ValidationStatus{
+		ids[0]: domain.ValidationStatusValidated,
+		ids[1]: domain.ValidationStatusFailed,
+	}, strategies.statuses)
+}
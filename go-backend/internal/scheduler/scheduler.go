@@ -4,16 +4,21 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
 	"github.com/saltfish/freqsearch/go-backend/internal/config"
 	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
 	"github.com/saltfish/freqsearch/go-backend/internal/docker"
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 	"github.com/saltfish/freqsearch/go-backend/internal/parser"
+	"github.com/saltfish/freqsearch/go-backend/internal/pricing"
 )
 
 // EventPublisher defines the interface for publishing events.
@@ -31,24 +36,56 @@ type Scheduler struct {
 	dockerManager  docker.Manager
 	eventPublisher EventPublisher
 	parser         *parser.Parser
+	normalizer     *pricing.Normalizer // Optional; converts profits to the reference currency
+	clock          clock.Clock
 	logger         *zap.Logger
+	host           string // Recorded on job timeline events
 
 	workers    []*Worker
 	jobChan    chan *domain.BacktestJob
 	resultChan chan *JobResult
 
-	activeJobs sync.Map // jobID -> *RunningJob
+	activeJobs sync.Map         // jobID -> *RunningJob
+	resumed    chan *RunningJob // Jobs taken over on start, waiting for a worker
+	validating chan struct{}    // Semaphore bounding concurrent validation containers
+	imports    domain.ImportAllowlist
+	safety     *domain.CodeSafetyPolicy // Nil when the safety checks are disabled
 	wg         sync.WaitGroup
 	ctx        context.Context
 	cancel     context.CancelFunc
+
+	marketData MarketDataInventory  // Nil unless backtests' data is checked before they start
+	downloader MarketDataDownloader // Nil unless missing data is downloaded
+
+	affinity     *affinitySelector
+	affinityMu   sync.Mutex
+	affinityKeys map[uuid.UUID][]string // Anti-affinity keys of dispatched jobs
+
+	quotaMu sync.Mutex
+	quotas  map[uuid.UUID]domain.OptimizationQuota // Run quotas of dispatched jobs
+
+	stateMu sync.Mutex
+	claims  map[uuid.UUID]*ClaimedJob // Unfinished jobs dispatched here
+	retryAt map[uuid.UUID]time.Time   // When jobs waiting to be retried are due
+
+	modeMu        sync.Mutex
+	mode          domain.SchedulerMode
+	modeSince     time.Time
+	modeChangedBy string
+	dispatchMu    sync.Mutex // Held while jobs are dispatched to workers
+
+	backpressure *backpressure // Nil unless backpressure is enabled
 }
 
 // RunningJob tracks a job that's currently being executed.
 type RunningJob struct {
 	Job         *domain.BacktestJob
 	ContainerID string
+	DockerHost  string // Pool host the container was placed on, if any
 	StartedAt   time.Time
 	Cancel      context.CancelFunc
+
+	cancelling atomic.Bool // Set once the job is cancelled and its container is being stopped
 }
 
 // JobResult represents the result of processing a job.
@@ -58,6 +95,14 @@ type JobResult struct {
 	Success bool
 	Error   error
 	Logs    string
+	Worker  string // Name of the worker that processed the job
+
+	// Cancelled is set for jobs cancelled while their container ran; Logs
+	// holds the container's output up to when it was stopped.
+	Cancelled bool
+
+	// FailureCategory classifies the failure of an unsuccessful job.
+	FailureCategory domain.FailureCategory
 }
 
 // Config holds scheduler configuration.
@@ -79,20 +124,79 @@ func NewScheduler(
 ) *Scheduler {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	host, err := os.Hostname()
+	if err != nil {
+		logger.Warn("Failed to determine hostname for job events", zap.Error(err))
+	}
+
+	validationConcurrency := cfg.ValidationConcurrency
+	if validationConcurrency <= 0 {
+		validationConcurrency = 1
+	}
+
+	maxDeferral := 10 * time.Minute
+	if cfg.AffinityMaxDeferral != "" {
+		if parsed, err := time.ParseDuration(cfg.AffinityMaxDeferral); err == nil {
+			maxDeferral = parsed
+		}
+	}
+
+	var bp *backpressure
+	if cfg.Backpressure.Enabled {
+		bp = newBackpressure(&cfg.Backpressure)
+	}
+
 	return &Scheduler{
 		config:         cfg,
 		repos:          repos,
 		dockerManager:  dockerManager,
 		eventPublisher: eventPublisher,
 		parser:         parser.NewParser(logger),
+		clock:          clock.Real(),
 		logger:         logger,
+		host:           host,
 		jobChan:        make(chan *domain.BacktestJob, cfg.MaxConcurrentBacktests),
 		resultChan:     make(chan *JobResult, cfg.MaxConcurrentBacktests),
+		validating:     make(chan struct{}, validationConcurrency),
+		imports:        domain.NewImportAllowlist(cfg.AllowedImports...),
+		safety:         cfg.CodeSafety.Policy(),
+		affinity:       newAffinitySelector(maxDeferral, cfg.PriorityAgingPerHour),
+		affinityKeys:   make(map[uuid.UUID][]string),
+		quotas:         make(map[uuid.UUID]domain.OptimizationQuota),
+		claims:         make(map[uuid.UUID]*ClaimedJob),
+		retryAt:        make(map[uuid.UUID]time.Time),
+		mode:           domain.SchedulerModeRunning,
+		modeSince:      time.Now(),
+		backpressure:   bp,
 		ctx:            ctx,
 		cancel:         cancel,
 	}
 }
 
+// SetClock replaces the scheduler's time source. It must be called before Start.
+func (s *Scheduler) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// SetNormalizer enables converting result profits to a reference currency
+// before results are stored. It must be called before Start.
+func (s *Scheduler) SetNormalizer(n *pricing.Normalizer) {
+	s.normalizer = n
+}
+
+// SetParserRegistry replaces the backtest output formats results are parsed
+// with. It must be called before Start.
+func (s *Scheduler) SetParserRegistry(r *parser.Registry) {
+	s.parser.SetRegistry(r)
+}
+
+// SetDataCache lets jobs preferring cached market data be started ahead of
+// jobs of equal priority whose data is not on this host. It must be called
+// before Start.
+func (s *Scheduler) SetDataCache(c DataCache) {
+	s.affinity.cache = c
+}
+
 // Start starts the scheduler and workers.
 func (s *Scheduler) Start() error {
 	s.logger.Info("Starting scheduler",
@@ -100,6 +204,16 @@ func (s *Scheduler) Start() error {
 		zap.Int("poll_interval_seconds", s.config.PollIntervalSeconds),
 	)
 
+	// Resume from the state saved before a restart
+	if s.config.StateFile != "" {
+		s.restoreState()
+	}
+
+	// Take over or requeue the jobs left running
+	if s.config.ReconcileOnStart {
+		s.reconcileRunningJobs()
+	}
+
 	// Start workers
 	for i := 0; i < s.config.MaxConcurrentBacktests; i++ {
 		worker := NewWorker(i, s, s.logger)
@@ -120,6 +234,21 @@ func (s *Scheduler) Start() error {
 	s.wg.Add(1)
 	go s.watchTimeouts()
 
+	// Start cancellation watcher
+	s.wg.Add(1)
+	go s.watchCancellations()
+
+	if s.config.StateFile != "" {
+		s.wg.Add(1)
+		go s.persistState()
+	}
+
+	// Start host load watcher
+	if s.backpressure != nil {
+		s.wg.Add(1)
+		go s.watchHostLoad()
+	}
+
 	s.logger.Info("Scheduler started")
 	return nil
 }
@@ -149,12 +278,19 @@ func (s *Scheduler) Stop() error {
 	select {
 	case <-done:
 		s.logger.Info("Scheduler stopped gracefully")
-	case <-time.After(timeout):
+	case <-s.clock.After(timeout):
 		s.logger.Warn("Scheduler shutdown timed out")
 		// Force stop any running containers
 		s.forceStopRunningJobs()
 	}
 
+	// Jobs still claimed are requeued by the next scheduler
+	if s.config.StateFile != "" {
+		if err := s.saveState(); err != nil {
+			s.logger.Error("Failed to save scheduler state", zap.Error(err))
+		}
+	}
+
 	return nil
 }
 
@@ -162,14 +298,14 @@ func (s *Scheduler) Stop() error {
 func (s *Scheduler) fetchJobs() {
 	defer s.wg.Done()
 
-	ticker := time.NewTicker(time.Duration(s.config.PollIntervalSeconds) * time.Second)
+	ticker := s.clock.NewTicker(time.Duration(s.config.PollIntervalSeconds) * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-s.ctx.Done():
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			s.fetchAndDispatch()
 		}
 	}
@@ -177,18 +313,41 @@ func (s *Scheduler) fetchJobs() {
 
 // fetchAndDispatch fetches pending jobs and dispatches them to workers.
 func (s *Scheduler) fetchAndDispatch() {
+	s.dispatchMu.Lock()
+	defer s.dispatchMu.Unlock()
+
+	if !s.dispatching() {
+		return
+	}
+
+	// Leave jobs queued, possibly for other schedulers, while the Docker
+	// host is loaded
+	if s.backpressure != nil && s.backpressure.holding() {
+		return
+	}
+
 	// Calculate how many jobs we can take
 	available := cap(s.jobChan) - len(s.jobChan)
 	if available <= 0 {
 		return
 	}
 
-	// Fetch pending jobs using FOR UPDATE SKIP LOCKED
-	jobs, err := s.repos.BacktestJob.GetPendingJobs(s.ctx, available)
+	// Fetch pending jobs using FOR UPDATE SKIP LOCKED. More are fetched than
+	// can be taken, so job hints can pick among them. Jobs are aged by the
+	// scheduler's clock, in the query and when picking among them alike.
+	now := s.clock.Now()
+	candidates, err := s.repos.BacktestJob.GetPendingJobs(s.ctx, available*affinityLookahead, s.config.PriorityAgingPerHour, now)
 	if err != nil {
 		s.logger.Error("Failed to fetch pending jobs", zap.Error(err))
 		return
 	}
+	candidates = s.dueJobs(candidates, now)
+	candidates, usage, err := s.withinQuotas(candidates)
+	if err != nil {
+		s.logger.Error("Failed to apply optimization quotas", zap.Error(err))
+		return
+	}
+	jobs := s.affinity.selectJobs(candidates, s.heldAffinityKeys(), available, now)
 
 	for _, job := range jobs {
 		// Mark job as running
@@ -202,8 +361,17 @@ func (s *Scheduler) fetchAndDispatch() {
 
 		// Update job status
 		job.Status = domain.JobStatusRunning
-		now := time.Now()
-		job.StartedAt = &now
+		startedAt := s.clock.Now()
+		job.StartedAt = &startedAt
+		s.holdAffinityKeys(job)
+		s.holdQuota(job, usage)
+		s.claim(job.ID, startedAt)
+
+		s.recordJobEvent(&domain.JobEvent{
+			JobID:  job.ID,
+			Type:   domain.JobEventDispatched,
+			Status: domain.JobStatusRunning,
+		})
 
 		// Publish event
 		if s.eventPublisher != nil {
@@ -216,6 +384,7 @@ func (s *Scheduler) fetchAndDispatch() {
 			s.logger.Debug("Dispatched job",
 				zap.String("job_id", job.ID.String()),
 				zap.Int("priority", job.Priority),
+				zap.Int("effective_priority", job.EffectivePriority(now, s.config.PriorityAgingPerHour)),
 			)
 		case <-s.ctx.Done():
 			return
@@ -223,6 +392,38 @@ func (s *Scheduler) fetchAndDispatch() {
 	}
 }
 
+// heldAffinityKeys returns the anti-affinity keys of the jobs running here.
+func (s *Scheduler) heldAffinityKeys() map[string]bool {
+	s.affinityMu.Lock()
+	defer s.affinityMu.Unlock()
+
+	held := make(map[string]bool)
+	for _, keys := range s.affinityKeys {
+		for _, key := range keys {
+			held[key] = true
+		}
+	}
+	return held
+}
+
+// holdAffinityKeys records the anti-affinity keys of a dispatched job.
+func (s *Scheduler) holdAffinityKeys(job *domain.BacktestJob) {
+	keys := job.AntiAffinityKeys()
+	if len(keys) == 0 {
+		return
+	}
+	s.affinityMu.Lock()
+	s.affinityKeys[job.ID] = keys
+	s.affinityMu.Unlock()
+}
+
+// releaseAffinityKeys drops the anti-affinity keys of a finished job.
+func (s *Scheduler) releaseAffinityKeys(jobID uuid.UUID) {
+	s.affinityMu.Lock()
+	delete(s.affinityKeys, jobID)
+	s.affinityMu.Unlock()
+}
+
 // handleResults processes job results from workers.
 func (s *Scheduler) handleResults() {
 	defer s.wg.Done()
@@ -243,8 +444,26 @@ func (s *Scheduler) processResult(result *JobResult) {
 
 	// Remove from active jobs
 	s.activeJobs.Delete(job.ID)
+	s.releaseAffinityKeys(job.ID)
+	s.releaseQuota(job.ID)
+	s.releaseClaim(job.ID)
+
+	if result.Cancelled {
+		s.storePartialLog(result)
+		return
+	}
 
 	if result.Success && result.Result != nil {
+		// Normalize profit to the reference currency; the result is stored either way
+		if s.normalizer != nil {
+			if err := s.normalizer.Normalize(s.ctx, result.Result); err != nil {
+				s.logger.Warn("Failed to normalize result profit",
+					zap.String("job_id", job.ID.String()),
+					zap.Error(err),
+				)
+			}
+		}
+
 		// Save result to database
 		if err := s.repos.Result.Create(s.ctx, result.Result); err != nil {
 			s.logger.Error("Failed to save backtest result",
@@ -259,6 +478,14 @@ func (s *Scheduler) processResult(result *JobResult) {
 				zap.String("job_id", job.ID.String()),
 				zap.Error(err),
 			)
+		} else {
+			s.recordJobEvent(&domain.JobEvent{
+				JobID:       job.ID,
+				Type:        domain.JobEventCompleted,
+				Status:      domain.JobStatusCompleted,
+				ContainerID: job.ContainerID,
+				Worker:      optionalString(result.Worker),
+			})
 		}
 
 		// Publish event
@@ -276,17 +503,33 @@ func (s *Scheduler) processResult(result *JobResult) {
 		if result.Error != nil {
 			errMsg = result.Error.Error()
 		}
+		reason := errMsg
+		category := result.FailureCategory
+		if category == "" {
+			category = domain.FailureCategoryUnknown
+		}
 
 		// Append logs to error message for debugging
 		if result.Logs != "" {
-			errMsg = errMsg + "\n\n--- Container Logs ---\n" + result.Logs
+			errMsg = errMsg + ContainerLogsMarker + result.Logs
 		}
 
-		if err := s.repos.BacktestJob.MarkFailed(s.ctx, job.ID, errMsg); err != nil {
+		if err := s.repos.BacktestJob.MarkFailed(s.ctx, job.ID, category, errMsg); err != nil {
 			s.logger.Error("Failed to mark job failed",
 				zap.String("job_id", job.ID.String()),
 				zap.Error(err),
 			)
+		} else {
+			job.Status = domain.JobStatusFailed
+			job.FailureCategory = &category
+			s.recordJobEvent(&domain.JobEvent{
+				JobID:       job.ID,
+				Type:        domain.JobEventFailed,
+				Status:      domain.JobStatusFailed,
+				ContainerID: job.ContainerID,
+				Worker:      optionalString(result.Worker),
+				Detail:      &reason,
+			})
 		}
 
 		// Publish event
@@ -296,6 +539,7 @@ func (s *Scheduler) processResult(result *JobResult) {
 
 		s.logger.Warn("Job failed",
 			zap.String("job_id", job.ID.String()),
+			zap.String("failure_category", category.String()),
 			zap.Error(result.Error),
 		)
 	}
@@ -305,7 +549,7 @@ func (s *Scheduler) processResult(result *JobResult) {
 func (s *Scheduler) watchTimeouts() {
 	defer s.wg.Done()
 
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := s.clock.NewTicker(30 * time.Second)
 	defer ticker.Stop()
 
 	timeout := time.Duration(s.config.JobTimeoutMinutes) * time.Minute
@@ -314,7 +558,7 @@ func (s *Scheduler) watchTimeouts() {
 		select {
 		case <-s.ctx.Done():
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			s.checkTimeouts(timeout)
 		}
 	}
@@ -353,11 +597,22 @@ func (s *Scheduler) checkTimeouts(timeout time.Duration) {
 		}
 
 		// Mark as failed
-		if err := s.repos.BacktestJob.MarkFailed(s.ctx, job.ID, "job timed out"); err != nil {
+		if err := s.repos.BacktestJob.MarkFailed(s.ctx, job.ID, domain.FailureCategoryTimeout, "job timed out"); err != nil {
 			s.logger.Error("Failed to mark timed out job as failed",
 				zap.String("job_id", job.ID.String()),
 				zap.Error(err),
 			)
+		} else {
+			category := domain.FailureCategoryTimeout
+			job.FailureCategory = &category
+			detail := "job timed out"
+			s.recordJobEvent(&domain.JobEvent{
+				JobID:       job.ID,
+				Type:        domain.JobEventFailed,
+				Status:      domain.JobStatusFailed,
+				ContainerID: job.ContainerID,
+				Detail:      &detail,
+			})
 		}
 
 		// Publish event
@@ -367,6 +622,39 @@ func (s *Scheduler) checkTimeouts(timeout time.Duration) {
 	}
 }
 
+// recordJobEvent appends an event to a job's timeline, stamped with this host
+// and the current time. Failures are logged but don't affect the job.
+func (s *Scheduler) recordJobEvent(event *domain.JobEvent) {
+	event.Host = optionalString(s.host)
+	event.OccurredAt = s.clock.Now()
+
+	if err := s.repos.BacktestJob.AddEvent(s.ctx, event); err != nil {
+		s.logger.Warn("Failed to record job event",
+			zap.String("job_id", event.JobID.String()),
+			zap.String("event", string(event.Type)),
+			zap.Error(err),
+		)
+	}
+}
+
+// optionalString returns nil for an empty string.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// dockerHostDetail returns the detail of a container_started event placed on
+// a pool host, or nil without a pool.
+func dockerHostDetail(dockerHost string) *string {
+	if dockerHost == "" {
+		return nil
+	}
+	detail := "docker host " + dockerHost
+	return &detail
+}
+
 // forceStopRunningJobs stops all running containers during shutdown.
 func (s *Scheduler) forceStopRunningJobs() {
 	s.activeJobs.Range(func(key, value interface{}) bool {
@@ -414,18 +702,81 @@ func (s *Scheduler) GetStats() map[string]interface{} {
 	return stats
 }
 
+// WorkerCount returns the configured number of concurrent backtest workers.
+func (s *Scheduler) WorkerCount() int {
+	return s.config.MaxConcurrentBacktests
+}
+
+// PriorityAgingPerHour returns the priority levels a pending job gains per
+// hour it waits; see domain.BacktestJob.EffectivePriority.
+func (s *Scheduler) PriorityAgingPerHour() float64 {
+	return s.config.PriorityAgingPerHour
+}
+
 // Scheduler errors
 var (
 	ErrContainerStartFailed = errors.New("container failed to start")
 	ErrDockerDaemonError    = errors.New("docker daemon error")
 	ErrStrategyCodeError    = errors.New("strategy code error")
+	ErrDataMissing          = errors.New("backtest data missing")
+	ErrOutOfMemory          = errors.New("container ran out of memory")
+	ErrResultParseFailed    = errors.New("failed to parse backtest result")
 )
 
 // ValidateStrategy validates strategy code using Docker container.
-// This is faster than running a full backtest.
+// This is faster than running a full backtest. It waits for a free slot in
+// the validation pool, so at most ValidationConcurrency validations run at once.
+//
+// The code is checked statically first: strategies failing the safety checks
+// or importing packages the backtest image lacks fail without starting a
+// container, with an error per violation and unresolved import. Unresolved
+// imports outside module level are warnings.
 func (s *Scheduler) ValidateStrategy(ctx context.Context, code string, name string) (*docker.ValidationResult, error) {
-	return s.dockerManager.ValidateStrategy(ctx, &docker.ValidateStrategyParams{
+	return s.ValidateStrategyOnImage(ctx, code, name, "")
+}
+
+// ValidateStrategyOnImage validates strategy code as ValidateStrategy does,
+// against the given Freqtrade image; empty uses the validator's default.
+func (s *Scheduler) ValidateStrategyOnImage(ctx context.Context, code, name, image string) (*docker.ValidationResult, error) {
+	violations := s.safety.Check(code)
+	unresolved := domain.AnalyzeImports(code, s.imports)
+	var errs, warnings []string
+	for _, v := range violations {
+		errs = append(errs, v.Message())
+	}
+	for _, u := range unresolved {
+		if u.Optional {
+			warnings = append(warnings, u.Message())
+		} else {
+			errs = append(errs, u.Message())
+		}
+	}
+	if len(errs) > 0 {
+		return &docker.ValidationResult{
+			Valid:             false,
+			Errors:            errs,
+			Warnings:          warnings,
+			UnresolvedImports: unresolved,
+			SafetyViolations:  violations,
+		}, nil
+	}
+
+	select {
+	case s.validating <- struct{}{}:
+		defer func() { <-s.validating }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	result, err := s.dockerManager.ValidateStrategy(ctx, &docker.ValidateStrategyParams{
 		StrategyCode: code,
 		StrategyName: name,
+		BaseImage:    image,
 	})
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = append(result.Warnings, warnings...)
+	result.UnresolvedImports = unresolved
+	return result, nil
 }
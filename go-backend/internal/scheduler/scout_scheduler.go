@@ -2,31 +2,43 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
 	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 	"github.com/saltfish/freqsearch/go-backend/internal/events"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
 )
 
+// scoutCronParser parses the five-field cron expressions of Scout schedules.
+// A "CRON_TZ=<zone>" prefix evaluates the expression in that timezone.
+var scoutCronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
 // ScoutScheduler manages cron-based Scout runs.
 type ScoutScheduler struct {
 	repos          *repository.Repositories
 	eventPublisher events.Publisher
+	secrets        *secrets.Store
+	sourceLimits   map[string]domain.ScoutSourceLimits
+	clock          clock.Clock
 	logger         *zap.Logger
 
 	cronParser   cron.Parser
 	schedules    map[uuid.UUID]*scheduledTask
 	mu           sync.RWMutex
 	pollInterval time.Duration
+	paused       atomic.Bool // Set in maintenance mode; due schedules wait
 
-	ticker *time.Ticker
+	ticker clock.Ticker
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
@@ -48,13 +60,43 @@ func NewScoutScheduler(
 	return &ScoutScheduler{
 		repos:          repos,
 		eventPublisher: publisher,
+		clock:          clock.Real(),
 		logger:         logger,
-		cronParser:     cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow),
+		cronParser:     scoutCronParser,
 		schedules:      make(map[uuid.UUID]*scheduledTask),
 		pollInterval:   30 * time.Second,
 	}
 }
 
+// EnterMaintenance stops starting scheduled runs. Schedules that fall due
+// meanwhile run once maintenance mode is over.
+func (s *ScoutScheduler) EnterMaintenance() {
+	s.paused.Store(true)
+}
+
+// ExitMaintenance starts scheduled runs again.
+func (s *ScoutScheduler) ExitMaintenance() {
+	s.paused.Store(false)
+}
+
+// SetClock replaces the scheduler's time source. It must be called before Start.
+func (s *ScoutScheduler) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// SetSecrets sets the store that source credentials referenced by schedules
+// are decrypted from. It must be called before Start.
+func (s *ScoutScheduler) SetSecrets(store *secrets.Store) {
+	s.secrets = store
+}
+
+// SetSourceLimits sets the etiquette limits of Scout sources, keyed by source.
+// Scheduled runs that would break them are skipped. It must be called before
+// Start.
+func (s *ScoutScheduler) SetSourceLimits(limits map[string]domain.ScoutSourceLimits) {
+	s.sourceLimits = limits
+}
+
 // Start starts the scheduler.
 func (s *ScoutScheduler) Start() error {
 	s.logger.Info("Starting Scout scheduler",
@@ -70,7 +112,7 @@ func (s *ScoutScheduler) Start() error {
 	}
 
 	// Start ticker loop
-	s.ticker = time.NewTicker(s.pollInterval)
+	s.ticker = s.clock.NewTicker(s.pollInterval)
 	s.wg.Add(1)
 	go s.schedulerLoop()
 
@@ -132,7 +174,7 @@ func (s *ScoutScheduler) ReloadSchedules() error {
 		}
 
 		// Calculate next run time
-		now := time.Now()
+		now := s.clock.Now()
 		nextRun := cronSpec.Next(now)
 
 		// Update next_run_at in database if it's different
@@ -194,7 +236,7 @@ func (s *ScoutScheduler) loadSchedules() error {
 		}
 
 		// Calculate next run time
-		now := time.Now()
+		now := s.clock.Now()
 		nextRun := cronSpec.Next(now)
 
 		// Update next_run_at in database if it's different
@@ -238,7 +280,7 @@ func (s *ScoutScheduler) schedulerLoop() {
 		select {
 		case <-s.ctx.Done():
 			return
-		case <-s.ticker.C:
+		case <-s.ticker.C():
 			s.checkSchedules()
 		}
 	}
@@ -246,8 +288,12 @@ func (s *ScoutScheduler) schedulerLoop() {
 
 // checkSchedules checks if any schedules are due and executes them.
 func (s *ScoutScheduler) checkSchedules() {
+	if s.paused.Load() {
+		return
+	}
+
 	s.mu.RLock()
-	now := time.Now()
+	now := s.clock.Now()
 
 	// Find due schedules
 	var dueSchedules []*domain.ScoutSchedule
@@ -285,8 +331,20 @@ func (s *ScoutScheduler) executeSchedule(schedule *domain.ScoutSchedule) {
 		schedule.MaxStrategies,
 	)
 
-	// Save to database
-	if err := s.repos.Scout.CreateRun(s.ctx, run); err != nil {
+	// Save to database, unless the source was scouted too recently, e.g. by
+	// an overlapping schedule
+	err := s.repos.Scout.CreateRunWithinLimits(s.ctx, run, s.sourceLimits[schedule.Source])
+	if errors.Is(err, domain.ErrScoutRateLimited) {
+		s.logger.Warn("Skipped scheduled Scout run over source limits",
+			zap.String("schedule_id", schedule.ID.String()),
+			zap.String("schedule_name", schedule.Name),
+			zap.String("source", schedule.Source),
+			zap.Error(err),
+		)
+		s.advanceSchedule(schedule)
+		return
+	}
+	if err != nil {
 		s.logger.Error("Failed to create Scout run",
 			zap.String("schedule_id", schedule.ID.String()),
 			zap.String("schedule_name", schedule.Name),
@@ -303,31 +361,30 @@ func (s *ScoutScheduler) executeSchedule(schedule *domain.ScoutSchedule) {
 		)
 	}
 
-	// Calculate next run time
-	s.mu.Lock()
-	task, exists := s.schedules[schedule.ID]
-	if exists {
-		nextRun := task.CronSpec.Next(time.Now())
-		task.NextRun = nextRun
-
-		// Update in database
-		if err := s.repos.Scout.UpdateScheduleNextRun(s.ctx, schedule.ID, nextRun); err != nil {
-			s.logger.Warn("Failed to update schedule next run",
-				zap.String("schedule_id", schedule.ID.String()),
-				zap.Error(err),
-			)
-		}
-
-		s.logger.Debug("Updated next run time",
-			zap.String("schedule_id", schedule.ID.String()),
-			zap.Time("next_run", nextRun),
-		)
-	}
-	s.mu.Unlock()
+	s.advanceSchedule(schedule)
 
 	// Publish scout.trigger event
 	if s.eventPublisher != nil {
 		event := events.NewScoutTriggerEvent(run)
+		if schedule.CredentialSecretID != nil {
+			if _, err := s.secrets.Reveal(s.ctx, *schedule.CredentialSecretID); err != nil {
+				// The agent cannot fetch without the credential, so fail the
+				// run rather than trigger it
+				s.logger.Error("Failed to read source credential",
+					zap.String("schedule_id", schedule.ID.String()),
+					zap.String("secret_id", schedule.CredentialSecretID.String()),
+					zap.Error(err),
+				)
+				if err := s.repos.Scout.FailRun(s.ctx, run.ID, "failed to read source credential: "+err.Error()); err != nil {
+					s.logger.Warn("Failed to mark Scout run failed",
+						zap.String("run_id", run.ID.String()),
+						zap.Error(err),
+					)
+				}
+				return
+			}
+			event.CredentialSecretID = schedule.CredentialSecretID
+		}
 		if err := s.eventPublisher.PublishScoutTrigger(event); err != nil {
 			s.logger.Error("Failed to publish scout trigger event",
 				zap.String("run_id", run.ID.String()),
@@ -345,6 +402,30 @@ func (s *ScoutScheduler) executeSchedule(schedule *domain.ScoutSchedule) {
 	)
 }
 
+// advanceSchedule moves a schedule that fired on to its next run time.
+func (s *ScoutScheduler) advanceSchedule(schedule *domain.ScoutSchedule) {
+	s.mu.Lock()
+	task, exists := s.schedules[schedule.ID]
+	if exists {
+		nextRun := task.CronSpec.Next(s.clock.Now())
+		task.NextRun = nextRun
+
+		// Update in database
+		if err := s.repos.Scout.UpdateScheduleNextRun(s.ctx, schedule.ID, nextRun); err != nil {
+			s.logger.Warn("Failed to update schedule next run",
+				zap.String("schedule_id", schedule.ID.String()),
+				zap.Error(err),
+			)
+		}
+
+		s.logger.Debug("Updated next run time",
+			zap.String("schedule_id", schedule.ID.String()),
+			zap.Time("next_run", nextRun),
+		)
+	}
+	s.mu.Unlock()
+}
+
 // calculateNextRun calculates the next run time for a cron expression.
 func (s *ScoutScheduler) calculateNextRun(cronExpr string) (time.Time, error) {
 	cronSpec, err := s.cronParser.Parse(cronExpr)
@@ -352,5 +433,49 @@ func (s *ScoutScheduler) calculateNextRun(cronExpr string) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("failed to parse cron expression: %w", err)
 	}
 
-	return cronSpec.Next(time.Now()), nil
+	return cronSpec.Next(s.clock.Now()), nil
+}
+
+// PreviewFireTimes returns the next count times after from at which a Scout
+// schedule with the given cron expression would fire, in the expression's
+// timezone (from's when it has none), along with that timezone.
+func PreviewFireTimes(cronExpr string, from time.Time, count int) ([]time.Time, *time.Location, error) {
+	cronSpec, err := scoutCronParser.Parse(cronExpr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse cron expression: %w", err)
+	}
+
+	loc := cronLocation(cronSpec, from.Location())
+
+	times := make([]time.Time, 0, count)
+	next := from
+	for len(times) < count {
+		next = cronSpec.Next(next)
+		if next.IsZero() {
+			// The expression never fires again, e.g. February 30th
+			break
+		}
+		times = append(times, next.In(loc))
+	}
+	return times, loc, nil
+}
+
+// ScheduleTimezone returns the timezone a Scout schedule's cron expression is
+// evaluated in: its CRON_TZ zone, or fallback when it has none. An invalid
+// expression yields fallback.
+func ScheduleTimezone(cronExpr string, fallback *time.Location) *time.Location {
+	cronSpec, err := scoutCronParser.Parse(cronExpr)
+	if err != nil {
+		return fallback
+	}
+	return cronLocation(cronSpec, fallback)
+}
+
+// cronLocation returns the zone of an expression with a CRON_TZ prefix, or
+// fallback for one without.
+func cronLocation(cronSpec cron.Schedule, fallback *time.Location) *time.Location {
+	if spec, ok := cronSpec.(*cron.SpecSchedule); ok && spec.Location != time.Local {
+		return spec.Location
+	}
+	return fallback
 }
@@ -23,6 +23,8 @@ type mockScoutRepository struct {
 	nextRunUpdates  map[uuid.UUID]time.Time
 	createRunErr    error
 	getSchedulesErr error
+	limits          []domain.ScoutSourceLimits
+	rateLimited     bool
 }
 
 func newMockScoutRepository() *mockScoutRepository {
@@ -42,6 +44,14 @@ func (m *mockScoutRepository) CreateRun(ctx context.Context, run *domain.ScoutRu
 	return nil
 }
 
+func (m *mockScoutRepository) CreateRunWithinLimits(ctx context.Context, run *domain.ScoutRun, limits domain.ScoutSourceLimits) error {
+	m.limits = append(m.limits, limits)
+	if m.rateLimited {
+		return &domain.ScoutRateLimitError{Source: run.Source, Reason: "reached its cap", RetryAfter: time.Now().Add(time.Hour)}
+	}
+	return m.CreateRun(ctx, run)
+}
+
 func (m *mockScoutRepository) GetActiveSchedules(ctx context.Context) ([]*domain.ScoutSchedule, error) {
 	if m.getSchedulesErr != nil {
 		return nil, m.getSchedulesErr
@@ -69,6 +79,9 @@ func (m *mockScoutRepository) UpdateRun(ctx context.Context, run *domain.ScoutRu
 func (m *mockScoutRepository) UpdateRunStatus(ctx context.Context, id uuid.UUID, status domain.ScoutRunStatus, errorMsg *string) error {
 	return nil
 }
+func (m *mockScoutRepository) CancelRun(ctx context.Context, id uuid.UUID, c domain.Cancellation) error {
+	return nil
+}
 func (m *mockScoutRepository) CompleteRun(ctx context.Context, id uuid.UUID, metrics *domain.ScoutMetrics) error {
 	return nil
 }
@@ -81,6 +94,15 @@ func (m *mockScoutRepository) ListRuns(ctx context.Context, query domain.ScoutRu
 func (m *mockScoutRepository) GetActiveRun(ctx context.Context) (*domain.ScoutRun, error) {
 	return nil, nil
 }
+func (m *mockScoutRepository) AggregateMetrics(ctx context.Context, query domain.ScoutMetricsQuery) (*domain.ScoutMetricsSummary, error) {
+	return &domain.ScoutMetricsSummary{}, nil
+}
+func (m *mockScoutRepository) RecordDiscoveries(ctx context.Context, discoveries []*domain.ScoutDiscovery) error {
+	return nil
+}
+func (m *mockScoutRepository) ListDiscoveries(ctx context.Context, query domain.ScoutDiscoveryQuery) ([]*domain.ScoutDiscovery, error) {
+	return nil, nil
+}
 func (m *mockScoutRepository) CreateSchedule(ctx context.Context, schedule *domain.ScoutSchedule) error {
 	return nil
 }
@@ -128,6 +150,10 @@ func (m *mockEventPublisher) Publish(ctx context.Context, routingKey string, eve
 	return nil
 }
 
+func (m *mockEventPublisher) PublishTaskCreated(job *domain.BacktestJob) error {
+	return nil
+}
+
 func (m *mockEventPublisher) PublishTaskRunning(job *domain.BacktestJob) error {
 	return nil
 }
@@ -144,12 +170,48 @@ func (m *mockEventPublisher) PublishTaskCancelled(job *domain.BacktestJob) error
 	return nil
 }
 
+func (m *mockEventPublisher) PublishOptimizationStarted(run *domain.OptimizationRun) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishOptimizationIteration(event *events.OptimizationIterationEvent) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishOptimizationCompleted(run *domain.OptimizationRun) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishOptimizationFailed(run *domain.OptimizationRun, reason string) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishOptimizationStatusChanged(run *domain.OptimizationRun, oldStatus, newStatus string) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishOptimizationIterationRetry(event *events.OptimizationIterationRetryEvent) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishStrategyCreated(strategy *domain.Strategy) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishStrategyUpdated(strategy *domain.Strategy) error {
+	return nil
+}
+
+func (m *mockEventPublisher) PublishStrategyDeleted(strategy *domain.Strategy) error {
+	return nil
+}
+
 func (m *mockEventPublisher) PublishScoutTrigger(event *events.ScoutTriggerEvent) error {
 	m.publishedEvents = append(m.publishedEvents, event)
 	return nil
 }
 
-func (m *mockEventPublisher) PublishScoutCancelled(runID uuid.UUID) error {
+func (m *mockEventPublisher) PublishScoutCancelled(runID uuid.UUID, c domain.Cancellation) error {
 	return nil
 }
 
@@ -266,6 +328,37 @@ func TestScoutScheduler_ExecuteSchedule(t *testing.T) {
 	assert.Equal(t, schedule.MaxStrategies, event.MaxStrategies)
 }
 
+func TestScoutScheduler_ExecuteSchedule_SourceLimits(t *testing.T) {
+	mockScout := newMockScoutRepository()
+	publisher := newMockEventPublisher()
+	limits := domain.ScoutSourceLimits{MinInterval: time.Hour, MaxRunsPerDay: 4}
+
+	scheduler := NewScoutScheduler(newMockRepositories(mockScout), publisher, zaptest.NewLogger(t))
+	scheduler.SetSourceLimits(map[string]domain.ScoutSourceLimits{"stratninja": limits})
+	scheduler.ctx, scheduler.cancel = context.WithCancel(context.Background())
+	defer scheduler.cancel()
+
+	schedule := domain.NewScoutSchedule("limited", "0 * * * *", "stratninja", 15)
+	cronSpec, err := scheduler.cronParser.Parse(schedule.CronExpression)
+	require.NoError(t, err)
+	scheduler.schedules[schedule.ID] = &scheduledTask{Schedule: schedule, CronSpec: cronSpec}
+
+	// A run over the limits is skipped, and the schedule moves on
+	mockScout.rateLimited = true
+	scheduler.executeSchedule(schedule)
+	assert.Equal(t, []domain.ScoutSourceLimits{limits}, mockScout.limits)
+	assert.Empty(t, mockScout.runs)
+	assert.Empty(t, publisher.publishedEvents)
+	assert.Empty(t, mockScout.lastRunUpdates)
+	assert.False(t, scheduler.schedules[schedule.ID].NextRun.IsZero())
+
+	// Sources without limits are not limited
+	mockScout.rateLimited = false
+	scheduler.executeSchedule(domain.NewScoutSchedule("other", "0 * * * *", "github", 15))
+	assert.Equal(t, domain.ScoutSourceLimits{}, mockScout.limits[1])
+	assert.Len(t, mockScout.runs, 1)
+}
+
 func TestScoutScheduler_CalculateNextRun(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	mockScout := newMockScoutRepository()
@@ -320,6 +413,44 @@ func TestScoutScheduler_CalculateNextRun(t *testing.T) {
 	}
 }
 
+func TestPreviewFireTimes(t *testing.T) {
+	from := time.Date(2025, 3, 29, 22, 30, 0, 0, time.UTC)
+
+	times, loc, err := PreviewFireTimes("0 * * * *", from, 3)
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 3, 29, 23, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 30, 1, 0, 0, 0, time.UTC),
+	}, times)
+
+	// Evaluated in the expression's timezone, across the DST change
+	times, loc, err = PreviewFireTimes("CRON_TZ=Europe/Berlin 30 2 * * *", from, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", loc.String())
+	require.Len(t, times, 2)
+	assert.Equal(t, loc, times[0].Location())
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 30, 0, 0, time.UTC), times[0].UTC())
+
+	// Never fires
+	times, _, err = PreviewFireTimes("0 0 30 2 *", from, 5)
+	require.NoError(t, err)
+	assert.Empty(t, times)
+
+	_, _, err = PreviewFireTimes("invalid", from, 5)
+	assert.Error(t, err)
+}
+
+func TestScheduleTimezone(t *testing.T) {
+	server, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	assert.Equal(t, server, ScheduleTimezone("0 * * * *", server))
+	assert.Equal(t, "Europe/Berlin", ScheduleTimezone("CRON_TZ=Europe/Berlin 30 2 * * *", server).String())
+	assert.Equal(t, server, ScheduleTimezone("invalid", server))
+}
+
 func TestScoutScheduler_CheckSchedules(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	mockScout := newMockScoutRepository()
@@ -0,0 +1,252 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// slaBreachKey identifies a breach so each one is announced only once.
+type slaBreachKey struct {
+	kind  domain.SLAKind
+	jobID uuid.UUID
+}
+
+// SLAMonitor periodically checks the backtest queue against the SLA targets,
+// keeps a time series of breach counts, and publishes a system.sla_breach event
+// the first time each job breaches a target.
+type SLAMonitor struct {
+	repos          *repository.Repositories
+	eventPublisher events.Publisher
+	config         *config.SLAConfig
+	targets        domain.SLATargets
+	clock          clock.Clock
+	logger         *zap.Logger
+
+	interval time.Duration
+
+	mu          sync.RWMutex
+	samples     []domain.SLASample // Oldest first, capped at HistorySize
+	breaches    []*domain.SLABreach
+	reported    map[slaBreachKey]struct{}
+	totals      map[domain.SLAKind]int64
+	lastCheckAt *time.Time
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewSLAMonitor creates a new SLA monitor.
+func NewSLAMonitor(
+	cfg *config.SLAConfig,
+	repos *repository.Repositories,
+	publisher events.Publisher,
+	logger *zap.Logger,
+) *SLAMonitor {
+	interval, err := time.ParseDuration(cfg.CheckInterval)
+	if err != nil || interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &SLAMonitor{
+		repos:          repos,
+		eventPublisher: publisher,
+		config:         cfg,
+		targets: domain.SLATargets{
+			MaxQueueWait:   parseSLATarget(cfg.MaxQueueWait),
+			MaxRunDuration: parseSLATarget(cfg.MaxRunDuration),
+		},
+		clock:    clock.Real(),
+		logger:   logger,
+		interval: interval,
+		reported: make(map[slaBreachKey]struct{}),
+		totals:   make(map[domain.SLAKind]int64),
+	}
+}
+
+// parseSLATarget parses a target duration, treating empty or invalid values as unset.
+func parseSLATarget(v string) time.Duration {
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// SetClock replaces the monitor's time source. It must be called before Start.
+func (m *SLAMonitor) SetClock(c clock.Clock) {
+	m.clock = c
+}
+
+// Targets returns the SLA targets being tracked.
+func (m *SLAMonitor) Targets() domain.SLATargets {
+	return m.targets
+}
+
+// Start starts checking the queue periodically.
+func (m *SLAMonitor) Start() error {
+	m.logger.Info("Starting SLA monitor",
+		zap.Duration("interval", m.interval),
+		zap.Duration("max_queue_wait", m.targets.MaxQueueWait),
+		zap.Duration("max_run_duration", m.targets.MaxRunDuration),
+	)
+
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.ticker = m.clock.NewTicker(m.interval)
+	m.wg.Add(1)
+	go m.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the monitor.
+func (m *SLAMonitor) Stop() error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	if m.ticker != nil {
+		m.ticker.Stop()
+	}
+	m.wg.Wait()
+
+	m.logger.Info("SLA monitor stopped")
+	return nil
+}
+
+// loop runs a check on every tick.
+func (m *SLAMonitor) loop() {
+	defer m.wg.Done()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-m.ticker.C():
+			if err := m.Check(m.ctx); err != nil {
+				m.logger.Error("SLA check failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Check samples the queue once and records the result in the time series.
+// Breaches not present in the previous check are counted and announced, so a
+// job is announced once per target while it stays in breach.
+func (m *SLAMonitor) Check(ctx context.Context) error {
+	now := m.clock.Now()
+	breaches, err := m.repos.BacktestJob.GetSLABreaches(ctx, m.targets, now)
+	if err != nil {
+		return fmt.Errorf("failed to get SLA breaches: %w", err)
+	}
+
+	sample := domain.SLASample{Timestamp: now}
+	current := make(map[slaBreachKey]struct{}, len(breaches))
+	var fresh []*domain.SLABreach
+
+	m.mu.Lock()
+	for _, b := range breaches {
+		key := slaBreachKey{kind: b.Kind, jobID: b.JobID}
+		current[key] = struct{}{}
+
+		switch b.Kind {
+		case domain.SLAKindQueueWait:
+			sample.QueueWaitBreaches++
+		case domain.SLAKindRunDuration:
+			sample.RunDurationBreaches++
+		}
+
+		if _, ok := m.reported[key]; !ok {
+			m.totals[b.Kind]++
+			fresh = append(fresh, b)
+		}
+	}
+	sample.NewBreaches = len(fresh)
+
+	m.reported = current
+	m.breaches = breaches
+	m.lastCheckAt = &now
+	m.samples = append(m.samples, sample)
+	if over := len(m.samples) - m.config.HistorySize; over > 0 && m.config.HistorySize > 0 {
+		m.samples = append([]domain.SLASample(nil), m.samples[over:]...)
+	}
+	m.mu.Unlock()
+
+	for _, b := range fresh {
+		m.logger.Warn("Backtest job breached SLA",
+			zap.String("kind", string(b.Kind)),
+			zap.String("job_id", b.JobID.String()),
+			zap.Int64("elapsed_ms", b.ElapsedMs),
+			zap.Int64("target_ms", b.TargetMs),
+		)
+
+		if m.eventPublisher == nil {
+			continue
+		}
+		event := events.NewSLABreachEvent(b)
+		if err := m.eventPublisher.Publish(ctx, events.RoutingKeySystemSLABreach, event); err != nil {
+			m.logger.Warn("Failed to publish SLA breach event",
+				zap.String("job_id", b.JobID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// Status returns a summary of the latest check.
+func (m *SLAMonitor) Status() domain.SLAStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.statusLocked()
+}
+
+// statusLocked builds the status. The caller must hold m.mu.
+func (m *SLAMonitor) statusLocked() domain.SLAStatus {
+	status := domain.SLAStatus{
+		MaxQueueWaitMs:   m.targets.MaxQueueWait.Milliseconds(),
+		MaxRunDurationMs: m.targets.MaxRunDuration.Milliseconds(),
+		BreachesTotal:    make(map[domain.SLAKind]int64, len(m.totals)),
+		LastCheckAt:      m.lastCheckAt,
+	}
+	for kind, n := range m.totals {
+		status.BreachesTotal[kind] = n
+	}
+	if n := len(m.samples); n > 0 {
+		status.QueueWaitBreaches = m.samples[n-1].QueueWaitBreaches
+		status.RunDurationBreaches = m.samples[n-1].RunDurationBreaches
+	}
+	return status
+}
+
+// Report returns the breach time series since the given time along with the
+// jobs currently in breach. A zero since returns the whole retained history.
+func (m *SLAMonitor) Report(since time.Time) *domain.SLAReport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	report := &domain.SLAReport{
+		SLAStatus: m.statusLocked(),
+		Breaches:  append([]*domain.SLABreach{}, m.breaches...),
+		Samples:   []domain.SLASample{},
+	}
+	for _, s := range m.samples {
+		if !s.Timestamp.Before(since) {
+			report.Samples = append(report.Samples, s)
+		}
+	}
+	return report
+}
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// mockSLARepository implements GetSLABreaches of BacktestJobRepository.
+// Other methods panic via the nil embedded interface.
+type mockSLARepository struct {
+	repository.BacktestJobRepository
+	breaches    []*domain.SLABreach
+	lastTargets domain.SLATargets
+}
+
+func (m *mockSLARepository) GetSLABreaches(ctx context.Context, targets domain.SLATargets, now time.Time) ([]*domain.SLABreach, error) {
+	m.lastTargets = targets
+	return m.breaches, nil
+}
+
+func newTestSLAMonitor(t *testing.T, historySize int) (*SLAMonitor, *mockSLARepository, *mockEventPublisher, *clock.Fake) {
+	cfg := &config.SLAConfig{
+		Enabled:       true,
+		CheckInterval: "1m",
+		MaxQueueWait:  "15m",
+		HistorySize:   historySize,
+	}
+	repo := &mockSLARepository{}
+	publisher := newMockEventPublisher()
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	monitor := NewSLAMonitor(cfg, &repository.Repositories{BacktestJob: repo}, publisher, zaptest.NewLogger(t))
+	monitor.SetClock(fake)
+	return monitor, repo, publisher, fake
+}
+
+func TestSLAMonitor_AnnouncesEachBreachOnce(t *testing.T) {
+	monitor, repo, publisher, fake := newTestSLAMonitor(t, 10)
+	ctx := context.Background()
+
+	first := &domain.SLABreach{Kind: domain.SLAKindQueueWait, JobID: uuid.New(), ElapsedMs: 20 * 60 * 1000, TargetMs: 15 * 60 * 1000}
+	second := &domain.SLABreach{Kind: domain.SLAKindQueueWait, JobID: uuid.New()}
+
+	repo.breaches = []*domain.SLABreach{first}
+	require.NoError(t, monitor.Check(ctx))
+	assert.Equal(t, 15*time.Minute, repo.lastTargets.MaxQueueWait)
+	assert.Zero(t, repo.lastTargets.MaxRunDuration)
+
+	// The same breach persists, and a new one appears
+	fake.Advance(time.Minute)
+	repo.breaches = []*domain.SLABreach{first, second}
+	require.NoError(t, monitor.Check(ctx))
+
+	require.Len(t, publisher.publishedEvents, 2)
+	event, ok := publisher.publishedEvents[0].(*events.SLABreachEvent)
+	require.True(t, ok)
+	assert.Equal(t, events.EventTypeSystemSLABreach, event.EventType)
+	assert.Equal(t, first.JobID, event.JobID)
+	assert.Equal(t, first.TargetMs, event.TargetMs)
+
+	// Once cleared, a recurring breach is announced again
+	fake.Advance(time.Minute)
+	repo.breaches = nil
+	require.NoError(t, monitor.Check(ctx))
+	fake.Advance(time.Minute)
+	repo.breaches = []*domain.SLABreach{first}
+	require.NoError(t, monitor.Check(ctx))
+	assert.Len(t, publisher.publishedEvents, 3)
+
+	status := monitor.Status()
+	assert.Equal(t, 1, status.QueueWaitBreaches)
+	assert.Equal(t, int64(3), status.BreachesTotal[domain.SLAKindQueueWait])
+	assert.Equal(t, int64(15*60*1000), status.MaxQueueWaitMs)
+	require.NotNil(t, status.LastCheckAt)
+	assert.Equal(t, fake.Now(), *status.LastCheckAt)
+}
+
+func TestSLAMonitor_ReportTimeSeries(t *testing.T) {
+	monitor, repo, _, fake := newTestSLAMonitor(t, 3)
+	ctx := context.Background()
+	start := fake.Now()
+
+	for i := 0; i < 5; i++ {
+		repo.breaches = make([]*domain.SLABreach, i)
+		for j := range repo.breaches {
+			repo.breaches[j] = &domain.SLABreach{Kind: domain.SLAKindQueueWait, JobID: uuid.New()}
+		}
+		require.NoError(t, monitor.Check(ctx))
+		fake.Advance(time.Minute)
+	}
+
+	// Only the last HistorySize samples are retained
+	report := monitor.Report(time.Time{})
+	require.Len(t, report.Samples, 3)
+	assert.Equal(t, start.Add(2*time.Minute), report.Samples[0].Timestamp)
+	assert.Equal(t, 2, report.Samples[0].QueueWaitBreaches)
+	assert.Equal(t, 4, report.Samples[2].QueueWaitBreaches)
+	assert.Len(t, report.Breaches, 4)
+
+	report = monitor.Report(start.Add(4 * time.Minute))
+	require.Len(t, report.Samples, 1)
+	assert.Equal(t, 4, report.Samples[0].NewBreaches)
+}
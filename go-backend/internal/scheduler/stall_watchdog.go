@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// StallWatchdog flags running optimizations that recorded no iteration and
+// had no backtest job activity for longer than the configured threshold, so
+// operators can step in on runs whose orchestrator has silently stopped. A
+// stalled run that shows activity again is returned to running.
+type StallWatchdog struct {
+	repos          *repository.Repositories
+	eventPublisher events.Publisher
+	config         *config.StallConfig
+	clock          clock.Clock
+	logger         *zap.Logger
+
+	interval  time.Duration
+	threshold time.Duration
+	mu        sync.Mutex // serializes checks
+
+	ticker clock.Ticker
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewStallWatchdog creates a new stall watchdog.
+func NewStallWatchdog(
+	cfg *config.StallConfig,
+	repos *repository.Repositories,
+	publisher events.Publisher,
+	logger *zap.Logger,
+) *StallWatchdog {
+	interval, err := time.ParseDuration(cfg.CheckInterval)
+	if err != nil || interval <= 0 {
+		interval = time.Minute
+	}
+	threshold, err := time.ParseDuration(cfg.Threshold)
+	if err != nil || threshold <= 0 {
+		threshold = 30 * time.Minute
+	}
+
+	return &StallWatchdog{
+		repos:          repos,
+		eventPublisher: publisher,
+		config:         cfg,
+		clock:          clock.Real(),
+		logger:         logger,
+		interval:       interval,
+		threshold:      threshold,
+	}
+}
+
+// SetClock replaces the watchdog's time source. It must be called before Start.
+func (w *StallWatchdog) SetClock(c clock.Clock) {
+	w.clock = c
+}
+
+// Start starts checking for stalled runs periodically.
+func (w *StallWatchdog) Start() error {
+	w.logger.Info("Starting stall watchdog",
+		zap.Duration("interval", w.interval),
+		zap.Duration("threshold", w.threshold),
+	)
+
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.ticker = w.clock.NewTicker(w.interval)
+	w.wg.Add(1)
+	go w.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the watchdog.
+func (w *StallWatchdog) Stop() error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if w.ticker != nil {
+		w.ticker.Stop()
+	}
+	w.wg.Wait()
+
+	w.logger.Info("Stall watchdog stopped")
+	return nil
+}
+
+// loop runs a check on every tick.
+func (w *StallWatchdog) loop() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-w.ticker.C():
+			if err := w.Check(w.ctx); err != nil {
+				w.logger.Error("Stall check failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Check returns stalled runs with fresh activity to running, then flags
+// running runs inactive for longer than the threshold as stalled and
+// publishes an optimization.stalled event for each.
+func (w *StallWatchdog) Check(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	revived, err := w.repos.Optimization.ReviveStalled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revive stalled optimizations: %w", err)
+	}
+	for _, run := range revived {
+		w.logger.Info("Stalled optimization made progress, resumed",
+			zap.String("run_id", run.ID.String()),
+		)
+		w.publishStatusChanged(run, domain.OptimizationStatusStalled, domain.OptimizationStatusRunning)
+	}
+
+	now := w.clock.Now()
+	stalled, err := w.repos.Optimization.MarkStalled(ctx, now.Add(-w.threshold), now)
+	if err != nil {
+		return fmt.Errorf("failed to mark stalled optimizations: %w", err)
+	}
+
+	for _, s := range stalled {
+		w.logger.Warn("Optimization stalled",
+			zap.String("run_id", s.Run.ID.String()),
+			zap.String("name", s.Run.Name),
+			zap.Int("current_iteration", s.Run.CurrentIteration),
+			zap.Time("last_activity_at", s.LastActivityAt),
+			zap.Duration("inactive", s.StalledFor(now)),
+		)
+
+		w.publishStatusChanged(s.Run, domain.OptimizationStatusRunning, domain.OptimizationStatusStalled)
+		if w.eventPublisher == nil {
+			continue
+		}
+		event := events.NewOptimizationStalledEvent(s, now, w.threshold)
+		if err := w.eventPublisher.Publish(ctx, events.RoutingKeyOptStalled, event); err != nil {
+			w.logger.Warn("Failed to publish optimization stalled event",
+				zap.String("run_id", s.Run.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// publishStatusChanged publishes an optimization status change, logging failures.
+func (w *StallWatchdog) publishStatusChanged(run *domain.OptimizationRun, oldStatus, newStatus domain.OptimizationStatus) {
+	if w.eventPublisher == nil {
+		return
+	}
+	if err := w.eventPublisher.PublishOptimizationStatusChanged(run, oldStatus.String(), newStatus.String()); err != nil {
+		w.logger.Warn("Failed to publish optimization status change",
+			zap.String("run_id", run.ID.String()),
+			zap.String("new_status", newStatus.String()),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+)
+
+// mockStallRepository implements MarkStalled and ReviveStalled of OptimizationRepository
+// against per-run last activity times.
+type mockStallRepository struct {
+	repository.OptimizationRepository
+	runs         []*domain.OptimizationRun
+	lastActivity map[uuid.UUID]time.Time
+}
+
+func (m *mockStallRepository) MarkStalled(ctx context.Context, inactiveSince, at time.Time) ([]*domain.StalledOptimization, error) {
+	var stalled []*domain.StalledOptimization
+	for _, run := range m.runs {
+		last := m.lastActivity[run.ID]
+		if run.Status != domain.OptimizationStatusRunning || !last.Before(inactiveSince) {
+			continue
+		}
+		run.Status = domain.OptimizationStatusStalled
+		stalledAt := at
+		run.StalledAt = &stalledAt
+		stalled = append(stalled, &domain.StalledOptimization{Run: run, LastActivityAt: last})
+	}
+	return stalled, nil
+}
+
+func (m *mockStallRepository) ReviveStalled(ctx context.Context) ([]*domain.OptimizationRun, error) {
+	var revived []*domain.OptimizationRun
+	for _, run := range m.runs {
+		if run.Status != domain.OptimizationStatusStalled || !m.lastActivity[run.ID].After(*run.StalledAt) {
+			continue
+		}
+		run.Status = domain.OptimizationStatusRunning
+		run.StalledAt = nil
+		revived = append(revived, run)
+	}
+	return revived, nil
+}
+
+func TestStallWatchdog_FlagsAndRevives(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+
+	idle := &domain.OptimizationRun{ID: uuid.New(), Name: "idle", Status: domain.OptimizationStatusRunning, MaxIterations: 10}
+	busy := &domain.OptimizationRun{ID: uuid.New(), Name: "busy", Status: domain.OptimizationStatusRunning, MaxIterations: 10}
+	repo := &mockStallRepository{
+		runs: []*domain.OptimizationRun{idle, busy},
+		lastActivity: map[uuid.UUID]time.Time{
+			idle.ID: start.Add(-40 * time.Minute),
+			busy.ID: start.Add(-5 * time.Minute),
+		},
+	}
+	publisher := &recordingPublisher{mockEventPublisher: newMockEventPublisher()}
+
+	cfg := &config.StallConfig{Enabled: true, CheckInterval: "1m", Threshold: "30m"}
+	watchdog := NewStallWatchdog(cfg, &repository.Repositories{Optimization: repo}, publisher, zaptest.NewLogger(t))
+	watchdog.SetClock(fake)
+
+	// Only the run inactive past the threshold is flagged
+	require.NoError(t, watchdog.Check(ctx))
+	assert.Equal(t, domain.OptimizationStatusStalled, idle.Status)
+	assert.Equal(t, domain.OptimizationStatusRunning, busy.Status)
+	assert.Equal(t, []string{"running->stalled"}, publisher.statusChanges)
+
+	require.Len(t, publisher.publishedEvents, 1)
+	event, ok := publisher.publishedEvents[0].(*events.OptimizationStalledEvent)
+	require.True(t, ok)
+	assert.Equal(t, idle.ID, event.OptimizationRunID)
+	assert.Equal(t, (40 * time.Minute).Milliseconds(), event.InactiveMs)
+	assert.Equal(t, (30 * time.Minute).Milliseconds(), event.ThresholdMs)
+
+	// A stalled run is not flagged again
+	fake.Advance(time.Minute)
+	require.NoError(t, watchdog.Check(ctx))
+	assert.Len(t, publisher.publishedEvents, 1)
+
+	// New activity returns it to running
+	repo.lastActivity[idle.ID] = fake.Now()
+	fake.Advance(time.Minute)
+	require.NoError(t, watchdog.Check(ctx))
+	assert.Equal(t, domain.OptimizationStatusRunning, idle.Status)
+	assert.Nil(t, idle.StalledAt)
+	assert.Equal(t, []string{"running->stalled", "stalled->running"}, publisher.statusChanges)
+}
@@ -0,0 +1,322 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// stateSaveInterval is how often a running scheduler saves its state, bounding
+// what a crash loses.
+const stateSaveInterval = 15 * time.Second
+
+// State is a snapshot of the scheduler's in-memory state. Saved to the state
+// file, it lets a restarted scheduler requeue the jobs it had claimed right
+// away instead of waiting for them to time out, and resume its timers.
+type State struct {
+	Host    string    `json:"host"`
+	SavedAt time.Time `json:"saved_at"`
+
+	// Mode carries over, so a scheduler paused for maintenance stays paused
+	Mode          domain.SchedulerMode `json:"mode"`
+	ModeSince     time.Time            `json:"mode_since"`
+	ModeChangedBy string               `json:"mode_changed_by,omitempty"`
+
+	Claimed  []ClaimedJob   `json:"claimed"`  // Dispatched here and not finished, oldest first
+	Retries  []RetryBackoff `json:"retries"`  // Jobs waiting before a retry
+	Deferred []DeferredJob  `json:"deferred"` // Pending jobs passed over by affinity hints
+}
+
+// ClaimedJob is a job the scheduler marked running. Until a worker starts
+// its container the job's container ID is "pending" in the database.
+type ClaimedJob struct {
+	JobID       uuid.UUID `json:"job_id"`
+	ClaimedAt   time.Time `json:"claimed_at"`
+	ContainerID string    `json:"container_id,omitempty"` // Set once the container started
+	DockerHost  string    `json:"docker_host,omitempty"`  // Pool host the container was placed on
+}
+
+// RetryBackoff is a failed job due to be retried at RetryAt.
+type RetryBackoff struct {
+	JobID   uuid.UUID `json:"job_id"`
+	RetryAt time.Time `json:"retry_at"`
+}
+
+// DeferredJob is a pending job passed over since Since; it is started
+// regardless of its hints once passed over for the affinity max deferral.
+type DeferredJob struct {
+	JobID uuid.UUID `json:"job_id"`
+	Since time.Time `json:"since"`
+}
+
+// State returns a snapshot of the scheduler's in-memory state.
+func (s *Scheduler) State() *State {
+	state := &State{
+		Host:     s.host,
+		SavedAt:  s.clock.Now(),
+		Claimed:  []ClaimedJob{},
+		Retries:  []RetryBackoff{},
+		Deferred: []DeferredJob{},
+	}
+
+	s.modeMu.Lock()
+	state.Mode, state.ModeSince, state.ModeChangedBy = s.mode, s.modeSince, s.modeChangedBy
+	s.modeMu.Unlock()
+
+	s.stateMu.Lock()
+	for _, claim := range s.claims {
+		state.Claimed = append(state.Claimed, *claim)
+	}
+	for id, at := range s.retryAt {
+		state.Retries = append(state.Retries, RetryBackoff{JobID: id, RetryAt: at})
+	}
+	s.stateMu.Unlock()
+
+	for id, since := range s.affinity.deferrals() {
+		state.Deferred = append(state.Deferred, DeferredJob{JobID: id, Since: since})
+	}
+
+	sort.Slice(state.Claimed, func(i, j int) bool { return state.Claimed[i].ClaimedAt.Before(state.Claimed[j].ClaimedAt) })
+	sort.Slice(state.Retries, func(i, j int) bool { return state.Retries[i].RetryAt.Before(state.Retries[j].RetryAt) })
+	sort.Slice(state.Deferred, func(i, j int) bool { return state.Deferred[i].Since.Before(state.Deferred[j].Since) })
+
+	return state
+}
+
+// claim records a job dispatched here.
+func (s *Scheduler) claim(jobID uuid.UUID, at time.Time) {
+	s.stateMu.Lock()
+	s.claims[jobID] = &ClaimedJob{JobID: jobID, ClaimedAt: at}
+	s.stateMu.Unlock()
+}
+
+// setClaimContainer records the container a claimed job runs in and the
+// Docker host it was placed on, if there is a pool of them.
+func (s *Scheduler) setClaimContainer(jobID uuid.UUID, containerID, dockerHost string) {
+	s.stateMu.Lock()
+	if claim, ok := s.claims[jobID]; ok {
+		claim.ContainerID = containerID
+		claim.DockerHost = dockerHost
+	}
+	s.stateMu.Unlock()
+}
+
+// releaseClaim forgets a job once its result is handled.
+func (s *Scheduler) releaseClaim(jobID uuid.UUID) {
+	s.stateMu.Lock()
+	delete(s.claims, jobID)
+	delete(s.retryAt, jobID)
+	s.stateMu.Unlock()
+}
+
+// setRetryAt records when a failed job is due to be retried; a zero time
+// clears it.
+func (s *Scheduler) setRetryAt(jobID uuid.UUID, at time.Time) {
+	s.stateMu.Lock()
+	if at.IsZero() {
+		delete(s.retryAt, jobID)
+	} else {
+		s.retryAt[jobID] = at
+	}
+	s.stateMu.Unlock()
+}
+
+// dueJobs drops the candidates whose retry backoff, restored from a saved
+// state, has not run out yet.
+func (s *Scheduler) dueJobs(candidates []*domain.BacktestJob, now time.Time) []*domain.BacktestJob {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	if len(s.retryAt) == 0 {
+		return candidates
+	}
+	due := candidates[:0:0]
+	for _, job := range candidates {
+		if at, ok := s.retryAt[job.ID]; ok {
+			if now.Before(at) {
+				continue
+			}
+			delete(s.retryAt, job.ID)
+		}
+		due = append(due, job)
+	}
+	return due
+}
+
+// persistState saves the scheduler state periodically while it runs.
+func (s *Scheduler) persistState() {
+	defer s.wg.Done()
+
+	ticker := s.clock.NewTicker(stateSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C():
+			if err := s.saveState(); err != nil {
+				s.logger.Warn("Failed to save scheduler state", zap.Error(err))
+			}
+		}
+	}
+}
+
+// saveState writes the scheduler state to the state file. The file is
+// replaced atomically, so a crash mid-write leaves the previous state.
+func (s *Scheduler) saveState() error {
+	data, err := json.MarshalIndent(s.State(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduler state: %w", err)
+	}
+
+	path := s.config.StateFile
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+	return nil
+}
+
+// loadState reads a saved scheduler state. It returns nil without error if
+// there is none.
+func loadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode state file: %w", err)
+	}
+	return &state, nil
+}
+
+// restoreState resumes from the state saved by the previous scheduler on
+// this host: the jobs it had claimed are requeued, stopping any container
+// still running them, and its mode, retry backoffs and affinity deferrals
+// carry over. A state from another host is ignored, as are the jobs and
+// timers of a state older than the job timeout; the timeout watcher handles
+// those jobs. The state file is removed
+// once read, so it is never applied twice.
+func (s *Scheduler) restoreState() {
+	path := s.config.StateFile
+	state, err := loadState(path)
+	if err != nil {
+		s.logger.Warn("Ignoring scheduler state", zap.String("path", path), zap.Error(err))
+		return
+	}
+	if state == nil {
+		return
+	}
+	if err := os.Remove(path); err != nil {
+		s.logger.Warn("Failed to remove scheduler state file", zap.String("path", path), zap.Error(err))
+	}
+
+	if state.Host != s.host {
+		s.logger.Warn("Ignoring scheduler state saved on another host",
+			zap.String("path", path),
+			zap.String("host", state.Host),
+		)
+		return
+	}
+
+	if state.Mode.IsValid() {
+		s.modeMu.Lock()
+		s.mode, s.modeSince, s.modeChangedBy = state.Mode, state.ModeSince, state.ModeChangedBy
+		s.modeMu.Unlock()
+	}
+
+	now := s.clock.Now()
+	timeout := time.Duration(s.config.JobTimeoutMinutes) * time.Minute
+	if now.Sub(state.SavedAt) > timeout {
+		s.logger.Info("Ignoring jobs of scheduler state older than the job timeout",
+			zap.Time("saved_at", state.SavedAt),
+		)
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(state.Claimed))
+	for _, claim := range state.Claimed {
+		ids = append(ids, claim.JobID)
+		if claim.ContainerID == "" || claim.ContainerID == "pending" {
+			continue
+		}
+		if err := s.dockerManager.StopContainer(s.ctx, claim.ContainerID); err != nil {
+			s.logger.Debug("Failed to stop container of claimed job",
+				zap.String("job_id", claim.JobID.String()),
+				zap.String("container_id", claim.ContainerID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	requeued, err := s.repos.BacktestJob.Requeue(s.ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to requeue claimed jobs", zap.Error(err))
+	}
+	detail := "requeued after scheduler restart"
+	for _, id := range requeued {
+		s.recordJobEvent(&domain.JobEvent{
+			JobID:  id,
+			Type:   domain.JobEventRequeued,
+			Status: domain.JobStatusPending,
+			Detail: &detail,
+		})
+	}
+
+	// Only requeued jobs are still waiting for their retry
+	pending := make(map[uuid.UUID]bool, len(requeued))
+	for _, id := range requeued {
+		pending[id] = true
+	}
+	s.stateMu.Lock()
+	for _, backoff := range state.Retries {
+		if pending[backoff.JobID] && backoff.RetryAt.After(now) {
+			s.retryAt[backoff.JobID] = backoff.RetryAt
+		}
+	}
+	s.stateMu.Unlock()
+
+	deferred := make(map[uuid.UUID]time.Time, len(state.Deferred))
+	for _, d := range state.Deferred {
+		deferred[d.JobID] = d.Since
+	}
+	s.affinity.restoreDeferrals(deferred)
+
+	s.logger.Info("Restored scheduler state",
+		zap.String("mode", string(state.Mode)),
+		zap.Int("claimed", len(state.Claimed)),
+		zap.Int("requeued", len(requeued)),
+		zap.Int("retries", len(state.Retries)),
+		zap.Int("deferred", len(state.Deferred)),
+	)
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockRequeueRepository implements the job methods used when restoring state.
+// Only jobs in running are requeued.
+type mockRequeueRepository struct {
+	repository.BacktestJobRepository
+	running  map[uuid.UUID]bool
+	requeued []uuid.UUID
+	events   []*domain.JobEvent
+}
+
+func (m *mockRequeueRepository) Requeue(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	var requeued []uuid.UUID
+	for _, id := range ids {
+		if m.running[id] {
+			requeued = append(requeued, id)
+		}
+	}
+	m.requeued = append(m.requeued, requeued...)
+	return requeued, nil
+}
+
+func (m *mockRequeueRepository) AddEvent(ctx context.Context, event *domain.JobEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+// mockStopManager records the containers stopped.
+type mockStopManager struct {
+	docker.Manager
+	stopped []string
+}
+
+func (m *mockStopManager) StopContainer(ctx context.Context, containerID string) error {
+	m.stopped = append(m.stopped, containerID)
+	return nil
+}
+
+func newStateScheduler(t *testing.T, path string, repo *mockRequeueRepository, manager *mockStopManager, fake *clock.Fake) *Scheduler {
+	cfg := &config.SchedulerConfig{MaxConcurrentBacktests: 2, JobTimeoutMinutes: 10, StateFile: path}
+	sched := NewScheduler(cfg, &repository.Repositories{BacktestJob: repo}, manager, nil, zaptest.NewLogger(t))
+	sched.SetClock(fake)
+	return sched
+}
+
+func TestScheduler_SaveAndRestoreState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "scheduler.json")
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	started, queued, finished, deferred := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	before := newStateScheduler(t, path, &mockRequeueRepository{}, &mockStopManager{}, fake)
+	before.claim(started, fake.Now().Add(-time.Minute))
+	before.setClaimContainer(started, "container-1", "docker-1")
+	before.claim(queued, fake.Now())
+	before.setRetryAt(queued, fake.Now().Add(retryBackoff))
+	before.claim(finished, fake.Now())
+	before.releaseClaim(finished)
+	before.affinity.restoreDeferrals(map[uuid.UUID]time.Time{deferred: fake.Now().Add(-5 * time.Minute)})
+
+	state := before.State()
+	assert.Equal(t, []ClaimedJob{
+		{JobID: started, ClaimedAt: fake.Now().Add(-time.Minute), ContainerID: "container-1", DockerHost: "docker-1"},
+		{JobID: queued, ClaimedAt: fake.Now()},
+	}, state.Claimed)
+	assert.Equal(t, []RetryBackoff{{JobID: queued, RetryAt: fake.Now().Add(retryBackoff)}}, state.Retries)
+	require.NoError(t, before.saveState())
+
+	fake.Advance(2 * time.Second)
+	repo := &mockRequeueRepository{running: map[uuid.UUID]bool{started: true, queued: true}}
+	manager := &mockStopManager{}
+	after := newStateScheduler(t, path, repo, manager, fake)
+	after.restoreState()
+
+	assert.ElementsMatch(t, []uuid.UUID{started, queued}, repo.requeued)
+	assert.Equal(t, []string{"container-1"}, manager.stopped)
+	require.Len(t, repo.events, 2)
+	assert.Equal(t, domain.JobEventRequeued, repo.events[0].Type)
+	assert.Equal(t, domain.JobStatusPending, repo.events[0].Status)
+	assert.NoFileExists(t, path, "a state is applied once")
+
+	assert.Empty(t, after.State().Claimed, "requeued jobs are no longer claimed")
+	assert.Equal(t, map[uuid.UUID]time.Time{deferred: fake.Now().Add(-5*time.Minute - 2*time.Second)}, after.affinity.deferrals())
+
+	// The requeued job waits out the rest of its retry backoff
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{}, 0, nil)
+	job.ID = queued
+	assert.Empty(t, after.dueJobs([]*domain.BacktestJob{job}, fake.Now()))
+	fake.Advance(retryBackoff)
+	assert.Equal(t, []*domain.BacktestJob{job}, after.dueJobs([]*domain.BacktestJob{job}, fake.Now()))
+	assert.Empty(t, after.State().Retries)
+}
+
+func TestScheduler_RestoreState_IgnoresStaleState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.json")
+	fake := clock.NewFake(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	jobID := uuid.New()
+
+	before := newStateScheduler(t, path, &mockRequeueRepository{}, &mockStopManager{}, fake)
+	before.claim(jobID, fake.Now())
+	require.NoError(t, before.saveState())
+
+	fake.Advance(11 * time.Minute)
+	repo := &mockRequeueRepository{running: map[uuid.UUID]bool{jobID: true}}
+	after := newStateScheduler(t, path, repo, &mockStopManager{}, fake)
+	after.restoreState()
+
+	assert.Empty(t, repo.requeued, "the timeout watcher handles jobs claimed before the job timeout")
+	assert.NoFileExists(t, path)
+}
+
+func TestScheduler_RestoreState_NoState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.json")
+	repo := &mockRequeueRepository{}
+	sched := newStateScheduler(t, path, repo, &mockStopManager{}, clock.NewFake(time.Now()))
+
+	sched.restoreState()
+	assert.Empty(t, repo.requeued)
+
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+	sched.restoreState()
+	assert.Empty(t, repo.requeued, "an unreadable state is ignored")
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+const (
+	// previewRuntimeLookback is how far back runtimes are sampled for the
+	// estimates of a dry-run submission.
+	previewRuntimeLookback = 30 * 24 * time.Hour
+	// previewRuntimeSamples caps the number of runtimes sampled.
+	previewRuntimeSamples = 1000
+)
+
+// PreviewSubmission works out what submitting the job would do without
+// persisting it: where it would enter the queue, when it would start and
+// finish with the given number of workers, and what coverage it would add for
+// its strategy. It returns warnings about the job's data alongside. Jobs
+// already queued are ranked by their effective priority at now, with priority
+// aging at agingPerHour.
+//
+// Completion is estimated as the time to drain the jobs ahead of it and the
+// job itself, simulated like the capacity planner does.
+func PreviewSubmission(ctx context.Context, repos *repository.Repositories, workers int, agingPerHour float64, job *domain.BacktestJob, now time.Time) (*domain.SubmissionPreview, []string, error) {
+	warnings := domain.CheckBacktestData(job.Config, now)
+
+	entries, err := repos.BacktestJob.GetCoverageEntries(ctx, job.StrategyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := domain.CoverageTargetOf(job.Config)
+	if target.TimerangeEnd == "" {
+		// Open-ended timeranges run up to the latest data
+		target.TimerangeEnd = now.UTC().Format("20060102")
+	}
+	coverage := domain.BuildStrategyCoverage(job.StrategyID, entries, target)
+
+	ahead, err := repos.BacktestJob.CountPendingAhead(ctx, job.Priority, agingPerHour, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats, err := repos.BacktestJob.GetQueueStats(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preview := &domain.SubmissionPreview{
+		QueuePosition: ahead,
+		RunningJobs:   stats.RunningJobs,
+		Workers:       workers,
+		NewCoverage:   coverage.Gaps,
+	}
+	closed := target.TimerangeStart != "" && target.Validate() == nil
+	if closed && preview.AlreadyCovered() && coverage.CompletedJobs > 0 {
+		warnings = append(warnings, "completed backtests of the strategy already cover these pairs, timeframe and timerange")
+	}
+	if workers <= 0 {
+		return preview, warnings, nil
+	}
+
+	runtimes, err := repos.BacktestJob.GetRecentRuntimes(ctx, now.Add(-previewRuntimeLookback), previewRuntimeSamples)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(runtimes) == 0 {
+		return preview, warnings, nil
+	}
+
+	sorted := slices.Clone(runtimes)
+	slices.Sort(sorted)
+	runtime := percentileDuration(sorted, 0.50).Milliseconds()
+
+	scenarios := SimulateCapacity(CapacityInput{
+		QueueLength: ahead + 1,
+		RunningJobs: stats.RunningJobs,
+		Runtimes:    runtimes,
+		Trials:      DefaultCapacityTrials,
+		Seed:        1,
+	}, []int{workers})
+	completion := scenarios[0].DrainP50Ms
+	wait := max(completion-runtime, 0)
+
+	preview.EstimatedRuntimeMs = &runtime
+	preview.EstimatedWaitMs = &wait
+	preview.EstimatedCompletionMs = &completion
+	preview.EstimatedCompletionP90Ms = &scenarios[0].DrainP90Ms
+	return preview, warnings, nil
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// mockPreviewRepository implements the BacktestJobRepository methods used to
+// preview a submission. Other methods panic via the nil embedded interface.
+type mockPreviewRepository struct {
+	repository.BacktestJobRepository
+	entries      []domain.CoverageEntry
+	ahead        int
+	running      int
+	runtimes     []time.Duration
+	lastPriority int
+	lastAging    float64
+	lastNow      time.Time
+}
+
+func (m *mockPreviewRepository) GetCoverageEntries(ctx context.Context, strategyID uuid.UUID) ([]domain.CoverageEntry, error) {
+	return m.entries, nil
+}
+
+func (m *mockPreviewRepository) CountPendingAhead(ctx context.Context, priority int, agingPerHour float64, now time.Time) (int, error) {
+	m.lastPriority = priority
+	m.lastAging = agingPerHour
+	m.lastNow = now
+	return m.ahead, nil
+}
+
+func (m *mockPreviewRepository) GetQueueStats(ctx context.Context) (*domain.QueueStats, error) {
+	return &domain.QueueStats{PendingJobs: m.ahead, RunningJobs: m.running}, nil
+}
+
+func (m *mockPreviewRepository) GetRecentRuntimes(ctx context.Context, since time.Time, limit int) ([]time.Duration, error) {
+	return m.runtimes, nil
+}
+
+func TestPreviewSubmission(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := &mockPreviewRepository{
+		entries: []domain.CoverageEntry{{
+			Config: domain.BacktestConfig{
+				Pairs:          []string{"BTC/USDT"},
+				Timeframe:      "1h",
+				TimerangeStart: "20240101",
+				TimerangeEnd:   "20240131",
+			},
+			Status:    domain.JobStatusCompleted,
+			HasResult: true,
+		}},
+		ahead:    3,
+		running:  2,
+		runtimes: []time.Duration{10 * time.Minute},
+	}
+	repos := &repository.Repositories{BacktestJob: repo}
+
+	job := domain.NewBacktestJob(uuid.New(), domain.BacktestConfig{
+		Pairs:          []string{"BTC/USDT", "ETH/USDT"},
+		Timeframe:      "1h",
+		TimerangeStart: "20240101",
+		TimerangeEnd:   "20240229",
+	}, 5, nil)
+
+	preview, warnings, err := PreviewSubmission(context.Background(), repos, 2, 1.5, job, now)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, 5, repo.lastPriority)
+	assert.Equal(t, 1.5, repo.lastAging)
+	assert.Equal(t, now, repo.lastNow)
+	assert.Equal(t, 3, preview.QueuePosition)
+	assert.Equal(t, 2, preview.RunningJobs)
+	assert.Equal(t, 2, preview.Workers)
+
+	// Two running jobs free both workers after 5 minutes, then four jobs of
+	// 10 minutes run two at a time
+	require.NotNil(t, preview.EstimatedCompletionMs)
+	assert.Equal(t, (25 * time.Minute).Milliseconds(), *preview.EstimatedCompletionMs)
+	assert.Equal(t, (10 * time.Minute).Milliseconds(), *preview.EstimatedRuntimeMs)
+	assert.Equal(t, (15 * time.Minute).Milliseconds(), *preview.EstimatedWaitMs)
+
+	assert.Equal(t, []string{"ETH/USDT"}, preview.NewCoverage.Pairs)
+	assert.Empty(t, preview.NewCoverage.Timeframes)
+	require.Len(t, preview.NewCoverage.Timeranges, 1)
+	assert.Equal(t, domain.DateRange{Start: "2024-02-01", End: "2024-02-29", Days: 29}, preview.NewCoverage.Timeranges[0])
+}
+
+func TestPreviewSubmission_WarnsAboutData(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg := domain.BacktestConfig{
+		Pairs:          []string{"BTC/USDT"},
+		Timeframe:      "1h",
+		TimerangeStart: "20240101",
+		TimerangeEnd:   "20240131",
+	}
+	repo := &mockPreviewRepository{
+		entries: []domain.CoverageEntry{{Config: cfg, Status: domain.JobStatusCompleted, HasResult: true}},
+	}
+	repos := &repository.Repositories{BacktestJob: repo}
+
+	// Already covered, and no runtime history for estimates
+	preview, warnings, err := PreviewSubmission(context.Background(), repos, 2, 0, domain.NewBacktestJob(uuid.New(), cfg, 0, nil), now)
+	require.NoError(t, err)
+	assert.True(t, preview.AlreadyCovered())
+	assert.Nil(t, preview.EstimatedCompletionMs)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "already cover")
+
+	cfg.TimerangeStart = "20240701"
+	cfg.TimerangeEnd = ""
+	_, warnings, err = PreviewSubmission(context.Background(), repos, 2, 0, domain.NewBacktestJob(uuid.New(), cfg, 0, nil), now)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "in the future")
+}
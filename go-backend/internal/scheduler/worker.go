@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -12,6 +13,9 @@ import (
 	"github.com/saltfish/freqsearch/go-backend/internal/domain"
 )
 
+// retryBackoff is how long a worker waits before retrying a failed job.
+const retryBackoff = 5 * time.Second
+
 // Worker processes backtest jobs.
 type Worker struct {
 	id        int
@@ -35,29 +39,44 @@ func (w *Worker) Run(ctx context.Context, wg *sync.WaitGroup) {
 	w.logger.Info("Worker started")
 
 	for {
+		var result *JobResult
 		select {
 		case <-ctx.Done():
 			w.logger.Info("Worker stopped")
 			return
 		case job := <-w.scheduler.jobChan:
-			result := w.processJobWithRetry(ctx, job)
-			select {
-			case w.scheduler.resultChan <- result:
-			case <-ctx.Done():
-				return
-			}
+			result = w.processJobWithRetry(ctx, job)
+		case running := <-w.scheduler.resumed:
+			result = w.retryFailedJob(ctx, running.Job, w.resumeJob(ctx, running))
+		}
+
+		result.Worker = w.name()
+		select {
+		case w.scheduler.resultChan <- result:
+		case <-ctx.Done():
+			return
 		}
 	}
 }
 
+// name returns the worker's name as recorded on job timeline events.
+func (w *Worker) name() string {
+	return fmt.Sprintf("worker-%d", w.id)
+}
+
 // processJobWithRetry processes a job with retry logic.
 func (w *Worker) processJobWithRetry(ctx context.Context, job *domain.BacktestJob) *JobResult {
-	result := w.processJob(ctx, job)
+	return w.retryFailedJob(ctx, job, w.processJob(ctx, job))
+}
 
-	if !result.Success && w.shouldRetry(job, result.Error) {
+// retryFailedJob runs a job again in a new container if its first attempt
+// failed in a way worth retrying.
+func (w *Worker) retryFailedJob(ctx context.Context, job *domain.BacktestJob, result *JobResult) *JobResult {
+	if !result.Success && !result.Cancelled && w.shouldRetry(job, result.FailureCategory) {
 		w.logger.Info("Retrying job",
 			zap.String("job_id", job.ID.String()),
 			zap.Int("retry_count", job.RetryCount),
+			zap.String("failure_category", result.FailureCategory.String()),
 		)
 
 		// Increment retry count in database
@@ -69,12 +88,23 @@ func (w *Worker) processJobWithRetry(ctx context.Context, job *domain.BacktestJo
 		}
 		job.RetryCount++
 
+		detail := result.Error.Error()
+		w.scheduler.recordJobEvent(&domain.JobEvent{
+			JobID:  job.ID,
+			Type:   domain.JobEventRetried,
+			Status: domain.JobStatusRunning,
+			Worker: optionalString(w.name()),
+			Detail: &detail,
+		})
+
 		// Wait before retry
+		w.scheduler.setRetryAt(job.ID, w.scheduler.clock.Now().Add(retryBackoff))
 		select {
 		case <-ctx.Done():
 			return result
-		case <-time.After(5 * time.Second):
+		case <-w.scheduler.clock.After(retryBackoff):
 		}
+		w.scheduler.setRetryAt(job.ID, time.Time{})
 
 		result = w.processJob(ctx, job)
 	}
@@ -84,7 +114,7 @@ func (w *Worker) processJobWithRetry(ctx context.Context, job *domain.BacktestJo
 
 // processJob processes a single backtest job.
 func (w *Worker) processJob(ctx context.Context, job *domain.BacktestJob) *JobResult {
-	startTime := time.Now()
+	startTime := w.scheduler.clock.Now()
 
 	// Create job-specific context with timeout
 	timeout := time.Duration(w.scheduler.config.JobTimeoutMinutes) * time.Minute
@@ -109,12 +139,18 @@ func (w *Worker) processJob(ctx context.Context, job *domain.BacktestJob) *JobRe
 	strategy, err := w.scheduler.repos.Strategy.GetByID(ctx, job.StrategyID)
 	if err != nil {
 		return &JobResult{
-			Job:     job,
-			Success: false,
-			Error:   err,
+			Job:             job,
+			Success:         false,
+			Error:           err,
+			FailureCategory: domain.FailureCategoryUnknown,
 		}
 	}
 
+	// Fail jobs lacking market data here rather than in their container
+	if result := w.checkMarketData(jobCtx, job); result != nil {
+		return result
+	}
+
 	// Start Docker container
 	params := &docker.RunBacktestParams{
 		JobID:        job.ID,
@@ -122,6 +158,7 @@ func (w *Worker) processJob(ctx context.Context, job *domain.BacktestJob) *JobRe
 		StrategyName: strategy.Name,
 		Config:       job.Config,
 	}
+	w.scheduler.applyQuota(params)
 
 	containerID, err := w.scheduler.dockerManager.RunBacktest(jobCtx, params)
 	if err != nil {
@@ -130,20 +167,77 @@ func (w *Worker) processJob(ctx context.Context, job *domain.BacktestJob) *JobRe
 			zap.Error(err),
 		)
 		return &JobResult{
-			Job:     job,
-			Success: false,
-			Error:   ErrContainerStartFailed,
+			Job:             job,
+			Success:         false,
+			Error:           ErrContainerStartFailed,
+			FailureCategory: domain.FailureCategoryDocker,
 		}
 	}
 
 	// Update running job with container ID
+	dockerHost := w.scheduler.dockerManager.ContainerHost(containerID)
 	running.ContainerID = containerID
+	running.DockerHost = dockerHost
+	w.scheduler.setClaimContainer(job.ID, containerID, dockerHost)
 
 	// Update database with container ID
 	w.scheduler.repos.BacktestJob.UpdateStatus(ctx, job.ID, domain.JobStatusRunning, &containerID, nil)
+	job.ContainerID = &containerID
+
+	w.scheduler.recordJobEvent(&domain.JobEvent{
+		JobID:       job.ID,
+		Type:        domain.JobEventContainerStarted,
+		Status:      domain.JobStatusRunning,
+		ContainerID: &containerID,
+		Worker:      optionalString(w.name()),
+		Detail:      dockerHostDetail(dockerHost),
+	})
+
+	return w.awaitContainer(ctx, jobCtx, running, startTime)
+}
+
+// resumeJob waits for the container of a job taken over when the scheduler
+// started, as processJob does for the containers it starts. The job timeout
+// runs from when the job was started.
+func (w *Worker) resumeJob(ctx context.Context, running *RunningJob) *JobResult {
+	job := running.Job
+	timeout := time.Duration(w.scheduler.config.JobTimeoutMinutes) * time.Minute
+	jobCtx, cancel := context.WithTimeout(ctx, timeout-w.scheduler.clock.Since(running.StartedAt))
+	defer cancel()
+
+	running.Cancel = cancel
+	w.scheduler.activeJobs.Store(job.ID, running)
+	defer w.scheduler.activeJobs.Delete(job.ID)
+
+	w.logger.Info("Resuming job",
+		zap.String("job_id", job.ID.String()),
+		zap.String("container_id", running.ContainerID),
+	)
+
+	return w.awaitContainer(ctx, jobCtx, running, running.StartedAt)
+}
+
+// awaitContainer waits for a job's container to exit and parses its output.
+// jobCtx bounds the wait by the job timeout.
+func (w *Worker) awaitContainer(ctx, jobCtx context.Context, running *RunningJob, startTime time.Time) *JobResult {
+	job := running.Job
+	containerID := running.ContainerID
 
 	// Wait for container to complete
 	exitCode, logs, err := w.scheduler.dockerManager.WaitContainer(jobCtx, containerID)
+	if running.cancelling.Load() {
+		// The job was cancelled and its container stopped; keep what it
+		// wrote until then
+		w.logger.Info("Cancelled job's container exited",
+			zap.String("job_id", job.ID.String()),
+		)
+		w.scheduler.dockerManager.RemoveContainer(context.Background(), containerID)
+		return &JobResult{
+			Job:       job,
+			Cancelled: true,
+			Logs:      logs,
+		}
+	}
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			w.logger.Warn("Job timed out",
@@ -154,9 +248,10 @@ func (w *Worker) processJob(ctx context.Context, job *domain.BacktestJob) *JobRe
 		}
 
 		return &JobResult{
-			Job:     job,
-			Success: false,
-			Error:   err,
+			Job:             job,
+			Success:         false,
+			Error:           err,
+			FailureCategory: classifyError(err),
 		}
 	}
 
@@ -165,9 +260,11 @@ func (w *Worker) processJob(ctx context.Context, job *domain.BacktestJob) *JobRe
 
 	// Check exit code
 	if exitCode != 0 {
+		category := classifyContainerFailure(exitCode, logs)
 		w.logger.Warn("Container exited with non-zero code",
 			zap.String("job_id", job.ID.String()),
 			zap.Int64("exit_code", exitCode),
+			zap.String("failure_category", category.String()),
 			zap.String("logs", logs),
 		)
 
@@ -176,28 +273,40 @@ func (w *Worker) processJob(ctx context.Context, job *domain.BacktestJob) *JobRe
 			logs = logs[len(logs)-2000:]
 		}
 		return &JobResult{
-			Job:     job,
-			Success: false,
-			Error:   ErrStrategyCodeError,
-			Logs:    logs,
+			Job:             job,
+			Success:         false,
+			Error:           containerFailureError(category),
+			Logs:            logs,
+			FailureCategory: category,
 		}
 	}
 
+	// The image and host the container ran on, which also select the
+	// output format to parse
+	env, err := w.scheduler.dockerManager.InspectEnvironment(ctx, containerID)
+	if err != nil {
+		w.logger.Warn("Failed to inspect execution environment",
+			zap.String("job_id", job.ID.String()),
+			zap.Error(err),
+		)
+	}
+
 	// Parse results
-	result, err := w.scheduler.parser.ParseResult(logs, job)
+	result, err := w.scheduler.parser.ParseResult(logs, job, env)
 	if err != nil {
 		w.logger.Error("Failed to parse backtest result",
 			zap.String("job_id", job.ID.String()),
 			zap.Error(err),
 		)
 		return &JobResult{
-			Job:     job,
-			Success: false,
-			Error:   err,
+			Job:             job,
+			Success:         false,
+			Error:           fmt.Errorf("%w: %v", ErrResultParseFailed, err),
+			FailureCategory: domain.FailureCategoryParser,
 		}
 	}
 
-	duration := time.Since(startTime)
+	duration := w.scheduler.clock.Since(startTime)
 	w.logger.Info("Job completed",
 		zap.String("job_id", job.ID.String()),
 		zap.Duration("duration", duration),
@@ -212,23 +321,14 @@ func (w *Worker) processJob(ctx context.Context, job *domain.BacktestJob) *JobRe
 	}
 }
 
-// shouldRetry determines if a job should be retried based on the error.
-func (w *Worker) shouldRetry(job *domain.BacktestJob, err error) bool {
+// shouldRetry determines if a job should be retried based on the category
+// of its failure.
+func (w *Worker) shouldRetry(job *domain.BacktestJob, category domain.FailureCategory) bool {
 	// Check if already retried
 	if job.RetryCount >= w.scheduler.config.MaxRetries {
 		return false
 	}
 
-	// Only retry on container/infrastructure errors, not code errors
-	switch {
-	case errors.Is(err, ErrContainerStartFailed):
-		return true
-	case errors.Is(err, context.DeadlineExceeded):
-		return true
-	case errors.Is(err, ErrDockerDaemonError):
-		return true
-	default:
-		// Strategy code errors should not be retried
-		return false
-	}
+	// Only retry transient failures, not strategy code errors
+	return category.Retryable()
 }
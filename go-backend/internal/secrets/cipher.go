@@ -0,0 +1,128 @@
+// Package secrets stores credentials such as scout source tokens encrypted at
+// rest with AES-256-GCM under a master key that never touches the database.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+// KeySize is the size of the master key in bytes (AES-256).
+const KeySize = 32
+
+// ErrNotConfigured is returned when no master key is configured.
+var ErrNotConfigured = errors.New("secrets store is not configured: no master key")
+
+// ErrKeyMismatch is returned when a secret was encrypted under a different
+// master key than the one configured.
+var ErrKeyMismatch = errors.New("secret was encrypted under a different master key")
+
+// Cipher encrypts and decrypts secret values under a master key.
+type Cipher struct {
+	aead  cipher.AEAD
+	keyID string
+}
+
+// NewCipher creates a cipher for a 32-byte master key.
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &Cipher{aead: aead, keyID: hex.EncodeToString(sum[:4])}, nil
+}
+
+// KeyID identifies the master key without revealing it, so secrets encrypted
+// under another key are recognized instead of failing to decrypt.
+func (c *Cipher) KeyID() string {
+	return c.keyID
+}
+
+// Seal encrypts plaintext with a fresh random nonce. The additional data,
+// e.g. the secret's ID, is authenticated but not stored, so a ciphertext
+// copied onto another record does not decrypt.
+func (c *Cipher) Seal(plaintext, additionalData []byte) (nonce, ciphertext []byte, err error) {
+	nonce = make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return nonce, c.aead.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+// Open decrypts a ciphertext sealed under this key with the same additional data.
+func (c *Cipher) Open(keyID string, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if keyID != c.keyID {
+		return nil, ErrKeyMismatch
+	}
+	if len(nonce) != c.aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return plaintext, nil
+}
+
+// ParseMasterKey decodes a master key given as 64 hex characters or as
+// standard base64.
+func ParseMasterKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if key, err := hex.DecodeString(s); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != KeySize {
+		return nil, fmt.Errorf("master key must be %d bytes encoded as hex or base64", KeySize)
+	}
+	return key, nil
+}
+
+// LoadCipher creates the cipher from the configured master key source: an
+// environment variable, or a file such as one written by a KMS or secret
+// manager agent. It returns ErrNotConfigured if the key is not set.
+func LoadCipher(cfg *config.SecretsConfig) (*Cipher, error) {
+	var encoded string
+	switch cfg.MasterKeySource {
+	case config.SecretsKeySourceEnv, "":
+		encoded = os.Getenv(cfg.MasterKeyEnv)
+	case config.SecretsKeySourceFile:
+		if cfg.MasterKeyFile == "" {
+			return nil, ErrNotConfigured
+		}
+		data, err := os.ReadFile(cfg.MasterKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read master key file: %w", err)
+		}
+		encoded = string(data)
+	default:
+		return nil, fmt.Errorf("unknown master key source %q", cfg.MasterKeySource)
+	}
+
+	if strings.TrimSpace(encoded) == "" {
+		return nil, ErrNotConfigured
+	}
+	key, err := ParseMasterKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(key)
+}
@@ -0,0 +1,76 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+)
+
+func TestCipher_SealOpen(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, KeySize)
+	c, err := NewCipher(key)
+	require.NoError(t, err)
+
+	nonce, ciphertext, err := c.Seal([]byte("ghp_token"), []byte("secret-1"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "ghp_token")
+
+	plaintext, err := c.Open(c.KeyID(), nonce, ciphertext, []byte("secret-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_token", string(plaintext))
+
+	// Bound to the additional data
+	_, err = c.Open(c.KeyID(), nonce, ciphertext, []byte("secret-2"))
+	assert.Error(t, err)
+
+	// Encrypted under another key
+	other, err := NewCipher(bytes.Repeat([]byte{8}, KeySize))
+	require.NoError(t, err)
+	assert.NotEqual(t, c.KeyID(), other.KeyID())
+	_, err = other.Open(c.KeyID(), nonce, ciphertext, []byte("secret-1"))
+	assert.ErrorIs(t, err, ErrKeyMismatch)
+
+	_, err = NewCipher(key[:16])
+	assert.Error(t, err)
+}
+
+func TestParseMasterKey(t *testing.T) {
+	key := bytes.Repeat([]byte{0xab}, KeySize)
+
+	parsed, err := ParseMasterKey(hex.EncodeToString(key))
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	parsed, err = ParseMasterKey(base64.StdEncoding.EncodeToString(key) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = ParseMasterKey("too-short")
+	assert.Error(t, err)
+}
+
+func TestLoadCipher(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, KeySize))
+
+	t.Setenv("TEST_SECRETS_MASTER_KEY", "")
+	_, err := LoadCipher(&config.SecretsConfig{MasterKeySource: config.SecretsKeySourceEnv, MasterKeyEnv: "TEST_SECRETS_MASTER_KEY"})
+	assert.ErrorIs(t, err, ErrNotConfigured)
+
+	t.Setenv("TEST_SECRETS_MASTER_KEY", encoded)
+	fromEnv, err := LoadCipher(&config.SecretsConfig{MasterKeySource: config.SecretsKeySourceEnv, MasterKeyEnv: "TEST_SECRETS_MASTER_KEY"})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "master.key")
+	require.NoError(t, os.WriteFile(path, []byte(encoded+"\n"), 0o600))
+	fromFile, err := LoadCipher(&config.SecretsConfig{MasterKeySource: config.SecretsKeySourceFile, MasterKeyFile: path})
+	require.NoError(t, err)
+	assert.Equal(t, fromEnv.KeyID(), fromFile.KeyID())
+}
@@ -0,0 +1,120 @@
+package secrets
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db/repository"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Store encrypts secret values before they reach the repository and decrypts
+// them for internal use. Plaintext is accepted on create and update and only
+// ever returned by Reveal, which must not be exposed through the API beyond
+// the operator-only GetScoutCredential gRPC method the Scout agent uses.
+type Store struct {
+	repo   repository.SecretRepository
+	cipher *Cipher
+}
+
+// NewStore creates a secrets store. A nil cipher yields a store whose
+// operations fail with ErrNotConfigured.
+func NewStore(repo repository.SecretRepository, cipher *Cipher) *Store {
+	return &Store{repo: repo, cipher: cipher}
+}
+
+// Configured reports whether a master key is available.
+func (s *Store) Configured() bool {
+	return s != nil && s.cipher != nil
+}
+
+// Create encrypts value and stores it as a new secret.
+func (s *Store) Create(ctx context.Context, name, description, value string) (*domain.Secret, error) {
+	if !s.Configured() {
+		return nil, ErrNotConfigured
+	}
+	if err := domain.ValidateSecretName(name); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateSecretValue(value); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	secret := &domain.Secret{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.seal(secret, value); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Update changes a secret's description and, if value is non-nil, rotates
+// its value. Rotating also re-encrypts under the current master key.
+func (s *Store) Update(ctx context.Context, id uuid.UUID, description, value *string) (*domain.Secret, error) {
+	if !s.Configured() {
+		return nil, ErrNotConfigured
+	}
+
+	secret, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if description != nil {
+		secret.Description = *description
+	}
+	if value != nil {
+		if err := domain.ValidateSecretValue(*value); err != nil {
+			return nil, err
+		}
+		if err := s.seal(secret, *value); err != nil {
+			return nil, err
+		}
+	}
+	secret.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(ctx, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Reveal decrypts a secret's value, e.g. a source credential the Scout agent
+// requests.
+func (s *Store) Reveal(ctx context.Context, id uuid.UUID) (string, error) {
+	if !s.Configured() {
+		return "", ErrNotConfigured
+	}
+
+	secret, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := s.cipher.Open(secret.KeyID, secret.Nonce, secret.Ciphertext, secret.ID[:])
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// seal encrypts value into the secret, bound to the secret's ID.
+func (s *Store) seal(secret *domain.Secret, value string) error {
+	nonce, ciphertext, err := s.cipher.Seal([]byte(value), secret.ID[:])
+	if err != nil {
+		return err
+	}
+	secret.Nonce = nonce
+	secret.Ciphertext = ciphertext
+	secret.KeyID = s.cipher.KeyID()
+	return nil
+}
@@ -0,0 +1,224 @@
+// Package stats implements the statistical tests used to tell real
+// performance differences between backtests from noise.
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Options configures a significance test.
+type Options struct {
+	Method domain.SignificanceMethod
+	Alpha  float64
+
+	// Bootstrap only: the number of resamples and the random seed, fixed so
+	// repeating a comparison gives the same answer.
+	Iterations int
+	Seed       uint64
+}
+
+// Describe summarizes a set of per-trade returns.
+func Describe(returns []float64) domain.TradeSample {
+	sample := domain.TradeSample{Trades: len(returns)}
+	if len(returns) == 0 {
+		return sample
+	}
+
+	for _, r := range returns {
+		sample.TotalReturnPct += r
+	}
+	sample.MeanReturnPct = sample.TotalReturnPct / float64(len(returns))
+	sample.StdDevPct = math.Sqrt(variance(returns, sample.MeanReturnPct))
+	return sample
+}
+
+// CompareReturns tests whether the mean trade return of b differs from that
+// of a. Both sides need at least domain.MinSignificanceTrades returns.
+func CompareReturns(a, b []float64, opts Options) *domain.SignificanceReport {
+	report := &domain.SignificanceReport{
+		Method: opts.Method,
+		Alpha:  opts.Alpha,
+		A:      Describe(a),
+		B:      Describe(b),
+	}
+	report.DifferencePct = report.B.MeanReturnPct - report.A.MeanReturnPct
+
+	switch opts.Method {
+	case domain.SignificanceBootstrap:
+		report.Iterations = opts.Iterations
+		report.PValue, report.ConfidenceLow, report.ConfidenceHigh = bootstrap(a, b, opts)
+	default:
+		welch(report)
+	}
+
+	report.Significant = report.PValue < opts.Alpha
+	if report.Significant {
+		report.Better = "a"
+		if report.DifferencePct > 0 {
+			report.Better = "b"
+		}
+	}
+	return report
+}
+
+// welch fills in Welch's t-test of the report's samples.
+func welch(report *domain.SignificanceReport) {
+	a, b := report.A, report.B
+	va := a.StdDevPct * a.StdDevPct / float64(a.Trades)
+	vb := b.StdDevPct * b.StdDevPct / float64(b.Trades)
+	se := math.Sqrt(va + vb)
+
+	// Without variance on either side the difference is exact
+	if se == 0 {
+		report.ConfidenceLow, report.ConfidenceHigh = report.DifferencePct, report.DifferencePct
+		report.PValue = 1
+		if report.DifferencePct != 0 {
+			report.PValue = 0
+		}
+		return
+	}
+
+	t := report.DifferencePct / se
+	df := (va + vb) * (va + vb) / (va*va/float64(a.Trades-1) + vb*vb/float64(b.Trades-1))
+	margin := studentTQuantile(report.Alpha, df) * se
+
+	report.TStatistic = &t
+	report.DegreesOfFreedom = &df
+	report.PValue = studentTTwoSided(t, df)
+	report.ConfidenceLow = report.DifferencePct - margin
+	report.ConfidenceHigh = report.DifferencePct + margin
+}
+
+// bootstrap resamples both sides with replacement and returns the two-sided
+// p-value of the difference in means and its percentile confidence interval.
+func bootstrap(a, b []float64, opts Options) (pValue, low, high float64) {
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+
+	diffs := make([]float64, opts.Iterations)
+	below, above := 0, 0
+	for i := range diffs {
+		diffs[i] = resampleMean(rng, b) - resampleMean(rng, a)
+		if diffs[i] <= 0 {
+			below++
+		}
+		if diffs[i] >= 0 {
+			above++
+		}
+	}
+	sort.Float64s(diffs)
+
+	n := float64(opts.Iterations)
+	pValue = math.Min(1, 2*math.Min(float64(below+1), float64(above+1))/(n+1))
+	low = diffs[int(math.Floor(opts.Alpha/2*(n-1)))]
+	high = diffs[int(math.Ceil((1-opts.Alpha/2)*(n-1)))]
+	return pValue, low, high
+}
+
+// resampleMean returns the mean of a resample of xs with replacement.
+func resampleMean(rng *rand.Rand, xs []float64) float64 {
+	sum := 0.0
+	for range xs {
+		sum += xs[rng.IntN(len(xs))]
+	}
+	return sum / float64(len(xs))
+}
+
+// variance returns the sample variance of xs around mean.
+func variance(xs []float64, mean float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += (x - mean) * (x - mean)
+	}
+	return sum / float64(len(xs)-1)
+}
+
+// studentTTwoSided returns P(|T| >= |t|) for Student's t distribution with
+// df degrees of freedom.
+func studentTTwoSided(t, df float64) float64 {
+	return regularizedIncompleteBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// studentTQuantile returns the critical value c with P(|T| >= c) = alpha.
+func studentTQuantile(alpha, df float64) float64 {
+	high := 1.0
+	for studentTTwoSided(high, df) > alpha {
+		high *= 2
+	}
+	low := 0.0
+	for i := 0; i < 100; i++ {
+		mid := (low + high) / 2
+		if studentTTwoSided(mid, df) > alpha {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// regularizedIncompleteBeta returns I_x(a, b), evaluated with the continued
+// fraction on whichever side converges quickly.
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction of the incomplete
+// beta function with the modified Lentz method.
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 3e-14
+		tiny          = 1e-300
+	)
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+
+	c := 1.0
+	d := 1 / clamp(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+
+		num := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 / clamp(1+num*d)
+		c = clamp(1 + num/c)
+		h *= d * c
+
+		num = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 / clamp(1+num*d)
+		c = clamp(1 + num/c)
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
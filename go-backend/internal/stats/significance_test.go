@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestStudentT(t *testing.T) {
+	// Reference values from statistical tables
+	assert.InDelta(t, 0.0734, studentTTwoSided(2.0, 10), 1e-4)
+	assert.InDelta(t, 1.0, studentTTwoSided(0, 5), 1e-12)
+	assert.InDelta(t, 2.228, studentTQuantile(0.05, 10), 1e-3)
+	assert.InDelta(t, 1.960, studentTQuantile(0.05, 1e6), 1e-3)
+}
+
+func TestCompareReturns_WelchT(t *testing.T) {
+	a := []float64{0.5, -0.2, 0.1, 0.3, -0.4, 0.2, 0.0, 0.1}
+	b := []float64{2.1, 1.8, 2.5, 1.9, 2.2, 2.0, 2.4, 1.7}
+
+	report := CompareReturns(a, b, Options{Method: domain.SignificanceWelchT, Alpha: 0.05})
+	assert.Equal(t, 8, report.A.Trades)
+	assert.InDelta(t, 0.075, report.A.MeanReturnPct, 1e-9)
+	assert.InDelta(t, 2.075, report.B.MeanReturnPct, 1e-9)
+	assert.InDelta(t, 2.0, report.DifferencePct, 1e-9)
+	require.NotNil(t, report.TStatistic)
+	require.NotNil(t, report.DegreesOfFreedom)
+	assert.Greater(t, *report.TStatistic, 10.0)
+	assert.Less(t, report.PValue, 1e-6)
+	assert.Less(t, report.ConfidenceLow, 2.0)
+	assert.Greater(t, report.ConfidenceHigh, 2.0)
+	assert.True(t, report.Significant)
+	assert.Equal(t, "b", report.Better)
+
+	// A small improvement buried in noise is not significant
+	noisy := []float64{3, -2, 4, -3, 2, -1, 5, -4}
+	shifted := []float64{3.2, -1.8, 4.2, -2.8, 2.2, -0.8, 5.2, -3.8}
+	report = CompareReturns(noisy, shifted, Options{Method: domain.SignificanceWelchT, Alpha: 0.05})
+	assert.Greater(t, report.PValue, 0.5)
+	assert.False(t, report.Significant)
+	assert.Empty(t, report.Better)
+
+	// Without variance the difference is exact
+	report = CompareReturns([]float64{1, 1}, []float64{1, 1}, Options{Method: domain.SignificanceWelchT, Alpha: 0.05})
+	assert.Nil(t, report.TStatistic)
+	assert.Equal(t, 1.0, report.PValue)
+}
+
+func TestCompareReturns_Bootstrap(t *testing.T) {
+	a := []float64{0.5, -0.2, 0.1, 0.3, -0.4, 0.2, 0.0, 0.1}
+	b := []float64{2.1, 1.8, 2.5, 1.9, 2.2, 2.0, 2.4, 1.7}
+	opts := Options{Method: domain.SignificanceBootstrap, Alpha: 0.05, Iterations: 2000, Seed: 1}
+
+	report := CompareReturns(a, b, opts)
+	assert.Equal(t, 2000, report.Iterations)
+	assert.Nil(t, report.TStatistic)
+	assert.InDelta(t, 2.0/2001, report.PValue, 1e-12)
+	assert.True(t, report.Significant)
+	assert.Equal(t, "b", report.Better)
+	assert.Less(t, report.ConfidenceLow, report.DifferencePct)
+	assert.Greater(t, report.ConfidenceHigh, report.DifferencePct)
+
+	// The fixed seed makes repeated comparisons agree
+	assert.Equal(t, report, CompareReturns(a, b, opts))
+
+	noisy := []float64{3, -2, 4, -3, 2, -1, 5, -4}
+	shifted := []float64{3.2, -1.8, 4.2, -2.8, 2.2, -0.8, 5.2, -3.8}
+	report = CompareReturns(noisy, shifted, opts)
+	assert.False(t, report.Significant)
+}
@@ -0,0 +1,164 @@
+package strategycode
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// Diff compares the code and settings of two strategies. The code diff is a
+// unified diff with contextLines unchanged lines around each change. Settings
+// are read from the code where it declares them, falling back to the stored
+// metadata, so edits the metadata doesn't reflect yet still show up.
+func Diff(from, to *domain.Strategy, contextLines int) (*domain.StrategyDiff, error) {
+	diff := &domain.StrategyDiff{
+		FromID:            from.ID,
+		ToID:              to.ID,
+		Identical:         from.Code == to.Code,
+		Changes:           []domain.SettingChange{},
+		IndicatorsAdded:   []string{},
+		IndicatorsRemoved: []string{},
+	}
+
+	if !diff.Identical {
+		a, b := difflib.SplitLines(from.Code), difflib.SplitLines(to.Code)
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        a,
+			B:        b,
+			FromFile: "a/" + from.Name + ".py",
+			ToFile:   "b/" + to.Name + ".py",
+			Context:  contextLines,
+		})
+		if err != nil {
+			return nil, err
+		}
+		diff.UnifiedDiff = text
+
+		for _, op := range difflib.NewMatcher(a, b).GetOpCodes() {
+			switch op.Tag {
+			case 'r':
+				diff.LinesRemoved += op.I2 - op.I1
+				diff.LinesAdded += op.J2 - op.J1
+			case 'd':
+				diff.LinesRemoved += op.I2 - op.I1
+			case 'i':
+				diff.LinesAdded += op.J2 - op.J1
+			}
+		}
+	}
+
+	fromSettings, toSettings := settings(from), settings(to)
+	fields := make([]string, 0, len(fromSettings)+len(toSettings))
+	for field := range fromSettings {
+		fields = append(fields, field)
+	}
+	for field := range toSettings {
+		if _, ok := fromSettings[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if fromSettings[field] != toSettings[field] {
+			diff.Changes = append(diff.Changes, domain.SettingChange{
+				Field: field,
+				From:  fromSettings[field],
+				To:    toSettings[field],
+			})
+		}
+	}
+
+	diff.IndicatorsAdded = missingFrom(to.Indicators, from.Indicators)
+	diff.IndicatorsRemoved = missingFrom(from.Indicators, to.Indicators)
+
+	return diff, nil
+}
+
+// settings returns a strategy's settings formatted as in code, by field.
+// Unset settings are left out.
+func settings(s *domain.Strategy) map[string]string {
+	merged := *s
+	var hyperopt []domain.HyperoptParam
+	if params, err := Parse(s.Code); err == nil {
+		params.ApplyTo(&merged)
+		hyperopt = params.HyperoptParams
+	}
+
+	out := make(map[string]string)
+	if merged.Timeframe != "" {
+		out["timeframe"] = merged.Timeframe
+	}
+	if merged.Stoploss != nil {
+		out["stoploss"] = formatFloat(*merged.Stoploss)
+	}
+	out["trailing_stop"] = formatBool(merged.TrailingStop)
+	if merged.TrailingStopPositive != nil {
+		out["trailing_stop_positive"] = formatFloat(*merged.TrailingStopPositive)
+	}
+	if merged.TrailingStopPositiveOffset != nil {
+		out["trailing_stop_positive_offset"] = formatFloat(*merged.TrailingStopPositiveOffset)
+	}
+	if merged.StartupCandleCount != nil {
+		out["startup_candle_count"] = strconv.Itoa(*merged.StartupCandleCount)
+	}
+	if len(merged.MinimalROI) > 0 {
+		steps := make([]domain.ROIStep, 0, len(merged.MinimalROI))
+		for minutes, roi := range merged.MinimalROI {
+			m, err := strconv.Atoi(minutes)
+			if err != nil {
+				continue
+			}
+			steps = append(steps, domain.ROIStep{Minutes: m, ROI: roi})
+		}
+		out["minimal_roi"] = formatROI(steps, false, "")
+	}
+	for _, p := range hyperopt {
+		out["hyperopt."+p.Name] = formatHyperoptParam(p)
+	}
+
+	return out
+}
+
+// formatHyperoptParam renders the default and search space of a hyperopt
+// parameter, e.g. "default=30, low=10, high=40, space=buy".
+func formatHyperoptParam(p domain.HyperoptParam) string {
+	parts := []string{"default=" + formatLiteral(p.Default, p.Type)}
+	if p.Low != nil {
+		parts = append(parts, "low="+formatLiteral(*p.Low, p.Type))
+	}
+	if p.High != nil {
+		parts = append(parts, "high="+formatLiteral(*p.High, p.Type))
+	}
+	if len(p.Options) > 0 {
+		options := make([]string, len(p.Options))
+		for i, o := range p.Options {
+			options[i] = formatLiteral(o, p.Type)
+		}
+		parts = append(parts, "options=["+strings.Join(options, ", ")+"]")
+	}
+	if p.Space != "" {
+		parts = append(parts, "space="+p.Space)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// missingFrom returns the values of a that are not in b, sorted.
+func missingFrom(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	out := []string{}
+	for _, v := range a {
+		if !in[v] {
+			out = append(out, v)
+			in[v] = true
+		}
+	}
+	sort.Strings(out)
+	return out
+}
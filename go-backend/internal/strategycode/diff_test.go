@@ -0,0 +1,58 @@
+package strategycode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestDiff(t *testing.T) {
+	parent := domain.NewStrategy("SampleStrategy", sampleStrategy, "", nil)
+	parent.Indicators = []string{"rsi", "ema"}
+
+	childCode := strings.Replace(sampleStrategy, "stoploss: float = -0.10", "stoploss: float = -0.05", 1)
+	childCode = strings.Replace(childCode, "default=30", "default=25", 1)
+	child := domain.NewStrategy("SampleStrategy", childCode, "", &parent.ID)
+	child.Indicators = []string{"rsi", "macd"}
+
+	diff, err := Diff(parent, child, domain.DefaultDiffContextLines)
+	require.NoError(t, err)
+
+	assert.False(t, diff.Identical)
+	assert.Equal(t, parent.ID, diff.FromID)
+	assert.Equal(t, 2, diff.LinesAdded)
+	assert.Equal(t, 2, diff.LinesRemoved)
+	assert.Contains(t, diff.UnifiedDiff, "--- a/SampleStrategy.py\n+++ b/SampleStrategy.py\n")
+	assert.Contains(t, diff.UnifiedDiff, "-    stoploss: float = -0.10\n+    stoploss: float = -0.05\n")
+
+	assert.Equal(t, []domain.SettingChange{
+		{Field: "hyperopt.buy_rsi", From: "default=30, low=10, high=40, space=buy", To: "default=25, low=10, high=40, space=buy"},
+		{Field: "stoploss", From: "-0.1", To: "-0.05"},
+	}, diff.Changes)
+	assert.Equal(t, []string{"macd"}, diff.IndicatorsAdded)
+	assert.Equal(t, []string{"ema"}, diff.IndicatorsRemoved)
+}
+
+func TestDiff_StoredMetadata(t *testing.T) {
+	// Code without a strategy class: settings come from the stored metadata
+	from := domain.NewStrategy("A", "print('a')\n", "", nil)
+	from.Timeframe = "5m"
+	to := domain.NewStrategy("B", "print('a')\n", "", nil)
+	to.Timeframe = "1h"
+	to.MinimalROI = map[string]float64{"0": 0.04, "30": 0.02}
+
+	diff, err := Diff(from, to, 0)
+	require.NoError(t, err)
+
+	assert.True(t, diff.Identical)
+	assert.Empty(t, diff.UnifiedDiff)
+	assert.Equal(t, []domain.SettingChange{
+		{Field: "minimal_roi", From: "", To: `{"0": 0.04, "30": 0.02}`},
+		{Field: "timeframe", From: "5m", To: "1h"},
+	}, diff.Changes)
+	assert.Empty(t, diff.IndicatorsAdded)
+}
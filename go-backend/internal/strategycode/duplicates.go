@@ -0,0 +1,226 @@
+package strategycode
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// classNameRegex matches class declarations and captures their name and bases.
+var classNameRegex = regexp.MustCompile(`(?m)^[ \t]*class\s+(\w+)\s*\(([^)]*)\)\s*:`)
+
+// canonicalClassName replaces the strategy class name before fingerprinting.
+const canonicalClassName = "Strategy"
+
+// FindDuplicates groups strategies whose code matches apart from the
+// strategy class name, or, if normalized, also apart from comments,
+// docstrings and whitespace. Strategies are expected oldest first; the
+// oldest of each cluster is the one to keep.
+func FindDuplicates(strategies []*domain.Strategy, normalized bool) *domain.StrategyDuplicatesReport {
+	type group struct {
+		exact   map[string]bool
+		members []*domain.Strategy
+	}
+
+	groups := map[string]*group{}
+	var order []string
+	for _, s := range strategies {
+		code := renameClass(s.Code)
+		exact := fingerprint(code)
+		key := exact
+		if normalized {
+			key = fingerprint(Normalize(code))
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{exact: map[string]bool{}}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.exact[exact] = true
+		g.members = append(g.members, s)
+	}
+
+	report := &domain.StrategyDuplicatesReport{
+		Scanned:  len(strategies),
+		Clusters: []domain.DuplicateCluster{},
+	}
+	for _, key := range order {
+		g := groups[key]
+		if len(g.members) < 2 {
+			continue
+		}
+
+		cluster := domain.DuplicateCluster{
+			Match:       domain.DuplicateMatchExact,
+			Fingerprint: key,
+			KeepID:      g.members[0].ID,
+			Strategies:  make([]domain.DuplicateStrategy, len(g.members)),
+		}
+		if len(g.exact) > 1 {
+			cluster.Match = domain.DuplicateMatchNormalized
+		}
+		for i, s := range g.members {
+			cluster.Strategies[i] = domain.DuplicateStrategy{
+				ID:         s.ID,
+				Name:       s.Name,
+				CodeHash:   s.CodeHash,
+				CreatedAt:  s.CreatedAt,
+				ArchivedAt: s.ArchivedAt,
+			}
+		}
+		report.Clusters = append(report.Clusters, cluster)
+		report.Duplicates += len(g.members) - 1
+	}
+
+	// Largest first; ties keep the order of their oldest strategy
+	sort.SliceStable(report.Clusters, func(i, j int) bool {
+		return len(report.Clusters[i].Strategies) > len(report.Clusters[j].Strategies)
+	})
+
+	return report
+}
+
+// Normalize strips what doesn't change what code does: comments, docstrings,
+// blank lines, indentation and whitespace between tokens. Line breaks inside
+// brackets are dropped too, so rewrapping a call doesn't matter. String
+// literals are kept as written.
+func Normalize(code string) string {
+	var b strings.Builder
+	var last byte     // Last byte written
+	lineStart := true // Nothing written on the current line yet
+	space := false    // Whitespace was skipped since the last token
+	depth := 0        // Bracket nesting
+
+	// write appends a token, separating it from the previous one only where
+	// two words would otherwise run together
+	write := func(tok string) {
+		if space && !lineStart && isWordByte(last) && isWordByte(tok[0]) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(tok)
+		last = tok[len(tok)-1]
+		lineStart, space = false, false
+	}
+
+	for i := 0; i < len(code); {
+		c := code[i]
+		switch {
+		case c == '#':
+			for i < len(code) && code[i] != '\n' {
+				i++
+			}
+		case c == '\n':
+			if depth == 0 && !lineStart {
+				b.WriteByte('\n')
+				lineStart = true
+			}
+			space = true
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			space = true
+			i++
+		case c == '"' || c == '\'':
+			end := stringEnd(code, i)
+			if lineStart && depth == 0 && isDocstring(code, end) {
+				i = end
+				continue
+			}
+			write(code[i:end])
+			i = end
+		case isWordByte(c):
+			j := i
+			for j < len(code) && isWordByte(code[j]) {
+				j++
+			}
+			// String prefixes such as r"..." and f'...'
+			if j < len(code) && (code[j] == '"' || code[j] == '\'') && j-i <= 2 &&
+				strings.Trim(strings.ToLower(code[i:j]), "rbfu") == "" {
+				j = stringEnd(code, j)
+			}
+			write(code[i:j])
+			i = j
+		default:
+			switch c {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				if depth > 0 {
+					depth--
+				}
+			}
+			write(code[i : i+1])
+			i++
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renameClass replaces the name of the strategy class, and references to
+// it, with a fixed name.
+func renameClass(code string) string {
+	var name string
+	for _, m := range classNameRegex.FindAllStringSubmatch(code, -1) {
+		if name == "" || strings.Contains(m[2], "IStrategy") {
+			name = m[1]
+		}
+		if strings.Contains(m[2], "IStrategy") {
+			break
+		}
+	}
+	if name == "" || name == canonicalClassName {
+		return code
+	}
+	return regexp.MustCompile(`\b`+regexp.QuoteMeta(name)+`\b`).ReplaceAllString(code, canonicalClassName)
+}
+
+// stringEnd returns the offset just past the string literal whose opening
+// quote is at start. Unterminated strings end at the end of the line, or of
+// the code for triple-quoted ones.
+func stringEnd(code string, start int) int {
+	q := code[start : start+1]
+	if strings.HasPrefix(code[start:], strings.Repeat(q, 3)) {
+		q = strings.Repeat(q, 3)
+	}
+
+	for i := start + len(q); i < len(code); i++ {
+		switch {
+		case code[i] == '\\':
+			i++
+		case len(q) == 1 && code[i] == '\n':
+			return i
+		case strings.HasPrefix(code[i:], q):
+			return i + len(q)
+		}
+	}
+	return len(code)
+}
+
+// isDocstring reports whether the string literal ending at end, which begins
+// a line, is a statement of its own: nothing but a comment follows it on its
+// line.
+func isDocstring(code string, end int) bool {
+	rest := code[end:]
+	if idx := strings.IndexByte(rest, '\n'); idx != -1 {
+		rest = rest[:idx]
+	}
+	rest = strings.TrimSpace(rest)
+	return rest == "" || strings.HasPrefix(rest, "#")
+}
+
+// isWordByte reports whether c can be part of a Python identifier or number.
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// fingerprint returns the SHA256 of code, as code_hash is computed.
+func fingerprint(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
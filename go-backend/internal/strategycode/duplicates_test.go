@@ -0,0 +1,88 @@
+package strategycode
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+func TestNormalize(t *testing.T) {
+	code := `class SampleStrategy(IStrategy):
+    """
+    Sample strategy.
+    """
+    timeframe = '5m'  # candles
+
+    def populate_indicators(self, dataframe, metadata):
+        dataframe['rsi'] = ta.RSI(dataframe,
+                                  timeperiod=14)
+        return dataframe  # done
+`
+	assert.Equal(t,
+		"class SampleStrategy(IStrategy):\ntimeframe='5m'\ndef populate_indicators(self,dataframe,metadata):\ndataframe['rsi']=ta.RSI(dataframe,timeperiod=14)\nreturn dataframe",
+		Normalize(code))
+
+	// Strings are kept as written, including what looks like a comment
+	assert.Equal(t, `x="a  # b"+r'\'c'`, Normalize(`x = "a  # b" + r'\'c'  # comment`))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	newStrategy := func(name, code string) *domain.Strategy {
+		s := domain.NewStrategy(name, code, "", nil)
+		s.CodeHash = fingerprint(code)
+		s.CreatedAt = created
+		created = created.Add(time.Hour)
+		return s
+	}
+
+	original := newStrategy("SampleStrategy", sampleStrategy)
+	renamed := newStrategy("SampleStrategyV2", strings.ReplaceAll(sampleStrategy, "SampleStrategy", "SampleStrategyV2"))
+	reformatted := newStrategy("Sample", strings.ReplaceAll(
+		strings.ReplaceAll(sampleStrategy, "class SampleStrategy", "class Sample"),
+		"    # ROI table\n", ""))
+	changed := newStrategy("Changed", strings.Replace(sampleStrategy, "default=30", "default=25", 1))
+	strategies := []*domain.Strategy{original, renamed, reformatted, changed}
+
+	t.Run("Exact", func(t *testing.T) {
+		report := FindDuplicates(strategies, false)
+
+		assert.Equal(t, 4, report.Scanned)
+		assert.Equal(t, 1, report.Duplicates)
+		require.Len(t, report.Clusters, 1)
+		cluster := report.Clusters[0]
+		assert.Equal(t, domain.DuplicateMatchExact, cluster.Match)
+		assert.Equal(t, original.ID, cluster.KeepID)
+		require.Len(t, cluster.Strategies, 2)
+		assert.Equal(t, renamed.ID, cluster.Strategies[1].ID)
+		assert.Equal(t, renamed.CodeHash, cluster.Strategies[1].CodeHash)
+	})
+
+	t.Run("Normalized", func(t *testing.T) {
+		report := FindDuplicates(strategies, true)
+
+		assert.Equal(t, 2, report.Duplicates)
+		require.Len(t, report.Clusters, 1)
+		cluster := report.Clusters[0]
+		assert.Equal(t, domain.DuplicateMatchNormalized, cluster.Match)
+		assert.Equal(t, original.ID, cluster.KeepID)
+		var ids []string
+		for _, s := range cluster.Strategies {
+			ids = append(ids, s.Name)
+		}
+		assert.Equal(t, []string{"SampleStrategy", "SampleStrategyV2", "Sample"}, ids)
+	})
+
+	t.Run("NoDuplicates", func(t *testing.T) {
+		report := FindDuplicates([]*domain.Strategy{original, changed}, true)
+
+		assert.Zero(t, report.Duplicates)
+		assert.NotNil(t, report.Clusters)
+		assert.Empty(t, report.Clusters)
+	})
+}
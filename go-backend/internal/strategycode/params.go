@@ -0,0 +1,803 @@
+// Package strategycode extracts and rewrites tunable parameters in Freqtrade strategy source.
+//
+// It is a line-oriented scanner rather than a Python parser: it understands
+// class-level assignments (minimal_roi, stoploss, trailing stop settings,
+// timeframe) and *Parameter(...) hyperopt declarations, and rewrites only the
+// value spans it changes so the rest of the code keeps its formatting.
+package strategycode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+// ErrNoStrategyClass is returned when the code does not declare a strategy class.
+var ErrNoStrategyClass = errors.New("no strategy class found in code")
+
+var (
+	// classRegex matches a class declaration and captures its indentation and bases.
+	classRegex = regexp.MustCompile(`^(\s*)class\s+\w+\s*\(([^)]*)\)\s*:`)
+
+	// attrRegex matches a class-level assignment with an optional type annotation.
+	attrRegex = regexp.MustCompile(`^([A-Za-z_]\w*)\s*(?::\s*[^=]+?)?\s*=\s*`)
+
+	// paramCallRegex matches hyperopt parameter declarations.
+	paramCallRegex = regexp.MustCompile(`^(IntParameter|DecimalParameter|RealParameter|BooleanParameter|CategoricalParameter)\s*\(`)
+)
+
+// paramTypes maps Freqtrade parameter classes to parameter types.
+var paramTypes = map[string]domain.HyperoptParamType{
+	"IntParameter":         domain.HyperoptParamInt,
+	"DecimalParameter":     domain.HyperoptParamDecimal,
+	"RealParameter":        domain.HyperoptParamReal,
+	"BooleanParameter":     domain.HyperoptParamBoolean,
+	"CategoricalParameter": domain.HyperoptParamCategorical,
+}
+
+// Class attribute names handled as core settings, in insertion order.
+const (
+	attrMinimalROI                  = "minimal_roi"
+	attrStoploss                    = "stoploss"
+	attrTrailingStop                = "trailing_stop"
+	attrTrailingStopPositive        = "trailing_stop_positive"
+	attrTrailingStopPositiveOffset  = "trailing_stop_positive_offset"
+	attrTrailingOnlyOffsetIsReached = "trailing_only_offset_is_reached"
+	attrTimeframe                   = "timeframe"
+)
+
+var coreAttrs = []string{
+	attrMinimalROI,
+	attrStoploss,
+	attrTrailingStop,
+	attrTrailingStopPositive,
+	attrTrailingStopPositiveOffset,
+	attrTrailingOnlyOffsetIsReached,
+	attrTimeframe,
+}
+
+// attribute is a class-level assignment located in the source.
+type attribute struct {
+	name       string
+	valueStart int // offset of the value expression
+	valueEnd   int // offset just past the value expression
+	lineEnd    int // offset just past the statement's final newline
+}
+
+// source is a scanned strategy class.
+type source struct {
+	code      string
+	indent    string // class body indentation
+	bodyStart int    // offset where new attributes are inserted if no anchor exists
+	attrs     map[string]*attribute
+	order     []*attribute
+}
+
+// value returns the raw value expression of an attribute.
+func (s *source) value(a *attribute) string {
+	return s.code[a.valueStart:a.valueEnd]
+}
+
+// Parse extracts the tunable parameters declared on the strategy class.
+func Parse(code string) (*domain.StrategyParams, error) {
+	src, err := scan(code)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &domain.StrategyParams{
+		MinimalROI:     []domain.ROIStep{},
+		HyperoptParams: []domain.HyperoptParam{},
+	}
+
+	if a := src.attrs[attrMinimalROI]; a != nil {
+		steps, err := parseROI(src.value(a))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse minimal_roi: %w", err)
+		}
+		params.MinimalROI = steps
+	}
+
+	params.Stoploss = src.floatAttr(attrStoploss)
+	params.TrailingStop = src.boolAttr(attrTrailingStop)
+	params.TrailingStopPositive = src.floatAttr(attrTrailingStopPositive)
+	params.TrailingStopPositiveOffset = src.floatAttr(attrTrailingStopPositiveOffset)
+	params.TrailingOnlyOffsetIsReached = src.boolAttr(attrTrailingOnlyOffsetIsReached)
+	if v, ok := src.literalAttr(attrTimeframe).(string); ok {
+		params.Timeframe = &v
+	}
+
+	for _, a := range src.order {
+		value := src.value(a)
+		m := paramCallRegex.FindStringSubmatch(value)
+		if m == nil {
+			continue
+		}
+		call, err := parseCall(value, a.valueStart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse hyperopt parameter %s: %w", a.name, err)
+		}
+		params.HyperoptParams = append(params.HyperoptParams, hyperoptParam(a.name, paramTypes[m[1]], call))
+	}
+
+	return params, nil
+}
+
+// Apply writes the patch values into the strategy code and returns the new code.
+// Attributes that are not yet declared are added to the class body.
+func Apply(code string, patch *domain.StrategyParamsPatch) (string, error) {
+	src, err := scan(code)
+	if err != nil {
+		return "", err
+	}
+	current, err := Parse(code)
+	if err != nil {
+		return "", err
+	}
+	if err := patch.Validate(current); err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+
+	var edits []edit
+
+	if patch.MinimalROI != nil {
+		multiline := false
+		if a := src.attrs[attrMinimalROI]; a != nil {
+			multiline = strings.Contains(src.value(a), "\n")
+		}
+		edits = append(edits, src.setAttr(attrMinimalROI, formatROI(patch.MinimalROI, multiline, src.indent)))
+	}
+	if patch.Stoploss != nil {
+		edits = append(edits, src.setAttr(attrStoploss, formatFloat(*patch.Stoploss)))
+	}
+	if patch.TrailingStop != nil {
+		edits = append(edits, src.setAttr(attrTrailingStop, formatBool(*patch.TrailingStop)))
+	}
+	if patch.TrailingStopPositive != nil {
+		edits = append(edits, src.setAttr(attrTrailingStopPositive, formatFloat(*patch.TrailingStopPositive)))
+	}
+	if patch.TrailingStopPositiveOffset != nil {
+		edits = append(edits, src.setAttr(attrTrailingStopPositiveOffset, formatFloat(*patch.TrailingStopPositiveOffset)))
+	}
+	if patch.TrailingOnlyOffsetIsReached != nil {
+		edits = append(edits, src.setAttr(attrTrailingOnlyOffsetIsReached, formatBool(*patch.TrailingOnlyOffsetIsReached)))
+	}
+	if patch.Timeframe != nil {
+		edits = append(edits, src.setAttr(attrTimeframe, strconv.Quote(*patch.Timeframe)))
+	}
+
+	// Apply hyperopt defaults in a stable order
+	names := make([]string, 0, len(patch.HyperoptDefaults))
+	for name := range patch.HyperoptDefaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		a := src.attrs[name]
+		call, err := parseCall(src.value(a), a.valueStart)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse hyperopt parameter %s: %w", name, err)
+		}
+		value := formatLiteral(patch.HyperoptDefaults[name], current.HyperoptParam(name).Type)
+		if arg := call.kwarg("default"); arg != nil {
+			edits = append(edits, edit{start: arg.valueStart, end: arg.end, text: value})
+		} else if len(call.args) == 0 {
+			edits = append(edits, edit{start: call.closeParen, end: call.closeParen, text: "default=" + value})
+		} else {
+			last := call.args[len(call.args)-1].end
+			edits = append(edits, edit{start: last, end: last, text: ", default=" + value})
+		}
+	}
+
+	updated := applyEdits(code, edits)
+
+	// Make sure the result still scans
+	if _, err := Parse(updated); err != nil {
+		return "", fmt.Errorf("patched code failed to parse: %w", err)
+	}
+
+	return updated, nil
+}
+
+// ============================================================================
+// Scanning
+// ============================================================================
+
+// scan locates the strategy class and its class-level assignments.
+func scan(code string) (*source, error) {
+	lines := splitLines(code)
+
+	// Prefer a class deriving from IStrategy, fall back to the first class
+	classLine, classIndent := -1, 0
+	for i, l := range lines {
+		m := classRegex.FindStringSubmatch(code[l.start:l.end])
+		if m == nil {
+			continue
+		}
+		if classLine == -1 || strings.Contains(m[2], "IStrategy") {
+			classLine, classIndent = i, len(m[1])
+		}
+		if strings.Contains(m[2], "IStrategy") {
+			break
+		}
+	}
+	if classLine == -1 {
+		return nil, ErrNoStrategyClass
+	}
+
+	src := &source{
+		code:      code,
+		bodyStart: -1,
+		attrs:     map[string]*attribute{},
+	}
+
+	var tripleQuote string
+	bodyIndent := -1
+	for i := classLine + 1; i < len(lines); i++ {
+		l := lines[i]
+		text := code[l.start:l.end]
+
+		// Skip the inside of triple-quoted strings (docstrings, SQL, etc.)
+		if tripleQuote != "" {
+			if strings.Contains(text, tripleQuote) {
+				tripleQuote = ""
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indent := len(text) - len(strings.TrimLeft(text, " \t"))
+		if indent <= classIndent {
+			break // end of class body
+		}
+		if bodyIndent == -1 {
+			bodyIndent = indent
+			src.indent = text[:indent]
+		}
+
+		if q := openedTripleQuote(trimmed); q != "" {
+			tripleQuote = q
+			continue
+		}
+		if indent != bodyIndent {
+			continue
+		}
+		if strings.HasPrefix(trimmed, `"`) || strings.HasPrefix(trimmed, `'`) {
+			continue // single-line docstring
+		}
+		if src.bodyStart == -1 {
+			src.bodyStart = l.start
+		}
+
+		m := attrRegex.FindStringIndex(trimmed)
+		if m == nil || strings.HasPrefix(trimmed[m[1]:], "=") {
+			continue
+		}
+
+		name := attrRegex.FindStringSubmatch(trimmed)[1]
+		valueStart := l.start + indent + m[1]
+		valueEnd, stmtEnd := scanValue(code, valueStart)
+		a := &attribute{name: name, valueStart: valueStart, valueEnd: valueEnd, lineEnd: stmtEnd}
+		src.attrs[name] = a
+		src.order = append(src.order, a)
+
+		// Skip continuation lines of multi-line values
+		for i+1 < len(lines) && lines[i+1].start < stmtEnd {
+			i++
+		}
+	}
+
+	if bodyIndent == -1 {
+		return nil, ErrNoStrategyClass
+	}
+	if src.bodyStart == -1 {
+		// Class body holds only a docstring; append after it
+		src.bodyStart = len(code)
+		if last := lines[len(lines)-1]; last.end < len(code) {
+			src.bodyStart = last.end + 1
+		}
+	}
+
+	return src, nil
+}
+
+// line is the span of a line without its newline.
+type line struct {
+	start, end int
+}
+
+// splitLines returns the spans of all lines in code.
+func splitLines(code string) []line {
+	var lines []line
+	start := 0
+	for start <= len(code) {
+		idx := strings.IndexByte(code[start:], '\n')
+		if idx == -1 {
+			if start < len(code) {
+				lines = append(lines, line{start: start, end: len(code)})
+			}
+			break
+		}
+		lines = append(lines, line{start: start, end: start + idx})
+		start += idx + 1
+	}
+	return lines
+}
+
+// openedTripleQuote returns the delimiter if the line opens a triple-quoted
+// string that is not closed on the same line.
+func openedTripleQuote(trimmed string) string {
+	for _, q := range []string{`"""`, `'''`} {
+		if strings.Count(trimmed, q)%2 == 1 {
+			return q
+		}
+	}
+	return ""
+}
+
+// scanValue finds the end of the expression starting at pos, following
+// brackets across lines. It returns the end of the expression (before any
+// trailing comment or whitespace) and the end of the statement line.
+func scanValue(code string, pos int) (valueEnd, stmtEnd int) {
+	depth := 0
+	var quote byte
+	end := pos
+	for i := pos; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			end = i + 1
+		case c == '\'' || c == '"':
+			quote = c
+			end = i + 1
+		case c == '(' || c == '[' || c == '{':
+			depth++
+			end = i + 1
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			end = i + 1
+		case c == '#':
+			// Comment runs to end of line
+			nl := strings.IndexByte(code[i:], '\n')
+			if nl == -1 {
+				return end, len(code)
+			}
+			if depth <= 0 {
+				return end, i + nl + 1
+			}
+			i += nl - 1
+		case c == '\n':
+			if depth <= 0 {
+				return end, i + 1
+			}
+		case c != ' ' && c != '\t' && c != '\r':
+			end = i + 1
+		}
+	}
+	return end, len(code)
+}
+
+// floatAttr returns a numeric class attribute, if declared.
+func (s *source) floatAttr(name string) *float64 {
+	if v, ok := s.literalAttr(name).(float64); ok {
+		return &v
+	}
+	return nil
+}
+
+// boolAttr returns a boolean class attribute, if declared.
+func (s *source) boolAttr(name string) *bool {
+	if v, ok := s.literalAttr(name).(bool); ok {
+		return &v
+	}
+	return nil
+}
+
+// literalAttr returns the literal value of a class attribute, or nil.
+func (s *source) literalAttr(name string) interface{} {
+	a := s.attrs[name]
+	if a == nil {
+		return nil
+	}
+	v, ok := parseLiteral(s.value(a))
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+// ============================================================================
+// Editing
+// ============================================================================
+
+// edit replaces code[start:end] with text.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// setAttr returns an edit that sets a class attribute, declaring it if missing.
+func (s *source) setAttr(name, value string) edit {
+	if a := s.attrs[name]; a != nil {
+		return edit{start: a.valueStart, end: a.valueEnd, text: value}
+	}
+
+	// Insert after the last declared core setting, or at the top of the body
+	anchor := s.bodyStart
+	for _, core := range coreAttrs {
+		if a := s.attrs[core]; a != nil && a.lineEnd > anchor {
+			anchor = a.lineEnd
+		}
+	}
+	text := s.indent + name + " = " + value + "\n"
+	if anchor > 0 && anchor == len(s.code) && !strings.HasSuffix(s.code, "\n") {
+		text = "\n" + text
+	}
+	return edit{start: anchor, end: anchor, text: text}
+}
+
+// applyEdits applies non-overlapping edits. Insertions at the same offset keep their order.
+func applyEdits(code string, edits []edit) string {
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		code = code[:e.start] + e.text + code[e.end:]
+	}
+	return code
+}
+
+// formatROI renders a minimal_roi dict sorted by minutes.
+func formatROI(steps []domain.ROIStep, multiline bool, indent string) string {
+	sorted := append([]domain.ROIStep(nil), steps...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Minutes < sorted[j].Minutes
+	})
+
+	entries := make([]string, len(sorted))
+	for i, step := range sorted {
+		entries[i] = fmt.Sprintf("%q: %s", strconv.Itoa(step.Minutes), formatFloat(step.ROI))
+	}
+
+	if len(entries) == 0 {
+		return "{}"
+	}
+	if !multiline {
+		return "{" + strings.Join(entries, ", ") + "}"
+	}
+	inner := indent + "    "
+	return "{\n" + inner + strings.Join(entries, ",\n"+inner) + "\n" + indent + "}"
+}
+
+// formatLiteral renders a JSON-decoded value as a Python literal.
+func formatLiteral(v interface{}, paramType domain.HyperoptParamType) string {
+	switch val := v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		return formatBool(val)
+	case float64:
+		if paramType == domain.HyperoptParamInt {
+			return strconv.FormatInt(int64(val), 10)
+		}
+		return formatFloat(val)
+	case string:
+		return strconv.Quote(val)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// formatFloat renders a float in the shortest exact form.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatBool renders a Python boolean.
+func formatBool(v bool) string {
+	if v {
+		return "True"
+	}
+	return "False"
+}
+
+// ============================================================================
+// Literal parsing
+// ============================================================================
+
+// parseLiteral parses a simple Python literal: number, bool, None, string, or list.
+func parseLiteral(s string) (interface{}, bool) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "True":
+		return true, true
+	case "False":
+		return false, true
+	case "None":
+		return nil, true
+	}
+
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], true
+	}
+
+	if len(s) >= 2 && (s[0] == '[' || s[0] == '(') {
+		items := []interface{}{}
+		for _, part := range splitTopLevel(s[1:len(s)-1], ',') {
+			if strings.TrimSpace(part.text) == "" {
+				continue
+			}
+			v, ok := parseLiteral(part.text)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, v)
+		}
+		return items, true
+	}
+
+	if f, err := strconv.ParseFloat(strings.ReplaceAll(s, "_", ""), 64); err == nil && !math.IsInf(f, 0) {
+		return f, true
+	}
+
+	return nil, false
+}
+
+// parseROI parses a minimal_roi dict literal.
+func parseROI(s string) ([]domain.ROIStep, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, errors.New("expected a dict literal")
+	}
+
+	steps := []domain.ROIStep{}
+	for _, part := range splitTopLevel(s[1:len(s)-1], ',') {
+		entry := stripComments(part.text)
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		kv := splitTopLevel(entry, ':')
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid entry %q", strings.TrimSpace(entry))
+		}
+		key, ok := parseLiteral(kv[0].text)
+		if !ok {
+			return nil, fmt.Errorf("invalid key %q", strings.TrimSpace(kv[0].text))
+		}
+		var minutes int
+		switch k := key.(type) {
+		case string:
+			n, err := strconv.Atoi(k)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %q", k)
+			}
+			minutes = n
+		case float64:
+			minutes = int(k)
+		default:
+			return nil, fmt.Errorf("invalid key %v", k)
+		}
+		value, ok := parseLiteral(kv[1].text)
+		roi, isFloat := value.(float64)
+		if !ok || !isFloat {
+			return nil, fmt.Errorf("invalid value %q", strings.TrimSpace(kv[1].text))
+		}
+		steps = append(steps, domain.ROIStep{Minutes: minutes, ROI: roi})
+	}
+
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].Minutes < steps[j].Minutes
+	})
+	return steps, nil
+}
+
+// stripComments removes Python comments from each line of s.
+func stripComments(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if idx := strings.IndexByte(l, '#'); idx != -1 && !strings.ContainsAny(l[:idx], `"'`) {
+			lines[i] = l[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// part is a substring of a split, with its offset in the split input.
+type part struct {
+	text   string
+	offset int
+}
+
+// splitTopLevel splits s on sep, ignoring separators nested in brackets or strings.
+func splitTopLevel(s string, sep byte) []part {
+	var parts []part
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#':
+			if nl := strings.IndexByte(s[i:], '\n'); nl != -1 {
+				i += nl
+			} else {
+				i = len(s)
+			}
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, part{text: s[start:i], offset: start})
+			start = i + 1
+		}
+	}
+	return append(parts, part{text: s[start:], offset: start})
+}
+
+// ============================================================================
+// Hyperopt parameter calls
+// ============================================================================
+
+// callArg is an argument of a parameter declaration call.
+type callArg struct {
+	key        string // empty for positional arguments
+	value      string
+	valueStart int // absolute offset of the value
+	end        int // absolute offset just past the value
+}
+
+// call is a parsed *Parameter(...) call.
+type call struct {
+	args       []callArg
+	closeParen int // absolute offset of the closing parenthesis
+}
+
+// kwarg returns the keyword argument with the given name, or nil.
+func (c *call) kwarg(name string) *callArg {
+	for i := range c.args {
+		if c.args[i].key == name {
+			return &c.args[i]
+		}
+	}
+	return nil
+}
+
+// positional returns the positional arguments.
+func (c *call) positional() []callArg {
+	var args []callArg
+	for _, a := range c.args {
+		if a.key == "" {
+			args = append(args, a)
+		}
+	}
+	return args
+}
+
+// kwargRegex matches the "name=" prefix of a keyword argument.
+var kwargRegex = regexp.MustCompile(`^\s*([A-Za-z_]\w*)\s*=[^=]`)
+
+// parseCall parses the arguments of a call expression located at offset.
+func parseCall(expr string, offset int) (*call, error) {
+	open := strings.IndexByte(expr, '(')
+	closing := strings.LastIndexByte(expr, ')')
+	if open == -1 || closing < open {
+		return nil, errors.New("unbalanced parentheses")
+	}
+
+	c := &call{closeParen: offset + closing}
+	inner := expr[open+1 : closing]
+	if strings.TrimSpace(stripComments(inner)) == "" {
+		return c, nil
+	}
+
+	for _, p := range splitTopLevel(inner, ',') {
+		text := stripComments(p.text)
+		if strings.TrimSpace(text) == "" {
+			continue // trailing comma
+		}
+		arg := callArg{}
+		valueOffset := 0
+		if m := kwargRegex.FindStringSubmatchIndex(text); m != nil {
+			arg.key = text[m[2]:m[3]]
+			valueOffset = m[1] - 1
+		}
+		value := text[valueOffset:]
+		lead := len(value) - len(strings.TrimLeft(value, " \t\r\n"))
+		value = strings.TrimSpace(value)
+		arg.value = value
+		arg.valueStart = offset + open + 1 + p.offset + valueOffset + lead
+		arg.end = arg.valueStart + len(value)
+		c.args = append(c.args, arg)
+	}
+	return c, nil
+}
+
+// hyperoptParam builds a HyperoptParam from a parsed declaration.
+func hyperoptParam(name string, paramType domain.HyperoptParamType, c *call) domain.HyperoptParam {
+	param := domain.HyperoptParam{Name: name, Type: paramType}
+
+	literal := func(arg *callArg) interface{} {
+		if arg == nil {
+			return nil
+		}
+		v, _ := parseLiteral(arg.value)
+		return v
+	}
+	number := func(v interface{}) *float64 {
+		if f, ok := v.(float64); ok {
+			return &f
+		}
+		return nil
+	}
+
+	positional := c.positional()
+	switch paramType {
+	case domain.HyperoptParamInt, domain.HyperoptParamDecimal, domain.HyperoptParamReal:
+		if len(positional) > 0 {
+			first := literal(&positional[0])
+			if bounds, ok := first.([]interface{}); ok && len(bounds) == 2 {
+				param.Low, param.High = number(bounds[0]), number(bounds[1])
+			} else {
+				param.Low = number(first)
+				if len(positional) > 1 {
+					param.High = number(literal(&positional[1]))
+				}
+			}
+		}
+		if low := c.kwarg("low"); low != nil {
+			param.Low = number(literal(low))
+		}
+		if high := c.kwarg("high"); high != nil {
+			param.High = number(literal(high))
+		}
+		if decimals, ok := literal(c.kwarg("decimals")).(float64); ok {
+			d := int(decimals)
+			param.Decimals = &d
+		}
+	case domain.HyperoptParamCategorical:
+		if len(positional) > 0 {
+			if options, ok := literal(&positional[0]).([]interface{}); ok {
+				param.Options = options
+			}
+		} else if options, ok := literal(c.kwarg("categories")).([]interface{}); ok {
+			param.Options = options
+		}
+	}
+
+	param.Default = literal(c.kwarg("default"))
+	if param.Default == nil && paramType == domain.HyperoptParamCategorical && len(param.Options) > 0 {
+		param.Default = param.Options[0] // Freqtrade defaults to the first category
+	}
+	if space, ok := literal(c.kwarg("space")).(string); ok {
+		param.Space = space
+	}
+	if optimize, ok := literal(c.kwarg("optimize")).(bool); ok {
+		param.Optimize = &optimize
+	}
+
+	return param
+}
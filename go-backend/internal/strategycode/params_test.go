@@ -0,0 +1,174 @@
+package strategycode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+)
+
+const sampleStrategy = `from freqtrade.strategy import IStrategy, IntParameter, DecimalParameter, CategoricalParameter
+
+
+class SampleStrategy(IStrategy):
+    """
+    Sample strategy.
+    stoploss = -0.99 (docstring, not code)
+    """
+
+    INTERFACE_VERSION = 3
+
+    # ROI table
+    minimal_roi = {
+        "60": 0.01,
+        "30": 0.02,  # mid
+        "0": 0.04
+    }
+
+    stoploss: float = -0.10
+    trailing_stop = False
+    timeframe = '5m'
+
+    buy_rsi = IntParameter(10, 40, default=30, space="buy")
+    sell_rsi = DecimalParameter(
+        low=0.5,
+        high=0.9,
+        decimals=2,
+        space="sell",
+    )
+    mode = CategoricalParameter(["fast", "slow"], default="fast", optimize=False)
+
+    def populate_indicators(self, dataframe, metadata):
+        stoploss = -0.5
+        return dataframe
+`
+
+func float(v float64) *float64 { return &v }
+
+func TestParse_ExtractsParams(t *testing.T) {
+	params, err := Parse(sampleStrategy)
+	require.NoError(t, err)
+
+	assert.Equal(t, []domain.ROIStep{{Minutes: 0, ROI: 0.04}, {Minutes: 30, ROI: 0.02}, {Minutes: 60, ROI: 0.01}}, params.MinimalROI)
+	require.NotNil(t, params.Stoploss)
+	assert.Equal(t, -0.10, *params.Stoploss)
+	require.NotNil(t, params.TrailingStop)
+	assert.False(t, *params.TrailingStop)
+	assert.Nil(t, params.TrailingStopPositive)
+	require.NotNil(t, params.Timeframe)
+	assert.Equal(t, "5m", *params.Timeframe)
+
+	require.Len(t, params.HyperoptParams, 3)
+
+	buy := params.HyperoptParam("buy_rsi")
+	require.NotNil(t, buy)
+	assert.Equal(t, domain.HyperoptParamInt, buy.Type)
+	assert.Equal(t, 10.0, *buy.Low)
+	assert.Equal(t, 40.0, *buy.High)
+	assert.Equal(t, 30.0, buy.Default)
+	assert.Equal(t, "buy", buy.Space)
+
+	sell := params.HyperoptParam("sell_rsi")
+	require.NotNil(t, sell)
+	assert.Equal(t, domain.HyperoptParamDecimal, sell.Type)
+	assert.Equal(t, 0.5, *sell.Low)
+	assert.Equal(t, 0.9, *sell.High)
+	assert.Equal(t, 2, *sell.Decimals)
+	assert.Nil(t, sell.Default)
+
+	mode := params.HyperoptParam("mode")
+	require.NotNil(t, mode)
+	assert.Equal(t, []interface{}{"fast", "slow"}, mode.Options)
+	assert.Equal(t, "fast", mode.Default)
+	assert.False(t, *mode.Optimize)
+}
+
+func TestParse_NoStrategyClass(t *testing.T) {
+	_, err := Parse("x = 1\n")
+	assert.True(t, errors.Is(err, ErrNoStrategyClass))
+}
+
+func TestApply_RewritesValuesInPlace(t *testing.T) {
+	stoploss := -0.05
+	timeframe := "1h"
+	patch := &domain.StrategyParamsPatch{
+		MinimalROI: []domain.ROIStep{{Minutes: 20, ROI: 0.03}, {Minutes: 0, ROI: 0.1}},
+		Stoploss:   &stoploss,
+		Timeframe:  &timeframe,
+		HyperoptDefaults: map[string]interface{}{
+			"buy_rsi":  25.0,
+			"sell_rsi": 0.75,
+			"mode":     "slow",
+		},
+	}
+
+	code, err := Apply(sampleStrategy, patch)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "minimal_roi = {\n        \"0\": 0.1,\n        \"20\": 0.03\n    }\n")
+	assert.Contains(t, code, "stoploss: float = -0.05\n")
+	assert.Contains(t, code, `timeframe = "1h"`)
+	assert.Contains(t, code, `IntParameter(10, 40, default=25, space="buy")`)
+	assert.Contains(t, code, "space=\"sell\", default=0.75,\n    )")
+	assert.Contains(t, code, `CategoricalParameter(["fast", "slow"], default="slow", optimize=False)`)
+	// Code outside the class attributes is untouched
+	assert.Contains(t, code, "stoploss = -0.99 (docstring, not code)")
+	assert.Contains(t, code, "        stoploss = -0.5\n")
+
+	params, err := Parse(code)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ROIStep{{Minutes: 0, ROI: 0.1}, {Minutes: 20, ROI: 0.03}}, params.MinimalROI)
+	assert.Equal(t, -0.05, *params.Stoploss)
+	assert.Equal(t, "1h", *params.Timeframe)
+	assert.Equal(t, 25.0, params.HyperoptParam("buy_rsi").Default)
+	assert.Equal(t, 0.75, params.HyperoptParam("sell_rsi").Default)
+	assert.Equal(t, "slow", params.HyperoptParam("mode").Default)
+}
+
+func TestApply_InsertsMissingAttributes(t *testing.T) {
+	trailing := true
+	patch := &domain.StrategyParamsPatch{
+		TrailingStop:         &trailing,
+		TrailingStopPositive: float(0.01),
+	}
+
+	code, err := Apply(sampleStrategy, patch)
+	require.NoError(t, err)
+	assert.Contains(t, code, "    timeframe = '5m'\n    trailing_stop_positive = 0.01\n")
+	assert.Contains(t, code, "    trailing_stop = True\n")
+
+	params, err := Parse(code)
+	require.NoError(t, err)
+	assert.True(t, *params.TrailingStop)
+	assert.Equal(t, 0.01, *params.TrailingStopPositive)
+
+	// Class without any settings gets them at the top of the body
+	code, err = Apply("class Bare(IStrategy):\n    def f(self):\n        pass\n", &domain.StrategyParamsPatch{Stoploss: float(-0.2)})
+	require.NoError(t, err)
+	assert.Equal(t, "class Bare(IStrategy):\n    stoploss = -0.2\n    def f(self):\n        pass\n", code)
+}
+
+func TestApply_RejectsInvalidPatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch *domain.StrategyParamsPatch
+	}{
+		{"empty", &domain.StrategyParamsPatch{}},
+		{"positive stoploss", &domain.StrategyParamsPatch{Stoploss: float(0.1)}},
+		{"duplicate roi", &domain.StrategyParamsPatch{MinimalROI: []domain.ROIStep{{Minutes: 0, ROI: 0.1}, {Minutes: 0, ROI: 0.2}}}},
+		{"unknown param", &domain.StrategyParamsPatch{HyperoptDefaults: map[string]interface{}{"nope": 1.0}}},
+		{"out of range", &domain.StrategyParamsPatch{HyperoptDefaults: map[string]interface{}{"buy_rsi": 50.0}}},
+		{"non-integer", &domain.StrategyParamsPatch{HyperoptDefaults: map[string]interface{}{"buy_rsi": 20.5}}},
+		{"unknown option", &domain.StrategyParamsPatch{HyperoptDefaults: map[string]interface{}{"mode": "medium"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Apply(sampleStrategy, tt.patch)
+			assert.True(t, errors.Is(err, domain.ErrInvalidInput), "got %v", err)
+		})
+	}
+}
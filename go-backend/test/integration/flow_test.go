@@ -0,0 +1,206 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpapi "github.com/saltfish/freqsearch/go-backend/internal/api/http"
+	"github.com/saltfish/freqsearch/go-backend/internal/clock"
+	"github.com/saltfish/freqsearch/go-backend/internal/config"
+	"github.com/saltfish/freqsearch/go-backend/internal/docker"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/events"
+	"github.com/saltfish/freqsearch/go-backend/internal/scheduler"
+)
+
+// flowTimeout bounds how long a job may take to move through the pipeline.
+const flowTimeout = 30 * time.Second
+
+// eventRecorder collects task events for a single job from RabbitMQ.
+type eventRecorder struct {
+	mu     sync.Mutex
+	jobID  uuid.UUID
+	events []string
+}
+
+func (r *eventRecorder) handle(routingKey string, body []byte) error {
+	var payload struct {
+		JobID uuid.UUID `json:"job_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if payload.JobID == r.jobID {
+		r.events = append(r.events, routingKey)
+	}
+	return nil
+}
+
+func (r *eventRecorder) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// flowEnv wires the HTTP API, scheduler, fake executor, and RabbitMQ together.
+type flowEnv struct {
+	api       *httptest.Server
+	scheduler *scheduler.Scheduler
+	clock     *clock.Fake
+	recorder  *eventRecorder
+}
+
+// newFlowEnv starts a scheduler that executes jobs on the fake executor
+// with a fake clock, and subscribes to task events.
+func newFlowEnv(t *testing.T, failureRate float64) *flowEnv {
+	t.Helper()
+
+	publisher, err := events.NewRabbitMQPublisher(&env.rabbitMQ, env.logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { publisher.Close() })
+
+	recorder := &eventRecorder{}
+	subscriber, err := events.NewRabbitMQSubscriber(&env.rabbitMQ, "integration-"+uuid.NewString(), env.logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { subscriber.Close() })
+	require.NoError(t, subscriber.Subscribe(context.Background(), []string{"task.*"}, recorder.handle))
+
+	clk := clock.NewFake(time.Now())
+	manager, err := docker.NewFakeManager(&config.DockerConfig{
+		Executor: config.ExecutorFake,
+		Fake: config.FakeExecutorConfig{
+			MinDuration:     "5m",
+			MaxDuration:     "10m",
+			FailureRate:     failureRate,
+			ProfitMeanPct:   3,
+			ProfitStdDevPct: 2,
+			Seed:            42,
+		},
+	}, clk, env.logger)
+	require.NoError(t, err)
+
+	sched := scheduler.NewScheduler(&config.SchedulerConfig{
+		MaxConcurrentBacktests: 2,
+		PollIntervalSeconds:    1,
+		JobTimeoutMinutes:      30,
+		MaxRetries:             0,
+		ShutdownTimeout:        "5s",
+	}, env.repos, manager, publisher, env.logger)
+	sched.SetClock(clk)
+	require.NoError(t, sched.Start())
+	t.Cleanup(func() { sched.Stop() })
+
+	server := httpapi.NewServer("127.0.0.1:0", env.pool, env.repos, sched, env.logger)
+	server.SetEventPublisher(publisher)
+	api := httptest.NewServer(server.Handler())
+	t.Cleanup(api.Close)
+
+	return &flowEnv{api: api, scheduler: sched, clock: clk, recorder: recorder}
+}
+
+// submit submits a backtest through the REST API.
+func (f *flowEnv) submit(t *testing.T, strategyID uuid.UUID) *domain.BacktestJob {
+	t.Helper()
+
+	body, err := json.Marshal(httpapi.SubmitBacktestRequest{
+		StrategyID: strategyID.String(),
+		Config:     testBacktestConfig(),
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Post(f.api.URL+"/api/v1/backtests", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var out httpapi.SubmitBacktestResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	f.recorder.mu.Lock()
+	f.recorder.jobID = out.Job.ID
+	f.recorder.mu.Unlock()
+	return out.Job
+}
+
+// waitForStatus advances the fake clock until the job reaches a terminal status.
+func (f *flowEnv) waitForStatus(t *testing.T, jobID uuid.UUID) *httpapi.GetBacktestJobResponse {
+	t.Helper()
+
+	var out httpapi.GetBacktestJobResponse
+	require.Eventually(t, func() bool {
+		f.clock.Advance(time.Minute)
+
+		resp, err := http.Get(f.api.URL + "/api/v1/backtests/" + jobID.String())
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		out = httpapi.GetBacktestJobResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+			return false
+		}
+		return out.Job != nil && out.Job.Status.IsTerminal()
+	}, flowTimeout, 50*time.Millisecond)
+
+	return &out
+}
+
+// TestFlow_SubmitScheduleComplete exercises submit → schedule → complete → events.
+func TestFlow_SubmitScheduleComplete(t *testing.T) {
+	resetDatabase(t)
+	f := newFlowEnv(t, 0)
+
+	strategy := createTestStrategy(t, "FlowStrategy", nil)
+	job := f.submit(t, strategy.ID)
+	assert.Equal(t, domain.JobStatusPending, job.Status)
+
+	got := f.waitForStatus(t, job.ID)
+	assert.Equal(t, domain.JobStatusCompleted, got.Job.Status)
+	require.NotNil(t, got.Result, "completed job exposes its result")
+	assert.Greater(t, got.Result.TotalTrades, 0)
+	assert.Len(t, got.Result.PairResults, 1)
+
+	result, err := env.repos.Result.GetByJobID(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, strategy.ID, result.StrategyID)
+
+	assert.Eventually(t, func() bool {
+		received := f.recorder.received()
+		return assert.ObjectsAreEqual([]string{events.RoutingKeyTaskRunning, events.RoutingKeyTaskCompleted}, received)
+	}, flowTimeout, 50*time.Millisecond, "expected running and completed events, got %v", f.recorder.received())
+}
+
+// TestFlow_SubmitScheduleFail exercises a failing backtest end to end.
+func TestFlow_SubmitScheduleFail(t *testing.T) {
+	resetDatabase(t)
+	f := newFlowEnv(t, 1)
+
+	strategy := createTestStrategy(t, "FailingStrategy", nil)
+	job := f.submit(t, strategy.ID)
+
+	got := f.waitForStatus(t, job.ID)
+	assert.Equal(t, domain.JobStatusFailed, got.Job.Status)
+	require.NotNil(t, got.Job.ErrorMessage)
+	assert.Contains(t, *got.Job.ErrorMessage, "simulated backtest failure")
+	assert.Nil(t, got.Result)
+
+	assert.Eventually(t, func() bool {
+		received := f.recorder.received()
+		return assert.ObjectsAreEqual([]string{events.RoutingKeyTaskRunning, events.RoutingKeyTaskFailed}, received)
+	}, flowTimeout, 50*time.Millisecond, "expected running and failed events, got %v", f.recorder.received())
+}
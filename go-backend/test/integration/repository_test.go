@@ -0,0 +1,3050 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/saltfish/freqsearch/go-backend/internal/db"
+	"github.com/saltfish/freqsearch/go-backend/internal/db/migrations"
+	"github.com/saltfish/freqsearch/go-backend/internal/domain"
+	"github.com/saltfish/freqsearch/go-backend/internal/secrets"
+)
+
+// testBacktestConfig returns a config that satisfies the backtest_jobs CHECK constraint.
+func testBacktestConfig() domain.BacktestConfig {
+	return domain.BacktestConfig{
+		Exchange:       "binance",
+		Pairs:          []string{"BTC/USDT:USDT"},
+		Timeframe:      "1h",
+		TimerangeStart: "2024-01-01",
+		TimerangeEnd:   "2024-03-01",
+		DryRunWallet:   1000,
+		MaxOpenTrades:  3,
+		StakeAmount:    "unlimited",
+	}
+}
+
+// createTestStrategy inserts a strategy with unique code.
+func createTestStrategy(t *testing.T, name string, parentID *uuid.UUID) *domain.Strategy {
+	t.Helper()
+
+	code := "class " + name + "(IStrategy):\n    # " + uuid.NewString() + "\n    pass\n"
+	strategy := domain.NewStrategy(name, code, "integration test strategy", parentID)
+	strategy.Timeframe = "1h"
+	require.NoError(t, env.repos.Strategy.Create(context.Background(), strategy))
+	return strategy
+}
+
+// TestStrategyRepository_Conformance tests the Postgres strategy repository.
+func TestStrategyRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Strategy
+
+	parent := createTestStrategy(t, "ParentStrategy", nil)
+	assert.NotEmpty(t, parent.CodeHash, "code hash is set by trigger")
+	assert.Equal(t, 0, parent.Generation)
+
+	child := createTestStrategy(t, "ChildStrategy", &parent.ID)
+	assert.Equal(t, 1, child.Generation, "generation is derived from parent")
+
+	t.Run("GetByID", func(t *testing.T) {
+		got, err := repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, parent.Name, got.Name)
+		assert.Equal(t, parent.Code, got.Code)
+	})
+
+	t.Run("GetByCodeHash", func(t *testing.T) {
+		got, err := repo.GetByCodeHash(ctx, parent.CodeHash)
+		require.NoError(t, err)
+		assert.Equal(t, parent.ID, got.ID)
+	})
+
+	t.Run("DuplicateCode", func(t *testing.T) {
+		dup := domain.NewStrategy("Duplicate", parent.Code, "", nil)
+		err := repo.Create(ctx, dup)
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		parent.Description = "updated"
+		require.NoError(t, repo.Update(ctx, parent))
+
+		got, err := repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "updated", got.Description)
+	})
+
+	t.Run("Lineage", func(t *testing.T) {
+		ancestors, err := repo.GetAncestors(ctx, child.ID)
+		require.NoError(t, err)
+		require.Len(t, ancestors, 1)
+		assert.Equal(t, parent.ID, ancestors[0].ID)
+
+		descendants, err := repo.GetDescendants(ctx, parent.ID)
+		require.NoError(t, err)
+		require.Len(t, descendants, 1)
+		assert.Equal(t, child.ID, descendants[0].ID)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		pattern := "Child"
+		results, total, err := repo.Search(ctx, domain.StrategySearchQuery{NamePattern: &pattern})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, results, 1)
+		assert.Equal(t, child.ID, results[0].Strategy.ID)
+	})
+
+	t.Run("ArchiveHidesFromSearch", func(t *testing.T) {
+		require.NoError(t, repo.Archive(ctx, parent.ID, "test"))
+
+		got, err := repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err)
+		require.True(t, got.IsArchived())
+		assert.Equal(t, "test", *got.ArchiveReason)
+
+		pattern := "Parent"
+		_, total, err := repo.Search(ctx, domain.StrategySearchQuery{NamePattern: &pattern})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+
+		_, total, err = repo.Search(ctx, domain.StrategySearchQuery{NamePattern: &pattern, IncludeArchived: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		require.NoError(t, repo.Unarchive(ctx, parent.ID))
+		got, err = repo.GetByID(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.False(t, got.IsArchived())
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		assert.Equal(t, domain.ValidationStatusUnvalidated, parent.ValidationStatus)
+
+		require.NoError(t, repo.SetValidation(ctx, child.ID, domain.ValidationStatusFailed, []string{"ImportError: talib"}))
+		got, err := repo.GetByID(ctx, child.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ValidationStatusFailed, got.ValidationStatus)
+		assert.Equal(t, []string{"ImportError: talib"}, got.ValidationErrors)
+		require.NotNil(t, got.ValidatedAt)
+
+		_, err = got.CheckSubmittable(false)
+		assert.ErrorIs(t, err, domain.ErrStrategyValidationFailed)
+
+		failed := domain.ValidationStatusFailed
+		results, total, err := repo.Search(ctx, domain.StrategySearchQuery{ValidationStatus: &failed})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, results, 1)
+		assert.Equal(t, child.ID, results[0].Strategy.ID)
+
+		err = repo.SetValidation(ctx, uuid.New(), domain.ValidationStatusValidated, nil)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("ListAll", func(t *testing.T) {
+		require.NoError(t, repo.Archive(ctx, child.ID, "test"))
+		defer func() { require.NoError(t, repo.Unarchive(ctx, child.ID)) }()
+
+		strategies, err := repo.ListAll(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, strategies, 1)
+		assert.Equal(t, parent.ID, strategies[0].ID)
+		assert.Equal(t, parent.Code, strategies[0].Code)
+
+		strategies, err = repo.ListAll(ctx, true)
+		require.NoError(t, err)
+		require.Len(t, strategies, 2)
+		assert.Equal(t, parent.ID, strategies[0].ID, "oldest first")
+		assert.Equal(t, child.ID, strategies[1].ID)
+	})
+
+	t.Run("DeleteAndNotFound", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, child.ID))
+
+		_, err := repo.GetByID(ctx, child.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		err = repo.Delete(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestStrategyRepository_LineageAggregates tests the per-node aggregates of lineage trees.
+func TestStrategyRepository_LineageAggregates(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	root := createTestStrategy(t, "LineageRoot", nil)
+	child := createTestStrategy(t, "LineageChild", &root.ID)
+	createTestStrategy(t, "LineageSibling", &root.ID)
+	createTestStrategy(t, "LineageGrandchild", &child.ID)
+
+	// Distinct timeranges so neither result supersedes the other
+	for i, sharpe := range []float64{0.5, 1.5} {
+		cfg := testBacktestConfig()
+		cfg.TimerangeEnd = fmt.Sprintf("2024-%02d-01", 3+i)
+		job := domain.NewBacktestJob(root.ID, cfg, 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+		result := domain.NewBacktestResult(job.ID, root.ID)
+		result.SharpeRatio = &sharpe
+		require.NoError(t, env.repos.Result.Create(ctx, result))
+	}
+
+	lineage, err := env.repos.Strategy.GetLineage(ctx, root.ID, domain.StrategyLineageQuery{Depth: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, lineage.BacktestCount)
+	require.NotNil(t, lineage.BestSharpe)
+	assert.InDelta(t, 1.5, *lineage.BestSharpe, 1e-9)
+	assert.Equal(t, 2, lineage.ChildCount)
+	require.Len(t, lineage.Children, 2)
+
+	for _, node := range lineage.Children {
+		assert.Zero(t, node.BacktestCount)
+		assert.Nil(t, node.BestSharpe)
+		if node.ID == child.ID {
+			// The grandchild is beyond the depth but still counted
+			assert.Equal(t, 1, node.ChildCount)
+			assert.Empty(t, node.Children)
+		} else {
+			assert.Zero(t, node.ChildCount)
+		}
+	}
+}
+
+// TestStrategyRepository_LineageDirections tests walking ancestors and the
+// metrics of lineage trees.
+func TestStrategyRepository_LineageDirections(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	root := createTestStrategy(t, "DirectionRoot", nil)
+	child := createTestStrategy(t, "DirectionChild", &root.ID)
+	createTestStrategy(t, "DirectionSibling", &root.ID)
+	grandchild := createTestStrategy(t, "DirectionGrandchild", &child.ID)
+
+	for _, r := range []struct {
+		strategy *domain.Strategy
+		sharpe   float64
+		profit   float64
+	}{{root, 1.0, 10}, {child, 1.5, 8}} {
+		job := domain.NewBacktestJob(r.strategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+		result := domain.NewBacktestResult(job.ID, r.strategy.ID)
+		result.SharpeRatio = &r.sharpe
+		result.ProfitPct = r.profit
+		require.NoError(t, env.repos.Result.Create(ctx, result))
+	}
+
+	t.Run("Up", func(t *testing.T) {
+		lineage, err := env.repos.Strategy.GetLineage(ctx, grandchild.ID,
+			domain.StrategyLineageQuery{Depth: 5, Direction: domain.LineageUp})
+		require.NoError(t, err)
+
+		// Rooted at the furthest ancestor, with only the line down
+		assert.Equal(t, root.ID, lineage.ID)
+		assert.Equal(t, -2, lineage.Level)
+		assert.Equal(t, 2, lineage.ChildCount)
+		require.Len(t, lineage.Children, 1)
+		assert.Equal(t, child.ID, lineage.Children[0].ID)
+		require.Len(t, lineage.Children[0].Children, 1)
+		assert.Equal(t, grandchild.ID, lineage.Children[0].Children[0].ID)
+		assert.Equal(t, 0, lineage.Children[0].Children[0].Level)
+		assert.Nil(t, lineage.Metrics, "metrics are only set when asked for")
+
+		lineage, err = env.repos.Strategy.GetLineage(ctx, grandchild.ID,
+			domain.StrategyLineageQuery{Depth: 1, Direction: domain.LineageUp})
+		require.NoError(t, err)
+		assert.Equal(t, child.ID, lineage.ID)
+	})
+
+	t.Run("BothWithMetrics", func(t *testing.T) {
+		lineage, err := env.repos.Strategy.GetLineage(ctx, child.ID,
+			domain.StrategyLineageQuery{Depth: 1, Direction: domain.LineageBoth, IncludeMetrics: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, root.ID, lineage.ID)
+		require.NotNil(t, lineage.Metrics)
+		assert.InDelta(t, 10, lineage.Metrics.ProfitPct, 1e-9)
+		assert.Nil(t, lineage.SharpeDelta, "the root's parent is not in the tree")
+
+		require.Len(t, lineage.Children, 1)
+		node := lineage.Children[0]
+		assert.Equal(t, child.ID, node.ID)
+		require.NotNil(t, node.SharpeDelta)
+		assert.InDelta(t, 0.5, *node.SharpeDelta, 1e-9)
+		require.NotNil(t, node.ProfitDelta)
+		assert.InDelta(t, -2, *node.ProfitDelta, 1e-9)
+
+		require.Len(t, node.Children, 1)
+		assert.Equal(t, grandchild.ID, node.Children[0].ID)
+		assert.Nil(t, node.Children[0].Metrics, "no results")
+		assert.Nil(t, node.Children[0].SharpeDelta)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := env.repos.Strategy.GetLineage(ctx, uuid.New(), domain.StrategyLineageQuery{Direction: domain.LineageBoth})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestStrategyVersionRepository_Conformance tests strategy version history.
+func TestStrategyVersionRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.StrategyVersion
+
+	strategy := createTestStrategy(t, "VersionedStrategy", nil)
+	original := strategy.Code
+
+	strategy.Code = original + "    # tuned\n"
+	require.NoError(t, env.repos.Strategy.Update(ctx, strategy))
+
+	t.Run("List", func(t *testing.T) {
+		versions, err := repo.List(ctx, strategy.ID, false)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, 2, versions[0].Version)
+		assert.Equal(t, strategy.CodeHash, versions[0].CodeHash)
+		assert.Empty(t, versions[0].Code)
+
+		versions, err = repo.List(ctx, strategy.ID, true)
+		require.NoError(t, err)
+		assert.Equal(t, original, versions[1].Code)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		v, err := repo.Rollback(ctx, strategy.ID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, v.Version)
+		require.NotNil(t, v.RolledBackFrom)
+		assert.Equal(t, 1, *v.RolledBackFrom)
+
+		got, err := env.repos.Strategy.GetByID(ctx, strategy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, original, got.Code)
+
+		_, err = repo.Rollback(ctx, strategy.ID, 9)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		_, err = repo.Get(ctx, strategy.ID, 9)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("RollbackToTakenCode", func(t *testing.T) {
+		// Version 2's code now belongs to another strategy
+		v2, err := repo.Get(ctx, strategy.ID, 2)
+		require.NoError(t, err)
+		require.NoError(t, env.repos.Strategy.Create(ctx, domain.NewStrategy("Copy", v2.Code, "", nil)))
+
+		_, err = repo.Rollback(ctx, strategy.ID, 2)
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+	})
+}
+
+// TestBacktestJobRepository_Conformance tests the Postgres job queue repository.
+func TestBacktestJobRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.BacktestJob
+
+	strategy := createTestStrategy(t, "QueueStrategy", nil)
+
+	low := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+	high := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 10, nil)
+	require.NoError(t, repo.Create(ctx, low))
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.BacktestJob{high}))
+
+	t.Run("GetByID", func(t *testing.T) {
+		got, err := repo.GetByID(ctx, high.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusPending, got.Status)
+		assert.Equal(t, high.Config.Pairs, got.Config.Pairs)
+
+		_, err = repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("PendingOrderedByPriority", func(t *testing.T) {
+		jobs, err := repo.GetPendingJobs(ctx, 10, 0, time.Now())
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		assert.Equal(t, high.ID, jobs[0].ID)
+		assert.Equal(t, low.ID, jobs[1].ID)
+
+		ahead, err := repo.CountPendingAhead(ctx, 10, 0, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, ahead)
+		ahead, err = repo.CountPendingAhead(ctx, 0, 0, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 2, ahead)
+	})
+
+	t.Run("PendingWithPriorityAging", func(t *testing.T) {
+		now := time.Now()
+		aged := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		aged.CreatedAt = now.Add(-3 * time.Hour)
+		require.NoError(t, repo.Create(ctx, aged))
+		defer func() {
+			require.NoError(t, repo.Cancel(ctx, aged.ID, domain.Cancellation{}))
+		}()
+
+		jobs, err := repo.GetPendingJobs(ctx, 10, 0, now)
+		require.NoError(t, err)
+		require.Len(t, jobs, 3)
+		assert.Equal(t, []uuid.UUID{high.ID, aged.ID, low.ID}, []uuid.UUID{jobs[0].ID, jobs[1].ID, jobs[2].ID})
+
+		// Three hours at 4 levels an hour lift the old job above priority 10
+		jobs, err = repo.GetPendingJobs(ctx, 10, 4, now)
+		require.NoError(t, err)
+		require.Len(t, jobs, 3)
+		assert.Equal(t, []uuid.UUID{aged.ID, high.ID, low.ID}, []uuid.UUID{jobs[0].ID, jobs[1].ID, jobs[2].ID})
+
+		// Jobs are aged by the given time, not the database's clock
+		jobs, err = repo.GetPendingJobs(ctx, 10, 4, aged.CreatedAt)
+		require.NoError(t, err)
+		require.Len(t, jobs, 3)
+		assert.Equal(t, high.ID, jobs[0].ID)
+
+		// The queue position of a new job counts aged jobs ahead of it
+		ahead, err := repo.CountPendingAhead(ctx, 11, 4, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ahead)
+		ahead, err = repo.CountPendingAhead(ctx, 11, 0, now)
+		require.NoError(t, err)
+		assert.Zero(t, ahead)
+	})
+
+	t.Run("SetPriority", func(t *testing.T) {
+		missing := uuid.New()
+		updated, err := repo.SetPriority(ctx, domain.PriorityChange{JobIDs: []uuid.UUID{low.ID, missing}, Priority: 20})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{low.ID}, updated)
+
+		queue, err := repo.GetPendingQueue(ctx)
+		require.NoError(t, err)
+		require.Len(t, queue, 2)
+		assert.Equal(t, low.ID, queue[0].JobID)
+		assert.Equal(t, 20, queue[0].Priority)
+
+		_, err = repo.SetPriority(ctx, domain.PriorityChange{JobIDs: []uuid.UUID{low.ID}, Priority: 0})
+		require.NoError(t, err)
+	})
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		require.NoError(t, repo.MarkRunning(ctx, high.ID, "container-1"))
+		assert.ErrorIs(t, repo.MarkRunning(ctx, high.ID, "container-2"), domain.ErrJobAlreadyRunning)
+
+		stats, err := repo.GetQueueStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.PendingJobs)
+		assert.Equal(t, 1, stats.RunningJobs)
+
+		require.NoError(t, repo.MarkCompleted(ctx, high.ID))
+		got, err := repo.GetByID(ctx, high.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusCompleted, got.Status)
+		assert.NotNil(t, got.CompletedAt)
+
+		runtimes, err := repo.GetRecentRuntimes(ctx, time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Len(t, runtimes, 1)
+	})
+
+	t.Run("FailAndRetry", func(t *testing.T) {
+		require.NoError(t, repo.IncrementRetryCount(ctx, low.ID))
+		require.NoError(t, repo.MarkRunning(ctx, low.ID, "container-3"))
+		require.NoError(t, repo.MarkFailed(ctx, low.ID, domain.FailureCategoryDataMissing, "boom"))
+
+		got, err := repo.GetByID(ctx, low.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusFailed, got.Status)
+		assert.Equal(t, 1, got.RetryCount)
+		require.NotNil(t, got.ErrorMessage)
+		assert.Equal(t, "boom", *got.ErrorMessage)
+		require.NotNil(t, got.FailureCategory)
+		assert.Equal(t, domain.FailureCategoryDataMissing, *got.FailureCategory)
+
+		// One completed and one failed job so far
+		analytics, err := repo.GetFailureAnalytics(ctx, &domain.TimeRange{Start: time.Now().Add(-time.Hour)})
+		require.NoError(t, err)
+		assert.Equal(t, 2, analytics.FinishedJobs)
+		assert.Equal(t, 1, analytics.FailedJobs)
+		assert.InDelta(t, 0.5, analytics.FailureRate, 1e-9)
+		for _, c := range analytics.Categories {
+			if c.Category == domain.FailureCategoryDataMissing {
+				assert.Equal(t, 1, c.Failures)
+				assert.InDelta(t, 1.0, c.ShareOfFailures, 1e-9)
+			} else {
+				assert.Zero(t, c.Failures, c.Category)
+			}
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, repo.Create(ctx, job))
+		require.NoError(t, repo.Cancel(ctx, job.ID, domain.NewCancellation(" superseded ", "ops")))
+
+		got, err := repo.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusCancelled, got.Status)
+		assert.Equal(t, domain.Cancellation{Reason: "superseded", CancelledBy: "ops"}, got.Cancellation())
+
+		events, err := repo.GetEvents(ctx, job.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		require.NotNil(t, events[len(events)-1].Detail)
+		assert.Equal(t, "cancelled by ops: superseded", *events[len(events)-1].Detail)
+
+		assert.ErrorIs(t, repo.Cancel(ctx, job.ID, domain.Cancellation{}), domain.ErrJobNotCancellable)
+
+		pending, err := repo.GetPendingJobs(ctx, 10, 0, time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("CancelMatching", func(t *testing.T) {
+		queued := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		running := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, repo.CreateBatch(ctx, []*domain.BacktestJob{queued, running}))
+		require.NoError(t, repo.MarkRunning(ctx, running.ID, "container-8"))
+
+		filter := domain.JobCancelFilter{StrategyID: &strategy.ID, Status: domain.JobStatusPending}
+		cancelled, err := repo.CancelMatching(ctx, filter, domain.NewCancellation("runaway", "ops"))
+		require.NoError(t, err)
+		require.Len(t, cancelled, 1)
+		assert.Equal(t, queued.ID, cancelled[0].ID)
+		assert.Equal(t, domain.JobStatusCancelled, cancelled[0].Status)
+		assert.Equal(t, domain.Cancellation{Reason: "runaway", CancelledBy: "ops"}, cancelled[0].Cancellation())
+
+		events, err := repo.GetEvents(ctx, queued.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		require.NotNil(t, events[len(events)-1].Detail)
+		assert.Equal(t, "cancelled by ops: runaway", *events[len(events)-1].Detail)
+
+		// Other runs' jobs are left alone
+		otherRun := uuid.New()
+		filter = domain.JobCancelFilter{OptimizationRunID: &otherRun, StrategyID: &strategy.ID, Status: domain.JobStatusRunning}
+		cancelled, err = repo.CancelMatching(ctx, filter, domain.Cancellation{})
+		require.NoError(t, err)
+		assert.Empty(t, cancelled)
+
+		filter.OptimizationRunID = nil
+		cancelled, err = repo.CancelMatching(ctx, filter, domain.Cancellation{})
+		require.NoError(t, err)
+		require.Len(t, cancelled, 1)
+		assert.Equal(t, running.ID, cancelled[0].ID)
+		require.NotNil(t, cancelled[0].ContainerID)
+		assert.Equal(t, "container-8", *cancelled[0].ContainerID)
+	})
+
+	t.Run("Resubmit", func(t *testing.T) {
+		original, err := repo.GetByID(ctx, low.ID)
+		require.NoError(t, err)
+		start := "20240201"
+		job := original.Resubmit(domain.ResubmitOverrides{TimerangeStart: &start})
+		require.NoError(t, repo.Create(ctx, job))
+		require.NoError(t, repo.Cancel(ctx, job.ID, domain.Cancellation{}))
+
+		got, err := repo.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.ResubmittedFrom)
+		assert.Equal(t, low.ID, *got.ResubmittedFrom)
+		assert.Equal(t, start, got.Config.TimerangeStart)
+		assert.Equal(t, original.Config.Pairs, got.Config.Pairs)
+
+		// The original must exist
+		orphan := original.Resubmit(domain.ResubmitOverrides{})
+		missing := uuid.New()
+		orphan.ResubmittedFrom = &missing
+		assert.Error(t, repo.Create(ctx, orphan))
+	})
+
+	t.Run("Hints", func(t *testing.T) {
+		hinted := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		hinted.Hints = &domain.JobHints{PreferCachedData: []string{"binance/5m"}, AntiAffinity: []string{"exchange:binance"}}
+		require.NoError(t, repo.Create(ctx, hinted))
+		require.NoError(t, repo.Cancel(ctx, hinted.ID, domain.Cancellation{}))
+
+		got, err := repo.GetByID(ctx, hinted.ID)
+		require.NoError(t, err)
+		assert.Equal(t, hinted.Hints, got.Hints)
+
+		got, err = repo.GetByID(ctx, low.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.Hints)
+	})
+
+	t.Run("SLABreaches", func(t *testing.T) {
+		waiting := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 5, nil)
+		running := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, repo.CreateBatch(ctx, []*domain.BacktestJob{waiting, running}))
+		require.NoError(t, repo.MarkRunning(ctx, running.ID, "container-4"))
+
+		targets := domain.SLATargets{MaxQueueWait: 15 * time.Minute, MaxRunDuration: 30 * time.Minute}
+
+		breaches, err := repo.GetSLABreaches(ctx, targets, time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, breaches)
+
+		breaches, err = repo.GetSLABreaches(ctx, targets, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, breaches, 2)
+		byKind := map[domain.SLAKind]*domain.SLABreach{}
+		for _, b := range breaches {
+			byKind[b.Kind] = b
+		}
+		require.Contains(t, byKind, domain.SLAKindQueueWait)
+		assert.Equal(t, waiting.ID, byKind[domain.SLAKindQueueWait].JobID)
+		assert.Equal(t, 5, byKind[domain.SLAKindQueueWait].Priority)
+		assert.Equal(t, int64(15*60*1000), byKind[domain.SLAKindQueueWait].TargetMs)
+		require.Contains(t, byKind, domain.SLAKindRunDuration)
+		assert.Equal(t, running.ID, byKind[domain.SLAKindRunDuration].JobID)
+
+		// A zero target is not tracked
+		breaches, err = repo.GetSLABreaches(ctx, domain.SLATargets{MaxQueueWait: 15 * time.Minute}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, breaches, 1)
+		assert.Equal(t, domain.SLAKindQueueWait, breaches[0].Kind)
+	})
+
+	t.Run("Requeue", func(t *testing.T) {
+		claimed := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		finished := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, repo.CreateBatch(ctx, []*domain.BacktestJob{claimed, finished}))
+		require.NoError(t, repo.MarkRunning(ctx, claimed.ID, "container-6"))
+		require.NoError(t, repo.MarkRunning(ctx, finished.ID, "container-7"))
+		require.NoError(t, repo.MarkCompleted(ctx, finished.ID))
+
+		requeued, err := repo.Requeue(ctx, []uuid.UUID{claimed.ID, finished.ID, uuid.New()})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{claimed.ID}, requeued)
+
+		got, err := repo.GetByID(ctx, claimed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusPending, got.Status)
+		assert.Nil(t, got.ContainerID)
+		assert.Nil(t, got.StartedAt)
+
+		got, err = repo.GetByID(ctx, finished.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusCompleted, got.Status)
+
+		require.NoError(t, repo.Cancel(ctx, claimed.ID, domain.Cancellation{}))
+	})
+
+	t.Run("GetCancelledIDs", func(t *testing.T) {
+		running := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		cancelled := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, repo.CreateBatch(ctx, []*domain.BacktestJob{running, cancelled}))
+		require.NoError(t, repo.MarkRunning(ctx, running.ID, "container-8"))
+		require.NoError(t, repo.MarkRunning(ctx, cancelled.ID, "container-9"))
+		require.NoError(t, repo.Cancel(ctx, cancelled.ID, domain.Cancellation{Reason: "superseded"}))
+
+		ids, err := repo.GetCancelledIDs(ctx, []uuid.UUID{running.ID, cancelled.ID, uuid.New()})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{cancelled.ID}, ids)
+
+		ids, err = repo.GetCancelledIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+
+		require.NoError(t, repo.Cancel(ctx, running.ID, domain.Cancellation{}))
+	})
+
+	t.Run("GetActiveDuplicates", func(t *testing.T) {
+		// A config no other subtest queues
+		config := testBacktestConfig()
+		config.Timeframe = "4h"
+		other := testBacktestConfig()
+		other.Timeframe = "15m"
+
+		pending := domain.NewBacktestJob(strategy.ID, config, 0, nil)
+		later := domain.NewBacktestJob(strategy.ID, config, 5, nil)
+		finished := domain.NewBacktestJob(strategy.ID, other, 0, nil)
+		require.NoError(t, repo.CreateBatch(ctx, []*domain.BacktestJob{pending, finished}))
+		require.NoError(t, repo.Create(ctx, later))
+		require.NoError(t, repo.Cancel(ctx, finished.ID, domain.Cancellation{}))
+
+		candidates := []*domain.BacktestJob{
+			domain.NewBacktestJob(strategy.ID, config, 0, nil),
+			domain.NewBacktestJob(strategy.ID, other, 0, nil),
+			domain.NewBacktestJob(uuid.New(), config, 0, nil),
+		}
+		duplicates, err := repo.GetActiveDuplicates(ctx, candidates)
+		require.NoError(t, err)
+		require.Len(t, duplicates, 3)
+		// The oldest identical job is reported; cancelled jobs don't count
+		require.NotNil(t, duplicates[0])
+		assert.Equal(t, pending.ID, *duplicates[0])
+		assert.Nil(t, duplicates[1])
+		assert.Nil(t, duplicates[2])
+		assert.Equal(t, candidates[0].WorkKey(), pending.WorkKey())
+
+		duplicates, err = repo.GetActiveDuplicates(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, duplicates)
+
+		require.NoError(t, repo.Cancel(ctx, pending.ID, domain.Cancellation{}))
+		require.NoError(t, repo.Cancel(ctx, later.ID, domain.Cancellation{}))
+	})
+
+	t.Run("Events", func(t *testing.T) {
+		job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, repo.Create(ctx, job))
+
+		containerID := "container-5"
+		worker := "worker-1"
+		require.NoError(t, repo.AddEvent(ctx, &domain.JobEvent{
+			JobID:       job.ID,
+			Type:        domain.JobEventContainerStarted,
+			Status:      domain.JobStatusRunning,
+			ContainerID: &containerID,
+			Worker:      &worker,
+		}))
+		require.NoError(t, repo.Cancel(ctx, job.ID, domain.Cancellation{}))
+
+		events, err := repo.GetEvents(ctx, job.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.Equal(t, domain.JobEventQueued, events[0].Type)
+		assert.Equal(t, domain.JobStatusPending, events[0].Status)
+		assert.Equal(t, domain.JobEventContainerStarted, events[1].Type)
+		require.NotNil(t, events[1].Worker)
+		assert.Equal(t, worker, *events[1].Worker)
+		assert.Equal(t, domain.JobEventCancelled, events[2].Type)
+		assert.Nil(t, events[2].Detail)
+
+		err = repo.AddEvent(ctx, &domain.JobEvent{JobID: uuid.New(), Type: domain.JobEventQueued, Status: domain.JobStatusPending})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("CreateBatchPartial", func(t *testing.T) {
+		first := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		missing := domain.NewBacktestJob(uuid.New(), testBacktestConfig(), 0, nil)
+		last := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+
+		errs, err := repo.CreateBatchPartial(ctx, []*domain.BacktestJob{first, missing, last})
+		require.NoError(t, err)
+		require.Len(t, errs, 3)
+		assert.NoError(t, errs[0])
+		assert.ErrorIs(t, errs[1], domain.ErrNotFound)
+		assert.NoError(t, errs[2])
+
+		// Jobs around the failed one are created
+		_, err = repo.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		_, err = repo.GetByID(ctx, last.ID)
+		require.NoError(t, err)
+		_, err = repo.GetByID(ctx, missing.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// TestBacktestResultRepository_Conformance tests the Postgres result repository.
+func TestBacktestResultRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Result
+
+	strategy := createTestStrategy(t, "ResultStrategy", nil)
+
+	// Distinct timeranges so neither result supersedes the other
+	var results []*domain.BacktestResult
+	for i, sharpe := range []float64{0.5, 2.0} {
+		cfg := testBacktestConfig()
+		cfg.TimerangeEnd = fmt.Sprintf("2024-%02d-01", 3+i)
+		job := domain.NewBacktestJob(strategy.ID, cfg, 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+		result := domain.NewBacktestResult(job.ID, strategy.ID)
+		result.TotalTrades = 20
+		result.WinningTrades = 12
+		result.LosingTrades = 8
+		result.WinRate = 0.6
+		result.ProfitPct = 5
+		result.SharpeRatio = &sharpe
+		result.PairResults = []domain.PairResult{{Pair: "BTC/USDT:USDT", Trades: 20, ProfitPct: 5, WinRate: 0.6}}
+		require.NoError(t, repo.Create(ctx, result))
+		results = append(results, result)
+	}
+
+	t.Run("GetByJobID", func(t *testing.T) {
+		got, err := repo.GetByJobID(ctx, results[0].JobID)
+		require.NoError(t, err)
+		assert.Equal(t, results[0].ID, got.ID)
+		assert.Equal(t, 12, got.WinningTrades)
+		assert.Len(t, got.PairResults, 1)
+
+		_, err = repo.GetByJobID(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("GetByStrategyID", func(t *testing.T) {
+		got, err := repo.GetByStrategyID(ctx, strategy.ID)
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("GetBestByStrategyID", func(t *testing.T) {
+		got, err := repo.GetBestByStrategyID(ctx, strategy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, results[1].ID, got.ID)
+	})
+
+	t.Run("GetBestComparableByStrategyID", func(t *testing.T) {
+		// Written differently from the stored config, but comparable
+		cfg := testBacktestConfig()
+		cfg.TimerangeStart = "20240101"
+		cfg.TimerangeEnd = "20240301"
+		cfg.TradingMode = "futures"
+		cfg.HyperoptOverrides = map[string]interface{}{"buy_rsi": 30}
+		got, err := repo.GetBestComparableByStrategyID(ctx, strategy.ID, cfg.Comparable())
+		require.NoError(t, err)
+		assert.Equal(t, results[0].ID, got.ID)
+
+		cfg.Pairs = append(cfg.Pairs, "ETH/USDT:USDT")
+		_, err = repo.GetBestComparableByStrategyID(ctx, strategy.ID, cfg.Comparable())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("GetStatistics", func(t *testing.T) {
+		stats, err := repo.GetStatistics(ctx, strategy.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.ResultCount)
+		assert.Equal(t, 2, stats.SharpeRatio.Count)
+		assert.InDelta(t, 1.25, stats.SharpeRatio.Mean, 1e-9)
+		assert.InDelta(t, 1.25, stats.SharpeRatio.Median, 1e-9)
+		assert.InDelta(t, 1.0607, stats.SharpeRatio.StdDev, 1e-4)
+		assert.InDelta(t, 0.5, stats.SharpeRatio.Min, 1e-9)
+		assert.InDelta(t, 2.0, stats.SharpeRatio.Max, 1e-9)
+		assert.InDelta(t, 5.0, stats.ProfitPct.Mean, 1e-9)
+		assert.Zero(t, stats.ProfitPct.StdDev)
+		assert.NotNil(t, stats.FirstResultAt)
+
+		// A window in the future matches nothing
+		window := &domain.TimeRange{Start: time.Now().Add(time.Hour)}
+		stats, err = repo.GetStatistics(ctx, strategy.ID, window)
+		require.NoError(t, err)
+		assert.Zero(t, stats.ResultCount)
+		assert.Zero(t, stats.SharpeRatio.Count)
+		assert.Nil(t, stats.FirstResultAt)
+	})
+
+	t.Run("GetStatisticsBatch", func(t *testing.T) {
+		other := createTestStrategy(t, "StatisticsBatchStrategy", nil)
+		missing := uuid.New()
+
+		stats, err := repo.GetStatisticsBatch(ctx, []uuid.UUID{other.ID, strategy.ID, missing}, nil)
+		require.NoError(t, err)
+		require.Len(t, stats, 3)
+		// In request order; strategies without results get zero statistics
+		assert.Equal(t, other.ID, stats[0].StrategyID)
+		assert.Zero(t, stats[0].ResultCount)
+		assert.Equal(t, strategy.ID, stats[1].StrategyID)
+		assert.Equal(t, 2, stats[1].ResultCount)
+		assert.InDelta(t, 2.0, stats[1].SharpeRatio.Max, 1e-9)
+		assert.Equal(t, missing, stats[2].StrategyID)
+		assert.Zero(t, stats[2].ResultCount)
+	})
+
+	t.Run("GetCohortMetrics", func(t *testing.T) {
+		// Written differently from the stored config, but the same cohort
+		cfg := testBacktestConfig()
+		cfg.TimerangeStart = "20240101"
+		cfg.TimerangeEnd = "20240301"
+		cohort := cfg.Cohort()
+
+		metrics, err := repo.GetCohortMetrics(ctx, cohort)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{0.5}, metrics.SharpeRatios)
+		assert.Equal(t, []float64{5}, metrics.ProfitPcts)
+
+		ranks := metrics.Rank(cohort, results[0])
+		assert.Equal(t, 1, ranks.CohortSize)
+		require.NotNil(t, ranks.Sharpe)
+		assert.InDelta(t, 50.0, *ranks.Sharpe, 1e-9)
+
+		cfg.Pairs = append(cfg.Pairs, "ETH/USDT:USDT")
+		metrics, err = repo.GetCohortMetrics(ctx, cfg.Cohort())
+		require.NoError(t, err)
+		assert.Empty(t, metrics.ProfitPcts)
+	})
+
+	t.Run("Currency", func(t *testing.T) {
+		got, err := repo.GetByID(ctx, results[0].ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.StakeCurrency)
+		assert.Nil(t, got.ProfitTotalNormalized)
+
+		btcStrategy := createTestStrategy(t, "BTCStakedStrategy", nil)
+		cfg := testBacktestConfig()
+		cfg.StakeCurrency = "BTC"
+		job := domain.NewBacktestJob(btcStrategy.ID, cfg, 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+		result := domain.NewBacktestResult(job.ID, btcStrategy.ID)
+		result.ProfitTotal = 0.02
+		btc := "BTC"
+		result.StakeCurrency = &btc
+		result.Normalize("USDT", 50000)
+		require.NoError(t, repo.Create(ctx, result))
+
+		got, err = repo.GetByID(ctx, result.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.StakeCurrency)
+		assert.Equal(t, "BTC", *got.StakeCurrency)
+		require.NotNil(t, got.ReferenceCurrency)
+		assert.Equal(t, "USDT", *got.ReferenceCurrency)
+		require.NotNil(t, got.ProfitTotalNormalized)
+		assert.InDelta(t, 1000.0, *got.ProfitTotalNormalized, 1e-6)
+	})
+
+	t.Run("Supersede", func(t *testing.T) {
+		rerun := createTestStrategy(t, "RerunStrategy", nil)
+
+		var runs []*domain.BacktestResult
+		for _, sharpe := range []float64{1.0, 3.0} {
+			job := domain.NewBacktestJob(rerun.ID, testBacktestConfig(), 0, nil)
+			require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+			result := domain.NewBacktestResult(job.ID, rerun.ID)
+			result.SharpeRatio = &sharpe
+			require.NoError(t, repo.Create(ctx, result))
+			runs = append(runs, result)
+		}
+
+		old, err := repo.GetByID(ctx, runs[0].ID)
+		require.NoError(t, err)
+		require.NotNil(t, old.SupersededBy)
+		assert.Equal(t, runs[1].ID, *old.SupersededBy)
+
+		latest, _, err := repo.Query(ctx, domain.BacktestResultQuery{StrategyID: &rerun.ID, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, latest, 1)
+		assert.Equal(t, runs[1].ID, latest[0].ID)
+		assert.Nil(t, latest[0].SupersededBy)
+
+		all, total, err := repo.Query(ctx, domain.BacktestResultQuery{StrategyID: &rerun.ID, IncludeSuperseded: true, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+		assert.Equal(t, 2, total)
+
+		stats, err := repo.GetStatistics(ctx, rerun.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.ResultCount)
+		assert.InDelta(t, 3.0, stats.SharpeRatio.Mean, 1e-9)
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		envStrategy := createTestStrategy(t, "EnvironmentStrategy", nil)
+		job := domain.NewBacktestJob(envStrategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+		result := domain.NewBacktestResult(job.ID, envStrategy.ID)
+		result.Environment = &domain.ExecutionEnvironment{
+			FreqtradeVersion: "2025.4",
+			ImageDigest:      "sha256:abc",
+			Host:             "worker-1",
+			Packages:         map[string]string{"ccxt": "4.4.0"},
+		}
+		require.NoError(t, repo.Create(ctx, result))
+
+		got, err := repo.GetByID(ctx, result.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.Environment)
+		assert.Equal(t, "2025.4", got.Environment.FreqtradeVersion)
+		assert.Equal(t, "4.4.0", got.Environment.Packages["ccxt"])
+
+		version := "2025.4"
+		matching, total, err := repo.Query(ctx, domain.BacktestResultQuery{FreqtradeVersion: &version, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, matching, 1)
+		assert.Equal(t, result.ID, matching[0].ID)
+
+		host := "worker-2"
+		_, total, err = repo.Query(ctx, domain.BacktestResultQuery{Host: &host, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+
+	t.Run("RawLog", func(t *testing.T) {
+		logStrategy := createTestStrategy(t, "RawLogStrategy", nil)
+		job := domain.NewBacktestJob(logStrategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+		result := domain.NewBacktestResult(job.ID, logStrategy.ID)
+		result.RawLog = []byte{0x1f, 0x8b, 0x08, 0x00, 0x01, 0x02}
+		require.NoError(t, repo.Create(ctx, result))
+
+		// Results load without the log; it is fetched on demand
+		got, err := repo.GetByID(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.RawLog)
+
+		rawLog, err := repo.GetRawLog(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, result.RawLog, rawLog)
+
+		_, err = repo.GetRawLog(ctx, results[0].ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("TradeReturns", func(t *testing.T) {
+		tradeStrategy := createTestStrategy(t, "TradeReturnsStrategy", nil)
+		var ids []uuid.UUID
+		for i, returns := range [][]float64{{1.5, -0.5}, {2.0}} {
+			cfg := testBacktestConfig()
+			cfg.TimerangeEnd = fmt.Sprintf("2024-%02d-01", 3+i)
+			job := domain.NewBacktestJob(tradeStrategy.ID, cfg, 0, nil)
+			require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+			result := domain.NewBacktestResult(job.ID, tradeStrategy.ID)
+			result.TradeReturns = returns
+			require.NoError(t, repo.Create(ctx, result))
+			ids = append(ids, result.ID)
+		}
+
+		returns, err := repo.GetTradeReturns(ctx, ids[0])
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1.5, -0.5}, returns)
+
+		// Results whose format did not report trades have none
+		returns, err = repo.GetTradeReturns(ctx, results[0].ID)
+		require.NoError(t, err)
+		assert.Empty(t, returns)
+
+		pooled, err := repo.GetStrategyTradeReturns(ctx, tradeStrategy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1.5, -0.5, 2.0}, pooled)
+
+		_, err = repo.GetTradeReturns(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("EquityCurve", func(t *testing.T) {
+		equityStrategy := createTestStrategy(t, "EquityCurveStrategy", nil)
+		job := domain.NewBacktestJob(equityStrategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+		day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		result := domain.NewBacktestResult(job.ID, equityStrategy.ID)
+		result.EquityCurve = domain.NewEquityCurve(1000, []domain.DailyProfit{
+			{Date: day, ProfitAbs: 100},
+			{Date: day.AddDate(0, 0, 1), ProfitAbs: -220},
+			{Date: day.AddDate(0, 0, 2), ProfitAbs: 20},
+		})
+		require.NoError(t, repo.Create(ctx, result))
+
+		points, err := repo.GetEquityCurve(ctx, result.ID)
+		require.NoError(t, err)
+		require.Len(t, points, 3)
+		assert.True(t, points[0].Timestamp.Equal(day))
+		assert.InDelta(t, 1100.0, points[0].Balance, 1e-9)
+		assert.InDelta(t, 880.0, points[1].Balance, 1e-9)
+		assert.InDelta(t, 20.0, points[1].DrawdownPct, 1e-9)
+		assert.InDelta(t, 900.0, points[2].Balance, 1e-9)
+
+		// Results whose format did not report daily profits have none
+		points, err = repo.GetEquityCurve(ctx, results[0].ID)
+		require.NoError(t, err)
+		assert.Empty(t, points)
+	})
+
+	t.Run("ExitReasons", func(t *testing.T) {
+		reasonStrategy := createTestStrategy(t, "ExitReasonStrategy", nil)
+		job := domain.NewBacktestJob(reasonStrategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+		result := domain.NewBacktestResult(job.ID, reasonStrategy.ID)
+		result.SetExitReasons([]domain.TradeReasonStats{
+			{Reason: "roi", Trades: 2, AvgProfitPct: 3.0},
+			{Reason: "trailing_stop_loss", Trades: 6, AvgProfitPct: 1.5},
+			{Reason: "stop_loss", Trades: 2, AvgProfitPct: -4.0},
+		})
+		result.EntryTags = []domain.TradeReasonStats{{Reason: "breakout", Trades: 10, AvgProfitPct: 0.9}}
+		require.NoError(t, repo.Create(ctx, result))
+
+		got, err := repo.GetByID(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, result.ExitReasons, got.ExitReasons)
+		assert.Equal(t, result.EntryTags, got.EntryTags)
+		require.NotNil(t, got.TrailingStopExitPct)
+		assert.InDelta(t, 60.0, *got.TrailingStopExitPct, 0.01)
+
+		// Results without an exit breakdown are excluded
+		maxTrailing := 75.0
+		matching, total, err := repo.Query(ctx, domain.BacktestResultQuery{MaxTrailingStopExitPct: &maxTrailing, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, matching, 1)
+		assert.Equal(t, result.ID, matching[0].ID)
+
+		maxTrailing = 50.0
+		_, total, err = repo.Query(ctx, domain.BacktestResultQuery{MaxTrailingStopExitPct: &maxTrailing, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+}
+
+// TestStrategyRepository_ArchivalCandidates tests the archival policy query.
+func TestStrategyRepository_ArchivalCandidates(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	// addResults completes n backtests with the given sharpe for a strategy
+	addResults := func(strategy *domain.Strategy, n int, sharpe float64) {
+		for i := 0; i < n; i++ {
+			cfg := testBacktestConfig()
+			cfg.TimerangeEnd = fmt.Sprintf("2024-%02d-01", 3+i)
+			job := domain.NewBacktestJob(strategy.ID, cfg, 0, nil)
+			require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+			require.NoError(t, env.repos.BacktestJob.MarkCompleted(ctx, job.ID))
+
+			result := domain.NewBacktestResult(job.ID, strategy.ID)
+			result.SharpeRatio = &sharpe
+			require.NoError(t, env.repos.Result.Create(ctx, result))
+		}
+	}
+
+	loser := createTestStrategy(t, "Loser", nil)
+	addResults(loser, 3, 0.2)
+
+	winner := createTestStrategy(t, "Winner", nil)
+	addResults(winner, 3, 1.5)
+
+	fewBacktests := createTestStrategy(t, "FewBacktests", nil)
+	addResults(fewBacktests, 1, 0.1)
+
+	starred := createTestStrategy(t, "Starred", nil)
+	addResults(starred, 3, 0.1)
+	require.NoError(t, env.repos.Star.Star(ctx, "alice", domain.StarEntityStrategy, starred.ID))
+
+	policy := domain.ArchivalPolicy{MinBacktests: 2, MaxBestSharpe: 0.5, InactiveFor: 24 * time.Hour, Limit: 10}
+
+	t.Run("RecentActivityIsKept", func(t *testing.T) {
+		candidates, err := env.repos.Strategy.FindArchivalCandidates(ctx, policy, time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+	})
+
+	t.Run("InactiveUnderperformer", func(t *testing.T) {
+		candidates, err := env.repos.Strategy.FindArchivalCandidates(ctx, policy, time.Now().Add(48*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, loser.ID, candidates[0].StrategyID)
+		assert.Equal(t, 3, candidates[0].BacktestCount)
+
+		require.NoError(t, env.repos.Strategy.Archive(ctx, loser.ID, policy.Reason()))
+		candidates, err = env.repos.Strategy.FindArchivalCandidates(ctx, policy, time.Now().Add(48*time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, candidates, "archived strategies are not candidates")
+	})
+}
+
+// TestStrategyRepository_GenerationStatistics tests the per-generation evolution statistics.
+func TestStrategyRepository_GenerationStatistics(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	addResult := func(strategy *domain.Strategy, sharpe float64) {
+		job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+		result := domain.NewBacktestResult(job.ID, strategy.ID)
+		result.SharpeRatio = &sharpe
+		require.NoError(t, env.repos.Result.Create(ctx, result))
+	}
+
+	root := createTestStrategy(t, "Root", nil)
+	addResult(root, 0.5)
+	other := createTestStrategy(t, "OtherRoot", nil)
+	addResult(other, 1.5)
+	child := createTestStrategy(t, "Child", &root.ID)
+	addResult(child, 2.0)
+	createTestStrategy(t, "Untested", &root.ID)
+
+	generations, err := env.repos.Strategy.GetGenerationStatistics(ctx)
+	require.NoError(t, err)
+	require.Len(t, generations, 2)
+
+	assert.Equal(t, 0, generations[0].Generation)
+	assert.Equal(t, 2, generations[0].StrategyCount)
+	assert.Equal(t, 2, generations[0].TestedCount)
+	assert.Equal(t, 1, generations[0].SurvivorCount)
+	require.NotNil(t, generations[0].MeanSharpe)
+	assert.InDelta(t, 1.0, *generations[0].MeanSharpe, 1e-9)
+
+	assert.Equal(t, 1, generations[1].Generation)
+	assert.Equal(t, 2, generations[1].StrategyCount)
+	assert.Equal(t, 1, generations[1].TestedCount)
+	assert.Zero(t, generations[1].SurvivorCount)
+	require.NotNil(t, generations[1].BestSharpe)
+	assert.InDelta(t, 2.0, *generations[1].BestSharpe, 1e-9)
+
+	summary := domain.NewEvolutionSummary(generations, time.Now())
+	assert.Equal(t, 4, summary.StrategyCount)
+	assert.InDelta(t, 0.5, summary.Generations[0].SurvivalRate, 1e-9)
+	assert.Equal(t, 1, summary.ComparedGenerations)
+	assert.Equal(t, 1, summary.ImprovingGenerations)
+	require.NotNil(t, summary.Generations[1].MeanSharpeDelta)
+	assert.InDelta(t, 1.0, *summary.Generations[1].MeanSharpeDelta, 1e-9)
+}
+
+// TestStrategyRepository_DescriptionQueue tests claiming strategies lacking a
+// description and storing generated ones.
+func TestStrategyRepository_DescriptionQueue(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Strategy
+
+	createUndescribed := func(name string) *domain.Strategy {
+		code := "class " + name + "(IStrategy):\n    # " + uuid.NewString() + "\n    pass\n"
+		strategy := domain.NewStrategy(name, code, "", nil)
+		require.NoError(t, repo.Create(ctx, strategy))
+		return strategy
+	}
+
+	createTestStrategy(t, "Described", nil)
+	first := createUndescribed("First")
+	second := createUndescribed("Second")
+	archived := createUndescribed("Archived")
+	require.NoError(t, repo.Archive(ctx, archived.ID, "test"))
+
+	now := time.Now()
+	requests, err := repo.ClaimDescriptionRequests(ctx, 1, time.Hour, now)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, first.ID, requests[0].StrategyID, "oldest first")
+
+	requests, err = repo.ClaimDescriptionRequests(ctx, 10, time.Hour, now)
+	require.NoError(t, err)
+	require.Len(t, requests, 1, "requested and archived strategies are skipped")
+	assert.Equal(t, second.ID, requests[0].StrategyID)
+
+	requests, err = repo.ClaimDescriptionRequests(ctx, 10, time.Hour, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, requests, 2, "unanswered requests are retried")
+
+	coverage, err := repo.GetDescriptionCoverage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, coverage.Strategies)
+	assert.Equal(t, 1, coverage.Described)
+	assert.Equal(t, 2, coverage.Missing)
+	assert.Equal(t, 2, coverage.Requested)
+
+	require.NoError(t, repo.SetGeneratedDescription(ctx, first.ID, "Buys RSI dips in an uptrend."))
+	got, err := repo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Buys RSI dips in an uptrend.", got.Description)
+
+	err = repo.SetGeneratedDescription(ctx, first.ID, "Another description.")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	err = repo.SetGeneratedDescription(ctx, uuid.New(), "Description.")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	coverage, err = repo.GetDescriptionCoverage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, coverage.Described)
+	assert.Equal(t, 1, coverage.Generated)
+	assert.Equal(t, 1, coverage.Requested)
+	assert.InDelta(t, 200.0/3, coverage.CoveragePct, 1e-9)
+}
+
+// TestOptimizationRepository_Conformance tests the Postgres optimization repository.
+func TestOptimizationRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Optimization
+
+	strategy := createTestStrategy(t, "OptStrategy", nil)
+	run := domain.NewOptimizationRun("integration run", strategy.ID, domain.OptimizationConfig{
+		BacktestConfig: testBacktestConfig(),
+		MaxIterations:  5,
+		Mode:           domain.OptimizationModeBalanced,
+	})
+	require.NoError(t, repo.Create(ctx, run))
+
+	got, err := repo.GetByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OptimizationStatusPending, got.Status)
+	assert.Equal(t, 5, got.MaxIterations)
+
+	require.NoError(t, repo.UpdateStatus(ctx, run.ID, domain.OptimizationStatusRunning))
+	require.NoError(t, repo.IncrementIteration(ctx, run.ID))
+
+	got, err = repo.GetByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OptimizationStatusRunning, got.Status)
+	assert.Equal(t, 1, got.CurrentIteration)
+
+	runs, total, err := repo.List(ctx, domain.OptimizationListQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, runs, 1)
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	t.Run("IterationCodePin", func(t *testing.T) {
+		job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, &run.ID)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+		iteration := domain.NewOptimizationIteration(run.ID, 1, strategy.ID, job.ID)
+		require.NoError(t, repo.AddIteration(ctx, iteration))
+		assert.Equal(t, strategy.CodeHash, iteration.CodeHash)
+		assert.Nil(t, iteration.CodeSnapshot)
+
+		// Runs with snapshot_code also keep the code itself
+		snapshotRun := domain.NewOptimizationRun("snapshot run", strategy.ID, domain.OptimizationConfig{
+			BacktestConfig: testBacktestConfig(),
+			MaxIterations:  5,
+			Mode:           domain.OptimizationModeBalanced,
+			SnapshotCode:   true,
+		})
+		require.NoError(t, repo.Create(ctx, snapshotRun))
+		snapshotJob := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, &snapshotRun.ID)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, snapshotJob))
+		require.NoError(t, repo.AddIteration(ctx, domain.NewOptimizationIteration(snapshotRun.ID, 1, strategy.ID, snapshotJob.ID)))
+
+		iterations, err := repo.GetIterations(ctx, snapshotRun.ID)
+		require.NoError(t, err)
+		require.Len(t, iterations, 1)
+		assert.Equal(t, strategy.CodeHash, iterations[0].CodeHash)
+		require.NotNil(t, iterations[0].CodeSnapshot)
+		assert.Equal(t, strategy.Code, *iterations[0].CodeSnapshot)
+
+		err = repo.AddIteration(ctx, domain.NewOptimizationIteration(run.ID, 2, uuid.New(), job.ID))
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Seeds", func(t *testing.T) {
+		assert.Equal(t, []uuid.UUID{strategy.ID}, got.SeedStrategyIDs)
+
+		other := createTestStrategy(t, "OptSeed", nil)
+		seeded := domain.NewOptimizationRun("seeded run", uuid.Nil, domain.OptimizationConfig{
+			BacktestConfig: testBacktestConfig(),
+			MaxIterations:  5,
+			Mode:           domain.OptimizationModeBalanced,
+		})
+		require.NoError(t, seeded.SetSeeds([]uuid.UUID{other.ID, strategy.ID, other.ID}))
+		assert.Equal(t, other.ID, seeded.BaseStrategyID)
+		require.NoError(t, repo.Create(ctx, seeded))
+
+		gotSeeded, err := repo.GetByID(ctx, seeded.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{other.ID, strategy.ID}, gotSeeded.SeedStrategyIDs)
+	})
+
+	t.Run("DataSnapshot", func(t *testing.T) {
+		require.NotNil(t, got.DataSnapshot)
+		assert.Equal(t, run.DataSnapshot.Pairs, got.DataSnapshot.Pairs)
+		assert.Equal(t, run.DataSnapshot.TimerangeEnd, got.DataSnapshot.TimerangeEnd)
+
+		// A later job with a different range is forced back onto the snapshot
+		cfg := testBacktestConfig()
+		cfg.TimerangeEnd = "20991231"
+		warnings := got.PinBacktestConfig(&cfg)
+		assert.Len(t, warnings, 1)
+		assert.Equal(t, run.DataSnapshot.TimerangeEnd, cfg.TimerangeEnd)
+	})
+
+	t.Run("PerformanceSeries", func(t *testing.T) {
+		for i, sharpe := range []float64{1.0, 2.0} {
+			cfg := testBacktestConfig()
+			cfg.TimerangeEnd = fmt.Sprintf("2024-%02d-01", 3+i)
+			job := domain.NewBacktestJob(strategy.ID, cfg, 0, &run.ID)
+			require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+			result := domain.NewBacktestResult(job.ID, strategy.ID)
+			result.ProfitPct = 5
+			result.SharpeRatio = &sharpe
+			require.NoError(t, env.repos.Result.Create(ctx, result))
+
+			iteration := domain.NewOptimizationIteration(run.ID, 10+i, strategy.ID, job.ID)
+			require.NoError(t, repo.AddIteration(ctx, iteration))
+			require.NoError(t, repo.UpdateIterationResult(ctx, iteration.ID, result.ID))
+		}
+
+		query := domain.PerformanceQuery{
+			Start:  time.Now().Add(-time.Hour),
+			End:    time.Now().Add(time.Minute),
+			Metric: domain.PerformanceMetricSharpe,
+			Bucket: domain.PerformanceBucketDaily,
+			PerRun: true,
+		}
+		series, err := repo.GetPerformanceSeries(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, series, 1)
+		require.NotNil(t, series[0].OptimizationRunID)
+		assert.Equal(t, run.ID, *series[0].OptimizationRunID)
+		assert.Equal(t, run.Name, series[0].OptimizationName)
+		points := series[0].Points
+		require.NotEmpty(t, points)
+		var total int
+		for _, p := range points {
+			total += p.Iterations
+		}
+		assert.Equal(t, 2, total)
+
+		// One point per iteration across all runs
+		query.Bucket = domain.PerformanceBucketNone
+		query.PerRun = false
+		series, err = repo.GetPerformanceSeries(ctx, query)
+		require.NoError(t, err)
+		require.Len(t, series, 1)
+		assert.Nil(t, series[0].OptimizationRunID)
+		require.Len(t, series[0].Points, 2)
+		assert.InDelta(t, 1.0, series[0].Points[0].Value, 1e-9)
+		assert.InDelta(t, 2.0, series[0].Points[1].Best, 1e-9)
+	})
+}
+
+// TestOptimizationRepository_AutoPause tests pausing runs for an outage and resuming them.
+func TestOptimizationRepository_AutoPause(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Optimization
+
+	strategy := createTestStrategy(t, "AutoPauseStrategy", nil)
+	newRun := func(name string, status domain.OptimizationStatus) *domain.OptimizationRun {
+		run := domain.NewOptimizationRun(name, strategy.ID, domain.OptimizationConfig{
+			BacktestConfig: testBacktestConfig(),
+			MaxIterations:  5,
+		})
+		require.NoError(t, repo.Create(ctx, run))
+		require.NoError(t, repo.UpdateStatus(ctx, run.ID, status))
+		return run
+	}
+
+	running := newRun("running", domain.OptimizationStatusRunning)
+	manual := newRun("manual", domain.OptimizationStatusPaused)
+	takenOver := newRun("taken over", domain.OptimizationStatusRunning)
+
+	outageStart := time.Now().UTC().Truncate(time.Second)
+	paused, err := repo.AutoPause(ctx, []string{"docker"}, outageStart)
+	require.NoError(t, err)
+	assert.Len(t, paused, 2)
+
+	got, err := repo.GetByID(ctx, running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OptimizationStatusPaused, got.Status)
+	require.NotNil(t, got.PauseReason)
+	assert.Equal(t, domain.PauseReasonAutoPaused, *got.PauseReason)
+	require.Len(t, got.Incidents, 1)
+	assert.Equal(t, []string{"docker"}, got.Incidents[0].Dependencies)
+	assert.True(t, outageStart.Equal(got.Incidents[0].PausedAt))
+	assert.Nil(t, got.Incidents[0].ResumedAt)
+
+	// An operator resuming a run during the outage takes it over
+	require.NoError(t, repo.UpdateStatus(ctx, takenOver.ID, domain.OptimizationStatusPaused))
+
+	resumed, err := repo.AutoResume(ctx, outageStart.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, resumed, 1)
+	assert.Equal(t, running.ID, resumed[0].ID)
+	assert.Equal(t, domain.OptimizationStatusRunning, resumed[0].Status)
+	assert.Nil(t, resumed[0].PauseReason)
+	require.Len(t, resumed[0].Incidents, 1)
+	require.NotNil(t, resumed[0].Incidents[0].ResumedAt)
+	assert.True(t, outageStart.Add(time.Minute).Equal(*resumed[0].Incidents[0].ResumedAt))
+
+	got, err = repo.GetByID(ctx, manual.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OptimizationStatusPaused, got.Status)
+	require.NotNil(t, got.PauseReason)
+	assert.Equal(t, domain.PauseReasonManual, *got.PauseReason)
+	assert.Empty(t, got.Incidents)
+
+	got, err = repo.GetByID(ctx, takenOver.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OptimizationStatusPaused, got.Status)
+	require.Len(t, got.Incidents, 1)
+	assert.NotNil(t, got.Incidents[0].ResumedAt)
+}
+
+// TestOptimizationRepository_Stall tests flagging inactive runs as stalled and reviving them.
+func TestOptimizationRepository_Stall(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Optimization
+
+	strategy := createTestStrategy(t, "StallStrategy", nil)
+	newRun := func(name string, status domain.OptimizationStatus) *domain.OptimizationRun {
+		run := domain.NewOptimizationRun(name, strategy.ID, domain.OptimizationConfig{
+			BacktestConfig: testBacktestConfig(),
+			MaxIterations:  5,
+		})
+		require.NoError(t, repo.Create(ctx, run))
+		require.NoError(t, repo.UpdateStatus(ctx, run.ID, status))
+		return run
+	}
+
+	running := newRun("running", domain.OptimizationStatusRunning)
+	newRun("paused", domain.OptimizationStatusPaused)
+
+	// Nothing is inactive for an hour yet
+	now := time.Now().UTC().Truncate(time.Second)
+	stalled, err := repo.MarkStalled(ctx, now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	assert.Empty(t, stalled)
+
+	// Only running runs are flagged
+	stalledAt := now.Add(-time.Minute)
+	stalled, err = repo.MarkStalled(ctx, now.Add(time.Hour), stalledAt)
+	require.NoError(t, err)
+	require.Len(t, stalled, 1)
+	assert.Equal(t, running.ID, stalled[0].Run.ID)
+	assert.Equal(t, domain.OptimizationStatusStalled, stalled[0].Run.Status)
+	require.NotNil(t, stalled[0].Run.StalledAt)
+	assert.True(t, stalledAt.Equal(*stalled[0].Run.StalledAt))
+	assert.False(t, stalled[0].LastActivityAt.IsZero())
+
+	status := domain.OptimizationStatusStalled
+	listed, total, err := repo.List(ctx, domain.OptimizationListQuery{Status: &status, Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, listed, 1)
+	assert.Equal(t, running.ID, listed[0].ID)
+
+	// Without new activity the run stays stalled
+	revived, err := repo.ReviveStalled(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, revived)
+
+	// A new backtest job revives it
+	job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, &running.ID)
+	require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+
+	revived, err = repo.ReviveStalled(ctx)
+	require.NoError(t, err)
+	require.Len(t, revived, 1)
+	assert.Equal(t, domain.OptimizationStatusRunning, revived[0].Status)
+	assert.Nil(t, revived[0].StalledAt)
+}
+
+// TestOptimizationRepository_Cancel tests cancelling a run with a reason.
+func TestOptimizationRepository_Cancel(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Optimization
+
+	strategy := createTestStrategy(t, "CancelStrategy", nil)
+	run := domain.NewOptimizationRun("cancel", strategy.ID, domain.OptimizationConfig{
+		BacktestConfig: testBacktestConfig(),
+		MaxIterations:  5,
+	})
+	require.NoError(t, repo.Create(ctx, run))
+	require.NoError(t, repo.UpdateStatus(ctx, run.ID, domain.OptimizationStatusPaused))
+
+	cancelled, err := repo.Cancel(ctx, run.ID, domain.NewCancellation("budget exhausted", "ops"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.OptimizationStatusCancelled, cancelled.Status)
+	assert.Nil(t, cancelled.PauseReason)
+	assert.NotNil(t, cancelled.CompletedAt)
+
+	got, err := repo.GetByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Cancellation{Reason: "budget exhausted", CancelledBy: "ops"}, got.Cancellation())
+
+	_, err = repo.Cancel(ctx, uuid.New(), domain.Cancellation{})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+// TestOptimizationRepository_Quota tests quota usage and that pending jobs of
+// runs at their concurrency quota are skipped.
+func TestOptimizationRepository_Quota(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Optimization
+
+	strategy := createTestStrategy(t, "QuotaStrategy", nil)
+	limited := domain.NewOptimizationRun("limited", strategy.ID, domain.OptimizationConfig{
+		BacktestConfig: testBacktestConfig(),
+		MaxIterations:  5,
+		Quota:          &domain.OptimizationQuota{MaxConcurrentJobs: 1, MemoryLimitMB: 1024},
+	})
+	require.NoError(t, repo.Create(ctx, limited))
+	unlimited := domain.NewOptimizationRun("unlimited", strategy.ID, domain.OptimizationConfig{
+		BacktestConfig: testBacktestConfig(),
+		MaxIterations:  5,
+	})
+	require.NoError(t, repo.Create(ctx, unlimited))
+
+	var limitedJobs []*domain.BacktestJob
+	for i := 0; i < 2; i++ {
+		job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 10, &limited.ID)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+		limitedJobs = append(limitedJobs, job)
+	}
+	other := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, &unlimited.ID)
+	require.NoError(t, env.repos.BacktestJob.Create(ctx, other))
+
+	pending, err := env.repos.BacktestJob.GetPendingJobs(ctx, 10, 0, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, pending, 3)
+
+	require.NoError(t, env.repos.BacktestJob.MarkRunning(ctx, limitedJobs[0].ID, "pending"))
+
+	usage, err := repo.GetQuotaUsage(ctx, []uuid.UUID{limited.ID, unlimited.ID})
+	require.NoError(t, err)
+	require.Len(t, usage, 1, "runs without a quota are left out")
+	assert.Equal(t, 1, usage[limited.ID].RunningJobs)
+	assert.Equal(t, domain.OptimizationQuota{MaxConcurrentJobs: 1, MemoryLimitMB: 1024}, usage[limited.ID].Quota)
+	assert.Equal(t, 0, usage[limited.ID].Remaining())
+
+	pending, err = env.repos.BacktestJob.GetPendingJobs(ctx, 10, 0, time.Now())
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "the limited run is at its quota")
+	assert.Equal(t, other.ID, pending[0].ID)
+}
+
+// TestOptimizationRepository_ClaimNextAction tests the orchestrator state machine and its claims.
+func TestOptimizationRepository_ClaimNextAction(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Optimization
+	lease := time.Minute
+	now := time.Now().UTC().Truncate(time.Second)
+
+	strategy := createTestStrategy(t, "ClaimStrategy", nil)
+	run := domain.NewOptimizationRun("claims", strategy.ID, domain.OptimizationConfig{
+		BacktestConfig: testBacktestConfig(),
+		MaxIterations:  1,
+	})
+	require.NoError(t, repo.Create(ctx, run))
+
+	action, err := repo.ClaimNextAction(ctx, run.ID, "orchestrator-a", lease, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrchestratorActionNone, action.Type, "pending runs have nothing to do")
+
+	require.NoError(t, repo.UpdateStatus(ctx, run.ID, domain.OptimizationStatusRunning))
+
+	action, err = repo.ClaimNextAction(ctx, run.ID, "orchestrator-a", lease, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrchestratorActionGenerateCandidate, action.Type)
+	assert.Equal(t, 1, action.IterationNumber)
+	assert.Equal(t, strategy.ID, *action.SourceStrategyID)
+	assert.Equal(t, "orchestrator-a", *action.ClaimedBy)
+
+	// Another replica is kept off the claimed step until the claim expires
+	action, err = repo.ClaimNextAction(ctx, run.ID, "orchestrator-b", lease, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrchestratorActionNone, action.Type)
+	assert.Equal(t, "orchestrator-a", *action.ClaimedBy)
+
+	// Asking for work revives a stalled run, even while the step is claimed
+	_, err = repo.MarkStalled(ctx, now.Add(time.Hour), now)
+	require.NoError(t, err)
+	action, err = repo.ClaimNextAction(ctx, run.ID, "orchestrator-b", lease, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrchestratorActionNone, action.Type)
+	revived, err := repo.GetByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OptimizationStatusRunning, revived.Status)
+
+	action, err = repo.ClaimNextAction(ctx, run.ID, "orchestrator-b", lease, now.Add(2*lease))
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrchestratorActionGenerateCandidate, action.Type)
+	assert.Equal(t, "orchestrator-b", *action.ClaimedBy)
+
+	job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, &run.ID)
+	require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+	iteration := domain.NewOptimizationIteration(run.ID, 1, strategy.ID, job.ID)
+	require.NoError(t, repo.AddIteration(ctx, iteration))
+
+	// The claim lapses once the run has moved on
+	action, err = repo.ClaimNextAction(ctx, run.ID, "orchestrator-a", lease, now.Add(2*lease))
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrchestratorActionAwaitResults, action.Type)
+	assert.Nil(t, action.ClaimedBy)
+
+	require.NoError(t, env.repos.BacktestJob.MarkRunning(ctx, job.ID, "container"))
+	require.NoError(t, env.repos.BacktestJob.MarkCompleted(ctx, job.ID))
+
+	action, err = repo.ClaimNextAction(ctx, run.ID, "orchestrator-a", lease, now.Add(2*lease))
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrchestratorActionEvaluateCriteria, action.Type)
+	assert.Equal(t, iteration.ID, action.Iteration.ID)
+
+	require.NoError(t, repo.UpdateIterationFeedback(ctx, iteration.ID, "", "tighten stoploss", domain.ApprovalStatusNeedsIteration))
+
+	action, err = repo.ClaimNextAction(ctx, run.ID, "orchestrator-a", lease, now.Add(2*lease))
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrchestratorActionFinalize, action.Type)
+	assert.Equal(t, domain.TerminationReasonMaxIterations, action.Reason)
+
+	_, err = repo.ClaimNextAction(ctx, uuid.New(), "orchestrator-a", lease, now)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+// TestConsistencyRepository_CheckAndRepair tests finding and repairing
+// cross-table invariant violations, including dry runs.
+func TestConsistencyRepository_CheckAndRepair(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Consistency
+	now := time.Now().UTC().Truncate(time.Second)
+
+	strategy := createTestStrategy(t, "ConsistencyStrategy", nil)
+	run := domain.NewOptimizationRun("drifted", strategy.ID, domain.OptimizationConfig{
+		BacktestConfig: testBacktestConfig(),
+		MaxIterations:  5,
+	})
+	require.NoError(t, env.repos.Optimization.Create(ctx, run))
+	// current_iteration moves without an iteration being recorded
+	require.NoError(t, env.repos.Optimization.IncrementIteration(ctx, run.ID))
+
+	job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+	require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+	require.NoError(t, env.repos.BacktestJob.MarkRunning(ctx, job.ID, ""))
+
+	report, err := repo.Check(ctx, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Total())
+	assert.Equal(t, 1, report.Counts[domain.ConsistencyCheckRunIterationMismatch])
+	assert.Equal(t, 1, report.Counts[domain.ConsistencyCheckRunningJobWithoutContainer])
+	assert.False(t, report.Repair)
+
+	t.Run("DryRun", func(t *testing.T) {
+		report, err := repo.Repair(ctx, nil, true, now)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 2, report.Total())
+		for _, v := range report.Violations {
+			assert.False(t, v.Repaired)
+		}
+
+		got, err := env.repos.BacktestJob.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusRunning, got.Status, "dry run must not change anything")
+	})
+
+	t.Run("Repair", func(t *testing.T) {
+		report, err := repo.Repair(ctx, []domain.ConsistencyCheck{domain.ConsistencyCheckRunIterationMismatch}, false, now)
+		require.NoError(t, err)
+		require.Len(t, report.Violations, 1)
+		assert.True(t, report.Violations[0].Repaired)
+
+		got, err := env.repos.Optimization.GetByID(ctx, run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, got.CurrentIteration)
+
+		report, err = repo.Repair(ctx, nil, false, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Total(), "only the running job is left to repair")
+
+		failed, err := env.repos.BacktestJob.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusFailed, failed.Status)
+
+		events, err := env.repos.BacktestJob.GetEvents(ctx, job.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		assert.Equal(t, domain.JobEventFailed, events[len(events)-1].Type)
+
+		report, err = repo.Check(ctx, nil, now)
+		require.NoError(t, err)
+		assert.Zero(t, report.Total())
+	})
+}
+
+// TestExternalRef_Conformance tests external references on jobs and
+// optimization runs, which are unique per owner.
+func TestExternalRef_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	strategy := createTestStrategy(t, "ExternalRefStrategy", nil)
+
+	t.Run("BacktestJob", func(t *testing.T) {
+		repo := env.repos.BacktestJob
+
+		job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		job.SetExternalRef("alice", "ci:build-42")
+		require.NoError(t, repo.Create(ctx, job))
+
+		got, err := repo.GetByExternalRef(ctx, "alice", "ci:build-42")
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, got.ID)
+		require.NotNil(t, got.ExternalRef)
+		assert.Equal(t, "ci:build-42", *got.ExternalRef)
+
+		// Another owner's reference is not visible
+		_, err = repo.GetByExternalRef(ctx, "bob", "ci:build-42")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		duplicate := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		duplicate.SetExternalRef("alice", "ci:build-42")
+		assert.ErrorIs(t, repo.Create(ctx, duplicate), domain.ErrDuplicate)
+
+		other := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+		other.SetExternalRef("bob", "ci:build-42")
+		require.NoError(t, repo.Create(ctx, other), "references are unique per owner")
+	})
+
+	t.Run("OptimizationRun", func(t *testing.T) {
+		repo := env.repos.Optimization
+		newRun := func(owner string) *domain.OptimizationRun {
+			run := domain.NewOptimizationRun("external ref run", strategy.ID, domain.OptimizationConfig{
+				BacktestConfig: testBacktestConfig(),
+				MaxIterations:  5,
+				Mode:           domain.OptimizationModeBalanced,
+			})
+			run.SetExternalRef(owner, "sweep-7")
+			return run
+		}
+
+		run := newRun("alice")
+		require.NoError(t, repo.Create(ctx, run))
+
+		got, err := repo.GetByExternalRef(ctx, "alice", "sweep-7")
+		require.NoError(t, err)
+		assert.Equal(t, run.ID, got.ID)
+
+		_, err = repo.GetByExternalRef(ctx, "bob", "sweep-7")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		assert.ErrorIs(t, repo.Create(ctx, newRun("alice")), domain.ErrDuplicate)
+		require.NoError(t, repo.Create(ctx, newRun("bob")))
+	})
+}
+
+// TestPreferenceRepository_Conformance tests the Postgres preference repository.
+func TestPreferenceRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Preference
+
+	pref := domain.NewUserPreference("alice", domain.PreferenceKeyDefaultPageSize, json.RawMessage(`50`))
+	require.NoError(t, repo.Upsert(ctx, pref))
+
+	pref.Value = json.RawMessage(`100`)
+	require.NoError(t, repo.Upsert(ctx, pref))
+
+	got, err := repo.Get(ctx, "alice", domain.PreferenceKeyDefaultPageSize)
+	require.NoError(t, err)
+	assert.JSONEq(t, `100`, string(got.Value))
+
+	list, err := repo.List(ctx, "alice")
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	list, err = repo.List(ctx, "bob")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	require.NoError(t, repo.Delete(ctx, "alice", domain.PreferenceKeyDefaultPageSize))
+	_, err = repo.Get(ctx, "alice", domain.PreferenceKeyDefaultPageSize)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+// TestAgentRepository_Conformance tests the Postgres agent registration repository.
+func TestAgentRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Agent
+
+	past := time.Now().Add(-time.Hour)
+	old := &domain.AgentRegistration{
+		AgentID:             "orchestrator@host-1",
+		Type:                domain.AgentTypeOrchestrator,
+		Version:             "0.1.0",
+		EventSchemaVersions: []int{domain.EventSchemaVersion},
+		Capabilities:        []string{},
+		RegisteredAt:        past,
+		LastSeenAt:          past,
+	}
+	require.NoError(t, repo.Register(ctx, old))
+
+	now := time.Now()
+	current := &domain.AgentRegistration{
+		AgentID:             "orchestrator@host-2",
+		Type:                domain.AgentTypeOrchestrator,
+		Version:             "0.2.0",
+		EventSchemaVersions: []int{domain.EventSchemaVersion},
+		Capabilities:        []string{domain.CapabilityPopulationMode},
+		RegisteredAt:        now,
+		LastSeenAt:          now,
+	}
+	require.NoError(t, repo.Register(ctx, current))
+
+	all, err := repo.List(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	since := now.Add(-time.Minute)
+	live, err := repo.List(ctx, &since)
+	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Equal(t, current.AgentID, live[0].AgentID)
+	assert.Equal(t, []string{domain.CapabilityPopulationMode}, live[0].Capabilities)
+
+	// Population runs need the capable orchestrator
+	run := domain.NewOptimizationRun("population", uuid.Nil, domain.OptimizationConfig{})
+	require.NoError(t, run.SetSeeds([]uuid.UUID{uuid.New(), uuid.New()}))
+	assert.Empty(t, domain.UnmetAgentRequirements(run.RequiredAgents(), live))
+
+	// A heartbeat makes the old agent live again
+	require.NoError(t, repo.Touch(ctx, old.AgentID, time.Now()))
+	live, err = repo.List(ctx, &since)
+	require.NoError(t, err)
+	assert.Len(t, live, 2)
+	assert.ErrorIs(t, repo.Touch(ctx, "unknown", time.Now()), domain.ErrNotFound)
+}
+
+// TestSecretRepository_Conformance tests the Postgres secret repository through
+// the secrets store.
+func TestSecretRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	cipher, err := secrets.NewCipher(bytes.Repeat([]byte{1}, secrets.KeySize))
+	require.NoError(t, err)
+	store := secrets.NewStore(env.repos.Secret, cipher)
+
+	secret, err := store.Create(ctx, "github-token", "scout source", "ghp_first")
+	require.NoError(t, err)
+	assert.Equal(t, cipher.KeyID(), secret.KeyID)
+
+	_, err = store.Create(ctx, "github-token", "", "ghp_other")
+	assert.ErrorIs(t, err, domain.ErrDuplicate)
+
+	value, err := store.Reveal(ctx, secret.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_first", value)
+
+	// Rotating replaces the value; listing never carries ciphertext
+	rotated := "ghp_second"
+	_, err = store.Update(ctx, secret.ID, nil, &rotated)
+	require.NoError(t, err)
+	value, err = store.Reveal(ctx, secret.ID)
+	require.NoError(t, err)
+	assert.Equal(t, rotated, value)
+
+	list, err := env.repos.Secret.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Empty(t, list[0].Ciphertext)
+
+	// Referenced secrets cannot be deleted
+	schedule := domain.NewScoutSchedule("nightly", "0 2 * * *", "github", 10)
+	schedule.CredentialSecretID = &secret.ID
+	require.NoError(t, env.repos.Scout.CreateSchedule(ctx, schedule))
+	got, err := env.repos.Scout.GetScheduleByID(ctx, schedule.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.CredentialSecretID)
+	assert.Equal(t, secret.ID, *got.CredentialSecretID)
+	assert.ErrorIs(t, env.repos.Secret.Delete(ctx, secret.ID), domain.ErrSecretInUse)
+
+	missing := uuid.New()
+	schedule.CredentialSecretID = &missing
+	assert.ErrorIs(t, env.repos.Scout.UpdateSchedule(ctx, schedule), domain.ErrInvalidInput)
+
+	schedule.CredentialSecretID = nil
+	require.NoError(t, env.repos.Scout.UpdateSchedule(ctx, schedule))
+	require.NoError(t, env.repos.Secret.Delete(ctx, secret.ID))
+	assert.ErrorIs(t, env.repos.Secret.Delete(ctx, secret.ID), domain.ErrNotFound)
+}
+
+// TestAPIKeyRepository_Conformance tests the Postgres API key repository.
+func TestAPIKeyRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	newKey := func(name string) *domain.APIKey {
+		key, prefix, err := domain.GenerateAPIKey()
+		require.NoError(t, err)
+		return &domain.APIKey{
+			ID: uuid.New(), Name: name, Prefix: prefix, KeyHash: domain.HashAPIKey(key), CreatedAt: now,
+		}
+	}
+
+	key := newKey("scout-agent")
+	key.Role = domain.RoleAdmin
+	require.NoError(t, env.repos.APIKey.Create(ctx, key))
+	assert.ErrorIs(t, env.repos.APIKey.Create(ctx, newKey("scout-agent")), domain.ErrDuplicate)
+
+	got, err := env.repos.APIKey.GetByHash(ctx, key.KeyHash)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, got.ID)
+	assert.Equal(t, domain.RoleAdmin, got.Role)
+	assert.Nil(t, got.LastUsedAt)
+
+	_, err = env.repos.APIKey.GetByHash(ctx, domain.HashAPIKey("unknown"))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Uses close together are recorded once
+	require.NoError(t, env.repos.APIKey.TouchLastUsed(ctx, key.ID, now))
+	require.NoError(t, env.repos.APIKey.TouchLastUsed(ctx, key.ID, now.Add(time.Second)))
+	got, err = env.repos.APIKey.GetByHash(ctx, key.KeyHash)
+	require.NoError(t, err)
+	require.NotNil(t, got.LastUsedAt)
+	assert.True(t, got.LastUsedAt.Equal(now))
+
+	// Revoking frees the name
+	require.NoError(t, env.repos.APIKey.Revoke(ctx, key.ID, now))
+	assert.ErrorIs(t, env.repos.APIKey.Revoke(ctx, key.ID, now), domain.ErrNotFound)
+	require.NoError(t, env.repos.APIKey.Create(ctx, newKey("scout-agent")))
+
+	got, err = env.repos.APIKey.GetByHash(ctx, key.KeyHash)
+	require.NoError(t, err)
+	assert.False(t, got.Active(now))
+
+	list, err := env.repos.APIKey.List(ctx, false)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+	list, err = env.repos.APIKey.List(ctx, true)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+// TestFeatureFlagRepository_Conformance tests the Postgres feature flag repository.
+func TestFeatureFlagRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.FeatureFlag
+
+	override := &domain.FeatureFlagOverride{Name: domain.FeatureHyperopt, Enabled: true, UpdatedBy: "alice"}
+	require.NoError(t, repo.Upsert(ctx, override))
+	assert.False(t, override.UpdatedAt.IsZero())
+
+	override = &domain.FeatureFlagOverride{Name: domain.FeatureHyperopt, Enabled: false, UpdatedBy: "bob"}
+	require.NoError(t, repo.Upsert(ctx, override))
+
+	overrides, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.False(t, overrides[0].Enabled)
+	assert.Equal(t, "bob", overrides[0].UpdatedBy)
+
+	flags := domain.ResolveFeatureFlags(map[string]bool{domain.FeatureHyperopt: true}, overrides)
+	for _, flag := range flags {
+		if flag.Name == domain.FeatureHyperopt {
+			assert.False(t, flag.Enabled)
+			assert.Equal(t, domain.FeatureSourceOverride, flag.Source)
+		}
+	}
+
+	require.NoError(t, repo.Delete(ctx, domain.FeatureHyperopt))
+	assert.ErrorIs(t, repo.Delete(ctx, domain.FeatureHyperopt), domain.ErrNotFound)
+}
+
+// TestCampaignRepository_Conformance tests the Postgres campaign repository and campaign aggregates.
+func TestInsightRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Insight
+
+	tmpl := &domain.InsightTemplate{
+		Name:       "strategies-by-generation",
+		SQL:        "SELECT generation, COUNT(*) AS strategies FROM strategies WHERE generation >= $1 GROUP BY 1 ORDER BY 1",
+		Params:     []domain.InsightParam{{Name: "min_generation", Type: domain.InsightParamInt, Default: "0"}},
+		MaxRows:    10,
+		ApprovedBy: "analytics-lead",
+	}
+	require.NoError(t, repo.CreateTemplate(ctx, tmpl))
+	assert.ErrorIs(t, repo.CreateTemplate(ctx, tmpl), domain.ErrDuplicate)
+
+	got, err := repo.GetTemplate(ctx, tmpl.Name)
+	require.NoError(t, err)
+	assert.Equal(t, domain.InsightSourceDatabase, got.Source)
+	assert.Equal(t, tmpl.Params, got.Params)
+	require.NoError(t, got.Validate())
+
+	list, err := repo.ListTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	_, err = repo.GetTemplate(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	createTestStrategy(t, "InsightA", nil)
+	createTestStrategy(t, "InsightB", nil)
+
+	result, err := repo.Execute(ctx, got.SQL, []interface{}{int64(0)}, 10, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"generation", "strategies"}, result.Columns)
+	require.Equal(t, 1, result.RowCount)
+	assert.False(t, result.Truncated)
+
+	// Rows beyond the limit are reported as truncated
+	result, err = repo.Execute(ctx, "SELECT id, name FROM strategies", nil, 1, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.RowCount)
+	assert.True(t, result.Truncated)
+	assert.IsType(t, "", result.Rows[0][0], "UUIDs are returned as strings")
+
+	// Queries run read-only and under the timeout; a data-modifying CTE passes
+	// template validation but not the transaction
+	_, err = repo.Execute(ctx, "WITH d AS (DELETE FROM strategies RETURNING id) SELECT COUNT(*) FROM d", nil, 1, time.Second)
+	assert.ErrorContains(t, err, "read-only")
+	strategies, err := repo.Execute(ctx, "SELECT COUNT(*) FROM strategies", nil, 1, time.Second)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, strategies.Rows[0][0])
+	_, err = repo.Execute(ctx, "SELECT pg_sleep(1)", nil, 1, 50*time.Millisecond)
+	assert.ErrorIs(t, err, domain.ErrInsightTimeout)
+}
+
+func TestCampaignRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Campaign
+
+	campaign := domain.NewCampaign("RSI sweep", "RSI strategies over 2024", "alice")
+	require.NoError(t, repo.Create(ctx, campaign))
+
+	got, err := repo.GetByID(ctx, campaign.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "RSI sweep", got.Name)
+	assert.Equal(t, "alice", got.CreatedBy)
+
+	strategy := createTestStrategy(t, "CampaignStrategy", nil)
+
+	// One job without a result, two with distinct timeranges and results
+	pending := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+	pending.CampaignID = &campaign.ID
+	require.NoError(t, env.repos.BacktestJob.Create(ctx, pending))
+
+	var best *domain.BacktestResult
+	for i, sharpe := range []float64{1.0, 3.0} {
+		cfg := testBacktestConfig()
+		cfg.TimerangeEnd = fmt.Sprintf("2024-%02d-01", 3+i)
+		job := domain.NewBacktestJob(strategy.ID, cfg, 0, nil)
+		job.CampaignID = &campaign.ID
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+		require.NoError(t, env.repos.BacktestJob.MarkRunning(ctx, job.ID, "container"))
+		require.NoError(t, env.repos.BacktestJob.MarkCompleted(ctx, job.ID))
+
+		result := domain.NewBacktestResult(job.ID, strategy.ID)
+		result.TotalTrades = 10
+		result.ProfitPct = 4
+		result.SharpeRatio = &sharpe
+		require.NoError(t, env.repos.Result.Create(ctx, result))
+		best = result
+	}
+
+	// Jobs outside the campaign are not counted
+	other := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, nil)
+	require.NoError(t, env.repos.BacktestJob.Create(ctx, other))
+
+	t.Run("Progress", func(t *testing.T) {
+		progress, err := repo.GetProgress(ctx, campaign.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, progress.TotalJobs)
+		assert.Equal(t, 1, progress.PendingJobs)
+		assert.Equal(t, 2, progress.CompletedJobs)
+		assert.InDelta(t, 200.0/3, progress.PercentComplete, 1e-9)
+
+		jobs, _, err := env.repos.BacktestJob.Query(ctx, &domain.BacktestJobQuery{CampaignID: &campaign.ID})
+		require.NoError(t, err)
+		assert.Len(t, jobs, 3)
+	})
+
+	t.Run("Results", func(t *testing.T) {
+		results, err := repo.GetResults(ctx, campaign.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, results.ResultCount)
+		assert.Equal(t, 20, results.TotalTrades)
+		require.NotNil(t, results.AvgSharpeRatio)
+		assert.InDelta(t, 2.0, *results.AvgSharpeRatio, 1e-9)
+
+		got, err := env.repos.Result.GetBestByCampaignID(ctx, campaign.ID)
+		require.NoError(t, err)
+		assert.Equal(t, best.ID, got.ID)
+	})
+
+	t.Run("ListAndUpdate", func(t *testing.T) {
+		campaign.Name = "RSI sweep v2"
+		require.NoError(t, repo.Update(ctx, campaign))
+
+		pattern := "v2"
+		campaigns, total, err := repo.List(ctx, domain.CampaignListQuery{NamePattern: &pattern})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, campaigns, 1)
+		assert.Equal(t, campaign.ID, campaigns[0].ID)
+	})
+
+	t.Run("DeleteDetachesJobs", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, campaign.ID))
+		assert.ErrorIs(t, repo.Delete(ctx, campaign.ID), domain.ErrNotFound)
+
+		job, err := env.repos.BacktestJob.GetByID(ctx, pending.ID)
+		require.NoError(t, err)
+		assert.Nil(t, job.CampaignID)
+	})
+}
+
+// TestStarRepository_Conformance tests the Postgres star repository and starred filters.
+func TestStarRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Star
+
+	strategy := createTestStrategy(t, "StarredStrategy", nil)
+	createTestStrategy(t, "OtherStrategy", nil)
+
+	require.NoError(t, repo.Star(ctx, "alice", domain.StarEntityStrategy, strategy.ID))
+	require.NoError(t, repo.Star(ctx, "alice", domain.StarEntityStrategy, strategy.ID), "starring is idempotent")
+
+	starred, err := repo.IsStarred(ctx, "alice", domain.StarEntityStrategy, strategy.ID)
+	require.NoError(t, err)
+	assert.True(t, starred)
+
+	ids, err := repo.ListStarredIDs(ctx, "alice", domain.StarEntityStrategy)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{strategy.ID}, ids)
+
+	owner := "alice"
+	results, total, err := env.repos.Strategy.Search(ctx, domain.StrategySearchQuery{StarredBy: &owner})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, results, 1)
+	assert.Equal(t, strategy.ID, results[0].Strategy.ID)
+
+	// Other owners' stars are not included
+	other := "bob"
+	_, total, err = env.repos.Strategy.Search(ctx, domain.StrategySearchQuery{StarredBy: &other})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	err = repo.Star(ctx, "alice", domain.StarEntityStrategy, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.Unstar(ctx, "alice", domain.StarEntityStrategy, strategy.ID))
+	starred, err = repo.IsStarred(ctx, "alice", domain.StarEntityStrategy, strategy.ID)
+	require.NoError(t, err)
+	assert.False(t, starred)
+}
+
+// TestScoutRepository_Metrics tests metrics validation on completion and the
+// aggregate over completed runs.
+func TestScoutRepository_Metrics(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Scout
+
+	complete := func(source string, metrics *domain.ScoutMetrics) *domain.ScoutRun {
+		run := domain.NewScoutRun(domain.ScoutTriggerTypeManual, "test", source, 10)
+		require.NoError(t, repo.CreateRun(ctx, run))
+		require.NoError(t, repo.CompleteRun(ctx, run.ID, metrics))
+		return run
+	}
+
+	// A single-source run without a breakdown
+	complete("stratninja", &domain.ScoutMetrics{
+		TotalFetched: 10, Validated: 8, ValidationFailed: 2, DuplicatesRemoved: 1, Submitted: 7,
+		ErrorCategories: map[string]int{"syntax_error": 2},
+	})
+	// A multi-source run
+	complete("multi", &domain.ScoutMetrics{
+		Found: 25, TotalFetched: 20, Parsed: 18, Validated: 15, ValidationFailed: 3, DuplicatesRemoved: 5, Submitted: 10,
+		Sources: map[string]*domain.ScoutSourceMetrics{
+			"stratninja": {TotalFetched: 12, Validated: 9, ValidationFailed: 1, DuplicatesRemoved: 4, Submitted: 5},
+			"github":     {TotalFetched: 8, Validated: 6, ValidationFailed: 2, DuplicatesRemoved: 1, Submitted: 5},
+		},
+		ErrorCategories: map[string]int{"syntax_error": 1, "fetch_timeout": 2},
+	})
+
+	// Submitting more than were validated breaks the pipeline order
+	invalid := domain.NewScoutRun(domain.ScoutTriggerTypeManual, "test", "stratninja", 10)
+	require.NoError(t, repo.CreateRun(ctx, invalid))
+	err := repo.CompleteRun(ctx, invalid.ID, &domain.ScoutMetrics{TotalFetched: 5, Validated: 3, Submitted: 4})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	got, err := repo.GetRunByID(ctx, invalid.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScoutRunStatusPending, got.Status)
+
+	summary, err := repo.AggregateMetrics(ctx, domain.ScoutMetricsQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Runs)
+	assert.Equal(t, 30, summary.Metrics.TotalFetched)
+	assert.Equal(t, 23, summary.Metrics.Validated)
+	assert.Equal(t, 17, summary.Metrics.Submitted)
+	assert.Equal(t, map[string]int{"syntax_error": 3, "fetch_timeout": 2}, summary.Metrics.ErrorCategories)
+	require.Len(t, summary.Metrics.Sources, 2)
+	assert.Equal(t, 22, summary.Metrics.Sources["stratninja"].TotalFetched)
+	assert.Equal(t, 12, summary.Metrics.Sources["stratninja"].Submitted)
+	assert.Equal(t, 8, summary.Metrics.Sources["github"].TotalFetched)
+	assert.InDelta(t, 23.0/30.0*100, summary.ValidationRate, 0.001)
+
+	github := "github"
+	summary, err = repo.AggregateMetrics(ctx, domain.ScoutMetricsQuery{Source: &github})
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Runs, "runs with the source in their breakdown match")
+
+	future := domain.TimeRange{Start: time.Now().Add(time.Hour), End: time.Now().Add(2 * time.Hour)}
+	summary, err = repo.AggregateMetrics(ctx, domain.ScoutMetricsQuery{TimeRange: &future})
+	require.NoError(t, err)
+	assert.Zero(t, summary.Runs)
+	assert.Empty(t, summary.Metrics.Sources)
+}
+
+// TestScoutRepository_CancelRun tests cancelling a scout run with a reason.
+func TestScoutRepository_CancelRun(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Scout
+
+	run := domain.NewScoutRun(domain.ScoutTriggerTypeManual, "test", "stratninja", 10)
+	require.NoError(t, repo.CreateRun(ctx, run))
+	require.NoError(t, repo.CancelRun(ctx, run.ID, domain.NewCancellation("", "ops")))
+
+	got, err := repo.GetRunByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScoutRunStatusCancelled, got.Status)
+	require.NotNil(t, got.CompletedAt)
+	assert.Equal(t, domain.Cancellation{CancelledBy: "ops"}, got.Cancellation())
+
+	// Cancelling again fills in the missing reason only
+	require.NoError(t, repo.CancelRun(ctx, run.ID, domain.NewCancellation("rate limited", "agent")))
+	got, err = repo.GetRunByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Cancellation{Reason: "rate limited", CancelledBy: "ops"}, got.Cancellation())
+
+	completed := domain.NewScoutRun(domain.ScoutTriggerTypeManual, "test", "stratninja", 10)
+	require.NoError(t, repo.CreateRun(ctx, completed))
+	require.NoError(t, repo.CompleteRun(ctx, completed.ID, nil))
+	assert.Error(t, repo.CancelRun(ctx, completed.ID, domain.Cancellation{}))
+
+	assert.ErrorIs(t, repo.CancelRun(ctx, uuid.New(), domain.Cancellation{}), domain.ErrNotFound)
+}
+
+func TestScoutRepository_CreateRunWithinLimits(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Scout
+	limits := domain.ScoutSourceLimits{MinInterval: time.Hour, MaxRunsPerDay: 2}
+
+	newRun := func(source string, at time.Time) *domain.ScoutRun {
+		run := domain.NewScoutRun(domain.ScoutTriggerTypeScheduled, "test", source, 10)
+		run.CreatedAt = at
+		return run
+	}
+
+	now := time.Now()
+	require.NoError(t, repo.CreateRunWithinLimits(ctx, newRun("stratninja", now.Add(-3*time.Hour)), limits))
+
+	// Too soon after the previous run
+	err := repo.CreateRunWithinLimits(ctx, newRun("stratninja", now.Add(-150*time.Minute)), limits)
+	var limitErr *domain.ScoutRateLimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.ErrorIs(t, err, domain.ErrScoutRateLimited)
+	assert.WithinDuration(t, now.Add(-2*time.Hour), limitErr.RetryAfter, time.Millisecond)
+
+	// Other sources are limited separately
+	require.NoError(t, repo.CreateRunWithinLimits(ctx, newRun("github", now.Add(-150*time.Minute)), limits))
+
+	require.NoError(t, repo.CreateRunWithinLimits(ctx, newRun("stratninja", now.Add(-time.Hour)), limits))
+
+	// The daily cap is reached; the oldest run leaves the window in 21 hours
+	err = repo.CreateRunWithinLimits(ctx, newRun("stratninja", now), limits)
+	require.ErrorAs(t, err, &limitErr)
+	assert.Contains(t, limitErr.Reason, "cap of 2 runs per day")
+	assert.WithinDuration(t, now.Add(21*time.Hour), limitErr.RetryAfter, time.Millisecond)
+
+	// Without limits runs are always created
+	require.NoError(t, repo.CreateRunWithinLimits(ctx, newRun("stratninja", now), domain.ScoutSourceLimits{}))
+
+	source := "stratninja"
+	runs, total, err := repo.ListRuns(ctx, domain.ScoutRunQuery{Source: &source, Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, runs, 3)
+}
+
+func TestScoutRepository_Discoveries(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Scout
+
+	run := domain.NewScoutRun(domain.ScoutTriggerTypeManual, "test", "stratninja", 10)
+	require.NoError(t, repo.CreateRun(ctx, run))
+	strategy := createTestStrategy(t, "Imported", nil)
+
+	// The agent reports what it fetched before submitting
+	valid := domain.NewScoutDiscovery(run.ID, "Valid", "stratninja", "https://example.com/valid", strategy.CodeHash, domain.ScoutValidationValid)
+	invalid := domain.NewScoutDiscovery(run.ID, "Invalid", "stratninja", "", "hash-invalid", domain.ScoutValidationInvalid)
+	invalid.ValidationErrors = []string{"syntax error"}
+	require.NoError(t, repo.RecordDiscoveries(ctx, []*domain.ScoutDiscovery{valid, invalid}))
+	assert.Equal(t, domain.ScoutOutcomeNotSubmitted, valid.Outcome)
+	assert.False(t, valid.Imported)
+
+	// Importing fills in the outcome of the reported discovery
+	imported := domain.NewScoutDiscovery(run.ID, "", "stratninja", "", strategy.CodeHash, domain.ScoutValidationValid)
+	imported.SetOutcome(domain.ScoutOutcomeImported, &strategy.ID, "")
+	require.NoError(t, repo.RecordDiscoveries(ctx, []*domain.ScoutDiscovery{imported}))
+	assert.Equal(t, valid.ID, imported.ID)
+	assert.Equal(t, "Valid", imported.Name)
+	assert.Equal(t, "https://example.com/valid", imported.SourceURL)
+	assert.True(t, imported.Imported)
+	require.NotNil(t, imported.StrategyID)
+	assert.Equal(t, strategy.ID, *imported.StrategyID)
+
+	// Reporting it again, or a later duplicate, keeps it imported
+	again := domain.NewScoutDiscovery(run.ID, "Valid", "stratninja", "", strategy.CodeHash, domain.ScoutValidationValid)
+	again.SetOutcome(domain.ScoutOutcomeDuplicate, &strategy.ID, "")
+	require.NoError(t, repo.RecordDiscoveries(ctx, []*domain.ScoutDiscovery{again}))
+	assert.Equal(t, domain.ScoutOutcomeImported, again.Outcome)
+
+	all, err := repo.ListDiscoveries(ctx, domain.ScoutDiscoveryQuery{RunID: run.ID})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, valid.ID, all[0].ID)
+	assert.Equal(t, []string{"syntax error"}, all[1].ValidationErrors)
+
+	outcome := domain.ScoutOutcomeImported
+	got, err := repo.ListDiscoveries(ctx, domain.ScoutDiscoveryQuery{RunID: run.ID, Outcome: &outcome})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, valid.ID, got[0].ID)
+
+	status := domain.ScoutValidationInvalid
+	got, err = repo.ListDiscoveries(ctx, domain.ScoutDiscoveryQuery{RunID: run.ID, ValidationStatus: &status})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, invalid.ID, got[0].ID)
+
+	// Deleting the strategy keeps the discovery
+	require.NoError(t, env.repos.Strategy.Delete(ctx, strategy.ID))
+	got, err = repo.ListDiscoveries(ctx, domain.ScoutDiscoveryQuery{RunID: run.ID, Outcome: &outcome})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Nil(t, got[0].StrategyID)
+
+	orphan := domain.NewScoutDiscovery(uuid.New(), "Orphan", "github", "", "hash-orphan", domain.ScoutValidationValid)
+	assert.ErrorIs(t, repo.RecordDiscoveries(ctx, []*domain.ScoutDiscovery{orphan}), domain.ErrNotFound)
+
+	none, err := repo.ListDiscoveries(ctx, domain.ScoutDiscoveryQuery{RunID: uuid.New()})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+// TestArtifactRepository_Conformance tests the Postgres artifact repository.
+func TestArtifactRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Artifact
+
+	run := domain.NewScoutRun(domain.ScoutTriggerTypeManual, "test", "stratninja", 10)
+	require.NoError(t, env.repos.Scout.CreateRun(ctx, run))
+
+	sourceURL := "https://example.com/strategy/1"
+	content := []byte("<html><body>class Sample(IStrategy): pass</body></html>")
+	artifact := domain.NewArtifact(domain.ArtifactOwnerScoutRun, run.ID, domain.ArtifactKindHTML, "strategy-1.html", "text/html", content)
+	artifact.SourceURL = &sourceURL
+	artifact.Metadata = map[string]interface{}{"status_code": float64(200)}
+	require.NoError(t, artifact.Validate())
+	require.NoError(t, repo.Create(ctx, artifact))
+
+	got, err := repo.GetByID(ctx, artifact.ID)
+	require.NoError(t, err)
+	assert.Equal(t, artifact.SHA256, got.SHA256)
+	assert.Equal(t, int64(len(content)), got.SizeBytes)
+	assert.Equal(t, sourceURL, *got.SourceURL)
+	assert.Equal(t, float64(200), got.Metadata["status_code"])
+	assert.Nil(t, got.Content, "metadata lookups do not load content")
+
+	withContent, err := repo.GetContent(ctx, artifact.ID)
+	require.NoError(t, err)
+	assert.Equal(t, content, withContent.Content)
+
+	list, err := repo.ListByOwner(ctx, domain.ArtifactOwnerScoutRun, run.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, artifact.ID, list[0].ID)
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	t.Run("IterationArtifacts", func(t *testing.T) {
+		strategy := createTestStrategy(t, "ArtifactStrategy", nil)
+		optRun := domain.NewOptimizationRun("artifact run", strategy.ID, domain.OptimizationConfig{
+			BacktestConfig: testBacktestConfig(),
+			MaxIterations:  5,
+		})
+		require.NoError(t, env.repos.Optimization.Create(ctx, optRun))
+
+		var iterationIDs []uuid.UUID
+		for n := 1; n <= 2; n++ {
+			job := domain.NewBacktestJob(strategy.ID, testBacktestConfig(), 0, &optRun.ID)
+			require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+			iteration := domain.NewOptimizationIteration(optRun.ID, n, strategy.ID, job.ID)
+			require.NoError(t, env.repos.Optimization.AddIteration(ctx, iteration))
+			iterationIDs = append(iterationIDs, iteration.ID)
+		}
+
+		prompt := domain.NewArtifact(domain.ArtifactOwnerIteration, iterationIDs[0], domain.ArtifactKindPrompt, "prompt.txt", "text/plain", []byte("Improve the RSI entry"))
+		require.NoError(t, repo.Create(ctx, prompt))
+		response := domain.NewArtifact(domain.ArtifactOwnerIteration, iterationIDs[1], domain.ArtifactKindResponse, "response.txt", "text/plain", []byte("class Improved(IStrategy): pass"))
+		require.NoError(t, repo.Create(ctx, response))
+
+		list, err := repo.ListByOwners(ctx, domain.ArtifactOwnerIteration, iterationIDs)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, prompt.ID, list[0].ID)
+		assert.Equal(t, domain.ArtifactKindResponse, list[1].Kind)
+		assert.Nil(t, list[0].Content)
+
+		// Deleting the run deletes its iterations and their artifacts
+		_, err = env.pool.Exec(ctx, `DELETE FROM optimization_runs WHERE id = $1`, optRun.ID)
+		require.NoError(t, err)
+		list, err = repo.ListByOwners(ctx, domain.ArtifactOwnerIteration, iterationIDs)
+		require.NoError(t, err)
+		assert.Empty(t, list)
+	})
+}
+
+// TestExportRepository_Conformance tests the export job lifecycle and the
+// lookups the exporter pages through.
+func TestExportRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Export
+
+	strategy := createTestStrategy(t, "ExportStrategy", nil)
+	var resultIDs []uuid.UUID
+	for i := 0; i < 3; i++ {
+		cfg := testBacktestConfig()
+		cfg.TimerangeEnd = fmt.Sprintf("2024-%02d-01", 3+i)
+		job := domain.NewBacktestJob(strategy.ID, cfg, 0, nil)
+		require.NoError(t, env.repos.BacktestJob.Create(ctx, job))
+		result := domain.NewBacktestResult(job.ID, strategy.ID)
+		require.NoError(t, env.repos.Result.Create(ctx, result))
+		resultIDs = append(resultIDs, result.ID)
+	}
+
+	t.Run("ListAfterID", func(t *testing.T) {
+		query := domain.BacktestResultQuery{StrategyID: &strategy.ID}
+		first, err := env.repos.Result.ListAfterID(ctx, query, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		rest, err := env.repos.Result.ListAfterID(ctx, query, &first[1].ID, 2)
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+
+		var seen []uuid.UUID
+		for _, r := range append(first, rest...) {
+			seen = append(seen, r.ID)
+		}
+		assert.ElementsMatch(t, resultIDs, seen)
+	})
+
+	t.Run("GetByIDs", func(t *testing.T) {
+		found, err := env.repos.Strategy.GetByIDs(ctx, []uuid.UUID{strategy.ID, uuid.New()})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, strategy.ID, found[0].ID)
+	})
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		minTrades := 5
+		job := domain.NewExportJob("alice", domain.ExportFormatJSONL, domain.ExportFilter{MinTrades: &minTrades})
+		require.NoError(t, repo.Create(ctx, job))
+
+		claimed, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, claimed)
+		assert.Equal(t, job.ID, claimed.ID)
+		assert.Equal(t, domain.ExportStatusRunning, claimed.Status)
+		assert.Equal(t, 5, *claimed.Filter.MinTrades)
+
+		none, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, none)
+
+		// Interrupted exports are picked up again
+		requeued, err := repo.RequeueRunning(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, requeued)
+		claimed, err = repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, claimed)
+
+		claimed.ResultCount = 3
+		archive := domain.NewArtifact(domain.ArtifactOwnerExport, claimed.ID, domain.ArtifactKindOther,
+			domain.ExportArchiveName(claimed.ID), "application/gzip", []byte("archive"))
+		require.NoError(t, repo.Complete(ctx, claimed, archive))
+
+		got, err := repo.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ExportStatusCompleted, got.Status)
+		assert.Equal(t, archive.ID, *got.ArtifactID)
+		assert.Equal(t, 3, got.ResultCount)
+		assert.Equal(t, int64(len("archive")), got.SizeBytes)
+
+		content, err := env.repos.Artifact.GetContent(ctx, archive.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("archive"), content.Content)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		job := domain.NewExportJob("alice", domain.ExportFormatJSONL, domain.ExportFilter{})
+		require.NoError(t, repo.Create(ctx, job))
+		require.NoError(t, repo.Fail(ctx, job.ID, "boom"))
+
+		got, err := repo.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ExportStatusFailed, got.Status)
+		assert.Equal(t, "boom", *got.Error)
+
+		assert.ErrorIs(t, repo.Fail(ctx, uuid.New(), "boom"), domain.ErrNotFound)
+	})
+}
+
+// TestHyperoptRepository_Conformance tests the hyperopt job lifecycle and
+// picking a strategy's best result.
+func TestHyperoptRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Hyperopt
+
+	strategy := createTestStrategy(t, "HyperoptStrategy", nil)
+	newJob := func() *domain.HyperoptJob {
+		job := domain.NewHyperoptJob(strategy.ID, "alice", domain.HyperoptConfig{
+			Backtest: testBacktestConfig(),
+			Spaces:   []domain.HyperoptSpace{domain.HyperoptSpaceBuy, domain.HyperoptSpaceStoploss},
+		})
+		require.NoError(t, repo.Create(ctx, job))
+		return job
+	}
+	complete := func(job *domain.HyperoptJob, loss float64) {
+		claimed, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, claimed)
+		require.Equal(t, job.ID, claimed.ID)
+		stoploss := -0.08
+		require.NoError(t, repo.Complete(ctx, job.ID, &domain.HyperoptResult{
+			BestEpoch:   12,
+			TotalEpochs: 100,
+			Loss:        loss,
+			Params:      domain.HyperoptParamSet{"stoploss": {"stoploss": stoploss}},
+			TotalTrades: 40,
+			ParamsPatch: &domain.StrategyParamsPatch{Stoploss: &stoploss},
+		}))
+	}
+
+	t.Run("GetBest", func(t *testing.T) {
+		_, err := repo.GetBest(ctx, strategy.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		worse, better := newJob(), newJob()
+		complete(worse, -1.5)
+		complete(better, -2.5)
+
+		best, err := repo.GetBest(ctx, strategy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, better.ID, best.ID)
+		assert.Equal(t, domain.JobStatusCompleted, best.Status)
+		require.NotNil(t, best.Result)
+		assert.InDelta(t, -2.5, best.Result.Loss, 1e-9)
+		require.NotNil(t, best.Result.ParamsPatch)
+		assert.InDelta(t, -0.08, *best.Result.ParamsPatch.Stoploss, 1e-9)
+		assert.Equal(t, []domain.HyperoptSpace{domain.HyperoptSpaceBuy, domain.HyperoptSpaceStoploss}, best.Config.Spaces)
+		assert.Equal(t, domain.DefaultHyperoptEpochs, best.Config.Epochs)
+
+		// Finished jobs can't be finished again
+		assert.ErrorIs(t, repo.Fail(ctx, worse.ID, "boom"), domain.ErrConflict)
+	})
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		job := newJob()
+
+		claimed, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, claimed)
+		assert.Equal(t, job.ID, claimed.ID)
+		assert.Equal(t, domain.JobStatusRunning, claimed.Status)
+		require.NoError(t, repo.SetContainer(ctx, job.ID, "container-1"))
+
+		none, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, none)
+
+		// Interrupted jobs are picked up again
+		requeued, err := repo.RequeueRunning(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, requeued)
+		got, err := repo.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusPending, got.Status)
+		assert.Nil(t, got.ContainerID)
+
+		_, err = repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		require.NoError(t, repo.Fail(ctx, job.ID, "hyperopt exited with code 1"))
+
+		got, err = repo.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusFailed, got.Status)
+		assert.Equal(t, "hyperopt exited with code 1", *got.Error)
+		assert.NotNil(t, got.CompletedAt)
+
+		_, err = repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		job := newJob()
+		_, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+
+		// The job is returned as it was, so the caller knows to stop it
+		prior, err := repo.Cancel(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusRunning, prior.Status)
+
+		got, err := repo.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobStatusCancelled, got.Status)
+
+		// A run finishing after the cancel doesn't overwrite it
+		assert.ErrorIs(t, repo.Complete(ctx, job.ID, &domain.HyperoptResult{}), domain.ErrConflict)
+		_, err = repo.Cancel(ctx, job.ID)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		_, err = repo.Cancel(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		all, err := repo.List(ctx, domain.HyperoptJobQuery{StrategyID: &strategy.ID, Limit: 50})
+		require.NoError(t, err)
+		assert.Len(t, all, 4)
+		for i := 1; i < len(all); i++ {
+			assert.False(t, all[i].CreatedAt.After(all[i-1].CreatedAt))
+		}
+
+		completed := domain.JobStatusCompleted
+		done, err := repo.List(ctx, domain.HyperoptJobQuery{Status: &completed, Limit: 50})
+		require.NoError(t, err)
+		assert.Len(t, done, 2)
+
+		other := uuid.New()
+		none, err := repo.List(ctx, domain.HyperoptJobQuery{StrategyID: &other, Limit: 50})
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+}
+
+// TestDeadLetterRepository_Conformance tests storing, listing and
+// requeueing dead letters.
+func TestDeadLetterRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.DeadLetter
+
+	newLetter := func(routingKey string, createdAt time.Time) *domain.DeadLetter {
+		letter := &domain.DeadLetter{
+			ID:         uuid.New(),
+			RoutingKey: routingKey,
+			Exchange:   "freqsearch.events",
+			Queue:      "go-backend-events",
+			Error:      "handler error: boom",
+			Body:       []byte(`{"run_id": "x"}`),
+			FailedAt:   createdAt,
+			CreatedAt:  createdAt,
+		}
+		require.NoError(t, repo.Create(ctx, letter))
+		return letter
+	}
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	older := newLetter("scout.completed", base)
+	newer := newLetter("scout.failed", base.Add(time.Minute))
+
+	// A message collected twice is stored once
+	dup := *older
+	dup.Error = "changed"
+	require.NoError(t, repo.Create(ctx, &dup))
+
+	got, err := repo.GetByID(ctx, older.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "handler error: boom", got.Error)
+	assert.Equal(t, older.Body, got.Body)
+	assert.True(t, older.FailedAt.Equal(got.FailedAt))
+	assert.Nil(t, got.RequeuedAt)
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	all, err := repo.List(ctx, domain.DeadLetterQuery{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, newer.ID, all[0].ID)
+
+	byKey, err := repo.List(ctx, domain.DeadLetterQuery{RoutingKey: "scout.completed", Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, byKey, 1)
+	assert.Equal(t, older.ID, byKey[0].ID)
+
+	requeued, err := repo.MarkRequeued(ctx, older.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requeued.RequeueCount)
+	require.NotNil(t, requeued.RequeuedAt)
+
+	_, err = repo.MarkRequeued(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Requeued dead letters are left out unless asked for
+	pending, err := repo.List(ctx, domain.DeadLetterQuery{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, newer.ID, pending[0].ID)
+
+	all, err = repo.List(ctx, domain.DeadLetterQuery{IncludeRequeued: true, Limit: 50})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+// TestMaintenanceRepository_Conformance tests reading and changing the
+// maintenance mode.
+func TestMaintenanceRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Maintenance
+
+	// Without a row maintenance mode is disabled
+	mode, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, mode.Enabled)
+	assert.Nil(t, mode.Since)
+
+	enabled, err := repo.Set(ctx, true, "Database upgrade", "ops")
+	require.NoError(t, err)
+	assert.True(t, enabled.Enabled)
+	assert.Equal(t, "Database upgrade", enabled.Message)
+	assert.Equal(t, "ops", enabled.ChangedBy)
+	require.NotNil(t, enabled.Since)
+
+	// Changing the message while enabled keeps when it was enabled
+	updated, err := repo.Set(ctx, true, "Database upgrade, back at 14:00 UTC", "alice")
+	require.NoError(t, err)
+	require.NotNil(t, updated.Since)
+	assert.True(t, enabled.Since.Equal(*updated.Since))
+
+	mode, err = repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Database upgrade, back at 14:00 UTC", mode.Message)
+	assert.Equal(t, "alice", mode.ChangedBy)
+
+	disabled, err := repo.Set(ctx, false, "", "ops")
+	require.NoError(t, err)
+	assert.False(t, disabled.Enabled)
+	assert.Nil(t, disabled.Since)
+}
+
+// TestRevalidationRepository_Conformance tests re-validation runs and the
+// compatibility matrix built from their outcomes.
+func TestRevalidationRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Revalidation
+
+	valid := createTestStrategy(t, "RevalidValid", nil)
+	invalid := createTestStrategy(t, "RevalidInvalid", nil)
+	deleted := uuid.New()
+
+	run := domain.NewRevalidationRun("freqtrade:2025.6", []uuid.UUID{valid.ID, invalid.ID, deleted}, "ops")
+	require.NoError(t, repo.Create(ctx, run))
+
+	claimed, err := repo.ClaimNext(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, run.ID, claimed.ID)
+	assert.Equal(t, domain.RevalidationStatusRunning, claimed.Status)
+	assert.Equal(t, run.StrategyIDs, claimed.StrategyIDs)
+
+	none, err := repo.ClaimNext(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	record := func(strategyID uuid.UUID, image string, ok bool, errs ...string) {
+		t.Helper()
+		require.NoError(t, repo.Record(ctx, run.ID, strategyID, &domain.StrategyImageValidation{
+			StrategyID:  strategyID,
+			Image:       image,
+			Valid:       ok,
+			Errors:      errs,
+			RunID:       &run.ID,
+			ValidatedAt: time.Now(),
+		}))
+	}
+	record(valid.ID, "freqtrade:2025.6", true)
+
+	// Interrupted runs are requeued with their progress reset
+	requeued, err := repo.RequeueRunning(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requeued)
+	claimed, err = repo.ClaimNext(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Zero(t, claimed.Validated)
+
+	record(valid.ID, "freqtrade:2025.6", true)
+	record(invalid.ID, "freqtrade:2025.6", false, "ImportError: talib.abstract")
+	require.NoError(t, repo.Record(ctx, run.ID, deleted, nil))
+	require.NoError(t, repo.Complete(ctx, run.ID))
+
+	got, err := repo.GetByID(ctx, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RevalidationStatusCompleted, got.Status)
+	assert.Equal(t, 3, got.Validated)
+	assert.Equal(t, 1, got.Valid)
+	assert.Equal(t, 1, got.Invalid)
+	assert.Equal(t, 1, got.Errors)
+	assert.NotNil(t, got.CompletedAt)
+
+	// Recording outside a running run fails
+	err = repo.Record(ctx, run.ID, valid.ID, nil)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// A second run validates the valid strategy on an earlier image
+	earlier := domain.NewRevalidationRun("freqtrade:2025.4", []uuid.UUID{valid.ID}, "ops")
+	require.NoError(t, repo.Create(ctx, earlier))
+	_, err = repo.ClaimNext(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repo.Record(ctx, earlier.ID, valid.ID, &domain.StrategyImageValidation{
+		StrategyID:  valid.ID,
+		Image:       earlier.Image,
+		Valid:       true,
+		RunID:       &earlier.ID,
+		ValidatedAt: time.Now(),
+	}))
+
+	images, rows, err := repo.GetCompatibility(ctx, run.StrategyIDs, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"freqtrade:2025.4", "freqtrade:2025.6"}, images)
+	// The deleted strategy has no row; rows are ordered by name
+	require.Len(t, rows, 2)
+	assert.Equal(t, invalid.ID, rows[0].StrategyID)
+	assert.Len(t, rows[0].Images, 1)
+	assert.False(t, rows[0].Images["freqtrade:2025.6"].Valid)
+	assert.Equal(t, []string{"ImportError: talib.abstract"}, rows[0].Images["freqtrade:2025.6"].Errors)
+	assert.Equal(t, valid.ID, rows[1].StrategyID)
+	assert.Len(t, rows[1].Images, 2)
+
+	images, rows, err = repo.GetCompatibility(ctx, run.StrategyIDs, []string{"freqtrade:2025.4"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"freqtrade:2025.4"}, images)
+	require.Len(t, rows, 2)
+	assert.Empty(t, rows[0].Images)
+	assert.Len(t, rows[1].Images, 1)
+
+	runs, err := repo.List(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, earlier.ID, runs[0].ID)
+
+	require.NoError(t, repo.Fail(ctx, run.ID, "stopped"))
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestIncidentRepository_Conformance(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	repo := env.repos.Incident
+
+	incident := domain.NewIncident("Backtests stuck in pending", domain.IncidentSeverityMajor, domain.IncidentSourceManual, "oncall")
+	incident.Notes = []domain.IncidentNote{*domain.NewIncidentNote(incident.ID, "oncall", "Looking into it")}
+	require.NoError(t, repo.Create(ctx, incident))
+
+	outage := domain.NewIncident("Dependency outage: docker", domain.IncidentSeverityCritical, domain.IncidentSourceWatchdog, "watchdog")
+	require.NoError(t, repo.Create(ctx, outage))
+
+	got, err := repo.GetByID(ctx, incident.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.IncidentStatusOpen, got.Status)
+	require.Len(t, got.Notes, 1)
+	assert.Equal(t, "Looking into it", got.Notes[0].Body)
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	severity := domain.IncidentSeverityCritical
+	got, err = repo.Update(ctx, incident.ID, domain.IncidentUpdate{Severity: &severity})
+	require.NoError(t, err)
+	assert.Equal(t, domain.IncidentSeverityCritical, got.Severity)
+	assert.Equal(t, "Backtests stuck in pending", got.Title)
+
+	require.NoError(t, repo.AddNote(ctx, domain.NewIncidentNote(incident.ID, "oncall", "Restarted the scheduler")))
+	err = repo.AddNote(ctx, domain.NewIncidentNote(uuid.New(), "oncall", "Lost"))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	open := domain.IncidentStatusOpen
+	listed, err := repo.List(ctx, domain.IncidentListQuery{Status: &open, Source: domain.IncidentSourceWatchdog, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, outage.ID, listed[0].ID)
+
+	closed, err := repo.Close(ctx, incident.ID, "oncall", domain.NewIncidentNote(incident.ID, "oncall", "Resolved"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.IncidentStatusClosed, closed.Status)
+	require.NotNil(t, closed.ClosedBy)
+	assert.Equal(t, "oncall", *closed.ClosedBy)
+	require.Len(t, closed.Notes, 3)
+	assert.Equal(t, "Resolved", closed.Notes[2].Body)
+
+	_, err = repo.Close(ctx, incident.ID, "oncall", nil)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	n, err := repo.CloseBySource(ctx, domain.IncidentSourceWatchdog, "watchdog", "Dependencies recovered")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	listed, err = repo.List(ctx, domain.IncidentListQuery{Status: &open, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+}
+
+func TestMigrator_Conformance(t *testing.T) {
+	ctx := context.Background()
+	migrator, err := db.NewMigrator(env.pool, migrations.FS, zap.NewNop())
+	require.NoError(t, err)
+
+	// The suite migrated the database at startup
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, applied)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	for _, status := range statuses {
+		assert.NotNil(t, status.AppliedAt, "migration %03d_%s", status.Version, status.Name)
+	}
+
+	// Roll back the latest migration and apply it again
+	latest := statuses[len(statuses)-1]
+	previous := statuses[len(statuses)-2].Version
+	rolledBack, err := migrator.Down(ctx, previous)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rolledBack)
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, statuses[len(statuses)-1].AppliedAt)
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+
+	// Baselining what is already applied changes nothing
+	require.NoError(t, migrator.Baseline(ctx, latest.Version))
+	assert.Error(t, migrator.Baseline(ctx, latest.Version+1))
+}
@@ -15,6 +15,41 @@ from ...schemas.diagnosis import DiagnosisStatus
 
 logger = structlog.get_logger(__name__)
 
+# Feedback for the Engineer by backtest failure category (set by the Go backend).
+# Each entry is (suggestion_type, what to do, root cause).
+FAILURE_FEEDBACK: dict[str, tuple[str, str, str]] = {
+    "strategy_import_error": (
+        "code_fix",
+        "Fix the code so the strategy loads",
+        "Strategy code fails to import: syntax, import or class definition errors",
+    ),
+    "oom": (
+        "code_fix",
+        "Reduce the strategy's memory use, e.g. fewer informative pairs or shorter rolling windows",
+        "Backtest ran out of memory",
+    ),
+    "timeout": (
+        "performance",
+        "Make the indicators cheaper to compute so the backtest finishes in time",
+        "Backtest exceeded its time limit",
+    ),
+    "data_missing": (
+        "infrastructure",
+        "Keep the strategy logic; avoid informative pairs or timeframes outside the configured ones",
+        "Candle data for the configured pairs, timeframe or timerange is missing",
+    ),
+    "docker_error": (
+        "infrastructure",
+        "Keep the strategy logic unchanged; the backtest container failed",
+        "Backtest infrastructure error, not caused by the strategy",
+    ),
+    "parser_error": (
+        "infrastructure",
+        "Keep the strategy logic unchanged; the backtest output could not be read",
+        "Backtest output could not be parsed",
+    ),
+}
+
 
 async def initialize_run_node(
     state: OrchestratorState,
@@ -446,6 +481,7 @@ async def wait_for_result_node(
                     break
                 elif job_status == "JOB_STATUS_FAILED":
                     error_msg = job_data["job"].get("error_message", "Unknown error")
+                    failure_category = job_data["job"].get("failure_category", "unknown")
                     logs = job_data["job"].get("logs", "")
                     logger.warning("Backtest failed - will provide feedback to Engineer", job_id=job_id, error=error_msg)
                     # Return failed result for Analyst to review and provide feedback
@@ -456,6 +492,7 @@ async def wait_for_result_node(
                             "strategy_id": state["current_strategy_id"],
                             "status": "FAILED",
                             "error_message": error_msg,
+                            "failure_category": failure_category,
                             "logs": logs,
                             "total_trades": 0,
                             "profit_pct": 0.0,
@@ -582,21 +619,33 @@ async def invoke_analyst_node(
     # Handle failed backtests directly - no need to call Analyst
     if result.get("status") == "FAILED":
         error_msg = result.get("error_message", "Unknown error")
+        failure_category = result.get("failure_category", "unknown")
         logs = result.get("logs", "")
         logger.warning(
             "Backtest failed - automatically requesting code fix",
             job_id=result.get("job_id"),
             iteration=state["current_iteration"],
             error=error_msg,
+            failure_category=failure_category,
+        )
+        # Tailor the feedback to why the backtest failed; unknown failures
+        # are usually runtime errors in the strategy code
+        suggestion_type, description, root_cause = FAILURE_FEEDBACK.get(
+            failure_category,
+            (
+                "code_fix",
+                f"Fix code error: {error_msg}",
+                "Strategy code contains errors that prevent execution",
+            ),
         )
-        # Extract relevant error info from logs for Engineer
         feedback = {
-            "suggestion_type": "code_fix",
-            "suggestion_description": f"Fix code error: {error_msg}",
+            "suggestion_type": suggestion_type,
+            "suggestion_description": description,
             "error_message": error_msg,
+            "failure_category": failure_category,
             "logs": logs[-2000:] if logs else "",  # Last 2000 chars of logs
             "issues": [error_msg],
-            "root_causes": ["Strategy code contains errors that prevent execution"],
+            "root_causes": [root_cause],
         }
         return {
             "analyst_decision": DiagnosisStatus.NEEDS_MODIFICATION.value,
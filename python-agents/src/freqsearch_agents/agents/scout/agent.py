@@ -61,6 +61,7 @@ async def run_scout(
     source: str = "stratninja",
     limit: int = 50,
     run_id: str | None = None,
+    credential: str | None = None,
 ) -> dict[str, Any]:
     """Run the Scout Agent to discover strategies.
 
@@ -68,6 +69,8 @@ async def run_scout(
         source: Data source to use ("stratninja", "github", etc.)
         limit: Maximum number of strategies to fetch
         run_id: Optional run ID for tracking and progress reporting
+        credential: Optional source credential, e.g. an API token, for
+            sources that require one
 
     Returns:
         Final state with discovery results
@@ -108,7 +111,7 @@ async def run_scout(
         }
 
         # Add configuration with run_id
-        config = {"configurable": {"limit": limit, "run_id": run_id}}
+        config = {"configurable": {"limit": limit, "run_id": run_id, "credential": credential}}
 
         # Run the agent
         final_state = await agent.ainvoke(initial_state, config=config)
@@ -388,6 +388,7 @@ async def submit_strategies_node(
         try:
             # Prepare event payload
             event_data = {
+                "run_id": run_id,
                 "name": strategy.get("name", ""),
                 "source_type": strategy.get("source", "unknown"),
                 "source_url": strategy.get("source_url", ""),
@@ -28,6 +28,10 @@ class Events:
     STRATEGY_APPROVED = "strategy.approved"
     STRATEGY_EVOLVE = "strategy.evolve"
     STRATEGY_ARCHIVED = "strategy.archived"
+    STRATEGY_NEEDS_DESCRIPTION = "strategy.needs_description"
+    STRATEGY_CREATED = "strategy.created"
+    STRATEGY_UPDATED = "strategy.updated"
+    STRATEGY_DELETED = "strategy.deleted"
 
     # Backtest lifecycle
     BACKTEST_SUBMITTED = "backtest.submitted"
@@ -46,6 +50,9 @@ class Events:
     # Agent heartbeat
     AGENT_HEARTBEAT = "agent.heartbeat"
 
+    # System events
+    SYSTEM_SLA_BREACH = "system.sla_breach"
+
     # Optimization events
     OPTIMIZATION_STARTED = "optimization.started"
     OPTIMIZATION_ITERATION_STARTED = "optimization.iteration.started"
@@ -54,6 +61,8 @@ class Events:
     OPTIMIZATION_COMPLETED = "optimization.completed"
     OPTIMIZATION_FAILED = "optimization.failed"
     OPTIMIZATION_STATUS_CHANGED = "optimization.status_changed"
+    OPTIMIZATION_ITERATION_RETRY = "optimization.iteration_retry"
+    OPTIMIZATION_PROGRESS = "optimization.progress"
 
     # Task events
     TASK_CREATED = "task.created"
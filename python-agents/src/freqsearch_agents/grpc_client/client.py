@@ -493,6 +493,9 @@ class FreqSearchClient:
                 - errors: List[str] - validation errors
                 - warnings: List[str] - non-fatal warnings
                 - class_name: str - detected strategy class name
+                - unresolved_imports: List[dict] - imports missing from the
+                  backtest image (module, names, line, optional, fix); their
+                  fixes are also listed in errors
 
         Example:
             result = await client.validate_strategy(strategy_code)
@@ -966,6 +969,33 @@ class FreqSearchClient:
             logger.error("Failed to list optimization runs", error=str(e))
             raise _map_grpc_error(e)
 
+    # ===== Scout =====
+
+    async def get_scout_credential(self, secret_id: str) -> str:
+        """
+        Decrypt the source credential a scout.trigger event references.
+
+        Args:
+            secret_id: The event's credential_secret_id
+
+        Returns:
+            The credential, e.g. an API token. Never log it.
+
+        Raises:
+            NotFoundError: Secret not found
+        """
+        self._ensure_connected()
+
+        request = freqsearch_pb2.GetScoutCredentialRequest(secret_id=secret_id)
+
+        try:
+            response = await self._stub.GetScoutCredential(request, timeout=self.timeout)
+            logger.debug("Scout credential retrieved", secret_id=secret_id)
+            return response.credential
+        except grpc.RpcError as e:
+            logger.error("Failed to get scout credential", secret_id=secret_id, error=str(e))
+            raise _map_grpc_error(e)
+
     # ===== Health =====
 
     async def health_check(self) -> Dict[str, Any]:
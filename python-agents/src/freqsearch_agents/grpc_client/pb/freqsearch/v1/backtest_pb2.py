@@ -26,7 +26,7 @@ from google.protobuf import timestamp_pb2 as google_dot_protobuf_dot_timestamp__
 from . import common_pb2 as freqsearch_dot_v1_dot_common__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1c\x66reqsearch/v1/backtest.proto\x12\rfreqsearch.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1a\x66reqsearch/v1/common.proto\"\xbb\x01\n\x0e\x42\x61\x63ktestConfig\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\r\n\x05pairs\x18\x02 \x03(\t\x12\x11\n\ttimeframe\x18\x03 \x01(\t\x12\x17\n\x0ftimerange_start\x18\x04 \x01(\t\x12\x15\n\rtimerange_end\x18\x05 \x01(\t\x12\x16\n\x0e\x64ry_run_wallet\x18\x06 \x01(\x01\x12\x17\n\x0fmax_open_trades\x18\x07 \x01(\x05\x12\x14\n\x0cstake_amount\x18\x08 \x01(\t\"\xbf\x03\n\x0b\x42\x61\x63ktestJob\x12\n\n\x02id\x18\x01 \x01(\t\x12\x13\n\x0bstrategy_id\x18\x02 \x01(\t\x12 \n\x13optimization_run_id\x18\x03 \x01(\tH\x00\x88\x01\x01\x12-\n\x06\x63onfig\x18\x04 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestConfig\x12(\n\x06status\x18\x05 \x01(\x0e\x32\x18.freqsearch.v1.JobStatus\x12\x19\n\x0c\x63ontainer_id\x18\x06 \x01(\tH\x01\x88\x01\x01\x12\x1a\n\rerror_message\x18\x07 \x01(\tH\x02\x88\x01\x01\x12\x10\n\x08priority\x18\x08 \x01(\x05\x12.\n\ncreated_at\x18\t \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nstarted_at\x18\n \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x30\n\x0c\x63ompleted_at\x18\x0b \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x16\n\x14_optimization_run_idB\x0f\n\r_container_idB\x10\n\x0e_error_message\"\xdb\x04\n\x0e\x42\x61\x63ktestResult\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0e\n\x06job_id\x18\x02 \x01(\t\x12\x13\n\x0bstrategy_id\x18\x03 \x01(\t\x12\x14\n\x0ctotal_trades\x18\x04 \x01(\x05\x12\x16\n\x0ewinning_trades\x18\x05 \x01(\x05\x12\x15\n\rlosing_trades\x18\x06 \x01(\x05\x12\x10\n\x08win_rate\x18\x07 \x01(\x01\x12\x14\n\x0cprofit_total\x18\x08 \x01(\x01\x12\x12\n\nprofit_pct\x18\t \x01(\x01\x12\x15\n\rprofit_factor\x18\n \x01(\x01\x12\x14\n\x0cmax_drawdown\x18\x0b \x01(\x01\x12\x18\n\x10max_drawdown_pct\x18\x0c \x01(\x01\x12\x14\n\x0csharpe_ratio\x18\r \x01(\x01\x12\x15\n\rsortino_ratio\x18\x0e \x01(\x01\x12\x14\n\x0c\x63\x61lmar_ratio\x18\x0f \x01(\x01\x12\"\n\x1a\x61vg_trade_duration_minutes\x18\x10 \x01(\x01\x12\x1c\n\x14\x61vg_profit_per_trade\x18\x11 \x01(\x01\x12\x16\n\x0e\x62\x65st_trade_pct\x18\x12 \x01(\x01\x12\x17\n\x0fworst_trade_pct\x18\x13 \x01(\x01\x12/\n\x0cpair_results\x18\x14 \x03(\x0b\x32\x19.freqsearch.v1.PairResult\x12\x0f\n\x07raw_log\x18\x15 \x01(\t\x12\x18\n\x0btrades_json\x18\x16 \x01(\tH\x00\x88\x01\x01\x12.\n\ncreated_at\x18\x17 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x0e\n\x0c_trades_json\"n\n\nPairResult\x12\x0c\n\x04pair\x18\x01 \x01(\t\x12\x0e\n\x06trades\x18\x02 \x01(\x05\x12\x12\n\nprofit_pct\x18\x03 \x01(\x01\x12\x10\n\x08win_rate\x18\x04 \x01(\x01\x12\x1c\n\x14\x61vg_duration_minutes\x18\x05 \x01(\x01\"\xa7\x01\n\x15SubmitBacktestRequest\x12\x13\n\x0bstrategy_id\x18\x01 \x01(\t\x12-\n\x06\x63onfig\x18\x02 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestConfig\x12 \n\x13optimization_run_id\x18\x03 \x01(\tH\x00\x88\x01\x01\x12\x10\n\x08priority\x18\x04 \x01(\x05\x42\x16\n\x14_optimization_run_id\"A\n\x16SubmitBacktestResponse\x12\'\n\x03job\x18\x01 \x01(\x0b\x32\x1a.freqsearch.v1.BacktestJob\"U\n\x1aSubmitBatchBacktestRequest\x12\x37\n\tbacktests\x18\x01 \x03(\x0b\x32$.freqsearch.v1.SubmitBacktestRequest\"G\n\x1bSubmitBatchBacktestResponse\x12(\n\x04jobs\x18\x01 \x03(\x0b\x32\x1a.freqsearch.v1.BacktestJob\"\'\n\x15GetBacktestJobRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\t\"\x80\x01\n\x16GetBacktestJobResponse\x12\'\n\x03job\x18\x01 \x01(\x0b\x32\x1a.freqsearch.v1.BacktestJob\x12\x32\n\x06result\x18\x02 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResultH\x00\x88\x01\x01\x42\t\n\x07_result\"*\n\x18GetBacktestResultRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\t\"J\n\x19GetBacktestResultResponse\x12-\n\x06result\x18\x01 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResult\"\xbe\x03\n\x1bQueryBacktestResultsRequest\x12\x18\n\x0bstrategy_id\x18\x01 \x01(\tH\x00\x88\x01\x01\x12 \n\x13optimization_run_id\x18\x02 \x01(\tH\x01\x88\x01\x01\x12\x17\n\nmin_sharpe\x18\x03 \x01(\x01H\x02\x88\x01\x01\x12\x1b\n\x0emin_profit_pct\x18\x04 \x01(\x01H\x03\x88\x01\x01\x12\x1d\n\x10max_drawdown_pct\x18\x05 \x01(\x01H\x04\x88\x01\x01\x12\x17\n\nmin_trades\x18\x06 \x01(\x05H\x05\x88\x01\x01\x12,\n\ntime_range\x18\x07 \x01(\x0b\x32\x18.freqsearch.v1.TimeRange\x12\x34\n\npagination\x18\x08 \x01(\x0b\x32 .freqsearch.v1.PaginationRequest\x12\x10\n\x08order_by\x18\t \x01(\t\x12\x11\n\tascending\x18\n \x01(\x08\x42\x0e\n\x0c_strategy_idB\x16\n\x14_optimization_run_idB\r\n\x0b_min_sharpeB\x11\n\x0f_min_profit_pctB\x13\n\x11_max_drawdown_pctB\r\n\x0b_min_trades\"\x8c\x01\n\x1cQueryBacktestResultsResponse\x12\x35\n\x07results\x18\x01 \x03(\x0b\x32$.freqsearch.v1.BacktestResultSummary\x12\x35\n\npagination\x18\x02 \x01(\x0b\x32!.freqsearch.v1.PaginationResponse\"\xfb\x01\n\x15\x42\x61\x63ktestResultSummary\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0e\n\x06job_id\x18\x02 \x01(\t\x12\x13\n\x0bstrategy_id\x18\x03 \x01(\t\x12\x15\n\rstrategy_name\x18\x04 \x01(\t\x12\x12\n\nprofit_pct\x18\x05 \x01(\x01\x12\x14\n\x0csharpe_ratio\x18\x06 \x01(\x01\x12\x18\n\x10max_drawdown_pct\x18\x07 \x01(\x01\x12\x14\n\x0ctotal_trades\x18\x08 \x01(\x05\x12\x10\n\x08win_rate\x18\t \x01(\x01\x12.\n\ncreated_at\x18\n \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"\'\n\x15\x43\x61ncelBacktestRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\t\":\n\x16\x43\x61ncelBacktestResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\"\x16\n\x14GetQueueStatsRequest\"\x8a\x01\n\x15GetQueueStatsResponse\x12\x14\n\x0cpending_jobs\x18\x01 \x01(\x05\x12\x14\n\x0crunning_jobs\x18\x02 \x01(\x05\x12\x17\n\x0f\x63ompleted_today\x18\x03 \x01(\x05\x12\x14\n\x0c\x66\x61iled_today\x18\x04 \x01(\x05\x12\x16\n\x0emax_concurrent\x18\x05 \x01(\x05\x42MZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1b\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1c\x66reqsearch/v1/backtest.proto\x12\rfreqsearch.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1a\x66reqsearch/v1/common.proto\"\xbb\x01\n\x0e\x42\x61\x63ktestConfig\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\r\n\x05pairs\x18\x02 \x03(\t\x12\x11\n\ttimeframe\x18\x03 \x01(\t\x12\x17\n\x0ftimerange_start\x18\x04 \x01(\t\x12\x15\n\rtimerange_end\x18\x05 \x01(\t\x12\x16\n\x0e\x64ry_run_wallet\x18\x06 \x01(\x01\x12\x17\n\x0fmax_open_trades\x18\x07 \x01(\x05\x12\x14\n\x0cstake_amount\x18\x08 \x01(\t\"\xff\x05\n\x0b\x42\x61\x63ktestJob\x12\n\n\x02id\x18\x01 \x01(\t\x12\x13\n\x0bstrategy_id\x18\x02 \x01(\t\x12 \n\x13optimization_run_id\x18\x03 \x01(\tH\x00\x88\x01\x01\x12-\n\x06\x63onfig\x18\x04 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestConfig\x12(\n\x06status\x18\x05 \x01(\x0e\x32\x18.freqsearch.v1.JobStatus\x12\x19\n\x0c\x63ontainer_id\x18\x06 \x01(\tH\x01\x88\x01\x01\x12\x1a\n\rerror_message\x18\x07 \x01(\tH\x02\x88\x01\x01\x12\x10\n\x08priority\x18\x08 \x01(\x05\x12.\n\ncreated_at\x18\t \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nstarted_at\x18\n \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x30\n\x0c\x63ompleted_at\x18\x0b \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x19\n\x0c\x65xternal_ref\x18\x0c \x01(\tH\x03\x88\x01\x01\x12\x18\n\x0b\x63\x61mpaign_id\x18\r \x01(\tH\x04\x88\x01\x01\x12\x1d\n\x10\x66\x61ilure_category\x18\x0e \x01(\tH\x05\x88\x01\x01\x12\x1d\n\x10resubmitted_from\x18\x0f \x01(\tH\x06\x88\x01\x01\x12&\n\x05hints\x18\x10 \x01(\x0b\x32\x17.freqsearch.v1.JobHints\x12\x1a\n\rcancel_reason\x18\x11 \x01(\tH\x07\x88\x01\x01\x12\x19\n\x0c\x63\x61ncelled_by\x18\x12 \x01(\tH\x08\x88\x01\x01\x42\x16\n\x14_optimization_run_idB\x0f\n\r_container_idB\x10\n\x0e_error_messageB\x0f\n\r_external_refB\x0e\n\x0c_campaign_idB\x13\n\x11_failure_categoryB\x13\n\x11_resubmitted_fromB\x10\n\x0e_cancel_reasonB\x0f\n\r_cancelled_by\"=\n\x08JobHints\x12\x1a\n\x12prefer_cached_data\x18\x01 \x03(\t\x12\x15\n\ranti_affinity\x18\x02 \x03(\t\"\xb4\t\n\x0e\x42\x61\x63ktestResult\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0e\n\x06job_id\x18\x02 \x01(\t\x12\x13\n\x0bstrategy_id\x18\x03 \x01(\t\x12\x14\n\x0ctotal_trades\x18\x04 \x01(\x05\x12\x16\n\x0ewinning_trades\x18\x05 \x01(\x05\x12\x15\n\rlosing_trades\x18\x06 \x01(\x05\x12\x10\n\x08win_rate\x18\x07 \x01(\x01\x12\x14\n\x0cprofit_total\x18\x08 \x01(\x01\x12\x12\n\nprofit_pct\x18\t \x01(\x01\x12\x15\n\rprofit_factor\x18\n \x01(\x01\x12\x14\n\x0cmax_drawdown\x18\x0b \x01(\x01\x12\x18\n\x10max_drawdown_pct\x18\x0c \x01(\x01\x12\x14\n\x0csharpe_ratio\x18\r \x01(\x01\x12\x15\n\rsortino_ratio\x18\x0e \x01(\x01\x12\x14\n\x0c\x63\x61lmar_ratio\x18\x0f \x01(\x01\x12\"\n\x1a\x61vg_trade_duration_minutes\x18\x10 \x01(\x01\x12\x1c\n\x14\x61vg_profit_per_trade\x18\x11 \x01(\x01\x12\x16\n\x0e\x62\x65st_trade_pct\x18\x12 \x01(\x01\x12\x17\n\x0fworst_trade_pct\x18\x13 \x01(\x01\x12/\n\x0cpair_results\x18\x14 \x03(\x0b\x32\x19.freqsearch.v1.PairResult\x12\x0f\n\x07raw_log\x18\x15 \x01(\t\x12\x18\n\x0btrades_json\x18\x16 \x01(\tH\x00\x88\x01\x01\x12.\n\ncreated_at\x18\x17 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x1a\n\rsuperseded_by\x18\x18 \x01(\tH\x01\x88\x01\x01\x12\x1b\n\x0estake_currency\x18\x19 \x01(\tH\x02\x88\x01\x01\x12\x1f\n\x12reference_currency\x18\x1a \x01(\tH\x03\x88\x01\x01\x12\x1b\n\x0ereference_rate\x18\x1b \x01(\x01H\x04\x88\x01\x01\x12$\n\x17profit_total_normalized\x18\x1c \x01(\x01H\x05\x88\x01\x01\x12\x38\n\x0b\x65nvironment\x18\x1d \x01(\x0b\x32#.freqsearch.v1.ExecutionEnvironment\x12\x35\n\x0c\x65xit_reasons\x18\x1e \x03(\x0b\x32\x1f.freqsearch.v1.TradeReasonStats\x12\x33\n\nentry_tags\x18\x1f \x03(\x0b\x32\x1f.freqsearch.v1.TradeReasonStats\x12\x1e\n\x11stoploss_exit_pct\x18  \x01(\x01H\x06\x88\x01\x01\x12#\n\x16trailing_stop_exit_pct\x18! \x01(\x01H\x07\x88\x01\x01\x12\x33\n\x0bpercentiles\x18\" \x01(\x0b\x32\x1e.freqsearch.v1.PercentileRanksB\x0e\n\x0c_trades_jsonB\x10\n\x0e_superseded_byB\x11\n\x0f_stake_currencyB\x15\n\x13_reference_currencyB\x11\n\x0f_reference_rateB\x1a\n\x18_profit_total_normalizedB\x14\n\x12_stoploss_exit_pctB\x19\n\x17_trailing_stop_exit_pct\"\xde\x01\n\x0fPercentileRanks\x12\x14\n\x0c\x63ohort_pairs\x18\x01 \x03(\t\x12\x1e\n\x16\x63ohort_timerange_start\x18\x02 \x01(\t\x12\x1c\n\x14\x63ohort_timerange_end\x18\x03 \x01(\t\x12\x13\n\x0b\x63ohort_size\x18\x04 \x01(\x05\x12\x13\n\x06sharpe\x18\x05 \x01(\x01H\x00\x88\x01\x01\x12\x13\n\x06profit\x18\x06 \x01(\x01H\x01\x88\x01\x01\x12\x15\n\x08\x64rawdown\x18\x07 \x01(\x01H\x02\x88\x01\x01\x42\t\n\x07_sharpeB\t\n\x07_profitB\x0b\n\t_drawdown\"J\n\x10TradeReasonStats\x12\x0e\n\x06reason\x18\x01 \x01(\t\x12\x0e\n\x06trades\x18\x02 \x01(\x05\x12\x16\n\x0e\x61vg_profit_pct\x18\x03 \x01(\x01\"\xf2\x01\n\x14\x45xecutionEnvironment\x12\x19\n\x11\x66reqtrade_version\x18\x01 \x01(\t\x12\x16\n\x0epython_version\x18\x02 \x01(\t\x12\r\n\x05image\x18\x03 \x01(\t\x12\x14\n\x0cimage_digest\x18\x04 \x01(\t\x12\x0c\n\x04host\x18\x05 \x01(\t\x12\x43\n\x08packages\x18\x06 \x03(\x0b\x32\x31.freqsearch.v1.ExecutionEnvironment.PackagesEntry\x1a/\n\rPackagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"n\n\nPairResult\x12\x0c\n\x04pair\x18\x01 \x01(\t\x12\x0e\n\x06trades\x18\x02 \x01(\x05\x12\x12\n\nprofit_pct\x18\x03 \x01(\x01\x12\x10\n\x08win_rate\x18\x04 \x01(\x01\x12\x1c\n\x14\x61vg_duration_minutes\x18\x05 \x01(\x01\"\xee\x02\n\x15SubmitBacktestRequest\x12\x13\n\x0bstrategy_id\x18\x01 \x01(\t\x12-\n\x06\x63onfig\x18\x02 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestConfig\x12 \n\x13optimization_run_id\x18\x03 \x01(\tH\x00\x88\x01\x01\x12\x10\n\x08priority\x18\x04 \x01(\x05\x12\x19\n\x0c\x65xternal_ref\x18\x05 \x01(\tH\x01\x88\x01\x01\x12\x1d\n\x15skip_validation_check\x18\x06 \x01(\x08\x12\x18\n\x0b\x63\x61mpaign_id\x18\x07 \x01(\tH\x02\x88\x01\x01\x12\x0f\n\x07\x64ry_run\x18\x08 \x01(\x08\x12&\n\x05hints\x18\t \x01(\x0b\x32\x17.freqsearch.v1.JobHints\x12\x17\n\x0f\x61llow_duplicate\x18\n \x01(\x08\x42\x16\n\x14_optimization_run_idB\x0f\n\r_external_refB\x0e\n\x0c_campaign_id\"\x86\x01\n\x16SubmitBacktestResponse\x12\'\n\x03job\x18\x01 \x01(\x0b\x32\x1a.freqsearch.v1.BacktestJob\x12\x10\n\x08warnings\x18\x02 \x03(\t\x12\x31\n\x07preview\x18\x03 \x01(\x0b\x32 .freqsearch.v1.SubmissionPreview\"\xad\x03\n\x11SubmissionPreview\x12\x16\n\x0equeue_position\x18\x01 \x01(\x05\x12\x14\n\x0crunning_jobs\x18\x02 \x01(\x05\x12\x0f\n\x07workers\x18\x03 \x01(\x05\x12!\n\x14\x65stimated_runtime_ms\x18\x04 \x01(\x03H\x00\x88\x01\x01\x12\x1e\n\x11\x65stimated_wait_ms\x18\x05 \x01(\x03H\x01\x88\x01\x01\x12$\n\x17\x65stimated_completion_ms\x18\x06 \x01(\x03H\x02\x88\x01\x01\x12(\n\x1b\x65stimated_completion_p90_ms\x18\x07 \x01(\x03H\x03\x88\x01\x01\x12\x11\n\tnew_pairs\x18\x08 \x03(\t\x12\x16\n\x0enew_timeframes\x18\t \x03(\t\x12\x30\n\x0enew_timeranges\x18\n \x03(\x0b\x32\x18.freqsearch.v1.DateRangeB\x17\n\x15_estimated_runtime_msB\x14\n\x12_estimated_wait_msB\x1a\n\x18_estimated_completion_msB\x1e\n\x1c_estimated_completion_p90_ms\"5\n\tDateRange\x12\r\n\x05start\x18\x01 \x01(\t\x12\x0b\n\x03\x65nd\x18\x02 \x01(\t\x12\x0c\n\x04\x64\x61ys\x18\x03 \x01(\x05\"f\n\x1aSubmitBatchBacktestRequest\x12\x37\n\tbacktests\x18\x01 \x03(\x0b\x32$.freqsearch.v1.SubmitBacktestRequest\x12\x0f\n\x07partial\x18\x02 \x01(\x08\"\x88\x01\n\x1bSubmitBatchBacktestResponse\x12(\n\x04jobs\x18\x01 \x03(\x0b\x32\x1a.freqsearch.v1.BacktestJob\x12\x10\n\x08warnings\x18\x02 \x03(\t\x12-\n\x05items\x18\x03 \x03(\x0b\x32\x1e.freqsearch.v1.BatchItemResult\"r\n\x0f\x42\x61tchItemResult\x12\r\n\x05index\x18\x01 \x01(\x05\x12\x13\n\x06job_id\x18\x02 \x01(\tH\x00\x88\x01\x01\x12\x12\n\x05\x65rror\x18\x03 \x01(\tH\x01\x88\x01\x01\x12\x12\n\nerror_code\x18\x04 \x01(\tB\t\n\x07_job_idB\x08\n\x06_error\"=\n\x15GetBacktestJobRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\t\x12\x14\n\x0c\x65xternal_ref\x18\x02 \x01(\t\"\x80\x01\n\x16GetBacktestJobResponse\x12\'\n\x03job\x18\x01 \x01(\x0b\x32\x1a.freqsearch.v1.BacktestJob\x12\x32\n\x06result\x18\x02 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResultH\x00\x88\x01\x01\x42\t\n\x07_result\")\n\x17WatchBacktestJobRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\t\"\x91\x01\n\x18WatchBacktestJobResponse\x12\'\n\x03job\x18\x01 \x01(\x0b\x32\x1a.freqsearch.v1.BacktestJob\x12\x32\n\x06result\x18\x02 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResultH\x00\x88\x01\x01\x12\r\n\x05\x66inal\x18\x03 \x01(\x08\x42\t\n\x07_result\"G\n\x18GetBacktestResultRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\t\x12\x1b\n\x13include_percentiles\x18\x02 \x01(\x08\"J\n\x19GetBacktestResultResponse\x12-\n\x06result\x18\x01 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResult\"\xde\x05\n\x1bQueryBacktestResultsRequest\x12\x18\n\x0bstrategy_id\x18\x01 \x01(\tH\x00\x88\x01\x01\x12 \n\x13optimization_run_id\x18\x02 \x01(\tH\x01\x88\x01\x01\x12\x17\n\nmin_sharpe\x18\x03 \x01(\x01H\x02\x88\x01\x01\x12\x1b\n\x0emin_profit_pct\x18\x04 \x01(\x01H\x03\x88\x01\x01\x12\x1d\n\x10max_drawdown_pct\x18\x05 \x01(\x01H\x04\x88\x01\x01\x12\x17\n\nmin_trades\x18\x06 \x01(\x05H\x05\x88\x01\x01\x12,\n\ntime_range\x18\x07 \x01(\x0b\x32\x18.freqsearch.v1.TimeRange\x12\x34\n\npagination\x18\x08 \x01(\x0b\x32 .freqsearch.v1.PaginationRequest\x12\x10\n\x08order_by\x18\t \x01(\t\x12\x11\n\tascending\x18\n \x01(\x08\x12\x1a\n\x12include_superseded\x18\x0b \x01(\x08\x12\x1e\n\x11\x66reqtrade_version\x18\x0c \x01(\tH\x06\x88\x01\x01\x12\x19\n\x0cimage_digest\x18\r \x01(\tH\x07\x88\x01\x01\x12\x11\n\x04host\x18\x0e \x01(\tH\x08\x88\x01\x01\x12\"\n\x15max_stoploss_exit_pct\x18\x0f \x01(\x01H\t\x88\x01\x01\x12\'\n\x1amax_trailing_stop_exit_pct\x18\x10 \x01(\x01H\n\x88\x01\x01\x42\x0e\n\x0c_strategy_idB\x16\n\x14_optimization_run_idB\r\n\x0b_min_sharpeB\x11\n\x0f_min_profit_pctB\x13\n\x11_max_drawdown_pctB\r\n\x0b_min_tradesB\x14\n\x12_freqtrade_versionB\x0f\n\r_image_digestB\x07\n\x05_hostB\x18\n\x16_max_stoploss_exit_pctB\x1d\n\x1b_max_trailing_stop_exit_pct\"\x8c\x01\n\x1cQueryBacktestResultsResponse\x12\x35\n\x07results\x18\x01 \x03(\x0b\x32$.freqsearch.v1.BacktestResultSummary\x12\x35\n\npagination\x18\x02 \x01(\x0b\x32!.freqsearch.v1.PaginationResponse\"\xfb\x01\n\x15\x42\x61\x63ktestResultSummary\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0e\n\x06job_id\x18\x02 \x01(\t\x12\x13\n\x0bstrategy_id\x18\x03 \x01(\t\x12\x15\n\rstrategy_name\x18\x04 \x01(\t\x12\x12\n\nprofit_pct\x18\x05 \x01(\x01\x12\x14\n\x0csharpe_ratio\x18\x06 \x01(\x01\x12\x18\n\x10max_drawdown_pct\x18\x07 \x01(\x01\x12\x14\n\x0ctotal_trades\x18\x08 \x01(\x05\x12\x10\n\x08win_rate\x18\t \x01(\x01\x12.\n\ncreated_at\x18\n \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"G\n\x15\x43\x61ncelBacktestRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\t\x12\x13\n\x06reason\x18\x02 \x01(\tH\x00\x88\x01\x01\x42\t\n\x07_reason\":\n\x16\x43\x61ncelBacktestResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x0f\n\x07message\x18\x02 \x01(\t\"\x16\n\x14GetQueueStatsRequest\"\x8a\x01\n\x15GetQueueStatsResponse\x12\x14\n\x0cpending_jobs\x18\x01 \x01(\x05\x12\x14\n\x0crunning_jobs\x18\x02 \x01(\x05\x12\x17\n\x0f\x63ompleted_today\x18\x03 \x01(\x05\x12\x14\n\x0c\x66\x61iled_today\x18\x04 \x01(\x05\x12\x16\n\x0emax_concurrent\x18\x05 \x01(\x05\"\xb8\x01\n\x0fSchedulerStatus\x12*\n\x04mode\x18\x01 \x01(\x0e\x32\x1c.freqsearch.v1.SchedulerMode\x12)\n\x05since\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x12\n\nchanged_by\x18\x03 \x01(\t\x12\x14\n\x0c\x63laimed_jobs\x18\x04 \x01(\x05\x12\x13\n\x0b\x61\x63tive_jobs\x18\x05 \x01(\x05\x12\x0f\n\x07\x64rained\x18\x06 \x01(\x08\"\x1b\n\x19GetSchedulerStatusRequest\"L\n\x1aGetSchedulerStatusResponse\x12.\n\x06status\x18\x01 \x01(\x0b\x32\x1e.freqsearch.v1.SchedulerStatus\"I\n\x17\x43ontrolSchedulerRequest\x12.\n\x06\x61\x63tion\x18\x01 \x01(\x0e\x32\x1e.freqsearch.v1.SchedulerAction\"J\n\x18\x43ontrolSchedulerResponse\x12.\n\x06status\x18\x01 \x01(\x0b\x32\x1e.freqsearch.v1.SchedulerStatus*\x83\x01\n\rSchedulerMode\x12\x1e\n\x1aSCHEDULER_MODE_UNSPECIFIED\x10\x00\x12\x1a\n\x16SCHEDULER_MODE_RUNNING\x10\x01\x12\x19\n\x15SCHEDULER_MODE_PAUSED\x10\x02\x12\x1b\n\x17SCHEDULER_MODE_DRAINING\x10\x03*\x88\x01\n\x0fSchedulerAction\x12 \n\x1cSCHEDULER_ACTION_UNSPECIFIED\x10\x00\x12\x1a\n\x16SCHEDULER_ACTION_PAUSE\x10\x01\x12\x1a\n\x16SCHEDULER_ACTION_DRAIN\x10\x02\x12\x1b\n\x17SCHEDULER_ACTION_RESUME\x10\x03\x42MZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1b\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -34,42 +34,78 @@ _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'freqsearch.v1.backtest_pb2'
 if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._loaded_options = None
   _globals['DESCRIPTOR']._serialized_options = b'ZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1'
+  _globals['_EXECUTIONENVIRONMENT_PACKAGESENTRY']._loaded_options = None
+  _globals['_EXECUTIONENVIRONMENT_PACKAGESENTRY']._serialized_options = b'8\001'
+  _globals['_SCHEDULERMODE']._serialized_start=6760
+  _globals['_SCHEDULERMODE']._serialized_end=6891
+  _globals['_SCHEDULERACTION']._serialized_start=6894
+  _globals['_SCHEDULERACTION']._serialized_end=7030
   _globals['_BACKTESTCONFIG']._serialized_start=109
   _globals['_BACKTESTCONFIG']._serialized_end=296
   _globals['_BACKTESTJOB']._serialized_start=299
-  _globals['_BACKTESTJOB']._serialized_end=746
-  _globals['_BACKTESTRESULT']._serialized_start=749
-  _globals['_BACKTESTRESULT']._serialized_end=1352
-  _globals['_PAIRRESULT']._serialized_start=1354
-  _globals['_PAIRRESULT']._serialized_end=1464
-  _globals['_SUBMITBACKTESTREQUEST']._serialized_start=1467
-  _globals['_SUBMITBACKTESTREQUEST']._serialized_end=1634
-  _globals['_SUBMITBACKTESTRESPONSE']._serialized_start=1636
-  _globals['_SUBMITBACKTESTRESPONSE']._serialized_end=1701
-  _globals['_SUBMITBATCHBACKTESTREQUEST']._serialized_start=1703
-  _globals['_SUBMITBATCHBACKTESTREQUEST']._serialized_end=1788
-  _globals['_SUBMITBATCHBACKTESTRESPONSE']._serialized_start=1790
-  _globals['_SUBMITBATCHBACKTESTRESPONSE']._serialized_end=1861
-  _globals['_GETBACKTESTJOBREQUEST']._serialized_start=1863
-  _globals['_GETBACKTESTJOBREQUEST']._serialized_end=1902
-  _globals['_GETBACKTESTJOBRESPONSE']._serialized_start=1905
-  _globals['_GETBACKTESTJOBRESPONSE']._serialized_end=2033
-  _globals['_GETBACKTESTRESULTREQUEST']._serialized_start=2035
-  _globals['_GETBACKTESTRESULTREQUEST']._serialized_end=2077
-  _globals['_GETBACKTESTRESULTRESPONSE']._serialized_start=2079
-  _globals['_GETBACKTESTRESULTRESPONSE']._serialized_end=2153
-  _globals['_QUERYBACKTESTRESULTSREQUEST']._serialized_start=2156
-  _globals['_QUERYBACKTESTRESULTSREQUEST']._serialized_end=2602
-  _globals['_QUERYBACKTESTRESULTSRESPONSE']._serialized_start=2605
-  _globals['_QUERYBACKTESTRESULTSRESPONSE']._serialized_end=2745
-  _globals['_BACKTESTRESULTSUMMARY']._serialized_start=2748
-  _globals['_BACKTESTRESULTSUMMARY']._serialized_end=2999
-  _globals['_CANCELBACKTESTREQUEST']._serialized_start=3001
-  _globals['_CANCELBACKTESTREQUEST']._serialized_end=3040
-  _globals['_CANCELBACKTESTRESPONSE']._serialized_start=3042
-  _globals['_CANCELBACKTESTRESPONSE']._serialized_end=3100
-  _globals['_GETQUEUESTATSREQUEST']._serialized_start=3102
-  _globals['_GETQUEUESTATSREQUEST']._serialized_end=3124
-  _globals['_GETQUEUESTATSRESPONSE']._serialized_start=3127
-  _globals['_GETQUEUESTATSRESPONSE']._serialized_end=3265
+  _globals['_BACKTESTJOB']._serialized_end=1066
+  _globals['_JOBHINTS']._serialized_start=1068
+  _globals['_JOBHINTS']._serialized_end=1129
+  _globals['_BACKTESTRESULT']._serialized_start=1132
+  _globals['_BACKTESTRESULT']._serialized_end=2336
+  _globals['_PERCENTILERANKS']._serialized_start=2339
+  _globals['_PERCENTILERANKS']._serialized_end=2561
+  _globals['_TRADEREASONSTATS']._serialized_start=2563
+  _globals['_TRADEREASONSTATS']._serialized_end=2637
+  _globals['_EXECUTIONENVIRONMENT']._serialized_start=2640
+  _globals['_EXECUTIONENVIRONMENT']._serialized_end=2882
+  _globals['_EXECUTIONENVIRONMENT_PACKAGESENTRY']._serialized_start=2835
+  _globals['_EXECUTIONENVIRONMENT_PACKAGESENTRY']._serialized_end=2882
+  _globals['_PAIRRESULT']._serialized_start=2884
+  _globals['_PAIRRESULT']._serialized_end=2994
+  _globals['_SUBMITBACKTESTREQUEST']._serialized_start=2997
+  _globals['_SUBMITBACKTESTREQUEST']._serialized_end=3363
+  _globals['_SUBMITBACKTESTRESPONSE']._serialized_start=3366
+  _globals['_SUBMITBACKTESTRESPONSE']._serialized_end=3500
+  _globals['_SUBMISSIONPREVIEW']._serialized_start=3503
+  _globals['_SUBMISSIONPREVIEW']._serialized_end=3932
+  _globals['_DATERANGE']._serialized_start=3934
+  _globals['_DATERANGE']._serialized_end=3987
+  _globals['_SUBMITBATCHBACKTESTREQUEST']._serialized_start=3989
+  _globals['_SUBMITBATCHBACKTESTREQUEST']._serialized_end=4091
+  _globals['_SUBMITBATCHBACKTESTRESPONSE']._serialized_start=4094
+  _globals['_SUBMITBATCHBACKTESTRESPONSE']._serialized_end=4230
+  _globals['_BATCHITEMRESULT']._serialized_start=4232
+  _globals['_BATCHITEMRESULT']._serialized_end=4346
+  _globals['_GETBACKTESTJOBREQUEST']._serialized_start=4348
+  _globals['_GETBACKTESTJOBREQUEST']._serialized_end=4409
+  _globals['_GETBACKTESTJOBRESPONSE']._serialized_start=4412
+  _globals['_GETBACKTESTJOBRESPONSE']._serialized_end=4540
+  _globals['_WATCHBACKTESTJOBREQUEST']._serialized_start=4542
+  _globals['_WATCHBACKTESTJOBREQUEST']._serialized_end=4583
+  _globals['_WATCHBACKTESTJOBRESPONSE']._serialized_start=4586
+  _globals['_WATCHBACKTESTJOBRESPONSE']._serialized_end=4731
+  _globals['_GETBACKTESTRESULTREQUEST']._serialized_start=4733
+  _globals['_GETBACKTESTRESULTREQUEST']._serialized_end=4804
+  _globals['_GETBACKTESTRESULTRESPONSE']._serialized_start=4806
+  _globals['_GETBACKTESTRESULTRESPONSE']._serialized_end=4880
+  _globals['_QUERYBACKTESTRESULTSREQUEST']._serialized_start=4883
+  _globals['_QUERYBACKTESTRESULTSREQUEST']._serialized_end=5617
+  _globals['_QUERYBACKTESTRESULTSRESPONSE']._serialized_start=5620
+  _globals['_QUERYBACKTESTRESULTSRESPONSE']._serialized_end=5760
+  _globals['_BACKTESTRESULTSUMMARY']._serialized_start=5763
+  _globals['_BACKTESTRESULTSUMMARY']._serialized_end=6014
+  _globals['_CANCELBACKTESTREQUEST']._serialized_start=6016
+  _globals['_CANCELBACKTESTREQUEST']._serialized_end=6087
+  _globals['_CANCELBACKTESTRESPONSE']._serialized_start=6089
+  _globals['_CANCELBACKTESTRESPONSE']._serialized_end=6147
+  _globals['_GETQUEUESTATSREQUEST']._serialized_start=6149
+  _globals['_GETQUEUESTATSREQUEST']._serialized_end=6171
+  _globals['_GETQUEUESTATSRESPONSE']._serialized_start=6174
+  _globals['_GETQUEUESTATSRESPONSE']._serialized_end=6312
+  _globals['_SCHEDULERSTATUS']._serialized_start=6315
+  _globals['_SCHEDULERSTATUS']._serialized_end=6499
+  _globals['_GETSCHEDULERSTATUSREQUEST']._serialized_start=6501
+  _globals['_GETSCHEDULERSTATUSREQUEST']._serialized_end=6528
+  _globals['_GETSCHEDULERSTATUSRESPONSE']._serialized_start=6530
+  _globals['_GETSCHEDULERSTATUSRESPONSE']._serialized_end=6606
+  _globals['_CONTROLSCHEDULERREQUEST']._serialized_start=6608
+  _globals['_CONTROLSCHEDULERREQUEST']._serialized_end=6681
+  _globals['_CONTROLSCHEDULERRESPONSE']._serialized_start=6683
+  _globals['_CONTROLSCHEDULERRESPONSE']._serialized_end=6757
 # @@protoc_insertion_point(module_scope)
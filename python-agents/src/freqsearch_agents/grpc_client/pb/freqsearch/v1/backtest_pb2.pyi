@@ -3,6 +3,7 @@ import datetime
 from google.protobuf import timestamp_pb2 as _timestamp_pb2
 from freqsearch.v1 import common_pb2 as _common_pb2
 from google.protobuf.internal import containers as _containers
+from google.protobuf.internal import enum_type_wrapper as _enum_type_wrapper
 from google.protobuf import descriptor as _descriptor
 from google.protobuf import message as _message
 from collections.abc import Iterable as _Iterable, Mapping as _Mapping
@@ -10,6 +11,28 @@ from typing import ClassVar as _ClassVar, Optional as _Optional, Union as _Union
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
+class SchedulerMode(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    SCHEDULER_MODE_UNSPECIFIED: _ClassVar[SchedulerMode]
+    SCHEDULER_MODE_RUNNING: _ClassVar[SchedulerMode]
+    SCHEDULER_MODE_PAUSED: _ClassVar[SchedulerMode]
+    SCHEDULER_MODE_DRAINING: _ClassVar[SchedulerMode]
+
+class SchedulerAction(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    SCHEDULER_ACTION_UNSPECIFIED: _ClassVar[SchedulerAction]
+    SCHEDULER_ACTION_PAUSE: _ClassVar[SchedulerAction]
+    SCHEDULER_ACTION_DRAIN: _ClassVar[SchedulerAction]
+    SCHEDULER_ACTION_RESUME: _ClassVar[SchedulerAction]
+SCHEDULER_MODE_UNSPECIFIED: SchedulerMode
+SCHEDULER_MODE_RUNNING: SchedulerMode
+SCHEDULER_MODE_PAUSED: SchedulerMode
+SCHEDULER_MODE_DRAINING: SchedulerMode
+SCHEDULER_ACTION_UNSPECIFIED: SchedulerAction
+SCHEDULER_ACTION_PAUSE: SchedulerAction
+SCHEDULER_ACTION_DRAIN: SchedulerAction
+SCHEDULER_ACTION_RESUME: SchedulerAction
+
 class BacktestConfig(_message.Message):
     __slots__ = ("exchange", "pairs", "timeframe", "timerange_start", "timerange_end", "dry_run_wallet", "max_open_trades", "stake_amount")
     EXCHANGE_FIELD_NUMBER: _ClassVar[int]
@@ -31,7 +54,7 @@ class BacktestConfig(_message.Message):
     def __init__(self, exchange: _Optional[str] = ..., pairs: _Optional[_Iterable[str]] = ..., timeframe: _Optional[str] = ..., timerange_start: _Optional[str] = ..., timerange_end: _Optional[str] = ..., dry_run_wallet: _Optional[float] = ..., max_open_trades: _Optional[int] = ..., stake_amount: _Optional[str] = ...) -> None: ...
 
 class BacktestJob(_message.Message):
-    __slots__ = ("id", "strategy_id", "optimization_run_id", "config", "status", "container_id", "error_message", "priority", "created_at", "started_at", "completed_at")
+    __slots__ = ("id", "strategy_id", "optimization_run_id", "config", "status", "container_id", "error_message", "priority", "created_at", "started_at", "completed_at", "external_ref", "campaign_id", "failure_category", "resubmitted_from", "hints", "cancel_reason", "cancelled_by")
     ID_FIELD_NUMBER: _ClassVar[int]
     STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
     OPTIMIZATION_RUN_ID_FIELD_NUMBER: _ClassVar[int]
@@ -43,6 +66,13 @@ class BacktestJob(_message.Message):
     CREATED_AT_FIELD_NUMBER: _ClassVar[int]
     STARTED_AT_FIELD_NUMBER: _ClassVar[int]
     COMPLETED_AT_FIELD_NUMBER: _ClassVar[int]
+    EXTERNAL_REF_FIELD_NUMBER: _ClassVar[int]
+    CAMPAIGN_ID_FIELD_NUMBER: _ClassVar[int]
+    FAILURE_CATEGORY_FIELD_NUMBER: _ClassVar[int]
+    RESUBMITTED_FROM_FIELD_NUMBER: _ClassVar[int]
+    HINTS_FIELD_NUMBER: _ClassVar[int]
+    CANCEL_REASON_FIELD_NUMBER: _ClassVar[int]
+    CANCELLED_BY_FIELD_NUMBER: _ClassVar[int]
     id: str
     strategy_id: str
     optimization_run_id: str
@@ -54,10 +84,25 @@ class BacktestJob(_message.Message):
     created_at: _timestamp_pb2.Timestamp
     started_at: _timestamp_pb2.Timestamp
     completed_at: _timestamp_pb2.Timestamp
-    def __init__(self, id: _Optional[str] = ..., strategy_id: _Optional[str] = ..., optimization_run_id: _Optional[str] = ..., config: _Optional[_Union[BacktestConfig, _Mapping]] = ..., status: _Optional[_Union[_common_pb2.JobStatus, str]] = ..., container_id: _Optional[str] = ..., error_message: _Optional[str] = ..., priority: _Optional[int] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., started_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., completed_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+    external_ref: str
+    campaign_id: str
+    failure_category: str
+    resubmitted_from: str
+    hints: JobHints
+    cancel_reason: str
+    cancelled_by: str
+    def __init__(self, id: _Optional[str] = ..., strategy_id: _Optional[str] = ..., optimization_run_id: _Optional[str] = ..., config: _Optional[_Union[BacktestConfig, _Mapping]] = ..., status: _Optional[_Union[_common_pb2.JobStatus, str]] = ..., container_id: _Optional[str] = ..., error_message: _Optional[str] = ..., priority: _Optional[int] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., started_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., completed_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., external_ref: _Optional[str] = ..., campaign_id: _Optional[str] = ..., failure_category: _Optional[str] = ..., resubmitted_from: _Optional[str] = ..., hints: _Optional[_Union[JobHints, _Mapping]] = ..., cancel_reason: _Optional[str] = ..., cancelled_by: _Optional[str] = ...) -> None: ...
+
+class JobHints(_message.Message):
+    __slots__ = ("prefer_cached_data", "anti_affinity")
+    PREFER_CACHED_DATA_FIELD_NUMBER: _ClassVar[int]
+    ANTI_AFFINITY_FIELD_NUMBER: _ClassVar[int]
+    prefer_cached_data: _containers.RepeatedScalarFieldContainer[str]
+    anti_affinity: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, prefer_cached_data: _Optional[_Iterable[str]] = ..., anti_affinity: _Optional[_Iterable[str]] = ...) -> None: ...
 
 class BacktestResult(_message.Message):
-    __slots__ = ("id", "job_id", "strategy_id", "total_trades", "winning_trades", "losing_trades", "win_rate", "profit_total", "profit_pct", "profit_factor", "max_drawdown", "max_drawdown_pct", "sharpe_ratio", "sortino_ratio", "calmar_ratio", "avg_trade_duration_minutes", "avg_profit_per_trade", "best_trade_pct", "worst_trade_pct", "pair_results", "raw_log", "trades_json", "created_at")
+    __slots__ = ("id", "job_id", "strategy_id", "total_trades", "winning_trades", "losing_trades", "win_rate", "profit_total", "profit_pct", "profit_factor", "max_drawdown", "max_drawdown_pct", "sharpe_ratio", "sortino_ratio", "calmar_ratio", "avg_trade_duration_minutes", "avg_profit_per_trade", "best_trade_pct", "worst_trade_pct", "pair_results", "raw_log", "trades_json", "created_at", "superseded_by", "stake_currency", "reference_currency", "reference_rate", "profit_total_normalized", "environment", "exit_reasons", "entry_tags", "stoploss_exit_pct", "trailing_stop_exit_pct", "percentiles")
     ID_FIELD_NUMBER: _ClassVar[int]
     JOB_ID_FIELD_NUMBER: _ClassVar[int]
     STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
@@ -81,6 +126,17 @@ class BacktestResult(_message.Message):
     RAW_LOG_FIELD_NUMBER: _ClassVar[int]
     TRADES_JSON_FIELD_NUMBER: _ClassVar[int]
     CREATED_AT_FIELD_NUMBER: _ClassVar[int]
+    SUPERSEDED_BY_FIELD_NUMBER: _ClassVar[int]
+    STAKE_CURRENCY_FIELD_NUMBER: _ClassVar[int]
+    REFERENCE_CURRENCY_FIELD_NUMBER: _ClassVar[int]
+    REFERENCE_RATE_FIELD_NUMBER: _ClassVar[int]
+    PROFIT_TOTAL_NORMALIZED_FIELD_NUMBER: _ClassVar[int]
+    ENVIRONMENT_FIELD_NUMBER: _ClassVar[int]
+    EXIT_REASONS_FIELD_NUMBER: _ClassVar[int]
+    ENTRY_TAGS_FIELD_NUMBER: _ClassVar[int]
+    STOPLOSS_EXIT_PCT_FIELD_NUMBER: _ClassVar[int]
+    TRAILING_STOP_EXIT_PCT_FIELD_NUMBER: _ClassVar[int]
+    PERCENTILES_FIELD_NUMBER: _ClassVar[int]
     id: str
     job_id: str
     strategy_id: str
@@ -104,7 +160,69 @@ class BacktestResult(_message.Message):
     raw_log: str
     trades_json: str
     created_at: _timestamp_pb2.Timestamp
-    def __init__(self, id: _Optional[str] = ..., job_id: _Optional[str] = ..., strategy_id: _Optional[str] = ..., total_trades: _Optional[int] = ..., winning_trades: _Optional[int] = ..., losing_trades: _Optional[int] = ..., win_rate: _Optional[float] = ..., profit_total: _Optional[float] = ..., profit_pct: _Optional[float] = ..., profit_factor: _Optional[float] = ..., max_drawdown: _Optional[float] = ..., max_drawdown_pct: _Optional[float] = ..., sharpe_ratio: _Optional[float] = ..., sortino_ratio: _Optional[float] = ..., calmar_ratio: _Optional[float] = ..., avg_trade_duration_minutes: _Optional[float] = ..., avg_profit_per_trade: _Optional[float] = ..., best_trade_pct: _Optional[float] = ..., worst_trade_pct: _Optional[float] = ..., pair_results: _Optional[_Iterable[_Union[PairResult, _Mapping]]] = ..., raw_log: _Optional[str] = ..., trades_json: _Optional[str] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+    superseded_by: str
+    stake_currency: str
+    reference_currency: str
+    reference_rate: float
+    profit_total_normalized: float
+    environment: ExecutionEnvironment
+    exit_reasons: _containers.RepeatedCompositeFieldContainer[TradeReasonStats]
+    entry_tags: _containers.RepeatedCompositeFieldContainer[TradeReasonStats]
+    stoploss_exit_pct: float
+    trailing_stop_exit_pct: float
+    percentiles: PercentileRanks
+    def __init__(self, id: _Optional[str] = ..., job_id: _Optional[str] = ..., strategy_id: _Optional[str] = ..., total_trades: _Optional[int] = ..., winning_trades: _Optional[int] = ..., losing_trades: _Optional[int] = ..., win_rate: _Optional[float] = ..., profit_total: _Optional[float] = ..., profit_pct: _Optional[float] = ..., profit_factor: _Optional[float] = ..., max_drawdown: _Optional[float] = ..., max_drawdown_pct: _Optional[float] = ..., sharpe_ratio: _Optional[float] = ..., sortino_ratio: _Optional[float] = ..., calmar_ratio: _Optional[float] = ..., avg_trade_duration_minutes: _Optional[float] = ..., avg_profit_per_trade: _Optional[float] = ..., best_trade_pct: _Optional[float] = ..., worst_trade_pct: _Optional[float] = ..., pair_results: _Optional[_Iterable[_Union[PairResult, _Mapping]]] = ..., raw_log: _Optional[str] = ..., trades_json: _Optional[str] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., superseded_by: _Optional[str] = ..., stake_currency: _Optional[str] = ..., reference_currency: _Optional[str] = ..., reference_rate: _Optional[float] = ..., profit_total_normalized: _Optional[float] = ..., environment: _Optional[_Union[ExecutionEnvironment, _Mapping]] = ..., exit_reasons: _Optional[_Iterable[_Union[TradeReasonStats, _Mapping]]] = ..., entry_tags: _Optional[_Iterable[_Union[TradeReasonStats, _Mapping]]] = ..., stoploss_exit_pct: _Optional[float] = ..., trailing_stop_exit_pct: _Optional[float] = ..., percentiles: _Optional[_Union[PercentileRanks, _Mapping]] = ...) -> None: ...
+
+class PercentileRanks(_message.Message):
+    __slots__ = ("cohort_pairs", "cohort_timerange_start", "cohort_timerange_end", "cohort_size", "sharpe", "profit", "drawdown")
+    COHORT_PAIRS_FIELD_NUMBER: _ClassVar[int]
+    COHORT_TIMERANGE_START_FIELD_NUMBER: _ClassVar[int]
+    COHORT_TIMERANGE_END_FIELD_NUMBER: _ClassVar[int]
+    COHORT_SIZE_FIELD_NUMBER: _ClassVar[int]
+    SHARPE_FIELD_NUMBER: _ClassVar[int]
+    PROFIT_FIELD_NUMBER: _ClassVar[int]
+    DRAWDOWN_FIELD_NUMBER: _ClassVar[int]
+    cohort_pairs: _containers.RepeatedScalarFieldContainer[str]
+    cohort_timerange_start: str
+    cohort_timerange_end: str
+    cohort_size: int
+    sharpe: float
+    profit: float
+    drawdown: float
+    def __init__(self, cohort_pairs: _Optional[_Iterable[str]] = ..., cohort_timerange_start: _Optional[str] = ..., cohort_timerange_end: _Optional[str] = ..., cohort_size: _Optional[int] = ..., sharpe: _Optional[float] = ..., profit: _Optional[float] = ..., drawdown: _Optional[float] = ...) -> None: ...
+
+class TradeReasonStats(_message.Message):
+    __slots__ = ("reason", "trades", "avg_profit_pct")
+    REASON_FIELD_NUMBER: _ClassVar[int]
+    TRADES_FIELD_NUMBER: _ClassVar[int]
+    AVG_PROFIT_PCT_FIELD_NUMBER: _ClassVar[int]
+    reason: str
+    trades: int
+    avg_profit_pct: float
+    def __init__(self, reason: _Optional[str] = ..., trades: _Optional[int] = ..., avg_profit_pct: _Optional[float] = ...) -> None: ...
+
+class ExecutionEnvironment(_message.Message):
+    __slots__ = ("freqtrade_version", "python_version", "image", "image_digest", "host", "packages")
+    class PackagesEntry(_message.Message):
+        __slots__ = ("key", "value")
+        KEY_FIELD_NUMBER: _ClassVar[int]
+        VALUE_FIELD_NUMBER: _ClassVar[int]
+        key: str
+        value: str
+        def __init__(self, key: _Optional[str] = ..., value: _Optional[str] = ...) -> None: ...
+    FREQTRADE_VERSION_FIELD_NUMBER: _ClassVar[int]
+    PYTHON_VERSION_FIELD_NUMBER: _ClassVar[int]
+    IMAGE_FIELD_NUMBER: _ClassVar[int]
+    IMAGE_DIGEST_FIELD_NUMBER: _ClassVar[int]
+    HOST_FIELD_NUMBER: _ClassVar[int]
+    PACKAGES_FIELD_NUMBER: _ClassVar[int]
+    freqtrade_version: str
+    python_version: str
+    image: str
+    image_digest: str
+    host: str
+    packages: _containers.ScalarMap[str, str]
+    def __init__(self, freqtrade_version: _Optional[str] = ..., python_version: _Optional[str] = ..., image: _Optional[str] = ..., image_digest: _Optional[str] = ..., host: _Optional[str] = ..., packages: _Optional[_Mapping[str, str]] = ...) -> None: ...
 
 class PairResult(_message.Message):
     __slots__ = ("pair", "trades", "profit_pct", "win_rate", "avg_duration_minutes")
@@ -121,40 +239,110 @@ class PairResult(_message.Message):
     def __init__(self, pair: _Optional[str] = ..., trades: _Optional[int] = ..., profit_pct: _Optional[float] = ..., win_rate: _Optional[float] = ..., avg_duration_minutes: _Optional[float] = ...) -> None: ...
 
 class SubmitBacktestRequest(_message.Message):
-    __slots__ = ("strategy_id", "config", "optimization_run_id", "priority")
+    __slots__ = ("strategy_id", "config", "optimization_run_id", "priority", "external_ref", "skip_validation_check", "campaign_id", "dry_run", "hints", "allow_duplicate")
     STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
     CONFIG_FIELD_NUMBER: _ClassVar[int]
     OPTIMIZATION_RUN_ID_FIELD_NUMBER: _ClassVar[int]
     PRIORITY_FIELD_NUMBER: _ClassVar[int]
+    EXTERNAL_REF_FIELD_NUMBER: _ClassVar[int]
+    SKIP_VALIDATION_CHECK_FIELD_NUMBER: _ClassVar[int]
+    CAMPAIGN_ID_FIELD_NUMBER: _ClassVar[int]
+    DRY_RUN_FIELD_NUMBER: _ClassVar[int]
+    HINTS_FIELD_NUMBER: _ClassVar[int]
+    ALLOW_DUPLICATE_FIELD_NUMBER: _ClassVar[int]
     strategy_id: str
     config: BacktestConfig
     optimization_run_id: str
     priority: int
-    def __init__(self, strategy_id: _Optional[str] = ..., config: _Optional[_Union[BacktestConfig, _Mapping]] = ..., optimization_run_id: _Optional[str] = ..., priority: _Optional[int] = ...) -> None: ...
+    external_ref: str
+    skip_validation_check: bool
+    campaign_id: str
+    dry_run: bool
+    hints: JobHints
+    allow_duplicate: bool
+    def __init__(self, strategy_id: _Optional[str] = ..., config: _Optional[_Union[BacktestConfig, _Mapping]] = ..., optimization_run_id: _Optional[str] = ..., priority: _Optional[int] = ..., external_ref: _Optional[str] = ..., skip_validation_check: bool = ..., campaign_id: _Optional[str] = ..., dry_run: bool = ..., hints: _Optional[_Union[JobHints, _Mapping]] = ..., allow_duplicate: bool = ...) -> None: ...
 
 class SubmitBacktestResponse(_message.Message):
-    __slots__ = ("job",)
+    __slots__ = ("job", "warnings", "preview")
     JOB_FIELD_NUMBER: _ClassVar[int]
+    WARNINGS_FIELD_NUMBER: _ClassVar[int]
+    PREVIEW_FIELD_NUMBER: _ClassVar[int]
     job: BacktestJob
-    def __init__(self, job: _Optional[_Union[BacktestJob, _Mapping]] = ...) -> None: ...
+    warnings: _containers.RepeatedScalarFieldContainer[str]
+    preview: SubmissionPreview
+    def __init__(self, job: _Optional[_Union[BacktestJob, _Mapping]] = ..., warnings: _Optional[_Iterable[str]] = ..., preview: _Optional[_Union[SubmissionPreview, _Mapping]] = ...) -> None: ...
+
+class SubmissionPreview(_message.Message):
+    __slots__ = ("queue_position", "running_jobs", "workers", "estimated_runtime_ms", "estimated_wait_ms", "estimated_completion_ms", "estimated_completion_p90_ms", "new_pairs", "new_timeframes", "new_timeranges")
+    QUEUE_POSITION_FIELD_NUMBER: _ClassVar[int]
+    RUNNING_JOBS_FIELD_NUMBER: _ClassVar[int]
+    WORKERS_FIELD_NUMBER: _ClassVar[int]
+    ESTIMATED_RUNTIME_MS_FIELD_NUMBER: _ClassVar[int]
+    ESTIMATED_WAIT_MS_FIELD_NUMBER: _ClassVar[int]
+    ESTIMATED_COMPLETION_MS_FIELD_NUMBER: _ClassVar[int]
+    ESTIMATED_COMPLETION_P90_MS_FIELD_NUMBER: _ClassVar[int]
+    NEW_PAIRS_FIELD_NUMBER: _ClassVar[int]
+    NEW_TIMEFRAMES_FIELD_NUMBER: _ClassVar[int]
+    NEW_TIMERANGES_FIELD_NUMBER: _ClassVar[int]
+    queue_position: int
+    running_jobs: int
+    workers: int
+    estimated_runtime_ms: int
+    estimated_wait_ms: int
+    estimated_completion_ms: int
+    estimated_completion_p90_ms: int
+    new_pairs: _containers.RepeatedScalarFieldContainer[str]
+    new_timeframes: _containers.RepeatedScalarFieldContainer[str]
+    new_timeranges: _containers.RepeatedCompositeFieldContainer[DateRange]
+    def __init__(self, queue_position: _Optional[int] = ..., running_jobs: _Optional[int] = ..., workers: _Optional[int] = ..., estimated_runtime_ms: _Optional[int] = ..., estimated_wait_ms: _Optional[int] = ..., estimated_completion_ms: _Optional[int] = ..., estimated_completion_p90_ms: _Optional[int] = ..., new_pairs: _Optional[_Iterable[str]] = ..., new_timeframes: _Optional[_Iterable[str]] = ..., new_timeranges: _Optional[_Iterable[_Union[DateRange, _Mapping]]] = ...) -> None: ...
+
+class DateRange(_message.Message):
+    __slots__ = ("start", "end", "days")
+    START_FIELD_NUMBER: _ClassVar[int]
+    END_FIELD_NUMBER: _ClassVar[int]
+    DAYS_FIELD_NUMBER: _ClassVar[int]
+    start: str
+    end: str
+    days: int
+    def __init__(self, start: _Optional[str] = ..., end: _Optional[str] = ..., days: _Optional[int] = ...) -> None: ...
 
 class SubmitBatchBacktestRequest(_message.Message):
-    __slots__ = ("backtests",)
+    __slots__ = ("backtests", "partial")
     BACKTESTS_FIELD_NUMBER: _ClassVar[int]
+    PARTIAL_FIELD_NUMBER: _ClassVar[int]
     backtests: _containers.RepeatedCompositeFieldContainer[SubmitBacktestRequest]
-    def __init__(self, backtests: _Optional[_Iterable[_Union[SubmitBacktestRequest, _Mapping]]] = ...) -> None: ...
+    partial: bool
+    def __init__(self, backtests: _Optional[_Iterable[_Union[SubmitBacktestRequest, _Mapping]]] = ..., partial: bool = ...) -> None: ...
 
 class SubmitBatchBacktestResponse(_message.Message):
-    __slots__ = ("jobs",)
+    __slots__ = ("jobs", "warnings", "items")
     JOBS_FIELD_NUMBER: _ClassVar[int]
+    WARNINGS_FIELD_NUMBER: _ClassVar[int]
+    ITEMS_FIELD_NUMBER: _ClassVar[int]
     jobs: _containers.RepeatedCompositeFieldContainer[BacktestJob]
-    def __init__(self, jobs: _Optional[_Iterable[_Union[BacktestJob, _Mapping]]] = ...) -> None: ...
+    warnings: _containers.RepeatedScalarFieldContainer[str]
+    items: _containers.RepeatedCompositeFieldContainer[BatchItemResult]
+    def __init__(self, jobs: _Optional[_Iterable[_Union[BacktestJob, _Mapping]]] = ..., warnings: _Optional[_Iterable[str]] = ..., items: _Optional[_Iterable[_Union[BatchItemResult, _Mapping]]] = ...) -> None: ...
+
+class BatchItemResult(_message.Message):
+    __slots__ = ("index", "job_id", "error", "error_code")
+    INDEX_FIELD_NUMBER: _ClassVar[int]
+    JOB_ID_FIELD_NUMBER: _ClassVar[int]
+    ERROR_FIELD_NUMBER: _ClassVar[int]
+    ERROR_CODE_FIELD_NUMBER: _ClassVar[int]
+    index: int
+    job_id: str
+    error: str
+    error_code: str
+    def __init__(self, index: _Optional[int] = ..., job_id: _Optional[str] = ..., error: _Optional[str] = ..., error_code: _Optional[str] = ...) -> None: ...
 
 class GetBacktestJobRequest(_message.Message):
-    __slots__ = ("job_id",)
+    __slots__ = ("job_id", "external_ref")
     JOB_ID_FIELD_NUMBER: _ClassVar[int]
+    EXTERNAL_REF_FIELD_NUMBER: _ClassVar[int]
     job_id: str
-    def __init__(self, job_id: _Optional[str] = ...) -> None: ...
+    external_ref: str
+    def __init__(self, job_id: _Optional[str] = ..., external_ref: _Optional[str] = ...) -> None: ...
 
 class GetBacktestJobResponse(_message.Message):
     __slots__ = ("job", "result")
@@ -164,12 +352,30 @@ class GetBacktestJobResponse(_message.Message):
     result: BacktestResult
     def __init__(self, job: _Optional[_Union[BacktestJob, _Mapping]] = ..., result: _Optional[_Union[BacktestResult, _Mapping]] = ...) -> None: ...
 
-class GetBacktestResultRequest(_message.Message):
+class WatchBacktestJobRequest(_message.Message):
     __slots__ = ("job_id",)
     JOB_ID_FIELD_NUMBER: _ClassVar[int]
     job_id: str
     def __init__(self, job_id: _Optional[str] = ...) -> None: ...
 
+class WatchBacktestJobResponse(_message.Message):
+    __slots__ = ("job", "result", "final")
+    JOB_FIELD_NUMBER: _ClassVar[int]
+    RESULT_FIELD_NUMBER: _ClassVar[int]
+    FINAL_FIELD_NUMBER: _ClassVar[int]
+    job: BacktestJob
+    result: BacktestResult
+    final: bool
+    def __init__(self, job: _Optional[_Union[BacktestJob, _Mapping]] = ..., result: _Optional[_Union[BacktestResult, _Mapping]] = ..., final: bool = ...) -> None: ...
+
+class GetBacktestResultRequest(_message.Message):
+    __slots__ = ("job_id", "include_percentiles")
+    JOB_ID_FIELD_NUMBER: _ClassVar[int]
+    INCLUDE_PERCENTILES_FIELD_NUMBER: _ClassVar[int]
+    job_id: str
+    include_percentiles: bool
+    def __init__(self, job_id: _Optional[str] = ..., include_percentiles: bool = ...) -> None: ...
+
 class GetBacktestResultResponse(_message.Message):
     __slots__ = ("result",)
     RESULT_FIELD_NUMBER: _ClassVar[int]
@@ -177,7 +383,7 @@ class GetBacktestResultResponse(_message.Message):
     def __init__(self, result: _Optional[_Union[BacktestResult, _Mapping]] = ...) -> None: ...
 
 class QueryBacktestResultsRequest(_message.Message):
-    __slots__ = ("strategy_id", "optimization_run_id", "min_sharpe", "min_profit_pct", "max_drawdown_pct", "min_trades", "time_range", "pagination", "order_by", "ascending")
+    __slots__ = ("strategy_id", "optimization_run_id", "min_sharpe", "min_profit_pct", "max_drawdown_pct", "min_trades", "time_range", "pagination", "order_by", "ascending", "include_superseded", "freqtrade_version", "image_digest", "host", "max_stoploss_exit_pct", "max_trailing_stop_exit_pct")
     STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
     OPTIMIZATION_RUN_ID_FIELD_NUMBER: _ClassVar[int]
     MIN_SHARPE_FIELD_NUMBER: _ClassVar[int]
@@ -188,6 +394,12 @@ class QueryBacktestResultsRequest(_message.Message):
     PAGINATION_FIELD_NUMBER: _ClassVar[int]
     ORDER_BY_FIELD_NUMBER: _ClassVar[int]
     ASCENDING_FIELD_NUMBER: _ClassVar[int]
+    INCLUDE_SUPERSEDED_FIELD_NUMBER: _ClassVar[int]
+    FREQTRADE_VERSION_FIELD_NUMBER: _ClassVar[int]
+    IMAGE_DIGEST_FIELD_NUMBER: _ClassVar[int]
+    HOST_FIELD_NUMBER: _ClassVar[int]
+    MAX_STOPLOSS_EXIT_PCT_FIELD_NUMBER: _ClassVar[int]
+    MAX_TRAILING_STOP_EXIT_PCT_FIELD_NUMBER: _ClassVar[int]
     strategy_id: str
     optimization_run_id: str
     min_sharpe: float
@@ -198,7 +410,13 @@ class QueryBacktestResultsRequest(_message.Message):
     pagination: _common_pb2.PaginationRequest
     order_by: str
     ascending: bool
-    def __init__(self, strategy_id: _Optional[str] = ..., optimization_run_id: _Optional[str] = ..., min_sharpe: _Optional[float] = ..., min_profit_pct: _Optional[float] = ..., max_drawdown_pct: _Optional[float] = ..., min_trades: _Optional[int] = ..., time_range: _Optional[_Union[_common_pb2.TimeRange, _Mapping]] = ..., pagination: _Optional[_Union[_common_pb2.PaginationRequest, _Mapping]] = ..., order_by: _Optional[str] = ..., ascending: bool = ...) -> None: ...
+    include_superseded: bool
+    freqtrade_version: str
+    image_digest: str
+    host: str
+    max_stoploss_exit_pct: float
+    max_trailing_stop_exit_pct: float
+    def __init__(self, strategy_id: _Optional[str] = ..., optimization_run_id: _Optional[str] = ..., min_sharpe: _Optional[float] = ..., min_profit_pct: _Optional[float] = ..., max_drawdown_pct: _Optional[float] = ..., min_trades: _Optional[int] = ..., time_range: _Optional[_Union[_common_pb2.TimeRange, _Mapping]] = ..., pagination: _Optional[_Union[_common_pb2.PaginationRequest, _Mapping]] = ..., order_by: _Optional[str] = ..., ascending: bool = ..., include_superseded: bool = ..., freqtrade_version: _Optional[str] = ..., image_digest: _Optional[str] = ..., host: _Optional[str] = ..., max_stoploss_exit_pct: _Optional[float] = ..., max_trailing_stop_exit_pct: _Optional[float] = ...) -> None: ...
 
 class QueryBacktestResultsResponse(_message.Message):
     __slots__ = ("results", "pagination")
@@ -233,10 +451,12 @@ class BacktestResultSummary(_message.Message):
     def __init__(self, id: _Optional[str] = ..., job_id: _Optional[str] = ..., strategy_id: _Optional[str] = ..., strategy_name: _Optional[str] = ..., profit_pct: _Optional[float] = ..., sharpe_ratio: _Optional[float] = ..., max_drawdown_pct: _Optional[float] = ..., total_trades: _Optional[int] = ..., win_rate: _Optional[float] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
 
 class CancelBacktestRequest(_message.Message):
-    __slots__ = ("job_id",)
+    __slots__ = ("job_id", "reason")
     JOB_ID_FIELD_NUMBER: _ClassVar[int]
+    REASON_FIELD_NUMBER: _ClassVar[int]
     job_id: str
-    def __init__(self, job_id: _Optional[str] = ...) -> None: ...
+    reason: str
+    def __init__(self, job_id: _Optional[str] = ..., reason: _Optional[str] = ...) -> None: ...
 
 class CancelBacktestResponse(_message.Message):
     __slots__ = ("success", "message")
@@ -263,3 +483,41 @@ class GetQueueStatsResponse(_message.Message):
     failed_today: int
     max_concurrent: int
     def __init__(self, pending_jobs: _Optional[int] = ..., running_jobs: _Optional[int] = ..., completed_today: _Optional[int] = ..., failed_today: _Optional[int] = ..., max_concurrent: _Optional[int] = ...) -> None: ...
+
+class SchedulerStatus(_message.Message):
+    __slots__ = ("mode", "since", "changed_by", "claimed_jobs", "active_jobs", "drained")
+    MODE_FIELD_NUMBER: _ClassVar[int]
+    SINCE_FIELD_NUMBER: _ClassVar[int]
+    CHANGED_BY_FIELD_NUMBER: _ClassVar[int]
+    CLAIMED_JOBS_FIELD_NUMBER: _ClassVar[int]
+    ACTIVE_JOBS_FIELD_NUMBER: _ClassVar[int]
+    DRAINED_FIELD_NUMBER: _ClassVar[int]
+    mode: SchedulerMode
+    since: _timestamp_pb2.Timestamp
+    changed_by: str
+    claimed_jobs: int
+    active_jobs: int
+    drained: bool
+    def __init__(self, mode: _Optional[_Union[SchedulerMode, str]] = ..., since: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., changed_by: _Optional[str] = ..., claimed_jobs: _Optional[int] = ..., active_jobs: _Optional[int] = ..., drained: bool = ...) -> None: ...
+
+class GetSchedulerStatusRequest(_message.Message):
+    __slots__ = ()
+    def __init__(self) -> None: ...
+
+class GetSchedulerStatusResponse(_message.Message):
+    __slots__ = ("status",)
+    STATUS_FIELD_NUMBER: _ClassVar[int]
+    status: SchedulerStatus
+    def __init__(self, status: _Optional[_Union[SchedulerStatus, _Mapping]] = ...) -> None: ...
+
+class ControlSchedulerRequest(_message.Message):
+    __slots__ = ("action",)
+    ACTION_FIELD_NUMBER: _ClassVar[int]
+    action: SchedulerAction
+    def __init__(self, action: _Optional[_Union[SchedulerAction, str]] = ...) -> None: ...
+
+class ControlSchedulerResponse(_message.Message):
+    __slots__ = ("status",)
+    STATUS_FIELD_NUMBER: _ClassVar[int]
+    status: SchedulerStatus
+    def __init__(self, status: _Optional[_Union[SchedulerStatus, _Mapping]] = ...) -> None: ...
@@ -29,7 +29,7 @@ from . import strategy_pb2 as freqsearch_dot_v1_dot_strategy__pb2
 from . import backtest_pb2 as freqsearch_dot_v1_dot_backtest__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1e\x66reqsearch/v1/freqsearch.proto\x12\rfreqsearch.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1a\x66reqsearch/v1/common.proto\x1a\x1c\x66reqsearch/v1/strategy.proto\x1a\x1c\x66reqsearch/v1/backtest.proto\"\x9f\x04\n\x0fOptimizationRun\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x18\n\x10\x62\x61se_strategy_id\x18\x03 \x01(\t\x12\x31\n\x06\x63onfig\x18\x04 \x01(\x0b\x32!.freqsearch.v1.OptimizationConfig\x12\x31\n\x06status\x18\x05 \x01(\x0e\x32!.freqsearch.v1.OptimizationStatus\x12\x19\n\x11\x63urrent_iteration\x18\x06 \x01(\x05\x12\x16\n\x0emax_iterations\x18\x07 \x01(\x05\x12\x1d\n\x10\x62\x65st_strategy_id\x18\x08 \x01(\tH\x00\x88\x01\x01\x12\x37\n\x0b\x62\x65st_result\x18\t \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResultH\x01\x88\x01\x01\x12\x1a\n\x12termination_reason\x18\n \x01(\t\x12.\n\ncreated_at\x18\x0b \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nupdated_at\x18\x0c \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x35\n\x0c\x63ompleted_at\x18\r \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x02\x88\x01\x01\x42\x13\n\x11_best_strategy_idB\x0e\n\x0c_best_resultB\x0f\n\r_completed_at\"\xca\x01\n\x12OptimizationConfig\x12\x36\n\x0f\x62\x61\x63ktest_config\x18\x01 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestConfig\x12\x16\n\x0emax_iterations\x18\x02 \x01(\x05\x12\x35\n\x08\x63riteria\x18\x03 \x01(\x0b\x32#.freqsearch.v1.OptimizationCriteria\x12-\n\x04mode\x18\x04 \x01(\x0e\x32\x1f.freqsearch.v1.OptimizationMode\"\x86\x01\n\x14OptimizationCriteria\x12\x12\n\nmin_sharpe\x18\x01 \x01(\x01\x12\x16\n\x0emin_profit_pct\x18\x02 \x01(\x01\x12\x18\n\x10max_drawdown_pct\x18\x03 \x01(\x01\x12\x12\n\nmin_trades\x18\x04 \x01(\x05\x12\x14\n\x0cmin_win_rate\x18\x05 \x01(\x01\"\xb2\x02\n\x15OptimizationIteration\x12\x18\n\x10iteration_number\x18\x01 \x01(\x05\x12\x13\n\x0bstrategy_id\x18\x02 \x01(\t\x12\x17\n\x0f\x62\x61\x63ktest_job_id\x18\x03 \x01(\t\x12\x32\n\x06result\x18\x04 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResultH\x00\x88\x01\x01\x12\x18\n\x10\x65ngineer_changes\x18\x05 \x01(\t\x12\x18\n\x10\x61nalyst_feedback\x18\x06 \x01(\t\x12/\n\x08\x61pproval\x18\x07 \x01(\x0e\x32\x1d.freqsearch.v1.ApprovalStatus\x12-\n\ttimestamp\x18\x08 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\t\n\x07_result\"u\n\x18StartOptimizationRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x18\n\x10\x62\x61se_strategy_id\x18\x02 \x01(\t\x12\x31\n\x06\x63onfig\x18\x03 \x01(\x0b\x32!.freqsearch.v1.OptimizationConfig\"H\n\x19StartOptimizationResponse\x12+\n\x03run\x18\x01 \x01(\x0b\x32\x1e.freqsearch.v1.OptimizationRun\"+\n\x19GetOptimizationRunRequest\x12\x0e\n\x06run_id\x18\x01 \x01(\t\"\x83\x01\n\x1aGetOptimizationRunResponse\x12+\n\x03run\x18\x01 \x01(\x0b\x32\x1e.freqsearch.v1.OptimizationRun\x12\x38\n\niterations\x18\x02 \x03(\x0b\x32$.freqsearch.v1.OptimizationIteration\"\xff\x01\n\x1a\x43ontrolOptimizationRequest\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x31\n\x06\x61\x63tion\x18\x02 \x01(\x0e\x32!.freqsearch.v1.OptimizationAction\x12\x1d\n\x10total_iterations\x18\x03 \x01(\x05H\x00\x88\x01\x01\x12\x1d\n\x10\x62\x65st_strategy_id\x18\x04 \x01(\tH\x01\x88\x01\x01\x12\x1f\n\x12termination_reason\x18\x05 \x01(\tH\x02\x88\x01\x01\x42\x13\n\x11_total_iterationsB\x13\n\x11_best_strategy_idB\x15\n\x13_termination_reason\"[\n\x1b\x43ontrolOptimizationResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12+\n\x03run\x18\x02 \x01(\x0b\x32\x1e.freqsearch.v1.OptimizationRun\"\xc4\x01\n\x1bListOptimizationRunsRequest\x12\x36\n\x06status\x18\x01 \x01(\x0e\x32!.freqsearch.v1.OptimizationStatusH\x00\x88\x01\x01\x12,\n\ntime_range\x18\x02 \x01(\x0b\x32\x18.freqsearch.v1.TimeRange\x12\x34\n\npagination\x18\x03 \x01(\x0b\x32 .freqsearch.v1.PaginationRequestB\t\n\x07_status\"\x83\x01\n\x1cListOptimizationRunsResponse\x12,\n\x04runs\x18\x01 \x03(\x0b\x32\x1e.freqsearch.v1.OptimizationRun\x12\x35\n\npagination\x18\x02 \x01(\x0b\x32!.freqsearch.v1.PaginationResponse\"G\n\x1cUpdateIterationResultRequest\x12\x14\n\x0citeration_id\x18\x01 \x01(\t\x12\x11\n\tresult_id\x18\x02 \x01(\t\"\x9b\x01\n\x1eUpdateIterationFeedbackRequest\x12\x14\n\x0citeration_id\x18\x01 \x01(\t\x12\x18\n\x10\x65ngineer_changes\x18\x02 \x01(\t\x12\x18\n\x10\x61nalyst_feedback\x18\x03 \x01(\t\x12/\n\x08\x61pproval\x18\x04 \x01(\x0e\x32\x1d.freqsearch.v1.ApprovalStatus*\xcc\x01\n\x10OptimizationMode\x12!\n\x1dOPTIMIZATION_MODE_UNSPECIFIED\x10\x00\x12%\n!OPTIMIZATION_MODE_MAXIMIZE_SHARPE\x10\x01\x12%\n!OPTIMIZATION_MODE_MAXIMIZE_PROFIT\x10\x02\x12\'\n#OPTIMIZATION_MODE_MINIMIZE_DRAWDOWN\x10\x03\x12\x1e\n\x1aOPTIMIZATION_MODE_BALANCED\x10\x04*\x81\x02\n\x12OptimizationStatus\x12#\n\x1fOPTIMIZATION_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n\x1bOPTIMIZATION_STATUS_PENDING\x10\x01\x12\x1f\n\x1bOPTIMIZATION_STATUS_RUNNING\x10\x02\x12\x1e\n\x1aOPTIMIZATION_STATUS_PAUSED\x10\x03\x12!\n\x1dOPTIMIZATION_STATUS_COMPLETED\x10\x04\x12\x1e\n\x1aOPTIMIZATION_STATUS_FAILED\x10\x05\x12!\n\x1dOPTIMIZATION_STATUS_CANCELLED\x10\x06*\xd8\x01\n\x12OptimizationAction\x12#\n\x1fOPTIMIZATION_ACTION_UNSPECIFIED\x10\x00\x12\x1d\n\x19OPTIMIZATION_ACTION_PAUSE\x10\x01\x12\x1e\n\x1aOPTIMIZATION_ACTION_RESUME\x10\x02\x12\x1e\n\x1aOPTIMIZATION_ACTION_CANCEL\x10\x03\x12 \n\x1cOPTIMIZATION_ACTION_COMPLETE\x10\x04\x12\x1c\n\x18OPTIMIZATION_ACTION_FAIL\x10\x05\x32\xe4\x0f\n\x11\x46reqSearchService\x12]\n\x0e\x43reateStrategy\x12$.freqsearch.v1.CreateStrategyRequest\x1a%.freqsearch.v1.CreateStrategyResponse\x12T\n\x0bGetStrategy\x12!.freqsearch.v1.GetStrategyRequest\x1a\".freqsearch.v1.GetStrategyResponse\x12\x63\n\x10SearchStrategies\x12&.freqsearch.v1.SearchStrategiesRequest\x1a\'.freqsearch.v1.SearchStrategiesResponse\x12i\n\x12GetStrategyLineage\x12(.freqsearch.v1.GetStrategyLineageRequest\x1a).freqsearch.v1.GetStrategyLineageResponse\x12]\n\x0e\x44\x65leteStrategy\x12$.freqsearch.v1.DeleteStrategyRequest\x1a%.freqsearch.v1.DeleteStrategyResponse\x12\x63\n\x10ValidateStrategy\x12&.freqsearch.v1.ValidateStrategyRequest\x1a\'.freqsearch.v1.ValidateStrategyResponse\x12]\n\x0eSubmitBacktest\x12$.freqsearch.v1.SubmitBacktestRequest\x1a%.freqsearch.v1.SubmitBacktestResponse\x12l\n\x13SubmitBatchBacktest\x12).freqsearch.v1.SubmitBatchBacktestRequest\x1a*.freqsearch.v1.SubmitBatchBacktestResponse\x12]\n\x0eGetBacktestJob\x12$.freqsearch.v1.GetBacktestJobRequest\x1a%.freqsearch.v1.GetBacktestJobResponse\x12\x66\n\x11GetBacktestResult\x12\'.freqsearch.v1.GetBacktestResultRequest\x1a(.freqsearch.v1.GetBacktestResultResponse\x12o\n\x14QueryBacktestResults\x12*.freqsearch.v1.QueryBacktestResultsRequest\x1a+.freqsearch.v1.QueryBacktestResultsResponse\x12]\n\x0e\x43\x61ncelBacktest\x12$.freqsearch.v1.CancelBacktestRequest\x1a%.freqsearch.v1.CancelBacktestResponse\x12Z\n\rGetQueueStats\x12#.freqsearch.v1.GetQueueStatsRequest\x1a$.freqsearch.v1.GetQueueStatsResponse\x12\x66\n\x11StartOptimization\x12\'.freqsearch.v1.StartOptimizationRequest\x1a(.freqsearch.v1.StartOptimizationResponse\x12i\n\x12GetOptimizationRun\x12(.freqsearch.v1.GetOptimizationRunRequest\x1a).freqsearch.v1.GetOptimizationRunResponse\x12l\n\x13\x43ontrolOptimization\x12).freqsearch.v1.ControlOptimizationRequest\x1a*.freqsearch.v1.ControlOptimizationResponse\x12o\n\x14ListOptimizationRuns\x12*.freqsearch.v1.ListOptimizationRunsRequest\x1a+.freqsearch.v1.ListOptimizationRunsResponse\x12\\\n\x15UpdateIterationResult\x12+.freqsearch.v1.UpdateIterationResultRequest\x1a\x16.google.protobuf.Empty\x12`\n\x17UpdateIterationFeedback\x12-.freqsearch.v1.UpdateIterationFeedbackRequest\x1a\x16.google.protobuf.Empty\x12T\n\x0bHealthCheck\x12!.freqsearch.v1.HealthCheckRequest\x1a\".freqsearch.v1.HealthCheckResponseBMZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1b\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1e\x66reqsearch/v1/freqsearch.proto\x12\rfreqsearch.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1a\x66reqsearch/v1/common.proto\x1a\x1c\x66reqsearch/v1/strategy.proto\x1a\x1c\x66reqsearch/v1/backtest.proto\"\xc0\x05\n\x0fOptimizationRun\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x18\n\x10\x62\x61se_strategy_id\x18\x03 \x01(\t\x12\x31\n\x06\x63onfig\x18\x04 \x01(\x0b\x32!.freqsearch.v1.OptimizationConfig\x12\x31\n\x06status\x18\x05 \x01(\x0e\x32!.freqsearch.v1.OptimizationStatus\x12\x19\n\x11\x63urrent_iteration\x18\x06 \x01(\x05\x12\x16\n\x0emax_iterations\x18\x07 \x01(\x05\x12\x1d\n\x10\x62\x65st_strategy_id\x18\x08 \x01(\tH\x00\x88\x01\x01\x12\x37\n\x0b\x62\x65st_result\x18\t \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResultH\x01\x88\x01\x01\x12\x1a\n\x12termination_reason\x18\n \x01(\t\x12.\n\ncreated_at\x18\x0b \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nupdated_at\x18\x0c \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x35\n\x0c\x63ompleted_at\x18\r \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x02\x88\x01\x01\x12\x19\n\x0c\x65xternal_ref\x18\x0e \x01(\tH\x03\x88\x01\x01\x12\x19\n\x11seed_strategy_ids\x18\x0f \x03(\t\x12\x1a\n\rcancel_reason\x18\x10 \x01(\tH\x04\x88\x01\x01\x12\x19\n\x0c\x63\x61ncelled_by\x18\x11 \x01(\tH\x05\x88\x01\x01\x42\x13\n\x11_best_strategy_idB\x0e\n\x0c_best_resultB\x0f\n\r_completed_atB\x0f\n\r_external_refB\x10\n\x0e_cancel_reasonB\x0f\n\r_cancelled_by\"\xa1\x02\n\x12OptimizationConfig\x12\x36\n\x0f\x62\x61\x63ktest_config\x18\x01 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestConfig\x12\x16\n\x0emax_iterations\x18\x02 \x01(\x05\x12\x35\n\x08\x63riteria\x18\x03 \x01(\x0b\x32#.freqsearch.v1.OptimizationCriteria\x12-\n\x04mode\x18\x04 \x01(\x0e\x32\x1f.freqsearch.v1.OptimizationMode\x12\x15\n\rsnapshot_code\x18\x05 \x01(\x08\x12\x34\n\x05quota\x18\x06 \x01(\x0b\x32 .freqsearch.v1.OptimizationQuotaH\x00\x88\x01\x01\x42\x08\n\x06_quota\"\\\n\x11OptimizationQuota\x12\x1b\n\x13max_concurrent_jobs\x18\x01 \x01(\x05\x12\x11\n\tcpu_limit\x18\x02 \x01(\x01\x12\x17\n\x0fmemory_limit_mb\x18\x03 \x01(\x05\"\x86\x01\n\x14OptimizationCriteria\x12\x12\n\nmin_sharpe\x18\x01 \x01(\x01\x12\x16\n\x0emin_profit_pct\x18\x02 \x01(\x01\x12\x18\n\x10max_drawdown_pct\x18\x03 \x01(\x01\x12\x12\n\nmin_trades\x18\x04 \x01(\x05\x12\x14\n\x0cmin_win_rate\x18\x05 \x01(\x01\"\xf3\x02\n\x15OptimizationIteration\x12\x18\n\x10iteration_number\x18\x01 \x01(\x05\x12\x13\n\x0bstrategy_id\x18\x02 \x01(\t\x12\x17\n\x0f\x62\x61\x63ktest_job_id\x18\x03 \x01(\t\x12\x32\n\x06result\x18\x04 \x01(\x0b\x32\x1d.freqsearch.v1.BacktestResultH\x00\x88\x01\x01\x12\x18\n\x10\x65ngineer_changes\x18\x05 \x01(\t\x12\x18\n\x10\x61nalyst_feedback\x18\x06 \x01(\t\x12/\n\x08\x61pproval\x18\x07 \x01(\x0e\x32\x1d.freqsearch.v1.ApprovalStatus\x12-\n\ttimestamp\x18\x08 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x11\n\tcode_hash\x18\t \x01(\t\x12\x1a\n\rcode_snapshot\x18\n \x01(\tH\x01\x88\x01\x01\x42\t\n\x07_resultB\x10\n\x0e_code_snapshot\"\x97\x02\n\x14OptimizationProgress\x12\x1c\n\x14\x63ompleted_iterations\x18\x01 \x01(\x05\x12\x16\n\x0emax_iterations\x18\x02 \x01(\x05\x12\x18\n\x10percent_complete\x18\x03 \x01(\x01\x12\x12\n\nelapsed_ms\x18\x04 \x01(\x03\x12\x1d\n\x10\x61vg_iteration_ms\x18\x05 \x01(\x03H\x00\x88\x01\x01\x12\x19\n\x0cremaining_ms\x18\x06 \x01(\x03H\x01\x88\x01\x01\x12;\n\x17\x65stimated_completion_at\x18\x07 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x13\n\x11_avg_iteration_msB\x0f\n\r_remaining_ms\"\xbc\x01\n\x18StartOptimizationRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x18\n\x10\x62\x61se_strategy_id\x18\x02 \x01(\t\x12\x31\n\x06\x63onfig\x18\x03 \x01(\x0b\x32!.freqsearch.v1.OptimizationConfig\x12\x19\n\x0c\x65xternal_ref\x18\x04 \x01(\tH\x00\x88\x01\x01\x12\x19\n\x11\x62\x61se_strategy_ids\x18\x05 \x03(\tB\x0f\n\r_external_ref\"H\n\x19StartOptimizationResponse\x12+\n\x03run\x18\x01 \x01(\x0b\x32\x1e.freqsearch.v1.OptimizationRun\"A\n\x19GetOptimizationRunRequest\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x14\n\x0c\x65xternal_ref\x18\x02 \x01(\t\"\xba\x01\n\x1aGetOptimizationRunResponse\x12+\n\x03run\x18\x01 \x01(\x0b\x32\x1e.freqsearch.v1.OptimizationRun\x12\x38\n\niterations\x18\x02 \x03(\x0b\x32$.freqsearch.v1.OptimizationIteration\x12\x35\n\x08progress\x18\x03 \x01(\x0b\x32#.freqsearch.v1.OptimizationProgress\"\xff\x01\n\x1a\x43ontrolOptimizationRequest\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x31\n\x06\x61\x63tion\x18\x02 \x01(\x0e\x32!.freqsearch.v1.OptimizationAction\x12\x1d\n\x10total_iterations\x18\x03 \x01(\x05H\x00\x88\x01\x01\x12\x1d\n\x10\x62\x65st_strategy_id\x18\x04 \x01(\tH\x01\x88\x01\x01\x12\x1f\n\x12termination_reason\x18\x05 \x01(\tH\x02\x88\x01\x01\x42\x13\n\x11_total_iterationsB\x13\n\x11_best_strategy_idB\x15\n\x13_termination_reason\"[\n\x1b\x43ontrolOptimizationResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12+\n\x03run\x18\x02 \x01(\x0b\x32\x1e.freqsearch.v1.OptimizationRun\"\xc4\x01\n\x1bListOptimizationRunsRequest\x12\x36\n\x06status\x18\x01 \x01(\x0e\x32!.freqsearch.v1.OptimizationStatusH\x00\x88\x01\x01\x12,\n\ntime_range\x18\x02 \x01(\x0b\x32\x18.freqsearch.v1.TimeRange\x12\x34\n\npagination\x18\x03 \x01(\x0b\x32 .freqsearch.v1.PaginationRequestB\t\n\x07_status\"\x83\x01\n\x1cListOptimizationRunsResponse\x12,\n\x04runs\x18\x01 \x03(\x0b\x32\x1e.freqsearch.v1.OptimizationRun\x12\x35\n\npagination\x18\x02 \x01(\x0b\x32!.freqsearch.v1.PaginationResponse\"G\n\x1cUpdateIterationResultRequest\x12\x14\n\x0citeration_id\x18\x01 \x01(\t\x12\x11\n\tresult_id\x18\x02 \x01(\t\"\x9b\x01\n\x1eUpdateIterationFeedbackRequest\x12\x14\n\x0citeration_id\x18\x01 \x01(\t\x12\x18\n\x10\x65ngineer_changes\x18\x02 \x01(\t\x12\x18\n\x10\x61nalyst_feedback\x18\x03 \x01(\t\x12/\n\x08\x61pproval\x18\x04 \x01(\x0e\x32\x1d.freqsearch.v1.ApprovalStatus\"m\n\"ClaimNextOptimizationActionRequest\x12\x13\n\x06run_id\x18\x01 \x01(\tH\x00\x88\x01\x01\x12\x10\n\x08\x63laimant\x18\x02 \x01(\t\x12\x15\n\rlease_seconds\x18\x03 \x01(\x05\x42\t\n\x07_run_id\"\xa8\x03\n#ClaimNextOptimizationActionResponse\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12-\n\x06\x61\x63tion\x18\x02 \x01(\x0e\x32\x1d.freqsearch.v1.NextActionType\x12\x18\n\x10iteration_number\x18\x03 \x01(\x05\x12\x0e\n\x06reason\x18\x04 \x01(\t\x12\x1f\n\x12source_strategy_id\x18\x05 \x01(\tH\x00\x88\x01\x01\x12\x10\n\x08\x66\x65\x65\x64\x62\x61\x63k\x18\x06 \x01(\t\x12<\n\titeration\x18\x07 \x01(\x0b\x32$.freqsearch.v1.OptimizationIterationH\x01\x88\x01\x01\x12\x16\n\tresult_id\x18\x08 \x01(\tH\x02\x88\x01\x01\x12\x17\n\nclaimed_by\x18\t \x01(\tH\x03\x88\x01\x01\x12\x34\n\x10\x63laim_expires_at\x18\n \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x15\n\x13_source_strategy_idB\x0c\n\n_iterationB\x0c\n\n_result_idB\r\n\x0b_claimed_by\"\xde\x01\n\x11\x41gentRegistration\x12\x10\n\x08\x61gent_id\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\x0f\n\x07version\x18\x03 \x01(\t\x12\x1d\n\x15\x65vent_schema_versions\x18\x04 \x03(\x05\x12\x14\n\x0c\x63\x61pabilities\x18\x05 \x03(\t\x12\x31\n\rregistered_at\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x30\n\x0clast_seen_at\x18\x07 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"|\n\x14RegisterAgentRequest\x12\x10\n\x08\x61gent_id\x18\x01 \x01(\t\x12\x0c\n\x04type\x18\x02 \x01(\t\x12\x0f\n\x07version\x18\x03 \x01(\t\x12\x1d\n\x15\x65vent_schema_versions\x18\x04 \x03(\x05\x12\x14\n\x0c\x63\x61pabilities\x18\x05 \x03(\t\"x\n\x15RegisterAgentResponse\x12/\n\x05\x61gent\x18\x01 \x01(\x0b\x32 .freqsearch.v1.AgentRegistration\x12\x1c\n\x14\x65vent_schema_version\x18\x02 \x01(\x05\x12\x10\n\x08warnings\x18\x03 \x03(\t\"\xbf\x02\n\x0eScoutDiscovery\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0e\n\x06run_id\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0e\n\x06source\x18\x04 \x01(\t\x12\x12\n\nsource_url\x18\x05 \x01(\t\x12\x11\n\tcode_hash\x18\x06 \x01(\t\x12\x19\n\x11validation_status\x18\x07 \x01(\t\x12\x19\n\x11validation_errors\x18\x08 \x03(\t\x12\x0f\n\x07outcome\x18\t \x01(\t\x12\x10\n\x08imported\x18\n \x01(\x08\x12\x18\n\x0bstrategy_id\x18\x0b \x01(\tH\x00\x88\x01\x01\x12\x16\n\x0eoutcome_detail\x18\x0c \x01(\t\x12\x31\n\rdiscovered_at\x18\r \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x0e\n\x0c_strategy_id\"c\n\x1dRecordScoutDiscoveriesRequest\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x32\n\x0b\x64iscoveries\x18\x02 \x03(\x0b\x32\x1d.freqsearch.v1.ScoutDiscovery\"T\n\x1eRecordScoutDiscoveriesResponse\x12\x32\n\x0b\x64iscoveries\x18\x01 \x03(\x0b\x32\x1d.freqsearch.v1.ScoutDiscovery\"\x85\x01\n\x1bListScoutDiscoveriesRequest\x12\x0e\n\x06run_id\x18\x01 \x01(\t\x12\x14\n\x07outcome\x18\x02 \x01(\tH\x00\x88\x01\x01\x12\x1e\n\x11validation_status\x18\x03 \x01(\tH\x01\x88\x01\x01\x42\n\n\x08_outcomeB\x14\n\x12_validation_status\"R\n\x1cListScoutDiscoveriesResponse\x12\x32\n\x0b\x64iscoveries\x18\x01 \x03(\x0b\x32\x1d.freqsearch.v1.ScoutDiscovery\".\n\x19GetScoutCredentialRequest\x12\x11\n\tsecret_id\x18\x01 \x01(\t\"0\n\x1aGetScoutCredentialResponse\x12\x12\n\ncredential\x18\x01 \x01(\t*\xcc\x01\n\x10OptimizationMode\x12!\n\x1dOPTIMIZATION_MODE_UNSPECIFIED\x10\x00\x12%\n!OPTIMIZATION_MODE_MAXIMIZE_SHARPE\x10\x01\x12%\n!OPTIMIZATION_MODE_MAXIMIZE_PROFIT\x10\x02\x12\'\n#OPTIMIZATION_MODE_MINIMIZE_DRAWDOWN\x10\x03\x12\x1e\n\x1aOPTIMIZATION_MODE_BALANCED\x10\x04*\xa2\x02\n\x12OptimizationStatus\x12#\n\x1fOPTIMIZATION_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n\x1bOPTIMIZATION_STATUS_PENDING\x10\x01\x12\x1f\n\x1bOPTIMIZATION_STATUS_RUNNING\x10\x02\x12\x1e\n\x1aOPTIMIZATION_STATUS_PAUSED\x10\x03\x12!\n\x1dOPTIMIZATION_STATUS_COMPLETED\x10\x04\x12\x1e\n\x1aOPTIMIZATION_STATUS_FAILED\x10\x05\x12!\n\x1dOPTIMIZATION_STATUS_CANCELLED\x10\x06\x12\x1f\n\x1bOPTIMIZATION_STATUS_STALLED\x10\x07*\xd8\x01\n\x12OptimizationAction\x12#\n\x1fOPTIMIZATION_ACTION_UNSPECIFIED\x10\x00\x12\x1d\n\x19OPTIMIZATION_ACTION_PAUSE\x10\x01\x12\x1e\n\x1aOPTIMIZATION_ACTION_RESUME\x10\x02\x12\x1e\n\x1aOPTIMIZATION_ACTION_CANCEL\x10\x03\x12 \n\x1cOPTIMIZATION_ACTION_COMPLETE\x10\x04\x12\x1c\n\x18OPTIMIZATION_ACTION_FAIL\x10\x05*\xe1\x01\n\x0eNextActionType\x12 \n\x1cNEXT_ACTION_TYPE_UNSPECIFIED\x10\x00\x12\x19\n\x15NEXT_ACTION_TYPE_NONE\x10\x01\x12\'\n#NEXT_ACTION_TYPE_GENERATE_CANDIDATE\x10\x02\x12\"\n\x1eNEXT_ACTION_TYPE_AWAIT_RESULTS\x10\x03\x12&\n\"NEXT_ACTION_TYPE_EVALUATE_CRITERIA\x10\x04\x12\x1d\n\x19NEXT_ACTION_TYPE_FINALIZE\x10\x05\x32\x9b\x19\n\x11\x46reqSearchService\x12]\n\x0e\x43reateStrategy\x12$.freqsearch.v1.CreateStrategyRequest\x1a%.freqsearch.v1.CreateStrategyResponse\x12T\n\x0bGetStrategy\x12!.freqsearch.v1.GetStrategyRequest\x1a\".freqsearch.v1.GetStrategyResponse\x12\x63\n\x10SearchStrategies\x12&.freqsearch.v1.SearchStrategiesRequest\x1a\'.freqsearch.v1.SearchStrategiesResponse\x12i\n\x12GetStrategyLineage\x12(.freqsearch.v1.GetStrategyLineageRequest\x1a).freqsearch.v1.GetStrategyLineageResponse\x12]\n\x0e\x44\x65leteStrategy\x12$.freqsearch.v1.DeleteStrategyRequest\x1a%.freqsearch.v1.DeleteStrategyResponse\x12\x63\n\x10ValidateStrategy\x12&.freqsearch.v1.ValidateStrategyRequest\x1a\'.freqsearch.v1.ValidateStrategyResponse\x12r\n\x15GetStrategyStatistics\x12+.freqsearch.v1.GetStrategyStatisticsRequest\x1a,.freqsearch.v1.GetStrategyStatisticsResponse\x12u\n\x16SetStrategyDescription\x12,.freqsearch.v1.SetStrategyDescriptionRequest\x1a-.freqsearch.v1.SetStrategyDescriptionResponse\x12]\n\x0e\x44iffStrategies\x12$.freqsearch.v1.DiffStrategiesRequest\x1a%.freqsearch.v1.DiffStrategiesResponse\x12]\n\x0eSubmitBacktest\x12$.freqsearch.v1.SubmitBacktestRequest\x1a%.freqsearch.v1.SubmitBacktestResponse\x12l\n\x13SubmitBatchBacktest\x12).freqsearch.v1.SubmitBatchBacktestRequest\x1a*.freqsearch.v1.SubmitBatchBacktestResponse\x12]\n\x0eGetBacktestJob\x12$.freqsearch.v1.GetBacktestJobRequest\x1a%.freqsearch.v1.GetBacktestJobResponse\x12\x65\n\x10WatchBacktestJob\x12&.freqsearch.v1.WatchBacktestJobRequest\x1a\'.freqsearch.v1.WatchBacktestJobResponse0\x01\x12\x66\n\x11GetBacktestResult\x12\'.freqsearch.v1.GetBacktestResultRequest\x1a(.freqsearch.v1.GetBacktestResultResponse\x12o\n\x14QueryBacktestResults\x12*.freqsearch.v1.QueryBacktestResultsRequest\x1a+.freqsearch.v1.QueryBacktestResultsResponse\x12]\n\x0e\x43\x61ncelBacktest\x12$.freqsearch.v1.CancelBacktestRequest\x1a%.freqsearch.v1.CancelBacktestResponse\x12Z\n\rGetQueueStats\x12#.freqsearch.v1.GetQueueStatsRequest\x1a$.freqsearch.v1.GetQueueStatsResponse\x12i\n\x12GetSchedulerStatus\x12(.freqsearch.v1.GetSchedulerStatusRequest\x1a).freqsearch.v1.GetSchedulerStatusResponse\x12\x63\n\x10\x43ontrolScheduler\x12&.freqsearch.v1.ControlSchedulerRequest\x1a\'.freqsearch.v1.ControlSchedulerResponse\x12\x66\n\x11StartOptimization\x12\'.freqsearch.v1.StartOptimizationRequest\x1a(.freqsearch.v1.StartOptimizationResponse\x12i\n\x12GetOptimizationRun\x12(.freqsearch.v1.GetOptimizationRunRequest\x1a).freqsearch.v1.GetOptimizationRunResponse\x12l\n\x13\x43ontrolOptimization\x12).freqsearch.v1.ControlOptimizationRequest\x1a*.freqsearch.v1.ControlOptimizationResponse\x12o\n\x14ListOptimizationRuns\x12*.freqsearch.v1.ListOptimizationRunsRequest\x1a+.freqsearch.v1.ListOptimizationRunsResponse\x12\\\n\x15UpdateIterationResult\x12+.freqsearch.v1.UpdateIterationResultRequest\x1a\x16.google.protobuf.Empty\x12`\n\x17UpdateIterationFeedback\x12-.freqsearch.v1.UpdateIterationFeedbackRequest\x1a\x16.google.protobuf.Empty\x12\x84\x01\n\x1b\x43laimNextOptimizationAction\x12\x31.freqsearch.v1.ClaimNextOptimizationActionRequest\x1a\x32.freqsearch.v1.ClaimNextOptimizationActionResponse\x12Z\n\rRegisterAgent\x12#.freqsearch.v1.RegisterAgentRequest\x1a$.freqsearch.v1.RegisterAgentResponse\x12u\n\x16RecordScoutDiscoveries\x12,.freqsearch.v1.RecordScoutDiscoveriesRequest\x1a-.freqsearch.v1.RecordScoutDiscoveriesResponse\x12o\n\x14ListScoutDiscoveries\x12*.freqsearch.v1.ListScoutDiscoveriesRequest\x1a+.freqsearch.v1.ListScoutDiscoveriesResponse\x12i\n\x12GetScoutCredential\x12(.freqsearch.v1.GetScoutCredentialRequest\x1a).freqsearch.v1.GetScoutCredentialResponse\x12T\n\x0bHealthCheck\x12!.freqsearch.v1.HealthCheckRequest\x1a\".freqsearch.v1.HealthCheckResponseBMZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1b\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -37,40 +37,70 @@ _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'freqsearch.v1.freqsearch_pb
 if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._loaded_options = None
   _globals['DESCRIPTOR']._serialized_options = b'ZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1'
-  _globals['_OPTIMIZATIONMODE']._serialized_start=2684
-  _globals['_OPTIMIZATIONMODE']._serialized_end=2888
-  _globals['_OPTIMIZATIONSTATUS']._serialized_start=2891
-  _globals['_OPTIMIZATIONSTATUS']._serialized_end=3148
-  _globals['_OPTIMIZATIONACTION']._serialized_start=3151
-  _globals['_OPTIMIZATIONACTION']._serialized_end=3367
+  _globals['_OPTIMIZATIONMODE']._serialized_start=5360
+  _globals['_OPTIMIZATIONMODE']._serialized_end=5564
+  _globals['_OPTIMIZATIONSTATUS']._serialized_start=5567
+  _globals['_OPTIMIZATIONSTATUS']._serialized_end=5857
+  _globals['_OPTIMIZATIONACTION']._serialized_start=5860
+  _globals['_OPTIMIZATIONACTION']._serialized_end=6076
+  _globals['_NEXTACTIONTYPE']._serialized_start=6079
+  _globals['_NEXTACTIONTYPE']._serialized_end=6304
   _globals['_OPTIMIZATIONRUN']._serialized_start=200
-  _globals['_OPTIMIZATIONRUN']._serialized_end=743
-  _globals['_OPTIMIZATIONCONFIG']._serialized_start=746
-  _globals['_OPTIMIZATIONCONFIG']._serialized_end=948
-  _globals['_OPTIMIZATIONCRITERIA']._serialized_start=951
-  _globals['_OPTIMIZATIONCRITERIA']._serialized_end=1085
-  _globals['_OPTIMIZATIONITERATION']._serialized_start=1088
-  _globals['_OPTIMIZATIONITERATION']._serialized_end=1394
-  _globals['_STARTOPTIMIZATIONREQUEST']._serialized_start=1396
-  _globals['_STARTOPTIMIZATIONREQUEST']._serialized_end=1513
-  _globals['_STARTOPTIMIZATIONRESPONSE']._serialized_start=1515
-  _globals['_STARTOPTIMIZATIONRESPONSE']._serialized_end=1587
-  _globals['_GETOPTIMIZATIONRUNREQUEST']._serialized_start=1589
-  _globals['_GETOPTIMIZATIONRUNREQUEST']._serialized_end=1632
-  _globals['_GETOPTIMIZATIONRUNRESPONSE']._serialized_start=1635
-  _globals['_GETOPTIMIZATIONRUNRESPONSE']._serialized_end=1766
-  _globals['_CONTROLOPTIMIZATIONREQUEST']._serialized_start=1769
-  _globals['_CONTROLOPTIMIZATIONREQUEST']._serialized_end=2024
-  _globals['_CONTROLOPTIMIZATIONRESPONSE']._serialized_start=2026
-  _globals['_CONTROLOPTIMIZATIONRESPONSE']._serialized_end=2117
-  _globals['_LISTOPTIMIZATIONRUNSREQUEST']._serialized_start=2120
-  _globals['_LISTOPTIMIZATIONRUNSREQUEST']._serialized_end=2316
-  _globals['_LISTOPTIMIZATIONRUNSRESPONSE']._serialized_start=2319
-  _globals['_LISTOPTIMIZATIONRUNSRESPONSE']._serialized_end=2450
-  _globals['_UPDATEITERATIONRESULTREQUEST']._serialized_start=2452
-  _globals['_UPDATEITERATIONRESULTREQUEST']._serialized_end=2523
-  _globals['_UPDATEITERATIONFEEDBACKREQUEST']._serialized_start=2526
-  _globals['_UPDATEITERATIONFEEDBACKREQUEST']._serialized_end=2681
-  _globals['_FREQSEARCHSERVICE']._serialized_start=3370
-  _globals['_FREQSEARCHSERVICE']._serialized_end=5390
+  _globals['_OPTIMIZATIONRUN']._serialized_end=904
+  _globals['_OPTIMIZATIONCONFIG']._serialized_start=907
+  _globals['_OPTIMIZATIONCONFIG']._serialized_end=1196
+  _globals['_OPTIMIZATIONQUOTA']._serialized_start=1198
+  _globals['_OPTIMIZATIONQUOTA']._serialized_end=1290
+  _globals['_OPTIMIZATIONCRITERIA']._serialized_start=1293
+  _globals['_OPTIMIZATIONCRITERIA']._serialized_end=1427
+  _globals['_OPTIMIZATIONITERATION']._serialized_start=1430
+  _globals['_OPTIMIZATIONITERATION']._serialized_end=1801
+  _globals['_OPTIMIZATIONPROGRESS']._serialized_start=1804
+  _globals['_OPTIMIZATIONPROGRESS']._serialized_end=2083
+  _globals['_STARTOPTIMIZATIONREQUEST']._serialized_start=2086
+  _globals['_STARTOPTIMIZATIONREQUEST']._serialized_end=2274
+  _globals['_STARTOPTIMIZATIONRESPONSE']._serialized_start=2276
+  _globals['_STARTOPTIMIZATIONRESPONSE']._serialized_end=2348
+  _globals['_GETOPTIMIZATIONRUNREQUEST']._serialized_start=2350
+  _globals['_GETOPTIMIZATIONRUNREQUEST']._serialized_end=2415
+  _globals['_GETOPTIMIZATIONRUNRESPONSE']._serialized_start=2418
+  _globals['_GETOPTIMIZATIONRUNRESPONSE']._serialized_end=2604
+  _globals['_CONTROLOPTIMIZATIONREQUEST']._serialized_start=2607
+  _globals['_CONTROLOPTIMIZATIONREQUEST']._serialized_end=2862
+  _globals['_CONTROLOPTIMIZATIONRESPONSE']._serialized_start=2864
+  _globals['_CONTROLOPTIMIZATIONRESPONSE']._serialized_end=2955
+  _globals['_LISTOPTIMIZATIONRUNSREQUEST']._serialized_start=2958
+  _globals['_LISTOPTIMIZATIONRUNSREQUEST']._serialized_end=3154
+  _globals['_LISTOPTIMIZATIONRUNSRESPONSE']._serialized_start=3157
+  _globals['_LISTOPTIMIZATIONRUNSRESPONSE']._serialized_end=3288
+  _globals['_UPDATEITERATIONRESULTREQUEST']._serialized_start=3290
+  _globals['_UPDATEITERATIONRESULTREQUEST']._serialized_end=3361
+  _globals['_UPDATEITERATIONFEEDBACKREQUEST']._serialized_start=3364
+  _globals['_UPDATEITERATIONFEEDBACKREQUEST']._serialized_end=3519
+  _globals['_CLAIMNEXTOPTIMIZATIONACTIONREQUEST']._serialized_start=3521
+  _globals['_CLAIMNEXTOPTIMIZATIONACTIONREQUEST']._serialized_end=3630
+  _globals['_CLAIMNEXTOPTIMIZATIONACTIONRESPONSE']._serialized_start=3633
+  _globals['_CLAIMNEXTOPTIMIZATIONACTIONRESPONSE']._serialized_end=4057
+  _globals['_AGENTREGISTRATION']._serialized_start=4060
+  _globals['_AGENTREGISTRATION']._serialized_end=4282
+  _globals['_REGISTERAGENTREQUEST']._serialized_start=4284
+  _globals['_REGISTERAGENTREQUEST']._serialized_end=4408
+  _globals['_REGISTERAGENTRESPONSE']._serialized_start=4410
+  _globals['_REGISTERAGENTRESPONSE']._serialized_end=4530
+  _globals['_SCOUTDISCOVERY']._serialized_start=4533
+  _globals['_SCOUTDISCOVERY']._serialized_end=4852
+  _globals['_RECORDSCOUTDISCOVERIESREQUEST']._serialized_start=4854
+  _globals['_RECORDSCOUTDISCOVERIESREQUEST']._serialized_end=4953
+  _globals['_RECORDSCOUTDISCOVERIESRESPONSE']._serialized_start=4955
+  _globals['_RECORDSCOUTDISCOVERIESRESPONSE']._serialized_end=5039
+  _globals['_LISTSCOUTDISCOVERIESREQUEST']._serialized_start=5042
+  _globals['_LISTSCOUTDISCOVERIESREQUEST']._serialized_end=5175
+  _globals['_LISTSCOUTDISCOVERIESRESPONSE']._serialized_start=5177
+  _globals['_LISTSCOUTDISCOVERIESRESPONSE']._serialized_end=5259
+  _globals['_GETSCOUTCREDENTIALREQUEST']._serialized_start=5261
+  _globals['_GETSCOUTCREDENTIALREQUEST']._serialized_end=5307
+  _globals['_GETSCOUTCREDENTIALRESPONSE']._serialized_start=5309
+  _globals['_GETSCOUTCREDENTIALRESPONSE']._serialized_end=5357
+  _globals['_FREQSEARCHSERVICE']._serialized_start=6307
+  _globals['_FREQSEARCHSERVICE']._serialized_end=9534
 # @@protoc_insertion_point(module_scope)
@@ -31,6 +31,7 @@ class OptimizationStatus(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
     OPTIMIZATION_STATUS_COMPLETED: _ClassVar[OptimizationStatus]
     OPTIMIZATION_STATUS_FAILED: _ClassVar[OptimizationStatus]
     OPTIMIZATION_STATUS_CANCELLED: _ClassVar[OptimizationStatus]
+    OPTIMIZATION_STATUS_STALLED: _ClassVar[OptimizationStatus]
 
 class OptimizationAction(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
     __slots__ = ()
@@ -40,6 +41,15 @@ class OptimizationAction(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
     OPTIMIZATION_ACTION_CANCEL: _ClassVar[OptimizationAction]
     OPTIMIZATION_ACTION_COMPLETE: _ClassVar[OptimizationAction]
     OPTIMIZATION_ACTION_FAIL: _ClassVar[OptimizationAction]
+
+class NextActionType(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    NEXT_ACTION_TYPE_UNSPECIFIED: _ClassVar[NextActionType]
+    NEXT_ACTION_TYPE_NONE: _ClassVar[NextActionType]
+    NEXT_ACTION_TYPE_GENERATE_CANDIDATE: _ClassVar[NextActionType]
+    NEXT_ACTION_TYPE_AWAIT_RESULTS: _ClassVar[NextActionType]
+    NEXT_ACTION_TYPE_EVALUATE_CRITERIA: _ClassVar[NextActionType]
+    NEXT_ACTION_TYPE_FINALIZE: _ClassVar[NextActionType]
 OPTIMIZATION_MODE_UNSPECIFIED: OptimizationMode
 OPTIMIZATION_MODE_MAXIMIZE_SHARPE: OptimizationMode
 OPTIMIZATION_MODE_MAXIMIZE_PROFIT: OptimizationMode
@@ -52,15 +62,22 @@ OPTIMIZATION_STATUS_PAUSED: OptimizationStatus
 OPTIMIZATION_STATUS_COMPLETED: OptimizationStatus
 OPTIMIZATION_STATUS_FAILED: OptimizationStatus
 OPTIMIZATION_STATUS_CANCELLED: OptimizationStatus
+OPTIMIZATION_STATUS_STALLED: OptimizationStatus
 OPTIMIZATION_ACTION_UNSPECIFIED: OptimizationAction
 OPTIMIZATION_ACTION_PAUSE: OptimizationAction
 OPTIMIZATION_ACTION_RESUME: OptimizationAction
 OPTIMIZATION_ACTION_CANCEL: OptimizationAction
 OPTIMIZATION_ACTION_COMPLETE: OptimizationAction
 OPTIMIZATION_ACTION_FAIL: OptimizationAction
+NEXT_ACTION_TYPE_UNSPECIFIED: NextActionType
+NEXT_ACTION_TYPE_NONE: NextActionType
+NEXT_ACTION_TYPE_GENERATE_CANDIDATE: NextActionType
+NEXT_ACTION_TYPE_AWAIT_RESULTS: NextActionType
+NEXT_ACTION_TYPE_EVALUATE_CRITERIA: NextActionType
+NEXT_ACTION_TYPE_FINALIZE: NextActionType
 
 class OptimizationRun(_message.Message):
-    __slots__ = ("id", "name", "base_strategy_id", "config", "status", "current_iteration", "max_iterations", "best_strategy_id", "best_result", "termination_reason", "created_at", "updated_at", "completed_at")
+    __slots__ = ("id", "name", "base_strategy_id", "config", "status", "current_iteration", "max_iterations", "best_strategy_id", "best_result", "termination_reason", "created_at", "updated_at", "completed_at", "external_ref", "seed_strategy_ids", "cancel_reason", "cancelled_by")
     ID_FIELD_NUMBER: _ClassVar[int]
     NAME_FIELD_NUMBER: _ClassVar[int]
     BASE_STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
@@ -74,6 +91,10 @@ class OptimizationRun(_message.Message):
     CREATED_AT_FIELD_NUMBER: _ClassVar[int]
     UPDATED_AT_FIELD_NUMBER: _ClassVar[int]
     COMPLETED_AT_FIELD_NUMBER: _ClassVar[int]
+    EXTERNAL_REF_FIELD_NUMBER: _ClassVar[int]
+    SEED_STRATEGY_IDS_FIELD_NUMBER: _ClassVar[int]
+    CANCEL_REASON_FIELD_NUMBER: _ClassVar[int]
+    CANCELLED_BY_FIELD_NUMBER: _ClassVar[int]
     id: str
     name: str
     base_strategy_id: str
@@ -87,19 +108,37 @@ class OptimizationRun(_message.Message):
     created_at: _timestamp_pb2.Timestamp
     updated_at: _timestamp_pb2.Timestamp
     completed_at: _timestamp_pb2.Timestamp
-    def __init__(self, id: _Optional[str] = ..., name: _Optional[str] = ..., base_strategy_id: _Optional[str] = ..., config: _Optional[_Union[OptimizationConfig, _Mapping]] = ..., status: _Optional[_Union[OptimizationStatus, str]] = ..., current_iteration: _Optional[int] = ..., max_iterations: _Optional[int] = ..., best_strategy_id: _Optional[str] = ..., best_result: _Optional[_Union[_backtest_pb2.BacktestResult, _Mapping]] = ..., termination_reason: _Optional[str] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., updated_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., completed_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+    external_ref: str
+    seed_strategy_ids: _containers.RepeatedScalarFieldContainer[str]
+    cancel_reason: str
+    cancelled_by: str
+    def __init__(self, id: _Optional[str] = ..., name: _Optional[str] = ..., base_strategy_id: _Optional[str] = ..., config: _Optional[_Union[OptimizationConfig, _Mapping]] = ..., status: _Optional[_Union[OptimizationStatus, str]] = ..., current_iteration: _Optional[int] = ..., max_iterations: _Optional[int] = ..., best_strategy_id: _Optional[str] = ..., best_result: _Optional[_Union[_backtest_pb2.BacktestResult, _Mapping]] = ..., termination_reason: _Optional[str] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., updated_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., completed_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., external_ref: _Optional[str] = ..., seed_strategy_ids: _Optional[_Iterable[str]] = ..., cancel_reason: _Optional[str] = ..., cancelled_by: _Optional[str] = ...) -> None: ...
 
 class OptimizationConfig(_message.Message):
-    __slots__ = ("backtest_config", "max_iterations", "criteria", "mode")
+    __slots__ = ("backtest_config", "max_iterations", "criteria", "mode", "snapshot_code", "quota")
     BACKTEST_CONFIG_FIELD_NUMBER: _ClassVar[int]
     MAX_ITERATIONS_FIELD_NUMBER: _ClassVar[int]
     CRITERIA_FIELD_NUMBER: _ClassVar[int]
     MODE_FIELD_NUMBER: _ClassVar[int]
+    SNAPSHOT_CODE_FIELD_NUMBER: _ClassVar[int]
+    QUOTA_FIELD_NUMBER: _ClassVar[int]
     backtest_config: _backtest_pb2.BacktestConfig
     max_iterations: int
     criteria: OptimizationCriteria
     mode: OptimizationMode
-    def __init__(self, backtest_config: _Optional[_Union[_backtest_pb2.BacktestConfig, _Mapping]] = ..., max_iterations: _Optional[int] = ..., criteria: _Optional[_Union[OptimizationCriteria, _Mapping]] = ..., mode: _Optional[_Union[OptimizationMode, str]] = ...) -> None: ...
+    snapshot_code: bool
+    quota: OptimizationQuota
+    def __init__(self, backtest_config: _Optional[_Union[_backtest_pb2.BacktestConfig, _Mapping]] = ..., max_iterations: _Optional[int] = ..., criteria: _Optional[_Union[OptimizationCriteria, _Mapping]] = ..., mode: _Optional[_Union[OptimizationMode, str]] = ..., snapshot_code: bool = ..., quota: _Optional[_Union[OptimizationQuota, _Mapping]] = ...) -> None: ...
+
+class OptimizationQuota(_message.Message):
+    __slots__ = ("max_concurrent_jobs", "cpu_limit", "memory_limit_mb")
+    MAX_CONCURRENT_JOBS_FIELD_NUMBER: _ClassVar[int]
+    CPU_LIMIT_FIELD_NUMBER: _ClassVar[int]
+    MEMORY_LIMIT_MB_FIELD_NUMBER: _ClassVar[int]
+    max_concurrent_jobs: int
+    cpu_limit: float
+    memory_limit_mb: int
+    def __init__(self, max_concurrent_jobs: _Optional[int] = ..., cpu_limit: _Optional[float] = ..., memory_limit_mb: _Optional[int] = ...) -> None: ...
 
 class OptimizationCriteria(_message.Message):
     __slots__ = ("min_sharpe", "min_profit_pct", "max_drawdown_pct", "min_trades", "min_win_rate")
@@ -116,7 +155,7 @@ class OptimizationCriteria(_message.Message):
     def __init__(self, min_sharpe: _Optional[float] = ..., min_profit_pct: _Optional[float] = ..., max_drawdown_pct: _Optional[float] = ..., min_trades: _Optional[int] = ..., min_win_rate: _Optional[float] = ...) -> None: ...
 
 class OptimizationIteration(_message.Message):
-    __slots__ = ("iteration_number", "strategy_id", "backtest_job_id", "result", "engineer_changes", "analyst_feedback", "approval", "timestamp")
+    __slots__ = ("iteration_number", "strategy_id", "backtest_job_id", "result", "engineer_changes", "analyst_feedback", "approval", "timestamp", "code_hash", "code_snapshot")
     ITERATION_NUMBER_FIELD_NUMBER: _ClassVar[int]
     STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
     BACKTEST_JOB_ID_FIELD_NUMBER: _ClassVar[int]
@@ -125,6 +164,8 @@ class OptimizationIteration(_message.Message):
     ANALYST_FEEDBACK_FIELD_NUMBER: _ClassVar[int]
     APPROVAL_FIELD_NUMBER: _ClassVar[int]
     TIMESTAMP_FIELD_NUMBER: _ClassVar[int]
+    CODE_HASH_FIELD_NUMBER: _ClassVar[int]
+    CODE_SNAPSHOT_FIELD_NUMBER: _ClassVar[int]
     iteration_number: int
     strategy_id: str
     backtest_job_id: str
@@ -133,17 +174,41 @@ class OptimizationIteration(_message.Message):
     analyst_feedback: str
     approval: _common_pb2.ApprovalStatus
     timestamp: _timestamp_pb2.Timestamp
-    def __init__(self, iteration_number: _Optional[int] = ..., strategy_id: _Optional[str] = ..., backtest_job_id: _Optional[str] = ..., result: _Optional[_Union[_backtest_pb2.BacktestResult, _Mapping]] = ..., engineer_changes: _Optional[str] = ..., analyst_feedback: _Optional[str] = ..., approval: _Optional[_Union[_common_pb2.ApprovalStatus, str]] = ..., timestamp: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+    code_hash: str
+    code_snapshot: str
+    def __init__(self, iteration_number: _Optional[int] = ..., strategy_id: _Optional[str] = ..., backtest_job_id: _Optional[str] = ..., result: _Optional[_Union[_backtest_pb2.BacktestResult, _Mapping]] = ..., engineer_changes: _Optional[str] = ..., analyst_feedback: _Optional[str] = ..., approval: _Optional[_Union[_common_pb2.ApprovalStatus, str]] = ..., timestamp: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., code_hash: _Optional[str] = ..., code_snapshot: _Optional[str] = ...) -> None: ...
+
+class OptimizationProgress(_message.Message):
+    __slots__ = ("completed_iterations", "max_iterations", "percent_complete", "elapsed_ms", "avg_iteration_ms", "remaining_ms", "estimated_completion_at")
+    COMPLETED_ITERATIONS_FIELD_NUMBER: _ClassVar[int]
+    MAX_ITERATIONS_FIELD_NUMBER: _ClassVar[int]
+    PERCENT_COMPLETE_FIELD_NUMBER: _ClassVar[int]
+    ELAPSED_MS_FIELD_NUMBER: _ClassVar[int]
+    AVG_ITERATION_MS_FIELD_NUMBER: _ClassVar[int]
+    REMAINING_MS_FIELD_NUMBER: _ClassVar[int]
+    ESTIMATED_COMPLETION_AT_FIELD_NUMBER: _ClassVar[int]
+    completed_iterations: int
+    max_iterations: int
+    percent_complete: float
+    elapsed_ms: int
+    avg_iteration_ms: int
+    remaining_ms: int
+    estimated_completion_at: _timestamp_pb2.Timestamp
+    def __init__(self, completed_iterations: _Optional[int] = ..., max_iterations: _Optional[int] = ..., percent_complete: _Optional[float] = ..., elapsed_ms: _Optional[int] = ..., avg_iteration_ms: _Optional[int] = ..., remaining_ms: _Optional[int] = ..., estimated_completion_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
 
 class StartOptimizationRequest(_message.Message):
-    __slots__ = ("name", "base_strategy_id", "config")
+    __slots__ = ("name", "base_strategy_id", "config", "external_ref", "base_strategy_ids")
     NAME_FIELD_NUMBER: _ClassVar[int]
     BASE_STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
     CONFIG_FIELD_NUMBER: _ClassVar[int]
+    EXTERNAL_REF_FIELD_NUMBER: _ClassVar[int]
+    BASE_STRATEGY_IDS_FIELD_NUMBER: _ClassVar[int]
     name: str
     base_strategy_id: str
     config: OptimizationConfig
-    def __init__(self, name: _Optional[str] = ..., base_strategy_id: _Optional[str] = ..., config: _Optional[_Union[OptimizationConfig, _Mapping]] = ...) -> None: ...
+    external_ref: str
+    base_strategy_ids: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, name: _Optional[str] = ..., base_strategy_id: _Optional[str] = ..., config: _Optional[_Union[OptimizationConfig, _Mapping]] = ..., external_ref: _Optional[str] = ..., base_strategy_ids: _Optional[_Iterable[str]] = ...) -> None: ...
 
 class StartOptimizationResponse(_message.Message):
     __slots__ = ("run",)
@@ -152,18 +217,22 @@ class StartOptimizationResponse(_message.Message):
     def __init__(self, run: _Optional[_Union[OptimizationRun, _Mapping]] = ...) -> None: ...
 
 class GetOptimizationRunRequest(_message.Message):
-    __slots__ = ("run_id",)
+    __slots__ = ("run_id", "external_ref")
     RUN_ID_FIELD_NUMBER: _ClassVar[int]
+    EXTERNAL_REF_FIELD_NUMBER: _ClassVar[int]
     run_id: str
-    def __init__(self, run_id: _Optional[str] = ...) -> None: ...
+    external_ref: str
+    def __init__(self, run_id: _Optional[str] = ..., external_ref: _Optional[str] = ...) -> None: ...
 
 class GetOptimizationRunResponse(_message.Message):
-    __slots__ = ("run", "iterations")
+    __slots__ = ("run", "iterations", "progress")
     RUN_FIELD_NUMBER: _ClassVar[int]
     ITERATIONS_FIELD_NUMBER: _ClassVar[int]
+    PROGRESS_FIELD_NUMBER: _ClassVar[int]
     run: OptimizationRun
     iterations: _containers.RepeatedCompositeFieldContainer[OptimizationIteration]
-    def __init__(self, run: _Optional[_Union[OptimizationRun, _Mapping]] = ..., iterations: _Optional[_Iterable[_Union[OptimizationIteration, _Mapping]]] = ...) -> None: ...
+    progress: OptimizationProgress
+    def __init__(self, run: _Optional[_Union[OptimizationRun, _Mapping]] = ..., iterations: _Optional[_Iterable[_Union[OptimizationIteration, _Mapping]]] = ..., progress: _Optional[_Union[OptimizationProgress, _Mapping]] = ...) -> None: ...
 
 class ControlOptimizationRequest(_message.Message):
     __slots__ = ("run_id", "action", "total_iterations", "best_strategy_id", "termination_reason")
@@ -224,3 +293,151 @@ class UpdateIterationFeedbackRequest(_message.Message):
     analyst_feedback: str
     approval: _common_pb2.ApprovalStatus
     def __init__(self, iteration_id: _Optional[str] = ..., engineer_changes: _Optional[str] = ..., analyst_feedback: _Optional[str] = ..., approval: _Optional[_Union[_common_pb2.ApprovalStatus, str]] = ...) -> None: ...
+
+class ClaimNextOptimizationActionRequest(_message.Message):
+    __slots__ = ("run_id", "claimant", "lease_seconds")
+    RUN_ID_FIELD_NUMBER: _ClassVar[int]
+    CLAIMANT_FIELD_NUMBER: _ClassVar[int]
+    LEASE_SECONDS_FIELD_NUMBER: _ClassVar[int]
+    run_id: str
+    claimant: str
+    lease_seconds: int
+    def __init__(self, run_id: _Optional[str] = ..., claimant: _Optional[str] = ..., lease_seconds: _Optional[int] = ...) -> None: ...
+
+class ClaimNextOptimizationActionResponse(_message.Message):
+    __slots__ = ("run_id", "action", "iteration_number", "reason", "source_strategy_id", "feedback", "iteration", "result_id", "claimed_by", "claim_expires_at")
+    RUN_ID_FIELD_NUMBER: _ClassVar[int]
+    ACTION_FIELD_NUMBER: _ClassVar[int]
+    ITERATION_NUMBER_FIELD_NUMBER: _ClassVar[int]
+    REASON_FIELD_NUMBER: _ClassVar[int]
+    SOURCE_STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
+    FEEDBACK_FIELD_NUMBER: _ClassVar[int]
+    ITERATION_FIELD_NUMBER: _ClassVar[int]
+    RESULT_ID_FIELD_NUMBER: _ClassVar[int]
+    CLAIMED_BY_FIELD_NUMBER: _ClassVar[int]
+    CLAIM_EXPIRES_AT_FIELD_NUMBER: _ClassVar[int]
+    run_id: str
+    action: NextActionType
+    iteration_number: int
+    reason: str
+    source_strategy_id: str
+    feedback: str
+    iteration: OptimizationIteration
+    result_id: str
+    claimed_by: str
+    claim_expires_at: _timestamp_pb2.Timestamp
+    def __init__(self, run_id: _Optional[str] = ..., action: _Optional[_Union[NextActionType, str]] = ..., iteration_number: _Optional[int] = ..., reason: _Optional[str] = ..., source_strategy_id: _Optional[str] = ..., feedback: _Optional[str] = ..., iteration: _Optional[_Union[OptimizationIteration, _Mapping]] = ..., result_id: _Optional[str] = ..., claimed_by: _Optional[str] = ..., claim_expires_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+
+class AgentRegistration(_message.Message):
+    __slots__ = ("agent_id", "type", "version", "event_schema_versions", "capabilities", "registered_at", "last_seen_at")
+    AGENT_ID_FIELD_NUMBER: _ClassVar[int]
+    TYPE_FIELD_NUMBER: _ClassVar[int]
+    VERSION_FIELD_NUMBER: _ClassVar[int]
+    EVENT_SCHEMA_VERSIONS_FIELD_NUMBER: _ClassVar[int]
+    CAPABILITIES_FIELD_NUMBER: _ClassVar[int]
+    REGISTERED_AT_FIELD_NUMBER: _ClassVar[int]
+    LAST_SEEN_AT_FIELD_NUMBER: _ClassVar[int]
+    agent_id: str
+    type: str
+    version: str
+    event_schema_versions: _containers.RepeatedScalarFieldContainer[int]
+    capabilities: _containers.RepeatedScalarFieldContainer[str]
+    registered_at: _timestamp_pb2.Timestamp
+    last_seen_at: _timestamp_pb2.Timestamp
+    def __init__(self, agent_id: _Optional[str] = ..., type: _Optional[str] = ..., version: _Optional[str] = ..., event_schema_versions: _Optional[_Iterable[int]] = ..., capabilities: _Optional[_Iterable[str]] = ..., registered_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., last_seen_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+
+class RegisterAgentRequest(_message.Message):
+    __slots__ = ("agent_id", "type", "version", "event_schema_versions", "capabilities")
+    AGENT_ID_FIELD_NUMBER: _ClassVar[int]
+    TYPE_FIELD_NUMBER: _ClassVar[int]
+    VERSION_FIELD_NUMBER: _ClassVar[int]
+    EVENT_SCHEMA_VERSIONS_FIELD_NUMBER: _ClassVar[int]
+    CAPABILITIES_FIELD_NUMBER: _ClassVar[int]
+    agent_id: str
+    type: str
+    version: str
+    event_schema_versions: _containers.RepeatedScalarFieldContainer[int]
+    capabilities: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, agent_id: _Optional[str] = ..., type: _Optional[str] = ..., version: _Optional[str] = ..., event_schema_versions: _Optional[_Iterable[int]] = ..., capabilities: _Optional[_Iterable[str]] = ...) -> None: ...
+
+class RegisterAgentResponse(_message.Message):
+    __slots__ = ("agent", "event_schema_version", "warnings")
+    AGENT_FIELD_NUMBER: _ClassVar[int]
+    EVENT_SCHEMA_VERSION_FIELD_NUMBER: _ClassVar[int]
+    WARNINGS_FIELD_NUMBER: _ClassVar[int]
+    agent: AgentRegistration
+    event_schema_version: int
+    warnings: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, agent: _Optional[_Union[AgentRegistration, _Mapping]] = ..., event_schema_version: _Optional[int] = ..., warnings: _Optional[_Iterable[str]] = ...) -> None: ...
+
+class ScoutDiscovery(_message.Message):
+    __slots__ = ("id", "run_id", "name", "source", "source_url", "code_hash", "validation_status", "validation_errors", "outcome", "imported", "strategy_id", "outcome_detail", "discovered_at")
+    ID_FIELD_NUMBER: _ClassVar[int]
+    RUN_ID_FIELD_NUMBER: _ClassVar[int]
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    SOURCE_FIELD_NUMBER: _ClassVar[int]
+    SOURCE_URL_FIELD_NUMBER: _ClassVar[int]
+    CODE_HASH_FIELD_NUMBER: _ClassVar[int]
+    VALIDATION_STATUS_FIELD_NUMBER: _ClassVar[int]
+    VALIDATION_ERRORS_FIELD_NUMBER: _ClassVar[int]
+    OUTCOME_FIELD_NUMBER: _ClassVar[int]
+    IMPORTED_FIELD_NUMBER: _ClassVar[int]
+    STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
+    OUTCOME_DETAIL_FIELD_NUMBER: _ClassVar[int]
+    DISCOVERED_AT_FIELD_NUMBER: _ClassVar[int]
+    id: str
+    run_id: str
+    name: str
+    source: str
+    source_url: str
+    code_hash: str
+    validation_status: str
+    validation_errors: _containers.RepeatedScalarFieldContainer[str]
+    outcome: str
+    imported: bool
+    strategy_id: str
+    outcome_detail: str
+    discovered_at: _timestamp_pb2.Timestamp
+    def __init__(self, id: _Optional[str] = ..., run_id: _Optional[str] = ..., name: _Optional[str] = ..., source: _Optional[str] = ..., source_url: _Optional[str] = ..., code_hash: _Optional[str] = ..., validation_status: _Optional[str] = ..., validation_errors: _Optional[_Iterable[str]] = ..., outcome: _Optional[str] = ..., imported: bool = ..., strategy_id: _Optional[str] = ..., outcome_detail: _Optional[str] = ..., discovered_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+
+class RecordScoutDiscoveriesRequest(_message.Message):
+    __slots__ = ("run_id", "discoveries")
+    RUN_ID_FIELD_NUMBER: _ClassVar[int]
+    DISCOVERIES_FIELD_NUMBER: _ClassVar[int]
+    run_id: str
+    discoveries: _containers.RepeatedCompositeFieldContainer[ScoutDiscovery]
+    def __init__(self, run_id: _Optional[str] = ..., discoveries: _Optional[_Iterable[_Union[ScoutDiscovery, _Mapping]]] = ...) -> None: ...
+
+class RecordScoutDiscoveriesResponse(_message.Message):
+    __slots__ = ("discoveries",)
+    DISCOVERIES_FIELD_NUMBER: _ClassVar[int]
+    discoveries: _containers.RepeatedCompositeFieldContainer[ScoutDiscovery]
+    def __init__(self, discoveries: _Optional[_Iterable[_Union[ScoutDiscovery, _Mapping]]] = ...) -> None: ...
+
+class ListScoutDiscoveriesRequest(_message.Message):
+    __slots__ = ("run_id", "outcome", "validation_status")
+    RUN_ID_FIELD_NUMBER: _ClassVar[int]
+    OUTCOME_FIELD_NUMBER: _ClassVar[int]
+    VALIDATION_STATUS_FIELD_NUMBER: _ClassVar[int]
+    run_id: str
+    outcome: str
+    validation_status: str
+    def __init__(self, run_id: _Optional[str] = ..., outcome: _Optional[str] = ..., validation_status: _Optional[str] = ...) -> None: ...
+
+class ListScoutDiscoveriesResponse(_message.Message):
+    __slots__ = ("discoveries",)
+    DISCOVERIES_FIELD_NUMBER: _ClassVar[int]
+    discoveries: _containers.RepeatedCompositeFieldContainer[ScoutDiscovery]
+    def __init__(self, discoveries: _Optional[_Iterable[_Union[ScoutDiscovery, _Mapping]]] = ...) -> None: ...
+
+class GetScoutCredentialRequest(_message.Message):
+    __slots__ = ("secret_id",)
+    SECRET_ID_FIELD_NUMBER: _ClassVar[int]
+    secret_id: str
+    def __init__(self, secret_id: _Optional[str] = ...) -> None: ...
+
+class GetScoutCredentialResponse(_message.Message):
+    __slots__ = ("credential",)
+    CREDENTIAL_FIELD_NUMBER: _ClassVar[int]
+    credential: str
+    def __init__(self, credential: _Optional[str] = ...) -> None: ...
@@ -71,6 +71,21 @@ class FreqSearchServiceStub(object):
                 request_serializer=freqsearch_dot_v1_dot_strategy__pb2.ValidateStrategyRequest.SerializeToString,
                 response_deserializer=freqsearch_dot_v1_dot_strategy__pb2.ValidateStrategyResponse.FromString,
                 _registered_method=True)
+        self.GetStrategyStatistics = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/GetStrategyStatistics',
+                request_serializer=freqsearch_dot_v1_dot_strategy__pb2.GetStrategyStatisticsRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_strategy__pb2.GetStrategyStatisticsResponse.FromString,
+                _registered_method=True)
+        self.SetStrategyDescription = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/SetStrategyDescription',
+                request_serializer=freqsearch_dot_v1_dot_strategy__pb2.SetStrategyDescriptionRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_strategy__pb2.SetStrategyDescriptionResponse.FromString,
+                _registered_method=True)
+        self.DiffStrategies = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/DiffStrategies',
+                request_serializer=freqsearch_dot_v1_dot_strategy__pb2.DiffStrategiesRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_strategy__pb2.DiffStrategiesResponse.FromString,
+                _registered_method=True)
         self.SubmitBacktest = channel.unary_unary(
                 '/freqsearch.v1.FreqSearchService/SubmitBacktest',
                 request_serializer=freqsearch_dot_v1_dot_backtest__pb2.SubmitBacktestRequest.SerializeToString,
@@ -86,6 +101,11 @@ class FreqSearchServiceStub(object):
                 request_serializer=freqsearch_dot_v1_dot_backtest__pb2.GetBacktestJobRequest.SerializeToString,
                 response_deserializer=freqsearch_dot_v1_dot_backtest__pb2.GetBacktestJobResponse.FromString,
                 _registered_method=True)
+        self.WatchBacktestJob = channel.unary_stream(
+                '/freqsearch.v1.FreqSearchService/WatchBacktestJob',
+                request_serializer=freqsearch_dot_v1_dot_backtest__pb2.WatchBacktestJobRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_backtest__pb2.WatchBacktestJobResponse.FromString,
+                _registered_method=True)
         self.GetBacktestResult = channel.unary_unary(
                 '/freqsearch.v1.FreqSearchService/GetBacktestResult',
                 request_serializer=freqsearch_dot_v1_dot_backtest__pb2.GetBacktestResultRequest.SerializeToString,
@@ -106,6 +126,16 @@ class FreqSearchServiceStub(object):
                 request_serializer=freqsearch_dot_v1_dot_backtest__pb2.GetQueueStatsRequest.SerializeToString,
                 response_deserializer=freqsearch_dot_v1_dot_backtest__pb2.GetQueueStatsResponse.FromString,
                 _registered_method=True)
+        self.GetSchedulerStatus = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/GetSchedulerStatus',
+                request_serializer=freqsearch_dot_v1_dot_backtest__pb2.GetSchedulerStatusRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_backtest__pb2.GetSchedulerStatusResponse.FromString,
+                _registered_method=True)
+        self.ControlScheduler = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/ControlScheduler',
+                request_serializer=freqsearch_dot_v1_dot_backtest__pb2.ControlSchedulerRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_backtest__pb2.ControlSchedulerResponse.FromString,
+                _registered_method=True)
         self.StartOptimization = channel.unary_unary(
                 '/freqsearch.v1.FreqSearchService/StartOptimization',
                 request_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.StartOptimizationRequest.SerializeToString,
@@ -136,6 +166,31 @@ class FreqSearchServiceStub(object):
                 request_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.UpdateIterationFeedbackRequest.SerializeToString,
                 response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
                 _registered_method=True)
+        self.ClaimNextOptimizationAction = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/ClaimNextOptimizationAction',
+                request_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.ClaimNextOptimizationActionRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.ClaimNextOptimizationActionResponse.FromString,
+                _registered_method=True)
+        self.RegisterAgent = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/RegisterAgent',
+                request_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.RegisterAgentRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.RegisterAgentResponse.FromString,
+                _registered_method=True)
+        self.RecordScoutDiscoveries = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/RecordScoutDiscoveries',
+                request_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.RecordScoutDiscoveriesRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.RecordScoutDiscoveriesResponse.FromString,
+                _registered_method=True)
+        self.ListScoutDiscoveries = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/ListScoutDiscoveries',
+                request_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.ListScoutDiscoveriesRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.ListScoutDiscoveriesResponse.FromString,
+                _registered_method=True)
+        self.GetScoutCredential = channel.unary_unary(
+                '/freqsearch.v1.FreqSearchService/GetScoutCredential',
+                request_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.GetScoutCredentialRequest.SerializeToString,
+                response_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.GetScoutCredentialResponse.FromString,
+                _registered_method=True)
         self.HealthCheck = channel.unary_unary(
                 '/freqsearch.v1.FreqSearchService/HealthCheck',
                 request_serializer=freqsearch_dot_v1_dot_common__pb2.HealthCheckRequest.SerializeToString,
@@ -191,6 +246,27 @@ class FreqSearchServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def GetStrategyStatistics(self, request, context):
+        """Get aggregated result statistics (mean/median/stddev) for a strategy, computed in SQL
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def SetStrategyDescription(self, request, context):
+        """Set a generated description on a strategy lacking one (answers strategy.needs_description)
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def DiffStrategies(self, request, context):
+        """Compare a strategy's code and settings with another strategy, by default its parent
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def SubmitBacktest(self, request, context):
         """===== Backtest Operations =====
 
@@ -214,6 +290,14 @@ class FreqSearchServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def WatchBacktestJob(self, request, context):
+        """Stream a backtest job's status transitions as they happen, ending with
+        its result once it finishes
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def GetBacktestResult(self, request, context):
         """Get backtest result only
         """
@@ -242,6 +326,20 @@ class FreqSearchServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def GetSchedulerStatus(self, request, context):
+        """Get whether the backtest scheduler dequeues jobs
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def ControlScheduler(self, request, context):
+        """Pause, drain or resume the backtest scheduler
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def StartOptimization(self, request, context):
         """===== Optimization Operations =====
 
@@ -286,6 +384,49 @@ class FreqSearchServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def ClaimNextOptimizationAction(self, request, context):
+        """Atomically determine and claim the next step of an optimization run, so
+        multiple orchestrator replicas don't duplicate iterations
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def RegisterAgent(self, request, context):
+        """===== Agents =====
+
+        Register an agent instance with its version, event schema versions and
+        capabilities; registering again with the same agent_id replaces it
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def RecordScoutDiscoveries(self, request, context):
+        """===== Scout =====
+
+        Record the strategies a Scout run fetched and how they validated,
+        including those it did not submit for import
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def ListScoutDiscoveries(self, request, context):
+        """List the strategies a Scout run discovered and whether they were imported
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def GetScoutCredential(self, request, context):
+        """Decrypt the source credential a scout.trigger event references, for the
+        Scout agent; operators only, and not served over gRPC-Web
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def HealthCheck(self, request, context):
         """===== Health =====
 
@@ -328,6 +469,21 @@ def add_FreqSearchServiceServicer_to_server(servicer, server):
                     request_deserializer=freqsearch_dot_v1_dot_strategy__pb2.ValidateStrategyRequest.FromString,
                     response_serializer=freqsearch_dot_v1_dot_strategy__pb2.ValidateStrategyResponse.SerializeToString,
             ),
+            'GetStrategyStatistics': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetStrategyStatistics,
+                    request_deserializer=freqsearch_dot_v1_dot_strategy__pb2.GetStrategyStatisticsRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_strategy__pb2.GetStrategyStatisticsResponse.SerializeToString,
+            ),
+            'SetStrategyDescription': grpc.unary_unary_rpc_method_handler(
+                    servicer.SetStrategyDescription,
+                    request_deserializer=freqsearch_dot_v1_dot_strategy__pb2.SetStrategyDescriptionRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_strategy__pb2.SetStrategyDescriptionResponse.SerializeToString,
+            ),
+            'DiffStrategies': grpc.unary_unary_rpc_method_handler(
+                    servicer.DiffStrategies,
+                    request_deserializer=freqsearch_dot_v1_dot_strategy__pb2.DiffStrategiesRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_strategy__pb2.DiffStrategiesResponse.SerializeToString,
+            ),
             'SubmitBacktest': grpc.unary_unary_rpc_method_handler(
                     servicer.SubmitBacktest,
                     request_deserializer=freqsearch_dot_v1_dot_backtest__pb2.SubmitBacktestRequest.FromString,
@@ -343,6 +499,11 @@ def add_FreqSearchServiceServicer_to_server(servicer, server):
                     request_deserializer=freqsearch_dot_v1_dot_backtest__pb2.GetBacktestJobRequest.FromString,
                     response_serializer=freqsearch_dot_v1_dot_backtest__pb2.GetBacktestJobResponse.SerializeToString,
             ),
+            'WatchBacktestJob': grpc.unary_stream_rpc_method_handler(
+                    servicer.WatchBacktestJob,
+                    request_deserializer=freqsearch_dot_v1_dot_backtest__pb2.WatchBacktestJobRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_backtest__pb2.WatchBacktestJobResponse.SerializeToString,
+            ),
             'GetBacktestResult': grpc.unary_unary_rpc_method_handler(
                     servicer.GetBacktestResult,
                     request_deserializer=freqsearch_dot_v1_dot_backtest__pb2.GetBacktestResultRequest.FromString,
@@ -363,6 +524,16 @@ def add_FreqSearchServiceServicer_to_server(servicer, server):
                     request_deserializer=freqsearch_dot_v1_dot_backtest__pb2.GetQueueStatsRequest.FromString,
                     response_serializer=freqsearch_dot_v1_dot_backtest__pb2.GetQueueStatsResponse.SerializeToString,
             ),
+            'GetSchedulerStatus': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetSchedulerStatus,
+                    request_deserializer=freqsearch_dot_v1_dot_backtest__pb2.GetSchedulerStatusRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_backtest__pb2.GetSchedulerStatusResponse.SerializeToString,
+            ),
+            'ControlScheduler': grpc.unary_unary_rpc_method_handler(
+                    servicer.ControlScheduler,
+                    request_deserializer=freqsearch_dot_v1_dot_backtest__pb2.ControlSchedulerRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_backtest__pb2.ControlSchedulerResponse.SerializeToString,
+            ),
             'StartOptimization': grpc.unary_unary_rpc_method_handler(
                     servicer.StartOptimization,
                     request_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.StartOptimizationRequest.FromString,
@@ -393,6 +564,31 @@ def add_FreqSearchServiceServicer_to_server(servicer, server):
                     request_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.UpdateIterationFeedbackRequest.FromString,
                     response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
             ),
+            'ClaimNextOptimizationAction': grpc.unary_unary_rpc_method_handler(
+                    servicer.ClaimNextOptimizationAction,
+                    request_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.ClaimNextOptimizationActionRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.ClaimNextOptimizationActionResponse.SerializeToString,
+            ),
+            'RegisterAgent': grpc.unary_unary_rpc_method_handler(
+                    servicer.RegisterAgent,
+                    request_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.RegisterAgentRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.RegisterAgentResponse.SerializeToString,
+            ),
+            'RecordScoutDiscoveries': grpc.unary_unary_rpc_method_handler(
+                    servicer.RecordScoutDiscoveries,
+                    request_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.RecordScoutDiscoveriesRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.RecordScoutDiscoveriesResponse.SerializeToString,
+            ),
+            'ListScoutDiscoveries': grpc.unary_unary_rpc_method_handler(
+                    servicer.ListScoutDiscoveries,
+                    request_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.ListScoutDiscoveriesRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.ListScoutDiscoveriesResponse.SerializeToString,
+            ),
+            'GetScoutCredential': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetScoutCredential,
+                    request_deserializer=freqsearch_dot_v1_dot_freqsearch__pb2.GetScoutCredentialRequest.FromString,
+                    response_serializer=freqsearch_dot_v1_dot_freqsearch__pb2.GetScoutCredentialResponse.SerializeToString,
+            ),
             'HealthCheck': grpc.unary_unary_rpc_method_handler(
                     servicer.HealthCheck,
                     request_deserializer=freqsearch_dot_v1_dot_common__pb2.HealthCheckRequest.FromString,
@@ -574,6 +770,87 @@ class FreqSearchService(object):
             metadata,
             _registered_method=True)
 
+    @staticmethod
+    def GetStrategyStatistics(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/GetStrategyStatistics',
+            freqsearch_dot_v1_dot_strategy__pb2.GetStrategyStatisticsRequest.SerializeToString,
+            freqsearch_dot_v1_dot_strategy__pb2.GetStrategyStatisticsResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def SetStrategyDescription(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/SetStrategyDescription',
+            freqsearch_dot_v1_dot_strategy__pb2.SetStrategyDescriptionRequest.SerializeToString,
+            freqsearch_dot_v1_dot_strategy__pb2.SetStrategyDescriptionResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def DiffStrategies(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/DiffStrategies',
+            freqsearch_dot_v1_dot_strategy__pb2.DiffStrategiesRequest.SerializeToString,
+            freqsearch_dot_v1_dot_strategy__pb2.DiffStrategiesResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
     @staticmethod
     def SubmitBacktest(request,
             target,
@@ -655,6 +932,33 @@ class FreqSearchService(object):
             metadata,
             _registered_method=True)
 
+    @staticmethod
+    def WatchBacktestJob(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_stream(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/WatchBacktestJob',
+            freqsearch_dot_v1_dot_backtest__pb2.WatchBacktestJobRequest.SerializeToString,
+            freqsearch_dot_v1_dot_backtest__pb2.WatchBacktestJobResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
     @staticmethod
     def GetBacktestResult(request,
             target,
@@ -763,6 +1067,60 @@ class FreqSearchService(object):
             metadata,
             _registered_method=True)
 
+    @staticmethod
+    def GetSchedulerStatus(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/GetSchedulerStatus',
+            freqsearch_dot_v1_dot_backtest__pb2.GetSchedulerStatusRequest.SerializeToString,
+            freqsearch_dot_v1_dot_backtest__pb2.GetSchedulerStatusResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def ControlScheduler(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/ControlScheduler',
+            freqsearch_dot_v1_dot_backtest__pb2.ControlSchedulerRequest.SerializeToString,
+            freqsearch_dot_v1_dot_backtest__pb2.ControlSchedulerResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
     @staticmethod
     def StartOptimization(request,
             target,
@@ -925,6 +1283,141 @@ class FreqSearchService(object):
             metadata,
             _registered_method=True)
 
+    @staticmethod
+    def ClaimNextOptimizationAction(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/ClaimNextOptimizationAction',
+            freqsearch_dot_v1_dot_freqsearch__pb2.ClaimNextOptimizationActionRequest.SerializeToString,
+            freqsearch_dot_v1_dot_freqsearch__pb2.ClaimNextOptimizationActionResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def RegisterAgent(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/RegisterAgent',
+            freqsearch_dot_v1_dot_freqsearch__pb2.RegisterAgentRequest.SerializeToString,
+            freqsearch_dot_v1_dot_freqsearch__pb2.RegisterAgentResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def RecordScoutDiscoveries(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/RecordScoutDiscoveries',
+            freqsearch_dot_v1_dot_freqsearch__pb2.RecordScoutDiscoveriesRequest.SerializeToString,
+            freqsearch_dot_v1_dot_freqsearch__pb2.RecordScoutDiscoveriesResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def ListScoutDiscoveries(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/ListScoutDiscoveries',
+            freqsearch_dot_v1_dot_freqsearch__pb2.ListScoutDiscoveriesRequest.SerializeToString,
+            freqsearch_dot_v1_dot_freqsearch__pb2.ListScoutDiscoveriesResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def GetScoutCredential(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/freqsearch.v1.FreqSearchService/GetScoutCredential',
+            freqsearch_dot_v1_dot_freqsearch__pb2.GetScoutCredentialRequest.SerializeToString,
+            freqsearch_dot_v1_dot_freqsearch__pb2.GetScoutCredentialResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
     @staticmethod
     def HealthCheck(request,
             target,
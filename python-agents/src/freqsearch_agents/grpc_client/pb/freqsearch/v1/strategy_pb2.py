@@ -26,7 +26,7 @@ from google.protobuf import timestamp_pb2 as google_dot_protobuf_dot_timestamp__
 from . import common_pb2 as freqsearch_dot_v1_dot_common__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1c\x66reqsearch/v1/strategy.proto\x12\rfreqsearch.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1a\x66reqsearch/v1/common.proto\"{\n\x0cStrategyTags\x12\x15\n\rstrategy_type\x18\x01 \x03(\t\x12\x12\n\nrisk_level\x18\x02 \x01(\t\x12\x15\n\rtrading_style\x18\x03 \x01(\t\x12\x12\n\nindicators\x18\x04 \x03(\t\x12\x15\n\rmarket_regime\x18\x05 \x03(\t\"\xd2\x02\n\x08Strategy\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0c\n\x04\x63ode\x18\x03 \x01(\t\x12\x11\n\tcode_hash\x18\x04 \x01(\t\x12\x16\n\tparent_id\x18\x05 \x01(\tH\x00\x88\x01\x01\x12\x12\n\ngeneration\x18\x06 \x01(\x05\x12\x13\n\x0b\x64\x65scription\x18\x07 \x01(\t\x12\x31\n\x08metadata\x18\x08 \x01(\x0b\x32\x1f.freqsearch.v1.StrategyMetadata\x12)\n\x04tags\x18\x0b \x01(\x0b\x32\x1b.freqsearch.v1.StrategyTags\x12.\n\ncreated_at\x18\t \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nupdated_at\x18\n \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x0c\n\n_parent_id\"\xc0\x02\n\x10StrategyMetadata\x12\x11\n\ttimeframe\x18\x01 \x01(\t\x12\x12\n\nindicators\x18\x02 \x03(\t\x12\x10\n\x08stoploss\x18\x03 \x01(\x01\x12\x15\n\rtrailing_stop\x18\x04 \x01(\x08\x12\x1e\n\x16trailing_stop_positive\x18\x05 \x01(\x01\x12%\n\x1dtrailing_stop_positive_offset\x18\x06 \x01(\x01\x12\x44\n\x0bminimal_roi\x18\x07 \x03(\x0b\x32/.freqsearch.v1.StrategyMetadata.MinimalRoiEntry\x12\x1c\n\x14startup_candle_count\x18\x08 \x01(\x05\x1a\x31\n\x0fMinimalRoiEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"\x98\x01\n\x13StrategyWithMetrics\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\x12>\n\x0b\x62\x65st_result\x18\x02 \x01(\x0b\x32).freqsearch.v1.StrategyPerformanceMetrics\x12\x16\n\x0e\x62\x61\x63ktest_count\x18\x03 \x01(\x05\"\xb6\x01\n\x1aStrategyPerformanceMetrics\x12\x14\n\x0csharpe_ratio\x18\x01 \x01(\x01\x12\x15\n\rsortino_ratio\x18\x02 \x01(\x01\x12\x12\n\nprofit_pct\x18\x03 \x01(\x01\x12\x18\n\x10max_drawdown_pct\x18\x04 \x01(\x01\x12\x14\n\x0ctotal_trades\x18\x05 \x01(\x05\x12\x10\n\x08win_rate\x18\x06 \x01(\x01\x12\x15\n\rprofit_factor\x18\x07 \x01(\x01\"\x99\x01\n\x15\x43reateStrategyRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0c\n\x04\x63ode\x18\x02 \x01(\t\x12\x16\n\tparent_id\x18\x03 \x01(\tH\x00\x88\x01\x01\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12)\n\x04tags\x18\x05 \x01(\x0b\x32\x1b.freqsearch.v1.StrategyTagsB\x0c\n\n_parent_id\"C\n\x16\x43reateStrategyResponse\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\" \n\x12GetStrategyRequest\x12\n\n\x02id\x18\x01 \x01(\t\"@\n\x13GetStrategyResponse\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\"\xd4\x02\n\x17SearchStrategiesRequest\x12\x19\n\x0cname_pattern\x18\x01 \x01(\tH\x00\x88\x01\x01\x12\x17\n\nmin_sharpe\x18\x02 \x01(\x01H\x01\x88\x01\x01\x12\x1b\n\x0emin_profit_pct\x18\x03 \x01(\x01H\x02\x88\x01\x01\x12\x17\n\nmin_trades\x18\x04 \x01(\x05H\x03\x88\x01\x01\x12\x1d\n\x10max_drawdown_pct\x18\x05 \x01(\x01H\x04\x88\x01\x01\x12\x34\n\npagination\x18\x06 \x01(\x0b\x32 .freqsearch.v1.PaginationRequest\x12\x10\n\x08order_by\x18\x07 \x01(\t\x12\x11\n\tascending\x18\x08 \x01(\x08\x42\x0f\n\r_name_patternB\r\n\x0b_min_sharpeB\x11\n\x0f_min_profit_pctB\r\n\x0b_min_tradesB\x13\n\x11_max_drawdown_pct\"\x89\x01\n\x18SearchStrategiesResponse\x12\x36\n\nstrategies\x18\x01 \x03(\x0b\x32\".freqsearch.v1.StrategyWithMetrics\x12\x35\n\npagination\x18\x02 \x01(\x0b\x32!.freqsearch.v1.PaginationResponse\"?\n\x19GetStrategyLineageRequest\x12\x13\n\x0bstrategy_id\x18\x01 \x01(\t\x12\r\n\x05\x64\x65pth\x18\x02 \x01(\x05\"Q\n\x1aGetStrategyLineageResponse\x12\x33\n\x07lineage\x18\x01 \x03(\x0b\x32\".freqsearch.v1.StrategyLineageNode\"\xc3\x01\n\x13StrategyLineageNode\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\x12?\n\x07metrics\x18\x02 \x01(\x0b\x32).freqsearch.v1.StrategyPerformanceMetricsH\x00\x88\x01\x01\x12\x34\n\x08\x63hildren\x18\x03 \x03(\x0b\x32\".freqsearch.v1.StrategyLineageNodeB\n\n\x08_metrics\"#\n\x15\x44\x65leteStrategyRequest\x12\n\n\x02id\x18\x01 \x01(\t\")\n\x16\x44\x65leteStrategyResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\"5\n\x17ValidateStrategyRequest\x12\x0c\n\x04\x63ode\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\"_\n\x18ValidateStrategyResponse\x12\r\n\x05valid\x18\x01 \x01(\x08\x12\x0e\n\x06\x65rrors\x18\x02 \x03(\t\x12\x10\n\x08warnings\x18\x03 \x03(\t\x12\x12\n\nclass_name\x18\x04 \x01(\tBMZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1b\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1c\x66reqsearch/v1/strategy.proto\x12\rfreqsearch.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1a\x66reqsearch/v1/common.proto\"{\n\x0cStrategyTags\x12\x15\n\rstrategy_type\x18\x01 \x03(\t\x12\x12\n\nrisk_level\x18\x02 \x01(\t\x12\x15\n\rtrading_style\x18\x03 \x01(\t\x12\x12\n\nindicators\x18\x04 \x03(\t\x12\x15\n\rmarket_regime\x18\x05 \x03(\t\"\xd0\x03\n\x08Strategy\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0c\n\x04\x63ode\x18\x03 \x01(\t\x12\x11\n\tcode_hash\x18\x04 \x01(\t\x12\x16\n\tparent_id\x18\x05 \x01(\tH\x00\x88\x01\x01\x12\x12\n\ngeneration\x18\x06 \x01(\x05\x12\x13\n\x0b\x64\x65scription\x18\x07 \x01(\t\x12\x31\n\x08metadata\x18\x08 \x01(\x0b\x32\x1f.freqsearch.v1.StrategyMetadata\x12)\n\x04tags\x18\x0b \x01(\x0b\x32\x1b.freqsearch.v1.StrategyTags\x12.\n\ncreated_at\x18\t \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nupdated_at\x18\n \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x19\n\x11validation_status\x18\x0c \x01(\t\x12\x19\n\x11validation_errors\x18\r \x03(\t\x12\x35\n\x0cvalidated_at\x18\x0e \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x01\x88\x01\x01\x42\x0c\n\n_parent_idB\x0f\n\r_validated_at\"\xc0\x02\n\x10StrategyMetadata\x12\x11\n\ttimeframe\x18\x01 \x01(\t\x12\x12\n\nindicators\x18\x02 \x03(\t\x12\x10\n\x08stoploss\x18\x03 \x01(\x01\x12\x15\n\rtrailing_stop\x18\x04 \x01(\x08\x12\x1e\n\x16trailing_stop_positive\x18\x05 \x01(\x01\x12%\n\x1dtrailing_stop_positive_offset\x18\x06 \x01(\x01\x12\x44\n\x0bminimal_roi\x18\x07 \x03(\x0b\x32/.freqsearch.v1.StrategyMetadata.MinimalRoiEntry\x12\x1c\n\x14startup_candle_count\x18\x08 \x01(\x05\x1a\x31\n\x0fMinimalRoiEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"\x98\x01\n\x13StrategyWithMetrics\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\x12>\n\x0b\x62\x65st_result\x18\x02 \x01(\x0b\x32).freqsearch.v1.StrategyPerformanceMetrics\x12\x16\n\x0e\x62\x61\x63ktest_count\x18\x03 \x01(\x05\"\xb6\x01\n\x1aStrategyPerformanceMetrics\x12\x14\n\x0csharpe_ratio\x18\x01 \x01(\x01\x12\x15\n\rsortino_ratio\x18\x02 \x01(\x01\x12\x12\n\nprofit_pct\x18\x03 \x01(\x01\x12\x18\n\x10max_drawdown_pct\x18\x04 \x01(\x01\x12\x14\n\x0ctotal_trades\x18\x05 \x01(\x05\x12\x10\n\x08win_rate\x18\x06 \x01(\x01\x12\x15\n\rprofit_factor\x18\x07 \x01(\x01\"\xea\x01\n\x15\x43reateStrategyRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0c\n\x04\x63ode\x18\x02 \x01(\t\x12\x16\n\tparent_id\x18\x03 \x01(\tH\x00\x88\x01\x01\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\x12)\n\x04tags\x18\x05 \x01(\x0b\x32\x1b.freqsearch.v1.StrategyTags\x12\x1e\n\x11validation_status\x18\x06 \x01(\tH\x01\x88\x01\x01\x12\x19\n\x11validation_errors\x18\x07 \x03(\tB\x0c\n\n_parent_idB\x14\n\x12_validation_status\"C\n\x16\x43reateStrategyResponse\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\" \n\x12GetStrategyRequest\x12\n\n\x02id\x18\x01 \x01(\t\"@\n\x13GetStrategyResponse\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\"\x8a\x03\n\x17SearchStrategiesRequest\x12\x19\n\x0cname_pattern\x18\x01 \x01(\tH\x00\x88\x01\x01\x12\x17\n\nmin_sharpe\x18\x02 \x01(\x01H\x01\x88\x01\x01\x12\x1b\n\x0emin_profit_pct\x18\x03 \x01(\x01H\x02\x88\x01\x01\x12\x17\n\nmin_trades\x18\x04 \x01(\x05H\x03\x88\x01\x01\x12\x1d\n\x10max_drawdown_pct\x18\x05 \x01(\x01H\x04\x88\x01\x01\x12\x34\n\npagination\x18\x06 \x01(\x0b\x32 .freqsearch.v1.PaginationRequest\x12\x10\n\x08order_by\x18\x07 \x01(\t\x12\x11\n\tascending\x18\x08 \x01(\x08\x12\x1e\n\x11validation_status\x18\t \x01(\tH\x05\x88\x01\x01\x42\x0f\n\r_name_patternB\r\n\x0b_min_sharpeB\x11\n\x0f_min_profit_pctB\r\n\x0b_min_tradesB\x13\n\x11_max_drawdown_pctB\x14\n\x12_validation_status\"\x89\x01\n\x18SearchStrategiesResponse\x12\x36\n\nstrategies\x18\x01 \x03(\x0b\x32\".freqsearch.v1.StrategyWithMetrics\x12\x35\n\npagination\x18\x02 \x01(\x0b\x32!.freqsearch.v1.PaginationResponse\"\x8c\x01\n\x19GetStrategyLineageRequest\x12\x13\n\x0bstrategy_id\x18\x01 \x01(\t\x12\r\n\x05\x64\x65pth\x18\x02 \x01(\x05\x12\x32\n\tdirection\x18\x03 \x01(\x0e\x32\x1f.freqsearch.v1.LineageDirection\x12\x17\n\x0finclude_metrics\x18\x04 \x01(\x08\"Q\n\x1aGetStrategyLineageResponse\x12\x33\n\x07lineage\x18\x01 \x03(\x0b\x32\".freqsearch.v1.StrategyLineageNode\"\x81\x03\n\x13StrategyLineageNode\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\x12?\n\x07metrics\x18\x02 \x01(\x0b\x32).freqsearch.v1.StrategyPerformanceMetricsH\x00\x88\x01\x01\x12\x34\n\x08\x63hildren\x18\x03 \x03(\x0b\x32\".freqsearch.v1.StrategyLineageNode\x12\x16\n\x0e\x62\x61\x63ktest_count\x18\x04 \x01(\x05\x12\x18\n\x0b\x62\x65st_sharpe\x18\x05 \x01(\x01H\x01\x88\x01\x01\x12\x13\n\x0b\x63hild_count\x18\x06 \x01(\x05\x12\r\n\x05level\x18\x07 \x01(\x05\x12\x19\n\x0csharpe_delta\x18\x08 \x01(\x01H\x02\x88\x01\x01\x12\x19\n\x0cprofit_delta\x18\t \x01(\x01H\x03\x88\x01\x01\x42\n\n\x08_metricsB\x0e\n\x0c_best_sharpeB\x0f\n\r_sharpe_deltaB\x0f\n\r_profit_delta\"#\n\x15\x44\x65leteStrategyRequest\x12\n\n\x02id\x18\x01 \x01(\t\")\n\x16\x44\x65leteStrategyResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\"m\n\x1cGetStrategyStatisticsRequest\x12\x13\n\x0bstrategy_id\x18\x01 \x01(\t\x12-\n\x06window\x18\x02 \x01(\x0b\x32\x18.freqsearch.v1.TimeRangeH\x00\x88\x01\x01\x42\t\n\x07_window\"i\n\x10MetricStatistics\x12\r\n\x05\x63ount\x18\x01 \x01(\x05\x12\x0c\n\x04mean\x18\x02 \x01(\x01\x12\x0e\n\x06median\x18\x03 \x01(\x01\x12\x0e\n\x06stddev\x18\x04 \x01(\x01\x12\x0b\n\x03min\x18\x05 \x01(\x01\x12\x0b\n\x03max\x18\x06 \x01(\x01\"\x8b\x03\n\x1dGetStrategyStatisticsResponse\x12\x13\n\x0bstrategy_id\x18\x01 \x01(\t\x12\x14\n\x0cresult_count\x18\x02 \x01(\x05\x12\x35\n\x0csharpe_ratio\x18\x03 \x01(\x0b\x32\x1f.freqsearch.v1.MetricStatistics\x12\x33\n\nprofit_pct\x18\x04 \x01(\x0b\x32\x1f.freqsearch.v1.MetricStatistics\x12\x39\n\x10max_drawdown_pct\x18\x05 \x01(\x0b\x32\x1f.freqsearch.v1.MetricStatistics\x12\x38\n\x0f\x66irst_result_at\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x00\x88\x01\x01\x12\x37\n\x0elast_result_at\x18\x07 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x01\x88\x01\x01\x42\x12\n\x10_first_result_atB\x11\n\x0f_last_result_at\"I\n\x1dSetStrategyDescriptionRequest\x12\x13\n\x0bstrategy_id\x18\x01 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x02 \x01(\t\"K\n\x1eSetStrategyDescriptionResponse\x12)\n\x08strategy\x18\x01 \x01(\x0b\x32\x17.freqsearch.v1.Strategy\"\x82\x01\n\x15\x44iffStrategiesRequest\x12\x13\n\x0bstrategy_id\x18\x01 \x01(\t\x12\x17\n\nagainst_id\x18\x02 \x01(\tH\x00\x88\x01\x01\x12\x1a\n\rcontext_lines\x18\x03 \x01(\x05H\x01\x88\x01\x01\x42\r\n\x0b_against_idB\x10\n\x0e_context_lines\"D\n\rSettingChange\x12\r\n\x05\x66ield\x18\x01 \x01(\t\x12\x12\n\nfrom_value\x18\x02 \x01(\t\x12\x10\n\x08to_value\x18\x03 \x01(\t\"\xf2\x01\n\x16\x44iffStrategiesResponse\x12\x0f\n\x07\x66rom_id\x18\x01 \x01(\t\x12\r\n\x05to_id\x18\x02 \x01(\t\x12\x11\n\tidentical\x18\x03 \x01(\x08\x12\x14\n\x0cunified_diff\x18\x04 \x01(\t\x12\x13\n\x0blines_added\x18\x05 \x01(\x05\x12\x15\n\rlines_removed\x18\x06 \x01(\x05\x12-\n\x07\x63hanges\x18\x07 \x03(\x0b\x32\x1c.freqsearch.v1.SettingChange\x12\x18\n\x10indicators_added\x18\x08 \x03(\t\x12\x1a\n\x12indicators_removed\x18\t \x03(\t\"_\n\x17ValidateStrategyRequest\x12\x0c\n\x04\x63ode\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x18\n\x0bstrategy_id\x18\x03 \x01(\tH\x00\x88\x01\x01\x42\x0e\n\x0c_strategy_id\"\x9c\x01\n\x18ValidateStrategyResponse\x12\r\n\x05valid\x18\x01 \x01(\x08\x12\x0e\n\x06\x65rrors\x18\x02 \x03(\t\x12\x10\n\x08warnings\x18\x03 \x03(\t\x12\x12\n\nclass_name\x18\x04 \x01(\t\x12;\n\x12unresolved_imports\x18\x05 \x03(\x0b\x32\x1f.freqsearch.v1.UnresolvedImport\"o\n\x10UnresolvedImport\x12\x0e\n\x06module\x18\x01 \x01(\t\x12\x0f\n\x07package\x18\x02 \x01(\t\x12\r\n\x05names\x18\x03 \x03(\t\x12\x0c\n\x04line\x18\x04 \x01(\x05\x12\x10\n\x08optional\x18\x05 \x01(\x08\x12\x0b\n\x03\x66ix\x18\x06 \x01(\t*\x87\x01\n\x10LineageDirection\x12!\n\x1dLINEAGE_DIRECTION_UNSPECIFIED\x10\x00\x12\x1a\n\x16LINEAGE_DIRECTION_DOWN\x10\x01\x12\x18\n\x14LINEAGE_DIRECTION_UP\x10\x02\x12\x1a\n\x16LINEAGE_DIRECTION_BOTH\x10\x03\x42MZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1b\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -36,42 +36,62 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._serialized_options = b'ZKgithub.com/saltfish/freqsearch/go-backend/pkg/pb/freqsearch/v1;freqsearchv1'
   _globals['_STRATEGYMETADATA_MINIMALROIENTRY']._loaded_options = None
   _globals['_STRATEGYMETADATA_MINIMALROIENTRY']._serialized_options = b'8\001'
+  _globals['_LINEAGEDIRECTION']._serialized_start=4586
+  _globals['_LINEAGEDIRECTION']._serialized_end=4721
   _globals['_STRATEGYTAGS']._serialized_start=108
   _globals['_STRATEGYTAGS']._serialized_end=231
   _globals['_STRATEGY']._serialized_start=234
-  _globals['_STRATEGY']._serialized_end=572
-  _globals['_STRATEGYMETADATA']._serialized_start=575
-  _globals['_STRATEGYMETADATA']._serialized_end=895
-  _globals['_STRATEGYMETADATA_MINIMALROIENTRY']._serialized_start=846
-  _globals['_STRATEGYMETADATA_MINIMALROIENTRY']._serialized_end=895
-  _globals['_STRATEGYWITHMETRICS']._serialized_start=898
-  _globals['_STRATEGYWITHMETRICS']._serialized_end=1050
-  _globals['_STRATEGYPERFORMANCEMETRICS']._serialized_start=1053
-  _globals['_STRATEGYPERFORMANCEMETRICS']._serialized_end=1235
-  _globals['_CREATESTRATEGYREQUEST']._serialized_start=1238
-  _globals['_CREATESTRATEGYREQUEST']._serialized_end=1391
-  _globals['_CREATESTRATEGYRESPONSE']._serialized_start=1393
-  _globals['_CREATESTRATEGYRESPONSE']._serialized_end=1460
-  _globals['_GETSTRATEGYREQUEST']._serialized_start=1462
-  _globals['_GETSTRATEGYREQUEST']._serialized_end=1494
-  _globals['_GETSTRATEGYRESPONSE']._serialized_start=1496
-  _globals['_GETSTRATEGYRESPONSE']._serialized_end=1560
-  _globals['_SEARCHSTRATEGIESREQUEST']._serialized_start=1563
-  _globals['_SEARCHSTRATEGIESREQUEST']._serialized_end=1903
-  _globals['_SEARCHSTRATEGIESRESPONSE']._serialized_start=1906
-  _globals['_SEARCHSTRATEGIESRESPONSE']._serialized_end=2043
-  _globals['_GETSTRATEGYLINEAGEREQUEST']._serialized_start=2045
-  _globals['_GETSTRATEGYLINEAGEREQUEST']._serialized_end=2108
-  _globals['_GETSTRATEGYLINEAGERESPONSE']._serialized_start=2110
-  _globals['_GETSTRATEGYLINEAGERESPONSE']._serialized_end=2191
-  _globals['_STRATEGYLINEAGENODE']._serialized_start=2194
-  _globals['_STRATEGYLINEAGENODE']._serialized_end=2389
-  _globals['_DELETESTRATEGYREQUEST']._serialized_start=2391
-  _globals['_DELETESTRATEGYREQUEST']._serialized_end=2426
-  _globals['_DELETESTRATEGYRESPONSE']._serialized_start=2428
-  _globals['_DELETESTRATEGYRESPONSE']._serialized_end=2469
-  _globals['_VALIDATESTRATEGYREQUEST']._serialized_start=2471
-  _globals['_VALIDATESTRATEGYREQUEST']._serialized_end=2524
-  _globals['_VALIDATESTRATEGYRESPONSE']._serialized_start=2526
-  _globals['_VALIDATESTRATEGYRESPONSE']._serialized_end=2621
+  _globals['_STRATEGY']._serialized_end=698
+  _globals['_STRATEGYMETADATA']._serialized_start=701
+  _globals['_STRATEGYMETADATA']._serialized_end=1021
+  _globals['_STRATEGYMETADATA_MINIMALROIENTRY']._serialized_start=972
+  _globals['_STRATEGYMETADATA_MINIMALROIENTRY']._serialized_end=1021
+  _globals['_STRATEGYWITHMETRICS']._serialized_start=1024
+  _globals['_STRATEGYWITHMETRICS']._serialized_end=1176
+  _globals['_STRATEGYPERFORMANCEMETRICS']._serialized_start=1179
+  _globals['_STRATEGYPERFORMANCEMETRICS']._serialized_end=1361
+  _globals['_CREATESTRATEGYREQUEST']._serialized_start=1364
+  _globals['_CREATESTRATEGYREQUEST']._serialized_end=1598
+  _globals['_CREATESTRATEGYRESPONSE']._serialized_start=1600
+  _globals['_CREATESTRATEGYRESPONSE']._serialized_end=1667
+  _globals['_GETSTRATEGYREQUEST']._serialized_start=1669
+  _globals['_GETSTRATEGYREQUEST']._serialized_end=1701
+  _globals['_GETSTRATEGYRESPONSE']._serialized_start=1703
+  _globals['_GETSTRATEGYRESPONSE']._serialized_end=1767
+  _globals['_SEARCHSTRATEGIESREQUEST']._serialized_start=1770
+  _globals['_SEARCHSTRATEGIESREQUEST']._serialized_end=2164
+  _globals['_SEARCHSTRATEGIESRESPONSE']._serialized_start=2167
+  _globals['_SEARCHSTRATEGIESRESPONSE']._serialized_end=2304
+  _globals['_GETSTRATEGYLINEAGEREQUEST']._serialized_start=2307
+  _globals['_GETSTRATEGYLINEAGEREQUEST']._serialized_end=2447
+  _globals['_GETSTRATEGYLINEAGERESPONSE']._serialized_start=2449
+  _globals['_GETSTRATEGYLINEAGERESPONSE']._serialized_end=2530
+  _globals['_STRATEGYLINEAGENODE']._serialized_start=2533
+  _globals['_STRATEGYLINEAGENODE']._serialized_end=2918
+  _globals['_DELETESTRATEGYREQUEST']._serialized_start=2920
+  _globals['_DELETESTRATEGYREQUEST']._serialized_end=2955
+  _globals['_DELETESTRATEGYRESPONSE']._serialized_start=2957
+  _globals['_DELETESTRATEGYRESPONSE']._serialized_end=2998
+  _globals['_GETSTRATEGYSTATISTICSREQUEST']._serialized_start=3000
+  _globals['_GETSTRATEGYSTATISTICSREQUEST']._serialized_end=3109
+  _globals['_METRICSTATISTICS']._serialized_start=3111
+  _globals['_METRICSTATISTICS']._serialized_end=3216
+  _globals['_GETSTRATEGYSTATISTICSRESPONSE']._serialized_start=3219
+  _globals['_GETSTRATEGYSTATISTICSRESPONSE']._serialized_end=3614
+  _globals['_SETSTRATEGYDESCRIPTIONREQUEST']._serialized_start=3616
+  _globals['_SETSTRATEGYDESCRIPTIONREQUEST']._serialized_end=3689
+  _globals['_SETSTRATEGYDESCRIPTIONRESPONSE']._serialized_start=3691
+  _globals['_SETSTRATEGYDESCRIPTIONRESPONSE']._serialized_end=3766
+  _globals['_DIFFSTRATEGIESREQUEST']._serialized_start=3769
+  _globals['_DIFFSTRATEGIESREQUEST']._serialized_end=3899
+  _globals['_SETTINGCHANGE']._serialized_start=3901
+  _globals['_SETTINGCHANGE']._serialized_end=3969
+  _globals['_DIFFSTRATEGIESRESPONSE']._serialized_start=3972
+  _globals['_DIFFSTRATEGIESRESPONSE']._serialized_end=4214
+  _globals['_VALIDATESTRATEGYREQUEST']._serialized_start=4216
+  _globals['_VALIDATESTRATEGYREQUEST']._serialized_end=4311
+  _globals['_VALIDATESTRATEGYRESPONSE']._serialized_start=4314
+  _globals['_VALIDATESTRATEGYRESPONSE']._serialized_end=4470
+  _globals['_UNRESOLVEDIMPORT']._serialized_start=4472
+  _globals['_UNRESOLVEDIMPORT']._serialized_end=4583
 # @@protoc_insertion_point(module_scope)
@@ -3,6 +3,7 @@ import datetime
 from google.protobuf import timestamp_pb2 as _timestamp_pb2
 from freqsearch.v1 import common_pb2 as _common_pb2
 from google.protobuf.internal import containers as _containers
+from google.protobuf.internal import enum_type_wrapper as _enum_type_wrapper
 from google.protobuf import descriptor as _descriptor
 from google.protobuf import message as _message
 from collections.abc import Iterable as _Iterable, Mapping as _Mapping
@@ -10,6 +11,17 @@ from typing import ClassVar as _ClassVar, Optional as _Optional, Union as _Union
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
+class LineageDirection(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    LINEAGE_DIRECTION_UNSPECIFIED: _ClassVar[LineageDirection]
+    LINEAGE_DIRECTION_DOWN: _ClassVar[LineageDirection]
+    LINEAGE_DIRECTION_UP: _ClassVar[LineageDirection]
+    LINEAGE_DIRECTION_BOTH: _ClassVar[LineageDirection]
+LINEAGE_DIRECTION_UNSPECIFIED: LineageDirection
+LINEAGE_DIRECTION_DOWN: LineageDirection
+LINEAGE_DIRECTION_UP: LineageDirection
+LINEAGE_DIRECTION_BOTH: LineageDirection
+
 class StrategyTags(_message.Message):
     __slots__ = ("strategy_type", "risk_level", "trading_style", "indicators", "market_regime")
     STRATEGY_TYPE_FIELD_NUMBER: _ClassVar[int]
@@ -25,7 +37,7 @@ class StrategyTags(_message.Message):
     def __init__(self, strategy_type: _Optional[_Iterable[str]] = ..., risk_level: _Optional[str] = ..., trading_style: _Optional[str] = ..., indicators: _Optional[_Iterable[str]] = ..., market_regime: _Optional[_Iterable[str]] = ...) -> None: ...
 
 class Strategy(_message.Message):
-    __slots__ = ("id", "name", "code", "code_hash", "parent_id", "generation", "description", "metadata", "tags", "created_at", "updated_at")
+    __slots__ = ("id", "name", "code", "code_hash", "parent_id", "generation", "description", "metadata", "tags", "created_at", "updated_at", "validation_status", "validation_errors", "validated_at")
     ID_FIELD_NUMBER: _ClassVar[int]
     NAME_FIELD_NUMBER: _ClassVar[int]
     CODE_FIELD_NUMBER: _ClassVar[int]
@@ -37,6 +49,9 @@ class Strategy(_message.Message):
     TAGS_FIELD_NUMBER: _ClassVar[int]
     CREATED_AT_FIELD_NUMBER: _ClassVar[int]
     UPDATED_AT_FIELD_NUMBER: _ClassVar[int]
+    VALIDATION_STATUS_FIELD_NUMBER: _ClassVar[int]
+    VALIDATION_ERRORS_FIELD_NUMBER: _ClassVar[int]
+    VALIDATED_AT_FIELD_NUMBER: _ClassVar[int]
     id: str
     name: str
     code: str
@@ -48,7 +63,10 @@ class Strategy(_message.Message):
     tags: StrategyTags
     created_at: _timestamp_pb2.Timestamp
     updated_at: _timestamp_pb2.Timestamp
-    def __init__(self, id: _Optional[str] = ..., name: _Optional[str] = ..., code: _Optional[str] = ..., code_hash: _Optional[str] = ..., parent_id: _Optional[str] = ..., generation: _Optional[int] = ..., description: _Optional[str] = ..., metadata: _Optional[_Union[StrategyMetadata, _Mapping]] = ..., tags: _Optional[_Union[StrategyTags, _Mapping]] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., updated_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+    validation_status: str
+    validation_errors: _containers.RepeatedScalarFieldContainer[str]
+    validated_at: _timestamp_pb2.Timestamp
+    def __init__(self, id: _Optional[str] = ..., name: _Optional[str] = ..., code: _Optional[str] = ..., code_hash: _Optional[str] = ..., parent_id: _Optional[str] = ..., generation: _Optional[int] = ..., description: _Optional[str] = ..., metadata: _Optional[_Union[StrategyMetadata, _Mapping]] = ..., tags: _Optional[_Union[StrategyTags, _Mapping]] = ..., created_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., updated_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., validation_status: _Optional[str] = ..., validation_errors: _Optional[_Iterable[str]] = ..., validated_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
 
 class StrategyMetadata(_message.Message):
     __slots__ = ("timeframe", "indicators", "stoploss", "trailing_stop", "trailing_stop_positive", "trailing_stop_positive_offset", "minimal_roi", "startup_candle_count")
@@ -106,18 +124,22 @@ class StrategyPerformanceMetrics(_message.Message):
     def __init__(self, sharpe_ratio: _Optional[float] = ..., sortino_ratio: _Optional[float] = ..., profit_pct: _Optional[float] = ..., max_drawdown_pct: _Optional[float] = ..., total_trades: _Optional[int] = ..., win_rate: _Optional[float] = ..., profit_factor: _Optional[float] = ...) -> None: ...
 
 class CreateStrategyRequest(_message.Message):
-    __slots__ = ("name", "code", "parent_id", "description", "tags")
+    __slots__ = ("name", "code", "parent_id", "description", "tags", "validation_status", "validation_errors")
     NAME_FIELD_NUMBER: _ClassVar[int]
     CODE_FIELD_NUMBER: _ClassVar[int]
     PARENT_ID_FIELD_NUMBER: _ClassVar[int]
     DESCRIPTION_FIELD_NUMBER: _ClassVar[int]
     TAGS_FIELD_NUMBER: _ClassVar[int]
+    VALIDATION_STATUS_FIELD_NUMBER: _ClassVar[int]
+    VALIDATION_ERRORS_FIELD_NUMBER: _ClassVar[int]
     name: str
     code: str
     parent_id: str
     description: str
     tags: StrategyTags
-    def __init__(self, name: _Optional[str] = ..., code: _Optional[str] = ..., parent_id: _Optional[str] = ..., description: _Optional[str] = ..., tags: _Optional[_Union[StrategyTags, _Mapping]] = ...) -> None: ...
+    validation_status: str
+    validation_errors: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, name: _Optional[str] = ..., code: _Optional[str] = ..., parent_id: _Optional[str] = ..., description: _Optional[str] = ..., tags: _Optional[_Union[StrategyTags, _Mapping]] = ..., validation_status: _Optional[str] = ..., validation_errors: _Optional[_Iterable[str]] = ...) -> None: ...
 
 class CreateStrategyResponse(_message.Message):
     __slots__ = ("strategy",)
@@ -138,7 +160,7 @@ class GetStrategyResponse(_message.Message):
     def __init__(self, strategy: _Optional[_Union[Strategy, _Mapping]] = ...) -> None: ...
 
 class SearchStrategiesRequest(_message.Message):
-    __slots__ = ("name_pattern", "min_sharpe", "min_profit_pct", "min_trades", "max_drawdown_pct", "pagination", "order_by", "ascending")
+    __slots__ = ("name_pattern", "min_sharpe", "min_profit_pct", "min_trades", "max_drawdown_pct", "pagination", "order_by", "ascending", "validation_status")
     NAME_PATTERN_FIELD_NUMBER: _ClassVar[int]
     MIN_SHARPE_FIELD_NUMBER: _ClassVar[int]
     MIN_PROFIT_PCT_FIELD_NUMBER: _ClassVar[int]
@@ -147,6 +169,7 @@ class SearchStrategiesRequest(_message.Message):
     PAGINATION_FIELD_NUMBER: _ClassVar[int]
     ORDER_BY_FIELD_NUMBER: _ClassVar[int]
     ASCENDING_FIELD_NUMBER: _ClassVar[int]
+    VALIDATION_STATUS_FIELD_NUMBER: _ClassVar[int]
     name_pattern: str
     min_sharpe: float
     min_profit_pct: float
@@ -155,7 +178,8 @@ class SearchStrategiesRequest(_message.Message):
     pagination: _common_pb2.PaginationRequest
     order_by: str
     ascending: bool
-    def __init__(self, name_pattern: _Optional[str] = ..., min_sharpe: _Optional[float] = ..., min_profit_pct: _Optional[float] = ..., min_trades: _Optional[int] = ..., max_drawdown_pct: _Optional[float] = ..., pagination: _Optional[_Union[_common_pb2.PaginationRequest, _Mapping]] = ..., order_by: _Optional[str] = ..., ascending: bool = ...) -> None: ...
+    validation_status: str
+    def __init__(self, name_pattern: _Optional[str] = ..., min_sharpe: _Optional[float] = ..., min_profit_pct: _Optional[float] = ..., min_trades: _Optional[int] = ..., max_drawdown_pct: _Optional[float] = ..., pagination: _Optional[_Union[_common_pb2.PaginationRequest, _Mapping]] = ..., order_by: _Optional[str] = ..., ascending: bool = ..., validation_status: _Optional[str] = ...) -> None: ...
 
 class SearchStrategiesResponse(_message.Message):
     __slots__ = ("strategies", "pagination")
@@ -166,12 +190,16 @@ class SearchStrategiesResponse(_message.Message):
     def __init__(self, strategies: _Optional[_Iterable[_Union[StrategyWithMetrics, _Mapping]]] = ..., pagination: _Optional[_Union[_common_pb2.PaginationResponse, _Mapping]] = ...) -> None: ...
 
 class GetStrategyLineageRequest(_message.Message):
-    __slots__ = ("strategy_id", "depth")
+    __slots__ = ("strategy_id", "depth", "direction", "include_metrics")
     STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
     DEPTH_FIELD_NUMBER: _ClassVar[int]
+    DIRECTION_FIELD_NUMBER: _ClassVar[int]
+    INCLUDE_METRICS_FIELD_NUMBER: _ClassVar[int]
     strategy_id: str
     depth: int
-    def __init__(self, strategy_id: _Optional[str] = ..., depth: _Optional[int] = ...) -> None: ...
+    direction: LineageDirection
+    include_metrics: bool
+    def __init__(self, strategy_id: _Optional[str] = ..., depth: _Optional[int] = ..., direction: _Optional[_Union[LineageDirection, str]] = ..., include_metrics: bool = ...) -> None: ...
 
 class GetStrategyLineageResponse(_message.Message):
     __slots__ = ("lineage",)
@@ -180,14 +208,26 @@ class GetStrategyLineageResponse(_message.Message):
     def __init__(self, lineage: _Optional[_Iterable[_Union[StrategyLineageNode, _Mapping]]] = ...) -> None: ...
 
 class StrategyLineageNode(_message.Message):
-    __slots__ = ("strategy", "metrics", "children")
+    __slots__ = ("strategy", "metrics", "children", "backtest_count", "best_sharpe", "child_count", "level", "sharpe_delta", "profit_delta")
     STRATEGY_FIELD_NUMBER: _ClassVar[int]
     METRICS_FIELD_NUMBER: _ClassVar[int]
     CHILDREN_FIELD_NUMBER: _ClassVar[int]
+    BACKTEST_COUNT_FIELD_NUMBER: _ClassVar[int]
+    BEST_SHARPE_FIELD_NUMBER: _ClassVar[int]
+    CHILD_COUNT_FIELD_NUMBER: _ClassVar[int]
+    LEVEL_FIELD_NUMBER: _ClassVar[int]
+    SHARPE_DELTA_FIELD_NUMBER: _ClassVar[int]
+    PROFIT_DELTA_FIELD_NUMBER: _ClassVar[int]
     strategy: Strategy
     metrics: StrategyPerformanceMetrics
     children: _containers.RepeatedCompositeFieldContainer[StrategyLineageNode]
-    def __init__(self, strategy: _Optional[_Union[Strategy, _Mapping]] = ..., metrics: _Optional[_Union[StrategyPerformanceMetrics, _Mapping]] = ..., children: _Optional[_Iterable[_Union[StrategyLineageNode, _Mapping]]] = ...) -> None: ...
+    backtest_count: int
+    best_sharpe: float
+    child_count: int
+    level: int
+    sharpe_delta: float
+    profit_delta: float
+    def __init__(self, strategy: _Optional[_Union[Strategy, _Mapping]] = ..., metrics: _Optional[_Union[StrategyPerformanceMetrics, _Mapping]] = ..., children: _Optional[_Iterable[_Union[StrategyLineageNode, _Mapping]]] = ..., backtest_count: _Optional[int] = ..., best_sharpe: _Optional[float] = ..., child_count: _Optional[int] = ..., level: _Optional[int] = ..., sharpe_delta: _Optional[float] = ..., profit_delta: _Optional[float] = ...) -> None: ...
 
 class DeleteStrategyRequest(_message.Message):
     __slots__ = ("id",)
@@ -201,22 +241,140 @@ class DeleteStrategyResponse(_message.Message):
     success: bool
     def __init__(self, success: bool = ...) -> None: ...
 
+class GetStrategyStatisticsRequest(_message.Message):
+    __slots__ = ("strategy_id", "window")
+    STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
+    WINDOW_FIELD_NUMBER: _ClassVar[int]
+    strategy_id: str
+    window: _common_pb2.TimeRange
+    def __init__(self, strategy_id: _Optional[str] = ..., window: _Optional[_Union[_common_pb2.TimeRange, _Mapping]] = ...) -> None: ...
+
+class MetricStatistics(_message.Message):
+    __slots__ = ("count", "mean", "median", "stddev", "min", "max")
+    COUNT_FIELD_NUMBER: _ClassVar[int]
+    MEAN_FIELD_NUMBER: _ClassVar[int]
+    MEDIAN_FIELD_NUMBER: _ClassVar[int]
+    STDDEV_FIELD_NUMBER: _ClassVar[int]
+    MIN_FIELD_NUMBER: _ClassVar[int]
+    MAX_FIELD_NUMBER: _ClassVar[int]
+    count: int
+    mean: float
+    median: float
+    stddev: float
+    min: float
+    max: float
+    def __init__(self, count: _Optional[int] = ..., mean: _Optional[float] = ..., median: _Optional[float] = ..., stddev: _Optional[float] = ..., min: _Optional[float] = ..., max: _Optional[float] = ...) -> None: ...
+
+class GetStrategyStatisticsResponse(_message.Message):
+    __slots__ = ("strategy_id", "result_count", "sharpe_ratio", "profit_pct", "max_drawdown_pct", "first_result_at", "last_result_at")
+    STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
+    RESULT_COUNT_FIELD_NUMBER: _ClassVar[int]
+    SHARPE_RATIO_FIELD_NUMBER: _ClassVar[int]
+    PROFIT_PCT_FIELD_NUMBER: _ClassVar[int]
+    MAX_DRAWDOWN_PCT_FIELD_NUMBER: _ClassVar[int]
+    FIRST_RESULT_AT_FIELD_NUMBER: _ClassVar[int]
+    LAST_RESULT_AT_FIELD_NUMBER: _ClassVar[int]
+    strategy_id: str
+    result_count: int
+    sharpe_ratio: MetricStatistics
+    profit_pct: MetricStatistics
+    max_drawdown_pct: MetricStatistics
+    first_result_at: _timestamp_pb2.Timestamp
+    last_result_at: _timestamp_pb2.Timestamp
+    def __init__(self, strategy_id: _Optional[str] = ..., result_count: _Optional[int] = ..., sharpe_ratio: _Optional[_Union[MetricStatistics, _Mapping]] = ..., profit_pct: _Optional[_Union[MetricStatistics, _Mapping]] = ..., max_drawdown_pct: _Optional[_Union[MetricStatistics, _Mapping]] = ..., first_result_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ..., last_result_at: _Optional[_Union[datetime.datetime, _timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+
+class SetStrategyDescriptionRequest(_message.Message):
+    __slots__ = ("strategy_id", "description")
+    STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
+    DESCRIPTION_FIELD_NUMBER: _ClassVar[int]
+    strategy_id: str
+    description: str
+    def __init__(self, strategy_id: _Optional[str] = ..., description: _Optional[str] = ...) -> None: ...
+
+class SetStrategyDescriptionResponse(_message.Message):
+    __slots__ = ("strategy",)
+    STRATEGY_FIELD_NUMBER: _ClassVar[int]
+    strategy: Strategy
+    def __init__(self, strategy: _Optional[_Union[Strategy, _Mapping]] = ...) -> None: ...
+
+class DiffStrategiesRequest(_message.Message):
+    __slots__ = ("strategy_id", "against_id", "context_lines")
+    STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
+    AGAINST_ID_FIELD_NUMBER: _ClassVar[int]
+    CONTEXT_LINES_FIELD_NUMBER: _ClassVar[int]
+    strategy_id: str
+    against_id: str
+    context_lines: int
+    def __init__(self, strategy_id: _Optional[str] = ..., against_id: _Optional[str] = ..., context_lines: _Optional[int] = ...) -> None: ...
+
+class SettingChange(_message.Message):
+    __slots__ = ("field", "from_value", "to_value")
+    FIELD_FIELD_NUMBER: _ClassVar[int]
+    FROM_VALUE_FIELD_NUMBER: _ClassVar[int]
+    TO_VALUE_FIELD_NUMBER: _ClassVar[int]
+    field: str
+    from_value: str
+    to_value: str
+    def __init__(self, field: _Optional[str] = ..., from_value: _Optional[str] = ..., to_value: _Optional[str] = ...) -> None: ...
+
+class DiffStrategiesResponse(_message.Message):
+    __slots__ = ("from_id", "to_id", "identical", "unified_diff", "lines_added", "lines_removed", "changes", "indicators_added", "indicators_removed")
+    FROM_ID_FIELD_NUMBER: _ClassVar[int]
+    TO_ID_FIELD_NUMBER: _ClassVar[int]
+    IDENTICAL_FIELD_NUMBER: _ClassVar[int]
+    UNIFIED_DIFF_FIELD_NUMBER: _ClassVar[int]
+    LINES_ADDED_FIELD_NUMBER: _ClassVar[int]
+    LINES_REMOVED_FIELD_NUMBER: _ClassVar[int]
+    CHANGES_FIELD_NUMBER: _ClassVar[int]
+    INDICATORS_ADDED_FIELD_NUMBER: _ClassVar[int]
+    INDICATORS_REMOVED_FIELD_NUMBER: _ClassVar[int]
+    from_id: str
+    to_id: str
+    identical: bool
+    unified_diff: str
+    lines_added: int
+    lines_removed: int
+    changes: _containers.RepeatedCompositeFieldContainer[SettingChange]
+    indicators_added: _containers.RepeatedScalarFieldContainer[str]
+    indicators_removed: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, from_id: _Optional[str] = ..., to_id: _Optional[str] = ..., identical: bool = ..., unified_diff: _Optional[str] = ..., lines_added: _Optional[int] = ..., lines_removed: _Optional[int] = ..., changes: _Optional[_Iterable[_Union[SettingChange, _Mapping]]] = ..., indicators_added: _Optional[_Iterable[str]] = ..., indicators_removed: _Optional[_Iterable[str]] = ...) -> None: ...
+
 class ValidateStrategyRequest(_message.Message):
-    __slots__ = ("code", "name")
+    __slots__ = ("code", "name", "strategy_id")
     CODE_FIELD_NUMBER: _ClassVar[int]
     NAME_FIELD_NUMBER: _ClassVar[int]
+    STRATEGY_ID_FIELD_NUMBER: _ClassVar[int]
     code: str
     name: str
-    def __init__(self, code: _Optional[str] = ..., name: _Optional[str] = ...) -> None: ...
+    strategy_id: str
+    def __init__(self, code: _Optional[str] = ..., name: _Optional[str] = ..., strategy_id: _Optional[str] = ...) -> None: ...
 
 class ValidateStrategyResponse(_message.Message):
-    __slots__ = ("valid", "errors", "warnings", "class_name")
+    __slots__ = ("valid", "errors", "warnings", "class_name", "unresolved_imports")
     VALID_FIELD_NUMBER: _ClassVar[int]
     ERRORS_FIELD_NUMBER: _ClassVar[int]
     WARNINGS_FIELD_NUMBER: _ClassVar[int]
     CLASS_NAME_FIELD_NUMBER: _ClassVar[int]
+    UNRESOLVED_IMPORTS_FIELD_NUMBER: _ClassVar[int]
     valid: bool
     errors: _containers.RepeatedScalarFieldContainer[str]
     warnings: _containers.RepeatedScalarFieldContainer[str]
     class_name: str
-    def __init__(self, valid: bool = ..., errors: _Optional[_Iterable[str]] = ..., warnings: _Optional[_Iterable[str]] = ..., class_name: _Optional[str] = ...) -> None: ...
+    unresolved_imports: _containers.RepeatedCompositeFieldContainer[UnresolvedImport]
+    def __init__(self, valid: bool = ..., errors: _Optional[_Iterable[str]] = ..., warnings: _Optional[_Iterable[str]] = ..., class_name: _Optional[str] = ..., unresolved_imports: _Optional[_Iterable[_Union[UnresolvedImport, _Mapping]]] = ...) -> None: ...
+
+class UnresolvedImport(_message.Message):
+    __slots__ = ("module", "package", "names", "line", "optional", "fix")
+    MODULE_FIELD_NUMBER: _ClassVar[int]
+    PACKAGE_FIELD_NUMBER: _ClassVar[int]
+    NAMES_FIELD_NUMBER: _ClassVar[int]
+    LINE_FIELD_NUMBER: _ClassVar[int]
+    OPTIONAL_FIELD_NUMBER: _ClassVar[int]
+    FIX_FIELD_NUMBER: _ClassVar[int]
+    module: str
+    package: str
+    names: _containers.RepeatedScalarFieldContainer[str]
+    line: int
+    optional: bool
+    fix: str
+    def __init__(self, module: _Optional[str] = ..., package: _Optional[str] = ..., names: _Optional[_Iterable[str]] = ..., line: _Optional[int] = ..., optional: bool = ..., fix: _Optional[str] = ...) -> None: ...
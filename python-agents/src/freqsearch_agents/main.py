@@ -301,7 +301,16 @@ def serve(
                 agent_tasks["scout"] = f"Scout: {source} (run: {run_id})"
                 console.print(f"[cyan]Scout triggered: {source}, limit: {max_strategies}, run_id: {run_id}[/cyan]")
                 try:
-                    await run_scout(source=source, limit=max_strategies, run_id=run_id)
+                    # The event only references the source credential
+                    credential = None
+                    if secret_id := data.get("credential_secret_id"):
+                        from freqsearch_agents.grpc_client.client import FreqSearchClient
+                        grpc_address = get_settings().grpc.go_backend_addr
+                        async with FreqSearchClient(grpc_address) as client:
+                            credential = await client.get_scout_credential(secret_id)
+                    await run_scout(
+                        source=source, limit=max_strategies, run_id=run_id, credential=credential
+                    )
                 except Exception as e:
                     logger.error("Scout run failed", error=str(e), run_id=run_id)
                 finally:
@@ -70,6 +70,7 @@ class BacktestCompletedEvent(BaseEvent):
     # Status
     success: bool
     error_message: str | None = None
+    failure_category: str | None = None  # e.g. "strategy_import_error", "data_missing", "oom"
 
     # Results (if success)
     total_trades: int | None = None
@@ -157,11 +158,15 @@ class ScoutCompletedEvent(BaseEvent):
     """Event: Scout Agent run completed successfully."""
 
     run_id: str
+    found: int = 0  # Optional; 0 means not reported
     total_fetched: int
+    parsed: int = 0  # Optional; 0 means not reported
     validated: int
     validation_failed: int
     duplicates_removed: int
     submitted: int
+    sources: dict[str, dict[str, int]] = Field(default_factory=dict)  # Per-source counts
+    error_categories: dict[str, int] = Field(default_factory=dict)
 
 
 class ScoutFailedEvent(BaseEvent):